@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	commandregistry "github.com/bmf-san/ggc/v8/cmd/command"
+)
+
+// expandAbbreviations replaces unambiguous prefixes of command and
+// subcommand names in words with the full names, so that `br li` becomes
+// `branch list`. Subcommand words are taken from the registry's usage
+// lines. Expansion stops at the first word that is a flag or not a prefix
+// of any name, leaving the arguments that follow as typed, and it never
+// touches a word where the command also takes a value, as in
+// `switch <branch>`, because the value could be a name that happens to be
+// a prefix. A prefix that fits more than one name is an error listing the
+// candidates.
+func expandAbbreviations(registry *commandregistry.Registry, words []string) ([]string, error) {
+	if len(words) == 0 {
+		return words, nil
+	}
+	info, ok := registry.Find(words[0])
+	if !ok {
+		var names []string
+		for _, c := range registry.VisibleCommands() {
+			names = append(names, c.Name)
+		}
+		name, err := matchPrefix(words[0], names)
+		if err != nil || name == "" {
+			return words, err
+		}
+		info, _ = registry.Find(name)
+	}
+	out := append([]string{info.Name}, words[1:]...)
+
+	usages := subcommandWords(&info)
+	for i := 1; i < len(out); i++ {
+		if strings.HasPrefix(out[i], "-") {
+			break
+		}
+		names, literal := nextWords(usages, out[1:i])
+		if !literal || len(names) == 0 {
+			break
+		}
+		name, err := matchPrefix(out[i], names)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			break
+		}
+		out[i] = name
+	}
+	return out, nil
+}
+
+// matchPrefix returns the name word is, or the one name it is a prefix
+// of, and "" when it fits none.
+func matchPrefix(word string, names []string) (string, error) {
+	var matches []string
+	for _, name := range names {
+		if strings.EqualFold(name, word) {
+			return name, nil
+		}
+		if strings.HasPrefix(name, strings.ToLower(word)) {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", nil
+	case 1:
+		return matches[0], nil
+	}
+	sort.Strings(matches)
+	return "", fmt.Errorf("%w %q: could be %s", ErrAmbiguousCommand, word, strings.Join(matches, ", "))
+}
+
+// subcommandWords returns the words after the command name of each
+// visible subcommand usage line of info.
+func subcommandWords(info *commandregistry.Info) [][]string {
+	var usages [][]string
+	for _, sub := range info.Subcommands {
+		if sub.Hidden {
+			continue
+		}
+		fields := strings.Fields(sub.Name)
+		if len(fields) < 2 || fields[0] != info.Name {
+			continue
+		}
+		usages = append(usages, fields[1:])
+	}
+	return usages
+}
+
+// nextWords returns the distinct names that can follow typed in usages.
+// literal is false when a value can follow too.
+func nextWords(usages [][]string, typed []string) (names []string, literal bool) {
+	seen := make(map[string]bool)
+	literal = true
+	for _, usage := range usages {
+		if len(usage) <= len(typed) || !hasWords(usage, typed) {
+			continue
+		}
+		word := usage[len(typed)]
+		switch {
+		case strings.HasPrefix(word, "<"), strings.HasPrefix(word, "["):
+			literal = false
+		case strings.HasPrefix(word, "-"), seen[word]:
+		default:
+			seen[word] = true
+			names = append(names, word)
+		}
+	}
+	return names, literal
+}
+
+func hasWords(usage, typed []string) bool {
+	for i, word := range typed {
+		if usage[i] != word {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	commandregistry "github.com/bmf-san/ggc/v8/cmd/command"
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+func TestExpandAbbreviations(t *testing.T) {
+	registry := commandregistry.NewRegistry()
+	tests := []struct {
+		words []string
+		want  []string
+	}{
+		{[]string{"br", "li"}, []string{"branch", "list"}},
+		{[]string{"br", "li", "verb"}, []string{"branch", "list", "verbose"}},
+		{[]string{"branch", "del", "old-feature"}, []string{"branch", "delete", "old-feature"}},
+		{[]string{"sw", "re"}, []string{"switch", "re"}},
+		{[]string{"cherry", "sel"}, []string{"cherry-pick", "select"}},
+		{[]string{"stas", "--help"}, []string{"stash", "--help"}},
+		{[]string{"nosuch"}, []string{"nosuch"}},
+	}
+	for _, tt := range tests {
+		got, err := expandAbbreviations(registry, tt.words)
+		if err != nil {
+			t.Errorf("expandAbbreviations(%q): %v", tt.words, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("expandAbbreviations(%q) = %q, want %q", tt.words, got, tt.want)
+		}
+	}
+
+	_, err := expandAbbreviations(registry, []string{"st"})
+	if !errors.Is(err, ErrAmbiguousCommand) {
+		t.Fatalf("expandAbbreviations(st) = %v, want an ambiguous command", err)
+	}
+	if want := `ambiguous command "st": could be stack, stash, stats, status`; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+	if ExitCode(err) != ExitUsage {
+		t.Errorf("ExitCode = %d, want %d", ExitCode(err), ExitUsage)
+	}
+}
+
+func TestCmd_Route_Abbreviations(t *testing.T) {
+	mockClient := testutil.NewMockGitClient()
+	cm := config.NewConfigManager(mockClient)
+	c, err := NewCmd(mockClient, cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Route([]string{"vers"}); !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("abbreviations are off by default, Route(vers) = %v", err)
+	}
+
+	cm.GetConfig().Behavior.Abbreviations = true
+	c, err = NewCmd(mockClient, cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Route([]string{"vers"}); err != nil {
+		t.Errorf("Route(vers) = %v", err)
+	}
+	if err := c.Route([]string{"s"}); !errors.Is(err, ErrAmbiguousCommand) {
+		t.Errorf("Route(s) = %v, want an ambiguous command", err)
+	}
+}
+
+func TestCompleter_CompleteAbbreviations(t *testing.T) {
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().Behavior.Abbreviations = true
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"args", "br", "li"}, "verbose\nlocal\nremote\n"},
+		{[]string{"args", "br", "del"}, "feature/x\nmain\n"},
+		{[]string{"args", "sw"}, "feature/x\nmain\n"},
+		{[]string{"args", "st"}, ""},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		c := NewCompleter().withConfigManager(cm).withGit(&mockCompletionSource{})
+		c.outputWriter = &buf
+		c.Complete(tt.args)
+		if buf.String() != tt.want {
+			t.Errorf("Complete(%q) = %q, want %q", tt.args, buf.String(), tt.want)
+		}
+	}
+}
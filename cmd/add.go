@@ -6,13 +6,24 @@ import (
 	"io"
 	"os"
 
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
 )
 
+// hunkStager runs hunk-level staging over paths (all changes when empty).
+type hunkStager func(paths []string) error
+
 // Adder provides functionality for the add command.
 type Adder struct {
 	gitClient    git.Stager
 	outputWriter io.Writer
+	stageHunks   hunkStager    // nil falls back to `git add -p`
+	selectMany   multiSelector // nil makes `ggc add select` unavailable
+	explore      func() error  // nil makes `ggc add explore` unavailable
+	scope        *pathScope
 }
 
 // NewAdder creates a new Adder.
@@ -23,21 +34,153 @@ func NewAdder(client git.Stager) *Adder {
 	}
 }
 
+// withHunkStaging enables the built-in hunk stager for `ggc add patch`
+// when stdin is a terminal. cm supplies the keybinding profile and may be nil.
+func (a *Adder) withHunkStaging(src interactive.HunkSource, cm *config.Manager) *Adder {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return a
+	}
+	a.stageHunks = func(paths []string) error {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewHunkStager(src, paths, cfg).Run()
+	}
+	return a
+}
+
+// explorerSource returns client as the file explorer's source, or nil
+// when it cannot read the porcelain status.
+func explorerSource(client any) interactive.ExplorerSource {
+	src, _ := client.(interactive.ExplorerSource)
+	return src
+}
+
+// withExplorer enables the file explorer of `ggc add explore` when stdin
+// is a terminal. cm supplies the keybinding profile and diff tool and may
+// be nil; a nil src leaves the explorer off.
+func (a *Adder) withExplorer(src interactive.ExplorerSource, cm *config.Manager) *Adder {
+	if src == nil || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return a
+	}
+	a.explore = func() error {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewFileExplorer(scopedExplorerSource{src, a.scope}, cfg).Run()
+	}
+	return a
+}
+
+// withPathScope limits the files offered by ggc add select and explore to
+// the directory of ggc --path. Call it before withExplorer.
+func (a *Adder) withPathScope(scope *pathScope) *Adder {
+	a.scope = scope
+	return a
+}
+
+// withMultiSelect lets `ggc add select` pick files in the full-screen
+// multi-select picker.
+func (a *Adder) withMultiSelect(sel multiSelector) *Adder {
+	a.selectMany = sel
+	return a
+}
+
 // Add executes the add command with the given arguments.
 func (a *Adder) Add(args []string) {
 	if len(args) == 0 {
-		_, _ = fmt.Fprintf(a.outputWriter, "Usage: ggc add <file> | ggc add interactive | ggc add patch\n")
+		if a.explore != nil {
+			a.addExplore()
+			return
+		}
+		_, _ = fmt.Fprintf(a.outputWriter, "Usage: ggc add <file> | ggc add interactive | ggc add patch [<path>...] | ggc add select | ggc add explore\n")
 		return
 	}
 
-	if len(args) == 1 && (args[0] == "interactive" || args[0] == "patch") {
+	if len(args) == 1 && args[0] == "interactive" {
 		if err := a.gitClient.AddInteractive(); err != nil {
 			WriteError(a.outputWriter, err)
 		}
 		return
 	}
 
+	if args[0] == "patch" {
+		a.addPatch(args[1:])
+		return
+	}
+
+	if len(args) == 1 && args[0] == "select" {
+		a.addSelect()
+		return
+	}
+
+	if len(args) == 1 && args[0] == "explore" {
+		a.addExplore()
+		return
+	}
+
 	if err := a.gitClient.Add(args...); err != nil {
 		WriteError(a.outputWriter, err)
 	}
 }
+
+// addPatch stages hunks in the built-in stager, or through `git add -p`
+// when no terminal is attached.
+func (a *Adder) addPatch(paths []string) {
+	var err error
+	switch {
+	case a.stageHunks != nil:
+		err = a.stageHunks(paths)
+	case len(paths) == 0:
+		err = a.gitClient.AddInteractive()
+	default:
+		err = fmt.Errorf("staging hunks of specific paths requires an interactive terminal")
+	}
+	if err != nil {
+		WriteError(a.outputWriter, err)
+	}
+}
+
+// addSelect stages the files picked from the modified and untracked ones.
+func (a *Adder) addSelect() {
+	if a.selectMany == nil {
+		WriteError(a.outputWriter, fmt.Errorf("selecting files requires an interactive terminal"))
+		return
+	}
+	files, err := a.gitClient.UnstagedFiles(a.scope.pathspecs()...)
+	if err != nil {
+		WriteError(a.outputWriter, err)
+		return
+	}
+	if len(files) == 0 {
+		WriteLine(a.outputWriter, "No changes to stage.")
+		return
+	}
+	selected, ok, err := a.selectMany("Select files to stage", files)
+	if err != nil {
+		WriteError(a.outputWriter, err)
+		return
+	}
+	if !ok {
+		WriteLine(a.outputWriter, "Canceled.")
+		return
+	}
+	if err := a.gitClient.Add(selected...); err != nil {
+		WriteError(a.outputWriter, err)
+		return
+	}
+	WriteLinef(a.outputWriter, "Staged %d file(s).", len(selected))
+}
+
+// addExplore opens the file explorer.
+func (a *Adder) addExplore() {
+	if a.explore == nil {
+		WriteError(a.outputWriter, fmt.Errorf("the file explorer requires an interactive terminal"))
+		return
+	}
+	if err := a.explore(); err != nil {
+		WriteError(a.outputWriter, err)
+	}
+}
@@ -36,10 +36,12 @@ type mockAddGitClient struct {
 	addFiles                          []string
 	addError                          error
 	addInteractiveError               error
+	unstagedFiles                     []string
 	GetCurrentBranchFunc              func() (string, error)
 	LogOnelineFunc                    func(from, to string) (string, error)
 	RebaseInteractiveFunc             func(commitCount int) error
 	RebaseInteractiveAutosquashCalled bool
+	RebaseTodo                        string
 	RebaseInteractiveAutosquashCount  int
 	RebaseCalled                      bool
 	RebaseUpstream                    string
@@ -64,6 +66,10 @@ func (m *mockAddGitClient) AddInteractive() error {
 	return m.addInteractiveError
 }
 
+func (m *mockAddGitClient) UnstagedFiles(...string) ([]string, error) {
+	return m.unstagedFiles, nil
+}
+
 func (m *mockAddGitClient) GetCurrentBranch() (string, error) {
 	if m.GetCurrentBranchFunc != nil {
 		return m.GetCurrentBranchFunc()
@@ -85,6 +91,11 @@ func (m *mockAddGitClient) RebaseInteractive(commitCount int) error {
 	return nil
 }
 
+func (m *mockAddGitClient) RebaseInteractiveWithTodo(_ int, todo string) error {
+	m.RebaseTodo = todo
+	return nil
+}
+
 func (m *mockAddGitClient) RebaseInteractiveAutosquash(commitCount int) error {
 	m.RebaseInteractiveAutosquashCalled = true
 	m.RebaseInteractiveAutosquashCount = commitCount
@@ -314,3 +325,121 @@ func TestAdder_Add_InteractiveSubcommand_CallsInteractive(t *testing.T) {
 		t.Error("Add should not be called for 'interactive' subcommand")
 	}
 }
+
+func TestAdder_Add_PatchSubcommand_UsesHunkStager(t *testing.T) {
+	mockClient := &mockAddGitClient{}
+	var gotPaths []string
+	adder := &Adder{
+		gitClient:    mockClient,
+		outputWriter: &bytes.Buffer{},
+		stageHunks: func(paths []string) error {
+			gotPaths = paths
+			return nil
+		},
+	}
+
+	adder.Add([]string{"patch", "main.go", "cmd/"})
+
+	if strings.Join(gotPaths, ",") != "main.go,cmd/" {
+		t.Errorf("hunk stager got paths %v", gotPaths)
+	}
+	if mockClient.addInteractiveCalled || mockClient.addCalled {
+		t.Error("git add should not run when the hunk stager is available")
+	}
+}
+
+func TestAdder_Add_PatchSubcommand_PathsWithoutTerminal(t *testing.T) {
+	mockClient := &mockAddGitClient{}
+	var buf bytes.Buffer
+	adder := &Adder{
+		gitClient:    mockClient,
+		outputWriter: &buf,
+	}
+
+	adder.Add([]string{"patch", "main.go"})
+
+	if mockClient.addCalled || mockClient.addInteractiveCalled {
+		t.Error("paths must not be passed to git add without the hunk stager")
+	}
+	if !strings.Contains(buf.String(), "interactive terminal") {
+		t.Errorf("expected terminal error, got %q", buf.String())
+	}
+}
+
+func TestAdder_AddSelect(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      []string
+		sel        multiSelector
+		wantAdded  []string
+		wantOutput string
+	}{
+		{
+			name:  "stages picked files",
+			files: []string{"a.go", "b.go"},
+			sel: func(_ string, items []string) ([]string, bool, error) {
+				return items[1:], true, nil
+			},
+			wantAdded:  []string{"b.go"},
+			wantOutput: "Staged 1 file(s).",
+		},
+		{
+			name:  "canceled",
+			files: []string{"a.go"},
+			sel: func(string, []string) ([]string, bool, error) {
+				return nil, false, nil
+			},
+			wantOutput: "Canceled.",
+		},
+		{
+			name:       "nothing to stage",
+			sel:        func(string, []string) ([]string, bool, error) { return nil, false, errors.New("unexpected") },
+			wantOutput: "No changes to stage.",
+		},
+		{
+			name:       "no terminal",
+			files:      []string{"a.go"},
+			wantOutput: "requires an interactive terminal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mockClient := &mockAddGitClient{unstagedFiles: tt.files}
+			adder := &Adder{gitClient: mockClient, outputWriter: &buf, selectMany: tt.sel}
+
+			adder.Add([]string{"select"})
+
+			if strings.Join(mockClient.addFiles, " ") != strings.Join(tt.wantAdded, " ") {
+				t.Errorf("added %v, want %v", mockClient.addFiles, tt.wantAdded)
+			}
+			if !strings.Contains(buf.String(), tt.wantOutput) {
+				t.Errorf("output = %q, want %q", buf.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestAdder_Add_Explore(t *testing.T) {
+	var buf bytes.Buffer
+	adder := &Adder{gitClient: &mockAddGitClient{}, outputWriter: &buf}
+	adder.Add([]string{"explore"})
+	if !strings.Contains(buf.String(), "requires an interactive terminal") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	buf.Reset()
+	runs := 0
+	adder.explore = func() error {
+		runs++
+		return errors.New("not a git repository")
+	}
+	adder.Add(nil)
+	adder.Add([]string{"explore"})
+	if runs != 2 {
+		t.Errorf("explorer ran %d time(s), want 2", runs)
+	}
+	if !strings.Contains(buf.String(), "not a git repository") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
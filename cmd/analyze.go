@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// analyzeOps is what ggc analyze needs from git.
+type analyzeOps interface {
+	git.LargeFileReader
+	git.RemoteURLReader
+}
+
+// Analyzer handles ggc analyze.
+type Analyzer struct {
+	gitClient     analyzeOps
+	outputWriter  io.Writer
+	helper        *Helper
+	pick          picker // nil unless stdin is a terminal
+	lookPath      func(string) (string, error)
+	configManager *config.Manager
+}
+
+// NewAnalyzer creates a new Analyzer instance.
+func NewAnalyzer(client analyzeOps) *Analyzer {
+	return &Analyzer{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+		lookPath:     exec.LookPath,
+	}
+}
+
+// withPicker lets ggc analyze scrub choose the file among the largest ones.
+func (a *Analyzer) withPicker(p picker) *Analyzer {
+	a.pick = p
+	return a
+}
+
+// withConfigManager supplies git.default-remote, the remote the scrub
+// instructions clone and push.
+func (a *Analyzer) withConfigManager(cm *config.Manager) *Analyzer {
+	a.configManager = cm
+	return a
+}
+
+// Analyze lists the largest files in history or explains how to remove one.
+func (a *Analyzer) Analyze(args []string) {
+	if len(args) == 0 {
+		a.helper.ShowAnalyzeHelp()
+		return
+	}
+	switch args[0] {
+	case "large-files":
+		a.largeFiles(args[1:])
+	case "scrub":
+		a.scrub(args[1:])
+	default:
+		a.helper.ShowAnalyzeHelp()
+	}
+}
+
+// largeFiles lists the largest files in history with the commit that
+// added each, as many as --top says.
+func (a *Analyzer) largeFiles(args []string) {
+	top := defaultTopBlobs
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--top" && i+1 < len(args):
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				WriteErrorf(a.outputWriter, "--top expects a positive number, got %q", args[i])
+				return
+			}
+			top = n
+		default:
+			WriteErrorf(a.outputWriter, "unknown analyze large-files option %s", arg)
+			return
+		}
+	}
+	blobs, err := a.gitClient.LargestBlobs(top)
+	if err != nil {
+		WriteError(a.outputWriter, err)
+		return
+	}
+	if len(blobs) == 0 {
+		WriteLine(a.outputWriter, "No files in history.")
+		return
+	}
+	w := a.outputWriter
+	WriteLinef(w, "%10s  %-9s %s", "SIZE", "COMMIT", "PATH")
+	for _, b := range blobs {
+		commit, err := a.gitClient.BlobCommit(b.ID)
+		if err != nil || commit == "" {
+			commit = "-"
+		}
+		WriteLinef(w, "%10s  %-9s %s", ui.FormatBytes(b.Size), commit, b.Path)
+	}
+	WriteLine(w, "")
+	WriteLine(w, "Run 'ggc analyze scrub <path>' to see how to remove one from history.")
+}
+
+// scrub prints the git filter-repo commands that remove a path from the
+// whole history, after warning about what that rewrite does. It never
+// rewrites anything itself. Without a path, the user picks one of the
+// largest files.
+func (a *Analyzer) scrub(args []string) {
+	var target string
+	switch len(args) {
+	case 0:
+		p, ok := a.pickLargeFile()
+		if !ok {
+			return
+		}
+		target = p
+	case 1:
+		target = strings.TrimPrefix(args[0], "./")
+	default:
+		WriteErrorf(a.outputWriter, "analyze scrub takes one path; quote it if it contains spaces")
+		return
+	}
+	if target == "" {
+		WriteErrorf(a.outputWriter, "analyze scrub expects a path")
+		return
+	}
+
+	remote := a.remoteName()
+	url, dir := "<url>", "repo-scrub"
+	if u, err := a.gitClient.RemoteGetURL(remote); err == nil && u != "" {
+		url = shellQuote(u)
+		dir = shellQuote(strings.TrimSuffix(path.Base(strings.TrimRight(u, "/")), ".git") + "-scrub")
+	}
+
+	w := a.outputWriter
+	WriteLinef(w, "!!! WARNING: removing %s rewrites the history of every branch and tag !!!", target)
+	WriteLine(w, "")
+	WriteLine(w, "  - Every commit from the first one that touches it gets a new hash.")
+	WriteLine(w, "  - Everyone else must clone the repository again; pushing from an old clone brings the file back.")
+	WriteLine(w, "  - Open pull requests, and links to the rewritten commits, stop working.")
+	WriteLine(w, "  - Copies in forks and on the hosting service stay until they are purged there; rotate any secret the file held.")
+	WriteLine(w, "")
+	WriteLine(w, "ggc does not run this for you. git filter-repo works in a fresh clone:")
+	WriteLine(w, "")
+	WriteLinef(w, "  git clone %s %s", url, dir)
+	WriteLinef(w, "  cd %s", dir)
+	WriteLinef(w, "  git filter-repo --invert-paths --path %s", shellQuote(target))
+	WriteLinef(w, "  git remote add %s %s", remote, url)
+	WriteLinef(w, "  git push --force --all %s", remote)
+	WriteLinef(w, "  git push --force --tags %s", remote)
+	WriteLine(w, "")
+	WriteLine(w, "Turn off branch protection for the force pushes, and tell everyone who works on the repository before you start.")
+	if _, err := a.lookPath("git-filter-repo"); err != nil {
+		WriteLine(w, "git filter-repo is not installed; get it from https://github.com/newren/git-filter-repo.")
+	}
+}
+
+// pickLargeFile lets the user choose among the largest files in history.
+// ok is false when there is no picker, nothing to pick or the user
+// cancels.
+func (a *Analyzer) pickLargeFile() (string, bool) {
+	if a.pick == nil {
+		WriteErrorf(a.outputWriter, "name the path to remove: ggc analyze scrub <path>")
+		return "", false
+	}
+	blobs, err := a.gitClient.LargestBlobs(50)
+	if err != nil {
+		WriteError(a.outputWriter, err)
+		return "", false
+	}
+	var items []interactive.PickItem
+	seen := map[string]bool{}
+	for _, b := range blobs {
+		if !seen[b.Path] {
+			seen[b.Path] = true
+			items = append(items, interactive.PickItem{Value: b.Path, Detail: ui.FormatBytes(b.Size)})
+		}
+	}
+	if len(items) == 0 {
+		WriteLine(a.outputWriter, "No files in history.")
+		return "", false
+	}
+	p, ok, err := a.pick("File to remove from history", items, "")
+	if err != nil {
+		WriteError(a.outputWriter, err)
+		return "", false
+	}
+	return p, ok
+}
+
+// remoteName returns git.default-remote, origin when it is unset.
+func (a *Analyzer) remoteName() string {
+	if a.configManager != nil {
+		if r := strings.TrimSpace(a.configManager.GetConfig().Git.DefaultRemote); r != "" {
+			return r
+		}
+	}
+	return "origin"
+}
+
+// shellQuote single-quotes s for POSIX sh when it holds anything but
+// letters, digits and punctuation the shell leaves alone.
+func shellQuote(s string) string {
+	safe := s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:@%+=,", r))
+	}) < 0
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type analyzeMock struct {
+	testutil.MockGitClient
+	blobs   []git.BlobSize
+	commits map[string]string
+	url     string
+}
+
+func (m *analyzeMock) LargestBlobs(n int) ([]git.BlobSize, error) {
+	return m.blobs[:min(n, len(m.blobs))], nil
+}
+
+func (m *analyzeMock) BlobCommit(id string) (string, error) { return m.commits[id], nil }
+
+func (m *analyzeMock) RemoteGetURL(string) (string, error) {
+	if m.url == "" {
+		return "", errors.New("no such remote")
+	}
+	return m.url, nil
+}
+
+func newTestAnalyzer(m *analyzeMock) (*Analyzer, *bytes.Buffer) {
+	var buf bytes.Buffer
+	a := NewAnalyzer(m)
+	a.outputWriter = &buf
+	a.helper.outputWriter = &buf
+	a.lookPath = func(string) (string, error) { return "/usr/bin/git-filter-repo", nil }
+	return a, &buf
+}
+
+func TestAnalyzer_LargeFiles(t *testing.T) {
+	m := &analyzeMock{
+		blobs: []git.BlobSize{
+			{Path: "assets/video.mp4", ID: "b2", Size: 5 << 20},
+			{Path: "vendor/lib.a", ID: "b3", Size: 2 << 20},
+			{Path: "README.md", ID: "b1", Size: 900},
+		},
+		commits: map[string]string{"b2": "a1b2c3d"},
+	}
+	a, buf := newTestAnalyzer(m)
+	a.Analyze([]string{"large-files", "--top", "2"})
+	out := buf.String()
+	for _, want := range []string{"5.0 MiB  a1b2c3d   assets/video.mp4", "2.0 MiB  -         vendor/lib.a", "ggc analyze scrub"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "README.md") {
+		t.Errorf("--top 2 should list two files:\n%s", out)
+	}
+}
+
+func TestAnalyzer_Scrub(t *testing.T) {
+	m := &analyzeMock{url: "git@github.com:acme/app.git"}
+	a, buf := newTestAnalyzer(m)
+	a.Analyze([]string{"scrub", "./design/big file.psd"})
+	out := buf.String()
+	for _, want := range []string{
+		"WARNING",
+		"git clone git@github.com:acme/app.git app-scrub",
+		"git filter-repo --invert-paths --path 'design/big file.psd'",
+		"git remote add origin git@github.com:acme/app.git",
+		"git push --force --all origin",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "not installed") {
+		t.Errorf("git filter-repo is installed:\n%s", out)
+	}
+}
+
+func TestAnalyzer_ScrubPick(t *testing.T) {
+	m := &analyzeMock{blobs: []git.BlobSize{{Path: "assets/video.mp4", Size: 5 << 20}, {Path: "assets/video.mp4", Size: 4 << 20}}}
+	a, buf := newTestAnalyzer(m)
+	a.lookPath = func(string) (string, error) { return "", exec.ErrNotFound }
+	var offered []interactive.PickItem
+	a.pick = func(_ string, items []interactive.PickItem, _ string) (string, bool, error) {
+		offered = items
+		return items[0].Value, true, nil
+	}
+	a.Analyze([]string{"scrub"})
+	if len(offered) != 1 || offered[0].Detail != "5.0 MiB" {
+		t.Errorf("offered %v", offered)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "--path assets/video.mp4") || !strings.Contains(out, "git clone <url> repo-scrub") || !strings.Contains(out, "not installed") {
+		t.Errorf("output = %s", out)
+	}
+
+	a, buf = newTestAnalyzer(m)
+	a.Analyze([]string{"scrub"})
+	if !strings.Contains(buf.String(), "ggc analyze scrub <path>") {
+		t.Errorf("without a picker: %q", buf.String())
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+)
+
+// autostashOps stash uncommitted changes and take them back, and tell
+// whether an operation run in between changed the repository.
+type autostashOps interface {
+	StashPush(message string) error
+	StashPop(stash string) error
+	RevParse(ref string) (string, error)
+	GitDir() (string, error)
+}
+
+// autostasher sets uncommitted changes aside in a stash for the commands
+// that need a clean working tree, and re-applies them afterwards, as
+// behavior.autostash says: ask (the default) offers it on a terminal,
+// always does it without asking and never leaves the changes to git. A
+// nil autostasher leaves them to git.
+type autostasher struct {
+	ops           autostashOps
+	status        git.StatusSummaryReader
+	prompter      prompt.Prompter // nil when stdin is not a terminal
+	configManager *config.Manager
+}
+
+// newAutostasher returns the autostasher for client, nil when client
+// cannot read the working tree status or stash.
+func newAutostasher(client any, cm *config.Manager) *autostasher {
+	ops, ok := client.(autostashOps)
+	if !ok {
+		return nil
+	}
+	status, ok := client.(git.StatusSummaryReader)
+	if !ok {
+		return nil
+	}
+	a := &autostasher{ops: ops, status: status, configManager: cm}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		a.prompter = prompt.New(os.Stdin, os.Stdout)
+	}
+	return a
+}
+
+// mode returns behavior.autostash, ask when unset.
+func (a *autostasher) mode() string {
+	if a.configManager != nil {
+		if m := a.configManager.GetConfig().Behavior.Autostash; m != "" {
+			return m
+		}
+	}
+	return "ask"
+}
+
+// save stashes the staged and modified files when there are any and the
+// mode allows it, asking question first in ask mode. message names the
+// stash; its %s is the current branch. ok is false when the user cancels,
+// or stashing fails, and the command should not go ahead.
+func (a *autostasher) save(w io.Writer, question, message string) (stashed, ok bool) {
+	if a == nil || a.mode() == "never" {
+		return false, true
+	}
+	summary, err := a.status.StatusSummary()
+	if err != nil || summary.Staged()+summary.Modified() == 0 || summary.Conflicted() > 0 {
+		return false, true
+	}
+	if a.mode() == "ask" {
+		if a.prompter == nil {
+			return false, true
+		}
+		yes, canceled, err := a.prompter.Confirm(question)
+		if canceled {
+			return false, false
+		}
+		if err != nil || !yes {
+			return false, true
+		}
+	}
+	if err := a.ops.StashPush(fmt.Sprintf(message, summary.Branch)); err != nil {
+		WriteError(w, err)
+		return false, false
+	}
+	WriteLine(w, "Stashed your uncommitted changes")
+	return true, true
+}
+
+// restore re-applies the stashed changes, on branch when it is not empty.
+// When they conflict, git keeps the stash and w is told how to finish.
+func (a *autostasher) restore(w io.Writer, branch string) {
+	if err := a.ops.StashPop(""); err != nil {
+		WriteError(w, err)
+		WriteLine(w, "Your changes are still in the stash (stash@{0}); resolve the conflicts, then run 'ggc stash drop'.")
+		return
+	}
+	if branch != "" {
+		WriteLinef(w, "Re-applied your changes on %s", branch)
+		return
+	}
+	WriteLine(w, "Re-applied your changes")
+}
+
+// around runs op, such as a rebase, with the uncommitted changes stashed,
+// and re-applies them once run reports it finished. When op fails before
+// touching the repository, such as a pull that cannot reach the remote,
+// HEAD has not moved and no operation is in progress, so the changes go
+// straight back too. When it stops halfway, git may be waiting on
+// conflicts, so the changes stay in the stash and w is told how to get
+// them back.
+func (a *autostasher) around(w io.Writer, op string, run func() (finished bool)) {
+	stashed, ok := a.save(w,
+		fmt.Sprintf("You have uncommitted changes. Stash them and re-apply them after the %s? (y/n): ", op),
+		"ggc "+op+" autostash on %s")
+	if !ok {
+		return
+	}
+	var head string
+	if stashed {
+		head, _ = a.ops.RevParse("HEAD")
+	}
+	finished := run()
+	if !stashed {
+		return
+	}
+	if !finished && !a.untouched(head) {
+		WriteLinef(w, "Your uncommitted changes are in the stash (stash@{0}); run 'ggc stash pop' once the %s is done.", op)
+		return
+	}
+	a.restore(w, "")
+}
+
+// untouched reports whether HEAD is still at head and git is not in the
+// middle of a rebase, merge or other operation. When either cannot be
+// told, the repository counts as changed.
+func (a *autostasher) untouched(head string) bool {
+	if head == "" {
+		return false
+	}
+	if now, err := a.ops.RevParse("HEAD"); err != nil || now != head {
+		return false
+	}
+	gitDir, err := a.ops.GitDir()
+	return err == nil && gitDir != "" && git.OperationInProgress(gitDir) == ""
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+// recordingStash records stash pushes and pops; popErr makes a pop
+// conflict. head is what HEAD resolves to and gitDir the repository's
+// .git directory.
+type recordingStash struct {
+	calls  []string
+	popErr error
+	head   string
+	gitDir string
+}
+
+func (r *recordingStash) RevParse(string) (string, error) { return r.head, nil }
+func (r *recordingStash) GitDir() (string, error)         { return r.gitDir, nil }
+
+func (r *recordingStash) StashPush(message string) error {
+	r.calls = append(r.calls, "push "+message)
+	return nil
+}
+
+func (r *recordingStash) StashPop(string) error {
+	r.calls = append(r.calls, "pop")
+	return r.popErr
+}
+
+func newTestAutostasher(mode, answer string, ops *recordingStash) *autostasher {
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().Behavior.Autostash = mode
+	a := &autostasher{ops: ops, status: dirtyStatus{}, configManager: cm}
+	if answer != "" {
+		a.prompter = prompt.New(strings.NewReader(answer+"\n"), &bytes.Buffer{})
+	}
+	return a
+}
+
+func TestAutostasher_Around(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		answer   string // empty: no terminal
+		finished bool
+		moved    bool   // HEAD moved while the operation ran
+		state    string // a file the operation left in .git
+		want     string
+		output   string
+	}{
+		{"ask, yes", "", "y", true, true, "", "push ggc rebase autostash on main; pop", "Re-applied your changes"},
+		{"ask, no", "ask", "n", true, true, "", "", ""},
+		{"ask without a terminal", "ask", "", true, true, "", "", ""},
+		{"always", "always", "", true, true, "", "push ggc rebase autostash on main; pop", "Stashed your uncommitted changes"},
+		{"never", "never", "y", true, true, "", "", ""},
+		{"stopped halfway", "always", "", false, true, "", "push ggc rebase autostash on main", "run 'ggc stash pop' once the rebase is done"},
+		{"stopped on a conflict", "always", "", false, false, "MERGE_HEAD", "push ggc rebase autostash on main", "run 'ggc stash pop' once the rebase is done"},
+		{"failed before starting", "always", "", false, false, "", "push ggc rebase autostash on main; pop", "Re-applied your changes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			ops := &recordingStash{head: "aaa111", gitDir: t.TempDir()}
+			ran := false
+			newTestAutostasher(tt.mode, tt.answer, ops).around(&out, "rebase", func() bool {
+				ran = true
+				if tt.moved {
+					ops.head = "bbb222"
+				}
+				if tt.state != "" {
+					if err := os.WriteFile(filepath.Join(ops.gitDir, tt.state), nil, 0o600); err != nil {
+						t.Fatal(err)
+					}
+				}
+				return tt.finished
+			})
+			if !ran {
+				t.Fatal("the operation did not run")
+			}
+			if got := strings.Join(ops.calls, "; "); got != tt.want {
+				t.Errorf("calls = %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(out.String(), tt.output) {
+				t.Errorf("output %q, want it to contain %q", out.String(), tt.output)
+			}
+		})
+	}
+}
+
+// cancelingPrompter answers every confirmation with a cancel.
+type cancelingPrompter struct{ mockPrompter }
+
+func (cancelingPrompter) Confirm(string) (bool, bool, error) { return false, true, nil }
+
+func TestAutostasher_AroundCanceled(t *testing.T) {
+	ops := &recordingStash{}
+	a := newTestAutostasher("ask", "", ops)
+	a.prompter = &cancelingPrompter{}
+	ran := false
+	a.around(&bytes.Buffer{}, "pull", func() bool { ran = true; return true })
+	if ran || len(ops.calls) != 0 {
+		t.Errorf("ran = %v, calls = %v after canceling", ran, ops.calls)
+	}
+}
+
+func TestAutostasher_RestoreConflict(t *testing.T) {
+	var out bytes.Buffer
+	ops := &recordingStash{popErr: errors.New("conflict in a.go")}
+	newTestAutostasher("always", "", ops).around(&out, "restack", func() bool { return true })
+	for _, want := range []string{"Error: conflict in a.go", "still in the stash (stash@{0})", "'ggc stash drop'"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output %q, want it to contain %q", out.String(), want)
+		}
+	}
+}
+
+func TestAutostasher_Nil(t *testing.T) {
+	var a *autostasher
+	ran := false
+	a.around(&bytes.Buffer{}, "pull", func() bool { ran = true; return true })
+	if !ran {
+		t.Error("a nil autostasher should run the operation as is")
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// batchFlag makes ggc read commands from stdin even when it is a terminal.
+const batchFlag = "--batch"
+
+// stdinIsTerminal reports whether ggc's stdin is a terminal rather than a
+// pipe or a file.
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// RunBatch runs the commands piped to stdin even when it is a terminal,
+// for the global --batch flag. args are what follows the global flags;
+// batch mode takes none.
+func (c *Cmd) RunBatch(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%s takes no arguments; it reads one command per line from stdin", batchFlag)
+	}
+	return c.Batch(c.stdin)
+}
+
+// Batch runs the commands in r one line at a time, as if each had been
+// given on the command line. Blank lines and lines starting with # are
+// skipped, and a leading "ggc" is optional, so a script written for the
+// shell can be piped in as it is. The first command that fails stops the
+// batch, and its error is returned.
+func (c *Cmd) Batch(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		args := tokenize(line)
+		if len(args) > 0 && args[0] == "ggc" {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(c.outputWriter, "Executing: %s\n", strings.Join(args, " "))
+		if err := c.dispatch(args); err != nil {
+			if isReported(err) {
+				// The command printed its error; say where the batch stopped.
+				WriteLinef(c.outputWriter, "Batch stopped at line %d: %s", n, line)
+				return err
+			}
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read commands: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newBatchCmd returns a Cmd whose status, version and add commands record
+// the arguments they were run with; add reports an error as handlers do.
+func newBatchCmd(t *testing.T, stdin string) (*Cmd, *[][]string) {
+	t.Helper()
+	installIsolatedHistory(t)
+	c := newRouterCmd(t)
+	c.stdin = strings.NewReader(stdin)
+	c.stdinIsTerminal = func() bool { return false }
+	var ran [][]string
+	record := func(name string) func([]string) {
+		return func(args []string) {
+			ran = append(ran, append([]string{name}, args...))
+		}
+	}
+	c.cmdRouter.handlers["status"] = record("status")
+	c.cmdRouter.handlers["version"] = record("version")
+	c.cmdRouter.handlers["add"] = func(args []string) {
+		ran = append(ran, append([]string{"add"}, args...))
+		WriteError(c.outputWriter, errors.New("failed"))
+	}
+	return c, &ran
+}
+
+func TestBatch_RunsEachLine(t *testing.T) {
+	c, ran := newBatchCmd(t, "# prepare\nstatus short\n\n  ggc version json\nstatus 'a b'\n")
+
+	if err := c.Execute(nil); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	want := [][]string{{"status", "short"}, {"version", "json"}, {"status", "a b"}}
+	if !reflect.DeepEqual(*ran, want) {
+		t.Errorf("ran %v, want %v", *ran, want)
+	}
+	out := c.outputWriter.(*bytes.Buffer).String()
+	if !strings.Contains(out, "Executing: version json") {
+		t.Errorf("output does not echo the command:\n%s", out)
+	}
+}
+
+func TestBatch_StopsAtFirstFailure(t *testing.T) {
+	c, ran := newBatchCmd(t, "status\nadd now\nversion\n")
+
+	err := c.RunBatch(nil)
+	if !isReported(err) {
+		t.Fatalf("RunBatch = %v, want the failure the command reported", err)
+	}
+	want := [][]string{{"status"}, {"add", "now"}}
+	if !reflect.DeepEqual(*ran, want) {
+		t.Errorf("ran %v, want %v", *ran, want)
+	}
+	if out := c.outputWriter.(*bytes.Buffer).String(); !strings.Contains(out, "Batch stopped at line 2: add now") {
+		t.Errorf("output does not say where the batch stopped:\n%s", out)
+	}
+}
+
+func TestBatch_UnknownCommand(t *testing.T) {
+	c, _ := newBatchCmd(t, "status\nnope\n")
+
+	err := c.Execute(nil)
+	if !errors.Is(err, ErrUnknownCommand) || !strings.HasPrefix(err.Error(), "line 2: ") {
+		t.Errorf("Execute = %v, want an unknown command error for line 2", err)
+	}
+}
+
+func TestBatch_FlagForcesBatchInTerminal(t *testing.T) {
+	c, ran := newBatchCmd(t, "version\n")
+	c.stdinIsTerminal = func() bool { return true }
+
+	if err := c.RunBatch(nil); err != nil {
+		t.Fatalf("RunBatch: %v", err)
+	}
+	if want := [][]string{{"version"}}; !reflect.DeepEqual(*ran, want) {
+		t.Errorf("ran %v, want %v", *ran, want)
+	}
+	if err := c.RunBatch([]string{"status"}); err == nil {
+		t.Error("--batch with arguments should be an error")
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+)
+
+// blameViewer shows the interactive blame of path at rev.
+type blameViewer func(rev, path string) error
+
+// Blamer handles git blame.
+type Blamer struct {
+	gitClient    git.PassthroughOps
+	outputWriter io.Writer
+	helper       *Helper
+	view         blameViewer // nil when stdin is not a terminal
+}
+
+// NewBlamer creates a new Blamer instance.
+func NewBlamer(client git.PassthroughOps) *Blamer {
+	return &Blamer{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+	}
+}
+
+// withViewer opens `ggc blame <file>` in the blame viewer when stdin is a
+// terminal. cm supplies the keybinding profile and diff tool and may be nil.
+func (b *Blamer) withViewer(src interactive.BlameSource, cm *config.Manager) *Blamer {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return b
+	}
+	b.view = func(rev, path string) error {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewBlameViewer(src, cfg).Run(rev, path)
+	}
+	return b
+}
+
+// Blame opens `ggc blame [<rev>] <file>` in the viewer on a terminal.
+// Anything with options, and every call without a terminal, is forwarded
+// to git blame as-is.
+func (b *Blamer) Blame(args []string) {
+	if len(args) == 0 || args[0] == "help" {
+		b.helper.ShowPassthroughHelp("blame")
+		return
+	}
+	if b.view != nil && len(args) <= 2 && !hasOption(args) {
+		rev, path := "", args[0]
+		if len(args) == 2 {
+			rev, path = args[0], args[1]
+		}
+		if err := b.view(rev, path); err != nil {
+			WriteError(b.outputWriter, err)
+		}
+		return
+	}
+	if err := b.gitClient.RunGit("blame", args); err != nil {
+		WriteError(b.outputWriter, err)
+	}
+}
+
+func hasOption(args []string) bool {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return true
+		}
+	}
+	return false
+}
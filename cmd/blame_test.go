@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBlamer_Blame_Viewer(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantView string
+		wantGit  []string
+	}{
+		{args: []string{"main.go"}, wantView: ":main.go"},
+		{args: []string{"v1.0", "main.go"}, wantView: "v1.0:main.go"},
+		{args: []string{"-L", "10,20", "main.go"}, wantGit: []string{"-L", "10,20", "main.go"}},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		mockClient := &mockBisectClient{}
+		var viewed string
+		b := NewBlamer(mockClient)
+		b.outputWriter = &buf
+		b.view = func(rev, path string) error {
+			viewed = rev + ":" + path
+			return nil
+		}
+
+		b.Blame(tt.args)
+
+		if viewed != tt.wantView {
+			t.Errorf("%v: viewed %q, want %q", tt.args, viewed, tt.wantView)
+		}
+		if mockClient.called != (tt.wantGit != nil) || !slices.Equal(mockClient.gotArgs, tt.wantGit) {
+			t.Errorf("%v: RunGit called=%v args=%v, want %v", tt.args, mockClient.called, mockClient.gotArgs, tt.wantGit)
+		}
+	}
+}
+
+func TestBlamer_Blame_ForwardsWithoutTerminal(t *testing.T) {
+	mockClient := &mockBisectClient{}
+	b := NewBlamer(mockClient)
+	b.Blame([]string{"main.go"})
+	if mockClient.gotName != "blame" || !slices.Equal(mockClient.gotArgs, []string{"main.go"}) {
+		t.Errorf("RunGit(%q, %v)", mockClient.gotName, mockClient.gotArgs)
+	}
+}
+
+func TestBlamer_Blame_Help(t *testing.T) {
+	var buf bytes.Buffer
+	mockClient := &mockBisectClient{}
+	b := NewBlamer(mockClient)
+	b.outputWriter = &buf
+	b.helper.outputWriter = &buf
+
+	b.Blame(nil)
+
+	if mockClient.called || !strings.Contains(buf.String(), "ggc blame") {
+		t.Errorf("expected blame help, got %q", buf.String())
+	}
+}
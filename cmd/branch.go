@@ -8,9 +8,10 @@ import (
 
 	"github.com/bmf-san/ggc/v8/internal/git"
 	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
-const errMsgBranchNameEmpty = "Error: branch name cannot be empty."
+const errMsgBranchNameEmpty = "branch name cannot be empty."
 
 // Brancher provides functionality for the branch command.
 type Brancher struct {
@@ -18,6 +19,13 @@ type Brancher struct {
 	prompter     prompt.Prompter
 	outputWriter io.Writer
 	helper       *Helper
+	undo         *Undoer
+	selectMany   multiSelector // nil falls back to numbered prompts
+	guard        *branchGuard
+	confirm      *ui.Confirmer
+	pick         picker                  // nil unless stdin is a terminal
+	renames      renamePropagator        // nil renames local branches only
+	copy         func(text string) error // nil refuses branch current --copy
 }
 
 // NewBrancher creates a new Brancher.
@@ -33,6 +41,19 @@ func NewBrancher(client git.BranchOps) *Brancher {
 	}
 }
 
+// withUndo journals branch deletions so `ggc undo` can restore them.
+func (b *Brancher) withUndo(u *Undoer) *Brancher {
+	b.undo = u
+	return b
+}
+
+// withMultiSelect lets branch deletion pick branches in the full-screen
+// multi-select picker.
+func (b *Brancher) withMultiSelect(sel multiSelector) *Brancher {
+	b.selectMany = sel
+	return b
+}
+
 // Branch executes the branch command with the given arguments.
 func (b *Brancher) Branch(args []string) {
 	if len(args) == 0 {
@@ -46,13 +67,15 @@ func (b *Brancher) Branch(args []string) {
 // handleBranchCommand processes the specific branch subcommand
 func (b *Brancher) handleBranchCommand(cmd string, args []string) {
 	branchCommands := map[string]func([]string){
-		"current":  func([]string) { b.handleCurrentBranch() },
+		"current":  b.handleCurrentBranch,
 		"checkout": b.handleCheckoutCommand,
 		"create":   b.branchCreate,
 		"delete":   b.handleDeleteCommand,
 		"rename":   b.branchRename,
 		"move":     b.branchMove,
 		"set":      b.handleSetCommand,
+		"track":    b.branchTrack,
+		"untrack":  b.branchUntrack,
 		"info":     b.branchInfo,
 		"list":     b.handleListCommand,
 		"sort":     b.branchSort,
@@ -66,14 +89,43 @@ func (b *Brancher) handleBranchCommand(cmd string, args []string) {
 	b.helper.ShowBranchHelp()
 }
 
-// handleCurrentBranch shows the current branch
-func (b *Brancher) handleCurrentBranch() {
+// handleCurrentBranch shows the current branch, and copies its name with
+// --copy.
+func (b *Brancher) handleCurrentBranch(args []string) {
+	_, copy := cutCopyFlag(args)
 	branch, err := b.gitClient.GetCurrentBranch()
 	if err != nil {
 		WriteError(b.outputWriter, err)
 		return
 	}
 	_, _ = fmt.Fprintln(b.outputWriter, branch)
+	if copy {
+		copyResult(b.outputWriter, b.copy, branch)
+	}
+}
+
+// withGuard makes branch deletion respect safety.protected-branches.
+func (b *Brancher) withGuard(g *branchGuard) *Brancher {
+	b.guard = g
+	return b
+}
+
+// withClipboard lets ggc branch current --copy copy the branch name.
+func (b *Brancher) withClipboard(copy func(text string) error) *Brancher {
+	b.copy = copy
+	return b
+}
+
+// withConfirmer makes branch deletion ask first.
+func (b *Brancher) withConfirmer(c *ui.Confirmer) *Brancher {
+	b.confirm = c
+	return b
+}
+
+// withPicker lets ggc branch track choose the upstream in a fuzzy picker.
+func (b *Brancher) withPicker(p picker) *Brancher {
+	b.pick = p
+	return b
 }
 
 // handleCheckoutCommand handles checkout subcommand
@@ -88,7 +140,7 @@ func (b *Brancher) handleCheckoutCommand(args []string) {
 // handleDeleteCommand handles delete subcommand
 func (b *Brancher) handleDeleteCommand(args []string) {
 	if len(args) > 0 && args[0] == "merged" {
-		b.branchDeleteMerged()
+		b.branchDeleteMerged(args[1:])
 	} else {
 		b.branchDeleteArgs(args)
 	}
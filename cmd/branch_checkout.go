@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"errors"
+	"io"
 	"strings"
 
 	"github.com/bmf-san/ggc/v8/internal/prompt"
@@ -58,7 +59,9 @@ func (b *Brancher) promptSelectIndex(title string, items []string, promptText st
 		return 0, false
 	}
 	idx, canceled, err := b.prompter.Select(title, items, promptText)
-	if canceled {
+	if canceled || errors.Is(err, io.EOF) {
+		// Stdin ending without an answer cancels, as Ctrl+D does in a
+		// terminal; it is not a failure.
 		return 0, false
 	}
 	if err != nil {
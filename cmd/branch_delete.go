@@ -4,11 +4,15 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/safety"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 func (b *Brancher) branchDeleteArgs(args []string) {
+	args, unsafe := safety.CutForceFlag(args)
 	if len(args) > 0 {
-		b.deleteBranchesFromArgs(args)
+		b.deleteBranchesFromArgs(args, unsafe)
 		return
 	}
 
@@ -16,17 +20,46 @@ func (b *Brancher) branchDeleteArgs(args []string) {
 	if !ok {
 		return
 	}
+	branches = b.skipProtected(branches, unsafe)
 
 	if len(branches) == 0 {
 		WriteLine(b.outputWriter, "No local branches found.")
 		return
 	}
 
+	if b.selectMany != nil {
+		b.deleteSelectedBranches("Select local branches to delete", branches, "Selected branches deleted.")
+		return
+	}
 	b.runBranchDeleteLoop(branches)
 }
 
-func (b *Brancher) deleteBranchesFromArgs(args []string) {
+// deleteSelectedBranches deletes the branches picked in the multi-select
+// picker.
+func (b *Brancher) deleteSelectedBranches(title string, branches []string, done string) {
+	selected, ok, err := b.selectMany(title, branches)
+	if err != nil {
+		WriteError(b.outputWriter, err)
+		return
+	}
+	if !ok {
+		WriteLine(b.outputWriter, "Canceled.")
+		return
+	}
+	if !b.confirmDelete(selected) {
+		return
+	}
+	for _, br := range selected {
+		if err := b.deleteBranch(br); err != nil {
+			WriteError(b.outputWriter, err)
+		}
+	}
+	WriteLine(b.outputWriter, done)
+}
+
+func (b *Brancher) deleteBranchesFromArgs(args []string, unsafe bool) {
 	current, _ := b.gitClient.GetCurrentBranch()
+	var targets []string
 	for _, a := range args {
 		br := strings.TrimSpace(a)
 		if br == "" {
@@ -36,10 +69,60 @@ func (b *Brancher) deleteBranchesFromArgs(args []string) {
 			WriteLinef(b.outputWriter, "Skipping current branch: %s", br)
 			continue
 		}
-		if err := b.gitClient.DeleteBranch(br); err != nil {
+		if err := b.guard.check("delete", br, unsafe); err != nil {
 			WriteError(b.outputWriter, err)
+			continue
 		}
+		targets = append(targets, br)
+	}
+	if len(targets) == 0 || !b.confirmDelete(targets) {
+		return
 	}
+	for _, br := range targets {
+		if err := b.deleteBranch(br); err != nil {
+			WriteError(b.outputWriter, err)
+		}
+	}
+}
+
+// confirmDelete asks before deleting branches. Deleting a single branch
+// asks for its name.
+func (b *Brancher) confirmDelete(branches []string) bool {
+	var ok bool
+	var err error
+	if len(branches) == 1 {
+		ok, err = b.confirm.ConfirmTyped(fmt.Sprintf("Delete branch '%s'?", branches[0]), branches[0])
+	} else {
+		ok, err = b.confirm.Confirm(fmt.Sprintf("Delete %d branches (%s)?", len(branches), strings.Join(branches, ", ")))
+	}
+	return proceed(b.outputWriter, ok, err)
+}
+
+// deleteBranch deletes br and journals its tip so `ggc undo` can recreate it.
+func (b *Brancher) deleteBranch(br string) error {
+	pending := b.undo.beginBranchDelete(br)
+	if err := b.gitClient.DeleteBranch(br); err != nil {
+		return err
+	}
+	b.undo.commit(pending)
+	return nil
+}
+
+// skipProtected leaves protected branches out of the branches offered for
+// deletion, unless --force-unsafe was given.
+func (b *Brancher) skipProtected(branches []string, unsafe bool) []string {
+	if b.guard == nil || unsafe {
+		return branches
+	}
+	kept := make([]string, 0, len(branches))
+	for _, br := range branches {
+		if _, protected := b.guard.protection.Match(br); protected {
+			WriteLinef(b.outputWriter, "Skipping protected branch: %s (pass %s to include it)", br, safety.ForceFlag)
+			continue
+		}
+		kept = append(kept, br)
+	}
+	return kept
 }
 
 func (b *Brancher) collectDeletableBranches() ([]string, bool) {
@@ -87,9 +170,10 @@ func (b *Brancher) runBranchDeleteLoop(branches []string) {
 
 // displayBranchSelection shows the branch selection interface
 func (b *Brancher) displayBranchSelection(branches []string) {
-	WriteLine(b.outputWriter, "\033[1;36mSelect local branches to delete by number (space separated, all: select all, none: deselect all, e.g. 1 3 5):\033[0m")
+	colors := ui.ColorsFor(b.outputWriter)
+	WriteLine(b.outputWriter, colors.Bold+colors.Cyan+"Select local branches to delete by number (space separated, all: select all, none: deselect all, e.g. 1 3 5):"+colors.Reset)
 	for i, br := range branches {
-		WriteLinef(b.outputWriter, "  [\033[1;33m%d\033[0m] %s", i+1, br)
+		WriteLinef(b.outputWriter, "  [%s%d%s] %s", colors.Bold+colors.Yellow, i+1, colors.Reset, br)
 	}
 	_, _ = fmt.Fprint(b.outputWriter, "> ")
 }
@@ -97,8 +181,11 @@ func (b *Brancher) displayBranchSelection(branches []string) {
 // handleBranchSpecialCommands processes "all" and "none" commands for branches
 func (b *Brancher) handleBranchSpecialCommands(input string, branches []string) bool {
 	if input == "all" {
+		if !b.confirmDelete(branches) {
+			return true
+		}
 		for _, br := range branches {
-			if err := b.gitClient.DeleteBranch(br); err != nil {
+			if err := b.deleteBranch(br); err != nil {
 				WriteError(b.outputWriter, err)
 			}
 		}
@@ -117,9 +204,12 @@ func (b *Brancher) handleBranchSelection(input string, branches []string) bool {
 	if !valid {
 		return false // Continue loop
 	}
+	if !b.confirmDelete(selectedBranches) {
+		return true
+	}
 
 	for _, br := range selectedBranches {
-		if err := b.gitClient.DeleteBranch(br); err != nil {
+		if err := b.deleteBranch(br); err != nil {
 			WriteError(b.outputWriter, err)
 		}
 	}
@@ -135,7 +225,8 @@ func (b *Brancher) parseBranchIndices(input string, branches []string) ([]string
 	for _, idx := range indices {
 		n, err := strconv.Atoi(idx)
 		if err != nil || n < 1 || n > len(branches) {
-			WriteLinef(b.outputWriter, "\033[1;31mInvalid number: %s\033[0m", idx)
+			colors := ui.ColorsFor(b.outputWriter)
+			WriteLinef(b.outputWriter, "%sInvalid number: %s%s", colors.Bold+colors.Red, idx, colors.Reset)
 			return nil, false
 		}
 		selectedBranches = append(selectedBranches, branches[n-1])
@@ -143,17 +234,23 @@ func (b *Brancher) parseBranchIndices(input string, branches []string) ([]string
 	return selectedBranches, true
 }
 
-func (b *Brancher) branchDeleteMerged() {
+func (b *Brancher) branchDeleteMerged(args []string) {
+	_, unsafe := safety.CutForceFlag(args)
 	branches, err := b.getMergedBranchesForDeletion()
 	if err != nil {
 		WriteError(b.outputWriter, err)
 		return
 	}
+	branches = b.skipProtected(branches, unsafe)
 	if len(branches) == 0 {
 		WriteLine(b.outputWriter, "No merged local branches.")
 		return
 	}
 
+	if b.selectMany != nil {
+		b.deleteSelectedBranches("Select merged local branches to delete", branches, "Selected merged branches deleted.")
+		return
+	}
 	b.runMergedBranchDeleteLoop(branches)
 }
 
@@ -203,9 +300,10 @@ func (b *Brancher) runMergedBranchDeleteLoop(branches []string) {
 
 // displayMergedBranchSelection shows the merged branch selection interface
 func (b *Brancher) displayMergedBranchSelection(branches []string) {
-	WriteLine(b.outputWriter, "\033[1;36mSelect merged local branches to delete by number (space separated, all: select all, none: deselect all, e.g. 1 3 5):\033[0m")
+	colors := ui.ColorsFor(b.outputWriter)
+	WriteLine(b.outputWriter, colors.Bold+colors.Cyan+"Select merged local branches to delete by number (space separated, all: select all, none: deselect all, e.g. 1 3 5):"+colors.Reset)
 	for i, br := range branches {
-		WriteLinef(b.outputWriter, "  [\033[1;33m%d\033[0m] %s", i+1, br)
+		WriteLinef(b.outputWriter, "  [%s%d%s] %s", colors.Bold+colors.Yellow, i+1, colors.Reset, br)
 	}
 	_, _ = fmt.Fprint(b.outputWriter, "> ")
 }
@@ -213,8 +311,11 @@ func (b *Brancher) displayMergedBranchSelection(branches []string) {
 // handleMergedBranchSpecialCommands processes "all" and "none" commands for merged branches
 func (b *Brancher) handleMergedBranchSpecialCommands(input string, branches []string) bool {
 	if input == "all" {
+		if !b.confirmDelete(branches) {
+			return true
+		}
 		for _, br := range branches {
-			if err := b.gitClient.DeleteBranch(br); err != nil {
+			if err := b.deleteBranch(br); err != nil {
 				WriteError(b.outputWriter, err)
 			}
 		}
@@ -233,9 +334,12 @@ func (b *Brancher) handleMergedBranchSelection(input string, branches []string)
 	if !valid {
 		return false // Continue loop
 	}
+	if !b.confirmDelete(selectedBranches) {
+		return true
+	}
 
 	for _, br := range selectedBranches {
-		if err := b.gitClient.DeleteBranch(br); err != nil {
+		if err := b.deleteBranch(br); err != nil {
 			WriteError(b.outputWriter, err)
 		}
 	}
@@ -7,14 +7,14 @@ import (
 
 func (b *Brancher) branchInfo(args []string) {
 	if len(args) > 1 {
-		WriteLine(b.outputWriter, "Error: branch info accepts at most one branch name.")
+		WriteErrorf(b.outputWriter, "branch info accepts at most one branch name.")
 		return
 	}
 
 	if len(args) == 1 {
 		branch := strings.TrimSpace(args[0])
 		if branch == "" {
-			WriteLine(b.outputWriter, errMsgBranchNameEmpty)
+			WriteErrorf(b.outputWriter, errMsgBranchNameEmpty)
 			return
 		}
 		b.printBranchInfo(branch)
@@ -120,14 +120,14 @@ func (b *Brancher) branchListRemote() {
 
 func (b *Brancher) branchSort(args []string) {
 	if len(args) > 1 {
-		WriteLine(b.outputWriter, "Error: branch sort accepts at most one option (name|date).")
+		WriteErrorf(b.outputWriter, "branch sort accepts at most one option (name|date).")
 		return
 	}
 
 	if len(args) == 1 {
 		choice := strings.ToLower(strings.TrimSpace(args[0]))
 		if choice == "" {
-			WriteLine(b.outputWriter, "Error: sort option cannot be empty.")
+			WriteErrorf(b.outputWriter, "sort option cannot be empty.")
 			return
 		}
 		if choice != "name" && choice != "date" {
@@ -168,14 +168,14 @@ func (b *Brancher) printSortedBranches(by string) {
 
 func (b *Brancher) branchContains(args []string) {
 	if len(args) > 1 {
-		WriteLine(b.outputWriter, "Error: branch contains accepts at most one commit or ref.")
+		WriteErrorf(b.outputWriter, "branch contains accepts at most one commit or ref.")
 		return
 	}
 
 	if len(args) == 1 {
 		commit := strings.TrimSpace(args[0])
 		if commit == "" {
-			WriteLine(b.outputWriter, "Error: commit or ref cannot be empty.")
+			WriteErrorf(b.outputWriter, "commit or ref cannot be empty.")
 			return
 		}
 		b.branchContainsForCommit(commit)
@@ -31,75 +31,16 @@ func (b *Brancher) branchCreate(args []string) {
 	}
 }
 
-func (b *Brancher) branchRename(args []string) {
-	if len(args) >= 2 {
-		oldName := strings.TrimSpace(args[0])
-		newName := strings.TrimSpace(args[1])
-		if oldName == "" {
-			WriteLine(b.outputWriter, errMsgBranchNameEmpty)
-			return
-		}
-		if newName == "" {
-			WriteLine(b.outputWriter, "Error: new branch name cannot be empty.")
-			return
-		}
-		if err := b.gitClient.ValidateBranchName(newName); err != nil {
-			WriteErrorf(b.outputWriter, "invalid branch name: %v", err)
-			return
-		}
-		if err := b.gitClient.RenameBranch(oldName, newName); err != nil {
-			WriteError(b.outputWriter, err)
-		}
-		return
-	}
-
-	b.branchRenameInteractive()
-}
-
-func (b *Brancher) branchRenameInteractive() {
-	branches, err := b.gitClient.ListLocalBranches()
-	if err != nil {
-		WriteError(b.outputWriter, err)
-		return
-	}
-	if len(branches) == 0 {
-		WriteLine(b.outputWriter, "No local branches found.")
-		return
-	}
-	idx, ok := b.promptSelectIndex("Local branches:", branches, "Enter the number of the branch to rename: ")
-	if !ok {
-		return
-	}
-	oldName := branches[idx]
-	newInput, ok := ReadLine(b.prompter, b.outputWriter, "Enter new branch name: ")
-	if !ok {
-		return
-	}
-	newName := strings.TrimSpace(newInput)
-	if newName == "" {
-		WriteLine(b.outputWriter, "Canceled.")
-		return
-	}
-	if err := b.gitClient.ValidateBranchName(newName); err != nil {
-		WriteErrorf(b.outputWriter, "invalid branch name: %v", err)
-		return
-	}
-	if err := b.gitClient.RenameBranch(oldName, newName); err != nil {
-		WriteError(b.outputWriter, err)
-		return
-	}
-}
-
 func (b *Brancher) branchMove(args []string) {
 	if len(args) >= 2 {
 		branch := strings.TrimSpace(args[0])
 		commit := strings.TrimSpace(args[1])
 		if branch == "" {
-			WriteLine(b.outputWriter, errMsgBranchNameEmpty)
+			WriteErrorf(b.outputWriter, errMsgBranchNameEmpty)
 			return
 		}
 		if commit == "" {
-			WriteLine(b.outputWriter, "Error: commit or ref cannot be empty.")
+			WriteErrorf(b.outputWriter, "commit or ref cannot be empty.")
 			return
 		}
 		if !b.gitClient.RevParseVerify(commit) {
@@ -156,7 +97,7 @@ func (b *Brancher) branchSetUpstream(args []string) {
 	case 2:
 		branch := strings.TrimSpace(args[0])
 		if branch == "" {
-			WriteLine(b.outputWriter, errMsgBranchNameEmpty)
+			WriteErrorf(b.outputWriter, errMsgBranchNameEmpty)
 			return
 		}
 		upstream, ok := b.resolveUpstreamArgument(strings.TrimSpace(args[1]))
@@ -167,7 +108,7 @@ func (b *Brancher) branchSetUpstream(args []string) {
 			WriteError(b.outputWriter, err)
 		}
 	default:
-		WriteLine(b.outputWriter, "Error: branch set upstream expects <branch> <upstream>.")
+		WriteErrorf(b.outputWriter, "branch set upstream expects <branch> <upstream>.")
 	}
 }
 
@@ -199,7 +140,7 @@ func (b *Brancher) branchSetUpstreamInteractive() {
 
 func (b *Brancher) resolveUpstreamArgument(input string) (string, bool) {
 	if input == "" {
-		WriteLine(b.outputWriter, "Error: upstream cannot be empty.")
+		WriteErrorf(b.outputWriter, "upstream cannot be empty.")
 		return "", false
 	}
 
@@ -232,7 +173,7 @@ func (b *Brancher) selectLocalBranch(branches []string) string {
 func (b *Brancher) selectUpstreamBranch() string {
 	remotes, err := b.getValidRemoteBranches()
 	if err != nil {
-		WriteLinef(b.outputWriter, "Error listing remote branches: %v", err)
+		WriteErrorf(b.outputWriter, "listing remote branches: %w", err)
 		return ""
 	}
 
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/safety"
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// renamePropagator carries a branch rename over to the branch's remote.
+type renamePropagator interface {
+	git.RemoteNameLister
+	git.UpstreamPusher
+	git.RemoteBranchDeleter
+}
+
+// remoteUpstream is the remote branch a local branch tracks.
+type remoteUpstream struct {
+	remote string
+	branch string
+	gone   bool // deleted on the remote since the last fetch
+}
+
+func (u remoteUpstream) String() string { return u.remote + "/" + u.branch }
+
+// withRenamePropagation lets ggc branch rename push the new name, track it
+// and delete the old remote branch. The answers come from the confirmer of
+// withConfirmer.
+func (b *Brancher) withRenamePropagation(r renamePropagator) *Brancher {
+	b.renames = r
+	return b
+}
+
+// branchRename renames the named branch, or the current one when only the
+// new name is given, and lets the user pick the branch when neither is.
+// --local leaves the remote alone.
+func (b *Brancher) branchRename(args []string) {
+	args, unsafe := safety.CutForceFlag(args)
+	local := false
+	if i := slices.Index(args, "--local"); i >= 0 {
+		args, local = slices.Delete(args, i, i+1), true
+	}
+
+	var oldName, newName string
+	switch len(args) {
+	case 0:
+		var ok bool
+		if oldName, newName, ok = b.askRename(); !ok {
+			return
+		}
+	case 1:
+		current, err := b.gitClient.GetCurrentBranch()
+		if err != nil {
+			WriteError(b.outputWriter, err)
+			return
+		}
+		oldName, newName = current, strings.TrimSpace(args[0])
+	case 2:
+		oldName, newName = strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+	default:
+		WriteErrorf(b.outputWriter, "branch rename expects [<old>] <new>.")
+		return
+	}
+	if oldName == "" {
+		WriteErrorf(b.outputWriter, errMsgBranchNameEmpty)
+		return
+	}
+	if newName == "" {
+		WriteErrorf(b.outputWriter, "new branch name cannot be empty.")
+		return
+	}
+	if err := b.gitClient.ValidateBranchName(newName); err != nil {
+		WriteErrorf(b.outputWriter, "invalid branch name: %v", err)
+		return
+	}
+	if err := b.renameBranch(oldName, newName, local, unsafe); err != nil {
+		WriteError(b.outputWriter, err)
+	}
+}
+
+// askRename asks for the branch to rename and its new name. ok is false
+// when the user cancels.
+func (b *Brancher) askRename() (oldName, newName string, ok bool) {
+	branches, err := b.gitClient.ListLocalBranches()
+	if err != nil {
+		WriteError(b.outputWriter, err)
+		return "", "", false
+	}
+	if len(branches) == 0 {
+		WriteLine(b.outputWriter, "No local branches found.")
+		return "", "", false
+	}
+	if b.pick != nil {
+		current, _ := b.gitClient.GetCurrentBranch()
+		items := make([]interactive.PickItem, 0, len(branches))
+		for _, br := range branches {
+			item := interactive.PickItem{Value: br}
+			if br == current {
+				item.Detail = "current"
+			}
+			items = append(items, item)
+		}
+		oldName, ok, err = b.pick("Rename branch", items, "")
+		if err != nil {
+			WriteError(b.outputWriter, err)
+			return "", "", false
+		}
+		if !ok {
+			WriteLine(b.outputWriter, "Canceled.")
+			return "", "", false
+		}
+	} else {
+		idx, ok := b.promptSelectIndex("Local branches:", branches, "Enter the number of the branch to rename: ")
+		if !ok {
+			return "", "", false
+		}
+		oldName = branches[idx]
+	}
+	newInput, ok := ReadLine(b.prompter, b.outputWriter, fmt.Sprintf("Enter new name for %s: ", oldName))
+	if !ok {
+		return "", "", false
+	}
+	if newName = strings.TrimSpace(newInput); newName == "" {
+		WriteLine(b.outputWriter, "Canceled.")
+		return "", "", false
+	}
+	return oldName, newName, true
+}
+
+// renameBranch renames oldName to newName. When oldName tracks a remote
+// branch it first asks whether to push newName and track it instead, and
+// after that whether to delete the old remote branch, so the remote ends
+// up renamed as well.
+func (b *Brancher) renameBranch(oldName, newName string, local, unsafe bool) error {
+	if err := b.guard.check("rename", oldName, unsafe); err != nil {
+		return err
+	}
+	upstream, tracked := b.remoteUpstream(oldName)
+	push := false
+	if tracked && !local {
+		ok, err := b.confirm.Confirm(fmt.Sprintf("%s tracks %s. Push %s to %s and track it instead?", oldName, upstream, newName, upstream.remote))
+		if err != nil {
+			if errors.Is(err, ui.ErrNotInteractive) {
+				return fmt.Errorf("%w, or --local to rename only the local branch", err)
+			}
+			return err
+		}
+		push = ok
+	}
+
+	if err := b.gitClient.RenameBranch(oldName, newName); err != nil {
+		return err
+	}
+	WriteLinef(b.outputWriter, "Renamed %s to %s.", oldName, newName)
+	if !tracked {
+		return nil
+	}
+	if !push {
+		WriteLinef(b.outputWriter, "%s still tracks %s.", newName, upstream)
+		return nil
+	}
+
+	if err := b.renames.PushSetUpstream(upstream.remote, newName); err != nil {
+		return err
+	}
+	WriteLinef(b.outputWriter, "%s now tracks %s/%s.", newName, upstream.remote, newName)
+	if upstream.gone || upstream.branch == newName {
+		return nil
+	}
+	if err := b.guard.check("delete", upstream.branch, unsafe); err != nil {
+		return err
+	}
+	ok, err := b.confirm.Confirm(fmt.Sprintf("Delete %s from %s?", upstream.branch, upstream.remote))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		WriteLinef(b.outputWriter, "Kept %s.", upstream)
+		return nil
+	}
+	if err := b.renames.DeleteRemoteBranch(upstream.remote, upstream.branch); err != nil {
+		return err
+	}
+	WriteLinef(b.outputWriter, "Deleted %s.", upstream)
+	return nil
+}
+
+// remoteUpstream returns the remote branch that branch tracks. ok is false
+// when it tracks none, or a local branch, or the remote cannot be changed
+// from here.
+func (b *Brancher) remoteUpstream(branch string) (upstream remoteUpstream, ok bool) {
+	if b.renames == nil {
+		return remoteUpstream{}, false
+	}
+	bi, err := b.gitClient.GetBranchInfo(branch)
+	if err != nil || bi.Upstream == "" {
+		return remoteUpstream{}, false
+	}
+	remotes, err := b.renames.RemoteNames()
+	if err != nil {
+		return remoteUpstream{}, false
+	}
+	// Remote names may hold slashes, so take the longest that matches.
+	for _, remote := range remotes {
+		if rest, found := strings.CutPrefix(bi.Upstream, remote+"/"); found && len(remote) > len(upstream.remote) {
+			upstream = remoteUpstream{remote: remote, branch: rest, gone: bi.AheadBehind == "gone"}
+		}
+	}
+	return upstream, upstream.remote != ""
+}
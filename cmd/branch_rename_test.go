@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/safety"
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+type stubRenamePropagator struct {
+	calls []string
+}
+
+func (s *stubRenamePropagator) RemoteNames() ([]string, error) {
+	return []string{"origin", "team/fork"}, nil
+}
+
+func (s *stubRenamePropagator) PushSetUpstream(remote, branch string) error {
+	s.calls = append(s.calls, "push "+remote+" "+branch)
+	return nil
+}
+
+func (s *stubRenamePropagator) DeleteRemoteBranch(remote, branch string) error {
+	s.calls = append(s.calls, "delete "+remote+" "+branch)
+	return nil
+}
+
+func TestBrancher_Branch_Rename_Propagates(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		upstream    string
+		aheadBehind string
+		answers     string
+		wantRename  bool
+		wantCalls   []string
+		wantOut     []string
+	}{
+		{
+			name:       "push and delete",
+			args:       []string{"feature/new"},
+			upstream:   "origin/feature/old",
+			answers:    "y\ny\n",
+			wantRename: true,
+			wantCalls:  []string{"push origin feature/new", "delete origin feature/old"},
+			wantOut: []string{
+				"feature/old tracks origin/feature/old. Push feature/new to origin and track it instead? [y/N]",
+				"Renamed feature/old to feature/new.",
+				"feature/new now tracks origin/feature/new.",
+				"Delete feature/old from origin? [y/N]",
+				"Deleted origin/feature/old.",
+			},
+		},
+		{
+			name:       "push but keep the old remote branch",
+			args:       []string{"feature/new"},
+			upstream:   "team/fork/feature/old",
+			answers:    "y\nn\n",
+			wantRename: true,
+			wantCalls:  []string{"push team/fork feature/new"},
+			wantOut:    []string{"Kept team/fork/feature/old."},
+		},
+		{
+			name:       "local only when declined",
+			args:       []string{"feature/new"},
+			upstream:   "origin/feature/old",
+			answers:    "n\n",
+			wantRename: true,
+			wantOut:    []string{"feature/new still tracks origin/feature/old."},
+		},
+		{
+			name:        "upstream gone from the remote",
+			args:        []string{"feature/new"},
+			upstream:    "origin/feature/old",
+			aheadBehind: "gone",
+			answers:     "y\n",
+			wantRename:  true,
+			wantCalls:   []string{"push origin feature/new"},
+		},
+		{
+			name:       "--local skips the remote",
+			args:       []string{"feature/new", "--local"},
+			upstream:   "origin/feature/old",
+			wantRename: true,
+		},
+		{
+			name:       "no upstream",
+			args:       []string{"feature/new"},
+			wantRename: true,
+			wantOut:    []string{"Renamed feature/old to feature/new.\n"},
+		},
+		{
+			name:     "no terminal",
+			args:     []string{"feature/new"},
+			upstream: "origin/feature/old",
+			wantOut:  []string{"pass --yes to go ahead, or --local to rename only the local branch"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mockClient := &mockBranchGitClient{
+				currentBranch:      "feature/old",
+				branchInfoOverride: &git.BranchInfo{Name: "feature/old", Upstream: tt.upstream, AheadBehind: tt.aheadBehind},
+			}
+			renames := &stubRenamePropagator{}
+			brancher := &Brancher{gitClient: mockClient, outputWriter: &buf, helper: NewHelper(),
+				confirm: ui.NewConfirmer(prompt.New(strings.NewReader(tt.answers), &buf), tt.answers != "", ui.ConfirmSimple)}
+			brancher.withRenamePropagation(renames)
+
+			brancher.Branch(append([]string{"rename"}, tt.args...))
+
+			renamed := mockClient.ops != nil && len(mockClient.ops.renameBranchCalls) == 1
+			if renamed != tt.wantRename {
+				t.Fatalf("renamed = %v, want %v; output:\n%s", renamed, tt.wantRename, buf.String())
+			}
+			if renamed {
+				if call := mockClient.ops.renameBranchCalls[0]; call.old != "feature/old" || call.new != "feature/new" {
+					t.Errorf("rename call = %+v", call)
+				}
+			}
+			if !slices.Equal(renames.calls, tt.wantCalls) {
+				t.Errorf("remote calls = %v, want %v", renames.calls, tt.wantCalls)
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output lacks %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestBrancher_Branch_Rename_Protected(t *testing.T) {
+	var buf bytes.Buffer
+	mockClient := &mockBranchGitClient{currentBranch: "main"}
+	brancher := &Brancher{gitClient: mockClient, outputWriter: &buf, helper: NewHelper(),
+		guard: &branchGuard{protection: safety.NewProtection([]string{"main"})}}
+
+	brancher.Branch([]string{"rename", "trunk"})
+	if mockClient.ops != nil && len(mockClient.ops.renameBranchCalls) != 0 {
+		t.Fatalf("a protected branch was renamed")
+	}
+	if !strings.Contains(buf.String(), "refusing to rename protected branch 'main'") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	buf.Reset()
+	brancher.Branch([]string{"rename", "trunk", "--force-unsafe"})
+	if mockClient.ops == nil || len(mockClient.ops.renameBranchCalls) != 1 {
+		t.Errorf("--force-unsafe should rename the branch; output %q", buf.String())
+	}
+}
+
+func TestBrancher_Branch_Rename_Picker(t *testing.T) {
+	var buf bytes.Buffer
+	mockClient := &mockBranchGitClient{currentBranch: "main"}
+	brancher := &Brancher{gitClient: mockClient, outputWriter: &buf, helper: NewHelper(),
+		prompter: prompt.New(strings.NewReader("feature/renamed\n"), &buf)}
+	var offered []interactive.PickItem
+	brancher.withPicker(func(_ string, items []interactive.PickItem, _ string) (string, bool, error) {
+		offered = items
+		return "feature/test", true, nil
+	})
+
+	brancher.Branch([]string{"rename"})
+	if len(offered) != 2 || offered[0] != (interactive.PickItem{Value: "main", Detail: "current"}) {
+		t.Errorf("picker items = %v", offered)
+	}
+	if mockClient.ops == nil || len(mockClient.ops.renameBranchCalls) != 1 ||
+		mockClient.ops.renameBranchCalls[0] != (struct{ old, new string }{"feature/test", "feature/renamed"}) {
+		t.Errorf("rename calls = %v; output:\n%s", mockClient.ops, buf.String())
+	}
+}
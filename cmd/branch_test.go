@@ -3,11 +3,14 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
 	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 // mockBranchGitClient is a mock implementation focused on branch operations for tests
@@ -81,6 +84,7 @@ type mockBranchOperations struct {
 	renameBranchError       error
 	moveBranchError         error
 	setUpstreamError        error
+	unsetUpstreamCalls      []string
 	revParseVerifyResult    bool
 	sortBranchesCalls       []string
 	sortBranchesError       error
@@ -113,6 +117,14 @@ func (m *mockBranchGitClient) SetUpstreamBranch(branch, upstream string) error {
 	return m.ops.setUpstreamError
 }
 
+func (m *mockBranchGitClient) UnsetUpstreamBranch(branch string) error {
+	if m.ops == nil {
+		m.ops = &mockBranchOperations{}
+	}
+	m.ops.unsetUpstreamCalls = append(m.ops.unsetUpstreamCalls, branch)
+	return nil
+}
+
 func (m *mockBranchGitClient) RevParseVerify(ref string) bool {
 	if m.ops != nil {
 		return m.ops.revParseVerifyResult
@@ -205,6 +217,33 @@ func TestBrancher_Branch_Current(t *testing.T) {
 	}
 }
 
+func TestBrancher_Branch_CurrentCopy(t *testing.T) {
+	var buf bytes.Buffer
+	var copied string
+	brancher := (&Brancher{
+		gitClient:    &mockBranchGitClient{currentBranch: "feature/test"},
+		outputWriter: &buf,
+	}).withClipboard(func(text string) error {
+		copied = text
+		return nil
+	})
+	brancher.Branch([]string{"current", "--copy"})
+
+	if copied != "feature/test" {
+		t.Errorf("copied %q, want feature/test", copied)
+	}
+	if want := "feature/test\nCopied feature/test to the clipboard.\n"; buf.String() != want {
+		t.Errorf("unexpected output: got %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	brancher.copy = func(string) error { return errors.New("no clipboard available") }
+	brancher.Branch([]string{"current", "--copy"})
+	if want := "feature/test\nError: no clipboard available\n"; buf.String() != want {
+		t.Errorf("unexpected output: got %q, want %q", buf.String(), want)
+	}
+}
+
 func TestBrancher_Branch_Current_Error(t *testing.T) {
 	var buf bytes.Buffer
 	mockClient := &mockBranchGitClient{
@@ -243,6 +282,20 @@ func TestBrancher_Branch_Checkout(t *testing.T) {
 	}
 }
 
+func TestBrancher_Branch_CheckoutNoAnswer(t *testing.T) {
+	var buf bytes.Buffer
+	brancher := &Brancher{
+		gitClient:    &mockBranchGitClient{},
+		outputWriter: &buf,
+		prompter:     prompt.New(strings.NewReader(""), &buf),
+	}
+	brancher.Branch([]string{"checkout"})
+
+	if strings.Contains(buf.String(), "Error") {
+		t.Errorf("stdin ending without an answer should cancel, got %q", buf.String())
+	}
+}
+
 func TestBrancher_Branch_CheckoutRemote(t *testing.T) {
 	var buf bytes.Buffer
 	brancher := &Brancher{
@@ -857,6 +910,50 @@ func TestBrancher_branchDelete_Success(t *testing.T) {
 	}
 }
 
+func TestBrancher_branchDelete_MultiSelect(t *testing.T) {
+	tests := []struct {
+		name        string
+		picked      []string
+		ok          bool
+		wantDeleted []string
+		wantOutput  string
+	}{
+		{name: "picked", picked: []string{"bugfix/issue"}, ok: true, wantDeleted: []string{"bugfix/issue"}, wantOutput: "Selected branches deleted."},
+		{name: "canceled", wantOutput: "Canceled."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mockClient := &mockBranchGitClient{
+				listLocalBranches: func() ([]string, error) {
+					return []string{"feature/test", "bugfix/issue"}, nil
+				},
+			}
+			var offered []string
+			brancher := &Brancher{
+				gitClient:    mockClient,
+				outputWriter: &buf,
+				selectMany: func(_ string, items []string) ([]string, bool, error) {
+					offered = items
+					return tt.picked, tt.ok, nil
+				},
+			}
+
+			brancher.branchDeleteArgs(nil)
+
+			if strings.Join(offered, " ") != "feature/test bugfix/issue" {
+				t.Errorf("offered %v", offered)
+			}
+			if strings.Join(mockClient.deletedBranches, " ") != strings.Join(tt.wantDeleted, " ") {
+				t.Errorf("deleted %v, want %v", mockClient.deletedBranches, tt.wantDeleted)
+			}
+			if !strings.Contains(buf.String(), tt.wantOutput) {
+				t.Errorf("output = %q, want %q", buf.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
 func TestBrancher_branchDelete_All(t *testing.T) {
 	var buf bytes.Buffer
 	mockClient := &mockBranchGitClient{
@@ -951,7 +1048,7 @@ func TestBrancher_branchDeleteMerged_Success(t *testing.T) {
 		prompter:     prompt.New(strings.NewReader("1 2\n"), &buf),
 	}
 
-	brancher.branchDeleteMerged()
+	brancher.branchDeleteMerged(nil)
 
 	output := buf.String()
 	if !strings.Contains(output, "Selected merged branches deleted.") {
@@ -971,7 +1068,7 @@ func TestBrancher_branchDeleteMerged_All(t *testing.T) {
 		prompter:     prompt.New(strings.NewReader("all\n"), &buf),
 	}
 
-	brancher.branchDeleteMerged()
+	brancher.branchDeleteMerged(nil)
 
 	output := buf.String()
 	if !strings.Contains(output, "All merged branches deleted.") {
@@ -991,7 +1088,7 @@ func TestBrancher_branchDeleteMerged_Cancel(t *testing.T) {
 		prompter:     prompt.New(strings.NewReader("\n"), &buf),
 	}
 
-	brancher.branchDeleteMerged()
+	brancher.branchDeleteMerged(nil)
 
 	output := buf.String()
 	if !strings.Contains(output, "Canceled.") {
@@ -1009,7 +1106,7 @@ func TestBrancher_branchDeleteMerged_CurrentBranchError(t *testing.T) {
 		outputWriter: &buf,
 	}
 
-	brancher.branchDeleteMerged()
+	brancher.branchDeleteMerged(nil)
 
 	output := buf.String()
 	if !strings.Contains(output, "Error: failed to get current branch") {
@@ -1027,7 +1124,7 @@ func TestBrancher_branchDeleteMerged_NoMergedBranches(t *testing.T) {
 		outputWriter: &buf,
 	}
 
-	brancher.branchDeleteMerged()
+	brancher.branchDeleteMerged(nil)
 
 	output := buf.String()
 	if !strings.Contains(output, "No merged local branches.") {
@@ -1744,7 +1841,7 @@ func TestBrancher_selectUpstreamBranch_ErrorHandling(t *testing.T) {
 		t.Errorf("expected empty string when ListRemoteBranches fails, got %q", result)
 	}
 	output := buf.String()
-	if !strings.Contains(output, "Error listing remote branches") {
+	if !strings.Contains(output, "Error: listing remote branches") {
 		t.Errorf("expected error message in output, got: %s", output)
 	}
 	if !strings.Contains(output, "network error") {
@@ -2178,3 +2275,111 @@ func TestBrancher_BranchListRemote_Error(t *testing.T) {
 		t.Errorf("expected error output, got: %s", buf.String())
 	}
 }
+
+func TestBrancher_DeleteConfirmsByName(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   []string
+	}{
+		{"feature/test\n", []string{"feature/test"}},
+		{"y\n", nil},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		mock := &mockBranchGitClient{currentBranch: "main"}
+		b := &Brancher{
+			gitClient:    mock,
+			outputWriter: &buf,
+			helper:       NewHelper(),
+			confirm:      ui.NewConfirmer(prompt.New(strings.NewReader(tt.answer), &buf), true, ui.ConfirmSimple),
+		}
+		b.Branch([]string{"delete", "feature/test"})
+		if !reflect.DeepEqual(mock.deletedBranches, tt.want) {
+			t.Errorf("answer %q deleted %v, want %v", tt.answer, mock.deletedBranches, tt.want)
+		}
+		if !strings.Contains(buf.String(), `Type "feature/test" to confirm`) {
+			t.Errorf("expected a typed confirmation prompt, got %q", buf.String())
+		}
+	}
+}
+
+func TestBrancher_Branch_Track(t *testing.T) {
+	var buf bytes.Buffer
+	mockClient := &mockBranchGitClient{currentBranch: "main"}
+	brancher := &Brancher{gitClient: mockClient, outputWriter: &buf, helper: NewHelper()}
+
+	brancher.Branch([]string{"track", "origin/feature/test"})
+	if mockClient.ops == nil || len(mockClient.ops.setUpstreamBranchCalls) != 1 {
+		t.Fatalf("expected one upstream call, got %v", mockClient.ops)
+	}
+	if call := mockClient.ops.setUpstreamBranchCalls[0]; call.branch != "main" || call.upstream != "origin/feature/test" {
+		t.Errorf("unexpected upstream args: got %+v", call)
+	}
+	if !strings.Contains(buf.String(), "main tracks origin/main (ahead 1).") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	buf.Reset()
+	brancher.Branch([]string{"track", "origin/nope"})
+	if len(mockClient.ops.setUpstreamBranchCalls) != 1 || !strings.Contains(buf.String(), "origin/nope is not a remote branch") {
+		t.Errorf("an unknown remote branch should be refused: %q", buf.String())
+	}
+
+	buf.Reset()
+	mockClient.branchInfoOverride = &git.BranchInfo{Name: "main"}
+	brancher.Branch([]string{"track"})
+	if !strings.Contains(buf.String(), "main has no upstream. Set one with 'ggc branch track <remote>/<branch>'.") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestBrancher_Branch_Track_Picker(t *testing.T) {
+	var buf bytes.Buffer
+	mockClient := &mockBranchGitClient{
+		currentBranch: "feature/test",
+		listRemoteBranches: func() ([]string, error) {
+			return []string{"origin/main", "upstream/feature/test", "origin/feature/test"}, nil
+		},
+		branchInfoOverride: &git.BranchInfo{Name: "feature/test", Upstream: "origin/main"},
+	}
+	var offered []interactive.PickItem
+	brancher := &Brancher{gitClient: mockClient, outputWriter: &buf, helper: NewHelper()}
+	brancher.withPicker(func(_ string, items []interactive.PickItem, _ string) (string, bool, error) {
+		offered = items
+		return "origin/feature/test", true, nil
+	})
+
+	brancher.Branch([]string{"track"})
+	want := []interactive.PickItem{
+		{Value: "origin/main", Detail: "current upstream"},
+		{Value: "upstream/feature/test", Detail: "same name"},
+		{Value: "origin/feature/test", Detail: "same name"},
+	}
+	if !reflect.DeepEqual(offered, want) {
+		t.Errorf("picker items = %v, want %v", offered, want)
+	}
+	if mockClient.ops == nil || len(mockClient.ops.setUpstreamBranchCalls) != 1 || mockClient.ops.setUpstreamBranchCalls[0].upstream != "origin/feature/test" {
+		t.Errorf("upstream calls = %v", mockClient.ops)
+	}
+}
+
+func TestBrancher_Branch_Untrack(t *testing.T) {
+	var buf bytes.Buffer
+	mockClient := &mockBranchGitClient{currentBranch: "main"}
+	brancher := &Brancher{gitClient: mockClient, outputWriter: &buf, helper: NewHelper()}
+
+	brancher.Branch([]string{"untrack"})
+	if mockClient.ops == nil || !reflect.DeepEqual(mockClient.ops.unsetUpstreamCalls, []string{"main"}) {
+		t.Fatalf("unset calls = %v", mockClient.ops)
+	}
+	if !strings.Contains(buf.String(), "main no longer tracks origin/main.") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	buf.Reset()
+	mockClient.branchInfoOverride = &git.BranchInfo{Name: "feature"}
+	brancher.Branch([]string{"untrack", "feature"})
+	if len(mockClient.ops.unsetUpstreamCalls) != 1 || !strings.Contains(buf.String(), "feature has no upstream.") {
+		t.Errorf("untracking a branch without upstream: calls %v, output %q", mockClient.ops.unsetUpstreamCalls, buf.String())
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+)
+
+// branchTrack shows or sets the upstream of the current branch. Without an
+// argument it lets the user pick a remote branch when stdin is a terminal,
+// and otherwise prints the current upstream.
+func (b *Brancher) branchTrack(args []string) {
+	branch, err := b.gitClient.GetCurrentBranch()
+	if err != nil {
+		WriteError(b.outputWriter, err)
+		return
+	}
+	switch len(args) {
+	case 0:
+		if b.pick == nil {
+			b.printTracking(branch)
+			return
+		}
+		b.pickUpstream(branch)
+	case 1:
+		if err := b.track(branch, strings.TrimSpace(args[0])); err != nil {
+			WriteError(b.outputWriter, err)
+		}
+	default:
+		WriteErrorf(b.outputWriter, "branch track expects <remote>/<branch>.")
+	}
+}
+
+// track makes branch track upstream, which must be a remote-tracking
+// branch, and prints the result.
+func (b *Brancher) track(branch, upstream string) error {
+	if upstream == "" {
+		return fmt.Errorf("upstream cannot be empty")
+	}
+	remotes, err := b.getValidRemoteBranches()
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(remotes, upstream) {
+		return fmt.Errorf("%s is not a remote branch; run 'ggc fetch' if it is new, or 'ggc push current' to create it", upstream)
+	}
+	if err := b.gitClient.SetUpstreamBranch(branch, upstream); err != nil {
+		return err
+	}
+	b.printTracking(branch)
+	return nil
+}
+
+// pickUpstream lets the user choose the upstream of branch among the
+// remote branches, the one with the same name first.
+func (b *Brancher) pickUpstream(branch string) {
+	remotes, err := b.getValidRemoteBranches()
+	if err != nil {
+		WriteError(b.outputWriter, err)
+		return
+	}
+	if len(remotes) == 0 {
+		WriteLine(b.outputWriter, "No remote branches found. Push the branch with 'ggc push current' first.")
+		return
+	}
+	current := ""
+	if bi, err := b.gitClient.GetBranchInfo(branch); err == nil {
+		current = bi.Upstream
+	}
+	// List the current upstream first, then the remote branches of the
+	// same name, which are the likely choice.
+	var first, same, rest []interactive.PickItem
+	for _, r := range remotes {
+		switch {
+		case r == current:
+			first = append(first, interactive.PickItem{Value: r, Detail: "current upstream"})
+		case strings.HasSuffix(r, "/"+branch):
+			same = append(same, interactive.PickItem{Value: r, Detail: "same name"})
+		default:
+			rest = append(rest, interactive.PickItem{Value: r})
+		}
+	}
+	items := slices.Concat(first, same, rest)
+	upstream, ok, err := b.pick("Upstream for "+branch, items, "")
+	if err != nil {
+		WriteError(b.outputWriter, err)
+		return
+	}
+	if !ok || upstream == current {
+		b.printTracking(branch)
+		return
+	}
+	if err := b.track(branch, upstream); err != nil {
+		WriteError(b.outputWriter, err)
+	}
+}
+
+// branchUntrack stops the current branch, or the named one, tracking its
+// upstream.
+func (b *Brancher) branchUntrack(args []string) {
+	if len(args) > 1 {
+		WriteErrorf(b.outputWriter, "branch untrack accepts at most one branch name.")
+		return
+	}
+	var branch string
+	if len(args) == 1 {
+		branch = strings.TrimSpace(args[0])
+	} else {
+		current, err := b.gitClient.GetCurrentBranch()
+		if err != nil {
+			WriteError(b.outputWriter, err)
+			return
+		}
+		branch = current
+	}
+	if branch == "" {
+		WriteErrorf(b.outputWriter, errMsgBranchNameEmpty)
+		return
+	}
+	bi, err := b.gitClient.GetBranchInfo(branch)
+	if err != nil {
+		WriteError(b.outputWriter, err)
+		return
+	}
+	if bi.Upstream == "" {
+		WriteLinef(b.outputWriter, "%s has no upstream.", branch)
+		return
+	}
+	if err := b.gitClient.UnsetUpstreamBranch(branch); err != nil {
+		WriteError(b.outputWriter, err)
+		return
+	}
+	WriteLinef(b.outputWriter, "%s no longer tracks %s.", branch, bi.Upstream)
+}
+
+// printTracking prints the upstream of branch and how far apart they are.
+func (b *Brancher) printTracking(branch string) {
+	bi, err := b.gitClient.GetBranchInfo(branch)
+	if err != nil {
+		WriteError(b.outputWriter, err)
+		return
+	}
+	switch {
+	case bi.Upstream == "":
+		WriteLinef(b.outputWriter, "%s has no upstream. Set one with 'ggc branch track <remote>/<branch>'.", branch)
+	case bi.AheadBehind == "gone":
+		WriteLinef(b.outputWriter, "%s tracks %s, which is gone from the remote.", branch, bi.Upstream)
+	case bi.AheadBehind == "":
+		WriteLinef(b.outputWriter, "%s tracks %s (up to date).", branch, bi.Upstream)
+	default:
+		WriteLinef(b.outputWriter, "%s tracks %s (%s).", branch, bi.Upstream, bi.AheadBehind)
+	}
+}
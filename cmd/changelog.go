@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/commitmsg"
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/hosting"
+)
+
+const (
+	// changelogFile is where --write puts the changelog unless given a
+	// path.
+	changelogFile = "CHANGELOG.md"
+	// changelogUnreleased titles the changes since the last tag.
+	changelogUnreleased = "Unreleased"
+)
+
+// changelogPRRe finds a pull request reference in a subject, as GitHub
+// appends to squash merges: "Add pager (#12)".
+var changelogPRRe = regexp.MustCompile(`\(([#!])(\d+)\)`)
+
+// changelogOps are the git operations behind ggc changelog.
+type changelogOps interface {
+	git.CommitLister
+	git.NearestTagReader
+	git.RemoteURLReader
+	TagExists(name string) bool
+}
+
+// changelogOptions are the parsed flags of ggc changelog.
+type changelogOptions struct {
+	from   string
+	to     string
+	format string // markdown or json
+	write  string // file to prepend to; empty prints
+}
+
+// changelogLinks builds links to the hosting service of the default
+// remote.
+type changelogLinks struct {
+	kind hosting.Kind
+	host string
+	repo hosting.Repo
+}
+
+// release is one changelog section: the commits between two refs,
+// grouped by type.
+type release struct {
+	Title    string             `json:"release"`
+	From     string             `json:"from,omitempty"`
+	To       string             `json:"to"`
+	Date     string             `json:"date"`
+	Sections []releaseSection   `json:"sections"`
+	links    *changelogLinks    // nil leaves the entries unlinked
+	changes  []commitmsg.Change // as listed, newest first
+}
+
+type releaseSection struct {
+	Title   string         `json:"title"`
+	Changes []releaseEntry `json:"changes"`
+}
+
+type releaseEntry struct {
+	Hash        string `json:"hash"`
+	Type        string `json:"type,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	Subject     string `json:"subject"`
+	Breaking    bool   `json:"breaking,omitempty"`
+	PullRequest int    `json:"pullRequest,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// Changelogger handles ggc changelog.
+type Changelogger struct {
+	gitClient     changelogOps
+	outputWriter  io.Writer
+	helper        *Helper
+	configManager *config.Manager
+	now           func() time.Time
+}
+
+// NewChangelogger creates a new Changelogger instance.
+func NewChangelogger(client changelogOps) *Changelogger {
+	c := &Changelogger{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+		now:          time.Now,
+	}
+	c.helper.outputWriter = c.outputWriter
+	return c
+}
+
+// withConfigManager supplies git.default-remote and the hosting API URLs
+// used to link commits and pull requests.
+func (c *Changelogger) withConfigManager(cm *config.Manager) *Changelogger {
+	c.configManager = cm
+	return c
+}
+
+// Changelog executes the changelog command with the given arguments.
+func (c *Changelogger) Changelog(args []string) {
+	if len(args) > 0 && args[0] == "help" {
+		c.helper.ShowChangelogHelp()
+		return
+	}
+	opts, err := parseChangelogArgs(args)
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		c.helper.ShowChangelogHelp()
+		return
+	}
+	rel, err := c.collect(opts)
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+
+	switch {
+	case opts.format == "json":
+		encoded, err := json.MarshalIndent(rel, "", "  ")
+		if err != nil {
+			WriteError(c.outputWriter, err)
+			return
+		}
+		WriteLine(c.outputWriter, string(encoded))
+	case opts.write != "":
+		if err := prependChangelog(opts.write, rel); err != nil {
+			WriteError(c.outputWriter, err)
+			return
+		}
+		_, _ = fmt.Fprintf(c.outputWriter, "Wrote %s (%d commit(s)) to %s\n", rel.Title, len(rel.changes), opts.write)
+	default:
+		_, _ = fmt.Fprint(c.outputWriter, rel.markdown())
+	}
+}
+
+func parseChangelogArgs(args []string) (changelogOptions, error) {
+	opts := changelogOptions{to: "HEAD", format: "markdown"}
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		var target *string
+		switch name {
+		case "--from":
+			target = &opts.from
+		case "--to":
+			target = &opts.to
+		case "--format":
+			target = &opts.format
+		case "--write":
+			// The file is optional, so it only comes after '='.
+			opts.write = changelogFile
+			if hasValue {
+				opts.write = value
+			}
+			continue
+		default:
+			return opts, fmt.Errorf("unknown argument %q", args[i])
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("%s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		*target = value
+	}
+	if opts.format != "markdown" && opts.format != "json" {
+		return opts, fmt.Errorf("--format must be markdown or json")
+	}
+	if opts.format == "json" && opts.write != "" {
+		return opts, fmt.Errorf("--write only writes markdown")
+	}
+	return opts, nil
+}
+
+// collect lists the commits of the release ending at opts.to. Without
+// --from it starts at the newest tag before opts.to, or covers the whole
+// history when there is none.
+func (c *Changelogger) collect(opts changelogOptions) (*release, error) {
+	from := opts.from
+	if from == "" {
+		// The parent, so that a tagged --to starts at the tag before it.
+		// A root commit has no parent and no earlier tag either.
+		from, _ = c.gitClient.NearestTag(opts.to + "^")
+	}
+	revs := opts.to
+	if from != "" {
+		revs = from + ".." + opts.to
+	}
+	commits, err := c.gitClient.ListCommits("--no-merges", revs)
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits in %s", revs)
+	}
+
+	rel := &release{
+		Title: changelogUnreleased,
+		From:  from,
+		To:    opts.to,
+		Date:  c.now().Format("2006-01-02"),
+		links: c.links(),
+	}
+	if opts.to != "HEAD" && c.gitClient.TagExists(opts.to) {
+		rel.Title = opts.to
+	}
+	byHash := make(map[string]git.CommitSummary, len(commits))
+	for _, commit := range commits {
+		rel.changes = append(rel.changes, commitmsg.Change{Hash: commit.Short, Message: commitmsg.Parse(commit.Subject)})
+		byHash[commit.Short] = commit
+	}
+	for _, section := range commitmsg.Group(rel.changes) {
+		// Keep each scope together, unscoped changes first.
+		sort.SliceStable(section.Changes, func(i, j int) bool {
+			return section.Changes[i].Message.Scope < section.Changes[j].Message.Scope
+		})
+		s := releaseSection{Title: section.Title}
+		for _, change := range section.Changes {
+			s.Changes = append(s.Changes, rel.entry(change, byHash[change.Hash].Hash))
+		}
+		rel.Sections = append(rel.Sections, s)
+	}
+	return rel, nil
+}
+
+// links works out the hosting service of the default remote, or returns
+// nil when there is no remote or ggc does not recognize the service.
+func (c *Changelogger) links() *changelogLinks {
+	remote := "origin"
+	apiURLs := make(map[hosting.Kind]string)
+	if c.configManager != nil {
+		cfg := c.configManager.GetConfig()
+		if r := strings.TrimSpace(cfg.Git.DefaultRemote); r != "" {
+			remote = r
+		}
+		apiURLs[hosting.GitHub] = cfg.Integration.GitHub.APIURL
+		apiURLs[hosting.GitLab] = cfg.Integration.GitLab.APIURL
+		apiURLs[hosting.Gitea] = cfg.Integration.Gitea.APIURL
+	}
+	url, err := c.gitClient.RemoteGetURL(remote)
+	if err != nil {
+		return nil
+	}
+	host, repo, err := hosting.ParseRemoteURL(url)
+	if err != nil {
+		return nil
+	}
+	kind, err := hosting.Detect(host, apiURLs)
+	if err != nil {
+		return nil
+	}
+	return &changelogLinks{kind: kind, host: host, repo: repo}
+}
+
+// entry describes one change, with the pull request its subject names.
+func (r *release) entry(change commitmsg.Change, fullHash string) releaseEntry {
+	m := change.Message
+	e := releaseEntry{Hash: change.Hash, Type: m.Type, Scope: m.Scope, Subject: m.Subject, Breaking: m.Breaking}
+	if match := changelogPRRe.FindStringSubmatch(m.Subject); match != nil {
+		e.PullRequest, _ = strconv.Atoi(match[2])
+	}
+	if r.links != nil && fullHash != "" {
+		e.URL = hosting.CommitURL(r.links.kind, r.links.host, r.links.repo, fullHash)
+	}
+	return e
+}
+
+// markdown renders r as a changelog section between markers that let
+// --write find it again.
+func (r *release) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n## %s - %s\n", changelogMarker(r.Title), r.Title, r.Date)
+	for _, s := range r.Sections {
+		fmt.Fprintf(&b, "\n### %s\n\n", s.Title)
+		for _, e := range s.Changes {
+			b.WriteString("- ")
+			if e.Scope != "" {
+				fmt.Fprintf(&b, "**%s:** ", e.Scope)
+			}
+			b.WriteString(r.linkPullRequest(e.Subject))
+			if e.URL != "" {
+				fmt.Fprintf(&b, " ([%s](%s))", e.Hash, e.URL)
+			} else {
+				fmt.Fprintf(&b, " (%s)", e.Hash)
+			}
+			b.WriteByte('\n')
+		}
+	}
+	fmt.Fprintf(&b, "%s\n", changelogEndMarker(r.Title))
+	return b.String()
+}
+
+// linkPullRequest turns "(#12)" in subject into a link to pull request 12.
+func (r *release) linkPullRequest(subject string) string {
+	if r.links == nil {
+		return subject
+	}
+	return changelogPRRe.ReplaceAllStringFunc(subject, func(ref string) string {
+		match := changelogPRRe.FindStringSubmatch(ref)
+		number, _ := strconv.Atoi(match[2])
+		url := hosting.PullRequestURL(r.links.kind, r.links.host, r.links.repo, number)
+		return fmt.Sprintf("([%s%s](%s))", match[1], match[2], url)
+	})
+}
+
+func changelogMarker(title string) string    { return "<!-- ggc-changelog: " + title + " -->" }
+func changelogEndMarker(title string) string { return "<!-- /ggc-changelog: " + title + " -->" }
+
+// prependChangelog puts r at the top of the changelog in path, below its
+// "# " title, creating the file when needed. A section ggc wrote for the
+// same release before is replaced where it is, so running it again does
+// not duplicate it; the Unreleased section goes once a release is written.
+func prependChangelog(path string, r *release) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	content := string(data)
+	if r.Title != changelogUnreleased {
+		content, _ = cutChangelogSection(content, changelogUnreleased)
+	}
+	section := r.markdown()
+
+	if rest, at := cutChangelogSection(content, r.Title); at >= 0 {
+		content = rest[:at] + section + rest[at:]
+	} else {
+		switch {
+		case content == "":
+			content = "# Changelog\n\n" + section
+		case strings.HasPrefix(content, "# "):
+			title, body, _ := strings.Cut(content, "\n")
+			content = title + "\n\n" + section + "\n" + strings.TrimLeft(body, "\n")
+		default:
+			content = section + "\n" + content
+		}
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+// cutChangelogSection removes the section ggc wrote for title, and the
+// blank lines after it, returning where it started or -1 when there is
+// none.
+func cutChangelogSection(content, title string) (string, int) {
+	start := strings.Index(content, changelogMarker(title)+"\n")
+	if start < 0 {
+		return content, -1
+	}
+	endMarker := changelogEndMarker(title) + "\n"
+	end := strings.Index(content[start:], endMarker)
+	if end < 0 {
+		return content, -1
+	}
+	end += start + len(endMarker)
+	rest := strings.TrimLeft(content[end:], "\n")
+	if rest != "" {
+		rest = "\n" + rest
+	}
+	return content[:start] + rest, start
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockChangelogClient struct {
+	testutil.MockGitClient
+	url     string
+	tags    map[string]string // rev -> nearest tag
+	commits []git.CommitSummary
+	listed  string
+}
+
+func (m *mockChangelogClient) NearestTag(rev string) (string, error) { return m.tags[rev], nil }
+func (m *mockChangelogClient) TagExists(name string) bool            { return name == "v1.1.0" }
+
+func (m *mockChangelogClient) RemoteGetURL(_ string) (string, error) {
+	if m.url == "" {
+		return "", errors.New("no remote")
+	}
+	return m.url, nil
+}
+
+func (m *mockChangelogClient) ListCommits(revs ...string) ([]git.CommitSummary, error) {
+	m.listed = strings.Join(revs, " ")
+	return m.commits, nil
+}
+
+func newChangelogFixture() *mockChangelogClient {
+	return &mockChangelogClient{
+		url:  "git@github.com:acme/app.git",
+		tags: map[string]string{"HEAD^": "v1.0.0", "v1.1.0^": "v1.0.0"},
+		commits: []git.CommitSummary{
+			{Hash: "ddd444full", Short: "ddd444", Subject: "fix(ui): wrap long lines"},
+			{Hash: "ccc333full", Short: "ccc333", Subject: "feat(api)!: drop v1 (#12)"},
+			{Hash: "bbb222full", Short: "bbb222", Subject: "fix: crash on empty repo"},
+			{Hash: "aaa111full", Short: "aaa111", Subject: "feat: add pager"},
+		},
+	}
+}
+
+func newTestChangelogger(client *mockChangelogClient, buf *bytes.Buffer) *Changelogger {
+	c := NewChangelogger(client)
+	c.outputWriter = buf
+	c.helper.outputWriter = buf
+	c.now = func() time.Time { return time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC) }
+	return c
+}
+
+func TestChangelogger_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	client := newChangelogFixture()
+	newTestChangelogger(client, &buf).Changelog(nil)
+
+	want := "<!-- ggc-changelog: Unreleased -->\n" +
+		"## Unreleased - 2026-10-16\n" +
+		"\n### Breaking Changes\n\n" +
+		"- **api:** drop v1 ([#12](https://github.com/acme/app/pull/12)) ([ccc333](https://github.com/acme/app/commit/ccc333full))\n" +
+		"\n### Features\n\n" +
+		"- add pager ([aaa111](https://github.com/acme/app/commit/aaa111full))\n" +
+		"\n### Bug Fixes\n\n" +
+		"- crash on empty repo ([bbb222](https://github.com/acme/app/commit/bbb222full))\n" +
+		"- **ui:** wrap long lines ([ddd444](https://github.com/acme/app/commit/ddd444full))\n" +
+		"<!-- /ggc-changelog: Unreleased -->\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+	if client.listed != "--no-merges v1.0.0..HEAD" {
+		t.Errorf("listed %q", client.listed)
+	}
+
+	buf.Reset()
+	client.url = ""
+	newTestChangelogger(client, &buf).Changelog([]string{"--from", "v0.9.0", "--to=v1.1.0"})
+	if !strings.Contains(buf.String(), "## v1.1.0 - 2026-10-16\n") || !strings.Contains(buf.String(), "- **api:** drop v1 (#12) (ccc333)\n") {
+		t.Errorf("unlinked output =\n%s", buf.String())
+	}
+	if client.listed != "--no-merges v0.9.0..v1.1.0" {
+		t.Errorf("listed %q", client.listed)
+	}
+}
+
+func TestChangelogger_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	newTestChangelogger(newChangelogFixture(), &buf).Changelog([]string{"--format", "json"})
+
+	var got struct {
+		Release  string `json:"release"`
+		From     string `json:"from"`
+		Sections []struct {
+			Title   string         `json:"title"`
+			Changes []releaseEntry `json:"changes"`
+		} `json:"sections"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if got.Release != "Unreleased" || got.From != "v1.0.0" || len(got.Sections) != 3 {
+		t.Fatalf("unexpected changelog %+v", got)
+	}
+	want := releaseEntry{Hash: "ccc333", Type: "feat", Scope: "api", Subject: "drop v1 (#12)", Breaking: true, PullRequest: 12, URL: "https://github.com/acme/app/commit/ccc333full"}
+	if got.Sections[0].Changes[0] != want {
+		t.Errorf("entry = %+v, want %+v", got.Sections[0].Changes[0], want)
+	}
+}
+
+func TestChangelogger_Write(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	if err := os.WriteFile(path, []byte("# Changelog\n\n## v1.0.0\n\n- First release\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	client := newChangelogFixture()
+	client.url = ""
+	var buf bytes.Buffer
+	c := newTestChangelogger(client, &buf)
+
+	c.Changelog([]string{"--write=" + path})
+	c.Changelog([]string{"--write=" + path})
+	data, _ := os.ReadFile(path)
+	if n := strings.Count(string(data), "## Unreleased"); n != 1 {
+		t.Fatalf("Unreleased written %d times:\n%s", n, data)
+	}
+	if !strings.HasPrefix(string(data), "# Changelog\n\n<!-- ggc-changelog: Unreleased -->\n") ||
+		!strings.HasSuffix(string(data), "<!-- /ggc-changelog: Unreleased -->\n\n## v1.0.0\n\n- First release\n") {
+		t.Errorf("unexpected changelog:\n%s", data)
+	}
+
+	// Releasing replaces the Unreleased section.
+	c.Changelog([]string{"--to", "v1.1.0", "--write=" + path})
+	data, _ = os.ReadFile(path)
+	if strings.Contains(string(data), "Unreleased") || !strings.HasPrefix(string(data), "# Changelog\n\n<!-- ggc-changelog: v1.1.0 -->\n## v1.1.0") {
+		t.Errorf("unexpected changelog:\n%s", data)
+	}
+	if !strings.Contains(buf.String(), "Wrote v1.1.0 (4 commit(s)) to "+path) {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestChangelogger_BadArgs(t *testing.T) {
+	for _, args := range [][]string{{"--format", "yaml"}, {"--format=json", "--write"}, {"--from"}, {"extra"}} {
+		var buf bytes.Buffer
+		client := newChangelogFixture()
+		newTestChangelogger(client, &buf).Changelog(args)
+		if client.listed != "" || !strings.Contains(buf.String(), "Error:") {
+			t.Errorf("args %q: listed %q, output %q", args, client.listed, buf.String())
+		}
+	}
+}
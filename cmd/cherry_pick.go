@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+)
+
+// cherryPickRecovery follows a cherry-pick that stopped on a conflict.
+const cherryPickRecovery = "The cherry-pick stopped. Resolve the conflicts, 'ggc add' the files and run 'ggc cherry-pick continue';\n" +
+	"'ggc cherry-pick skip' drops the commit and 'ggc cherry-pick abort' puts the branch back."
+
+// cherryPickOps are the git operations behind ggc cherry-pick.
+type cherryPickOps interface {
+	git.PassthroughOps
+	git.CherryPickOps
+	git.CommitLister
+	GetCurrentBranch() (string, error)
+	ListLocalBranches() ([]string, error)
+	ListRemoteBranches() ([]string, error)
+}
+
+// CherryPicker handles ggc cherry-pick.
+type CherryPicker struct {
+	gitClient    cherryPickOps
+	outputWriter io.Writer
+	helper       *Helper
+	pick         picker        // nil: the branch has to be named
+	selectMany   multiSelector // nil: the commits are listed instead
+}
+
+// NewCherryPicker creates a new CherryPicker instance.
+func NewCherryPicker(client cherryPickOps) *CherryPicker {
+	c := &CherryPicker{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+	}
+	c.helper.outputWriter = c.outputWriter
+	return c
+}
+
+// withPicker lets ggc cherry-pick select pick the branch to take commits
+// from.
+func (c *CherryPicker) withPicker(p picker) *CherryPicker {
+	c.pick = p
+	return c
+}
+
+// withMultiSelect lets ggc cherry-pick select choose commits in the
+// full-screen multi-select picker.
+func (c *CherryPicker) withMultiSelect(sel multiSelector) *CherryPicker {
+	c.selectMany = sel
+	return c
+}
+
+// CherryPick executes the cherry-pick command with the given arguments.
+// Commits and ranges are applied in the order given; options go to git
+// unchanged.
+func (c *CherryPicker) CherryPick(args []string) {
+	if len(args) == 0 {
+		if c.selectMany == nil {
+			c.helper.ShowCherryPickHelp()
+			return
+		}
+		c.selectCommits("")
+		return
+	}
+	switch args[0] {
+	case "help":
+		c.helper.ShowCherryPickHelp()
+	case "continue", "abort", "skip":
+		if len(args) != 1 {
+			c.helper.ShowCherryPickHelp()
+			return
+		}
+		c.run("--" + args[0])
+	case "select":
+		if len(args) > 2 {
+			c.helper.ShowCherryPickHelp()
+			return
+		}
+		var branch string
+		if len(args) == 2 {
+			branch = args[1]
+		}
+		c.selectCommits(branch)
+	default:
+		if strings.HasPrefix(args[0], "-") {
+			c.run(args...)
+			return
+		}
+		c.apply(args)
+	}
+}
+
+// run hands args to git cherry-pick, as for --continue or -x.
+func (c *CherryPicker) run(args ...string) {
+	if err := c.gitClient.RunGit("cherry-pick", args); err != nil {
+		WriteError(c.outputWriter, err)
+		if args[0] != "--abort" {
+			WriteLine(c.outputWriter, cherryPickRecovery)
+		}
+	}
+}
+
+// apply cherry-picks revs, which may be ranges such as A..B.
+func (c *CherryPicker) apply(revs []string) {
+	if err := c.gitClient.CherryPick(revs...); err != nil {
+		WriteError(c.outputWriter, err)
+		WriteLine(c.outputWriter, cherryPickRecovery)
+		return
+	}
+	_, _ = fmt.Fprintf(c.outputWriter, "Cherry-picked %s\n", strings.Join(revs, " "))
+}
+
+// selectCommits lists the commits branch has that HEAD lacks, leaving out
+// ones HEAD already has a copy of, and applies the chosen ones oldest
+// first. Without a branch, one is picked first.
+func (c *CherryPicker) selectCommits(branch string) {
+	if branch == "" {
+		var ok bool
+		if branch, ok = c.pickBranch(); !ok {
+			return
+		}
+	}
+	commits, err := c.gitClient.ListCommits("--reverse", "--no-merges", "--cherry-pick", "--right-only", "HEAD..."+branch)
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	if len(commits) == 0 {
+		_, _ = fmt.Fprintf(c.outputWriter, "%s has no commits that are not on the current branch\n", branch)
+		return
+	}
+
+	labels := make([]string, len(commits))
+	for i, commit := range commits {
+		labels[i] = fmt.Sprintf("%s %s (%s, %s)", commit.Short, commit.Subject, commit.Author, commit.Date)
+	}
+	if c.selectMany == nil {
+		_, _ = fmt.Fprintf(c.outputWriter, "Commits on %s, oldest first:\n", branch)
+		for _, label := range labels {
+			WriteLine(c.outputWriter, "  "+label)
+		}
+		WriteLine(c.outputWriter, "Apply them with 'ggc cherry-pick <commit>...'.")
+		return
+	}
+	selected, ok, err := c.selectMany(fmt.Sprintf("Cherry-pick from %s", branch), labels)
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	if !ok || len(selected) == 0 {
+		WriteLine(c.outputWriter, "Canceled.")
+		return
+	}
+	// Keep the branch's order whatever order the commits were ticked in.
+	var hashes []string
+	for i, label := range labels {
+		if slices.Contains(selected, label) {
+			hashes = append(hashes, commits[i].Hash)
+		}
+	}
+	if err := c.gitClient.CherryPick(hashes...); err != nil {
+		WriteError(c.outputWriter, err)
+		WriteLine(c.outputWriter, cherryPickRecovery)
+		return
+	}
+	_, _ = fmt.Fprintf(c.outputWriter, "Cherry-picked %d commit(s) from %s\n", len(hashes), branch)
+}
+
+// pickBranch offers every branch except the current one. ok is false when
+// there is nothing to pick, no picker or the user cancels.
+func (c *CherryPicker) pickBranch() (string, bool) {
+	if c.pick == nil {
+		WriteErrorf(c.outputWriter, "name the branch to cherry-pick from: ggc cherry-pick select <branch>")
+		return "", false
+	}
+	current, err := c.gitClient.GetCurrentBranch()
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return "", false
+	}
+	locals, err := c.gitClient.ListLocalBranches()
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return "", false
+	}
+	remotes, err := c.gitClient.ListRemoteBranches()
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return "", false
+	}
+	var items []interactive.PickItem
+	for _, b := range locals {
+		if b != current {
+			items = append(items, interactive.PickItem{Value: b})
+		}
+	}
+	for _, r := range remotes {
+		if !strings.HasSuffix(r, "/HEAD") {
+			items = append(items, interactive.PickItem{Value: r, Detail: "remote"})
+		}
+	}
+	if len(items) == 0 {
+		WriteLine(c.outputWriter, "No other branches to cherry-pick from.")
+		return "", false
+	}
+	branch, ok, err := c.pick("Cherry-pick from", items, "")
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return "", false
+	}
+	if !ok {
+		WriteLine(c.outputWriter, "Canceled.")
+	}
+	return branch, ok
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockCherryPickClient struct {
+	testutil.MockGitClient
+	commits []git.CommitSummary
+	listed  string
+	fail    bool
+	calls   []string
+}
+
+func (m *mockCherryPickClient) GetCurrentBranch() (string, error) { return "main", nil }
+func (m *mockCherryPickClient) ListLocalBranches() ([]string, error) {
+	return []string{"main", "topic"}, nil
+}
+func (m *mockCherryPickClient) ListRemoteBranches() ([]string, error) {
+	return []string{"origin/HEAD", "origin/fix"}, nil
+}
+
+func (m *mockCherryPickClient) ListCommits(revs ...string) ([]git.CommitSummary, error) {
+	m.listed = strings.Join(revs, " ")
+	return m.commits, nil
+}
+
+func (m *mockCherryPickClient) CherryPick(commits ...string) error {
+	m.calls = append(m.calls, "cherry-pick "+strings.Join(commits, " "))
+	if m.fail {
+		return errors.New("conflict")
+	}
+	return nil
+}
+
+func (m *mockCherryPickClient) RunGit(name string, args []string) error {
+	m.calls = append(m.calls, name+" "+strings.Join(args, " "))
+	return nil
+}
+
+func newTestCherryPicker(client *mockCherryPickClient, buf *bytes.Buffer) *CherryPicker {
+	c := NewCherryPicker(client)
+	c.outputWriter = buf
+	c.helper.outputWriter = buf
+	return c
+}
+
+func TestCherryPicker_CherryPick(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		fail bool
+		want string
+		out  string
+	}{
+		{"single commit", []string{"abc123"}, false, "cherry-pick abc123", "Cherry-picked abc123"},
+		{"range", []string{"A..B", "c0ffee"}, false, "cherry-pick A..B c0ffee", ""},
+		{"options go to git", []string{"-x", "abc123"}, false, "cherry-pick -x abc123", ""},
+		{"continue", []string{"continue"}, false, "cherry-pick --continue", ""},
+		{"skip", []string{"skip"}, false, "cherry-pick --skip", ""},
+		{"abort", []string{"abort"}, false, "cherry-pick --abort", ""},
+		{"conflict", []string{"abc123"}, true, "cherry-pick abc123", "ggc cherry-pick continue"},
+		{"help without a terminal", nil, false, "", "ggc cherry-pick select"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			client := &mockCherryPickClient{fail: tt.fail}
+			newTestCherryPicker(client, &buf).CherryPick(tt.args)
+			if got := strings.Join(client.calls, "; "); got != tt.want {
+				t.Errorf("calls = %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(buf.String(), tt.out) {
+				t.Errorf("output %q should contain %q", buf.String(), tt.out)
+			}
+		})
+	}
+}
+
+func TestCherryPicker_Select(t *testing.T) {
+	var buf bytes.Buffer
+	client := &mockCherryPickClient{commits: []git.CommitSummary{
+		{Hash: "aaa111full", Short: "aaa111", Subject: "Add login", Author: "Alice", Date: "2 days ago"},
+		{Hash: "bbb222full", Short: "bbb222", Subject: "Fix typo", Author: "Bob", Date: "1 day ago"},
+		{Hash: "ccc333full", Short: "ccc333", Subject: "Add logout", Author: "Alice", Date: "1 hour ago"},
+	}}
+	var gotBranches []interactive.PickItem
+	var gotItems []string
+	c := newTestCherryPicker(client, &buf).
+		withPicker(func(_ string, items []interactive.PickItem, _ string) (string, bool, error) {
+			gotBranches = items
+			return "topic", true, nil
+		}).
+		withMultiSelect(func(_ string, items []string) ([]string, bool, error) {
+			gotItems = items
+			// Ticked out of order; they are still applied oldest first.
+			return []string{items[2], items[0]}, true, nil
+		})
+
+	c.CherryPick(nil)
+
+	wantBranches := []interactive.PickItem{{Value: "topic"}, {Value: "origin/fix", Detail: "remote"}}
+	if len(gotBranches) != 2 || gotBranches[0] != wantBranches[0] || gotBranches[1] != wantBranches[1] {
+		t.Errorf("branches = %+v, want %+v", gotBranches, wantBranches)
+	}
+	if want := "--reverse --no-merges --cherry-pick --right-only HEAD...topic"; client.listed != want {
+		t.Errorf("listed %q, want %q", client.listed, want)
+	}
+	if len(gotItems) != 3 || gotItems[0] != "aaa111 Add login (Alice, 2 days ago)" {
+		t.Errorf("items = %q", gotItems)
+	}
+	if got := strings.Join(client.calls, "; "); got != "cherry-pick aaa111full ccc333full" {
+		t.Errorf("calls = %q", got)
+	}
+	if !strings.Contains(buf.String(), "Cherry-picked 2 commit(s) from topic") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestCherryPicker_Select_WithoutTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	client := &mockCherryPickClient{commits: []git.CommitSummary{
+		{Hash: "aaa111full", Short: "aaa111", Subject: "Add login", Author: "Alice", Date: "2 days ago"},
+	}}
+	newTestCherryPicker(client, &buf).CherryPick([]string{"select", "topic"})
+
+	if len(client.calls) != 0 {
+		t.Errorf("calls = %v, want none", client.calls)
+	}
+	for _, want := range []string{"Commits on topic, oldest first", "  aaa111 Add login (Alice, 2 days ago)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output %q should contain %q", buf.String(), want)
+		}
+	}
+
+	buf.Reset()
+	client.commits = nil
+	newTestCherryPicker(client, &buf).CherryPick([]string{"select", "topic"})
+	if !strings.Contains(buf.String(), "topic has no commits that are not on the current branch") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
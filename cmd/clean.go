@@ -9,7 +9,10 @@ import (
 	"strings"
 
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/journal"
 	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 // Cleaner provides functionality for the clean command.
@@ -18,6 +21,10 @@ type Cleaner struct {
 	outputWriter io.Writer
 	prompter     prompt.Prompter
 	helper       *Helper
+	undo         *Undoer
+	selectFiles  cleanSelector // nil falls back to numbered prompts
+	confirm      *ui.Confirmer
+	scope        *pathScope
 }
 
 // NewCleaner creates a new Cleaner.
@@ -33,22 +40,55 @@ func NewCleaner(client git.CleanOps) *Cleaner {
 	}
 }
 
+// withUndo snapshots cleaned files so `ggc undo` can bring them back.
+func (c *Cleaner) withUndo(u *Undoer) *Cleaner {
+	c.undo = u
+	return c
+}
+
+// withCleanSelector lets `ggc clean` pick untracked and ignored files in
+// the full-screen picker with sizes and previews.
+func (c *Cleaner) withCleanSelector(sel cleanSelector) *Cleaner {
+	c.selectFiles = sel
+	return c
+}
+
+// withConfirmer makes clean ask before deleting files.
+func (c *Cleaner) withConfirmer(cf *ui.Confirmer) *Cleaner {
+	c.confirm = cf
+	return c
+}
+
+// withPathScope limits ggc clean to the directory of ggc --path.
+func (c *Cleaner) withPathScope(scope *pathScope) *Cleaner {
+	c.scope = scope
+	return c
+}
+
+// scopeSuffix names the scope in confirmation questions.
+func (c *Cleaner) scopeSuffix() string {
+	if dir := c.scope.describe(); dir != "" {
+		return " under " + dir
+	}
+	return ""
+}
+
 // Clean executes the clean command with the given arguments.
 func (c *Cleaner) Clean(args []string) {
 	if len(args) == 0 {
+		if c.selectFiles != nil {
+			c.CleanInteractive()
+			return
+		}
 		c.helper.ShowCleanHelp()
 		return
 	}
 
 	switch args[0] {
 	case "files":
-		if err := c.gitClient.CleanFiles(); err != nil {
-			WriteError(c.outputWriter, err)
-		}
+		c.cleanFiles()
 	case "dirs":
-		if err := c.gitClient.CleanDirs(); err != nil {
-			WriteError(c.outputWriter, err)
-		}
+		c.cleanDirs()
 	case "interactive":
 		c.CleanInteractive()
 	default:
@@ -56,8 +96,72 @@ func (c *Cleaner) Clean(args []string) {
 	}
 }
 
+// cleanFiles removes untracked files, snapshotting them first when undo
+// journaling is enabled.
+func (c *Cleaner) cleanFiles() {
+	if ok, err := c.confirm.Confirm("Delete every untracked file" + c.scopeSuffix() + "?"); !proceed(c.outputWriter, ok, err) {
+		return
+	}
+	var pending *journal.Entry
+	if c.undo != nil {
+		if files, err := c.getCleanableFiles(); err == nil {
+			pending = c.undo.beginClean("clean files", files)
+		}
+	}
+	if err := c.gitClient.CleanFiles(c.scope.pathspecs()...); err != nil {
+		c.undo.discard(pending)
+		WriteError(c.outputWriter, err)
+		return
+	}
+	c.undo.commit(pending)
+}
+
+// cleanDirs removes untracked and ignored files and directories,
+// snapshotting them first when undo journaling is enabled.
+func (c *Cleaner) cleanDirs() {
+	if ok, err := c.confirm.Confirm("Delete every untracked file and directory" + c.scopeSuffix() + "?"); !proceed(c.outputWriter, ok, err) {
+		return
+	}
+	var pending *journal.Entry
+	if c.undo != nil {
+		if paths, err := cleanablePaths(c.gitClient, c.scope.pathspecs()...); err == nil {
+			pending = c.undo.beginClean("clean dirs", paths)
+		}
+	}
+	if err := c.gitClient.CleanDirs(c.scope.pathspecs()...); err != nil {
+		c.undo.discard(pending)
+		WriteError(c.outputWriter, err)
+		return
+	}
+	c.undo.commit(pending)
+}
+
+// cleanLister lists what git clean would delete.
+type cleanLister interface {
+	CleanDryRun(paths ...string) (string, error)
+	CleanIgnoredDryRun(paths ...string) (string, error)
+}
+
+// cleanablePaths lists the untracked and ignored files and directories
+// that git clean -fdx deletes, under paths when any are given.
+func cleanablePaths(l cleanLister, paths ...string) ([]string, error) {
+	out, err := l.CleanDryRun(paths...)
+	if err != nil {
+		return nil, err
+	}
+	ignored, err := l.CleanIgnoredDryRun(paths...)
+	if err != nil {
+		return nil, err
+	}
+	return append(parseCleanDryRun(out), parseCleanDryRun(ignored)...), nil
+}
+
 // CleanInteractive interactively selects files to clean.
 func (c *Cleaner) CleanInteractive() {
+	if c.selectFiles != nil {
+		c.cleanSelectedFiles()
+		return
+	}
 	files, err := c.getCleanableFiles()
 	if err != nil {
 		WriteError(c.outputWriter, err)
@@ -67,25 +171,79 @@ func (c *Cleaner) CleanInteractive() {
 		WriteLine(c.outputWriter, "No files to clean.")
 		return
 	}
-
 	c.runInteractiveCleanLoop(files)
 }
 
+// cleanSelectedFiles offers the untracked and ignored files in the picker,
+// then lists the chosen ones with their sizes and asks before deleting
+// them.
+func (c *Cleaner) cleanSelectedFiles() {
+	untracked, err := c.getCleanableFiles()
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	out, err := c.gitClient.CleanIgnoredDryRun(c.scope.pathspecs()...)
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	ignored := parseCleanDryRun(out)
+	if len(untracked)+len(ignored) == 0 {
+		WriteLine(c.outputWriter, "No files to clean.")
+		return
+	}
+
+	selected, ok, err := c.selectFiles(interactive.StatCleanFiles(untracked, ignored))
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	if !ok {
+		WriteLine(c.outputWriter, "Canceled.")
+		return
+	}
+	var total int64
+	paths := make([]string, len(selected))
+	for i, f := range selected {
+		paths[i] = f.Path
+		total += f.Size
+		WriteLinef(c.outputWriter, "  %10s  %s", ui.FormatBytes(f.Size), f.Path)
+	}
+	question := fmt.Sprintf("Delete %d path(s), %s in total?", len(paths), ui.FormatBytes(total))
+	if ok, err := c.confirm.Confirm(question); !proceed(c.outputWriter, ok, err) {
+		return
+	}
+
+	pending := c.undo.beginClean("clean interactive", paths)
+	if err := c.gitClient.CleanPathsForce(paths); err != nil {
+		c.undo.discard(pending)
+		WriteError(c.outputWriter, err)
+		return
+	}
+	c.undo.commit(pending)
+	WriteLinef(c.outputWriter, "Deleted %d path(s), freeing %s.", len(paths), ui.FormatBytes(total))
+}
+
 // getCleanableFiles retrieves the list of files that can be cleaned
 func (c *Cleaner) getCleanableFiles() ([]string, error) {
-	out, err := c.gitClient.CleanDryRun()
+	out, err := c.gitClient.CleanDryRun(c.scope.pathspecs()...)
 	if err != nil {
 		return nil, err
 	}
+	return parseCleanDryRun(out), nil
+}
 
-	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+// parseCleanDryRun returns the paths in the output of git clean -n.
+func parseCleanDryRun(out string) []string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
 	files := []string{}
 	for _, line := range lines {
 		if strings.HasPrefix(line, "Would remove ") {
 			files = append(files, strings.TrimPrefix(line, "Would remove "))
 		}
 	}
-	return files, nil
+	return files
 }
 
 // runInteractiveCleanLoop runs the interactive selection loop
@@ -113,9 +271,10 @@ func (c *Cleaner) runInteractiveCleanLoop(files []string) {
 
 // displayFileSelection shows the file selection interface
 func (c *Cleaner) displayFileSelection(files []string) {
-	WriteLine(c.outputWriter, "\033[1;36mSelect files to delete by number (space separated, all: select all, none: deselect all, e.g. 1 3 5):\033[0m")
+	colors := ui.ColorsFor(c.outputWriter)
+	WriteLine(c.outputWriter, colors.Bold+colors.Cyan+"Select files to delete by number (space separated, all: select all, none: deselect all, e.g. 1 3 5):"+colors.Reset)
 	for i, f := range files {
-		WriteLinef(c.outputWriter, "  [\033[1;33m%d\033[0m] %s", i+1, f)
+		WriteLinef(c.outputWriter, "  [%s%d%s] %s", colors.Bold+colors.Yellow, i+1, colors.Reset, f)
 	}
 	_, _ = fmt.Fprint(c.outputWriter, "> ")
 }
@@ -139,7 +298,8 @@ func (c *Cleaner) handleFileSelection(input string, files []string) bool {
 		return false // Continue loop
 	}
 	if len(selectedFiles) == 0 {
-		WriteLine(c.outputWriter, "\033[1;33mNothing selected.\033[0m")
+		colors := ui.ColorsFor(c.outputWriter)
+		WriteLine(c.outputWriter, colors.Bold+colors.Yellow+"Nothing selected."+colors.Reset)
 		return false // Continue loop
 	}
 
@@ -154,7 +314,8 @@ func (c *Cleaner) parseFileIndices(input string, files []string) ([]string, bool
 	for _, idx := range indices {
 		n, err := strconv.Atoi(idx)
 		if err != nil || n < 1 || n > len(files) {
-			WriteLinef(c.outputWriter, "\033[1;31mInvalid number: %s\033[0m", idx)
+			colors := ui.ColorsFor(c.outputWriter)
+			WriteLinef(c.outputWriter, "%sInvalid number: %s%s", colors.Bold+colors.Red, idx, colors.Reset)
 			return nil, false
 		}
 		selectedFiles = append(selectedFiles, files[n-1])
@@ -164,21 +325,25 @@ func (c *Cleaner) parseFileIndices(input string, files []string) ([]string, bool
 
 // confirmAndDelete confirms deletion and executes it
 func (c *Cleaner) confirmAndDelete(selectedFiles []string) bool {
-	WriteLinef(c.outputWriter, "\033[1;32mSelected files: %v\033[0m", selectedFiles)
+	colors := ui.ColorsFor(c.outputWriter)
+	WriteLinef(c.outputWriter, "%sSelected files: %v%s", colors.Bold+colors.Green, selectedFiles, colors.Reset)
 	for {
 		confirm, canceled, err := c.prompter.Confirm("Delete these files? (y/n): ")
 		if canceled {
 			return true
 		}
 		if err != nil {
-			WriteLine(c.outputWriter, "\033[1;31mInvalid choice.\033[0m")
+			WriteLine(c.outputWriter, colors.Bold+colors.Red+"Invalid choice."+colors.Reset)
 			continue
 		}
 		if confirm {
+			pending := c.undo.beginClean("clean interactive", selectedFiles)
 			if err := c.gitClient.CleanFilesForce(selectedFiles); err != nil {
+				c.undo.discard(pending)
 				WriteError(c.outputWriter, err)
 				return true
 			}
+			c.undo.commit(pending)
 			WriteLine(c.outputWriter, "Selected files deleted.")
 			return true
 		}
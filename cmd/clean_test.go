@@ -6,7 +6,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/bmf-san/ggc/v8/internal/interactive"
 	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 // mockGitClient for clean_test
@@ -17,30 +19,44 @@ type mockCleanGitClient struct {
 	cleanDirsCalled   bool
 	cleanDryRunResult string
 	cleanDryRunErr    error
+	ignoredResult     string
+	cleanedPaths      []string
+	scopePaths        []string
 }
 
-func (m *mockCleanGitClient) CleanFiles() error {
+func (m *mockCleanGitClient) CleanFiles(paths ...string) error {
 	m.cleanFilesCalled = true
+	m.scopePaths = paths
 	return m.cleanFilesErr
 }
 
-func (m *mockCleanGitClient) CleanDirs() error {
+func (m *mockCleanGitClient) CleanDirs(paths ...string) error {
 	m.cleanDirsCalled = true
+	m.scopePaths = paths
 	return m.cleanDirsErr
 }
 
-func (m *mockCleanGitClient) CleanDryRun() (string, error) {
+func (m *mockCleanGitClient) CleanDryRun(...string) (string, error) {
 	return m.cleanDryRunResult, m.cleanDryRunErr
 }
 
+func (m *mockCleanGitClient) CleanIgnoredDryRun(...string) (string, error) {
+	return m.ignoredResult, nil
+}
+
 func (m *mockCleanGitClient) CleanFilesForce(_ []string) error {
 	return nil
 }
 
+func (m *mockCleanGitClient) CleanPathsForce(paths []string) error {
+	m.cleanedPaths = paths
+	return nil
+}
+
 // mockCleanGitClient intentionally implements only the methods exercised by Cleaner:
 // - CleanFiles, CleanDirs: used by non-interactive cleaning subcommands
-// - CleanDryRun: used to list candidates in interactive mode
-// - CleanFilesForce: used to delete selected files after confirmation
+// - CleanDryRun, CleanIgnoredDryRun: used to list candidates in interactive mode
+// - CleanFilesForce, CleanPathsForce: used to delete selected files after confirmation
 
 func TestCleaner_Clean(t *testing.T) {
 	tests := []struct {
@@ -222,6 +238,93 @@ func TestCleaner_CleanInteractive_Error(t *testing.T) {
 	}
 }
 
+func TestCleaner_CleanInteractive_MultiSelect(t *testing.T) {
+	var buf bytes.Buffer
+	var offered []string
+	mock := &mockCleanGitClient{cleanDryRunResult: "Would remove file1.txt\nWould remove file2.txt\n"}
+	cleaner := &Cleaner{
+		gitClient:    mock,
+		outputWriter: &buf,
+		helper:       NewHelper(),
+		confirm:      ui.NewConfirmer(prompt.New(strings.NewReader("y\n"), &buf), true, ui.ConfirmSimple),
+		selectFiles: func(files []interactive.CleanFile) ([]interactive.CleanFile, bool, error) {
+			for _, f := range files {
+				offered = append(offered, f.Path)
+			}
+			return files[1:], true, nil
+		},
+	}
+
+	cleaner.CleanInteractive()
+
+	if strings.Join(offered, " ") != "file1.txt file2.txt" {
+		t.Errorf("offered %v", offered)
+	}
+	if strings.Join(mock.cleanedPaths, " ") != "file2.txt" {
+		t.Errorf("cleaned %v, want only the selected file", mock.cleanedPaths)
+	}
+	if !strings.Contains(buf.String(), "Delete 1 path(s)") || !strings.Contains(buf.String(), "Deleted 1 path(s)") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestCleaner_CleanInteractive_Selector(t *testing.T) {
+	var buf bytes.Buffer
+	var offered []interactive.CleanFile
+	mock := &mockCleanGitClient{
+		cleanDryRunResult: "Would remove file1.txt\nWould remove file2.txt\n",
+		ignoredResult:     "Would remove build/\n",
+	}
+	cleaner := &Cleaner{
+		gitClient:    mock,
+		outputWriter: &buf,
+		helper:       NewHelper(),
+		selectFiles: func(files []interactive.CleanFile) ([]interactive.CleanFile, bool, error) {
+			offered = files
+			return []interactive.CleanFile{{Path: "file2.txt", Size: 512}, {Path: "build/", Ignored: true, Size: 1536}}, true, nil
+		},
+	}
+
+	cleaner.Clean(nil)
+
+	var paths []string
+	for _, f := range offered {
+		paths = append(paths, f.Path)
+	}
+	if strings.Join(paths, " ") != "file1.txt file2.txt build/" || !offered[2].Ignored {
+		t.Errorf("offered %v", offered)
+	}
+	if strings.Join(mock.cleanedPaths, " ") != "file2.txt build/" {
+		t.Errorf("cleaned %v", mock.cleanedPaths)
+	}
+	if !strings.Contains(buf.String(), "512 B  file2.txt") || !strings.Contains(buf.String(), "Deleted 2 path(s), freeing 2.0 KiB.") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestCleaner_CleanInteractive_SelectorDeclined(t *testing.T) {
+	var buf bytes.Buffer
+	mock := &mockCleanGitClient{cleanDryRunResult: "Would remove file1.txt\n"}
+	cleaner := &Cleaner{
+		gitClient:    mock,
+		outputWriter: &buf,
+		helper:       NewHelper(),
+		confirm:      ui.NewConfirmer(prompt.New(strings.NewReader("n\n"), &buf), true, ui.ConfirmSimple),
+		selectFiles: func(files []interactive.CleanFile) ([]interactive.CleanFile, bool, error) {
+			return files, true, nil
+		},
+	}
+
+	cleaner.CleanInteractive()
+
+	if mock.cleanedPaths != nil {
+		t.Errorf("cleaned %v after the prompt was declined", mock.cleanedPaths)
+	}
+	if !strings.Contains(buf.String(), "Delete 1 path(s), 0 B in total? [y/N]") {
+		t.Errorf("expected the summary prompt, got %q", buf.String())
+	}
+}
+
 func TestCleaner_CleanInteractive_Cancel(t *testing.T) {
 	var buf bytes.Buffer
 	inputBuf := strings.NewReader("\n")
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// errNoCopy is reported for --copy when the command was built without a
+// clipboard.
+var errNoCopy = errors.New("--copy is not supported here")
+
+// headResolver resolves HEAD so ggc commit --copy can copy the hash of the
+// commit it made.
+type headResolver interface {
+	RevParse(ref string) (string, error)
+}
+
+// systemClipboard returns a function copying text to the system clipboard,
+// falling back to OSC 52 when stdout is a terminal.
+func systemClipboard() func(text string) error {
+	var tty io.Writer
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		tty = os.Stdout
+	}
+	return ui.NewClipboard(tty).Copy
+}
+
+// cutCopyFlag removes --copy from args and reports whether it was there.
+func cutCopyFlag(args []string) (rest []string, copy bool) {
+	rest = make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "--copy" {
+			copy = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, copy
+}
+
+// copyResult copies text with copy and says so, or why it could not.
+func copyResult(w io.Writer, copy func(text string) error, text string) {
+	if copy == nil {
+		WriteError(w, errNoCopy)
+		return
+	}
+	if err := copy(text); err != nil {
+		WriteError(w, err)
+		return
+	}
+	WriteLinef(w, "Copied %s to the clipboard.", text)
+}
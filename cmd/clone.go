@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+)
+
+// defaultCloneHost is where an "owner/repo" shorthand points when
+// clone.default-host is not set.
+const defaultCloneHost = "github.com"
+
+// Cloner provides the clone command. Besides what git clone does, it
+// expands "owner/repo" shorthands and sets up the new clone from the
+// clone section of the config.
+type Cloner struct {
+	gitClient     git.CloneOps
+	outputWriter  io.Writer
+	helper        *Helper
+	prompter      prompt.Prompter
+	configManager *config.Manager
+	execCommand   func(string, ...string) *exec.Cmd
+	// interactive lets `ggc clone` without arguments ask for the
+	// repository, directory and depth.
+	interactive bool
+}
+
+// NewCloner creates a new Cloner.
+func NewCloner(client git.CloneOps) *Cloner {
+	output := os.Stdout
+	helper := NewHelper()
+	helper.outputWriter = output
+	return &Cloner{
+		gitClient:    client,
+		outputWriter: output,
+		helper:       helper,
+		prompter:     prompt.New(os.Stdin, output),
+		execCommand:  exec.Command,
+		interactive:  term.IsTerminal(int(os.Stdin.Fd())),
+	}
+}
+
+// withConfigManager supplies the shorthand host and protocol and the
+// post-clone setup.
+func (c *Cloner) withConfigManager(cm *config.Manager) *Cloner {
+	c.configManager = cm
+	return c
+}
+
+// cloneArgs are the parsed arguments of `ggc clone`.
+type cloneArgs struct {
+	repo string
+	dir  string
+	opts git.CloneOptions
+}
+
+func parseCloneArgs(args []string) (cloneArgs, error) {
+	var ca cloneArgs
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--recurse-submodules":
+			ca.opts.RecurseSubmodules = true
+			continue
+		case "--sparse":
+			ca.opts.Sparse = true
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(arg, "=")
+		if name != "--depth" && name != "--branch" && name != "-b" && name != "--filter" {
+			return ca, fmt.Errorf("unknown argument %q", arg)
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return ca, fmt.Errorf("%s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		switch name {
+		case "--depth":
+			depth, err := parseCloneDepth(value)
+			if err != nil {
+				return ca, err
+			}
+			ca.opts.Depth = depth
+		case "--filter":
+			if value == "" {
+				return ca, fmt.Errorf("--filter requires a value such as blob:none")
+			}
+			ca.opts.Filter = value
+		default:
+			ca.opts.Branch = value
+		}
+	}
+
+	switch len(positional) {
+	case 0:
+		return ca, fmt.Errorf("repository required")
+	case 1, 2:
+		ca.repo = positional[0]
+		if len(positional) == 2 {
+			ca.dir = positional[1]
+		}
+	default:
+		return ca, fmt.Errorf("unexpected argument %q", positional[2])
+	}
+	return ca, nil
+}
+
+func parseCloneDepth(value string) (int, error) {
+	depth, err := strconv.Atoi(value)
+	if err != nil || depth <= 0 {
+		return 0, fmt.Errorf("--depth must be a positive number, got %q", value)
+	}
+	return depth, nil
+}
+
+// Clone executes the clone command with the given arguments.
+func (c *Cloner) Clone(args []string) {
+	if len(args) == 0 && !c.interactive {
+		c.helper.ShowCloneHelp()
+		return
+	}
+	if err := c.clone(args); err != nil {
+		WriteError(c.outputWriter, err)
+	}
+}
+
+func (c *Cloner) clone(args []string) error {
+	var ca cloneArgs
+	if len(args) == 0 {
+		var ok bool
+		if ca, ok = c.ask(); !ok {
+			return nil
+		}
+	} else {
+		var err error
+		if ca, err = parseCloneArgs(args); err != nil {
+			return err
+		}
+	}
+
+	url := c.expandURL(ca.repo)
+	if ca.dir == "" {
+		ca.dir = cloneDirName(url)
+	}
+	if err := c.gitClient.Clone(url, ca.dir, ca.opts); err != nil {
+		return err
+	}
+	if err := c.setUp(ca.dir); err != nil {
+		return fmt.Errorf("cloned into %s, but setting it up failed: %w", ca.dir, err)
+	}
+	WriteLinef(c.outputWriter, "Cloned %s into %s", url, ca.dir)
+	return nil
+}
+
+// ask prompts for the repository, the directory and the depth. ok is
+// false when the user cancels or leaves the repository empty.
+func (c *Cloner) ask() (ca cloneArgs, ok bool) {
+	repo, ok := ReadLine(c.prompter, c.outputWriter, "Repository (URL or owner/repo): ")
+	if ca.repo = strings.TrimSpace(repo); !ok || ca.repo == "" {
+		return ca, false
+	}
+	defaultDir := cloneDirName(c.expandURL(ca.repo))
+	dir, ok := ReadLine(c.prompter, c.outputWriter, fmt.Sprintf("Directory [%s]: ", defaultDir))
+	if !ok {
+		return ca, false
+	}
+	ca.dir = strings.TrimSpace(dir)
+	for {
+		depth, ok := ReadLine(c.prompter, c.outputWriter, "Depth (empty for full history): ")
+		if !ok {
+			return ca, false
+		}
+		if depth = strings.TrimSpace(depth); depth == "" {
+			return ca, true
+		}
+		d, err := parseCloneDepth(depth)
+		if err == nil {
+			ca.opts.Depth = d
+			return ca, true
+		}
+		WriteError(c.outputWriter, err)
+	}
+}
+
+func (c *Cloner) cloneConfig() *config.Config {
+	if c.configManager == nil {
+		return &config.Config{}
+	}
+	return c.configManager.GetConfig()
+}
+
+// expandURL turns "owner/repo" into a URL on the configured host, and
+// "host/owner/repo" into one on that host. URLs, scp-style addresses and
+// local paths come back unchanged.
+func (c *Cloner) expandURL(repo string) string {
+	if strings.Contains(repo, "://") || strings.Contains(repo, ":") ||
+		strings.HasPrefix(repo, ".") || strings.HasPrefix(repo, "/") || strings.HasPrefix(repo, "~") {
+		return repo
+	}
+	if _, err := os.Stat(repo); err == nil {
+		return repo
+	}
+	parts := strings.Split(strings.Trim(repo, "/"), "/")
+	if len(parts) < 2 {
+		return repo
+	}
+	cfg := c.cloneConfig()
+	host := cfg.Clone.DefaultHost
+	if strings.Contains(parts[0], ".") && len(parts) > 2 {
+		host, parts = parts[0], parts[1:]
+	} else if len(parts) > 2 {
+		// Nested paths such as GitLab subgroups need the host spelled out.
+		return repo
+	}
+	if host == "" {
+		host = defaultCloneHost
+	}
+	repoPath := strings.TrimSuffix(strings.Join(parts, "/"), ".git") + ".git"
+	if cfg.Clone.Protocol == "ssh" {
+		return "git@" + host + ":" + repoPath
+	}
+	return "https://" + host + "/" + repoPath
+}
+
+// cloneDirName is the directory git clone would pick for url.
+func cloneDirName(url string) string {
+	url = strings.TrimRight(url, "/")
+	if i := strings.LastIndex(url, ":"); i > strings.LastIndex(url, "/") {
+		url = url[i+1:]
+	}
+	name := strings.TrimSuffix(path.Base(strings.ReplaceAll(url, "\\", "/")), ".git")
+	if name == "" || name == "." || name == "/" {
+		return "repository"
+	}
+	return name
+}
+
+// setUp applies the configured identity and profile to the clone in dir
+// and runs the post-clone commands there, stopping at the first failure.
+func (c *Cloner) setUp(dir string) error {
+	cfg := c.cloneConfig()
+	identity := []struct{ key, value string }{
+		{"user.name", cfg.Clone.UserName},
+		{"user.email", cfg.Clone.UserEmail},
+	}
+	for _, id := range identity {
+		if id.value == "" {
+			continue
+		}
+		if err := c.gitClient.ConfigSetIn(dir, id.key, id.value); err != nil {
+			return err
+		}
+	}
+	if name, ok := cloneProfile(cfg, dir); ok {
+		set := func(key, value string) error { return c.gitClient.ConfigSetIn(dir, key, value) }
+		if err := applyProfile(set, cfg.Profiles[name]); err != nil {
+			return err
+		}
+		// A path-matched profile follows the clone's location; pin only
+		// an explicit choice.
+		if cfg.Clone.Profile != "" {
+			if err := set(config.PinnedProfileKey, name); err != nil {
+				return err
+			}
+		}
+		WriteLinef(c.outputWriter, "Applied profile %s", name)
+	}
+	for _, command := range cfg.Clone.PostClone {
+		WriteLinef(c.outputWriter, "Running %s", command)
+		if err := c.runShell(dir, command); err != nil {
+			return fmt.Errorf("post-clone command %q: %w", command, err)
+		}
+	}
+	return nil
+}
+
+// cloneProfile picks the profile for a new clone in dir: clone.profile,
+// or else the profile whose paths match the clone's location.
+func cloneProfile(cfg *config.Config, dir string) (string, bool) {
+	if cfg.Clone.Profile != "" {
+		_, ok := cfg.Profiles[cfg.Clone.Profile]
+		return cfg.Clone.Profile, ok
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	return cfg.ExpectedProfile("", abs)
+}
+
+func (c *Cloner) runShell(dir, command string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = c.execCommand("cmd", "/C", command)
+	} else {
+		cmd = c.execCommand("sh", "-c", command)
+	}
+	cmd.Dir = dir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = c.outputWriter
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
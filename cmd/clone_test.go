@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockCloneGitClient struct {
+	testutil.MockGitClient
+	url, dir string
+	opts     git.CloneOptions
+	set      []string
+}
+
+func (m *mockCloneGitClient) Clone(url, dir string, opts git.CloneOptions) error {
+	m.url, m.dir, m.opts = url, dir, opts
+	return nil
+}
+
+func (m *mockCloneGitClient) ConfigSetIn(dir, key, value string) error {
+	m.set = append(m.set, dir+" "+key+"="+value)
+	return nil
+}
+
+func newTestCloner(m *mockCloneGitClient, input string) (*Cloner, *bytes.Buffer, *config.Config) {
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	var buf bytes.Buffer
+	c := NewCloner(m).withConfigManager(cm)
+	c.outputWriter = &buf
+	c.helper.outputWriter = &buf
+	c.prompter = prompt.New(strings.NewReader(input), &buf)
+	c.interactive = false
+	return c, &buf, cm.GetConfig()
+}
+
+func TestParseCloneArgs(t *testing.T) {
+	got, err := parseCloneArgs([]string{"o/r", "work", "--depth=1", "-b", "dev", "--recurse-submodules"})
+	want := cloneArgs{repo: "o/r", dir: "work", opts: git.CloneOptions{Depth: 1, Branch: "dev", RecurseSubmodules: true}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCloneArgs() = %+v, %v; want %+v", got, err, want)
+	}
+
+	got, err = parseCloneArgs([]string{"o/r", "--filter=blob:none", "--sparse"})
+	want = cloneArgs{repo: "o/r", opts: git.CloneOptions{Filter: "blob:none", Sparse: true}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCloneArgs() = %+v, %v; want %+v", got, err, want)
+	}
+
+	for _, args := range [][]string{
+		{"--depth", "1"},
+		{"o/r", "--depth", "0"},
+		{"o/r", "--branch"},
+		{"o/r", "--bare"},
+		{"o/r", "--filter="},
+		{"o/r", "a", "b"},
+	} {
+		if _, err := parseCloneArgs(args); err == nil {
+			t.Errorf("parseCloneArgs(%q) should fail", args)
+		}
+	}
+}
+
+func TestCloner_ExpandURL(t *testing.T) {
+	c, _, cfg := newTestCloner(&mockCloneGitClient{}, "")
+	tests := []struct {
+		protocol, host, repo, want string
+	}{
+		{"", "", "bmf-san/ggc", "https://github.com/bmf-san/ggc.git"},
+		{"ssh", "", "bmf-san/ggc.git", "git@github.com:bmf-san/ggc.git"},
+		{"", "gitlab.example.com", "team/app", "https://gitlab.example.com/team/app.git"},
+		{"ssh", "", "gitlab.com/group/sub/project", "git@gitlab.com:group/sub/project.git"},
+		{"", "", "group/sub/project", "group/sub/project"},
+		{"", "", "https://example.com/o/r", "https://example.com/o/r"},
+		{"", "", "git@example.com:o/r.git", "git@example.com:o/r.git"},
+		{"", "", "../local/repo", "../local/repo"},
+		{"", "", "repo", "repo"},
+	}
+	for _, tt := range tests {
+		cfg.Clone.Protocol, cfg.Clone.DefaultHost = tt.protocol, tt.host
+		if got := c.expandURL(tt.repo); got != tt.want {
+			t.Errorf("expandURL(%q) with %q/%q = %q, want %q", tt.repo, tt.protocol, tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestCloneDirName(t *testing.T) {
+	for url, want := range map[string]string{
+		"https://github.com/bmf-san/ggc.git": "ggc",
+		"git@github.com:bmf-san/ggc.git":     "ggc",
+		"git@github.com:ggc.git":             "ggc",
+		"/srv/repos/app/":                    "app",
+	} {
+		if got := cloneDirName(url); got != want {
+			t.Errorf("cloneDirName(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+// inCloneDir works in a temporary directory holding an empty dir, where
+// the fake clone would have landed.
+func inCloneDir(t *testing.T, dir string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCloner_Clone(t *testing.T) {
+	inCloneDir(t, "ggc")
+	m := &mockCloneGitClient{}
+	c, buf, cfg := newTestCloner(m, "")
+	cfg.Clone.UserName = "Jane"
+	cfg.Clone.UserEmail = "jane@example.com"
+	cfg.Clone.PostClone = []string{"make setup"}
+	var ran []string
+	c.execCommand = func(name string, args ...string) *exec.Cmd {
+		ran = append(ran, name+" "+strings.Join(args, " "))
+		return exec.Command("true")
+	}
+
+	c.Clone([]string{"bmf-san/ggc", "--depth", "1"})
+
+	if m.url != "https://github.com/bmf-san/ggc.git" || m.dir != "ggc" || m.opts.Depth != 1 {
+		t.Errorf("Clone(%q, %q, %+v)", m.url, m.dir, m.opts)
+	}
+	if want := []string{"ggc user.name=Jane", "ggc user.email=jane@example.com"}; !reflect.DeepEqual(m.set, want) {
+		t.Errorf("config set = %v, want %v", m.set, want)
+	}
+	if len(ran) != 1 || !strings.HasSuffix(ran[0], "make setup") {
+		t.Errorf("post-clone ran %v", ran)
+	}
+	if !strings.Contains(buf.String(), "Cloned https://github.com/bmf-san/ggc.git into ggc") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestCloner_PostCloneFailure(t *testing.T) {
+	inCloneDir(t, "r")
+	c, buf, cfg := newTestCloner(&mockCloneGitClient{}, "")
+	cfg.Clone.PostClone = []string{"false", "echo never"}
+	calls := 0
+	c.execCommand = func(string, ...string) *exec.Cmd {
+		calls++
+		return exec.Command("false")
+	}
+
+	c.Clone([]string{"o/r"})
+
+	if calls != 1 {
+		t.Errorf("ran %d post-clone commands, want 1", calls)
+	}
+	if out := buf.String(); !strings.Contains(out, `post-clone command "false"`) || strings.Contains(out, "Cloned ") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestCloner_Interactive(t *testing.T) {
+	m := &mockCloneGitClient{}
+	c, buf, _ := newTestCloner(m, "bmf-san/ggc\n\nx\n5\n")
+	c.interactive = true
+
+	c.Clone(nil)
+
+	if m.url != "https://github.com/bmf-san/ggc.git" || m.dir != "ggc" || m.opts.Depth != 5 {
+		t.Errorf("Clone(%q, %q, %+v)", m.url, m.dir, m.opts)
+	}
+	if !strings.Contains(buf.String(), "Directory [ggc]") || !strings.Contains(buf.String(), "--depth must be a positive number") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestCloner_NoArgsShowsHelp(t *testing.T) {
+	m := &mockCloneGitClient{}
+	c, buf, _ := newTestCloner(m, "")
+
+	c.Clone(nil)
+
+	if m.url != "" || !strings.Contains(buf.String(), "ggc clone") {
+		t.Errorf("expected help, got %q (cloned %q)", buf.String(), m.url)
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"sort"
 	"strings"
+	"time"
 
 	commandregistry "github.com/bmf-san/ggc/v8/cmd/command"
 	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
 	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/stats"
 )
 
 // Interactive mode command constants.
@@ -27,33 +30,64 @@ type Cmd struct {
 	configManager *config.Manager
 	gitClient     git.StatusInfoReader
 	outputWriter  io.Writer
-	helper        *Helper
-	brancher      *Brancher
-	committer     *Committer
-	logger        *Logger
-	puller        *Puller
-	pusher        *Pusher
-	resetter      *Resetter
-	cleaner       *Cleaner
-	adder         *Adder
-	remoter       *Remoter
-	rebaser       *Rebaser
-	bisector      *Bisector
-	stasher       *Stasher
-	configurer    *Configurer
-	hooker        *Hooker
-	tagger        *Tagger
-	statuser      *Statuser
-	versioner     *Versioner
-	differ        *Differ
-	restorer      *Restorer
-	fetcher       *Fetcher
-	shower        *Shower
-	passthroughs  map[string]*passthroughCommand
-	cmdRouter     *commandRouter
-	debugger      *Debugger
-	doctor        *Doctor
-	completer     *Completer
+	// stdin is where batch mode reads commands from, and stdinIsTerminal
+	// tells whether ggc without arguments starts interactive mode or
+	// batch mode.
+	stdin           io.Reader
+	stdinIsTerminal func() bool
+	helper          *Helper
+	brancher        *Brancher
+	committer       *Committer
+	logger          *Logger
+	puller          *Puller
+	pusher          *Pusher
+	resetter        *Resetter
+	cleaner         *Cleaner
+	adder           *Adder
+	remoter         *Remoter
+	rebaser         *Rebaser
+	bisector        *Bisector
+	blamer          *Blamer
+	switcher        *Switcher
+	stasher         *Stasher
+	configurer      *Configurer
+	hooker          *Hooker
+	tagger          *Tagger
+	pullRequester   *PullRequester
+	statuser        *Statuser
+	versioner       *Versioner
+	differ          *Differ
+	restorer        *Restorer
+	fetcher         *Fetcher
+	syncer          *Syncer
+	stacker         *Stacker
+	cherryPicker    *CherryPicker
+	reverter        *Reverter
+	changelogger    *Changelogger
+	releaser        *Releaser
+	cloner          *Cloner
+	verifier        *Verifier
+	profiler        *Profiler
+	workflower      *Workflower
+	shower          *Shower
+	grepper         *Grepper
+	lfser           *LFSer
+	maintainer      *Maintainer
+	analyzer        *Analyzer
+	wiper           *WIPer
+	snapshotter     *Snapshotter
+	patcher         *Patcher
+	noter           *Noter
+	repoer          *Repoer
+	sparser         *Sparser
+	ticketer        *Ticketer
+	passthroughs    map[string]*passthroughCommand
+	cmdRouter       *commandRouter
+	debugger        *Debugger
+	doctor          *Doctor
+	completer       *Completer
+	undoer          *Undoer
+	scope           *pathScope
 }
 
 // GitDeps is a composite for wiring commands that depend on git operations.
@@ -61,26 +95,70 @@ type Cmd struct {
 // construction ergonomic.
 type GitDeps interface {
 	git.BranchOps
+	git.PublishedReader
 	git.CommitWriter
 	git.LogReader
+	git.LogGraphReader
+	git.BlameReader
+	git.CommitMessageReader
 	git.Puller
 	git.Pusher
+	git.ForcePusher
+	git.PushPreviewer
 	git.ResetOps
 	git.CleanOps
 	git.Stager
+	git.IndexPatcher
 	git.RemoteManager
+	git.RemoteURLReader
+	git.RemoteRenamer
+	git.RemoteProber
+	git.UpstreamPusher
+	git.RemoteBranchDeleter
+	git.RefspecFetcher
 	git.RebaseOps
 	git.StashOps
 	git.ConfigOps
+	git.ConfigReader
+	git.ConfigWriter
 	git.TagOps
+	git.TagSigner
+	git.SignatureReader
 	git.StatusInfoReader
 	git.DiffReader
 	git.RestoreOps
 	git.FetchOps
+	git.RemoteFetcher
+	git.RefTipReader
+	git.CloneOps
 	git.ShowOps
 	git.PassthroughOps
 	git.LocalBranchLister
 	git.FileLister
+	git.UndoOps
+	git.CherryPickOps
+	git.RevertOps
+	git.RecentBranchReader
+	git.MergeOps
+	git.StackOps
+	git.CommitLister
+	git.FileHistoryReader
+	git.Grepper
+	git.LFSReader
+	git.MaintenanceReader
+	git.LargeFileReader
+	git.OutgoingPatchReader
+	git.WIPCommitter
+	git.SnapshotOps
+	git.CommitRewriter
+	git.NotesOps
+	git.RepoStateReader
+	git.TransportURLReader
+	git.SparseCheckoutOps
+	git.TagAnnotator
+	git.NearestTagReader
+	git.TagNameLister
+	git.RemoteNameLister
 }
 
 // NewCmd creates a new Cmd with the provided git client and config manager.
@@ -100,7 +178,7 @@ func NewCmd(client GitDeps, cm *config.Manager) (*Cmd, error) {
 	}
 	config.SetValidCommandNames(names)
 
-	tagger := NewTagger(client)
+	tagger := NewTagger(client).withSigner(client).withAnnotator(client).withNotes(client)
 	// Inline default-remote configuration to avoid a post-construction setter.
 	if cm != nil {
 		if r := strings.TrimSpace(cm.GetConfig().Git.DefaultRemote); r != "" {
@@ -108,43 +186,87 @@ func NewCmd(client GitDeps, cm *config.Manager) (*Cmd, error) {
 		}
 	}
 
+	undoer := NewUndoer(client)
+	sel := newMultiSelector(cm)
+	gate := newPushGate(client).withSecretScan(client, cm)
+	pullRequester := NewPullRequester(client).withConfigManager(cm).withPushGate(gate)
+	confirmer := newConfirmer(cm)
+	guard := newBranchGuard(cm, client, confirmer)
+	clip := systemClipboard()
+	autostash := newAutostasher(client, cm)
+	scope := newPathScope("")
+	if cm != nil {
+		scope = newPathScope(cm.GetConfig().Core.DefaultPathspec)
+	}
+
 	cmd := &Cmd{
-		registry:      registry,
-		configManager: cm,
-		gitClient:     client,
-		outputWriter:  os.Stdout,
-		helper:        NewHelper(registry),
-		brancher:      NewBrancher(client),
-		committer:     NewCommitter(client),
-		logger:        NewLogger(client),
-		puller:        NewPuller(client),
-		pusher:        NewPusher(client),
-		resetter:      NewResetter(client),
-		cleaner:       NewCleaner(client),
-		adder:         NewAdder(client),
-		remoter:       NewRemoter(client),
-		rebaser:       NewRebaser(client),
-		bisector:      NewBisector(client),
-		stasher:       NewStasher(client),
-		configurer:    NewConfigurer(client),
-		hooker:        NewHooker(client),
-		tagger:        tagger,
-		statuser:      NewStatuser(client),
-		versioner:     NewVersioner(client).withConfigManager(cm),
-		differ:        NewDiffer(client),
-		restorer:      NewRestorer(client),
-		fetcher:       NewFetcher(client),
-		shower:        NewShower(client),
-		passthroughs:  buildPassthroughs(client),
-		doctor:        NewDoctor(),
-		debugger:      NewDebugger(),
-		completer:     NewCompleter(),
+		registry:        registry,
+		configManager:   cm,
+		gitClient:       client,
+		outputWriter:    os.Stdout,
+		stdin:           os.Stdin,
+		stdinIsTerminal: stdinIsTerminal,
+		helper:          NewHelper(registry),
+		brancher:        NewBrancher(client).withUndo(undoer).withMultiSelect(sel).withGuard(guard).withConfirmer(confirmer).withPicker(newPicker(cm)).withRenamePropagation(client).withClipboard(clip),
+		committer:       NewCommitter(client).withUndo(undoer).withComposer(client, cm).withSnippets(client).withLint(client).withAmendChecks(client, guard, confirmer).withReword(client).withClipboard(client, clip),
+		logger:          NewLogger(client).withPathScope(scope).withViewer(client, cm).withFileHistory(client, cm).withConfirmer(confirmer),
+		puller:          NewPuller(client).withAutostash(autostash).withSSHPreflight(client, cm),
+		pusher:          NewPusher(client).withGuard(guard).withForcePusher(client, cm).withPreview(client, confirmer).withTracking(client).withDefaultRemote(cm).withPushGate(gate).withSSHPreflight(client, cm),
+		resetter:        NewResetter(client).withUndo(undoer).withCleanSnapshot(client).withGuard(guard).withConfirmer(confirmer),
+		cleaner:         NewCleaner(client).withPathScope(scope).withUndo(undoer).withCleanSelector(newCleanSelector(cm)).withConfirmer(confirmer),
+		adder:           NewAdder(client).withPathScope(scope).withHunkStaging(client, cm).withMultiSelect(sel).withExplorer(explorerSource(client), cm),
+		remoter:         NewRemoter(client).withConfirmer(confirmer).withRenamer(client).withURLTools(client),
+		rebaser:         NewRebaser(client).withUndo(undoer).withConfigManager(cm).withGuard(guard).withAutostash(autostash),
+		bisector:        NewBisector(client),
+		blamer:          NewBlamer(client).withViewer(client, cm),
+		switcher:        NewSwitcher(client).withPicker(newPicker(cm)).withAutostash(autostash).withConfigManager(cm),
+		stasher:         NewStasher(client).withBrowser(cm).withConfirmer(confirmer).withStashOptions(client, sel),
+		configurer:      NewConfigurer(client).withRepoConfig(client).withEditor(),
+		hooker:          NewHooker(client),
+		tagger:          tagger,
+		pullRequester:   pullRequester,
+		statuser:        NewStatuser(client).withLFS(client).withPathScope(scope),
+		versioner:       NewVersioner(client).withConfigManager(cm),
+		differ:          NewDiffer(client).withConfigManager(cm).withPathScope(scope),
+		restorer:        NewRestorer(client),
+		fetcher:         NewFetcher(client).withRemotes(client).withSummary(client).withNotes(client, cm).withSSHPreflight(client, cm),
+		syncer:          NewSyncer(client).withConfigManager(cm).withStatus(client).withSSHPreflight(client, cm).withPushGate(gate),
+		stacker:         NewStacker(client).withAutostash(autostash),
+		cherryPicker:    NewCherryPicker(client).withPicker(newPicker(cm)).withMultiSelect(sel),
+		reverter:        NewReverter(client).withMultiSelect(sel),
+		changelogger:    NewChangelogger(client).withConfigManager(cm),
+		releaser:        NewReleaser(client).withConfigManager(cm).withProvider(pullRequester.provider),
+		cloner:          NewCloner(client).withConfigManager(cm),
+		verifier:        NewVerifier(client),
+		profiler:        NewProfiler(client).withConfigManager(cm),
+		workflower:      NewWorkflower().withConfigManager(cm),
+		shower:          NewShower(client).withConfigManager(cm).withViewer(client, cm),
+		grepper:         NewGrepper(client).withViewer(client, cm),
+		lfser:           NewLFSer(client),
+		maintainer:      NewMaintainer(client),
+		analyzer:        NewAnalyzer(client).withPicker(newPicker(cm)).withConfigManager(cm),
+		wiper:           NewWIPer(client).withStatus(client).withUndo(undoer),
+		snapshotter:     NewSnapshotter(client).withPicker(newPicker(cm)).withConfigManager(cm),
+		patcher:         NewPatcher(client).withMultiSelect(sel).withConfigManager(cm),
+		noter:           NewNoter(client).withConfigManager(cm),
+		repoer:          NewRepoer().withConfigManager(cm).withDashboard(client, cm),
+		sparser:         NewSparser(client).withTreePicker(cm),
+		ticketer:        NewTicketer(client).withConfigManager(cm),
+		passthroughs:    buildPassthroughs(client),
+		doctor:          NewDoctor().withAuth(pullRequester).withGit(client),
+		debugger:        NewDebugger(),
+		completer:       NewCompleter().withConfigManager(cm).withGit(client),
+		undoer:          undoer,
+		scope:           scope,
 	}
 	router, err := newCommandRouter(cmd)
 	if err != nil {
 		return nil, err
 	}
+	router.abbreviate = cm != nil && cm.GetConfig().Behavior.Abbreviations
 	cmd.cmdRouter = router
+	// Re-run workflow steps go through the same routing as the command line.
+	cmd.workflower.withRouter(cmd, client)
 	return cmd, nil
 }
 
@@ -181,6 +303,86 @@ func (c *Cmd) Bisect(args []string) {
 	c.bisector.Bisect(args)
 }
 
+// Blame executes the blame command with the given arguments.
+func (c *Cmd) Blame(args []string) {
+	c.blamer.Blame(args)
+}
+
+// Grep executes the grep command with the given arguments.
+func (c *Cmd) Grep(args []string) {
+	c.grepper.Grep(args)
+}
+
+// LFS executes the lfs command with the given arguments.
+func (c *Cmd) LFS(args []string) {
+	c.lfser.LFS(args)
+}
+
+// Ticket executes the ticket command with the given arguments.
+func (c *Cmd) Ticket(args []string) {
+	c.ticketer.Ticket(args)
+}
+
+// Sparse executes the sparse command with the given arguments.
+func (c *Cmd) Sparse(args []string) {
+	c.sparser.Sparse(args)
+}
+
+// Maintenance executes the maintenance command with the given arguments.
+func (c *Cmd) Maintenance(args []string) {
+	c.maintainer.Maintenance(args)
+}
+
+// Analyze executes the analyze command with the given arguments.
+func (c *Cmd) Analyze(args []string) {
+	c.analyzer.Analyze(args)
+}
+
+// WIP executes the wip command with the given arguments.
+func (c *Cmd) WIP(args []string) {
+	c.wiper.WIP(args)
+}
+
+// Unwip executes the unwip command with the given arguments.
+func (c *Cmd) Unwip(args []string) {
+	c.wiper.Unwip(args)
+}
+
+// Snapshot executes the snapshot command with the given arguments.
+func (c *Cmd) Snapshot(args []string) {
+	c.snapshotter.Snapshot(args)
+}
+
+// FormatPatch executes the format-patch command with the given arguments.
+func (c *Cmd) FormatPatch(args []string) {
+	c.patcher.FormatPatch(args)
+}
+
+// AM executes the am command with the given arguments.
+func (c *Cmd) AM(args []string) {
+	c.patcher.AM(args)
+}
+
+// Apply executes the apply command with the given arguments.
+func (c *Cmd) Apply(args []string) {
+	c.patcher.Apply(args)
+}
+
+// Notes executes the notes command with the given arguments.
+func (c *Cmd) Notes(args []string) {
+	c.noter.Notes(args)
+}
+
+// Repos executes the repos command with the given arguments.
+func (c *Cmd) Repos(args []string) {
+	c.repoer.Repos(args)
+}
+
+// Switch executes the switch command with the given arguments.
+func (c *Cmd) Switch(args []string) {
+	c.switcher.Switch(args)
+}
+
 // Stash executes the stash command with the given arguments.
 func (c *Cmd) Stash(args []string) {
 	c.stasher.Stash(args)
@@ -191,6 +393,56 @@ func (c *Cmd) Fetch(args []string) {
 	c.fetcher.Fetch(args)
 }
 
+// Stack executes the stack command with the given arguments.
+func (c *Cmd) Stack(args []string) {
+	c.stacker.Stack(args)
+}
+
+// CherryPick executes the cherry-pick command with the given arguments.
+func (c *Cmd) CherryPick(args []string) {
+	c.cherryPicker.CherryPick(args)
+}
+
+// Revert executes the revert command with the given arguments.
+func (c *Cmd) Revert(args []string) {
+	c.reverter.Revert(args)
+}
+
+// Changelog executes the changelog command with the given arguments.
+func (c *Cmd) Changelog(args []string) {
+	c.changelogger.Changelog(args)
+}
+
+// Release executes the release command with the given arguments.
+func (c *Cmd) Release(args []string) {
+	c.releaser.Release(args)
+}
+
+// Sync executes the sync command with the given arguments.
+func (c *Cmd) Sync(args []string) {
+	c.syncer.Sync(args)
+}
+
+// Clone executes the clone command with the given arguments.
+func (c *Cmd) Clone(args []string) {
+	c.cloner.Clone(args)
+}
+
+// Verify executes the verify command with the given arguments.
+func (c *Cmd) Verify(args []string) {
+	c.verifier.Verify(args)
+}
+
+// Profile executes the profile command with the given arguments.
+func (c *Cmd) Profile(args []string) {
+	c.profiler.Profile(args)
+}
+
+// Workflow executes the workflow command with the given arguments.
+func (c *Cmd) Workflow(args []string) {
+	c.workflower.Workflow(args)
+}
+
 // Show executes the show command with the given arguments.
 func (c *Cmd) Show(args []string) {
 	c.shower.Show(args)
@@ -231,6 +483,11 @@ func (c *Cmd) Tag(args []string) {
 	c.tagger.Tag(args)
 }
 
+// PR executes the pr command with the given arguments.
+func (c *Cmd) PR(args []string) {
+	c.pullRequester.PR(args)
+}
+
 // Diff executes the diff command with the given arguments.
 func (c *Cmd) Diff(args []string) {
 	c.differ.Diff(args)
@@ -266,6 +523,11 @@ func (c *Cmd) Clean(args []string) {
 	c.cleaner.Clean(args)
 }
 
+// Undo executes the undo command with the given arguments.
+func (c *Cmd) Undo(args []string) {
+	c.undoer.Undo(args)
+}
+
 // DebugKeys executes the debug-keys command with the given arguments.
 func (c *Cmd) DebugKeys(args []string) {
 	c.debugger.DebugKeys(args)
@@ -283,21 +545,149 @@ func buildInteractiveCommands(registry *commandregistry.Registry) []interactive.
 			continue
 		}
 		if len(allCmds[i].Subcommands) == 0 {
-			list = append(list, interactive.CommandInfo{Command: allCmds[i].Name, Description: allCmds[i].Summary})
+			list = append(list, interactive.CommandInfo{
+				Command:     allCmds[i].Name,
+				Description: allCmds[i].Summary,
+				Git:         allCmds[i].Git,
+				Usage:       allCmds[i].Usage,
+				Examples:    allCmds[i].Examples,
+			})
 			continue
 		}
 		for j := range allCmds[i].Subcommands {
-			if allCmds[i].Subcommands[j].Hidden {
+			sub := &allCmds[i].Subcommands[j]
+			if sub.Hidden {
 				continue
 			}
-			list = append(list, interactive.CommandInfo{Command: allCmds[i].Subcommands[j].Name, Description: allCmds[i].Subcommands[j].Summary})
+			list = append(list, interactive.CommandInfo{
+				Command:     sub.Name,
+				Description: sub.Summary,
+				Git:         sub.Git,
+				Usage:       sub.Usage,
+				Examples:    subcommandExamples(&allCmds[i], sub),
+			})
 		}
 	}
 	return list
 }
 
+// subcommandExamples returns the subcommand's own examples, or else the
+// parent's examples that invoke it.
+func subcommandExamples(parent *commandregistry.Info, sub *commandregistry.SubcommandInfo) []string {
+	if len(sub.Examples) > 0 {
+		return sub.Examples
+	}
+	prefix := "ggc " + paletteKey(sub.Name)
+	var examples []string
+	for _, ex := range parent.Examples {
+		if ex == prefix || strings.HasPrefix(ex, prefix+" ") {
+			examples = append(examples, ex)
+		}
+	}
+	return examples
+}
+
+// buildInteractiveAliases lists configured aliases alongside the registry
+// commands. Placeholders are rendered as <name> so the UI prompts for them
+// before dispatch; positional {N} placeholders come first to match the order
+// in which aliases consume arguments.
+func buildInteractiveAliases(cfg *config.Config) []interactive.CommandInfo {
+	if cfg == nil {
+		return nil
+	}
+	aliases := cfg.GetAllAliases()
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]interactive.CommandInfo, 0, len(names))
+	for _, name := range names {
+		alias := aliases[name]
+		command := name
+		for i := 0; i <= alias.MaxPositionalArg; i++ {
+			command += fmt.Sprintf(" <arg%d>", i)
+		}
+		for _, p := range alias.Prompts {
+			command += " <" + p + ">"
+		}
+		list = append(list, interactive.CommandInfo{
+			Command:     command,
+			Description: "Alias: " + strings.Join(alias.Commands, " && "),
+		})
+	}
+	return list
+}
+
+// pinnedSection is the palette heading for interactive.pinned commands.
+const pinnedSection = "Pinned"
+
+// arrangeInteractiveCommands applies the interactive.pinned, .sections and
+// .hidden settings: hidden commands are dropped, and pinned and sectioned
+// ones move to the front, tagged with their section, in configured order.
+// A command listed twice keeps its first placement, pinned first.
+func arrangeInteractiveCommands(list []interactive.CommandInfo, cfg *config.Config) []interactive.CommandInfo {
+	if cfg == nil {
+		return list
+	}
+	byName := make(map[string]int, len(list))
+	for i := range list {
+		byName[paletteKey(list[i].Command)] = i
+	}
+	hidden := make(map[int]bool)
+	for _, name := range cfg.Interactive.Hidden {
+		if i, ok := byName[paletteKey(name)]; ok {
+			hidden[i] = true
+		}
+	}
+
+	placed := make(map[int]bool)
+	var front []interactive.CommandInfo
+	place := func(section string, names []string) {
+		for _, name := range names {
+			i, ok := byName[paletteKey(name)]
+			if !ok || hidden[i] || placed[i] {
+				continue
+			}
+			placed[i] = true
+			item := list[i]
+			item.Section = section
+			front = append(front, item)
+		}
+	}
+	place(pinnedSection, cfg.Interactive.Pinned)
+	for _, s := range cfg.Interactive.Sections {
+		place(s.Name, s.Commands)
+	}
+
+	arranged := front
+	for i := range list {
+		if !hidden[i] && !placed[i] {
+			arranged = append(arranged, list[i])
+		}
+	}
+	return arranged
+}
+
+// paletteKey normalizes a command for matching config entries, ignoring
+// placeholders so "add" and "add <file>" refer to the same entry.
+func paletteKey(command string) string {
+	var words []string
+	for _, w := range strings.Fields(command) {
+		if !strings.HasPrefix(w, "<") {
+			words = append(words, w)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
 // Interactive starts the interactive UI mode.
 func (c *Cmd) Interactive() {
+	// The session length goes into ggc stats however the session ends.
+	started := time.Now()
+	defer func() { _ = stats.RecordSession(time.Since(started)) }()
+
 	// Set up global Ctrl+C handling without introducing a reset window
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt)
@@ -307,12 +697,17 @@ func (c *Cmd) Interactive() {
 		_, _ = fmt.Fprintln(c.outputWriter, "\nExiting...")
 		signal.Stop(sigChan)
 		signal.Reset(os.Interrupt)
+		_ = stats.RecordSession(time.Since(started))
 		os.Exit(0)
 	}()
 
 	// Create persistent UI instance to preserve state; pass already-loaded
 	// config so NewUI does not perform a second config load (Problem H fix).
-	ui := interactive.NewUI(c.gitClient, buildInteractiveCommands(c.registry), c.configManager.GetConfig(), c)
+	cfg := c.configManager.GetConfig()
+	commands := append(buildInteractiveCommands(c.registry), buildInteractiveAliases(cfg)...)
+	commands = arrangeInteractiveCommands(commands, cfg)
+	ui := interactive.NewUI(c.gitClient, commands, cfg, c)
+	c.committer.interactive = true
 
 	for {
 		args := ui.Run()
@@ -333,8 +728,8 @@ func (c *Cmd) Interactive() {
 			continue
 		}
 
-		if err := c.Route(args[1:]); err != nil {
-			_, _ = fmt.Fprintln(c.outputWriter, "Error:", err)
+		if err := c.dispatch(args[1:]); err != nil && !isReported(err) {
+			WriteError(c.outputWriter, err)
 		}
 
 		// Wait for user to continue
@@ -344,24 +739,48 @@ func (c *Cmd) Interactive() {
 }
 
 // Route routes the command to the appropriate handler based on args.
-// It returns an error if the command is not recognized.
+// It returns an error wrapping ErrUnknownCommand if the command is not
+// recognized, and a *ReportedError if the command printed a failure.
 func (c *Cmd) Route(args []string) error {
 	if len(args) == 0 {
 		c.Help(nil)
 		return nil
 	}
 
-	return c.routeCommand(args[0], args[1:])
+	_ = takeFailure()
+	if err := c.routeCommand(args[0], args[1:]); err != nil {
+		return err
+	}
+	if err := takeFailure(); err != nil {
+		return &ReportedError{Err: err}
+	}
+	return nil
 }
 
 // routeCommand routes to the appropriate command handler
 func (c *Cmd) routeCommand(cmd string, args []string) error {
+	if c.cmdRouter.abbreviate {
+		words, err := expandAbbreviations(c.cmdRouter.registry, append([]string{cmd}, args...))
+		if err != nil {
+			return err
+		}
+		cmd, args = words[0], words[1:]
+	}
+
+	// "ggc <command> --help" shows the same help as "ggc help <command>".
+	// Only a lone flag is taken, so commands that pass -h on to git keep it.
+	if len(args) == 1 && (args[0] == "--help" || args[0] == "-h") {
+		if _, ok := c.helper.registry.Find(cmd); ok {
+			c.Help([]string{cmd})
+			return nil
+		}
+	}
 
 	if c.cmdRouter.route(cmd, args) {
 		return nil
 	}
 
-	return fmt.Errorf("unknown command: %q", cmd)
+	return fmt.Errorf("%w: %q", ErrUnknownCommand, cmd)
 }
 
 // waitForContinue waits for user input to continue
@@ -47,12 +47,12 @@ func (m *mockGitClient) FetchPrune() error {
 	return nil
 }
 
-func (m *mockGitClient) LogSimple() error {
+func (m *mockGitClient) LogSimple(...string) error {
 	m.logSimpleCalled = true
 	return nil
 }
 
-func (m *mockGitClient) LogGraph() error {
+func (m *mockGitClient) LogGraph(...string) error {
 	m.logGraphCalled = true
 	return nil
 }
@@ -67,12 +67,12 @@ func (m *mockGitClient) ResetHardAndClean() error {
 	return nil
 }
 
-func (m *mockGitClient) CleanFiles() error {
+func (m *mockGitClient) CleanFiles(...string) error {
 	m.cleanFilesCalled = true
 	return nil
 }
 
-func (m *mockGitClient) CleanDirs() error {
+func (m *mockGitClient) CleanDirs(...string) error {
 	m.cleanDirsCalled = true
 	return nil
 }
@@ -450,6 +450,40 @@ func TestCmd_Route(t *testing.T) {
 	}
 }
 
+func TestCmd_Route_HelpFlag(t *testing.T) {
+	var buf bytes.Buffer
+	helper := NewHelper()
+	helper.outputWriter = &buf
+	cmd := &Cmd{
+		gitClient:    &mockGitClient{},
+		outputWriter: &buf,
+		helper:       helper,
+		registry:     commandregistry.NewRegistry(),
+	}
+	var routerErr error
+	cmd.cmdRouter, routerErr = newCommandRouter(cmd)
+	if routerErr != nil {
+		t.Fatalf("newCommandRouter returned an unexpected error: %v", routerErr)
+	}
+
+	for _, flag := range []string{"--help", "-h"} {
+		buf.Reset()
+		if err := cmd.Route([]string{"release", flag}); err != nil {
+			t.Fatalf("Route(release %s) error: %v", flag, err)
+		}
+		out := buf.String()
+		for _, want := range []string{"Usage: ggc release", "Picks the next version", "ggc release --dry-run  # Show the next version"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("release %s output lacks %q:\n%s", flag, want, out)
+			}
+		}
+	}
+
+	if err := cmd.Route([]string{"nosuchcommand", "--help"}); err == nil {
+		t.Error("Route(nosuchcommand --help) should report an unknown command")
+	}
+}
+
 func TestCmd_Route_SeparatorAllowsHyphenValues(t *testing.T) {
 	// Use mock client to avoid git command side effects
 	mockClient := &mockGitClient{}
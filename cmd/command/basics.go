@@ -7,8 +7,8 @@ func basics() []Info {
 			Name:     "help",
 			Category: CategoryBasics,
 			Summary:  "Show help information for commands",
-			Usage:    []string{"ggc help", "ggc help <command>"},
-			Examples: []string{"ggc help", "ggc help branch"},
+			Usage:    []string{"ggc help", "ggc help <command>", "ggc <command> --help"},
+			Examples: []string{"ggc help", "ggc help branch", "ggc release --help"},
 			Subcommands: []SubcommandInfo{
 				{
 					Name:    "help",
@@ -17,8 +17,8 @@ func basics() []Info {
 				},
 				{
 					Name:    "help <command>",
-					Summary: "Show help for a specific command",
-					Usage:   []string{"ggc help branch"},
+					Summary: "Show a command's description, usage and examples",
+					Usage:   []string{"ggc help branch", "ggc branch --help", "ggc branch -h"},
 				},
 			},
 		},
@@ -26,33 +26,52 @@ func basics() []Info {
 			Name:     "add",
 			Category: CategoryBasics,
 			Summary:  "Stage changes for the next commit",
-			Usage:    []string{"ggc add <file>", "ggc add .", "ggc add interactive", "ggc add patch"},
+			Usage:    []string{"ggc add <file>", "ggc add .", "ggc add interactive", "ggc add patch [<path>...]", "ggc add select", "ggc add explore"},
 			Examples: []string{
 				"ggc add file.txt   # Add a specific file",
 				"ggc add .          # Add all changes to index",
 				"ggc add interactive  # Add changes interactively",
-				"ggc add patch        # Add changes interactively (patch mode)",
+				"ggc add patch        # Stage, unstage and split hunks in a TUI",
+				"ggc add patch cmd/   # Only show hunks under cmd/",
+				"ggc add select       # Pick several changed files to stage",
+				"ggc add explore      # Stage and unstage files in a tree with diff previews",
 			},
 			Subcommands: []SubcommandInfo{
 				{
 					Name:    "add <file>",
 					Summary: "Add a specific file to the index",
+					Git:     "git add <file>",
 					Usage:   []string{"ggc add README.md"},
 				},
 				{
 					Name:    "add .",
 					Summary: "Add all changes to the index",
+					Git:     "git add .",
 					Usage:   []string{"ggc add ."},
 				},
 				{
 					Name:    "add interactive",
 					Summary: "Add changes interactively",
+					Git:     "git add -p",
 					Usage:   []string{"ggc add interactive"},
 				},
 				{
 					Name:    "add patch",
-					Summary: "Add changes interactively (patch mode)",
-					Usage:   []string{"ggc add patch"},
+					Summary: "Stage or unstage individual hunks (j/k move, s stage, u unstage, x split)",
+					Git:     "git apply --cached (per hunk)",
+					Usage:   []string{"ggc add patch", "ggc add patch main.go"},
+				},
+				{
+					Name:    "add select",
+					Summary: "Pick changed files to stage (space mark, ctrl+a mark all, enter stage)",
+					Git:     "git add <files>",
+					Usage:   []string{"ggc add select"},
+				},
+				{
+					Name:    "add explore",
+					Summary: "Browse changed files by directory (j/k move, s stage, u unstage, enter fold); bare ggc add in a terminal",
+					Git:     "git add / git restore --staged <path>",
+					Usage:   []string{"ggc add explore", "ggc add"},
 				},
 			},
 		},
@@ -10,38 +10,76 @@ func branch() []Info {
 			Usage:    []string{"ggc branch <subcommand>"},
 			Examples: []string{
 				"ggc branch current                # Show current branch",
+				"ggc branch current --copy         # Show current branch and copy its name to the clipboard",
 				"ggc branch checkout               # Switch to an existing branch",
 				"ggc branch checkout remote        # Create and checkout a local branch from the remote",
 				"ggc branch create feature/login   # Create and checkout new branch",
 				"ggc branch delete feature/login   # Delete local branch",
 				"ggc branch delete merged          # Delete local merged branch",
 				"ggc branch rename old new         # Rename a branch",
+				"ggc branch rename feature/login   # Rename the current branch, and its remote branch after asking",
 				"ggc branch move feature abc123    # Move branch to specified commit",
 				"ggc branch set upstream feature origin/feature  # Set upstream branch",
+				"ggc branch track origin/feature   # Make the current branch track origin/feature",
+				"ggc branch untrack                # Stop the current branch tracking its upstream",
 				"ggc branch info feature           # Show detailed branch information",
 				"ggc branch list verbose           # Show detailed branch listing",
 				"ggc branch sort date              # List branches sorted by date",
 				"ggc branch contains abc123        # Show branches containing a commit",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "branch current", Summary: "Show current branch name", Usage: []string{"ggc branch current"}},
-				{Name: "branch checkout", Summary: "Switch to an existing branch", Usage: []string{"ggc branch checkout"}},
-				{Name: "branch checkout remote", Summary: "Create and checkout a local branch from the remote", Usage: []string{"ggc branch checkout remote"}},
-				{Name: "branch create", Summary: "Create and checkout a new branch", Usage: []string{"ggc branch create feature/login"}},
-				{Name: "branch delete", Summary: "Delete local branch", Usage: []string{"ggc branch delete feature/login"}, Examples: []string{
+				{Name: "branch current", Summary: "Show current branch name; --copy also copies it to the clipboard", Git: "git rev-parse --abbrev-ref HEAD", Usage: []string{"ggc branch current", "ggc branch current --copy"}},
+				{Name: "branch checkout", Summary: "Switch to an existing branch", Git: "git checkout <branch>", Usage: []string{"ggc branch checkout"}},
+				{Name: "branch checkout remote", Summary: "Create and checkout a local branch from the remote", Git: "git checkout -b <branch> --track <remote>/<branch>", Usage: []string{"ggc branch checkout remote"}},
+				{Name: "branch create", Summary: "Create and checkout a new branch", Git: "git checkout -b <branch>", Usage: []string{"ggc branch create feature/login"}},
+				{Name: "branch delete", Summary: "Delete local branch", Git: "git branch -d <branch>", Usage: []string{"ggc branch delete feature/login"}, Examples: []string{
 					"ggc branch delete feature/123          # Delete a branch",
 					"ggc branch delete feature/123 --force  # Force delete a branch",
+					"ggc branch delete release/1.0 --force-unsafe  # Delete a protected branch without asking",
 				}},
-				{Name: "branch delete merged", Summary: "Delete local merged branch", Usage: []string{"ggc branch delete merged"}},
-				{Name: "branch rename <old> <new>", Summary: "Rename a branch", Usage: []string{"ggc branch rename old new"}},
-				{Name: "branch move <branch> <commit>", Summary: "Move branch to specified commit", Usage: []string{"ggc branch move feature abc123"}},
-				{Name: "branch set upstream <branch> <upstream>", Summary: "Set upstream for a branch", Usage: []string{"ggc branch set upstream feature origin/feature"}},
+				{Name: "branch delete merged", Summary: "Delete local merged branch", Git: "git branch --merged, then git branch -d", Usage: []string{"ggc branch delete merged"}},
+				{Name: "branch rename [<old>] <new>", Summary: "Rename a branch, the current one by default; offers to push the new name, track it and delete the old remote branch", Git: "git branch -m <old> <new>, git push --set-upstream <remote> <new>, git push <remote> --delete <old>", Usage: []string{"ggc branch rename old new", "ggc branch rename feature/login", "ggc branch rename feature/login --local"}},
+				{Name: "branch move <branch> <commit>", Summary: "Move branch to specified commit", Git: "git branch -f <branch> <commit>", Usage: []string{"ggc branch move feature abc123"}},
+				{Name: "branch set upstream <branch> <upstream>", Summary: "Set upstream for a branch", Git: "git branch -u <upstream> <branch>", Usage: []string{"ggc branch set upstream feature origin/feature"}},
+				{Name: "branch track [<remote>/<branch>]", Summary: "Show the current branch's upstream, or set it; without an argument, pick a remote branch in a terminal", Git: "git branch -u <remote>/<branch>", Usage: []string{"ggc branch track", "ggc branch track origin/feature"}},
+				{Name: "branch untrack [<branch>]", Summary: "Stop a branch tracking its upstream", Git: "git branch --unset-upstream <branch>", Usage: []string{"ggc branch untrack", "ggc branch untrack feature"}},
 				{Name: "branch info <branch>", Summary: "Show detailed branch information", Usage: []string{"ggc branch info feature"}},
-				{Name: "branch list verbose", Summary: "Show detailed branch listing", Usage: []string{"ggc branch list verbose"}},
-				{Name: "branch list local", Summary: "List local branches", Usage: []string{"ggc branch list local"}},
-				{Name: "branch list remote", Summary: "List remote branches", Usage: []string{"ggc branch list remote"}},
-				{Name: "branch sort [date|name]", Summary: "List branches sorted by date or name", Usage: []string{"ggc branch sort date"}},
-				{Name: "branch contains <commit>", Summary: "Show branches containing a commit", Usage: []string{"ggc branch contains abc123"}},
+				{Name: "branch list verbose", Summary: "Show detailed branch listing", Git: "git branch -vv", Usage: []string{"ggc branch list verbose"}},
+				{Name: "branch list local", Summary: "List local branches", Git: "git branch", Usage: []string{"ggc branch list local"}},
+				{Name: "branch list remote", Summary: "List remote branches", Git: "git branch -r", Usage: []string{"ggc branch list remote"}},
+				{Name: "branch sort [date|name]", Summary: "List branches sorted by date or name", Git: "git branch --sort=<key>", Usage: []string{"ggc branch sort date"}},
+				{Name: "branch contains <commit>", Summary: "Show branches containing a commit", Git: "git branch --contains <commit>", Usage: []string{"ggc branch contains abc123"}},
+			},
+		},
+		{
+			Name:     "stack",
+			Category: CategoryBranch,
+			Summary:  "Manage branches stacked on top of each other",
+			Usage:    []string{"ggc stack create <name>", "ggc stack list", "ggc stack restack"},
+			Examples: []string{
+				"ggc stack create feature/api      # Start a branch stacked on the current one",
+				"ggc stack list                    # Show each stack as a tree",
+				"ggc stack restack                 # Rebase the stack onto its updated parents",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "stack create <name>", Summary: "Create a branch stacked on the current branch and switch to it", Git: "git checkout -b <name>", Usage: []string{"ggc stack create feature/api"}},
+				{Name: "stack list", Summary: "Show stacked branches as trees, with the ones that need restacking", Usage: []string{"ggc stack list"}},
+				{Name: "stack restack", Summary: "Rebase each branch of the current stack onto its parent, parents first", Git: "git rebase --onto <parent> <base> <branch>", Usage: []string{"ggc stack restack"}},
+			},
+		},
+		{
+			Name:        "ticket",
+			Category:    CategoryBranch,
+			Summary:     "Show or open the issue-tracker ticket named in a branch",
+			Description: "Finds the ticket ID in the current branch name, or the one given, with the rules under tickets.rules in the config; without rules it looks for a Jira-style key such as PROJ-123. It prints the ID, then the ticket URL when its rule has one.\n\nWith tickets.commit or tickets.pr-title set, ggc commit and ggc pr create add the reference to messages and titles that do not mention it yet.",
+			Usage:       []string{"ggc ticket [<branch>]", "ggc ticket open [<branch>]"},
+			Examples: []string{
+				"ggc ticket                        # Print the ticket of the current branch and its URL",
+				"ggc ticket open                   # Open the ticket in the browser",
+				"ggc ticket feature/PROJ-7-login   # Print the ticket of another branch",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "ticket open", Summary: "Open the ticket URL in the browser", Usage: []string{"ggc ticket open", "ggc ticket open feature/PROJ-7-login"}},
 			},
 		},
 	}
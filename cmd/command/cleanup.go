@@ -7,16 +7,33 @@ func cleanup() []Info {
 			Name:     "clean",
 			Category: CategoryCleanup,
 			Summary:  "Remove untracked files and directories",
-			Usage:    []string{"ggc clean files", "ggc clean dirs", "ggc clean interactive"},
+			Usage:    []string{"ggc clean", "ggc clean files", "ggc clean dirs", "ggc clean interactive"},
 			Examples: []string{
+				"ggc clean             # Pick untracked and ignored files to delete",
 				"ggc clean files       # Clean untracked files",
 				"ggc clean dirs        # Clean untracked directories",
 				"ggc clean interactive # Clean files interactively",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "clean files", Summary: "Clean untracked files", Usage: []string{"ggc clean files"}},
-				{Name: "clean dirs", Summary: "Clean untracked directories", Usage: []string{"ggc clean dirs"}},
-				{Name: "clean interactive", Summary: "Clean files interactively", Usage: []string{"ggc clean interactive"}},
+				{Name: "clean", Summary: "Pick untracked and ignored files to delete, with sizes and a preview", Git: "git clean -nd, git clean -ndX, then git clean -fdx -- <paths>", Usage: []string{"ggc clean"}},
+				{Name: "clean files", Summary: "Clean untracked files", Git: "git clean -fd", Usage: []string{"ggc clean files"}},
+				{Name: "clean dirs", Summary: "Clean untracked directories", Git: "git clean -fdx", Usage: []string{"ggc clean dirs"}},
+				{Name: "clean interactive", Summary: "Clean files interactively", Git: "git clean -nd, then git clean -f -- <files>; in a terminal, same as ggc clean", Usage: []string{"ggc clean interactive"}},
+			},
+		},
+		{
+			Name:        "undo",
+			Category:    CategoryCleanup,
+			Summary:     "Reverse the last destructive ggc operation",
+			Description: "ggc journals each reset, rebase, amend, branch delete and clean it runs, in .git/ggc/undo.jsonl. undo reverses the newest entry: it moves the branch back, recreates the deleted branch or restores the cleaned files. The untracked and ignored files that clean dirs and ggc reset delete are snapshotted first, so they come back too; uncommitted changes to tracked files that a reset discards do not.\n\nAn undo that would move HEAD only runs on the branch the operation ran on, and one that discards uncommitted changes asks first.",
+			Usage:       []string{"ggc undo", "ggc undo list"},
+			Examples: []string{
+				"ggc undo       # Reverse the most recent reset, rebase, amend, branch delete or clean",
+				"ggc undo list  # Show journaled operations, newest first",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "undo", Summary: "Reverse the most recent destructive operation", Usage: []string{"ggc undo"}},
+				{Name: "undo list", Summary: "List journaled operations that can be undone", Usage: []string{"ggc undo list"}},
 			},
 		},
 		{
@@ -26,11 +43,11 @@ func cleanup() []Info {
 			Usage:    []string{"ggc restore <file>", "ggc restore .", "ggc restore staged <file>", "ggc restore staged .", "ggc restore <commit> <file>"},
 			Examples: []string{"ggc restore staged .", "ggc restore main README.md"},
 			Subcommands: []SubcommandInfo{
-				{Name: "restore <file>", Summary: "Restore file in working directory from index", Usage: []string{"ggc restore README.md"}},
-				{Name: "restore .", Summary: "Restore all files in working directory from index", Usage: []string{"ggc restore ."}},
-				{Name: "restore staged <file>", Summary: "Unstage file (restore from HEAD to index)", Usage: []string{"ggc restore staged README.md"}},
-				{Name: "restore staged .", Summary: "Unstage all files", Usage: []string{"ggc restore staged ."}},
-				{Name: "restore <commit> <file>", Summary: "Restore file from specific commit", Usage: []string{"ggc restore HEAD~1 README.md"}},
+				{Name: "restore <file>", Summary: "Restore file in working directory from index", Git: "git restore <file>", Usage: []string{"ggc restore README.md"}},
+				{Name: "restore .", Summary: "Restore all files in working directory from index", Git: "git restore .", Usage: []string{"ggc restore ."}},
+				{Name: "restore staged <file>", Summary: "Unstage file (restore from HEAD to index)", Git: "git restore --staged <file>", Usage: []string{"ggc restore staged README.md"}},
+				{Name: "restore staged .", Summary: "Unstage all files", Git: "git restore --staged .", Usage: []string{"ggc restore staged ."}},
+				{Name: "restore <commit> <file>", Summary: "Restore file from specific commit", Git: "git restore --source <commit> <file>", Usage: []string{"ggc restore HEAD~1 README.md"}},
 			},
 		},
 	}
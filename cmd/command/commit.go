@@ -7,34 +7,86 @@ func commit() []Info {
 			Name:     "log",
 			Category: CategoryCommit,
 			Summary:  "Inspect commit history",
-			Usage:    []string{"ggc log simple", "ggc log graph"},
+			Usage:    []string{"ggc log simple", "ggc log graph", "ggc log browse", "ggc log file [--follow] <path>"},
 			Examples: []string{
-				"ggc log simple  # Show commit logs in a simple format",
-				"ggc log graph   # Show commit logs with a graph",
+				"ggc log simple                 # Show commit logs in a simple format",
+				"ggc log graph                  # Show commit logs with a graph",
+				"ggc log browse                 # Browse the commit graph and act on a commit",
+				"ggc log file --follow main.go  # List the commits that changed main.go, across renames",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "log simple", Summary: "Show simple historical log", Usage: []string{"ggc log simple"}},
-				{Name: "log graph", Summary: "Show log with graph", Usage: []string{"ggc log graph"}},
+				{Name: "log simple", Summary: "Show simple historical log", Git: "git log --oneline --graph --decorate -10", Usage: []string{"ggc log simple"}},
+				{Name: "log graph", Summary: "Show log with graph", Git: "git log --graph --oneline --decorate --all", Usage: []string{"ggc log graph"}},
+				{Name: "log browse", Summary: "Browse the commit graph; check out, cherry-pick, revert, branch from or copy a commit", Git: "git log --graph --all --decorate", Usage: []string{"ggc log browse"}},
+				{Name: "log file <path>", Summary: "List the commits that changed a file; on a terminal, view its diff at each or restore a version", Git: "git log --name-only -- <path>", Usage: []string{"ggc log file main.go", "ggc log file --follow main.go"}},
 			},
 		},
 		{
-			Name:     "commit",
-			Category: CategoryCommit,
-			Summary:  "Create commits from staged changes",
-			Usage:    []string{"ggc commit <message>", "ggc commit amend", "ggc commit allow empty", "ggc commit fixup <commit>"},
+			Name:        "commit",
+			Category:    CategoryCommit,
+			Summary:     "Create commits from staged changes",
+			Description: "Commits what is staged. A message given on the command line is used as is; in interactive mode the composer helps write a Conventional Commits message.\n\ncommit -m takes a template instead: {{name}} inserts a snippet from snippets.commit in the config, and {NAME} a variable such as {TICKET}, the issue key in the branch name. Variables the branch does not provide are asked for. The composer expands the same placeholders.\n\ncommit lint checks messages against the rules in the commit section of the config and can be installed as a commit-msg hook with --file.\n\ncommit amend warns when HEAD is already on a remote branch, since amending it rewrites published history; on a branch in safety.protected-branches it asks first, or needs --force-unsafe without a terminal.\n\ncommit reword changes the message of any commit on the current branch without a rebase todo list: git opens the editor on the current message, or the message given is used. The commits after it are replayed and uncommitted changes are stashed meanwhile. When it cannot finish, the rebase is aborted and the branch is left as it was. Rewording a pushed commit is checked like an amend.",
+			Usage:       []string{"ggc commit <message> [--sign | --no-sign] [--copy]", "ggc commit -m <template>", "ggc commit amend [no-edit | --no-edit] [--reset-author] [<message>]", "ggc commit allow empty", "ggc commit fixup <commit>", "ggc commit reword <commit> [<message>]", "ggc commit lint [--range <rev-range>] [--file <path>] [--fix]"},
 			Examples: []string{
 				"ggc commit \"Update docs\"        # Create commit with a message",
+				"ggc commit -m \"{{ticket}}fix login\" # Expand snippets, e.g. to \"[PROJ-123] fix login\"",
 				"ggc commit allow empty            # Create an empty commit",
 				"ggc commit amend                  # Amend previous commit (editor)",
 				"ggc commit amend no-edit          # Amend without editing commit message",
+				"ggc commit amend --reset-author    # Amend and make yourself the author (editor)",
 				"ggc commit fixup abc1234          # Create a fixup commit targeting abc1234",
+				"ggc commit reword HEAD~2          # Edit an older commit's message (editor)",
+				"ggc commit --sign \"Release\"      # Sign this commit whatever commit.gpgsign says",
+				"ggc commit \"Fix typo\" --copy     # Commit and copy the new commit's hash to the clipboard",
+				"ggc commit lint --fix             # Lint HEAD and suggest a rewrite",
+				"ggc commit lint --file \"$1\"       # Use as a commit-msg hook",
+				"ggc                               # Interactive mode: choosing commit opens the composer",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "commit <message>", Summary: "Create commit with a message", Usage: []string{"ggc commit \"Add feature\""}},
-				{Name: "commit allow empty", Summary: "Create an empty commit", Usage: []string{"ggc commit allow empty"}},
-				{Name: "commit amend", Summary: "Amend previous commit (editor)", Usage: []string{"ggc commit amend"}},
-				{Name: "commit amend no-edit", Summary: "Amend without editing commit message", Usage: []string{"ggc commit amend no-edit"}},
-				{Name: "commit fixup <commit>", Summary: "Create a fixup commit targeting <commit>", Usage: []string{"ggc commit fixup abc1234"}},
+				{Name: "commit <message>", Summary: "Create commit with a message", Git: "git commit -m <message>", Usage: []string{"ggc commit \"Add feature\""}},
+				{Name: "commit -m <template>", Summary: "Create commit with a message expanded from snippets and branch variables", Git: "git commit -m <message>", Usage: []string{"ggc commit -m \"{{ticket}}fix login\"", "ggc commit -m \"[{TICKET}] fix login\""}},
+				{Name: "commit allow empty", Summary: "Create an empty commit", Git: "git commit --allow-empty -m \"empty commit\"", Usage: []string{"ggc commit allow empty"}},
+				{Name: "commit amend", Summary: "Amend previous commit (editor); on a terminal, shows the staged changes it folds in and asks first", Git: "git commit --amend", Usage: []string{"ggc commit amend", "ggc commit amend \"fix: handle empty input\""}},
+				{Name: "commit amend no-edit", Summary: "Amend without editing commit message", Git: "git commit --amend --no-edit", Usage: []string{"ggc commit amend no-edit", "ggc commit amend --no-edit"}},
+				{Name: "commit amend --reset-author", Summary: "Amend and make yourself the author, with a new author date", Git: "git commit --amend --reset-author", Usage: []string{"ggc commit amend --no-edit --reset-author"}},
+				{Name: "commit fixup <commit>", Summary: "Create a fixup commit targeting <commit>", Git: "git commit --fixup <commit>", Usage: []string{"ggc commit fixup abc1234"}},
+				{Name: "commit reword <commit>", Summary: "Change an older commit's message (editor, or the message given) and replay the commits after it", Git: "git rebase -i --autostash <commit>^ with reword <commit>", Usage: []string{"ggc commit reword HEAD~2", "ggc commit reword abc1234 \"fix: handle empty input\""}},
+				{Name: "commit lint", Summary: "Check commit messages against Conventional Commits; exits 1 on violations", Usage: []string{"ggc commit lint", "ggc commit lint --range origin/main..HEAD --fix", "ggc commit lint --file .git/COMMIT_EDITMSG"}},
+				{Name: "commit --copy", Summary: "Copy the hash of the commit made to the clipboard", Usage: []string{"ggc commit \"Fix typo\" --copy", "ggc commit -m \"{{ticket}}fix login\" --copy"}},
+				{Name: "commit --sign / --no-sign", Summary: "Sign, or skip signing, any commit subcommand regardless of commit.gpgsign", Git: "git commit -S / git commit --no-gpg-sign", Usage: []string{"ggc commit --sign \"Add feature\"", "ggc commit amend no-edit --no-sign"}},
+			},
+		},
+		{
+			Name:        "wip",
+			Category:    CategoryCommit,
+			Summary:     "Save every change, untracked files included, in a WIP commit",
+			Description: "Stages everything and commits it with a subject starting with \"WIP: \", skipping the commit hooks, so you can switch away or pick the work up on another machine. ggc unwip takes the commit back. ggc push, ggc sync and ggc pr create refuse to push a branch whose tip is a WIP commit unless --allow-wip is given. When the commit fails, as when signing does, the index is put back as it was.",
+			Git:         "git add --all, git commit --no-verify",
+			Usage:       []string{"ggc wip [<message>]"},
+			Examples: []string{
+				"ggc wip                           # Commit everything as \"WIP: work in progress\"",
+				"ggc wip login form                # Commit everything as \"WIP: login form\"",
+			},
+		},
+		{
+			Name:        "unwip",
+			Category:    CategoryCommit,
+			Summary:     "Take back the WIP commit at HEAD, leaving its changes unstaged",
+			Description: "Resets the branch to the commit before the WIP commit at HEAD and keeps its changes in the working tree. It refuses when HEAD is not a WIP commit: one made by ggc wip, or whose subject starts with [WIP] or --wip--. ggc undo puts the commit back.",
+			Git:         "git reset --mixed HEAD~1",
+			Usage:       []string{"ggc unwip"},
+			Examples: []string{
+				"ggc unwip                         # Back to where you were before ggc wip",
+			},
+		},
+		{
+			Name:     "verify",
+			Category: CategoryCommit,
+			Summary:  "Report signature status for commits and tags",
+			Usage:    []string{"ggc verify <rev-range>"},
+			Examples: []string{
+				"ggc verify origin/main..HEAD      # Check the commits you are about to push",
+				"ggc verify v1.0.0..v1.1.0         # Check a release, including its tags",
 			},
 		},
 	}
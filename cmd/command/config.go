@@ -7,16 +7,81 @@ func config() []Info {
 			Name:     "config",
 			Category: CategoryConfig,
 			Summary:  "Get and set ggc configuration",
-			Usage:    []string{"ggc config list", "ggc config get <key>", "ggc config set <key> <value>"},
+			Usage: []string{
+				"ggc config list [--describe]",
+				"ggc config edit",
+				"ggc config describe [<key>] [--json]",
+				"ggc config schema --json",
+				"ggc config get <key>",
+				"ggc config set <key> <value>",
+				"ggc config keybindings show [--profile <name>] [--context <name>]",
+				"ggc config keybindings doctor [--profile <name>]",
+				"ggc config signing [show]",
+				"ggc config signing setup [--format gpg|ssh] [--key <key>] [--always] [--global]",
+				"ggc config signing off [--global]",
+			},
 			Examples: []string{
 				"ggc config list                  # List all configuration values",
+				"ggc config edit                  # Browse and edit configuration interactively",
+				"ggc config describe ui           # Show type, default and description of the ui.* keys",
+				"ggc config schema --json > ggc-config.schema.json",
 				"ggc config get <key>             # Get a config value by key path (e.g., 'ui.color')",
 				"ggc config set <key> <value>     # Set a config value by key path",
+				"ggc config keybindings show      # Show the effective interactive keybindings",
+				"ggc config keybindings show --profile emacs --context input",
+				"ggc config keybindings doctor    # Find keys bound to two actions and how to fix them",
+				"ggc config signing setup --always  # Sign with ~/.ssh/id_ed25519.pub or your GPG key",
+				"ggc config signing setup --format ssh --key ~/.ssh/work.pub --global",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "config list", Summary: "List all configuration", Usage: []string{"ggc config list"}},
+				{Name: "config list", Summary: "List all configuration; --describe adds each key's description", Usage: []string{"ggc config list --describe"}},
+				{Name: "config edit", Summary: "Browse keys with their defaults and descriptions and edit them inline", Usage: []string{"ggc config edit"}},
+				{Name: "config describe [<key>]", Summary: "Show the type, default, allowed values and description of keys; --json for tooling", Usage: []string{"ggc config describe behavior --json"}},
+				{Name: "config schema --json", Summary: "Print the JSON Schema of the config file, generated from ggc's config definition", Usage: []string{"ggc config schema --json"}},
 				{Name: "config get <key>", Summary: "Get a specific config value", Usage: []string{"ggc config get core.editor"}},
 				{Name: "config set <key> <value>", Summary: "Set a configuration value", Usage: []string{"ggc config set core.editor vim"}},
+				{
+					Name:    "config keybindings show",
+					Summary: "Show the effective interactive keybindings",
+					Usage:   []string{"ggc config keybindings show --profile emacs --context input"},
+				},
+				{
+					Name:    "config keybindings doctor",
+					Summary: "List keys bound to more than one action, the layer that set each, and how to fix them",
+					Usage:   []string{"ggc config keybindings doctor --profile vi"},
+				},
+				{Name: "config signing show", Summary: "Show the git commit and tag signing settings", Git: "git config gpg.format; git config user.signingkey", Usage: []string{"ggc config signing"}},
+				{Name: "config signing setup", Summary: "Configure a GPG or SSH signing key; SSH keys are added to the allowed signers file", Git: "git config gpg.format <format>; git config user.signingkey <key>", Usage: []string{"ggc config signing setup --format ssh --always"}},
+				{Name: "config signing off", Summary: "Stop signing commits and tags by default", Git: "git config commit.gpgsign false; git config tag.gpgsign false", Usage: []string{"ggc config signing off"}},
+			},
+		},
+		{
+			Name:     "profile",
+			Category: CategoryConfig,
+			Summary:  "Manage named identities and apply them to repositories",
+			Usage: []string{
+				"ggc profile list",
+				"ggc profile current",
+				"ggc profile add <name> --name <name> --email <email> [--signing-key <key>] [--signing-format gpg|ssh] [--sign] [--path <glob>]...",
+				"ggc profile remove <name>",
+				"ggc profile use <name>",
+				"ggc profile apply",
+			},
+			Examples: []string{
+				"ggc profile add work --name \"Jane Doe\" --email jane@corp.example --path '~/work/*'",
+				"ggc profile add oss --name Jane --email jane@example.com --signing-key ~/.ssh/id_ed25519.pub --signing-format ssh --sign",
+				"ggc profile list                 # List profiles; * marks the one expected here",
+				"ggc profile current              # Show this repository's identity and expected profile",
+				"ggc profile use oss              # Apply a profile to this repository and pin it",
+				"ggc profile apply                # Apply the pinned or path-matched profile",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "profile list", Summary: "List profiles", Usage: []string{"ggc profile list"}},
+				{Name: "profile current", Summary: "Show the repository identity and expected profile", Git: "git config user.name; git config user.email", Usage: []string{"ggc profile current"}},
+				{Name: "profile add <name>", Summary: "Create or replace a profile", Usage: []string{"ggc profile add work --name \"Jane Doe\" --email jane@corp.example --path '~/work/*'"}},
+				{Name: "profile remove <name>", Summary: "Delete a profile", Usage: []string{"ggc profile remove work"}},
+				{Name: "profile use <name>", Summary: "Apply a profile to this repository and pin it", Git: "git config user.name <name>; git config user.email <email>", Usage: []string{"ggc profile use work"}},
+				{Name: "profile apply", Summary: "Apply the profile this repository is expected to use", Git: "git config user.name <name>; git config user.email <email>", Usage: []string{"ggc profile apply"}},
 			},
 		},
 	}
@@ -8,20 +8,25 @@ func diff() []Info {
 			Category: CategoryDiff,
 			Summary:  "Inspect changes between commits, the index, and the working tree",
 			Usage: []string{
-				"ggc diff [staged|unstaged|head] [--stat|--name-only|--name-status] [<commit>|<commit1> <commit2>] [--] [<path>...]",
+				"ggc diff [staged|unstaged|head] [--stat|--name-only|--name-status] [<commit>|<commit1> <commit2>|<commit1>..<commit2>] [--] [<path>...]",
 			},
 			Examples: []string{
 				"ggc diff --stat                     # Show staged + unstaged changes with summary",
 				"ggc diff staged cmd/diff.go         # Diff staged changes for a file",
+				"ggc diff main                       # Compare the working tree with a branch",
 				"ggc diff abc123 def456              # Compare two commits",
+				"ggc diff main..feature              # Compare the tips of two branches",
 				"ggc diff abc123 cmd/diff.go         # Compare commit to working tree for a path",
 				"ggc diff -- cmd/deleted_file.go     # Diff a path using -- for disambiguation",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "diff", Summary: "Show changes (git diff HEAD)", Usage: []string{"ggc diff"}},
-				{Name: "diff unstaged", Summary: "Show unstaged changes", Usage: []string{"ggc diff unstaged"}},
-				{Name: "diff staged", Summary: "Show staged changes", Usage: []string{"ggc diff staged"}},
-				{Name: "diff head", Summary: "Alias for default diff against HEAD", Usage: []string{"ggc diff head"}},
+				{Name: "diff", Summary: "Show changes (git diff HEAD)", Git: "git diff HEAD", Usage: []string{"ggc diff"}},
+				{Name: "diff unstaged", Summary: "Show unstaged changes", Git: "git diff", Usage: []string{"ggc diff unstaged"}},
+				{Name: "diff staged", Summary: "Show staged changes", Git: "git diff --staged", Usage: []string{"ggc diff staged"}},
+				{Name: "diff head", Summary: "Alias for default diff against HEAD", Git: "git diff HEAD", Usage: []string{"ggc diff head"}},
+				{Name: "diff --stat", Summary: "Summarize changes against HEAD per file", Git: "git diff --stat HEAD", Usage: []string{"ggc diff --stat", "ggc diff staged --stat"}},
+				{Name: "diff <branch>", Summary: "Show changes between a branch and the working tree", Git: "git diff <branch>", Usage: []string{"ggc diff main"}},
+				{Name: "diff <from>..<to>", Summary: "Show changes between two commits or branches", Git: "git diff <from>..<to>", Usage: []string{"ggc diff v1.0.0..HEAD", "ggc diff main...feature"}},
 			},
 		},
 	}
@@ -11,24 +11,32 @@ func expansion() []Info {
 			Name:     "switch",
 			Category: CategoryBranch,
 			Summary:  "Switch branches",
-			Usage:    []string{"ggc switch [<options>] <branch>"},
+			Usage:    []string{"ggc switch [<branch>]", "ggc switch recent [<n>|--list]", "ggc switch [<options>] <branch>"},
 			Examples: []string{
+				"ggc switch                            # Pick a branch in a fuzzy picker",
 				"ggc switch main                       # Switch to an existing branch",
+				"ggc switch login                      # Fuzzy-match a branch name",
+				"ggc switch origin/fix-42              # Create a tracking branch and switch to it",
+				"ggc switch recent                     # Pick from the branches used last",
+				"ggc switch recent 2                   # Switch to the second most recent branch",
 				"ggc switch -c feature/login           # Create and switch to a new branch",
 				"ggc switch -C feature/login          # Force-create and switch",
 				"ggc switch --detach HEAD~3            # Detached checkout",
 				"ggc switch -                          # Switch back to the previous branch",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "switch <branch>", Summary: "Switch to an existing branch", Usage: []string{"ggc switch main"}},
-				{Name: "switch -c <branch>", Summary: "Create and switch to a new branch", Usage: []string{"ggc switch -c feature/login"}},
-				{Name: "switch --detach <ref>", Summary: "Detached checkout at a ref", Usage: []string{"ggc switch --detach HEAD~3"}},
+				{Name: "switch", Summary: "Pick a local or remote branch in a fuzzy picker", Usage: []string{"ggc switch"}},
+				{Name: "switch <branch>", Summary: "Switch to a branch, matching remote branches and partial names", Git: "git switch <branch>", Usage: []string{"ggc switch main", "ggc switch login"}},
+				{Name: "switch recent", Summary: "Pick from the branches used last, newest first", Usage: []string{"ggc switch recent", "ggc switch recent 2", "ggc switch recent --list"}},
+				{Name: "switch -c <branch>", Summary: "Create and switch to a new branch", Git: "git switch -c <branch>", Usage: []string{"ggc switch -c feature/login"}},
+				{Name: "switch --detach <ref>", Summary: "Detached checkout at a ref", Git: "git switch --detach <ref>", Usage: []string{"ggc switch --detach HEAD~3"}},
 			},
 		},
 		{
 			Name:     "checkout",
 			Category: CategoryBranch,
 			Summary:  "Switch branches or restore working tree files",
+			Git:      "git checkout",
 			Usage:    []string{"ggc checkout [<options>] [<branch>|<commit>] [--] [<path>...]"},
 			Examples: []string{
 				"ggc checkout main                     # Switch to an existing branch",
@@ -41,6 +49,7 @@ func expansion() []Info {
 			Name:     "merge",
 			Category: CategoryBranch,
 			Summary:  "Join two or more development histories together",
+			Git:      "git merge",
 			Usage:    []string{"ggc merge [<options>] [<commit>...]"},
 			Examples: []string{
 				"ggc merge feature/login               # Merge a branch into the current branch",
@@ -54,35 +63,55 @@ func expansion() []Info {
 			Name:     "cherry-pick",
 			Category: CategoryCommit,
 			Summary:  "Apply the changes introduced by some existing commits",
-			Usage:    []string{"ggc cherry-pick [<options>] <commit>..."},
+			Git:      "git cherry-pick",
+			Usage:    []string{"ggc cherry-pick [<options>] <commit|range>...", "ggc cherry-pick select [<branch>]", "ggc cherry-pick <continue|abort|skip>"},
 			Examples: []string{
 				"ggc cherry-pick abc1234               # Apply a single commit",
 				"ggc cherry-pick -x abc1234            # Apply and append \"(cherry picked from ...)\"",
 				"ggc cherry-pick A..B                  # Apply a range of commits",
-				"ggc cherry-pick --continue            # Continue after resolving conflicts",
-				"ggc cherry-pick --abort               # Abort the in-progress cherry-pick",
+				"ggc cherry-pick select feature/login  # Choose commits from a branch to apply",
+				"ggc cherry-pick continue              # Continue after resolving conflicts",
+				"ggc cherry-pick abort                 # Abort the in-progress cherry-pick",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "cherry-pick select", Summary: "Choose commits another branch has that the current one lacks, and apply them oldest first", Git: "git log --cherry-pick --right-only HEAD...<branch>", Usage: []string{"ggc cherry-pick select [<branch>]"}},
+				{Name: "cherry-pick continue", Summary: "Continue after resolving conflicts", Git: "git cherry-pick --continue", Usage: []string{"ggc cherry-pick continue"}},
+				{Name: "cherry-pick skip", Summary: "Drop the commit that stopped and carry on", Git: "git cherry-pick --skip", Usage: []string{"ggc cherry-pick skip"}},
+				{Name: "cherry-pick abort", Summary: "Stop and put the branch back", Git: "git cherry-pick --abort", Usage: []string{"ggc cherry-pick abort"}},
 			},
 		},
 		{
 			Name:     "revert",
 			Category: CategoryCommit,
 			Summary:  "Revert some existing commits",
-			Usage:    []string{"ggc revert [<options>] <commit>..."},
+			Git:      "git revert",
+			Usage:    []string{"ggc revert [--no-commit] [-m <parent>] <commit|range>...", "ggc revert select [--no-commit]", "ggc revert <continue|abort|skip>"},
 			Examples: []string{
 				"ggc revert HEAD                       # Revert the latest commit",
 				"ggc revert --no-edit abc1234          # Revert without editing the message",
-				"ggc revert -n abc1234                 # Revert without committing (stage only)",
-				"ggc revert --continue                 # Continue after resolving conflicts",
-				"ggc revert --abort                    # Abort the in-progress revert",
+				"ggc revert --no-commit abc1234        # Revert without committing (stage only)",
+				"ggc revert HEAD~3..HEAD               # Revert the last three commits, newest first",
+				"ggc revert -m 1 abc1234               # Revert a merge, keeping its first parent",
+				"ggc revert select                     # Choose recent commits to revert",
+				"ggc revert continue                   # Continue after resolving conflicts",
+				"ggc revert abort                      # Abort the in-progress revert",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "revert select", Summary: "Choose commits of the current branch to revert, newest first; merges are reverted against their first parent", Git: "git revert [-m 1] <commit>...", Usage: []string{"ggc revert select [--no-commit]"}},
+				{Name: "revert continue", Summary: "Continue after resolving conflicts", Git: "git revert --continue", Usage: []string{"ggc revert continue"}},
+				{Name: "revert skip", Summary: "Drop the commit that stopped and carry on", Git: "git revert --skip", Usage: []string{"ggc revert skip"}},
+				{Name: "revert abort", Summary: "Stop and put the branch back", Git: "git revert --abort", Usage: []string{"ggc revert abort"}},
 			},
 		},
 		{
 			Name:     "blame",
 			Category: CategoryBasics,
 			Summary:  "Show what revision and author last modified each line of a file",
-			Usage:    []string{"ggc blame [<options>] <file>"},
+			Git:      "git blame",
+			Usage:    []string{"ggc blame [<rev>] <file>", "ggc blame [<options>] <file>"},
 			Examples: []string{
-				"ggc blame README.md                   # Show line authorship for a file",
+				"ggc blame README.md                   # Browse line authorship (plain output without a terminal)",
+				"ggc blame v1.0 README.md              # Browse the blame as of a revision",
 				"ggc blame -L 10,20 README.md          # Limit blame to specific lines",
 				"ggc blame -C -C README.md             # Detect copy/move across files",
 			},
@@ -92,6 +121,7 @@ func expansion() []Info {
 			Name:     "worktree",
 			Category: CategoryBranch,
 			Summary:  "Manage multiple working trees",
+			Git:      "git worktree",
 			Usage:    []string{"ggc worktree <subcommand> [<options>]"},
 			Examples: []string{
 				"ggc worktree list                     # List linked working trees",
@@ -104,6 +134,7 @@ func expansion() []Info {
 			Name:     "bisect",
 			Category: CategoryUtility,
 			Summary:  "Use binary search to find the commit that introduced a bug",
+			Git:      "git bisect",
 			Usage:    []string{"ggc bisect <subcommand> [<options>]"},
 			Examples: []string{
 				"ggc bisect start <bad> <good>         # Start a new bisect session with known refs",
@@ -117,6 +148,7 @@ func expansion() []Info {
 			Name:     "reflog",
 			Category: CategoryUtility,
 			Summary:  "Manage reflog information (recovery aid)",
+			Git:      "git reflog",
 			Usage:    []string{"ggc reflog [<subcommand>] [<options>] [<ref>]"},
 			Examples: []string{
 				"ggc reflog                            # Show HEAD reflog",
@@ -125,30 +157,63 @@ func expansion() []Info {
 			},
 		},
 		{
-			Name:     "format-patch",
-			Category: CategoryUtility,
-			Summary:  "Prepare patches for e-mail submission",
-			Usage:    []string{"ggc format-patch [<options>] <commit-range>"},
+			Name:        "format-patch",
+			Category:    CategoryUtility,
+			Summary:     "Write commits as patch files for e-mail review",
+			Description: "Writes each commit as a numbered patch file that git am, or a mailing list, takes in with its author and message. Options go to git format-patch unchanged. select lists the commits the current branch has over <base>, by default its upstream or else default.branch, and writes the ones ticked in a multi-select picker as one series, oldest first, whether or not they follow each other.",
+			Git:         "git format-patch",
+			Usage:       []string{"ggc format-patch [<options>] <commit-range>", "ggc format-patch select [<base>] [<options>]"},
 			Examples: []string{
 				"ggc format-patch -1 HEAD              # Produce a patch for the latest commit",
 				"ggc format-patch origin/main..HEAD    # Produce patches for a branch",
+				"ggc format-patch -o out --cover-letter origin/main..HEAD  # A series with a cover letter in out/",
+				"ggc format-patch select               # Pick the commits to send",
+				"ggc format-patch select main -o out -v2  # Pick from the commits since main; version 2 in out/",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "format-patch <commit-range>", Summary: "Write the commits in a range as numbered patch files", Git: "git format-patch <commit-range>", Usage: []string{"ggc format-patch origin/main..HEAD", "ggc format-patch -1 HEAD"}},
+				{Name: "format-patch select", Summary: "Choose the commits to write in a multi-select picker; options go to git format-patch", Git: "git format-patch --no-walk=unsorted <commit>...", Usage: []string{"ggc format-patch select", "ggc format-patch select origin/main -o out --cover-letter"}},
 			},
 		},
 		{
-			Name:     "am",
-			Category: CategoryUtility,
-			Summary:  "Apply a series of patches from a mailbox",
-			Usage:    []string{"ggc am [<options>] [<mailbox>...]"},
+			Name:        "am",
+			Category:    CategoryUtility,
+			Summary:     "Apply a series of patches from a mailbox as commits",
+			Description: "Makes a commit of each patch in the mailboxes or patch files given, with the author and message it carries. A directory stands for the patch files in it (.patch, .diff, .mbox, .eml), in name order, so a format-patch series applies as it was numbered. A patch that does not apply as it is falls back to a three-way merge unless --no-3way is given; when that conflicts, ggc am stops for you to resolve it and go on with ggc am continue.",
+			Git:         "git am --3way",
+			Usage:       []string{"ggc am [<options>] <mailbox|patch|dir>...", "ggc am <continue|skip|abort>"},
 			Examples: []string{
 				"ggc am 0001-fix-bug.patch             # Apply a single patch",
-				"ggc am --continue                     # Continue after resolving conflicts",
-				"ggc am --abort                        # Abort the in-progress am",
+				"ggc am patches/                       # Apply a whole series from a directory",
+				"ggc am -s series.mbox                 # Apply a mailbox and sign off each commit",
+				"ggc am continue                       # Continue after resolving conflicts",
+				"ggc am abort                          # Abort the in-progress am",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "am <mailbox|patch|dir>...", Summary: "Commit each patch, with a three-way merge when it does not apply as it is", Git: "git am --3way", Usage: []string{"ggc am 0001-fix-bug.patch", "ggc am patches/"}},
+				{Name: "am continue", Summary: "Commit the resolved patch and go on with the series", Git: "git am --continue", Usage: []string{"ggc am continue"}},
+				{Name: "am skip", Summary: "Drop the patch that stopped and go on with the series", Git: "git am --skip", Usage: []string{"ggc am skip"}},
+				{Name: "am abort", Summary: "Stop and put the branch back where it was", Git: "git am --abort", Usage: []string{"ggc am abort"}},
+			},
+		},
+		{
+			Name:        "apply",
+			Category:    CategoryUtility,
+			Summary:     "Apply patch files to the working tree, falling back to a three-way merge",
+			Description: "Applies the changes of patch files, or of the patch files in a directory, to the working tree without committing them. A patch that does not apply as it is is tried again as a three-way merge, which needs the blobs it was made against, stages the result and leaves conflict markers where it cannot merge. --check, --stat, --3way and other options go to git apply unchanged. Use ggc am to commit patches with their authors and messages.",
+			Git:         "git apply, git apply --3way",
+			Usage:       []string{"ggc apply [<options>] <patch|dir>..."},
+			Examples: []string{
+				"ggc apply fix.diff                    # Apply a diff, or merge it when it does not apply",
+				"ggc apply --check fix.diff            # Only check whether it applies",
+				"ggc apply -R fix.diff                 # Take the changes back out",
 			},
 		},
 		{
 			Name:     "sparse-checkout",
 			Category: CategoryUtility,
 			Summary:  "Reduce the working tree to a subset of tracked files",
+			Git:      "git sparse-checkout",
 			Usage:    []string{"ggc sparse-checkout <subcommand> [<options>]"},
 			Examples: []string{
 				"ggc sparse-checkout init --cone       # Enable sparse-checkout in cone mode",
@@ -157,10 +222,33 @@ func expansion() []Info {
 				"ggc sparse-checkout disable           # Disable sparse-checkout",
 			},
 		},
+		{
+			Name:        "sparse",
+			Category:    CategoryUtility,
+			Summary:     "Check out only some directories of a large repository",
+			Description: "sparse keeps a cone-mode sparse checkout: the files at the top of the tree are always there, and whole directories are added or removed. Without directories, add and remove open a tree of every directory in HEAD on a terminal; Space includes or excludes one and Enter applies the choice.\n\nCombine it with a partial clone, ggc clone --filter=blob:none --sparse, to download file contents only for the directories you check out.",
+			Usage:       []string{"ggc sparse list", "ggc sparse init [<dir>...]", "ggc sparse add [<dir>...]", "ggc sparse remove [<dir>...]", "ggc sparse disable"},
+			Examples: []string{
+				"ggc sparse init                       # Check out only the top-level files",
+				"ggc sparse add services/api docs      # Check out two more directories",
+				"ggc sparse add                        # Choose directories in a tree",
+				"ggc sparse remove docs                # Stop checking out a directory",
+				"ggc sparse list                       # Show what is checked out",
+				"ggc sparse disable                    # Check out the whole tree again",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "sparse list", Summary: "Show the directories, or patterns, checked out", Git: "git sparse-checkout list", Usage: []string{"ggc sparse list"}},
+				{Name: "sparse init", Summary: "Turn on a cone-mode sparse checkout of the top-level files and the given directories", Git: "git sparse-checkout set --cone [<dir>...]", Usage: []string{"ggc sparse init", "ggc sparse init services/api"}},
+				{Name: "sparse add", Summary: "Check out more directories, or choose them in a tree", Git: "git sparse-checkout add <dir>...", Usage: []string{"ggc sparse add services/api", "ggc sparse add"}},
+				{Name: "sparse remove", Summary: "Stop checking out directories, or choose them in a tree", Git: "git sparse-checkout set --cone <dir>...", Usage: []string{"ggc sparse remove docs", "ggc sparse remove"}},
+				{Name: "sparse disable", Summary: "Check out the whole tree again", Git: "git sparse-checkout disable", Usage: []string{"ggc sparse disable"}},
+			},
+		},
 		{
 			Name:     "mv",
 			Category: CategoryBasics,
 			Summary:  "Move or rename a file, directory, or symlink",
+			Git:      "git mv",
 			Usage:    []string{"ggc mv [<options>] <source>... <destination>"},
 			Examples: []string{
 				"ggc mv old.go new.go                  # Rename a tracked file",
@@ -171,6 +259,7 @@ func expansion() []Info {
 			Name:     "rm",
 			Category: CategoryBasics,
 			Summary:  "Remove files from the working tree and the index",
+			Git:      "git rm",
 			Usage:    []string{"ggc rm [<options>] <file>..."},
 			Examples: []string{
 				"ggc rm old.go                         # Stage removal of a tracked file",
@@ -182,6 +271,7 @@ func expansion() []Info {
 			Name:     "submodule",
 			Category: CategoryUtility,
 			Summary:  "Initialize, update, or inspect submodules",
+			Git:      "git submodule",
 			Usage:    []string{"ggc submodule <subcommand> [<options>]"},
 			Examples: []string{
 				"ggc submodule status                  # Show submodule status",
@@ -189,11 +279,32 @@ func expansion() []Info {
 				"ggc submodule foreach git status      # Run a command in each submodule",
 			},
 		},
+		{
+			Name:     "lfs",
+			Category: CategoryUtility,
+			Summary:  "Track large files with Git LFS and download their content",
+			Git:      "git lfs",
+			Usage:    []string{"ggc lfs status", "ggc lfs track [<pattern>...]", "ggc lfs untrack <pattern>...", "ggc lfs pull [<remote>] [<options>]"},
+			Examples: []string{
+				"ggc lfs status                        # Show LFS files that are staged or modified",
+				"ggc lfs track \"*.psd\"                # Store matching files in LFS",
+				"ggc lfs track                         # List the tracked patterns",
+				"ggc lfs untrack \"*.psd\"              # Stop storing matching files in LFS",
+				"ggc lfs pull                          # Download the content of LFS files",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "lfs status", Summary: "Show LFS files that are staged or modified", Git: "git lfs status", Usage: []string{"ggc lfs status"}},
+				{Name: "lfs track", Summary: "Store files matching the patterns in LFS, or list the tracked patterns", Git: "git lfs track", Usage: []string{"ggc lfs track \"*.psd\""}},
+				{Name: "lfs untrack", Summary: "Stop storing files matching the patterns in LFS", Git: "git lfs untrack", Usage: []string{"ggc lfs untrack \"*.psd\""}},
+				{Name: "lfs pull", Summary: "Download the content of LFS files and replace their pointers", Git: "git lfs pull", Usage: []string{"ggc lfs pull"}},
+			},
+		},
 		// --- Tier 3 ---
 		{
 			Name:     "describe",
 			Category: CategoryUtility,
 			Summary:  "Give an object a human-readable name based on an available ref",
+			Git:      "git describe",
 			Usage:    []string{"ggc describe [<options>] [<commit>]"},
 			Examples: []string{
 				"ggc describe                          # Describe current HEAD",
@@ -205,6 +316,7 @@ func expansion() []Info {
 			Name:     "range-diff",
 			Category: CategoryDiff,
 			Summary:  "Compare two commit ranges (e.g. before and after a rebase)",
+			Git:      "git range-diff",
 			Usage:    []string{"ggc range-diff <range1> <range2>"},
 			Examples: []string{
 				"ggc range-diff main..@{u} main..HEAD  # Compare upstream vs. local rewrite",
@@ -214,29 +326,45 @@ func expansion() []Info {
 		{
 			Name:     "grep",
 			Category: CategoryBasics,
-			Summary:  "Print lines matching a pattern in tracked files",
-			Usage:    []string{"ggc grep [<options>] <pattern> [<pathspec>...]"},
+			Summary:  "Search tracked files; on a terminal, browse the hits and open one in your editor",
+			Git:      "git grep",
+			Usage:    []string{"ggc grep [-i] [-w] [-E | -F | -P] <pattern> [--] [<pathspec>...]", "ggc grep [<options>] <pattern> [<pathspec>...]"},
 			Examples: []string{
-				"ggc grep TODO                         # Search tracked files for TODO",
-				"ggc grep -n -i fixme                  # Case-insensitive with line numbers",
-				"ggc grep -e foo -e bar -- cmd         # Match multiple patterns in cmd/",
+				"ggc grep TODO                         # Browse the TODOs and open one in $EDITOR",
+				"ggc grep -i -w fixme -- cmd           # Whole words, any case, in cmd/",
+				"ggc grep -E 'func (New|new)[A-Z]'     # Extended regular expression",
+				"ggc grep -n TODO                      # Other options print git grep's output",
 			},
 		},
 		{
-			Name:     "notes",
-			Category: CategoryUtility,
-			Summary:  "Add, read, or edit object notes",
-			Usage:    []string{"ggc notes <subcommand> [<options>]"},
+			Name:        "notes",
+			Category:    CategoryUtility,
+			Summary:     "Annotate commits, for instance with review remarks, and share the notes",
+			Description: "Notes attach text to a commit without changing it, so they suit review remarks on commits that are already pushed. They live under notes.ref, refs/notes/commits unless it is set, and the log viewer marks the commits that have one and shows the note with the diff. add without -m opens the editor on the note, starting it when there is none; with -m the text is appended. git does not push or fetch notes with branches: push sends them, and fetch merges the remote's into yours, joining the notes both sides wrote on the same commit. With notes.auto-fetch set, ggc fetch does that too. Other subcommands, such as remove, go to git notes for notes.ref.",
+			Git:         "git notes --ref <notes.ref>",
+			Usage:       []string{"ggc notes <add|show|list|push|fetch> [<args>]"},
 			Examples: []string{
-				"ggc notes add -m \"reviewed\" HEAD     # Attach a note to HEAD",
+				"ggc notes add                         # Write the note on HEAD in the editor",
+				"ggc notes add -m \"reviewed\" HEAD~2    # Append to a commit's note",
 				"ggc notes show HEAD                   # Show a note",
-				"ggc notes list                        # List notes",
+				"ggc notes list                        # Commits with notes and their notes",
+				"ggc notes push                        # Share the notes",
+				"ggc notes fetch                       # Merge in the remote's notes",
+				"ggc notes remove HEAD                 # Other subcommands go to git notes",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "notes add", Summary: "Write a commit's note in the editor, or append -m to it", Git: "git notes edit, git notes append -m", Usage: []string{"ggc notes add [<commit>]", "ggc notes add -m \"<message>\" [<commit>]"}},
+				{Name: "notes show", Summary: "Show the note on a commit, HEAD by default", Git: "git show -s --notes=<notes.ref> --format=%N", Usage: []string{"ggc notes show [<commit>]"}},
+				{Name: "notes list", Summary: "List the commits with notes, newest first, with their notes", Git: "git notes list, git log --no-walk", Usage: []string{"ggc notes list"}},
+				{Name: "notes push", Summary: "Push the notes to a remote, git.default-remote by default", Git: "git push <remote> <notes.ref>", Usage: []string{"ggc notes push [<remote>]"}},
+				{Name: "notes fetch", Summary: "Fetch a remote's notes and merge them into yours", Git: "git fetch <remote> refs/notes/*, git notes merge -s cat_sort_uniq", Usage: []string{"ggc notes fetch [<remote>]"}},
 			},
 		},
 		{
 			Name:     "archive",
 			Category: CategoryUtility,
 			Summary:  "Create an archive of files from a named tree",
+			Git:      "git archive",
 			Usage:    []string{"ggc archive [<options>] <tree-ish> [<path>...]"},
 			Examples: []string{
 				"ggc archive -o out.tar.gz HEAD        # Archive current HEAD to a tarball",
@@ -247,6 +375,7 @@ func expansion() []Info {
 			Name:     "shortlog",
 			Category: CategoryBasics,
 			Summary:  "Summarize git log output grouped by committer",
+			Git:      "git shortlog",
 			Usage:    []string{"ggc shortlog [<options>] [<revision-range>]"},
 			Examples: []string{
 				"ggc shortlog -sn                      # Summary count by author",
@@ -254,20 +383,53 @@ func expansion() []Info {
 			},
 		},
 		{
-			Name:     "maintenance",
-			Category: CategoryUtility,
-			Summary:  "Run scheduled background repository optimizations",
-			Usage:    []string{"ggc maintenance <subcommand> [<options>]"},
+			Name:        "maintenance",
+			Category:    CategoryUtility,
+			Summary:     "Optimize the repository, report on its size and schedule background maintenance",
+			Description: "run packs loose objects, combines packs and prunes what nothing refers to. --quick runs the incremental tasks of git maintenance, which are cheap enough to run any time; --full recomputes every delta and writes a bitmap index, which takes a while on a large repository. Other options go to git maintenance run as they are.\n\nanalyze reports the size of the object store, how it is packed and the largest files in history. start schedules hourly, daily and weekly maintenance with launchd on macOS, the Task Scheduler on Windows and systemd timers or cron elsewhere.",
+			Git:         "git maintenance, git gc, git repack",
+			Usage:       []string{"ggc maintenance run [--quick|--full]", "ggc maintenance analyze [--top <n>]", "ggc maintenance start [--scheduler <scheduler>]", "ggc maintenance <stop|register|unregister>"},
 			Examples: []string{
-				"ggc maintenance run                   # Run all enabled tasks once",
-				"ggc maintenance start                 # Install scheduled maintenance",
+				"ggc maintenance run                   # gc: pack, combine and prune",
+				"ggc maintenance run --quick           # Incremental repack and commit-graph",
+				"ggc maintenance run --full            # Aggressive gc and a bitmap index",
+				"ggc maintenance analyze               # Repository size and largest files",
+				"ggc maintenance start                 # Schedule background maintenance",
 				"ggc maintenance stop                  # Remove scheduled maintenance",
 			},
+			Subcommands: []SubcommandInfo{
+				{Name: "maintenance run", Summary: "Pack loose objects, combine packs and prune unreachable objects", Git: "git gc", Usage: []string{"ggc maintenance run"}},
+				{Name: "maintenance run --quick", Summary: "Run the incremental repack, loose-objects and commit-graph tasks", Git: "git maintenance run", Usage: []string{"ggc maintenance run --quick"}},
+				{Name: "maintenance run --full", Summary: "Recompute every delta, prune now and write a bitmap index", Git: "git gc --aggressive --prune=now, git repack -a -d --write-bitmap-index", Usage: []string{"ggc maintenance run --full"}},
+				{Name: "maintenance analyze", Summary: "Report the object store size, packs and largest files in history; also --analyze", Git: "git count-objects -v, git cat-file --batch-check", Usage: []string{"ggc maintenance analyze", "ggc maintenance analyze --top 20"}},
+				{Name: "maintenance start", Summary: "Register the repository and schedule maintenance with the platform's scheduler", Git: "git maintenance start", Usage: []string{"ggc maintenance start", "ggc maintenance start --scheduler crontab"}},
+				{Name: "maintenance stop", Summary: "Remove the maintenance schedule", Git: "git maintenance stop", Usage: []string{"ggc maintenance stop"}},
+				{Name: "maintenance unregister", Summary: "Take the repository off the maintenance schedule", Git: "git maintenance unregister", Usage: []string{"ggc maintenance unregister"}},
+			},
+		},
+		{
+			Name:        "analyze",
+			Category:    CategoryUtility,
+			Summary:     "Find the largest files in history and plan their removal",
+			Description: "large-files lists the largest files reachable from any branch or tag, with the commit that added each. scrub prints the git filter-repo commands that remove a path from the whole history, and what that rewrite breaks; it never rewrites anything itself. Without a path, scrub offers the largest files in a picker.",
+			Git:         "git rev-list --objects --all, git cat-file --batch-check, git filter-repo",
+			Usage:       []string{"ggc analyze large-files [--top <n>]", "ggc analyze scrub [<path>]"},
+			Examples: []string{
+				"ggc analyze large-files               # The 10 largest files in history",
+				"ggc analyze large-files --top 30      # The 30 largest",
+				"ggc analyze scrub assets/video.mp4    # How to remove a file from history",
+				"ggc analyze scrub                     # Pick the file among the largest",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "analyze large-files", Summary: "List the largest files in history with the commit that added each", Git: "git rev-list --objects --all, git log --find-object", Usage: []string{"ggc analyze large-files", "ggc analyze large-files --top 30"}},
+				{Name: "analyze scrub", Summary: "Print the git filter-repo commands that remove a path from history, with warnings", Git: "git filter-repo --invert-paths --path", Usage: []string{"ggc analyze scrub <path>", "ggc analyze scrub"}},
+			},
 		},
 		{
 			Name:     "gc",
 			Category: CategoryUtility,
 			Summary:  "Cleanup unnecessary files and optimize the local repository",
+			Git:      "git gc",
 			Usage:    []string{"ggc gc [<options>]"},
 			Examples: []string{
 				"ggc gc                                # Run a normal gc",
@@ -278,6 +440,7 @@ func expansion() []Info {
 			Name:     "fsck",
 			Category: CategoryUtility,
 			Summary:  "Verify the connectivity and validity of objects in the repository",
+			Git:      "git fsck",
 			Usage:    []string{"ggc fsck [<options>]"},
 			Examples: []string{
 				"ggc fsck                              # Run a basic fsck",
@@ -288,6 +451,7 @@ func expansion() []Info {
 			Name:     "prune",
 			Category: CategoryUtility,
 			Summary:  "Prune all unreachable objects from the object database",
+			Git:      "git prune",
 			Usage:    []string{"ggc prune [<options>]"},
 			Examples: []string{
 				"ggc prune                             # Prune unreachable objects",
@@ -11,6 +11,9 @@ func hook() []Info {
 			Examples: []string{
 				"ggc hook list                    # List all hooks and their status",
 				"ggc hook install <hook>          # Install a hook",
+				"ggc hook install --template commit-lint  # Install a ready-made hook",
+				"ggc hook templates               # List hook templates",
+				"ggc hook sync                    # Install the hooks declared in .ggc-hooks.yaml",
 				"ggc hook enable <hook>           # Make a hook executable",
 				"ggc hook disable <hook>          # Make a hook non-executable",
 				"ggc hook uninstall <hook>        # Remove a hook",
@@ -18,7 +21,10 @@ func hook() []Info {
 			},
 			Subcommands: []SubcommandInfo{
 				{Name: "hook list", Summary: "List all hooks", Usage: []string{"ggc hook list"}},
-				{Name: "hook install <hook>", Summary: "Install a hook", Usage: []string{"ggc hook install pre-commit"}},
+				{Name: "hook install <hook>", Summary: "Install a hook from its sample or a basic template; --template <name> uses a ready-made hook", Usage: []string{"ggc hook install pre-commit", "ggc hook install --template pre-push-test"}},
+				{Name: "hook templates", Summary: "List the templates hook install --template accepts", Usage: []string{"ggc hook templates"}},
+				{Name: "hook sync", Summary: "Install the hooks declared in .ggc-hooks.yaml and remove stale ones; --force replaces existing hooks", Usage: []string{"ggc hook sync", "ggc hook sync --force"}},
+				{Name: "hook run <hook> [<args>...]", Summary: "Run the steps .ggc-hooks.yaml declares for a hook (used by synced hooks)", Usage: []string{"ggc hook run pre-commit"}},
 				{Name: "hook enable <hook>", Summary: "Enable a hook", Usage: []string{"ggc hook enable pre-commit"}},
 				{Name: "hook disable <hook>", Summary: "Disable a hook", Usage: []string{"ggc hook disable pre-commit"}},
 				{Name: "hook uninstall <hook>", Summary: "Uninstall an existing hook", Usage: []string{"ggc hook uninstall pre-commit"}},
@@ -7,9 +7,9 @@ func rebase() []Info {
 			Name:     "rebase",
 			Category: CategoryRebase,
 			Summary:  "Reapply commits on top of another base tip",
-			Usage:    []string{"ggc rebase <subcommand>"},
+			Usage:    []string{"ggc rebase <subcommand> [--force-unsafe]"},
 			Examples: []string{
-				"ggc rebase interactive  # Interactive rebase",
+				"ggc rebase interactive  # Reorder, squash, fixup, drop or reword commits in a TUI",
 				"ggc rebase autosquash   # Interactive rebase with --autosquash",
 				"ggc rebase main         # Rebase current branch onto 'main'",
 				"ggc rebase continue     # Continue an in-progress rebase",
@@ -17,12 +17,12 @@ func rebase() []Info {
 				"ggc rebase skip         # Skip current patch and continue",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "rebase interactive", Summary: "Interactive rebase", Usage: []string{"ggc rebase interactive"}},
-				{Name: "rebase autosquash", Summary: "Interactive rebase with --autosquash", Usage: []string{"ggc rebase autosquash"}},
-				{Name: "rebase <upstream>", Summary: "Rebase current branch onto <upstream>", Usage: []string{"ggc rebase main"}},
-				{Name: "rebase continue", Summary: "Continue an in-progress rebase", Usage: []string{"ggc rebase continue"}},
-				{Name: "rebase abort", Summary: "Abort an in-progress rebase", Usage: []string{"ggc rebase abort"}},
-				{Name: "rebase skip", Summary: "Skip current patch and continue", Usage: []string{"ggc rebase skip"}},
+				{Name: "rebase interactive", Summary: "Interactive rebase with a built-in todo editor", Git: "git rebase -i HEAD~<n>", Usage: []string{"ggc rebase interactive"}},
+				{Name: "rebase autosquash", Summary: "Interactive rebase with --autosquash", Git: "git rebase -i --autosquash HEAD~<n>", Usage: []string{"ggc rebase autosquash"}},
+				{Name: "rebase <upstream>", Summary: "Rebase current branch onto <upstream>", Git: "git rebase <upstream>", Usage: []string{"ggc rebase main"}},
+				{Name: "rebase continue", Summary: "Continue an in-progress rebase", Git: "git rebase --continue", Usage: []string{"ggc rebase continue"}},
+				{Name: "rebase abort", Summary: "Abort an in-progress rebase", Git: "git rebase --abort", Usage: []string{"ggc rebase abort"}},
+				{Name: "rebase skip", Summary: "Skip current patch and continue", Git: "git rebase --skip", Usage: []string{"ggc rebase skip"}},
 			},
 		},
 	}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -401,3 +402,16 @@ func TestOrderedCategories(t *testing.T) {
 		t.Errorf("last category = %q, want %q", cats[len(cats)-1], CategoryUtility)
 	}
 }
+
+func TestNewRegistry_GitInvocations(t *testing.T) {
+	for _, cmd := range NewRegistry().All() {
+		if cmd.Git != "" && !strings.HasPrefix(cmd.Git, "git ") {
+			t.Errorf("%s: Git = %q, want a git invocation", cmd.Name, cmd.Git)
+		}
+		for _, sub := range cmd.Subcommands {
+			if sub.Git != "" && !strings.HasPrefix(sub.Git, "git ") {
+				t.Errorf("%s: Git = %q, want a git invocation", sub.Name, sub.Git)
+			}
+		}
+	}
+}
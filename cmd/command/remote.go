@@ -4,17 +4,22 @@ package command
 func remote() []Info {
 	return []Info{
 		{
-			Name:     "push",
-			Category: CategoryRemote,
-			Summary:  "Update remote branches",
-			Usage:    []string{"ggc push current", "ggc push force"},
+			Name:        "push",
+			Category:    CategoryRemote,
+			Summary:     "Update remote branches",
+			Description: "Pushes the current branch to origin. When the branch has no upstream yet, ggc push current offers to track <remote>/<branch> on git.default-remote, origin unless set. Before pushing, ggc lists the commits it sends, from the last fetch. A force push that would drop commits on the remote branch lists them too and asks first; without a terminal it needs --yes.\n\nForce pushes use --force-with-lease, so they fail rather than overwrite remote commits you have not fetched. Set push.force-with-lease: false to use --force.\n\n--scan, or safety.scan-secrets in the config, looks for credentials such as cloud keys, private keys and API tokens in the lines the outgoing commits add, and stops the push when any turn up. --no-scan skips the scan.\n\nA branch whose tip is a WIP commit from ggc wip is not pushed; --allow-wip pushes it anyway. ggc sync and ggc pr create run the same checks, the secret scan included, before they push.\n\nWith ssh.preflight set, ggc first checks that ssh has a usable key for an SSH remote, loaded in the agent or without a passphrase, and stops with the fix when it has none.",
+			Usage:       []string{"ggc push current [--scan|--no-scan] [--allow-wip]", "ggc push force [--force-unsafe] [--scan|--no-scan] [--allow-wip]"},
 			Examples: []string{
 				"ggc push current  # Push current branch to remote",
 				"ggc push force    # Force push current branch",
+				"ggc push current --scan  # Check the commits for secrets first",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "push current", Summary: "Push current branch to remote repository", Usage: []string{"ggc push current"}},
-				{Name: "push force", Summary: "Force push current branch", Usage: []string{"ggc push force"}},
+				{Name: "push current", Summary: "Push current branch to remote repository", Git: "git push origin <branch>", Usage: []string{"ggc push current"}},
+				{Name: "push force", Summary: "Force push current branch; asks before dropping remote commits, and protected branches need confirmation or --force-unsafe", Git: "git push origin <branch> --force-with-lease", Usage: []string{"ggc push force"}},
+				{Name: "push current --scan", Summary: "Look for secrets in the commits to push and stop when any turn up", Git: "git log -p -U0 <upstream>..HEAD, git push origin <branch>", Usage: []string{"ggc push current --scan", "ggc push force --scan"}},
+				{Name: "push current --no-scan", Summary: "Push without the secret scan that safety.scan-secrets turns on", Git: "git push origin <branch>", Usage: []string{"ggc push current --no-scan"}},
+				{Name: "push current --allow-wip", Summary: "Push even though the branch ends in a WIP commit", Git: "git push origin <branch>", Usage: []string{"ggc push current --allow-wip"}},
 			},
 		},
 		{
@@ -27,34 +32,102 @@ func remote() []Info {
 				"ggc pull rebase   # Pull with rebase",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "pull current", Summary: "Pull current branch from remote repository", Usage: []string{"ggc pull current"}},
-				{Name: "pull rebase", Summary: "Pull and rebase", Usage: []string{"ggc pull rebase"}},
+				{Name: "pull current", Summary: "Pull current branch from remote repository", Git: "git pull", Usage: []string{"ggc pull current"}},
+				{Name: "pull rebase", Summary: "Pull and rebase", Git: "git pull --rebase", Usage: []string{"ggc pull rebase"}},
 			},
 		},
 		{
-			Name:     "fetch",
-			Category: CategoryRemote,
-			Summary:  "Download objects and refs from remotes",
-			Usage:    []string{"ggc fetch", "ggc fetch prune"},
+			Name:        "sync",
+			Category:    CategoryRemote,
+			Summary:     "Fetch, take in the upstream and push the current branch",
+			Description: "Fetches from the branch's remote, takes in its upstream and pushes the result, so a topic branch is up to date in one step. Uncommitted changes are stashed first and restored at the end.\n\nThe strategy, pruning, autostash and push defaults come from the sync section of the config; the flags override them for one run. When the rebase or merge stops on a conflict, ggc stops too and leaves the stash in place.\n\nLike ggc push, a sync that pushes refuses a branch whose tip is a WIP commit unless --allow-wip is given, and scans the outgoing commits for secrets with --scan or safety.scan-secrets unless --no-scan is given.",
+			Usage:       []string{"ggc sync [--rebase|--merge] [--no-push] [--no-prune] [--no-autostash] [--scan|--no-scan] [--allow-wip]"},
 			Examples: []string{
-				"ggc fetch prune   # Fetch and remove stale remote-tracking references",
+				"ggc sync            # Fetch with prune, rebase onto the upstream, push",
+				"ggc sync --merge    # Merge the upstream instead of rebasing",
+				"ggc sync --no-push  # Stop after taking in the upstream",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "fetch", Summary: "Fetch from the remote", Usage: []string{"ggc fetch"}},
-				{Name: "fetch prune", Summary: "Fetch and clean stale references", Usage: []string{"ggc fetch prune"}},
+				{Name: "sync", Summary: "Fetch with prune, rebase or merge the upstream, then push; uncommitted changes are stashed around it", Git: "git fetch --prune, git rebase <upstream>, git push", Usage: []string{"ggc sync", "ggc sync --merge --no-push"}},
 			},
 		},
 		{
-			Name:     "remote",
+			Name:        "fetch",
+			Category:    CategoryRemote,
+			Summary:     "Download objects and refs from remotes",
+			Usage:       []string{"ggc fetch prune", "ggc fetch [prune] [--all] [--prune-tags]"},
+			Description: "--all fetches every remote, up to four at once, and prefixes each line of their output with the remote's name. --prune-tags deletes local tags that are gone from the remote as well; it implies prune.\n\nOnce the fetch is done, ggc lists the remote-tracking branches and tags it created, moved or deleted.",
+			Examples: []string{
+				"ggc fetch prune                 # Fetch and remove stale remote-tracking references",
+				"ggc fetch --all                 # Fetch every remote at once, each line prefixed with its name",
+				"ggc fetch prune --prune-tags    # Also delete local tags the remote no longer has",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "fetch", Summary: "Fetch from the remote", Git: "git fetch", Usage: []string{"ggc fetch"}},
+				{Name: "fetch prune", Summary: "Fetch and clean stale references", Git: "git fetch --prune", Usage: []string{"ggc fetch prune"}},
+				{Name: "fetch --all", Summary: "Fetch every remote, up to four at a time", Git: "git fetch <remote> (per remote)", Usage: []string{"ggc fetch --all", "ggc fetch prune --all"}},
+				{Name: "fetch --prune-tags", Summary: "Prune, and delete local tags the remote no longer has", Git: "git fetch --prune --prune-tags", Usage: []string{"ggc fetch --prune-tags"}},
+			},
+		},
+		{
+			Name:     "clone",
 			Category: CategoryRemote,
-			Summary:  "Manage remotes",
-			Usage:    []string{"ggc remote list", "ggc remote add <name> <url>", "ggc remote remove <name>", "ggc remote set-url <name> <url>"},
-			Examples: []string{"ggc remote list", "ggc remote add origin git@github.com:user/repo.git"},
+			Summary:  "Clone a repository, expanding owner/repo shorthands",
+			Usage:    []string{"ggc clone <repository> [<directory>] [--depth <n>] [--branch <name>] [--recurse-submodules] [--filter <spec>] [--sparse]", "ggc clone"},
+			Examples: []string{
+				"ggc clone bmf-san/ggc                     # Clone from the default host (github.com)",
+				"ggc clone gitlab.com/group/sub/project     # Shorthand with an explicit host",
+				"ggc clone bmf-san/ggc work --depth 1      # Shallow clone into ./work",
+				"ggc clone <url> --branch dev --recurse-submodules",
+				"ggc clone org/monorepo --filter=blob:none --sparse  # Partial clone, top-level files only",
+				"ggc clone                                 # Ask for the repository, directory and depth",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "clone <repository>", Summary: "Clone a repository and apply the clone settings", Git: "git clone <url> <directory>", Usage: []string{"ggc clone bmf-san/ggc", "ggc clone git@github.com:bmf-san/ggc.git ggc-src --depth 1"}},
+			},
+		},
+		{
+			Name:        "remote",
+			Category:    CategoryRemote,
+			Summary:     "Manage remotes",
+			Description: "remote add, set-url and convert check that the new URL answers with git ls-remote before saving it, so a typo or missing access shows up straight away; --no-check saves it unchecked.\n\nremote convert switches a remote between its SSH (git@host:owner/repo.git) and HTTPS (https://host/owner/repo.git) URLs, for GitHub, GitLab and other hosts that use owner/repo paths.",
+			Usage:       []string{"ggc remote list", "ggc remote add <name> <url> [--no-check]", "ggc remote remove <name>", "ggc remote set-url <name> <url> [--no-check]", "ggc remote rename <old> <new>", "ggc remote convert [<name>] --ssh|--https [--no-check]"},
+			Examples: []string{
+				"ggc remote list",
+				"ggc remote add origin git@github.com:user/repo.git",
+				"ggc remote convert --ssh            # Switch origin to SSH",
+				"ggc remote convert upstream --https # Switch upstream to HTTPS",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "remote list", Summary: "List all remote repositories", Git: "git remote -v", Usage: []string{"ggc remote list"}},
+				{Name: "remote add <name> <url>", Summary: "Add remote repository", Git: "git remote add <name> <url>", Usage: []string{"ggc remote add upstream git@github.com:user/repo.git"}},
+				{Name: "remote remove <name>", Summary: "Remove remote repository", Git: "git remote remove <name>", Usage: []string{"ggc remote remove upstream"}},
+				{Name: "remote set-url <name> <url>", Summary: "Change remote URL", Git: "git remote set-url <name> <url>", Usage: []string{"ggc remote set-url origin git@github.com:user/new.git"}},
+				{Name: "remote rename <old> <new>", Summary: "Rename a remote and its remote-tracking branches", Git: "git remote rename <old> <new>", Usage: []string{"ggc remote rename origin upstream"}},
+				{Name: "remote convert --ssh", Summary: "Switch a remote to its SSH URL", Git: "git remote set-url <name> git@<host>:<owner>/<repo>.git", Usage: []string{"ggc remote convert --ssh", "ggc remote convert upstream --ssh"}},
+				{Name: "remote convert --https", Summary: "Switch a remote to its HTTPS URL", Git: "git remote set-url <name> https://<host>/<owner>/<repo>.git", Usage: []string{"ggc remote convert --https"}},
+			},
+		},
+		{
+			Name:        "pr",
+			Aliases:     []string{"mr"},
+			Category:    CategoryRemote,
+			Summary:     "Create, list, and check out pull requests on GitHub, GitLab, or Gitea",
+			Description: "Works with the hosting service behind the origin remote: GitHub, GitLab or Gitea, including self-hosted instances whose API URL is set in the integration section of the config.\n\npr create pushes the current branch when needed, with the WIP and secret checks of ggc push, and fills the title and body from its commits unless they are given. pr checkout fetches the pull request head into a local branch and switches to it.",
+			Usage:       []string{"ggc pr create [--base <branch>] [--title <title>] [--body <body>] [--draft] [--scan|--no-scan] [--allow-wip]", "ggc pr list [--state open|closed|all]", "ggc pr checkout <number>"},
+			Examples: []string{
+				"ggc pr create                  # Push and open a PR titled from the branch commits",
+				"ggc pr create --base develop   # Target another base branch",
+				"ggc pr create --draft          # Open as a draft",
+				"ggc pr list                    # List open pull requests",
+				"ggc pr list --state all        # Include closed and merged ones",
+				"ggc pr checkout 42             # Fetch and switch to pull request #42",
+				"ggc mr list                    # Same command, GitLab wording",
+			},
 			Subcommands: []SubcommandInfo{
-				{Name: "remote list", Summary: "List all remote repositories", Usage: []string{"ggc remote list"}},
-				{Name: "remote add <name> <url>", Summary: "Add remote repository", Usage: []string{"ggc remote add upstream git@github.com:user/repo.git"}},
-				{Name: "remote remove <name>", Summary: "Remove remote repository", Usage: []string{"ggc remote remove upstream"}},
-				{Name: "remote set-url <name> <url>", Summary: "Change remote URL", Usage: []string{"ggc remote set-url origin git@github.com:user/new.git"}},
+				{Name: "pr create", Summary: "Push the current branch and open a pull request", Usage: []string{"ggc pr create", "ggc pr create --base main --title \"feat: add pr\" --draft"}},
+				{Name: "pr list", Summary: "List pull requests", Usage: []string{"ggc pr list", "ggc pr list --state closed"}},
+				{Name: "pr checkout <number>", Summary: "Check out a pull request locally", Usage: []string{"ggc pr checkout 42", "ggc mr checkout !7"}},
 			},
 		},
 	}
@@ -15,15 +15,17 @@ func show() []Info {
 				"ggc show HEAD~1                       # Show previous commit",
 				"ggc show abc1234                      # Show a specific commit",
 				"ggc show --stat HEAD                  # Show commit with diffstat",
-				"ggc show --name-only HEAD             # Show only changed file names",
+				"ggc show --name-only HEAD             # List only the changed file names",
+				"ggc show --format json HEAD           # Print the commit as JSON",
 				"ggc show v1.0.0                       # Show a tag",
 				"ggc show HEAD:path/to/file.go         # Show file contents at HEAD",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "show", Summary: "Show HEAD commit", Usage: []string{"ggc show"}},
-				{Name: "show <object>", Summary: "Show a specific commit, tag, tree, or blob", Usage: []string{"ggc show HEAD~1"}},
-				{Name: "show --stat <object>", Summary: "Show object with diffstat", Usage: []string{"ggc show --stat HEAD"}},
-				{Name: "show --name-only <object>", Summary: "Show object with names only", Usage: []string{"ggc show --name-only HEAD"}},
+				{Name: "show", Summary: "Show HEAD commit (in the commit viewer on a terminal)", Git: "git show", Usage: []string{"ggc show"}},
+				{Name: "show <object>", Summary: "Show a specific commit, tag, tree, or blob", Git: "git show <object>", Usage: []string{"ggc show HEAD~1"}},
+				{Name: "show --stat <object>", Summary: "Show object with diffstat", Git: "git show --stat <object>", Usage: []string{"ggc show --stat HEAD"}},
+				{Name: "show --name-only <object>", Summary: "List the files a commit changed", Git: "git show --format= --name-only <object>", Usage: []string{"ggc show --name-only HEAD"}},
+				{Name: "show --format json <commit>", Summary: "Print a commit's metadata, message, stats, files and patch as JSON", Git: "git show --numstat --patch <commit>", Usage: []string{"ggc show --format json HEAD", "ggc show --format json --name-only HEAD"}},
 			},
 		},
 	}
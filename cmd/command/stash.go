@@ -11,36 +11,69 @@ func stash() []Info {
 			Examples: []string{
 				"ggc stash                              # Stash current changes",
 				"ggc stash list                         # List all stashes",
+				"ggc stash browse                       # Browse stashes with diff previews",
 				"ggc stash show [stash]                 # Show changes in stash",
 				"ggc stash apply [stash]                # Apply stash without removing it",
 				"ggc stash pop [stash]                  # Apply and remove stash",
 				"ggc stash drop [stash]                 # Remove stash",
 				"ggc stash branch <branch> [stash]      # Create branch from stash",
-				"ggc stash push [-m message] [files]    # Save changes to new stash",
+				"ggc stash push [-m message] [-- paths] # Save changes to new stash",
+				"ggc stash push -u -m \"WIP\"           # Stash untracked files too",
+				"ggc stash push --staged                # Stash only the staged changes",
+				"ggc stash push select                  # Choose the files to stash",
 				"ggc stash save [message]               # Save changes to new stash",
 				"ggc stash clear                        # Remove all stashes",
 				"ggc stash create                       # Create stash and return object name",
 				"ggc stash store <object>               # Store stash object",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "stash", Summary: "Stash current changes", Usage: []string{"ggc stash"}},
-				{Name: "stash list", Summary: "List all stashes", Usage: []string{"ggc stash list"}},
-				{Name: "stash show", Summary: "Show changes in stash", Usage: []string{"ggc stash show"}},
-				{Name: "stash show <stash>", Summary: "Show changes in specific stash", Usage: []string{"ggc stash show stash@{1}"}},
-				{Name: "stash apply", Summary: "Apply stash without removing it", Usage: []string{"ggc stash apply"}},
-				{Name: "stash apply <stash>", Summary: "Apply specific stash without removing it", Usage: []string{"ggc stash apply stash@{1}"}},
-				{Name: "stash pop", Summary: "Apply and remove the latest stash", Usage: []string{"ggc stash pop"}},
-				{Name: "stash pop <stash>", Summary: "Apply and remove specific stash", Usage: []string{"ggc stash pop stash@{1}"}},
-				{Name: "stash drop", Summary: "Remove the latest stash", Usage: []string{"ggc stash drop"}},
-				{Name: "stash drop <stash>", Summary: "Remove specific stash", Usage: []string{"ggc stash drop stash@{1}"}},
-				{Name: "stash branch <branch>", Summary: "Create branch from stash", Usage: []string{"ggc stash branch feature"}},
-				{Name: "stash branch <branch> <stash>", Summary: "Create branch from specific stash", Usage: []string{"ggc stash branch feature stash@{1}"}},
-				{Name: "stash push", Summary: "Save changes to new stash", Usage: []string{"ggc stash push"}},
-				{Name: "stash push -m <message>", Summary: "Save changes to new stash with message", Usage: []string{"ggc stash push -m \"WIP\""}},
-				{Name: "stash save <message>", Summary: "Save changes to new stash with message", Usage: []string{"ggc stash save \"WIP\""}},
-				{Name: "stash clear", Summary: "Remove all stashes", Usage: []string{"ggc stash clear"}},
-				{Name: "stash create", Summary: "Create stash and return object name", Usage: []string{"ggc stash create"}},
-				{Name: "stash store <object>", Summary: "Store stash object", Usage: []string{"ggc stash store 1234abcd"}},
+				{Name: "stash", Summary: "Stash current changes", Git: "git stash", Usage: []string{"ggc stash"}},
+				{Name: "stash list", Summary: "List all stashes", Git: "git stash list", Usage: []string{"ggc stash list"}},
+				{Name: "stash browse", Summary: "Browse stashes with diff previews; apply, pop, drop or branch from one", Usage: []string{"ggc stash browse"}},
+				{Name: "stash show", Summary: "Show changes in stash", Git: "git stash show", Usage: []string{"ggc stash show"}},
+				{Name: "stash show <stash>", Summary: "Show changes in specific stash", Git: "git stash show <stash>", Usage: []string{"ggc stash show stash@{1}"}},
+				{Name: "stash apply", Summary: "Apply stash without removing it", Git: "git stash apply", Usage: []string{"ggc stash apply"}},
+				{Name: "stash apply <stash>", Summary: "Apply specific stash without removing it", Git: "git stash apply <stash>", Usage: []string{"ggc stash apply stash@{1}"}},
+				{Name: "stash pop", Summary: "Apply and remove the latest stash", Git: "git stash pop", Usage: []string{"ggc stash pop"}},
+				{Name: "stash pop <stash>", Summary: "Apply and remove specific stash", Git: "git stash pop <stash>", Usage: []string{"ggc stash pop stash@{1}"}},
+				{Name: "stash drop", Summary: "Remove the latest stash", Git: "git stash drop", Usage: []string{"ggc stash drop"}},
+				{Name: "stash drop <stash>", Summary: "Remove specific stash", Git: "git stash drop <stash>", Usage: []string{"ggc stash drop stash@{1}"}},
+				{Name: "stash branch <branch>", Summary: "Create branch from stash", Git: "git stash branch <branch>", Usage: []string{"ggc stash branch feature"}},
+				{Name: "stash branch <branch> <stash>", Summary: "Create branch from specific stash", Git: "git stash branch <branch> <stash>", Usage: []string{"ggc stash branch feature stash@{1}"}},
+				{Name: "stash push", Summary: "Save changes to new stash", Git: "git stash push", Usage: []string{"ggc stash push"}},
+				{Name: "stash push -m <message>", Summary: "Save changes to new stash with message", Git: "git stash push -m <message>", Usage: []string{"ggc stash push -m \"WIP\""}},
+				{Name: "stash push --include-untracked", Summary: "Stash untracked files too; -u for short", Git: "git stash push --include-untracked", Usage: []string{"ggc stash push -u", "ggc stash push --include-untracked -m \"WIP\""}},
+				{Name: "stash push --all", Summary: "Stash untracked and ignored files too; -a for short", Git: "git stash push --all", Usage: []string{"ggc stash push --all -m \"WIP\""}},
+				{Name: "stash push --staged", Summary: "Stash only the staged changes", Git: "git stash push --staged", Usage: []string{"ggc stash push --staged -m \"split out\""}},
+				{Name: "stash push -- <path>...", Summary: "Stash only the changes to the given paths", Git: "git stash push -- <path>...", Usage: []string{"ggc stash push -m \"docs\" -- docs README.md"}},
+				{Name: "stash push select", Summary: "Choose the changed files to stash in a multi-select picker; honors -u, -a and --staged", Git: "git stash push -- <path>...", Usage: []string{"ggc stash push select", "ggc stash push -u -m \"WIP\" select"}},
+				{Name: "stash save <message>", Summary: "Save changes to new stash with message", Git: "git stash push -m <message>", Usage: []string{"ggc stash save \"WIP\""}},
+				{Name: "stash clear", Summary: "Remove all stashes", Git: "git stash clear", Usage: []string{"ggc stash clear"}},
+				{Name: "stash create", Summary: "Create stash and return object name", Git: "git stash create", Usage: []string{"ggc stash create"}},
+				{Name: "stash store <object>", Summary: "Store stash object", Git: "git stash store <object>", Usage: []string{"ggc stash store 1234abcd"}},
+			},
+		},
+		{
+			Name:        "snapshot",
+			Category:    CategoryStash,
+			Summary:     "Save and restore the working tree without touching the index or HEAD",
+			Description: "create saves every file in the working tree, untracked ones included and ignored ones left out, in a commit under refs/ggc/snapshots. Unlike a stash, the working tree, the index and HEAD stay exactly as they are, so you can save before an experiment and carry on. restore writes a snapshot's files back into the working tree, after saving the current state as a snapshot of its own; files added since are left alone. Snapshots are named after the time they were saved and can be given by that name or by their number in the list.\n\nSaving keeps the newest 20 snapshots and drops the rest. snapshot.keep changes the number and snapshot.max-age also drops snapshots older than a Go duration such as 720h.",
+			Git:         "git add --all, git write-tree, git commit-tree, git update-ref refs/ggc/snapshots/<name>",
+			Usage:       []string{"ggc snapshot <create|list|restore|drop|prune> [<args>]"},
+			Examples: []string{
+				"ggc snapshot create before the refactor  # Save the working tree",
+				"ggc snapshot list                        # Numbered list, newest first",
+				"ggc snapshot restore 1                   # Put the newest snapshot back",
+				"ggc snapshot restore                     # Pick the snapshot to restore",
+				"ggc snapshot drop 20261016-142301        # Drop a snapshot by name",
+				"ggc snapshot prune                       # Apply snapshot.keep and snapshot.max-age",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "snapshot create", Summary: "Save the working tree, untracked files included, as a snapshot", Git: "git add --all, git commit-tree, git update-ref", Usage: []string{"ggc snapshot create", "ggc snapshot create -m \"before the refactor\""}},
+				{Name: "snapshot list", Summary: "List the snapshots, newest first", Git: "git for-each-ref refs/ggc/snapshots", Usage: []string{"ggc snapshot list"}},
+				{Name: "snapshot restore", Summary: "Write a snapshot's files back into the working tree, saving the current state first", Git: "git restore --source=<snapshot> --worktree -- :/", Usage: []string{"ggc snapshot restore 1", "ggc snapshot restore"}},
+				{Name: "snapshot drop", Summary: "Delete a snapshot", Git: "git update-ref -d refs/ggc/snapshots/<name>", Usage: []string{"ggc snapshot drop 2"}},
+				{Name: "snapshot prune", Summary: "Drop the snapshots that snapshot.keep and snapshot.max-age no longer keep", Git: "git update-ref -d", Usage: []string{"ggc snapshot prune"}},
 			},
 		},
 	}
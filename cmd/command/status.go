@@ -13,8 +13,8 @@ func status() []Info {
 				"ggc status short  # Short, concise output (porcelain format)",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "status", Summary: "Show working tree status", Usage: []string{"ggc status"}},
-				{Name: "status short", Summary: "Show concise status (porcelain format)", Usage: []string{"ggc status short"}},
+				{Name: "status", Summary: "Show working tree status", Git: "git status", Usage: []string{"ggc status"}},
+				{Name: "status short", Summary: "Show concise status (porcelain format)", Git: "git status --short", Usage: []string{"ggc status short"}},
 			},
 		},
 	}
@@ -7,13 +7,17 @@ func tag() []Info {
 			Name:     "tag",
 			Category: CategoryTag,
 			Summary:  "Create, list, and manage tags",
-			Usage:    []string{"ggc tag list", "ggc tag annotated <tag> <message>", "ggc tag delete <tag>", "ggc tag show <tag>", "ggc tag push [<remote> <tag>]", "ggc tag create <tag>"},
+			Usage:    []string{"ggc tag list", "ggc tag annotated <tag> <message>", "ggc tag delete <tag>", "ggc tag show <tag>", "ggc tag push [<remote> <tag>]", "ggc tag create <tag> [<commit>] [--annotate|--sign] [-m <message>] [--notes]", "ggc tag notes [<commit>]"},
 			Examples: []string{
 				"ggc tag                                   # List all tags",
 				"ggc tag list                              # List all tags (sorted)",
 				"ggc tag list v1.*                         # List tags matching pattern",
 				"ggc tag create v1.0.0                     # Create tag",
 				"ggc tag create v1.0.0 abc123              # Tag specific commit",
+				"ggc tag create v1.0.0 --sign -m 'v1.0.0'  # Create signed tag",
+				"ggc tag create v1.0.0 --annotate -m 'v1'  # Create annotated tag",
+				"ggc tag create v1.1.0 --notes             # Annotated tag listing changes since the last tag",
+				"ggc tag notes                             # Preview the notes --notes would write",
 				"ggc tag annotated v1.0.0 'Release notes'  # Create annotated tag",
 				"ggc tag delete v1.0.0                     # Delete tag",
 				"ggc tag push                              # Push all tags to origin",
@@ -21,12 +25,52 @@ func tag() []Info {
 				"ggc tag show v1.0.0                       # Show tag information",
 			},
 			Subcommands: []SubcommandInfo{
-				{Name: "tag list", Summary: "List all tags", Usage: []string{"ggc tag list"}},
-				{Name: "tag annotated <tag> <message>", Summary: "Create annotated tag", Usage: []string{"ggc tag annotated v1.0.0 \"Release\""}},
-				{Name: "tag delete <tag>", Summary: "Delete tag", Usage: []string{"ggc tag delete v1.0.0"}},
-				{Name: "tag show <tag>", Summary: "Show tag information", Usage: []string{"ggc tag show v1.0.0"}},
-				{Name: "tag push", Summary: "Push tags to remote", Usage: []string{"ggc tag push", "ggc tag push <remote> <tag>"}},
-				{Name: "tag create <tag>", Summary: "Create tag", Usage: []string{"ggc tag create v1.0.1"}},
+				{Name: "tag list", Summary: "List all tags", Git: "git tag --sort=-version:refname", Usage: []string{"ggc tag list"}},
+				{Name: "tag annotated <tag> <message>", Summary: "Create annotated tag", Git: "git tag -a <tag> -m <message>", Usage: []string{"ggc tag annotated v1.0.0 \"Release\""}},
+				{Name: "tag delete <tag>", Summary: "Delete tag", Git: "git tag -d <tag>", Usage: []string{"ggc tag delete v1.0.0"}},
+				{Name: "tag show <tag>", Summary: "Show tag information", Git: "git show <tag>", Usage: []string{"ggc tag show v1.0.0"}},
+				{Name: "tag push", Summary: "Push tags to remote", Git: "git push <remote> --tags", Usage: []string{"ggc tag push", "ggc tag push <remote> <tag>"}},
+				{Name: "tag create <tag>", Summary: "Create tag", Git: "git tag <tag>", Usage: []string{"ggc tag create v1.0.1"}},
+				{Name: "tag create <tag> --annotate", Summary: "Create annotated tag; -m sets the message, otherwise the editor opens", Git: "git tag -a <tag> -m <message> [<commit>]", Usage: []string{"ggc tag create v1.0.1 --annotate -m \"Release v1.0.1\""}},
+				{Name: "tag create <tag> --sign", Summary: "Create signed tag; -m sets the message, otherwise the editor opens", Git: "git tag -s <tag> -m <message>", Usage: []string{"ggc tag create v1.0.1 --sign -m \"Release v1.0.1\""}},
+				{Name: "tag create <tag> --notes", Summary: "Create annotated (or, with --sign, signed) tag whose message lists the commits since the previous tag by Conventional Commits type", Git: "git log --no-merges <previous>..<commit>; git tag -a <tag> -m <notes>", Usage: []string{"ggc tag create v1.1.0 --notes", "ggc tag create v1.1.0 --notes --sign -m \"Release v1.1.0\""}},
+				{Name: "tag notes", Summary: "Print the release notes for the commits since the previous tag", Git: "git log --no-merges <previous>..<commit>", Usage: []string{"ggc tag notes", "ggc tag notes <commit>"}},
+			},
+		},
+		{
+			Name:        "changelog",
+			Category:    CategoryTag,
+			Summary:     "Generate a changelog from Conventional Commits",
+			Description: "Groups the commits between two refs by their Conventional Commits type into Features, Bug Fixes and the other sections, with breaking changes called out first. Commits that do not follow the convention go under Other Changes; merge commits are left out.\n\nWithout --from the range starts at the last tag reachable from --to. --write prepends the section to CHANGELOG.md and replaces a section already written for the same release, so it can be rerun.",
+			Usage:       []string{"ggc changelog [--from <tag>] [--to <ref>] [--format markdown|json] [--write[=<file>]]"},
+			Examples: []string{
+				"ggc changelog                             # Changes since the last tag, as markdown",
+				"ggc changelog --from v1.0.0 --to v1.1.0   # Changes in a past release",
+				"ggc changelog --format json               # Machine-readable output",
+				"ggc changelog --to v1.1.0 --write         # Prepend the release to CHANGELOG.md",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "changelog --write", Summary: "Prepend the section to CHANGELOG.md (or the given file), replacing one written for the same release", Git: "git log --no-merges <from>..<to>", Usage: []string{"ggc changelog --write", "ggc changelog --write=docs/CHANGES.md"}},
+			},
+		},
+		{
+			Name:        "release",
+			Category:    CategoryTag,
+			Summary:     "Bump the version, tag it, push it and publish the release",
+			Description: "Picks the next version from the Conventional Commits since the last vMAJOR.MINOR.PATCH tag: a breaking change bumps the major version, a feature the minor and anything else the patch. The first release is v0.1.0.\n\nIt then updates the configured version file and commits it, creates an annotated tag carrying the release notes, pushes the branch and the tag and, with --publish, creates the release on the hosting service. Run it with --dry-run first to see each step.",
+			Usage:       []string{"ggc release [--major|--minor|--patch] [--dry-run] [--no-push] [--publish|--no-publish]"},
+			Examples: []string{
+				"ggc release --dry-run     # Show the next version, the steps and the notes",
+				"ggc release               # Bump from the Conventional Commits since the last tag",
+				"ggc release --minor       # Force a minor bump",
+				"ggc release --publish     # Also create the release on GitHub, GitLab or Gitea",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "release --dry-run", Summary: "List the release steps and notes without changing anything", Usage: []string{"ggc release --dry-run", "ggc release --major -n"}},
+				{Name: "release --major", Summary: "Bump the major version whatever the commits call for", Git: "git tag -a <tag> -m <notes>", Usage: []string{"ggc release --major"}},
+				{Name: "release --minor", Summary: "Bump the minor version whatever the commits call for", Git: "git tag -a <tag> -m <notes>", Usage: []string{"ggc release --minor"}},
+				{Name: "release --patch", Summary: "Bump the patch version whatever the commits call for", Git: "git tag -a <tag> -m <notes>", Usage: []string{"ggc release --patch"}},
+				{Name: "release --publish", Summary: "Also create the release on GitHub, GitLab or Gitea", Usage: []string{"ggc release --publish", "ggc release --no-publish"}},
 			},
 		},
 	}
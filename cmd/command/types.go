@@ -6,6 +6,8 @@ type Info struct {
 	Aliases     []string
 	Category    Category
 	Summary     string
+	Description string // long-form help; paragraphs are separated by blank lines
+	Git         string // underlying git invocation, for display; empty for ggc-only commands
 	Usage       []string
 	Examples    []string
 	Hidden      bool
@@ -16,6 +18,7 @@ type Info struct {
 type SubcommandInfo struct {
 	Name     string
 	Summary  string
+	Git      string // underlying git invocation, for display; empty for ggc-only commands
 	Usage    []string
 	Examples []string
 	Hidden   bool
@@ -23,10 +26,12 @@ type SubcommandInfo struct {
 
 func (c *Info) clone() Info {
 	clone := Info{
-		Name:     c.Name,
-		Category: c.Category,
-		Summary:  c.Summary,
-		Hidden:   c.Hidden,
+		Name:        c.Name,
+		Category:    c.Category,
+		Summary:     c.Summary,
+		Description: c.Description,
+		Git:         c.Git,
+		Hidden:      c.Hidden,
 	}
 	if len(c.Aliases) > 0 {
 		clone.Aliases = append([]string(nil), c.Aliases...)
@@ -50,6 +55,7 @@ func (s *SubcommandInfo) clone() SubcommandInfo {
 	clone := SubcommandInfo{
 		Name:    s.Name,
 		Summary: s.Summary,
+		Git:     s.Git,
 		Hidden:  s.Hidden,
 	}
 	if len(s.Usage) > 0 {
@@ -27,9 +27,20 @@ func utility() []Info {
 			Name:     "doctor",
 			Category: CategoryUtility,
 			Summary:  "Diagnose the local ggc installation",
-			Usage:    []string{"ggc doctor"},
+			Usage: []string{
+				"ggc doctor",
+				"ggc doctor auth",
+			},
 			Examples: []string{
-				"ggc doctor   # Check git binary, config, shell completions, TTY, etc.",
+				"ggc doctor        # Check git, config, PATH, completions, terminal and keybindings",
+				"ggc doctor auth   # Check hosting tokens and SSH or HTTPS credentials for the default remote",
+			},
+			Subcommands: []SubcommandInfo{
+				{
+					Name:    "doctor auth",
+					Summary: "Check hosting tokens, SSH agent and keys, and credentials for the default remote",
+					Usage:   []string{"ggc doctor auth"},
+				},
 			},
 		},
 		{
@@ -58,18 +69,90 @@ func utility() []Info {
 				{Name: "history clear", Summary: "Delete every recorded entry", Usage: []string{"ggc history clear"}},
 			},
 		},
+		{
+			Name:        "stats",
+			Category:    CategoryUtility,
+			Summary:     "Show local usage statistics",
+			Description: "Shows the commands you run most, how long interactive sessions last, how many workflows ran and a rough estimate of the typing ggc saved. The numbers are recorded on this machine only, in the user cache directory, and are never sent anywhere.\n\nSet stats.enabled: false in the config, or GGC_NO_STATS=1 in the environment, to stop recording.",
+			Usage:       []string{"ggc stats", "ggc stats reset"},
+			Examples: []string{
+				"ggc stats        # Show the most-used commands, sessions, workflows and time saved",
+				"ggc stats reset  # Delete the recorded statistics",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "stats", Summary: "Show the recorded usage statistics", Usage: []string{"ggc stats"}},
+				{Name: "stats reset", Summary: "Delete the recorded usage statistics", Usage: []string{"ggc stats reset"}},
+			},
+		},
+		{
+			Name:        "workflow",
+			Category:    CategoryUtility,
+			Summary:     "List saved workflows, add new ones from templates and re-run failed steps",
+			Description: "Workflows are command sequences run from the workflow view of interactive mode. ggc ships templates for common ones, such as starting a feature branch or syncing a fork; ggc workflow add copies a template into the workflows section of the config, asking for its placeholders. Placeholders left empty are asked for each time the workflow runs.\n\nYour own templates are YAML files in ~/.config/ggc/workflows, named after the template, with a description and a list of steps. A template there replaces the built-in one of the same name.\n\nEvery workflow run leaves a transcript in the workflow-runs directory under the user cache directory: the steps with their arguments, exit status, duration and, for steps of parallel groups, their output. ggc workflow rerun --failed runs the steps that failed in the last run again.",
+			Usage: []string{
+				"ggc workflow list",
+				"ggc workflow templates [<template>]",
+				"ggc workflow add <template> [<name>] [--set <placeholder>=<value>]...",
+				"ggc workflow rerun --failed",
+			},
+			Examples: []string{
+				"ggc workflow list                                # Show the saved workflows and their steps",
+				"ggc workflow templates                           # List the built-in and your own templates",
+				"ggc workflow templates sync-fork                 # Show a template's steps and placeholders",
+				"ggc workflow add feature-start start --set base=main",
+				"ggc workflow rerun --failed                      # Run the steps that failed in the last run again",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "workflow list", Summary: "Show the saved workflows and their steps", Usage: []string{"ggc workflow list"}},
+				{Name: "workflow templates", Summary: "List the workflow templates, or show one", Usage: []string{"ggc workflow templates", "ggc workflow templates feature-start"}},
+				{Name: "workflow add <template>", Summary: "Save a workflow made from a template, filling in its placeholders", Usage: []string{"ggc workflow add feature-start", "ggc workflow add sync-fork sync-main --set branch=main"}},
+				{Name: "workflow rerun --failed", Summary: "Run the steps that failed in the last workflow run again", Usage: []string{"ggc workflow rerun --failed"}},
+			},
+		},
+		{
+			Name:        "repos",
+			Category:    CategoryUtility,
+			Summary:     "Run ggc commands across many repositories at once",
+			Description: "ggc repos works on the repositories listed in repos.paths, which ggc repos add and remove change, and those found up to three levels below the directories in repos.roots. exec runs any ggc command in each of them, several at once, as its own process in that repository; every line of output starts with the repository's name, and a table at the end shows how long each took and why the ones that failed did. sync is short for exec -- sync. repos.parallel caps how many run at once, 4 by default. The commands cannot ask questions, so anything that would prompt takes its default or stops; ggc --yes repos exec answers yes in every repository, and --no-color reaches them too. On a terminal, dashboard shows every repository's branch, changes, commits ahead of and behind its upstream and any rebase, merge or other operation in progress; r reads them again, Enter opens interactive ggc in the highlighted repository and x runs a ggc command there, coming back to the dashboard afterwards.",
+			Usage: []string{
+				"ggc repos list",
+				"ggc repos add [<path>...]",
+				"ggc repos remove <path|name>",
+				"ggc repos exec [--] <command> [<args>]",
+				"ggc repos sync [<args>]",
+				"ggc repos dashboard",
+			},
+			Examples: []string{
+				"ggc repos add ~/src/api ~/src/web     # List repositories to run in",
+				"ggc repos list                        # Show them, with those under repos.roots",
+				"ggc repos exec -- status short        # Status of every repository",
+				"ggc repos exec fetch --prune          # Fetch everywhere",
+				"ggc repos sync                        # Fetch, rebase and push every repository",
+				"ggc repos remove web                  # Stop running in one",
+				"ggc repos dashboard                   # See and work in them on one screen",
+			},
+			Subcommands: []SubcommandInfo{
+				{Name: "repos list", Summary: "List the repositories ggc repos runs in", Usage: []string{"ggc repos list"}},
+				{Name: "repos add", Summary: "Add repositories, the current one by default, to repos.paths", Usage: []string{"ggc repos add", "ggc repos add ~/src/api ~/src/web"}},
+				{Name: "repos remove", Summary: "Take a repository out of repos.paths", Usage: []string{"ggc repos remove ~/src/web", "ggc repos remove web"}},
+				{Name: "repos exec", Summary: "Run a ggc command in every repository, several at once", Usage: []string{"ggc repos exec -- status", "ggc repos exec pull current"}},
+				{Name: "repos sync", Summary: "Run ggc sync in every repository", Usage: []string{"ggc repos sync"}},
+				{Name: "repos dashboard", Summary: "Show the state of every repository and work in one of them", Usage: []string{"ggc repos dashboard"}},
+			},
+		},
 		{
 			Name:     "completion",
 			Category: CategoryUtility,
 			Summary:  "Print or install shell completion scripts",
 			Usage: []string{
-				"ggc completion <bash|zsh|fish>",
-				"ggc completion install <bash|zsh|fish>",
+				"ggc completion <bash|zsh|fish|powershell|nushell>",
+				"ggc completion install <bash|zsh|fish|powershell|nushell>",
 			},
 			Examples: []string{
 				"ggc completion bash                   # Print the bash completion to stdout",
 				"ggc completion install zsh            # Install zsh completion under ~/.zsh/completions/",
 				"ggc completion fish > ~/.config/fish/completions/ggc.fish",
+				"ggc completion install powershell     # Write ~/.config/powershell/completions/ggc.ps1 to load from $PROFILE",
 			},
 			Subcommands: []SubcommandInfo{
 				{
@@ -87,13 +170,30 @@ func utility() []Info {
 					Summary: "Print fish completion script",
 					Usage:   []string{"ggc completion fish"},
 				},
+				{
+					Name:    "completion powershell",
+					Summary: "Print PowerShell completion script",
+					Usage:   []string{"ggc completion powershell"},
+				},
+				{
+					Name:    "completion nushell",
+					Summary: "Print Nushell completion script",
+					Usage:   []string{"ggc completion nushell"},
+				},
 				{
 					Name:    "completion install <shell>",
-					Summary: "Install the completion script for <bash|zsh|fish>",
-					Usage:   []string{"ggc completion install <bash|zsh|fish>"},
+					Summary: "Install the completion script for <bash|zsh|fish|powershell|nushell>",
+					Usage:   []string{"ggc completion install <bash|zsh|fish|powershell|nushell>"},
 				},
 			},
 		},
+		{
+			Name:     "__complete",
+			Category: CategoryUtility,
+			Summary:  "Print dynamic completion candidates for shell scripts",
+			Usage:    []string{"ggc __complete <aliases|branch|remote-branch|remote|tag|stash|files|config-key>", "ggc __complete args <word>..."},
+			Hidden:   true,
+		},
 		{
 			Name:     "debug-keys",
 			Category: CategoryUtility,
@@ -102,11 +202,13 @@ func utility() []Info {
 				"ggc debug-keys",
 				"ggc debug-keys raw",
 				"ggc debug-keys raw <file>",
+				"ggc debug-keys --output <file>",
 			},
 			Examples: []string{
 				"ggc debug-keys                 # Show active keybindings",
 				"ggc debug-keys raw             # Capture key sequences interactively",
 				"ggc debug-keys raw keys.txt    # Capture and save to keys.txt",
+				"ggc debug-keys --output keys.txt # Same as 'raw keys.txt'",
 			},
 			Subcommands: []SubcommandInfo{
 				{
@@ -124,6 +226,11 @@ func utility() []Info {
 					Summary: "Capture key sequences and save them to a file",
 					Usage:   []string{"ggc debug-keys raw keys.txt"},
 				},
+				{
+					Name:    "debug-keys --output <file>",
+					Summary: "Capture key sequences and save them to a file",
+					Usage:   []string{"ggc debug-keys --output keys.txt"},
+				},
 			},
 		},
 		{
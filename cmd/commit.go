@@ -4,16 +4,55 @@ package cmd
 import (
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/journal"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/safety"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
+// amendChecker is what ggc commit amend reads before it rewrites HEAD.
+type amendChecker interface {
+	git.PublishedReader
+	DiffWith(args []string) (string, error)
+}
+
+// messageComposer asks the user for a commit message. It returns false
+// when the user cancels.
+type messageComposer func() (string, bool)
+
 // Committer provides functionality for the commit command.
 type Committer struct {
-	gitClient    git.CommitWriter
-	outputWriter io.Writer
-	helper       *Helper
+	gitClient     git.CommitWriter
+	outputWriter  io.Writer
+	helper        *Helper
+	undo          *Undoer
+	configManager *config.Manager
+	compose       messageComposer         // nil shows help for a bare `ggc commit`
+	messages      git.CommitMessageReader // history source for `commit lint`
+	exit          func(code int)
+	amendChecks   amendChecker       // nil amends without checking
+	rewriter      git.CommitRewriter // nil refuses `commit reword`
+	guard         *branchGuard
+	confirm       *ui.Confirmer
+	branches      currentBranchReader // nil leaves snippet variables to be asked for
+	prompter      prompt.Prompter     // asks for missing snippet variables; nil without a terminal
+	heads         headResolver        // nil refuses --copy
+	copy          func(text string) error
+	// previewAmend shows the staged changes an amend folds in and asks
+	// first; it is set when stdin is a terminal.
+	previewAmend bool
+	// interactive is set by interactive mode, where a terminal is known to
+	// be attached: a bare `ggc commit` opens compose, and lint failures
+	// are reported without ending the process.
+	interactive bool
 }
 
 // NewCommitter creates a new Committer.
@@ -22,14 +61,166 @@ func NewCommitter(client git.CommitWriter) *Committer {
 		gitClient:    client,
 		outputWriter: os.Stdout,
 		helper:       NewHelper(),
+		exit:         os.Exit,
 	}
 	c.helper.outputWriter = c.outputWriter
 	return c
 }
 
-// Commit executes the commit command with the given arguments.
+// withUndo journals amends so `ggc undo` can reverse them.
+func (c *Committer) withUndo(u *Undoer) *Committer {
+	c.undo = u
+	return c
+}
+
+// withAmendChecks makes ggc commit amend warn when HEAD has been pushed,
+// refuse on a protected branch unless confirmed, and, on a terminal, show
+// the staged changes it folds in before asking.
+func (c *Committer) withAmendChecks(checks amendChecker, guard *branchGuard, confirm *ui.Confirmer) *Committer {
+	c.amendChecks = checks
+	c.guard = guard
+	c.confirm = confirm
+	c.previewAmend = term.IsTerminal(int(os.Stdin.Fd()))
+	return c
+}
+
+// withReword enables `ggc commit reword`, which changes the message of
+// an older commit. It is checked like an amend when withAmendChecks is set.
+func (c *Committer) withReword(rewriter git.CommitRewriter) *Committer {
+	c.rewriter = rewriter
+	return c
+}
+
+// withComposer sets up the commit composer used for a bare `ggc commit`
+// in interactive mode. It is prefilled from git's commit.template and
+// follows the commit section of the ggc config.
+func (c *Committer) withComposer(reader git.ConfigReader, cm *config.Manager) *Committer {
+	c.configManager = cm
+	c.compose = func() (string, bool) {
+		cfg := c.config()
+		template, err := loadCommitTemplate(reader)
+		if err != nil {
+			WriteError(c.outputWriter, err)
+		}
+		opts := interactive.CommitComposerOptionsFromConfig(cfg, template)
+		opts.Variables = c.snippetVariables()
+		opts.Ticket = c.addTicket
+		return interactive.NewCommitComposer(opts, cfg).Run()
+	}
+	return c
+}
+
+// withLint lets `ggc commit lint` read commit messages from history.
+func (c *Committer) withLint(reader git.CommitMessageReader) *Committer {
+	c.messages = reader
+	return c
+}
+
+// config returns the loaded ggc config, or nil when none was shared.
+func (c *Committer) config() *config.Config {
+	if c.configManager == nil {
+		return nil
+	}
+	return c.configManager.GetConfig()
+}
+
+// loadCommitTemplate returns the content of the file named by git's
+// commit.template, or "" when none is configured.
+func loadCommitTemplate(reader git.ConfigReader) (string, error) {
+	path, err := reader.ConfigGet("commit.template")
+	if err != nil || path == "" {
+		// git config exits non-zero for unset keys.
+		return "", nil
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Commit executes the commit command with the given arguments. --sign
+// and --no-sign, anywhere in args, override commit.gpgsign for this
+// commit, and --copy copies the new commit's hash to the clipboard.
 func (c *Committer) Commit(args []string) {
+	args, copy := cutCopyFlag(args)
+	if copy {
+		if c.heads == nil {
+			WriteError(c.outputWriter, errNoCopy)
+			return
+		}
+		defer c.copyNewHead(c.head())
+	}
+	args, sign := cutSignFlags(args)
+	if sign == nil {
+		c.run(args)
+		return
+	}
+	signer, ok := c.gitClient.(git.CommitSigner)
+	if !ok {
+		WriteErrorf(c.outputWriter, "commit signing is not supported here")
+		return
+	}
+	signed := *c
+	signed.gitClient = signer.WithCommitSigning(*sign)
+	signed.run(args)
+}
+
+// withClipboard lets ggc commit --copy copy the hash of the new commit,
+// read from heads.
+func (c *Committer) withClipboard(heads headResolver, copy func(text string) error) *Committer {
+	c.heads = heads
+	c.copy = copy
+	return c
+}
+
+// head returns the commit HEAD points at, "" before the first commit.
+func (c *Committer) head() string {
+	hash, err := c.heads.RevParse("HEAD")
+	if err != nil {
+		return ""
+	}
+	return hash
+}
+
+// copyNewHead copies the hash of HEAD when it moved from before, that is
+// when a commit was made.
+func (c *Committer) copyNewHead(before string) {
+	if after := c.head(); after != "" && after != before {
+		copyResult(c.outputWriter, c.copy, after)
+	}
+}
+
+// cutSignFlags removes --sign and --no-sign from args. sign is nil when
+// neither is present; the last one wins.
+func cutSignFlags(args []string) (rest []string, sign *bool) {
+	rest = make([]string, 0, len(args))
+	for _, arg := range args {
+		switch arg {
+		case "--sign", "-S":
+			on := true
+			sign = &on
+		case "--no-sign":
+			off := false
+			sign = &off
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, sign
+}
+
+func (c *Committer) run(args []string) {
 	if len(args) == 0 {
+		if c.interactive && c.compose != nil {
+			c.composeCommit()
+			return
+		}
 		c.helper.ShowCommitHelp()
 		return
 	}
@@ -41,11 +232,29 @@ func (c *Committer) Commit(args []string) {
 		c.handleAmendCommand(args[1:])
 	case "fixup":
 		c.handleFixupCommand(args[1:])
+	case "reword":
+		c.handleRewordCommand(args[1:])
+	case "lint":
+		c.handleLintCommand(args[1:])
+	case "-m", "--message":
+		c.handleTemplateCommit(args[1:])
 	default:
 		c.handleDefaultCommit(args)
 	}
 }
 
+// composeCommit commits with a message written in the commit composer.
+func (c *Committer) composeCommit() {
+	msg, ok := c.compose()
+	if !ok {
+		WriteLine(c.outputWriter, "Commit canceled")
+		return
+	}
+	if err := c.gitClient.Commit(msg); err != nil {
+		WriteError(c.outputWriter, err)
+	}
+}
+
 // handleAllowCommand handles the "allow" subcommand
 func (c *Committer) handleAllowCommand(args []string) {
 	if len(args) >= 1 && args[0] == "empty" {
@@ -57,23 +266,144 @@ func (c *Committer) handleAllowCommand(args []string) {
 	c.helper.ShowCommitHelp()
 }
 
-// handleAmendCommand handles the "amend" subcommand
+// handleAmendCommand handles the "amend" subcommand: ggc commit amend
+// [no-edit | --no-edit] [--reset-author] [<message>].
 func (c *Committer) handleAmendCommand(args []string) {
+	opts, unsafe := parseAmendArgs(args)
+	if !c.checkAmend(unsafe) {
+		return
+	}
+	pending := c.undo.begin(journal.KindAmend, strings.TrimSpace("commit amend "+strings.Join(args, " ")))
+	var err error
 	switch {
-	case len(args) == 0:
-		if err := c.gitClient.CommitAmend(); err != nil {
-			WriteError(c.outputWriter, err)
+	case opts.ResetAuthor:
+		err = c.gitClient.CommitAmendWith(opts)
+	case opts.Message != "":
+		err = c.gitClient.CommitAmendWithMessage(opts.Message)
+	case opts.NoEdit:
+		err = c.gitClient.CommitAmendNoEdit()
+	default:
+		err = c.gitClient.CommitAmend()
+	}
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	c.undo.commit(pending)
+}
+
+// parseAmendArgs splits the options of ggc commit amend from its message.
+// unsafe reports --force-unsafe, which skips the protected-branch check.
+func parseAmendArgs(args []string) (opts git.AmendOptions, unsafe bool) {
+	args, unsafe = safety.CutForceFlag(args)
+	words := make([]string, 0, len(args))
+	for i, arg := range args {
+		switch {
+		case arg == "--no-edit", arg == "no-edit" && i == 0:
+			opts.NoEdit = true
+		case arg == "--reset-author":
+			opts.ResetAuthor = true
+		default:
+			words = append(words, arg)
 		}
-	case args[0] == "no-edit":
-		if err := c.gitClient.CommitAmendNoEdit(); err != nil {
+	}
+	opts.Message = strings.Join(words, " ")
+	return opts, unsafe
+}
+
+// checkAmend reports whether amending HEAD may go ahead. Amending a commit
+// that is already on a remote branch rewrites published history: ggc warns,
+// and on a protected branch asks first or needs --force-unsafe. On a
+// terminal it then shows the staged changes the amend folds in and asks.
+func (c *Committer) checkAmend(unsafe bool) bool {
+	if c.amendChecks == nil {
+		return true
+	}
+	remotes, err := c.amendChecks.RemoteBranchesContaining("HEAD")
+	if err != nil {
+		// Without a commit or remote branches there is nothing to protect.
+		remotes = nil
+	}
+	if len(remotes) > 0 {
+		if err := c.guard.checkCurrent("amend pushed commits on", unsafe); err != nil {
 			WriteError(c.outputWriter, err)
+			return false
 		}
-	default:
-		msg := strings.Join(args, " ")
-		if err := c.gitClient.CommitAmendWithMessage(msg); err != nil {
+		WriteLinef(c.outputWriter, "Warning: HEAD is already on %s. Amending rewrites published history; pushing it afterwards needs 'ggc push force'.", strings.Join(remotes, ", "))
+	}
+	if !c.previewAmend {
+		return true
+	}
+
+	staged, err := c.amendChecks.DiffWith([]string{"--staged"})
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return false
+	}
+	question := "Amend HEAD anyway?"
+	if strings.TrimSpace(staged) != "" {
+		WriteLine(c.outputWriter, "Staged changes to fold into HEAD:")
+		if err := newHighlighter(c.configManager).Render(c.outputWriter, staged); err != nil {
 			WriteError(c.outputWriter, err)
+			return false
 		}
+		question = "Amend HEAD with these changes?"
+	} else if len(remotes) == 0 {
+		// Only the message changes, and nobody has the commit yet.
+		return true
+	}
+	ok, err := c.confirm.Confirm(question)
+	return proceed(c.outputWriter, ok, err)
+}
+
+// handleRewordCommand handles the "reword" subcommand: ggc commit reword
+// <commit> [<message>]. Without a message git opens the editor on the
+// current one.
+func (c *Committer) handleRewordCommand(args []string) {
+	args, unsafe := safety.CutForceFlag(args)
+	if len(args) == 0 {
+		WriteErrorf(c.outputWriter, "commit reference required for reword")
+		c.helper.ShowCommitHelp()
+		return
+	}
+	if c.rewriter == nil {
+		WriteErrorf(c.outputWriter, "rewording commits is not supported here")
+		return
+	}
+	commit, message := args[0], strings.Join(args[1:], " ")
+	if !c.checkReword(commit, unsafe) {
+		return
+	}
+	pending := c.undo.begin(journal.KindRebase, "commit reword "+commit)
+	if err := c.rewriter.RewordCommit(commit, message); err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	c.undo.commit(pending)
+}
+
+// checkReword reports whether rewording commit may go ahead. Like an
+// amend, rewording a pushed commit rewrites published history: ggc warns,
+// asks first on a terminal and, on a protected branch, needs confirmation
+// or --force-unsafe.
+func (c *Committer) checkReword(commit string, unsafe bool) bool {
+	if c.amendChecks == nil {
+		return true
+	}
+	remotes, err := c.amendChecks.RemoteBranchesContaining(commit)
+	if err != nil || len(remotes) == 0 {
+		return true
+	}
+	if err := c.guard.checkCurrent("reword pushed commits on", unsafe); err != nil {
+		WriteError(c.outputWriter, err)
+		return false
+	}
+	WriteLinef(c.outputWriter, "Warning: %s is already on %s. Rewording it rewrites it and every commit after it; pushing them afterwards needs 'ggc push force'.", commit, strings.Join(remotes, ", "))
+	if !c.previewAmend {
+		return true
 	}
+	ok, err := c.confirm.Confirm("Reword it anyway?")
+	return proceed(c.outputWriter, ok, err)
 }
 
 // handleFixupCommand handles the "fixup" subcommand
@@ -90,7 +420,7 @@ func (c *Committer) handleFixupCommand(args []string) {
 
 // handleDefaultCommit handles regular commit with message
 func (c *Committer) handleDefaultCommit(args []string) {
-	msg := strings.Join(args, " ")
+	msg := c.addTicket(strings.Join(args, " "))
 	if err := c.gitClient.Commit(msg); err != nil {
 		WriteError(c.outputWriter, err)
 	}
@@ -0,0 +1,148 @@
+// Package cmd provides command implementations for the ggc CLI tool.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/commitmsg"
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// lintArgs are the parsed flags of `ggc commit lint`.
+type lintArgs struct {
+	revRange string
+	file     string
+	fix      bool
+}
+
+func parseLintArgs(args []string) (lintArgs, error) {
+	var la lintArgs
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--fix":
+			la.fix = true
+		case arg == "--range" || arg == "--file":
+			if i+1 >= len(args) {
+				return la, fmt.Errorf("%s requires a value", arg)
+			}
+			i++
+			if arg == "--range" {
+				la.revRange = args[i]
+			} else {
+				la.file = args[i]
+			}
+		case strings.HasPrefix(arg, "--range="):
+			la.revRange = strings.TrimPrefix(arg, "--range=")
+		case strings.HasPrefix(arg, "--file="):
+			la.file = strings.TrimPrefix(arg, "--file=")
+		default:
+			return la, fmt.Errorf("unknown argument %q", arg)
+		}
+	}
+	if la.revRange != "" && la.file != "" {
+		return la, fmt.Errorf("--range and --file cannot be combined")
+	}
+	return la, nil
+}
+
+// handleLintCommand checks commit messages against Conventional Commits.
+// It reads HEAD by default, every commit in --range, or the message file
+// given by --file (as passed to a commit-msg hook). Violations make the
+// process exit with status 1 outside interactive mode.
+func (c *Committer) handleLintCommand(args []string) {
+	la, err := parseLintArgs(args)
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		c.helper.ShowCommitHelp()
+		c.fail()
+		return
+	}
+
+	messages, err := c.lintInputs(la)
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		c.fail()
+		return
+	}
+	if len(messages) == 0 {
+		WriteLine(c.outputWriter, "No commits to lint.")
+		return
+	}
+
+	opts := c.lintOptions()
+	failed := 0
+	for _, m := range messages {
+		violations := commitmsg.Lint(m.Message, opts)
+		if len(violations) == 0 {
+			continue
+		}
+		failed++
+		header, _, _ := strings.Cut(m.Message, "\n")
+		WriteLinef(c.outputWriter, "✗ %s %s", m.Hash, header)
+		for _, v := range violations {
+			WriteLinef(c.outputWriter, "    %s: %s", v.Rule, v.Message)
+		}
+		if !la.fix {
+			continue
+		}
+		if fixed, ok := commitmsg.SuggestFix(m.Message, opts); ok {
+			WriteLine(c.outputWriter, "    suggested:")
+			for _, line := range strings.Split(fixed, "\n") {
+				WriteLine(c.outputWriter, strings.TrimRight("      "+line, " "))
+			}
+		}
+	}
+
+	if failed == 0 {
+		WriteLinef(c.outputWriter, "✓ %d commit message(s) follow Conventional Commits", len(messages))
+		return
+	}
+	WriteLinef(c.outputWriter, "%d of %d commit message(s) failed lint", failed, len(messages))
+	c.fail()
+}
+
+// lintInputs collects the messages to lint. A message file is labeled with
+// its path in place of a hash.
+func (c *Committer) lintInputs(la lintArgs) ([]git.CommitMessage, error) {
+	if la.file != "" {
+		data, err := os.ReadFile(la.file)
+		if err != nil {
+			return nil, err
+		}
+		msg := commitmsg.StripComments(string(data))
+		if msg == "" {
+			return nil, nil
+		}
+		return []git.CommitMessage{{Hash: la.file, Message: msg}}, nil
+	}
+	if c.messages == nil {
+		return nil, fmt.Errorf("commit history is not available")
+	}
+	return c.messages.CommitMessages(la.revRange)
+}
+
+// lintOptions applies the commit section of the config: allowed types and
+// scopes, and the subject length limit shared with the composer.
+func (c *Committer) lintOptions() commitmsg.LintOptions {
+	cfg := c.config()
+	if cfg == nil {
+		return commitmsg.LintOptions{}
+	}
+	return commitmsg.LintOptions{
+		Types:           cfg.Commit.Types,
+		Scopes:          cfg.Commit.Scopes,
+		HeaderMaxLength: cfg.Commit.SubjectMaxLength,
+	}
+}
+
+// fail ends the process with status 1 so scripts and hooks see the
+// failure. Interactive mode keeps running instead.
+func (c *Committer) fail() {
+	if c.interactive || c.exit == nil {
+		return
+	}
+	c.exit(1)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockCommitMessages struct {
+	testutil.MockGitClient
+	messages []git.CommitMessage
+	gotRange string
+}
+
+func (m *mockCommitMessages) CommitMessages(revRange string) ([]git.CommitMessage, error) {
+	m.gotRange = revRange
+	return m.messages, nil
+}
+
+func newTestLintCommitter(m *mockCommitMessages) (*Committer, *bytes.Buffer, *int) {
+	var buf bytes.Buffer
+	exitCode := -1
+	c := &Committer{
+		gitClient:    m,
+		outputWriter: &buf,
+		helper:       NewHelper(),
+		messages:     m,
+		exit:         func(code int) { exitCode = code },
+	}
+	c.helper.outputWriter = &buf
+	return c, &buf, &exitCode
+}
+
+func TestCommitter_Lint_Range(t *testing.T) {
+	m := &mockCommitMessages{messages: []git.CommitMessage{
+		{Hash: "aaa1111", Message: "feat(cli): add lint"},
+		{Hash: "bbb2222", Message: "Fix the crash."},
+		{Hash: "ccc3333", Message: "Merge branch 'main'"},
+	}}
+	c, buf, exitCode := newTestLintCommitter(m)
+
+	c.Commit([]string{"lint", "--range", "main..HEAD", "--fix"})
+
+	out := buf.String()
+	if m.gotRange != "main..HEAD" {
+		t.Errorf("range = %q", m.gotRange)
+	}
+	if strings.Contains(out, "aaa1111") || strings.Contains(out, "ccc3333") {
+		t.Errorf("valid and merge commits should pass: %s", out)
+	}
+	for _, want := range []string{"✗ bbb2222 Fix the crash.", "header-format", "suggested:", "      fix: Fix the crash", "1 of 3"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q:\n%s", want, out)
+		}
+	}
+	if *exitCode != 1 {
+		t.Errorf("exit code = %d, want 1", *exitCode)
+	}
+}
+
+func TestCommitter_Lint_PassesAndConfig(t *testing.T) {
+	m := &mockCommitMessages{messages: []git.CommitMessage{{Hash: "aaa1111", Message: "deploy(api): ship it"}}}
+	c, buf, exitCode := newTestLintCommitter(m)
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().Commit.Types = []string{"deploy"}
+	c.configManager = cm
+
+	c.Commit([]string{"lint"})
+
+	if m.gotRange != "" {
+		t.Errorf("default should lint HEAD only, got range %q", m.gotRange)
+	}
+	if !strings.Contains(buf.String(), "✓ 1 commit message(s)") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+	if *exitCode != -1 {
+		t.Errorf("passing lint must not exit, got %d", *exitCode)
+	}
+}
+
+func TestCommitter_Lint_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(path, []byte("wip\n# Please enter the commit message\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, buf, exitCode := newTestLintCommitter(&mockCommitMessages{})
+
+	c.Commit([]string{"lint", "--file", path})
+
+	if !strings.Contains(buf.String(), "✗ "+path+" wip") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+	if *exitCode != 1 {
+		t.Errorf("exit code = %d, want 1", *exitCode)
+	}
+}
+
+func TestCommitter_Lint_InteractiveDoesNotExit(t *testing.T) {
+	c, _, exitCode := newTestLintCommitter(&mockCommitMessages{messages: []git.CommitMessage{{Hash: "a", Message: "bad"}}})
+	c.interactive = true
+
+	c.Commit([]string{"lint"})
+
+	if *exitCode != -1 {
+		t.Errorf("interactive mode must not exit, got %d", *exitCode)
+	}
+}
+
+func TestParseLintArgs(t *testing.T) {
+	got, err := parseLintArgs([]string{"--range=v1..v2", "--fix"})
+	if err != nil || got.revRange != "v1..v2" || !got.fix {
+		t.Errorf("parseLintArgs() = %+v, %v", got, err)
+	}
+	for _, args := range [][]string{{"--range"}, {"--bogus"}, {"--range", "a..b", "--file", "f"}} {
+		if _, err := parseLintArgs(args); err == nil {
+			t.Errorf("parseLintArgs(%v) should fail", args)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/commitmsg"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/ticket"
+)
+
+// currentBranchReader supplies the branch name that snippet variables
+// such as {TICKET}, and ticket references, are taken from.
+type currentBranchReader interface {
+	GetCurrentBranch() (string, error)
+}
+
+// withSnippets expands the snippets and {NAME} variables of
+// `ggc commit -m` and of the commit composer, taking variables from the
+// current branch name, and adds the branch's ticket to messages when
+// tickets.commit is set. Missing variables are asked for when stdin is a
+// terminal.
+func (c *Committer) withSnippets(branches currentBranchReader) *Committer {
+	c.branches = branches
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		c.prompter = prompt.New(os.Stdin, c.outputWriter)
+	}
+	return c
+}
+
+// currentBranch returns the branch messages are written on, "" when it
+// cannot be read.
+func (c *Committer) currentBranch() string {
+	if c.branches == nil {
+		return ""
+	}
+	branch, err := c.branches.GetCurrentBranch()
+	if err != nil || branch == "HEAD" {
+		return ""
+	}
+	return branch
+}
+
+// snippetVariables returns the variables taken from the current branch,
+// none when it cannot be read. {TICKET} follows tickets.rules unless
+// snippets.variables sets it.
+func (c *Committer) snippetVariables() map[string]string {
+	branch := c.currentBranch()
+	if branch == "" {
+		return nil
+	}
+	cfg := c.config()
+	var patterns map[string]string
+	if cfg != nil {
+		patterns = cfg.Snippets.Variables
+	}
+	vars := commitmsg.BranchVariables(branch, patterns)
+	if _, set := patterns["TICKET"]; !set {
+		if t, ok := branchTicket(cfg, branch); ok {
+			vars["TICKET"] = t.ID
+		}
+	}
+	return vars
+}
+
+// addTicket adds the reference of the current branch's ticket to msg when
+// tickets.commit asks for it.
+func (c *Committer) addTicket(msg string) string {
+	cfg := c.config()
+	if cfg == nil || cfg.Tickets.Commit == "" {
+		return msg
+	}
+	branch := c.currentBranch()
+	if branch == "" {
+		return msg
+	}
+	t, ok := branchTicket(cfg, branch)
+	if !ok {
+		return msg
+	}
+	return ticket.Apply(msg, t, ticket.Placement(cfg.Tickets.Commit))
+}
+
+// expandMessage fills in the snippets and variables of a ggc commit -m
+// template.
+func (c *Committer) expandMessage(template string) (string, error) {
+	e := &commitmsg.Expander{Variables: c.snippetVariables()}
+	if cfg := c.config(); cfg != nil {
+		e.Snippets = cfg.Snippets.Commit
+	}
+	if c.prompter != nil {
+		e.Ask = func(name string) (string, error) {
+			value, canceled, err := c.prompter.Input(fmt.Sprintf("Value for {%s}: ", name))
+			if canceled {
+				return "", errors.New("commit canceled")
+			}
+			return strings.TrimSpace(value), err
+		}
+	}
+	return e.Expand(template)
+}
+
+// handleTemplateCommit commits with the message of ggc commit -m, after
+// expanding its snippets and variables.
+func (c *Committer) handleTemplateCommit(args []string) {
+	template := strings.Join(args, " ")
+	if strings.TrimSpace(template) == "" {
+		WriteErrorf(c.outputWriter, "commit -m expects a message")
+		return
+	}
+	msg, err := c.expandMessage(template)
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	if err := c.gitClient.Commit(c.addTicket(strings.TrimSpace(msg))); err != nil {
+		WriteError(c.outputWriter, err)
+	}
+}
@@ -3,8 +3,18 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/safety"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 // mockGitClient for commit_test (minimal CommitWriter)
@@ -18,6 +28,7 @@ type mockCommitGitClient struct {
 	commitFixupArg               string
 	commitMessage                string
 	amendMessage                 string
+	amendOptions                 *git.AmendOptions
 	err                          error
 }
 
@@ -45,6 +56,10 @@ func (m *mockCommitGitClient) CommitAmendWithMessage(message string) error {
 	m.amendMessage = message
 	return m.err
 }
+func (m *mockCommitGitClient) CommitAmendWith(opts git.AmendOptions) error {
+	m.amendOptions = &opts
+	return m.err
+}
 func (m *mockCommitGitClient) CommitFixup(commit string) error {
 	m.commitFixupCalled = true
 	m.commitFixupArg = commit
@@ -236,6 +251,170 @@ func TestCommitter_Commit_Amend_WithMultiWordMessage(t *testing.T) {
 	}
 }
 
+func TestParseAmendArgs(t *testing.T) {
+	tests := []struct {
+		args   []string
+		want   git.AmendOptions
+		unsafe bool
+	}{
+		{nil, git.AmendOptions{}, false},
+		{[]string{"no-edit"}, git.AmendOptions{NoEdit: true}, false},
+		{[]string{"--no-edit", "--reset-author"}, git.AmendOptions{NoEdit: true, ResetAuthor: true}, false},
+		{[]string{"--reset-author", "fix:", "typo", "--force-unsafe"}, git.AmendOptions{Message: "fix: typo", ResetAuthor: true}, true},
+		{[]string{"docs:", "explain", "no-edit"}, git.AmendOptions{Message: "docs: explain no-edit"}, false},
+	}
+	for _, tt := range tests {
+		opts, unsafe := parseAmendArgs(tt.args)
+		if opts != tt.want || unsafe != tt.unsafe {
+			t.Errorf("parseAmendArgs(%q) = %+v, %v; want %+v, %v", tt.args, opts, unsafe, tt.want, tt.unsafe)
+		}
+	}
+}
+
+type stubAmendChecks struct {
+	remotes []string
+	staged  string
+}
+
+func (s stubAmendChecks) RemoteBranchesContaining(string) ([]string, error) { return s.remotes, nil }
+func (s stubAmendChecks) DiffWith([]string) (string, error)                 { return s.staged, nil }
+
+type stubCurrentBranch string
+
+func (b stubCurrentBranch) GetCurrentBranch() (string, error) { return string(b), nil }
+
+func TestCommitter_Commit_Amend_Pushed(t *testing.T) {
+	tests := []struct {
+		name      string
+		branch    string
+		args      []string
+		wantAmend bool
+		wantOut   string
+	}{
+		{"unprotected branch warns", "feature", []string{"amend", "--no-edit"}, true, "Warning: HEAD is already on origin/feature"},
+		{"protected branch refuses", "main", []string{"amend", "--no-edit"}, false, "refusing to amend pushed commits on protected branch 'main'"},
+		{"--force-unsafe", "main", []string{"amend", "--no-edit", "--force-unsafe"}, true, "Warning: HEAD is already on origin/main"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mockClient := &mockCommitGitClient{}
+			c := &Committer{gitClient: mockClient, outputWriter: &buf, helper: NewHelper()}
+			guard := &branchGuard{protection: safety.NewProtection([]string{"main"}), branches: stubCurrentBranch(tt.branch)}
+			c.withAmendChecks(stubAmendChecks{remotes: []string{"origin/" + tt.branch}}, guard, nil)
+			c.previewAmend = false
+
+			c.Commit(tt.args)
+			if mockClient.commitAmendNoEditCalled != tt.wantAmend {
+				t.Errorf("amended = %v, want %v", mockClient.commitAmendNoEditCalled, tt.wantAmend)
+			}
+			if !strings.Contains(buf.String(), tt.wantOut) {
+				t.Errorf("output lacks %q:\n%s", tt.wantOut, buf.String())
+			}
+		})
+	}
+}
+
+func TestCommitter_Commit_Amend_Preview(t *testing.T) {
+	tests := []struct {
+		name      string
+		checks    stubAmendChecks
+		answer    string
+		wantAmend bool
+		wantAsk   bool
+	}{
+		{"staged changes confirmed", stubAmendChecks{staged: "+new line\n"}, "y\n", true, true},
+		{"staged changes declined", stubAmendChecks{staged: "+new line\n"}, "n\n", false, true},
+		{"message only, unpublished", stubAmendChecks{}, "", true, false},
+		{"message only, pushed", stubAmendChecks{remotes: []string{"origin/feature"}}, "n\n", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mockClient := &mockCommitGitClient{}
+			c := &Committer{gitClient: mockClient, outputWriter: &buf, helper: NewHelper()}
+			confirm := ui.NewConfirmer(prompt.New(strings.NewReader(tt.answer), &buf), true, ui.ConfirmSimple)
+			c.withAmendChecks(tt.checks, nil, confirm)
+			c.previewAmend = true
+
+			c.Commit([]string{"amend", "--reset-author", "--no-edit"})
+			if amended := mockClient.amendOptions != nil; amended != tt.wantAmend {
+				t.Errorf("amended = %v, want %v", amended, tt.wantAmend)
+			}
+			if asked := strings.Contains(buf.String(), "[y/N]"); asked != tt.wantAsk {
+				t.Errorf("asked = %v, want %v; output:\n%s", asked, tt.wantAsk, buf.String())
+			}
+			if tt.checks.staged != "" && !strings.Contains(buf.String(), "new line") {
+				t.Errorf("the staged diff was not shown:\n%s", buf.String())
+			}
+		})
+	}
+}
+
+type stubRewriter struct {
+	commit, message string
+	called          bool
+}
+
+func (r *stubRewriter) RewordCommit(commit, message string) error {
+	r.commit, r.message, r.called = commit, message, true
+	return nil
+}
+
+func TestCommitter_Commit_Reword(t *testing.T) {
+	tests := []struct {
+		name       string
+		branch     string
+		remotes    []string
+		args       []string
+		answer     string
+		terminal   bool
+		wantReword bool
+		wantOut    string
+	}{
+		{"unpublished", "feature", nil, []string{"reword", "HEAD~2", "fix:", "handle", "empty", "input"}, "", true, true, ""},
+		{"pushed, confirmed", "feature", []string{"origin/feature"}, []string{"reword", "HEAD~2"}, "y\n", true, true, "Warning: HEAD~2 is already on origin/feature"},
+		{"pushed, declined", "feature", []string{"origin/feature"}, []string{"reword", "HEAD~2"}, "n\n", true, false, "Reword it anyway?"},
+		{"pushed, no terminal", "feature", []string{"origin/feature"}, []string{"reword", "HEAD~2"}, "", false, true, "ggc push force"},
+		{"protected branch", "main", []string{"origin/main"}, []string{"reword", "HEAD~2"}, "", false, false, "refusing to reword pushed commits on protected branch 'main'"},
+		{"--force-unsafe", "main", []string{"origin/main"}, []string{"reword", "HEAD~2", "--force-unsafe"}, "", false, true, "Warning"},
+		{"no commit", "feature", nil, []string{"reword"}, "", false, false, "commit reference required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			rewriter := &stubRewriter{}
+			c := &Committer{gitClient: &mockCommitGitClient{}, outputWriter: &buf, helper: NewHelper()}
+			c.helper.outputWriter = &buf
+			guard := &branchGuard{protection: safety.NewProtection([]string{"main"}), branches: stubCurrentBranch(tt.branch)}
+			confirm := ui.NewConfirmer(prompt.New(strings.NewReader(tt.answer), &buf), true, ui.ConfirmSimple)
+			c.withAmendChecks(stubAmendChecks{remotes: tt.remotes}, guard, confirm).withReword(rewriter)
+			c.previewAmend = tt.terminal
+
+			c.Commit(tt.args)
+			if rewriter.called != tt.wantReword {
+				t.Fatalf("reworded = %v, want %v; output:\n%s", rewriter.called, tt.wantReword, buf.String())
+			}
+			if tt.wantReword && rewriter.commit != "HEAD~2" {
+				t.Errorf("reworded %q, want HEAD~2", rewriter.commit)
+			}
+			if !strings.Contains(buf.String(), tt.wantOut) {
+				t.Errorf("output lacks %q:\n%s", tt.wantOut, buf.String())
+			}
+		})
+	}
+}
+
+func TestCommitter_Commit_Reword_Message(t *testing.T) {
+	rewriter := &stubRewriter{}
+	c := &Committer{gitClient: &mockCommitGitClient{}, outputWriter: &bytes.Buffer{}, helper: NewHelper()}
+	c.withReword(rewriter)
+	c.Commit([]string{"reword", "abc1234", "fix:", "handle", "empty", "input"})
+	if rewriter.message != "fix: handle empty input" {
+		t.Errorf("message = %q", rewriter.message)
+	}
+}
+
 func TestCommitter_Commit_Fixup(t *testing.T) {
 	var buf bytes.Buffer
 	mockClient := &mockCommitGitClient{}
@@ -286,3 +465,230 @@ func TestCommitter_Commit_Fixup_Error(t *testing.T) {
 		t.Errorf("Expected error message, got: %s", buf.String())
 	}
 }
+
+func TestCommitter_Commit_Composer(t *testing.T) {
+	tests := []struct {
+		name        string
+		interactive bool
+		message     string
+		ok          bool
+		wantCommit  string
+		wantOutput  string
+	}{
+		{name: "commits the composed message", interactive: true, message: "feat: add composer\n\nBody", ok: true, wantCommit: "feat: add composer\n\nBody"},
+		{name: "canceled", interactive: true, ok: false, wantOutput: "Commit canceled"},
+		{name: "outside interactive mode shows help", interactive: false, ok: true, message: "unused", wantOutput: "Usage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockCommitGitClient{}
+			var buf bytes.Buffer
+			c := &Committer{
+				gitClient:    mockClient,
+				outputWriter: &buf,
+				helper:       NewHelper(),
+				compose:      func() (string, bool) { return tt.message, tt.ok },
+				interactive:  tt.interactive,
+			}
+
+			c.helper.outputWriter = &buf
+			c.Commit(nil)
+
+			if mockClient.commitMessage != tt.wantCommit {
+				t.Errorf("committed %q, want %q", mockClient.commitMessage, tt.wantCommit)
+			}
+			if tt.wantOutput != "" && !strings.Contains(buf.String(), tt.wantOutput) {
+				t.Errorf("output %q should contain %q", buf.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+type templateConfigReader map[string]string
+
+func (r templateConfigReader) ConfigGet(key string) (string, error) {
+	v, ok := r[key]
+	if !ok {
+		return "", errors.New("exit status 1")
+	}
+	return v, nil
+}
+
+func TestLoadCommitTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "template.txt")
+	if err := os.WriteFile(path, []byte("Subject\n# hint\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := loadCommitTemplate(templateConfigReader{"commit.template": path})
+	if err != nil || got != "Subject\n# hint\n" {
+		t.Errorf("loadCommitTemplate() = %q, %v", got, err)
+	}
+
+	if got, err := loadCommitTemplate(templateConfigReader{}); err != nil || got != "" {
+		t.Errorf("unset template = %q, %v", got, err)
+	}
+
+	if _, err := loadCommitTemplate(templateConfigReader{"commit.template": path + ".missing"}); err == nil {
+		t.Error("expected an error for a missing template file")
+	}
+}
+
+// signingCommitGitClient records the signing override the Committer asks for.
+type signingCommitGitClient struct {
+	mockCommitGitClient
+	sign *bool
+}
+
+func (m *signingCommitGitClient) WithCommitSigning(sign bool) git.CommitWriter {
+	m.sign = &sign
+	return &m.mockCommitGitClient
+}
+
+func TestCommitter_Commit_Sign(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantSign *bool
+		wantMsg  string
+	}{
+		{"no flag", []string{"msg"}, nil, "msg"},
+		{"sign", []string{"--sign", "msg"}, boolPtr(true), "msg"},
+		{"short flag after message", []string{"msg", "-S"}, boolPtr(true), "msg"},
+		{"last flag wins", []string{"--sign", "--no-sign", "msg"}, boolPtr(false), "msg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			m := &signingCommitGitClient{}
+			c := &Committer{gitClient: m, outputWriter: &buf, helper: NewHelper()}
+			c.Commit(tt.args)
+
+			if (m.sign == nil) != (tt.wantSign == nil) || (m.sign != nil && *m.sign != *tt.wantSign) {
+				t.Errorf("signing override = %v, want %v", m.sign, tt.wantSign)
+			}
+			if m.commitMessage != tt.wantMsg {
+				t.Errorf("commit message = %q, want %q (output %q)", m.commitMessage, tt.wantMsg, buf.String())
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestCommitter_Commit_MessageTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	mockClient := &mockCommitGitClient{}
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().Snippets.Commit = map[string]string{"ticket": "[{TICKET}] "}
+	c := &Committer{
+		gitClient:     mockClient,
+		outputWriter:  &buf,
+		helper:        NewHelper(),
+		configManager: cm,
+	}
+	c.withSnippets(stubCurrentBranch("feature/proj-42-login"))
+	c.prompter = nil
+
+	c.Commit([]string{"-m", "{{ticket}}add", "login", "on", "{BRANCH}"})
+	if want := "[PROJ-42] add login on feature/proj-42-login"; mockClient.commitMessage != want {
+		t.Errorf("commit message = %q, want %q", mockClient.commitMessage, want)
+	}
+
+	mockClient.commitCalled = false
+	c.Commit([]string{"--message", "{REVIEWER} approved"})
+	if mockClient.commitCalled {
+		t.Error("a variable without a value should stop the commit without a terminal")
+	}
+	if !strings.Contains(buf.String(), "no value for {REVIEWER}") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	c.prompter = prompt.New(strings.NewReader("Ann\n"), &buf)
+	c.Commit([]string{"-m", "{REVIEWER} approved"})
+	if mockClient.commitMessage != "Ann approved" {
+		t.Errorf("commit message = %q, want the asked value", mockClient.commitMessage)
+	}
+}
+
+func TestCommitter_Commit_Ticket(t *testing.T) {
+	mockClient := &mockCommitGitClient{}
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().Tickets.Commit = "prefix"
+	c := &Committer{gitClient: mockClient, outputWriter: &bytes.Buffer{}, helper: NewHelper(), configManager: cm}
+	c.withSnippets(stubCurrentBranch("feat/ABC-123-title"))
+
+	c.Commit([]string{"feat:", "add", "title"})
+	if want := "feat: [ABC-123] add title"; mockClient.commitMessage != want {
+		t.Errorf("commit message = %q, want %q", mockClient.commitMessage, want)
+	}
+	c.Commit([]string{"-m", "{TICKET}: rename"})
+	if mockClient.commitMessage != "ABC-123: rename" {
+		t.Errorf("a message naming the ticket should be kept, got %q", mockClient.commitMessage)
+	}
+
+	cm.GetConfig().Tickets.Commit = ""
+	c.Commit([]string{"add", "title"})
+	if mockClient.commitMessage != "add title" {
+		t.Errorf("without tickets.commit the message should be kept, got %q", mockClient.commitMessage)
+	}
+}
+
+// fakeHeads resolves HEAD to each of its hashes in turn.
+type fakeHeads []string
+
+func (f *fakeHeads) RevParse(string) (string, error) {
+	if len(*f) == 0 {
+		return "", errors.New("unknown revision HEAD")
+	}
+	hash := (*f)[0]
+	*f = (*f)[1:]
+	return hash, nil
+}
+
+func TestCommitter_Commit_Copy(t *testing.T) {
+	var out bytes.Buffer
+	var copied []string
+	copy := func(text string) error {
+		copied = append(copied, text)
+		return nil
+	}
+	mockClient := &mockCommitGitClient{}
+	c := (&Committer{gitClient: mockClient, outputWriter: &out, helper: NewHelper()}).
+		withClipboard(&fakeHeads{"old", "new"}, copy)
+
+	c.Commit([]string{"add", "title", "--copy"})
+	if mockClient.commitMessage != "add title" {
+		t.Errorf("commit message = %q, want %q", mockClient.commitMessage, "add title")
+	}
+	if !slices.Equal(copied, []string{"new"}) || !strings.Contains(out.String(), "Copied new to the clipboard.") {
+		t.Errorf("copied %v, output %q", copied, out.String())
+	}
+
+	// A failed commit leaves HEAD where it was, and nothing is copied.
+	copied = nil
+	mockClient.err = errors.New("nothing to commit")
+	c.heads = &fakeHeads{"new", "new"}
+	c.Commit([]string{"add", "more", "--copy"})
+	if len(copied) != 0 {
+		t.Errorf("copied %v after a failed commit", copied)
+	}
+
+	c.heads = nil
+	out.Reset()
+	c.Commit([]string{"--copy", "add", "title"})
+	if !strings.Contains(out.String(), "--copy is not supported here") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
+
+func TestCommitter_Commit_PlainMessageIsNotExpanded(t *testing.T) {
+	mockClient := &mockCommitGitClient{}
+	c := &Committer{gitClient: mockClient, outputWriter: &bytes.Buffer{}, helper: NewHelper()}
+	c.withSnippets(stubCurrentBranch("feature/PROJ-1"))
+
+	c.Commit([]string{"keep", "{TICKET}", "literal"})
+	if mockClient.commitMessage != "keep {TICKET} literal" {
+		t.Errorf("commit message = %q", mockClient.commitMessage)
+	}
+}
@@ -6,20 +6,103 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
 )
 
 // embeddedCompletions ships the shell completion scripts inside the ggc
 // binary so `ggc completion install <shell>` works with a stock Homebrew
 // install (no access to the source tree required).
 //
-//go:embed completions/ggc.bash completions/ggc.zsh completions/ggc.fish
+//go:embed completions/ggc.bash completions/ggc.zsh completions/ggc.fish completions/ggc.ps1 completions/ggc.nu
 var embeddedCompletions embed.FS
 
-// Completer handles the `ggc completion ...` subcommand.
+// completionScripts names the embedded script of each supported shell.
+var completionScripts = map[string]string{
+	"bash":       "completions/ggc.bash",
+	"zsh":        "completions/ggc.zsh",
+	"fish":       "completions/ggc.fish",
+	"powershell": "completions/ggc.ps1",
+	"nushell":    "completions/ggc.nu",
+}
+
+// supportedShells lists the shells for messages.
+const supportedShells = "bash, zsh, fish, powershell, nushell"
+
+// completionSource reads the repository values offered as completions.
+type completionSource interface {
+	git.LocalBranchLister
+	git.RemoteNameLister
+	git.TagNameLister
+	ListRemoteBranches() ([]string, error)
+	StashList() (string, error)
+	UnstagedFiles(paths ...string) ([]string, error)
+}
+
+// completionArg says which values complete the arguments of a command.
+type completionArg struct {
+	kind string
+	max  int // arguments completed; 0 for any number
+}
+
+// completionArgs maps commands, and commands with a subcommand, to the
+// values `ggc __complete args` offers for their arguments.
+var completionArgs = map[string]completionArg{
+	"add":                {"files", 0},
+	"restore":            {"files", 0},
+	"diff":               {"branch", 2},
+	"diff unstaged":      {"files", 0},
+	"diff head":          {"files", 0},
+	"show":               {"branch", 1},
+	"switch":             {"branch", 1},
+	"merge":              {"branch", 0},
+	"rebase":             {"branch", 1},
+	"branch checkout":    {"branch", 1},
+	"branch delete":      {"branch", 0},
+	"branch rename":      {"branch", 1},
+	"branch move":        {"branch", 1},
+	"branch info":        {"branch", 1},
+	"branch track":       {"remote-branch", 1},
+	"branch untrack":     {"branch", 1},
+	"cherry-pick select": {"branch", 1},
+	"ticket":             {"branch", 1},
+	"ticket open":        {"branch", 1},
+	"remote remove":      {"remote", 1},
+	"remote set-url":     {"remote", 1},
+	"remote rename":      {"remote", 1},
+	"remote convert":     {"remote", 1},
+	"tag delete":         {"tag", 0},
+	"tag show":           {"tag", 1},
+	"tag notes":          {"tag", 1},
+	"stash show":         {"stash", 1},
+	"stash apply":        {"stash", 1},
+	"stash pop":          {"stash", 1},
+	"stash drop":         {"stash", 1},
+	"config get":         {"config-key", 1},
+	"config set":         {"config-key", 1},
+	"config describe":    {"config-key", 1},
+}
+
+// completionFlags maps flags to the values that complete their argument.
+var completionFlags = map[string]string{
+	"--from":   "tag",
+	"--to":     "tag",
+	"--base":   "branch",
+	"--detach": "branch",
+}
+
+// Completer handles the `ggc completion ...` subcommand and the hidden
+// `ggc __complete ...` command the generated scripts call back into.
 type Completer struct {
-	outputWriter io.Writer
-	userHomeDir  func() (string, error)
-	helper       *Helper
+	outputWriter  io.Writer
+	userHomeDir   func() (string, error)
+	helper        *Helper
+	configManager *config.Manager
+	source        completionSource // nil: only aliases complete
 }
 
 // NewCompleter returns a Completer writing to stdout.
@@ -31,6 +114,192 @@ func NewCompleter() *Completer {
 	}
 }
 
+// withConfigManager shares the loaded config so `__complete aliases` can
+// list user-defined aliases.
+func (c *Completer) withConfigManager(cm *config.Manager) *Completer {
+	c.configManager = cm
+	return c
+}
+
+// withGit lets `__complete` offer branches, remotes, tags, stashes and
+// files from the repository.
+func (c *Completer) withGit(source completionSource) *Completer {
+	c.source = source
+	return c
+}
+
+// Complete prints one completion candidate per line for the requested
+// kind. It is called by the shell scripts, so unknown kinds and errors
+// print nothing rather than noise the shell would offer as candidates.
+//
+// `__complete args <word>...` takes the words typed after ggc, without
+// the one being completed, and works out the kind from the command, the
+// way cobra's __complete does; the scripts fall back to it for every
+// argument they do not complete themselves.
+func (c *Completer) Complete(args []string) {
+	if len(args) == 0 {
+		return
+	}
+	if args[0] == "args" {
+		words := c.expand(args[1:])
+		kind := argsCompletionKind(words)
+		if kind == "" {
+			if names := c.abbreviatedSubcommands(args[1:], words); names != nil {
+				c.printCandidates(names)
+				return
+			}
+		}
+		args = []string{kind}
+	}
+	var candidates []string
+	switch args[0] {
+	case "aliases":
+		candidates = c.aliases()
+	case "branch":
+		candidates = c.read(func(s completionSource) ([]string, error) { return s.ListLocalBranches() })
+	case "remote-branch":
+		candidates = c.read(func(s completionSource) ([]string, error) { return s.ListRemoteBranches() })
+	case "remote":
+		candidates = c.read(func(s completionSource) ([]string, error) { return s.RemoteNames() })
+	case "tag":
+		candidates = c.read(func(s completionSource) ([]string, error) { return s.TagNames() })
+	case "stash":
+		candidates = c.read(stashRefs)
+	case "files":
+		candidates = c.read(func(s completionSource) ([]string, error) { return s.UnstagedFiles() })
+	case "config-key":
+		candidates = c.configKeys()
+	}
+	c.printCandidates(candidates)
+}
+
+func (c *Completer) printCandidates(candidates []string) {
+	for _, candidate := range candidates {
+		_, _ = fmt.Fprintln(c.outputWriter, candidate)
+	}
+}
+
+// expand resolves abbreviated command names in words when
+// behavior.abbreviations is on, so `ggc br ch <TAB>` completes like
+// `ggc branch checkout <TAB>`.
+func (c *Completer) expand(words []string) []string {
+	if c.configManager == nil || !c.configManager.GetConfig().Behavior.Abbreviations || len(words) == 0 {
+		return words
+	}
+	expanded, err := expandAbbreviations(c.helper.registry, words)
+	if err != nil {
+		return words
+	}
+	return expanded
+}
+
+// abbreviatedSubcommands returns the subcommand names that can follow
+// words when they were abbreviated, since the scripts only list the
+// subcommands of names typed in full. It returns nil otherwise.
+func (c *Completer) abbreviatedSubcommands(typed, words []string) []string {
+	if slices.Equal(typed, words) {
+		return nil
+	}
+	info, ok := c.helper.registry.Find(words[0])
+	if !ok {
+		return nil
+	}
+	names, literal := nextWords(subcommandWords(&info), words[1:])
+	if !literal {
+		return nil
+	}
+	return names
+}
+
+// argsCompletionKind returns the kind of value that comes after words,
+// or "" when ggc has nothing to offer.
+func argsCompletionKind(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	if kind, ok := completionFlags[words[len(words)-1]]; ok {
+		return kind
+	}
+	var positional []string
+	for _, word := range words {
+		if !strings.HasPrefix(word, "-") {
+			positional = append(positional, word)
+		}
+	}
+	for _, n := range []int{2, 1} {
+		if len(positional) < n {
+			continue
+		}
+		arg, ok := completionArgs[strings.Join(positional[:n], " ")]
+		if !ok {
+			continue
+		}
+		if arg.max > 0 && len(positional)-n >= arg.max {
+			return ""
+		}
+		return arg.kind
+	}
+	return ""
+}
+
+// read runs list against the repository, treating errors, such as being
+// outside a repository, as no candidates.
+func (c *Completer) read(list func(completionSource) ([]string, error)) []string {
+	if c.source == nil {
+		return nil
+	}
+	values, err := list(c.source)
+	if err != nil {
+		return nil
+	}
+	return values
+}
+
+// stashRefs returns stash@{0}, stash@{1} and so on.
+func stashRefs(s completionSource) ([]string, error) {
+	out, err := s.StashList()
+	if err != nil {
+		return nil, err
+	}
+	var refs []string
+	for _, line := range strings.Split(out, "\n") {
+		if ref, _, ok := strings.Cut(line, ":"); ok {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// configKeys returns every configuration key, aliases and workflows
+// included.
+func (c *Completer) configKeys() []string {
+	if c.configManager == nil {
+		return nil
+	}
+	keys := c.configManager.Keys()
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.Key
+	}
+	return names
+}
+
+func (c *Completer) aliases() []string {
+	if c.configManager == nil {
+		return nil
+	}
+	cfg := c.configManager.GetConfig()
+	if cfg == nil {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Aliases))
+	for name := range cfg.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Completion dispatches the subcommand.
 func (c *Completer) Completion(args []string) {
 	if len(args) == 0 {
@@ -39,11 +308,11 @@ func (c *Completer) Completion(args []string) {
 		return
 	}
 	switch args[0] {
-	case "bash", "zsh", "fish":
+	case "bash", "zsh", "fish", "powershell", "nushell":
 		c.print(args[0])
 	case "install":
 		if len(args) < 2 {
-			_, _ = fmt.Fprintln(c.outputWriter, "usage: ggc completion install <bash|zsh|fish>")
+			_, _ = fmt.Fprintln(c.outputWriter, "usage: ggc completion install <bash|zsh|fish|powershell|nushell>")
 			return
 		}
 		c.install(args[1])
@@ -55,7 +324,7 @@ func (c *Completer) Completion(args []string) {
 
 // print emits the embedded completion script for the given shell to stdout.
 func (c *Completer) print(shell string) {
-	data, err := embeddedCompletions.ReadFile("completions/ggc." + shell)
+	data, err := embeddedCompletions.ReadFile(completionScripts[shell])
 	if err != nil {
 		_, _ = fmt.Fprintf(c.outputWriter, "unknown shell: %s\n", shell)
 		return
@@ -75,10 +344,10 @@ func (c *Completer) install(shell string) {
 	}
 	target, ok := c.targetPath(shell, home)
 	if !ok {
-		_, _ = fmt.Fprintf(c.outputWriter, "unknown shell: %s (supported: bash, zsh, fish)\n", shell)
+		_, _ = fmt.Fprintf(c.outputWriter, "unknown shell: %s (supported: %s)\n", shell, supportedShells)
 		return
 	}
-	data, err := embeddedCompletions.ReadFile("completions/ggc." + shell)
+	data, err := embeddedCompletions.ReadFile(completionScripts[shell])
 	if err != nil {
 		_, _ = fmt.Fprintf(c.outputWriter, "no embedded completion for %s: %v\n", shell, err)
 		return
@@ -92,7 +361,7 @@ func (c *Completer) install(shell string) {
 		return
 	}
 	_, _ = fmt.Fprintf(c.outputWriter, "installed %s completion to %s\n", shell, target)
-	c.printReloadHint(shell)
+	c.printReloadHint(shell, target)
 }
 
 // targetPath returns the canonical per-user install path for a shell.
@@ -107,14 +376,20 @@ func (c *Completer) targetPath(shell, home string) (string, bool) {
 		return filepath.Join(home, ".zsh/completions/_ggc"), true
 	case "fish":
 		return filepath.Join(home, ".config/fish/completions/ggc.fish"), true
+	case "powershell":
+		return filepath.Join(home, ".config/powershell/completions/ggc.ps1"), true
+	case "nushell":
+		return filepath.Join(home, ".config/nushell/completions/ggc.nu"), true
 	default:
 		return "", false
 	}
 }
 
 // printReloadHint tells the user the one manual step they still need:
-// loading the new completion in their current shell session.
-func (c *Completer) printReloadHint(shell string) {
+// loading the new completion in their current shell session. PowerShell
+// and Nushell have no directory they load completions from, so their
+// profile has to load the script at target.
+func (c *Completer) printReloadHint(shell, target string) {
 	switch shell {
 	case "bash":
 		_, _ = fmt.Fprintln(c.outputWriter, "Restart your shell or `source ~/.bashrc` to activate it.")
@@ -123,5 +398,9 @@ func (c *Completer) printReloadHint(shell string) {
 			"Ensure ~/.zsh/completions is on $fpath (e.g. add `fpath=(~/.zsh/completions $fpath)` to ~/.zshrc) and restart your shell.")
 	case "fish":
 		_, _ = fmt.Fprintln(c.outputWriter, "Fish will pick up the new completion in any new session.")
+	case "powershell":
+		_, _ = fmt.Fprintf(c.outputWriter, "Add `. %s` to your $PROFILE and restart PowerShell.\n", target)
+	case "nushell":
+		_, _ = fmt.Fprintf(c.outputWriter, "Add `source %s` to your config.nu (see `$nu.config-path`) and restart Nushell.\n", target)
 	}
 }
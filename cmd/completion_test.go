@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+func TestCompleter_CompleteAliases(t *testing.T) {
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().Aliases = map[string]interface{}{
+		"st":  "status",
+		"cmp": "add . && commit <message>",
+	}
+	var buf bytes.Buffer
+	c := NewCompleter().withConfigManager(cm)
+	c.outputWriter = &buf
+
+	c.Complete([]string{"aliases"})
+	if got := buf.String(); got != "cmp\nst\n" {
+		t.Errorf("Complete(aliases) = %q", got)
+	}
+
+	buf.Reset()
+	c.Complete([]string{"unknown"})
+	if buf.Len() != 0 {
+		t.Errorf("unknown kinds should print nothing, got %q", buf.String())
+	}
+}
+
+type mockCompletionSource struct {
+	testutil.MockGitClient
+}
+
+func (m *mockCompletionSource) ListLocalBranches() ([]string, error) {
+	return []string{"feature/x", "main"}, nil
+}
+func (m *mockCompletionSource) ListRemoteBranches() ([]string, error) {
+	return []string{"origin/main"}, nil
+}
+func (m *mockCompletionSource) RemoteNames() ([]string, error) { return []string{"origin"}, nil }
+func (m *mockCompletionSource) TagNames() ([]string, error)    { return []string{"v1.1.0", "v1.0.0"}, nil }
+func (m *mockCompletionSource) StashList() (string, error) {
+	return "stash@{0}: WIP on main: abc123 Fix\nstash@{1}: On main: spike\n", nil
+}
+
+func TestCompleter_CompleteArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"branch"}, "feature/x\nmain\n"},
+		{[]string{"args", "switch"}, "feature/x\nmain\n"},
+		{[]string{"args", "switch", "main"}, ""},
+		{[]string{"args", "branch", "delete", "main"}, "feature/x\nmain\n"},
+		{[]string{"args", "remote", "remove"}, "origin\n"},
+		{[]string{"args", "branch", "track"}, "origin/main\n"},
+		{[]string{"args", "branch", "track", "origin/main"}, ""},
+		{[]string{"args", "tag", "show"}, "v1.1.0\nv1.0.0\n"},
+		{[]string{"args", "changelog", "--from"}, "v1.1.0\nv1.0.0\n"},
+		{[]string{"args", "stash", "drop"}, "stash@{0}\nstash@{1}\n"},
+		{[]string{"args", "diff", "--stat"}, "feature/x\nmain\n"},
+		{[]string{"args", "diff", "main", "feature/x"}, ""},
+		{[]string{"args", "show"}, "feature/x\nmain\n"},
+		{[]string{"args", "show", "main"}, ""},
+		{[]string{"args", "status"}, ""},
+		{[]string{"args"}, ""},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		c := NewCompleter().withGit(&mockCompletionSource{})
+		c.outputWriter = &buf
+		c.Complete(tt.args)
+		if buf.String() != tt.want {
+			t.Errorf("Complete(%q) = %q, want %q", tt.args, buf.String(), tt.want)
+		}
+	}
+
+	// Without a repository or config, nothing is offered.
+	var buf bytes.Buffer
+	c := NewCompleter()
+	c.outputWriter = &buf
+	c.Complete([]string{"args", "config", "get"})
+	c.Complete([]string{"tag"})
+	if buf.Len() != 0 {
+		t.Errorf("got %q, want nothing", buf.String())
+	}
+}
+
+func TestCompleter_PowerShellAndNushell(t *testing.T) {
+	for shell, marker := range map[string]string{
+		"powershell": "Register-ArgumentCompleter -Native -CommandName ggc",
+		"nushell":    `export extern "ggc"`,
+	} {
+		var buf bytes.Buffer
+		c := NewCompleter()
+		c.outputWriter = &buf
+		c.Completion([]string{shell})
+		if !strings.Contains(buf.String(), marker) || !strings.Contains(buf.String(), "__complete args") {
+			t.Errorf("%s script lacks %q or the dynamic hook", shell, marker)
+		}
+
+		buf.Reset()
+		home := t.TempDir()
+		c.userHomeDir = func() (string, error) { return home, nil }
+		c.Completion([]string{"install", shell})
+		target, _ := c.targetPath(shell, home)
+		if _, err := os.Stat(target); err != nil {
+			t.Errorf("%s script not installed: %v", shell, err)
+		}
+		if !strings.Contains(buf.String(), target+"` to your") {
+			t.Errorf("%s install output %q should say how to load %s", shell, buf.String(), target)
+		}
+	}
+}
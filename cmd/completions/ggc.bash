@@ -1,6 +1,13 @@
 # bash completion for ggc
 # Code generated by go run tools/cmd/gencompletions/main.go; DO NOT EDIT.
 
+# _ggc_dynamic asks ggc which branches, remotes, tags, stashes or config
+# keys fit the words typed so far.
+_ggc_dynamic()
+{
+    ggc __complete args "${COMP_WORDS[@]:1:COMP_CWORD-1}" 2>/dev/null
+}
+
 _ggc()
 {
     local cur prev opts subopts
@@ -8,151 +15,297 @@ _ggc()
     cur="${COMP_WORDS[COMP_CWORD]}"
     prev="${COMP_WORDS[COMP_CWORD-1]}"
 
-    opts="add am archive bisect blame branch checkout cherry-pick clean commit completion config debug-keys describe diff doctor fetch format-patch fsck gc grep help history hook log maintenance merge mv notes prune pull push quit range-diff rebase reflog remote reset restore revert rm shortlog show sparse-checkout stash status submodule switch tag version worktree"
+    opts="add am analyze apply archive bisect blame branch changelog checkout cherry-pick clean clone commit completion config debug-keys describe diff doctor fetch format-patch fsck gc grep help history hook lfs log maintenance merge mv notes pr profile prune pull push quit range-diff rebase reflog release remote repos reset restore revert rm shortlog show snapshot sparse sparse-checkout stack stash stats status submodule switch sync tag ticket undo unwip verify version wip workflow worktree"
     case ${prev} in
+        am)
+            subopts="abort continue skip $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        analyze)
+            subopts="large-files scrub $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
         branch)
-            subopts="checkout contains create current delete info list move rename set sort"
+            subopts="checkout contains create current delete info list move rename set sort track untrack $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        changelog)
+            subopts="--write $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        cherry-pick)
+            subopts="abort continue select skip $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         clean)
-            subopts="dirs files interactive"
+            subopts="dirs files interactive $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         commit)
-            subopts="allow amend fixup"
+            subopts="--copy --sign -m allow amend fixup lint reword $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         completion)
-            subopts="bash fish install zsh"
+            subopts="bash fish install nushell powershell zsh $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         config)
-            subopts="get list set"
+            subopts="describe edit get keybindings list schema set signing $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         debug-keys)
-            subopts="raw"
+            subopts="--output raw $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         diff)
-            subopts="head staged unstaged"
+            subopts="--stat head staged unstaged $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        doctor)
+            subopts="auth $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         fetch)
-            subopts="prune"
+            subopts="--all --prune-tags prune $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        format-patch)
+            subopts="select $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         history)
-            subopts="clear last search"
+            subopts="clear last search $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         hook)
-            subopts="disable edit enable install list uninstall"
+            subopts="disable edit enable install list run sync templates uninstall $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        lfs)
+            subopts="pull status track untrack $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         log)
-            subopts="graph simple"
+            subopts="browse file graph simple $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        maintenance)
+            subopts="analyze run start stop unregister $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        notes)
+            subopts="add fetch list push show $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        pr)
+            subopts="checkout create list $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        profile)
+            subopts="add apply current list remove use $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         pull)
-            subopts="current rebase"
+            subopts="current rebase $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         push)
-            subopts="current force"
+            subopts="current force $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         rebase)
-            subopts="abort autosquash continue interactive skip"
+            subopts="abort autosquash continue interactive skip $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        release)
+            subopts="--dry-run --major --minor --patch --publish $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         remote)
-            subopts="add list remove set-url"
+            subopts="add convert list remove rename set-url $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        repos)
+            subopts="add dashboard exec list remove sync $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         reset)
-            subopts="hard soft"
+            subopts="hard soft $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         restore)
-            subopts="staged"
+            subopts="staged $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        revert)
+            subopts="abort continue select skip $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         show)
-            subopts="--name-only --stat"
+            subopts="--format --name-only --stat $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        snapshot)
+            subopts="create drop list prune restore $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        sparse)
+            subopts="add disable init list remove $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        stack)
+            subopts="create list restack $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         stash)
-            subopts="apply branch clear create drop list pop push save show store"
+            subopts="apply branch browse clear create drop list pop push save show store $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        stats)
+            subopts="reset $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         status)
-            subopts="short"
+            subopts="short $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         switch)
-            subopts="--detach -c"
+            subopts="--detach -c recent $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         tag)
-            subopts="annotated create delete list push show"
+            subopts="annotated create delete list notes push show $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        ticket)
+            subopts="open $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        undo)
+            subopts="list $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
         version)
-            subopts="json"
+            subopts="json $(_ggc_dynamic)"
+            COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
+            return 0
+            ;;
+        workflow)
+            subopts="add list rerun templates $(_ggc_dynamic)"
             COMPREPLY=( $(compgen -W "${subopts}" -- ${cur}) )
             return 0
             ;;
     esac
 
     if [[ ${COMP_CWORD} == 1 ]]; then
+        opts="${opts} $(ggc __complete aliases 2>/dev/null)"
         COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
         return 0
     fi
     if [[ ${COMP_WORDS[1]} == "branch" && ${COMP_WORDS[2]} == "delete" ]]; then
-        COMPREPLY=( $(compgen -W "merged" -- ${cur}) )
+        COMPREPLY=( $(compgen -W "merged $(_ggc_dynamic)" -- ${cur}) )
         return 0
     fi
     if [[ ${COMP_WORDS[1]} == "branch" && ${COMP_WORDS[2]} == "list" ]]; then
-        COMPREPLY=( $(compgen -W "local remote verbose" -- ${cur}) )
+        COMPREPLY=( $(compgen -W "local remote verbose $(_ggc_dynamic)" -- ${cur}) )
         return 0
     fi
     if [[ ${COMP_WORDS[1]} == "branch" && ${COMP_WORDS[2]} == "set" ]]; then
-        COMPREPLY=( $(compgen -W "upstream" -- ${cur}) )
+        COMPREPLY=( $(compgen -W "upstream $(_ggc_dynamic)" -- ${cur}) )
+        return 0
+    fi
+    if [[ ${COMP_WORDS[1]} == "commit" && ${COMP_WORDS[2]} == "--sign" ]]; then
+        COMPREPLY=( $(compgen -W "--no-sign / $(_ggc_dynamic)" -- ${cur}) )
         return 0
     fi
     if [[ ${COMP_WORDS[1]} == "commit" && ${COMP_WORDS[2]} == "allow" ]]; then
-        COMPREPLY=( $(compgen -W "empty" -- ${cur}) )
+        COMPREPLY=( $(compgen -W "empty $(_ggc_dynamic)" -- ${cur}) )
         return 0
     fi
     if [[ ${COMP_WORDS[1]} == "commit" && ${COMP_WORDS[2]} == "amend" ]]; then
-        COMPREPLY=( $(compgen -W "no-edit" -- ${cur}) )
+        COMPREPLY=( $(compgen -W "--reset-author no-edit $(_ggc_dynamic)" -- ${cur}) )
+        return 0
+    fi
+    if [[ ${COMP_WORDS[1]} == "config" && ${COMP_WORDS[2]} == "keybindings" ]]; then
+        COMPREPLY=( $(compgen -W "doctor show $(_ggc_dynamic)" -- ${cur}) )
+        return 0
+    fi
+    if [[ ${COMP_WORDS[1]} == "config" && ${COMP_WORDS[2]} == "schema" ]]; then
+        COMPREPLY=( $(compgen -W "--json $(_ggc_dynamic)" -- ${cur}) )
+        return 0
+    fi
+    if [[ ${COMP_WORDS[1]} == "config" && ${COMP_WORDS[2]} == "signing" ]]; then
+        COMPREPLY=( $(compgen -W "off setup show $(_ggc_dynamic)" -- ${cur}) )
+        return 0
+    fi
+    if [[ ${COMP_WORDS[1]} == "maintenance" && ${COMP_WORDS[2]} == "run" ]]; then
+        COMPREPLY=( $(compgen -W "--full --quick $(_ggc_dynamic)" -- ${cur}) )
+        return 0
+    fi
+    if [[ ${COMP_WORDS[1]} == "push" && ${COMP_WORDS[2]} == "current" ]]; then
+        COMPREPLY=( $(compgen -W "--allow-wip --no-scan --scan $(_ggc_dynamic)" -- ${cur}) )
+        return 0
+    fi
+    if [[ ${COMP_WORDS[1]} == "remote" && ${COMP_WORDS[2]} == "convert" ]]; then
+        COMPREPLY=( $(compgen -W "--https --ssh $(_ggc_dynamic)" -- ${cur}) )
+        return 0
+    fi
+    if [[ ${COMP_WORDS[1]} == "show" && ${COMP_WORDS[2]} == "--format" ]]; then
+        COMPREPLY=( $(compgen -W "json $(_ggc_dynamic)" -- ${cur}) )
         return 0
     fi
     if [[ ${COMP_WORDS[1]} == "stash" && ${COMP_WORDS[2]} == "push" ]]; then
-        COMPREPLY=( $(compgen -W "-m" -- ${cur}) )
+        COMPREPLY=( $(compgen -W "-- --all --include-untracked --staged -m select $(_ggc_dynamic)" -- ${cur}) )
+        return 0
+    fi
+    if [[ ${COMP_WORDS[1]} == "tag" && ${COMP_WORDS[2]} == "create" ]]; then
+        COMPREPLY=( $(compgen -W "--annotate --notes --sign $(_ggc_dynamic)" -- ${cur}) )
+        return 0
+    fi
+    if [[ ${COMP_WORDS[1]} == "workflow" && ${COMP_WORDS[2]} == "rerun" ]]; then
+        COMPREPLY=( $(compgen -W "--failed $(_ggc_dynamic)" -- ${cur}) )
         return 0
     fi
 
@@ -166,7 +319,7 @@ _ggc()
 
     if [[ ${COMP_WORDS[1]} == "add" ]]; then
         local files candidates extras
-        extras="interactive patch"
+        extras="explore interactive patch select"
         candidates="${extras}"
         files=$(ggc __complete files 2>/dev/null)
         if [[ -n ${files} ]]; then
@@ -188,6 +341,8 @@ _ggc()
                 ;;
         esac
     fi
+
+    COMPREPLY=( $(compgen -W "$(_ggc_dynamic)" -- ${cur}) )
 }
 
 complete -F _ggc ggc
@@ -9,37 +9,81 @@ function __ggc_complete_files
     ggc __complete files 2>/dev/null
 end
 
+function __ggc_complete_aliases
+    ggc __complete aliases 2>/dev/null
+end
+
+# Branches, remotes, tags, stashes or config keys that fit the words typed
+# so far
+function __ggc_complete_args
+    set -l words (commandline -opc)
+    ggc __complete args $words[2..-1] 2>/dev/null
+end
+
 # Main commands
-complete -c ggc -f -a "add am archive bisect blame branch checkout cherry-pick clean commit completion config debug-keys describe diff doctor fetch format-patch fsck gc grep help history hook log maintenance merge mv notes prune pull push quit range-diff rebase reflog remote reset restore revert rm shortlog show sparse-checkout stash status submodule switch tag version worktree"
-complete -c ggc -f -n "__fish_seen_subcommand_from branch" -a "checkout contains create current delete info list move rename set sort"
+complete -c ggc -f -a "add am analyze apply archive bisect blame branch changelog checkout cherry-pick clean clone commit completion config debug-keys describe diff doctor fetch format-patch fsck gc grep help history hook lfs log maintenance merge mv notes pr profile prune pull push quit range-diff rebase reflog release remote repos reset restore revert rm shortlog show snapshot sparse sparse-checkout stack stash stats status submodule switch sync tag ticket undo unwip verify version wip workflow worktree"
+complete -c ggc -f -n "__fish_use_subcommand" -a "(__ggc_complete_aliases)"
+complete -c ggc -f -n "not __fish_use_subcommand" -a "(__ggc_complete_args)"
+complete -c ggc -f -n "__fish_seen_subcommand_from am" -a "abort continue skip"
+complete -c ggc -f -n "__fish_seen_subcommand_from analyze" -a "large-files scrub"
+complete -c ggc -f -n "__fish_seen_subcommand_from branch" -a "checkout contains create current delete info list move rename set sort track untrack"
 complete -c ggc -f -n "__fish_seen_subcommand_from branch; and __fish_seen_subcommand_from delete" -a "merged"
 complete -c ggc -f -n "__fish_seen_subcommand_from branch; and __fish_seen_subcommand_from list" -a "local remote verbose"
 complete -c ggc -f -n "__fish_seen_subcommand_from branch; and __fish_seen_subcommand_from set" -a "upstream"
+complete -c ggc -f -n "__fish_seen_subcommand_from changelog" -a "--write"
+complete -c ggc -f -n "__fish_seen_subcommand_from cherry-pick" -a "abort continue select skip"
 complete -c ggc -f -n "__fish_seen_subcommand_from clean" -a "dirs files interactive"
-complete -c ggc -f -n "__fish_seen_subcommand_from commit" -a "allow amend fixup"
+complete -c ggc -f -n "__fish_seen_subcommand_from commit" -a "--copy --sign -m allow amend fixup lint reword"
+complete -c ggc -f -n "__fish_seen_subcommand_from commit; and __fish_seen_subcommand_from --sign" -a "--no-sign /"
 complete -c ggc -f -n "__fish_seen_subcommand_from commit; and __fish_seen_subcommand_from allow" -a "empty"
-complete -c ggc -f -n "__fish_seen_subcommand_from commit; and __fish_seen_subcommand_from amend" -a "no-edit"
-complete -c ggc -f -n "__fish_seen_subcommand_from completion" -a "bash fish install zsh"
-complete -c ggc -f -n "__fish_seen_subcommand_from config" -a "get list set"
-complete -c ggc -f -n "__fish_seen_subcommand_from debug-keys" -a "raw"
-complete -c ggc -f -n "__fish_seen_subcommand_from diff" -a "head staged unstaged"
-complete -c ggc -f -n "__fish_seen_subcommand_from fetch" -a "prune"
+complete -c ggc -f -n "__fish_seen_subcommand_from commit; and __fish_seen_subcommand_from amend" -a "--reset-author no-edit"
+complete -c ggc -f -n "__fish_seen_subcommand_from completion" -a "bash fish install nushell powershell zsh"
+complete -c ggc -f -n "__fish_seen_subcommand_from config" -a "describe edit get keybindings list schema set signing"
+complete -c ggc -f -n "__fish_seen_subcommand_from config; and __fish_seen_subcommand_from keybindings" -a "doctor show"
+complete -c ggc -f -n "__fish_seen_subcommand_from config; and __fish_seen_subcommand_from schema" -a "--json"
+complete -c ggc -f -n "__fish_seen_subcommand_from config; and __fish_seen_subcommand_from signing" -a "off setup show"
+complete -c ggc -f -n "__fish_seen_subcommand_from debug-keys" -a "--output raw"
+complete -c ggc -f -n "__fish_seen_subcommand_from diff" -a "--stat head staged unstaged"
+complete -c ggc -f -n "__fish_seen_subcommand_from doctor" -a "auth"
+complete -c ggc -f -n "__fish_seen_subcommand_from fetch" -a "--all --prune-tags prune"
+complete -c ggc -f -n "__fish_seen_subcommand_from format-patch" -a "select"
 complete -c ggc -f -n "__fish_seen_subcommand_from history" -a "clear last search"
-complete -c ggc -f -n "__fish_seen_subcommand_from hook" -a "disable edit enable install list uninstall"
-complete -c ggc -f -n "__fish_seen_subcommand_from log" -a "graph simple"
+complete -c ggc -f -n "__fish_seen_subcommand_from hook" -a "disable edit enable install list run sync templates uninstall"
+complete -c ggc -f -n "__fish_seen_subcommand_from lfs" -a "pull status track untrack"
+complete -c ggc -f -n "__fish_seen_subcommand_from log" -a "browse file graph simple"
+complete -c ggc -f -n "__fish_seen_subcommand_from maintenance" -a "analyze run start stop unregister"
+complete -c ggc -f -n "__fish_seen_subcommand_from maintenance; and __fish_seen_subcommand_from run" -a "--full --quick"
+complete -c ggc -f -n "__fish_seen_subcommand_from notes" -a "add fetch list push show"
+complete -c ggc -f -n "__fish_seen_subcommand_from pr" -a "checkout create list"
+complete -c ggc -f -n "__fish_seen_subcommand_from profile" -a "add apply current list remove use"
 complete -c ggc -f -n "__fish_seen_subcommand_from pull" -a "current rebase"
 complete -c ggc -f -n "__fish_seen_subcommand_from push" -a "current force"
+complete -c ggc -f -n "__fish_seen_subcommand_from push; and __fish_seen_subcommand_from current" -a "--allow-wip --no-scan --scan"
 complete -c ggc -f -n "__fish_seen_subcommand_from rebase" -a "abort autosquash continue interactive skip"
-complete -c ggc -f -n "__fish_seen_subcommand_from remote" -a "add list remove set-url"
+complete -c ggc -f -n "__fish_seen_subcommand_from release" -a "--dry-run --major --minor --patch --publish"
+complete -c ggc -f -n "__fish_seen_subcommand_from remote" -a "add convert list remove rename set-url"
+complete -c ggc -f -n "__fish_seen_subcommand_from remote; and __fish_seen_subcommand_from convert" -a "--https --ssh"
+complete -c ggc -f -n "__fish_seen_subcommand_from repos" -a "add dashboard exec list remove sync"
 complete -c ggc -f -n "__fish_seen_subcommand_from reset" -a "hard soft"
 complete -c ggc -f -n "__fish_seen_subcommand_from restore" -a "staged"
-complete -c ggc -f -n "__fish_seen_subcommand_from show" -a "--name-only --stat"
-complete -c ggc -f -n "__fish_seen_subcommand_from stash" -a "apply branch clear create drop list pop push save show store"
-complete -c ggc -f -n "__fish_seen_subcommand_from stash; and __fish_seen_subcommand_from push" -a "-m"
+complete -c ggc -f -n "__fish_seen_subcommand_from revert" -a "abort continue select skip"
+complete -c ggc -f -n "__fish_seen_subcommand_from show" -a "--format --name-only --stat"
+complete -c ggc -f -n "__fish_seen_subcommand_from show; and __fish_seen_subcommand_from --format" -a "json"
+complete -c ggc -f -n "__fish_seen_subcommand_from snapshot" -a "create drop list prune restore"
+complete -c ggc -f -n "__fish_seen_subcommand_from sparse" -a "add disable init list remove"
+complete -c ggc -f -n "__fish_seen_subcommand_from stack" -a "create list restack"
+complete -c ggc -f -n "__fish_seen_subcommand_from stash" -a "apply branch browse clear create drop list pop push save show store"
+complete -c ggc -f -n "__fish_seen_subcommand_from stash; and __fish_seen_subcommand_from push" -a "-- --all --include-untracked --staged -m select"
+complete -c ggc -f -n "__fish_seen_subcommand_from stats" -a "reset"
 complete -c ggc -f -n "__fish_seen_subcommand_from status" -a "short"
-complete -c ggc -f -n "__fish_seen_subcommand_from switch" -a "--detach -c"
-complete -c ggc -f -n "__fish_seen_subcommand_from tag" -a "annotated create delete list push show"
+complete -c ggc -f -n "__fish_seen_subcommand_from switch" -a "--detach -c recent"
+complete -c ggc -f -n "__fish_seen_subcommand_from tag" -a "annotated create delete list notes push show"
+complete -c ggc -f -n "__fish_seen_subcommand_from tag; and __fish_seen_subcommand_from create" -a "--annotate --notes --sign"
+complete -c ggc -f -n "__fish_seen_subcommand_from ticket" -a "open"
+complete -c ggc -f -n "__fish_seen_subcommand_from undo" -a "list"
 complete -c ggc -f -n "__fish_seen_subcommand_from version" -a "json"
+complete -c ggc -f -n "__fish_seen_subcommand_from workflow" -a "add list rerun templates"
+complete -c ggc -f -n "__fish_seen_subcommand_from workflow; and __fish_seen_subcommand_from rerun" -a "--failed"
 
 # Branch checkout needs both keyword and dynamic branch names
 complete -c ggc -f -n "__fish_seen_subcommand_from branch; and __fish_seen_subcommand_from checkout" -a "remote (__ggc_complete_branches)"
@@ -48,5 +92,5 @@ complete -c ggc -f -n "__fish_seen_subcommand_from branch; and __fish_seen_subco
 complete -c ggc -f -n "__fish_seen_subcommand_from rebase; and not __fish_seen_subcommand_from interactive; and not __fish_seen_subcommand_from continue; and not __fish_seen_subcommand_from abort; and not __fish_seen_subcommand_from skip" -a "(__ggc_complete_branches)"
 
 # Add subcommands also allow file completion
-complete -c ggc -f -n "__fish_seen_subcommand_from add" -a "interactive patch"
+complete -c ggc -f -n "__fish_seen_subcommand_from add" -a "explore interactive patch select"
 complete -c ggc -f -n "__fish_seen_subcommand_from add" -a "(__ggc_complete_files)"
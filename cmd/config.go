@@ -9,8 +9,11 @@ import (
 	"strconv"
 	"strings"
 
+	"golang.org/x/term"
+
 	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
 )
 
 // Configurer handles config operations.
@@ -19,6 +22,14 @@ type Configurer struct {
 	helper       *Helper
 	execCommand  func(string, ...string) *exec.Cmd
 	gitClient    git.ConfigOps
+	// repoConfig reads and writes repository git config for
+	// `ggc config signing`; nil disables the group.
+	repoConfig interface {
+		git.ConfigReader
+		git.ConfigWriter
+	}
+	// edit runs the config editor; nil when stdin is not a terminal.
+	edit func(cm *config.Manager) error
 }
 
 // NewConfigurer creates a new Configurer instance.
@@ -31,6 +42,26 @@ func NewConfigurer(client git.ConfigOps) *Configurer {
 	}
 }
 
+// withRepoConfig enables `ggc config signing`.
+func (c *Configurer) withRepoConfig(client interface {
+	git.ConfigReader
+	git.ConfigWriter
+}) *Configurer {
+	c.repoConfig = client
+	return c
+}
+
+// withEditor enables `ggc config edit` when stdin is a terminal.
+func (c *Configurer) withEditor() *Configurer {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return c
+	}
+	c.edit = func(cm *config.Manager) error {
+		return interactive.NewConfigEditor(cm, cm.GetConfig()).Run()
+	}
+	return c
+}
+
 // LoadConfig executes loads the configuration.
 func (c *Configurer) LoadConfig() *config.Manager {
 	cm := config.NewConfigManager(c.gitClient)
@@ -76,20 +107,60 @@ func (c *Configurer) Config(args []string) {
 
 	switch args[0] {
 	case "list":
-		c.configList()
+		c.configList(args[1:])
 	case "get":
 		c.configGet(args)
 	case "set":
 		c.configSet(args)
+	case "keybindings":
+		c.configKeybindings(args)
+	case "signing":
+		c.configSigning(args)
+	case "edit":
+		c.configEdit()
+	case "describe":
+		c.configDescribe(args[1:])
+	case "schema":
+		c.configSchema(args[1:])
 	default:
 		c.helper.ShowConfigHelp()
 	}
 }
 
-// configList lists all configuration values
-func (c *Configurer) configList() {
+// configEdit opens the interactive config editor.
+func (c *Configurer) configEdit() {
+	if c.edit == nil {
+		WriteErrorf(c.outputWriter, "ggc config edit needs a terminal; use ggc config set <key> <value>")
+		return
+	}
 	cm := c.LoadConfig()
+	if cm == nil {
+		return
+	}
+	if err := c.edit(cm); err != nil {
+		WriteError(c.outputWriter, err)
+	}
+}
+
+// configList lists all configuration values, with each key's
+// description under --describe.
+func (c *Configurer) configList(args []string) {
+	describe := false
+	for _, arg := range args {
+		if arg != "--describe" {
+			WriteErrorf(c.outputWriter, "unknown argument %q", arg)
+			return
+		}
+		describe = true
+	}
+	cm := c.LoadConfig()
+	if cm == nil {
+		return
+	}
 	configs := cm.List()
+	if path := cm.RepoConfigPath(); path != "" {
+		_, _ = fmt.Fprintf(c.outputWriter, "# Values marked (%s) come from %s\n", config.RepoConfigFile, path)
+	}
 
 	keys := make([]string, 0, len(configs))
 	for key := range configs {
@@ -103,7 +174,14 @@ func (c *Configurer) configList() {
 			c.displayAliases(val)
 			continue
 		}
-		_, _ = fmt.Fprintf(c.outputWriter, "%-30s = %s\n", key, formatValue(val))
+		line := fmt.Sprintf("%-30s = %s", key, displayValue(key, derefValue(val)))
+		if cm.FromRepo(key) {
+			line += "  (" + config.RepoConfigFile + ")"
+		}
+		_, _ = fmt.Fprintln(c.outputWriter, line)
+		if desc := config.Describe(key); describe && desc != "" {
+			_, _ = fmt.Fprintf(c.outputWriter, "%-30s   # %s\n", "", desc)
+		}
 	}
 }
 
@@ -151,7 +229,20 @@ func (c *Configurer) configSet(args []string) {
 		_, _ = fmt.Fprintf(c.outputWriter, "failed to set config value: %s", err)
 	}
 
-	_, _ = fmt.Fprintf(c.outputWriter, "Set %s = %s\n", args[1], formatValue(value))
+	_, _ = fmt.Fprintf(c.outputWriter, "Set %s = %s\n", args[1], displayValue(args[1], value))
+	if cm.FromRepo(args[1]) {
+		_, _ = fmt.Fprintf(c.outputWriter, "Note: %s sets %s too and wins in this repository\n", cm.RepoConfigPath(), args[1])
+	}
+}
+
+// displayValue formats value for listings, masking credentials such as
+// integration.github.token. `config get` still prints them in full.
+func displayValue(key string, value any) string {
+	formatted := formatValue(value)
+	if strings.HasSuffix(key, ".token") && formatted != "" {
+		return "********"
+	}
+	return formatted
 }
 
 func formatValue(value any) string {
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+)
+
+// keyDescription is the machine-readable form of one key for
+// `ggc config describe --json`. Current values are left out so the
+// output never carries credentials.
+type keyDescription struct {
+	Key         string   `json:"key"`
+	Type        string   `json:"type,omitempty"`
+	Default     any      `json:"default,omitempty"`
+	Allowed     []string `json:"allowed,omitempty"`
+	Description string   `json:"description"`
+	Env         string   `json:"env,omitempty"`
+}
+
+// configDescribe prints the type, default, allowed values and description
+// of every key, or of the keys under the given key path.
+func (c *Configurer) configDescribe(args []string) {
+	var prefix string
+	asJSON := false
+	for _, arg := range args {
+		switch {
+		case arg == "--json":
+			asJSON = true
+		case strings.HasPrefix(arg, "-") || prefix != "":
+			WriteErrorf(c.outputWriter, "unknown argument %q", arg)
+			return
+		default:
+			prefix = arg
+		}
+	}
+
+	cm := c.LoadConfig()
+	if cm == nil {
+		return
+	}
+	var infos []config.KeyInfo
+	for _, info := range cm.Keys() {
+		if prefix == "" || info.Key == prefix || strings.HasPrefix(info.Key, prefix+".") {
+			infos = append(infos, info)
+		}
+	}
+	if len(infos) == 0 {
+		WriteErrorf(c.outputWriter, "unknown config key %q", prefix)
+		return
+	}
+
+	if asJSON {
+		descriptions := make([]keyDescription, len(infos))
+		for i, info := range infos {
+			descriptions[i] = keyDescription{
+				Key:         info.Key,
+				Type:        info.Type,
+				Default:     derefValue(info.Default),
+				Allowed:     info.Allowed,
+				Description: info.Description,
+				Env:         info.Env,
+			}
+		}
+		c.writeJSON(descriptions)
+		return
+	}
+	for i, info := range infos {
+		if i > 0 {
+			_, _ = fmt.Fprintln(c.outputWriter)
+		}
+		_, _ = fmt.Fprintln(c.outputWriter, info.Key)
+		_, _ = fmt.Fprintf(c.outputWriter, "  Description: %s\n", info.Description)
+		if info.Type != "" {
+			_, _ = fmt.Fprintf(c.outputWriter, "  Type:        %s\n", info.Type)
+		}
+		if len(info.Allowed) > 0 {
+			_, _ = fmt.Fprintf(c.outputWriter, "  Allowed:     %s\n", strings.Join(info.Allowed, ", "))
+		}
+		_, _ = fmt.Fprintf(c.outputWriter, "  Default:     %s\n", orNone(formatValue(derefValue(info.Default))))
+		_, _ = fmt.Fprintf(c.outputWriter, "  Value:       %s\n", orNone(displayValue(info.Key, derefValue(info.Value))))
+		if info.Env != "" {
+			_, _ = fmt.Fprintf(c.outputWriter, "  Overridden:  by $%s\n", info.Env)
+		}
+	}
+}
+
+// configSchema prints the JSON Schema of the configuration file, for
+// editors that validate ~/.ggcconfig.yaml.
+func (c *Configurer) configSchema(args []string) {
+	for _, arg := range args {
+		if arg != "--json" {
+			WriteErrorf(c.outputWriter, "unknown argument %q", arg)
+			return
+		}
+	}
+	c.writeJSON(config.NewConfigManager(c.gitClient).Schema())
+}
+
+// derefValue unwraps optional values such as history.enabled; unset ones
+// become nil.
+func derefValue(value any) any {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Pointer {
+		return value
+	}
+	if v.IsNil() {
+		return nil
+	}
+	return v.Elem().Interface()
+}
+
+func orNone(s string) string {
+	if s == "" || s == "<nil>" {
+		return "(none)"
+	}
+	return s
+}
+
+func (c *Configurer) writeJSON(v any) {
+	enc := json.NewEncoder(c.outputWriter)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		WriteError(c.outputWriter, err)
+	}
+}
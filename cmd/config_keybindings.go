@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+)
+
+// configKeybindingsUsage is printed for a missing or unknown subcommand.
+const configKeybindingsUsage = "Usage: ggc config keybindings show [--profile <name>] [--context <name>] | doctor [--profile <name>]"
+
+// configKeybindings handles "ggc config keybindings show [--profile P] [--context C]"
+// and "ggc config keybindings doctor [--profile P]".
+func (c *Configurer) configKeybindings(args []string) {
+	if len(args) < 2 || (args[1] != "show" && args[1] != "doctor") {
+		_, _ = fmt.Fprintln(c.outputWriter, configKeybindingsUsage)
+		return
+	}
+	doctor := args[1] == "doctor"
+
+	var profile, context string
+	rest := args[2:]
+	for i := 0; i < len(rest); i++ {
+		name, value, hasValue := strings.Cut(rest[i], "=")
+		switch {
+		case name == "--profile", name == "--context" && !doctor:
+		default:
+			WriteErrorf(c.outputWriter, "unknown option %s", rest[i])
+			return
+		}
+		if !hasValue {
+			if i+1 >= len(rest) {
+				WriteErrorf(c.outputWriter, "%s requires a value", name)
+				return
+			}
+			i++
+			value = rest[i]
+		}
+		if name == "--profile" {
+			profile = value
+		} else {
+			context = value
+		}
+	}
+
+	cfg := &config.Config{}
+	if cm := c.LoadConfig(); cm != nil {
+		cfg = cm.GetConfig()
+	}
+	if profile == "" {
+		profile = cfg.Interactive.Profile
+	}
+	if profile == "" {
+		profile = string(kb.ProfileDefault)
+	}
+	if context == "" {
+		context = string(kb.ContextGlobal)
+	}
+
+	resolver := kb.NewKeyBindingResolver(cfg)
+	kb.RegisterBuiltinProfiles(resolver)
+	if doctor {
+		c.keybindingsDoctor(resolver, kb.Profile(profile))
+		return
+	}
+	show := kb.NewShowKeysCommand(resolver)
+	show.SetOutput(c.outputWriter)
+	if err := show.Execute(kb.Profile(profile), kb.Context(context), "full"); err != nil {
+		WriteError(c.outputWriter, err)
+	}
+}
+
+// keybindingsDoctor lists the keystrokes bound to more than one action,
+// with the layer that set each binding and how to fix the conflict.
+func (c *Configurer) keybindingsDoctor(resolver *kb.KeyBindingResolver, profile kb.Profile) {
+	if !profile.IsValid() {
+		WriteErrorf(c.outputWriter, "profile '%s' not found", profile)
+		return
+	}
+	conflicts := resolver.Conflicts(profile)
+	if len(conflicts) == 0 {
+		WriteLinef(c.outputWriter, "No keybinding conflicts in the %s profile.", profile)
+		return
+	}
+	for _, conflict := range conflicts {
+		WriteLine(c.outputWriter, conflict.String())
+		WriteLinef(c.outputWriter, "  Fix: %s", conflict.Fix())
+	}
+	WriteLine(c.outputWriter, "")
+	WriteLinef(c.outputWriter, "%d keybinding conflict(s) in the %s profile.", len(conflicts), profile)
+}
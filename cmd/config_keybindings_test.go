@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+func TestConfigurer_KeybindingsShow(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"defaults", []string{"keybindings", "show"}, []string{"Profile: Default", "Context: global", "move_up", "Ctrl+p"}},
+		{"profile and context", []string{"keybindings", "show", "--profile", "emacs", "--context=input"}, []string{"Profile: Emacs", "Context: input"}},
+		{"unknown profile", []string{"keybindings", "show", "--profile", "nope"}, []string{"Error: profile 'nope' not found"}},
+		{"unknown context", []string{"keybindings", "show", "--context", "nope"}, []string{"Error: unknown context 'nope'"}},
+		{"missing value", []string{"keybindings", "show", "--profile"}, []string{"Error: --profile requires a value"}},
+		{"unknown option", []string{"keybindings", "show", "--bogus"}, []string{"Error: unknown option --bogus"}},
+		{"no show", []string{"keybindings"}, []string{"Usage: ggc config keybindings show"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			c := &Configurer{
+				gitClient:    testutil.NewMockGitClient(),
+				outputWriter: &buf,
+				helper:       NewHelper(),
+				execCommand:  exec.Command,
+			}
+			c.Config(tt.args)
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestConfigurer_KeybindingsDoctor(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	newConfigurer := func(buf *bytes.Buffer) *Configurer {
+		return &Configurer{
+			gitClient:    testutil.NewMockGitClient(),
+			outputWriter: buf,
+			helper:       NewHelper(),
+			execCommand:  exec.Command,
+		}
+	}
+
+	var buf bytes.Buffer
+	newConfigurer(&buf).Config([]string{"keybindings", "doctor"})
+	if !strings.Contains(buf.String(), "No keybinding conflicts in the default profile.") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	cfg := "interactive:\n  contexts:\n    search:\n      keybindings:\n        move_down: \"Ctrl+U\"\n"
+	if err := os.WriteFile(filepath.Join(home, ".ggcconfig.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	newConfigurer(&buf).Config([]string{"keybindings", "doctor"})
+	for _, want := range []string{
+		"search: Ctrl+u is bound to clear_line (built-in defaults) and move_down (interactive.contexts.search.keybindings)",
+		"  Fix: bind move_down to another key in interactive.contexts.search.keybindings",
+		"1 keybinding conflict(s) in the default profile.",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	newConfigurer(&buf).Config([]string{"keybindings", "doctor", "--context", "input"})
+	if !strings.Contains(buf.String(), "Error: unknown option --context") {
+		t.Errorf("doctor should not take --context:\n%s", buf.String())
+	}
+}
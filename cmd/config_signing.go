@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// signingConfigKeys are the git settings `ggc config signing` manages.
+var signingConfigKeys = []string{
+	"gpg.format",
+	"user.signingkey",
+	"commit.gpgsign",
+	"tag.gpgsign",
+	"gpg.ssh.allowedSignersFile",
+}
+
+// sshSigningKeys are the public keys setup looks for, in order.
+var sshSigningKeys = []string{"id_ed25519.pub", "id_ecdsa.pub", "id_rsa.pub"}
+
+// configSigning dispatches `ggc config signing` subcommands.
+func (c *Configurer) configSigning(args []string) {
+	if c.repoConfig == nil {
+		WriteErrorf(c.outputWriter, "signing configuration is not supported here")
+		return
+	}
+	sub := "show"
+	if len(args) > 1 {
+		sub = args[1]
+	}
+	var rest []string
+	if len(args) > 2 {
+		rest = args[2:]
+	}
+	switch sub {
+	case "show":
+		c.signingShow()
+	case "setup":
+		c.signingSetup(rest)
+	case "off":
+		c.signingOff(rest)
+	default:
+		_, _ = fmt.Fprintln(c.outputWriter, "Usage: ggc config signing [show | setup [--format gpg|ssh] [--key <key>] [--always] [--global] | off [--global]]")
+	}
+}
+
+// signingShow prints the effective signing settings.
+func (c *Configurer) signingShow() {
+	for _, key := range signingConfigKeys {
+		value, err := c.repoConfig.ConfigGet(key)
+		if err != nil || value == "" {
+			value = "(unset)"
+		}
+		_, _ = fmt.Fprintf(c.outputWriter, "%-30s = %s\n", key, value)
+	}
+}
+
+type signingSetupOptions struct {
+	format string
+	key    string
+	always bool
+	global bool
+}
+
+func parseSigningSetupArgs(args []string) (signingSetupOptions, error) {
+	var opts signingSetupOptions
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		switch name {
+		case "--always":
+			opts.always = true
+			continue
+		case "--global":
+			opts.global = true
+			continue
+		case "--format", "--key":
+		default:
+			return opts, fmt.Errorf("unknown argument %q", args[i])
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("%s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		if name == "--key" {
+			opts.key = value
+			continue
+		}
+		if value != "gpg" && value != "ssh" {
+			return opts, fmt.Errorf("--format must be gpg or ssh, got %q", value)
+		}
+		opts.format = value
+	}
+	return opts, nil
+}
+
+// signingSetup configures a signing key. Without --format, a key that looks
+// like an SSH public key, or a found ~/.ssh key, selects SSH; otherwise the
+// single GPG secret key is used.
+func (c *Configurer) signingSetup(args []string) {
+	opts, err := parseSigningSetupArgs(args)
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	if opts.format == "" {
+		opts.format = "gpg"
+		if opts.key != "" && isSSHKey(opts.key) {
+			opts.format = "ssh"
+		} else if opts.key == "" && findSSHKey(home) != "" {
+			opts.format = "ssh"
+		}
+	}
+	if opts.key == "" {
+		if opts.format == "ssh" {
+			opts.key = findSSHKey(home)
+			if opts.key == "" {
+				WriteErrorf(c.outputWriter, "no SSH public key found in %s; pass --key <path>", filepath.Join(home, ".ssh"))
+				return
+			}
+		} else if opts.key, err = c.gpgSecretKey(); err != nil {
+			WriteError(c.outputWriter, err)
+			return
+		}
+	}
+
+	set := c.repoConfig.ConfigSet
+	if opts.global {
+		set = c.gitClient.ConfigSetGlobal
+	}
+	settings := [][2]string{{"user.signingkey", opts.key}, {"gpg.format", "openpgp"}}
+	if opts.format == "ssh" {
+		settings[1][1] = "ssh"
+	}
+	if opts.always {
+		settings = append(settings, [2]string{"commit.gpgsign", "true"}, [2]string{"tag.gpgsign", "true"})
+	}
+	for _, kv := range settings {
+		if err := set(kv[0], kv[1]); err != nil {
+			WriteError(c.outputWriter, err)
+			return
+		}
+	}
+	_, _ = fmt.Fprintf(c.outputWriter, "Signing with %s key %s\n", opts.format, opts.key)
+
+	if opts.format == "ssh" {
+		c.trustSSHKey(home, opts.key, set)
+	}
+	if !opts.always {
+		_, _ = fmt.Fprintln(c.outputWriter, "Sign with 'ggc commit --sign' and 'ggc tag create --sign', or rerun with --always.")
+	}
+}
+
+// trustSSHKey adds the key to the allowed signers file so git can verify
+// the user's own SSH signatures.
+func (c *Configurer) trustSSHKey(home, key string, set func(key, value string) error) {
+	email, _ := c.repoConfig.ConfigGet("user.email")
+	if email == "" {
+		_, _ = fmt.Fprintln(c.outputWriter, "Set user.email to verify your own SSH signatures.")
+		return
+	}
+	pub := strings.TrimPrefix(key, "key::")
+	if !strings.HasPrefix(pub, "ssh-") {
+		data, err := os.ReadFile(expandHome(key, home))
+		if err != nil {
+			WriteError(c.outputWriter, err)
+			return
+		}
+		pub = string(data)
+	}
+	// Keep the key type and material, dropping any comment.
+	if fields := strings.Fields(pub); len(fields) >= 2 {
+		pub = fields[0] + " " + fields[1]
+	}
+
+	signers, _ := c.repoConfig.ConfigGet("gpg.ssh.allowedSignersFile")
+	if signers == "" {
+		signers = filepath.Join(home, ".config", "git", "allowed_signers")
+		if err := set("gpg.ssh.allowedSignersFile", signers); err != nil {
+			WriteError(c.outputWriter, err)
+			return
+		}
+	}
+	signers = expandHome(signers, home)
+	line := fmt.Sprintf("%s namespaces=\"git\" %s", email, pub)
+	data, err := os.ReadFile(signers)
+	if err == nil && strings.Contains(string(data), pub) {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(signers), 0o755); err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	f, err := os.OpenFile(signers, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	defer func() { _ = f.Close() }()
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		line = "\n" + line
+	}
+	if _, err := fmt.Fprintln(f, line); err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	_, _ = fmt.Fprintf(c.outputWriter, "Trusted the key for %s in %s\n", email, signers)
+}
+
+// gpgSecretKey returns the only GPG secret key, or an error naming the
+// candidates when there are several.
+func (c *Configurer) gpgSecretKey() (string, error) {
+	out, err := c.execCommand("gpg", "--list-secret-keys", "--with-colons").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list GPG keys: %w", err)
+	}
+	var keys []string
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Split(line, ":")
+		if fields[0] == "sec" && len(fields) > 4 {
+			keys = append(keys, fields[4])
+		}
+	}
+	switch len(keys) {
+	case 0:
+		return "", fmt.Errorf("no GPG secret key found; create one with 'gpg --full-generate-key' or pass --format ssh")
+	case 1:
+		return keys[0], nil
+	default:
+		return "", fmt.Errorf("several GPG secret keys found (%s); pass --key <id>", strings.Join(keys, ", "))
+	}
+}
+
+// signingOff stops signing by default.
+func (c *Configurer) signingOff(args []string) {
+	set := c.repoConfig.ConfigSet
+	for _, arg := range args {
+		if arg != "--global" {
+			WriteErrorf(c.outputWriter, "unknown argument %q", arg)
+			return
+		}
+		set = c.gitClient.ConfigSetGlobal
+	}
+	for _, key := range []string{"commit.gpgsign", "tag.gpgsign"} {
+		if err := set(key, "false"); err != nil {
+			WriteError(c.outputWriter, err)
+			return
+		}
+	}
+	_, _ = fmt.Fprintln(c.outputWriter, "Commits and tags are no longer signed by default")
+}
+
+func findSSHKey(home string) string {
+	for _, name := range sshSigningKeys {
+		path := filepath.Join(home, ".ssh", name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func isSSHKey(key string) bool {
+	return strings.HasSuffix(key, ".pub") || strings.HasPrefix(key, "ssh-") || strings.HasPrefix(key, "key::")
+}
+
+func expandHome(path, home string) string {
+	if path == "~" {
+		return home
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		return filepath.Join(home, rest)
+	}
+	return path
+}
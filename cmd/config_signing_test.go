@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+func newTestSigningConfigurer(m *mockProfileGitClient, gpgOutput string) (*Configurer, *bytes.Buffer) {
+	var buf bytes.Buffer
+	c := &Configurer{
+		gitClient:    testutil.NewMockGitClient(),
+		outputWriter: &buf,
+		helper:       NewHelper(),
+		execCommand: func(string, ...string) *exec.Cmd {
+			return exec.Command("printf", "%s", gpgOutput)
+		},
+	}
+	return c.withRepoConfig(m), &buf
+}
+
+func TestConfigurer_SigningSetupSSH(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	key := filepath.Join(home, ".ssh", "id_ed25519.pub")
+	if err := os.MkdirAll(filepath.Dir(key), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(key, []byte("ssh-ed25519 AAAAC3Nza jane@laptop\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := &mockProfileGitClient{values: map[string]string{"user.email": "jane@example.com"}}
+	c, buf := newTestSigningConfigurer(m, "")
+
+	c.Config([]string{"signing", "setup", "--always"})
+
+	signers := filepath.Join(home, ".config", "git", "allowed_signers")
+	want := []string{
+		"user.signingkey=" + key, "gpg.format=ssh",
+		"commit.gpgsign=true", "tag.gpgsign=true",
+		"gpg.ssh.allowedSignersFile=" + signers,
+	}
+	if !reflect.DeepEqual(m.set, want) {
+		t.Errorf("config set = %v, want %v (output %q)", m.set, want, buf.String())
+	}
+	data, err := os.ReadFile(signers)
+	if err != nil || string(data) != "jane@example.com namespaces=\"git\" ssh-ed25519 AAAAC3Nza\n" {
+		t.Errorf("allowed signers = %q, %v", data, err)
+	}
+
+	// Running setup again does not duplicate the trusted key.
+	c.Config([]string{"signing", "setup"})
+	if data, _ := os.ReadFile(signers); strings.Count(string(data), "\n") != 1 {
+		t.Errorf("allowed signers after rerun = %q", data)
+	}
+
+	buf.Reset()
+	c.Config([]string{"signing"})
+	if out := buf.String(); !strings.Contains(out, "gpg.format") || !strings.Contains(out, "= ssh") {
+		t.Errorf("unexpected show output: %s", out)
+	}
+}
+
+func TestConfigurer_SigningSetupGPG(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tests := []struct {
+		name    string
+		gpg     string
+		args    []string
+		wantSet []string
+		wantOut string
+	}{
+		{
+			name:    "single key",
+			gpg:     "sec:u:255:22:ABCD1234:1700000000:::u:::scESC:::+:::ed25519:::0:\nuid:u::::::::Jane <jane@example.com>:\n",
+			args:    []string{"signing", "setup"},
+			wantSet: []string{"user.signingkey=ABCD1234", "gpg.format=openpgp"},
+			wantOut: "Signing with gpg key ABCD1234",
+		},
+		{
+			name:    "several keys",
+			gpg:     "sec:u:255:22:AAAA:\nsec:u:255:22:BBBB:\n",
+			args:    []string{"signing", "setup", "--format", "gpg"},
+			wantOut: "several GPG secret keys found (AAAA, BBBB)",
+		},
+		{
+			name:    "explicit key",
+			args:    []string{"signing", "setup", "--key=CAFE"},
+			wantSet: []string{"user.signingkey=CAFE", "gpg.format=openpgp"},
+		},
+		{
+			name:    "bad format",
+			args:    []string{"signing", "setup", "--format", "x509"},
+			wantOut: "--format must be gpg or ssh",
+		},
+		{
+			name:    "off",
+			args:    []string{"signing", "off"},
+			wantSet: []string{"commit.gpgsign=false", "tag.gpgsign=false"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockProfileGitClient{values: map[string]string{}}
+			c, buf := newTestSigningConfigurer(m, tt.gpg)
+			c.Config(tt.args)
+			if !reflect.DeepEqual(m.set, tt.wantSet) {
+				t.Errorf("config set = %v, want %v (output %q)", m.set, tt.wantSet, buf.String())
+			}
+			if !strings.Contains(buf.String(), tt.wantOut) {
+				t.Errorf("output missing %q: %q", tt.wantOut, buf.String())
+			}
+		})
+	}
+}
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/testutil"
 )
 
@@ -391,6 +395,18 @@ func TestFormatValue(t *testing.T) {
 	}
 }
 
+func TestDisplayValue(t *testing.T) {
+	if got := displayValue("integration.github.token", "ghp_secret"); got != "********" {
+		t.Errorf("token should be masked, got %q", got)
+	}
+	if got := displayValue("integration.github.token", ""); got != "" {
+		t.Errorf("empty token should stay empty, got %q", got)
+	}
+	if got := displayValue("git.default-remote", "origin"); got != "origin" {
+		t.Errorf("expected plain value, got %q", got)
+	}
+}
+
 func TestParseValue(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -510,3 +526,113 @@ func TestConfigurer_displayAliases_InvalidValue(t *testing.T) {
 		t.Errorf("expected invalid alias message, got: %s", buf.String())
 	}
 }
+
+func TestConfigurer_Edit(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var buf bytes.Buffer
+	c := &Configurer{gitClient: testutil.NewMockGitClient(), outputWriter: &buf, helper: NewHelper()}
+
+	c.Config([]string{"edit"})
+	if !strings.Contains(buf.String(), "needs a terminal") {
+		t.Errorf("unexpected output without a terminal: %q", buf.String())
+	}
+
+	var opened *config.Manager
+	c.edit = func(cm *config.Manager) error { opened = cm; return nil }
+	c.Config([]string{"edit"})
+	if opened == nil {
+		t.Error("config edit should open the editor on the loaded config")
+	}
+}
+
+func TestConfigurer_Describe(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("NO_COLOR", "")
+	var buf bytes.Buffer
+	c := &Configurer{gitClient: testutil.NewMockGitClient(), outputWriter: &buf, helper: NewHelper()}
+
+	c.Config([]string{"describe", "sync"})
+	for _, want := range []string{"sync.strategy", "Type:        string", "Allowed:     rebase, merge", "How ggc sync takes in the upstream"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("describe output missing %q:\n%s", want, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "ui.color") {
+		t.Errorf("describe sync should only show sync.* keys:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	c.Config([]string{"describe", "ui.color", "--json"})
+	var got []keyDescription
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("describe --json is not JSON: %v\n%s", err, buf.String())
+	}
+	if len(got) != 1 || got[0].Type != "boolean" || got[0].Default != true || got[0].Env != "" {
+		t.Errorf("describe --json = %+v", got)
+	}
+
+	buf.Reset()
+	c.Config([]string{"describe", "no.such.key"})
+	if !strings.Contains(buf.String(), `unknown config key "no.such.key"`) {
+		t.Errorf("unexpected output for an unknown key: %q", buf.String())
+	}
+}
+
+func TestConfigurer_Schema(t *testing.T) {
+	var buf bytes.Buffer
+	c := &Configurer{gitClient: testutil.NewMockGitClient(), outputWriter: &buf, helper: NewHelper()}
+
+	c.Config([]string{"schema", "--json"})
+	var schema struct {
+		ID         string                     `json:"$id"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &schema); err != nil {
+		t.Fatalf("schema is not JSON: %v", err)
+	}
+	if schema.ID != config.SchemaID || schema.Properties["behavior"] == nil {
+		t.Errorf("unexpected schema: id %q, %d properties", schema.ID, len(schema.Properties))
+	}
+
+	buf.Reset()
+	c.Config([]string{"schema", "--yaml"})
+	if !strings.Contains(buf.String(), `unknown argument "--yaml"`) {
+		t.Errorf("unexpected output for an unknown flag: %q", buf.String())
+	}
+}
+
+func TestConfigurer_ListDescribe(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var buf bytes.Buffer
+	c := &Configurer{gitClient: testutil.NewMockGitClient(), outputWriter: &buf, helper: NewHelper()}
+
+	c.Config([]string{"list", "--describe"})
+	if !strings.Contains(buf.String(), "# Push after each commit") {
+		t.Errorf("list --describe should show descriptions:\n%s", buf.String())
+	}
+}
+
+func TestConfigurer_ListRepoConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, config.RepoConfigFile), []byte("git:\n  default-remote: upstream\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(root)
+	var buf bytes.Buffer
+	c := &Configurer{gitClient: testutil.NewMockGitClient(), outputWriter: &buf, helper: NewHelper()}
+
+	c.Config([]string{"list"})
+	if !strings.Contains(buf.String(), "git.default-remote             = upstream  (.ggc.yaml)") {
+		t.Errorf("list should mark values from .ggc.yaml:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	c.Config([]string{"set", "git.default-remote", "origin"})
+	if !strings.Contains(buf.String(), "wins in this repository") {
+		t.Errorf("set should note the repository override:\n%s", buf.String())
+	}
+}
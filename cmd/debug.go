@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"strings"
 
 	"golang.org/x/term"
 
@@ -32,11 +33,16 @@ func (d *Debugger) DebugKeys(args []string) {
 		return
 	}
 
+	if strings.HasPrefix(args[0], "--output") {
+		args = append([]string{"raw"}, args...)
+	}
+
 	switch args[0] {
 	case "raw":
-		outputFile := ""
-		if len(args) > 1 {
-			outputFile = args[1]
+		outputFile, err := rawOutputFile(args[1:])
+		if err != nil {
+			WriteError(d.outputWriter, err)
+			return
 		}
 		d.captureRawKeySequences(outputFile)
 	case "help", "-h", "--help":
@@ -47,6 +53,30 @@ func (d *Debugger) DebugKeys(args []string) {
 	}
 }
 
+// rawOutputFile returns the capture file named by the arguments after
+// "raw": either a bare path or --output <file> / --output=<file>.
+func rawOutputFile(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", nil
+	}
+	if file, ok := strings.CutPrefix(args[0], "--output="); ok {
+		if file == "" {
+			return "", fmt.Errorf("--output requires a file name")
+		}
+		return file, nil
+	}
+	if args[0] == "--output" {
+		if len(args) < 2 {
+			return "", fmt.Errorf("--output requires a file name")
+		}
+		return args[1], nil
+	}
+	if strings.HasPrefix(args[0], "-") {
+		return "", fmt.Errorf("unknown option %s", args[0])
+	}
+	return args[0], nil
+}
+
 // showActiveKeybindings displays currently active key bindings
 func (d *Debugger) showActiveKeybindings() {
 	_, _ = fmt.Fprintln(d.outputWriter, "=== Active Key Bindings ===")
@@ -83,14 +113,14 @@ func (d *Debugger) showActiveKeybindings() {
 // captureRawKeySequences captures and displays raw key sequences
 func (d *Debugger) captureRawKeySequences(outputFile string) {
 	if !term.IsTerminal(int(os.Stdin.Fd())) {
-		_, _ = fmt.Fprintln(d.outputWriter, "Error: debug-keys raw mode requires a terminal")
+		WriteErrorf(d.outputWriter, "debug-keys raw mode requires a terminal")
 		return
 	}
 
 	debugCmd := keybindings.NewDebugKeysCommand(outputFile)
 	oldState, err := d.setupTerminalRawMode()
 	if err != nil {
-		_, _ = fmt.Fprintf(d.outputWriter, "Error setting terminal to raw mode: %v\n", err)
+		WriteErrorf(d.outputWriter, "setting terminal to raw mode: %w", err)
 		return
 	}
 
@@ -112,7 +142,7 @@ func (d *Debugger) setupTerminalRawMode() (*term.State, error) {
 func (d *Debugger) restoreTerminal(oldState *term.State) {
 	signal.Reset(os.Interrupt)
 	if err := term.Restore(int(os.Stdin.Fd()), oldState); err != nil {
-		_, _ = fmt.Fprintf(d.outputWriter, "Error restoring terminal: %v\n", err)
+		WriteErrorf(d.outputWriter, "restoring terminal: %w", err)
 	}
 }
 
@@ -125,10 +155,10 @@ func (d *Debugger) handleSignals(debugCmd *keybindings.DebugKeysCommand, oldStat
 		<-sigChan
 		_, _ = fmt.Fprintln(d.outputWriter, "\n\nReceived interrupt signal, stopping capture...")
 		if err := debugCmd.StopCapture(); err != nil {
-			_, _ = fmt.Fprintf(d.outputWriter, "Error stopping capture: %v\n", err)
+			WriteErrorf(d.outputWriter, "stopping capture: %w", err)
 		}
 		if err := term.Restore(int(os.Stdin.Fd()), oldState); err != nil {
-			_, _ = fmt.Fprintf(d.outputWriter, "Error restoring terminal: %v\n", err)
+			WriteErrorf(d.outputWriter, "restoring terminal: %w", err)
 		}
 		signal.Stop(sigChan)
 		signal.Reset(os.Interrupt)
@@ -142,7 +172,7 @@ func (d *Debugger) processInput(debugCmd *keybindings.DebugKeysCommand) {
 	for debugCmd.IsCapturing() {
 		n, err := os.Stdin.Read(buffer)
 		if err != nil {
-			_, _ = fmt.Fprintf(d.outputWriter, "Error reading input: %v\n", err)
+			WriteErrorf(d.outputWriter, "reading input: %w", err)
 			break
 		}
 
@@ -162,7 +192,7 @@ func (d *Debugger) checkForCtrlC(sequence []byte, debugCmd *keybindings.DebugKey
 		if b == 3 { // Ctrl+C
 			_, _ = fmt.Fprintln(d.outputWriter, "\nCapture stopped by user")
 			if err := debugCmd.StopCapture(); err != nil {
-				_, _ = fmt.Fprintf(d.outputWriter, "Error stopping capture: %v\n", err)
+				WriteErrorf(d.outputWriter, "stopping capture: %w", err)
 			}
 			return true
 		}
@@ -180,16 +210,21 @@ USAGE:
 SUBCOMMANDS:
     (none)          Show currently active key bindings
     raw [file]      Capture raw key sequences and optionally save to file
+
+OPTIONS:
+    --output <file> Capture raw key sequences and save them to file
     help            Show this help message
 
 EXAMPLES:
     ggc debug-keys                 # Show active keybindings
     ggc debug-keys raw             # Capture key sequences interactively
     ggc debug-keys raw keys.txt    # Capture and save to keys.txt
+    ggc debug-keys --output k.txt  # Same as 'raw k.txt'
 
 DESCRIPTION:
     The debug-keys command helps troubleshoot keybinding issues by:
-    1. Showing currently active key bindings
+    1. Showing currently active key bindings (see also
+       'ggc config keybindings show' for the fully resolved layers)
     2. Capturing raw key sequences sent by your terminal
     3. Identifying common key sequences (arrows, function keys, etc.)
     4. Providing the correct format for custom keybinding configuration
@@ -186,3 +186,41 @@ func TestDebugger_captureRawKeySequences_NonTerminal(t *testing.T) {
 		t.Error("Expected error message for non-terminal environment")
 	}
 }
+
+func TestRawOutputFile(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{nil, "", false},
+		{[]string{"keys.txt"}, "keys.txt", false},
+		{[]string{"--output", "keys.txt"}, "keys.txt", false},
+		{[]string{"--output=keys.txt"}, "keys.txt", false},
+		{[]string{"--output"}, "", true},
+		{[]string{"--output="}, "", true},
+		{[]string{"--verbose"}, "", true},
+	}
+	for _, tt := range tests {
+		got, err := rawOutputFile(tt.args)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("rawOutputFile(%q) = %q, %v", tt.args, got, err)
+		}
+	}
+}
+
+func TestDebugger_DebugKeys_OutputFlag(t *testing.T) {
+	var buf bytes.Buffer
+	debugger := &Debugger{outputWriter: &buf, helper: NewHelper()}
+
+	debugger.DebugKeys([]string{"--output", "keys.txt"})
+	if !strings.Contains(buf.String(), "requires a terminal") {
+		t.Errorf("expected --output to enter raw capture, got %q", buf.String())
+	}
+
+	buf.Reset()
+	debugger.DebugKeys([]string{"--output"})
+	if !strings.Contains(buf.String(), "Error: --output requires a file name") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
@@ -7,14 +7,17 @@ import (
 	"os"
 	"strings"
 
+	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
 )
 
 // Differ handles git diff operations.
 type Differ struct {
-	gitClient    git.DiffReader
-	outputWriter io.Writer
-	helper       *Helper
+	gitClient     git.DiffReader
+	outputWriter  io.Writer
+	helper        *Helper
+	configManager *config.Manager
+	scope         *pathScope
 }
 
 // NewDiffer creates a new Differ instance.
@@ -26,6 +29,19 @@ func NewDiffer(client git.DiffReader) *Differ {
 	}
 }
 
+// withConfigManager supplies ui.diff-tool.
+func (d *Differ) withConfigManager(cm *config.Manager) *Differ {
+	d.configManager = cm
+	return d
+}
+
+// withPathScope limits ggc diff to the directory of ggc --path when no
+// paths are given.
+func (d *Differ) withPathScope(scope *pathScope) *Differ {
+	d.scope = scope
+	return d
+}
+
 type diffMode int
 
 const (
@@ -90,6 +106,9 @@ func (d *Differ) Diff(args []string) {
 		return
 	}
 
+	if len(opts.paths) == 0 {
+		opts.paths = d.scope.pathspecs()
+	}
 	gitArgs := buildDiffArgs(opts)
 	output, err := d.gitClient.DiffWith(gitArgs)
 	if err != nil {
@@ -97,7 +116,13 @@ func (d *Differ) Diff(args []string) {
 		return
 	}
 
-	_, _ = fmt.Fprint(d.outputWriter, output)
+	if opts.stat || opts.nameOnly || opts.nameStatus {
+		_, _ = fmt.Fprint(d.outputWriter, output)
+		return
+	}
+	if err := newHighlighter(d.configManager).Render(d.outputWriter, output); err != nil {
+		WriteError(d.outputWriter, err)
+	}
 }
 
 func parseDiffArgs(args []string, pathExists func(string) bool) (*diffOptions, error) {
@@ -209,9 +234,18 @@ func (s *diffParseState) validateModes() error {
 	if (s.opts.mode == diffModeStaged || s.opts.mode == diffModeUnstaged || s.opts.mode == diffModeHead) && len(s.opts.commits) > 0 {
 		return newDiffUsageError(fmt.Sprintf("%s mode does not accept commit arguments (but allows path arguments)", s.opts.mode.String()))
 	}
+	// A range such as main..feature names both ends of the diff already.
+	if len(s.opts.commits) == 2 && (isCommitRange(s.opts.commits[0]) || isCommitRange(s.opts.commits[1])) {
+		return newDiffUsageError("a commit range cannot be combined with another commit")
+	}
 	return nil
 }
 
+// isCommitRange reports whether arg is a range such as a..b or a...b.
+func isCommitRange(arg string) bool {
+	return strings.Contains(arg, "..")
+}
+
 func classifyDiffArgs(tokens []string, pathExists func(string) bool) ([]string, []string, error) {
 	if len(tokens) == 0 {
 		return nil, nil, nil
@@ -9,7 +9,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 // mockDiffClient implements git.DiffReader with argument capture.
@@ -261,6 +264,21 @@ func TestParseDiffArgs_TooManyCommits(t *testing.T) {
 	}
 }
 
+func TestParseDiffArgs_CommitRange(t *testing.T) {
+	opts, err := parseDiffArgs([]string{"main..feature", "--stat"}, func(string) bool { return false })
+	if err != nil {
+		t.Fatalf("parseDiffArgs returned error: %v", err)
+	}
+	if got, want := buildDiffArgs(opts), []string{"--stat", "main..feature"}; !slices.Equal(got, want) {
+		t.Fatalf("expected git args %v, got %v", want, got)
+	}
+
+	_, err = parseDiffArgs([]string{"main..feature", "abc123"}, func(string) bool { return false })
+	if err == nil || !strings.Contains(err.Error(), "commit range cannot be combined") {
+		t.Fatalf("expected a range plus a commit to be rejected, got %v", err)
+	}
+}
+
 func TestParseDiffArgs_NameStatusFlag(t *testing.T) {
 	opts, err := parseDiffArgs([]string{"--name-status"}, func(string) bool { return false })
 	if err != nil {
@@ -404,3 +422,30 @@ func TestDiffer_Diff_NameStatusFlag(t *testing.T) {
 		t.Fatalf("expected git args %v, got %v", want, mockClient.diffArgs)
 	}
 }
+
+func TestDiffer_Diff_DiffTool(t *testing.T) {
+	t.Cleanup(func() { ui.SetColorMode(ui.ColorAuto) })
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().UI.DiffTool = "sed s/^/>/"
+	client := &mockDiffClient{output: "+added\n"}
+
+	var buf bytes.Buffer
+	d := newTestDiffer(client, &buf).withConfigManager(cm)
+	d.Diff([]string{"unstaged"})
+	if buf.String() != "+added\n" {
+		t.Errorf("piped output should stay plain, got %q", buf.String())
+	}
+
+	ui.SetColorMode(ui.ColorAlways)
+	buf.Reset()
+	d.Diff([]string{"unstaged"})
+	if buf.String() != ">+added\n" {
+		t.Errorf("expected output from the diff tool, got %q", buf.String())
+	}
+
+	buf.Reset()
+	d.Diff([]string{"--stat"})
+	if buf.String() != "+added\n" {
+		t.Errorf("--stat output should not be highlighted, got %q", buf.String())
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -9,8 +10,12 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/hosting"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
 
 	"go.yaml.in/yaml/v3"
 )
@@ -18,26 +23,50 @@ import (
 // Doctor inspects the local environment and reports anything that could
 // prevent ggc from working correctly.
 type Doctor struct {
-	outputWriter io.Writer
-	helper       *Helper
-	execCommand  func(string, ...string) *exec.Cmd
-	lookPath     func(string) (string, error)
-	userHomeDir  func() (string, error)
-	stdinStat    func() (os.FileInfo, error)
+	outputWriter   io.Writer
+	helper         *Helper
+	execCommand    func(string, ...string) *exec.Cmd
+	lookPath       func(string) (string, error)
+	userHomeDir    func() (string, error)
+	stdinStat      func() (os.FileInfo, error)
+	detectTerminal func() string
+	getenv         func(string) string
+	now            func() time.Time
+	tokenChecker   func(ctx context.Context, kind hosting.Kind, apiURL, token string) (*hosting.TokenInfo, error)
+	auth           *PullRequester
+	git            doctorGit // nil skips the checks that ask git about the repository
+}
+
+// doctorGit is what the checks that ask git about the repository need. It
+// is the git client, so those checks follow --trace and the configured git
+// binary like every other command.
+type doctorGit interface {
+	git.ConfigReader
+	git.LFSReader
 }
 
 // NewDoctor creates a new Doctor instance.
 func NewDoctor() *Doctor {
 	return &Doctor{
-		outputWriter: os.Stdout,
-		helper:       NewHelper(),
-		execCommand:  exec.Command,
-		lookPath:     exec.LookPath,
-		userHomeDir:  os.UserHomeDir,
-		stdinStat:    func() (os.FileInfo, error) { return os.Stdin.Stat() },
+		outputWriter:   os.Stdout,
+		helper:         NewHelper(),
+		execCommand:    exec.Command,
+		lookPath:       exec.LookPath,
+		userHomeDir:    os.UserHomeDir,
+		stdinStat:      func() (os.FileInfo, error) { return os.Stdin.Stat() },
+		detectTerminal: kb.DetectTerminal,
+		getenv:         os.Getenv,
+		now:            time.Now,
+		tokenChecker:   hosting.CheckToken,
 	}
 }
 
+// withGit makes the repository checks ask git through g.
+func (d *Doctor) withGit(g doctorGit) *Doctor {
+	d.git = g
+	return d
+}
+
 // diagResult captures one check's outcome.
 type diagResult struct {
 	name   string
@@ -46,8 +75,13 @@ type diagResult struct {
 	detail string
 }
 
-// Doctor runs diagnostics. Any arg prints help (stub for future --json etc).
+// Doctor runs diagnostics. `auth` checks the credentials for the hosting
+// services and the default remote instead; any other arg prints help.
 func (d *Doctor) Doctor(args []string) {
+	if len(args) == 1 && args[0] == "auth" {
+		d.printReport(d.authResults())
+		return
+	}
 	if len(args) > 0 {
 		// Keep the helper's writer in sync so callers/tests that redirect
 		// d.outputWriter see the help output too.
@@ -59,12 +93,15 @@ func (d *Doctor) Doctor(args []string) {
 		d.checkGoRuntime(),
 		d.checkGitBinary(),
 		d.checkGgcOnPATH(),
+		d.checkLFS(),
 		d.checkGgcConfig(),
 		d.checkCompletions("bash"),
 		d.checkCompletions("zsh"),
 		d.checkCompletions("fish"),
 		d.checkTerm(),
+		d.checkTerminalCapabilities(),
 		d.checkTTY(),
+		d.checkKeybindings(),
 	}
 	d.printReport(results)
 }
@@ -191,6 +228,37 @@ func (d *Doctor) checkGgcOnPATH() diagResult {
 	return diagResult{name: "ggc on PATH", ok: true, detail: resolved}
 }
 
+// checkLFS warns when the current repository stores files in Git LFS but
+// git-lfs is not installed: clones then hold pointer files instead of the
+// content.
+func (d *Doctor) checkLFS() diagResult {
+	if d.git == nil {
+		return diagResult{name: "git-lfs", ok: true, detail: "not checked"}
+	}
+	lfsFiles, err := d.git.LFSFiles()
+	if err != nil {
+		return diagResult{name: "git-lfs", ok: true, detail: "not in a git repository"}
+	}
+	files := len(lfsFiles)
+	if files == 0 {
+		return diagResult{name: "git-lfs", ok: true, detail: "this repository does not use Git LFS"}
+	}
+	if !d.git.LFSInstalled() {
+		return diagResult{
+			name:   "git-lfs",
+			ok:     false,
+			warn:   true,
+			detail: fmt.Sprintf("this repository stores %d file(s) in Git LFS but git-lfs is not installed; install it from https://git-lfs.com and run 'ggc lfs pull'", files),
+		}
+	}
+	path, err := d.lookPath("git-lfs")
+	if err != nil {
+		// git finds it outside PATH, in its exec-path.
+		path = "git lfs"
+	}
+	return diagResult{name: "git-lfs", ok: true, detail: fmt.Sprintf("%s (%d file(s) in Git LFS)", path, files)}
+}
+
 // configCandidatePaths mirrors (manager).getConfigPaths without exposing it.
 func (d *Doctor) configCandidatePaths() []string {
 	home, err := d.userHomeDir()
@@ -226,7 +294,7 @@ func (d *Doctor) checkGgcConfig() diagResult {
 	// git.ConfigOps (getDefaultConfig calls methods on it), and the doctor
 	// runs at diagnose time without that dependency wired in. We only need
 	// to know whether the file is a syntactically valid ggc config.
-	if err := parseConfigFile(found); err != nil {
+	if _, err := parseConfigFile(found); err != nil {
 		return diagResult{name: "ggc config", ok: false, detail: fmt.Sprintf("%s: %v", found, err)}
 	}
 	return diagResult{name: "ggc config", ok: true, detail: fmt.Sprintf("%s loaded", found)}
@@ -236,13 +304,52 @@ func (d *Doctor) checkGgcConfig() diagResult {
 // the ggc config schema. It does not apply defaults or perform any git
 // lookups, which makes it safe to call from the doctor without wiring up a
 // full config.Manager.
-func parseConfigFile(path string) error {
+func parseConfigFile(path string) (*config.Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	var cfg config.Config
-	return yaml.Unmarshal(data, &cfg)
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// userConfig is the first config file that parses, or an empty config.
+func (d *Doctor) userConfig() *config.Config {
+	for _, p := range d.configCandidatePaths() {
+		if cfg, err := parseConfigFile(p); err == nil {
+			return cfg
+		}
+	}
+	return &config.Config{}
+}
+
+// checkKeybindings resolves the interactive keybindings the way the UI
+// does and reports keystrokes bound to more than one action.
+func (d *Doctor) checkKeybindings() diagResult {
+	const name = "keybindings"
+	cfg := d.userConfig()
+	profile := kb.Profile(cfg.Interactive.Profile)
+	if profile == "" {
+		profile = kb.ProfileDefault
+	}
+	resolver := kb.NewKeyBindingResolver(cfg)
+	kb.RegisterBuiltinProfiles(resolver)
+	if conflicts := resolver.Conflicts(profile); len(conflicts) > 0 {
+		descriptions := make([]string, len(conflicts))
+		for i, c := range conflicts {
+			descriptions[i] = c.String()
+		}
+		return diagResult{
+			name:   name,
+			ok:     false,
+			warn:   true,
+			detail: fmt.Sprintf("%s; run `ggc config keybindings doctor` to see how to fix them", strings.Join(descriptions, "; ")),
+		}
+	}
+	return diagResult{name: name, ok: true, detail: fmt.Sprintf("%s profile, no conflicts", profile)}
 }
 
 // checkCompletions looks for an installed completion script in well-known
@@ -306,6 +413,45 @@ func (d *Doctor) checkTTY() diagResult {
 	return diagResult{name: "stdin TTY", ok: true, detail: "stdin is a TTY"}
 }
 
+// checkTerminalCapabilities reports what the interactive UI assumes the
+// detected terminal supports.
+func (d *Doctor) checkTerminalCapabilities() diagResult {
+	terminal := d.detectTerminal()
+	caps := kb.GetTerminalCapabilities(terminal)
+	var have, lack []string
+	for _, c := range terminalCapabilities {
+		if caps[c.key] {
+			have = append(have, c.label)
+		} else {
+			lack = append(lack, c.label)
+		}
+	}
+	detail := terminal
+	if len(have) > 0 {
+		detail += ": " + strings.Join(have, ", ")
+	}
+	if len(lack) > 0 {
+		detail += "; no " + strings.Join(lack, ", ")
+	}
+	if m, ok := kb.MultiplexerFor(terminal); ok {
+		detail += fmt.Sprintf("; %s keeps %s for itself, %s to send it to ggc", m.Name, m.Prefix, m.Passthrough)
+	}
+	if !caps["alt_keys"] {
+		return diagResult{name: "terminal", ok: false, warn: true, detail: detail + "; Alt keybindings will not work"}
+	}
+	return diagResult{name: "terminal", ok: true, detail: detail}
+}
+
+// terminalCapabilities are the keys of kb.GetTerminalCapabilities, in
+// report order.
+var terminalCapabilities = []struct{ key, label string }{
+	{"alt_keys", "Alt keys"},
+	{"function_keys", "function keys"},
+	{"color_256", "256 colors"},
+	{"unicode", "Unicode"},
+	{"mouse", "mouse"},
+}
+
 // checkTerm warns when $TERM looks like something the interactive TUI
 // cannot fully drive (dumb terminal, unset, or vt52-level).
 func (d *Doctor) checkTerm() diagResult {
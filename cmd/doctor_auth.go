@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/hosting"
+)
+
+// authCheckTimeout bounds each network check of `ggc doctor auth`.
+const authCheckTimeout = 10 * time.Second
+
+// tokenExpiryWarning is how close to its expiry a token is reported.
+const tokenExpiryWarning = 7 * 24 * time.Hour
+
+// sshKeyFiles are the private keys ssh tries when ~/.ssh/config names none.
+var sshKeyFiles = []string{"id_ed25519", "id_ecdsa", "id_rsa", "id_ed25519_sk", "id_ecdsa_sk"}
+
+// withAuth lets `ggc doctor auth` read the integration settings and the
+// default remote the pr command uses.
+func (d *Doctor) withAuth(p *PullRequester) *Doctor {
+	d.auth = p
+	return d
+}
+
+// authResults checks the hosting tokens and the credentials git uses for
+// the default remote: the token of every configured service, and the SSH
+// agent, keys and login, or the credential helper, of the remote.
+func (d *Doctor) authResults() []diagResult {
+	if d.auth == nil {
+		return []diagResult{{name: "auth", ok: false, warn: true, detail: "not available outside a ggc command"}}
+	}
+	settings := d.auth.integrations()
+	remote := d.auth.remote()
+	var results []diagResult
+
+	remoteURL, err := d.auth.gitClient.RemoteGetURL(remote)
+	if err != nil {
+		results = append(results, diagResult{
+			name:   "default remote",
+			ok:     false,
+			warn:   true,
+			detail: fmt.Sprintf("cannot read remote %q (%v); only tokens are checked", remote, err),
+		})
+		remoteURL = ""
+	}
+	var remoteKind hosting.Kind
+	var remoteHost string
+	if remoteURL != "" {
+		kind, host, _, err := detectService(remoteURL, settings)
+		if err != nil {
+			results = append(results, diagResult{name: "default remote", ok: true, detail: fmt.Sprintf("%s %s (%v)", remote, remoteURL, err)})
+		} else {
+			remoteKind, remoteHost = kind, host
+			results = append(results, diagResult{name: "default remote", ok: true, detail: fmt.Sprintf("%s %s (%s)", remote, remoteURL, kind)})
+		}
+	}
+
+	for _, kind := range hosting.Kinds {
+		apiURL := settings[kind].apiURL
+		if kind == remoteKind {
+			apiURL = serviceAPIURL(kind, remoteHost, settings[kind])
+		}
+		if r, ok := d.checkToken(kind, apiURL, settings[kind], kind == remoteKind); ok {
+			results = append(results, r)
+		}
+	}
+
+	if user, host, port, ok := parseSSHRemote(remoteURL); ok {
+		results = append(results, d.checkSSHAgent(), d.checkSSHKeys(), d.checkSSHLogin(user, host, port))
+	} else if strings.HasPrefix(remoteURL, "https://") || strings.HasPrefix(remoteURL, "http://") {
+		results = append(results, d.checkCredentialHelper())
+	}
+	return results
+}
+
+// checkToken calls the API of kind with its token. A service without a
+// token is only reported when it hosts the default remote; ok is false
+// when there is nothing to report.
+func (d *Doctor) checkToken(kind hosting.Kind, apiURL string, s integration, required bool) (diagResult, bool) {
+	name := kind.String() + " token"
+	token, source := d.auth.storedToken(kind, s)
+	if token == "" {
+		if !required {
+			return diagResult{}, false
+		}
+		return diagResult{
+			name:   name,
+			ok:     false,
+			warn:   true,
+			detail: fmt.Sprintf("not set; ggc pr and ggc release need one. Set integration.%s.token or %s", string(kind), s.env[0]),
+		}, true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), authCheckTimeout)
+	defer cancel()
+	info, err := d.tokenChecker(ctx, kind, apiURL, token)
+	var apiErr *hosting.APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized:
+		return diagResult{
+			name:   name,
+			ok:     false,
+			detail: fmt.Sprintf("the token from %s was rejected (expired or revoked); create a new one and update %s", source, source),
+		}, true
+	case err != nil:
+		return diagResult{name: name, ok: false, warn: true, detail: fmt.Sprintf("could not check the token from %s: %v", source, err)}, true
+	}
+
+	detail := fmt.Sprintf("authenticated as %s (from %s)", info.User, source)
+	if missing := info.MissingScopes(kind); len(missing) > 0 {
+		return diagResult{
+			name:   name,
+			ok:     false,
+			warn:   true,
+			detail: fmt.Sprintf("%s, but it lacks the %s scope(s) ggc pr and ggc release need; create a token with them", detail, strings.Join(missing, ", ")),
+		}, true
+	}
+	if !info.Expires.IsZero() {
+		expires := info.Expires.Local().Format(time.DateOnly)
+		if info.Expires.Sub(d.now()) < tokenExpiryWarning {
+			return diagResult{
+				name:   name,
+				ok:     false,
+				warn:   true,
+				detail: fmt.Sprintf("%s, but it expires on %s; renew it before then", detail, expires),
+			}, true
+		}
+		detail += ", expires " + expires
+	}
+	return diagResult{name: name, ok: true, detail: detail}, true
+}
+
+// checkSSHAgent asks the agent which keys it holds. ssh-add -l exits 1
+// when the agent has none and 2 when it cannot reach one.
+func (d *Doctor) checkSSHAgent() diagResult {
+	const name = "ssh agent"
+	out, err := d.execCommand("ssh-add", "-l").CombinedOutput()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		n := len(strings.Split(strings.TrimSpace(string(out)), "\n"))
+		return diagResult{name: name, ok: true, detail: fmt.Sprintf("%d key(s) loaded", n)}
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		return diagResult{name: name, ok: false, warn: true, detail: "the agent holds no keys; run `ssh-add` to load yours"}
+	case errors.As(err, &exitErr):
+		detail := "no agent is running"
+		if sock := d.getenv("SSH_AUTH_SOCK"); sock != "" {
+			detail = "cannot reach the agent at " + sock
+		}
+		return diagResult{name: name, ok: false, warn: true, detail: detail + "; keys with a passphrase will prompt on every fetch and push. Start one with `eval \"$(ssh-agent)\"` and run `ssh-add`"}
+	default:
+		return diagResult{name: name, ok: false, warn: true, detail: fmt.Sprintf("cannot run ssh-add: %v", err)}
+	}
+}
+
+// checkSSHKeys looks for the default private keys in ~/.ssh.
+func (d *Doctor) checkSSHKeys() diagResult {
+	const name = "ssh keys"
+	home, err := d.userHomeDir()
+	if err != nil {
+		return diagResult{name: name, ok: false, warn: true, detail: fmt.Sprintf("cannot resolve $HOME: %v", err)}
+	}
+	var found []string
+	for _, key := range sshKeyFiles {
+		if _, err := os.Stat(filepath.Join(home, ".ssh", key)); err == nil {
+			found = append(found, key)
+		}
+	}
+	if len(found) == 0 {
+		return diagResult{
+			name:   name,
+			ok:     false,
+			warn:   true,
+			detail: "no default key in ~/.ssh (fine if ~/.ssh/config sets an IdentityFile); create one with `ssh-keygen -t ed25519` and add the .pub file to your account",
+		}
+	}
+	return diagResult{name: name, ok: true, detail: strings.Join(found, ", ")}
+}
+
+// checkSSHLogin connects to the remote's host the way git does, without
+// prompting. The hosting services answer a shell-less login with a
+// greeting, and ssh exits non-zero even when it succeeds, so the output
+// decides.
+func (d *Doctor) checkSSHLogin(user, host, port string) diagResult {
+	const name = "ssh login"
+	args := []string{"-T", "-o", "BatchMode=yes", "-o", "ConnectTimeout=5"}
+	if port != "" {
+		args = append(args, "-p", port)
+	}
+	target := host
+	if user != "" {
+		target = user + "@" + host
+	}
+	out, err := d.execCommand("ssh", append(args, target)...).CombinedOutput()
+	text := strings.TrimSpace(string(out))
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "successfully authenticated"), strings.Contains(lower, "welcome to"):
+		return diagResult{name: name, ok: true, detail: target}
+	case strings.Contains(lower, "permission denied"):
+		return diagResult{
+			name:   name,
+			ok:     false,
+			detail: fmt.Sprintf("%s rejected your keys; add your public key to your account on %s, or point ~/.ssh/config at the right IdentityFile", target, host),
+		}
+	case strings.Contains(lower, "host key verification failed"):
+		return diagResult{
+			name:   name,
+			ok:     false,
+			detail: fmt.Sprintf("the host key of %s is unknown or has changed; run `ssh -T %s` once to check and accept it", host, target),
+		}
+	case err == nil:
+		return diagResult{name: name, ok: true, detail: target}
+	}
+	if i := strings.LastIndex(text, "\n"); i >= 0 {
+		text = text[i+1:]
+	}
+	if text == "" {
+		text = err.Error()
+	}
+	return diagResult{name: name, ok: false, warn: true, detail: fmt.Sprintf("could not log in to %s: %s", target, text)}
+}
+
+// checkCredentialHelper reports whether git stores HTTPS credentials.
+func (d *Doctor) checkCredentialHelper() diagResult {
+	const name = "credential helper"
+	if d.git == nil {
+		return diagResult{name: name, ok: true, detail: "not checked"}
+	}
+	if helper, _ := d.git.ConfigGet("credential.helper"); helper != "" {
+		return diagResult{name: name, ok: true, detail: helper}
+	}
+	return diagResult{
+		name:   name,
+		ok:     false,
+		warn:   true,
+		detail: "none configured; git asks for your password or token on every fetch and push. Set one with `git config --global credential.helper <osxkeychain|manager|libsecret|cache>`",
+	}
+}
+
+// parseSSHRemote returns the user, host and port of an SSH remote URL:
+// ssh://[user@]host[:port]/path or the scp-like [user@]host:path.
+func parseSSHRemote(raw string) (user, host, port string, ok bool) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "ssh://") || strings.HasPrefix(raw, "git+ssh://") {
+		u, err := url.Parse(raw)
+		if err != nil || u.Hostname() == "" {
+			return "", "", "", false
+		}
+		return u.User.Username(), u.Hostname(), u.Port(), true
+	}
+	if strings.Contains(raw, "://") {
+		return "", "", "", false
+	}
+	hostPart, _, found := strings.Cut(raw, ":")
+	// A colon after a slash is part of a local path, and a one-letter
+	// "host" is a Windows drive.
+	if !found || strings.Contains(hostPart, "/") || len(hostPart) < 2 {
+		return "", "", "", false
+	}
+	if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+		return hostPart[:i], hostPart[i+1:], "", true
+	}
+	return "", hostPart, "", true
+}
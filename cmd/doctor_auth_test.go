@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/hosting"
+)
+
+func newAuthDoctor(out *bytes.Buffer, remoteURL string, env map[string]string) *Doctor {
+	p := NewPullRequester(&mockPRGitClient{remoteURL: remoteURL})
+	p.getenv = func(k string) string { return env[k] }
+	d := newTestDoctor(out).withAuth(p)
+	d.getenv = p.getenv
+	d.now = func() time.Time { return time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC) }
+	d.userHomeDir = func() (string, error) { return "/nonexistent", nil }
+	return d
+}
+
+func TestDoctor_Auth_HTTPSRemote(t *testing.T) {
+	var out bytes.Buffer
+	d := newAuthDoctor(&out, "https://github.com/octo/hello.git", map[string]string{"GH_TOKEN": "secret"})
+	var gotURL string
+	d.tokenChecker = func(_ context.Context, kind hosting.Kind, apiURL, token string) (*hosting.TokenInfo, error) {
+		gotURL = apiURL
+		return &hosting.TokenInfo{User: "octo", ScopesKnown: true, Scopes: []string{"repo"}, Expires: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)}, nil
+	}
+	d.withGit(stubDoctorGit{config: map[string]string{"credential.helper": "osxkeychain"}})
+
+	d.Doctor([]string{"auth"})
+	if gotURL != hosting.DefaultGitHubAPIURL {
+		t.Errorf("checked %q, want the github.com API", gotURL)
+	}
+	for _, want := range []string{
+		"[OK  ] default remote: origin https://github.com/octo/hello.git (GitHub)",
+		"[WARN] GitHub token: authenticated as octo (from GH_TOKEN), but it expires on 2026-10-20",
+		"[OK  ] credential helper: osxkeychain",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "GitLab token") {
+		t.Errorf("an unconfigured service was reported:\n%s", out.String())
+	}
+}
+
+func TestDoctor_Auth_RejectedTokenAndMissingScope(t *testing.T) {
+	var out bytes.Buffer
+	d := newAuthDoctor(&out, "git@gitlab.com:group/app.git", map[string]string{"GITHUB_TOKEN": "old", "GITLAB_TOKEN": "ro"})
+	d.tokenChecker = func(_ context.Context, kind hosting.Kind, _, _ string) (*hosting.TokenInfo, error) {
+		if kind == hosting.GitHub {
+			return nil, &hosting.APIError{Kind: kind, StatusCode: 401, Message: "Bad credentials"}
+		}
+		return &hosting.TokenInfo{User: "tanuki", ScopesKnown: true, Scopes: []string{"read_api"}}, nil
+	}
+	d.execCommand = func(name string, _ ...string) *exec.Cmd {
+		if name == "ssh" {
+			return exec.Command("echo", "Welcome to GitLab, @tanuki!")
+		}
+		return exec.Command("false")
+	}
+
+	d.Doctor([]string{"auth"})
+	for _, want := range []string{
+		"[FAIL] GitHub token: the token from GITHUB_TOKEN was rejected",
+		"[WARN] GitLab token: authenticated as tanuki (from GITLAB_TOKEN), but it lacks the api scope(s)",
+		"[WARN] ssh agent: the agent holds no keys",
+		"[WARN] ssh keys: no default key in ~/.ssh",
+		"[OK  ] ssh login: git@gitlab.com",
+		"1 hard failure(s)",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestDoctor_Auth_MissingTokenAndSSHKeys(t *testing.T) {
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".ssh"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".ssh", "id_ed25519"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	d := newAuthDoctor(&out, "ssh://git@github.com:2222/octo/hello.git", nil)
+	d.userHomeDir = func() (string, error) { return home, nil }
+	var sshArgs []string
+	d.execCommand = func(name string, args ...string) *exec.Cmd {
+		if name == "ssh" {
+			sshArgs = args
+			return exec.Command("echo", "git@github.com: Permission denied (publickey).")
+		}
+		return exec.Command("echo", "256 SHA256:abc you@host (ED25519)")
+	}
+
+	d.Doctor([]string{"auth"})
+	for _, want := range []string{
+		"[WARN] GitHub token: not set; ggc pr and ggc release need one. Set integration.github.token or GITHUB_TOKEN",
+		"[OK  ] ssh agent: 1 key(s) loaded",
+		"[OK  ] ssh keys: id_ed25519",
+		"[FAIL] ssh login: git@github.com rejected your keys",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+	if got := strings.Join(sshArgs, " "); !strings.Contains(got, "-p 2222 git@github.com") {
+		t.Errorf("ssh args = %q", got)
+	}
+}
+
+func TestParseSSHRemote(t *testing.T) {
+	tests := []struct {
+		url              string
+		user, host, port string
+		ok               bool
+	}{
+		{"git@github.com:octo/hello.git", "git", "github.com", "", true},
+		{"ssh://git@example.com:2222/octo/hello.git", "git", "example.com", "2222", true},
+		{"example.com:octo/hello.git", "", "example.com", "", true},
+		{"https://github.com/octo/hello.git", "", "", "", false},
+		{"/srv/repos/a:b", "", "", "", false},
+		{`C:\repos\hello`, "", "", "", false},
+	}
+	for _, tt := range tests {
+		user, host, port, ok := parseSSHRemote(tt.url)
+		if user != tt.user || host != tt.host || port != tt.port || ok != tt.ok {
+			t.Errorf("parseSSHRemote(%q) = %q, %q, %q, %v", tt.url, user, host, port, ok)
+		}
+	}
+}
+
+func TestDoctor_CheckCredentialHelper(t *testing.T) {
+	d := newTestDoctor(&bytes.Buffer{}).withGit(stubDoctorGit{})
+	if r := d.checkCredentialHelper(); r.ok || !r.warn || !strings.Contains(r.detail, "none configured") {
+		t.Errorf("no helper should be WARN, got %+v", r)
+	}
+	d.withGit(stubDoctorGit{config: map[string]string{"credential.helper": "cache"}})
+	if r := d.checkCredentialHelper(); !r.ok || r.detail != "cache" {
+		t.Errorf("a helper should be OK, got %+v", r)
+	}
+}
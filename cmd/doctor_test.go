@@ -10,6 +10,24 @@ import (
 	"testing"
 )
 
+// stubDoctorGit answers the repository checks of ggc doctor.
+type stubDoctorGit struct {
+	config       map[string]string
+	lfsFiles     []string
+	lfsErr       error
+	lfsInstalled bool
+}
+
+func (s stubDoctorGit) LFSInstalled() bool          { return s.lfsInstalled }
+func (s stubDoctorGit) LFSFiles() ([]string, error) { return s.lfsFiles, s.lfsErr }
+
+func (s stubDoctorGit) ConfigGet(key string) (string, error) {
+	if v, ok := s.config[key]; ok {
+		return v, nil
+	}
+	return "", errors.New("not set")
+}
+
 func newTestDoctor(out *bytes.Buffer) *Doctor {
 	d := NewDoctor()
 	d.outputWriter = out
@@ -224,3 +242,60 @@ func TestDoctor_GgcOnPATH_NotFound(t *testing.T) {
 		t.Fatalf("missing ggc on PATH should be WARN, got %+v", r)
 	}
 }
+
+func TestDoctor_Keybindings(t *testing.T) {
+	tmp := t.TempDir()
+	d := newTestDoctor(&bytes.Buffer{})
+	d.userHomeDir = func() (string, error) { return tmp, nil }
+	if r := d.checkKeybindings(); !r.ok || !strings.Contains(r.detail, "default profile, no conflicts") {
+		t.Fatalf("built-in bindings should not conflict, got %+v", r)
+	}
+
+	cfg := "interactive:\n  keybindings:\n    move_up: \"ctrl+w\"\n"
+	if err := os.WriteFile(filepath.Join(tmp, ".ggcconfig.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r := d.checkKeybindings()
+	if r.ok || !r.warn || !strings.Contains(r.detail, "move_up") {
+		t.Fatalf("want a WARN naming move_up, got %+v", r)
+	}
+}
+
+func TestDoctor_TerminalCapabilities(t *testing.T) {
+	d := newTestDoctor(&bytes.Buffer{})
+	d.detectTerminal = func() string { return "xterm" }
+	r := d.checkTerminalCapabilities()
+	if !r.ok || r.detail != "xterm: Alt keys, function keys, 256 colors, Unicode; no mouse" {
+		t.Fatalf("unexpected result %+v", r)
+	}
+	d.detectTerminal = func() string { return "dumb" }
+	if r := d.checkTerminalCapabilities(); r.ok || !r.warn {
+		t.Fatalf("dumb terminal should WARN, got %+v", r)
+	}
+	d.detectTerminal = func() string { return "screen" }
+	if r := d.checkTerminalCapabilities(); !r.ok || !strings.HasSuffix(r.detail, "; screen keeps Ctrl+a for itself, press Ctrl+a then a to send it to ggc") {
+		t.Fatalf("screen should explain its prefix, got %+v", r)
+	}
+}
+
+func TestDoctor_LFS(t *testing.T) {
+	d := newTestDoctor(&bytes.Buffer{})
+	d.withGit(stubDoctorGit{lfsFiles: []string{"a.psd", "b.psd"}})
+	d.lookPath = func(string) (string, error) { return "", errors.New("not found") }
+	r := d.checkLFS()
+	if r.ok || !r.warn || !strings.Contains(r.detail, "2 file(s)") {
+		t.Fatalf("missing git-lfs should be WARN, got %+v", r)
+	}
+
+	d.withGit(stubDoctorGit{lfsFiles: []string{"a.psd", "b.psd"}, lfsInstalled: true})
+	d.lookPath = func(string) (string, error) { return "/usr/bin/git-lfs", nil }
+	if r := d.checkLFS(); !r.ok || !strings.Contains(r.detail, "/usr/bin/git-lfs") {
+		t.Fatalf("installed git-lfs should be OK, got %+v", r)
+	}
+
+	d.withGit(stubDoctorGit{})
+	d.lookPath = func(string) (string, error) { return "", errors.New("not found") }
+	if r := d.checkLFS(); !r.ok {
+		t.Fatalf("a repository without LFS files should be OK, got %+v", r)
+	}
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// Exit codes. 1 stays the code for any failure without a more specific
+// one, so scripts that only test for non-zero keep working.
+const (
+	ExitOK            = 0
+	ExitFailure       = 1
+	ExitUsage         = 2
+	ExitNotARepo      = 3
+	ExitMergeConflict = 4
+	ExitAuthFailed    = 5
+	ExitNetwork       = 6
+	ExitNoRemote      = 7
+	ExitNoUpstream    = 8
+	ExitDetachedHead  = 9
+	ExitDirtyWorktree = 10
+	ExitPushRejected  = 11
+	ExitInterrupted   = 130
+)
+
+// ErrUnknownCommand is returned by Route for a name that is neither a
+// command nor an alias.
+var ErrUnknownCommand = errors.New("unknown command")
+
+// ErrAmbiguousCommand is returned by Route for an abbreviation that fits
+// more than one command or subcommand.
+var ErrAmbiguousCommand = errors.New("ambiguous command")
+
+// errorKind is how ggc reports one class of failure: the exit code and the
+// next step it suggests.
+type errorKind struct {
+	err        error
+	code       int
+	suggestion string
+}
+
+var errorKinds = []errorKind{
+	{ErrUnknownCommand, ExitUsage, "run `ggc help` to list the commands"},
+	{ErrAmbiguousCommand, ExitUsage, "type more of the name to pick one"},
+	{git.ErrNotARepo, ExitNotARepo, "cd into a repository, or get one with `ggc clone <repository>` or `git init`"},
+	{git.ErrMergeConflict, ExitMergeConflict, "resolve the conflicts, `ggc add` the files, then continue (e.g. `ggc rebase continue`) or abort"},
+	{git.ErrAuthFailed, ExitAuthFailed, "check the SSH key or credential helper for this remote (`ggc remote list` shows its URL)"},
+	{git.ErrNetwork, ExitNetwork, "check your connection and proxy settings, then try again"},
+	{git.ErrNoRemote, ExitNoRemote, "run `ggc remote add <name> <url>` to add a remote"},
+	{git.ErrNoUpstream, ExitNoUpstream, "run `ggc push current` to publish the branch, or `ggc branch set upstream <branch> <upstream>`"},
+	{git.ErrDetachedHead, ExitDetachedHead, "run `ggc switch -c <branch>` to keep this work on a branch, or `ggc switch <branch>` to go back"},
+	{git.ErrDirtyWorktree, ExitDirtyWorktree, "commit your changes or put them away with `ggc stash`, then try again"},
+	{git.ErrPushRejected, ExitPushRejected, "run `ggc pull rebase` (or `ggc sync`) to bring in the remote commits, then push again"},
+}
+
+func kindOf(err error) (errorKind, bool) {
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			return k, true
+		}
+	}
+	return errorKind{}, false
+}
+
+// ExitCode returns the process exit code for err: 0 for nil, a specific
+// code for the failures ggc recognizes, and 1 otherwise.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	}
+	if k, ok := kindOf(err); ok {
+		return k.code
+	}
+	return ExitFailure
+}
+
+// Suggestion returns the next step ggc suggests for err, or "" when it has
+// none.
+func Suggestion(err error) string {
+	if k, ok := kindOf(err); ok {
+		return k.suggestion
+	}
+	return ""
+}
+
+// ReportedError is returned by Route when the command failed after it
+// printed its own error. Callers only need to exit with its code.
+type ReportedError struct {
+	Err error
+}
+
+func (e *ReportedError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the error the command printed.
+func (e *ReportedError) Unwrap() error { return e.Err }
+
+// isReported reports whether err was already printed by the command.
+func isReported(err error) bool {
+	var reported *ReportedError
+	return errors.As(err, &reported)
+}
+
+// failures records the first error a command prints through WriteError or
+// WriteErrorf, since handlers report failures by printing them rather than
+// returning them.
+var failures struct {
+	sync.Mutex
+	err error
+}
+
+func recordFailure(err error) {
+	failures.Lock()
+	defer failures.Unlock()
+	if failures.err == nil {
+		failures.err = err
+	}
+}
+
+// takeFailure returns the recorded error and clears it.
+func takeFailure() error {
+	failures.Lock()
+	defer failures.Unlock()
+	err := failures.err
+	failures.err = nil
+	return err
+}
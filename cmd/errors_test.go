@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, ExitOK},
+		{errors.New("boom"), ExitFailure},
+		{fmt.Errorf("%w: %q", ErrUnknownCommand, "nope"), ExitUsage},
+		{git.NewOpError("status", "git status", errors.New("fatal: not a git repository")), ExitNotARepo},
+		{&ReportedError{Err: git.NewOpError("push", "git push", errors.New("! [rejected] main -> main (non-fast-forward)"))}, ExitPushRejected},
+		{fmt.Errorf("fetch: %w", context.Canceled), ExitInterrupted},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestWriteError_SuggestsNextStep(t *testing.T) {
+	var buf bytes.Buffer
+	WriteError(&buf, git.NewOpError("pull", "git pull", errors.New("There is no tracking information for the current branch.")))
+	_ = takeFailure()
+	if !strings.Contains(buf.String(), "\n  Next: run `ggc push current`") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestCmd_Route_ReportsFailure(t *testing.T) {
+	mockClient := testutil.NewMockGitClient()
+	c, err := NewCmd(mockClient, config.NewConfigManager(mockClient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	c.outputWriter = &buf
+
+	err = c.Route([]string{"history", "last", "many"})
+	var reported *ReportedError
+	if !errors.As(err, &reported) || ExitCode(err) != ExitFailure {
+		t.Errorf("Route(history last many) = %v, want a reported failure", err)
+	}
+	if err := c.Route([]string{"version"}); err != nil {
+		t.Errorf("Route(version) = %v after a failed command", err)
+	}
+
+	err = c.Route([]string{"no-such-command"})
+	if !errors.Is(err, ErrUnknownCommand) || ExitCode(err) != ExitUsage {
+		t.Errorf("Route(unknown) = %v", err)
+	}
+}
@@ -12,14 +12,27 @@ import (
 // This is the main entry point that handles both aliases and regular commands.
 //
 // It returns a non-nil error if executing an alias fails, such as when alias parsing
-// or placeholder processing encounters an error; interactive mode and regular commands
-// do not cause Execute to return an error.
+// or placeholder processing encounters an error, and the error from Route when a
+// command fails; interactive mode does not cause Execute to return an error.
+//
+// Without arguments, Execute starts interactive mode when stdin is a
+// terminal and runs the commands piped to stdin in batch mode otherwise;
+// RunBatch forces batch mode.
 func (c *Cmd) Execute(args []string) error {
 	if len(args) == 0 {
+		if c.stdinIsTerminal != nil && !c.stdinIsTerminal() {
+			return c.Batch(c.stdin)
+		}
 		c.Interactive()
 		return nil
 	}
+	return c.dispatch(args)
+}
 
+// dispatch runs args as an alias when args[0] names one, and routes it as a
+// regular command otherwise. Both scripted and interactive mode go through
+// here so aliases behave the same in either.
+func (c *Cmd) dispatch(args []string) error {
 	cmdName, cmdArgs := args[0], args[1:]
 
 	// Check if this is an alias
@@ -58,12 +71,16 @@ func (c *Cmd) executeSimpleAlias(alias *config.ParsedAlias, args []string, name
 	}
 
 	command := tokenize(processedCommands[0])
-	if len(alias.Placeholders) == 0 {
+	if !alias.HasPlaceholders() {
 		// No placeholders, forward user arguments
 		return c.Route(append([]string{command[0]}, args...))
 	}
+	values, err := promptValues(alias, args, name)
+	if err != nil {
+		return err
+	}
 	// Placeholders were processed, use the processed command
-	return c.Route(command)
+	return c.Route(fillPrompts(command, values))
 }
 
 // executeSequenceAlias executes a sequence alias (multiple commands in order).
@@ -73,9 +90,14 @@ func (c *Cmd) executeSequenceAlias(alias *config.ParsedAlias, args []string, nam
 		return err
 	}
 
+	values, err := promptValues(alias, args, name)
+	if err != nil {
+		return err
+	}
+
 	for _, cmd := range processedCommands {
-		_, _ = fmt.Fprintf(c.outputWriter, "Executing: %s\n", cmd)
-		command := tokenize(cmd)
+		command := fillPrompts(tokenize(cmd), values)
+		_, _ = fmt.Fprintf(c.outputWriter, "Executing: %s\n", strings.Join(command, " "))
 		if err := c.Route(command); err != nil {
 			return err
 		}
@@ -93,7 +115,7 @@ func (c *Cmd) executeSequenceAlias(alias *config.ParsedAlias, args []string, nam
 // index in use and returns an error if the requirements are not met.
 func (c *Cmd) processPlaceholders(alias *config.ParsedAlias, args []string, aliasName string) ([]string, error) {
 	// If no placeholders are used, handle arguments appropriately
-	if len(alias.Placeholders) == 0 {
+	if !alias.HasPlaceholders() {
 		if alias.Type == config.SequenceAlias && len(args) > 0 {
 			return nil, fmt.Errorf("sequence alias '%s' does not accept arguments (got %s)", aliasName, strings.Join(args, " "))
 		}
@@ -134,6 +156,45 @@ func (c *Cmd) processPlaceholders(alias *config.ParsedAlias, args []string, alia
 	return processedCommands, nil
 }
 
+// promptValues assigns the arguments left over after positional
+// placeholders to the alias's <name> placeholders in order. The last one
+// takes all remaining words, so `ggc cmp fix the build` needs no quoting.
+// In interactive mode the UI prompts for each <name> before dispatching.
+func promptValues(alias *config.ParsedAlias, args []string, aliasName string) (map[string]string, error) {
+	if len(alias.Prompts) == 0 {
+		return nil, nil
+	}
+	rest := args[alias.MaxPositionalArg+1:]
+	if len(rest) < len(alias.Prompts) {
+		missing := alias.Prompts[len(rest):]
+		return nil, fmt.Errorf("alias '%s' requires a value for <%s>", aliasName, strings.Join(missing, ">, <"))
+	}
+
+	values := make(map[string]string, len(alias.Prompts))
+	last := len(alias.Prompts) - 1
+	for i, name := range alias.Prompts[:last] {
+		values[name] = rest[i]
+	}
+	values[alias.Prompts[last]] = strings.Join(rest[last:], " ")
+	return values, nil
+}
+
+// fillPrompts replaces <name> placeholders inside already tokenized args so
+// that multi-word values stay a single argument.
+func fillPrompts(args []string, values map[string]string) []string {
+	if len(values) == 0 {
+		return args
+	}
+	filled := make([]string, len(args))
+	for i, arg := range args {
+		for name, value := range values {
+			arg = strings.ReplaceAll(arg, "<"+name+">", value)
+		}
+		filled[i] = arg
+	}
+	return filled
+}
+
 // replaceNamedPlaceholders substitutes a small, well-known set of named
 // placeholders that are derived from environment rather than arguments.
 // Supported names:
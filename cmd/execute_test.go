@@ -4,7 +4,9 @@ import (
 	"strings"
 	"testing"
 
+	commandregistry "github.com/bmf-san/ggc/v8/cmd/command"
 	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
 	"github.com/bmf-san/ggc/v8/internal/testutil"
 )
 
@@ -359,3 +361,120 @@ func TestExecute_InvalidAliasFormat(t *testing.T) {
 		t.Fatal("invalid alias format should return error")
 	}
 }
+
+func TestExecute_WithChainedAliasAndPrompt(t *testing.T) {
+	mockClient := testutil.NewMockGitClient()
+	configManager := config.NewConfigManager(mockClient)
+	_ = configManager.LoadConfig()
+
+	cfg := configManager.GetConfig()
+	cfg.Aliases = map[string]interface{}{
+		"cmp": "status && commit <message> && push current",
+	}
+
+	c, err := NewCmd(mockClient, configManager)
+	if err != nil {
+		t.Fatalf("NewCmd returned an unexpected error: %v", err)
+	}
+	var buf strings.Builder
+	c.outputWriter = &buf
+
+	if err := c.Execute([]string{"cmp", "fix", "the", "build"}); err != nil {
+		t.Fatalf("chained alias should not return error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Executing: status", "Executing: commit fix the build", "Executing: push current"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	err = c.Execute([]string{"cmp"})
+	if err == nil || !strings.Contains(err.Error(), "<message>") {
+		t.Errorf("expected missing <message> error, got %v", err)
+	}
+}
+
+func TestPromptValues(t *testing.T) {
+	alias := &config.ParsedAlias{
+		Type:             config.SequenceAlias,
+		Prompts:          []string{"branch", "message"},
+		MaxPositionalArg: 0,
+	}
+	values, err := promptValues(alias, []string{"origin", "feat/x", "add", "login"}, "ship")
+	if err != nil {
+		t.Fatalf("promptValues() unexpected error: %v", err)
+	}
+	if values["branch"] != "feat/x" || values["message"] != "add login" {
+		t.Errorf("promptValues() = %v", values)
+	}
+
+	got := fillPrompts([]string{"commit", "-m", "<message>", "--to=<branch>"}, values)
+	want := []string{"commit", "-m", "add login", "--to=feat/x"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("fillPrompts() = %q, want %q", got, want)
+	}
+
+	if _, err := promptValues(alias, []string{"origin", "feat/x"}, "ship"); err == nil {
+		t.Error("promptValues() expected error for missing <message>")
+	}
+}
+
+func TestBuildInteractiveAliases(t *testing.T) {
+	cfg := &config.Config{Aliases: map[string]interface{}{
+		"st":   "status",
+		"ship": []interface{}{"branch checkout {0}", "commit <message>"},
+	}}
+
+	got := buildInteractiveAliases(cfg)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(got))
+	}
+	if got[0].Command != "ship <arg0> <message>" {
+		t.Errorf("Command = %q", got[0].Command)
+	}
+	if got[0].Description != "Alias: branch checkout {0} && commit <message>" {
+		t.Errorf("Description = %q", got[0].Description)
+	}
+	if got[1].Command != "st" {
+		t.Errorf("Command = %q", got[1].Command)
+	}
+}
+
+func TestBuildInteractiveCommands_PreviewDetails(t *testing.T) {
+	byName := map[string]interactive.CommandInfo{}
+	for _, cmd := range buildInteractiveCommands(commandregistry.NewRegistry()) {
+		byName[cmd.Command] = cmd
+	}
+
+	push := byName["push force"]
+	if push.Git != "git push origin <branch> --force-with-lease" || len(push.Usage) == 0 {
+		t.Errorf("push force = %+v, want git invocation and usage", push)
+	}
+	if len(push.Examples) != 1 || !strings.HasPrefix(push.Examples[0], "ggc push force") {
+		t.Errorf("push force examples = %v, want only the parent's push force example", push.Examples)
+	}
+}
+
+func TestArrangeInteractiveCommands(t *testing.T) {
+	list := []interactive.CommandInfo{
+		{Command: "add <file>"}, {Command: "status"}, {Command: "push current"}, {Command: "pull current"}, {Command: "debug-keys"},
+	}
+	cfg := &config.Config{}
+	cfg.Interactive.Pinned = []string{"push current", "missing", "add"}
+	cfg.Interactive.Hidden = []string{"debug-keys", "add <file>"}
+	cfg.Interactive.Sections = []config.PaletteSection{{Name: "Sync", Commands: []string{"pull current", "push current"}}}
+
+	var got []string
+	for _, c := range arrangeInteractiveCommands(list, cfg) {
+		got = append(got, c.Section+":"+c.Command)
+	}
+	want := []string{"Pinned:push current", "Sync:pull current", ":status"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("arrangeInteractiveCommands() = %v, want %v", got, want)
+	}
+
+	if got := arrangeInteractiveCommands(list, nil); len(got) != len(list) {
+		t.Errorf("nil config should keep the list, got %v", got)
+	}
+}
@@ -1,17 +1,43 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"slices"
+	"strings"
+	"sync"
 
+	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
+const (
+	// fetchParallel caps how many remotes ggc fetch --all fetches at once.
+	fetchParallel = 4
+	// fetchSummaryRefs caps how many refs of each kind the summary lists.
+	fetchSummaryRefs = 10
+)
+
+// remoteFetcher fetches each configured remote on its own, for ggc fetch
+// --all, and takes the fetch options the default fetch lacks.
+type remoteFetcher interface {
+	git.RemoteNameLister
+	git.RemoteFetcher
+}
+
 // Fetcher handles git fetch operations.
 type Fetcher struct {
 	gitClient    git.FetchOps
 	outputWriter io.Writer
 	helper       *Helper
+	remotes      remoteFetcher    // nil disables --all and --prune-tags
+	refs         git.RefTipReader // nil skips the summary
+	notes        git.NotesFetcher // nil leaves notes alone
+	notesConfig  *config.Manager
+	ssh          *sshPreflight // nil when ssh.preflight is off
 }
 
 // NewFetcher creates a new Fetcher instance.
@@ -23,6 +49,34 @@ func NewFetcher(client git.FetchOps) *Fetcher {
 	}
 }
 
+// withRemotes enables --all and --prune-tags.
+func (f *Fetcher) withRemotes(remotes remoteFetcher) *Fetcher {
+	f.remotes = remotes
+	return f
+}
+
+// withSummary prints the branches and tags a fetch created, moved or
+// deleted once it is done.
+func (f *Fetcher) withSummary(refs git.RefTipReader) *Fetcher {
+	f.refs = refs
+	return f
+}
+
+// withNotes also fetches and merges the default remote's notes when
+// notes.auto-fetch is set.
+func (f *Fetcher) withNotes(notes git.NotesFetcher, cm *config.Manager) *Fetcher {
+	f.notes = notes
+	f.notesConfig = cm
+	return f
+}
+
+// withSSHPreflight checks the SSH keys for the remotes before fetching
+// when ssh.preflight is set. cm may be nil.
+func (f *Fetcher) withSSHPreflight(urls sshRemotes, cm *config.Manager) *Fetcher {
+	f.ssh = newSSHPreflight(urls, cm)
+	return f
+}
+
 // Fetch executes git fetch with the given arguments.
 func (f *Fetcher) Fetch(args []string) {
 	if len(args) == 0 {
@@ -30,13 +84,221 @@ func (f *Fetcher) Fetch(args []string) {
 		return
 	}
 
-	switch args[0] {
-	case "prune":
-		if err := f.gitClient.Fetch(true); err != nil {
-			WriteError(f.outputWriter, err)
+	var opts git.FetchOptions
+	all := false
+	for _, arg := range args {
+		switch arg {
+		case "prune", "--prune":
+			opts.Prune = true
+		case "--prune-tags":
+			opts.PruneTags = true
+		case "--all":
+			all = true
+		default:
+			f.helper.ShowFetchHelp()
+			return
+		}
+	}
+
+	if !f.ssh.ready(f.outputWriter, "fetch", f.sshTargets(all)...) {
+		return
+	}
+
+	before := f.snapshot()
+	var err error
+	switch {
+	case all:
+		err = f.fetchAll(opts)
+	case opts.PruneTags:
+		if f.remotes == nil {
+			err = errors.New("--prune-tags is not supported here")
+			break
 		}
+		err = f.remotes.FetchWith(opts)
 	default:
-		f.helper.ShowFetchHelp()
+		err = f.gitClient.Fetch(opts.Prune)
+	}
+	if before != nil {
+		f.summarize(before)
+	}
+	if err != nil {
+		WriteError(f.outputWriter, err)
+		return
+	}
+	f.fetchNotes()
+}
+
+// sshTargets returns the remotes a fetch connects to: every remote with
+// --all, else the default one.
+func (f *Fetcher) sshTargets(all bool) []sshTarget {
+	if !all || f.remotes == nil {
+		return []sshTarget{{}}
+	}
+	names, _ := f.remotes.RemoteNames()
+	targets := make([]sshTarget, len(names))
+	for i, name := range names {
+		targets[i] = sshTarget{remote: name}
+	}
+	return targets
+}
+
+// fetchNotes merges the default remote's notes under notes.ref when
+// notes.auto-fetch is set, and says so only when there were any.
+func (f *Fetcher) fetchNotes() {
+	if f.notes == nil || f.notesConfig == nil || !f.notesConfig.GetConfig().Notes.AutoFetch {
 		return
 	}
+	remote, ref := notesRemote(f.notesConfig), notesRef(f.notesConfig)
+	found, err := f.notes.FetchNotes(remote, ref)
+	if err != nil {
+		WriteError(f.outputWriter, err)
+		return
+	}
+	if found {
+		WriteLinef(f.outputWriter, "Merged the notes under %s from %s.", ref, remote)
+	}
+}
+
+// fetchAll fetches every remote, several at once, with each line of
+// their output prefixed with the remote's name.
+func (f *Fetcher) fetchAll(opts git.FetchOptions) error {
+	if f.remotes == nil {
+		return errors.New("--all is not supported here")
+	}
+	names, err := f.remotes.RemoteNames()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return errors.New("no remotes are configured; add one with 'ggc remote add <name> <url>'")
+	}
+	width := 0
+	for _, name := range names {
+		width = max(width, len(name))
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, fetchParallel)
+	errs := make([]error, len(names))
+	for i, name := range names {
+		wg.Go(func() {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			out := ui.NewPrefixWriter(f.outputWriter, fmt.Sprintf("[%-*s] ", width, name), &mu)
+			// Progress is condensed to a line per finished phase, which is
+			// all that stays readable with several remotes interleaved.
+			progress := ui.NewProgressRenderer(out)
+			if err := f.remotes.FetchRemote(name, opts, progress); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", name, err)
+			}
+			_ = progress.Close()
+			out.Flush()
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// snapshot returns the tips of the remote-tracking branches and tags, nil
+// when they cannot be read, which skips the summary.
+func (f *Fetcher) snapshot() map[string]string {
+	if f.refs == nil {
+		return nil
+	}
+	tips, err := f.refs.RefTips("refs/remotes", "refs/tags")
+	if err != nil {
+		return nil
+	}
+	return tips
+}
+
+// refChange is a branch or tag that a fetch created, moved or deleted.
+// old is empty for a new ref and new for a deleted one.
+type refChange struct {
+	name, old, new string
+}
+
+// diffRefs compares the refs before and after a fetch, sorted by name.
+// The remotes' HEAD pointers are left out.
+func diffRefs(before, after map[string]string) (branches, tags []refChange) {
+	names := make([]string, 0, len(before)+len(after))
+	for ref := range before {
+		names = append(names, ref)
+	}
+	for ref := range after {
+		if _, ok := before[ref]; !ok {
+			names = append(names, ref)
+		}
+	}
+	slices.Sort(names)
+	for _, ref := range names {
+		if before[ref] == after[ref] || strings.HasSuffix(ref, "/HEAD") {
+			continue
+		}
+		if tag, ok := strings.CutPrefix(ref, "refs/tags/"); ok {
+			tags = append(tags, refChange{tag, before[ref], after[ref]})
+		} else if branch, ok := strings.CutPrefix(ref, "refs/remotes/"); ok {
+			branches = append(branches, refChange{branch, before[ref], after[ref]})
+		}
+	}
+	return branches, tags
+}
+
+// summarize prints what the fetch changed compared to before.
+func (f *Fetcher) summarize(before map[string]string) {
+	after := f.snapshot()
+	if after == nil {
+		return
+	}
+	branches, tags := diffRefs(before, after)
+	if len(branches) == 0 && len(tags) == 0 {
+		WriteLine(f.outputWriter, "No branches or tags changed.")
+		return
+	}
+	f.listChanges("Branches", branches)
+	f.listChanges("Tags", tags)
+}
+
+// listChanges prints the count of new, updated and deleted refs of one
+// kind, then the refs themselves: + new, ~ updated, - deleted.
+func (f *Fetcher) listChanges(kind string, changes []refChange) {
+	if len(changes) == 0 {
+		return
+	}
+	var added, updated, deleted int
+	for _, c := range changes {
+		switch {
+		case c.old == "":
+			added++
+		case c.new == "":
+			deleted++
+		default:
+			updated++
+		}
+	}
+	var counts []string
+	for _, n := range []struct {
+		count int
+		label string
+	}{{added, "new"}, {updated, "updated"}, {deleted, "deleted"}} {
+		if n.count > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n.count, n.label))
+		}
+	}
+	WriteLinef(f.outputWriter, "%s: %s", kind, strings.Join(counts, ", "))
+	for i, c := range changes {
+		if i == fetchSummaryRefs {
+			WriteLinef(f.outputWriter, "  … and %d more", len(changes)-i)
+			return
+		}
+		switch {
+		case c.old == "":
+			WriteLinef(f.outputWriter, "  + %s", c.name)
+		case c.new == "":
+			WriteLinef(f.outputWriter, "  - %s", c.name)
+		default:
+			WriteLinef(f.outputWriter, "  ~ %s %s..%s", c.name, shortSHA(c.old), shortSHA(c.new))
+		}
+	}
 }
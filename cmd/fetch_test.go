@@ -2,8 +2,15 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
 )
 
 func TestFetcher_Fetch(t *testing.T) {
@@ -77,3 +84,135 @@ func TestFetcher_Fetch_Error(t *testing.T) {
 		t.Errorf("Expected help message, got: %s", output)
 	}
 }
+
+type stubRemoteFetcher struct {
+	mu      sync.Mutex
+	names   []string
+	fetched []string
+	opts    git.FetchOptions
+	fail    map[string]bool
+}
+
+func (s *stubRemoteFetcher) RemoteNames() ([]string, error) { return s.names, nil }
+
+func (s *stubRemoteFetcher) FetchWith(opts git.FetchOptions) error {
+	s.opts = opts
+	return nil
+}
+
+func (s *stubRemoteFetcher) FetchRemote(remote string, opts git.FetchOptions, out io.Writer) error {
+	s.mu.Lock()
+	s.fetched = append(s.fetched, remote)
+	s.opts = opts
+	s.mu.Unlock()
+	_, _ = fmt.Fprintf(out, "From https://example.com/%s\nReceiving objects:  50%% (1/2)\rReceiving objects: 100%% (2/2), done.\n", remote)
+	if s.fail[remote] {
+		return errors.New("could not read from remote repository")
+	}
+	return nil
+}
+
+// stubRefTips returns each snapshot in turn.
+type stubRefTips struct {
+	snapshots []map[string]string
+}
+
+func (s *stubRefTips) RefTips(_ ...string) (map[string]string, error) {
+	tips := s.snapshots[0]
+	s.snapshots = s.snapshots[1:]
+	return tips, nil
+}
+
+func TestFetcher_Fetch_All(t *testing.T) {
+	var buf bytes.Buffer
+	remotes := &stubRemoteFetcher{names: []string{"origin", "upstream"}, fail: map[string]bool{"upstream": true}}
+	f := NewFetcher(&mockAddGitClient{}).withRemotes(remotes)
+	f.outputWriter = &buf
+
+	f.Fetch([]string{"--all", "prune"})
+
+	slices.Sort(remotes.fetched)
+	if !slices.Equal(remotes.fetched, []string{"origin", "upstream"}) || !remotes.opts.Prune {
+		t.Errorf("fetched %v with %+v", remotes.fetched, remotes.opts)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"[origin  ] From https://example.com/origin\n",
+		"[upstream] Receiving objects: 100% (2/2)\n",
+		"Error: upstream: could not read from remote repository",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "50%") || strings.Contains(out, "origin: ") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestFetcher_Fetch_PruneTags(t *testing.T) {
+	remotes := &stubRemoteFetcher{}
+	f := NewFetcher(&mockAddGitClient{}).withRemotes(remotes)
+	f.outputWriter = &bytes.Buffer{}
+
+	f.Fetch([]string{"--prune-tags"})
+	if !remotes.opts.PruneTags || len(remotes.fetched) != 0 {
+		t.Errorf("FetchWith opts = %+v, fetched %v", remotes.opts, remotes.fetched)
+	}
+}
+
+func TestFetcher_Fetch_Summary(t *testing.T) {
+	var buf bytes.Buffer
+	refs := &stubRefTips{snapshots: []map[string]string{
+		{
+			"refs/remotes/origin/HEAD": "1111111aaaa",
+			"refs/remotes/origin/main": "1111111aaaa",
+			"refs/remotes/origin/old":  "2222222bbbb",
+			"refs/tags/v1.0.0":         "3333333cccc",
+		},
+		{
+			"refs/remotes/origin/HEAD":    "4444444dddd",
+			"refs/remotes/origin/main":    "4444444dddd",
+			"refs/remotes/origin/feature": "5555555eeee",
+			"refs/tags/v1.0.0":            "3333333cccc",
+			"refs/tags/v1.1.0":            "6666666ffff",
+		},
+	}}
+	f := NewFetcher(&mockAddGitClient{}).withSummary(refs)
+	f.outputWriter = &buf
+
+	f.Fetch([]string{"prune"})
+
+	want := "Branches: 1 new, 1 updated, 1 deleted\n" +
+		"  + origin/feature\n" +
+		"  ~ origin/main 1111111..4444444\n" +
+		"  - origin/old\n" +
+		"Tags: 1 new\n" +
+		"  + v1.1.0\n"
+	if buf.String() != want {
+		t.Errorf("summary = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	same := map[string]string{"refs/tags/v1.0.0": "3333333cccc"}
+	f.refs = &stubRefTips{snapshots: []map[string]string{same, same}}
+	f.Fetch([]string{"prune"})
+	if buf.String() != "No branches or tags changed.\n" {
+		t.Errorf("unchanged summary = %q", buf.String())
+	}
+}
+
+func TestFetcher_Fetch_SummaryCap(t *testing.T) {
+	after := make(map[string]string)
+	for i := range fetchSummaryRefs + 3 {
+		after[fmt.Sprintf("refs/tags/v0.%02d", i)] = "abc"
+	}
+	var buf bytes.Buffer
+	f := NewFetcher(&mockAddGitClient{}).withSummary(&stubRefTips{snapshots: []map[string]string{{}, after}})
+	f.outputWriter = &buf
+
+	f.Fetch([]string{"prune"})
+	if !strings.Contains(buf.String(), "Tags: 13 new\n") || !strings.Contains(buf.String(), "  … and 3 more\n") {
+		t.Errorf("summary = %q", buf.String())
+	}
+}
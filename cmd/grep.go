@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+)
+
+// grepViewer searches with git grep arguments and returns the hit chosen
+// to open.
+type grepViewer func(args []string) (git.GrepHit, bool, error)
+
+// grepViewerFlags are the git grep options the grep viewer accepts; any
+// other option sends the search to git grep as-is.
+var grepViewerFlags = map[string]bool{
+	"-i": true, "--ignore-case": true,
+	"-w": true, "--word-regexp": true,
+	"-E": true, "--extended-regexp": true,
+	"-F": true, "--fixed-strings": true,
+	"-P": true, "--perl-regexp": true,
+}
+
+// Grepper handles ggc grep.
+type Grepper struct {
+	gitClient     git.PassthroughOps
+	outputWriter  io.Writer
+	helper        *Helper
+	configManager *config.Manager
+	view          grepViewer // nil unless stdin and stdout are terminals
+	execCommand   func(name string, arg ...string) *exec.Cmd
+}
+
+// NewGrepper creates a new Grepper instance.
+func NewGrepper(client git.PassthroughOps) *Grepper {
+	return &Grepper{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+		execCommand:  exec.Command,
+	}
+}
+
+// withViewer lists the hits of `ggc grep <pattern>` in the grep viewer
+// when stdin and stdout are terminals. cm supplies the keybinding profile
+// and default.editor and may be nil.
+func (g *Grepper) withViewer(src interactive.GrepSource, cm *config.Manager) *Grepper {
+	g.configManager = cm
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return g
+	}
+	g.view = func(args []string) (git.GrepHit, bool, error) {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewGrepViewer(src, cfg).Run(args)
+	}
+	return g
+}
+
+// Grep searches tracked files. On a terminal the hits are listed in the
+// grep viewer and the chosen one is opened in the editor; with options
+// the viewer does not take, or without a terminal, git grep prints them.
+func (g *Grepper) Grep(args []string) {
+	if len(args) == 0 || args[0] == "help" {
+		g.helper.ShowPassthroughHelp("grep")
+		return
+	}
+	if g.view == nil || !viewableGrepArgs(args) {
+		if err := g.gitClient.RunGit("grep", args); err != nil {
+			WriteError(g.outputWriter, err)
+		}
+		return
+	}
+
+	hit, ok, err := g.view(args)
+	if err != nil {
+		WriteError(g.outputWriter, err)
+		return
+	}
+	if !ok {
+		return
+	}
+	name, editorArgs := editorAt(g.editor(), hit.Path, hit.Line, hit.Column)
+	cmd := g.execCommand(name, editorArgs...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		WriteErrorf(g.outputWriter, "opening editor: %w", err)
+	}
+}
+
+// viewableGrepArgs reports whether args are a pattern, pathspecs and only
+// options the grep viewer accepts.
+func viewableGrepArgs(args []string) bool {
+	pattern := false
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return pattern || i+1 < len(args)
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			if !grepViewerFlags[arg] {
+				return false
+			}
+		default:
+			pattern = true
+		}
+	}
+	return pattern
+}
+
+// editor is $VISUAL, $EDITOR or default.editor, in that order, and vi
+// without any of them.
+func (g *Grepper) editor() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if e := strings.TrimSpace(os.Getenv(env)); e != "" {
+			return e
+		}
+	}
+	if g.configManager != nil {
+		if e := strings.TrimSpace(g.configManager.GetConfig().Default.Editor); e != "" {
+			return e
+		}
+	}
+	return "vi"
+}
+
+// editorAt returns the command that opens path at line and column in
+// editor, which may carry arguments of its own. Editors that take
+// path:line:col are told that way; the rest get vi's +line.
+func editorAt(editor, path string, line, column int) (string, []string) {
+	fields := strings.Fields(editor)
+	args := fields[1:]
+	switch strings.TrimSuffix(filepath.Base(fields[0]), ".exe") {
+	case "code", "code-insiders", "codium", "cursor":
+		args = append(args, "--goto", fmt.Sprintf("%s:%d:%d", path, line, column))
+	case "subl", "zed", "hx", "helix":
+		args = append(args, fmt.Sprintf("%s:%d:%d", path, line, column))
+	default:
+		args = append(args, fmt.Sprintf("+%d", line), path)
+	}
+	return fields[0], args
+}
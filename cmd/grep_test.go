@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+func TestGrepper_Grep(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantView []string
+		wantGit  []string
+	}{
+		{args: []string{"-i", "-w", "TODO"}, wantView: []string{"-i", "-w", "TODO"}},
+		{args: []string{"-E", "fix(me)?", "--", "cmd"}, wantView: []string{"-E", "fix(me)?", "--", "cmd"}},
+		{args: []string{"-n", "TODO"}, wantGit: []string{"-n", "TODO"}},
+		{args: []string{"-e", "foo", "-e", "bar"}, wantGit: []string{"-e", "foo", "-e", "bar"}},
+	}
+	for _, tt := range tests {
+		mockClient := &mockBisectClient{}
+		var viewed []string
+		g := NewGrepper(mockClient)
+		g.outputWriter = &bytes.Buffer{}
+		g.view = func(args []string) (git.GrepHit, bool, error) {
+			viewed = args
+			return git.GrepHit{}, false, nil
+		}
+
+		g.Grep(tt.args)
+
+		if !slices.Equal(viewed, tt.wantView) {
+			t.Errorf("%v: viewed %v, want %v", tt.args, viewed, tt.wantView)
+		}
+		if mockClient.called != (tt.wantGit != nil) || !slices.Equal(mockClient.gotArgs, tt.wantGit) {
+			t.Errorf("%v: RunGit called=%v args=%v, want %v", tt.args, mockClient.called, mockClient.gotArgs, tt.wantGit)
+		}
+	}
+}
+
+func TestGrepper_Grep_OpensEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --reuse-window")
+	var ran []string
+	g := NewGrepper(&mockBisectClient{})
+	g.outputWriter = &bytes.Buffer{}
+	g.view = func([]string) (git.GrepHit, bool, error) {
+		return git.GrepHit{Path: "cmd/a.go", Line: 12, Column: 3}, true, nil
+	}
+	g.execCommand = func(name string, args ...string) *exec.Cmd {
+		ran = append([]string{name}, args...)
+		return exec.Command("true")
+	}
+
+	g.Grep([]string{"main"})
+
+	if want := []string{"code", "--reuse-window", "--goto", "cmd/a.go:12:3"}; !slices.Equal(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+}
+
+func TestGrepper_Editor(t *testing.T) {
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().Default.Editor = "nano"
+	g := NewGrepper(&mockBisectClient{}).withViewer(nil, cm)
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := g.editor(); got != "nano" {
+		t.Errorf("editor() = %q, want default.editor", got)
+	}
+	t.Setenv("EDITOR", "vim")
+	if got := g.editor(); got != "vim" {
+		t.Errorf("editor() = %q, want $EDITOR", got)
+	}
+	t.Setenv("VISUAL", "emacs")
+	if got := g.editor(); got != "emacs" {
+		t.Errorf("editor() = %q, want $VISUAL", got)
+	}
+}
+
+func TestEditorAt(t *testing.T) {
+	tests := map[string][]string{
+		"vim":              {"vim", "+7", "a.go"},
+		"/usr/bin/nvim -p": {"/usr/bin/nvim", "-p", "+7", "a.go"},
+		"subl -w":          {"subl", "-w", "a.go:7:2"},
+		"code":             {"code", "--goto", "a.go:7:2"},
+	}
+	for editor, want := range tests {
+		name, args := editorAt(editor, "a.go", 7, 2)
+		if got := append([]string{name}, args...); !slices.Equal(got, want) {
+			t.Errorf("editorAt(%q) = %v, want %v", editor, got, want)
+		}
+	}
+}
+
+func TestGrepper_Grep_Help(t *testing.T) {
+	var buf bytes.Buffer
+	mockClient := &mockBisectClient{}
+	g := NewGrepper(mockClient)
+	g.helper.outputWriter = &buf
+	g.Grep(nil)
+	if mockClient.called || !strings.Contains(buf.String(), "ggc grep") {
+		t.Errorf("called=%v output=%q", mockClient.called, buf.String())
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/safety"
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// branchGuard stops force pushes, hard resets, deletes and rebases on the
+// branches listed in safety.protected-branches. When confirm can ask a
+// person it asks first; otherwise, as with --yes, without a terminal or
+// with confirm-destructive: never, the command needs --force-unsafe. A nil
+// guard allows everything.
+type branchGuard struct {
+	protection safety.Protection
+	branches   interface {
+		GetCurrentBranch() (string, error)
+	}
+	confirm *ui.Confirmer // nil never asks
+}
+
+// newBranchGuard returns the guard for the configured protected branches,
+// asking through confirm. cm may be nil, which protects nothing.
+func newBranchGuard(cm *config.Manager, branches interface {
+	GetCurrentBranch() (string, error)
+}, confirm *ui.Confirmer) *branchGuard {
+	g := &branchGuard{branches: branches, confirm: confirm}
+	if cm != nil {
+		g.protection = safety.NewProtection(cm.GetConfig().Safety.ProtectedBranches)
+	}
+	return g
+}
+
+// check returns an error when action, such as "force-push", would change
+// a protected branch and the user neither passed --force-unsafe nor
+// confirmed it.
+func (g *branchGuard) check(action, branch string, force bool) error {
+	if g == nil || force {
+		return nil
+	}
+	pattern, protected := g.protection.Match(branch)
+	if !protected {
+		return nil
+	}
+	if !g.confirm.Asks() {
+		return fmt.Errorf("refusing to %s protected branch '%s' (safety.protected-branches: %s); pass %s to do it anyway",
+			action, branch, pattern, safety.ForceFlag)
+	}
+	ok, err := g.confirm.Confirm(fmt.Sprintf("'%s' is a protected branch. %s it anyway?", branch, capitalize(action)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("canceled; '%s' was left unchanged", branch)
+	}
+	return nil
+}
+
+// checkCurrent is check for the checked-out branch.
+func (g *branchGuard) checkCurrent(action string, force bool) error {
+	if g == nil || force || g.branches == nil {
+		return nil
+	}
+	branch, err := g.branches.GetCurrentBranch()
+	if err != nil {
+		// A detached HEAD is not a protected branch.
+		return nil
+	}
+	return g.check(action, branch, force)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
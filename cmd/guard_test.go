@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/safety"
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+func TestBranchGuard_Check(t *testing.T) {
+	g := &branchGuard{protection: safety.NewProtection([]string{"main", "release/*"})}
+
+	if err := g.check("delete", "feature/x", false); err != nil {
+		t.Errorf("unprotected branch: %v", err)
+	}
+	err := g.check("delete", "release/1.0", false)
+	if err == nil || !strings.Contains(err.Error(), "--force-unsafe") {
+		t.Errorf("protected branch without a terminal: %v", err)
+	}
+	if err := g.check("delete", "main", true); err != nil {
+		t.Errorf("--force-unsafe should allow a protected branch: %v", err)
+	}
+
+	var asked bytes.Buffer
+	g.confirm = ui.NewConfirmer(prompt.New(strings.NewReader("n\n"), &asked), true, ui.ConfirmSimple)
+	if err := g.check("force-push", "main", false); err == nil {
+		t.Error("declining the confirmation should stop the command")
+	}
+	if !strings.Contains(asked.String(), "Force-push it anyway?") {
+		t.Errorf("unexpected question %q", asked.String())
+	}
+	g.confirm = ui.NewConfirmer(prompt.New(strings.NewReader("y\n"), &asked), true, ui.ConfirmSimple)
+	if err := g.check("force-push", "main", false); err != nil {
+		t.Errorf("confirmed: %v", err)
+	}
+
+	// --yes and confirm-destructive: never skip prompts, so they do not
+	// answer this one either.
+	g.confirm = ui.NewConfirmer(prompt.New(strings.NewReader("y\n"), &asked), true, ui.ConfirmNever)
+	if err := g.check("force-push", "main", false); err == nil || !strings.Contains(err.Error(), "--force-unsafe") {
+		t.Errorf("confirm-destructive: never: %v", err)
+	}
+	ui.SetAssumeYes(true)
+	t.Cleanup(func() { ui.SetAssumeYes(false) })
+	g.confirm = ui.NewConfirmer(prompt.New(strings.NewReader("y\n"), &asked), true, ui.ConfirmSimple)
+	if err := g.check("force-push", "main", false); err == nil || !strings.Contains(err.Error(), "--force-unsafe") {
+		t.Errorf("--yes: %v", err)
+	}
+
+	var nilGuard *branchGuard
+	if err := nilGuard.check("delete", "main", false); err != nil {
+		t.Errorf("a nil guard should allow everything: %v", err)
+	}
+}
+
+func TestResetter_ProtectedBranch(t *testing.T) {
+	var buf bytes.Buffer
+	mock := &mockResetOps{currentBranch: "main"}
+	g := &branchGuard{protection: safety.NewProtection([]string{"main"}), branches: mock}
+	r := (&Resetter{gitClient: mock, outputWriter: &buf, helper: NewHelper()}).withGuard(g)
+
+	r.Reset([]string{"hard", "HEAD~1"})
+	if mock.resetHardCalled || !strings.Contains(buf.String(), "refusing to hard-reset protected branch 'main'") {
+		t.Errorf("hard reset of a protected branch should be refused, output: %q", buf.String())
+	}
+
+	r.Reset([]string{"hard", "HEAD~1", safety.ForceFlag})
+	if !mock.resetHardCalled || mock.commit != "HEAD~1" {
+		t.Error("--force-unsafe should allow the reset")
+	}
+}
+
+func TestBrancher_DeleteProtectedBranch(t *testing.T) {
+	var buf bytes.Buffer
+	mock := &mockBranchGitClient{}
+	g := &branchGuard{protection: safety.NewProtection([]string{"release/*"})}
+	b := &Brancher{gitClient: mock, outputWriter: &buf, helper: NewHelper(), guard: g}
+
+	b.branchDeleteArgs([]string{"release/1.0"})
+	if len(mock.deletedBranches) != 0 || !strings.Contains(buf.String(), "refusing to delete protected branch 'release/1.0'") {
+		t.Errorf("deleting a protected branch should be refused, output: %q", buf.String())
+	}
+
+	if got := b.skipProtected([]string{"feature", "release/2.0"}, false); len(got) != 1 || got[0] != "feature" {
+		t.Errorf("skipProtected() = %v", got)
+	}
+	if got := b.skipProtected([]string{"feature", "release/2.0"}, true); len(got) != 2 {
+		t.Errorf("skipProtected() with --force-unsafe = %v", got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	commandregistry "github.com/bmf-san/ggc/v8/cmd/command"
 	"github.com/bmf-san/ggc/v8/internal/templates"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 // Helper provides help message functionality.
@@ -44,6 +45,9 @@ func (h *Helper) ShowHelp() {
 
 // ShowCommandHelp shows help message for a command.
 func (h *Helper) ShowCommandHelp(data templates.HelpData) {
+	if data.Colors == nil {
+		data.Colors = ui.ColorsFor(h.outputWriter)
+	}
 	helpMsg, err := templates.RenderCommandHelp(data)
 	if err != nil {
 		WriteError(h.outputWriter, err)
@@ -90,8 +94,10 @@ func buildHelpData(info *commandregistry.Info, usageOverride []string, descripti
 	usage := strings.Join(uniqueStrings(usageList), " | ")
 
 	description := descriptionOverride
+	var details []string
 	if description == "" {
 		description = info.Summary
+		details = paragraphs(info.Description)
 	}
 
 	examples := buildExamples(info, filter)
@@ -99,11 +105,30 @@ func buildHelpData(info *commandregistry.Info, usageOverride []string, descripti
 		examples = uniqueStrings(usageList)
 	}
 
-	return templates.HelpData{
+	data := templates.HelpData{
 		Usage:       usage,
 		Description: description,
+		Details:     details,
 		Examples:    examples,
 	}
+	// Aliases and the git command only describe the whole command, not the
+	// filtered or overridden views of it.
+	if filter == nil && descriptionOverride == "" {
+		data.Aliases = info.Aliases
+		data.Git = info.Git
+	}
+	return data
+}
+
+// paragraphs splits long-form help at blank lines.
+func paragraphs(text string) []string {
+	var result []string
+	for _, p := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
 }
 
 func collectSubcommandUsages(info *commandregistry.Info, filter func(commandregistry.SubcommandInfo) bool) []string {
@@ -138,7 +163,7 @@ func buildExamples(info *commandregistry.Info, filter func(commandregistry.Subco
 			continue
 		}
 		usage := firstNonEmpty(sub.Usage, fmt.Sprintf("ggc %s", sub.Name))
-		if usage == "" {
+		if usage == "" || hasExampleFor(examples, usage) {
 			continue
 		}
 		examples = append(examples, formatExample(usage, sub.Summary))
@@ -146,6 +171,18 @@ func buildExamples(info *commandregistry.Info, filter func(commandregistry.Subco
 	return uniqueStrings(examples)
 }
 
+// hasExampleFor reports whether examples already show the command usage,
+// with or without a comment.
+func hasExampleFor(examples []string, usage string) bool {
+	for _, e := range examples {
+		cmd, _, _ := strings.Cut(e, "  # ")
+		if strings.TrimSpace(cmd) == usage {
+			return true
+		}
+	}
+	return false
+}
+
 func firstNonEmpty(values []string, fallback string) string {
 	for _, v := range values {
 		trimmed := strings.TrimSpace(v)
@@ -236,6 +273,11 @@ func (h *Helper) ShowConfigHelp() {
 	h.renderCommandFromRegistry("config", []string{"ggc config [command]"}, "Get, set, and list configuration values for ggc")
 }
 
+// ShowUndoHelp shows help message for undo command.
+func (h *Helper) ShowUndoHelp() {
+	h.renderCommandFromRegistry("undo", []string{"ggc undo [list]"}, "Reverse the last destructive ggc operation")
+}
+
 // ShowRestoreHelp shows help message for restore command.
 func (h *Helper) ShowRestoreHelp() {
 	h.renderCommandFromRegistry("restore", []string{"ggc restore [command]"}, "Restore working tree files")
@@ -251,6 +293,26 @@ func (h *Helper) ShowTagHelp() {
 	h.renderCommandFromRegistry("tag", []string{"ggc tag [command] [options]"}, "Create, list, delete and verify tags")
 }
 
+// ShowStatsHelp shows help message for stats command.
+func (h *Helper) ShowStatsHelp() {
+	h.renderCommandFromRegistry("stats", nil, "")
+}
+
+// ShowReleaseHelp shows help message for release command.
+func (h *Helper) ShowReleaseHelp() {
+	h.renderCommandFromRegistry("release", []string{"ggc release [--major|--minor|--patch] [--dry-run] [--no-push] [--publish|--no-publish]"}, "Bump the version, tag it, push it and publish the release")
+}
+
+// ShowChangelogHelp shows help message for changelog command.
+func (h *Helper) ShowChangelogHelp() {
+	h.renderCommandFromRegistry("changelog", []string{"ggc changelog [--from <tag>] [--to <ref>] [--format markdown|json] [--write[=<file>]]"}, "Generate a changelog from Conventional Commits")
+}
+
+// ShowPRHelp shows help message for pr command.
+func (h *Helper) ShowPRHelp() {
+	h.renderCommandFromRegistry("pr", []string{"ggc pr [command] [options]"}, "Create, list and check out pull requests on GitHub, GitLab or Gitea")
+}
+
 // ShowVersionHelp shows help message for Version command.
 func (h *Helper) ShowVersionHelp() {
 	h.renderCommandFromRegistry("version", nil, "Show current ggc version")
@@ -314,11 +376,106 @@ func (h *Helper) ShowFetchHelp() {
 	h.renderCommandFromRegistry("fetch", []string{"ggc fetch [subcommand]"}, "Download objects and refs from another repository")
 }
 
+// ShowVerifyHelp shows help message for verify command.
+func (h *Helper) ShowVerifyHelp() {
+	h.renderCommandFromRegistry("verify", []string{"ggc verify <rev-range>"}, "Report signature status for commits and tags")
+}
+
+// ShowCloneHelp shows help message for clone command.
+func (h *Helper) ShowCloneHelp() {
+	h.renderCommandFromRegistry("clone", []string{"ggc clone <repository> [<directory>] [options]"}, "Clone a repository, expanding owner/repo shorthands")
+}
+
+// ShowWorkflowHelp shows help message for workflow command.
+func (h *Helper) ShowWorkflowHelp() {
+	h.renderCommandFromRegistry("workflow", []string{"ggc workflow [command] [options]"}, "List saved workflows, add new ones from templates and re-run failed steps")
+}
+
+// ShowProfileHelp shows help message for profile command.
+func (h *Helper) ShowProfileHelp() {
+	h.renderCommandFromRegistry("profile", []string{"ggc profile [command] [options]"}, "Manage named identities and apply them to repositories")
+}
+
 // ShowShowHelp shows help message for show command.
 func (h *Helper) ShowShowHelp() {
 	h.renderCommandFromRegistry("show", []string{"ggc show [<options>] [<object>...]"}, "Show various types of objects (commits, tags, trees, blobs)")
 }
 
+// ShowLFSHelp shows help message for lfs command.
+func (h *Helper) ShowLFSHelp() {
+	h.renderCommandFromRegistry("lfs", []string{"ggc lfs <status|track|untrack|pull> [<args>]"}, "Manage Git LFS files")
+}
+
+// ShowMaintenanceHelp shows help message for maintenance command.
+func (h *Helper) ShowMaintenanceHelp() {
+	h.renderCommandFromRegistry("maintenance", []string{"ggc maintenance <run|analyze|start|stop> [<options>]"}, "Optimize the repository and report on its size")
+}
+
+// ShowAnalyzeHelp shows help message for analyze command.
+func (h *Helper) ShowAnalyzeHelp() {
+	h.renderCommandFromRegistry("analyze", []string{"ggc analyze <large-files|scrub> [<options>]"}, "Find the largest files in history and plan their removal")
+}
+
+// ShowSnapshotHelp shows help message for snapshot command.
+func (h *Helper) ShowSnapshotHelp() {
+	h.renderCommandFromRegistry("snapshot", []string{"ggc snapshot <create|list|restore|drop|prune> [<args>]"}, "Save and restore the working tree without touching the index or HEAD")
+}
+
+// ShowNotesHelp shows help message for notes command.
+func (h *Helper) ShowNotesHelp() {
+	h.renderCommandFromRegistry("notes", []string{"ggc notes <add|show|list|push|fetch> [<args>]"}, "Annotate commits, for instance with review remarks, and share the notes")
+}
+
+// ShowReposHelp shows help message for repos command.
+func (h *Helper) ShowReposHelp() {
+	h.renderCommandFromRegistry("repos", []string{"ggc repos <list|add|remove|exec|sync|dashboard> [<args>]"}, "Run ggc commands across many repositories at once")
+}
+
+// ShowFormatPatchHelp shows help message for format-patch command.
+func (h *Helper) ShowFormatPatchHelp() {
+	h.renderCommandFromRegistry("format-patch", []string{"ggc format-patch [<options>] <commit-range>", "ggc format-patch select [<base>] [<options>]"}, "Write commits as patch files for e-mail review")
+}
+
+// ShowAMHelp shows help message for am command.
+func (h *Helper) ShowAMHelp() {
+	h.renderCommandFromRegistry("am", []string{"ggc am [<options>] <mailbox|patch|dir>...", "ggc am <continue|skip|abort>"}, "Apply a series of patches from a mailbox as commits")
+}
+
+// ShowApplyHelp shows help message for apply command.
+func (h *Helper) ShowApplyHelp() {
+	h.renderCommandFromRegistry("apply", []string{"ggc apply [<options>] <patch|dir>..."}, "Apply patch files to the working tree, falling back to a three-way merge")
+}
+
+// ShowTicketHelp shows help message for ticket command.
+func (h *Helper) ShowTicketHelp() {
+	h.renderCommandFromRegistry("ticket", []string{"ggc ticket [open] [<branch>]"}, "Show or open the issue-tracker ticket named in a branch")
+}
+
+// ShowSparseHelp shows help message for sparse command.
+func (h *Helper) ShowSparseHelp() {
+	h.renderCommandFromRegistry("sparse", []string{"ggc sparse <list|init|add|remove|disable> [<dir>...]"}, "Check out only some directories of a large repository")
+}
+
+// ShowStackHelp shows help message for stack command.
+func (h *Helper) ShowStackHelp() {
+	h.renderCommandFromRegistry("stack", []string{"ggc stack <create|list|restack> [name]"}, "Manage branches stacked on top of each other")
+}
+
+// ShowCherryPickHelp shows help message for cherry-pick command.
+func (h *Helper) ShowCherryPickHelp() {
+	h.renderCommandFromRegistry("cherry-pick", []string{"ggc cherry-pick <commit|range>...", "ggc cherry-pick select [<branch>]", "ggc cherry-pick <continue|abort|skip>"}, "Apply the changes introduced by some existing commits")
+}
+
+// ShowRevertHelp shows help message for revert command.
+func (h *Helper) ShowRevertHelp() {
+	h.renderCommandFromRegistry("revert", []string{"ggc revert [--no-commit] [-m <parent>] <commit|range>...", "ggc revert select [--no-commit]", "ggc revert <continue|abort|skip>"}, "Revert some existing commits")
+}
+
+// ShowSyncHelp shows help message for sync command.
+func (h *Helper) ShowSyncHelp() {
+	h.renderCommandFromRegistry("sync", []string{"ggc sync [--rebase|--merge] [--no-push] [--no-prune] [--no-autostash]"}, "Fetch, take in the upstream and push the current branch")
+}
+
 // ShowPassthroughHelp renders help for a pass-through command by looking up
 // its entry in the registry. Used by the generic passthroughCommand wrapper
 // for commands such as cherry-pick, revert, blame, etc.
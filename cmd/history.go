@@ -59,7 +59,7 @@ func (c *Cmd) handleHistoryLast(rest []string) {
 	}
 	n, err := strconv.Atoi(rest[0])
 	if err != nil || n <= 0 {
-		_, _ = fmt.Fprintln(c.outputWriter, "Error: 'last' requires a positive integer")
+		WriteErrorf(c.outputWriter, "'last' requires a positive integer")
 		return
 	}
 	c.showLast(n)
@@ -75,7 +75,7 @@ func (c *Cmd) handleHistorySearch(rest []string) {
 	pattern := strings.Join(rest, " ")
 	entries, err := history.Search(pattern)
 	if err != nil {
-		_, _ = fmt.Fprintf(c.outputWriter, "Error: %v\n", err)
+		WriteError(c.outputWriter, err)
 		return
 	}
 	c.printEntries(entries)
@@ -83,7 +83,7 @@ func (c *Cmd) handleHistorySearch(rest []string) {
 
 func (c *Cmd) handleHistoryClear() {
 	if err := history.Clear(); err != nil {
-		_, _ = fmt.Fprintf(c.outputWriter, "Error: %v\n", err)
+		WriteError(c.outputWriter, err)
 		return
 	}
 	_, _ = fmt.Fprintln(c.outputWriter, "History cleared.")
@@ -92,7 +92,7 @@ func (c *Cmd) handleHistoryClear() {
 func (c *Cmd) showLast(n int) {
 	entries, err := history.ReadLast(n)
 	if err != nil {
-		_, _ = fmt.Fprintf(c.outputWriter, "Error: %v\n", err)
+		WriteError(c.outputWriter, err)
 		return
 	}
 	c.printEntries(entries)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/hooks"
 )
 
 // Hooker handles git hook operations.
@@ -18,6 +19,8 @@ type Hooker struct {
 	helper       *Helper
 	execCommand  func(string, ...string) *exec.Cmd
 	gitClient    git.ConfigOps
+	stdin        io.Reader
+	exit         func(code int)
 }
 
 // NewHooker creates a new Hooker instance.
@@ -27,6 +30,8 @@ func NewHooker(client git.ConfigOps) *Hooker {
 		helper:       NewHelper(),
 		execCommand:  exec.Command,
 		gitClient:    client,
+		stdin:        os.Stdin,
+		exit:         os.Exit,
 	}
 }
 
@@ -38,7 +43,10 @@ func (h *Hooker) Hook(args []string) {
 	}
 	handlers := map[string]func([]string){
 		"list":      func(_ []string) { h.listHooks() },
-		"install":   h.withName(h.installHook),
+		"install":   h.install,
+		"templates": func(_ []string) { h.listTemplates() },
+		"sync":      h.syncHooks,
+		"run":       h.runHook,
 		"uninstall": h.withName(h.uninstallHook),
 		"enable":    h.withName(h.enableHook),
 		"disable":   h.withName(h.disableHook),
@@ -73,25 +81,20 @@ func (h *Hooker) listHooks() {
 		return
 	}
 
-	// Standard Git hooks
-	standardHooks := []string{
-		"applypatch-msg", "pre-applypatch", "post-applypatch",
-		"pre-commit", "prepare-commit-msg", "commit-msg", "post-commit",
-		"pre-rebase", "post-checkout", "post-merge", "pre-push",
-		"pre-receive", "update", "post-receive", "post-update",
-		"push-to-checkout", "pre-auto-gc", "post-rewrite",
-	}
-
 	_, _ = fmt.Fprintf(h.outputWriter, "Git Hooks Status:\n")
 	_, _ = fmt.Fprintf(h.outputWriter, "------------------\n")
 
-	for _, hook := range standardHooks {
+	for _, hook := range hooks.StandardHooks {
 		hookPath := filepath.Join(hooksDir, hook)
 		samplePath := filepath.Join(hooksDir, hook+".sample")
 
 		if _, err := os.Stat(hookPath); err == nil {
 			// Check if it's executable
 			if info, err := os.Stat(hookPath); err == nil && info.Mode()&0111 != 0 {
+				if script, err := os.ReadFile(hookPath); err == nil && hooks.IsShim(script) {
+					_, _ = fmt.Fprintf(h.outputWriter, "✓ %s (enabled, from %s)\n", hook, hooks.FileName)
+					continue
+				}
 				_, _ = fmt.Fprintf(h.outputWriter, "✓ %s (enabled)\n", hook)
 			} else {
 				_, _ = fmt.Fprintf(h.outputWriter, "✗ %s (disabled)\n", hook)
@@ -119,7 +122,7 @@ func (h *Hooker) installHook(hookName string) {
 	// Try to copy from sample first
 	if _, err := os.Stat(samplePath); err == nil {
 		if err := h.copyFile(samplePath, hookPath); err != nil {
-			_, _ = fmt.Fprintf(h.outputWriter, "Error copying sample hook: %v\n", err)
+			WriteErrorf(h.outputWriter, "copying sample hook: %w", err)
 			return
 		}
 		_, _ = fmt.Fprintf(h.outputWriter, "Hook '%s' installed from sample\n", hookName)
@@ -127,7 +130,7 @@ func (h *Hooker) installHook(hookName string) {
 		// Create basic template
 		template := h.getHookTemplate(hookName)
 		if err := os.WriteFile(hookPath, []byte(template), 0755); err != nil {
-			_, _ = fmt.Fprintf(h.outputWriter, "Error creating hook: %v\n", err)
+			WriteErrorf(h.outputWriter, "creating hook: %w", err)
 			return
 		}
 		_, _ = fmt.Fprintf(h.outputWriter, "Hook '%s' created with basic template\n", hookName)
@@ -144,7 +147,7 @@ func (h *Hooker) uninstallHook(hookName string) {
 	}
 
 	if err := os.Remove(hookPath); err != nil {
-		_, _ = fmt.Fprintf(h.outputWriter, "Error removing hook: %v\n", err)
+		WriteErrorf(h.outputWriter, "removing hook: %w", err)
 		return
 	}
 
@@ -161,7 +164,7 @@ func (h *Hooker) enableHook(hookName string) {
 	}
 
 	if err := os.Chmod(hookPath, 0755); err != nil {
-		_, _ = fmt.Fprintf(h.outputWriter, "Error enabling hook: %v\n", err)
+		WriteErrorf(h.outputWriter, "enabling hook: %w", err)
 		return
 	}
 
@@ -178,7 +181,7 @@ func (h *Hooker) disableHook(hookName string) {
 	}
 
 	if err := os.Chmod(hookPath, 0644); err != nil {
-		_, _ = fmt.Fprintf(h.outputWriter, "Error disabling hook: %v\n", err)
+		WriteErrorf(h.outputWriter, "disabling hook: %w", err)
 		return
 	}
 
@@ -206,7 +209,7 @@ func (h *Hooker) editHook(hookName string) {
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
-		_, _ = fmt.Fprintf(h.outputWriter, "Error opening editor: %v\n", err)
+		WriteErrorf(h.outputWriter, "opening editor: %w", err)
 	}
 }
 
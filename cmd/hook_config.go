@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/bmf-san/ggc/v8/internal/hooks"
+)
+
+// stdinHooks are the hooks git feeds on standard input; every step of a
+// declared hook gets its own copy.
+var stdinHooks = []string{"pre-push", "pre-receive", "post-receive", "post-rewrite"}
+
+// install installs a hook from its sample, a basic template, or a named
+// template with --template.
+func (h *Hooker) install(args []string) {
+	var name, tmplName string
+	force := false
+	for i := 0; i < len(args); i++ {
+		flag, value, hasValue := strings.Cut(args[i], "=")
+		switch flag {
+		case "--template", "-t":
+			if !hasValue {
+				if i+1 >= len(args) {
+					WriteErrorf(h.outputWriter, "%s requires a value", flag)
+					return
+				}
+				i++
+				value = args[i]
+			}
+			tmplName = value
+		case "--force":
+			force = true
+		default:
+			if name != "" {
+				WriteErrorf(h.outputWriter, "unknown argument %q", args[i])
+				return
+			}
+			name = args[i]
+		}
+	}
+
+	if tmplName == "" {
+		if name == "" {
+			WriteErrorf(h.outputWriter, "hook name required")
+			h.helper.ShowHookHelp()
+			return
+		}
+		h.installHook(name)
+		return
+	}
+
+	tmpl, ok := hooks.FindTemplate(tmplName)
+	if !ok {
+		WriteErrorf(h.outputWriter, "unknown template %q; see 'ggc hook templates'", tmplName)
+		return
+	}
+	if name != "" && name != tmpl.Hook {
+		WriteErrorf(h.outputWriter, "template %q is a %s hook, not %s", tmpl.Name, tmpl.Hook, name)
+		return
+	}
+	hookPath := filepath.Join(".git", "hooks", tmpl.Hook)
+	if _, err := os.Stat(hookPath); err == nil && !force {
+		_, _ = fmt.Fprintf(h.outputWriter, "Hook '%s' already exists (use --force to replace it)\n", tmpl.Hook)
+		return
+	}
+	if err := writeHook(hookPath, tmpl.Script); err != nil {
+		WriteErrorf(h.outputWriter, "creating hook: %w", err)
+		return
+	}
+	_, _ = fmt.Fprintf(h.outputWriter, "Hook '%s' installed from template '%s'\n", tmpl.Hook, tmpl.Name)
+}
+
+// listTemplates lists the templates install --template accepts.
+func (h *Hooker) listTemplates() {
+	tw := tabwriter.NewWriter(h.outputWriter, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TEMPLATE\tHOOK\tDESCRIPTION")
+	for _, t := range hooks.Templates {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", t.Name, t.Hook, t.Summary)
+	}
+	_ = tw.Flush()
+}
+
+// syncHooks installs a `ggc hook run` script for every hook declared in
+// .ggc-hooks.yaml and removes the ones no longer declared. Hooks that ggc
+// did not write are kept unless --force is given.
+func (h *Hooker) syncHooks(args []string) {
+	force := false
+	for _, arg := range args {
+		if arg != "--force" {
+			WriteErrorf(h.outputWriter, "unknown argument %q", arg)
+			return
+		}
+		force = true
+	}
+	file, err := hooks.Load(hooks.FileName)
+	if errors.Is(err, os.ErrNotExist) {
+		WriteErrorf(h.outputWriter, "no %s in this directory", hooks.FileName)
+		return
+	}
+	if err != nil {
+		WriteError(h.outputWriter, err)
+		return
+	}
+
+	hooksDir := filepath.Join(".git", "hooks")
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		WriteError(h.outputWriter, err)
+		return
+	}
+	declared := file.Names()
+	for _, name := range declared {
+		hookPath := filepath.Join(hooksDir, name)
+		if script, err := os.ReadFile(hookPath); err == nil && !hooks.IsShim(script) && !force {
+			_, _ = fmt.Fprintf(h.outputWriter, "Skipped '%s': an existing hook is in the way (use --force to replace it)\n", name)
+			continue
+		}
+		if err := writeHook(hookPath, hooks.Shim(name)); err != nil {
+			WriteError(h.outputWriter, err)
+			return
+		}
+		_, _ = fmt.Fprintf(h.outputWriter, "Hook '%s' runs %d step(s) from %s\n", name, len(file.Hooks[name]), hooks.FileName)
+	}
+	for _, name := range hooks.StandardHooks {
+		if slices.Contains(declared, name) {
+			continue
+		}
+		hookPath := filepath.Join(hooksDir, name)
+		if script, err := os.ReadFile(hookPath); err == nil && hooks.IsShim(script) {
+			if err := os.Remove(hookPath); err != nil {
+				WriteError(h.outputWriter, err)
+				return
+			}
+			_, _ = fmt.Fprintf(h.outputWriter, "Hook '%s' removed: no longer in %s\n", name, hooks.FileName)
+		}
+	}
+}
+
+// runHook runs the steps .ggc-hooks.yaml declares for a hook, stopping at
+// the first failure with exit status 1 so git aborts the operation.
+func (h *Hooker) runHook(args []string) {
+	if len(args) == 0 {
+		WriteErrorf(h.outputWriter, "hook name required")
+		return
+	}
+	name, hookArgs := args[0], args[1:]
+	file, err := hooks.Load(hooks.FileName)
+	if errors.Is(err, os.ErrNotExist) {
+		// The file was removed without a sync; let git carry on.
+		return
+	}
+	if err != nil {
+		WriteError(h.outputWriter, err)
+		h.exit(1)
+		return
+	}
+
+	var input []byte
+	if slices.Contains(stdinHooks, name) && h.stdin != nil {
+		if input, err = io.ReadAll(h.stdin); err != nil {
+			WriteError(h.outputWriter, err)
+			h.exit(1)
+			return
+		}
+	}
+	for _, step := range file.Hooks[name] {
+		_, _ = fmt.Fprintf(h.outputWriter, "%s: %s\n", name, step.Label())
+		cmd := h.execCommand("sh", append([]string{"-c", step.Run, name}, hookArgs...)...)
+		cmd.Stdin = bytes.NewReader(input)
+		cmd.Stdout = h.outputWriter
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			WriteErrorf(h.outputWriter, "%s: %s failed: %v", name, step.Label(), err)
+			h.exit(1)
+			return
+		}
+	}
+}
+
+func writeHook(path, script string) error {
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file.
+	return os.Chmod(path, 0o755)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/hooks"
+)
+
+// newTestHooker returns a Hooker working in a fresh directory with an
+// empty .git/hooks.
+func newTestHooker(t *testing.T, stdin string) (*Hooker, *bytes.Buffer, *int) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(filepath.Join(".git", "hooks"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	exitCode := -1
+	h := &Hooker{
+		outputWriter: &buf,
+		helper:       NewHelper(),
+		execCommand:  exec.Command,
+		stdin:        strings.NewReader(stdin),
+		exit:         func(code int) { exitCode = code },
+	}
+	h.helper.outputWriter = &buf
+	return h, &buf, &exitCode
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHooker_InstallTemplate(t *testing.T) {
+	h, buf, _ := newTestHooker(t, "")
+
+	h.Hook([]string{"install", "--template", "commit-lint"})
+	script, err := os.ReadFile(filepath.Join(".git", "hooks", "commit-msg"))
+	if err != nil || !strings.Contains(string(script), "ggc commit lint") {
+		t.Fatalf("commit-msg hook = %q, %v (output %q)", script, err, buf.String())
+	}
+
+	buf.Reset()
+	h.Hook([]string{"install", "pre-commit", "--template=commit-lint"})
+	if !strings.Contains(buf.String(), `template "commit-lint" is a commit-msg hook`) {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	buf.Reset()
+	h.Hook([]string{"templates"})
+	if !strings.Contains(buf.String(), "pre-push-test") {
+		t.Errorf("templates should be listed: %q", buf.String())
+	}
+}
+
+func TestHooker_SyncAndRun(t *testing.T) {
+	h, buf, exitCode := newTestHooker(t, "refs/heads/main abc refs/heads/main def\n")
+	writeTestFile(t, hooks.FileName, `hooks:
+  pre-push:
+    - name: remote
+      run: echo "pushing to $1"
+    - name: refs
+      run: cat
+  commit-msg:
+    - run: exit 3
+  pre-commit:
+    - run: "true"
+`)
+	custom := filepath.Join(".git", "hooks", "pre-commit")
+	writeTestFile(t, custom, "#!/bin/sh\nexit 0\n")
+	writeTestFile(t, filepath.Join(".git", "hooks", "post-merge"), hooks.Shim("post-merge"))
+
+	h.Hook([]string{"sync"})
+
+	out := buf.String()
+	for _, want := range []string{"'commit-msg' runs 1 step(s)", "'pre-push' runs 2 step(s)", "Skipped 'pre-commit'", "'post-merge' removed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("sync output missing %q:\n%s", want, out)
+		}
+	}
+	if script, _ := os.ReadFile(filepath.Join(".git", "hooks", "pre-push")); !hooks.IsShim(script) {
+		t.Errorf("pre-push should be a ggc hook, got %q", script)
+	}
+	if script, _ := os.ReadFile(custom); hooks.IsShim(script) {
+		t.Error("an existing hook should be kept without --force")
+	}
+
+	buf.Reset()
+	h.Hook([]string{"run", "pre-push", "origin", "git@example.com:o/r.git"})
+	if out := buf.String(); !strings.Contains(out, "pushing to origin") || !strings.Contains(out, "refs/heads/main abc") || *exitCode != -1 {
+		t.Errorf("unexpected run output (exit %d):\n%s", *exitCode, out)
+	}
+
+	buf.Reset()
+	h.Hook([]string{"run", "commit-msg", ".git/COMMIT_EDITMSG"})
+	if *exitCode != 1 || !strings.Contains(buf.String(), "commit-msg: exit 3 failed") {
+		t.Errorf("a failing step should exit 1, got %d:\n%s", *exitCode, buf.String())
+	}
+}
@@ -3,8 +3,14 @@ package cmd
 
 import (
 	"io"
+	"os"
 
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
 	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 // ReadLine prompts for and reads a single line of input.
@@ -28,3 +34,84 @@ func ReadLine(p prompt.Prompter, w io.Writer, promptText string) (string, bool)
 	}
 	return line, true
 }
+
+// multiSelector lets the user pick several of items. ok is false when the
+// user cancels.
+type multiSelector func(title string, items []string) (selected []string, ok bool, err error)
+
+// newMultiSelector returns the full-screen multi-select picker, or nil when
+// stdin is not a terminal so callers keep their numbered prompts. cm
+// supplies the keybinding profile and may be nil.
+func newMultiSelector(cm *config.Manager) multiSelector {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil
+	}
+	return func(title string, items []string) ([]string, bool, error) {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewMultiSelector(title, items, cfg).Run()
+	}
+}
+
+// cleanSelector picks the files `ggc clean` deletes. ok is false when the
+// user cancels.
+type cleanSelector func(files []interactive.CleanFile) (selected []interactive.CleanFile, ok bool, err error)
+
+// newCleanSelector returns the full-screen clean picker, or nil when stdin
+// is not a terminal. cm supplies the keybinding profile and may be nil.
+func newCleanSelector(cm *config.Manager) cleanSelector {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil
+	}
+	return func(files []interactive.CleanFile) ([]interactive.CleanFile, bool, error) {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewCleanSelector(files, cfg).Run()
+	}
+}
+
+// newConfirmer returns the confirmation prompt for destructive commands,
+// following behavior.confirm-destructive. cm may be nil.
+func newConfirmer(cm *config.Manager) *ui.Confirmer {
+	mode := ui.ConfirmSimple
+	if cm != nil {
+		mode = ui.ConfirmMode(cm.GetConfig().Behavior.ConfirmDestructive)
+	}
+	return ui.NewConfirmer(prompt.New(os.Stdin, os.Stdout), term.IsTerminal(int(os.Stdin.Fd())), mode)
+}
+
+// proceed reports whether a confirmed operation goes ahead, telling w why
+// not when it does not.
+func proceed(w io.Writer, ok bool, err error) bool {
+	if err != nil {
+		WriteError(w, err)
+		return false
+	}
+	if !ok {
+		WriteLine(w, "Canceled.")
+	}
+	return ok
+}
+
+// picker lets the user choose one of items, starting filtered by query.
+// ok is false when the user cancels.
+type picker func(title string, items []interactive.PickItem, query string) (value string, ok bool, err error)
+
+// newPicker returns the full-screen fuzzy picker, or nil when stdin is not
+// a terminal. cm supplies the keybinding profile and may be nil.
+func newPicker(cm *config.Manager) picker {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil
+	}
+	return func(title string, items []interactive.PickItem, query string) (string, bool, error) {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewPicker(title, items, query, cfg).Run()
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// lfsOps is what ggc lfs needs from git.
+type lfsOps interface {
+	git.LFSReader
+	git.PassthroughOps
+}
+
+// errLFSMissing is reported when git-lfs is not installed.
+var errLFSMissing = errors.New("git-lfs is not installed; install it from https://git-lfs.com and run `git lfs install`")
+
+// lfsSubcommands are the git lfs subcommands ggc lfs runs.
+var lfsSubcommands = map[string]bool{
+	"status":  true,
+	"track":   true,
+	"untrack": true,
+	"pull":    true,
+}
+
+// LFSer handles ggc lfs.
+type LFSer struct {
+	gitClient    lfsOps
+	outputWriter io.Writer
+	helper       *Helper
+}
+
+// NewLFSer creates a new LFSer instance.
+func NewLFSer(client lfsOps) *LFSer {
+	return &LFSer{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+	}
+}
+
+// LFS runs git lfs status, track, untrack or pull, after checking that
+// git-lfs is installed.
+func (l *LFSer) LFS(args []string) {
+	if len(args) == 0 || !lfsSubcommands[args[0]] {
+		l.helper.ShowLFSHelp()
+		return
+	}
+	if !l.gitClient.LFSInstalled() {
+		WriteError(l.outputWriter, errLFSMissing)
+		return
+	}
+	if err := l.gitClient.RunGit("lfs", args); err != nil {
+		WriteError(l.outputWriter, err)
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type lfsMock struct {
+	testutil.MockGitClient
+	missing bool
+	ran     []string
+}
+
+func (m *lfsMock) LFSInstalled() bool { return !m.missing }
+
+func (m *lfsMock) RunGit(name string, args []string) error {
+	m.ran = append([]string{name}, args...)
+	return nil
+}
+
+func TestLFSer_LFS(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"status", []string{"status"}, []string{"lfs", "status"}},
+		{"track", []string{"track", "*.psd"}, []string{"lfs", "track", "*.psd"}},
+		{"untrack", []string{"untrack", "*.psd"}, []string{"lfs", "untrack", "*.psd"}},
+		{"pull", []string{"pull", "origin"}, []string{"lfs", "pull", "origin"}},
+		{"unknown", []string{"migrate"}, nil},
+		{"none", nil, nil},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		m := &lfsMock{}
+		l := NewLFSer(m)
+		l.outputWriter = &buf
+		l.helper.outputWriter = &buf
+		l.LFS(tt.args)
+		if !slices.Equal(m.ran, tt.want) {
+			t.Errorf("%s: ran %v, want %v", tt.name, m.ran, tt.want)
+		}
+		if tt.want == nil && !strings.Contains(buf.String(), "ggc lfs") {
+			t.Errorf("%s: expected help, got %q", tt.name, buf.String())
+		}
+	}
+}
+
+func TestLFSer_LFS_Missing(t *testing.T) {
+	var buf bytes.Buffer
+	m := &lfsMock{missing: true}
+	l := NewLFSer(m)
+	l.outputWriter = &buf
+	l.LFS([]string{"pull"})
+	if m.ran != nil {
+		t.Errorf("git lfs should not run, ran %v", m.ran)
+	}
+	if !strings.Contains(buf.String(), "git-lfs is not installed") || !strings.Contains(buf.String(), "https://git-lfs.com") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
@@ -2,19 +2,56 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"strings"
 
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
+// logViewer shows the interactive commit graph and returns the chosen action.
+type logViewer func() (interactive.LogAction, bool, error)
+
+// logActions are the git operations behind the log viewer's actions.
+type logActions interface {
+	CheckoutBranch(name string) error
+	CreateBranchAt(name, commit string) error
+	git.CherryPickOps
+	git.RevertOps
+}
+
+// fileHistoryViewer shows the history of a file and returns the revision
+// chosen to restore.
+type fileHistoryViewer func(path string, follow bool) (git.FileRevision, bool, error)
+
+// fileHistoryOps are the git operations behind ggc log file.
+type fileHistoryOps interface {
+	git.FileHistoryReader
+	RestoreFromCommit(commit string, paths ...string) error
+}
+
 // Logger provides functionality for the log command.
 type Logger struct {
 	gitClient    git.LogReader
 	outputWriter io.Writer
 	execCommand  func(name string, arg ...string) *exec.Cmd
 	helper       *Helper
+	prompter     prompt.Prompter
+	browse       logViewer // nil when stdin is not a terminal
+	actions      logActions
+	files        fileHistoryOps
+	fileView     fileHistoryViewer // nil when stdin is not a terminal
+	confirm      *ui.Confirmer
+	scope        *pathScope
 }
 
 // NewLogger creates a new Logger.
@@ -24,28 +61,201 @@ func NewLogger(client git.LogReader) *Logger {
 		outputWriter: os.Stdout,
 		execCommand:  exec.Command,
 		helper:       NewHelper(),
+		prompter:     prompt.New(os.Stdin, os.Stdout),
 	}
 	l.helper.outputWriter = l.outputWriter
 	return l
 }
 
+// withViewer enables `ggc log browse` when stdin is a terminal. cm
+// supplies the keybinding profile and diff tool and may be nil.
+func (l *Logger) withViewer(client interface {
+	interactive.LogSource
+	logActions
+}, cm *config.Manager) *Logger {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return l
+	}
+	l.actions = client
+	l.browse = func() (interactive.LogAction, bool, error) {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewLogViewer(client, cfg).Run()
+	}
+	return l
+}
+
+// withFileHistory enables `ggc log file`, in the file history viewer when
+// stdin is a terminal. cm supplies the keybinding profile and diff tool and
+// may be nil.
+func (l *Logger) withFileHistory(client interface {
+	interactive.FileHistorySource
+	fileHistoryOps
+}, cm *config.Manager) *Logger {
+	l.files = client
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return l
+	}
+	l.fileView = func(path string, follow bool) (git.FileRevision, bool, error) {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewFileHistoryViewer(client, cfg).Run(path, follow)
+	}
+	return l
+}
+
+// withConfirmer makes restoring a file from its history ask first.
+func (l *Logger) withConfirmer(c *ui.Confirmer) *Logger {
+	l.confirm = c
+	return l
+}
+
+// withPathScope limits ggc log simple and graph to the commits that touch
+// the directory of ggc --path.
+func (l *Logger) withPathScope(scope *pathScope) *Logger {
+	l.scope = scope
+	return l
+}
+
 // Log executes the log command with the given arguments.
 func (l *Logger) Log(args []string) {
 	if len(args) == 0 {
+		if l.browse != nil {
+			l.logBrowse()
+			return
+		}
 		l.helper.ShowLogHelp()
 		return
 	}
 
 	switch args[0] {
 	case "simple":
-		if err := l.gitClient.LogSimple(); err != nil {
+		if err := l.gitClient.LogSimple(l.scope.pathspecs()...); err != nil {
 			WriteError(l.outputWriter, err)
 		}
 	case "graph":
-		if err := l.gitClient.LogGraph(); err != nil {
+		if err := l.gitClient.LogGraph(l.scope.pathspecs()...); err != nil {
 			WriteError(l.outputWriter, err)
 		}
+	case "browse":
+		l.logBrowse()
+	case "file":
+		l.logFile(args[1:])
 	default:
 		l.helper.ShowLogHelp()
 	}
 }
+
+// logBrowse opens the commit graph and runs the action chosen in it.
+func (l *Logger) logBrowse() {
+	if l.browse == nil {
+		WriteError(l.outputWriter, errors.New("log browse needs an interactive terminal"))
+		return
+	}
+	action, ok, err := l.browse()
+	if err != nil {
+		WriteError(l.outputWriter, err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	commit := action.Commit
+	switch action.Kind {
+	case interactive.LogCheckout:
+		err = l.actions.CheckoutBranch(checkoutTarget(commit))
+	case interactive.LogCherryPick:
+		if err = l.actions.CherryPick(commit.Hash); err == nil {
+			_, _ = fmt.Fprintf(l.outputWriter, "Cherry-picked %s %s\n", commit.Short, commit.Subject)
+		}
+	case interactive.LogRevert:
+		if err = l.actions.Revert(commit.Hash); err == nil {
+			_, _ = fmt.Fprintf(l.outputWriter, "Reverted %s %s\n", commit.Short, commit.Subject)
+		}
+	case interactive.LogBranch:
+		name, canceled, inputErr := l.prompter.Input(fmt.Sprintf("New branch at %s: ", commit.Short))
+		if canceled || inputErr != nil || strings.TrimSpace(name) == "" {
+			WriteLine(l.outputWriter, "Canceled.")
+			return
+		}
+		if err = l.actions.CreateBranchAt(strings.TrimSpace(name), commit.Hash); err == nil {
+			_, _ = fmt.Fprintf(l.outputWriter, "Created branch %s at %s\n", strings.TrimSpace(name), commit.Short)
+		}
+	}
+	if err != nil {
+		WriteError(l.outputWriter, err)
+	}
+}
+
+// logFile lists the commits that changed a file: in the file history
+// viewer on a terminal, where a revision can be restored, or one per line.
+func (l *Logger) logFile(args []string) {
+	follow, path := false, ""
+	for _, arg := range args {
+		switch {
+		case arg == "--follow":
+			follow = true
+		case path == "" && !strings.HasPrefix(arg, "-"):
+			path = arg
+		default:
+			l.helper.ShowLogHelp()
+			return
+		}
+	}
+	if path == "" || l.files == nil {
+		l.helper.ShowLogHelp()
+		return
+	}
+
+	if l.fileView == nil {
+		revs, err := l.files.FileHistory(path, follow)
+		if err != nil {
+			WriteError(l.outputWriter, err)
+			return
+		}
+		for _, r := range revs {
+			line := fmt.Sprintf("%s %s (%s, %s)", r.Short, r.Subject, r.Author, r.Date)
+			if r.Path != path {
+				line += " as " + r.Path
+			}
+			WriteLine(l.outputWriter, line)
+		}
+		return
+	}
+
+	rev, restore, err := l.fileView(path, follow)
+	if err != nil {
+		WriteError(l.outputWriter, err)
+		return
+	}
+	if !restore {
+		return
+	}
+	if l.confirm != nil {
+		ok, err := l.confirm.Confirm(fmt.Sprintf("Overwrite %s with its version at %s?", rev.Path, rev.Short))
+		if !proceed(l.outputWriter, ok, err) {
+			return
+		}
+	}
+	if err := l.files.RestoreFromCommit(rev.Hash, rev.Path); err != nil {
+		WriteError(l.outputWriter, err)
+		return
+	}
+	WriteLinef(l.outputWriter, "Restored %s from %s %s", rev.Path, rev.Short, rev.Subject)
+}
+
+// checkoutTarget prefers a local branch pointing at the commit, so
+// checking out a branch tip does not detach HEAD.
+func checkoutTarget(commit git.GraphLine) string {
+	for _, ref := range commit.Refs {
+		if name, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+			return name
+		}
+	}
+	return commit.Hash
+}
@@ -5,6 +5,11 @@ import (
 	"errors"
 	"strings"
 	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 type mockLogGitClient struct {
@@ -13,12 +18,12 @@ type mockLogGitClient struct {
 	err             error
 }
 
-func (m *mockLogGitClient) LogSimple() error {
+func (m *mockLogGitClient) LogSimple(...string) error {
 	m.logSimpleCalled = true
 	return m.err
 }
 
-func (m *mockLogGitClient) LogGraph() error {
+func (m *mockLogGitClient) LogGraph(...string) error {
 	m.logGraphCalled = true
 	return m.err
 }
@@ -216,3 +221,155 @@ func TestLogger_Log_Graph_OutputFormat(t *testing.T) {
 		})
 	}
 }
+
+// mockLogActions records the actions run from the log viewer.
+type mockLogActions struct {
+	calls []string
+}
+
+func (m *mockLogActions) CheckoutBranch(name string) error {
+	m.calls = append(m.calls, "checkout "+name)
+	return nil
+}
+
+func (m *mockLogActions) CreateBranchAt(name, commit string) error {
+	m.calls = append(m.calls, "branch "+name+" "+commit)
+	return nil
+}
+
+func (m *mockLogActions) CherryPick(commits ...string) error {
+	m.calls = append(m.calls, "cherry-pick "+strings.Join(commits, " "))
+	return nil
+}
+
+func (m *mockLogActions) Revert(commits ...string) error {
+	m.calls = append(m.calls, "revert "+strings.Join(commits, " "))
+	return nil
+}
+
+func TestLogger_LogBrowse(t *testing.T) {
+	tip := git.GraphLine{Hash: "abc123full", Short: "abc123", Subject: "tip",
+		Refs: []string{"refs/remotes/origin/topic", "refs/heads/topic"}}
+	plain := git.GraphLine{Hash: "def456full", Short: "def456", Subject: "older"}
+	tests := []struct {
+		name   string
+		action interactive.LogAction
+		input  string
+		want   string
+	}{
+		{"checkout prefers a local branch", interactive.LogAction{Kind: interactive.LogCheckout, Commit: tip}, "", "checkout topic"},
+		{"checkout detaches without one", interactive.LogAction{Kind: interactive.LogCheckout, Commit: plain}, "", "checkout def456full"},
+		{"cherry-pick", interactive.LogAction{Kind: interactive.LogCherryPick, Commit: plain}, "", "cherry-pick def456full"},
+		{"revert", interactive.LogAction{Kind: interactive.LogRevert, Commit: plain}, "", "revert def456full"},
+		{"branch", interactive.LogAction{Kind: interactive.LogBranch, Commit: plain}, "from-log\n", "branch from-log def456full"},
+		{"branch canceled", interactive.LogAction{Kind: interactive.LogBranch, Commit: plain}, "\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			actions := &mockLogActions{}
+			l := &Logger{
+				gitClient:    &mockLogGitClient{},
+				outputWriter: &buf,
+				helper:       NewHelper(),
+				prompter:     prompt.New(strings.NewReader(tt.input), &buf),
+				actions:      actions,
+				browse: func() (interactive.LogAction, bool, error) {
+					return tt.action, true, nil
+				},
+			}
+			l.Log(nil)
+			if got := strings.Join(actions.calls, "; "); got != tt.want {
+				t.Errorf("calls = %q, want %q (output %q)", got, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestLogger_LogBrowse_NoTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	l := &Logger{gitClient: &mockLogGitClient{}, outputWriter: &buf, helper: NewHelper()}
+	l.helper.outputWriter = &buf
+	l.Log([]string{"browse"})
+	if !strings.Contains(buf.String(), "needs an interactive terminal") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+// mockFileHistory serves a file renamed from old.go and records restores.
+type mockFileHistory struct {
+	follow   bool
+	restored []string
+}
+
+func (m *mockFileHistory) FileHistory(_ string, follow bool) ([]git.FileRevision, error) {
+	m.follow = follow
+	return []git.FileRevision{
+		{CommitSummary: git.CommitSummary{Hash: "c2full", Short: "c2", Subject: "Rename", Author: "Alice", Date: "now"}, Path: "new.go"},
+		{CommitSummary: git.CommitSummary{Hash: "c1full", Short: "c1", Subject: "Add", Author: "Bob", Date: "last week"}, Path: "old.go"},
+	}, nil
+}
+
+func (m *mockFileHistory) RestoreFromCommit(commit string, paths ...string) error {
+	m.restored = append(m.restored, commit+" "+strings.Join(paths, " "))
+	return nil
+}
+
+func TestLogger_LogFile_Plain(t *testing.T) {
+	var buf bytes.Buffer
+	files := &mockFileHistory{}
+	l := &Logger{gitClient: &mockLogGitClient{}, outputWriter: &buf, helper: NewHelper(), files: files}
+	l.Log([]string{"file", "--follow", "new.go"})
+	if want := "c2 Rename (Alice, now)\nc1 Add (Bob, last week) as old.go\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+	if !files.follow {
+		t.Error("--follow was not passed on")
+	}
+}
+
+func TestLogger_LogFile_Usage(t *testing.T) {
+	for _, args := range [][]string{{"file"}, {"file", "a.go", "b.go"}, {"file", "-p", "a.go"}} {
+		var buf bytes.Buffer
+		l := &Logger{gitClient: &mockLogGitClient{}, outputWriter: &buf, helper: NewHelper(), files: &mockFileHistory{}}
+		l.helper.outputWriter = &buf
+		l.Log(args)
+		if !strings.Contains(buf.String(), "Usage") {
+			t.Errorf("%v: output = %q", args, buf.String())
+		}
+	}
+}
+
+func TestLogger_LogFile_Restore(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  string
+		restore bool
+		want    string
+	}{
+		{"confirmed", "y\n", true, "c1full old.go"},
+		{"declined", "n\n", true, ""},
+		{"quit", "", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			files := &mockFileHistory{}
+			l := &Logger{
+				gitClient:    &mockLogGitClient{},
+				outputWriter: &buf,
+				helper:       NewHelper(),
+				files:        files,
+				confirm:      ui.NewConfirmer(prompt.New(strings.NewReader(tt.answer), &buf), true, ui.ConfirmSimple),
+				fileView: func(string, bool) (git.FileRevision, bool, error) {
+					revs, _ := files.FileHistory("new.go", false)
+					return revs[1], tt.restore, nil
+				},
+			}
+			l.Log([]string{"file", "new.go"})
+			if got := strings.Join(files.restored, "; "); got != tt.want {
+				t.Errorf("restored %q, want %q (output %q)", got, tt.want, buf.String())
+			}
+		})
+	}
+}
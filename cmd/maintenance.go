@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// maintenanceOps is what ggc maintenance needs from git.
+type maintenanceOps interface {
+	git.MaintenanceReader
+	git.PassthroughOps
+}
+
+// maintenancePresets are the git commands each preset of ggc maintenance
+// run runs, in order. standard is the default.
+var maintenancePresets = map[string][][]string{
+	// Cheap enough to run any time: packs loose objects, folds small
+	// packs together and refreshes the commit-graph.
+	"quick":    {{"maintenance", "run", "--task=loose-objects", "--task=incremental-repack", "--task=commit-graph"}},
+	"standard": {{"gc"}},
+	// Recomputes every delta and drops unreachable objects right away,
+	// which takes a while on a large repository.
+	"full": {{"gc", "--aggressive", "--prune=now"}, {"repack", "-a", "-d", "--write-bitmap-index"}},
+}
+
+// maintenanceSchedulers are the values git maintenance start accepts for
+// --scheduler.
+var maintenanceSchedulers = []string{"auto", "crontab", "systemd-timer", "launchctl", "schtasks"}
+
+// defaultTopBlobs is how many files ggc maintenance analyze lists.
+const defaultTopBlobs = 10
+
+// Maintainer handles ggc maintenance.
+type Maintainer struct {
+	gitClient    maintenanceOps
+	outputWriter io.Writer
+	helper       *Helper
+	goos         string
+	lookPath     func(string) (string, error)
+}
+
+// NewMaintainer creates a new Maintainer instance.
+func NewMaintainer(client maintenanceOps) *Maintainer {
+	return &Maintainer{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+		goos:         runtime.GOOS,
+		lookPath:     exec.LookPath,
+	}
+}
+
+// Maintenance runs a maintenance preset, reports on the object store or
+// schedules maintenance. Other subcommands go to git maintenance as they
+// are.
+func (m *Maintainer) Maintenance(args []string) {
+	if len(args) == 0 || args[0] == "help" {
+		m.helper.ShowMaintenanceHelp()
+		return
+	}
+	switch args[0] {
+	case "run":
+		m.run(args[1:])
+	case "analyze", "--analyze":
+		m.analyze(args[1:])
+	case "start":
+		m.start(args[1:])
+	default:
+		if err := m.gitClient.RunGit("maintenance", args); err != nil {
+			WriteError(m.outputWriter, err)
+		}
+	}
+}
+
+// run runs the preset named by --quick or --full, standard without
+// either, and reports how much space it freed. Any other option makes it
+// git maintenance run with the options as they are, as in
+// `ggc maintenance run --task=gc`.
+func (m *Maintainer) run(args []string) {
+	preset := "standard"
+	var rest []string
+	for _, arg := range args {
+		switch arg {
+		case "--quick":
+			preset = "quick"
+		case "--full":
+			preset = "full"
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	if len(rest) > 0 {
+		if len(rest) < len(args) {
+			WriteErrorf(m.outputWriter, "--quick and --full cannot be combined with git maintenance run options")
+			return
+		}
+		if err := m.gitClient.RunGit("maintenance", append([]string{"run"}, rest...)); err != nil {
+			WriteError(m.outputWriter, err)
+		}
+		return
+	}
+	before, statsErr := m.gitClient.RepoStats()
+	steps := maintenancePresets[preset]
+	for i, step := range steps {
+		WriteLinef(m.outputWriter, "[%d/%d] git %s", i+1, len(steps), strings.Join(step, " "))
+		if err := m.gitClient.RunGit(step[0], step[1:]); err != nil {
+			WriteError(m.outputWriter, err)
+			return
+		}
+	}
+	after, err := m.gitClient.RepoStats()
+	if statsErr != nil || err != nil {
+		return
+	}
+	WriteLinef(m.outputWriter, "Object store: %s -> %s", ui.FormatBytes(before.Size()), ui.FormatBytes(after.Size()))
+}
+
+// analyze reports the size of the object store, how it is packed and the
+// largest files in history, with --top setting how many.
+func (m *Maintainer) analyze(args []string) {
+	top := defaultTopBlobs
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--top" && i+1 < len(args):
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				WriteErrorf(m.outputWriter, "--top expects a positive number, got %q", args[i])
+				return
+			}
+			top = n
+		default:
+			WriteErrorf(m.outputWriter, "unknown maintenance analyze option %s", arg)
+			return
+		}
+	}
+	stats, err := m.gitClient.RepoStats()
+	if err != nil {
+		WriteError(m.outputWriter, err)
+		return
+	}
+	blobs, err := m.gitClient.LargestBlobs(top)
+	if err != nil {
+		WriteError(m.outputWriter, err)
+		return
+	}
+	w := m.outputWriter
+	WriteLinef(w, "Object store: %s", ui.FormatBytes(stats.Size()))
+	WriteLinef(w, "  Packed:  %d objects in %s, %s", stats.PackedObjects, plural(stats.Packs, "pack"), ui.FormatBytes(stats.PackSize))
+	WriteLinef(w, "  Loose:   %d objects, %s", stats.LooseObjects, ui.FormatBytes(stats.LooseSize))
+	if stats.Garbage > 0 {
+		WriteLinef(w, "  Garbage: %s, %s", plural(stats.Garbage, "file"), ui.FormatBytes(stats.GarbageSize))
+	}
+	if len(blobs) > 0 {
+		WriteLine(w, "")
+		WriteLine(w, "Largest files in history:")
+		for _, b := range blobs {
+			WriteLinef(w, "  %10s  %s", ui.FormatBytes(b.Size), b.Path)
+		}
+	}
+	if hints := maintenanceHints(stats); len(hints) > 0 {
+		WriteLine(w, "")
+		for _, hint := range hints {
+			WriteLine(w, hint)
+		}
+	}
+}
+
+// maintenanceHints suggests what would shrink or speed up an object store
+// in the state stats describes.
+func maintenanceHints(stats git.RepoStats) []string {
+	var hints []string
+	switch {
+	case stats.LooseObjects >= 1000 || stats.Packs >= 10:
+		hints = append(hints, "Run 'ggc maintenance run' to pack the loose objects and combine the packs.")
+	case stats.PrunePackable > 0:
+		hints = append(hints, "Run 'ggc maintenance run' to remove loose objects that are already packed.")
+	}
+	if stats.Garbage > 0 {
+		hints = append(hints, "Files that are not objects sit in .git/objects; 'ggc fsck' can help find out where they came from.")
+	}
+	return hints
+}
+
+// plural returns n with noun, adding an s unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// start registers the repository for background maintenance and
+// schedules it with the platform's scheduler, or the one --scheduler
+// names.
+func (m *Maintainer) start(args []string) {
+	scheduler := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--scheduler" && i+1 < len(args):
+			i++
+			scheduler = args[i]
+		case strings.HasPrefix(arg, "--scheduler="):
+			scheduler = strings.TrimPrefix(arg, "--scheduler=")
+		default:
+			WriteErrorf(m.outputWriter, "unknown maintenance start option %s", arg)
+			return
+		}
+	}
+	if scheduler == "" {
+		scheduler = m.defaultScheduler()
+	}
+	if !slices.Contains(maintenanceSchedulers, scheduler) {
+		WriteErrorf(m.outputWriter, "unknown scheduler %q; use one of %s", scheduler, strings.Join(maintenanceSchedulers, ", "))
+		return
+	}
+	if err := m.gitClient.RunGit("maintenance", []string{"start", "--scheduler=" + scheduler}); err != nil {
+		WriteError(m.outputWriter, err)
+		return
+	}
+	WriteLinef(m.outputWriter, "Scheduled hourly, daily and weekly maintenance with %s. Run 'ggc maintenance unregister' to take this repository off the schedule.", scheduler)
+}
+
+// defaultScheduler returns the scheduler git maintenance start uses on
+// this platform: launchd on macOS, the Task Scheduler on Windows, and
+// systemd timers where systemctl is installed, cron otherwise.
+func (m *Maintainer) defaultScheduler() string {
+	switch m.goos {
+	case "darwin":
+		return "launchctl"
+	case "windows":
+		return "schtasks"
+	}
+	if _, err := m.lookPath("systemctl"); err == nil {
+		return "systemd-timer"
+	}
+	return "crontab"
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type maintenanceMock struct {
+	testutil.MockGitClient
+	stats []git.RepoStats
+	blobs []git.BlobSize
+	ran   [][]string
+}
+
+func (m *maintenanceMock) RepoStats() (git.RepoStats, error) {
+	s := m.stats[0]
+	if len(m.stats) > 1 {
+		m.stats = m.stats[1:]
+	}
+	return s, nil
+}
+
+func (m *maintenanceMock) LargestBlobs(n int) ([]git.BlobSize, error) {
+	return m.blobs[:min(n, len(m.blobs))], nil
+}
+
+func (m *maintenanceMock) RunGit(name string, args []string) error {
+	m.ran = append(m.ran, append([]string{name}, args...))
+	return nil
+}
+
+func newTestMaintainer(m *maintenanceMock) (*Maintainer, *bytes.Buffer) {
+	var buf bytes.Buffer
+	mt := NewMaintainer(m)
+	mt.outputWriter = &buf
+	mt.helper.outputWriter = &buf
+	return mt, &buf
+}
+
+func TestMaintainer_Run(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want [][]string
+	}{
+		{"standard", []string{"run"}, [][]string{{"gc"}}},
+		{"quick", []string{"run", "--quick"}, [][]string{{"maintenance", "run", "--task=loose-objects", "--task=incremental-repack", "--task=commit-graph"}}},
+		{"full", []string{"run", "--full"}, [][]string{{"gc", "--aggressive", "--prune=now"}, {"repack", "-a", "-d", "--write-bitmap-index"}}},
+		{"git options", []string{"run", "--task=gc", "--auto"}, [][]string{{"maintenance", "run", "--task=gc", "--auto"}}},
+		{"other subcommand", []string{"stop"}, [][]string{{"maintenance", "stop"}}},
+	}
+	for _, tt := range tests {
+		m := &maintenanceMock{stats: []git.RepoStats{{PackSize: 4 << 20}, {PackSize: 1 << 20}}}
+		mt, _ := newTestMaintainer(m)
+		mt.Maintenance(tt.args)
+		if !reflect.DeepEqual(m.ran, tt.want) {
+			t.Errorf("%s: ran %v, want %v", tt.name, m.ran, tt.want)
+		}
+	}
+}
+
+func TestMaintainer_Run_ReportsSize(t *testing.T) {
+	m := &maintenanceMock{stats: []git.RepoStats{{PackSize: 4 << 20}, {PackSize: 1 << 20}}}
+	mt, buf := newTestMaintainer(m)
+	mt.Maintenance([]string{"run"})
+	if !strings.Contains(buf.String(), "[1/1] git gc") || !strings.Contains(buf.String(), "Object store: 4.0 MiB -> 1.0 MiB") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	m = &maintenanceMock{stats: []git.RepoStats{{}}}
+	mt, buf = newTestMaintainer(m)
+	mt.Maintenance([]string{"run", "--quick", "--auto"})
+	if m.ran != nil || !strings.Contains(buf.String(), "cannot be combined") {
+		t.Errorf("ran %v, output = %q", m.ran, buf.String())
+	}
+}
+
+func TestMaintainer_Analyze(t *testing.T) {
+	m := &maintenanceMock{
+		stats: []git.RepoStats{{LooseObjects: 2400, LooseSize: 3 << 20, PackedObjects: 9000, Packs: 1, PackSize: 20 << 20, Garbage: 2, GarbageSize: 1024}},
+		blobs: []git.BlobSize{{Path: "assets/video.mp4", Size: 5 << 20}, {Path: "README.md", Size: 1536}},
+	}
+	mt, buf := newTestMaintainer(m)
+	mt.Maintenance([]string{"--analyze", "--top", "1"})
+	out := buf.String()
+	for _, want := range []string{
+		"Object store: 23.0 MiB",
+		"Packed:  9000 objects in 1 pack, 20.0 MiB",
+		"Loose:   2400 objects, 3.0 MiB",
+		"Garbage: 2 files, 1.0 KiB",
+		"5.0 MiB  assets/video.mp4",
+		"ggc maintenance run",
+		"ggc fsck",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "README.md") {
+		t.Errorf("--top 1 should list one file:\n%s", out)
+	}
+}
+
+func TestMaintainer_Start(t *testing.T) {
+	tests := []struct {
+		name      string
+		goos      string
+		installed bool
+		args      []string
+		want      string
+	}{
+		{"macOS", "darwin", false, nil, "--scheduler=launchctl"},
+		{"Windows", "windows", false, nil, "--scheduler=schtasks"},
+		{"systemd", "linux", true, nil, "--scheduler=systemd-timer"},
+		{"cron", "linux", false, nil, "--scheduler=crontab"},
+		{"flag", "linux", true, []string{"--scheduler", "crontab"}, "--scheduler=crontab"},
+	}
+	for _, tt := range tests {
+		m := &maintenanceMock{}
+		mt, buf := newTestMaintainer(m)
+		mt.goos = tt.goos
+		mt.lookPath = func(string) (string, error) {
+			if tt.installed {
+				return "/usr/bin/systemctl", nil
+			}
+			return "", exec.ErrNotFound
+		}
+		mt.Maintenance(append([]string{"start"}, tt.args...))
+		if want := [][]string{{"maintenance", "start", tt.want}}; !reflect.DeepEqual(m.ran, want) {
+			t.Errorf("%s: ran %v, want %v", tt.name, m.ran, want)
+		}
+		if !strings.Contains(buf.String(), "ggc maintenance unregister") {
+			t.Errorf("%s: output = %q", tt.name, buf.String())
+		}
+	}
+
+	m := &maintenanceMock{}
+	mt, buf := newTestMaintainer(m)
+	mt.Maintenance([]string{"start", "--scheduler=at"})
+	if m.ran != nil || !strings.Contains(buf.String(), `unknown scheduler "at"`) {
+		t.Errorf("ran %v, output = %q", m.ran, buf.String())
+	}
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// notesOps is what ggc notes needs from git.
+type notesOps interface {
+	git.NotesOps
+	git.PassthroughOps
+}
+
+// Noter handles ggc notes, which annotates commits, for instance with
+// review remarks, without changing them.
+type Noter struct {
+	gitClient     notesOps
+	outputWriter  io.Writer
+	helper        *Helper
+	configManager *config.Manager
+}
+
+// NewNoter creates a new Noter instance.
+func NewNoter(client notesOps) *Noter {
+	n := &Noter{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+	}
+	n.helper.outputWriter = n.outputWriter
+	return n
+}
+
+// withConfigManager supplies notes.ref and git.default-remote.
+func (n *Noter) withConfigManager(cm *config.Manager) *Noter {
+	n.configManager = cm
+	return n
+}
+
+// Notes adds, shows, lists and shares the notes under notes.ref. Other
+// subcommands go to git notes for that ref.
+func (n *Noter) Notes(args []string) {
+	if len(args) == 0 || args[0] == "help" {
+		n.helper.ShowNotesHelp()
+		return
+	}
+	switch args[0] {
+	case "add":
+		n.add(args[1:])
+	case "show":
+		n.show(args[1:])
+	case "list":
+		n.list()
+	case "push":
+		n.push(args[1:])
+	case "fetch":
+		n.fetch(args[1:])
+	default:
+		if err := n.gitClient.RunGit("notes", append([]string{"--ref", n.ref()}, args...)); err != nil {
+			WriteError(n.outputWriter, err)
+		}
+	}
+}
+
+// add appends -m to the note on a commit, HEAD unless one is named, or
+// opens the editor on the note when -m is not given.
+func (n *Noter) add(args []string) {
+	var message, commit string
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-m" || arg == "--message":
+			if i+1 >= len(args) {
+				WriteErrorf(n.outputWriter, "%s needs a message", arg)
+				return
+			}
+			i++
+			message = args[i]
+		case strings.HasPrefix(arg, "-") || commit != "":
+			n.helper.ShowNotesHelp()
+			return
+		default:
+			commit = arg
+		}
+	}
+	if commit == "" {
+		commit = "HEAD"
+	}
+	ref := n.ref()
+	if err := n.gitClient.EditNote(ref, commit, strings.TrimSpace(message)); err != nil {
+		WriteError(n.outputWriter, err)
+		return
+	}
+	// git drops a note left empty in the editor.
+	if text, err := n.gitClient.NoteText(ref, commit); err == nil && text == "" {
+		WriteLinef(n.outputWriter, "%s has no note under %s.", commit, ref)
+		return
+	}
+	WriteLinef(n.outputWriter, "Saved the note on %s under %s. 'ggc notes push' shares it.", commit, ref)
+}
+
+// show prints the note on a commit, HEAD unless one is named.
+func (n *Noter) show(args []string) {
+	commit := "HEAD"
+	if len(args) > 0 {
+		commit = args[0]
+	}
+	ref := n.ref()
+	text, err := n.gitClient.NoteText(ref, commit)
+	if err != nil {
+		WriteError(n.outputWriter, err)
+		return
+	}
+	if text == "" {
+		WriteLinef(n.outputWriter, "%s has no note under %s. Add one with 'ggc notes add %s'.", commit, ref, commit)
+		return
+	}
+	WriteLine(n.outputWriter, text)
+}
+
+// list prints every commit with a note and the note beneath it, the most
+// recent commit first.
+func (n *Noter) list() {
+	ref := n.ref()
+	notes, err := n.gitClient.Notes(ref)
+	if err != nil {
+		WriteError(n.outputWriter, err)
+		return
+	}
+	if len(notes) == 0 {
+		WriteLinef(n.outputWriter, "No notes under %s. Add one with 'ggc notes add [<commit>]'.", ref)
+		return
+	}
+	for _, note := range notes {
+		WriteLinef(n.outputWriter, "%s %s", note.Commit, note.Subject)
+		for _, line := range strings.Split(note.Text, "\n") {
+			WriteLine(n.outputWriter, strings.TrimRight("    "+line, " "))
+		}
+	}
+}
+
+// push sends the notes to a remote, git.default-remote unless one is
+// named.
+func (n *Noter) push(args []string) {
+	remote, ref := n.remote(args), n.ref()
+	if err := n.gitClient.PushNotes(remote, ref); err != nil {
+		WriteError(n.outputWriter, err)
+		WriteLinef(n.outputWriter, "When %s has notes you do not, 'ggc notes fetch %s' merges them in first.", remote, remote)
+		return
+	}
+	WriteLinef(n.outputWriter, "Pushed %s to %s.", ref, remote)
+}
+
+// fetch brings in a remote's notes and merges them into the local ones.
+func (n *Noter) fetch(args []string) {
+	remote, ref := n.remote(args), n.ref()
+	found, err := n.gitClient.FetchNotes(remote, ref)
+	if err != nil {
+		WriteError(n.outputWriter, err)
+		return
+	}
+	if !found {
+		WriteLinef(n.outputWriter, "%s has no notes under %s.", remote, ref)
+		return
+	}
+	WriteLinef(n.outputWriter, "Merged the notes under %s from %s.", ref, remote)
+}
+
+// ref returns the notes ref ggc notes works on.
+func (n *Noter) ref() string {
+	return notesRef(n.configManager)
+}
+
+// remote returns the remote named in args, else git.default-remote.
+func (n *Noter) remote(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return notesRemote(n.configManager)
+}
+
+// notesRef returns the full notes ref from notes.ref, refs/notes/commits
+// when it is unset.
+func notesRef(cm *config.Manager) string {
+	if cm != nil {
+		if ref := strings.TrimSpace(cm.GetConfig().Notes.Ref); ref != "" {
+			return git.NotesRef(ref)
+		}
+	}
+	return git.NotesRef("commits")
+}
+
+// notesRemote returns git.default-remote, origin when it is unset.
+func notesRemote(cm *config.Manager) string {
+	if cm != nil {
+		if r := strings.TrimSpace(cm.GetConfig().Git.DefaultRemote); r != "" {
+			return r
+		}
+	}
+	return "origin"
+}
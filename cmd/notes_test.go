@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+// notesMock keeps the notes of one ref per commit and records calls.
+type notesMock struct {
+	testutil.MockGitClient
+	notes    map[string]string
+	list     []git.Note
+	found    bool
+	pushErr  error
+	fetchErr error
+	calls    []string
+	ranGit   []string
+}
+
+func (m *notesMock) EditNote(ref, commit, message string) error {
+	m.calls = append(m.calls, "edit "+ref+" "+commit+" "+message)
+	if message != "" {
+		m.notes[commit] = strings.TrimSpace(m.notes[commit] + "\n\n" + message)
+	}
+	return nil
+}
+
+func (m *notesMock) NoteText(_, commit string) (string, error) { return m.notes[commit], nil }
+
+func (m *notesMock) Notes(ref string) ([]git.Note, error) {
+	m.calls = append(m.calls, "list "+ref)
+	return m.list, nil
+}
+
+func (m *notesMock) PushNotes(remote, ref string) error {
+	m.calls = append(m.calls, "push "+remote+" "+ref)
+	return m.pushErr
+}
+
+func (m *notesMock) FetchNotes(remote, ref string) (bool, error) {
+	m.calls = append(m.calls, "fetch "+remote+" "+ref)
+	return m.found, m.fetchErr
+}
+
+func (m *notesMock) RunGit(name string, args []string) error {
+	m.ranGit = append([]string{name}, args...)
+	return nil
+}
+
+func newTestNoter(m *notesMock, ref string) (*Noter, *bytes.Buffer) {
+	var buf bytes.Buffer
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().Notes.Ref = ref
+	n := NewNoter(m).withConfigManager(cm)
+	n.outputWriter = &buf
+	n.helper.outputWriter = &buf
+	return n, &buf
+}
+
+func TestNoter_Add(t *testing.T) {
+	m := &notesMock{notes: map[string]string{"HEAD~2": "needs a test"}}
+	n, buf := newTestNoter(m, "review")
+	n.Notes([]string{"add", "HEAD~2", "-m", "and docs"})
+	if m.notes["HEAD~2"] != "needs a test\n\nand docs" {
+		t.Errorf("note = %q, want the message appended", m.notes["HEAD~2"])
+	}
+	if !strings.Contains(buf.String(), "Saved the note on HEAD~2 under refs/notes/review") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	// Without -m the editor opens on HEAD's note; git drops it when it is
+	// left empty.
+	buf.Reset()
+	n.Notes([]string{"add"})
+	if got := m.calls[len(m.calls)-1]; got != "edit refs/notes/review HEAD " {
+		t.Errorf("last call = %q, want the editor on HEAD", got)
+	}
+	if !strings.Contains(buf.String(), "HEAD has no note under refs/notes/review") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	buf.Reset()
+	n.Notes([]string{"add", "-m"})
+	if !strings.Contains(buf.String(), "-m needs a message") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestNoter_ShowAndList(t *testing.T) {
+	m := &notesMock{
+		notes: map[string]string{"abc123": "LGTM"},
+		list: []git.Note{
+			{Commit: "abc123", Subject: "Add notes", Text: "LGTM"},
+			{Commit: "def456", Subject: "Fix log", Text: "needs a test\n\nand docs"},
+		},
+	}
+	n, buf := newTestNoter(m, "")
+	n.Notes([]string{"show", "abc123"})
+	if buf.String() != "LGTM\n" {
+		t.Errorf("show = %q", buf.String())
+	}
+	buf.Reset()
+	n.Notes([]string{"show"})
+	if !strings.Contains(buf.String(), "HEAD has no note under refs/notes/commits") {
+		t.Errorf("show = %q", buf.String())
+	}
+
+	buf.Reset()
+	n.Notes([]string{"list"})
+	want := "abc123 Add notes\n    LGTM\ndef456 Fix log\n    needs a test\n\n    and docs\n"
+	if buf.String() != want {
+		t.Errorf("list = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestNoter_PushAndFetch(t *testing.T) {
+	m := &notesMock{found: true}
+	n, buf := newTestNoter(m, "refs/notes/review")
+	n.configManager.GetConfig().Git.DefaultRemote = "upstream"
+	n.Notes([]string{"push"})
+	n.Notes([]string{"fetch", "origin"})
+	want := []string{"push upstream refs/notes/review", "fetch origin refs/notes/review"}
+	if !slices.Equal(m.calls, want) {
+		t.Errorf("calls = %v, want %v", m.calls, want)
+	}
+	if !strings.Contains(buf.String(), "Merged the notes under refs/notes/review from origin") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	m.found, m.pushErr = false, errors.New("rejected")
+	buf.Reset()
+	n.Notes([]string{"fetch"})
+	n.Notes([]string{"push"})
+	out := buf.String()
+	if !strings.Contains(out, "upstream has no notes under refs/notes/review") ||
+		!strings.Contains(out, "'ggc notes fetch upstream' merges them in first") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestNoter_OtherSubcommandsGoToGit(t *testing.T) {
+	m := &notesMock{}
+	n, _ := newTestNoter(m, "review")
+	n.Notes([]string{"remove", "HEAD"})
+	want := []string{"notes", "--ref", "refs/notes/review", "remove", "HEAD"}
+	if !slices.Equal(m.ranGit, want) {
+		t.Errorf("ran %v, want %v", m.ranGit, want)
+	}
+}
+
+func TestFetcher_Fetch_AutoFetchNotes(t *testing.T) {
+	for _, autoFetch := range []bool{false, true} {
+		m := &notesMock{found: true}
+		cm := config.NewConfigManager(testutil.NewMockGitClient())
+		cm.GetConfig().Notes.AutoFetch = autoFetch
+		var buf bytes.Buffer
+		f := NewFetcher(&mockAddGitClient{}).withNotes(m, cm)
+		f.outputWriter = &buf
+		f.Fetch([]string{"prune"})
+		if got := len(m.calls) == 1 && m.calls[0] == "fetch origin refs/notes/commits"; got != autoFetch {
+			t.Errorf("auto-fetch %v: calls = %v", autoFetch, m.calls)
+		}
+		if got := strings.Contains(buf.String(), "Merged the notes"); got != autoFetch {
+			t.Errorf("auto-fetch %v: output = %q", autoFetch, buf.String())
+		}
+	}
+}
@@ -6,14 +6,22 @@ import (
 	"io"
 )
 
-// WriteError writes an error message to the writer
+// WriteError writes an error message to the writer, followed by the next
+// step to take when ggc recognizes the error. The error also becomes the
+// command's failure, which sets the exit code.
 func WriteError(w io.Writer, err error) {
 	_, _ = fmt.Fprintf(w, "Error: %v\n", err)
+	if s := Suggestion(err); s != "" {
+		_, _ = fmt.Fprintf(w, "  Next: %s\n", s)
+	}
+	recordFailure(err)
 }
 
-// WriteErrorf writes a formatted error message to the writer
+// WriteErrorf writes a formatted error message to the writer and records
+// it as the command's failure.
 func WriteErrorf(w io.Writer, format string, args ...any) {
-	_, _ = fmt.Fprintf(w, "Error: "+format+"\n", args...)
+	err := fmt.Errorf(format, args...)
+	WriteError(w, err)
 }
 
 // WriteLine writes a line to the writer
@@ -47,17 +47,11 @@ func (p *passthroughCommand) Run(args []string) {
 // matching registry entry, automatically wires it into the router.
 var passthroughCommandNames = []string{
 	// Tier 1
-	"switch",
 	"checkout",
 	"merge",
-	"cherry-pick",
-	"revert",
-	"blame",
 	// Tier 2
 	"worktree",
 	"reflog",
-	"format-patch",
-	"am",
 	"sparse-checkout",
 	"mv",
 	"rm",
@@ -65,11 +59,8 @@ var passthroughCommandNames = []string{
 	// Tier 3
 	"describe",
 	"range-diff",
-	"grep",
-	"notes",
 	"archive",
 	"shortlog",
-	"maintenance",
 	"gc",
 	"fsck",
 	"prune",
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// amRecovery follows a git am that stopped on a patch.
+const amRecovery = "The patch did not apply. Resolve the conflicts, 'ggc add' the files and run 'ggc am continue';\n" +
+	"'ggc am skip' drops the patch and 'ggc am abort' puts the branch back."
+
+// patchExtensions are the files ggc am and ggc apply take from a
+// directory: what git format-patch writes and what mail clients save.
+var patchExtensions = []string{".patch", ".diff", ".mbox", ".eml"}
+
+// patchOps are the git operations behind ggc format-patch, am and apply.
+type patchOps interface {
+	git.PassthroughOps
+	git.CommitLister
+	GetCurrentBranch() (string, error)
+	GetUpstreamBranch(branch string) (string, error)
+}
+
+// Patcher handles ggc format-patch, ggc am and ggc apply, which send and
+// take in commits as patch files.
+type Patcher struct {
+	gitClient     patchOps
+	outputWriter  io.Writer
+	helper        *Helper
+	selectMany    multiSelector // nil: the commits are listed instead
+	configManager *config.Manager
+}
+
+// NewPatcher creates a new Patcher instance.
+func NewPatcher(client patchOps) *Patcher {
+	p := &Patcher{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+	}
+	p.helper.outputWriter = p.outputWriter
+	return p
+}
+
+// withMultiSelect lets ggc format-patch select choose commits in the
+// full-screen multi-select picker.
+func (p *Patcher) withMultiSelect(sel multiSelector) *Patcher {
+	p.selectMany = sel
+	return p
+}
+
+// withConfigManager supplies default.branch, the base ggc format-patch
+// select falls back to when the branch has no upstream.
+func (p *Patcher) withConfigManager(cm *config.Manager) *Patcher {
+	p.configManager = cm
+	return p
+}
+
+// FormatPatch writes commits as patch files. select lets the user choose
+// the commits; anything else goes to git format-patch unchanged.
+func (p *Patcher) FormatPatch(args []string) {
+	if len(args) == 0 || args[0] == "help" {
+		p.helper.ShowFormatPatchHelp()
+		return
+	}
+	if args[0] == "select" {
+		p.selectCommits(args[1:])
+		return
+	}
+	if err := p.gitClient.RunGit("format-patch", args); err != nil {
+		WriteError(p.outputWriter, err)
+	}
+}
+
+// selectCommits lists the commits the current branch has over its base,
+// oldest first, and writes the chosen ones as a numbered series. The base
+// is the first argument when it is not an option, else the upstream, else
+// default.branch. The other arguments go to git format-patch.
+func (p *Patcher) selectCommits(args []string) {
+	var base string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		base, args = args[0], args[1:]
+	}
+	if base == "" {
+		base = p.defaultBase()
+	}
+	commits, err := p.gitClient.ListCommits("--reverse", "--no-merges", base+"..HEAD")
+	if err != nil {
+		WriteError(p.outputWriter, err)
+		return
+	}
+	if len(commits) == 0 {
+		WriteLinef(p.outputWriter, "The current branch has no commits that are not on %s.", base)
+		return
+	}
+	labels := make([]string, len(commits))
+	for i, commit := range commits {
+		labels[i] = fmt.Sprintf("%s %s (%s, %s)", commit.Short, commit.Subject, commit.Author, commit.Date)
+	}
+	if p.selectMany == nil {
+		WriteLinef(p.outputWriter, "Commits since %s, oldest first:", base)
+		for _, label := range labels {
+			WriteLine(p.outputWriter, "  "+label)
+		}
+		WriteLinef(p.outputWriter, "Write them with 'ggc format-patch %s..HEAD', or one with 'ggc format-patch -1 <commit>'.", base)
+		return
+	}
+	selected, ok, err := p.selectMany(fmt.Sprintf("Patches from commits since %s", base), labels)
+	if err != nil {
+		WriteError(p.outputWriter, err)
+		return
+	}
+	if !ok || len(selected) == 0 {
+		WriteLine(p.outputWriter, "Canceled.")
+		return
+	}
+	// With --no-walk=unsorted, git format-patch numbers the commits in the
+	// reverse of the order given, so newest first makes 0001 the oldest.
+	var hashes []string
+	for i := len(labels) - 1; i >= 0; i-- {
+		if slices.Contains(selected, labels[i]) {
+			hashes = append(hashes, commits[i].Hash)
+		}
+	}
+	formatArgs := append(append([]string{}, args...), "--no-walk=unsorted")
+	if err := p.gitClient.RunGit("format-patch", append(formatArgs, hashes...)); err != nil {
+		WriteError(p.outputWriter, err)
+	}
+}
+
+// defaultBase is the upstream of the current branch, or default.branch
+// when it has none.
+func (p *Patcher) defaultBase() string {
+	if branch, err := p.gitClient.GetCurrentBranch(); err == nil {
+		if upstream, err := p.gitClient.GetUpstreamBranch(branch); err == nil && upstream != "" {
+			return upstream
+		}
+	}
+	if p.configManager != nil && p.configManager.GetConfig().Default.Branch != "" {
+		return p.configManager.GetConfig().Default.Branch
+	}
+	return "main"
+}
+
+// AM applies a series of patches from mailboxes or patch files as
+// commits, with their authors and messages. It falls back to a three-way
+// merge unless --no-3way is given, and takes the patch files of a
+// directory in name order.
+func (p *Patcher) AM(args []string) {
+	if len(args) == 0 || args[0] == "help" {
+		p.helper.ShowAMHelp()
+		return
+	}
+	switch args[0] {
+	case "continue", "skip", "abort":
+		if len(args) != 1 {
+			p.helper.ShowAMHelp()
+			return
+		}
+		p.runAM("--" + args[0])
+		return
+	}
+	if !slices.ContainsFunc(args, isAMModeFlag) {
+		args = append([]string{"--3way"}, args...)
+	}
+	files, err := expandPatchDirs(args)
+	if err != nil {
+		WriteError(p.outputWriter, err)
+		return
+	}
+	p.runAM(files...)
+}
+
+// isAMModeFlag reports options that make ggc am leave --3way out: the
+// three-way options themselves and those that resume a stopped git am.
+func isAMModeFlag(arg string) bool {
+	switch arg {
+	case "-3", "--3way", "--no-3way", "--continue", "--resolved", "-r", "--skip", "--abort", "--quit", "--show-current-patch":
+		return true
+	}
+	return false
+}
+
+// runAM hands args to git am and explains how to go on when it stops.
+func (p *Patcher) runAM(args ...string) {
+	if err := p.gitClient.RunGit("am", args); err != nil {
+		WriteError(p.outputWriter, err)
+		if args[0] != "--abort" {
+			WriteLine(p.outputWriter, amRecovery)
+		}
+	}
+}
+
+// Apply applies patch files to the working tree without committing. When
+// one does not apply as it is, it is tried again as a three-way merge,
+// which needs the blobs the patch was made against and stages the result.
+func (p *Patcher) Apply(args []string) {
+	if len(args) == 0 || args[0] == "help" {
+		p.helper.ShowApplyHelp()
+		return
+	}
+	files, err := expandPatchDirs(args)
+	if err != nil {
+		WriteError(p.outputWriter, err)
+		return
+	}
+	if slices.ContainsFunc(files, isApplyModeFlag) {
+		if err := p.gitClient.RunGit("apply", files); err != nil {
+			WriteError(p.outputWriter, err)
+		}
+		return
+	}
+	// A plain apply either applies every hunk or none, so a failure leaves
+	// the tree as it was for the second try.
+	if p.gitClient.RunGit("apply", files) == nil {
+		WriteLine(p.outputWriter, "Applied; the changes are in the working tree, unstaged.")
+		return
+	}
+	WriteLine(p.outputWriter, "The patch does not apply as it is; trying a three-way merge.")
+	if err := p.gitClient.RunGit("apply", append([]string{"--3way"}, files...)); err != nil {
+		WriteError(p.outputWriter, err)
+		WriteLine(p.outputWriter, "Resolve the conflicts marked in the files and 'ggc add' them, or 'ggc restore' them to start over.")
+		return
+	}
+	WriteLine(p.outputWriter, "Applied with a three-way merge; the changes are staged.")
+}
+
+// isApplyModeFlag reports options that make ggc apply leave the
+// three-way fallback alone: an explicit --3way, or a mode that only
+// reports on the patch.
+func isApplyModeFlag(arg string) bool {
+	switch arg {
+	case "-3", "--3way", "--check", "--stat", "--numstat", "--summary":
+		return true
+	}
+	return false
+}
+
+// expandPatchDirs replaces each directory in args with the patch files in
+// it, sorted by name so a format-patch series applies in order.
+func expandPatchDirs(args []string) ([]string, error) {
+	var out []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if strings.HasPrefix(arg, "-") || err != nil || !info.IsDir() {
+			out = append(out, arg)
+			continue
+		}
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, err
+		}
+		var found []string
+		for _, e := range entries {
+			if !e.IsDir() && slices.Contains(patchExtensions, strings.ToLower(filepath.Ext(e.Name()))) {
+				found = append(found, filepath.Join(arg, e.Name()))
+			}
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("no patch files (%s) in %s", strings.Join(patchExtensions, ", "), arg)
+		}
+		out = append(out, found...)
+	}
+	return out, nil
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockPatchClient struct {
+	testutil.MockGitClient
+	commits  []git.CommitSummary
+	upstream string
+	listed   string
+	fail     map[string]bool // git command lines that fail
+	calls    []string
+}
+
+func (m *mockPatchClient) GetCurrentBranch() (string, error) { return "topic", nil }
+func (m *mockPatchClient) GetUpstreamBranch(string) (string, error) {
+	if m.upstream == "" {
+		return "", errors.New("no upstream")
+	}
+	return m.upstream, nil
+}
+
+func (m *mockPatchClient) ListCommits(revs ...string) ([]git.CommitSummary, error) {
+	m.listed = strings.Join(revs, " ")
+	return m.commits, nil
+}
+
+func (m *mockPatchClient) RunGit(name string, args []string) error {
+	call := strings.TrimSpace(name + " " + strings.Join(args, " "))
+	m.calls = append(m.calls, call)
+	if m.fail[call] {
+		return errors.New("git failed")
+	}
+	return nil
+}
+
+func newTestPatcher(m *mockPatchClient) (*Patcher, *bytes.Buffer) {
+	var buf bytes.Buffer
+	p := NewPatcher(m)
+	p.outputWriter = &buf
+	p.helper.outputWriter = &buf
+	return p, &buf
+}
+
+func TestPatcher_FormatPatchSelect(t *testing.T) {
+	commits := []git.CommitSummary{
+		{Hash: "aaa111", Short: "aaa111", Subject: "Add parser"},
+		{Hash: "bbb222", Short: "bbb222", Subject: "Debug logging"},
+		{Hash: "ccc333", Short: "ccc333", Subject: "Use parser"},
+	}
+	m := &mockPatchClient{commits: commits, upstream: "origin/topic"}
+	p, _ := newTestPatcher(m)
+	p.withMultiSelect(func(_ string, items []string) ([]string, bool, error) {
+		// Ticked newest first; the series still starts with the oldest.
+		return []string{items[2], items[0]}, true, nil
+	})
+	p.FormatPatch([]string{"select", "-o", "out", "--cover-letter"})
+	if m.listed != "--reverse --no-merges origin/topic..HEAD" {
+		t.Errorf("listed %q", m.listed)
+	}
+	want := []string{"format-patch -o out --cover-letter --no-walk=unsorted ccc333 aaa111"}
+	if !slices.Equal(m.calls, want) {
+		t.Errorf("calls = %q, want %q", m.calls, want)
+	}
+
+	m = &mockPatchClient{commits: commits}
+	p, buf := newTestPatcher(m)
+	p.FormatPatch([]string{"select", "develop"})
+	if m.listed != "--reverse --no-merges develop..HEAD" || len(m.calls) != 0 {
+		t.Errorf("listed %q, calls %q", m.listed, m.calls)
+	}
+	if !strings.Contains(buf.String(), "ggc format-patch develop..HEAD") {
+		t.Errorf("without a picker the commits should be listed:\n%s", buf.String())
+	}
+
+	m = &mockPatchClient{}
+	p, _ = newTestPatcher(m)
+	p.FormatPatch([]string{"select"})
+	if m.listed != "--reverse --no-merges main..HEAD" {
+		t.Errorf("without an upstream the base should be default.branch: %q", m.listed)
+	}
+}
+
+func TestPatcher_AM(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"0002-b.patch", "0001-a.patch", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"three-way by default", []string{"fix.patch"}, "am --3way fix.patch"},
+		{"directory", []string{"-s", dir}, "am --3way -s " + filepath.Join(dir, "0001-a.patch") + " " + filepath.Join(dir, "0002-b.patch")},
+		{"--no-3way", []string{"--no-3way", "fix.patch"}, "am --no-3way fix.patch"},
+		{"continue", []string{"continue"}, "am --continue"},
+		{"--abort", []string{"--abort"}, "am --abort"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockPatchClient{}
+			p, _ := newTestPatcher(m)
+			p.AM(tt.args)
+			if !slices.Equal(m.calls, []string{tt.want}) {
+				t.Errorf("calls = %q, want %q", m.calls, tt.want)
+			}
+		})
+	}
+
+	m := &mockPatchClient{fail: map[string]bool{"am --3way fix.patch": true}}
+	p, buf := newTestPatcher(m)
+	p.AM([]string{"fix.patch"})
+	if !strings.Contains(buf.String(), "ggc am continue") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestPatcher_Apply(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		fail      []string
+		wantCalls []string
+		wantOut   string
+	}{
+		{"applies", []string{"fix.diff"}, nil, []string{"apply fix.diff"}, "unstaged"},
+		{"three-way fallback", []string{"fix.diff"}, []string{"apply fix.diff"}, []string{"apply fix.diff", "apply --3way fix.diff"}, "three-way merge; the changes are staged"},
+		{"fallback conflicts", []string{"fix.diff"}, []string{"apply fix.diff", "apply --3way fix.diff"}, []string{"apply fix.diff", "apply --3way fix.diff"}, "Resolve the conflicts"},
+		{"--check has no fallback", []string{"--check", "fix.diff"}, []string{"apply --check fix.diff"}, []string{"apply --check fix.diff"}, "git failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockPatchClient{fail: map[string]bool{}}
+			for _, call := range tt.fail {
+				m.fail[call] = true
+			}
+			p, buf := newTestPatcher(m)
+			p.Apply(tt.args)
+			if !slices.Equal(m.calls, tt.wantCalls) {
+				t.Errorf("calls = %q, want %q", m.calls, tt.wantCalls)
+			}
+			if !strings.Contains(buf.String(), tt.wantOut) {
+				t.Errorf("output lacks %q: %q", tt.wantOut, buf.String())
+			}
+		})
+	}
+
+	p, buf := newTestPatcher(&mockPatchClient{})
+	p.Apply([]string{t.TempDir()})
+	if !strings.Contains(buf.String(), "no patch files") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
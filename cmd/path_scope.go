@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+)
+
+// pathScope limits status, diff, add, log and clean to one directory of
+// the repository, set by ggc --path or core.default-pathspec. The commands
+// share one scope, so setting it after NewCmd reaches all of them.
+type pathScope struct {
+	dir string // relative to the repository root; "" is the whole repository
+}
+
+// newPathScope returns the scope of core.default-pathspec, a directory
+// relative to the repository root.
+func newPathScope(dir string) *pathScope {
+	return &pathScope{dir: cleanScopeDir(dir)}
+}
+
+// cleanScopeDir normalizes a directory relative to the repository root.
+func cleanScopeDir(dir string) string {
+	dir = path.Clean(filepath.ToSlash(strings.TrimSpace(dir)))
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	return strings.Trim(dir, "/")
+}
+
+// pathspecs returns the pathspec of the scope, nil when it is the whole
+// repository. :(top) makes it independent of the working directory.
+func (p *pathScope) pathspecs() []string {
+	if p == nil || p.dir == "" {
+		return nil
+	}
+	return []string{":(top)" + p.dir}
+}
+
+// contains reports whether path, relative to the repository root, is
+// inside the scope.
+func (p *pathScope) contains(path string) bool {
+	return p == nil || p.dir == "" || path == p.dir || strings.HasPrefix(path, p.dir+"/")
+}
+
+// describe returns the scope for messages, "" for the whole repository.
+func (p *pathScope) describe() string {
+	if p == nil || p.dir == "" {
+		return ""
+	}
+	return p.dir + "/"
+}
+
+// SetPathScope limits status, diff, add, log and clean to dir, a directory
+// relative to the working directory, for ggc --path. "." at the
+// repository root, or the root itself, lifts the limit.
+func (c *Cmd) SetPathScope(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("--path %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("--path %s: not a directory", dir)
+	}
+	reader, ok := c.gitClient.(interface{ TopLevel() (string, error) })
+	if !ok {
+		return errors.New("--path needs a git repository")
+	}
+	top, err := reader.TopLevel()
+	if err != nil {
+		return err
+	}
+	rel, err := repoRelative(top, dir)
+	if err != nil {
+		return fmt.Errorf("--path %s: %w", dir, err)
+	}
+	c.scope.dir = rel
+	return nil
+}
+
+// repoRelative returns dir relative to the repository root top, resolving
+// symbolic links on both so a linked checkout still matches.
+func repoRelative(top, dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(top); err == nil {
+		top = resolved
+	}
+	rel, err := filepath.Rel(top, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.New("not inside the repository")
+	}
+	return cleanScopeDir(rel), nil
+}
+
+// PickPathScope lets the user choose the directory that status, diff, add,
+// log and clean are limited to, for ggc --pick-path. ok is false when the
+// user cancels.
+func (c *Cmd) PickPathScope() (ok bool, err error) {
+	pick := newPicker(c.configManager)
+	if pick == nil {
+		return false, errors.New("--pick-path needs an interactive terminal; use --path <dir> instead")
+	}
+	lister, isLister := c.gitClient.(interface{ TreeDirectories() ([]string, error) })
+	if !isLister {
+		return false, errors.New("--pick-path needs a git repository")
+	}
+	dirs, err := lister.TreeDirectories()
+	if err != nil {
+		return false, err
+	}
+	items := make([]interactive.PickItem, 0, len(dirs)+1)
+	items = append(items, interactive.PickItem{Value: ".", Detail: "the whole repository"})
+	for _, dir := range dirs {
+		items = append(items, interactive.PickItem{Value: dir + "/"})
+	}
+	chosen, ok, err := pick("Limit ggc to", items, c.scope.describe())
+	if err != nil || !ok {
+		return false, err
+	}
+	c.scope.dir = cleanScopeDir(chosen)
+	return true, nil
+}
+
+// scopedExplorerSource shows the file explorer only the changes inside the
+// scope.
+type scopedExplorerSource struct {
+	interactive.ExplorerSource
+	scope *pathScope
+}
+
+// StatusSummary drops the entries outside the scope.
+func (s scopedExplorerSource) StatusSummary() (*git.StatusSummary, error) {
+	summary, err := s.ExplorerSource.StatusSummary()
+	if err != nil || s.scope.describe() == "" {
+		return summary, err
+	}
+	scoped := *summary
+	scoped.Entries = nil
+	for _, e := range summary.Entries {
+		if s.scope.contains(e.Path) {
+			scoped.Entries = append(scoped.Entries, e)
+		}
+	}
+	return &scoped, nil
+}
+
+// LFSFiles passes the Git LFS listing through when the source has one.
+func (s scopedExplorerSource) LFSFiles() ([]string, error) {
+	if lister, ok := s.ExplorerSource.(interface{ LFSFiles() ([]string, error) }); ok {
+		return lister.LFSFiles()
+	}
+	return nil, nil
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+func TestPathScope(t *testing.T) {
+	tests := []struct {
+		dir       string
+		pathspecs []string
+		describe  string
+	}{
+		{"", nil, ""},
+		{".", nil, ""},
+		{"apps/web/", []string{":(top)apps/web"}, "apps/web/"},
+		{"./apps//web", []string{":(top)apps/web"}, "apps/web/"},
+	}
+	for _, tt := range tests {
+		p := newPathScope(tt.dir)
+		if got := p.pathspecs(); !slices.Equal(got, tt.pathspecs) {
+			t.Errorf("newPathScope(%q).pathspecs() = %v, want %v", tt.dir, got, tt.pathspecs)
+		}
+		if got := p.describe(); got != tt.describe {
+			t.Errorf("newPathScope(%q).describe() = %q, want %q", tt.dir, got, tt.describe)
+		}
+	}
+
+	p := newPathScope("apps/web")
+	for path, want := range map[string]bool{"apps/web": true, "apps/web/main.go": true, "apps/webapp/x": false, "README.md": false} {
+		if got := p.contains(path); got != want {
+			t.Errorf("contains(%q) = %v, want %v", path, got, want)
+		}
+	}
+	var none *pathScope
+	if none.pathspecs() != nil || !none.contains("anything") {
+		t.Error("a nil scope should cover the whole repository")
+	}
+}
+
+func TestRepoRelative(t *testing.T) {
+	top := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(top, "apps", "web"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := repoRelative(top, filepath.Join(top, "apps", "web")); err != nil || got != "apps/web" {
+		t.Errorf("repoRelative() = %q, %v", got, err)
+	}
+	if got, err := repoRelative(top, top); err != nil || got != "" {
+		t.Errorf("repoRelative(top) = %q, %v", got, err)
+	}
+	if _, err := repoRelative(filepath.Join(top, "apps"), top); err == nil {
+		t.Error("a directory above the repository should be rejected")
+	}
+}
+
+func TestStatuser_Status_PathScope(t *testing.T) {
+	var buf bytes.Buffer
+	mock := &mockStatusInfoReader{}
+	s := NewStatuser(mock).withPathScope(newPathScope("apps/web"))
+	s.outputWriter = &buf
+
+	s.Status(nil)
+	if !slices.Equal(mock.paths, []string{":(top)apps/web"}) {
+		t.Errorf("status paths = %v", mock.paths)
+	}
+	if !strings.Contains(buf.String(), "Showing changes under apps/web/ only") {
+		t.Errorf("output does not name the scope:\n%s", buf.String())
+	}
+
+	s.Status([]string{"short"})
+	if !slices.Equal(mock.paths, []string{":(top)apps/web"}) {
+		t.Errorf("status short paths = %v", mock.paths)
+	}
+}
+
+func TestCleaner_Clean_PathScope(t *testing.T) {
+	var buf bytes.Buffer
+	mock := &mockCleanGitClient{}
+	c := &Cleaner{
+		gitClient:    mock,
+		outputWriter: &buf,
+		helper:       NewHelper(),
+		confirm:      ui.NewConfirmer(prompt.New(strings.NewReader("y\n"), &buf), true, ui.ConfirmSimple),
+		scope:        newPathScope("apps/web"),
+	}
+
+	c.Clean([]string{"files"})
+	if !mock.cleanFilesCalled || !slices.Equal(mock.scopePaths, []string{":(top)apps/web"}) {
+		t.Errorf("CleanFiles called = %v with %v", mock.cleanFilesCalled, mock.scopePaths)
+	}
+	if !strings.Contains(buf.String(), "Delete every untracked file under apps/web/?") {
+		t.Errorf("question does not name the scope:\n%s", buf.String())
+	}
+}
+
+type stubExplorerSource struct {
+	interactive.ExplorerSource
+	summary *git.StatusSummary
+}
+
+func (s stubExplorerSource) StatusSummary() (*git.StatusSummary, error) { return s.summary, nil }
+
+func TestScopedExplorerSource(t *testing.T) {
+	src := stubExplorerSource{summary: &git.StatusSummary{Branch: "main", Entries: []git.StatusEntry{
+		{Kind: git.StatusOrdinary, Path: "apps/web/main.go"},
+		{Kind: git.StatusUntracked, Path: "apps/api/main.go"},
+		{Kind: git.StatusOrdinary, Path: "README.md"},
+	}}}
+
+	summary, err := scopedExplorerSource{src, newPathScope("apps/web")}.StatusSummary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.Entries) != 1 || summary.Entries[0].Path != "apps/web/main.go" || summary.Branch != "main" {
+		t.Errorf("scoped summary = %+v", summary)
+	}
+	if len(src.summary.Entries) != 3 {
+		t.Error("the source's summary was modified")
+	}
+
+	summary, _ = scopedExplorerSource{src, newPathScope("")}.StatusSummary()
+	if len(summary.Entries) != 3 {
+		t.Errorf("without a scope every entry should stay, got %d", len(summary.Entries))
+	}
+}
+
+func TestDiffer_Diff_PathScope(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{nil, []string{"HEAD", "--", ":(top)apps/web"}},
+		{[]string{"staged", "--", "README.md"}, []string{"--staged", "--", "README.md"}},
+	}
+	for _, tt := range tests {
+		mock := &mockDiffClient{}
+		d := NewDiffer(mock).withPathScope(newPathScope("apps/web"))
+		d.outputWriter = &bytes.Buffer{}
+		d.Diff(tt.args)
+		if !slices.Equal(mock.diffArgs, tt.want) {
+			t.Errorf("Diff(%v) args = %v, want %v", tt.args, mock.diffArgs, tt.want)
+		}
+	}
+}
@@ -0,0 +1,456 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/commitmsg"
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/hosting"
+	"github.com/bmf-san/ggc/v8/internal/ticket"
+)
+
+// prGitClient is the git surface `ggc pr` needs.
+type prGitClient interface {
+	git.BranchReader
+	CheckoutBranch(name string) error
+	git.CommitMessageReader
+	git.RemoteURLReader
+	git.UpstreamPusher
+	git.RefspecFetcher
+}
+
+// PullRequester provides the pr command (also available as mr). It works
+// with GitHub, GitLab and Gitea, picking the service from the URL of the
+// default remote.
+type PullRequester struct {
+	gitClient     prGitClient
+	outputWriter  io.Writer
+	helper        *Helper
+	configManager *config.Manager
+	getenv        func(string) string
+	gate          *pushGate // nil pushes without the shared checks
+}
+
+// NewPullRequester creates a new PullRequester.
+func NewPullRequester(client prGitClient) *PullRequester {
+	p := &PullRequester{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+		getenv:       os.Getenv,
+	}
+	p.helper.outputWriter = p.outputWriter
+	return p
+}
+
+// withConfigManager supplies the default remote and the integration
+// settings, and lets a device-flow token be saved.
+func (p *PullRequester) withConfigManager(cm *config.Manager) *PullRequester {
+	p.configManager = cm
+	return p
+}
+
+// withPushGate runs the checks shared by every command that pushes before
+// ggc pr create pushes the branch.
+func (p *PullRequester) withPushGate(g *pushGate) *PullRequester {
+	p.gate = g
+	return p
+}
+
+// PR executes the pr command with the given arguments.
+func (p *PullRequester) PR(args []string) {
+	if len(args) == 0 {
+		p.helper.ShowPRHelp()
+		return
+	}
+
+	var err error
+	switch args[0] {
+	case "create":
+		err = p.create(args[1:])
+	case "list", "ls":
+		err = p.list(args[1:])
+	case "checkout", "co":
+		err = p.checkout(args[1:])
+	default:
+		p.helper.ShowPRHelp()
+		return
+	}
+	if err != nil {
+		WriteError(p.outputWriter, err)
+	}
+}
+
+// prCreateArgs are the parsed flags of `ggc pr create`.
+type prCreateArgs struct {
+	base  string
+	title string
+	body  string
+	draft bool
+	gate  []string // the flags for the push gate
+}
+
+func parsePRCreateArgs(args []string) (prCreateArgs, error) {
+	var pa prCreateArgs
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--draft" {
+			pa.draft = true
+			continue
+		}
+		if isPushGateFlag(arg) {
+			pa.gate = append(pa.gate, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(arg, "=")
+		var target *string
+		switch name {
+		case "--base":
+			target = &pa.base
+		case "--title":
+			target = &pa.title
+		case "--body":
+			target = &pa.body
+		default:
+			return pa, fmt.Errorf("unknown argument %q", arg)
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return pa, fmt.Errorf("%s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		*target = value
+	}
+	return pa, nil
+}
+
+// create pushes the current branch and opens a pull request (a merge
+// request on GitLab) for it. The title and body default to the commits on
+// the branch; tickets.pr-title adds the branch's ticket to the title.
+func (p *PullRequester) create(args []string) error {
+	pa, err := parsePRCreateArgs(args)
+	if err != nil {
+		return err
+	}
+	branch, err := p.gitClient.GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+	if branch == "HEAD" {
+		return fmt.Errorf("cannot open a pull request from a detached HEAD")
+	}
+	if err := p.gate.check(p.outputWriter, pa.gate); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	remote := p.remote()
+	provider, err := p.provider(ctx, remote)
+	if err != nil {
+		return err
+	}
+	if pa.base == "" {
+		if pa.base, err = provider.DefaultBranch(ctx); err != nil {
+			return err
+		}
+	}
+	if pa.base == branch {
+		return fmt.Errorf("branch %q is the base branch; switch to a feature branch first", branch)
+	}
+
+	if pa.title == "" {
+		messages, err := p.branchCommits(remote, pa.base)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return fmt.Errorf("no commits on %s that are not on %s", branch, pa.base)
+		}
+		title, body := describeCommits(messages)
+		pa.title = title
+		if pa.body == "" {
+			pa.body = body
+		}
+	}
+
+	if cfg := p.config(); cfg != nil && cfg.Tickets.PRTitle != "" {
+		if t, ok := branchTicket(cfg, branch); ok {
+			pa.title = ticket.Apply(pa.title, t, ticket.Placement(cfg.Tickets.PRTitle))
+		}
+	}
+
+	if err := p.gitClient.PushSetUpstream(remote, branch); err != nil {
+		return err
+	}
+	pr, err := provider.CreatePullRequest(ctx, hosting.NewPullRequest{
+		Title: pa.title,
+		Head:  branch,
+		Base:  pa.base,
+		Body:  pa.body,
+		Draft: pa.draft,
+	})
+	if err != nil {
+		return err
+	}
+	kind := provider.Kind()
+	WriteLinef(p.outputWriter, "Created %s %s: %s", kind.Noun(), kind.Ref(pr.Number), pr.Title)
+	WriteLine(p.outputWriter, pr.URL)
+	return nil
+}
+
+// branchCommits returns the commits on HEAD that are not on base, newest
+// first, comparing against the remote-tracking branch when there is one.
+func (p *PullRequester) branchCommits(remote, base string) ([]git.CommitMessage, error) {
+	ref := remote + "/" + base
+	if !p.gitClient.RevParseVerify("refs/remotes/" + ref) {
+		ref = base
+	}
+	return p.gitClient.CommitMessages(ref + "..HEAD")
+}
+
+// describeCommits derives a pull request title and body from commits
+// (newest first): a single commit supplies both, several commits give the
+// oldest subject as the title and a list of every subject as the body.
+func describeCommits(messages []git.CommitMessage) (string, string) {
+	if len(messages) == 1 {
+		m := commitmsg.Parse(messages[0].Message)
+		return m.Header(), m.Body
+	}
+	var b strings.Builder
+	for i := len(messages) - 1; i >= 0; i-- {
+		header, _, _ := strings.Cut(strings.TrimSpace(messages[i].Message), "\n")
+		fmt.Fprintf(&b, "- %s\n", header)
+	}
+	title, _, _ := strings.Cut(strings.TrimSpace(messages[len(messages)-1].Message), "\n")
+	return title, strings.TrimSuffix(b.String(), "\n")
+}
+
+// list prints the pull requests of the repository.
+func (p *PullRequester) list(args []string) error {
+	state := "open"
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--state" && i+1 < len(args):
+			i++
+			state = args[i]
+		case strings.HasPrefix(args[i], "--state="):
+			state = strings.TrimPrefix(args[i], "--state=")
+		default:
+			return fmt.Errorf("unknown argument %q", args[i])
+		}
+	}
+	if state != "open" && state != "closed" && state != "all" {
+		return fmt.Errorf("--state must be open, closed or all")
+	}
+
+	ctx := context.Background()
+	provider, err := p.provider(ctx, p.remote())
+	if err != nil {
+		return err
+	}
+	prs, err := provider.ListPullRequests(ctx, state)
+	if err != nil {
+		return err
+	}
+	kind := provider.Kind()
+	if len(prs) == 0 {
+		WriteLinef(p.outputWriter, "No %s %ss in %s", state, kind.Noun(), provider.Repo())
+		return nil
+	}
+	for _, pr := range prs {
+		draft := ""
+		if pr.Draft {
+			draft = " [draft]"
+		}
+		WriteLinef(p.outputWriter, "%-6s %s%s  (%s → %s, @%s)",
+			kind.Ref(pr.Number), pr.Title, draft, pr.Head, pr.Base, pr.Author)
+	}
+	return nil
+}
+
+// checkout fetches the head of a pull request into a local branch and
+// switches to it. The branch is named after the pull request's head
+// branch, or pr-<number> when a local branch of that name already exists.
+func (p *PullRequester) checkout(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: ggc pr checkout <number>")
+	}
+	number, err := strconv.Atoi(strings.TrimLeft(args[0], "#!"))
+	if err != nil || number <= 0 {
+		return fmt.Errorf("invalid pull request number %q", args[0])
+	}
+
+	ctx := context.Background()
+	remote := p.remote()
+	provider, err := p.provider(ctx, remote)
+	if err != nil {
+		return err
+	}
+	pr, err := provider.GetPullRequest(ctx, number)
+	if err != nil {
+		return err
+	}
+
+	local := pr.Head
+	if local == "" || p.gitClient.RevParseVerify("refs/heads/"+local) {
+		local = fmt.Sprintf("pr-%d", number)
+	}
+	if current, err := p.gitClient.GetCurrentBranch(); err == nil && current == local {
+		return fmt.Errorf("branch %q is checked out; switch away before updating it", local)
+	}
+	if err := p.gitClient.FetchRefspec(remote, "+"+provider.HeadRefspec(number)+":"+local); err != nil {
+		return err
+	}
+	if err := p.gitClient.CheckoutBranch(local); err != nil {
+		return err
+	}
+	WriteLinef(p.outputWriter, "Checked out %s (%s) as %s", provider.Kind().Ref(pr.Number), pr.Title, local)
+	return nil
+}
+
+// remote returns the configured default remote.
+func (p *PullRequester) remote() string {
+	if cfg := p.config(); cfg != nil {
+		if r := strings.TrimSpace(cfg.Git.DefaultRemote); r != "" {
+			return r
+		}
+	}
+	return "origin"
+}
+
+func (p *PullRequester) config() *config.Config {
+	if p.configManager == nil {
+		return nil
+	}
+	return p.configManager.GetConfig()
+}
+
+// provider returns the hosting service behind remote, detected from its
+// URL and the configured API URLs.
+func (p *PullRequester) provider(ctx context.Context, remote string) (hosting.Provider, error) {
+	url, err := p.gitClient.RemoteGetURL(remote)
+	if err != nil {
+		return nil, err
+	}
+	settings := p.integrations()
+	kind, host, repo, err := detectService(url, settings)
+	if err != nil {
+		return nil, err
+	}
+	token, err := p.token(ctx, kind, host, settings[kind])
+	if err != nil {
+		return nil, err
+	}
+	return hosting.New(kind, serviceAPIURL(kind, host, settings[kind]), token, repo)
+}
+
+// detectService returns the hosting service, host and repository of a
+// remote URL.
+func detectService(url string, settings map[hosting.Kind]integration) (hosting.Kind, string, hosting.Repo, error) {
+	host, repo, err := hosting.ParseRemoteURL(url)
+	if err != nil {
+		return "", "", hosting.Repo{}, err
+	}
+	apiURLs := make(map[hosting.Kind]string, len(settings))
+	for kind, s := range settings {
+		apiURLs[kind] = s.apiURL
+	}
+	kind, err := hosting.Detect(host, apiURLs)
+	if err != nil {
+		return "", "", hosting.Repo{}, err
+	}
+	return kind, host, repo, nil
+}
+
+// serviceAPIURL is the configured API URL of kind, or the usual one on
+// host.
+func serviceAPIURL(kind hosting.Kind, host string, s integration) string {
+	if s.apiURL != "" {
+		return s.apiURL
+	}
+	return hosting.DefaultAPIURL(kind, host)
+}
+
+// integration holds the config of one hosting service.
+type integration struct {
+	token    string
+	apiURL   string
+	clientID string
+	env      []string // token environment variables, in order
+}
+
+func (p *PullRequester) integrations() map[hosting.Kind]integration {
+	settings := map[hosting.Kind]integration{
+		hosting.GitHub: {env: []string{"GITHUB_TOKEN", "GH_TOKEN"}},
+		hosting.GitLab: {env: []string{"GITLAB_TOKEN"}},
+		hosting.Gitea:  {env: []string{"GITEA_TOKEN"}},
+	}
+	cfg := p.config()
+	if cfg == nil {
+		return settings
+	}
+	gh := cfg.Integration.GitHub
+	settings[hosting.GitHub] = integration{gh.Token, gh.APIURL, gh.ClientID, settings[hosting.GitHub].env}
+	gl := cfg.Integration.GitLab
+	settings[hosting.GitLab] = integration{gl.Token, gl.APIURL, "", settings[hosting.GitLab].env}
+	gt := cfg.Integration.Gitea
+	settings[hosting.Gitea] = integration{gt.Token, gt.APIURL, "", settings[hosting.Gitea].env}
+	return settings
+}
+
+// storedToken returns the token for kind that is already set up, from
+// the config or the environment, and where it came from.
+func (p *PullRequester) storedToken(kind hosting.Kind, s integration) (token, source string) {
+	if s.token != "" {
+		return s.token, "integration." + string(kind) + ".token"
+	}
+	for _, env := range s.env {
+		if v := strings.TrimSpace(p.getenv(env)); v != "" {
+			return v, env
+		}
+	}
+	return "", ""
+}
+
+// token finds the token for kind: integration.<kind>.token, then the
+// service's environment variables, then, for GitHub only, a device-flow
+// sign-in when integration.github.client-id is configured. A token
+// obtained by the device flow is saved to the config.
+func (p *PullRequester) token(ctx context.Context, kind hosting.Kind, host string, s integration) (string, error) {
+	if token, _ := p.storedToken(kind, s); token != "" {
+		return token, nil
+	}
+	if kind != hosting.GitHub || s.clientID == "" {
+		hint := fmt.Sprintf("set integration.%s.token or %s", string(kind), s.env[0])
+		if kind == hosting.GitHub {
+			hint += ", or set integration.github.client-id to sign in with the device flow"
+		}
+		return "", fmt.Errorf("no %s token: %s", kind, hint)
+	}
+
+	flow := hosting.NewDeviceFlow("https://"+host, s.clientID)
+	code, err := flow.RequestCode(ctx, "repo")
+	if err != nil {
+		return "", err
+	}
+	WriteLinef(p.outputWriter, "Open %s and enter the code %s", code.VerificationURI, code.UserCode)
+	WriteLine(p.outputWriter, "Waiting for authorization...")
+	token, err := flow.PollToken(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	if err := p.configManager.Set("integration.github.token", token); err != nil {
+		WriteErrorf(p.outputWriter, "signed in, but the token could not be saved: %v", err)
+	} else {
+		WriteLine(p.outputWriter, "Signed in; token saved to integration.github.token")
+	}
+	return token, nil
+}
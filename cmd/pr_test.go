@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockPRGitClient struct {
+	testutil.MockGitClient
+	branch      string
+	localRefs   map[string]bool
+	messages    []git.CommitMessage
+	gotRange    string
+	pushed      []string
+	fetched     []string
+	checkedOut  string
+	remoteNames []string
+	remoteURL   string
+}
+
+func (m *mockPRGitClient) GetCurrentBranch() (string, error) { return m.branch, nil }
+
+func (m *mockPRGitClient) RemoteGetURL(name string) (string, error) {
+	m.remoteNames = append(m.remoteNames, name)
+	if m.remoteURL != "" {
+		return m.remoteURL, nil
+	}
+	return "git@github.com:octo/hello.git", nil
+}
+
+func (m *mockPRGitClient) RevParseVerify(ref string) bool { return m.localRefs[ref] }
+
+func (m *mockPRGitClient) CommitMessages(revRange string) ([]git.CommitMessage, error) {
+	m.gotRange = revRange
+	return m.messages, nil
+}
+
+func (m *mockPRGitClient) PushSetUpstream(remote, branch string) error {
+	m.pushed = append(m.pushed, remote, branch)
+	return nil
+}
+
+func (m *mockPRGitClient) FetchRefspec(remote, refspec string) error {
+	m.fetched = append(m.fetched, remote, refspec)
+	return nil
+}
+
+func (m *mockPRGitClient) CheckoutBranch(name string) error {
+	m.checkedOut = name
+	return nil
+}
+
+func newTestPullRequester(t *testing.T, m *mockPRGitClient, handler http.HandlerFunc) (*PullRequester, *bytes.Buffer) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cfg := cm.GetConfig()
+	cfg.Git.DefaultRemote = "upstream"
+	cfg.Integration.GitHub.APIURL = srv.URL
+	cfg.Integration.GitHub.Token = "secret"
+
+	var buf bytes.Buffer
+	p := NewPullRequester(m).withConfigManager(cm)
+	p.outputWriter = &buf
+	p.helper.outputWriter = &buf
+	p.getenv = func(string) string { return "" }
+	return p, &buf
+}
+
+func TestPullRequester_Create(t *testing.T) {
+	m := &mockPRGitClient{
+		branch:    "feature/pr",
+		localRefs: map[string]bool{"refs/remotes/upstream/main": true},
+		messages: []git.CommitMessage{
+			{Hash: "bbb", Message: "fix: handle errors"},
+			{Hash: "aaa", Message: "feat: add pr command\n\nDetails."},
+		},
+	}
+	var got map[string]any
+	p, buf := newTestPullRequester(t, m, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("missing token: %q", r.Header.Get("Authorization"))
+		}
+		switch r.URL.Path {
+		case "/repos/octo/hello":
+			_, _ = w.Write([]byte(`{"default_branch":"main"}`))
+		case "/repos/octo/hello/pulls":
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(`{"number":5,"title":"feat: add pr command","html_url":"https://github.com/octo/hello/pull/5"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	p.PR([]string{"create", "--draft"})
+
+	if m.gotRange != "upstream/main..HEAD" {
+		t.Errorf("range = %q", m.gotRange)
+	}
+	if strings.Join(m.pushed, " ") != "upstream feature/pr" {
+		t.Errorf("pushed = %v", m.pushed)
+	}
+	if got["title"] != "feat: add pr command" || got["head"] != "feature/pr" || got["base"] != "main" || got["draft"] != true {
+		t.Errorf("unexpected request %v", got)
+	}
+	if got["body"] != "- feat: add pr command\n- fix: handle errors" {
+		t.Errorf("body = %q", got["body"])
+	}
+	if out := buf.String(); !strings.Contains(out, "Created pull request #5") || !strings.Contains(out, "/pull/5") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
+
+func TestPullRequester_Create_WIPTip(t *testing.T) {
+	m := &mockPRGitClient{branch: "feature/pr"}
+	p, buf := newTestPullRequester(t, m, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/hello":
+			_, _ = w.Write([]byte(`{"default_branch":"main"}`))
+		case "/repos/octo/hello/pulls":
+			_, _ = w.Write([]byte(`{"number":5,"title":"WIP","html_url":"https://github.com/octo/hello/pull/5"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	p.withPushGate(newPushGate(stubHead("WIP: work in progress")))
+
+	p.PR([]string{"create", "--title", "Login form"})
+	if len(m.pushed) != 0 || !strings.Contains(buf.String(), "--allow-wip") {
+		t.Fatalf("a WIP tip should not be pushed; pushed %v, output %q", m.pushed, buf.String())
+	}
+
+	p.PR([]string{"create", "--title", "Login form", "--allow-wip"})
+	if strings.Join(m.pushed, " ") != "upstream feature/pr" {
+		t.Errorf("--allow-wip should push; pushed %v, output %q", m.pushed, buf.String())
+	}
+}
+
+func TestPullRequester_Create_SecretScan(t *testing.T) {
+	m := &mockPRGitClient{branch: "feature/pr"}
+	p, buf := newTestPullRequester(t, m, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/hello":
+			_, _ = w.Write([]byte(`{"default_branch":"main"}`))
+		case "/repos/octo/hello/pulls":
+			_, _ = w.Write([]byte(`{"number":5,"title":"Login form","html_url":"https://github.com/octo/hello/pull/5"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	leaky, _ := leakyPatches()
+	p.withPushGate(newPushGate(nil).withSecretScan(leaky, nil))
+
+	p.PR([]string{"create", "--title", "Login form", "--scan"})
+	if len(m.pushed) != 0 || !strings.Contains(buf.String(), "look like secrets") {
+		t.Fatalf("secrets should not be pushed; pushed %v, output %q", m.pushed, buf.String())
+	}
+
+	p.PR([]string{"create", "--title", "Login form"})
+	if strings.Join(m.pushed, " ") != "upstream feature/pr" {
+		t.Errorf("without --scan the push should go ahead; pushed %v, output %q", m.pushed, buf.String())
+	}
+}
+
+func TestPullRequester_Create_SingleCommitAndFlags(t *testing.T) {
+	m := &mockPRGitClient{
+		branch:   "topic",
+		messages: []git.CommitMessage{{Hash: "aaa", Message: "docs: explain pr\n\nLonger text."}},
+	}
+	var got map[string]any
+	p, _ := newTestPullRequester(t, m, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo/hello/pulls" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"number":1}`))
+	})
+
+	p.PR([]string{"create", "--base=develop"})
+
+	if m.gotRange != "develop..HEAD" {
+		t.Errorf("range = %q", m.gotRange)
+	}
+	if got["title"] != "docs: explain pr" || got["body"] != "Longer text." || got["base"] != "develop" {
+		t.Errorf("unexpected request %v", got)
+	}
+}
+
+func TestPullRequester_Create_Ticket(t *testing.T) {
+	m := &mockPRGitClient{
+		branch:   "feature/PROJ-9-pager",
+		messages: []git.CommitMessage{{Hash: "aaa", Message: "feat: add pager"}},
+	}
+	var got map[string]any
+	p, _ := newTestPullRequester(t, m, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"number":1}`))
+	})
+	p.configManager.GetConfig().Tickets.PRTitle = "suffix"
+
+	p.PR([]string{"create", "--base=main"})
+
+	if got["title"] != "feat: add pager [PROJ-9]" {
+		t.Errorf("title = %v", got["title"])
+	}
+}
+
+func TestPullRequester_Create_OnBaseBranch(t *testing.T) {
+	m := &mockPRGitClient{branch: "main"}
+	p, buf := newTestPullRequester(t, m, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"default_branch":"main"}`))
+	})
+
+	p.PR([]string{"create"})
+
+	if !strings.Contains(buf.String(), "is the base branch") || m.pushed != nil {
+		t.Errorf("expected refusal without push, got %q (pushed %v)", buf.String(), m.pushed)
+	}
+}
+
+func TestPullRequester_List(t *testing.T) {
+	m := &mockPRGitClient{}
+	p, buf := newTestPullRequester(t, m, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") != "all" {
+			t.Errorf("state = %q", r.URL.Query().Get("state"))
+		}
+		_, _ = w.Write([]byte(`[{"number":12,"title":"feat: x","draft":true,"user":{"login":"octo"},"head":{"ref":"x"},"base":{"ref":"main"}}]`))
+	})
+
+	p.PR([]string{"list", "--state", "all"})
+
+	out := buf.String()
+	for _, want := range []string{"#12", "feat: x [draft]", "x → main", "@octo"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output should contain %q: %s", want, out)
+		}
+	}
+	if len(m.remoteNames) == 0 || m.remoteNames[0] != "upstream" {
+		t.Errorf("should use the configured default remote, got %v", m.remoteNames)
+	}
+}
+
+func TestPullRequester_Checkout(t *testing.T) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number":42,"title":"feat: y","head":{"ref":"feature-y"}}`))
+	}
+
+	m := &mockPRGitClient{branch: "main"}
+	p, _ := newTestPullRequester(t, m, handler)
+	p.PR([]string{"checkout", "#42"})
+	if strings.Join(m.fetched, " ") != "upstream +pull/42/head:feature-y" || m.checkedOut != "feature-y" {
+		t.Errorf("fetched %v, checked out %q", m.fetched, m.checkedOut)
+	}
+
+	m = &mockPRGitClient{branch: "main", localRefs: map[string]bool{"refs/heads/feature-y": true}}
+	p, _ = newTestPullRequester(t, m, handler)
+	p.PR([]string{"checkout", "42"})
+	if m.checkedOut != "pr-42" {
+		t.Errorf("existing branch should not be overwritten, checked out %q", m.checkedOut)
+	}
+}
+
+func TestPullRequester_TokenFallback(t *testing.T) {
+	m := &mockPRGitClient{}
+	var auth string
+	p, buf := newTestPullRequester(t, m, func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`[]`))
+	})
+	p.config().Integration.GitHub.Token = ""
+	p.getenv = func(key string) string {
+		if key == "GH_TOKEN" {
+			return "from-env"
+		}
+		return ""
+	}
+
+	p.PR([]string{"list"})
+	if auth != "Bearer from-env" {
+		t.Errorf("Authorization = %q", auth)
+	}
+
+	p.getenv = func(string) string { return "" }
+	buf.Reset()
+	p.PR([]string{"list"})
+	if !strings.Contains(buf.String(), "no GitHub token") {
+		t.Errorf("expected missing token error, got %q", buf.String())
+	}
+}
+
+func TestPullRequester_GitLab(t *testing.T) {
+	// The remote is on the host of the configured GitLab API URL.
+	m := &mockPRGitClient{branch: "main", remoteURL: "git@127.0.0.1:group/app.git"}
+	p, buf := newTestPullRequester(t, m, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "gl-secret" {
+			t.Errorf("PRIVATE-TOKEN = %q", r.Header.Get("PRIVATE-TOKEN"))
+		}
+		switch r.URL.EscapedPath() {
+		case "/projects/group%2Fapp/merge_requests/3":
+			_, _ = w.Write([]byte(`{"iid":3,"title":"feat: z","source_branch":"feature-z"}`))
+		case "/projects/group%2Fapp/merge_requests":
+			_, _ = w.Write([]byte(`[{"iid":3,"title":"feat: z","state":"opened","source_branch":"feature-z","target_branch":"main","author":{"username":"dev"}}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	cfg := p.config()
+	cfg.Integration.GitLab.APIURL = cfg.Integration.GitHub.APIURL
+	cfg.Integration.GitHub.APIURL = ""
+	cfg.Integration.GitLab.Token = "gl-secret"
+
+	p.PR([]string{"list"})
+	if out := buf.String(); !strings.Contains(out, "!3") || !strings.Contains(out, "feature-z → main, @dev") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	p.PR([]string{"checkout", "!3"})
+	if strings.Join(m.fetched, " ") != "upstream +merge-requests/3/head:feature-z" || m.checkedOut != "feature-z" {
+		t.Errorf("fetched %v, checked out %q", m.fetched, m.checkedOut)
+	}
+}
+
+func TestPullRequester_LookalikeHost(t *testing.T) {
+	m := &mockPRGitClient{remoteURL: "https://github.evil.example/team/app.git"}
+	p, buf := newTestPullRequester(t, m, func(http.ResponseWriter, *http.Request) {
+		t.Error("no request expected")
+	})
+	p.getenv = func(name string) string { return "env-" + name }
+
+	p.PR([]string{"list"})
+	if !strings.Contains(buf.String(), "cannot tell which hosting service runs on github.evil.example") {
+		t.Errorf("a host merely named like GitHub should not get the token: %s", buf.String())
+	}
+}
+
+func TestPullRequester_UnknownHost(t *testing.T) {
+	m := &mockPRGitClient{remoteURL: "https://scm.example.com/team/app.git"}
+	p, buf := newTestPullRequester(t, m, func(http.ResponseWriter, *http.Request) {
+		t.Error("no request expected")
+	})
+
+	p.PR([]string{"list"})
+	if !strings.Contains(buf.String(), "cannot tell which hosting service") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
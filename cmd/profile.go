@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// profileGitClient is the git surface `ggc profile` needs.
+type profileGitClient interface {
+	git.ConfigReader
+	git.ConfigWriter
+}
+
+// Profiler provides the profile command, which keeps named identities in
+// the config and applies them to repositories.
+type Profiler struct {
+	gitClient     profileGitClient
+	outputWriter  io.Writer
+	helper        *Helper
+	configManager *config.Manager
+	getwd         func() (string, error)
+}
+
+// NewProfiler creates a new Profiler.
+func NewProfiler(client profileGitClient) *Profiler {
+	p := &Profiler{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+		getwd:        os.Getwd,
+	}
+	p.helper.outputWriter = p.outputWriter
+	return p
+}
+
+// withConfigManager supplies the profiles and lets add and remove save
+// them.
+func (p *Profiler) withConfigManager(cm *config.Manager) *Profiler {
+	p.configManager = cm
+	return p
+}
+
+// Profile executes the profile command with the given arguments.
+func (p *Profiler) Profile(args []string) {
+	if len(args) == 0 || p.configManager == nil {
+		p.helper.ShowProfileHelp()
+		return
+	}
+
+	var err error
+	switch args[0] {
+	case "list", "ls":
+		err = p.list()
+	case "current", "show":
+		err = p.current()
+	case "add":
+		err = p.add(args[1:])
+	case "remove", "rm":
+		err = p.remove(args[1:])
+	case "use":
+		err = p.use(args[1:])
+	case "apply":
+		err = p.apply()
+	default:
+		p.helper.ShowProfileHelp()
+		return
+	}
+	if err != nil {
+		WriteError(p.outputWriter, err)
+	}
+}
+
+// expected returns the profile this repository should use.
+func (p *Profiler) expected() (string, bool) {
+	pinned, _ := p.gitClient.ConfigGet(config.PinnedProfileKey)
+	dir, err := p.getwd()
+	if err != nil {
+		dir = ""
+	}
+	return p.configManager.GetConfig().ExpectedProfile(pinned, dir)
+}
+
+func (p *Profiler) list() error {
+	cfg := p.configManager.GetConfig()
+	names := cfg.ProfileNames()
+	if len(names) == 0 {
+		WriteLine(p.outputWriter, "No profiles configured. Add one with: ggc profile add <name> --name <name> --email <email>")
+		return nil
+	}
+	expected, _ := p.expected()
+	tw := tabwriter.NewWriter(p.outputWriter, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		prof := cfg.Profiles[name]
+		marker := " "
+		if name == expected {
+			marker = "*"
+		}
+		signing := "-"
+		if prof.SigningKey != "" {
+			signing = profileSigningFormat(prof) + " signing"
+			if prof.Sign {
+				signing += " (always)"
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "%s %s\t%s <%s>\t%s\t%s\n",
+			marker, name, prof.Name, prof.Email, signing, strings.Join(prof.Paths, " "))
+	}
+	return tw.Flush()
+}
+
+func profileSigningFormat(prof config.Profile) string {
+	if prof.SigningFormat == "" {
+		return "gpg"
+	}
+	return prof.SigningFormat
+}
+
+// current shows the repository's identity and whether it matches the
+// profile it is expected to use.
+func (p *Profiler) current() error {
+	name, _ := p.gitClient.ConfigGet("user.name")
+	email, _ := p.gitClient.ConfigGet("user.email")
+	if name == "" && email == "" {
+		WriteLine(p.outputWriter, "Identity: (not set)")
+	} else {
+		WriteLinef(p.outputWriter, "Identity: %s <%s>", name, email)
+	}
+
+	expected, ok := p.expected()
+	if !ok {
+		WriteLine(p.outputWriter, "Expected profile: none")
+		return nil
+	}
+	prof := p.configManager.GetConfig().Profiles[expected]
+	WriteLinef(p.outputWriter, "Expected profile: %s (%s <%s>)", expected, prof.Name, prof.Email)
+	if !prof.Matches(name, email) {
+		WriteLinef(p.outputWriter, "Warning: the identity does not match profile %s; run 'ggc profile apply' to fix it", expected)
+	}
+	return nil
+}
+
+// parseProfileAddArgs parses `ggc profile add <name> [flags]`.
+func parseProfileAddArgs(args []string) (string, config.Profile, error) {
+	var prof config.Profile
+	var name string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--sign" {
+			prof.Sign = true
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") {
+			if name != "" {
+				return "", prof, fmt.Errorf("unexpected argument %q", arg)
+			}
+			name = arg
+			continue
+		}
+		flag, value, hasValue := strings.Cut(arg, "=")
+		if !hasValue {
+			if i+1 >= len(args) {
+				return "", prof, fmt.Errorf("%s requires a value", flag)
+			}
+			i++
+			value = args[i]
+		}
+		switch flag {
+		case "--name":
+			prof.Name = value
+		case "--email":
+			prof.Email = value
+		case "--signing-key":
+			prof.SigningKey = value
+		case "--signing-format":
+			prof.SigningFormat = value
+		case "--path":
+			prof.Paths = append(prof.Paths, value)
+		default:
+			return "", prof, fmt.Errorf("unknown argument %q", arg)
+		}
+	}
+	if name == "" {
+		return "", prof, fmt.Errorf("profile name required")
+	}
+	return name, prof, nil
+}
+
+// add creates or replaces a profile and saves the config.
+func (p *Profiler) add(args []string) error {
+	name, prof, err := parseProfileAddArgs(args)
+	if err != nil {
+		return err
+	}
+	cfg := p.configManager.GetConfig()
+	previous, existed := cfg.Profiles[name]
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]config.Profile{}
+	}
+	if err := p.configManager.Set("profiles."+name, prof); err != nil {
+		if existed {
+			cfg.Profiles[name] = previous
+		} else {
+			delete(cfg.Profiles, name)
+		}
+		return err
+	}
+	WriteLinef(p.outputWriter, "Saved profile %s", name)
+	return nil
+}
+
+func (p *Profiler) remove(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: ggc profile remove <name>")
+	}
+	cfg := p.configManager.GetConfig()
+	prof, ok := cfg.Profiles[args[0]]
+	if !ok {
+		return fmt.Errorf("no profile named %q", args[0])
+	}
+	if cfg.Clone.Profile == args[0] {
+		return fmt.Errorf("profile %s is set as clone.profile; change that first", args[0])
+	}
+	delete(cfg.Profiles, args[0])
+	if err := p.configManager.Save(); err != nil {
+		cfg.Profiles[args[0]] = prof
+		return err
+	}
+	WriteLinef(p.outputWriter, "Removed profile %s", args[0])
+	return nil
+}
+
+// use applies a profile to the current repository and pins it there, so
+// the repository keeps expecting it wherever it lives.
+func (p *Profiler) use(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: ggc profile use <name>")
+	}
+	prof, ok := p.configManager.GetConfig().Profiles[args[0]]
+	if !ok {
+		return fmt.Errorf("no profile named %q", args[0])
+	}
+	if err := applyProfile(p.gitClient.ConfigSet, prof); err != nil {
+		return err
+	}
+	if err := p.gitClient.ConfigSet(config.PinnedProfileKey, args[0]); err != nil {
+		return err
+	}
+	WriteLinef(p.outputWriter, "Using profile %s: %s <%s>", args[0], prof.Name, prof.Email)
+	return nil
+}
+
+// apply applies the profile the repository is expected to use.
+func (p *Profiler) apply() error {
+	name, ok := p.expected()
+	if !ok {
+		return fmt.Errorf("no profile is pinned to this repository or matches its path")
+	}
+	prof := p.configManager.GetConfig().Profiles[name]
+	if err := applyProfile(p.gitClient.ConfigSet, prof); err != nil {
+		return err
+	}
+	WriteLinef(p.outputWriter, "Applied profile %s: %s <%s>", name, prof.Name, prof.Email)
+	return nil
+}
+
+// applyProfile writes prof into a repository's git config through set.
+func applyProfile(set func(key, value string) error, prof config.Profile) error {
+	for _, kv := range prof.GitConfig() {
+		if err := set(kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockProfileGitClient struct {
+	testutil.MockGitClient
+	values map[string]string
+	set    []string
+}
+
+func (m *mockProfileGitClient) ConfigGet(key string) (string, error) {
+	return m.values[key], nil
+}
+
+func (m *mockProfileGitClient) ConfigSet(key, value string) error {
+	m.set = append(m.set, key+"="+value)
+	m.values[key] = value
+	return nil
+}
+
+// newTestProfiler returns a Profiler working in dir whose config saves
+// under a temporary home.
+func newTestProfiler(t *testing.T, m *mockProfileGitClient, dir string) (*Profiler, *bytes.Buffer, *config.Manager) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	if err := cm.Load(); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	p := NewProfiler(m).withConfigManager(cm)
+	p.outputWriter = &buf
+	p.helper.outputWriter = &buf
+	p.getwd = func() (string, error) { return dir, nil }
+	return p, &buf, cm
+}
+
+func TestProfiler_AddAndRemove(t *testing.T) {
+	m := &mockProfileGitClient{values: map[string]string{}}
+	p, buf, cm := newTestProfiler(t, m, "/src")
+
+	p.Profile([]string{"add", "work", "--name", "Jane Doe", "--email=jane@corp.example", "--path", "~/work/*"})
+
+	want := config.Profile{Name: "Jane Doe", Email: "jane@corp.example", Paths: []string{"~/work/*"}}
+	if got := cm.GetConfig().Profiles["work"]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("saved profile = %+v, want %+v (output %q)", got, want, buf.String())
+	}
+	data, err := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".ggcconfig.yaml"))
+	if err != nil || !strings.Contains(string(data), "jane@corp.example") {
+		t.Errorf("config file should hold the profile: %v\n%s", err, data)
+	}
+
+	buf.Reset()
+	p.Profile([]string{"add", "bad", "--name", "Jane"})
+	if _, ok := cm.GetConfig().Profiles["bad"]; ok || !strings.Contains(buf.String(), "profiles.bad.email") {
+		t.Errorf("an invalid profile should be rejected and dropped, output %q", buf.String())
+	}
+
+	p.Profile([]string{"remove", "work"})
+	if len(cm.GetConfig().Profiles) != 0 {
+		t.Errorf("profiles after remove = %v", cm.GetConfig().Profiles)
+	}
+}
+
+func TestProfiler_UseAndCurrent(t *testing.T) {
+	m := &mockProfileGitClient{values: map[string]string{"user.name": "Jane", "user.email": "jane@example.com"}}
+	p, buf, cm := newTestProfiler(t, m, "/src/app")
+	cm.GetConfig().Profiles = map[string]config.Profile{
+		"oss":  {Name: "Jane", Email: "jane@example.com", Paths: []string{"/src"}},
+		"work": {Name: "Jane Doe", Email: "jane@corp.example", SigningKey: "ABCD", Sign: true},
+	}
+
+	p.Profile([]string{"current"})
+	if out := buf.String(); !strings.Contains(out, "Expected profile: oss") || strings.Contains(out, "Warning") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	buf.Reset()
+	p.Profile([]string{"use", "work"})
+	want := []string{
+		"user.name=Jane Doe", "user.email=jane@corp.example",
+		"user.signingkey=ABCD", "gpg.format=openpgp",
+		"commit.gpgsign=true", "tag.gpgsign=true",
+		"ggc.profile=work",
+	}
+	if !reflect.DeepEqual(m.set, want) {
+		t.Errorf("config set = %v, want %v", m.set, want)
+	}
+
+	// The pin now wins over the path match; a drifted identity warns.
+	m.values["user.email"] = "jane@example.com"
+	buf.Reset()
+	p.Profile([]string{"current"})
+	if out := buf.String(); !strings.Contains(out, "Expected profile: work") || !strings.Contains(out, "Warning") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	buf.Reset()
+	p.Profile([]string{"list"})
+	if out := buf.String(); !strings.Contains(out, "* work") || !strings.Contains(out, "gpg signing (always)") {
+		t.Errorf("unexpected list: %s", out)
+	}
+}
+
+func TestCloner_AppliesProfile(t *testing.T) {
+	inCloneDir(t, "r")
+	m := &mockCloneGitClient{}
+	c, _, cfg := newTestCloner(m, "")
+	cfg.Profiles = map[string]config.Profile{"oss": {Name: "Jane", Email: "jane@example.com"}}
+	cfg.Clone.Profile = "oss"
+
+	c.Clone([]string{"o/r"})
+
+	want := []string{"r user.name=Jane", "r user.email=jane@example.com", "r ggc.profile=oss"}
+	if !reflect.DeepEqual(m.set, want) {
+		t.Errorf("config set = %v, want %v", m.set, want)
+	}
+}
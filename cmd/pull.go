@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
 )
 
@@ -13,6 +14,8 @@ type Puller struct {
 	gitClient    git.Puller
 	outputWriter io.Writer
 	helper       *Helper
+	stash        *autostasher  // nil leaves uncommitted changes to git
+	ssh          *sshPreflight // nil when ssh.preflight is off
 }
 
 // NewPuller creates a new Puller.
@@ -26,6 +29,20 @@ func NewPuller(client git.Puller) *Puller {
 	return p
 }
 
+// withAutostash stashes uncommitted changes around a pull, as
+// behavior.autostash says.
+func (p *Puller) withAutostash(a *autostasher) *Puller {
+	p.stash = a
+	return p
+}
+
+// withSSHPreflight checks the SSH key for the remote before pulling when
+// ssh.preflight is set. cm may be nil.
+func (p *Puller) withSSHPreflight(urls sshRemotes, cm *config.Manager) *Puller {
+	p.ssh = newSSHPreflight(urls, cm)
+	return p
+}
+
 // Pull executes the pull command with the given arguments.
 func (p *Puller) Pull(args []string) {
 	if len(args) == 0 {
@@ -33,16 +50,23 @@ func (p *Puller) Pull(args []string) {
 		return
 	}
 
+	var rebase bool
 	switch args[0] {
 	case "current":
-		if err := p.gitClient.Pull(false); err != nil {
-			WriteError(p.outputWriter, err)
-		}
 	case "rebase":
-		if err := p.gitClient.Pull(true); err != nil {
-			WriteError(p.outputWriter, err)
-		}
+		rebase = true
 	default:
 		p.helper.ShowPullHelp()
+		return
 	}
+	if !p.ssh.ready(p.outputWriter, "pull", sshTarget{}) {
+		return
+	}
+	p.stash.around(p.outputWriter, "pull", func() bool {
+		if err := p.gitClient.Pull(rebase); err != nil {
+			WriteError(p.outputWriter, err)
+			return false
+		}
+		return true
+	})
 }
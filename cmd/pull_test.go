@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -126,6 +127,27 @@ func TestPuller_Pull_RebaseError(t *testing.T) {
 	}
 }
 
+func TestPuller_Pull_Autostash(t *testing.T) {
+	var buf bytes.Buffer
+	ops := &recordingStash{}
+	puller := (&Puller{gitClient: &mockPullGitClient{}, outputWriter: &buf, helper: NewHelper()}).
+		withAutostash(newTestAutostasher("always", "", ops))
+	puller.Pull([]string{"rebase"})
+	if got := strings.Join(ops.calls, "; "); got != "push ggc pull autostash on main; pop" {
+		t.Errorf("calls = %q", got)
+	}
+
+	ops.calls = nil
+	puller.gitClient = &mockPullGitClient{err: errors.New("conflict")}
+	puller.Pull([]string{"current"})
+	if got := strings.Join(ops.calls, "; "); got != "push ggc pull autostash on main" {
+		t.Errorf("calls = %q; a failed pull should keep the stash", got)
+	}
+	if !strings.Contains(buf.String(), "run 'ggc stash pop' once the pull is done") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
 func TestPuller_Pull_UnknownCommand(t *testing.T) {
 	var buf bytes.Buffer
 	puller := &Puller{
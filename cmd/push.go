@@ -2,17 +2,41 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"strings"
 
+	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/safety"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
+// pushPreviewCommits caps how many commits the push preview lists.
+const pushPreviewCommits = 10
+
+// upstreamTracker tells whether the current branch has an upstream and
+// pushes it with --set-upstream.
+type upstreamTracker interface {
+	git.BranchUpstreamReader
+	git.UpstreamPusher
+}
+
 // Pusher provides functionality for the push command.
 type Pusher struct {
 	gitClient    git.Pusher
 	outputWriter io.Writer
 	helper       *Helper
+	guard        *branchGuard
+	forcer       git.ForcePusher   // nil force-pushes through Push
+	lease        bool              // push.force-with-lease
+	preview      git.PushPreviewer // nil pushes without a preview
+	confirm      *ui.Confirmer
+	tracker      upstreamTracker // nil never offers to set an upstream
+	remote       string          // git.default-remote, which a new branch tracks
+	gate         *pushGate       // nil pushes without the shared checks
+	ssh          *sshPreflight   // nil when ssh.preflight is off
 }
 
 // NewPusher creates a new Pusher.
@@ -21,11 +45,65 @@ func NewPusher(client git.Pusher) *Pusher {
 		gitClient:    client,
 		outputWriter: os.Stdout,
 		helper:       NewHelper(),
+		remote:       "origin",
 	}
 	p.helper.outputWriter = p.outputWriter
 	return p
 }
 
+// withGuard makes force pushes respect safety.protected-branches.
+func (p *Pusher) withGuard(g *branchGuard) *Pusher {
+	p.guard = g
+	return p
+}
+
+// withForcePusher makes ggc push force follow push.force-with-lease, which
+// is on by default. cm may be nil.
+func (p *Pusher) withForcePusher(forcer git.ForcePusher, cm *config.Manager) *Pusher {
+	p.forcer = forcer
+	p.lease = cm == nil || cm.GetConfig().Push.ForceWithLease
+	return p
+}
+
+// withPreview lists the commits a push sends before it runs, and makes a
+// force push that drops remote commits ask first.
+func (p *Pusher) withPreview(preview git.PushPreviewer, confirm *ui.Confirmer) *Pusher {
+	p.preview = preview
+	p.confirm = confirm
+	return p
+}
+
+// withTracking makes ggc push current offer to set the upstream of a
+// branch that has none. The answer comes from the confirmer of withPreview.
+func (p *Pusher) withTracking(tracker upstreamTracker) *Pusher {
+	p.tracker = tracker
+	return p
+}
+
+// withDefaultRemote makes ggc push current offer to track a branch on
+// git.default-remote rather than origin, and check the SSH key for it.
+func (p *Pusher) withDefaultRemote(cm *config.Manager) *Pusher {
+	if cm != nil {
+		if r := strings.TrimSpace(cm.GetConfig().Git.DefaultRemote); r != "" {
+			p.remote = r
+		}
+	}
+	return p
+}
+
+// withSSHPreflight checks the SSH key for the remote before pushing when
+// ssh.preflight is set. cm may be nil.
+func (p *Pusher) withSSHPreflight(urls sshRemotes, cm *config.Manager) *Pusher {
+	p.ssh = newSSHPreflight(urls, cm)
+	return p
+}
+
+// withPushGate runs the checks shared by every command that pushes.
+func (p *Pusher) withPushGate(g *pushGate) *Pusher {
+	p.gate = g
+	return p
+}
+
 // Push executes the push command with the given arguments.
 func (p *Pusher) Push(args []string) {
 	if len(args) == 0 {
@@ -35,14 +113,118 @@ func (p *Pusher) Push(args []string) {
 
 	switch args[0] {
 	case "current":
-		if err := p.gitClient.Push(false); err != nil {
+		if !p.ssh.ready(p.outputWriter, "push", sshTarget{p.remote, true}) ||
+			!p.checkGate(args[1:]) || !p.showPreview(false) {
+			return
+		}
+		if err := p.pushCurrent(); err != nil {
 			WriteError(p.outputWriter, err)
 		}
 	case "force":
-		if err := p.gitClient.Push(true); err != nil {
+		_, unsafe := safety.CutForceFlag(args[1:])
+		if err := p.guard.checkCurrent("force-push", unsafe); err != nil {
+			WriteError(p.outputWriter, err)
+			return
+		}
+		if !p.ssh.ready(p.outputWriter, "push", sshTarget{"origin", true}) ||
+			!p.checkGate(args[1:]) || !p.showPreview(true) {
+			return
+		}
+		if err := p.pushForce(); err != nil {
 			WriteError(p.outputWriter, err)
 		}
 	default:
 		p.helper.ShowPushHelp()
 	}
 }
+
+// checkGate runs the push gate and reports whether the push may go ahead.
+func (p *Pusher) checkGate(args []string) bool {
+	if err := p.gate.check(p.outputWriter, args); err != nil {
+		WriteError(p.outputWriter, err)
+		return false
+	}
+	return true
+}
+
+// pushCurrent pushes the current branch. When the branch has no upstream
+// yet it offers to track <remote>/<branch>; without anyone to answer it
+// pushes anyway and tells how to set one.
+func (p *Pusher) pushCurrent() error {
+	if p.tracker == nil {
+		return p.gitClient.Push(false)
+	}
+	branch, err := p.tracker.GetCurrentBranch()
+	if err != nil || branch == "HEAD" {
+		return p.gitClient.Push(false)
+	}
+	if upstream, err := p.tracker.GetUpstreamBranchName(branch); err == nil && upstream != "" {
+		return p.gitClient.Push(false)
+	}
+	ok, askErr := p.confirm.Confirm(fmt.Sprintf("%s has no upstream. Track %s/%s?", branch, p.remote, branch))
+	if ok {
+		return p.tracker.PushSetUpstream(p.remote, branch)
+	}
+	if err := p.gitClient.Push(false); err != nil {
+		return err
+	}
+	if askErr != nil {
+		WriteLinef(p.outputWriter, "%s has no upstream; 'ggc branch track %s/%s' sets it.", branch, p.remote, branch)
+	}
+	return nil
+}
+
+// pushForce force-pushes with --force-with-lease, or with --force when
+// push.force-with-lease is off.
+func (p *Pusher) pushForce() error {
+	if p.forcer == nil {
+		return p.gitClient.Push(true)
+	}
+	return p.forcer.PushForce(p.lease)
+}
+
+// showPreview lists the commits the push sends and the remote commits it
+// would overwrite, as of the last fetch. It reports whether to go ahead:
+// a force push that drops remote commits needs confirmation.
+func (p *Pusher) showPreview(force bool) bool {
+	if p.preview == nil {
+		return true
+	}
+	preview, err := p.preview.PushPreview()
+	if err != nil {
+		// Let git report what is wrong, such as a detached HEAD.
+		return true
+	}
+	target := preview.Remote + "/" + preview.Branch
+	if len(preview.Ahead) > 0 {
+		header := fmt.Sprintf("Pushing %d commit(s) to %s", len(preview.Ahead), target)
+		if preview.New {
+			header += " (a new branch)"
+		}
+		WriteLine(p.outputWriter, header+":")
+		p.listCommits(preview.Ahead)
+	}
+	if len(preview.Behind) == 0 {
+		return true
+	}
+	if !force {
+		WriteLinef(p.outputWriter, "%s has %d commit(s) your branch lacks, so the push will be rejected. Run 'ggc pull rebase' first, or 'ggc push force' to overwrite them.",
+			target, len(preview.Behind))
+		return true
+	}
+	WriteLinef(p.outputWriter, "This rewrites %s, dropping %d commit(s) that are not in your branch:", target, len(preview.Behind))
+	p.listCommits(preview.Behind)
+	ok, err := p.confirm.Confirm(fmt.Sprintf("Overwrite %s?", target))
+	return proceed(p.outputWriter, ok, err)
+}
+
+// listCommits prints commits one per line, at most pushPreviewCommits.
+func (p *Pusher) listCommits(commits []git.CommitSummary) {
+	for i, c := range commits {
+		if i == pushPreviewCommits {
+			WriteLinef(p.outputWriter, "  … and %d more", len(commits)-i)
+			return
+		}
+		WriteLinef(p.outputWriter, "  %s %s", c.Short, c.Subject)
+	}
+}
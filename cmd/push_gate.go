@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"io"
+	"slices"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/secrets"
+)
+
+// pushGate holds the checks every ggc command that pushes runs first,
+// the WIP tip check and the secret scan: ggc push, the push step of ggc
+// sync and ggc pr create. A nil gate lets
+// every push through.
+type pushGate struct {
+	messages   git.CommitMessageReader // nil pushes WIP commits too
+	patches    git.OutgoingPatchReader
+	scanner    *secrets.Scanner // nil cannot scan for secrets
+	scanAlways bool             // safety.scan-secrets
+}
+
+// newPushGate returns the gate that reads the tip of the branch from
+// messages.
+func newPushGate(messages git.CommitMessageReader) *pushGate {
+	return &pushGate{messages: messages}
+}
+
+// isPushGateFlag reports whether arg is a flag the gate reads, which the
+// commands that push accept on top of their own.
+func isPushGateFlag(arg string) bool {
+	return arg == allowWIPFlag || arg == scanFlag || arg == noScanFlag
+}
+
+// check returns why the push must not go ahead, given the command-line
+// flags in args, or nil when it may. Details that do not fit in the
+// error go to w.
+func (g *pushGate) check(w io.Writer, args []string) error {
+	if g == nil {
+		return nil
+	}
+	if err := g.checkWIP(slices.Contains(args, allowWIPFlag)); err != nil {
+		return err
+	}
+	return g.checkSecrets(w, args)
+}
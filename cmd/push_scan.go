@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/secrets"
+)
+
+// The flags that turn the secret scan on or off for one push.
+const (
+	scanFlag   = "--scan"
+	noScanFlag = "--no-scan"
+)
+
+// withSecretScan lets --scan look for credentials in the commits a push
+// sends, and makes every push do so when safety.scan-secrets is set. The
+// rules are the built-in ones changed by safety.secret-rules. cm may be
+// nil.
+func (g *pushGate) withSecretScan(patches git.OutgoingPatchReader, cm *config.Manager) *pushGate {
+	g.patches = patches
+	var rules map[string]string
+	if cm != nil {
+		cfg := cm.GetConfig()
+		g.scanAlways = cfg.Safety.ScanSecrets
+		rules = cfg.Safety.SecretRules
+	}
+	g.scanner = secrets.NewScanner(rules)
+	return g
+}
+
+// checkSecrets scans the outgoing commits when --scan or
+// safety.scan-secrets asks for it and --no-scan does not. It returns an
+// error when a line looks like a secret, after listing those lines on w,
+// or when the commits cannot be read.
+func (g *pushGate) checkSecrets(w io.Writer, args []string) error {
+	scan := (g.scanAlways || slices.Contains(args, scanFlag)) && !slices.Contains(args, noScanFlag)
+	if !scan {
+		return nil
+	}
+	if g.patches == nil || g.scanner == nil {
+		return fmt.Errorf("the secret scan is not supported here; push with %s", noScanFlag)
+	}
+	patch, err := g.patches.OutgoingPatch()
+	if err != nil {
+		return err
+	}
+	findings := g.scanner.ScanLog(patch)
+	if len(findings) == 0 {
+		return nil
+	}
+	WriteLine(w, "These lines in the outgoing commits look like secrets:")
+	for _, f := range findings {
+		WriteLinef(w, "  %s %s:%d  %s", f.Commit, f.Path, f.Line, f.Rule)
+		WriteLinef(w, "      %s", f.Text)
+	}
+	WriteLine(w, "Take the secrets out of those commits, for example with 'ggc rebase interactive', and rotate them.")
+	WriteLinef(w, "If a line is not a secret, add %q to it, or push with %s.", secrets.AllowMarker, noScanFlag)
+	return fmt.Errorf("push stopped: %d line(s) in the outgoing commits look like secrets", len(findings))
+}
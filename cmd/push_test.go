@@ -3,7 +3,14 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"strings"
 	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 type mockPushGitClient struct {
@@ -141,3 +148,282 @@ func TestPusher_Push_UnknownCommand(t *testing.T) {
 		t.Errorf("Usage should be displayed for unknown command, but got: %s", output)
 	}
 }
+
+type stubForcePusher struct {
+	called bool
+	lease  bool
+}
+
+func (s *stubForcePusher) PushForce(lease bool) error {
+	s.called, s.lease = true, lease
+	return nil
+}
+
+func TestPusher_Push_ForceLease(t *testing.T) {
+	for _, lease := range []bool{true, false} {
+		forcer := &stubForcePusher{}
+		mockClient := &mockPushGitClient{}
+		pusher := &Pusher{gitClient: mockClient, outputWriter: &bytes.Buffer{}, helper: NewHelper(), forcer: forcer, lease: lease}
+		pusher.Push([]string{"force"})
+		if !forcer.called || forcer.lease != lease || mockClient.pushCalled {
+			t.Errorf("lease %v: PushForce called = %v with lease %v, Push called = %v", lease, forcer.called, forcer.lease, mockClient.pushCalled)
+		}
+	}
+}
+
+type stubPushPreviewer git.PushPreview
+
+func (s *stubPushPreviewer) PushPreview() (*git.PushPreview, error) {
+	p := git.PushPreview(*s)
+	return &p, nil
+}
+
+func TestPusher_Push_Preview(t *testing.T) {
+	commits := func(subjects ...string) []git.CommitSummary {
+		var cs []git.CommitSummary
+		for i, s := range subjects {
+			cs = append(cs, git.CommitSummary{Short: strings.Repeat("a", i+1), Subject: s})
+		}
+		return cs
+	}
+	tests := []struct {
+		name     string
+		args     []string
+		preview  stubPushPreviewer
+		answer   string
+		wantPush bool
+		wantOut  []string
+	}{
+		{
+			name:     "commits to push",
+			args:     []string{"current"},
+			preview:  stubPushPreviewer{Remote: "origin", Branch: "feature", Ahead: commits("add widget", "fix widget")},
+			wantPush: true,
+			wantOut:  []string{"Pushing 2 commit(s) to origin/feature:", "  a add widget", "  aa fix widget"},
+		},
+		{
+			name:     "new branch",
+			args:     []string{"current"},
+			preview:  stubPushPreviewer{Remote: "origin", Branch: "feature", New: true, Ahead: commits("start")},
+			wantPush: true,
+			wantOut:  []string{"Pushing 1 commit(s) to origin/feature (a new branch):"},
+		},
+		{
+			name:     "plain push behind the remote",
+			args:     []string{"current"},
+			preview:  stubPushPreviewer{Remote: "origin", Branch: "feature", Behind: commits("theirs")},
+			wantPush: true,
+			wantOut:  []string{"origin/feature has 1 commit(s) your branch lacks, so the push will be rejected."},
+		},
+		{
+			name:     "force push rewriting the remote, declined",
+			args:     []string{"force"},
+			preview:  stubPushPreviewer{Remote: "origin", Branch: "feature", Ahead: commits("mine"), Behind: commits("theirs")},
+			answer:   "n\n",
+			wantPush: false,
+			wantOut:  []string{"This rewrites origin/feature, dropping 1 commit(s) that are not in your branch:", "  a theirs", "Overwrite origin/feature? [y/N]"},
+		},
+		{
+			name:     "force push rewriting the remote, confirmed",
+			args:     []string{"force"},
+			preview:  stubPushPreviewer{Remote: "origin", Branch: "feature", Behind: commits("theirs")},
+			answer:   "y\n",
+			wantPush: true,
+		},
+		{
+			name:     "force push without a rewrite",
+			args:     []string{"force"},
+			preview:  stubPushPreviewer{Remote: "origin", Branch: "feature", Ahead: commits("mine")},
+			wantPush: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mockClient := &mockPushGitClient{}
+			pusher := &Pusher{gitClient: mockClient, outputWriter: &buf, helper: NewHelper()}
+			pusher.withPreview(&tt.preview, ui.NewConfirmer(prompt.New(strings.NewReader(tt.answer), &buf), true, ui.ConfirmSimple))
+			pusher.Push(tt.args)
+			if mockClient.pushCalled != tt.wantPush {
+				t.Errorf("pushed = %v, want %v; output:\n%s", mockClient.pushCalled, tt.wantPush, buf.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output lacks %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestPusher_ListCommits_Caps(t *testing.T) {
+	var buf bytes.Buffer
+	pusher := &Pusher{outputWriter: &buf}
+	pusher.listCommits(make([]git.CommitSummary, pushPreviewCommits+3))
+	if !strings.HasSuffix(buf.String(), "  … and 3 more\n") || strings.Count(buf.String(), "\n") != pushPreviewCommits+1 {
+		t.Errorf("output:\n%s", buf.String())
+	}
+}
+
+type stubUpstreamTracker struct {
+	upstream   string
+	setRemote  string
+	setBranch  string
+	setCalled  bool
+	currentErr error
+}
+
+func (s *stubUpstreamTracker) GetCurrentBranch() (string, error) { return "feature", s.currentErr }
+
+func (s *stubUpstreamTracker) GetUpstreamBranchName(string) (string, error) {
+	if s.upstream == "" {
+		return "", errors.New("no upstream configured for branch 'feature'")
+	}
+	return s.upstream, nil
+}
+
+func (s *stubUpstreamTracker) GetAheadBehindCount(string, string) (string, error) { return "0\t0", nil }
+
+func (s *stubUpstreamTracker) PushSetUpstream(remote, branch string) error {
+	s.setCalled, s.setRemote, s.setBranch = true, remote, branch
+	return nil
+}
+
+func TestPusher_Push_SuggestsUpstream(t *testing.T) {
+	tests := []struct {
+		name        string
+		upstream    string
+		remote      string
+		answer      string
+		interactive bool
+		wantSet     bool
+		wantPush    bool
+		wantOut     string
+	}{
+		{name: "accepted", answer: "y\n", interactive: true, wantSet: true, wantOut: "feature has no upstream. Track origin/feature? [y/N]"},
+		{name: "git.default-remote", remote: "upstream", answer: "y\n", interactive: true, wantSet: true, wantOut: "feature has no upstream. Track upstream/feature? [y/N]"},
+		{name: "declined", answer: "n\n", interactive: true, wantPush: true},
+		{name: "no terminal", wantPush: true, wantOut: "feature has no upstream; 'ggc branch track origin/feature' sets it."},
+		{name: "no terminal, git.default-remote", remote: "upstream", wantPush: true, wantOut: "feature has no upstream; 'ggc branch track upstream/feature' sets it."},
+		{name: "already tracking", upstream: "origin/feature", wantPush: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mockClient := &mockPushGitClient{}
+			tracker := &stubUpstreamTracker{upstream: tt.upstream}
+			pusher := NewPusher(mockClient)
+			pusher.outputWriter = &buf
+			pusher.confirm = ui.NewConfirmer(prompt.New(strings.NewReader(tt.answer), &buf), tt.interactive, ui.ConfirmSimple)
+			cm := config.NewConfigManager(testutil.NewMockGitClient())
+			cm.GetConfig().Git.DefaultRemote = tt.remote
+			pusher.withTracking(tracker).withDefaultRemote(cm)
+
+			pusher.Push([]string{"current"})
+			if tracker.setCalled != tt.wantSet || mockClient.pushCalled != tt.wantPush {
+				t.Errorf("set upstream = %v, push = %v; output:\n%s", tracker.setCalled, mockClient.pushCalled, buf.String())
+			}
+			wantRemote := "origin"
+			if tt.remote != "" {
+				wantRemote = tt.remote
+			}
+			if tt.wantSet && (tracker.setRemote != wantRemote || tracker.setBranch != "feature") {
+				t.Errorf("PushSetUpstream(%q, %q)", tracker.setRemote, tracker.setBranch)
+			}
+			if !strings.Contains(buf.String(), tt.wantOut) {
+				t.Errorf("output lacks %q:\n%s", tt.wantOut, buf.String())
+			}
+		})
+	}
+}
+
+type stubPatches string
+
+func (s stubPatches) OutgoingPatch() (string, error) { return string(s), nil }
+
+// leakyPatches returns outgoing commits that add an AWS key, and the key.
+func leakyPatches() (stubPatches, string) {
+	// Put together at run time so that scanning this file does not flag it.
+	key := "AKIA" + "IOSFODNN7EXAMPLE"
+	return stubPatches("\x00a1b2c3d\ndiff --git a/.env b/.env\n--- /dev/null\n+++ b/.env\n@@ -0,0 +1 @@\n+AWS_ACCESS_KEY_ID=" + key + "\n"), key
+}
+
+func TestPusher_Push_SecretScan(t *testing.T) {
+	leaky, key := leakyPatches()
+	tests := []struct {
+		name     string
+		always   bool
+		patches  stubPatches
+		args     []string
+		wantPush bool
+	}{
+		{"not asked for", false, leaky, []string{"current"}, true},
+		{"--scan", false, leaky, []string{"current", "--scan"}, false},
+		{"safety.scan-secrets", true, leaky, []string{"current"}, false},
+		{"force", true, leaky, []string{"force"}, false},
+		{"--no-scan", true, leaky, []string{"current", "--no-scan"}, true},
+		{"clean", true, stubPatches("\x00a1b2c3d\n"), []string{"current"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockPushGitClient{}
+			var buf bytes.Buffer
+			gate := newPushGate(nil).withSecretScan(tt.patches, nil)
+			gate.scanAlways = tt.always
+			p := NewPusher(client).withPushGate(gate)
+			p.outputWriter = &buf
+			p.Push(tt.args)
+			if client.pushCalled != tt.wantPush {
+				t.Fatalf("pushed = %v, want %v; output:\n%s", client.pushCalled, tt.wantPush, buf.String())
+			}
+			if tt.wantPush {
+				return
+			}
+			out := buf.String()
+			for _, want := range []string{"look like secrets", "a1b2c3d .env:1  aws-access-key-id", "AKIA****", "--no-scan"} {
+				if !strings.Contains(out, want) {
+					t.Errorf("output lacks %q:\n%s", want, out)
+				}
+			}
+			if strings.Contains(out, key) {
+				t.Errorf("output shows the key:\n%s", out)
+			}
+		})
+	}
+}
+
+type stubHead string
+
+func (s stubHead) CommitMessages(string) ([]git.CommitMessage, error) {
+	return []git.CommitMessage{{Hash: "a1b2c3d", Message: string(s)}}, nil
+}
+
+func TestPusher_Push_WIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		head     stubHead
+		args     []string
+		wantPush bool
+	}{
+		{"wip tip", "WIP: work in progress", []string{"current"}, false},
+		{"wip tip allowed", "WIP: work in progress", []string{"current", "--allow-wip"}, true},
+		{"wip tip forced", "WIP: work in progress", []string{"current", "--force-unsafe"}, false},
+		{"force with lease", "[WIP] half done", []string{"force"}, false},
+		{"ordinary tip", "Add login form", []string{"current"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockPushGitClient{}
+			var buf bytes.Buffer
+			p := NewPusher(client).withPushGate(newPushGate(tt.head))
+			p.outputWriter = &buf
+			p.Push(tt.args)
+			if client.pushCalled != tt.wantPush {
+				t.Fatalf("pushed = %v, want %v; output:\n%s", client.pushCalled, tt.wantPush, buf.String())
+			}
+			if !tt.wantPush && !strings.Contains(buf.String(), "ggc unwip") {
+				t.Errorf("output = %q", buf.String())
+			}
+		})
+	}
+}
@@ -2,21 +2,37 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"strconv"
 	"strings"
 
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/journal"
 	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/safety"
 )
 
+// todoEditor lets the user edit a rebase todo list. It returns false when
+// the user aborts.
+type todoEditor func(title string, entries []interactive.RebaseTodoEntry) ([]interactive.RebaseTodoEntry, bool)
+
 // Rebaser handles rebase operations.
 type Rebaser struct {
-	gitClient    git.RebaseOps
-	outputWriter io.Writer
-	helper       *Helper
-	prompter     prompt.Prompter
+	gitClient     git.RebaseOps
+	outputWriter  io.Writer
+	helper        *Helper
+	prompter      prompt.Prompter
+	undo          *Undoer
+	configManager *config.Manager
+	editTodo      todoEditor // nil falls back to git's sequence editor
+	guard         *branchGuard
+	stash         *autostasher // nil leaves uncommitted changes to git
 }
 
 // NewRebaser creates a new Rebaser instance.
@@ -24,21 +40,70 @@ func NewRebaser(client git.RebaseOps) *Rebaser {
 	output := os.Stdout
 	helper := NewHelper()
 	helper.outputWriter = output
-	return &Rebaser{
+	r := &Rebaser{
 		gitClient:    client,
 		outputWriter: output,
 		helper:       helper,
 		prompter:     prompt.New(os.Stdin, output),
 	}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		r.editTodo = r.runTodoEditor
+	}
+	return r
+}
+
+// withConfigManager shares the loaded config so the todo editor follows
+// the user's keybinding profile.
+func (r *Rebaser) withConfigManager(cm *config.Manager) *Rebaser {
+	r.configManager = cm
+	return r
+}
+
+// withGuard makes rebases respect safety.protected-branches.
+func (r *Rebaser) withGuard(g *branchGuard) *Rebaser {
+	r.guard = g
+	return r
+}
+
+func (r *Rebaser) runTodoEditor(title string, entries []interactive.RebaseTodoEntry) ([]interactive.RebaseTodoEntry, bool) {
+	var cfg *config.Config
+	if r.configManager != nil {
+		cfg = r.configManager.GetConfig()
+	}
+	return interactive.NewRebaseEditor(title, entries, cfg).Run()
+}
+
+// withAutostash stashes uncommitted changes around a rebase, as
+// behavior.autostash says.
+func (r *Rebaser) withAutostash(a *autostasher) *Rebaser {
+	r.stash = a
+	return r
+}
+
+// withUndo journals completed rebases so `ggc undo` can reverse them.
+func (r *Rebaser) withUndo(u *Undoer) *Rebaser {
+	r.undo = u
+	return r
 }
 
 // Rebase executes git rebase commands.
 func (r *Rebaser) Rebase(args []string) {
+	args, unsafe := safety.CutForceFlag(args)
 	if len(args) == 0 {
 		r.helper.ShowRebaseHelp()
 		return
 	}
 
+	switch args[0] {
+	case "continue", "abort", "skip":
+	default:
+		// Rebasing rewrites the checked-out branch.
+		if err := r.guard.checkCurrent("rebase", unsafe); err != nil {
+			WriteError(r.outputWriter, err)
+			return
+		}
+	}
+
 	switch args[0] {
 	case "interactive":
 		r.RebaseInteractive()
@@ -84,11 +149,16 @@ func (r *Rebaser) handleStandardRebase(ref string) {
 	if upstream == "" {
 		return
 	}
-	if err := r.gitClient.Rebase(upstream); err != nil {
-		WriteError(r.outputWriter, err)
-		return
-	}
-	WriteLine(r.outputWriter, "Rebase successful")
+	r.stash.around(r.outputWriter, "rebase", func() bool {
+		pending := r.undo.begin(journal.KindRebase, "rebase "+ref)
+		if err := r.gitClient.Rebase(upstream); err != nil {
+			WriteError(r.outputWriter, err)
+			return false
+		}
+		r.undo.commit(pending)
+		WriteLine(r.outputWriter, "Rebase successful")
+		return true
+	})
 }
 
 func (r *Rebaser) resolveUpstream(ref string) string {
@@ -114,11 +184,43 @@ func (r *Rebaser) RebaseInteractive() {
 	if !ok {
 		return
 	}
-	if err := r.gitClient.RebaseInteractive(num); err != nil {
-		WriteError(r.outputWriter, err)
+	todo, ok := r.editTodoFor(ctx, num)
+	if !ok {
+		WriteLine(r.outputWriter, "Rebase canceled")
 		return
 	}
-	WriteLine(r.outputWriter, "Rebase successful")
+	r.stash.around(r.outputWriter, "rebase", func() bool {
+		pending := r.undo.begin(journal.KindRebase, "rebase interactive")
+		var err error
+		if todo == "" {
+			err = r.gitClient.RebaseInteractive(num)
+		} else {
+			err = r.gitClient.RebaseInteractiveWithTodo(num, todo)
+		}
+		if err != nil {
+			WriteError(r.outputWriter, err)
+			return false
+		}
+		r.undo.commit(pending)
+		WriteLine(r.outputWriter, "Rebase successful")
+		return true
+	})
+}
+
+// editTodoFor lets the user edit the todo list for the last num commits in
+// the built-in editor. It returns an empty todo when no editor is
+// available, in which case git opens its own sequence editor.
+func (r *Rebaser) editTodoFor(ctx rebaseCtx, num int) (string, bool) {
+	if r.editTodo == nil {
+		return "", true
+	}
+	entries := interactive.ParseRebaseTodo(strings.Join(ctx.lines[len(ctx.lines)-num:], "\n"))
+	title := fmt.Sprintf("Interactive rebase of %d commit(s) on %s", num, ctx.currentBranch)
+	edited, ok := r.editTodo(title, entries)
+	if !ok {
+		return "", false
+	}
+	return interactive.FormatRebaseTodo(edited), true
 }
 
 // RebaseAutosquash executes interactive rebase with --autosquash.
@@ -132,11 +234,16 @@ func (r *Rebaser) RebaseAutosquash() {
 	if !ok {
 		return
 	}
-	if err := r.gitClient.RebaseInteractiveAutosquash(num); err != nil {
-		WriteError(r.outputWriter, err)
-		return
-	}
-	WriteLine(r.outputWriter, "Rebase successful")
+	r.stash.around(r.outputWriter, "rebase", func() bool {
+		pending := r.undo.begin(journal.KindRebase, "rebase autosquash")
+		if err := r.gitClient.RebaseInteractiveAutosquash(num); err != nil {
+			WriteError(r.outputWriter, err)
+			return false
+		}
+		r.undo.commit(pending)
+		WriteLine(r.outputWriter, "Rebase successful")
+		return true
+	})
 }
 
 type rebaseCtx struct {
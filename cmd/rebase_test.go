@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/bmf-san/ggc/v8/internal/interactive"
 	"github.com/bmf-san/ggc/v8/internal/prompt"
 )
 
@@ -167,6 +168,65 @@ func TestRebaser_RebaseInteractive_RebaseError(t *testing.T) {
 	}
 }
 
+func TestRebaser_RebaseInteractive_TodoEditor(t *testing.T) {
+	var buf bytes.Buffer
+	mockClient := &mockAddGitClient{}
+	var seen []interactive.RebaseTodoEntry
+	r := &Rebaser{
+		gitClient:    mockClient,
+		outputWriter: &buf,
+		helper:       NewHelper(),
+		prompter:     prompt.New(strings.NewReader("2\n"), &buf),
+		editTodo: func(_ string, entries []interactive.RebaseTodoEntry) ([]interactive.RebaseTodoEntry, bool) {
+			seen = entries
+			edited := []interactive.RebaseTodoEntry{entries[1], entries[0]}
+			edited[1].Action = interactive.RebaseDrop
+			return edited, true
+		},
+	}
+	r.helper.outputWriter = &buf
+
+	r.RebaseInteractive()
+
+	if len(seen) != 2 || seen[0].Hash != "def456" || seen[1].Hash != "ghi789" {
+		t.Fatalf("editor should receive the last 2 commits oldest first, got %+v", seen)
+	}
+	if want := "pick ghi789 Third commit\ndrop def456 Second commit\n"; mockClient.RebaseTodo != want {
+		t.Errorf("todo = %q, want %q", mockClient.RebaseTodo, want)
+	}
+	if !strings.Contains(buf.String(), "Rebase successful") {
+		t.Errorf("expected success message, got: %s", buf.String())
+	}
+}
+
+func TestRebaser_RebaseInteractive_TodoEditorAbort(t *testing.T) {
+	var buf bytes.Buffer
+	mockClient := &mockAddGitClient{}
+	called := false
+	mockClient.RebaseInteractiveFunc = func(int) error {
+		called = true
+		return nil
+	}
+	r := &Rebaser{
+		gitClient:    mockClient,
+		outputWriter: &buf,
+		helper:       NewHelper(),
+		prompter:     prompt.New(strings.NewReader("1\n"), &buf),
+		editTodo: func(string, []interactive.RebaseTodoEntry) ([]interactive.RebaseTodoEntry, bool) {
+			return nil, false
+		},
+	}
+
+	r.RebaseInteractive()
+
+	if called || mockClient.RebaseTodo != "" {
+		t.Error("aborting the editor must not start a rebase")
+	}
+	if !strings.Contains(buf.String(), "Rebase canceled") {
+		t.Errorf("expected cancel message, got: %s", buf.String())
+	}
+}
+
 func TestRebaser_Rebase(t *testing.T) {
 	cases := []struct {
 		name           string
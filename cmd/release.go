@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/commitmsg"
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/hosting"
+)
+
+// releaseVersionRe finds a version in a version file whose current
+// version is not known, such as before the first release.
+var releaseVersionRe = regexp.MustCompile(`\d+\.\d+\.\d+`)
+
+// releaseOps are the git operations behind ggc release.
+type releaseOps interface {
+	git.BranchReader
+	git.CommitMessageReader
+	git.NearestTagReader
+	git.TagAnnotator
+	git.UpstreamPusher
+	Add(files ...string) error
+	DiffWith(args []string) (string, error)
+	Commit(message string) error
+	TagExists(name string) bool
+	TagPush(remote, name string) error
+}
+
+// releaseProvider returns the hosting service behind a remote.
+type releaseProvider func(ctx context.Context, remote string) (hosting.Provider, error)
+
+// releaseOptions are the release.* settings after command-line overrides.
+type releaseOptions struct {
+	bump        *commitmsg.Bump // nil: worked out from the commits
+	dryRun      bool
+	tagPrefix   string
+	versionFile string
+	push        bool
+	publish     bool
+	remote      string
+}
+
+// version is a semantic version without pre-release or build parts.
+type version struct {
+	major, minor, patch int
+}
+
+func (v version) String() string { return fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch) }
+
+func (v version) bump(b commitmsg.Bump) version {
+	switch b {
+	case commitmsg.BumpMajor:
+		return version{major: v.major + 1}
+	case commitmsg.BumpMinor:
+		return version{major: v.major, minor: v.minor + 1}
+	default:
+		return version{major: v.major, minor: v.minor, patch: v.patch + 1}
+	}
+}
+
+// parseVersion reads "1.2.3".
+func parseVersion(s string) (version, bool) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return version{}, false
+	}
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || part != strconv.Itoa(n) {
+			return version{}, false
+		}
+		nums[i] = n
+	}
+	return version{nums[0], nums[1], nums[2]}, true
+}
+
+// releasePlan is what ggc release is about to do.
+type releasePlan struct {
+	branch   string
+	previous string // tag of the last release; empty for the first
+	current  version
+	next     version
+	bump     commitmsg.Bump
+	tag      string
+	notes    string
+	commits  int
+	// versionFile is release.version-file resolved against the top of
+	// the working tree; empty when it is not set.
+	versionFile string
+}
+
+// releaseStep is one stage of ggc release.
+type releaseStep struct {
+	title string
+	run   func() error
+}
+
+// Releaser handles ggc release.
+type Releaser struct {
+	gitClient     releaseOps
+	outputWriter  io.Writer
+	helper        *Helper
+	configManager *config.Manager
+	provider      releaseProvider // nil: releases cannot be published
+	topLevel      func() (string, error)
+}
+
+// NewReleaser creates a new Releaser instance.
+func NewReleaser(client releaseOps) *Releaser {
+	r := &Releaser{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+		topLevel:     os.Getwd,
+	}
+	r.helper.outputWriter = r.outputWriter
+	if reader, ok := client.(interface{ TopLevel() (string, error) }); ok {
+		r.topLevel = reader.TopLevel
+	}
+	return r
+}
+
+// withConfigManager supplies the release.* settings and
+// git.default-remote.
+func (r *Releaser) withConfigManager(cm *config.Manager) *Releaser {
+	r.configManager = cm
+	return r
+}
+
+// withProvider lets ggc release publish releases on the hosting service.
+func (r *Releaser) withProvider(provider releaseProvider) *Releaser {
+	r.provider = provider
+	return r
+}
+
+// Release works out the next version from the Conventional Commits since
+// the last release tag, or takes the bump from --major, --minor or
+// --patch, then updates the version file, tags the release, pushes it
+// and publishes it on the hosting service, as configured. Each stage is
+// numbered as it runs; --dry-run only lists them.
+func (r *Releaser) Release(args []string) {
+	opts, ok := r.parseArgs(args)
+	if !ok {
+		r.helper.ShowReleaseHelp()
+		return
+	}
+	if opts.publish && !opts.push {
+		WriteErrorf(r.outputWriter, "publishing a release needs its tag pushed; set release.push to true or drop --publish")
+		return
+	}
+	if opts.publish && r.provider == nil {
+		WriteErrorf(r.outputWriter, "publishing releases is not available")
+		return
+	}
+	plan, err := r.plan(opts)
+	if err != nil {
+		WriteError(r.outputWriter, err)
+		return
+	}
+
+	from := "no earlier release"
+	if plan.previous != "" {
+		from = "was " + plan.previous
+	}
+	_, _ = fmt.Fprintf(r.outputWriter, "Release %s (%s, %s; %d commit(s))\n", plan.tag, plan.bump, from, plan.commits)
+	steps := r.steps(plan, opts)
+	if opts.dryRun {
+		for i, step := range steps {
+			_, _ = fmt.Fprintf(r.outputWriter, "[%d/%d] %s\n", i+1, len(steps), step.title)
+		}
+		_, _ = fmt.Fprintf(r.outputWriter, "\n%s\nDry run: nothing was changed.\n", plan.notes)
+		return
+	}
+	for i, step := range steps {
+		_, _ = fmt.Fprintf(r.outputWriter, "[%d/%d] %s\n", i+1, len(steps), step.title)
+		if err := step.run(); err != nil {
+			WriteError(r.outputWriter, err)
+			if i > 0 {
+				WriteLine(r.outputWriter, "The steps before this one were done; finish the release by hand or undo them.")
+			}
+			return
+		}
+	}
+	_, _ = fmt.Fprintf(r.outputWriter, "Released %s\n", plan.tag)
+}
+
+// parseArgs reads the release.* settings and applies the command-line
+// overrides. ok is false for help and unknown arguments.
+func (r *Releaser) parseArgs(args []string) (releaseOptions, bool) {
+	opts := releaseOptions{tagPrefix: "v", push: true, remote: "origin"}
+	if r.configManager != nil {
+		cfg := r.configManager.GetConfig()
+		opts.tagPrefix = cfg.Release.TagPrefix
+		opts.versionFile = strings.TrimSpace(cfg.Release.VersionFile)
+		opts.push, opts.publish = cfg.Release.Push, cfg.Release.Publish
+		if remote := strings.TrimSpace(cfg.Git.DefaultRemote); remote != "" {
+			opts.remote = remote
+		}
+	}
+	for _, arg := range args {
+		var bump commitmsg.Bump
+		switch arg {
+		case "--major":
+			bump = commitmsg.BumpMajor
+		case "--minor":
+			bump = commitmsg.BumpMinor
+		case "--patch":
+			bump = commitmsg.BumpPatch
+		case "--dry-run", "-n":
+			opts.dryRun = true
+			continue
+		case "--no-push":
+			opts.push = false
+			continue
+		case "--publish":
+			opts.publish = true
+			continue
+		case "--no-publish":
+			opts.publish = false
+			continue
+		default:
+			return opts, false
+		}
+		if opts.bump != nil {
+			return opts, false
+		}
+		opts.bump = &bump
+	}
+	return opts, true
+}
+
+// plan reads the last release tag and the commits since it and works out
+// the next version and its notes.
+func (r *Releaser) plan(opts releaseOptions) (*releasePlan, error) {
+	branch, err := r.gitClient.GetCurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+	if branch == "" || branch == "HEAD" {
+		return nil, fmt.Errorf("release needs a branch, but HEAD is detached")
+	}
+	plan := &releasePlan{branch: branch}
+
+	// No tag is not an error: it is the first release.
+	plan.previous, _ = r.gitClient.NearestTag("HEAD")
+	revRange := "HEAD"
+	if plan.previous != "" {
+		current, ok := parseVersion(strings.TrimPrefix(plan.previous, opts.tagPrefix))
+		if !strings.HasPrefix(plan.previous, opts.tagPrefix) || !ok {
+			return nil, fmt.Errorf("the last tag, %s, is not a %sMAJOR.MINOR.PATCH version; tag the release yourself or set release.tag-prefix", plan.previous, opts.tagPrefix)
+		}
+		plan.current = current
+		revRange = plan.previous + "..HEAD"
+	}
+
+	messages, err := r.gitClient.CommitMessages(revRange)
+	if err != nil {
+		return nil, err
+	}
+	var parsed []commitmsg.Message
+	var changes []commitmsg.Change
+	for _, m := range messages {
+		if strings.HasPrefix(m.Message, "Merge ") {
+			continue
+		}
+		msg := commitmsg.Parse(m.Message)
+		parsed = append(parsed, msg)
+		changes = append(changes, commitmsg.Change{Hash: m.Hash, Message: msg})
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("nothing to release: no commits since %s", plan.previous)
+	}
+	plan.commits = len(changes)
+
+	plan.bump = commitmsg.BumpFor(parsed)
+	if opts.bump != nil {
+		plan.bump = *opts.bump
+	}
+	if plan.previous == "" {
+		// The first release starts at 0.1.0 unless asked for more.
+		plan.next = version{minor: 1}
+		if plan.bump == commitmsg.BumpMajor && opts.bump != nil {
+			plan.next = version{major: 1}
+		}
+	} else {
+		plan.next = plan.current.bump(plan.bump)
+	}
+	plan.tag = opts.tagPrefix + plan.next.String()
+	if r.gitClient.TagExists(plan.tag) {
+		return nil, fmt.Errorf("tag %s already exists", plan.tag)
+	}
+	plan.notes = commitmsg.PlainNotes(commitmsg.Group(changes))
+	if opts.versionFile != "" {
+		if plan.versionFile, err = r.prepareVersionFile(opts.versionFile); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// prepareVersionFile resolves path against the top of the working tree,
+// so the file is found and staged from any directory. The release commit
+// takes whatever is staged, so it refuses to go ahead while anything is.
+func (r *Releaser) prepareVersionFile(path string) (string, error) {
+	staged, err := r.gitClient.DiffWith([]string{"--cached", "--name-only"})
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(staged) != "" {
+		return "", fmt.Errorf("changes are staged and would go into the release commit; commit or unstage them first")
+	}
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
+	root, err := r.topLevel()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, path), nil
+}
+
+// steps lists the stages of the release in plan.
+func (r *Releaser) steps(plan *releasePlan, opts releaseOptions) []releaseStep {
+	var steps []releaseStep
+	if opts.versionFile != "" {
+		commitMessage := "chore(release): " + plan.tag
+		steps = append(steps,
+			releaseStep{fmt.Sprintf("Update %s to %s", opts.versionFile, plan.next), func() error {
+				return r.updateVersionFile(plan)
+			}},
+			releaseStep{fmt.Sprintf("Commit %q", commitMessage), func() error {
+				if err := r.gitClient.Add(plan.versionFile); err != nil {
+					return err
+				}
+				return r.gitClient.Commit(commitMessage)
+			}},
+		)
+	}
+	steps = append(steps, releaseStep{"Tag " + plan.tag, func() error {
+		return r.gitClient.TagCreateAnnotatedAt(plan.tag, "", plan.tag+"\n\n"+plan.notes)
+	}})
+	if opts.push {
+		if opts.versionFile != "" {
+			steps = append(steps, releaseStep{fmt.Sprintf("Push %s to %s", plan.branch, opts.remote), func() error {
+				return r.gitClient.PushSetUpstream(opts.remote, plan.branch)
+			}})
+		}
+		steps = append(steps, releaseStep{fmt.Sprintf("Push %s to %s", plan.tag, opts.remote), func() error {
+			return r.gitClient.TagPush(opts.remote, plan.tag)
+		}})
+	}
+	if opts.publish {
+		steps = append(steps, releaseStep{"Publish the release", func() error {
+			return r.publish(plan, opts.remote)
+		}})
+	}
+	return steps
+}
+
+// updateVersionFile replaces the current version in the version file with
+// the next one. Before the first release, when there is no current
+// version, it replaces the first version it finds.
+func (r *Releaser) updateVersionFile(plan *releasePlan) error {
+	path := plan.versionFile
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	content := string(data)
+	old := plan.current.String()
+	if plan.previous == "" || !strings.Contains(content, old) {
+		old = releaseVersionRe.FindString(content)
+		if old == "" {
+			return fmt.Errorf("no version found in %s", path)
+		}
+	}
+	content = strings.Replace(content, old, plan.next.String(), 1)
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+// publish creates the release for the pushed tag on the hosting service
+// of remote.
+func (r *Releaser) publish(plan *releasePlan, remote string) error {
+	ctx := context.Background()
+	provider, err := r.provider(ctx, remote)
+	if err != nil {
+		return err
+	}
+	rel, err := provider.CreateRelease(ctx, hosting.NewRelease{Tag: plan.tag, Name: plan.tag, Body: plan.notes})
+	if err != nil {
+		return err
+	}
+	if rel.URL != "" {
+		_, _ = fmt.Fprintf(r.outputWriter, "      %s\n", rel.URL)
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/hosting"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockReleaseClient struct {
+	testutil.MockGitClient
+	tag      string
+	messages []git.CommitMessage
+	root     string
+	staged   string
+	read     string
+	calls    []string
+}
+
+func (m *mockReleaseClient) GetCurrentBranch() (string, error)   { return "main", nil }
+func (m *mockReleaseClient) NearestTag(_ string) (string, error) { return m.tag, nil }
+func (m *mockReleaseClient) TagExists(_ string) bool             { return false }
+func (m *mockReleaseClient) TopLevel() (string, error)           { return m.root, nil }
+
+func (m *mockReleaseClient) CommitMessages(revRange string) ([]git.CommitMessage, error) {
+	m.read = revRange
+	return m.messages, nil
+}
+
+func (m *mockReleaseClient) DiffWith(args []string) (string, error) {
+	if strings.Join(args, " ") != "--cached --name-only" {
+		return "", fmt.Errorf("unexpected git diff %v", args)
+	}
+	return m.staged, nil
+}
+
+func (m *mockReleaseClient) Add(files ...string) error {
+	m.calls = append(m.calls, "add "+strings.Join(files, " "))
+	return nil
+}
+
+func (m *mockReleaseClient) Commit(message string) error {
+	m.calls = append(m.calls, "commit "+message)
+	return nil
+}
+
+func (m *mockReleaseClient) TagCreateAnnotatedAt(name, _, message string) error {
+	m.calls = append(m.calls, "tag "+name+" "+strings.SplitN(message, "\n", 2)[0])
+	return nil
+}
+
+func (m *mockReleaseClient) PushSetUpstream(remote, branch string) error {
+	m.calls = append(m.calls, "push "+remote+" "+branch)
+	return nil
+}
+
+func (m *mockReleaseClient) TagPush(remote, name string) error {
+	m.calls = append(m.calls, "push "+remote+" "+name)
+	return nil
+}
+
+type fakeReleaseProvider struct {
+	hosting.Provider
+	created []hosting.NewRelease
+}
+
+func (p *fakeReleaseProvider) CreateRelease(_ context.Context, rel hosting.NewRelease) (*hosting.Release, error) {
+	p.created = append(p.created, rel)
+	return &hosting.Release{Tag: rel.Tag, URL: "https://example.com/releases/" + rel.Tag}, nil
+}
+
+func newReleaseFixture(t *testing.T) *mockReleaseClient {
+	return &mockReleaseClient{
+		tag:  "v1.2.3",
+		root: t.TempDir(),
+		messages: []git.CommitMessage{
+			{Hash: "ccc333", Message: "Merge branch 'topic'"},
+			{Hash: "bbb222", Message: "feat(ui): dark mode"},
+			{Hash: "aaa111", Message: "fix: crash on start"},
+		},
+	}
+}
+
+func newTestReleaser(client *mockReleaseClient, buf *bytes.Buffer) *Releaser {
+	r := NewReleaser(client)
+	r.outputWriter = buf
+	r.helper.outputWriter = buf
+	return r
+}
+
+func TestReleaser_NextVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		messages []string
+		args     []string
+		want     string
+	}{
+		{"feature", "v1.2.3", []string{"feat: a", "fix: b"}, nil, "Release v1.3.0 (minor, was v1.2.3; 2 commit(s))"},
+		{"fixes", "v1.2.3", []string{"fix: b"}, nil, "Release v1.2.4 (patch, was v1.2.3; 1 commit(s))"},
+		{"breaking", "v1.2.3", []string{"fix: b\n\nBREAKING CHANGE: gone"}, nil, "Release v2.0.0 (major, was v1.2.3; 1 commit(s))"},
+		{"forced", "v1.2.3", []string{"feat: a"}, []string{"--patch"}, "Release v1.2.4 (patch, was v1.2.3; 1 commit(s))"},
+		{"first release", "", []string{"feat!: a"}, nil, "Release v0.1.0 (major, no earlier release; 1 commit(s))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newReleaseFixture(t)
+			client.tag = tt.tag
+			client.messages = nil
+			for i, m := range tt.messages {
+				client.messages = append(client.messages, git.CommitMessage{Hash: string(rune('a' + i)), Message: m})
+			}
+			var buf bytes.Buffer
+			newTestReleaser(client, &buf).Release(append(tt.args, "--dry-run"))
+			if !strings.HasPrefix(buf.String(), tt.want+"\n") {
+				t.Errorf("output %q should start with %q", buf.String(), tt.want)
+			}
+			if len(client.calls) != 0 {
+				t.Errorf("dry run made calls %v", client.calls)
+			}
+		})
+	}
+}
+
+func TestReleaser_DryRun(t *testing.T) {
+	var buf bytes.Buffer
+	client := newReleaseFixture(t)
+	newTestReleaser(client, &buf).Release([]string{"--dry-run"})
+
+	want := "Release v1.3.0 (minor, was v1.2.3; 2 commit(s))\n" +
+		"[1/2] Tag v1.3.0\n" +
+		"[2/2] Push v1.3.0 to origin\n" +
+		"\nFeatures:\n- ui: dark mode (bbb222)\n\nBug Fixes:\n- crash on start (aaa111)\n\n" +
+		"Dry run: nothing was changed.\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+	if client.read != "v1.2.3..HEAD" {
+		t.Errorf("read %q", client.read)
+	}
+}
+
+func TestReleaser_Release(t *testing.T) {
+	var buf bytes.Buffer
+	client := newReleaseFixture(t)
+	versionFile := filepath.Join(client.root, "VERSION")
+	if err := os.WriteFile(versionFile, []byte("1.2.3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	provider := &fakeReleaseProvider{}
+	r := newTestReleaser(client, &buf).withProvider(func(_ context.Context, remote string) (hosting.Provider, error) {
+		if remote != "origin" {
+			t.Errorf("remote = %q", remote)
+		}
+		return provider, nil
+	})
+
+	opts, _ := r.parseArgs([]string{"--publish"})
+	opts.versionFile = "VERSION"
+	plan, err := r.plan(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range r.steps(plan, opts) {
+		if err := step.run(); err != nil {
+			t.Fatalf("%s: %v", step.title, err)
+		}
+	}
+
+	// The version file is staged by its full path, which is right from
+	// any directory in the working tree.
+	want := "add " + versionFile + "; commit chore(release): v1.3.0; tag v1.3.0 v1.3.0; push origin main; push origin v1.3.0"
+	if got := strings.Join(client.calls, "; "); got != want {
+		t.Errorf("calls = %q, want %q", got, want)
+	}
+	if data, _ := os.ReadFile(versionFile); string(data) != "1.3.0\n" {
+		t.Errorf("version file = %q", data)
+	}
+	if len(provider.created) != 1 || provider.created[0].Tag != "v1.3.0" || !strings.Contains(provider.created[0].Body, "- ui: dark mode (bbb222)") {
+		t.Errorf("created %+v", provider.created)
+	}
+}
+
+func TestReleaser_Refuses(t *testing.T) {
+	tests := []struct {
+		name  string
+		tag   string
+		args  []string
+		want  string
+		empty bool
+	}{
+		{"not a version", "nightly", nil, "the last tag, nightly, is not a vMAJOR.MINOR.PATCH version", false},
+		{"nothing new", "v1.2.3", nil, "nothing to release", true},
+		{"publish without push", "v1.2.3", []string{"--publish", "--no-push"}, "needs its tag pushed", false},
+		{"two bumps", "v1.2.3", []string{"--major", "--minor"}, "Usage:", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newReleaseFixture(t)
+			client.tag = tt.tag
+			if tt.empty {
+				client.messages = nil
+			}
+			var buf bytes.Buffer
+			newTestReleaser(client, &buf).Release(tt.args)
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("output %q should contain %q", buf.String(), tt.want)
+			}
+			if len(client.calls) != 0 {
+				t.Errorf("calls = %v, want none", client.calls)
+			}
+		})
+	}
+}
+
+func TestReleaser_RefusesStagedChanges(t *testing.T) {
+	client := newReleaseFixture(t)
+	client.staged = "notes.txt\n"
+	if err := os.WriteFile(filepath.Join(client.root, "VERSION"), []byte("1.2.3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	r := newTestReleaser(client, &buf)
+	opts, _ := r.parseArgs(nil)
+	opts.versionFile = "VERSION"
+	if _, err := r.plan(opts); err == nil || !strings.Contains(err.Error(), "changes are staged") {
+		t.Errorf("plan() error = %v, want a refusal while changes are staged", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(client.root, "VERSION")); string(data) != "1.2.3\n" {
+		t.Errorf("version file changed to %q", data)
+	}
+
+	// Without a version file there is no release commit to fold them into.
+	opts.versionFile = ""
+	if _, err := r.plan(opts); err != nil {
+		t.Errorf("plan() without a version file: %v", err)
+	}
+}
@@ -5,15 +5,31 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/hosting"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
+// remoteNoCheck skips the reachability check before a URL is saved.
+const remoteNoCheck = "--no-check"
+
+// remoteURLTools reads a remote's URL and checks that a URL answers, for
+// remote convert and the check before add and set-url.
+type remoteURLTools interface {
+	git.RemoteURLReader
+	git.RemoteProber
+}
+
 // Remoter provides functionality for the remote command.
 type Remoter struct {
 	gitClient    git.RemoteManager
 	outputWriter io.Writer
 	helper       *Helper
+	confirm      *ui.Confirmer
+	renamer      git.RemoteRenamer // nil: remote rename is unavailable
+	urls         remoteURLTools    // nil: no convert, and URLs are saved unchecked
 }
 
 // NewRemoter creates a new Remoter.
@@ -27,6 +43,25 @@ func NewRemoter(client git.RemoteManager) *Remoter {
 	return r
 }
 
+// withConfirmer makes remote remove ask first.
+func (r *Remoter) withConfirmer(c *ui.Confirmer) *Remoter {
+	r.confirm = c
+	return r
+}
+
+// withRenamer enables remote rename.
+func (r *Remoter) withRenamer(renamer git.RemoteRenamer) *Remoter {
+	r.renamer = renamer
+	return r
+}
+
+// withURLTools enables remote convert, and makes add, set-url and convert
+// check that the new URL answers before saving it.
+func (r *Remoter) withURLTools(urls remoteURLTools) *Remoter {
+	r.urls = urls
+	return r
+}
+
 // Remote executes the remote command with the given arguments.
 func (r *Remoter) Remote(args []string) {
 	if len(args) == 0 {
@@ -34,6 +69,13 @@ func (r *Remoter) Remote(args []string) {
 		return
 	}
 
+	check := !slices.Contains(args, remoteNoCheck)
+	if !check && args[0] != "add" && args[0] != "set-url" && args[0] != "convert" {
+		r.helper.ShowRemoteHelp()
+		return
+	}
+	args = slices.DeleteFunc(slices.Clone(args), func(arg string) bool { return arg == remoteNoCheck })
+
 	switch args[0] {
 	case "list":
 		r.remoteList()
@@ -42,7 +84,7 @@ func (r *Remoter) Remote(args []string) {
 			r.helper.ShowRemoteHelp()
 			return
 		}
-		r.remoteAdd(args[1], args[2])
+		r.remoteAdd(args[1], args[2], check)
 	case "remove":
 		if len(args) != 2 {
 			r.helper.ShowRemoteHelp()
@@ -54,7 +96,15 @@ func (r *Remoter) Remote(args []string) {
 			r.helper.ShowRemoteHelp()
 			return
 		}
-		r.remoteSetURL(args[1], args[2])
+		r.remoteSetURL(args[1], args[2], check)
+	case "rename":
+		if len(args) != 3 || r.renamer == nil {
+			r.helper.ShowRemoteHelp()
+			return
+		}
+		r.remoteRename(args[1], args[2])
+	case "convert":
+		r.remoteConvert(args[1:], check)
 	default:
 		r.helper.ShowRemoteHelp()
 	}
@@ -66,7 +116,10 @@ func (r *Remoter) remoteList() {
 	}
 }
 
-func (r *Remoter) remoteAdd(name, url string) {
+func (r *Remoter) remoteAdd(name, url string, check bool) {
+	if check && !r.reachable(url) {
+		return
+	}
 	if err := r.gitClient.RemoteAdd(name, url); err != nil {
 		WriteError(r.outputWriter, err)
 		return
@@ -75,6 +128,10 @@ func (r *Remoter) remoteAdd(name, url string) {
 }
 
 func (r *Remoter) remoteRemove(name string) {
+	ok, err := r.confirm.ConfirmTyped(fmt.Sprintf("Remove remote '%s' and its remote-tracking branches?", name), name)
+	if !proceed(r.outputWriter, ok, err) {
+		return
+	}
 	if err := r.gitClient.RemoteRemove(name); err != nil {
 		WriteError(r.outputWriter, err)
 		return
@@ -82,10 +139,92 @@ func (r *Remoter) remoteRemove(name string) {
 	_, _ = fmt.Fprintf(r.outputWriter, "Remote '%s' removed\n", name)
 }
 
-func (r *Remoter) remoteSetURL(name, url string) {
+func (r *Remoter) remoteSetURL(name, url string, check bool) {
+	if check && !r.reachable(url) {
+		return
+	}
 	if err := r.gitClient.RemoteSetURL(name, url); err != nil {
 		WriteError(r.outputWriter, err)
 		return
 	}
 	_, _ = fmt.Fprintf(r.outputWriter, "Remote '%s' URL updated\n", name)
 }
+
+func (r *Remoter) remoteRename(oldName, newName string) {
+	if err := r.renamer.RemoteRename(oldName, newName); err != nil {
+		WriteError(r.outputWriter, err)
+		return
+	}
+	_, _ = fmt.Fprintf(r.outputWriter, "Remote '%s' renamed to '%s'\n", oldName, newName)
+}
+
+// remoteConvert switches a remote between its SSH and HTTPS URLs:
+//
+//	convert [<name>] --ssh|--https
+//
+// The name defaults to origin.
+func (r *Remoter) remoteConvert(args []string, check bool) {
+	name, toSSH, ok := parseRemoteConvertArgs(args)
+	if !ok || r.urls == nil {
+		r.helper.ShowRemoteHelp()
+		return
+	}
+	current, err := r.urls.RemoteGetURL(name)
+	if err != nil {
+		WriteError(r.outputWriter, err)
+		return
+	}
+	converted, err := hosting.ConvertURL(current, toSSH)
+	if err != nil {
+		WriteError(r.outputWriter, err)
+		return
+	}
+	if converted == current {
+		_, _ = fmt.Fprintf(r.outputWriter, "Remote '%s' already uses %s\n", name, converted)
+		return
+	}
+	if check && !r.reachable(converted) {
+		return
+	}
+	if err := r.gitClient.RemoteSetURL(name, converted); err != nil {
+		WriteError(r.outputWriter, err)
+		return
+	}
+	_, _ = fmt.Fprintf(r.outputWriter, "Remote '%s' now uses %s (was %s)\n", name, converted, current)
+}
+
+func parseRemoteConvertArgs(args []string) (name string, toSSH, ok bool) {
+	name = "origin"
+	named := false
+	protocols := 0
+	for _, arg := range args {
+		switch arg {
+		case "--ssh":
+			toSSH = true
+			protocols++
+		case "--https":
+			protocols++
+		default:
+			if named || arg == "" || arg[0] == '-' {
+				return "", false, false
+			}
+			name, named = arg, true
+		}
+	}
+	return name, toSSH, protocols == 1
+}
+
+// reachable checks url with git ls-remote before it is saved, reporting
+// why it failed when it does.
+func (r *Remoter) reachable(url string) bool {
+	if r.urls == nil {
+		return true
+	}
+	_, _ = fmt.Fprintf(r.outputWriter, "Checking %s...\n", url)
+	if err := r.urls.RemoteReachable(url); err != nil {
+		WriteError(r.outputWriter, err)
+		_, _ = fmt.Fprintf(r.outputWriter, "Not saved. Add %s to save the URL anyway.\n", remoteNoCheck)
+		return false
+	}
+	return true
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 type mockRemoteManager struct {
@@ -316,3 +317,118 @@ func TestRemoter_ErrorPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoter_RemoveRefusesWithoutTerminal(t *testing.T) {
+	m := &mockRemoteManager{}
+	var buf bytes.Buffer
+	r := &Remoter{gitClient: m, outputWriter: &buf, helper: NewHelper()}
+	r.helper.outputWriter = &buf
+	r.confirm = ui.NewConfirmer(nil, false, ui.ConfirmSimple)
+
+	r.Remote([]string{"remove", "origin"})
+	if m.removeCalled {
+		t.Fatal("RemoteRemove must not run without confirmation")
+	}
+	if !strings.Contains(buf.String(), "--yes") {
+		t.Errorf("expected a hint about --yes, got %q", buf.String())
+	}
+
+	ui.SetAssumeYes(true)
+	t.Cleanup(func() { ui.SetAssumeYes(false) })
+	r.Remote([]string{"remove", "origin"})
+	if !m.removeCalled {
+		t.Fatal("expected --yes to skip the confirmation")
+	}
+}
+
+type mockRemoteURLTools struct {
+	url         string
+	reachErr    error
+	checked     []string
+	renamedFrom string
+	renamedTo   string
+}
+
+func (m *mockRemoteURLTools) RemoteGetURL(_ string) (string, error) { return m.url, nil }
+func (m *mockRemoteURLTools) RemoteReachable(url string) error {
+	m.checked = append(m.checked, url)
+	return m.reachErr
+}
+func (m *mockRemoteURLTools) RemoteRename(oldName, newName string) error {
+	m.renamedFrom, m.renamedTo = oldName, newName
+	return nil
+}
+
+func newURLRemoter(t *testing.T, tools *mockRemoteURLTools) (*Remoter, *mockRemoteManager, *bytes.Buffer) {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	client := &mockRemoteManager{}
+	r := (&Remoter{gitClient: client, outputWriter: buf, helper: NewHelper()}).withRenamer(tools).withURLTools(tools)
+	r.helper.outputWriter = buf
+	return r, client, buf
+}
+
+func TestRemoter_Convert(t *testing.T) {
+	tools := &mockRemoteURLTools{url: "https://github.com/user/repo.git"}
+	r, client, buf := newURLRemoter(t, tools)
+
+	r.Remote([]string{"convert", "--ssh"})
+	if client.setName != "origin" || client.setURL != "git@github.com:user/repo.git" {
+		t.Errorf("set-url = %q %q", client.setName, client.setURL)
+	}
+	if len(tools.checked) != 1 || tools.checked[0] != "git@github.com:user/repo.git" {
+		t.Errorf("checked = %v", tools.checked)
+	}
+	if !strings.Contains(buf.String(), "Remote 'origin' now uses git@github.com:user/repo.git (was https://github.com/user/repo.git)") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	buf.Reset()
+	client.setURLCalled = false
+	r.Remote([]string{"convert", "upstream", "--https"})
+	if client.setURLCalled || !strings.Contains(buf.String(), "Remote 'upstream' already uses") {
+		t.Errorf("converting to the current protocol: %q", buf.String())
+	}
+
+	buf.Reset()
+	r.Remote([]string{"convert", "--ssh", "--https"})
+	if !strings.Contains(buf.String(), "Usage: ggc remote <command>") {
+		t.Errorf("both protocols should show the usage: %q", buf.String())
+	}
+}
+
+func TestRemoter_ChecksURLBeforeSaving(t *testing.T) {
+	tools := &mockRemoteURLTools{reachErr: git.NewOpError("reach remote", "git ls-remote", errors.New("fatal: Could not resolve host: github.example"))}
+	r, client, buf := newURLRemoter(t, tools)
+
+	r.Remote([]string{"add", "upstream", "https://github.example/user/repo.git"})
+	_ = takeFailure()
+	if client.addCalled {
+		t.Error("an unreachable URL was saved")
+	}
+	for _, want := range []string{"Error: git: reach remote failed", "Next: check your connection", "Not saved. Add --no-check"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output lacks %q: %q", want, buf.String())
+		}
+	}
+
+	r.Remote([]string{"add", "upstream", "https://github.example/user/repo.git", "--no-check"})
+	if !client.addCalled || client.addURL != "https://github.example/user/repo.git" {
+		t.Errorf("--no-check should save the URL, add = %v %q", client.addCalled, client.addURL)
+	}
+	if len(tools.checked) != 1 {
+		t.Errorf("--no-check still checked: %v", tools.checked)
+	}
+}
+
+func TestRemoter_Rename(t *testing.T) {
+	tools := &mockRemoteURLTools{}
+	r, _, buf := newURLRemoter(t, tools)
+	r.Remote([]string{"rename", "origin", "upstream"})
+	if tools.renamedFrom != "origin" || tools.renamedTo != "upstream" {
+		t.Errorf("renamed %q to %q", tools.renamedFrom, tools.renamedTo)
+	}
+	if !strings.Contains(buf.String(), "Remote 'origin' renamed to 'upstream'") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
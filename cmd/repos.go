@@ -0,0 +1,446 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/selfexec"
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+const (
+	// defaultReposParallel is how many repositories ggc repos runs at once
+	// when repos.parallel is not set.
+	defaultReposParallel = 4
+	// reposScanDepth is how far below a repos.roots directory repositories
+	// are looked for.
+	reposScanDepth = 3
+)
+
+// repoEntry is a repository ggc repos runs in.
+type repoEntry struct {
+	path  string
+	label string // the prefix of its output
+}
+
+// repoResult is how a command went in one repository.
+type repoResult struct {
+	repo     repoEntry
+	err      error
+	duration time.Duration
+	last     string // the last line of output, which says why it failed
+}
+
+// reposDashboard is the workspace dashboard; each Run reads the
+// repositories' state again.
+type reposDashboard interface {
+	Run() (interactive.DashboardAction, bool, error)
+}
+
+// Repoer handles ggc repos, which runs ggc commands across the
+// repositories in repos.paths and under repos.roots.
+type Repoer struct {
+	outputWriter  io.Writer
+	helper        *Helper
+	configManager *config.Manager
+	prompter      prompt.Prompter
+	run           func(dir string, args []string, out io.Writer) error
+	// interact runs ggc in a repository on the terminal, for the
+	// dashboard.
+	interact    func(dir string, args []string) error
+	userHomeDir func() (string, error)
+	// newDashboard is nil when stdin is not a terminal.
+	newDashboard func(repos []interactive.DashboardRepo) reposDashboard
+}
+
+// NewRepoer creates a new Repoer instance.
+func NewRepoer() *Repoer {
+	r := &Repoer{
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+		prompter:     prompt.New(os.Stdin, os.Stdout),
+		run:          selfexec.Run,
+		interact:     selfexec.Interact,
+		userHomeDir:  os.UserHomeDir,
+	}
+	r.helper.outputWriter = r.outputWriter
+	return r
+}
+
+// withConfigManager supplies repos.paths, repos.roots and repos.parallel,
+// and saves the repositories ggc repos add and remove change.
+func (r *Repoer) withConfigManager(cm *config.Manager) *Repoer {
+	r.configManager = cm
+	return r
+}
+
+// withDashboard enables ggc repos dashboard when stdin is a terminal.
+func (r *Repoer) withDashboard(client git.RepoStateReader, cm *config.Manager) *Repoer {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return r
+	}
+	r.newDashboard = func(repos []interactive.DashboardRepo) reposDashboard {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewReposDashboard(client, repos, cfg)
+	}
+	return r
+}
+
+// Repos lists the repositories, changes which are listed, or runs a ggc
+// command in each of them.
+func (r *Repoer) Repos(args []string) {
+	if len(args) == 0 || args[0] == "help" {
+		r.helper.ShowReposHelp()
+		return
+	}
+	if r.configManager == nil {
+		WriteErrorf(r.outputWriter, "ggc repos needs the configuration")
+		return
+	}
+	var err error
+	switch args[0] {
+	case "list":
+		r.list()
+	case "add":
+		err = r.add(args[1:])
+	case "remove":
+		err = r.remove(args[1:])
+	case "exec":
+		if len(args) > 1 && args[1] == "--" {
+			args = args[1:]
+		}
+		r.runAll(args[1:])
+	case "sync":
+		r.runAll(args)
+	case "dashboard":
+		r.dashboard()
+	default:
+		r.helper.ShowReposHelp()
+	}
+	if err != nil {
+		WriteError(r.outputWriter, err)
+	}
+}
+
+// list prints the repositories with their paths.
+func (r *Repoer) list() {
+	repos := r.repositories()
+	if len(repos) == 0 {
+		WriteLine(r.outputWriter, "No repositories. Add one with 'ggc repos add [<path>]', or set repos.roots.")
+		return
+	}
+	width := 0
+	for _, repo := range repos {
+		width = max(width, len(repo.label))
+	}
+	for _, repo := range repos {
+		note := ""
+		if !isRepositoryRoot(repo.path) {
+			note = "  (missing)"
+		}
+		WriteLinef(r.outputWriter, "%-*s  %s%s", width, repo.label, repo.path, note)
+	}
+}
+
+// add puts the repositories at paths, the current directory when there
+// are none, into repos.paths and saves the config.
+func (r *Repoer) add(paths []string) error {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	cfg := r.configManager.GetConfig()
+	previous := slices.Clone(cfg.Repos.Paths)
+	var added []string
+	for _, path := range paths {
+		abs, err := filepath.Abs(r.expandHome(path))
+		if err != nil {
+			return err
+		}
+		if !isRepositoryRoot(abs) {
+			return fmt.Errorf("%s is not the top of a git repository", path)
+		}
+		if slices.Contains(cfg.Repos.Paths, abs) {
+			WriteLinef(r.outputWriter, "%s is already listed.", abs)
+			continue
+		}
+		cfg.Repos.Paths = append(cfg.Repos.Paths, abs)
+		added = append(added, abs)
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	if err := r.configManager.Save(); err != nil {
+		cfg.Repos.Paths = previous
+		return err
+	}
+	for _, path := range added {
+		WriteLinef(r.outputWriter, "Added %s.", path)
+	}
+	return nil
+}
+
+// remove takes a repository, given by path or by its name in the list,
+// out of repos.paths and saves the config.
+func (r *Repoer) remove(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: ggc repos remove <path|name>")
+	}
+	cfg := r.configManager.GetConfig()
+	abs, _ := filepath.Abs(r.expandHome(args[0]))
+	target := ""
+	for _, repo := range r.labeled(cfg.Repos.Paths) {
+		if repo.path == abs || repo.label == args[0] {
+			target = repo.path
+		}
+	}
+	if target == "" {
+		return fmt.Errorf("%s is not in repos.paths; 'ggc repos list' shows them", args[0])
+	}
+	previous := slices.Clone(cfg.Repos.Paths)
+	cfg.Repos.Paths = slices.DeleteFunc(cfg.Repos.Paths, func(p string) bool { return r.expandHome(p) == target })
+	if err := r.configManager.Save(); err != nil {
+		cfg.Repos.Paths = previous
+		return err
+	}
+	WriteLinef(r.outputWriter, "Removed %s.", target)
+	return nil
+}
+
+// runAll runs ggc with args in every repository, repos.parallel at a
+// time, with each line of output prefixed with the repository's name,
+// and ends with a table of how it went in each.
+func (r *Repoer) runAll(args []string) {
+	if len(args) == 0 {
+		WriteErrorf(r.outputWriter, "name the ggc command to run, as in 'ggc repos exec -- status'")
+		return
+	}
+	repos := r.repositories()
+	if len(repos) == 0 {
+		WriteErrorf(r.outputWriter, "no repositories; add one with 'ggc repos add [<path>]', or set repos.roots")
+		return
+	}
+	parallel := defaultReposParallel
+	if n := r.configManager.GetConfig().Repos.Parallel; n > 0 {
+		parallel = n
+	}
+	width := 0
+	for _, repo := range repos {
+		width = max(width, len(repo.label))
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, parallel)
+	results := make([]repoResult, len(repos))
+	for i, repo := range repos {
+		wg.Go(func() {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			out := ui.NewPrefixWriter(r.outputWriter, fmt.Sprintf("[%-*s] ", width, repo.label), &mu)
+			var captured bytes.Buffer
+			start := time.Now()
+			err := r.runIn(repo, args, io.MultiWriter(out, &captured))
+			out.Flush()
+			results[i] = repoResult{repo: repo, err: err, duration: time.Since(start), last: lastLine(captured.String())}
+		})
+	}
+	wg.Wait()
+	r.summarize(results, width)
+}
+
+// runIn runs ggc with args in repo, or reports it missing.
+func (r *Repoer) runIn(repo repoEntry, args []string, out io.Writer) error {
+	if !isRepositoryRoot(repo.path) {
+		err := fmt.Errorf("%s is not a git repository", repo.path)
+		_, _ = fmt.Fprintln(out, err)
+		return err
+	}
+	return r.run(repo.path, args, out)
+}
+
+// summarize prints a line for each repository with its result and run
+// time, and fails when any of them failed.
+func (r *Repoer) summarize(results []repoResult, width int) {
+	w := r.outputWriter
+	width = max(width, len("REPO"))
+	WriteLine(w, "")
+	WriteLinef(w, "%-*s  %-6s  %6s", width, "REPO", "RESULT", "TIME")
+	failed := 0
+	for _, res := range results {
+		status, detail := "ok", ""
+		if res.err != nil {
+			status, detail = "failed", "  "+res.last
+			failed++
+		}
+		WriteLinef(w, "%-*s  %-6s  %6s%s", width, res.repo.label, status, res.duration.Round(100*time.Millisecond), detail)
+	}
+	if failed > 0 {
+		WriteErrorf(w, "failed in %d of %d repositories", failed, len(results))
+	}
+}
+
+// dashboard shows the state of every repository and runs what is chosen
+// in one of them, returning to the dashboard afterwards until it is quit.
+func (r *Repoer) dashboard() {
+	if r.newDashboard == nil {
+		WriteErrorf(r.outputWriter, "ggc repos dashboard needs an interactive terminal")
+		return
+	}
+	repos := r.repositories()
+	if len(repos) == 0 {
+		WriteLine(r.outputWriter, "No repositories. Add one with 'ggc repos add [<path>]', or set repos.roots.")
+		return
+	}
+	entries := make([]interactive.DashboardRepo, len(repos))
+	for i, repo := range repos {
+		entries[i] = interactive.DashboardRepo{Label: repo.label, Path: repo.path}
+	}
+	board := r.newDashboard(entries)
+	for {
+		action, ok, err := board.Run()
+		if err != nil {
+			WriteError(r.outputWriter, err)
+			return
+		}
+		if !ok {
+			return
+		}
+		r.drillInto(action)
+	}
+}
+
+// drillInto runs the dashboard's action: interactive ggc in the
+// repository, or a ggc command typed for it. A failing command is the
+// repository's business, so it is reported without failing ggc repos.
+func (r *Repoer) drillInto(action interactive.DashboardAction) {
+	repo := action.Repo
+	if action.Kind == interactive.DashboardOpen {
+		if err := r.interact(repo.Path, nil); err != nil {
+			WriteLinef(r.outputWriter, "ggc in %s: %v", repo.Label, err)
+			r.pause()
+		}
+		return
+	}
+	line, canceled, err := r.prompter.Input(fmt.Sprintf("ggc command to run in %s: ", repo.Label))
+	if err != nil || canceled {
+		return
+	}
+	args := tokenize(line)
+	if len(args) > 0 && args[0] == "ggc" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return
+	}
+	if err := r.interact(repo.Path, args); err != nil {
+		WriteLinef(r.outputWriter, "ggc %s in %s: %v", strings.Join(args, " "), repo.Label, err)
+	}
+	r.pause()
+}
+
+// pause keeps a command's output on screen until Enter is pressed.
+func (r *Repoer) pause() {
+	_, _, _ = r.prompter.Input("Press Enter to go back to the dashboard ")
+}
+
+// repositories returns repos.paths in order, then the repositories found
+// under repos.roots sorted by path, each once.
+func (r *Repoer) repositories() []repoEntry {
+	cfg := r.configManager.GetConfig()
+	var paths []string
+	for _, path := range cfg.Repos.Paths {
+		paths = append(paths, filepath.Clean(r.expandHome(path)))
+	}
+	var found []string
+	for _, root := range cfg.Repos.Roots {
+		found = append(found, findRepositories(filepath.Clean(r.expandHome(root)), reposScanDepth)...)
+	}
+	slices.Sort(found)
+	for _, path := range found {
+		if !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	return r.labeled(paths)
+}
+
+// labeled names each repository after its directory, or after its parent
+// and its directory when two share a name.
+func (r *Repoer) labeled(paths []string) []repoEntry {
+	count := map[string]int{}
+	for _, path := range paths {
+		count[filepath.Base(r.expandHome(path))]++
+	}
+	repos := make([]repoEntry, len(paths))
+	for i, path := range paths {
+		path = r.expandHome(path)
+		label := filepath.Base(path)
+		if count[label] > 1 {
+			label = filepath.Join(filepath.Base(filepath.Dir(path)), label)
+		}
+		repos[i] = repoEntry{path: path, label: label}
+	}
+	return repos
+}
+
+// expandHome replaces a leading ~ with the home directory.
+func (r *Repoer) expandHome(path string) string {
+	home, err := r.userHomeDir()
+	if err != nil {
+		return path
+	}
+	return expandHome(path, home)
+}
+
+// findRepositories returns dir when it is a repository, else the
+// repositories below it down to depth levels. Hidden directories are
+// skipped.
+func findRepositories(dir string, depth int) []string {
+	if isRepositoryRoot(dir) {
+		return []string{dir}
+	}
+	if depth == 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var found []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			found = append(found, findRepositories(filepath.Join(dir, e.Name()), depth-1)...)
+		}
+	}
+	return found
+}
+
+// isRepositoryRoot reports whether dir is the top of a working tree: it
+// holds .git, a directory or, for worktrees and submodules, a file.
+func isRepositoryRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// lastLine returns the last non-empty line of out.
+func lastLine(out string) string {
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
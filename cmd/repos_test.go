@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+// makeRepos creates a directory with .git for each of names under root.
+func makeRepos(t *testing.T, root string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.MkdirAll(filepath.Join(root, name, ".git"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// newTestRepoer returns a Repoer whose config saves under a temporary
+// home.
+func newTestRepoer(t *testing.T) (*Repoer, *bytes.Buffer, *config.Manager) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	if err := cm.Load(); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	r := NewRepoer().withConfigManager(cm)
+	r.outputWriter = &buf
+	r.helper.outputWriter = &buf
+	r.userHomeDir = func() (string, error) { return home, nil }
+	return r, &buf, cm
+}
+
+func TestRepoer_AddListRemove(t *testing.T) {
+	r, buf, cm := newTestRepoer(t)
+	src := t.TempDir()
+	makeRepos(t, src, "api", "web", "team/web")
+
+	r.Repos([]string{"add", filepath.Join(src, "api"), filepath.Join(src, "web")})
+	want := []string{filepath.Join(src, "api"), filepath.Join(src, "web")}
+	if !slices.Equal(cm.GetConfig().Repos.Paths, want) {
+		t.Fatalf("repos.paths = %v, want %v (output %q)", cm.GetConfig().Repos.Paths, want, buf.String())
+	}
+	data, err := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".ggcconfig.yaml"))
+	if err != nil || !strings.Contains(string(data), filepath.Join(src, "web")) {
+		t.Errorf("config file should hold the repositories: %v\n%s", err, data)
+	}
+
+	buf.Reset()
+	r.Repos([]string{"add", src})
+	if !strings.Contains(buf.String(), "is not the top of a git repository") || len(cm.GetConfig().Repos.Paths) != 2 {
+		t.Errorf("adding a plain directory: output %q, paths %v", buf.String(), cm.GetConfig().Repos.Paths)
+	}
+
+	// The same name twice is told apart by the parent directory.
+	cm.GetConfig().Repos.Roots = []string{filepath.Join(src, "team")}
+	buf.Reset()
+	r.Repos([]string{"list"})
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "api ") ||
+		!strings.HasPrefix(lines[1], filepath.Join(filepath.Base(src), "web")) || !strings.HasPrefix(lines[2], filepath.Join("team", "web")) {
+		t.Errorf("list = %q", buf.String())
+	}
+
+	buf.Reset()
+	r.Repos([]string{"remove", "api"})
+	if !slices.Equal(cm.GetConfig().Repos.Paths, want[1:]) {
+		t.Errorf("repos.paths = %v after removing api (output %q)", cm.GetConfig().Repos.Paths, buf.String())
+	}
+}
+
+func TestRepoer_Exec(t *testing.T) {
+	r, buf, cm := newTestRepoer(t)
+	src := t.TempDir()
+	makeRepos(t, src, "api", "lib/core", "web")
+	cm.GetConfig().Repos.Paths = []string{filepath.Join(src, "web"), filepath.Join(src, "gone")}
+	cm.GetConfig().Repos.Roots = []string{src}
+
+	var mu sync.Mutex
+	var ran []string
+	r.run = func(dir string, args []string, out io.Writer) error {
+		mu.Lock()
+		ran = append(ran, filepath.Base(dir)+": "+strings.Join(args, " "))
+		mu.Unlock()
+		_, _ = fmt.Fprintf(out, "On branch main\n")
+		if filepath.Base(dir) == "core" {
+			_, _ = fmt.Fprintf(out, "Error: uncommitted changes\n")
+			return errors.New("exit status 1")
+		}
+		return nil
+	}
+	r.Repos([]string{"exec", "--", "status", "short"})
+
+	slices.Sort(ran)
+	want := []string{"api: status short", "core: status short", "web: status short"}
+	if !slices.Equal(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+	out := buf.String()
+	for _, line := range []string{
+		"[web ] On branch main",
+		"[core] Error: uncommitted changes",
+		"[gone] " + filepath.Join(src, "gone") + " is not a git repository",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("output should contain %q, got %q", line, out)
+		}
+	}
+	// The table lists the repositories in order: repos.paths first.
+	table := out[strings.Index(out, "REPO"):]
+	for _, row := range []string{"web   ok", "gone  failed", "api   ok", "core  failed"} {
+		if !strings.Contains(table, row) {
+			t.Errorf("table should contain %q, got %q", row, table)
+		}
+	}
+	if strings.Index(table, "web ") > strings.Index(table, "api ") || !strings.Contains(table, "Error: uncommitted changes") {
+		t.Errorf("table = %q", table)
+	}
+	if !strings.Contains(out, "failed in 2 of 4 repositories") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestRepoer_Sync(t *testing.T) {
+	r, _, cm := newTestRepoer(t)
+	src := t.TempDir()
+	makeRepos(t, src, "api")
+	cm.GetConfig().Repos.Roots = []string{src}
+	var got []string
+	r.run = func(_ string, args []string, _ io.Writer) error {
+		got = args
+		return nil
+	}
+	r.Repos([]string{"sync", "--no-push"})
+	if !slices.Equal(got, []string{"sync", "--no-push"}) {
+		t.Errorf("ran %v, want ggc sync --no-push", got)
+	}
+}
+
+func TestFindRepositories(t *testing.T) {
+	root := t.TempDir()
+	makeRepos(t, root, "a", "a/nested", "b/c/d", "b/c/d/e/f/too-deep", "x/y/z/w/deep", ".hidden/repo")
+	got := findRepositories(root, reposScanDepth)
+	want := []string{filepath.Join(root, "a"), filepath.Join(root, "b/c/d")}
+	if !slices.Equal(got, want) {
+		t.Errorf("findRepositories = %v, want %v", got, want)
+	}
+}
+
+// scriptedDashboard returns its actions in turn, then quits.
+type scriptedDashboard struct {
+	actions []interactive.DashboardAction
+	runs    int
+}
+
+func (d *scriptedDashboard) Run() (interactive.DashboardAction, bool, error) {
+	d.runs++
+	if len(d.actions) == 0 {
+		return interactive.DashboardAction{}, false, nil
+	}
+	action := d.actions[0]
+	d.actions = d.actions[1:]
+	return action, true, nil
+}
+
+func TestRepoer_Dashboard(t *testing.T) {
+	r, buf, cm := newTestRepoer(t)
+	src := t.TempDir()
+	makeRepos(t, src, "api", "web")
+	cm.GetConfig().Repos.Roots = []string{src}
+
+	r.Repos([]string{"dashboard"})
+	if !strings.Contains(buf.String(), "needs an interactive terminal") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	api := interactive.DashboardRepo{Label: "api", Path: filepath.Join(src, "api")}
+	web := interactive.DashboardRepo{Label: "web", Path: filepath.Join(src, "web")}
+	board := &scriptedDashboard{actions: []interactive.DashboardAction{
+		{Kind: interactive.DashboardOpen, Repo: api},
+		{Kind: interactive.DashboardRun, Repo: web},
+	}}
+	var shown []interactive.DashboardRepo
+	r.newDashboard = func(repos []interactive.DashboardRepo) reposDashboard {
+		shown = repos
+		return board
+	}
+	var ran []string
+	r.interact = func(dir string, args []string) error {
+		ran = append(ran, fmt.Sprintf("%s %v", filepath.Base(dir), args))
+		if len(args) > 0 {
+			return errors.New("exit status 1")
+		}
+		return nil
+	}
+	r.prompter = &mockPrompter{input: `ggc commit -m "fix it"`}
+	buf.Reset()
+	r.Repos([]string{"dashboard"})
+
+	if !slices.Equal(shown, []interactive.DashboardRepo{api, web}) {
+		t.Errorf("dashboard repos = %v", shown)
+	}
+	if want := []string{"api []", "web [commit -m fix it]"}; !slices.Equal(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+	if board.runs != 3 {
+		t.Errorf("dashboard ran %d times, want it back after each action", board.runs)
+	}
+	if !strings.Contains(buf.String(), "ggc commit -m fix it in web: exit status 1") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"os"
 
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/journal"
+	"github.com/bmf-san/ggc/v8/internal/safety"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 // Resetter handles reset operations.
@@ -14,6 +17,10 @@ type Resetter struct {
 	outputWriter io.Writer
 	helper       *Helper
 	gitClient    git.ResetOps
+	undo         *Undoer
+	cleaned      cleanLister // nil leaves the files ggc reset cleans out of undo
+	guard        *branchGuard
+	confirm      *ui.Confirmer
 }
 
 // NewResetter creates a new Resetter instance.
@@ -25,15 +32,51 @@ func NewResetter(client git.ResetOps) *Resetter {
 	}
 }
 
+// withUndo journals hard resets so `ggc undo` can reverse them.
+func (r *Resetter) withUndo(u *Undoer) *Resetter {
+	r.undo = u
+	return r
+}
+
+// withCleanSnapshot lets `ggc undo` bring back the untracked and ignored
+// files that ggc reset deletes.
+func (r *Resetter) withCleanSnapshot(l cleanLister) *Resetter {
+	r.cleaned = l
+	return r
+}
+
+// withGuard makes hard resets respect safety.protected-branches.
+func (r *Resetter) withGuard(g *branchGuard) *Resetter {
+	r.guard = g
+	return r
+}
+
+// withConfirmer makes hard resets ask first.
+func (r *Resetter) withConfirmer(c *ui.Confirmer) *Resetter {
+	r.confirm = c
+	return r
+}
+
 // Reset executes git reset commands.
 func (r *Resetter) Reset(args []string) {
+	args, unsafe := safety.CutForceFlag(args)
 	if len(args) == 0 {
+		if err := r.guard.checkCurrent("reset", unsafe); err != nil {
+			WriteError(r.outputWriter, err)
+			return
+		}
 		r.handleDefaultReset()
 		return
 	}
 
 	switch args[0] {
 	case "hard":
+		if len(args) > 1 {
+			if err := r.guard.checkCurrent("hard-reset", unsafe); err != nil {
+				WriteError(r.outputWriter, err)
+				return
+			}
+		}
 		r.handleHardReset(args[1:])
 	case "soft":
 		r.handleSoftReset(args[1:])
@@ -48,10 +91,22 @@ func (r *Resetter) handleDefaultReset() {
 		WriteErrorf(r.outputWriter, "failed to get current branch: %v", err)
 		return
 	}
+	ok, err := r.confirm.ConfirmTyped(fmt.Sprintf("Reset '%s' to origin/%s and delete every untracked and ignored file?", branch, branch), branch)
+	if !proceed(r.outputWriter, ok, err) {
+		return
+	}
+	pending := r.undo.begin(journal.KindReset, "reset")
+	if pending != nil && r.cleaned != nil {
+		if paths, err := cleanablePaths(r.cleaned); err == nil && len(paths) > 0 {
+			r.undo.snapshot(pending, paths)
+		}
+	}
 	if err := r.gitClient.ResetHardAndClean(); err != nil {
+		r.undo.discard(pending)
 		WriteError(r.outputWriter, err)
 		return
 	}
+	r.undo.commit(pending)
 	_, _ = fmt.Fprintf(r.outputWriter, "Reset to origin/%s successful\n", branch)
 }
 
@@ -62,10 +117,15 @@ func (r *Resetter) handleHardReset(args []string) {
 		return
 	}
 	commit := args[0]
+	if ok, err := r.confirm.Confirm(fmt.Sprintf("Reset to %s and discard uncommitted changes?", commit)); !proceed(r.outputWriter, ok, err) {
+		return
+	}
+	pending := r.undo.begin(journal.KindReset, "reset hard "+commit)
 	if err := r.gitClient.ResetHard(commit); err != nil {
 		WriteError(r.outputWriter, err)
 		return
 	}
+	r.undo.commit(pending)
 	_, _ = fmt.Fprintf(r.outputWriter, "Reset to %s successful\n", commit)
 }
 
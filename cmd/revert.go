@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+const (
+	// revertRecovery follows a revert that stopped on a conflict.
+	revertRecovery = "The revert stopped. Resolve the conflicts, 'ggc add' the files and run 'ggc revert continue';\n" +
+		"'ggc revert skip' drops the commit and 'ggc revert abort' puts the branch back."
+	// revertSelectLimit is how many commits ggc revert select offers.
+	revertSelectLimit = 50
+)
+
+// revertOps are the git operations behind ggc revert.
+type revertOps interface {
+	git.PassthroughOps
+	git.CommitLister
+}
+
+// Reverter handles ggc revert.
+type Reverter struct {
+	gitClient    revertOps
+	outputWriter io.Writer
+	helper       *Helper
+	selectMany   multiSelector // nil: the commits are listed instead
+}
+
+// NewReverter creates a new Reverter instance.
+func NewReverter(client revertOps) *Reverter {
+	r := &Reverter{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+	}
+	r.helper.outputWriter = r.outputWriter
+	return r
+}
+
+// withMultiSelect lets ggc revert select choose commits in the full-screen
+// multi-select picker.
+func (r *Reverter) withMultiSelect(sel multiSelector) *Reverter {
+	r.selectMany = sel
+	return r
+}
+
+// Revert executes the revert command with the given arguments. Commits,
+// ranges and options such as --no-commit and -m go to git revert; a merge
+// commit named without -m is refused with a hint rather than git's error.
+func (r *Reverter) Revert(args []string) {
+	if len(args) == 0 {
+		if r.selectMany == nil {
+			r.helper.ShowRevertHelp()
+			return
+		}
+		r.selectCommits(nil)
+		return
+	}
+	switch args[0] {
+	case "help":
+		r.helper.ShowRevertHelp()
+	case "continue", "abort", "skip":
+		if len(args) != 1 {
+			r.helper.ShowRevertHelp()
+			return
+		}
+		r.run([]string{"--" + args[0]})
+	case "select":
+		r.selectCommits(args[1:])
+	default:
+		r.revert(args)
+	}
+}
+
+// revert checks that every merge commit among args has a mainline, then
+// runs git revert.
+func (r *Reverter) revert(args []string) {
+	revs, mainline := parseRevertArgs(args)
+	if !mainline && len(revs) > 0 {
+		commits, err := r.gitClient.ListCommits(append([]string{"--no-walk"}, revs...)...)
+		if err != nil {
+			WriteError(r.outputWriter, err)
+			return
+		}
+		for _, commit := range commits {
+			if commit.Merge {
+				WriteErrorf(r.outputWriter, "%s is a merge commit; pass -m 1 to undo what it brought in from the merged branch", commit.Short)
+				return
+			}
+		}
+	}
+	r.run(args)
+}
+
+// run hands args to git revert.
+func (r *Reverter) run(args []string) {
+	if err := r.gitClient.RunGit("revert", args); err != nil {
+		WriteError(r.outputWriter, err)
+		if args[0] != "--abort" {
+			WriteLine(r.outputWriter, revertRecovery)
+		}
+	}
+}
+
+// parseRevertArgs picks the single commits out of args, leaving ranges and
+// options to git, and reports whether a mainline parent was given.
+func parseRevertArgs(args []string) (revs []string, mainline bool) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "-m" || arg == "--mainline":
+			mainline = true
+			i++ // the parent number
+		case strings.HasPrefix(arg, "-m") || strings.HasPrefix(arg, "--mainline="):
+			mainline = true
+		case strings.HasPrefix(arg, "-"), strings.Contains(arg, ".."), strings.HasPrefix(arg, "^"):
+		default:
+			revs = append(revs, arg)
+		}
+	}
+	return revs, mainline
+}
+
+// selectCommits offers the newest commits of the current branch, following
+// first parents so a merged branch shows as its merge commit, and reverts
+// the chosen ones newest first. Merge commits are reverted against their
+// first parent. options, such as --no-commit, go to git revert.
+func (r *Reverter) selectCommits(options []string) {
+	for _, opt := range options {
+		if !strings.HasPrefix(opt, "-") {
+			r.helper.ShowRevertHelp()
+			return
+		}
+	}
+	commits, err := r.gitClient.ListCommits("--first-parent", "-n", fmt.Sprint(revertSelectLimit), "HEAD")
+	if err != nil {
+		WriteError(r.outputWriter, err)
+		return
+	}
+	if len(commits) == 0 {
+		WriteLine(r.outputWriter, "No commits to revert.")
+		return
+	}
+
+	labels := make([]string, len(commits))
+	for i, commit := range commits {
+		labels[i] = fmt.Sprintf("%s %s (%s, %s)", commit.Short, commit.Subject, commit.Author, commit.Date)
+		if commit.Merge {
+			labels[i] += " [merge]"
+		}
+	}
+	if r.selectMany == nil {
+		WriteLine(r.outputWriter, "Recent commits, newest first:")
+		for _, label := range labels {
+			WriteLine(r.outputWriter, "  "+label)
+		}
+		WriteLine(r.outputWriter, "Revert them with 'ggc revert <commit>...'.")
+		return
+	}
+	selected, ok, err := r.selectMany("Revert commits", labels)
+	if err != nil {
+		WriteError(r.outputWriter, err)
+		return
+	}
+	if !ok || len(selected) == 0 {
+		WriteLine(r.outputWriter, "Canceled.")
+		return
+	}
+	// Newest first, so each revert applies on top of the ones after it.
+	var hashes []string
+	var merge bool
+	for i, label := range labels {
+		if slices.Contains(selected, label) {
+			hashes = append(hashes, commits[i].Hash)
+			merge = merge || commits[i].Merge
+		}
+	}
+	args := slices.Clone(options)
+	if merge {
+		// git accepts a mainline for ordinary commits too.
+		args = append(args, "-m", "1")
+	}
+	r.run(append(args, hashes...))
+}
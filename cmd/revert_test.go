@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockRevertClient struct {
+	testutil.MockGitClient
+	commits []git.CommitSummary
+	listed  []string
+	calls   []string
+}
+
+func (m *mockRevertClient) ListCommits(revs ...string) ([]git.CommitSummary, error) {
+	m.listed = append(m.listed, strings.Join(revs, " "))
+	if revs[0] != "--no-walk" {
+		return m.commits, nil
+	}
+	var out []git.CommitSummary
+	for _, c := range m.commits {
+		for _, rev := range revs[1:] {
+			if c.Short == rev {
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRevertClient) RunGit(name string, args []string) error {
+	m.calls = append(m.calls, name+" "+strings.Join(args, " "))
+	return nil
+}
+
+func newRevertFixture() *mockRevertClient {
+	return &mockRevertClient{commits: []git.CommitSummary{
+		{Hash: "ccc333full", Short: "ccc333", Subject: "Merge topic", Author: "Alice", Date: "1 hour ago", Merge: true},
+		{Hash: "bbb222full", Short: "bbb222", Subject: "Fix typo", Author: "Bob", Date: "1 day ago"},
+		{Hash: "aaa111full", Short: "aaa111", Subject: "Add login", Author: "Alice", Date: "2 days ago"},
+	}}
+}
+
+func newTestReverter(client *mockRevertClient, buf *bytes.Buffer) *Reverter {
+	r := NewReverter(client)
+	r.outputWriter = buf
+	r.helper.outputWriter = buf
+	return r
+}
+
+func TestReverter_Revert(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+		out  string
+	}{
+		{"single commit", []string{"bbb222"}, "revert bbb222", ""},
+		{"no commit", []string{"--no-commit", "bbb222", "aaa111"}, "revert --no-commit bbb222 aaa111", ""},
+		{"range", []string{"HEAD~3..HEAD"}, "revert HEAD~3..HEAD", ""},
+		{"merge without a mainline", []string{"ccc333"}, "", "ccc333 is a merge commit; pass -m 1"},
+		{"merge with a mainline", []string{"-m", "1", "ccc333"}, "revert -m 1 ccc333", ""},
+		{"attached mainline", []string{"-m1", "ccc333"}, "revert -m1 ccc333", ""},
+		{"continue", []string{"continue"}, "revert --continue", ""},
+		{"abort", []string{"abort"}, "revert --abort", ""},
+		{"help without a terminal", nil, "", "ggc revert select"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			client := newRevertFixture()
+			newTestReverter(client, &buf).Revert(tt.args)
+			if got := strings.Join(client.calls, "; "); got != tt.want {
+				t.Errorf("calls = %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(buf.String(), tt.out) {
+				t.Errorf("output %q should contain %q", buf.String(), tt.out)
+			}
+		})
+	}
+}
+
+func TestReverter_Select(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		pick []int
+		want string
+	}{
+		{"newest first", []string{"select"}, []int{2, 1}, "revert bbb222full aaa111full"},
+		{"merge gets a mainline", []string{"select", "--no-commit"}, []int{0}, "revert --no-commit -m 1 ccc333full"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			client := newRevertFixture()
+			var gotItems []string
+			r := newTestReverter(client, &buf).withMultiSelect(func(_ string, items []string) ([]string, bool, error) {
+				gotItems = items
+				var selected []string
+				for _, i := range tt.pick {
+					selected = append(selected, items[i])
+				}
+				return selected, true, nil
+			})
+
+			r.Revert(tt.args)
+
+			if want := "--first-parent -n 50 HEAD"; len(client.listed) != 1 || client.listed[0] != want {
+				t.Errorf("listed %q, want %q", client.listed, want)
+			}
+			if len(gotItems) != 3 || gotItems[0] != "ccc333 Merge topic (Alice, 1 hour ago) [merge]" {
+				t.Errorf("items = %q", gotItems)
+			}
+			if got := strings.Join(client.calls, "; "); got != tt.want {
+				t.Errorf("calls = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReverter_Select_WithoutTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	client := newRevertFixture()
+	newTestReverter(client, &buf).Revert([]string{"select"})
+	if len(client.calls) != 0 {
+		t.Errorf("calls = %v, want none", client.calls)
+	}
+	if !strings.Contains(buf.String(), "  bbb222 Fix typo (Bob, 1 day ago)\n") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	commandregistry "github.com/bmf-san/ggc/v8/cmd/command"
 	"github.com/bmf-san/ggc/v8/internal/history"
+	"github.com/bmf-san/ggc/v8/internal/stats"
 )
 
 // commandRouter dispatches a command name (plus its args) to the matching
@@ -15,6 +16,12 @@ import (
 type commandRouter struct {
 	registry *commandregistry.Registry
 	handlers map[string]func([]string)
+	// onRoute is told about every command routed, before its handler
+	// runs. It feeds the usage statistics of ggc stats.
+	onRoute func(info *commandregistry.Info)
+	// abbreviate expands unambiguous prefixes of command names, as set
+	// by behavior.abbreviations.
+	abbreviate bool
 }
 
 // newCommandRouter builds the handler map and validates that every
@@ -27,38 +34,68 @@ func newCommandRouter(cmd *Cmd) (*commandRouter, error) {
 	}
 
 	handlers := map[string]func([]string){
-		"help":       func(args []string) { cmd.Help(args) },
-		"add":        func(args []string) { cmd.Add(args) },
-		"branch":     func(args []string) { cmd.Branch(args) },
-		"commit":     func(args []string) { cmd.Commit(args) },
-		"log":        func(args []string) { cmd.Log(args) },
-		"history":    func(args []string) { cmd.History(args) },
-		"pull":       func(args []string) { cmd.Pull(args) },
-		"push":       func(args []string) { cmd.Push(args) },
-		"reset":      func(args []string) { cmd.Reset(args) },
-		"clean":      func(args []string) { cmd.Clean(args) },
-		"version":    func(args []string) { cmd.Version(args) },
-		"remote":     func(args []string) { cmd.Remote(args) },
-		"rebase":     func(args []string) { cmd.Rebase(args) },
-		"bisect":     func(args []string) { cmd.Bisect(args) },
-		"stash":      func(args []string) { cmd.Stash(args) },
-		"config":     func(args []string) { cmd.Config(args) },
-		"hook":       func(args []string) { cmd.Hook(args) },
-		"tag":        func(args []string) { cmd.Tag(args) },
-		"status":     func(args []string) { cmd.Status(args) },
-		"fetch":      func(args []string) { cmd.Fetch(args) },
-		"diff":       func(args []string) { cmd.Diff(args) },
-		"restore":    func(args []string) { cmd.Restore(args) },
-		"show":       func(args []string) { cmd.Show(args) },
-		"doctor":     func(args []string) { cmd.doctor.Doctor(args) },
-		"debug-keys": func(args []string) { cmd.DebugKeys(args) },
-		"completion": func(args []string) { cmd.completer.Completion(args) },
+		"help":         func(args []string) { cmd.Help(args) },
+		"add":          func(args []string) { cmd.Add(args) },
+		"branch":       func(args []string) { cmd.Branch(args) },
+		"commit":       func(args []string) { cmd.Commit(args) },
+		"log":          func(args []string) { cmd.Log(args) },
+		"history":      func(args []string) { cmd.History(args) },
+		"stats":        func(args []string) { cmd.Stats(args) },
+		"pull":         func(args []string) { cmd.Pull(args) },
+		"push":         func(args []string) { cmd.Push(args) },
+		"reset":        func(args []string) { cmd.Reset(args) },
+		"clean":        func(args []string) { cmd.Clean(args) },
+		"undo":         func(args []string) { cmd.Undo(args) },
+		"version":      func(args []string) { cmd.Version(args) },
+		"remote":       func(args []string) { cmd.Remote(args) },
+		"rebase":       func(args []string) { cmd.Rebase(args) },
+		"bisect":       func(args []string) { cmd.Bisect(args) },
+		"blame":        func(args []string) { cmd.Blame(args) },
+		"grep":         func(args []string) { cmd.Grep(args) },
+		"lfs":          func(args []string) { cmd.LFS(args) },
+		"maintenance":  func(args []string) { cmd.Maintenance(args) },
+		"analyze":      func(args []string) { cmd.Analyze(args) },
+		"wip":          func(args []string) { cmd.WIP(args) },
+		"unwip":        func(args []string) { cmd.Unwip(args) },
+		"snapshot":     func(args []string) { cmd.Snapshot(args) },
+		"notes":        func(args []string) { cmd.Notes(args) },
+		"repos":        func(args []string) { cmd.Repos(args) },
+		"format-patch": func(args []string) { cmd.FormatPatch(args) },
+		"am":           func(args []string) { cmd.AM(args) },
+		"apply":        func(args []string) { cmd.Apply(args) },
+		"sparse":       func(args []string) { cmd.Sparse(args) },
+		"switch":       func(args []string) { cmd.Switch(args) },
+		"stack":        func(args []string) { cmd.Stack(args) },
+		"ticket":       func(args []string) { cmd.Ticket(args) },
+		"cherry-pick":  func(args []string) { cmd.CherryPick(args) },
+		"revert":       func(args []string) { cmd.Revert(args) },
+		"changelog":    func(args []string) { cmd.Changelog(args) },
+		"release":      func(args []string) { cmd.Release(args) },
+		"stash":        func(args []string) { cmd.Stash(args) },
+		"config":       func(args []string) { cmd.Config(args) },
+		"hook":         func(args []string) { cmd.Hook(args) },
+		"tag":          func(args []string) { cmd.Tag(args) },
+		"pr":           func(args []string) { cmd.PR(args) },
+		"status":       func(args []string) { cmd.Status(args) },
+		"fetch":        func(args []string) { cmd.Fetch(args) },
+		"sync":         func(args []string) { cmd.Sync(args) },
+		"clone":        func(args []string) { cmd.Clone(args) },
+		"verify":       func(args []string) { cmd.Verify(args) },
+		"profile":      func(args []string) { cmd.Profile(args) },
+		"workflow":     func(args []string) { cmd.Workflow(args) },
+		"diff":         func(args []string) { cmd.Diff(args) },
+		"restore":      func(args []string) { cmd.Restore(args) },
+		"show":         func(args []string) { cmd.Show(args) },
+		"doctor":       func(args []string) { cmd.doctor.Doctor(args) },
+		"debug-keys":   func(args []string) { cmd.DebugKeys(args) },
+		"completion":   func(args []string) { cmd.completer.Completion(args) },
+		"__complete":   func(args []string) { cmd.completer.Complete(args) },
 		interactiveQuitCommand: func([]string) {
 			_, _ = fmt.Fprintln(cmd.outputWriter, "The 'quit' command is only available in interactive mode.")
 		},
 	}
 
-	// Wire pass-through commands (cherry-pick, revert, blame, ...). The
+	// Wire pass-through commands (cherry-pick, revert, merge, ...). The
 	// passthroughs map is built in NewCmd from the canonical name list; the
 	// closure resolves the entry lazily so that tests which construct a Cmd
 	// without populating the map still pass router validation (handlers are
@@ -86,7 +123,7 @@ func newCommandRouter(cmd *Cmd) (*commandRouter, error) {
 		return nil, fmt.Errorf("no handler registered for commands: %s", strings.Join(missing, ", "))
 	}
 
-	return &commandRouter{registry: cmd.registry, handlers: handlers}, nil
+	return &commandRouter{registry: cmd.registry, handlers: handlers, onRoute: recordStats}, nil
 }
 
 // route looks up cmd in the registry (which handles aliases and canonical
@@ -105,6 +142,9 @@ func (r *commandRouter) route(cmd string, args []string) bool {
 		return false
 	}
 	r.record(cmd, info.Name, args)
+	if r.onRoute != nil {
+		r.onRoute(&info)
+	}
 	handler(args)
 	return true
 }
@@ -132,6 +172,16 @@ func (r *commandRouter) record(typed, canonical string, args []string) {
 	_ = history.AppendCommand(canonical, args, raw)
 }
 
+// recordStats counts a routed command for ggc stats. Hidden commands run
+// from shell completions, and the meta-commands that record skips, would
+// only skew the counts.
+func recordStats(info *commandregistry.Info) {
+	if info.Hidden || info.Name == "history" || info.Name == "stats" || info.Name == interactiveQuitCommand {
+		return
+	}
+	_ = stats.RecordCommand(info.Name)
+}
+
 // missingHandlers returns every non-hidden registry command that has no
 // matching handler in available. It is used at startup to turn a registry
 // drift into a loud construction error instead of a silent "unknown command"
@@ -1,17 +1,66 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
 	"os"
+	"slices"
+	"strings"
 
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/difftool"
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
+// commitViewer shows one commit full-screen.
+type commitViewer func(rev string) error
+
 // Shower handles git show operations.
 type Shower struct {
-	gitClient    git.ShowOps
-	outputWriter io.Writer
-	helper       *Helper
+	gitClient     git.ShowOps
+	outputWriter  io.Writer
+	helper        *Helper
+	configManager *config.Manager
+	view          commitViewer // nil unless stdin and stdout are terminals
+}
+
+// showCommit is what ggc show --format json prints of a commit.
+type showCommit struct {
+	Hash      string     `json:"hash"`
+	Parents   []string   `json:"parents"`
+	Author    showPerson `json:"author"`
+	Committer showPerson `json:"committer"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	Stats     showStats  `json:"stats"`
+	Files     []showFile `json:"files"`
+	Patch     string     `json:"patch,omitempty"`
+}
+
+type showPerson struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Date  string `json:"date"`
+}
+
+type showStats struct {
+	Files      int `json:"files"`
+	Insertions int `json:"insertions"`
+	Deletions  int `json:"deletions"`
+}
+
+type showFile struct {
+	Path       string `json:"path"`
+	OldPath    string `json:"oldPath,omitempty"`
+	Status     string `json:"status"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+	Binary     bool   `json:"binary,omitempty"`
 }
 
 // NewShower creates a new Shower instance.
@@ -23,15 +72,188 @@ func NewShower(client git.ShowOps) *Shower {
 	}
 }
 
+// withConfigManager supplies ui.diff-tool.
+func (s *Shower) withConfigManager(cm *config.Manager) *Shower {
+	s.configManager = cm
+	return s
+}
+
+// withViewer opens `ggc show [<commit>]` in the commit viewer when stdin
+// and stdout are terminals. cm supplies the keybinding profile and diff
+// tool and may be nil.
+func (s *Shower) withViewer(src interactive.CommitSource, cm *config.Manager) *Shower {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return s
+	}
+	s.view = func(rev string) error {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewCommitViewer(src, cfg).Run(rev)
+	}
+	return s
+}
+
 // Show executes git show with the given arguments. With no arguments,
 // it shows the HEAD commit. The first argument may be "help" to print
-// usage information without invoking git.
+// usage information without invoking git. --format json prints one
+// commit as JSON, --name-only only the paths it changed, and a lone commit
+// on a terminal opens in the commit viewer.
 func (s *Shower) Show(args []string) {
 	if len(args) > 0 && args[0] == "help" {
 		s.helper.ShowShowHelp()
 		return
 	}
+	rest, asJSON, err := cutJSONFormat(args)
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	if asJSON {
+		s.showJSON(rest)
+		return
+	}
+	if slices.Contains(args, "--name-only") && !hasPrettyFormat(args) {
+		// Only the paths, so that the output can be piped.
+		args = append([]string{"--format="}, args...)
+	}
+	if s.view != nil && len(args) <= 1 && !hasOption(args) && (len(args) == 0 || !strings.Contains(args[0], ":")) {
+		rev := ""
+		if len(args) == 1 {
+			rev = args[0]
+		}
+		if err := s.view(rev); err != nil {
+			WriteError(s.outputWriter, err)
+		}
+		return
+	}
+	if h := newHighlighter(s.configManager); h.External() && ui.ColorEnabled(s.outputWriter) {
+		s.showHighlighted(h, args)
+		return
+	}
 	if err := s.gitClient.Show(args); err != nil {
 		WriteError(s.outputWriter, err)
 	}
 }
+
+// showHighlighted pipes git show through the configured diff tool.
+func (s *Shower) showHighlighted(h *difftool.Highlighter, args []string) {
+	output, err := s.gitClient.ShowOutput(args)
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	if err := h.Render(s.outputWriter, output); err != nil {
+		WriteError(s.outputWriter, err)
+	}
+}
+
+// newHighlighter returns the diff highlighter configured by ui.diff-tool.
+func newHighlighter(cm *config.Manager) *difftool.Highlighter {
+	if cm == nil {
+		return difftool.New("")
+	}
+	return difftool.New(cm.GetConfig().UI.DiffTool)
+}
+
+// cutJSONFormat removes --format json (or --format=json) from args. ok
+// reports whether it was there; any other --format is git's own.
+func cutJSONFormat(args []string) (rest []string, ok bool, err error) {
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--format=json":
+			ok = true
+		case args[i] == "--format" && i+1 < len(args) && args[i+1] == "json":
+			ok = true
+			i++
+		case args[i] == "--format":
+			return nil, false, errors.New("--format requires a value")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return rest, ok, nil
+}
+
+// hasPrettyFormat reports whether args choose how git show prints the
+// commit header.
+func hasPrettyFormat(args []string) bool {
+	for _, arg := range args {
+		if arg == "--oneline" || strings.HasPrefix(arg, "--format") || strings.HasPrefix(arg, "--pretty") {
+			return true
+		}
+	}
+	return false
+}
+
+// showJSON prints one commit as JSON: args hold at most a commit and
+// --name-only, which leaves out the patch.
+func (s *Shower) showJSON(args []string) {
+	rev, nameOnly := "", false
+	for _, arg := range args {
+		switch {
+		case arg == "--name-only":
+			nameOnly = true
+		case strings.HasPrefix(arg, "-"):
+			WriteErrorf(s.outputWriter, "--format json does not combine with %s", arg)
+			return
+		case rev != "":
+			WriteErrorf(s.outputWriter, "--format json shows one commit")
+			return
+		default:
+			rev = arg
+		}
+	}
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	detail, err := s.gitClient.CommitDetail(rev)
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	commit := newShowCommit(detail)
+	if !nameOnly {
+		if commit.Patch, err = s.gitClient.ShowOutput([]string{"--format=", "-M", "--patch", rev + "^{commit}"}); err != nil {
+			WriteError(s.outputWriter, err)
+			return
+		}
+	}
+	encoded, err := json.MarshalIndent(commit, "", "  ")
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	WriteLine(s.outputWriter, string(encoded))
+}
+
+func newShowCommit(d *git.CommitDetail) showCommit {
+	c := showCommit{
+		Hash:      d.Hash,
+		Parents:   d.Parents,
+		Author:    showPerson{Name: d.AuthorName, Email: d.AuthorEmail, Date: d.AuthorDate},
+		Committer: showPerson{Name: d.CommitterName, Email: d.CommitterEmail, Date: d.CommitterDate},
+		Subject:   d.Subject,
+		Body:      d.Body,
+		Files:     []showFile{},
+	}
+	if c.Parents == nil {
+		c.Parents = []string{}
+	}
+	for _, f := range d.Files {
+		c.Files = append(c.Files, showFile{
+			Path:       f.Path,
+			OldPath:    f.OldPath,
+			Status:     f.Status,
+			Insertions: f.Insertions,
+			Deletions:  f.Deletions,
+			Binary:     f.Binary,
+		})
+		c.Stats.Insertions += f.Insertions
+		c.Stats.Deletions += f.Deletions
+	}
+	c.Stats.Files = len(d.Files)
+	return c
+}
@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"slices"
 	"strings"
 	"testing"
 
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
 	"github.com/bmf-san/ggc/v8/internal/testutil"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 type mockShowGitClient struct {
@@ -111,3 +115,151 @@ func TestNewShower_Defaults(t *testing.T) {
 		t.Error("helper should be initialized")
 	}
 }
+
+type mockShowOutputClient struct {
+	mockShowGitClient
+	output string
+}
+
+func (m *mockShowOutputClient) ShowOutput(args []string) (string, error) {
+	m.gotArgs = slices.Clone(args)
+	return m.output, nil
+}
+
+func TestShower_Show_DiffTool(t *testing.T) {
+	t.Cleanup(func() { ui.SetColorMode(ui.ColorAuto) })
+	ui.SetColorMode(ui.ColorAlways)
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().UI.DiffTool = "sed s/^/>/"
+
+	var buf bytes.Buffer
+	mock := &mockShowOutputClient{output: "commit abc\n"}
+	s := NewShower(mock).withConfigManager(cm)
+	s.outputWriter = &buf
+	s.Show([]string{"HEAD~1"})
+
+	if mock.called || !slices.Equal(mock.gotArgs, []string{"HEAD~1"}) {
+		t.Errorf("expected captured show output, streamed=%v args=%v", mock.called, mock.gotArgs)
+	}
+	if buf.String() != ">commit abc\n" {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+type mockCommitDetailClient struct {
+	mockShowOutputClient
+	detailRev string
+}
+
+func (m *mockCommitDetailClient) CommitDetail(rev string) (*git.CommitDetail, error) {
+	m.detailRev = rev
+	return &git.CommitDetail{
+		Hash:       "abc",
+		Parents:    []string{"p1"},
+		AuthorName: "Alice",
+		Subject:    "Add pager",
+		Files: []git.FileChange{
+			{Status: "M", Path: "a.go", Insertions: 3, Deletions: 1},
+			{Status: "R", Path: "b.go", OldPath: "c.go", Insertions: 2},
+		},
+	}, nil
+}
+
+func TestShower_Show_JSON(t *testing.T) {
+	cases := []struct {
+		name      string
+		args      []string
+		wantRev   string
+		wantPatch bool
+	}{
+		{name: "defaults to HEAD", args: []string{"--format", "json"}, wantRev: "HEAD", wantPatch: true},
+		{name: "commit and name-only", args: []string{"--name-only", "--format=json", "HEAD~1"}, wantRev: "HEAD~1"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mock := &mockCommitDetailClient{}
+			mock.output = "diff --git a/a.go b/a.go\n"
+			s := NewShower(mock)
+			s.outputWriter = &buf
+			s.Show(tc.args)
+
+			var got showCommit
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+			}
+			if mock.detailRev != tc.wantRev || got.Hash != "abc" || got.Author.Name != "Alice" || len(got.Files) != 2 {
+				t.Errorf("rev %q, got %+v", mock.detailRev, got)
+			}
+			if got.Stats != (showStats{Files: 2, Insertions: 5, Deletions: 1}) {
+				t.Errorf("stats = %+v", got.Stats)
+			}
+			if got.Files[1].OldPath != "c.go" {
+				t.Errorf("rename = %+v", got.Files[1])
+			}
+			if (got.Patch != "") != tc.wantPatch {
+				t.Errorf("patch = %q, want one: %v", got.Patch, tc.wantPatch)
+			}
+			if tc.wantPatch && !slices.Equal(mock.gotArgs, []string{"--format=", "-M", "--patch", "HEAD^{commit}"}) {
+				t.Errorf("patch args = %v", mock.gotArgs)
+			}
+		})
+	}
+}
+
+func TestShower_Show_JSONErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"--format=json", "a", "b"},
+		{"--format=json", "--stat"},
+		{"--format"},
+	} {
+		var buf bytes.Buffer
+		mock := &mockCommitDetailClient{}
+		s := NewShower(mock)
+		s.outputWriter = &buf
+		s.Show(args)
+		if !strings.Contains(buf.String(), "Error") || mock.detailRev != "" {
+			t.Errorf("%v: output = %q, read %q", args, buf.String(), mock.detailRev)
+		}
+	}
+}
+
+func TestShower_Show_NameOnly(t *testing.T) {
+	mock := &mockShowGitClient{}
+	s := &Shower{gitClient: mock, outputWriter: &bytes.Buffer{}, helper: NewHelper()}
+	s.Show([]string{"--name-only", "HEAD"})
+	if want := []string{"--format=", "--name-only", "HEAD"}; !slices.Equal(mock.gotArgs, want) {
+		t.Errorf("args = %v, want %v", mock.gotArgs, want)
+	}
+	s.Show([]string{"--name-only", "--oneline", "HEAD"})
+	if want := []string{"--name-only", "--oneline", "HEAD"}; !slices.Equal(mock.gotArgs, want) {
+		t.Errorf("args = %v, want %v", mock.gotArgs, want)
+	}
+}
+
+func TestShower_Show_Viewer(t *testing.T) {
+	var viewed []string
+	mock := &mockShowGitClient{}
+	s := &Shower{gitClient: mock, outputWriter: &bytes.Buffer{}, helper: NewHelper()}
+	s.view = func(rev string) error {
+		viewed = append(viewed, rev)
+		return nil
+	}
+
+	s.Show(nil)
+	s.Show([]string{"HEAD~2"})
+	if !slices.Equal(viewed, []string{"", "HEAD~2"}) || mock.called {
+		t.Errorf("viewed %v, streamed %v", viewed, mock.called)
+	}
+	// Options, several objects and blobs stay with git show.
+	for _, args := range [][]string{{"--stat", "HEAD"}, {"HEAD", "HEAD~1"}, {"HEAD:go.mod"}} {
+		mock.called = false
+		s.Show(args)
+		if !mock.called {
+			t.Errorf("%v should run git show", args)
+		}
+	}
+	if len(viewed) != 2 {
+		t.Errorf("viewed %v", viewed)
+	}
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+)
+
+// defaultSnapshotKeep is how many snapshots are kept when snapshot.keep
+// is not set.
+const defaultSnapshotKeep = 20
+
+// Snapshotter handles ggc snapshot.
+type Snapshotter struct {
+	gitClient     git.SnapshotOps
+	outputWriter  io.Writer
+	helper        *Helper
+	pick          picker // nil unless stdin is a terminal
+	configManager *config.Manager
+	now           func() time.Time
+}
+
+// NewSnapshotter creates a new Snapshotter instance.
+func NewSnapshotter(client git.SnapshotOps) *Snapshotter {
+	return &Snapshotter{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+		now:          time.Now,
+	}
+}
+
+// withPicker lets ggc snapshot restore and drop choose the snapshot from a
+// list.
+func (s *Snapshotter) withPicker(p picker) *Snapshotter {
+	s.pick = p
+	return s
+}
+
+// withConfigManager supplies snapshot.keep and snapshot.max-age, which
+// decide which snapshots are pruned.
+func (s *Snapshotter) withConfigManager(cm *config.Manager) *Snapshotter {
+	s.configManager = cm
+	return s
+}
+
+// Snapshot saves, lists, restores and drops snapshots of the working tree.
+func (s *Snapshotter) Snapshot(args []string) {
+	if len(args) == 0 {
+		s.helper.ShowSnapshotHelp()
+		return
+	}
+	switch args[0] {
+	case "create":
+		s.create(args[1:])
+	case "list":
+		s.list()
+	case "restore":
+		s.restore(args[1:])
+	case "drop":
+		s.drop(args[1:])
+	case "prune":
+		s.prune(true)
+	default:
+		s.helper.ShowSnapshotHelp()
+	}
+}
+
+// create saves the working tree with the words in args, or -m, as its
+// message, then prunes the snapshots the policy no longer keeps.
+func (s *Snapshotter) create(args []string) {
+	if len(args) > 0 && (args[0] == "-m" || args[0] == "--message") {
+		args = args[1:]
+	}
+	snap, ok := s.save(strings.TrimSpace(strings.Join(args, " ")))
+	if !ok {
+		return
+	}
+	WriteLinef(s.outputWriter, "Saved snapshot %s (%s). Restore it with 'ggc snapshot restore %s'.", snap.Name(), snap.Commit, snap.Name())
+	s.prune(false)
+}
+
+// save records the working tree under a ref named after the current
+// time. The index, HEAD and the working tree are not touched.
+func (s *Snapshotter) save(message string) (git.Snapshot, bool) {
+	existing, err := s.gitClient.Snapshots()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return git.Snapshot{}, false
+	}
+	now := s.now()
+	name := now.UTC().Format("20060102-150405")
+	// Two snapshots in one second get a counter, which still sorts after
+	// the first.
+	for i := 2; findSnapshot(existing, name) != nil; i++ {
+		name = fmt.Sprintf("%s-%d", now.UTC().Format("20060102-150405"), i)
+	}
+	if message == "" {
+		message = "snapshot"
+	}
+	ref := git.SnapshotRefPrefix + name
+	commit, err := s.gitClient.SaveSnapshot(ref, message)
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return git.Snapshot{}, false
+	}
+	return git.Snapshot{Ref: ref, Commit: shortHash(commit), Time: now, Message: message}, true
+}
+
+// list prints the snapshots, newest first, numbered for restore and drop.
+func (s *Snapshotter) list() {
+	snapshots, err := s.gitClient.Snapshots()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	if len(snapshots) == 0 {
+		WriteLine(s.outputWriter, "No snapshots. Save one with 'ggc snapshot create [<message>]'.")
+		return
+	}
+	w := s.outputWriter
+	WriteLinef(w, "%3s  %-17s  %-16s  %-9s %s", "#", "NAME", "SAVED", "COMMIT", "MESSAGE")
+	for i, snap := range snapshots {
+		WriteLinef(w, "%3d  %-17s  %-16s  %-9s %s", i+1, snap.Name(), snap.Time.Local().Format("2006-01-02 15:04"), snap.Commit, snap.Message)
+	}
+}
+
+// restore writes the files of a snapshot back into the working tree,
+// after saving the current state so the restore can itself be undone.
+// The index and HEAD stay as they are.
+func (s *Snapshotter) restore(args []string) {
+	snap, ok := s.choose(args, "Snapshot to restore")
+	if !ok {
+		return
+	}
+	backup, ok := s.save("before restoring " + snap.Name())
+	if !ok {
+		return
+	}
+	if err := s.gitClient.RestoreSnapshot(snap.Ref); err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	WriteLinef(s.outputWriter, "Restored snapshot %s. Files added since were left alone.", snap.Name())
+	WriteLinef(s.outputWriter, "The state before it is snapshot %s; 'ggc snapshot restore %s' goes back.", backup.Name(), backup.Name())
+	s.prune(false)
+}
+
+// drop deletes one snapshot.
+func (s *Snapshotter) drop(args []string) {
+	snap, ok := s.choose(args, "Snapshot to drop")
+	if !ok {
+		return
+	}
+	if err := s.gitClient.DeleteRef(snap.Ref); err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	WriteLinef(s.outputWriter, "Dropped snapshot %s.", snap.Name())
+}
+
+// choose finds the snapshot named by args, by number in the list or by
+// name, or lets the user pick one.
+func (s *Snapshotter) choose(args []string, title string) (*git.Snapshot, bool) {
+	snapshots, err := s.gitClient.Snapshots()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return nil, false
+	}
+	if len(snapshots) == 0 {
+		WriteErrorf(s.outputWriter, "no snapshots; save one with 'ggc snapshot create'")
+		return nil, false
+	}
+	if len(args) > 0 {
+		if n, err := strconv.Atoi(args[0]); err == nil && n >= 1 && n <= len(snapshots) {
+			return &snapshots[n-1], true
+		}
+		if snap := findSnapshot(snapshots, args[0]); snap != nil {
+			return snap, true
+		}
+		WriteErrorf(s.outputWriter, "no snapshot %q; 'ggc snapshot list' shows them", args[0])
+		return nil, false
+	}
+	if s.pick == nil {
+		WriteErrorf(s.outputWriter, "name the snapshot by number or name; 'ggc snapshot list' shows them")
+		return nil, false
+	}
+	items := make([]interactive.PickItem, len(snapshots))
+	for i, snap := range snapshots {
+		items[i] = interactive.PickItem{Value: snap.Name(), Detail: snap.Message}
+	}
+	name, ok, err := s.pick(title, items, "")
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	return findSnapshot(snapshots, name), true
+}
+
+// prune drops the snapshots beyond snapshot.keep and those older than
+// snapshot.max-age. verbose also reports when nothing was dropped.
+func (s *Snapshotter) prune(verbose bool) {
+	keep, maxAge := defaultSnapshotKeep, time.Duration(0)
+	if s.configManager != nil {
+		cfg := s.configManager.GetConfig()
+		if cfg.Snapshot.Keep > 0 {
+			keep = cfg.Snapshot.Keep
+		}
+		// Validation rejects a max-age that does not parse.
+		maxAge, _ = time.ParseDuration(cfg.Snapshot.MaxAge)
+	}
+	snapshots, err := s.gitClient.Snapshots()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	var dropped []string
+	for i, snap := range snapshots {
+		if i < keep && (maxAge <= 0 || s.now().Sub(snap.Time) <= maxAge) {
+			continue
+		}
+		if err := s.gitClient.DeleteRef(snap.Ref); err != nil {
+			WriteError(s.outputWriter, err)
+			return
+		}
+		dropped = append(dropped, snap.Name())
+	}
+	switch {
+	case len(dropped) > 0:
+		WriteLinef(s.outputWriter, "Pruned %s: %s.", plural(len(dropped), "old snapshot"), strings.Join(dropped, ", "))
+	case verbose:
+		WriteLine(s.outputWriter, "No snapshots to prune.")
+	}
+}
+
+// findSnapshot returns the snapshot called name, or nil.
+func findSnapshot(snapshots []git.Snapshot, name string) *git.Snapshot {
+	for i := range snapshots {
+		if snapshots[i].Name() == name {
+			return &snapshots[i]
+		}
+	}
+	return nil
+}
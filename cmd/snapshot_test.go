@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+// snapshotMock keeps snapshots in memory, newest first.
+type snapshotMock struct {
+	testutil.MockGitClient
+	snapshots []git.Snapshot
+	saved     []string // messages
+	restored  string
+	deleted   []string
+	clock     time.Time
+}
+
+func (m *snapshotMock) SaveSnapshot(ref, message string) (string, error) {
+	m.saved = append(m.saved, message)
+	m.snapshots = append([]git.Snapshot{{Ref: ref, Commit: "abc1234", Time: m.clock, Message: message}}, m.snapshots...)
+	return "abc1234def", nil
+}
+
+func (m *snapshotMock) Snapshots() ([]git.Snapshot, error) { return slices.Clone(m.snapshots), nil }
+
+func (m *snapshotMock) RestoreSnapshot(commit string) error {
+	m.restored = commit
+	return nil
+}
+
+func (m *snapshotMock) DeleteRef(ref string) error {
+	m.deleted = append(m.deleted, ref)
+	m.snapshots = slices.DeleteFunc(m.snapshots, func(s git.Snapshot) bool { return s.Ref == ref })
+	return nil
+}
+
+func newTestSnapshotter(m *snapshotMock) (*Snapshotter, *bytes.Buffer) {
+	var buf bytes.Buffer
+	s := NewSnapshotter(m)
+	s.outputWriter = &buf
+	s.helper.outputWriter = &buf
+	s.now = func() time.Time { return m.clock }
+	return s, &buf
+}
+
+func snapshotAt(name string, t time.Time) git.Snapshot {
+	return git.Snapshot{Ref: git.SnapshotRefPrefix + name, Commit: "c" + name[len(name)-6:], Time: t, Message: "saved " + name}
+}
+
+func TestSnapshotter_Create(t *testing.T) {
+	clock := time.Date(2026, 10, 16, 14, 23, 1, 0, time.UTC)
+	m := &snapshotMock{clock: clock, snapshots: []git.Snapshot{snapshotAt("20261016-142301", clock)}}
+	s, buf := newTestSnapshotter(m)
+	s.Snapshot([]string{"create", "-m", "before", "the", "refactor"})
+	if !slices.Equal(m.saved, []string{"before the refactor"}) {
+		t.Errorf("saved %q", m.saved)
+	}
+	// A snapshot already has this second's name.
+	if got := m.snapshots[0].Name(); got != "20261016-142301-2" {
+		t.Errorf("name = %q, want 20261016-142301-2", got)
+	}
+	if !strings.Contains(buf.String(), "ggc snapshot restore 20261016-142301-2") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestSnapshotter_Prune(t *testing.T) {
+	clock := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	var snapshots []git.Snapshot
+	for day := 15; day >= 1; day-- {
+		at := time.Date(2026, 10, day, 12, 0, 0, 0, time.UTC)
+		snapshots = append(snapshots, snapshotAt(at.Format("20060102-150405"), at))
+	}
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().Snapshot.Keep = 10
+	cm.GetConfig().Snapshot.MaxAge = "168h"
+
+	m := &snapshotMock{clock: clock, snapshots: snapshots}
+	s, buf := newTestSnapshotter(m)
+	s.withConfigManager(cm)
+	s.Snapshot([]string{"create"})
+	// The new snapshot and the seven from the last week are kept.
+	if len(m.snapshots) != 8 || len(m.deleted) != 8 {
+		t.Errorf("kept %d and dropped %d, want 8 and 8", len(m.snapshots), len(m.deleted))
+	}
+	if !strings.Contains(buf.String(), "Pruned 8 old snapshots") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	cm.GetConfig().Snapshot.MaxAge = ""
+	cm.GetConfig().Snapshot.Keep = 3
+	buf.Reset()
+	s.Snapshot([]string{"prune"})
+	if len(m.snapshots) != 3 {
+		t.Errorf("kept %d, want 3", len(m.snapshots))
+	}
+	buf.Reset()
+	s.Snapshot([]string{"prune"})
+	if !strings.Contains(buf.String(), "No snapshots to prune") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestSnapshotter_List(t *testing.T) {
+	at := time.Date(2026, 10, 16, 14, 23, 1, 0, time.Local)
+	m := &snapshotMock{snapshots: []git.Snapshot{snapshotAt("20261016-142301", at)}}
+	s, buf := newTestSnapshotter(m)
+	s.Snapshot([]string{"list"})
+	if !strings.Contains(buf.String(), "  1  20261016-142301    2026-10-16 14:23  c142301   saved 20261016-142301") {
+		t.Errorf("output =\n%s", buf.String())
+	}
+
+	m.snapshots = nil
+	buf.Reset()
+	s.Snapshot([]string{"list"})
+	if !strings.Contains(buf.String(), "No snapshots") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestSnapshotter_Restore(t *testing.T) {
+	clock := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	older := snapshotAt("20261016-120000", clock.Add(-3*time.Hour))
+	newer := snapshotAt("20261016-140000", clock.Add(-time.Hour))
+	tests := []struct {
+		name string
+		args []string
+		pick picker
+		want string
+	}{
+		{"by number", []string{"restore", "2"}, nil, older.Ref},
+		{"by name", []string{"restore", "20261016-140000"}, nil, newer.Ref},
+		{"picked", []string{"restore"}, func(_ string, items []interactive.PickItem, _ string) (string, bool, error) {
+			return items[1].Value, true, nil
+		}, older.Ref},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &snapshotMock{clock: clock, snapshots: []git.Snapshot{newer, older}}
+			s, buf := newTestSnapshotter(m)
+			s.withPicker(tt.pick)
+			s.Snapshot(tt.args)
+			if m.restored != tt.want {
+				t.Errorf("restored %q, want %q", m.restored, tt.want)
+			}
+			// The state before the restore is saved first.
+			if len(m.saved) != 1 || !strings.HasPrefix(m.saved[0], "before restoring ") {
+				t.Errorf("saved %q", m.saved)
+			}
+			if !strings.Contains(buf.String(), "ggc snapshot restore 20261016-150000") {
+				t.Errorf("output = %q", buf.String())
+			}
+		})
+	}
+
+	m := &snapshotMock{clock: clock, snapshots: []git.Snapshot{newer}}
+	s, buf := newTestSnapshotter(m)
+	s.Snapshot([]string{"restore", "7"})
+	if m.restored != "" || len(m.saved) != 0 || !strings.Contains(buf.String(), `no snapshot "7"`) {
+		t.Errorf("restored %q, saved %q; output = %q", m.restored, m.saved, buf.String())
+	}
+}
+
+func TestSnapshotter_Drop(t *testing.T) {
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	m := &snapshotMock{snapshots: []git.Snapshot{snapshotAt("20261016-120000", at)}}
+	s, buf := newTestSnapshotter(m)
+	s.Snapshot([]string{"drop", "1"})
+	if !slices.Equal(m.deleted, []string{"refs/ggc/snapshots/20261016-120000"}) {
+		t.Errorf("deleted %q", m.deleted)
+	}
+
+	buf.Reset()
+	s.Snapshot([]string{"drop"})
+	if !strings.Contains(buf.String(), "no snapshots") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+)
+
+// sparsePicker lets the user choose the directories of a cone-mode sparse
+// checkout from the tree of all directories. ok is false when the user
+// cancels.
+type sparsePicker func(dirs, included []string) (chosen []string, ok bool, err error)
+
+// errSparsePatterns is reported when ggc sparse would have to rewrite a
+// sparse checkout that lists patterns rather than directories.
+var errSparsePatterns = errors.New("the sparse checkout uses patterns, not cone mode; change it with 'ggc sparse-checkout' or start over with 'ggc sparse init'")
+
+// Sparser handles ggc sparse: cone-mode sparse checkouts for working in
+// part of a large repository.
+type Sparser struct {
+	gitClient    git.SparseCheckoutOps
+	outputWriter io.Writer
+	helper       *Helper
+	pick         sparsePicker // nil unless stdin is a terminal
+}
+
+// NewSparser creates a new Sparser instance.
+func NewSparser(client git.SparseCheckoutOps) *Sparser {
+	return &Sparser{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+	}
+}
+
+// withTreePicker lets `ggc sparse add` and `ggc sparse remove` without
+// directories choose them in a tree when stdin is a terminal. cm supplies
+// the keybinding profile and may be nil.
+func (s *Sparser) withTreePicker(cm *config.Manager) *Sparser {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return s
+	}
+	s.pick = func(dirs, included []string) ([]string, bool, error) {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewSparsePicker(dirs, included, cfg).Run()
+	}
+	return s
+}
+
+// Sparse runs a ggc sparse subcommand.
+func (s *Sparser) Sparse(args []string) {
+	if len(args) == 0 {
+		s.helper.ShowSparseHelp()
+		return
+	}
+	var err error
+	switch args[0] {
+	case "list":
+		err = s.list()
+	case "init":
+		err = s.set(normalizeSparseDirs(args[1:]))
+	case "add":
+		err = s.add(normalizeSparseDirs(args[1:]))
+	case "remove":
+		err = s.remove(normalizeSparseDirs(args[1:]))
+	case "disable":
+		if err = s.gitClient.SparseDisable(); err == nil {
+			WriteLine(s.outputWriter, "Sparse checkout is off; the whole tree is checked out.")
+		}
+	default:
+		s.helper.ShowSparseHelp()
+		return
+	}
+	if err != nil {
+		WriteError(s.outputWriter, err)
+	}
+}
+
+// normalizeSparseDirs drops the slashes people type around directories.
+func normalizeSparseDirs(dirs []string) []string {
+	out := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if dir = strings.Trim(strings.TrimPrefix(dir, "./"), "/"); dir != "" {
+			out = append(out, dir)
+		}
+	}
+	return out
+}
+
+// list prints the directories or patterns checked out.
+func (s *Sparser) list() error {
+	sc, err := s.gitClient.SparseCheckout()
+	if err != nil {
+		return err
+	}
+	switch {
+	case !sc.Enabled:
+		WriteLine(s.outputWriter, "Sparse checkout is off; the whole tree is checked out.")
+	case !sc.Cone:
+		WriteLine(s.outputWriter, "Sparse checkout patterns:")
+		for _, rule := range sc.Rules {
+			WriteLinef(s.outputWriter, "  %s", rule)
+		}
+	case len(sc.Rules) == 0:
+		WriteLine(s.outputWriter, "Sparse checkout (cone mode): only the files at the top level are checked out.")
+	default:
+		WriteLine(s.outputWriter, "Sparse checkout (cone mode): the files at the top level and under")
+		for _, rule := range sc.Rules {
+			WriteLinef(s.outputWriter, "  %s/", rule)
+		}
+	}
+	return nil
+}
+
+// set makes the checkout hold dirs alone and prints the result.
+func (s *Sparser) set(dirs []string) error {
+	if err := s.gitClient.SparseSet(dirs); err != nil {
+		return err
+	}
+	return s.list()
+}
+
+// cone returns the directories of a cone-mode sparse checkout, empty when
+// sparse checkout is off.
+func (s *Sparser) cone() (*git.SparseCheckout, error) {
+	sc, err := s.gitClient.SparseCheckout()
+	if err != nil {
+		return nil, err
+	}
+	if sc.Enabled && !sc.Cone {
+		return nil, errSparsePatterns
+	}
+	return sc, nil
+}
+
+// add checks out dirs as well, or lets the user choose in the tree picker
+// when none are named.
+func (s *Sparser) add(dirs []string) error {
+	sc, err := s.cone()
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 {
+		return s.pickDirs(sc)
+	}
+	if !sc.Enabled {
+		return s.set(dirs)
+	}
+	if err := s.gitClient.SparseAdd(dirs); err != nil {
+		return err
+	}
+	return s.list()
+}
+
+// remove stops checking out dirs, or lets the user choose in the tree
+// picker when none are named.
+func (s *Sparser) remove(dirs []string) error {
+	sc, err := s.cone()
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 {
+		return s.pickDirs(sc)
+	}
+	if !sc.Enabled {
+		return errors.New("sparse checkout is off; run 'ggc sparse init' first")
+	}
+	kept := slices.Clone(sc.Rules)
+	for _, dir := range dirs {
+		i := slices.Index(kept, dir)
+		if i < 0 {
+			return fmt.Errorf("%s/ is not one of the checked-out directories; see 'ggc sparse list'", dir)
+		}
+		kept = slices.Delete(kept, i, i+1)
+	}
+	return s.set(kept)
+}
+
+// pickDirs shows the tree picker and applies the directories chosen.
+func (s *Sparser) pickDirs(sc *git.SparseCheckout) error {
+	if s.pick == nil {
+		return errors.New("name the directories, or run this in a terminal to choose them")
+	}
+	all, err := s.gitClient.TreeDirectories()
+	if err != nil {
+		return err
+	}
+	chosen, ok, err := s.pick(all, sc.Rules)
+	if err != nil || !ok {
+		return err
+	}
+	if sc.Enabled && slices.Equal(chosen, sc.Rules) {
+		WriteLine(s.outputWriter, "No changes.")
+		return nil
+	}
+	return s.set(chosen)
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type sparseMock struct {
+	testutil.MockGitClient
+	state   git.SparseCheckout
+	set     []string
+	added   []string
+	setRuns int
+}
+
+func (m *sparseMock) SparseCheckout() (*git.SparseCheckout, error) {
+	sc := m.state
+	return &sc, nil
+}
+
+func (m *sparseMock) SparseSet(dirs []string) error {
+	m.setRuns++
+	m.set = dirs
+	m.state = git.SparseCheckout{Enabled: true, Cone: true, Rules: dirs}
+	return nil
+}
+
+func (m *sparseMock) SparseAdd(dirs []string) error {
+	m.added = dirs
+	m.state.Rules = append(m.state.Rules, dirs...)
+	return nil
+}
+
+func (m *sparseMock) TreeDirectories() ([]string, error) {
+	return []string{"api", "docs", "web"}, nil
+}
+
+func newTestSparser(m *sparseMock) (*Sparser, *bytes.Buffer) {
+	var buf bytes.Buffer
+	s := NewSparser(m)
+	s.outputWriter = &buf
+	s.helper.outputWriter = &buf
+	return s, &buf
+}
+
+func TestSparser_List(t *testing.T) {
+	tests := []struct {
+		state git.SparseCheckout
+		want  string
+	}{
+		{git.SparseCheckout{}, "Sparse checkout is off; the whole tree is checked out.\n"},
+		{git.SparseCheckout{Enabled: true, Cone: true}, "Sparse checkout (cone mode): only the files at the top level are checked out.\n"},
+		{git.SparseCheckout{Enabled: true, Cone: true, Rules: []string{"api", "docs"}}, "Sparse checkout (cone mode): the files at the top level and under\n  api/\n  docs/\n"},
+		{git.SparseCheckout{Enabled: true, Rules: []string{"/*", "!/*/"}}, "Sparse checkout patterns:\n  /*\n  !/*/\n"},
+	}
+	for _, tt := range tests {
+		s, buf := newTestSparser(&sparseMock{state: tt.state})
+		s.Sparse([]string{"list"})
+		if buf.String() != tt.want {
+			t.Errorf("list = %q, want %q", buf.String(), tt.want)
+		}
+	}
+}
+
+func TestSparser_InitAddRemove(t *testing.T) {
+	m := &sparseMock{}
+	s, buf := newTestSparser(m)
+
+	s.Sparse([]string{"init", "./api/"})
+	if !slices.Equal(m.set, []string{"api"}) {
+		t.Errorf("init set %v", m.set)
+	}
+	s.Sparse([]string{"add", "docs", "web"})
+	if !slices.Equal(m.added, []string{"docs", "web"}) {
+		t.Errorf("add added %v", m.added)
+	}
+	s.Sparse([]string{"remove", "docs/"})
+	if !slices.Equal(m.set, []string{"api", "web"}) {
+		t.Errorf("remove set %v", m.set)
+	}
+	s.Sparse([]string{"remove", "nope"})
+	if !strings.Contains(buf.String(), "nope/ is not one of the checked-out directories") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestSparser_AddTurnsOn(t *testing.T) {
+	m := &sparseMock{}
+	s, _ := newTestSparser(m)
+	s.Sparse([]string{"add", "api"})
+	if !slices.Equal(m.set, []string{"api"}) || m.added != nil {
+		t.Errorf("set %v, added %v; a sparse checkout should be started", m.set, m.added)
+	}
+}
+
+func TestSparser_Patterns(t *testing.T) {
+	m := &sparseMock{state: git.SparseCheckout{Enabled: true, Rules: []string{"/*"}}}
+	s, buf := newTestSparser(m)
+	s.Sparse([]string{"remove", "api"})
+	if m.setRuns != 0 || !strings.Contains(buf.String(), "not cone mode") {
+		t.Errorf("set ran %d times; output = %q", m.setRuns, buf.String())
+	}
+}
+
+func TestSparser_Picker(t *testing.T) {
+	m := &sparseMock{state: git.SparseCheckout{Enabled: true, Cone: true, Rules: []string{"api"}}}
+	s, buf := newTestSparser(m)
+	var gotDirs, gotIncluded []string
+	chosen := []string{"api", "web"}
+	s.pick = func(dirs, included []string) ([]string, bool, error) {
+		gotDirs, gotIncluded = dirs, included
+		return chosen, true, nil
+	}
+	s.Sparse([]string{"add"})
+	if !slices.Equal(gotDirs, []string{"api", "docs", "web"}) || !slices.Equal(gotIncluded, []string{"api"}) {
+		t.Errorf("picker got %v, %v", gotDirs, gotIncluded)
+	}
+	if !slices.Equal(m.set, chosen) {
+		t.Errorf("set %v, want %v", m.set, chosen)
+	}
+
+	chosen = []string{"api", "web"}
+	m.setRuns = 0
+	s.Sparse([]string{"remove"})
+	if m.setRuns != 0 || !strings.Contains(buf.String(), "No changes.") {
+		t.Errorf("an unchanged choice should not run git; output = %q", buf.String())
+	}
+}
+
+func TestSparser_PickerNeedsTerminal(t *testing.T) {
+	s, buf := newTestSparser(&sparseMock{})
+	s.Sparse([]string{"add"})
+	if !strings.Contains(buf.String(), "name the directories") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// errNoSSHAgent is returned when no agent answers ssh-add.
+var errNoSSHAgent = errors.New("no ssh agent")
+
+// sshTarget is a remote git is about to connect to: fetched from, or with
+// push pushed to. An empty remote is the one git fetch uses by default.
+type sshTarget struct {
+	remote string
+	push   bool
+}
+
+// sshProblem is why ssh would fail to log in, with the lines that fix it.
+type sshProblem struct {
+	reason string
+	fixes  []string
+}
+
+// sshRemotes tells the URL git connects to for a remote and reads the git
+// configuration that changes how it runs ssh.
+type sshRemotes interface {
+	git.TransportURLReader
+	git.ConfigReader
+}
+
+// sshHostConfig is what ssh -G resolves for a host.
+type sshHostConfig struct {
+	hostname       string
+	identityFiles  []string
+	identitiesOnly bool
+	identityAgent  string // empty for SSH_AUTH_SOCK
+}
+
+// sshPreflight checks, before git connects to an SSH remote, that ssh has
+// a key for the host it can use without asking: one the agent holds, or
+// one without a passphrase. When it has none, the command stops with the
+// fix instead of git's "Permission denied (publickey)". A nil
+// sshPreflight checks nothing.
+type sshPreflight struct {
+	urls        sshRemotes
+	execCommand func(string, ...string) *exec.Cmd
+	userHomeDir func() (string, error)
+	getenv      func(string) string
+}
+
+// newSSHPreflight returns the preflight when ssh.preflight is set, and nil
+// otherwise. cm may be nil.
+func newSSHPreflight(urls sshRemotes, cm *config.Manager) *sshPreflight {
+	if cm == nil || !cm.GetConfig().SSH.Preflight {
+		return nil
+	}
+	return &sshPreflight{urls: urls, execCommand: exec.Command, userHomeDir: os.UserHomeDir, getenv: os.Getenv}
+}
+
+// ready checks the SSH hosts among targets, each once, and reports whether
+// op may go ahead. When it may not, it says why and how to fix it. URLs
+// that cannot be read are left for git to report.
+func (s *sshPreflight) ready(w io.Writer, op string, targets ...sshTarget) bool {
+	if s == nil || s.customSSH() {
+		return true
+	}
+	checked := map[string]bool{}
+	for _, t := range targets {
+		url, err := s.urls.TransportURL(t.remote, t.push)
+		if err != nil {
+			continue
+		}
+		user, host, port, ok := parseSSHRemote(url)
+		if !ok || checked[user+"@"+host+":"+port] {
+			continue
+		}
+		checked[user+"@"+host+":"+port] = true
+		if p := s.diagnose(user, host, port); p != nil {
+			WriteErrorf(w, "%s stopped: %s", op, p.reason)
+			for _, line := range p.fixes {
+				WriteLine(w, line)
+			}
+			WriteLine(w, "Set ssh.preflight to false to skip this check.")
+			return false
+		}
+	}
+	return true
+}
+
+// customSSH reports whether git runs its own ssh command, set with
+// GIT_SSH_COMMAND, core.sshCommand or GIT_SSH. Such a command may pick
+// another key or not be OpenSSH at all, so what ssh -G says about the
+// host does not apply to it.
+func (s *sshPreflight) customSSH() bool {
+	if s.getenv("GIT_SSH_COMMAND") != "" || s.getenv("GIT_SSH") != "" {
+		return true
+	}
+	command, err := s.urls.ConfigGet("core.sshCommand")
+	return err == nil && command != ""
+}
+
+// diagnose works out whether ssh can log in to host without asking, the
+// way git would run it, and returns nil when it can or when the tools to
+// tell are missing.
+func (s *sshPreflight) diagnose(user, host, port string) *sshProblem {
+	home, err := s.userHomeDir()
+	if err != nil {
+		return nil
+	}
+	cfg, err := s.hostConfig(user, host, port, home)
+	if err != nil {
+		return nil
+	}
+	loaded, err := s.agentKeys(cfg.identityAgent)
+	agent := !errors.Is(err, errNoSSHAgent)
+	if err != nil && agent {
+		return nil
+	}
+
+	var keys []string
+	for _, key := range cfg.identityFiles {
+		if _, err := os.Stat(key); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		// ssh offers whatever the agent holds; which key the host takes
+		// cannot be told from here.
+		if len(loaded) > 0 && !cfg.identitiesOnly {
+			return nil
+		}
+		return noSSHKeyProblem(host, cfg.hostname, home)
+	}
+	for _, key := range keys {
+		if loaded[s.fingerprint(key)] {
+			return nil
+		}
+	}
+	for _, key := range keys {
+		if s.unencrypted(key) {
+			return nil
+		}
+	}
+
+	shown := shortenHome(keys[0], home)
+	if !agent {
+		return &sshProblem{
+			reason: fmt.Sprintf("no SSH agent is running to unlock %s, the key for %s", shown, host),
+			fixes:  []string{"Start one and load the key:", `    eval "$(ssh-agent)"`, "    ssh-add " + shown},
+		}
+	}
+	return &sshProblem{
+		reason: fmt.Sprintf("the SSH agent does not hold %s, the key for %s", shown, host),
+		fixes:  []string{"Load it:", "    ssh-add " + shown},
+	}
+}
+
+// noSSHKeyProblem points ssh at a key in ~/.ssh it does not try for host,
+// or says to create one when there is none.
+func noSSHKeyProblem(host, hostname, home string) *sshProblem {
+	reason := "ssh has no key to offer " + host
+	if keys := otherSSHKeys(home); len(keys) > 0 {
+		return &sshProblem{reason: reason, fixes: []string{
+			"Tell ssh which key to use for it in ~/.ssh/config:",
+			"    Host " + host,
+			"        IdentityFile " + shortenHome(keys[0], home),
+		}}
+	}
+	return &sshProblem{reason: reason, fixes: []string{
+		"Create a key and add the .pub file to your account on " + hostname + ":",
+		"    ssh-keygen -t ed25519",
+	}}
+}
+
+// hostConfig asks ssh how it would connect to host, which takes in the
+// Host entries of ~/.ssh/config.
+func (s *sshPreflight) hostConfig(user, host, port, home string) (sshHostConfig, error) {
+	args := []string{"-G"}
+	if port != "" {
+		args = append(args, "-p", port)
+	}
+	target := host
+	if user != "" {
+		target = user + "@" + host
+	}
+	out, err := s.execCommand("ssh", append(args, target)...).Output()
+	if err != nil {
+		return sshHostConfig{}, err
+	}
+	cfg := sshHostConfig{hostname: host}
+	for _, line := range strings.Split(string(out), "\n") {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch key {
+		case "hostname":
+			cfg.hostname = value
+		case "identityfile":
+			cfg.identityFiles = append(cfg.identityFiles, expandHome(strings.ReplaceAll(value, "%d", home), home))
+		case "identitiesonly":
+			cfg.identitiesOnly = value == "yes"
+		case "identityagent":
+			if value != "SSH_AUTH_SOCK" && value != "$SSH_AUTH_SOCK" {
+				cfg.identityAgent = expandHome(value, home)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// agentKeys returns the fingerprints of the keys the agent at socket, or
+// at SSH_AUTH_SOCK when socket is empty, holds. ssh-add -l exits 1 when
+// the agent has none and 2 when it cannot reach one.
+func (s *sshPreflight) agentKeys(socket string) (map[string]bool, error) {
+	if socket == "none" {
+		return nil, errNoSSHAgent
+	}
+	cmd := s.execCommand("ssh-add", "-l", "-E", "sha256")
+	if socket != "" {
+		cmd.Env = append(os.Environ(), "SSH_AUTH_SOCK="+socket)
+	}
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		return nil, nil
+	case errors.As(err, &exitErr):
+		return nil, errNoSSHAgent
+	case err != nil:
+		return nil, err
+	}
+	keys := map[string]bool{}
+	for _, line := range strings.Split(string(out), "\n") {
+		if fields := strings.Fields(line); len(fields) > 1 {
+			keys[fields[1]] = true
+		}
+	}
+	return keys, nil
+}
+
+// fingerprint returns the SHA256 fingerprint of a key, read from its .pub
+// file when there is one so a passphrase is never asked for.
+func (s *sshPreflight) fingerprint(key string) string {
+	if _, err := os.Stat(key + ".pub"); err == nil {
+		key += ".pub"
+	}
+	out, err := s.execCommand("ssh-keygen", "-l", "-E", "sha256", "-f", key).Output()
+	if fields := strings.Fields(string(out)); err == nil && len(fields) > 1 {
+		return fields[1]
+	}
+	return ""
+}
+
+// unencrypted reports whether a private key opens without a passphrase.
+func (s *sshPreflight) unencrypted(key string) bool {
+	if strings.HasSuffix(key, ".pub") {
+		return false
+	}
+	return s.execCommand("ssh-keygen", "-y", "-P", "", "-f", key).Run() == nil
+}
+
+// otherSSHKeys returns the private keys in ~/.ssh, those with a .pub file
+// beside them.
+func otherSSHKeys(home string) []string {
+	dir := filepath.Join(home, ".ssh")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var keys []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".pub"); ok {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				keys = append(keys, filepath.Join(dir, name))
+			}
+		}
+	}
+	return keys
+}
+
+// shortenHome writes a path under the home directory with a leading ~.
+func shortenHome(path, home string) string {
+	if rest, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return "~/" + filepath.ToSlash(rest)
+	}
+	return path
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// stubTransportURLs maps remote names to URLs and records the lookups.
+// config holds the git configuration.
+type stubTransportURLs struct {
+	urls    map[string]string
+	lookups []string
+	config  map[string]string
+}
+
+func (s *stubTransportURLs) ConfigGet(key string) (string, error) {
+	if value, ok := s.config[key]; ok {
+		return value, nil
+	}
+	return "", errors.New("exit status 1")
+}
+
+func (s *stubTransportURLs) TransportURL(remote string, push bool) (string, error) {
+	s.lookups = append(s.lookups, remote+" "+strconv.FormatBool(push))
+	return s.urls[remote], nil
+}
+
+// fakeSSH answers ssh -G, ssh-add -l and ssh-keygen as OpenSSH does. A
+// key's fingerprint is SHA256: followed by the name of the file read.
+type fakeSSH struct {
+	config      string   // ssh -G lines after the defaults
+	agent       int      // ssh-add exit code: 1 holds no keys, 2 no agent
+	loaded      []string // fingerprints the agent holds
+	unencrypted bool
+	calls       []string
+}
+
+func (f *fakeSSH) command(name string, args ...string) *exec.Cmd {
+	f.calls = append(f.calls, name+" "+strings.Join(args, " "))
+	out, code := "", 0
+	switch name {
+	case "ssh":
+		out = "hostname github.com\nidentitiesonly no\nidentityfile ~/.ssh/id_rsa\nidentityfile ~/.ssh/id_ed25519\n" + f.config
+	case "ssh-add":
+		for _, fp := range f.loaded {
+			out += "256 " + fp + " you@host (ED25519)\n"
+		}
+		code = f.agent
+	case "ssh-keygen":
+		if args[0] == "-l" {
+			out = "256 SHA256:" + filepath.Base(args[len(args)-1]) + " you@host (ED25519)\n"
+		} else if !f.unencrypted {
+			code = 255
+		}
+	}
+	return exec.Command("sh", "-c", `printf '%s' "$1"; exit "$2"`, "sh", out, strconv.Itoa(code))
+}
+
+func newTestSSHPreflight(t *testing.T, f *fakeSSH, url string, keys ...string) (*sshPreflight, *stubTransportURLs) {
+	t.Helper()
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".ssh"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		if err := os.WriteFile(filepath.Join(home, ".ssh", key), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	urls := &stubTransportURLs{urls: map[string]string{"": url, "origin": url}}
+	return &sshPreflight{
+		urls:        urls,
+		execCommand: f.command,
+		userHomeDir: func() (string, error) { return home, nil },
+		getenv:      func(string) string { return "" },
+	}, urls
+}
+
+func TestSSHPreflight_Ready(t *testing.T) {
+	tests := []struct {
+		name string
+		ssh  fakeSSH
+		keys []string
+		want []string // nil when the command may go ahead
+	}{
+		{
+			name: "key loaded",
+			ssh:  fakeSSH{loaded: []string{"SHA256:other", "SHA256:id_ed25519.pub"}},
+			keys: []string{"id_ed25519", "id_ed25519.pub"},
+		},
+		{
+			name: "key not loaded",
+			ssh:  fakeSSH{loaded: []string{"SHA256:other"}},
+			keys: []string{"id_ed25519", "id_ed25519.pub"},
+			want: []string{"fetch stopped: the SSH agent does not hold ~/.ssh/id_ed25519, the key for github.com", "    ssh-add ~/.ssh/id_ed25519"},
+		},
+		{
+			name: "no agent",
+			ssh:  fakeSSH{agent: 2},
+			keys: []string{"id_ed25519"},
+			want: []string{"no SSH agent is running to unlock ~/.ssh/id_ed25519", `    eval "$(ssh-agent)"`, "    ssh-add ~/.ssh/id_ed25519"},
+		},
+		{
+			name: "key without a passphrase",
+			ssh:  fakeSSH{agent: 2, unencrypted: true},
+			keys: []string{"id_ed25519"},
+		},
+		{
+			name: "agent keys only",
+			ssh:  fakeSSH{loaded: []string{"SHA256:from-a-password-manager"}},
+		},
+		{
+			name: "key ssh does not try",
+			ssh:  fakeSSH{agent: 1},
+			keys: []string{"work", "work.pub"},
+			want: []string{"ssh has no key to offer github.com", "    Host github.com", "        IdentityFile ~/.ssh/work"},
+		},
+		{
+			name: "identities only",
+			ssh:  fakeSSH{config: "identitiesonly yes\n", loaded: []string{"SHA256:other"}},
+			want: []string{"ssh has no key to offer github.com", "your account on github.com:", "    ssh-keygen -t ed25519"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestSSHPreflight(t, &tt.ssh, "git@github.com:octo/hello.git", tt.keys...)
+			var buf bytes.Buffer
+			ok := s.ready(&buf, "fetch", sshTarget{})
+			if ok != (tt.want == nil) {
+				t.Fatalf("ready = %v, output %q", ok, buf.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output lacks %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestSSHPreflight_SkipsOtherRemotes(t *testing.T) {
+	f := &fakeSSH{agent: 2}
+	s, _ := newTestSSHPreflight(t, f, "https://github.com/octo/hello.git")
+	if !s.ready(&bytes.Buffer{}, "push", sshTarget{"origin", true}) || len(f.calls) != 0 {
+		t.Errorf("an HTTPS remote should not be checked; ran %v", f.calls)
+	}
+	var off *sshPreflight
+	if !off.ready(&bytes.Buffer{}, "push", sshTarget{"origin", true}) {
+		t.Error("without ssh.preflight every push should go ahead")
+	}
+}
+
+func TestSSHPreflight_SkipsCustomSSHCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		config map[string]string
+	}{
+		{"GIT_SSH_COMMAND", map[string]string{"GIT_SSH_COMMAND": "ssh -i ~/.ssh/deploy"}, nil},
+		{"GIT_SSH", map[string]string{"GIT_SSH": "/usr/bin/plink"}, nil},
+		{"core.sshCommand", nil, map[string]string{"core.sshCommand": "ssh -i ~/.ssh/deploy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeSSH{agent: 2}
+			s, urls := newTestSSHPreflight(t, f, "git@github.com:octo/hello.git")
+			s.getenv = func(key string) string { return tt.env[key] }
+			urls.config = tt.config
+			if !s.ready(&bytes.Buffer{}, "push", sshTarget{"origin", true}) || len(f.calls) != 0 {
+				t.Errorf("a custom ssh command should not be checked; ran %v", f.calls)
+			}
+		})
+	}
+}
+
+func TestSSHPreflight_StopsCommands(t *testing.T) {
+	f := &fakeSSH{agent: 1}
+	s, urls := newTestSSHPreflight(t, f, "ssh://git@github.com:2222/octo/hello.git", "id_ed25519")
+
+	var buf bytes.Buffer
+	m := &mockPushGitClient{}
+	p := NewPusher(m)
+	p.outputWriter, p.ssh = &buf, s
+	p.Push([]string{"current"})
+	if m.pushCalled || !strings.Contains(buf.String(), "push stopped: the SSH agent does not hold ~/.ssh/id_ed25519") {
+		t.Errorf("pushed = %v, output %q", m.pushCalled, buf.String())
+	}
+	if urls.lookups[0] != "origin true" || !strings.Contains(f.calls[0], "-G -p 2222 git@github.com") {
+		t.Errorf("lookups = %v, calls = %v", urls.lookups, f.calls)
+	}
+
+	buf.Reset()
+	pm := &mockPullGitClient{}
+	pl := NewPuller(pm)
+	pl.outputWriter, pl.ssh = &buf, s
+	pl.Pull([]string{"rebase"})
+	if pm.pullCalled || !strings.Contains(buf.String(), "pull stopped:") {
+		t.Errorf("pulled = %v, output %q", pm.pullCalled, buf.String())
+	}
+
+	// ssh.preflight set and the key loaded: the push goes ahead.
+	f.agent, f.loaded = 0, []string{"SHA256:id_ed25519"}
+	buf.Reset()
+	p.Push([]string{"current"})
+	if !m.pushCalled {
+		t.Errorf("push should go ahead, output %q", buf.String())
+	}
+
+	// ggc push current checks git.default-remote, not origin.
+	p.remote = "upstream"
+	p.Push([]string{"current"})
+	if last := urls.lookups[len(urls.lookups)-1]; last != "upstream true" {
+		t.Errorf("lookups = %v, want upstream last", urls.lookups)
+	}
+}
+
+func TestSSHPreflight_FetchAllChecksEachHostOnce(t *testing.T) {
+	f := &fakeSSH{loaded: []string{"SHA256:id_ed25519"}}
+	s, urls := newTestSSHPreflight(t, f, "git@github.com:octo/hello.git", "id_ed25519")
+	urls.urls["upstream"] = "git@github.com:upstream/hello.git"
+	urls.urls["mirror"] = "https://example.com/hello.git"
+
+	remotes := &stubRemoteFetcher{names: []string{"origin", "upstream", "mirror"}}
+	fetcher := NewFetcher(&mockAddGitClient{}).withRemotes(remotes)
+	fetcher.outputWriter, fetcher.ssh = &bytes.Buffer{}, s
+	fetcher.Fetch([]string{"--all"})
+
+	if got := strings.Join(urls.lookups, ", "); got != "origin false, upstream false, mirror false" {
+		t.Errorf("lookups = %s", got)
+	}
+	sshRuns := 0
+	for _, call := range f.calls {
+		if strings.HasPrefix(call, "ssh -G") {
+			sshRuns++
+		}
+	}
+	if sshRuns != 1 || len(remotes.fetched) != 3 {
+		t.Errorf("ssh -G ran %d times, fetched %v", sshRuns, remotes.fetched)
+	}
+}
@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// stackOps are the git operations behind ggc stack.
+type stackOps interface {
+	git.StackOps
+	GetCurrentBranch() (string, error)
+	ListLocalBranches() ([]string, error)
+	ValidateBranchName(name string) error
+	CheckoutNewBranch(name string) error
+	CheckoutBranch(name string) error
+	RevParse(ref string) (string, error)
+	GetAheadBehindCount(branch, upstream string) (string, error)
+}
+
+// stackTree is every recorded stacked branch that still exists, indexed
+// both ways.
+type stackTree struct {
+	links    map[string]git.StackLink // branch -> its link
+	children map[string][]string      // parent -> branches stacked on it
+	locals   []string
+}
+
+// Stacker handles ggc stack. A stacked branch remembers the branch it
+// was created on, and restacking rebases each branch of a stack onto its
+// parent's current tip, parents first.
+type Stacker struct {
+	gitClient    stackOps
+	outputWriter io.Writer
+	helper       *Helper
+	stash        *autostasher // nil leaves uncommitted changes to git
+}
+
+// NewStacker creates a new Stacker instance.
+func NewStacker(client stackOps) *Stacker {
+	s := &Stacker{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+	}
+	s.helper.outputWriter = s.outputWriter
+	return s
+}
+
+// withAutostash stashes uncommitted changes around a restack, as
+// behavior.autostash says.
+func (s *Stacker) withAutostash(a *autostasher) *Stacker {
+	s.stash = a
+	return s
+}
+
+// Stack executes the stack command with the given arguments.
+func (s *Stacker) Stack(args []string) {
+	if len(args) == 0 {
+		s.helper.ShowStackHelp()
+		return
+	}
+	switch {
+	case args[0] == "create" && len(args) == 2:
+		s.create(args[1])
+	case args[0] == "list" && len(args) == 1:
+		s.list()
+	case args[0] == "restack" && len(args) == 1:
+		s.restack()
+	default:
+		s.helper.ShowStackHelp()
+	}
+}
+
+// create starts name at HEAD, stacked on the current branch, and switches
+// to it.
+func (s *Stacker) create(name string) {
+	parent, err := s.gitClient.GetCurrentBranch()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	if parent == "" || parent == "HEAD" {
+		WriteErrorf(s.outputWriter, "a stacked branch needs a parent branch, but HEAD is detached")
+		return
+	}
+	if err := s.gitClient.ValidateBranchName(name); err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	locals, err := s.gitClient.ListLocalBranches()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	if slices.Contains(locals, name) {
+		WriteErrorf(s.outputWriter, "branch %q already exists", name)
+		return
+	}
+	base, err := s.gitClient.RevParse("HEAD")
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	if err := s.gitClient.CheckoutNewBranch(name); err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	if err := s.gitClient.SetStackLink(git.StackLink{Branch: name, Parent: parent, Base: base}); err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	_, _ = fmt.Fprintf(s.outputWriter, "Created %s stacked on %s\n", name, parent)
+}
+
+// list prints every stack as a tree from its bottom branch, with the
+// commits each branch adds and whether it needs restacking.
+func (s *Stacker) list() {
+	tree, err := s.load()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	current, _ := s.gitClient.GetCurrentBranch()
+	roots := tree.roots()
+	if len(roots) == 0 {
+		WriteLine(s.outputWriter, "No stacked branches. Create one with 'ggc stack create <name>'.")
+		return
+	}
+	for i, root := range roots {
+		if i > 0 {
+			WriteLine(s.outputWriter, "")
+		}
+		WriteLine(s.outputWriter, s.describe(tree, root, current))
+		s.listChildren(tree, root, current, "")
+	}
+}
+
+func (s *Stacker) listChildren(tree *stackTree, parent, current, indent string) {
+	children := tree.children[parent]
+	for i, child := range children {
+		branch, next := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, next = "└── ", "    "
+		}
+		WriteLine(s.outputWriter, indent+branch+s.describe(tree, child, current))
+		s.listChildren(tree, child, current, indent+next)
+	}
+}
+
+// describe renders one branch of the tree.
+func (s *Stacker) describe(tree *stackTree, branch, current string) string {
+	line := branch
+	if branch == current {
+		line += " *"
+	}
+	if !slices.Contains(tree.locals, branch) {
+		return line + " (deleted)"
+	}
+	link, ok := tree.links[branch]
+	if !ok {
+		return line
+	}
+	ahead, behind, ok := s.aheadBehind(branch, link.Parent)
+	if !ok {
+		return line
+	}
+	line += fmt.Sprintf(" (%d commit(s)", ahead)
+	if behind > 0 {
+		line += ", needs restack"
+	}
+	return line + ")"
+}
+
+// restack rebases every branch of the current branch's stack onto its
+// parent, parents first, and then returns to the current branch. A
+// conflict stops it; running it again after the rebase is finished
+// carries on where it stopped.
+func (s *Stacker) restack() {
+	tree, err := s.load()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	current, err := s.gitClient.GetCurrentBranch()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	root := tree.root(current)
+	order := tree.descendants(root)
+	if len(order) == 0 {
+		WriteErrorf(s.outputWriter, "%s is not part of a stack; start one with 'ggc stack create <name>'", current)
+		return
+	}
+	s.stash.around(s.outputWriter, "restack", func() bool {
+		return s.restackBranches(tree, current, order)
+	})
+}
+
+// restackBranches rebases the branches in order and reports whether all
+// of them were restacked.
+func (s *Stacker) restackBranches(tree *stackTree, current string, order []string) bool {
+	moved := 0
+	for i, branch := range order {
+		link := tree.links[branch]
+		_, _ = fmt.Fprintf(s.outputWriter, "[%d/%d] %s onto %s\n", i+1, len(order), branch, link.Parent)
+		if !slices.Contains(tree.locals, link.Parent) {
+			_, _ = fmt.Fprintf(s.outputWriter, "      Skipped: %s no longer exists\n", link.Parent)
+			continue
+		}
+		tip, err := s.gitClient.RevParse(link.Parent)
+		if err != nil {
+			WriteError(s.outputWriter, err)
+			return false
+		}
+		if _, behind, ok := s.aheadBehind(branch, link.Parent); ok && behind == 0 {
+			WriteLine(s.outputWriter, "      Already up to date")
+			if link.Base != tip {
+				link.Base = tip
+				_ = s.gitClient.SetStackLink(link)
+			}
+			continue
+		}
+		// Only the commits after the recorded base belong to branch; the
+		// ones before it are the parent's old commits.
+		upstream := link.Base
+		if upstream == "" {
+			upstream = link.Parent
+		}
+		if err := s.gitClient.RebaseOnto(link.Parent, upstream, branch); err != nil {
+			WriteError(s.outputWriter, err)
+			_, _ = fmt.Fprintf(s.outputWriter, "Restacking stopped at %s. Resolve the conflicts, 'ggc add' the files and run\n", branch)
+			WriteLine(s.outputWriter, "'ggc rebase continue', then run 'ggc stack restack' again to restack the rest;")
+			WriteLine(s.outputWriter, "or run 'ggc rebase abort' to leave the branch as it was.")
+			return false
+		}
+		moved++
+		link.Base = tip
+		if err := s.gitClient.SetStackLink(link); err != nil {
+			WriteError(s.outputWriter, err)
+			return false
+		}
+	}
+	if moved > 0 {
+		if err := s.gitClient.CheckoutBranch(current); err != nil {
+			WriteError(s.outputWriter, err)
+			return false
+		}
+	}
+	_, _ = fmt.Fprintf(s.outputWriter, "Restacked %d branch(es)\n", moved)
+	return true
+}
+
+// load reads the stack records, leaving out branches that were deleted.
+func (s *Stacker) load() (*stackTree, error) {
+	links, err := s.gitClient.StackLinks()
+	if err != nil {
+		return nil, err
+	}
+	locals, err := s.gitClient.ListLocalBranches()
+	if err != nil {
+		return nil, err
+	}
+	tree := &stackTree{
+		links:    make(map[string]git.StackLink),
+		children: make(map[string][]string),
+		locals:   locals,
+	}
+	for _, link := range links {
+		if !slices.Contains(locals, link.Branch) {
+			continue
+		}
+		tree.links[link.Branch] = link
+		tree.children[link.Parent] = append(tree.children[link.Parent], link.Branch)
+	}
+	return tree, nil
+}
+
+// root follows parents from branch down to the bottom of its stack,
+// which is usually the trunk.
+func (t *stackTree) root(branch string) string {
+	seen := map[string]bool{branch: true}
+	for {
+		link, ok := t.links[branch]
+		if !ok || seen[link.Parent] {
+			return branch
+		}
+		branch = link.Parent
+		seen[branch] = true
+	}
+}
+
+// roots returns the bottom branch of every stack, ordered by the name of
+// the first stacked branch found on it.
+func (t *stackTree) roots() []string {
+	var roots []string
+	for _, b := range t.locals {
+		if _, ok := t.links[b]; !ok {
+			continue
+		}
+		if r := t.root(b); !slices.Contains(roots, r) {
+			roots = append(roots, r)
+		}
+	}
+	return roots
+}
+
+// descendants lists the branches stacked on branch, directly or not,
+// each after its parent.
+func (t *stackTree) descendants(branch string) []string {
+	var out []string
+	seen := map[string]bool{branch: true}
+	var walk func(string)
+	walk = func(parent string) {
+		for _, child := range t.children[parent] {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			out = append(out, child)
+			walk(child)
+		}
+	}
+	walk(branch)
+	return out
+}
+
+// aheadBehind counts the commits branch has that parent lacks and the
+// other way round. ok is false when git cannot tell.
+func (s *Stacker) aheadBehind(branch, parent string) (ahead, behind int, ok bool) {
+	out, err := s.gitClient.GetAheadBehindCount(branch, parent)
+	if err != nil {
+		return 0, 0, false
+	}
+	if _, err := fmt.Sscan(out, &ahead, &behind); err != nil {
+		return 0, 0, false
+	}
+	return ahead, behind, true
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockStackClient struct {
+	testutil.MockGitClient
+	current string
+	locals  []string
+	links   []git.StackLink
+	counts  map[string]string // "branch parent" -> ahead/behind
+	fail    string            // the rebased branch that conflicts
+	calls   []string
+}
+
+func (m *mockStackClient) GetCurrentBranch() (string, error)    { return m.current, nil }
+func (m *mockStackClient) ListLocalBranches() ([]string, error) { return m.locals, nil }
+func (m *mockStackClient) StackLinks() ([]git.StackLink, error) { return m.links, nil }
+func (m *mockStackClient) RevParse(ref string) (string, error)  { return "tip-of-" + ref, nil }
+func (m *mockStackClient) CheckoutBranch(name string) error     { return m.record("checkout " + name) }
+func (m *mockStackClient) CheckoutNewBranch(name string) error {
+	return m.record("checkout -b " + name)
+}
+func (m *mockStackClient) SetStackLink(link git.StackLink) error {
+	return m.record("link " + stackLinkString(link))
+}
+
+func (m *mockStackClient) GetAheadBehindCount(branch, parent string) (string, error) {
+	if count, ok := m.counts[branch+" "+parent]; ok {
+		return count, nil
+	}
+	return "", errors.New("unknown")
+}
+
+func (m *mockStackClient) RebaseOnto(newBase, upstream, branch string) error {
+	if err := m.record("rebase --onto " + newBase + " " + upstream + " " + branch); err != nil {
+		return err
+	}
+	if branch == m.fail {
+		return errors.New("conflict")
+	}
+	return nil
+}
+
+func (m *mockStackClient) record(call string) error {
+	m.calls = append(m.calls, call)
+	return nil
+}
+
+func stackLinkString(l git.StackLink) string {
+	return l.Branch + "<-" + l.Parent + "@" + l.Base
+}
+
+func newTestStacker(client *mockStackClient, buf *bytes.Buffer) *Stacker {
+	s := NewStacker(client)
+	s.outputWriter = buf
+	s.helper.outputWriter = buf
+	return s
+}
+
+// newStackFixture is main <- a <- b, main <- c and an unrelated x <- y,
+// with b and c behind their parents.
+func newStackFixture() *mockStackClient {
+	return &mockStackClient{
+		current: "b",
+		locals:  []string{"a", "b", "c", "main", "x", "y"},
+		links: []git.StackLink{
+			{Branch: "a", Parent: "main", Base: "m1"},
+			{Branch: "b", Parent: "a", Base: "a1"},
+			{Branch: "c", Parent: "main", Base: "m0"},
+			{Branch: "gone", Parent: "a", Base: "a0"},
+			{Branch: "y", Parent: "x", Base: "x1"},
+		},
+		counts: map[string]string{
+			"a main": "2\t0",
+			"b a":    "1\t3",
+			"c main": "4\t1",
+			"y x":    "1\t0",
+		},
+	}
+}
+
+func TestStacker_Create(t *testing.T) {
+	var buf bytes.Buffer
+	client := &mockStackClient{current: "a", locals: []string{"main", "a"}}
+	newTestStacker(client, &buf).Stack([]string{"create", "b"})
+
+	want := "checkout -b b; link b<-a@tip-of-HEAD"
+	if got := strings.Join(client.calls, "; "); got != want {
+		t.Errorf("calls = %q, want %q", got, want)
+	}
+	if !strings.Contains(buf.String(), "Created b stacked on a") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+
+	buf.Reset()
+	client.calls = nil
+	newTestStacker(client, &buf).Stack([]string{"create", "main"})
+	if len(client.calls) != 0 || !strings.Contains(buf.String(), `branch "main" already exists`) {
+		t.Errorf("calls = %v, output %q", client.calls, buf.String())
+	}
+}
+
+func TestStacker_List(t *testing.T) {
+	var buf bytes.Buffer
+	newTestStacker(newStackFixture(), &buf).Stack([]string{"list"})
+
+	want := "main\n" +
+		"├── a (2 commit(s))\n" +
+		"│   └── b * (1 commit(s), needs restack)\n" +
+		"└── c (4 commit(s), needs restack)\n" +
+		"\n" +
+		"x\n" +
+		"└── y (1 commit(s))\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestStacker_Restack(t *testing.T) {
+	var buf bytes.Buffer
+	client := newStackFixture()
+	newTestStacker(client, &buf).Stack([]string{"restack"})
+
+	want := []string{
+		"link a<-main@tip-of-main",
+		"rebase --onto a a1 b",
+		"link b<-a@tip-of-a",
+		"rebase --onto main m0 c",
+		"link c<-main@tip-of-main",
+		"checkout b",
+	}
+	if got := strings.Join(client.calls, "; "); got != strings.Join(want, "; ") {
+		t.Errorf("calls = %q, want %q", got, strings.Join(want, "; "))
+	}
+	for _, line := range []string{"[1/3] a onto main", "Already up to date", "[3/3] c onto main", "Restacked 2 branch(es)"} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("output %q should contain %q", buf.String(), line)
+		}
+	}
+}
+
+func TestStacker_Restack_Conflict(t *testing.T) {
+	var buf bytes.Buffer
+	client := newStackFixture()
+	client.fail = "b"
+	newTestStacker(client, &buf).Stack([]string{"restack"})
+
+	want := "link a<-main@tip-of-main; rebase --onto a a1 b"
+	if got := strings.Join(client.calls, "; "); got != want {
+		t.Errorf("calls = %q, want %q", got, want)
+	}
+	if !strings.Contains(buf.String(), "Restacking stopped at b") || !strings.Contains(buf.String(), "ggc stack restack") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestStacker_Restack_NotStacked(t *testing.T) {
+	var buf bytes.Buffer
+	client := newStackFixture()
+	client.current = "z"
+	newTestStacker(client, &buf).Stack([]string{"restack"})
+	if len(client.calls) != 0 || !strings.Contains(buf.String(), "z is not part of a stack") {
+		t.Errorf("calls = %v, output %q", client.calls, buf.String())
+	}
+}
@@ -2,18 +2,35 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"strings"
 
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
+// stashBrowser shows the interactive stash list and returns the chosen action.
+type stashBrowser func() (interactive.StashAction, bool, error)
+
 // Stasher handles stash operations.
 type Stasher struct {
 	gitClient    git.StashOps
 	outputWriter io.Writer
 	helper       *Helper
+	prompter     prompt.Prompter
+	browse       stashBrowser // nil when stdin is not a terminal
+	confirm      *ui.Confirmer
+	pusher       git.StashPusher         // nil allows only a message on stash push
+	status       git.StatusSummaryReader // lists the paths stash push select offers
+	selectMany   multiSelector           // nil when stdin is not a terminal
 }
 
 // NewStasher creates a new Stasher instance.
@@ -22,9 +39,32 @@ func NewStasher(client git.StashOps) *Stasher {
 		gitClient:    client,
 		outputWriter: os.Stdout,
 		helper:       NewHelper(),
+		prompter:     prompt.New(os.Stdin, os.Stdout),
 	}
 }
 
+// withConfirmer makes stash drop and clear ask first.
+func (s *Stasher) withConfirmer(c *ui.Confirmer) *Stasher {
+	s.confirm = c
+	return s
+}
+
+// withBrowser enables `ggc stash browse` when stdin is a terminal. cm
+// supplies the keybinding profile and diff tool and may be nil.
+func (s *Stasher) withBrowser(cm *config.Manager) *Stasher {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return s
+	}
+	s.browse = func() (interactive.StashAction, bool, error) {
+		var cfg *config.Config
+		if cm != nil {
+			cfg = cm.GetConfig()
+		}
+		return interactive.NewStashBrowser(s.gitClient, cfg).Run()
+	}
+	return s
+}
+
 // Stash executes git stash commands.
 func (s *Stasher) Stash(args []string) {
 	if len(args) == 0 {
@@ -41,12 +81,16 @@ func (s *Stasher) Stash(args []string) {
 		s.stashApply(args)
 	case "pop":
 		s.stashPop(args)
-	case "push":
+	case "push", "save":
 		s.stashPush(args)
 	case "drop":
 		s.stashDrop(args)
 	case "clear":
 		s.stashClear()
+	case "branch":
+		s.stashBranch(args)
+	case "browse":
+		s.stashBrowse()
 	default:
 		s.helper.ShowStashHelp()
 	}
@@ -106,23 +150,19 @@ func (s *Stasher) stashPop(args []string) {
 	}
 }
 
-// stashPush creates a new stash with an optional message
-func (s *Stasher) stashPush(args []string) {
-	var message string
-	if len(args) > 1 {
-		message = strings.Join(args[1:], " ")
-	}
-	if err := s.gitClient.StashPush(message); err != nil {
-		WriteError(s.outputWriter, err)
-	}
-}
-
 // stashDrop drops the specified stash
 func (s *Stasher) stashDrop(args []string) {
 	var stash string
 	if len(args) > 1 {
 		stash = args[1]
 	}
+	name := stash
+	if name == "" {
+		name = "stash@{0}"
+	}
+	if ok, err := s.confirm.Confirm(fmt.Sprintf("Drop %s?", name)); !proceed(s.outputWriter, ok, err) {
+		return
+	}
 	if err := s.gitClient.StashDrop(stash); err != nil {
 		WriteError(s.outputWriter, err)
 	}
@@ -130,7 +170,59 @@ func (s *Stasher) stashDrop(args []string) {
 
 // stashClear removes all stashes
 func (s *Stasher) stashClear() {
+	if ok, err := s.confirm.Confirm("Drop every stash?"); !proceed(s.outputWriter, ok, err) {
+		return
+	}
 	if err := s.gitClient.StashClear(); err != nil {
 		WriteError(s.outputWriter, err)
 	}
 }
+
+// stashBranch creates a branch from the specified stash
+func (s *Stasher) stashBranch(args []string) {
+	if len(args) < 2 {
+		s.helper.ShowStashHelp()
+		return
+	}
+	var stash string
+	if len(args) > 2 {
+		stash = args[2]
+	}
+	if err := s.gitClient.StashBranch(args[1], stash); err != nil {
+		WriteError(s.outputWriter, err)
+	}
+}
+
+// stashBrowse opens the interactive stash browser and runs the action
+// picked there.
+func (s *Stasher) stashBrowse() {
+	if s.browse == nil {
+		WriteError(s.outputWriter, errors.New("stash browse needs an interactive terminal"))
+		return
+	}
+	action, ok, err := s.browse()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	switch action.Kind {
+	case interactive.StashApply:
+		err = s.gitClient.StashApply(action.Ref)
+	case interactive.StashPop:
+		err = s.gitClient.StashPop(action.Ref)
+	case interactive.StashBranch:
+		name, canceled, inputErr := s.prompter.Input("Branch name: ")
+		if canceled || inputErr != nil || strings.TrimSpace(name) == "" {
+			WriteLine(s.outputWriter, "Canceled.")
+			return
+		}
+		err = s.gitClient.StashBranch(strings.TrimSpace(name), action.Ref)
+	}
+	if err != nil {
+		WriteError(s.outputWriter, err)
+	}
+}
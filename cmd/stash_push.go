@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// withStashOptions enables the options of ggc stash push beyond a message
+// when client supports them, and `ggc stash push select`, which picks the
+// paths to stash with sel. sel is nil when stdin is not a terminal.
+func (s *Stasher) withStashOptions(client any, sel multiSelector) *Stasher {
+	if pusher, ok := client.(git.StashPusher); ok {
+		s.pusher = pusher
+	}
+	if reader, ok := client.(git.StatusSummaryReader); ok {
+		s.status = reader
+	}
+	s.selectMany = sel
+	return s
+}
+
+// parseStashPush reads the arguments of ggc stash push. Words outside an
+// option make up the message, as in `ggc stash push fix login`, and a lone
+// `select` asks for the paths to stash.
+func parseStashPush(args []string) (opts git.StashOptions, pick bool, err error) {
+	var words []string
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; arg {
+		case "-m", "--message":
+			if i+1 >= len(args) {
+				return opts, false, fmt.Errorf("%s expects a message", arg)
+			}
+			i++
+			opts.Message = args[i]
+		case "-u", "--include-untracked":
+			opts.Untracked = true
+		case "-a", "--all":
+			opts.All = true
+		case "--staged":
+			opts.Staged = true
+		case "--":
+			opts.Paths = append(opts.Paths, args[i+1:]...)
+			i = len(args)
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return opts, false, fmt.Errorf("unknown stash push option %s", arg)
+			}
+			words = append(words, arg)
+		}
+	}
+	if opts.Staged && (opts.Untracked || opts.All) {
+		return opts, false, errors.New("--staged cannot be combined with --include-untracked or --all")
+	}
+	switch {
+	case len(words) == 1 && words[0] == "select":
+		if len(opts.Paths) > 0 {
+			return opts, false, errors.New("stash push select picks the paths itself; drop the paths after --")
+		}
+		pick = true
+	case len(words) > 0 && opts.Message != "":
+		return opts, false, fmt.Errorf("unexpected %q; quote the message after -m", strings.Join(words, " "))
+	case len(words) > 0:
+		opts.Message = strings.Join(words, " ")
+	}
+	return opts, pick, nil
+}
+
+// stashPush creates a new stash: of everything, of the staged changes, of
+// chosen paths, and with or without untracked files.
+func (s *Stasher) stashPush(args []string) {
+	opts, pick, err := parseStashPush(args[1:])
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	plain := !pick && !opts.Untracked && !opts.All && !opts.Staged && len(opts.Paths) == 0
+	if plain {
+		if err := s.gitClient.StashPush(opts.Message); err != nil {
+			WriteError(s.outputWriter, err)
+		}
+		return
+	}
+	if s.pusher == nil {
+		WriteError(s.outputWriter, errors.New("stash push options are not supported here"))
+		return
+	}
+	if pick {
+		paths, ok, err := s.pickStashPaths(opts)
+		if err != nil {
+			WriteError(s.outputWriter, err)
+			return
+		}
+		if !ok {
+			return
+		}
+		opts.Paths = paths
+	}
+	if err := s.pusher.StashPushWith(opts); err != nil {
+		WriteError(s.outputWriter, err)
+	}
+}
+
+// pickStashPaths lets the user choose among the changed paths the stash
+// would take, and returns them as pathspecs. ok is false when there is
+// nothing to choose or the user cancels.
+func (s *Stasher) pickStashPaths(opts git.StashOptions) (paths []string, ok bool, err error) {
+	if s.selectMany == nil || s.status == nil {
+		return nil, false, errors.New("stash push select needs an interactive terminal; name the paths after --")
+	}
+	summary, err := s.status.StatusSummary()
+	if err != nil {
+		return nil, false, err
+	}
+	entries := map[string]git.StatusEntry{}
+	var items []string
+	for _, e := range summary.Entries {
+		if stashable(e, opts) {
+			entries[e.Path] = e
+			items = append(items, e.Path)
+		}
+	}
+	if len(items) == 0 {
+		WriteLine(s.outputWriter, "No local changes to save")
+		return nil, false, nil
+	}
+	selected, ok, err := s.selectMany("Paths to stash", items)
+	if err != nil || !ok || len(selected) == 0 {
+		if err == nil {
+			WriteLine(s.outputWriter, "Canceled.")
+		}
+		return nil, false, err
+	}
+	for _, p := range selected {
+		// Status paths are relative to the top of the working tree, and a
+		// rename is only stashed whole with its old path.
+		paths = append(paths, ":(top,literal)"+p)
+		if orig := entries[p].OrigPath; orig != "" {
+			paths = append(paths, ":(top,literal)"+orig)
+		}
+	}
+	return paths, true, nil
+}
+
+// stashable reports whether a stash shaped by opts would take e.
+func stashable(e git.StatusEntry, opts git.StashOptions) bool {
+	switch e.Kind {
+	case git.StatusOrdinary, git.StatusRenamed:
+		return !opts.Staged || e.Index != '.'
+	case git.StatusUntracked:
+		return opts.Untracked || opts.All
+	case git.StatusIgnored:
+		return opts.All
+	}
+	return false
+}
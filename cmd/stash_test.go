@@ -3,10 +3,15 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 type mockStashOps struct {
@@ -20,6 +25,7 @@ type mockStashOps struct {
 	clearCalled bool
 	stashName   string
 	listOutput  string
+	branchName  string
 }
 
 func (m *mockStashOps) Stash() error { m.stashCalled = true; return nil }
@@ -52,7 +58,13 @@ func (m *mockStashOps) StashDrop(stash string) error {
 	m.stashName = stash
 	return nil
 }
-func (m *mockStashOps) StashClear() error { m.clearCalled = true; return nil }
+func (m *mockStashOps) StashClear() error                  { m.clearCalled = true; return nil }
+func (m *mockStashOps) StashDiff(_ string) (string, error) { return "", nil }
+func (m *mockStashOps) StashBranch(branch, stash string) error {
+	m.branchName = branch
+	m.stashName = stash
+	return nil
+}
 
 var _ git.StashOps = (*mockStashOps)(nil)
 
@@ -388,14 +400,16 @@ type mockStashOpsWithErrors struct {
 	clearErr error
 }
 
-func (m *mockStashOpsWithErrors) Stash() error               { return m.stashErr }
-func (m *mockStashOpsWithErrors) StashList() (string, error) { return "", m.listErr }
-func (m *mockStashOpsWithErrors) StashShow(_ string) error   { return nil }
-func (m *mockStashOpsWithErrors) StashApply(_ string) error  { return nil }
-func (m *mockStashOpsWithErrors) StashPop(_ string) error    { return nil }
-func (m *mockStashOpsWithErrors) StashPush(_ string) error   { return nil }
-func (m *mockStashOpsWithErrors) StashDrop(_ string) error   { return nil }
-func (m *mockStashOpsWithErrors) StashClear() error          { return m.clearErr }
+func (m *mockStashOpsWithErrors) Stash() error                       { return m.stashErr }
+func (m *mockStashOpsWithErrors) StashList() (string, error)         { return "", m.listErr }
+func (m *mockStashOpsWithErrors) StashShow(_ string) error           { return nil }
+func (m *mockStashOpsWithErrors) StashApply(_ string) error          { return nil }
+func (m *mockStashOpsWithErrors) StashPop(_ string) error            { return nil }
+func (m *mockStashOpsWithErrors) StashPush(_ string) error           { return nil }
+func (m *mockStashOpsWithErrors) StashDrop(_ string) error           { return nil }
+func (m *mockStashOpsWithErrors) StashClear() error                  { return m.clearErr }
+func (m *mockStashOpsWithErrors) StashDiff(_ string) (string, error) { return "", nil }
+func (m *mockStashOpsWithErrors) StashBranch(_, _ string) error      { return nil }
 
 var _ git.StashOps = (*mockStashOpsWithErrors)(nil)
 
@@ -454,3 +468,193 @@ func TestStasher_StashList_Empty(t *testing.T) {
 		t.Errorf("expected 'No stashes found', got: %s", buf.String())
 	}
 }
+
+func TestStasher_StashBranch(t *testing.T) {
+	var buf bytes.Buffer
+	mock := &mockStashOps{}
+	s := &Stasher{gitClient: mock, outputWriter: &buf, helper: NewHelper()}
+	s.helper.outputWriter = &buf
+
+	s.Stash([]string{"branch", "feature", "stash@{1}"})
+	if mock.branchName != "feature" || mock.stashName != "stash@{1}" {
+		t.Errorf("StashBranch(%q, %q)", mock.branchName, mock.stashName)
+	}
+
+	s.Stash([]string{"branch"})
+	if !strings.Contains(buf.String(), "ggc stash") {
+		t.Errorf("expected help without a branch name, got %q", buf.String())
+	}
+}
+
+func TestStasher_StashBrowse(t *testing.T) {
+	browse := func(kind interactive.StashActionKind) stashBrowser {
+		return func() (interactive.StashAction, bool, error) {
+			return interactive.StashAction{Kind: kind, Ref: "stash@{2}"}, true, nil
+		}
+	}
+
+	var buf bytes.Buffer
+	mock := &mockStashOps{}
+	s := &Stasher{gitClient: mock, outputWriter: &buf, helper: NewHelper(), browse: browse(interactive.StashPop)}
+	s.Stash([]string{"browse"})
+	if !mock.popCalled || mock.stashName != "stash@{2}" {
+		t.Errorf("expected pop of stash@{2}, got popCalled=%v stash=%q", mock.popCalled, mock.stashName)
+	}
+
+	mock = &mockStashOps{}
+	s = &Stasher{gitClient: mock, outputWriter: &buf, helper: NewHelper(), browse: browse(interactive.StashBranch),
+		prompter: prompt.New(strings.NewReader("from-stash\n"), &buf)}
+	s.Stash([]string{"browse"})
+	if mock.branchName != "from-stash" || mock.stashName != "stash@{2}" {
+		t.Errorf("StashBranch(%q, %q)", mock.branchName, mock.stashName)
+	}
+
+	buf.Reset()
+	s = &Stasher{gitClient: &mockStashOps{}, outputWriter: &buf, helper: NewHelper()}
+	s.Stash([]string{"browse"})
+	if !strings.Contains(buf.String(), "needs an interactive terminal") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestStasher_ClearDeclined(t *testing.T) {
+	var buf bytes.Buffer
+	mock := &mockStashOps{}
+	s := &Stasher{
+		gitClient:    mock,
+		outputWriter: &buf,
+		helper:       NewHelper(),
+		confirm:      ui.NewConfirmer(prompt.New(strings.NewReader("n\n"), &buf), true, ui.ConfirmSimple),
+	}
+	s.helper.outputWriter = &buf
+
+	s.Stash([]string{"clear"})
+	if mock.clearCalled {
+		t.Fatal("StashClear must not run when the prompt is declined")
+	}
+	if !strings.Contains(buf.String(), "Canceled.") {
+		t.Errorf("expected Canceled., got %q", buf.String())
+	}
+}
+
+// mockStashPusher records the options of each stash push and serves a
+// fixed working tree status.
+type mockStashPusher struct {
+	mockStashOps
+	pushed  []git.StashOptions
+	entries []git.StatusEntry
+}
+
+func (m *mockStashPusher) StashPushWith(opts git.StashOptions) error {
+	m.pushed = append(m.pushed, opts)
+	return nil
+}
+
+func (m *mockStashPusher) StatusSummary() (*git.StatusSummary, error) {
+	return &git.StatusSummary{Entries: m.entries}, nil
+}
+
+func TestStasher_StashPush_Options(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    git.StashOptions
+		message string // for a push without options, which goes through StashPush
+		errText string
+	}{
+		{args: []string{"push", "fix", "login"}, message: "fix login"},
+		{args: []string{"push", "-m", "fix login"}, message: "fix login"},
+		{args: []string{"save", "WIP"}, message: "WIP"},
+		{args: []string{"push", "-m", "wip", "-u"}, want: git.StashOptions{Message: "wip", Untracked: true}},
+		{args: []string{"push", "--all"}, want: git.StashOptions{All: true}},
+		{args: []string{"push", "--staged", "--message", "staged only"}, want: git.StashOptions{Message: "staged only", Staged: true}},
+		{args: []string{"push", "-m", "docs", "--", "docs", "README.md"}, want: git.StashOptions{Message: "docs", Paths: []string{"docs", "README.md"}}},
+		{args: []string{"push", "-m"}, errText: "-m expects a message"},
+		{args: []string{"push", "--keep-index"}, errText: "unknown stash push option --keep-index"},
+		{args: []string{"push", "--staged", "-u"}, errText: "--staged cannot be combined"},
+		{args: []string{"push", "-m", "wip", "extra"}, errText: `unexpected "extra"`},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var buf bytes.Buffer
+			mock := &mockStashPusher{}
+			s := (&Stasher{gitClient: mock, outputWriter: &buf, helper: NewHelper()}).withStashOptions(mock, nil)
+			s.Stash(tt.args)
+			switch {
+			case tt.errText != "":
+				if !strings.Contains(buf.String(), tt.errText) || mock.pushCalled || len(mock.pushed) > 0 {
+					t.Errorf("output %q, want an error containing %q and no stash", buf.String(), tt.errText)
+				}
+			case len(mock.pushed) == 0:
+				if !mock.pushCalled || mock.stashName != tt.message {
+					t.Errorf("StashPush(%q) not called; output %q", tt.message, buf.String())
+				}
+			default:
+				if len(mock.pushed) != 1 || !reflect.DeepEqual(mock.pushed[0], tt.want) {
+					t.Errorf("pushed %+v, want %+v", mock.pushed, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStasher_StashPush_OptionsUnsupported(t *testing.T) {
+	var buf bytes.Buffer
+	s := &Stasher{gitClient: &mockStashOps{}, outputWriter: &buf, helper: NewHelper()}
+	s.Stash([]string{"push", "-u"})
+	if !strings.Contains(buf.String(), "stash push options are not supported here") {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestStasher_StashPush_Select(t *testing.T) {
+	entries := []git.StatusEntry{
+		{Kind: git.StatusOrdinary, Index: '.', WorkTree: 'M', Path: "cmd/add.go"},
+		{Kind: git.StatusRenamed, Index: 'R', WorkTree: '.', Path: "docs/new.md", OrigPath: "docs/old.md"},
+		{Kind: git.StatusUntracked, Path: "notes.txt"},
+	}
+	tests := []struct {
+		args    []string
+		offered []string
+		want    []string
+	}{
+		{[]string{"push", "select"}, []string{"cmd/add.go", "docs/new.md"}, []string{":(top,literal)cmd/add.go", ":(top,literal)docs/new.md", ":(top,literal)docs/old.md"}},
+		{[]string{"push", "select", "--staged"}, []string{"docs/new.md"}, []string{":(top,literal)docs/new.md", ":(top,literal)docs/old.md"}},
+		{[]string{"push", "-u", "select"}, []string{"cmd/add.go", "docs/new.md", "notes.txt"}, []string{":(top,literal)cmd/add.go", ":(top,literal)docs/new.md", ":(top,literal)docs/old.md", ":(top,literal)notes.txt"}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var offered []string
+			sel := func(_ string, items []string) ([]string, bool, error) {
+				offered = items
+				return items, true, nil
+			}
+			mock := &mockStashPusher{entries: entries}
+			s := (&Stasher{gitClient: mock, outputWriter: &bytes.Buffer{}, helper: NewHelper()}).withStashOptions(mock, sel)
+			s.Stash(tt.args)
+			if !slices.Equal(offered, tt.offered) {
+				t.Errorf("offered %v, want %v", offered, tt.offered)
+			}
+			if len(mock.pushed) != 1 || !slices.Equal(mock.pushed[0].Paths, tt.want) {
+				t.Errorf("pushed %+v, want paths %v", mock.pushed, tt.want)
+			}
+		})
+	}
+}
+
+func TestStasher_StashPush_SelectCanceledOrWithoutTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	mock := &mockStashPusher{entries: []git.StatusEntry{{Kind: git.StatusOrdinary, Index: '.', WorkTree: 'M', Path: "a.go"}}}
+	cancel := func(string, []string) ([]string, bool, error) { return nil, false, nil }
+	s := (&Stasher{gitClient: mock, outputWriter: &buf, helper: NewHelper()}).withStashOptions(mock, cancel)
+	s.Stash([]string{"push", "select"})
+	if len(mock.pushed) != 0 || !strings.Contains(buf.String(), "Canceled.") {
+		t.Errorf("pushed %+v, output %q", mock.pushed, buf.String())
+	}
+
+	buf.Reset()
+	s.selectMany = nil
+	s.Stash([]string{"push", "select"})
+	if len(mock.pushed) != 0 || !strings.Contains(buf.String(), "needs an interactive terminal") {
+		t.Errorf("pushed %+v, output %q", mock.pushed, buf.String())
+	}
+}
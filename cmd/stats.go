@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/stats"
+)
+
+// statsTopCommands is how many commands `ggc stats` ranks.
+const statsTopCommands = 10
+
+// statsDateFormat is the date the statistics started on.
+const statsDateFormat = "2006-01-02"
+
+// Stats shows or resets the local usage statistics. They are recorded by
+// the router, the interactive UI and the workflow executor, and never
+// leave the machine.
+//
+//	(no args)  — show the statistics
+//	reset      — delete them
+func (c *Cmd) Stats(args []string) {
+	switch {
+	case len(args) == 0:
+		c.showStats(stats.Current(), time.Now())
+	case len(args) == 1 && args[0] == "reset":
+		if err := stats.Current().Reset(); err != nil {
+			WriteError(c.outputWriter, err)
+			return
+		}
+		WriteLine(c.outputWriter, "Usage statistics reset.")
+	default:
+		c.helper.ShowStatsHelp()
+	}
+}
+
+func (c *Cmd) showStats(store *stats.Store, now time.Time) {
+	data, err := store.Load()
+	if err != nil {
+		WriteError(c.outputWriter, err)
+		return
+	}
+	if store.Disabled {
+		WriteLine(c.outputWriter, "Recording is off (stats.enabled: false or GGC_NO_STATS).")
+	}
+	if data.Empty() {
+		WriteLine(c.outputWriter, "No usage recorded yet.")
+		return
+	}
+
+	days := int(now.Sub(data.Since).Hours()/24) + 1
+	WriteLinef(c.outputWriter, "Usage since %s (%d day(s))", data.Since.Local().Format(statsDateFormat), days)
+	WriteLine(c.outputWriter, "")
+
+	if top := data.TopCommands(statsTopCommands); len(top) > 0 {
+		width := 0
+		for _, row := range top {
+			width = max(width, len(row.Command))
+		}
+		WriteLine(c.outputWriter, "Most-used commands:")
+		for _, row := range top {
+			WriteLinef(c.outputWriter, "  %-*s  %d", width, row.Command, row.Count)
+		}
+		WriteLine(c.outputWriter, "")
+	}
+
+	WriteLinef(c.outputWriter, "Commands run:          %d", data.CommandsRun())
+	if data.Sessions > 0 {
+		WriteLinef(c.outputWriter, "Interactive sessions:  %d, %s on average", data.Sessions, roundDuration(data.AverageSession()))
+	} else {
+		WriteLine(c.outputWriter, "Interactive sessions:  0")
+	}
+	WriteLinef(c.outputWriter, "Workflows executed:    %d (%d step(s))", data.Workflows, data.WorkflowSteps)
+	WriteLinef(c.outputWriter, "Time saved:            about %s (estimate)", roundDuration(data.TimeSaved()))
+
+	if path, err := store.Location(); err == nil {
+		WriteLine(c.outputWriter, "")
+		WriteLinef(c.outputWriter, "Recorded only on this machine, in %s.", path)
+	}
+}
+
+// roundDuration drops the precision a usage summary does not need.
+func roundDuration(d time.Duration) time.Duration {
+	if d >= time.Hour {
+		return d.Round(time.Minute)
+	}
+	return d.Round(time.Second)
+}
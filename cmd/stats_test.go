@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	commandregistry "github.com/bmf-san/ggc/v8/cmd/command"
+	"github.com/bmf-san/ggc/v8/internal/stats"
+)
+
+func useStatsStore(t *testing.T) *stats.Store {
+	t.Helper()
+	store := &stats.Store{Path: filepath.Join(t.TempDir(), "stats.json")}
+	prev := stats.Current()
+	stats.SetDefault(store)
+	t.Cleanup(func() { stats.SetDefault(prev) })
+	return store
+}
+
+func TestCmd_Stats(t *testing.T) {
+	store := useStatsStore(t)
+	since := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"status", "commit", "status"} {
+		if err := store.RecordCommand(name, since); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.RecordSession(90*time.Second, since); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RecordWorkflow(2, since); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	c := &Cmd{outputWriter: &buf, helper: NewHelper()}
+	c.showStats(store, since.Add(48*time.Hour))
+	out := buf.String()
+	for _, want := range []string{
+		"(3 day(s))",
+		"Most-used commands:\n  status  2\n  commit  1\n",
+		"Commands run:          3\n",
+		"Interactive sessions:  1, 1m30s on average\n",
+		"Workflows executed:    1 (2 step(s))\n",
+		"Time saved:            about 19s (estimate)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	c.Stats([]string{"reset"})
+	if !strings.Contains(buf.String(), "Usage statistics reset.") {
+		t.Errorf("reset output = %q", buf.String())
+	}
+	buf.Reset()
+	c.Stats(nil)
+	if !strings.Contains(buf.String(), "No usage recorded yet.") {
+		t.Errorf("output after reset = %q", buf.String())
+	}
+}
+
+func TestRecordStats_SkipsMetaCommands(t *testing.T) {
+	store := useStatsStore(t)
+	recordStats(&commandregistry.Info{Name: "status"})
+	recordStats(&commandregistry.Info{Name: "__complete", Hidden: true})
+	recordStats(&commandregistry.Info{Name: "stats"})
+	recordStats(&commandregistry.Info{Name: "history"})
+
+	data, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Commands) != 1 || data.Commands["status"] != 1 {
+		t.Errorf("commands = %v, want only status", data.Commands)
+	}
+}
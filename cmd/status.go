@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 // Statuser handles status operations.
@@ -14,6 +16,8 @@ type Statuser struct {
 	outputWriter io.Writer
 	helper       *Helper
 	gitClient    git.StatusInfoReader
+	lfs          git.LFSReader // nil skips the Git LFS check
+	scope        *pathScope
 }
 
 // NewStatuser creates a new Statuser instance.
@@ -25,6 +29,32 @@ func NewStatuser(client git.StatusInfoReader) *Statuser {
 	}
 }
 
+// withLFS warns in ggc status when the repository uses Git LFS but
+// git-lfs is not installed.
+func (s *Statuser) withLFS(lfs git.LFSReader) *Statuser {
+	s.lfs = lfs
+	return s
+}
+
+// withPathScope limits ggc status to the directory of ggc --path.
+func (s *Statuser) withPathScope(scope *pathScope) *Statuser {
+	s.scope = scope
+	return s
+}
+
+// lfsWarning returns a warning when files are stored in Git LFS but
+// git-lfs is not installed, so the working tree holds their pointers.
+func (s *Statuser) lfsWarning() string {
+	if s.lfs == nil {
+		return ""
+	}
+	files, err := s.lfs.LFSFiles()
+	if err != nil || len(files) == 0 || s.lfs.LFSInstalled() {
+		return ""
+	}
+	return fmt.Sprintf("Warning: this repository stores %d file(s) in Git LFS but git-lfs is not installed; they hold pointers instead of content. Install it from https://git-lfs.com and run 'ggc lfs pull'.", len(files))
+}
+
 // getUpstreamStatus gets the upstream tracking status ahead/behind counts.
 func (s *Statuser) getUpstreamStatus(branch string) string {
 	// Check if upstream exists
@@ -68,38 +98,63 @@ func (s *Statuser) formatAheadBehind(upstream, ahead, behind string) string {
 	}
 }
 
+// branchHeader returns the current branch and its upstream line, from a
+// single porcelain v2 read when the client supports it.
+func (s *Statuser) branchHeader() (string, string, error) {
+	if reader, ok := s.gitClient.(git.StatusSummaryReader); ok {
+		summary, err := reader.StatusSummary()
+		if err != nil {
+			return "", "", err
+		}
+		if summary.Upstream == "" {
+			return summary.Branch, "", nil
+		}
+		return summary.Branch, s.formatAheadBehind(summary.Upstream, strconv.Itoa(summary.Ahead), strconv.Itoa(summary.Behind)), nil
+	}
+
+	branch, err := s.gitClient.GetCurrentBranch()
+	if err != nil {
+		return "", "", err
+	}
+	return branch, s.getUpstreamStatus(branch), nil
+}
+
 // Status executes git status with the given arguments.
 func (s *Statuser) Status(args []string) {
 	if len(args) == 0 {
 		// Show status with color and branch info
-		branch, err := s.gitClient.GetCurrentBranch()
+		branch, upstreamStatus, err := s.branchHeader()
 		if err != nil {
-			_, _ = fmt.Fprintf(s.outputWriter, "Error getting current branch: %v\n", err)
+			WriteErrorf(s.outputWriter, "getting current branch: %w", err)
 			return
 		}
 
-		upstreamStatus := s.getUpstreamStatus(branch)
-
 		_, _ = fmt.Fprintf(s.outputWriter, "On branch %s\n", branch)
 		if upstreamStatus != "" {
 			_, _ = fmt.Fprintf(s.outputWriter, "%s\n", upstreamStatus)
 		}
+		if warning := s.lfsWarning(); warning != "" {
+			WriteLine(s.outputWriter, warning)
+		}
+		if dir := s.scope.describe(); dir != "" {
+			WriteLinef(s.outputWriter, "Showing changes under %s only", dir)
+		}
 		_, _ = fmt.Fprintf(s.outputWriter, "\n")
 
-		if output, err := s.gitClient.StatusWithColor(); err != nil {
+		if output, err := s.gitClient.StatusWithColor(s.scope.pathspecs()...); err != nil {
 			WriteError(s.outputWriter, err)
 		} else {
-			_, _ = fmt.Fprint(s.outputWriter, output)
+			s.writeColored(output)
 		}
 		return
 	}
 
 	switch args[0] {
 	case "short":
-		if output, err := s.gitClient.StatusShortWithColor(); err != nil {
+		if output, err := s.gitClient.StatusShortWithColor(s.scope.pathspecs()...); err != nil {
 			WriteError(s.outputWriter, err)
 		} else {
-			_, _ = fmt.Fprint(s.outputWriter, output)
+			s.writeColored(output)
 		}
 		return
 	default:
@@ -107,3 +162,12 @@ func (s *Statuser) Status(args []string) {
 		return
 	}
 }
+
+// writeColored writes git's forced-color output, stripped of color when
+// the output should be plain (--no-color, NO_COLOR, or not a terminal).
+func (s *Statuser) writeColored(output string) {
+	if !ui.ColorEnabled(s.outputWriter) {
+		output = ui.StripANSI(output)
+	}
+	_, _ = fmt.Fprint(s.outputWriter, output)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 type mockStatusInfoReader struct {
@@ -15,6 +16,7 @@ type mockStatusInfoReader struct {
 	aheadBehindCount     string
 	statusWithColor      string
 	statusShortWithColor string
+	paths                []string
 }
 
 func (m *mockStatusInfoReader) GetCurrentBranch() (string, error) {
@@ -35,10 +37,12 @@ func (m *mockStatusInfoReader) GetAheadBehindCount(string, string) (string, erro
 	}
 	return m.aheadBehindCount, nil
 }
-func (m *mockStatusInfoReader) StatusWithColor() (string, error) {
+func (m *mockStatusInfoReader) StatusWithColor(paths ...string) (string, error) {
+	m.paths = paths
 	return m.statusWithColor, nil
 }
-func (m *mockStatusInfoReader) StatusShortWithColor() (string, error) {
+func (m *mockStatusInfoReader) StatusShortWithColor(paths ...string) (string, error) {
+	m.paths = paths
 	return m.statusShortWithColor, nil
 }
 
@@ -443,3 +447,84 @@ func TestGetUpstreamStatus_MalformedOutput(t *testing.T) {
 		t.Errorf("expected up-to-date message for malformed output, got %q", result)
 	}
 }
+
+func TestStatuser_ColorMode(t *testing.T) {
+	t.Cleanup(func() { ui.SetColorMode(ui.ColorAuto) })
+	colored := "\033[31m??\033[m new.go\n"
+
+	for _, tt := range []struct {
+		mode ui.ColorMode
+		want string
+	}{
+		{ui.ColorNever, "?? new.go\n"},
+		{ui.ColorAlways, colored},
+	} {
+		ui.SetColorMode(tt.mode)
+		buf := &bytes.Buffer{}
+		s := &Statuser{gitClient: &mockStatusInfoReader{statusShortWithColor: colored}, outputWriter: buf, helper: NewHelper()}
+		s.Status([]string{"short"})
+		if buf.String() != tt.want {
+			t.Errorf("%v: got %q, want %q", tt.mode, buf.String(), tt.want)
+		}
+	}
+}
+
+// summaryStatusReader answers the branch header from one porcelain v2 read.
+type summaryStatusReader struct {
+	mockStatusInfoReader
+	summary *git.StatusSummary
+}
+
+func (m *summaryStatusReader) StatusSummary() (*git.StatusSummary, error) {
+	return m.summary, nil
+}
+
+func TestStatuser_Status_UsesSummary(t *testing.T) {
+	var buf bytes.Buffer
+	s := &Statuser{
+		outputWriter: &buf,
+		helper:       NewHelper(),
+		gitClient: &summaryStatusReader{
+			// The separate-call answers differ so a fallback would show.
+			mockStatusInfoReader: mockStatusInfoReader{currentBranch: "wrong", statusWithColor: "body\n"},
+			summary:              &git.StatusSummary{Branch: "feature", Upstream: "origin/feature", Ahead: 3},
+		},
+	}
+	s.Status(nil)
+
+	got := buf.String()
+	want := "On branch feature\nYour branch is ahead of 'origin/feature' by 3 commit(s)\n\nbody\n"
+	if got != want {
+		t.Errorf("Status() output = %q, want %q", got, want)
+	}
+}
+
+type stubLFSReader struct {
+	installed bool
+	files     []string
+}
+
+func (s stubLFSReader) LFSInstalled() bool          { return s.installed }
+func (s stubLFSReader) LFSFiles() ([]string, error) { return s.files, nil }
+
+func TestStatuser_Status_LFSWarning(t *testing.T) {
+	tests := []struct {
+		name string
+		lfs  stubLFSReader
+		warn bool
+	}{
+		{"missing git-lfs", stubLFSReader{files: []string{"a.psd", "b.psd"}}, true},
+		{"installed", stubLFSReader{installed: true, files: []string{"a.psd"}}, false},
+		{"no LFS files", stubLFSReader{}, false},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		s := NewStatuser(&mockStatusInfoReader{}).withLFS(tt.lfs)
+		s.outputWriter = &buf
+		s.Status(nil)
+		got := strings.Contains(buf.String(), "stores 2 file(s) in Git LFS but git-lfs is not installed")
+		if got != tt.warn {
+			t.Errorf("%s: warned = %v, want %v; output:\n%s", tt.name, got, tt.warn, buf.String())
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+)
+
+const (
+	// switchAmbiguousShown caps how many candidates an ambiguous name lists.
+	switchAmbiguousShown = 10
+	// switchRecentLimit is how many branches ggc switch recent lists
+	// unless switch.recent.limit says otherwise.
+	switchRecentLimit = 10
+	// switchRecentScan is how many reflog entries ggc switch recent reads.
+	switchRecentScan = 1000
+)
+
+// switchOps are the git operations behind ggc switch.
+type switchOps interface {
+	git.PassthroughOps
+	GetCurrentBranch() (string, error)
+	ListLocalBranches() ([]string, error)
+	ListRemoteBranches() ([]string, error)
+	git.RecentBranchReader
+}
+
+// switchTarget is where ggc switch goes: a local branch, or a remote
+// branch to create a local tracking branch for.
+type switchTarget struct {
+	local  string
+	remote string // empty unless local has to be created from it
+}
+
+// Switcher handles ggc switch.
+type Switcher struct {
+	gitClient     switchOps
+	outputWriter  io.Writer
+	helper        *Helper
+	prompter      prompt.Prompter
+	pick          picker
+	stash         *autostasher // nil leaves uncommitted changes to git
+	configManager *config.Manager
+}
+
+// NewSwitcher creates a new Switcher instance.
+func NewSwitcher(client switchOps) *Switcher {
+	return &Switcher{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+		prompter:     prompt.New(os.Stdin, os.Stdout),
+	}
+}
+
+// withPicker lets ggc switch pick among branches in a fuzzy picker.
+func (s *Switcher) withPicker(p picker) *Switcher {
+	s.pick = p
+	return s
+}
+
+// withConfigManager supplies switch.recent.limit and switch.recent.exclude.
+func (s *Switcher) withConfigManager(cm *config.Manager) *Switcher {
+	s.configManager = cm
+	return s
+}
+
+// withAutostash carries uncommitted changes across a switch in a stash,
+// as behavior.autostash says.
+func (s *Switcher) withAutostash(a *autostasher) *Switcher {
+	s.stash = a
+	return s
+}
+
+// Switch switches branches. A name is matched against local branches,
+// then remote ones (creating a tracking branch), then fuzzily; `-` goes
+// back to the previous branch and `recent` picks from the branches used
+// last. Options and other forms go to git switch as-is.
+func (s *Switcher) Switch(args []string) {
+	switch {
+	case len(args) == 1 && args[0] == "help":
+		s.helper.ShowPassthroughHelp("switch")
+	case len(args) > 0 && args[0] == "recent":
+		s.switchRecent(args[1:])
+	case len(args) == 0:
+		if s.pick == nil {
+			s.helper.ShowPassthroughHelp("switch")
+			return
+		}
+		s.switchByName("")
+	case len(args) == 1 && args[0] == "-":
+		s.switchTo(switchTarget{local: "-"})
+	case len(args) == 1 && !strings.HasPrefix(args[0], "-"):
+		s.switchByName(args[0])
+	default:
+		if err := s.gitClient.RunGit("switch", args); err != nil {
+			WriteError(s.outputWriter, err)
+		}
+	}
+}
+
+func (s *Switcher) switchByName(name string) {
+	locals, err := s.gitClient.ListLocalBranches()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	remotes, err := s.gitClient.ListRemoteBranches()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	current, _ := s.gitClient.GetCurrentBranch()
+	target, ok := s.resolveSwitchTarget(name, current, locals, remotes)
+	if ok {
+		s.switchTo(target)
+	}
+}
+
+// resolveSwitchTarget turns name into a branch. Exact names win; a name
+// that only matches fuzzily is taken when it is the only match, and
+// otherwise picked from the matches.
+func (s *Switcher) resolveSwitchTarget(name, current string, locals, remotes []string) (switchTarget, bool) {
+	if name != "" && slices.Contains(locals, name) {
+		return switchTarget{local: name}, true
+	}
+	remoteOnly := make(map[string]string) // remote ref -> local name
+	var tracking []string                 // remote refs whose local name is name
+	for _, ref := range remotes {
+		local, ok := deriveLocalFromRemote(ref)
+		if !ok || slices.Contains(locals, local) {
+			if ref == name {
+				return switchTarget{local: local}, true
+			}
+			continue
+		}
+		remoteOnly[ref] = local
+		if ref == name {
+			return switchTarget{local: local, remote: ref}, true
+		}
+		if local == name {
+			tracking = append(tracking, ref)
+		}
+	}
+	if len(tracking) == 1 {
+		return switchTarget{local: name, remote: tracking[0]}, true
+	}
+
+	var candidates []string
+	for _, b := range locals {
+		if b != current {
+			candidates = append(candidates, b)
+		}
+	}
+	for _, ref := range remotes {
+		if _, ok := remoteOnly[ref]; ok {
+			candidates = append(candidates, ref)
+		}
+	}
+	matches := candidates
+	if name != "" {
+		matches = interactive.FuzzyFilter(name, candidates)
+	}
+	target := func(b string) switchTarget {
+		if local, ok := remoteOnly[b]; ok {
+			return switchTarget{local: local, remote: b}
+		}
+		return switchTarget{local: b}
+	}
+
+	switch {
+	case len(matches) == 0 && name == "":
+		WriteLine(s.outputWriter, "No other branches to switch to.")
+		return switchTarget{}, false
+	case len(matches) == 0:
+		WriteErrorf(s.outputWriter, "no branch matches %q", name)
+		return switchTarget{}, false
+	case len(matches) == 1 && name != "":
+		_, _ = fmt.Fprintf(s.outputWriter, "Switching to %s (matched %q)\n", matches[0], name)
+		return target(matches[0]), true
+	case s.pick == nil:
+		shown := matches[:min(len(matches), switchAmbiguousShown)]
+		WriteErrorf(s.outputWriter, "%q matches several branches: %s", name, strings.Join(shown, ", "))
+		return switchTarget{}, false
+	}
+
+	items := make([]interactive.PickItem, len(candidates))
+	for i, b := range candidates {
+		items[i] = interactive.PickItem{Value: b}
+		if local, ok := remoteOnly[b]; ok {
+			items[i].Detail = "remote; creates " + local
+		}
+	}
+	choice, ok, err := s.pick("Switch to branch", items, name)
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return switchTarget{}, false
+	}
+	if !ok {
+		return switchTarget{}, false
+	}
+	return target(choice), true
+}
+
+// switchRecent lists the branches used last, newest first, and switches
+// to one: the nth with a number, or the one picked on a terminal. --list,
+// or no terminal, prints the list instead.
+func (s *Switcher) switchRecent(args []string) {
+	var list bool
+	var n int
+	switch {
+	case len(args) == 0:
+	case len(args) == 1 && args[0] == "--list":
+		list = true
+	case len(args) == 1:
+		var err error
+		if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+			WriteErrorf(s.outputWriter, "invalid recent branch number %q", args[0])
+			return
+		}
+	default:
+		s.helper.ShowPassthroughHelp("switch")
+		return
+	}
+
+	branches, err := s.recentBranches()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	switch {
+	case len(branches) == 0:
+		WriteLine(s.outputWriter, "No recent branches to switch to.")
+	case n > len(branches):
+		WriteErrorf(s.outputWriter, "there are only %d recent branches", len(branches))
+	case n > 0:
+		s.switchTo(switchTarget{local: branches[n-1]})
+	case list || s.pick == nil:
+		for i, b := range branches {
+			_, _ = fmt.Fprintf(s.outputWriter, "%3d  %s\n", i+1, b)
+		}
+	default:
+		items := make([]interactive.PickItem, len(branches))
+		for i, b := range branches {
+			items[i] = interactive.PickItem{Value: b, Detail: strconv.Itoa(i + 1)}
+		}
+		choice, ok, err := s.pick("Switch to a recent branch", items, "")
+		if err != nil {
+			WriteError(s.outputWriter, err)
+			return
+		}
+		if ok {
+			s.switchTo(switchTarget{local: choice})
+		}
+	}
+}
+
+// recentBranches returns the local branches HEAD moved between most
+// recently, newest first, leaving out the current branch, deleted
+// branches and those matching switch.recent.exclude.
+func (s *Switcher) recentBranches() ([]string, error) {
+	limit := switchRecentLimit
+	var exclude []string
+	if s.configManager != nil {
+		cfg := s.configManager.GetConfig()
+		if cfg.Switch.Recent.Limit > 0 {
+			limit = cfg.Switch.Recent.Limit
+		}
+		exclude = cfg.Switch.Recent.Exclude
+	}
+	names, err := s.gitClient.RecentBranches(switchRecentScan)
+	if err != nil {
+		return nil, err
+	}
+	locals, err := s.gitClient.ListLocalBranches()
+	if err != nil {
+		return nil, err
+	}
+	current, _ := s.gitClient.GetCurrentBranch()
+
+	var branches []string
+	for _, name := range names {
+		if len(branches) == limit {
+			break
+		}
+		if name == current || !slices.Contains(locals, name) || matchesAny(exclude, name) {
+			continue
+		}
+		branches = append(branches, name)
+	}
+	return branches, nil
+}
+
+// matchesAny reports whether name matches one of the globs.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// switchTo switches to target, first offering to carry uncommitted
+// changes across in a stash.
+func (s *Switcher) switchTo(target switchTarget) {
+	dest := target.local
+	if dest == "-" {
+		dest = "the previous branch"
+	}
+	stashed, ok := s.stash.save(s.outputWriter,
+		fmt.Sprintf("You have uncommitted changes. Stash them and re-apply them on %s? (y/n): ", dest),
+		"ggc switch autostash from %s")
+	if !ok {
+		return
+	}
+	args := []string{target.local}
+	if target.remote != "" {
+		args = []string{"-c", target.local, "--track", target.remote}
+	}
+	if err := s.gitClient.RunGit("switch", args); err != nil {
+		WriteError(s.outputWriter, err)
+		if stashed {
+			s.stash.restore(s.outputWriter, "")
+		}
+		return
+	}
+	if stashed {
+		s.stash.restore(s.outputWriter, target.local)
+	}
+}
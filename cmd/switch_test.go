@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockSwitchClient struct {
+	testutil.MockGitClient
+	current string
+	locals  []string
+	remotes []string
+	recent  []string
+	calls   []string
+}
+
+func (m *mockSwitchClient) RecentBranches(_ int) ([]string, error) { return m.recent, nil }
+
+func (m *mockSwitchClient) GetCurrentBranch() (string, error)     { return m.current, nil }
+func (m *mockSwitchClient) ListLocalBranches() ([]string, error)  { return m.locals, nil }
+func (m *mockSwitchClient) ListRemoteBranches() ([]string, error) { return m.remotes, nil }
+
+func (m *mockSwitchClient) RunGit(name string, args []string) error {
+	m.calls = append(m.calls, name+" "+strings.Join(args, " "))
+	return nil
+}
+
+func (m *mockSwitchClient) StashPush(message string) error {
+	m.calls = append(m.calls, "stash push "+message)
+	return nil
+}
+
+func (m *mockSwitchClient) StashPop(_ string) error {
+	m.calls = append(m.calls, "stash pop")
+	return nil
+}
+
+// dirtyStatus reports one modified file on main.
+type dirtyStatus struct{}
+
+func (dirtyStatus) StatusSummary() (*git.StatusSummary, error) {
+	return &git.StatusSummary{Branch: "main", Entries: []git.StatusEntry{
+		{Kind: git.StatusOrdinary, Index: '.', WorkTree: 'M', Path: "a.go"},
+	}}, nil
+}
+
+func newTestSwitcher(client *mockSwitchClient, buf *bytes.Buffer) *Switcher {
+	s := NewSwitcher(client)
+	s.outputWriter = buf
+	s.helper.outputWriter = buf
+	return s
+}
+
+func TestSwitcher_Switch(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+		out  string
+	}{
+		{"exact local", []string{"develop"}, "switch develop", ""},
+		{"previous branch", []string{"-"}, "switch -", ""},
+		{"remote ref creates a tracking branch", []string{"origin/fix-42"}, "switch -c fix-42 --track origin/fix-42", ""},
+		{"remote-only name creates a tracking branch", []string{"fix-42"}, "switch -c fix-42 --track origin/fix-42", ""},
+		{"remote ref of a local branch", []string{"origin/develop"}, "switch develop", ""},
+		{"unique fuzzy match", []string{"login"}, "switch feature/login", `Switching to feature/login (matched "login")`},
+		{"no match", []string{"zzz"}, "", `no branch matches "zzz"`},
+		{"ambiguous without a terminal", []string{"e"}, "", "matches several branches: develop, feature/login"},
+		{"options go to git", []string{"-c", "new"}, "switch -c new", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			client := &mockSwitchClient{
+				current: "main",
+				locals:  []string{"main", "develop", "feature/login"},
+				remotes: []string{"origin/main", "origin/develop", "origin/fix-42"},
+			}
+			newTestSwitcher(client, &buf).Switch(tt.args)
+			if got := strings.Join(client.calls, "; "); got != tt.want {
+				t.Errorf("calls = %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(buf.String(), tt.out) {
+				t.Errorf("output %q should contain %q", buf.String(), tt.out)
+			}
+		})
+	}
+}
+
+func TestSwitcher_Switch_Picker(t *testing.T) {
+	var buf bytes.Buffer
+	client := &mockSwitchClient{current: "main", locals: []string{"main", "develop"}, remotes: []string{"origin/fix-42"}}
+	var gotItems []interactive.PickItem
+	var gotQuery string
+	s := newTestSwitcher(client, &buf).withPicker(func(_ string, items []interactive.PickItem, query string) (string, bool, error) {
+		gotItems, gotQuery = items, query
+		return "origin/fix-42", true, nil
+	})
+
+	s.Switch(nil)
+
+	want := []interactive.PickItem{{Value: "develop"}, {Value: "origin/fix-42", Detail: "remote; creates fix-42"}}
+	if len(gotItems) != len(want) || gotItems[0] != want[0] || gotItems[1] != want[1] || gotQuery != "" {
+		t.Errorf("picker got %+v, %q", gotItems, gotQuery)
+	}
+	if got := strings.Join(client.calls, "; "); got != "switch -c fix-42 --track origin/fix-42" {
+		t.Errorf("calls = %q", got)
+	}
+}
+
+func TestSwitcher_Switch_Autostash(t *testing.T) {
+	for _, answer := range []string{"y", "n"} {
+		var buf bytes.Buffer
+		client := &mockSwitchClient{current: "main", locals: []string{"main", "develop"}}
+		s := newTestSwitcher(client, &buf)
+		s.stash = &autostasher{ops: client, status: dirtyStatus{}, prompter: prompt.New(strings.NewReader(answer+"\n"), &buf)}
+
+		s.Switch([]string{"develop"})
+
+		want := "switch develop"
+		if answer == "y" {
+			want = "stash push ggc switch autostash from main; switch develop; stash pop"
+		}
+		if got := strings.Join(client.calls, "; "); got != want {
+			t.Errorf("answer %q: calls = %q, want %q", answer, got, want)
+		}
+	}
+}
+
+func TestSwitcher_Switch_Recent(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+		out  string
+	}{
+		{"list without a terminal", []string{"recent"}, "", "  1  develop\n  2  feature/login\n"},
+		{"list", []string{"recent", "--list"}, "", "  2  feature/login\n"},
+		{"by number", []string{"recent", "2"}, "switch feature/login", ""},
+		{"number out of range", []string{"recent", "5"}, "", "there are only 2 recent branches"},
+		{"not a number", []string{"recent", "x"}, "", `invalid recent branch number "x"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			client := &mockSwitchClient{
+				current: "main",
+				locals:  []string{"main", "develop", "feature/login", "tmp/spike"},
+				// gone was deleted; tmp/spike is excluded below.
+				recent: []string{"main", "develop", "gone", "tmp/spike", "feature/login"},
+			}
+			s := newTestSwitcher(client, &buf)
+			s.configManager = config.NewConfigManager(client)
+			s.configManager.GetConfig().Switch.Recent.Exclude = []string{"tmp/*"}
+
+			s.Switch(tt.args)
+
+			if got := strings.Join(client.calls, "; "); got != tt.want {
+				t.Errorf("calls = %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(buf.String(), tt.out) {
+				t.Errorf("output %q should contain %q", buf.String(), tt.out)
+			}
+		})
+	}
+}
+
+func TestSwitcher_Switch_RecentPicker(t *testing.T) {
+	var buf bytes.Buffer
+	client := &mockSwitchClient{
+		current: "main",
+		locals:  []string{"main", "a", "b", "c"},
+		recent:  []string{"main", "c", "b", "a"},
+	}
+	var gotItems []interactive.PickItem
+	s := newTestSwitcher(client, &buf).withPicker(func(_ string, items []interactive.PickItem, _ string) (string, bool, error) {
+		gotItems = items
+		return items[0].Value, true, nil
+	})
+	s.configManager = config.NewConfigManager(client)
+	s.configManager.GetConfig().Switch.Recent.Limit = 2
+
+	s.Switch([]string{"recent"})
+
+	want := []interactive.PickItem{{Value: "c", Detail: "1"}, {Value: "b", Detail: "2"}}
+	if len(gotItems) != len(want) || gotItems[0] != want[0] || gotItems[1] != want[1] {
+		t.Errorf("picker got %+v, want %+v", gotItems, want)
+	}
+	if got := strings.Join(client.calls, "; "); got != "switch c" {
+		t.Errorf("calls = %q", got)
+	}
+}
@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// syncPushRecovery follows a failed push, which leaves the branch synced
+// locally.
+const syncPushRecovery = "The branch is up to date locally but was not pushed. Fix the cause above, then run 'ggc push current'."
+
+// syncOps are the git operations behind ggc sync.
+type syncOps interface {
+	git.BranchUpstreamReader
+	git.FetchOps
+	git.MergeOps
+	git.UpstreamPusher
+	Rebase(upstream string) error
+	StashPush(message string) error
+	StashPop(stash string) error
+}
+
+// syncOptions are the sync.* settings after command-line overrides.
+type syncOptions struct {
+	strategy  string // rebase or merge
+	prune     bool
+	autostash bool
+	push      bool
+	remote    string   // where a branch without an upstream is pushed
+	gateArgs  []string // the flags for the push gate
+}
+
+// syncStep is one stage of ggc sync. run may return a note, such as when
+// there was nothing to do; recovery tells the user how to carry on when
+// the stage fails.
+type syncStep struct {
+	title    string
+	run      func() (note string, err error)
+	recovery string
+}
+
+// Syncer handles ggc sync.
+type Syncer struct {
+	gitClient     syncOps
+	outputWriter  io.Writer
+	helper        *Helper
+	configManager *config.Manager
+	status        git.StatusSummaryReader // nil: uncommitted changes are left to git
+	ssh           *sshPreflight           // nil when ssh.preflight is off
+	gate          *pushGate               // nil pushes without the shared checks
+}
+
+// NewSyncer creates a new Syncer instance.
+func NewSyncer(client syncOps) *Syncer {
+	s := &Syncer{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+	}
+	s.helper.outputWriter = s.outputWriter
+	return s
+}
+
+// withConfigManager supplies the sync.* settings and git.default-remote.
+func (s *Syncer) withConfigManager(cm *config.Manager) *Syncer {
+	s.configManager = cm
+	return s
+}
+
+// withStatus lets ggc sync see uncommitted changes when client can read
+// the working tree status.
+func (s *Syncer) withStatus(client any) *Syncer {
+	if reader, ok := client.(git.StatusSummaryReader); ok {
+		s.status = reader
+	}
+	return s
+}
+
+// withSSHPreflight checks the SSH keys for the remotes sync fetches from
+// and pushes to before it starts, when ssh.preflight is set. cm may be nil.
+func (s *Syncer) withSSHPreflight(urls sshRemotes, cm *config.Manager) *Syncer {
+	s.ssh = newSSHPreflight(urls, cm)
+	return s
+}
+
+// withPushGate runs the checks shared by every command that pushes
+// before a sync that pushes starts.
+func (s *Syncer) withPushGate(g *pushGate) *Syncer {
+	s.gate = g
+	return s
+}
+
+// Sync fetches, takes in the current branch's upstream by rebasing or
+// merging, and pushes the branch, stashing uncommitted changes around it.
+// Each stage is numbered as it runs; when one fails, the remaining ones
+// are skipped and Sync says how to recover.
+func (s *Syncer) Sync(args []string) {
+	opts, ok := s.parseArgs(args)
+	if !ok {
+		s.helper.ShowSyncHelp()
+		return
+	}
+
+	branch, err := s.gitClient.GetCurrentBranch()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	if branch == "" || branch == "HEAD" {
+		WriteErrorf(s.outputWriter, "sync needs a branch, but HEAD is detached")
+		return
+	}
+	// No upstream is not an error: the branch is pushed and tracked.
+	upstream, _ := s.gitClient.GetUpstreamBranchName(branch)
+
+	dirty, err := s.dirty()
+	if err != nil {
+		WriteError(s.outputWriter, err)
+		return
+	}
+	if dirty && !opts.autostash {
+		WriteErrorf(s.outputWriter, "you have uncommitted changes; commit or stash them, or set sync.autostash to true")
+		return
+	}
+
+	targets := []sshTarget{{}}
+	if opts.push {
+		targets = append(targets, sshTarget{opts.remote, true})
+	}
+	if !s.ssh.ready(s.outputWriter, "sync", targets...) {
+		return
+	}
+	if opts.push {
+		if err := s.gate.check(s.outputWriter, opts.gateArgs); err != nil {
+			WriteError(s.outputWriter, err)
+			return
+		}
+	}
+
+	var stashed bool
+	var steps []syncStep
+	if dirty {
+		steps = append(steps, syncStep{"Stash uncommitted changes", func() (string, error) {
+			if err := s.gitClient.StashPush("ggc sync autostash on " + branch); err != nil {
+				return "", err
+			}
+			stashed = true
+			return "", nil
+		}, "Nothing was changed. Commit or stash your changes yourself, then run 'ggc sync' again."})
+	}
+	fetchTitle := "Fetch"
+	if opts.prune {
+		fetchTitle = "Fetch and prune"
+	}
+	steps = append(steps, syncStep{fetchTitle, func() (string, error) {
+		err := s.gitClient.Fetch(opts.prune)
+		// Nothing has changed yet, so the stash goes straight back.
+		if err != nil && stashed && s.gitClient.StashPop("") == nil {
+			stashed = false
+		}
+		return "", err
+	}, "The branch was not changed. Check the network and your credentials, then run 'ggc sync' again."})
+	if upstream != "" {
+		step := syncStep{
+			title: fmt.Sprintf("Rebase %s onto %s", branch, upstream),
+			recovery: "The rebase stopped. Resolve the conflicts, 'ggc add' the files and run 'ggc rebase continue',\n" +
+				"or run 'ggc rebase abort' to put the branch back. Then run 'ggc sync' again.",
+		}
+		if opts.strategy == "merge" {
+			step.title = fmt.Sprintf("Merge %s into %s", upstream, branch)
+			step.recovery = "The merge stopped. Resolve the conflicts, 'ggc add' the files and 'ggc commit' them,\n" +
+				"or run 'ggc merge --abort' to put the branch back. Then run 'ggc sync' again."
+		}
+		step.run = func() (string, error) { return s.integrate(opts.strategy, branch, upstream) }
+		steps = append(steps, step)
+	}
+	if dirty {
+		steps = append(steps, syncStep{"Re-apply uncommitted changes", func() (string, error) {
+			if err := s.gitClient.StashPop(""); err != nil {
+				return "", err
+			}
+			stashed = false
+			return "", nil
+		}, "The branch is up to date, but your changes conflict with it. They are still in the stash (stash@{0});\n" +
+			"resolve the conflicts, then run 'ggc stash drop'. The branch was not pushed."})
+	}
+	if opts.push {
+		steps = append(steps, s.pushStep(branch, upstream, opts.remote))
+	}
+
+	for i, step := range steps {
+		_, _ = fmt.Fprintf(s.outputWriter, "[%d/%d] %s\n", i+1, len(steps), step.title)
+		note, err := step.run()
+		if err != nil {
+			WriteError(s.outputWriter, err)
+			WriteLine(s.outputWriter, step.recovery)
+			if stashed {
+				_, _ = fmt.Fprintf(s.outputWriter, "Your uncommitted changes are in the stash (stash@{0}); run 'ggc stash pop' once the %s is done.\n", opts.strategy)
+			}
+			return
+		}
+		if note != "" {
+			_, _ = fmt.Fprintf(s.outputWriter, "      %s\n", note)
+		}
+	}
+	_, _ = fmt.Fprintf(s.outputWriter, "%s is in sync\n", branch)
+}
+
+// parseArgs reads the sync.* settings and applies the command-line
+// overrides. ok is false for help and unknown arguments.
+func (s *Syncer) parseArgs(args []string) (syncOptions, bool) {
+	opts := syncOptions{strategy: "rebase", prune: true, autostash: true, push: true, remote: "origin"}
+	if s.configManager != nil {
+		cfg := s.configManager.GetConfig()
+		if cfg.Sync.Strategy != "" {
+			opts.strategy = cfg.Sync.Strategy
+		}
+		opts.prune, opts.autostash, opts.push = cfg.Sync.Prune, cfg.Sync.Autostash, cfg.Sync.Push
+		if r := strings.TrimSpace(cfg.Git.DefaultRemote); r != "" {
+			opts.remote = r
+		}
+	}
+	for _, arg := range args {
+		switch arg {
+		case "--rebase":
+			opts.strategy = "rebase"
+		case "--merge":
+			opts.strategy = "merge"
+		case "--no-push":
+			opts.push = false
+		case "--no-prune":
+			opts.prune = false
+		case "--no-autostash":
+			opts.autostash = false
+		default:
+			if !isPushGateFlag(arg) {
+				return opts, false
+			}
+			opts.gateArgs = append(opts.gateArgs, arg)
+		}
+	}
+	return opts, true
+}
+
+// dirty reports whether the working tree has staged or modified files.
+// Conflicts stop the sync before it starts.
+func (s *Syncer) dirty() (bool, error) {
+	if s.status == nil {
+		return false, nil
+	}
+	summary, err := s.status.StatusSummary()
+	if err != nil {
+		return false, err
+	}
+	if summary.Conflicted() > 0 {
+		return false, fmt.Errorf("resolve the conflicted files before syncing")
+	}
+	return summary.Staged()+summary.Modified() > 0, nil
+}
+
+// integrate rebases branch onto upstream or merges upstream into it,
+// unless the branch already has everything upstream has.
+func (s *Syncer) integrate(strategy, branch, upstream string) (string, error) {
+	if _, behind, ok := s.aheadBehind(branch, upstream); ok && behind == 0 {
+		return "Already up to date", nil
+	}
+	if strategy == "merge" {
+		return "", s.gitClient.Merge(upstream)
+	}
+	return "", s.gitClient.Rebase(upstream)
+}
+
+// pushStep pushes branch. A branch without an upstream is pushed to
+// remote and tracks it from then on; one that tracks a branch of another
+// name, such as a feature branch started from origin/main, is left alone
+// rather than pushed over that branch.
+func (s *Syncer) pushStep(branch, upstream, remote string) syncStep {
+	if upstream == "" {
+		return syncStep{fmt.Sprintf("Push %s to %s and track it", branch, remote), func() (string, error) {
+			return "", s.gitClient.PushSetUpstream(remote, branch)
+		}, syncPushRecovery}
+	}
+	upstreamRemote, upstreamBranch, _ := strings.Cut(upstream, "/")
+	if upstreamBranch != branch {
+		return syncStep{"Push", func() (string, error) {
+			return fmt.Sprintf("Skipped: %s tracks %s, so it is not pushed; use ggc push current", branch, upstream), nil
+		}, ""}
+	}
+	return syncStep{fmt.Sprintf("Push %s to %s", branch, upstreamRemote), func() (string, error) {
+		if ahead, _, ok := s.aheadBehind(branch, upstream); ok && ahead == 0 {
+			return "Nothing to push", nil
+		}
+		return "", s.gitClient.PushSetUpstream(upstreamRemote, branch)
+	}, syncPushRecovery}
+}
+
+// aheadBehind counts the commits branch and upstream have that the other
+// lacks. ok is false when git cannot tell.
+func (s *Syncer) aheadBehind(branch, upstream string) (ahead, behind int, ok bool) {
+	out, err := s.gitClient.GetAheadBehindCount(branch, upstream)
+	if err != nil {
+		return 0, 0, false
+	}
+	if _, err := fmt.Sscan(out, &ahead, &behind); err != nil {
+		return 0, 0, false
+	}
+	return ahead, behind, true
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockSyncClient struct {
+	testutil.MockGitClient
+	branch   string
+	upstream string
+	counts   []string // ahead/behind answers, in order
+	fail     string   // the call that fails
+	calls    []string
+}
+
+func (m *mockSyncClient) record(call string) error {
+	m.calls = append(m.calls, call)
+	if call == m.fail {
+		return errors.New(call + " failed")
+	}
+	return nil
+}
+
+func (m *mockSyncClient) GetCurrentBranch() (string, error) { return m.branch, nil }
+
+func (m *mockSyncClient) GetUpstreamBranchName(_ string) (string, error) {
+	if m.upstream == "" {
+		return "", errors.New("no upstream")
+	}
+	return m.upstream, nil
+}
+
+func (m *mockSyncClient) GetAheadBehindCount(_, _ string) (string, error) {
+	if len(m.counts) == 0 {
+		return "", errors.New("unknown")
+	}
+	count := m.counts[0]
+	m.counts = m.counts[1:]
+	return count, nil
+}
+
+func (m *mockSyncClient) Fetch(prune bool) error {
+	if prune {
+		return m.record("fetch --prune")
+	}
+	return m.record("fetch")
+}
+
+func (m *mockSyncClient) Rebase(upstream string) error { return m.record("rebase " + upstream) }
+func (m *mockSyncClient) Merge(ref string) error       { return m.record("merge " + ref) }
+func (m *mockSyncClient) StashPush(_ string) error     { return m.record("stash push") }
+func (m *mockSyncClient) StashPop(_ string) error      { return m.record("stash pop") }
+
+func (m *mockSyncClient) PushSetUpstream(remote, branch string) error {
+	return m.record("push " + remote + " " + branch)
+}
+
+func newTestSyncer(client *mockSyncClient, buf *bytes.Buffer) *Syncer {
+	s := NewSyncer(client).withConfigManager(config.NewConfigManager(client))
+	s.outputWriter = buf
+	s.helper.outputWriter = buf
+	return s
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		upstream string
+		counts   []string
+		dirty    bool
+		fail     string
+		want     string
+		out      []string
+	}{
+		{
+			name: "rebase and push", upstream: "origin/feature", counts: []string{"1\t2", "3\t0"},
+			want: "fetch --prune; rebase origin/feature; push origin feature",
+			out:  []string{"[1/3] Fetch and prune", "[2/3] Rebase feature onto origin/feature", "[3/3] Push feature to origin", "feature is in sync"},
+		},
+		{
+			name: "merge without push", args: []string{"--merge", "--no-push"}, upstream: "origin/feature", counts: []string{"0\t2"},
+			want: "fetch --prune; merge origin/feature",
+			out:  []string{"[2/2] Merge origin/feature into feature"},
+		},
+		{
+			name: "up to date", upstream: "origin/feature", counts: []string{"0\t0", "0\t0"},
+			want: "fetch --prune",
+			out:  []string{"Already up to date", "Nothing to push"},
+		},
+		{
+			name: "no upstream", want: "fetch --prune; push origin feature",
+			out: []string{"[2/2] Push feature to origin and track it"},
+		},
+		{
+			name: "tracks another branch", upstream: "origin/main", counts: []string{"1\t1"},
+			want: "fetch --prune; rebase origin/main",
+			out:  []string{"Skipped: feature tracks origin/main"},
+		},
+		{
+			name: "autostash", upstream: "origin/feature", counts: []string{"0\t1", "0\t0"}, dirty: true,
+			want: "stash push; fetch --prune; rebase origin/feature; stash pop",
+			out:  []string{"[1/5] Stash uncommitted changes", "[4/5] Re-apply uncommitted changes"},
+		},
+		{
+			name: "failed fetch restores the stash", upstream: "origin/feature", dirty: true, fail: "fetch --prune",
+			want: "stash push; fetch --prune; stash pop",
+			out:  []string{"Check the network"},
+		},
+		{
+			name: "rebase conflict", upstream: "origin/feature", counts: []string{"0\t1"}, dirty: true, fail: "rebase origin/feature",
+			want: "stash push; fetch --prune; rebase origin/feature",
+			out:  []string{"ggc rebase continue", "run 'ggc stash pop' once the rebase is done"},
+		},
+		{
+			name: "failed push", upstream: "origin/feature", counts: []string{"0\t1", "1\t0"}, fail: "push origin feature",
+			want: "fetch --prune; rebase origin/feature; push origin feature",
+			out:  []string{"was not pushed", "ggc push current"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			client := &mockSyncClient{branch: "feature", upstream: tt.upstream, counts: tt.counts, fail: tt.fail}
+			s := newTestSyncer(client, &buf)
+			if tt.dirty {
+				s.status = dirtyStatus{}
+			}
+
+			s.Sync(tt.args)
+
+			if got := strings.Join(client.calls, "; "); got != tt.want {
+				t.Errorf("calls = %q, want %q", got, tt.want)
+			}
+			for _, want := range tt.out {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output %q should contain %q", buf.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncer_Sync_Refuses(t *testing.T) {
+	t.Run("detached HEAD", func(t *testing.T) {
+		var buf bytes.Buffer
+		client := &mockSyncClient{branch: "HEAD"}
+		newTestSyncer(client, &buf).Sync(nil)
+		if len(client.calls) != 0 || !strings.Contains(buf.String(), "HEAD is detached") {
+			t.Errorf("calls = %v, output %q", client.calls, buf.String())
+		}
+	})
+	t.Run("uncommitted changes without autostash", func(t *testing.T) {
+		var buf bytes.Buffer
+		client := &mockSyncClient{branch: "feature", upstream: "origin/feature"}
+		s := newTestSyncer(client, &buf)
+		s.status = dirtyStatus{}
+		s.Sync([]string{"--no-autostash"})
+		if len(client.calls) != 0 || !strings.Contains(buf.String(), "uncommitted changes") {
+			t.Errorf("calls = %v, output %q", client.calls, buf.String())
+		}
+	})
+}
+
+func TestSyncer_Sync_WIPTip(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"refused", nil, ""},
+		{"allowed", []string{"--allow-wip"}, "fetch --prune; push origin feature"},
+		{"not pushing", []string{"--no-push"}, "fetch --prune"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			client := &mockSyncClient{branch: "feature"}
+			s := newTestSyncer(client, &buf).withPushGate(newPushGate(stubHead("WIP: work in progress")))
+			s.Sync(tt.args)
+			if got := strings.Join(client.calls, "; "); got != tt.want {
+				t.Errorf("calls = %q, want %q; output:\n%s", got, tt.want, buf.String())
+			}
+			if tt.want == "" && !strings.Contains(buf.String(), "--allow-wip") {
+				t.Errorf("output = %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestSyncer_Sync_SecretScan(t *testing.T) {
+	leaky, _ := leakyPatches()
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"stopped", nil, ""},
+		{"--no-scan", []string{"--no-scan"}, "fetch --prune; push origin feature"},
+		{"not pushing", []string{"--no-push"}, "fetch --prune"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			client := &mockSyncClient{branch: "feature"}
+			gate := newPushGate(nil).withSecretScan(leaky, nil)
+			gate.scanAlways = true
+			s := newTestSyncer(client, &buf).withPushGate(gate)
+			s.Sync(tt.args)
+			if got := strings.Join(client.calls, "; "); got != tt.want {
+				t.Errorf("calls = %q, want %q; output:\n%s", got, tt.want, buf.String())
+			}
+			if tt.want == "" && !strings.Contains(buf.String(), "look like secrets") {
+				t.Errorf("output = %q", buf.String())
+			}
+		})
+	}
+}
@@ -6,9 +6,16 @@ import (
 	"os"
 	"strings"
 
+	"github.com/bmf-san/ggc/v8/internal/commitmsg"
 	"github.com/bmf-san/ggc/v8/internal/git"
 )
 
+// tagNotesSource reads the commits release notes are built from.
+type tagNotesSource interface {
+	git.NearestTagReader
+	git.CommitLister
+}
+
 // Tagger handles tagging operations.
 type Tagger struct {
 	gitClient interface {
@@ -17,6 +24,9 @@ type Tagger struct {
 	}
 	outputWriter io.Writer
 	helper       *Helper
+	signer       git.TagSigner    // nil when signed tags are unavailable
+	annotator    git.TagAnnotator // nil when annotated tags are unavailable
+	notes        tagNotesSource   // nil when --notes is unavailable
 	// defaultRemote caches the default remote name to avoid
 	// reloading configuration on each tag push.
 	defaultRemote string
@@ -35,6 +45,24 @@ func NewTagger(client interface {
 	}
 }
 
+// withSigner enables `ggc tag create --sign`.
+func (t *Tagger) withSigner(signer git.TagSigner) *Tagger {
+	t.signer = signer
+	return t
+}
+
+// withAnnotator enables `ggc tag create --annotate`.
+func (t *Tagger) withAnnotator(annotator git.TagAnnotator) *Tagger {
+	t.annotator = annotator
+	return t
+}
+
+// withNotes enables `ggc tag create --notes` and `ggc tag notes`.
+func (t *Tagger) withNotes(source tagNotesSource) *Tagger {
+	t.notes = source
+	return t
+}
+
 // Tag executes git tag operations with the given arguments.
 func (t *Tagger) Tag(args []string) {
 	if len(args) == 0 {
@@ -60,6 +88,12 @@ func (t *Tagger) Tag(args []string) {
 	case "show":
 		t.showTag(args[1:])
 		return
+	case "annotated":
+		t.CreateAnnotatedTag(args[1:])
+		return
+	case "notes":
+		t.showNotes(args[1:])
+		return
 	default:
 		t.helper.ShowTagHelp()
 		return
@@ -73,32 +107,153 @@ func (t *Tagger) listTags(args []string) {
 	}
 }
 
-// createTag creates a new tag
+// createTag creates a new tag. --annotate and --sign create annotated
+// tags, taking their message from -m or the editor; --notes makes one
+// whose message lists the changes since the previous tag.
 func (t *Tagger) createTag(args []string) {
-	if len(args) == 0 {
+	var positional []string
+	var sign, annotate, notes bool
+	var message string
+	for i := 0; i < len(args); i++ {
+		switch name, value, hasValue := strings.Cut(args[i], "="); name {
+		case "--sign", "-s":
+			sign = true
+		case "--annotate", "-a":
+			annotate = true
+		case "--notes":
+			notes = true
+		case "-m", "--message":
+			if !hasValue {
+				if i+1 >= len(args) {
+					WriteErrorf(t.outputWriter, "%s requires a value", name)
+					return
+				}
+				i++
+				value = args[i]
+			}
+			message = value
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+	if len(positional) == 0 {
 		WriteErrorf(t.outputWriter, "tag name is required")
 		return
 	}
 
-	tagName := args[0]
+	tagName := positional[0]
+	var commit string
+	if len(positional) > 1 {
+		commit = positional[1]
+	}
 
-	if len(args) == 1 {
-		// tag current commit
-		if err := t.gitClient.TagCreate(tagName, ""); err != nil {
+	if notes {
+		body, _, err := t.releaseNotes(commit)
+		if err != nil {
 			WriteError(t.outputWriter, err)
 			return
 		}
-	} else {
-		// tag specific commit
-		if err := t.gitClient.TagCreate(tagName, args[1]); err != nil {
+		if message == "" {
+			message = tagName
+		}
+		message += "\n\n" + body
+		annotate = true
+	}
+
+	if sign {
+		if t.signer == nil {
+			WriteErrorf(t.outputWriter, "tag signing is not supported here")
+			return
+		}
+		if err := t.signer.TagCreateSigned(tagName, commit, message); err != nil {
+			WriteError(t.outputWriter, err)
+			return
+		}
+		_, _ = fmt.Fprintf(t.outputWriter, "Signed tag '%s' created\n", tagName)
+		return
+	}
+	if annotate {
+		if t.annotator == nil {
+			WriteErrorf(t.outputWriter, "annotated tags are not supported here")
+			return
+		}
+		if err := t.annotator.TagCreateAnnotatedAt(tagName, commit, message); err != nil {
 			WriteError(t.outputWriter, err)
 			return
 		}
+		_, _ = fmt.Fprintf(t.outputWriter, "Annotated tag '%s' created\n", tagName)
+		return
+	}
+	if message != "" {
+		WriteErrorf(t.outputWriter, "-m is only used with --sign or --annotate")
+		return
+	}
+	// An empty commit tags HEAD.
+	if err := t.gitClient.TagCreate(tagName, commit); err != nil {
+		WriteError(t.outputWriter, err)
+		return
 	}
 
 	_, _ = fmt.Fprintf(t.outputWriter, "Tag '%s' created\n", tagName)
 }
 
+// showNotes prints the release notes a tag on commit (HEAD when not
+// given) would get from --notes.
+func (t *Tagger) showNotes(args []string) {
+	if len(args) > 1 {
+		t.helper.ShowTagHelp()
+		return
+	}
+	var commit string
+	if len(args) == 1 {
+		commit = args[0]
+	}
+	body, since, err := t.releaseNotes(commit)
+	if err != nil {
+		WriteError(t.outputWriter, err)
+		return
+	}
+	if since != "" {
+		_, _ = fmt.Fprintf(t.outputWriter, "Changes since %s:\n\n", since)
+	}
+	_, _ = fmt.Fprint(t.outputWriter, body)
+}
+
+// releaseNotes lists the commits on commit since the newest tag reachable
+// from it, grouped by Conventional Commits type. since is that tag, or
+// empty when there is none and the notes cover every commit.
+func (t *Tagger) releaseNotes(commit string) (body, since string, err error) {
+	if t.notes == nil {
+		return "", "", fmt.Errorf("release notes are not supported here")
+	}
+	if commit == "" {
+		commit = "HEAD"
+	}
+	since, err = t.notes.NearestTag(commit)
+	if err != nil {
+		return "", "", err
+	}
+	revs := commit
+	if since != "" {
+		revs = since + ".." + commit
+	}
+	commits, err := t.notes.ListCommits("--no-merges", revs)
+	if err != nil {
+		return "", "", err
+	}
+	if len(commits) == 0 {
+		if since == "" {
+			return "", "", fmt.Errorf("no commits to write release notes for")
+		}
+		return "", "", fmt.Errorf("no commits since %s to write release notes for", since)
+	}
+	changes := make([]commitmsg.Change, len(commits))
+	for i, c := range commits {
+		changes[i] = commitmsg.Change{Hash: c.Short, Message: commitmsg.Parse(c.Subject)}
+	}
+	return commitmsg.PlainNotes(commitmsg.Group(changes)), since, nil
+}
+
 // deleteTags deletes one or more tags
 func (t *Tagger) deleteTags(args []string) {
 	if len(args) == 0 {
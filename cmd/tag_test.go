@@ -666,3 +666,122 @@ func TestTagger_PushTags_SpecificError(t *testing.T) {
 		t.Errorf("expected push error, got: %s", buf.String())
 	}
 }
+
+type mockTagSigner struct {
+	name, commit, message string
+}
+
+func (m *mockTagSigner) TagCreateSigned(name, commit, message string) error {
+	m.name, m.commit, m.message = name, commit, message
+	return nil
+}
+
+func TestTagger_Create_Sign(t *testing.T) {
+	m := &mockTagOps{}
+	s := &mockTagSigner{}
+	var buf bytes.Buffer
+	tg := (&Tagger{gitClient: m, outputWriter: &buf, helper: NewHelper()}).withSigner(s)
+
+	tg.Tag([]string{"create", "v1.0.0", "abc123", "--sign", "-m", "Release"})
+	if s.name != "v1.0.0" || s.commit != "abc123" || s.message != "Release" || m.createCalled {
+		t.Fatalf("unexpected signed tag: %+v, plain create called=%v", s, m.createCalled)
+	}
+	if !strings.Contains(buf.String(), "Signed tag 'v1.0.0' created") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	buf.Reset()
+	tg.Tag([]string{"create", "v1.0.1", "-m", "Release"})
+	if m.createCalled || !strings.Contains(buf.String(), "-m is only used with --sign") {
+		t.Errorf("-m without --sign should be rejected, output %q", buf.String())
+	}
+}
+
+type mockTagAnnotator struct {
+	name, commit, message string
+}
+
+func (m *mockTagAnnotator) TagCreateAnnotatedAt(name, commit, message string) error {
+	m.name, m.commit, m.message = name, commit, message
+	return nil
+}
+
+type mockTagNotes struct {
+	nearest string
+	commits []git.CommitSummary
+	listed  string
+}
+
+func (m *mockTagNotes) NearestTag(string) (string, error) { return m.nearest, nil }
+
+func (m *mockTagNotes) ListCommits(revs ...string) ([]git.CommitSummary, error) {
+	m.listed = strings.Join(revs, " ")
+	return m.commits, nil
+}
+
+func newTagNotesFixture() *mockTagNotes {
+	return &mockTagNotes{nearest: "v1.0.0", commits: []git.CommitSummary{
+		{Short: "ccc333", Subject: "fix(ui): wrap long lines"},
+		{Short: "bbb222", Subject: "Update README"},
+		{Short: "aaa111", Subject: "feat: add pager"},
+	}}
+}
+
+func TestTagger_Create_Annotate(t *testing.T) {
+	m := &mockTagOps{}
+	a := &mockTagAnnotator{}
+	var buf bytes.Buffer
+	tg := (&Tagger{gitClient: m, outputWriter: &buf, helper: NewHelper()}).withAnnotator(a)
+
+	tg.Tag([]string{"create", "v1.1.0", "abc123", "--annotate", "-m", "Release"})
+	if a.name != "v1.1.0" || a.commit != "abc123" || a.message != "Release" || m.createCalled {
+		t.Fatalf("unexpected annotated tag: %+v, plain create called=%v", a, m.createCalled)
+	}
+	if !strings.Contains(buf.String(), "Annotated tag 'v1.1.0' created") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestTagger_Create_Notes(t *testing.T) {
+	m := &mockTagOps{}
+	a := &mockTagAnnotator{}
+	s := &mockTagSigner{}
+	notes := newTagNotesFixture()
+	var buf bytes.Buffer
+	tg := (&Tagger{gitClient: m, outputWriter: &buf, helper: NewHelper()}).withAnnotator(a).withSigner(s).withNotes(notes)
+
+	tg.Tag([]string{"create", "v1.1.0", "--notes"})
+	want := "v1.1.0\n\nFeatures:\n- add pager (aaa111)\n\nBug Fixes:\n- ui: wrap long lines (ccc333)\n\nOther Changes:\n- Update README (bbb222)\n"
+	if a.name != "v1.1.0" || a.message != want {
+		t.Errorf("annotated tag %q with message\n%s\nwant\n%s", a.name, a.message, want)
+	}
+	if notes.listed != "--no-merges v1.0.0..HEAD" {
+		t.Errorf("listed %q", notes.listed)
+	}
+
+	tg.Tag([]string{"create", "v1.1.0", "abc123", "--notes", "--sign", "-m", "Release 1.1"})
+	if s.commit != "abc123" || !strings.HasPrefix(s.message, "Release 1.1\n\nFeatures:\n") {
+		t.Errorf("signed tag %+v", s)
+	}
+	if notes.listed != "--no-merges v1.0.0..abc123" {
+		t.Errorf("listed %q", notes.listed)
+	}
+}
+
+func TestTagger_Notes(t *testing.T) {
+	var buf bytes.Buffer
+	notes := newTagNotesFixture()
+	tg := (&Tagger{gitClient: &mockTagOps{}, outputWriter: &buf, helper: NewHelper()}).withNotes(notes)
+
+	tg.Tag([]string{"notes"})
+	if !strings.HasPrefix(buf.String(), "Changes since v1.0.0:\n\nFeatures:\n- add pager (aaa111)\n") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	buf.Reset()
+	notes.nearest, notes.commits = "", nil
+	tg.Tag([]string{"notes"})
+	if notes.listed != "--no-merges HEAD" || !strings.Contains(buf.String(), "no commits") {
+		t.Errorf("listed %q, output %q", notes.listed, buf.String())
+	}
+}
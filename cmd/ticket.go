@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/ticket"
+)
+
+// Ticketer provides the ticket command, which shows or opens the
+// issue-tracker ticket named in a branch.
+type Ticketer struct {
+	gitClient     currentBranchReader
+	outputWriter  io.Writer
+	helper        *Helper
+	configManager *config.Manager
+	execCommand   func(string, ...string) *exec.Cmd
+	goos          string
+}
+
+// NewTicketer creates a new Ticketer.
+func NewTicketer(client currentBranchReader) *Ticketer {
+	t := &Ticketer{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+		execCommand:  exec.Command,
+		goos:         runtime.GOOS,
+	}
+	t.helper.outputWriter = t.outputWriter
+	return t
+}
+
+// withConfigManager supplies the ticket rules.
+func (t *Ticketer) withConfigManager(cm *config.Manager) *Ticketer {
+	t.configManager = cm
+	return t
+}
+
+// Ticket prints the ticket of the current branch, or of the named one, and
+// its URL; `ggc ticket open` opens the URL in the browser instead.
+func (t *Ticketer) Ticket(args []string) {
+	open := len(args) > 0 && args[0] == "open"
+	if open {
+		args = args[1:]
+	}
+	if len(args) > 1 {
+		t.helper.ShowTicketHelp()
+		return
+	}
+	found, err := t.find(args)
+	if err != nil {
+		WriteError(t.outputWriter, err)
+		return
+	}
+	if !open {
+		WriteLine(t.outputWriter, found.ID)
+		if found.URL != "" {
+			WriteLine(t.outputWriter, found.URL)
+		}
+		return
+	}
+	if found.URL == "" {
+		WriteErrorf(t.outputWriter, "%s has no URL; set url on its rule under tickets.rules in the config", found.ID)
+		return
+	}
+	name, browserArgs := browserCommand(t.goos, found.URL)
+	if err := t.execCommand(name, browserArgs...).Run(); err != nil {
+		WriteErrorf(t.outputWriter, "could not open %s: %v", found.URL, err)
+		return
+	}
+	WriteLinef(t.outputWriter, "Opened %s", found.URL)
+}
+
+// find returns the ticket of the branch in args, or of the current branch.
+func (t *Ticketer) find(args []string) (ticket.Ticket, error) {
+	var branch string
+	if len(args) == 1 {
+		branch = args[0]
+	} else {
+		current, err := t.gitClient.GetCurrentBranch()
+		if err != nil {
+			return ticket.Ticket{}, err
+		}
+		branch = current
+	}
+	if branch == "" || branch == "HEAD" {
+		return ticket.Ticket{}, errors.New("not on a branch")
+	}
+	var cfg *config.Config
+	if t.configManager != nil {
+		cfg = t.configManager.GetConfig()
+	}
+	found, ok := branchTicket(cfg, branch)
+	if ok {
+		return found, nil
+	}
+	if len(ticketRules(cfg)) == 0 {
+		return ticket.Ticket{}, fmt.Errorf("no ticket such as PROJ-123 in branch %s; describe yours under tickets.rules in the config", branch)
+	}
+	return ticket.Ticket{}, fmt.Errorf("no rule under tickets.rules matches branch %s", branch)
+}
+
+// ticketRules returns the ticket rules of cfg, which may be nil.
+func ticketRules(cfg *config.Config) []ticket.Rule {
+	if cfg == nil {
+		return nil
+	}
+	rules := make([]ticket.Rule, 0, len(cfg.Tickets.Rules))
+	for _, r := range cfg.Tickets.Rules {
+		rules = append(rules, ticket.Rule{Pattern: r.Pattern, URL: r.URL, Format: r.Format})
+	}
+	return rules
+}
+
+// branchTicket returns the ticket named in branch under the rules of cfg,
+// which may be nil.
+func branchTicket(cfg *config.Config, branch string) (ticket.Ticket, bool) {
+	return ticket.Find(branch, ticketRules(cfg))
+}
+
+// browserCommand returns the command that opens url in the default browser
+// on goos.
+func browserCommand(goos, url string) (string, []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
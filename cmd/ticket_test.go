@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+func newTestTicketer(branch string, rules ...config.TicketRule) (*Ticketer, *bytes.Buffer, *[]string) {
+	var buf bytes.Buffer
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	cm.GetConfig().Tickets.Rules = rules
+	tk := NewTicketer(stubCurrentBranch(branch)).withConfigManager(cm)
+	tk.outputWriter = &buf
+	tk.helper.outputWriter = &buf
+	tk.goos = "linux"
+	var ran []string
+	tk.execCommand = func(name string, args ...string) *exec.Cmd {
+		ran = append([]string{name}, args...)
+		return exec.Command("true")
+	}
+	return tk, &buf, &ran
+}
+
+func TestTicketer_Ticket(t *testing.T) {
+	jira := config.TicketRule{Pattern: `[A-Z]+-\d+`, URL: "https://acme.atlassian.net/browse/{ID}"}
+	tk, buf, _ := newTestTicketer("feat/ABC-123-title", jira)
+
+	tk.Ticket(nil)
+	if want := "ABC-123\nhttps://acme.atlassian.net/browse/ABC-123\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	tk.Ticket([]string{"fix/XY-9"})
+	if !strings.HasPrefix(buf.String(), "XY-9\n") {
+		t.Errorf("a named branch should be used, got %q", buf.String())
+	}
+
+	buf.Reset()
+	tk.Ticket([]string{"main"})
+	if !strings.Contains(buf.String(), "no rule under tickets.rules matches branch main") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestTicketer_Ticket_Open(t *testing.T) {
+	tk, buf, ran := newTestTicketer("issue/42-crash", config.TicketRule{Pattern: `^issue/(\d+)`, URL: "https://github.com/acme/app/issues/{ID}"})
+
+	tk.Ticket([]string{"open"})
+	if want := []string{"xdg-open", "https://github.com/acme/app/issues/42"}; !slices.Equal(*ran, want) {
+		t.Errorf("ran %v, want %v", *ran, want)
+	}
+	if !strings.Contains(buf.String(), "Opened https://github.com/acme/app/issues/42") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	// Without rules a Jira-style key is found, but there is no URL to open.
+	tk, buf, ran = newTestTicketer("feature/proj-7")
+	tk.Ticket([]string{"open"})
+	if *ran != nil || !strings.Contains(buf.String(), "PROJ-7 has no URL") {
+		t.Errorf("ran %v, output %q", *ran, buf.String())
+	}
+}
+
+func TestBrowserCommand(t *testing.T) {
+	for goos, want := range map[string]string{"darwin": "open", "windows": "rundll32", "linux": "xdg-open"} {
+		if name, args := browserCommand(goos, "https://x"); name != want || args[len(args)-1] != "https://x" {
+			t.Errorf("browserCommand(%s) = %s %v", goos, name, args)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// Package cmd provides command implementations for the ggc CLI tool.
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/journal"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+)
+
+// undoTimeFormat matches the history listing so both commands line up.
+const undoTimeFormat = "2006-01-02 15:04:05"
+
+// Undoer journals destructive operations performed by other commands and
+// reverses the most recent one on `ggc undo`. Commands hold an optional
+// *Undoer; every recording method is nil-safe so handlers built without
+// one (typically in tests) simply skip journaling.
+type Undoer struct {
+	gitClient    git.UndoOps
+	outputWriter io.Writer
+	helper       *Helper
+	prompter     prompt.Prompter
+}
+
+// NewUndoer creates a new Undoer.
+func NewUndoer(client git.UndoOps) *Undoer {
+	output := os.Stdout
+	helper := NewHelper()
+	helper.outputWriter = output
+	return &Undoer{
+		gitClient:    client,
+		outputWriter: output,
+		helper:       helper,
+		prompter:     prompt.New(os.Stdin, output),
+	}
+}
+
+// Undo executes the undo command with the given arguments.
+func (u *Undoer) Undo(args []string) {
+	if len(args) == 0 {
+		u.undoLast()
+		return
+	}
+
+	switch args[0] {
+	case "list":
+		u.list()
+	default:
+		u.helper.ShowUndoHelp()
+	}
+}
+
+// store returns the journal of the current repository, or nil when the
+// git directory cannot be resolved (e.g. outside a repository).
+func (u *Undoer) store() *journal.Store {
+	if u == nil || u.gitClient == nil {
+		return nil
+	}
+	dir, err := u.gitClient.GitDir()
+	if err != nil || strings.TrimSpace(dir) == "" {
+		return nil
+	}
+	return &journal.Store{Path: journal.PathForGitDir(dir)}
+}
+
+// resolve returns the commit ref points at, or "" when it cannot be
+// resolved.
+func (u *Undoer) resolve(ref string) string {
+	if u == nil || u.gitClient == nil {
+		return ""
+	}
+	sha, err := u.gitClient.RevParse(ref)
+	if err != nil {
+		return ""
+	}
+	return sha
+}
+
+// begin captures HEAD before an operation of the given kind runs. It
+// returns nil when journaling is unavailable; pass the result to commit
+// once the operation has succeeded.
+func (u *Undoer) begin(kind journal.Kind, command string) *journal.Entry {
+	head := u.resolve("HEAD")
+	if head == "" {
+		return nil
+	}
+	branch, _ := u.gitClient.GetCurrentBranch()
+	return &journal.Entry{Kind: kind, Command: command, Branch: branch, Head: head}
+}
+
+// beginBranchDelete captures the tip of branch before it is deleted.
+func (u *Undoer) beginBranchDelete(branch string) *journal.Entry {
+	tip := u.resolve(branch)
+	if tip == "" {
+		return nil
+	}
+	return &journal.Entry{Kind: journal.KindBranchDelete, Command: "branch delete " + branch, Branch: branch, Tip: tip}
+}
+
+// beginClean snapshots paths before they are cleaned. Snapshot failures
+// are reported but do not block the clean itself.
+func (u *Undoer) beginClean(command string, paths []string) *journal.Entry {
+	if u == nil || len(paths) == 0 || u.store() == nil {
+		return nil
+	}
+	e := &journal.Entry{Kind: journal.KindClean, Command: command}
+	if !u.snapshot(e, paths) {
+		return nil
+	}
+	return e
+}
+
+// snapshot records paths in a commit pinned for e, so undoing e brings
+// them back. It reports whether that worked; failures are reported but
+// do not block the operation.
+func (u *Undoer) snapshot(e *journal.Entry, paths []string) bool {
+	now := time.Now().UTC()
+	ref := journal.SnapshotRef(now)
+	sha, err := u.gitClient.SnapshotPaths(ref, "ggc undo: "+e.Command, paths)
+	if err != nil {
+		WriteLinef(u.outputWriter, "Warning: could not snapshot files for undo: %v", err)
+		return false
+	}
+	e.Timestamp = now
+	e.Snapshot = sha
+	e.Ref = ref
+	e.Paths = append([]string(nil), paths...)
+	return true
+}
+
+// commit records a pending entry after its operation succeeded, and
+// deletes the snapshot refs of the entries the journal dropped to make
+// room. Journal write failures are swallowed: an unwritable journal must
+// never fail the user's command.
+func (u *Undoer) commit(e *journal.Entry) {
+	if e == nil {
+		return
+	}
+	s := u.store()
+	if s == nil {
+		return
+	}
+	dropped, _ := s.Append(*e)
+	for _, old := range dropped {
+		if old.Ref != "" {
+			_ = u.gitClient.DeleteRef(old.Ref)
+		}
+	}
+}
+
+// discard drops a pending entry whose operation failed, releasing any
+// snapshot ref it pinned.
+func (u *Undoer) discard(e *journal.Entry) {
+	if e == nil || e.Ref == "" {
+		return
+	}
+	_ = u.gitClient.DeleteRef(e.Ref)
+}
+
+func (u *Undoer) list() {
+	s := u.store()
+	if s == nil {
+		WriteError(u.outputWriter, git.ErrNotARepo)
+		return
+	}
+	entries, err := s.ReadAll()
+	if err != nil {
+		WriteError(u.outputWriter, err)
+		return
+	}
+	if len(entries) == 0 {
+		WriteLine(u.outputWriter, "Nothing to undo.")
+		return
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		WriteLinef(u.outputWriter, "%3d  %s  %-14s %s", len(entries)-i, e.Timestamp.Local().Format(undoTimeFormat), e.Kind, e.Command)
+	}
+}
+
+func (u *Undoer) undoLast() {
+	s := u.store()
+	if s == nil {
+		WriteError(u.outputWriter, git.ErrNotARepo)
+		return
+	}
+	e, ok, err := s.Last()
+	if err != nil {
+		WriteError(u.outputWriter, err)
+		return
+	}
+	if !ok {
+		WriteLine(u.outputWriter, "Nothing to undo.")
+		return
+	}
+	if !u.apply(&e) {
+		return
+	}
+	if err := s.Pop(); err != nil {
+		WriteError(u.outputWriter, err)
+		return
+	}
+	WriteLinef(u.outputWriter, "Undid: %s", e.Command)
+}
+
+// apply reverses e. It returns false when the undo was refused, canceled
+// or failed, in which case the entry stays in the journal.
+func (u *Undoer) apply(e *journal.Entry) bool {
+	switch e.Kind {
+	case journal.KindReset, journal.KindRebase:
+		if !u.onBranch(e.Branch) || !u.confirm(fmt.Sprintf("Reset %s back to %s, discarding uncommitted changes? (y/n): ", e.Branch, shortSHA(e.Head))) {
+			return false
+		}
+		if !u.run(u.gitClient.ResetHard(e.Head)) {
+			return false
+		}
+		return e.Snapshot == "" || u.restoreSnapshot(e)
+	case journal.KindAmend, journal.KindUnwip:
+		if !u.onBranch(e.Branch) {
+			return false
+		}
+		return u.run(u.gitClient.ResetSoft(e.Head))
+	case journal.KindBranchDelete:
+		return u.run(u.gitClient.CreateBranchAt(e.Branch, e.Tip))
+	case journal.KindClean:
+		return u.restoreSnapshot(e)
+	default:
+		WriteErrorf(u.outputWriter, "don't know how to undo %q", e.Kind)
+		return false
+	}
+}
+
+// restoreSnapshot brings back the files snapshotted for e and releases
+// the ref that pinned them.
+func (u *Undoer) restoreSnapshot(e *journal.Entry) bool {
+	if !u.run(u.gitClient.RestorePathsFrom(e.Snapshot, e.Paths)) {
+		return false
+	}
+	_ = u.gitClient.DeleteRef(e.Ref)
+	return true
+}
+
+// onBranch refuses to move HEAD when the user has since switched away
+// from the branch the operation ran on.
+func (u *Undoer) onBranch(branch string) bool {
+	current, err := u.gitClient.GetCurrentBranch()
+	if err != nil {
+		WriteError(u.outputWriter, err)
+		return false
+	}
+	if branch != "" && current != branch {
+		WriteErrorf(u.outputWriter, "last operation ran on '%s' but '%s' is checked out; switch back first", branch, current)
+		return false
+	}
+	return true
+}
+
+func (u *Undoer) confirm(question string) bool {
+	ok, canceled, err := u.prompter.Confirm(question)
+	if canceled {
+		return false
+	}
+	if err != nil {
+		WriteError(u.outputWriter, err)
+		return false
+	}
+	if !ok {
+		WriteLine(u.outputWriter, "Canceled.")
+	}
+	return ok
+}
+
+func (u *Undoer) run(err error) bool {
+	if err != nil {
+		WriteError(u.outputWriter, err)
+		return false
+	}
+	return true
+}
+
+// shortSHA abbreviates a full object name for display.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/journal"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type mockUndoOps struct {
+	*testutil.MockGitClient
+	gitDir       string
+	branch       string
+	refs         map[string]string
+	resetHardTo  string
+	resetSoftTo  string
+	createdName  string
+	createdAt    string
+	restoredFrom string
+	restored     []string
+	deletedRef   string
+	snapshotRef  string
+}
+
+func newMockUndoOps(t *testing.T) *mockUndoOps {
+	t.Helper()
+	return &mockUndoOps{
+		MockGitClient: testutil.NewMockGitClient(),
+		gitDir:        t.TempDir(),
+		branch:        "main",
+		refs:          map[string]string{"HEAD": "1111111aaaa"},
+	}
+}
+
+func (m *mockUndoOps) GitDir() (string, error)             { return m.gitDir, nil }
+func (m *mockUndoOps) GetCurrentBranch() (string, error)   { return m.branch, nil }
+func (m *mockUndoOps) RevParse(ref string) (string, error) { return m.refs[ref], nil }
+func (m *mockUndoOps) ResetHard(commit string) error       { m.resetHardTo = commit; return nil }
+func (m *mockUndoOps) ResetSoft(commit string) error       { m.resetSoftTo = commit; return nil }
+func (m *mockUndoOps) DeleteRef(ref string) error          { m.deletedRef = ref; return nil }
+func (m *mockUndoOps) CreateBranchAt(name, commit string) error {
+	m.createdName, m.createdAt = name, commit
+	return nil
+}
+func (m *mockUndoOps) RestorePathsFrom(commit string, paths []string) error {
+	m.restoredFrom, m.restored = commit, paths
+	return nil
+}
+func (m *mockUndoOps) SnapshotPaths(ref, _ string, _ []string) (string, error) {
+	m.snapshotRef = ref
+	return "snap123", nil
+}
+
+var _ git.UndoOps = (*mockUndoOps)(nil)
+
+func newTestUndoer(m *mockUndoOps, answer string) (*Undoer, *bytes.Buffer) {
+	var buf bytes.Buffer
+	u := &Undoer{
+		gitClient:    m,
+		outputWriter: &buf,
+		helper:       &Helper{outputWriter: &buf},
+		prompter:     prompt.New(strings.NewReader(answer), &buf),
+	}
+	return u, &buf
+}
+
+func TestUndoer_ResetRoundTrip(t *testing.T) {
+	m := newMockUndoOps(t)
+	u, buf := newTestUndoer(m, "y\n")
+	r := &Resetter{outputWriter: buf, helper: NewHelper(), gitClient: m}
+	r.withUndo(u)
+
+	r.Reset([]string{"hard", "HEAD~1"})
+	u.Undo(nil)
+
+	if m.resetHardTo != "1111111aaaa" {
+		t.Fatalf("undo should reset back to the pre-reset HEAD, got %q", m.resetHardTo)
+	}
+	if !strings.Contains(buf.String(), "Undid: reset hard HEAD~1") {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+	if _, ok, _ := u.store().Last(); ok {
+		t.Fatal("undone entry should be removed from the journal")
+	}
+}
+
+func TestUndoer_ResetDeclined(t *testing.T) {
+	m := newMockUndoOps(t)
+	u, _ := newTestUndoer(m, "n\n")
+	u.commit(u.begin(journal.KindReset, "reset hard HEAD~1"))
+
+	u.Undo(nil)
+
+	if m.resetHardTo != "" {
+		t.Fatal("declined undo must not reset")
+	}
+	if _, ok, _ := u.store().Last(); !ok {
+		t.Fatal("declined undo should keep the entry")
+	}
+}
+
+func TestUndoer_RefusesOtherBranch(t *testing.T) {
+	m := newMockUndoOps(t)
+	u, buf := newTestUndoer(m, "y\n")
+	u.commit(u.begin(journal.KindAmend, "commit amend"))
+	m.branch = "feature"
+
+	u.Undo(nil)
+
+	if m.resetSoftTo != "" {
+		t.Fatal("undo should refuse to move a different branch")
+	}
+	if !strings.Contains(buf.String(), "switch back first") {
+		t.Fatalf("expected guidance, got %s", buf.String())
+	}
+}
+
+func TestUndoer_Amend(t *testing.T) {
+	m := newMockUndoOps(t)
+	u, _ := newTestUndoer(m, "")
+	c := &Committer{gitClient: m, outputWriter: &bytes.Buffer{}, helper: NewHelper()}
+	c.withUndo(u)
+
+	c.Commit([]string{"amend", "no-edit"})
+	u.Undo(nil)
+
+	if m.resetSoftTo != "1111111aaaa" {
+		t.Fatalf("undoing an amend should soft reset, got %q", m.resetSoftTo)
+	}
+}
+
+func TestUndoer_BranchDelete(t *testing.T) {
+	m := newMockUndoOps(t)
+	m.refs["feature"] = "2222222bbbb"
+	u, _ := newTestUndoer(m, "")
+	b := &Brancher{gitClient: m, outputWriter: &bytes.Buffer{}, helper: NewHelper()}
+	b.withUndo(u)
+
+	b.Branch([]string{"delete", "feature"})
+	u.Undo(nil)
+
+	if m.createdName != "feature" || m.createdAt != "2222222bbbb" {
+		t.Fatalf("undo should recreate the branch at its old tip, got %q@%q", m.createdName, m.createdAt)
+	}
+}
+
+func TestUndoer_Clean(t *testing.T) {
+	m := newMockUndoOps(t)
+	u, _ := newTestUndoer(m, "")
+	pending := u.beginClean("clean files", []string{"tmp.log"})
+	if pending == nil {
+		t.Fatal("expected a pending clean entry")
+	}
+	u.commit(pending)
+
+	u.Undo(nil)
+
+	if m.restoredFrom != "snap123" || len(m.restored) != 1 || m.restored[0] != "tmp.log" {
+		t.Fatalf("undo should restore cleaned paths, got %q %v", m.restoredFrom, m.restored)
+	}
+	if m.deletedRef != m.snapshotRef {
+		t.Fatalf("snapshot ref %q should be released, deleted %q", m.snapshotRef, m.deletedRef)
+	}
+}
+
+func TestUndoer_CleanDirs(t *testing.T) {
+	m := newMockUndoOps(t)
+	u, buf := newTestUndoer(m, "")
+	clean := &mockCleanGitClient{cleanDryRunResult: "Would remove build/\n", ignoredResult: "Would remove .env\n"}
+	c := (&Cleaner{gitClient: clean, outputWriter: buf, helper: NewHelper()}).withUndo(u)
+
+	c.Clean([]string{"dirs"})
+	u.Undo(nil)
+
+	if !clean.cleanDirsCalled {
+		t.Fatal("clean dirs should run")
+	}
+	if m.restoredFrom != "snap123" || strings.Join(m.restored, " ") != "build/ .env" {
+		t.Fatalf("undo should restore the untracked and ignored paths, got %q %v", m.restoredFrom, m.restored)
+	}
+	if !strings.Contains(buf.String(), "Undid: clean dirs") {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestUndoer_ResetRestoresCleanedFiles(t *testing.T) {
+	m := newMockUndoOps(t)
+	u, buf := newTestUndoer(m, "y\n")
+	r := (&Resetter{outputWriter: buf, helper: NewHelper(), gitClient: m}).
+		withUndo(u).
+		withCleanSnapshot(&mockCleanGitClient{cleanDryRunResult: "Would remove notes.txt\n"})
+
+	r.Reset(nil)
+	u.Undo(nil)
+
+	if m.resetHardTo != "1111111aaaa" {
+		t.Fatalf("undo should reset back to the pre-reset HEAD, got %q", m.resetHardTo)
+	}
+	if m.restoredFrom != "snap123" || strings.Join(m.restored, " ") != "notes.txt" {
+		t.Fatalf("undo should restore the cleaned files, got %q %v", m.restoredFrom, m.restored)
+	}
+	if m.deletedRef != m.snapshotRef {
+		t.Fatalf("snapshot ref %q should be released, deleted %q", m.snapshotRef, m.deletedRef)
+	}
+}
+
+func TestUndoer_DeletesRefsOfDroppedEntries(t *testing.T) {
+	m := newMockUndoOps(t)
+	u, _ := newTestUndoer(m, "")
+	for i := 0; i < journal.DefaultMaxEntries; i++ {
+		e := u.begin(journal.KindClean, "clean files")
+		e.Ref = fmt.Sprintf("refs/ggc/undo/%02d", i)
+		u.commit(e)
+	}
+	if m.deletedRef != "" {
+		t.Fatalf("a full journal should keep every ref, deleted %q", m.deletedRef)
+	}
+
+	u.commit(u.begin(journal.KindReset, "reset hard HEAD~1"))
+	if m.deletedRef != "refs/ggc/undo/00" {
+		t.Fatalf("the dropped entry's ref should be deleted, deleted %q", m.deletedRef)
+	}
+}
+
+func TestUndoer_List(t *testing.T) {
+	m := newMockUndoOps(t)
+	u, buf := newTestUndoer(m, "")
+
+	u.Undo([]string{"list"})
+	if !strings.Contains(buf.String(), "Nothing to undo.") {
+		t.Fatalf("empty journal output: %s", buf.String())
+	}
+
+	buf.Reset()
+	u.commit(u.begin(journal.KindReset, "reset hard HEAD~1"))
+	u.commit(u.begin(journal.KindRebase, "rebase main"))
+	u.Undo([]string{"list"})
+	out := buf.String()
+	if strings.Index(out, "rebase main") > strings.Index(out, "reset hard HEAD~1") {
+		t.Fatalf("list should show newest first: %s", out)
+	}
+}
+
+func TestUndoer_NilIsNoop(t *testing.T) {
+	var u *Undoer
+	u.commit(u.begin(journal.KindReset, "reset"))
+	u.commit(u.beginBranchDelete("feature"))
+	u.commit(u.beginClean("clean files", []string{"a"}))
+	u.discard(nil)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// Verifier reports commit and tag signatures.
+type Verifier struct {
+	gitClient    git.SignatureReader
+	outputWriter io.Writer
+	helper       *Helper
+}
+
+// NewVerifier creates a new Verifier instance.
+func NewVerifier(client git.SignatureReader) *Verifier {
+	return &Verifier{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+		helper:       NewHelper(),
+	}
+}
+
+// Verify prints the signature status of every commit in the revision
+// range and of the tags pointing at those commits.
+func (v *Verifier) Verify(args []string) {
+	if len(args) != 1 {
+		v.helper.ShowVerifyHelp()
+		return
+	}
+
+	commits, err := v.gitClient.CommitSignatures(args[0])
+	if err != nil {
+		WriteError(v.outputWriter, err)
+		return
+	}
+	if len(commits) == 0 {
+		_, _ = fmt.Fprintf(v.outputWriter, "No commits in %s\n", args[0])
+		return
+	}
+	hashes := make([]string, len(commits))
+	for i, sig := range commits {
+		hashes[i] = sig.Commit
+	}
+	tags, err := v.gitClient.TagSignatures(hashes)
+	if err != nil {
+		WriteError(v.outputWriter, err)
+		return
+	}
+
+	var verified, unsigned, problems int
+	tw := tabwriter.NewWriter(v.outputWriter, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TYPE\tOBJECT\tSTATUS\tSIGNER\tKEY\tSUBJECT")
+	for _, sig := range append(commits, tags...) {
+		kind, object := "commit", shortHash(sig.Object)
+		if sig.Tag {
+			kind, object = "tag", sig.Object
+		}
+		switch {
+		case sig.Verified():
+			verified++
+		case sig.Code == 'N':
+			unsigned++
+		default:
+			problems++
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", kind, object, sig.Status(), orDash(sig.Signer), orDash(sig.Key), sig.Subject)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(v.outputWriter, "\n%d verified, %d unsigned, %d with problems\n", verified, unsigned, problems)
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+type mockSignatureReader struct {
+	commits []git.Signature
+	tags    []git.Signature
+	asked   []string
+}
+
+func (m *mockSignatureReader) CommitSignatures(_ string) ([]git.Signature, error) {
+	return m.commits, nil
+}
+
+func (m *mockSignatureReader) TagSignatures(commits []string) ([]git.Signature, error) {
+	m.asked = commits
+	return m.tags, nil
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	m := &mockSignatureReader{
+		commits: []git.Signature{
+			{Object: "0123456789abcdef", Commit: "0123456789abcdef", Code: 'G', Signer: "jane@example.com", Key: "SHA256:abc", Subject: "feat: signed"},
+			{Object: "fedcba9876543210", Commit: "fedcba9876543210", Code: 'N', Subject: "fix: unsigned"},
+		},
+		tags: []git.Signature{
+			{Object: "v1.0.0", Tag: true, Commit: "0123456789abcdef", Code: 'B', Subject: "v1.0.0"},
+		},
+	}
+	var buf bytes.Buffer
+	v := NewVerifier(m)
+	v.outputWriter = &buf
+
+	v.Verify([]string{"main..HEAD"})
+
+	out := buf.String()
+	for _, want := range []string{
+		"TYPE", "commit  0123456  good", "jane@example.com", "commit  fedcba9  unsigned",
+		"tag     v1.0.0   bad", "1 verified, 1 unsigned, 1 with problems",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if len(m.asked) != 2 {
+		t.Errorf("tags should be looked up for both commits, got %v", m.asked)
+	}
+}
+
+func TestVerifier_Verify_Help(t *testing.T) {
+	var buf bytes.Buffer
+	v := NewVerifier(&mockSignatureReader{})
+	v.outputWriter = &buf
+	v.helper.outputWriter = &buf
+
+	v.Verify(nil)
+	if !strings.Contains(buf.String(), "ggc verify <rev-range>") {
+		t.Errorf("expected help, got %q", buf.String())
+	}
+}
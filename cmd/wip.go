@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/journal"
+)
+
+// wipPrefix starts the subject of the commits ggc wip makes.
+const wipPrefix = "WIP: "
+
+// allowWIPFlag lets ggc push, ggc sync and ggc pr create push a branch
+// whose tip is a WIP commit.
+const allowWIPFlag = "--allow-wip"
+
+// wipOps is what ggc wip and ggc unwip need from git.
+type wipOps interface {
+	git.WIPCommitter
+	git.CommitMessageReader
+	GetCurrentBranch() (string, error)
+}
+
+// WIPer handles ggc wip and ggc unwip.
+type WIPer struct {
+	gitClient    wipOps
+	outputWriter io.Writer
+	status       git.StatusSummaryReader // nil commits without looking first
+	undo         *Undoer
+}
+
+// NewWIPer creates a new WIPer instance.
+func NewWIPer(client wipOps) *WIPer {
+	return &WIPer{
+		gitClient:    client,
+		outputWriter: os.Stdout,
+	}
+}
+
+// withStatus lets ggc wip say there is nothing to save, and refuse to
+// stage conflicts, when client can read the working tree status.
+func (w *WIPer) withStatus(client any) *WIPer {
+	if reader, ok := client.(git.StatusSummaryReader); ok {
+		w.status = reader
+	}
+	return w
+}
+
+// withUndo journals ggc unwip so `ggc undo` can put the commit back.
+func (w *WIPer) withUndo(u *Undoer) *WIPer {
+	w.undo = u
+	return w
+}
+
+// isWIPMessage reports whether a commit message marks a work-in-progress
+// commit: one from ggc wip, a "[WIP]" subject or the "--wip--" of common
+// shell aliases.
+func isWIPMessage(message string) bool {
+	subject, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	subject = strings.ToUpper(subject)
+	return strings.HasPrefix(subject, strings.ToUpper(wipPrefix)) || strings.HasPrefix(subject, "[WIP]") || strings.HasPrefix(subject, "--WIP--")
+}
+
+// WIP commits every change, untracked files included, as a WIP commit
+// whose subject is "WIP: " and the words in args.
+func (w *WIPer) WIP(args []string) {
+	message := strings.TrimSpace(strings.Join(args, " "))
+	if message == "" {
+		message = "work in progress"
+	}
+	branch, _ := w.gitClient.GetCurrentBranch()
+	if w.status != nil {
+		summary, err := w.status.StatusSummary()
+		if err != nil {
+			WriteError(w.outputWriter, err)
+			return
+		}
+		if summary.Conflicted() > 0 {
+			WriteErrorf(w.outputWriter, "resolve the conflicts before saving a WIP commit")
+			return
+		}
+		if summary.Staged()+summary.Modified()+summary.Untracked() == 0 {
+			WriteLine(w.outputWriter, "Nothing to save.")
+			return
+		}
+	}
+	if err := w.gitClient.CommitWIP(wipPrefix + message); err != nil {
+		WriteError(w.outputWriter, err)
+		return
+	}
+	WriteLinef(w.outputWriter, "Saved your changes in a WIP commit on %s. 'ggc unwip' takes them back out; 'ggc push' refuses to push it.", branch)
+}
+
+// Unwip takes back the WIP commit at HEAD, leaving its changes in the
+// working tree, unstaged.
+func (w *WIPer) Unwip(_ []string) {
+	messages, err := w.gitClient.CommitMessages("")
+	if err != nil {
+		WriteError(w.outputWriter, err)
+		return
+	}
+	if len(messages) == 0 || !isWIPMessage(messages[0].Message) {
+		WriteErrorf(w.outputWriter, "HEAD is not a WIP commit; nothing to take back")
+		return
+	}
+	pending := w.undo.begin(journal.KindUnwip, "unwip")
+	if err := w.gitClient.ResetMixed("HEAD~1"); err != nil {
+		WriteError(w.outputWriter, err)
+		return
+	}
+	w.undo.commit(pending)
+	WriteLinef(w.outputWriter, "Took back WIP commit %s; its changes are in the working tree, unstaged.", messages[0].Hash)
+}
+
+// checkWIP refuses a push whose tip is a WIP commit unless allowed.
+func (g *pushGate) checkWIP(allowed bool) error {
+	if g.messages == nil || allowed {
+		return nil
+	}
+	messages, err := g.messages.CommitMessages("")
+	if err != nil || len(messages) == 0 || !isWIPMessage(messages[0].Message) {
+		return nil
+	}
+	return fmt.Errorf("the branch ends in WIP commit %s; run 'ggc unwip' and commit properly, or push it anyway with %s", messages[0].Hash, allowWIPFlag)
+}
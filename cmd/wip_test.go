@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+type wipMock struct {
+	testutil.MockGitClient
+	head      git.CommitMessage
+	entries   []git.StatusEntry
+	committed string
+	resetTo   string
+}
+
+func (m *wipMock) GetCurrentBranch() (string, error) { return "feature", nil }
+func (m *wipMock) CommitWIP(message string) error    { m.committed = message; return nil }
+func (m *wipMock) ResetMixed(commit string) error    { m.resetTo = commit; return nil }
+func (m *wipMock) CommitMessages(string) ([]git.CommitMessage, error) {
+	return []git.CommitMessage{m.head}, nil
+}
+func (m *wipMock) StatusSummary() (*git.StatusSummary, error) {
+	return &git.StatusSummary{Entries: m.entries}, nil
+}
+
+func newTestWIPer(m *wipMock) (*WIPer, *bytes.Buffer) {
+	var buf bytes.Buffer
+	w := NewWIPer(m).withStatus(m)
+	w.outputWriter = &buf
+	return w, &buf
+}
+
+func TestWIPer_WIP(t *testing.T) {
+	untracked := []git.StatusEntry{{Kind: git.StatusUntracked, Path: "notes.txt"}}
+	tests := []struct {
+		name    string
+		args    []string
+		entries []git.StatusEntry
+		want    string
+		wantOut string
+	}{
+		{"default message", nil, untracked, "WIP: work in progress", "WIP commit on feature"},
+		{"message", []string{"login", "form"}, untracked, "WIP: login form", "ggc unwip"},
+		{"clean tree", nil, nil, "", "Nothing to save"},
+		{"conflicts", nil, []git.StatusEntry{{Kind: git.StatusUnmerged, Index: 'U', WorkTree: 'U', Path: "a.go"}}, "", "resolve the conflicts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &wipMock{entries: tt.entries}
+			w, buf := newTestWIPer(m)
+			w.WIP(tt.args)
+			if m.committed != tt.want {
+				t.Errorf("committed %q, want %q", m.committed, tt.want)
+			}
+			if !strings.Contains(buf.String(), tt.wantOut) {
+				t.Errorf("output lacks %q: %q", tt.wantOut, buf.String())
+			}
+		})
+	}
+}
+
+func TestWIPer_Unwip(t *testing.T) {
+	m := &wipMock{head: git.CommitMessage{Hash: "a1b2c3d", Message: "wip: login form\n"}}
+	w, buf := newTestWIPer(m)
+	w.Unwip(nil)
+	if m.resetTo != "HEAD~1" {
+		t.Errorf("reset to %q, want HEAD~1", m.resetTo)
+	}
+	if !strings.Contains(buf.String(), "a1b2c3d") {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	m = &wipMock{head: git.CommitMessage{Hash: "d4e5f60", Message: "Add login form\n\nWIP: no longer"}}
+	w, buf = newTestWIPer(m)
+	w.Unwip(nil)
+	if m.resetTo != "" || !strings.Contains(buf.String(), "not a WIP commit") {
+		t.Errorf("reset to %q; output = %q", m.resetTo, buf.String())
+	}
+}
+
+func TestIsWIPMessage(t *testing.T) {
+	tests := map[string]bool{
+		"WIP: work in progress":     true,
+		"[WIP] half a refactor":     true,
+		"--wip-- [skip ci]":         true,
+		"Wipe the cache on logout":  false,
+		"Fix bug\n\nWIP: unrelated": false,
+		"":                          false,
+	}
+	for message, want := range tests {
+		if got := isWIPMessage(message); got != want {
+			t.Errorf("isWIPMessage(%q) = %v, want %v", message, got, want)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/interactive"
+	"github.com/bmf-san/ggc/v8/internal/prompt"
+	"github.com/bmf-san/ggc/v8/internal/transcript"
+)
+
+// Workflower provides the workflow command, which lists the workflows
+// saved in the config, adds new ones from templates and re-runs the steps
+// that failed last time.
+type Workflower struct {
+	outputWriter  io.Writer
+	helper        *Helper
+	prompter      prompt.Prompter
+	configManager *config.Manager
+	// templateDir is where user templates are read from.
+	templateDir func() (string, error)
+	// interactive lets add ask for the template's placeholders.
+	interactive bool
+	// router runs re-run steps, and status is what their when
+	// conditions are evaluated against.
+	router interactive.CommandRouter
+	status git.StatusInfoReader
+	// transcripts holds the records of past runs.
+	transcripts *transcript.Store
+}
+
+// NewWorkflower creates a new Workflower.
+func NewWorkflower() *Workflower {
+	output := os.Stdout
+	helper := NewHelper()
+	helper.outputWriter = output
+	return &Workflower{
+		outputWriter: output,
+		helper:       helper,
+		prompter:     prompt.New(os.Stdin, output),
+		templateDir:  config.WorkflowTemplateDir,
+		interactive:  term.IsTerminal(int(os.Stdin.Fd())),
+		transcripts:  &transcript.Store{},
+	}
+}
+
+// withConfigManager supplies the saved workflows and lets add save them.
+func (w *Workflower) withConfigManager(cm *config.Manager) *Workflower {
+	w.configManager = cm
+	return w
+}
+
+// withRouter lets rerun run steps through router.
+func (w *Workflower) withRouter(router interactive.CommandRouter, status git.StatusInfoReader) *Workflower {
+	w.router = router
+	w.status = status
+	return w
+}
+
+// Workflow executes the workflow command with the given arguments.
+func (w *Workflower) Workflow(args []string) {
+	if len(args) == 0 || w.configManager == nil {
+		w.helper.ShowWorkflowHelp()
+		return
+	}
+
+	var err error
+	switch args[0] {
+	case "list", "ls":
+		err = w.list()
+	case "templates":
+		if len(args) > 1 {
+			err = w.showTemplate(args[1])
+		} else {
+			err = w.listTemplates()
+		}
+	case "add":
+		err = w.add(args[1:])
+	case "rerun":
+		err = w.rerun(args[1:])
+	default:
+		w.helper.ShowWorkflowHelp()
+		return
+	}
+	if err != nil {
+		WriteError(w.outputWriter, err)
+	}
+}
+
+// list shows the saved workflows and their steps.
+func (w *Workflower) list() error {
+	workflows := w.configManager.GetConfig().Workflows
+	if len(workflows) == 0 {
+		WriteLine(w.outputWriter, "No saved workflows. Start one from a template: ggc workflow templates")
+		return nil
+	}
+	names := make([]string, 0, len(workflows))
+	for name := range workflows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		WriteLinef(w.outputWriter, "%s", name)
+		for i, step := range workflows[name] {
+			WriteLinef(w.outputWriter, "  %d. %s", i+1, step)
+		}
+	}
+	return nil
+}
+
+// templates loads the built-in and user templates. Broken user templates
+// are reported and left out.
+func (w *Workflower) templates() []config.WorkflowTemplate {
+	dir, err := w.templateDir()
+	if err != nil {
+		dir = ""
+	}
+	templates, err := config.LoadWorkflowTemplates(dir)
+	if err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			WriteLinef(w.outputWriter, "Warning: skipped template %s", line)
+		}
+	}
+	return templates
+}
+
+func (w *Workflower) findTemplate(name string) (config.WorkflowTemplate, error) {
+	for _, t := range w.templates() {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return config.WorkflowTemplate{}, fmt.Errorf("no workflow template named %q; see ggc workflow templates", name)
+}
+
+// listTemplates lists the templates add accepts.
+func (w *Workflower) listTemplates() error {
+	templates := w.templates()
+	tw := tabwriter.NewWriter(w.outputWriter, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TEMPLATE\tSTEPS\tSOURCE\tDESCRIPTION")
+	for _, t := range templates {
+		source := t.Source
+		if source != config.BuiltinTemplateSource {
+			source = "user"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", t.Name, len(t.Steps), source, t.Description)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if dir, err := w.templateDir(); err == nil {
+		WriteLinef(w.outputWriter, "\nAdd your own as YAML files in %s.", dir)
+	}
+	return nil
+}
+
+// showTemplate prints the steps and placeholders of a template.
+func (w *Workflower) showTemplate(name string) error {
+	t, err := w.findTemplate(name)
+	if err != nil {
+		return err
+	}
+	WriteLinef(w.outputWriter, "%s: %s", t.Name, t.Description)
+	WriteLinef(w.outputWriter, "Source: %s", t.Source)
+	WriteLine(w.outputWriter, "Steps:")
+	for i, step := range t.Steps {
+		WriteLinef(w.outputWriter, "  %d. %s", i+1, step)
+	}
+	if placeholders := t.Placeholders(); len(placeholders) > 0 {
+		WriteLinef(w.outputWriter, "Placeholders: <%s>", strings.Join(placeholders, ">, <"))
+	}
+	return nil
+}
+
+// parseWorkflowAddArgs parses `ggc workflow add <template> [<name>]
+// [--set <placeholder>=<value>]...`.
+func parseWorkflowAddArgs(args []string) (template, name string, values map[string]string, err error) {
+	values = make(map[string]string)
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+		flag, value, hasValue := strings.Cut(arg, "=")
+		if flag != "--set" {
+			return "", "", nil, fmt.Errorf("unknown argument %q", arg)
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return "", "", nil, fmt.Errorf("--set requires <placeholder>=<value>")
+			}
+			i++
+			value = args[i]
+		}
+		key, val, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return "", "", nil, fmt.Errorf("--set requires <placeholder>=<value>, got %q", value)
+		}
+		values[strings.Trim(key, "<>")] = val
+	}
+	switch len(positional) {
+	case 1:
+		return positional[0], positional[0], values, nil
+	case 2:
+		return positional[0], positional[1], values, nil
+	}
+	return "", "", nil, fmt.Errorf("usage: ggc workflow add <template> [<name>] [--set <placeholder>=<value>]...")
+}
+
+// add copies a template into the workflows section under name. The
+// template's placeholders are filled from --set or, in a terminal, asked
+// for; those left empty are asked for each time the workflow runs.
+func (w *Workflower) add(args []string) error {
+	templateName, name, values, err := parseWorkflowAddArgs(args)
+	if err != nil {
+		return err
+	}
+	t, err := w.findTemplate(templateName)
+	if err != nil {
+		return err
+	}
+	cfg := w.configManager.GetConfig()
+	if _, exists := cfg.Workflows[name]; exists {
+		return fmt.Errorf("a workflow named %q already exists; give the new one another name: ggc workflow add %s <name>", name, templateName)
+	}
+
+	for _, placeholder := range t.Placeholders() {
+		if _, ok := values[placeholder]; ok || !w.interactive {
+			continue
+		}
+		value, ok := ReadLine(w.prompter, w.outputWriter, fmt.Sprintf("Value for <%s> (empty asks each run): ", placeholder))
+		if !ok {
+			return nil
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			values[placeholder] = value
+		}
+	}
+	for placeholder, value := range values {
+		if strings.ContainsAny(value, " \t") {
+			return fmt.Errorf("the value of <%s> must be a single word; leave it empty to be asked each run", placeholder)
+		}
+	}
+
+	steps := t.Instantiate(values)
+	if cfg.Workflows == nil {
+		cfg.Workflows = map[string][]config.WorkflowStep{}
+	}
+	if err := w.configManager.Set("workflows."+name, steps); err != nil {
+		delete(cfg.Workflows, name)
+		return err
+	}
+	WriteLinef(w.outputWriter, "Saved workflow %s (%d step(s)); run it from the workflow view of interactive mode (Ctrl+T)", name, len(steps))
+	return nil
+}
+
+// rerun runs the steps that failed in the last workflow run again, in
+// order, with the arguments they ran with. It records a new run, so it
+// can be repeated until nothing fails.
+func (w *Workflower) rerun(args []string) error {
+	if len(args) != 1 || args[0] != "--failed" {
+		return fmt.Errorf("usage: ggc workflow rerun --failed")
+	}
+	if w.router == nil {
+		return fmt.Errorf("workflow steps cannot be run here")
+	}
+	run, path, ok, err := w.transcripts.Last()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("no workflow run has been recorded yet")
+	}
+	name := run.Workflow
+	if name == "" {
+		name = "the last workflow"
+	}
+
+	failed := run.Failed()
+	if len(failed) == 0 {
+		WriteLinef(w.outputWriter, "No step of %s failed in its last run (%s).", name, path)
+		return nil
+	}
+	wf := interactive.NewWorkflow()
+	for _, step := range failed {
+		if len(step.Args) == 0 {
+			WriteLinef(w.outputWriter, "Warning: %s has no command to re-run", step.Label)
+			continue
+		}
+		wf.AddStepWithOptions(interactive.WorkflowStep{
+			Command:     step.Args[0],
+			Args:        step.Args[1:],
+			Description: strings.Join(step.Args, " "),
+			When:        step.When,
+			Confirm:     step.Confirm,
+			// A step that failed without stopping the run should not stop
+			// the re-run either.
+			ContinueOnError: step.ContinueOnError,
+		})
+	}
+	if wf.IsEmpty() {
+		return nil
+	}
+	WriteLinef(w.outputWriter, "Re-running %d failed step(s) of %s", wf.Size(), name)
+
+	executor := interactive.NewWorkflowExecutor(w.router, nil).WithTranscripts(w.transcripts)
+	if w.status != nil {
+		executor.WithStatusReader(w.status)
+	}
+	return executor.ExecuteNamed(run.Workflow, wf)
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	commandregistry "github.com/bmf-san/ggc/v8/cmd/command"
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+	"github.com/bmf-san/ggc/v8/internal/transcript"
+)
+
+// newTestWorkflower returns a Workflower whose config saves under a
+// temporary home and whose user templates live in templateDir.
+func newTestWorkflower(t *testing.T, templateDir string) (*Workflower, *bytes.Buffer, *config.Manager) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	cm := config.NewConfigManager(testutil.NewMockGitClient())
+	if err := cm.Load(); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w := NewWorkflower().withConfigManager(cm)
+	w.outputWriter = &buf
+	w.helper.outputWriter = &buf
+	w.templateDir = func() (string, error) { return templateDir, nil }
+	w.interactive = false
+	w.transcripts = &transcript.Store{Dir: t.TempDir()}
+	return w, &buf, cm
+}
+
+func TestWorkflower_Templates(t *testing.T) {
+	dir := t.TempDir()
+	user := "description: Tag a release candidate\nsteps:\n  - tag create <version>\n  - run: tag push\n    confirm: true\n"
+	if err := os.WriteFile(filepath.Join(dir, "rc.yaml"), []byte(user), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("steps:\n  - status | grep x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	w, buf, _ := newTestWorkflower(t, dir)
+
+	w.Workflow([]string{"templates"})
+	out := buf.String()
+	for _, want := range []string{"feature-start", "sync-fork", "hotfix-release", "rc  ", "Tag a release candidate", "Warning: skipped template", "broken.yaml"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("templates output lacks %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	w.Workflow([]string{"templates", "rc"})
+	out = buf.String()
+	for _, want := range []string{"rc: Tag a release candidate", "1. tag create <version>", "2. tag push (confirm)", "Placeholders: <version>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("template output lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWorkflower_Add(t *testing.T) {
+	w, buf, cm := newTestWorkflower(t, t.TempDir())
+
+	w.Workflow([]string{"add", "feature-start", "start", "--set", "base=main"})
+	steps := cm.GetConfig().Workflows["start"]
+	if len(steps) != 3 || steps[0].Run != "switch main" || steps[2].Run != "branch create feature/<name>" {
+		t.Fatalf("saved steps = %+v (output %q)", steps, buf.String())
+	}
+	data, err := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".ggcconfig.yaml"))
+	if err != nil || !strings.Contains(string(data), "switch main") {
+		t.Errorf("config file should hold the workflow: %v\n%s", err, data)
+	}
+
+	buf.Reset()
+	w.Workflow([]string{"add", "feature-start", "start"})
+	if !strings.Contains(buf.String(), `a workflow named "start" already exists`) {
+		t.Errorf("adding over an existing workflow should fail, output %q", buf.String())
+	}
+
+	buf.Reset()
+	w.Workflow([]string{"add", "feature-start", "two", "--set=base=my main"})
+	if _, ok := cm.GetConfig().Workflows["two"]; ok || !strings.Contains(buf.String(), "must be a single word") {
+		t.Errorf("a value with spaces should be rejected, output %q", buf.String())
+	}
+
+	buf.Reset()
+	w.Workflow([]string{"add", "nope"})
+	if !strings.Contains(buf.String(), `no workflow template named "nope"`) {
+		t.Errorf("unknown template output %q", buf.String())
+	}
+
+	buf.Reset()
+	w.Workflow([]string{"list"})
+	if !strings.Contains(buf.String(), "start\n  1. switch main\n") {
+		t.Errorf("list output %q", buf.String())
+	}
+}
+
+func TestWorkflower_AddPrompts(t *testing.T) {
+	w, _, cm := newTestWorkflower(t, t.TempDir())
+	w.interactive = true
+	w.prompter = &mockPrompter{input: "develop"}
+
+	w.Workflow([]string{"add", "sync-fork"})
+	steps := cm.GetConfig().Workflows["sync-fork"]
+	if len(steps) != 3 || steps[0].Run != "switch develop" {
+		t.Fatalf("saved steps = %+v", steps)
+	}
+}
+
+func TestParseWorkflowAddArgs(t *testing.T) {
+	for _, args := range [][]string{nil, {"a", "b", "c"}, {"a", "--set"}, {"a", "--set", "novalue"}, {"a", "--force"}} {
+		if _, _, _, err := parseWorkflowAddArgs(args); err == nil {
+			t.Errorf("parseWorkflowAddArgs(%q) succeeded, want an error", args)
+		}
+	}
+}
+
+// TestBuiltinWorkflowTemplatesUseRegisteredCommands keeps the templates
+// in step with the command registry.
+func TestBuiltinWorkflowTemplatesUseRegisteredCommands(t *testing.T) {
+	registry := commandregistry.NewRegistry()
+	templates, err := config.LoadWorkflowTemplates("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tmpl := range templates {
+		for _, step := range tmpl.Steps {
+			name := strings.Fields(step.Run)[0]
+			if _, ok := registry.Find(name); !ok {
+				t.Errorf("template %s runs %q, which is not a ggc command", tmpl.Name, step.Run)
+			}
+		}
+	}
+}
+
+// recordingRouter records the commands it routes and fails those in fail.
+type recordingRouter struct {
+	fail   map[string]bool
+	routed []string
+}
+
+func (r *recordingRouter) Route(args []string) error {
+	r.routed = append(r.routed, strings.Join(args, " "))
+	if r.fail[args[0]] {
+		return fmt.Errorf("%s failed", args[0])
+	}
+	return nil
+}
+
+func TestWorkflower_RerunFailed(t *testing.T) {
+	w, buf, _ := newTestWorkflower(t, t.TempDir())
+	router := &recordingRouter{}
+	w.withRouter(router, nil)
+
+	w.Workflow([]string{"rerun", "--failed"})
+	if !strings.Contains(buf.String(), "no workflow run has been recorded yet") {
+		t.Errorf("unexpected output without a run:\n%s", buf.String())
+	}
+
+	if _, err := w.transcripts.Save(&transcript.Run{
+		Workflow: "refresh",
+		Started:  time.Now(),
+		Steps: []transcript.Step{
+			{Label: "[1] add .", Args: []string{"add", "."}, Status: transcript.StatusOK},
+			{Label: "[2.1] fetch origin", Args: []string{"fetch", "origin"}, Status: transcript.StatusFailed, ContinueOnError: true},
+			{Label: "[2.2] fetch upstream", Args: []string{"fetch", "upstream"}, Status: transcript.StatusOK},
+			{Label: "[3] push current", Args: []string{"push", "current"}, Status: transcript.StatusFailed},
+			{Label: "[4] status", Args: []string{"status"}, Status: transcript.StatusSkipped},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	w.Workflow([]string{"rerun", "--failed"})
+	if got, want := strings.Join(router.routed, "|"), "fetch origin|push current"; got != want {
+		t.Errorf("re-ran %q, want %q", got, want)
+	}
+	if !strings.Contains(buf.String(), "Re-running 2 failed step(s) of refresh") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	// The re-run is recorded, and nothing failed this time.
+	buf.Reset()
+	router.routed = nil
+	w.Workflow([]string{"rerun", "--failed"})
+	if len(router.routed) != 0 || !strings.Contains(buf.String(), "No step of refresh failed in its last run") {
+		t.Errorf("routed %v, output:\n%s", router.routed, buf.String())
+	}
+
+	buf.Reset()
+	w.Workflow([]string{"rerun"})
+	if !strings.Contains(buf.String(), "usage: ggc workflow rerun --failed") {
+		t.Errorf("unexpected output without --failed:\n%s", buf.String())
+	}
+}
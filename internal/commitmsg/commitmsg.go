@@ -0,0 +1,93 @@
+// Package commitmsg builds, parses and checks commit messages, including
+// the Conventional Commits header format.
+package commitmsg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultTypes are the Conventional Commits types offered when the
+// configuration does not list its own.
+var DefaultTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+var conventionalHeaderRe = regexp.MustCompile(`^([A-Za-z][\w-]*)(?:\(([^()]*)\))?(!)?: *(.*)$`)
+
+// Message is a commit message split into its parts. Type, Scope and
+// Breaking are only set for Conventional Commits headers.
+type Message struct {
+	Type     string
+	Scope    string
+	Breaking bool
+	Subject  string
+	Body     string
+}
+
+// Header renders the first line of m.
+func (m Message) Header() string {
+	if m.Type == "" {
+		return m.Subject
+	}
+	var b strings.Builder
+	b.WriteString(m.Type)
+	if m.Scope != "" {
+		fmt.Fprintf(&b, "(%s)", m.Scope)
+	}
+	if m.Breaking {
+		b.WriteByte('!')
+	}
+	b.WriteString(": ")
+	b.WriteString(m.Subject)
+	return b.String()
+}
+
+// String renders the full message: the header, a blank line and the body
+// when there is one.
+func (m Message) String() string {
+	body := strings.Trim(m.Body, "\n")
+	if body == "" {
+		return m.Header()
+	}
+	return m.Header() + "\n\n" + body
+}
+
+// Parse splits raw into a Message. A header that is not in Conventional
+// Commits form is kept whole as the subject.
+func Parse(raw string) Message {
+	header, body, _ := strings.Cut(strings.Trim(raw, "\n"), "\n")
+	m := Message{Subject: header, Body: strings.Trim(body, "\n")}
+	if match := conventionalHeaderRe.FindStringSubmatch(header); match != nil {
+		m.Type, m.Scope, m.Breaking, m.Subject = match[1], match[2], match[3] == "!", match[4]
+	}
+	return m
+}
+
+// ParseTemplate reads a commit.template file the way git does: lines
+// starting with '#' are dropped before the rest is parsed as a message.
+func ParseTemplate(template string) Message {
+	lines := strings.Split(StripComments(template), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return Parse(strings.Join(lines, "\n"))
+}
+
+// LengthWarnings reports lines of m longer than the given limits. A limit
+// of zero or less disables that check.
+func LengthWarnings(m Message, subjectMax, bodyMax int) []string {
+	var warnings []string
+	if header := m.Header(); subjectMax > 0 && utf8.RuneCountInString(header) > subjectMax {
+		warnings = append(warnings, fmt.Sprintf("subject is %d characters; keep it within %d",
+			utf8.RuneCountInString(header), subjectMax))
+	}
+	if bodyMax > 0 && m.Body != "" {
+		for i, line := range strings.Split(m.Body, "\n") {
+			if n := utf8.RuneCountInString(line); n > bodyMax {
+				warnings = append(warnings, fmt.Sprintf("body line %d is %d characters; wrap at %d", i+1, n, bodyMax))
+			}
+		}
+	}
+	return warnings
+}
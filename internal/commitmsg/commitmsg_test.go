@@ -0,0 +1,106 @@
+package commitmsg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessage_String(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  Message
+		want string
+	}{
+		{"plain", Message{Subject: "Fix login"}, "Fix login"},
+		{"with body", Message{Subject: "Fix login", Body: "Details.\n\n"}, "Fix login\n\nDetails."},
+		{"conventional", Message{Type: "feat", Scope: "ui", Subject: "add pager"}, "feat(ui): add pager"},
+		{"breaking", Message{Type: "fix", Breaking: true, Subject: "drop v1"}, "fix!: drop v1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.msg.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	m := Parse("feat(api)!: remove v1 endpoints\n\nThey were deprecated.\n")
+	if m.Type != "feat" || m.Scope != "api" || !m.Breaking || m.Subject != "remove v1 endpoints" {
+		t.Errorf("header parsed as %+v", m)
+	}
+	if m.Body != "They were deprecated." {
+		t.Errorf("body = %q", m.Body)
+	}
+
+	plain := Parse("Update README")
+	if plain.Type != "" || plain.Subject != "Update README" {
+		t.Errorf("plain header parsed as %+v", plain)
+	}
+}
+
+func TestParseTemplate(t *testing.T) {
+	m := ParseTemplate("# Summary in 50 chars\nfix: \n\n# Why?\nBecause.\n")
+	if m.Type != "fix" || m.Subject != "" {
+		t.Errorf("template header parsed as %+v", m)
+	}
+	if m.Body != "Because." {
+		t.Errorf("comments should be dropped from the body, got %q", m.Body)
+	}
+}
+
+func TestLengthWarnings(t *testing.T) {
+	m := Message{Subject: strings.Repeat("x", 80), Body: "short\n" + strings.Repeat("y", 90)}
+	warnings := LengthWarnings(m, 72, 72)
+	if len(warnings) != 2 {
+		t.Fatalf("warnings = %v", warnings)
+	}
+	if !strings.Contains(warnings[1], "body line 2") {
+		t.Errorf("body warning = %q", warnings[1])
+	}
+	if got := LengthWarnings(m, 0, 0); len(got) != 0 {
+		t.Errorf("zero limits should disable checks, got %v", got)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	var changes []Change
+	for i, header := range []string{"fix(ui): wrap long lines", "feat: add pager", "Update README", "feat(api)!: drop v1", "chore: bump deps", "fix: crash on empty repo"} {
+		changes = append(changes, Change{Hash: string(rune('a' + i)), Message: Parse(header)})
+	}
+	want := "Breaking Changes:\n- api: drop v1 (d)\n" +
+		"\nFeatures:\n- add pager (b)\n" +
+		"\nBug Fixes:\n- ui: wrap long lines (a)\n- crash on empty repo (f)\n" +
+		"\nOther Changes:\n- Update README (c)\n- bump deps (e)\n"
+	if got := PlainNotes(Group(changes)); got != want {
+		t.Errorf("PlainNotes() =\n%s\nwant\n%s", got, want)
+	}
+	if got := Group(nil); len(got) != 0 {
+		t.Errorf("Group(nil) = %+v", got)
+	}
+}
+
+func TestBumpFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		want    Bump
+	}{
+		{"fixes", []string{"fix: a", "docs: b", "Update README"}, BumpPatch},
+		{"feature", []string{"fix: a", "feat(ui): b"}, BumpMinor},
+		{"breaking header", []string{"feat: a", "refactor!: b"}, BumpMajor},
+		{"breaking footer", []string{"fix: a\n\nBREAKING CHANGE: config moved"}, BumpMajor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var messages []Message
+			for _, h := range tt.headers {
+				messages = append(messages, Parse(h))
+			}
+			if got := BumpFor(messages); got != tt.want {
+				t.Errorf("BumpFor() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
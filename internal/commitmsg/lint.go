@@ -0,0 +1,205 @@
+package commitmsg
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// strictHeaderRe is the Conventional Commits header grammar without the
+// leniency Parse allows for templates: exactly one space after the colon
+// and a non-empty description.
+var strictHeaderRe = regexp.MustCompile(`^[a-z][\w-]*(\([^()\s]+\))?!?: \S`)
+
+// typeSynonyms maps common misspellings of a type to its canonical form
+// for fix suggestions.
+var typeSynonyms = map[string]string{
+	"feature":     "feat",
+	"features":    "feat",
+	"bugfix":      "fix",
+	"hotfix":      "fix",
+	"doc":         "docs",
+	"tests":       "test",
+	"refac":       "refactor",
+	"performance": "perf",
+	"deps":        "build",
+}
+
+// verbTypes guesses a type from the first word of a free-form subject.
+var verbTypes = map[string]string{
+	"add":         "feat",
+	"adds":        "feat",
+	"added":       "feat",
+	"implement":   "feat",
+	"support":     "feat",
+	"introduce":   "feat",
+	"fix":         "fix",
+	"fixes":       "fix",
+	"fixed":       "fix",
+	"correct":     "fix",
+	"resolve":     "fix",
+	"document":    "docs",
+	"docs":        "docs",
+	"refactor":    "refactor",
+	"restructure": "refactor",
+	"simplify":    "refactor",
+	"rename":      "refactor",
+	"test":        "test",
+	"tests":       "test",
+	"revert":      "revert",
+	"bump":        "build",
+	"upgrade":     "build",
+}
+
+// LintOptions configures Lint.
+type LintOptions struct {
+	Types           []string // allowed types; empty means DefaultTypes
+	Scopes          []string // allowed scopes; empty allows any scope
+	HeaderMaxLength int      // zero or less disables the length rule
+}
+
+func (o LintOptions) types() []string {
+	if len(o.Types) == 0 {
+		return DefaultTypes
+	}
+	return o.Types
+}
+
+// Violation is one broken rule in a commit message.
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+// IsGenerated reports whether raw was written by git itself (merges,
+// reverts) or is an autosquash marker, which Lint accepts as is.
+func IsGenerated(raw string) bool {
+	header, _, _ := strings.Cut(raw, "\n")
+	for _, prefix := range []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(header, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// StripComments removes the '#' lines git drops from a message being
+// edited, and everything below a `git commit -v` scissors line.
+func StripComments(raw string) string {
+	var kept []string
+	for _, line := range strings.Split(raw, "\n") {
+		if strings.HasPrefix(line, "# ") && strings.Contains(line, ">8") {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Trim(strings.Join(kept, "\n"), "\n")
+}
+
+// Lint checks raw against the Conventional Commits specification and opts.
+// Generated messages (see IsGenerated) always pass.
+func Lint(raw string, opts LintOptions) []Violation {
+	raw = strings.Trim(raw, "\n")
+	if IsGenerated(raw) {
+		return nil
+	}
+	header, rest, hasBody := strings.Cut(raw, "\n")
+
+	var violations []Violation
+	add := func(rule, format string, args ...any) {
+		violations = append(violations, Violation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if !strictHeaderRe.MatchString(header) {
+		add("header-format", `header must look like "type(scope): description"`)
+	}
+	m := Parse(raw)
+	if m.Type != "" {
+		if !slices.Contains(opts.types(), m.Type) {
+			add("type-enum", "type %q is not one of: %s", m.Type, strings.Join(opts.types(), ", "))
+		}
+		if m.Scope != "" && len(opts.Scopes) > 0 && !slices.Contains(opts.Scopes, m.Scope) {
+			add("scope-enum", "scope %q is not one of: %s", m.Scope, strings.Join(opts.Scopes, ", "))
+		}
+		if strings.TrimSpace(m.Subject) == "" {
+			add("subject-empty", "description must not be empty")
+		}
+	}
+	if strings.HasSuffix(header, ".") {
+		add("subject-full-stop", "header must not end with a period")
+	}
+	if n := utf8.RuneCountInString(header); opts.HeaderMaxLength > 0 && n > opts.HeaderMaxLength {
+		add("header-max-length", "header is %d characters; the limit is %d", n, opts.HeaderMaxLength)
+	}
+	if hasBody && strings.TrimSpace(rest) != "" && !strings.HasPrefix(rest, "\n") {
+		add("body-leading-blank", "body must be separated from the header by a blank line")
+	}
+	return violations
+}
+
+// SuggestFix proposes a rewrite of raw for the rules that can be repaired
+// mechanically: a missing or misspelled type, a disallowed scope, a
+// missing space after the colon, a trailing period and a missing blank
+// line before the body. It returns false when raw already passes or no
+// rewrite would change it.
+func SuggestFix(raw string, opts LintOptions) (string, bool) {
+	raw = strings.Trim(raw, "\n")
+	if len(Lint(raw, opts)) == 0 {
+		return "", false
+	}
+	header, body, _ := strings.Cut(raw, "\n")
+
+	m := Parse(header)
+	if m.Type == "" {
+		// Free-form header: guess the type from its first word.
+		m = Message{Type: guessType(header, opts), Subject: header}
+	} else {
+		m.Type = canonicalType(m.Type, opts)
+	}
+	if m.Scope != "" && len(opts.Scopes) > 0 && !slices.Contains(opts.Scopes, m.Scope) {
+		m.Scope = ""
+	}
+	m.Subject = strings.TrimRight(strings.TrimSpace(m.Subject), ".")
+	m.Body = strings.Trim(body, "\n")
+
+	fixed := m.String()
+	if fixed == raw {
+		return "", false
+	}
+	return fixed, true
+}
+
+// canonicalType maps typ to an allowed type: a case-insensitive match or a
+// known synonym, falling back to "chore" (or the first allowed type).
+func canonicalType(typ string, opts LintOptions) string {
+	types := opts.types()
+	lower := strings.ToLower(typ)
+	if slices.Contains(types, lower) {
+		return lower
+	}
+	if syn, ok := typeSynonyms[lower]; ok && slices.Contains(types, syn) {
+		return syn
+	}
+	return fallbackType(types)
+}
+
+func guessType(subject string, opts LintOptions) string {
+	types := opts.types()
+	word, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(subject)), " ")
+	if t, ok := verbTypes[word]; ok && slices.Contains(types, t) {
+		return t
+	}
+	return fallbackType(types)
+}
+
+func fallbackType(types []string) string {
+	if slices.Contains(types, "chore") {
+		return "chore"
+	}
+	return types[0]
+}
@@ -0,0 +1,76 @@
+package commitmsg
+
+import (
+	"slices"
+	"testing"
+)
+
+func lintRules(vs []Violation) []string {
+	rules := make([]string, len(vs))
+	for i, v := range vs {
+		rules[i] = v.Rule
+	}
+	return rules
+}
+
+func TestLint(t *testing.T) {
+	opts := LintOptions{Scopes: []string{"cli", "git"}, HeaderMaxLength: 30}
+	tests := []struct {
+		name string
+		msg  string
+		want []string
+	}{
+		{"valid", "feat(cli): add lint", nil},
+		{"valid breaking with body", "fix!: drop v1\n\nDetails.", nil},
+		{"free form", "Update things", []string{"header-format"}},
+		{"missing space", "fix:crash", []string{"header-format"}},
+		{"unknown type", "feature: add lint", []string{"type-enum"}},
+		{"unknown scope", "feat(ui): add lint", []string{"scope-enum"}},
+		{"empty description", "feat: ", []string{"header-format", "subject-empty"}},
+		{"full stop", "docs: explain lint.", []string{"subject-full-stop"}},
+		{"too long", "docs: " + "x123456789x123456789x123456789", []string{"header-max-length"}},
+		{"no blank line", "chore: tidy\nbody", []string{"body-leading-blank"}},
+		{"merge", "Merge branch 'main' into feature", nil},
+		{"fixup", "fixup! feat: add lint", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lintRules(Lint(tt.msg, opts)); !slices.Equal(got, tt.want) {
+				t.Errorf("Lint(%q) rules = %v, want %v", tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestFix(t *testing.T) {
+	opts := LintOptions{Scopes: []string{"cli"}}
+	tests := []struct {
+		msg    string
+		want   string
+		wantOK bool
+	}{
+		{"Add lint subcommand", "feat: Add lint subcommand", true},
+		{"Feature(cli): add lint.", "feat(cli): add lint", true},
+		{"fix(ui):crash on start", "fix: crash on start", true},
+		{"tidy up\nbody text", "chore: tidy up\n\nbody text", true},
+		{"feat: already fine", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			got, ok := SuggestFix(tt.msg, opts)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("SuggestFix(%q) = %q, %v; want %q, %v", tt.msg, got, ok, tt.want, tt.wantOK)
+			}
+			if ok && len(Lint(got, opts)) != 0 {
+				t.Errorf("suggestion %q still fails lint: %v", got, Lint(got, opts))
+			}
+		})
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	raw := "feat: x\n# Please enter the commit message\n\nbody\n# ------------------------ >8 ------------------------\ndiff --git a/x b/x\n"
+	if got := StripComments(raw); got != "feat: x\n\nbody" {
+		t.Errorf("StripComments() = %q", got)
+	}
+}
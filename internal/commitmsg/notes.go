@@ -0,0 +1,151 @@
+package commitmsg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Change is one commit as it appears in release notes.
+type Change struct {
+	Hash    string // abbreviated
+	Message Message
+}
+
+// Section is a titled group of changes in release notes.
+type Section struct {
+	Title   string
+	Changes []Change
+}
+
+// noteSections orders the Conventional Commits types in release notes.
+// Types not listed here, and headers that are not Conventional Commits,
+// go under otherSection.
+var noteSections = []struct {
+	typ   string
+	title string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance"},
+	{"revert", "Reverts"},
+	{"refactor", "Refactoring"},
+	{"docs", "Documentation"},
+}
+
+const (
+	breakingSection = "Breaking Changes"
+	otherSection    = "Other Changes"
+)
+
+// Group sorts changes into sections by type, keeping their order within
+// each. Breaking changes get a section of their own ahead of the rest;
+// empty sections are left out.
+func Group(changes []Change) []Section {
+	byTitle := make(map[string][]Change)
+	for _, c := range changes {
+		title := otherSection
+		if c.Message.BreakingChange() {
+			title = breakingSection
+		} else {
+			for _, s := range noteSections {
+				if strings.EqualFold(c.Message.Type, s.typ) {
+					title = s.title
+					break
+				}
+			}
+		}
+		byTitle[title] = append(byTitle[title], c)
+	}
+
+	titles := []string{breakingSection}
+	for _, s := range noteSections {
+		titles = append(titles, s.title)
+	}
+	titles = append(titles, otherSection)
+	var sections []Section
+	for _, title := range titles {
+		if len(byTitle[title]) > 0 {
+			sections = append(sections, Section{Title: title, Changes: byTitle[title]})
+		}
+	}
+	return sections
+}
+
+// BreakingChange reports whether m is marked as breaking, by a '!' in its
+// header or a BREAKING CHANGE footer.
+func (m Message) BreakingChange() bool {
+	if m.Breaking {
+		return true
+	}
+	for _, line := range strings.Split(m.Body, "\n") {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			return true
+		}
+	}
+	return false
+}
+
+// Bump is how far a release moves a semantic version.
+type Bump int
+
+// The bumps, from smallest to largest.
+const (
+	BumpPatch Bump = iota
+	BumpMinor
+	BumpMajor
+)
+
+// String returns "patch", "minor" or "major".
+func (b Bump) String() string {
+	switch b {
+	case BumpMajor:
+		return "major"
+	case BumpMinor:
+		return "minor"
+	default:
+		return "patch"
+	}
+}
+
+// BumpFor returns the bump that messages call for: major when one is a
+// breaking change, minor when one adds a feature, patch otherwise.
+func BumpFor(messages []Message) Bump {
+	bump := BumpPatch
+	for _, m := range messages {
+		switch {
+		case m.BreakingChange():
+			return BumpMajor
+		case strings.EqualFold(m.Type, "feat"):
+			bump = BumpMinor
+		}
+	}
+	return bump
+}
+
+// Line renders c as one entry, led by its scope when it has one.
+func (c Change) Line() string {
+	line := c.Message.Subject
+	if c.Message.Scope != "" {
+		line = c.Message.Scope + ": " + line
+	}
+	if c.Hash != "" {
+		line += " (" + c.Hash + ")"
+	}
+	return line
+}
+
+// PlainNotes renders sections as plain text, which survives git's
+// stripping of '#' lines in tag and commit messages.
+func PlainNotes(sections []Section) string {
+	var b strings.Builder
+	for i, s := range sections {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%s:\n", s.Title)
+		for _, c := range s.Changes {
+			fmt.Fprintf(&b, "- %s\n", c.Line())
+		}
+	}
+	return b.String()
+}
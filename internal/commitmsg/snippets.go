@@ -0,0 +1,144 @@
+package commitmsg
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	snippetRefRe   = regexp.MustCompile(`\{\{([A-Za-z0-9_-]+)\}\}`)
+	variableRefRe  = regexp.MustCompile(`\{([A-Z][A-Z0-9_]*)\}`)
+	snippetNameRe  = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	variableNameRe = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	// ticketRe finds an issue-tracker key such as PROJ-123 in a branch
+	// name, whatever its case.
+	ticketRe = regexp.MustCompile(`(?:^|[^A-Za-z0-9])([A-Za-z][A-Za-z0-9]+-[0-9]+)(?:[^0-9]|$)`)
+)
+
+// ValidSnippetName reports whether name can be referenced as {{name}}.
+func ValidSnippetName(name string) bool {
+	return snippetNameRe.MatchString(name)
+}
+
+// ValidVariableName reports whether name can be referenced as {NAME}:
+// upper-case letters, digits and underscores, starting with a letter.
+func ValidVariableName(name string) bool {
+	return variableNameRe.MatchString(name)
+}
+
+// BranchVariables returns the variables taken from a branch name: BRANCH
+// itself, TICKET for an issue key such as PROJ-123 (upper-cased), and one
+// per entry of patterns, a regular expression whose first group, or else
+// whole match, becomes the value. Variables the branch does not match are
+// left out so they get asked for.
+func BranchVariables(branch string, patterns map[string]string) map[string]string {
+	vars := map[string]string{}
+	if branch == "" {
+		return vars
+	}
+	vars["BRANCH"] = branch
+	if m := ticketRe.FindStringSubmatch(branch); m != nil {
+		vars["TICKET"] = strings.ToUpper(m[1])
+	}
+	for name, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		m := re.FindStringSubmatch(branch)
+		switch {
+		case m == nil:
+			delete(vars, name)
+		case len(m) > 1:
+			vars[name] = m[1]
+		default:
+			vars[name] = m[0]
+		}
+	}
+	return vars
+}
+
+// Expander fills in commit message templates: {{name}} inserts the
+// snippet called name, and {NAME} the value of a variable. Snippets are
+// inserted first, so they may hold variables themselves.
+type Expander struct {
+	Snippets  map[string]string
+	Variables map[string]string
+	// Ask returns the value of a variable missing from Variables. Each
+	// variable is asked for once per Expander. When Ask is nil, a missing
+	// variable is an error.
+	Ask func(name string) (string, error)
+
+	asked map[string]string
+}
+
+// HasPlaceholders reports whether text references a snippet or a
+// variable.
+func HasPlaceholders(text string) bool {
+	return snippetRefRe.MatchString(text) || variableRefRe.MatchString(text)
+}
+
+// Expand returns text with its snippets and variables filled in.
+func (e *Expander) Expand(text string) (string, error) {
+	var err error
+	text = snippetRefRe.ReplaceAllStringFunc(text, func(ref string) string {
+		name := snippetRefRe.FindStringSubmatch(ref)[1]
+		snippet, ok := e.Snippets[name]
+		if !ok && err == nil {
+			err = e.unknownSnippet(name)
+		}
+		return snippet
+	})
+	if err != nil {
+		return "", err
+	}
+	text = variableRefRe.ReplaceAllStringFunc(text, func(ref string) string {
+		if err != nil {
+			return ref
+		}
+		var value string
+		value, err = e.value(variableRefRe.FindStringSubmatch(ref)[1])
+		return value
+	})
+	if err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// value returns the value of the variable name, asking for it when it is
+// not known.
+func (e *Expander) value(name string) (string, error) {
+	if v := e.Variables[name]; v != "" {
+		return v, nil
+	}
+	if v, ok := e.asked[name]; ok {
+		return v, nil
+	}
+	if e.Ask == nil {
+		return "", fmt.Errorf("no value for {%s}; it is not set by the branch name or snippets.variables", name)
+	}
+	v, err := e.Ask(name)
+	if err != nil {
+		return "", err
+	}
+	if e.asked == nil {
+		e.asked = map[string]string{}
+	}
+	e.asked[name] = v
+	return v, nil
+}
+
+func (e *Expander) unknownSnippet(name string) error {
+	if len(e.Snippets) == 0 {
+		return fmt.Errorf("unknown snippet {{%s}}; define snippets under snippets.commit in the config", name)
+	}
+	names := make([]string, 0, len(e.Snippets))
+	for n := range e.Snippets {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	return fmt.Errorf("unknown snippet {{%s}}; snippets.commit defines %s", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,77 @@
+package commitmsg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBranchVariables(t *testing.T) {
+	tests := []struct {
+		branch   string
+		patterns map[string]string
+		want     map[string]string
+	}{
+		{"", nil, map[string]string{}},
+		{"main", nil, map[string]string{"BRANCH": "main"}},
+		{"feature/PROJ-123-login", nil, map[string]string{"BRANCH": "feature/PROJ-123-login", "TICKET": "PROJ-123"}},
+		{"fix/proj-7", nil, map[string]string{"BRANCH": "fix/proj-7", "TICKET": "PROJ-7"}},
+		{"issue/42-crash", map[string]string{"ISSUE": `^issue/(\d+)`}, map[string]string{"BRANCH": "issue/42-crash", "ISSUE": "42"}},
+		{"feature/ABC-1", map[string]string{"JIRA": `[A-Z]+-\d+`, "TICKET": `^nomatch`}, map[string]string{"BRANCH": "feature/ABC-1", "JIRA": "ABC-1"}},
+	}
+	for _, tt := range tests {
+		got := BranchVariables(tt.branch, tt.patterns)
+		if len(got) != len(tt.want) {
+			t.Errorf("BranchVariables(%q) = %v, want %v", tt.branch, got, tt.want)
+			continue
+		}
+		for k, v := range tt.want {
+			if got[k] != v {
+				t.Errorf("BranchVariables(%q)[%s] = %q, want %q", tt.branch, k, got[k], v)
+			}
+		}
+	}
+}
+
+func TestExpander_Expand(t *testing.T) {
+	var asked []string
+	e := &Expander{
+		Snippets:  map[string]string{"ticket": "[{TICKET}] ", "pair": "Co-authored-by: {PAIR}"},
+		Variables: map[string]string{"TICKET": "PROJ-1"},
+		Ask: func(name string) (string, error) {
+			asked = append(asked, name)
+			return "Ann <ann@example.com>", nil
+		},
+	}
+	got, err := e.Expand("{{ticket}}fix login\n\n{{pair}}\n{{pair}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[PROJ-1] fix login\n\nCo-authored-by: Ann <ann@example.com>\nCo-authored-by: Ann <ann@example.com>"
+	if got != want {
+		t.Errorf("Expand() = %q, want %q", got, want)
+	}
+	if len(asked) != 1 || asked[0] != "PAIR" {
+		t.Errorf("asked for %v, want PAIR once", asked)
+	}
+
+	if got, err := e.Expand("handle {config} keys"); err != nil || got != "handle {config} keys" {
+		t.Errorf("lower-case braces should be left alone, got %q, %v", got, err)
+	}
+}
+
+func TestExpander_Errors(t *testing.T) {
+	e := &Expander{Snippets: map[string]string{"wip": "WIP: ", "ticket": "[{TICKET}] "}}
+	if _, err := e.Expand("{{nope}}x"); err == nil || !strings.Contains(err.Error(), "defines ticket, wip") {
+		t.Errorf("unknown snippet error = %v", err)
+	}
+	if _, err := e.Expand("{{ticket}}x"); err == nil || !strings.Contains(err.Error(), "no value for {TICKET}") {
+		t.Errorf("missing variable error = %v", err)
+	}
+
+	canceled := errors.New("canceled")
+	e.Ask = func(string) (string, error) { return "", canceled }
+	if _, err := e.Expand("{{ticket}}x"); !errors.Is(err, canceled) {
+		t.Errorf("Ask error = %v, want %v", err, canceled)
+	}
+}
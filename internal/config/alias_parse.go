@@ -38,6 +38,82 @@ func analyzePlaceholders(commands []string) (map[string]struct{}, int, error) {
 	return placeholders, maxPositionalArg, nil
 }
 
+// aliasChainSeparator joins several commands in a single string alias, e.g.
+// "add . && commit <message> && push current".
+const aliasChainSeparator = "&&"
+
+// splitAliasChain splits a string alias into its chained commands. An &&
+// inside single or double quotes, as in commit -m "a && b", belongs to the
+// argument and does not split; quotes follow the same rules as the command
+// line tokenizer, where a backslash escapes the next character only inside
+// double quotes.
+func splitAliasChain(value string) []string {
+	var parts []string
+	inSingle, inDouble := false, false
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '\'' && !inDouble:
+			inSingle = !inSingle
+		case c == '"' && !inSingle:
+			inDouble = !inDouble
+		case c == '\\' && inDouble:
+			i++
+		case !inSingle && !inDouble && strings.HasPrefix(value[i:], aliasChainSeparator):
+			parts = append(parts, strings.TrimSpace(value[start:i]))
+			i += len(aliasChainSeparator) - 1
+			start = i + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(value[start:]))
+}
+
+// analyzePrompts returns the unique <name> placeholders used by commands in
+// order of first appearance. Unlike {0}-style placeholders they are filled
+// from trailing arguments or prompted for in interactive mode.
+func analyzePrompts(commands []string) ([]string, error) {
+	var prompts []string
+	seen := make(map[string]struct{})
+	for _, cmd := range commands {
+		for _, match := range angleBracketPlaceholderRe.FindAllString(cmd, -1) {
+			name := match[1 : len(match)-1]
+			if err := validatePlaceholder(name); err != nil {
+				return nil, fmt.Errorf("invalid placeholder <%s>: %w", name, err)
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			prompts = append(prompts, name)
+		}
+	}
+	return prompts, nil
+}
+
+// parseAliasCommands builds a ParsedAlias of the given type from commands.
+func parseAliasCommands(name string, aliasType AliasType, commands []string) (*ParsedAlias, error) {
+	kind := "simple"
+	if aliasType == SequenceAlias {
+		kind = "sequence"
+	}
+	placeholders, maxPositionalArg, err := analyzePlaceholders(commands)
+	if err != nil {
+		return nil, fmt.Errorf("error analyzing placeholders in %s alias '%s': %w", kind, name, err)
+	}
+	prompts, err := analyzePrompts(commands)
+	if err != nil {
+		return nil, fmt.Errorf("error analyzing placeholders in %s alias '%s': %w", kind, name, err)
+	}
+
+	return &ParsedAlias{
+		Type:             aliasType,
+		Commands:         commands,
+		Placeholders:     placeholders,
+		Prompts:          prompts,
+		MaxPositionalArg: maxPositionalArg,
+	}, nil
+}
+
 // isValidPlaceholderChar checks if a character is valid in a placeholder
 func isValidPlaceholderChar(char rune) bool {
 	return (char >= 'a' && char <= 'z') ||
@@ -78,17 +154,11 @@ func (c *Config) ParseAlias(name string) (*ParsedAlias, error) {
 
 	switch v := value.(type) {
 	case string:
-		placeholders, maxPositionalArg, err := analyzePlaceholders([]string{v})
-		if err != nil {
-			return nil, fmt.Errorf("error analyzing placeholders in simple alias '%s': %w", name, err)
+		// A string chained with "&&" behaves exactly like a sequence alias.
+		if commands := splitAliasChain(v); len(commands) > 1 {
+			return parseAliasCommands(name, SequenceAlias, commands)
 		}
-
-		return &ParsedAlias{
-			Type:             SimpleAlias,
-			Commands:         []string{v},
-			Placeholders:     placeholders,
-			MaxPositionalArg: maxPositionalArg,
-		}, nil
+		return parseAliasCommands(name, SimpleAlias, []string{v})
 
 	case []interface{}:
 		commands := make([]string, len(v))
@@ -99,18 +169,7 @@ func (c *Config) ParseAlias(name string) (*ParsedAlias, error) {
 			}
 			commands[i] = cmdStr
 		}
-
-		placeholders, maxPositionalArg, err := analyzePlaceholders(commands)
-		if err != nil {
-			return nil, fmt.Errorf("error analyzing placeholders in sequence alias '%s': %w", name, err)
-		}
-
-		return &ParsedAlias{
-			Type:             SequenceAlias,
-			Commands:         commands,
-			Placeholders:     placeholders,
-			MaxPositionalArg: maxPositionalArg,
-		}, nil
+		return parseAliasCommands(name, SequenceAlias, commands)
 
 	default:
 		return nil, fmt.Errorf("invalid alias type for '%s'", name)
@@ -141,12 +141,17 @@ func validateAliasValue(name string, value interface{}) error {
 		if strings.TrimSpace(v) == "" {
 			return &ValidationError{"aliases." + name, v, "alias command cannot be empty"}
 		}
-		// Validate command security for simple string aliases
-		if err := defaultValidator.validateCommand(v); err != nil {
-			return &ValidationError{
-				Field:   "aliases." + name,
-				Value:   v,
-				Message: err.Error(),
+		// Validate command security for each command in a "&&" chain
+		for _, cmd := range splitAliasChain(v) {
+			if cmd == "" {
+				return &ValidationError{"aliases." + name, v, "chained alias command cannot be empty"}
+			}
+			if err := validateAliasCommand(cmd); err != nil {
+				return &ValidationError{
+					Field:   "aliases." + name,
+					Value:   v,
+					Message: err.Error(),
+				}
 			}
 		}
 		return nil
@@ -173,7 +178,7 @@ func validateAliasSequence(name string, seq []interface{}) error {
 		}
 
 		// Validate command security
-		if err := defaultValidator.validateCommand(cmdStr); err != nil {
+		if err := validateAliasCommand(cmdStr); err != nil {
 			return &ValidationError{
 				Field:   fmt.Sprintf("aliases.%s[%d]", name, i),
 				Value:   cmdStr,
@@ -183,3 +188,13 @@ func validateAliasSequence(name string, seq []interface{}) error {
 	}
 	return nil
 }
+
+// validateAliasCommand validates one alias command. <name> placeholders are
+// checked on their own and stripped before the security check, mirroring
+// workflow steps.
+func validateAliasCommand(cmd string) error {
+	if _, err := analyzePrompts([]string{cmd}); err != nil {
+		return err
+	}
+	return defaultValidator.validateCommand(angleBracketPlaceholderRe.ReplaceAllString(cmd, ""))
+}
@@ -157,6 +157,11 @@ func TestValidateAliasValue(t *testing.T) {
 		{name: "empty string", aliasName: "test", value: "", wantError: true},
 		{name: "whitespace only", aliasName: "test", value: "   ", wantError: true},
 		{name: "valid sequence", aliasName: "test", value: []interface{}{"status", "branch"}, wantError: false},
+		{name: "valid chain", aliasName: "test", value: "add . && commit <message> && push current", wantError: false},
+		{name: "chain with empty command", aliasName: "test", value: "add . && && push", wantError: true},
+		{name: "chain with invalid command", aliasName: "test", value: "add . && notacommand", wantError: true},
+		{name: "chain with single ampersand", aliasName: "test", value: "add . & push", wantError: true},
+		{name: "unsafe prompt name", aliasName: "test", value: "commit <a$b>", wantError: true},
 		{name: "invalid type", aliasName: "test", value: 123, wantError: true},
 		{name: "invalid type map", aliasName: "test", value: map[string]string{"key": "value"}, wantError: true},
 	}
@@ -19,80 +19,320 @@ var (
 // Config represents the complete configuration structure
 type Config struct {
 	Meta struct {
-		Version       string `yaml:"version"`
-		Commit        string `yaml:"commit"`
-		CreatedAt     string `yaml:"created-at"`
-		ConfigVersion string `yaml:"config-version"`
+		Version       string `yaml:"version" desc:"ggc version that last wrote the file"`
+		Commit        string `yaml:"commit" desc:"ggc commit that last wrote the file"`
+		CreatedAt     string `yaml:"created-at" desc:"When the file was created"`
+		ConfigVersion string `yaml:"config-version" desc:"Configuration format version"`
 	} `yaml:"meta"`
 
 	Default struct {
-		Branch    string `yaml:"branch"`
-		Editor    string `yaml:"editor"`
-		MergeTool string `yaml:"merge-tool"`
+		Branch    string `yaml:"branch" desc:"Default branch for new repositories and comparisons"`
+		Editor    string `yaml:"editor" desc:"Editor for commit messages, hooks and rebase todos"`
+		MergeTool string `yaml:"merge-tool" desc:"Tool that resolves merge conflicts"`
 	} `yaml:"default"`
 
 	UI struct {
-		Color bool `yaml:"color"`
-		Pager bool `yaml:"pager"`
+		Color    bool   `yaml:"color" desc:"Use colors in output"`
+		Pager    bool   `yaml:"pager" desc:"Page long output"`
+		DiffTool string `yaml:"diff-tool,omitempty" desc:"External diff highlighter such as delta, with optional arguments"`
+		// Accessible makes interactive mode announce changes in plain
+		// lines, for screen readers and dumb terminals.
+		Accessible bool `yaml:"accessible,omitempty" desc:"Screen-reader friendly interactive mode without decoration"`
 	} `yaml:"ui"`
 
 	Interactive struct {
-		Profile string `yaml:"profile,omitempty"`
+		Profile string `yaml:"profile,omitempty" desc:"Keybinding profile" enum:"default|emacs|vi|readline"`
+		// Frecency is a pointer so an absent field falls back to the
+		// built-in default (enabled). Setting it to false keeps the
+		// command list in registry order and stops recording picks.
+		Frecency *bool `yaml:"frecency,omitempty" desc:"Rank commands by how often and how recently they are picked"`
+		// Pinned commands lead the interactive list in their own section,
+		// and Hidden ones are left out of it. Both hold interactive
+		// command names such as "push current".
+		Pinned   []string         `yaml:"pinned,omitempty" desc:"Commands listed first in interactive mode"`
+		Hidden   []string         `yaml:"hidden,omitempty" desc:"Commands left out of interactive mode"`
+		Sections []PaletteSection `yaml:"sections,omitempty" desc:"Named groups of commands in interactive mode"`
+		// ChordTimeout is how long a multi-key binding such as "C-x C-w"
+		// waits for its next key, as a Go duration. Empty means 1s.
+		ChordTimeout string `yaml:"chord-timeout,omitempty" desc:"How long a multi-key binding waits for its next key (Go duration, default 1s)"`
+		// EscapeTimeout is how long ESC waits for the rest of a key
+		// sequence before it counts as a key press of its own, as a Go
+		// duration. Empty means 50ms inside tmux or screen and 0 elsewhere.
+		EscapeTimeout string `yaml:"escape-timeout,omitempty" desc:"How long ESC waits for the rest of a key sequence (Go duration, default 50ms in tmux and screen, 0 elsewhere)"`
+		// StatusRefresh is how often the header's git status reloads in
+		// the background, as a Go duration. Empty means 10s; 0 reloads
+		// only when the UI starts and after commands run.
+		StatusRefresh string `yaml:"status-refresh,omitempty" desc:"How often the header git status reloads (Go duration, default 10s, 0 disables)"`
 
 		Keybindings struct {
-			DeleteWord         string `yaml:"delete_word"`
-			ClearLine          string `yaml:"clear_line"`
-			DeleteToEnd        string `yaml:"delete_to_end"`
-			MoveToBeginning    string `yaml:"move_to_beginning"`
-			MoveToEnd          string `yaml:"move_to_end"`
-			MoveUp             string `yaml:"move_up"`
-			MoveDown           string `yaml:"move_down"`
-			MoveLeft           string `yaml:"move_left"`
-			MoveRight          string `yaml:"move_right"`
-			AddToWorkflow      string `yaml:"add_to_workflow"`
-			ToggleWorkflowView string `yaml:"toggle_workflow_view"`
-			ClearWorkflow      string `yaml:"clear_workflow"`
-			WorkflowCreate     string `yaml:"workflow_create"`
-			WorkflowDelete     string `yaml:"workflow_delete"`
-			SoftCancel         string `yaml:"soft_cancel"`
+			DeleteWord         string `yaml:"delete_word" desc:"Key that deletes the word before the cursor"`
+			ClearLine          string `yaml:"clear_line" desc:"Key that clears the input line"`
+			DeleteToEnd        string `yaml:"delete_to_end" desc:"Key that deletes to the end of the line"`
+			MoveToBeginning    string `yaml:"move_to_beginning" desc:"Key that moves to the start of the line"`
+			MoveToEnd          string `yaml:"move_to_end" desc:"Key that moves to the end of the line"`
+			MoveUp             string `yaml:"move_up" desc:"Key that moves the selection up"`
+			MoveDown           string `yaml:"move_down" desc:"Key that moves the selection down"`
+			MoveLeft           string `yaml:"move_left" desc:"Key that moves the cursor left"`
+			MoveRight          string `yaml:"move_right" desc:"Key that moves the cursor right"`
+			AddToWorkflow      string `yaml:"add_to_workflow" desc:"Key that adds the selected command to the workflow"`
+			ToggleWorkflowView string `yaml:"toggle_workflow_view" desc:"Key that shows or hides the workflow"`
+			ClearWorkflow      string `yaml:"clear_workflow" desc:"Key that empties the workflow"`
+			WorkflowCreate     string `yaml:"workflow_create" desc:"Key that creates a workflow"`
+			WorkflowDelete     string `yaml:"workflow_delete" desc:"Key that deletes a workflow"`
+			SoftCancel         string `yaml:"soft_cancel" desc:"Key that cancels the current action without quitting"`
 		} `yaml:"keybindings"`
 
 		Contexts struct {
-			Input   KeybindingsConfig `yaml:"input,omitempty"`
-			Results KeybindingsConfig `yaml:"results,omitempty"`
-			Search  KeybindingsConfig `yaml:"search,omitempty"`
+			Input   KeybindingsConfig `yaml:"input,omitempty" desc:"Keybindings while typing a command"`
+			Results KeybindingsConfig `yaml:"results,omitempty" desc:"Keybindings in result lists"`
+			Search  KeybindingsConfig `yaml:"search,omitempty" desc:"Keybindings while searching"`
 		} `yaml:"contexts,omitempty"`
 
-		Darwin  KeybindingsConfig `yaml:"darwin,omitempty"`
-		Linux   KeybindingsConfig `yaml:"linux,omitempty"`
-		Windows KeybindingsConfig `yaml:"windows,omitempty"`
+		Darwin  KeybindingsConfig `yaml:"darwin,omitempty" desc:"Keybindings used on macOS"`
+		Linux   KeybindingsConfig `yaml:"linux,omitempty" desc:"Keybindings used on Linux"`
+		Windows KeybindingsConfig `yaml:"windows,omitempty" desc:"Keybindings used on Windows"`
 
-		Terminals map[string]KeybindingsConfig `yaml:"terminals,omitempty"`
+		Terminals map[string]KeybindingsConfig `yaml:"terminals,omitempty" desc:"Keybindings per terminal, keyed by TERM_PROGRAM or TERM"`
 	} `yaml:"interactive"`
 
 	Behavior struct {
-		AutoPush           bool   `yaml:"auto-push"`
-		ConfirmDestructive string `yaml:"confirm-destructive"`
-		AutoFetch          bool   `yaml:"auto-fetch"`
-		StashBeforeSwitch  bool   `yaml:"stash-before-switch"`
+		AutoPush           bool   `yaml:"auto-push" desc:"Push after each commit"`
+		ConfirmDestructive string `yaml:"confirm-destructive" desc:"How destructive commands ask for confirmation" enum:"simple|always|never"`
+		AutoFetch          bool   `yaml:"auto-fetch" desc:"Fetch before comparing with the remote"`
+		// Autostash says what switch, rebase, pull and stack restack do
+		// with uncommitted changes: ask (the default) offers to stash them
+		// on a terminal, always stashes them without asking and never
+		// leaves them to git. It replaces stash-before-switch, which is
+		// migrated on load.
+		Autostash string `yaml:"autostash,omitempty" desc:"What switch, rebase, pull and stack restack do with uncommitted changes" enum:"ask|always|never"`
+		// UpdateCheck opts in to a once-a-day check for a newer ggc
+		// release. GGC_NO_UPDATE_CHECK turns it off again.
+		UpdateCheck bool `yaml:"update-check,omitempty" desc:"Check once a day for a newer ggc release and say so"`
+		// Abbreviations lets unambiguous prefixes stand for command and
+		// subcommand names, so that ggc br li runs ggc branch list.
+		Abbreviations bool `yaml:"abbreviations,omitempty" desc:"Accept unambiguous prefixes of command names, such as ggc br li"`
 	} `yaml:"behavior"`
 
-	Aliases   map[string]interface{} `yaml:"aliases"`
-	Workflows map[string][]string    `yaml:"workflows,omitempty"`
+	Switch struct {
+		// Recent shapes the list of `ggc switch recent`, which is read
+		// from HEAD's reflog.
+		Recent struct {
+			// Limit caps the number of branches listed. Zero or
+			// negative values keep the built-in default.
+			Limit int `yaml:"limit,omitempty" desc:"Branches listed by ggc switch recent; 0 keeps the default (10)"`
+			// Exclude are branch globs, such as main or tmp/*, left out
+			// of the list.
+			Exclude []string `yaml:"exclude,omitempty" desc:"Branch globs ggc switch recent leaves out"`
+		} `yaml:"recent,omitempty"`
+	} `yaml:"switch,omitempty"`
+
+	// Sync shapes ggc sync, which fetches, takes in the upstream and
+	// pushes the current branch in one go.
+	Sync struct {
+		Strategy  string `yaml:"strategy" desc:"How ggc sync takes in the upstream" enum:"rebase|merge"`
+		Prune     bool   `yaml:"prune" desc:"Prune deleted remote branches when ggc sync fetches"`
+		Autostash bool   `yaml:"autostash" desc:"Stash uncommitted changes while ggc sync runs"`
+		Push      bool   `yaml:"push" desc:"Push the branch once ggc sync has taken in the upstream"`
+	} `yaml:"sync"`
+
+	// Push shapes ggc push force.
+	Push struct {
+		// ForceWithLease makes ggc push force refuse to overwrite remote
+		// commits it has not fetched. False force-pushes unconditionally.
+		ForceWithLease bool `yaml:"force-with-lease" desc:"Force-push with --force-with-lease rather than --force"`
+	} `yaml:"push"`
+
+	// Release shapes ggc release, which bumps the version, tags it and
+	// pushes the tag.
+	Release struct {
+		TagPrefix   string `yaml:"tag-prefix" desc:"Prefix of release tags, such as v in v1.2.0"`
+		VersionFile string `yaml:"version-file,omitempty" desc:"File holding the version, which ggc release updates and commits"`
+		Push        bool   `yaml:"push" desc:"Push the release commit and tag"`
+		Publish     bool   `yaml:"publish" desc:"Publish a release on GitHub, GitLab or Gitea with the integration token"`
+	} `yaml:"release"`
+
+	Aliases   map[string]interface{}    `yaml:"aliases" desc:"Command shortcuts: a command string or a list run in order"`
+	Workflows map[string][]WorkflowStep `yaml:"workflows,omitempty" desc:"Saved interactive workflows"`
 
 	Git struct {
-		DefaultRemote string `yaml:"default-remote"`
+		DefaultRemote string `yaml:"default-remote" desc:"Remote used when none is given"`
 	} `yaml:"git"`
 
+	Core struct {
+		// FastRefs answers branch and upstream queries by reading .git
+		// directly instead of starting git.
+		FastRefs bool `yaml:"fast-refs,omitempty" desc:"Read branches and upstreams straight from .git instead of running git"`
+		// DefaultPathspec limits status, diff, add, log and clean to a
+		// directory, relative to the repository root, as ggc --path does.
+		// It is mostly set in a monorepo's .ggc.yaml.
+		DefaultPathspec string `yaml:"default-pathspec,omitempty" desc:"Directory, relative to the repository root, that status, diff, add, log and clean are limited to"`
+	} `yaml:"core,omitempty"`
+
+	Clone struct {
+		// DefaultHost and Protocol expand an "owner/repo" shorthand, so
+		// `ggc clone bmf-san/ggc` clones https://github.com/bmf-san/ggc.git.
+		// They default to github.com and https; protocol may also be ssh.
+		DefaultHost string `yaml:"default-host,omitempty" desc:"Host for owner/repo clone shorthands (default github.com)"`
+		Protocol    string `yaml:"protocol,omitempty" desc:"Protocol for clone shorthands" enum:"https|ssh"`
+		// UserName and UserEmail, when set, become user.name and
+		// user.email of every new clone.
+		UserName  string `yaml:"user-name,omitempty" desc:"user.name set in every new clone"`
+		UserEmail string `yaml:"user-email,omitempty" desc:"user.email set in every new clone"`
+		// Profile names the profile applied to every new clone. Empty
+		// picks the profile whose paths match the clone's directory.
+		Profile string `yaml:"profile,omitempty" desc:"Profile applied to every new clone"`
+		// PostClone commands run through the shell inside each new clone,
+		// in order, after the identity is set.
+		PostClone []string `yaml:"post-clone,omitempty" desc:"Shell commands run inside every new clone"`
+	} `yaml:"clone,omitempty"`
+
 	History struct {
 		// Enabled is a pointer so an absent field falls back to the
 		// built-in default (enabled). Setting it to false disables
 		// every history write without otherwise touching reads.
-		Enabled *bool `yaml:"enabled,omitempty"`
+		Enabled *bool `yaml:"enabled,omitempty" desc:"Record command history"`
 		// MaxEntries caps the number of stored history entries. Zero
 		// or negative values keep the built-in default.
-		MaxEntries int `yaml:"max-entries,omitempty"`
+		MaxEntries int `yaml:"max-entries,omitempty" desc:"Maximum history entries kept; 0 keeps the default"`
 	} `yaml:"history,omitempty"`
+
+	Stats struct {
+		// Enabled is a pointer so an absent field falls back to the
+		// built-in default (enabled). Setting it to false stops
+		// recording; ggc stats still shows what was recorded before.
+		Enabled *bool `yaml:"enabled,omitempty" desc:"Record local usage statistics for ggc stats"`
+	} `yaml:"stats,omitempty"`
+
+	Commit struct {
+		// SubjectMaxLength and BodyMaxLength make the commit composer
+		// warn about longer lines. Zero disables the warning.
+		SubjectMaxLength int `yaml:"subject-max-length" desc:"Warn about longer commit subjects; 0 disables"`
+		BodyMaxLength    int `yaml:"body-max-length" desc:"Warn about longer commit body lines; 0 disables"`
+		// Conventional makes the composer ask for a Conventional Commits
+		// type and scope before the subject.
+		Conventional bool `yaml:"conventional" desc:"Ask for a Conventional Commits type and scope"`
+		// Types overrides the offered commit types; empty keeps the
+		// Conventional Commits defaults. Scopes, when set, turns the
+		// scope prompt into a picker.
+		Types  []string `yaml:"types,omitempty" desc:"Commit types offered by the composer"`
+		Scopes []string `yaml:"scopes,omitempty" desc:"Commit scopes offered by the composer"`
+	} `yaml:"commit"`
+
+	// Snippets are reusable pieces of text for commit messages.
+	Snippets struct {
+		// Commit snippets are inserted into commit messages with {{name}}.
+		// {NAME} placeholders, in a snippet or in the message, are filled
+		// from the branch name or asked for.
+		Commit map[string]string `yaml:"commit,omitempty" desc:"Commit message snippets, inserted with {{name}}"`
+		// Variables are placeholders taken from the current branch name:
+		// each maps a name to a regular expression whose first group, or
+		// else whole match, is the value.
+		Variables map[string]string `yaml:"variables,omitempty" desc:"Placeholders filled from the branch name by regular expression"`
+	} `yaml:"snippets,omitempty"`
+
+	// Tickets find issue-tracker references in branch names.
+	Tickets struct {
+		// Rules are tried in order; without any, a Jira-style key such as
+		// PROJ-123 is looked for.
+		Rules []TicketRule `yaml:"rules,omitempty" desc:"How ticket IDs appear in branch names, tried in order"`
+		// Commit and PRTitle add the reference of the current branch to
+		// commit messages and pull request titles, before or after the
+		// text. Empty leaves them alone.
+		Commit  string `yaml:"commit,omitempty" desc:"Add the branch's ticket to commit messages" enum:"prefix|suffix"`
+		PRTitle string `yaml:"pr-title,omitempty" desc:"Add the branch's ticket to pull request titles" enum:"prefix|suffix"`
+	} `yaml:"tickets,omitempty"`
+
+	// Safety guards branches against destructive commands and pushes
+	// against leaked secrets.
+	Safety struct {
+		// ProtectedBranches are branch globs, such as main or release/*,
+		// that force pushes, hard resets, deletes and rebases refuse to
+		// change without confirmation or --force-unsafe.
+		ProtectedBranches []string `yaml:"protected-branches,omitempty" desc:"Branch globs that destructive commands refuse to change without confirmation"`
+		// ScanSecrets makes every command that pushes look for credentials
+		// in the commits it sends and stop when it finds any, as --scan
+		// does for one push.
+		ScanSecrets bool `yaml:"scan-secrets,omitempty" desc:"Scan outgoing commits for credentials before every push"`
+		// SecretRules add regular expressions to the secret scan, keyed by
+		// rule name. A built-in rule's name replaces its pattern, or turns
+		// it off when the pattern is empty.
+		SecretRules map[string]string `yaml:"secret-rules,omitempty" desc:"Extra or replaced secret scan patterns, keyed by rule name"`
+	} `yaml:"safety,omitempty"`
+
+	// Snapshot shapes ggc snapshot, which saves the working tree under
+	// refs/ggc/snapshots without touching the index or HEAD.
+	Snapshot struct {
+		// Keep caps the number of snapshots; saving one drops the oldest
+		// beyond it. Zero keeps the built-in default.
+		Keep int `yaml:"keep,omitempty" desc:"Snapshots kept before the oldest are dropped; 0 keeps the default (20)"`
+		// MaxAge drops snapshots older than it, as a Go duration such as
+		// 720h. Empty keeps them until Keep drops them.
+		MaxAge string `yaml:"max-age,omitempty" desc:"Drop snapshots older than this (Go duration such as 720h)"`
+	} `yaml:"snapshot,omitempty"`
+
+	// Notes shapes ggc notes and where the log viewer looks for notes.
+	Notes struct {
+		// Ref is the notes ref, such as review for refs/notes/review.
+		// Empty means git's default, refs/notes/commits.
+		Ref string `yaml:"ref,omitempty" desc:"Notes ref ggc notes and the log viewer use; empty means commits"`
+		// AutoFetch makes ggc fetch fetch and merge the remote's notes
+		// after the branches.
+		AutoFetch bool `yaml:"auto-fetch,omitempty" desc:"Fetch and merge the remote's notes with every ggc fetch"`
+	} `yaml:"notes,omitempty"`
+
+	// Repos are the checkouts ggc repos runs commands across.
+	Repos struct {
+		// Paths are repositories added with ggc repos add.
+		Paths []string `yaml:"paths,omitempty" desc:"Repositories ggc repos runs commands in"`
+		// Roots are directories whose repositories, up to three levels
+		// down, are included as well.
+		Roots []string `yaml:"roots,omitempty" desc:"Directories searched for repositories for ggc repos"`
+		// Parallel caps how many repositories run at once. Zero keeps
+		// the built-in default.
+		Parallel int `yaml:"parallel,omitempty" desc:"Repositories ggc repos runs at once; 0 keeps the default (4)"`
+	} `yaml:"repos,omitempty"`
+
+	// SSH shapes how ggc connects to SSH remotes.
+	SSH struct {
+		// Preflight makes push, fetch, pull and sync check, before git
+		// connects to an SSH remote, that ssh has a usable key for the
+		// host, and stop with the fix when it does not.
+		Preflight bool `yaml:"preflight,omitempty" desc:"Check the SSH agent and key for the remote's host before push, fetch, pull and sync"`
+	} `yaml:"ssh,omitempty"`
+
+	// Profiles are named identities keyed by profile name.
+	Profiles map[string]Profile `yaml:"profiles,omitempty" desc:"Named identities for ggc profile"`
+
+	Integration struct {
+		GitHub struct {
+			// Token is a personal access token for the GitHub API. When
+			// empty, GITHUB_TOKEN and GH_TOKEN are tried before the
+			// device flow.
+			Token string `yaml:"token,omitempty" desc:"GitHub API token; GITHUB_TOKEN and GH_TOKEN are tried when empty"`
+			// ClientID is the OAuth app used for device-flow sign-in.
+			ClientID string `yaml:"client-id,omitempty" desc:"OAuth app for GitHub device-flow sign-in"`
+			// APIURL points at a GitHub Enterprise API; empty means
+			// https://api.github.com.
+			APIURL string `yaml:"api-url,omitempty" desc:"GitHub Enterprise API URL"`
+		} `yaml:"github,omitempty"`
+		// GitLab and Gitea take a personal access token and, for
+		// self-hosted instances, the API URL, whose host also tells ggc
+		// which service a remote on that host runs.
+		GitLab struct {
+			Token  string `yaml:"token,omitempty" desc:"GitLab API token"`
+			APIURL string `yaml:"api-url,omitempty" desc:"Self-hosted GitLab API URL"`
+		} `yaml:"gitlab,omitempty"`
+		Gitea struct {
+			Token  string `yaml:"token,omitempty" desc:"Gitea API token"`
+			APIURL string `yaml:"api-url,omitempty" desc:"Gitea API URL"`
+		} `yaml:"gitea,omitempty"`
+		// HTTPProxy, CABundle and InsecureSkipVerify apply to every
+		// hosting API and to the update check, for networks that reach
+		// the internet through a proxy or inspect TLS.
+		HTTPProxy          string `yaml:"http-proxy,omitempty" desc:"Proxy URL for hosting APIs and the update check; HTTPS_PROXY and NO_PROXY apply when empty"`
+		CABundle           string `yaml:"ca-bundle,omitempty" desc:"PEM file of extra certificate authorities to trust"`
+		InsecureSkipVerify bool   `yaml:"insecure-skip-verify,omitempty" desc:"Skip TLS certificate verification (unsafe)"`
+	} `yaml:"integration,omitempty"`
 }
 
 // Manager handles configuration loading, saving, and operations
@@ -100,6 +340,7 @@ type Manager struct {
 	config     *Config
 	configPath string
 	gitClient  git.ConfigOps
+	repo       *repoOverlay
 }
 
 // NewConfigManager creates a new configuration manager with the provided git client
@@ -132,10 +373,20 @@ func getDefaultConfig(gitClient git.ConfigOps) *Config {
 	config.Behavior.AutoPush = false
 	config.Behavior.ConfirmDestructive = "simple"
 	config.Behavior.AutoFetch = true
-	config.Behavior.StashBeforeSwitch = true
 
 	config.Git.DefaultRemote = "origin"
 
+	config.Sync.Strategy = "rebase"
+	config.Sync.Prune = true
+	config.Sync.Autostash = true
+	config.Sync.Push = true
+	config.Push.ForceWithLease = true
+	config.Release.TagPrefix = "v"
+	config.Release.Push = true
+
+	config.Commit.SubjectMaxLength = 72
+	config.Commit.BodyMaxLength = 72
+
 	// Set meta values using gitClient
 	if version, err := gitClient.GetVersion(); err == nil {
 		config.Meta.Version = version
@@ -153,8 +153,8 @@ func TestGetDefaultConfig(t *testing.T) {
 	if !config.Behavior.AutoFetch {
 		t.Error("Expected auto-fetch to be true")
 	}
-	if !config.Behavior.StashBeforeSwitch {
-		t.Error("Expected stash-before-switch to be true")
+	if config.Behavior.Autostash != "" {
+		t.Errorf("Expected autostash to be unset (ask), got %s", config.Behavior.Autostash)
 	}
 
 	if config.Git.DefaultRemote != "origin" {
@@ -277,6 +277,10 @@ git:
 	if cm.config.Git.DefaultRemote != "upstream" {
 		t.Errorf("Expected git default remote to be 'upstream', got %s", cm.config.Git.DefaultRemote)
 	}
+	// The retired stash-before-switch: false carries over to autostash.
+	if cm.config.Behavior.Autostash != "never" {
+		t.Errorf("Expected stash-before-switch: false to set autostash to 'never', got %q", cm.config.Behavior.Autostash)
+	}
 }
 
 // TestLoad tests the Load method with no config file
@@ -775,7 +779,7 @@ func TestConfig_Validate(t *testing.T) {
 		cfg.Behavior.AutoPush = true
 		cfg.Behavior.ConfirmDestructive = "simple"
 		cfg.Behavior.AutoFetch = true
-		cfg.Behavior.StashBeforeSwitch = true
+		cfg.Behavior.Autostash = "ask"
 		cfg.Aliases = map[string]any{"st": "status"}
 		cfg.Git.DefaultRemote = "origin"
 
@@ -850,6 +854,382 @@ func TestConfig_Validate(t *testing.T) {
 		}
 	})
 
+	t.Run("Invalid commit type", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Commit.Types = []string{"feat", "fix(ui)"}
+
+		err := cfg.Validate()
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "commit.types[1]") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid snippets", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			snippets  map[string]string
+			variables map[string]string
+			field     string
+		}{
+			{"snippet name", map[string]string{"my ticket": "x"}, nil, "snippets.commit.my ticket"},
+			{"variable name", nil, map[string]string{"ticket": `\d+`}, "snippets.variables.ticket"},
+			{"pattern", nil, map[string]string{"TICKET": `(`}, "snippets.variables.TICKET"},
+		}
+		for _, tt := range tests {
+			cfg := &Config{}
+			cfg.Default.Branch = "main"
+			cfg.Default.Editor = "vim"
+			cfg.Behavior.ConfirmDestructive = "never"
+			cfg.Snippets.Commit = tt.snippets
+			cfg.Snippets.Variables = tt.variables
+
+			if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+		}
+	})
+
+	t.Run("Invalid secret rule", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Safety.SecretRules = map[string]string{"internal-token": `itk_(`}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "safety.secret-rules.internal-token") {
+			t.Errorf("unexpected error: %v", err)
+		}
+		cfg.Safety.SecretRules = map[string]string{"internal-token": `\bitk_[a-z0-9]{32}\b`, "generic-secret": ""}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid snapshot policy", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Snapshot.MaxAge = "30d"
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "snapshot.max-age") {
+			t.Errorf("unexpected error: %v", err)
+		}
+		cfg.Snapshot.MaxAge = "720h"
+		cfg.Snapshot.Keep = -1
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "snapshot.keep") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid repos parallel", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Repos.Parallel = -1
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "repos.parallel") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid notes ref", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		for _, ref := range []string{"review", "refs/notes/review", "team/review"} {
+			cfg.Notes.Ref = ref
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("notes.ref %q: unexpected error: %v", ref, err)
+			}
+		}
+		for _, ref := range []string{"code review", "refs/notes/", "a..b", "review.lock"} {
+			cfg.Notes.Ref = ref
+			if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "notes.ref") {
+				t.Errorf("notes.ref %q: unexpected error: %v", ref, err)
+			}
+		}
+	})
+
+	t.Run("Invalid autostash", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Behavior.Autostash = "sometimes"
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "behavior.autostash") {
+			t.Errorf("unexpected error: %v", err)
+		}
+		cfg.Behavior.Autostash = "always"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid tickets", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Tickets.Rules = []TicketRule{{Pattern: `[A-Z]+-\d+`, Format: "#ID"}}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "tickets.rules[0].format") {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		cfg.Tickets.Rules = nil
+		cfg.Tickets.PRTitle = "before"
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "tickets.pr-title") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Negative commit subject length", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Commit.SubjectMaxLength = -1
+
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "commit.subject-max-length") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid recent branch settings", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Switch.Recent.Limit = -1
+
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "switch.recent.limit") {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		cfg.Switch.Recent.Limit = 5
+		cfg.Switch.Recent.Exclude = []string{"tmp/["}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "switch.recent.exclude") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid sync strategy", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Sync.Strategy = "squash"
+
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "sync.strategy") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid release tag prefix", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Release.TagPrefix = "release v"
+
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "release.tag-prefix") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid hosting API URL", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Integration.GitHub.APIURL = "ftp://github.example.com"
+
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "integration.github.api-url") {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		cfg.Integration.GitHub.APIURL = "https://github.example.com/api/v3"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("unexpected error for enterprise URL: %v", err)
+		}
+
+		cfg.Integration.GitLab.APIURL = "gitlab.example.com"
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "integration.gitlab.api-url") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid HTTP proxy", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		for _, proxy := range []string{"http://proxy.corp.example:3128", "socks5://127.0.0.1:1080"} {
+			cfg.Integration.HTTPProxy = proxy
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("integration.http-proxy %q: unexpected error: %v", proxy, err)
+			}
+		}
+		for _, proxy := range []string{"proxy.corp.example:3128", "ftp://proxy.corp.example"} {
+			cfg.Integration.HTTPProxy = proxy
+			if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "integration.http-proxy") {
+				t.Errorf("integration.http-proxy %q: unexpected error: %v", proxy, err)
+			}
+		}
+	})
+
+	t.Run("Invalid palette section", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Interactive.Sections = []PaletteSection{{Name: "Daily", Commands: []string{"status"}}, {Name: " "}}
+
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "interactive.sections[1].name") {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		cfg.Interactive.Sections[1].Name = "Release"
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "interactive.sections[1].commands") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid chord timeout", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Interactive.ChordTimeout = "soon"
+
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "interactive.chord-timeout") {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		cfg.Interactive.ChordTimeout = "750ms"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid escape timeout", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Interactive.EscapeTimeout = "-1s"
+
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "interactive.escape-timeout") {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		cfg.Interactive.EscapeTimeout = "0"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Default pathspec outside the repository", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+
+		for _, p := range []string{"../other", "/abs/dir", "apps/../.."} {
+			cfg.Core.DefaultPathspec = p
+			if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "core.default-pathspec") {
+				t.Errorf("%q: unexpected error: %v", p, err)
+			}
+		}
+		cfg.Core.DefaultPathspec = "apps/web/"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid clone settings", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Clone.Protocol = "git"
+
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "clone.protocol") {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		cfg.Clone.Protocol = "ssh"
+		cfg.Clone.DefaultHost = "https://gitlab.com"
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "clone.default-host") {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		cfg.Clone.DefaultHost = "gitlab.example.com"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		cfg.Clone.Profile = "work"
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "clone.profile") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid profiles", func(t *testing.T) {
+		tests := []struct {
+			profile Profile
+			field   string
+		}{
+			{Profile{Email: "jane@example.com"}, "profiles.work.name"},
+			{Profile{Name: "Jane", Email: "jane"}, "profiles.work.email"},
+			{Profile{Name: "Jane", Email: "jane@example.com", SigningFormat: "x509"}, "profiles.work.signing-format"},
+			{Profile{Name: "Jane", Email: "jane@example.com", Sign: true}, "profiles.work.sign"},
+			{Profile{Name: "Jane", Email: "jane@example.com", Paths: []string{"~/work/["}}, "profiles.work.paths"},
+		}
+		for _, tt := range tests {
+			cfg := &Config{}
+			cfg.Default.Branch = "main"
+			cfg.Default.Editor = "vim"
+			cfg.Behavior.ConfirmDestructive = "never"
+			cfg.Profiles = map[string]Profile{"work": tt.profile}
+			if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("Validate(%+v) = %v, want an error for %s", tt.profile, err, tt.field)
+			}
+		}
+
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Profiles = map[string]Profile{"work": {Name: "Jane", Email: "jane@example.com", SigningKey: "ABCD", Sign: true}}
+		cfg.Clone.Profile = "work"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Invalid status refresh", func(t *testing.T) {
+		cfg := &Config{}
+		cfg.Default.Branch = "main"
+		cfg.Default.Editor = "vim"
+		cfg.Behavior.ConfirmDestructive = "never"
+		cfg.Interactive.StatusRefresh = "-5s"
+
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "interactive.status-refresh") {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		cfg.Interactive.StatusRefresh = "0"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
 	t.Run("Invalid interactive profile", func(t *testing.T) {
 		cfg := &Config{}
 		cfg.Default.Branch = "main"
@@ -875,6 +1255,8 @@ func TestConfig_ParseAlias(t *testing.T) {
 			"sync":       []interface{}{"pull", "add", "commit", "push"},
 			"deploy":     []interface{}{"branch checkout {0}", "push {0}"},
 			"deploy-msg": "commit -m '{0}'",
+			"cmp":        "add . && commit <message> && push current",
+			"cm-quoted":  `commit -m "a && b" && push current`,
 		},
 	}
 
@@ -932,6 +1314,24 @@ func TestConfig_ParseAlias(t *testing.T) {
 			wantPlaceholderCount: 1,
 			wantError:            false,
 		},
+		{
+			name:                 "chained string alias",
+			aliasName:            "cmp",
+			wantType:             SequenceAlias,
+			wantCommands:         []string{"add .", "commit <message>", "push current"},
+			wantMaxPositionalArg: -1,
+			wantPlaceholderCount: 0,
+			wantError:            false,
+		},
+		{
+			name:                 "chained string alias with && in quotes",
+			aliasName:            "cm-quoted",
+			wantType:             SequenceAlias,
+			wantCommands:         []string{`commit -m "a && b"`, "push current"},
+			wantMaxPositionalArg: -1,
+			wantPlaceholderCount: 0,
+			wantError:            false,
+		},
 		{
 			name:                 "non-existent alias",
 			aliasName:            "nonexistent",
@@ -984,6 +1384,26 @@ func TestConfig_ParseAlias(t *testing.T) {
 	}
 }
 
+func TestSplitAliasChain(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"status", []string{"status"}},
+		{"add . && commit <message>&&push current", []string{"add .", "commit <message>", "push current"}},
+		{`commit -m "a && b"`, []string{`commit -m "a && b"`}},
+		{`commit -m 'a && b' && push current`, []string{`commit -m 'a && b'`, "push current"}},
+		{`commit -m "say \"x && y\"" && status`, []string{`commit -m "say \"x && y\""`, "status"}},
+		{`commit -m "it's && fine"`, []string{`commit -m "it's && fine"`}},
+		{"add . && && push", []string{"add .", "", "push"}},
+	}
+	for _, tt := range tests {
+		if got := splitAliasChain(tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitAliasChain(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
 func TestAnalyzePlaceholders(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -1178,6 +1598,30 @@ func TestValidatePlaceholder(t *testing.T) {
 	}
 }
 
+func TestConfig_ParseAliasPrompts(t *testing.T) {
+	config := &Config{
+		Aliases: map[string]interface{}{
+			"feat": []interface{}{"branch create <name>", "commit <message>", "push <name>"},
+			"bad":  "commit <mess;age>",
+		},
+	}
+
+	alias, err := config.ParseAlias("feat")
+	if err != nil {
+		t.Fatalf("ParseAlias() unexpected error = %v", err)
+	}
+	if want := []string{"name", "message"}; !reflect.DeepEqual(alias.Prompts, want) {
+		t.Errorf("ParseAlias() prompts = %v, want %v", alias.Prompts, want)
+	}
+	if !alias.HasPlaceholders() {
+		t.Error("HasPlaceholders() = false, want true")
+	}
+
+	if _, err := config.ParseAlias("bad"); err == nil {
+		t.Error("ParseAlias() expected error for unsafe prompt name")
+	}
+}
+
 func TestConfig_ParseAliasPlaceholderEdgeCases(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -2395,3 +2839,22 @@ func TestReplaceConfigFileWithOps_RenameError(t *testing.T) {
 		t.Error("expected error when rename fails")
 	}
 }
+
+func TestMigrateStashBeforeSwitch(t *testing.T) {
+	tests := []struct {
+		yaml, autostash, want string
+	}{
+		{"behavior:\n  stash-before-switch: false\n", "", "never"},
+		{"behavior:\n  stash-before-switch: true\n", "", ""},
+		{"behavior:\n  stash-before-switch: false\n  autostash: always\n", "always", "always"},
+		{"behavior:\n  auto-fetch: true\n", "", ""},
+	}
+	for _, tt := range tests {
+		cfg := &Config{}
+		cfg.Behavior.Autostash = tt.autostash
+		migrateStashBeforeSwitch([]byte(tt.yaml), cfg)
+		if cfg.Behavior.Autostash != tt.want {
+			t.Errorf("%q: autostash = %q, want %q", tt.yaml, cfg.Behavior.Autostash, tt.want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// KeyInfo describes one configuration key for listings and editors.
+type KeyInfo struct {
+	Key         string
+	Value       any
+	Default     any
+	Description string
+	// Type is the JSON Schema type of the key: string, boolean, integer,
+	// array or object.
+	Type string
+	// Allowed lists the accepted values when the key takes one of a
+	// fixed set.
+	Allowed []string
+	// Env names the environment variable that currently overrides the
+	// key, if any.
+	Env string
+}
+
+// envOverrides are environment variables that take precedence over a key
+// whenever they are set.
+var envOverrides = map[string]string{
+	"ui.color":                                     "NO_COLOR",
+	"history.enabled":                              "GGC_NO_HISTORY",
+	"interactive.keybindings.delete_word":          "GGC_KEYBIND_DELETE_WORD",
+	"interactive.keybindings.clear_line":           "GGC_KEYBIND_CLEAR_LINE",
+	"interactive.keybindings.delete_to_end":        "GGC_KEYBIND_DELETE_TO_END",
+	"interactive.keybindings.move_to_beginning":    "GGC_KEYBIND_MOVE_TO_BEGINNING",
+	"interactive.keybindings.move_to_end":          "GGC_KEYBIND_MOVE_TO_END",
+	"interactive.keybindings.move_up":              "GGC_KEYBIND_MOVE_UP",
+	"interactive.keybindings.move_down":            "GGC_KEYBIND_MOVE_DOWN",
+	"interactive.keybindings.add_to_workflow":      "GGC_KEYBIND_ADD_TO_WORKFLOW",
+	"interactive.keybindings.toggle_workflow_view": "GGC_KEYBIND_TOGGLE_WORKFLOW_VIEW",
+	"interactive.keybindings.clear_workflow":       "GGC_KEYBIND_CLEAR_WORKFLOW",
+	"interactive.keybindings.workflow_create":      "GGC_KEYBIND_WORKFLOW_CREATE",
+	"interactive.keybindings.workflow_delete":      "GGC_KEYBIND_WORKFLOW_DELETE",
+	"interactive.keybindings.soft_cancel":          "GGC_KEYBIND_SOFT_CANCEL",
+}
+
+// EnvOverride returns the environment variable overriding key when it is
+// set.
+func EnvOverride(key string) (string, bool) {
+	name, ok := envOverrides[key]
+	if !ok || os.Getenv(name) == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// Keys returns every configuration key in sorted order with its current
+// value, built-in default and description.
+func (cm *Manager) Keys() []KeyInfo {
+	values := cm.List()
+	defaults := make(map[string]any)
+	cm.flattenConfig(getDefaultConfig(cm.gitClient), "", defaults)
+
+	infos := make([]KeyInfo, 0, len(values))
+	for key, value := range values {
+		info := KeyInfo{Key: key, Value: value, Default: defaults[key], Description: Describe(key)}
+		if field, ok := lookupKey(key); ok {
+			info.Type, info.Allowed = field.typ, field.allowed
+		}
+		info.Env, _ = EnvOverride(key)
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Key < infos[j].Key })
+	return infos
+}
+
+// Describe returns the description of a key path from the desc tags on
+// Config. Map entries, such as aliases.<name>, share their map's
+// description.
+func Describe(key string) string {
+	field, _ := lookupKey(key)
+	return field.desc
+}
+
+// keyField is what the struct tags say about one key path.
+type keyField struct {
+	desc    string
+	typ     string
+	allowed []string
+}
+
+// lookupKey walks the yaml tags of Config along key. It reports false when
+// key does not name a field, though desc still holds the deepest
+// description found on the way.
+func lookupKey(key string) (keyField, bool) {
+	t := reflect.TypeOf(Config{})
+	var field keyField
+	for _, part := range strings.Split(key, ".") {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			f, ok := fieldByYAMLName(t, part)
+			if !ok {
+				return field, false
+			}
+			if d := f.Tag.Get("desc"); d != "" {
+				field.desc = d
+			}
+			field.allowed = nil
+			if enum := f.Tag.Get("enum"); enum != "" {
+				field.allowed = strings.Split(enum, "|")
+			}
+			t = f.Type
+		case reflect.Map:
+			field.allowed = nil
+			t = t.Elem()
+		default:
+			return field, false
+		}
+	}
+	field.typ = schemaType(t)
+	return field, true
+}
+
+// schemaType names the JSON Schema type of t. Interface values, such as
+// aliases, have no single type and yield an empty string.
+func schemaType(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	}
+	return ""
+}
+
+func fieldByYAMLName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if tag, _, _ := strings.Cut(field.Tag.Get("yaml"), ","); tag == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
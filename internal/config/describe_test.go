@@ -0,0 +1,79 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		key, want string
+	}{
+		{"ui.pager", "Page long output"},
+		{"integration.gitlab.token", "GitLab API token"},
+		{"aliases.st", "Command shortcuts"},
+		{"interactive.contexts.input.keybindings", "Keybindings while typing a command"},
+		{"nope.key", ""},
+	}
+	for _, tt := range tests {
+		if got := Describe(tt.key); !strings.HasPrefix(got, tt.want) || (tt.want == "" && got != "") {
+			t.Errorf("Describe(%q) = %q, want prefix %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestManager_Keys(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	cm := NewConfigManager(testutil.NewMockGitClient())
+	cm.config.Commit.SubjectMaxLength = 50
+
+	infos := cm.Keys()
+	byKey := make(map[string]KeyInfo, len(infos))
+	for i, info := range infos {
+		if i > 0 && infos[i-1].Key >= info.Key {
+			t.Fatalf("keys are not sorted: %q before %q", infos[i-1].Key, info.Key)
+		}
+		if info.Description == "" {
+			t.Errorf("%s has no description", info.Key)
+		}
+		byKey[info.Key] = info
+	}
+	want := KeyInfo{Key: "commit.subject-max-length", Value: 50, Default: 72, Description: Describe("commit.subject-max-length"), Type: "integer"}
+	if got := byKey["commit.subject-max-length"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() entry = %+v, want %+v", got, want)
+	}
+	if got := byKey["ui.color"].Env; got != "NO_COLOR" {
+		t.Errorf("ui.color Env = %q, want NO_COLOR", got)
+	}
+	if got := byKey["sync.strategy"].Allowed; !reflect.DeepEqual(got, []string{"rebase", "merge"}) {
+		t.Errorf("sync.strategy Allowed = %v", got)
+	}
+	if got := byKey["history.enabled"].Type; got != "boolean" {
+		t.Errorf("history.enabled Type = %q, want boolean", got)
+	}
+}
+
+func TestManager_SetRollsBackInvalidValues(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cm := NewConfigManager(testutil.NewMockGitClient())
+	if err := cm.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cm.Set("behavior.confirm-destructive", "sometimes"); err == nil {
+		t.Fatal("Set() should reject an invalid value")
+	}
+	if got := cm.GetConfig().Behavior.ConfirmDestructive; got != "simple" {
+		t.Errorf("invalid value was kept: %q", got)
+	}
+
+	if err := cm.Set("history.enabled", false); err != nil {
+		t.Fatalf("Set() on an optional field: %v", err)
+	}
+	if got := cm.GetConfig().History.Enabled; got == nil || *got {
+		t.Errorf("history.enabled = %v, want false", got)
+	}
+}
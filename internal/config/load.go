@@ -23,14 +23,18 @@ func (cm *Manager) Load() error {
 	return cm.LoadWithFileOps(OSFileOps{})
 }
 
-// LoadWithFileOps loads configuration with custom file operations (for testing)
+// LoadWithFileOps loads configuration with custom file operations (for testing).
+// The repository's .ggc.yaml, if any, is overlaid on the user configuration.
 func (cm *Manager) LoadWithFileOps(fileOps FileOps) error {
 	paths := cm.getConfigPaths()
 
 	for _, path := range paths {
 		if _, err := fileOps.Stat(path); err == nil {
 			cm.configPath = path
-			return cm.loadFromFileWithOps(path, fileOps)
+			if err := cm.loadFromFileWithOps(path, fileOps); err != nil {
+				return err
+			}
+			return cm.loadRepoConfig(fileOps)
 		}
 	}
 
@@ -38,7 +42,7 @@ func (cm *Manager) LoadWithFileOps(fileOps FileOps) error {
 		return err
 	}
 	cm.configPath = paths[0]
-	return nil
+	return cm.loadRepoConfig(fileOps)
 }
 
 // loadFromFile loads configuration from a specific file
@@ -57,6 +61,7 @@ func (cm *Manager) loadFromFileWithOps(path string, fileOps FileOps) error {
 	if err := yaml.Unmarshal(data, config); err != nil {
 		return fmt.Errorf("failed to parse config file: %w", err)
 	}
+	migrateStashBeforeSwitch(data, config)
 
 	cm.syncFromGitConfig()
 	cm.config = config
@@ -73,6 +78,24 @@ func (cm *Manager) loadFromFileWithOps(path string, fileOps FileOps) error {
 	return nil
 }
 
+// migrateStashBeforeSwitch carries the retired behavior.stash-before-switch
+// over to behavior.autostash: false becomes never. true was the default
+// written to every config, so it leaves autostash at ask. The old key is
+// dropped the next time the config is saved.
+func migrateStashBeforeSwitch(data []byte, config *Config) {
+	var legacy struct {
+		Behavior struct {
+			StashBeforeSwitch *bool `yaml:"stash-before-switch"`
+		} `yaml:"behavior"`
+	}
+	if yaml.Unmarshal(data, &legacy) != nil || legacy.Behavior.StashBeforeSwitch == nil {
+		return
+	}
+	if !*legacy.Behavior.StashBeforeSwitch && config.Behavior.Autostash == "" {
+		config.Behavior.Autostash = "never"
+	}
+}
+
 func (cm *Manager) syncFromCommandName(command string) {
 	value, err := cm.gitClient.ConfigGetGlobal(command)
 	if err != nil || value == "" {
@@ -15,16 +15,21 @@ func (cm *Manager) Get(key string) (any, error) {
 	return cm.getValueByPath(cm.config, sanitized)
 }
 
-// Set sets a configuration value by key path
+// Set sets a configuration value by key path. A value that fails
+// validation is rolled back and nothing is saved.
 func (cm *Manager) Set(key string, value any) error {
 	sanitized, err := sanitizeConfigPath(key)
 	if err != nil {
 		return err
 	}
+	old, oldErr := cm.getValueByPath(cm.config, sanitized)
 	if err := cm.setValueByPath(cm.config, sanitized, value); err != nil {
 		return err
 	}
 	if err := cm.config.Validate(); err != nil {
+		if oldErr == nil && old != nil {
+			_ = cm.setValueByPath(cm.config, sanitized, old)
+		}
 		return err
 	}
 	return cm.Save()
@@ -185,6 +190,14 @@ func (cm *Manager) setStructField(current reflect.Value, fieldName string, value
 	}
 
 	newValue := reflect.ValueOf(value)
+	// Optional fields such as history.enabled are pointers; set them
+	// from a plain value.
+	if field.Kind() == reflect.Pointer && newValue.Type().ConvertibleTo(field.Type().Elem()) {
+		ptr := reflect.New(field.Type().Elem())
+		ptr.Elem().Set(newValue.Convert(field.Type().Elem()))
+		field.Set(ptr)
+		return nil
+	}
 	if !newValue.Type().ConvertibleTo(field.Type()) {
 		return fmt.Errorf("cannot convert %s to %s", newValue.Type(), field.Type())
 	}
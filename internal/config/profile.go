@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PinnedProfileKey is the repository git config key that pins a profile
+// to the repository, as `ggc profile use` does.
+const PinnedProfileKey = "ggc.profile"
+
+// Profile is a named identity that `ggc profile use` writes into a
+// repository's git config.
+type Profile struct {
+	Name  string `yaml:"name" desc:"user.name"`
+	Email string `yaml:"email" desc:"user.email"`
+	// SigningKey is a GPG key ID, or for the ssh format a public key or
+	// the path to one.
+	SigningKey string `yaml:"signing-key,omitempty" desc:"user.signingkey: a GPG key ID, or an SSH public key or its path"`
+	// SigningFormat is "gpg" (the default) or "ssh".
+	SigningFormat string `yaml:"signing-format,omitempty" desc:"Signature format (default gpg)" enum:"gpg|ssh"`
+	// Sign turns on commit.gpgsign and tag.gpgsign.
+	Sign bool `yaml:"sign,omitempty" desc:"Sign commits and tags by default"`
+	// Paths are directory globs, such as ~/work/*, under which this
+	// profile is the expected one.
+	Paths []string `yaml:"paths,omitempty" desc:"Directory globs where this profile is expected"`
+}
+
+// GitConfig returns the git config keys and values that apply p, in the
+// order they should be set.
+func (p Profile) GitConfig() [][2]string {
+	values := [][2]string{
+		{"user.name", p.Name},
+		{"user.email", p.Email},
+	}
+	if p.SigningKey != "" {
+		values = append(values, [2]string{"user.signingkey", p.SigningKey})
+		format := "openpgp"
+		if p.SigningFormat == "ssh" {
+			format = "ssh"
+		}
+		values = append(values, [2]string{"gpg.format", format})
+	}
+	if p.Sign {
+		values = append(values,
+			[2]string{"commit.gpgsign", "true"},
+			[2]string{"tag.gpgsign", "true"})
+	}
+	return values
+}
+
+// Matches reports whether a repository whose user.name and user.email
+// are name and email uses p. Emails compare case-insensitively.
+func (p Profile) Matches(name, email string) bool {
+	return name == p.Name && strings.EqualFold(email, p.Email)
+}
+
+// ProfileNames returns the configured profile names, sorted.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExpectedProfile returns the profile a repository in dir should use: the
+// one pinned to it (the ggc.profile git config value, passed as pinned)
+// when that exists, otherwise the one whose paths match dir most
+// specifically.
+func (c *Config) ExpectedProfile(pinned, dir string) (string, bool) {
+	if _, ok := c.Profiles[pinned]; ok {
+		return pinned, true
+	}
+	best, bestLen := "", -1
+	for _, name := range c.ProfileNames() {
+		for _, pattern := range c.Profiles[name].Paths {
+			if len(pattern) > bestLen && matchProfilePath(pattern, dir) {
+				best, bestLen = name, len(pattern)
+			}
+		}
+	}
+	return best, bestLen >= 0
+}
+
+// matchProfilePath reports whether dir or one of its parents matches
+// pattern, with a leading ~ standing for the home directory.
+func matchProfilePath(pattern, dir string) bool {
+	if rest, ok := strings.CutPrefix(pattern, "~"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return false
+		}
+		pattern = home + rest
+	}
+	pattern = filepath.Clean(pattern)
+	for d := filepath.Clean(dir); ; {
+		if ok, _ := filepath.Match(pattern, d); ok {
+			return true
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return false
+		}
+		d = parent
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExpectedProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	cfg := &Config{Profiles: map[string]Profile{
+		"work":    {Name: "Jane", Email: "jane@corp.example", Paths: []string{"~/work"}},
+		"client":  {Name: "Jane", Email: "jane@client.example", Paths: []string{"~/work/client-*"}},
+		"private": {Name: "Jane", Email: "jane@example.com"},
+	}}
+
+	tests := []struct {
+		name, pinned, dir, want string
+		ok                      bool
+	}{
+		{"parent directory matches", "", filepath.Join(home, "work", "api", "cmd"), "work", true},
+		{"longest pattern wins", "", filepath.Join(home, "work", "client-x", "app"), "client", true},
+		{"pinned wins over paths", "private", filepath.Join(home, "work", "api"), "private", true},
+		{"unknown pin falls back to paths", "gone", filepath.Join(home, "work"), "work", true},
+		{"no match", "", filepath.Join(home, "src"), "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := cfg.ExpectedProfile(tt.pinned, tt.dir)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("ExpectedProfile(%q, %q) = %q, %v; want %q, %v", tt.pinned, tt.dir, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestProfile_GitConfig(t *testing.T) {
+	p := Profile{Name: "Jane", Email: "jane@example.com", SigningKey: "~/.ssh/id.pub", SigningFormat: "ssh", Sign: true}
+	want := [][2]string{
+		{"user.name", "Jane"},
+		{"user.email", "jane@example.com"},
+		{"user.signingkey", "~/.ssh/id.pub"},
+		{"gpg.format", "ssh"},
+		{"commit.gpgsign", "true"},
+		{"tag.gpgsign", "true"},
+	}
+	if got := p.GitConfig(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GitConfig() = %v, want %v", got, want)
+	}
+
+	p = Profile{Name: "Jane", Email: "jane@example.com", SigningKey: "ABCD1234"}
+	if got := p.GitConfig(); got[3] != [2]string{"gpg.format", "openpgp"} {
+		t.Errorf("gpg keys should use the openpgp format, got %v", got)
+	}
+	if !p.Matches("Jane", "JANE@example.com") || p.Matches("J", "jane@example.com") {
+		t.Error("Matches() should compare the name exactly and the email case-insensitively")
+	}
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// RepoConfigFile is the per-repository configuration file. Placed at the
+// root of a repository, it overlays the user configuration there.
+const RepoConfigFile = ".ggc.yaml"
+
+// repoDeniedKeys cannot be set by a repository: they run programs, hold
+// credentials or describe the user rather than the project. A cloned
+// repository must not be able to change them.
+var repoDeniedKeys = []string{
+	"meta",
+	"default.editor",
+	"default.merge-tool",
+	"ui.diff-tool",
+	"clone",
+	"profiles",
+	"integration",
+	"repos",
+}
+
+// repoOverlay is a loaded .ggc.yaml.
+type repoOverlay struct {
+	path string
+	// values is the file as written, as nested maps.
+	values map[string]any
+	// user is the user configuration before the overlay, so Save can put
+	// it back.
+	user map[string]any
+}
+
+// RepoConfigPath returns the .ggc.yaml overlaid on the configuration, or
+// an empty string when there is none.
+func (cm *Manager) RepoConfigPath() string {
+	if cm.repo == nil {
+		return ""
+	}
+	return cm.repo.path
+}
+
+// FromRepo reports whether the repository's .ggc.yaml sets key.
+func (cm *Manager) FromRepo(key string) bool {
+	if cm.repo == nil {
+		return false
+	}
+	var current any = cm.repo.values
+	for _, part := range strings.Split(key, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			// The file sets a list or value above key.
+			return true
+		}
+		if current, ok = m[part]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// loadRepoConfig overlays the .ggc.yaml at the root of the repository
+// containing the working directory, if there is one. Keys it sets take
+// precedence over the user configuration; maps such as aliases are merged
+// and lists are replaced.
+func (cm *Manager) loadRepoConfig(fileOps FileOps) error {
+	root, ok := findRepoRoot(fileOps)
+	if !ok {
+		return nil
+	}
+	path := filepath.Join(root, RepoConfigFile)
+	data, err := fileOps.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	if key, denied := deniedRepoKey(values, ""); denied {
+		return fmt.Errorf("%s: %s cannot be set per repository; set it in your user config", path, key)
+	}
+	user, err := configMap(cm.config)
+	if err != nil {
+		return err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cm.config); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := cm.config.validateWorkflows(); err != nil {
+		return fmt.Errorf("invalid %s: %w", path, err)
+	}
+	cm.repo = &repoOverlay{path: path, values: values, user: user}
+	return nil
+}
+
+// findRepoRoot returns the nearest directory at or above the working
+// directory that contains .git.
+func findRepoRoot(fileOps FileOps) (string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	for {
+		if _, err := fileOps.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// deniedRepoKey returns the first key in values that repoDeniedKeys
+// forbids.
+func deniedRepoKey(values map[string]any, prefix string) (string, bool) {
+	for name, value := range values {
+		key := prefix + name
+		for _, denied := range repoDeniedKeys {
+			if key == denied {
+				return key, true
+			}
+		}
+		if nested, ok := value.(map[string]any); ok {
+			if key, denied := deniedRepoKey(nested, key+"."); denied {
+				return key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// userConfig returns the configuration to save: the loaded configuration
+// without the repository overlay. Values changed since loading are kept,
+// even where the overlay sets them.
+func (cm *Manager) userConfig() (*Config, error) {
+	if cm.repo == nil {
+		return cm.config, nil
+	}
+	effective, err := configMap(cm.config)
+	if err != nil {
+		return nil, err
+	}
+	removeOverlay(effective, cm.repo.user, cm.repo.values)
+	data, err := yaml.Marshal(effective)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	return &config, nil
+}
+
+// removeOverlay puts the user's own values back wherever effective still
+// holds what the overlay set.
+func removeOverlay(effective, user, overlay map[string]any) {
+	for key, value := range overlay {
+		if nested, ok := value.(map[string]any); ok {
+			if sub, ok := effective[key].(map[string]any); ok {
+				userSub, _ := user[key].(map[string]any)
+				removeOverlay(sub, userSub, nested)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(effective[key], value) {
+			continue
+		}
+		if userValue, ok := user[key]; ok {
+			effective[key] = userValue
+		} else {
+			delete(effective, key)
+		}
+	}
+}
+
+// configMap converts c to the nested maps its YAML form decodes to.
+func configMap(c *Config) (map[string]any, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	var m map[string]any
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	return m, nil
+}
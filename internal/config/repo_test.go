@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+// setupRepoConfig writes a user config and a repository with a .ggc.yaml,
+// and changes into a subdirectory of the repository.
+func setupRepoConfig(t *testing.T, user, repo string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, ".ggcconfig.yaml"), []byte(user), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, RepoConfigFile), []byte(repo), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(filepath.Join(root, "sub"))
+	return home
+}
+
+func TestManager_LoadRepoConfig(t *testing.T) {
+	home := setupRepoConfig(t, `
+git:
+  default-remote: origin
+aliases:
+  st: status
+`, `
+git:
+  default-remote: upstream
+commit:
+  conventional: true
+aliases:
+  ship: [add ., commit, push]
+`)
+	cm := NewConfigManager(testutil.NewMockGitClient())
+	if err := cm.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	cfg := cm.GetConfig()
+	if cfg.Git.DefaultRemote != "upstream" || !cfg.Commit.Conventional {
+		t.Errorf("repository values not applied: remote %q, conventional %v", cfg.Git.DefaultRemote, cfg.Commit.Conventional)
+	}
+	if cfg.Aliases["st"] == nil || cfg.Aliases["ship"] == nil {
+		t.Errorf("aliases should be merged, got %v", cfg.Aliases)
+	}
+	if !strings.HasSuffix(cm.RepoConfigPath(), RepoConfigFile) {
+		t.Errorf("RepoConfigPath() = %q", cm.RepoConfigPath())
+	}
+	if !cm.FromRepo("git.default-remote") || !cm.FromRepo("aliases.ship") || cm.FromRepo("aliases.st") {
+		t.Error("FromRepo() does not match the repository file")
+	}
+
+	if err := cm.Set("ui.pager", false); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	saved, err := os.ReadFile(filepath.Join(home, ".ggcconfig.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leaked := range []string{"upstream", "ship", "conventional: true"} {
+		if strings.Contains(string(saved), leaked) {
+			t.Errorf("repository value %q was saved to the user config:\n%s", leaked, saved)
+		}
+	}
+	for _, kept := range []string{"default-remote: origin", "st: status", "pager: false"} {
+		if !strings.Contains(string(saved), kept) {
+			t.Errorf("user config lost %q:\n%s", kept, saved)
+		}
+	}
+}
+
+func TestManager_LoadRepoConfigDeniedKeys(t *testing.T) {
+	for _, repo := range []string{
+		"default:\n  editor: ./evil.sh\n",
+		"integration:\n  github:\n    api-url: https://example.com\n",
+		"ui:\n  diff-tool: ./evil.sh\n",
+	} {
+		setupRepoConfig(t, "ui:\n  color: true\n", repo)
+		cm := NewConfigManager(testutil.NewMockGitClient())
+		err := cm.Load()
+		if err == nil || !strings.Contains(err.Error(), "cannot be set per repository") {
+			t.Errorf("Load() with %q: error = %v", repo, err)
+		}
+	}
+}
+
+func TestManager_LoadRepoConfigUnknownKey(t *testing.T) {
+	setupRepoConfig(t, "ui:\n  color: true\n", "git:\n  default-remot: upstream\n")
+	cm := NewConfigManager(testutil.NewMockGitClient())
+	if err := cm.Load(); err == nil {
+		t.Error("Load() should reject unknown keys in .ggc.yaml")
+	}
+}
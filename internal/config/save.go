@@ -19,7 +19,12 @@ func (cm *Manager) SaveWithFileOps(fileOps FileOps) error {
 	if err := fileOps.MkdirAll(dir, 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
-	data, err := yaml.Marshal(cm.config)
+	// A repository's .ggc.yaml is never written to the user's file.
+	config, err := cm.userConfig()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(config)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -89,7 +94,10 @@ func (cm *Manager) hardenPermissionsWithOps(path string, fileOps FileOps) {
 
 // syncToGitConfig synchronizes relevant config values TO Git's global configuration
 func (cm *Manager) syncToGitConfig() error {
-	config := cm.GetConfig()
+	config, err := cm.userConfig()
+	if err != nil {
+		return err
+	}
 
 	if err := cm.syncDefaultSettings(config); err != nil {
 		return err
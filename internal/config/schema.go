@@ -0,0 +1,86 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// SchemaID is the URL the published configuration schema is served from.
+const SchemaID = "https://bmf-san.github.io/ggc/ggc-config.schema.json"
+
+// Schema returns a JSON Schema for the configuration file. Types, property
+// names, descriptions and allowed values come from the yaml, desc and enum
+// tags on Config; defaults come from the built-in configuration.
+func (cm *Manager) Schema() map[string]any {
+	schema := valueSchema(reflect.TypeOf(Config{}), reflect.ValueOf(*getDefaultConfig(cm.gitClient)))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = SchemaID
+	schema["title"] = "ggc configuration"
+	return schema
+}
+
+var stringShorthandType = reflect.TypeOf((*stringShorthand)(nil)).Elem()
+
+// valueSchema describes values of type t. def is the default value, or
+// the zero reflect.Value when there is none, as for map entries.
+func valueSchema(t reflect.Type, def reflect.Value) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		if def.IsValid() && !def.IsNil() {
+			def = def.Elem()
+		} else {
+			def = reflect.Value{}
+		}
+	}
+	schema := map[string]any{}
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := map[string]any{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			var fieldDef reflect.Value
+			if def.IsValid() {
+				fieldDef = def.Field(i)
+			}
+			property := valueSchema(field.Type, fieldDef)
+			if desc := field.Tag.Get("desc"); desc != "" {
+				property["description"] = desc
+			}
+			if enum := field.Tag.Get("enum"); enum != "" {
+				property["enum"] = strings.Split(enum, "|")
+			}
+			properties[name] = property
+		}
+		schema["type"] = "object"
+		if reflect.PointerTo(t).Implements(stringShorthandType) {
+			schema["type"] = []string{"string", "object"}
+		}
+		schema["properties"] = properties
+		schema["additionalProperties"] = false
+	case reflect.Map:
+		schema["type"] = "object"
+		schema["additionalProperties"] = valueSchema(t.Elem(), reflect.Value{})
+	case reflect.Slice, reflect.Array:
+		schema["type"] = "array"
+		schema["items"] = valueSchema(t.Elem(), reflect.Value{})
+		if def.IsValid() && def.Len() > 0 {
+			schema["default"] = def.Interface()
+		}
+	case reflect.Interface:
+		// Aliases are a command string or a list of them.
+		schema["oneOf"] = []any{
+			map[string]any{"type": "string"},
+			map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		}
+	default:
+		schema["type"] = schemaType(t)
+		if def.IsValid() && !def.IsZero() {
+			schema["default"] = def.Interface()
+		}
+	}
+	return schema
+}
@@ -7,6 +7,8 @@ import (
 	"sort"
 	"strings"
 	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/testutil"
 )
 
 // TestConfigSchemaMatchesStruct guards against drift between the
@@ -56,3 +58,63 @@ func topLevelYAMLKeys(t reflect.Type) []string {
 	}
 	return keys
 }
+
+// TestGeneratedSchemaMatchesDocs checks that every property and enum in
+// the schema generated from the struct tags also appears in
+// docs/ggc-config.schema.json, and the other way round.
+func TestGeneratedSchemaMatchesDocs(t *testing.T) {
+	raw, err := os.ReadFile("../../docs/ggc-config.schema.json")
+	if err != nil {
+		t.Fatalf("read schema: %v", err)
+	}
+	var docs map[string]any
+	if err := json.Unmarshal(raw, &docs); err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+	generatedJSON, err := json.Marshal(NewConfigManager(testutil.NewMockGitClient()).Schema())
+	if err != nil {
+		t.Fatalf("marshal generated schema: %v", err)
+	}
+	var generated map[string]any
+	if err := json.Unmarshal(generatedJSON, &generated); err != nil {
+		t.Fatalf("parse generated schema: %v", err)
+	}
+
+	want, got := map[string]string{}, map[string]string{}
+	schemaPaths(docs, "", want)
+	schemaPaths(generated, "", got)
+	for path, enum := range got {
+		if docEnum, ok := want[path]; !ok {
+			t.Errorf("%s is missing from the docs schema", path)
+		} else if docEnum != enum {
+			t.Errorf("%s: docs enum %s, struct enum %s", path, docEnum, enum)
+		}
+	}
+	for path := range want {
+		if _, ok := got[path]; !ok {
+			t.Errorf("%s is in the docs schema but not in Config", path)
+		}
+	}
+	if generated["$id"] != docs["$id"] {
+		t.Errorf("$id = %v, docs have %v", generated["$id"], docs["$id"])
+	}
+}
+
+// schemaPaths records every property path under node with its enum.
+func schemaPaths(node map[string]any, prefix string, out map[string]string) {
+	if props, ok := node["properties"].(map[string]any); ok {
+		for name, child := range props {
+			path := strings.TrimPrefix(prefix+"."+name, ".")
+			childNode, _ := child.(map[string]any)
+			enum, _ := json.Marshal(childNode["enum"])
+			out[path] = string(enum)
+			schemaPaths(childNode, path, out)
+		}
+	}
+	if extra, ok := node["additionalProperties"].(map[string]any); ok {
+		schemaPaths(extra, prefix+".*", out)
+	}
+	if items, ok := node["items"].(map[string]any); ok {
+		schemaPaths(items, prefix+"[]", out)
+	}
+}
@@ -60,6 +60,21 @@ type KeybindingsConfig struct {
 	Keybindings map[string]interface{} `yaml:"keybindings,omitempty"`
 }
 
+// PaletteSection groups interactive commands under a heading of their own.
+type PaletteSection struct {
+	Name     string   `yaml:"name" desc:"Section heading"`
+	Commands []string `yaml:"commands" desc:"Commands in the section, in order"`
+}
+
+// TicketRule finds an issue-tracker reference in branch names.
+type TicketRule struct {
+	// Pattern is a regular expression whose first group, or else whole
+	// match, is the ticket ID.
+	Pattern string `yaml:"pattern" desc:"Regular expression matched against the branch name; its first group, or else whole match, is the ticket ID"`
+	URL     string `yaml:"url,omitempty" desc:"Ticket address, with {ID} standing for the ID"`
+	Format  string `yaml:"format,omitempty" desc:"How the ticket is written in messages, with {ID} standing for the ID (default [{ID}])"`
+}
+
 // AliasType represents the type of alias
 type AliasType int
 
@@ -75,9 +90,15 @@ type ParsedAlias struct {
 	Type             AliasType
 	Commands         []string
 	Placeholders     map[string]struct{} // Track which placeholders are used
+	Prompts          []string            // <name> placeholders in order of first use
 	MaxPositionalArg int                 // Highest positional argument index (-1 if none)
 }
 
+// HasPlaceholders reports whether the alias uses any {…} or <…> placeholder.
+func (a *ParsedAlias) HasPlaceholders() bool {
+	return len(a.Placeholders) > 0 || len(a.Prompts) > 0
+}
+
 // ValidationError creates a new error manager for validation operations
 type ValidationError struct {
 	Field   string
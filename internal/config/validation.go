@@ -2,9 +2,19 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/commitmsg"
+	"github.com/bmf-san/ggc/v8/internal/safety"
 )
 
 func (c *Config) validateBranch() error {
@@ -61,6 +71,15 @@ func (c *Config) validateConfirmDestructive() error {
 	return nil
 }
 
+func (c *Config) validateAutostash() error {
+	switch val := c.Behavior.Autostash; val {
+	case "", "ask", "always", "never":
+		return nil
+	default:
+		return &ValidationError{"behavior.autostash", val, "must be one of: ask, always, never"}
+	}
+}
+
 // validateGitDefaultRemote validates git default remote name format
 func (c *Config) validateGitDefaultRemote() error {
 	remote := c.Git.DefaultRemote
@@ -90,8 +109,10 @@ func (c *Config) validateGitDefaultRemote() error {
 
 // validateWorkflows validates all workflows defined in the configuration.
 // Workflow names must be non-empty and contain no spaces. Each workflow must
-// have at least one step, and each step must be a non-empty command string that
-// does not contain shell metacharacters.
+// have at least one step, each step must be a non-empty command string that
+// does not contain shell metacharacters, and its when, if any, must be a
+// condition ParseWorkflowCondition understands. A parallel group holds
+// steps that follow the same rules.
 //
 // Two placeholder syntaxes are permitted within step strings:
 //   - Interactive placeholder syntax: <name> (angle brackets, e.g. "commit <message>").
@@ -115,31 +136,266 @@ func (c *Config) validateWorkflows() error {
 			}
 		}
 		for i, step := range steps {
-			if strings.TrimSpace(step) == "" {
-				return &ValidationError{
-					Field:   fmt.Sprintf("workflows.%s[%d]", name, i),
-					Value:   step,
-					Message: "step command must not be empty",
+			field := fmt.Sprintf("workflows.%s[%d]", name, i)
+			if step.Parallel || len(step.Steps) > 0 {
+				if err := validateParallelGroup(field, step); err != nil {
+					return err
 				}
+				continue
 			}
-			// Strip <placeholder> tokens (interactive workflow syntax) before
-			// the metacharacter check so that e.g. "commit <message>" is valid.
-			// Note: alias-style placeholders like {0} are stripped by
-			// defaultValidator.validateCommand, so both forms are permitted
-			// in workflow step commands.
-			cleaned := angleBracketPlaceholderRe.ReplaceAllString(step, "")
-			if err := defaultValidator.validateCommand(cleaned); err != nil {
-				return &ValidationError{
-					Field:   fmt.Sprintf("workflows.%s[%d]", name, i),
-					Value:   step,
-					Message: err.Error(),
-				}
+			if err := validateWorkflowStep(field, step.Run, step.When); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateWorkflowStep checks the command and condition of one step.
+func validateWorkflowStep(field, run, when string) error {
+	if strings.TrimSpace(run) == "" {
+		return &ValidationError{
+			Field:   field,
+			Value:   run,
+			Message: "step command must not be empty",
+		}
+	}
+	// Strip <placeholder> tokens (interactive workflow syntax) before
+	// the metacharacter check so that e.g. "commit <message>" is valid.
+	// Note: alias-style placeholders like {0} are stripped by
+	// defaultValidator.validateCommand, so both forms are permitted
+	// in workflow step commands.
+	cleaned := angleBracketPlaceholderRe.ReplaceAllString(run, "")
+	if err := defaultValidator.validateCommand(cleaned); err != nil {
+		return &ValidationError{
+			Field:   field,
+			Value:   run,
+			Message: err.Error(),
+		}
+	}
+	if when != "" {
+		if _, err := ParseWorkflowCondition(when); err != nil {
+			return &ValidationError{
+				Field:   field + ".when",
+				Value:   when,
+				Message: err.Error(),
+			}
+		}
+	}
+	return nil
+}
+
+// validateParallelGroup checks a parallel group: parallel: true, steps
+// instead of run, and valid steps.
+func validateParallelGroup(field string, step WorkflowStep) error {
+	switch {
+	case !step.Parallel:
+		return &ValidationError{Field: field + ".steps", Value: step.Steps, Message: "steps are only allowed in a group with parallel: true"}
+	case step.Run != "":
+		return &ValidationError{Field: field + ".run", Value: step.Run, Message: "a parallel group runs its steps, not a command of its own"}
+	case len(step.Steps) == 0:
+		return &ValidationError{Field: field + ".steps", Value: step.Steps, Message: "a parallel group must have at least one step"}
+	}
+	if step.When != "" {
+		if _, err := ParseWorkflowCondition(step.When); err != nil {
+			return &ValidationError{Field: field + ".when", Value: step.When, Message: err.Error()}
+		}
+	}
+	for j, sub := range step.Steps {
+		if err := validateWorkflowStep(fmt.Sprintf("%s.steps[%d]", field, j), sub.Run, sub.When); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCommit validates the commit composer settings. Types and scopes
+// end up inside "type(scope):" headers, so they may not contain spaces,
+// parentheses or colons.
+func (c *Config) validateCommit() error {
+	if c.Commit.SubjectMaxLength < 0 {
+		return &ValidationError{"commit.subject-max-length", c.Commit.SubjectMaxLength, "must not be negative"}
+	}
+	if c.Commit.BodyMaxLength < 0 {
+		return &ValidationError{"commit.body-max-length", c.Commit.BodyMaxLength, "must not be negative"}
+	}
+	if err := validateCommitWords("commit.types", c.Commit.Types); err != nil {
+		return err
+	}
+	return validateCommitWords("commit.scopes", c.Commit.Scopes)
+}
+
+// validateSnippets validates the commit snippets and the patterns of the
+// branch variables.
+func (c *Config) validateSnippets() error {
+	for _, name := range slices.Sorted(maps.Keys(c.Snippets.Commit)) {
+		if !commitmsg.ValidSnippetName(name) {
+			return &ValidationError{"snippets.commit." + name, name, "snippet names may only contain letters, digits, '-' and '_'"}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Snippets.Variables)) {
+		pattern := c.Snippets.Variables[name]
+		field := "snippets.variables." + name
+		if !commitmsg.ValidVariableName(name) {
+			return &ValidationError{field, name, "variable names must be upper case, such as TICKET"}
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return &ValidationError{field, pattern, "must be a valid regular expression"}
+		}
+	}
+	return nil
+}
+
+func validateCommitWords(field string, values []string) error {
+	for i, v := range values {
+		if v == "" || strings.ContainsAny(v, " \t():!") {
+			return &ValidationError{fmt.Sprintf("%s[%d]", field, i), v, "must be a single word without parentheses, colons or '!'"}
+		}
+	}
+	return nil
+}
+
+// validateTickets validates the ticket rules and where references go.
+func (c *Config) validateTickets() error {
+	for i, rule := range c.Tickets.Rules {
+		field := fmt.Sprintf("tickets.rules[%d]", i)
+		if _, err := regexp.Compile(rule.Pattern); err != nil || rule.Pattern == "" {
+			return &ValidationError{field + ".pattern", rule.Pattern, "must be a valid regular expression"}
+		}
+		if rule.Format != "" && !strings.Contains(rule.Format, "{ID}") {
+			return &ValidationError{field + ".format", rule.Format, "must contain {ID}"}
+		}
+	}
+	placements := []struct{ field, value string }{
+		{"tickets.commit", c.Tickets.Commit},
+		{"tickets.pr-title", c.Tickets.PRTitle},
+	}
+	for _, p := range placements {
+		switch p.value {
+		case "", "prefix", "suffix":
+		default:
+			return &ValidationError{p.field, p.value, "must be one of: prefix, suffix"}
+		}
+	}
+	return nil
+}
+
+// validateIntegration validates the hosting service settings. The API URL
+// receives the token, so only http and https are accepted. The proxy may
+// also be a SOCKS5 one.
+func (c *Config) validateIntegration() error {
+	apiURLs := []struct{ field, value string }{
+		{"integration.github.api-url", c.Integration.GitHub.APIURL},
+		{"integration.gitlab.api-url", c.Integration.GitLab.APIURL},
+		{"integration.gitea.api-url", c.Integration.Gitea.APIURL},
+	}
+	for _, a := range apiURLs {
+		if a.value == "" {
+			continue
+		}
+		u, err := url.Parse(a.value)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return &ValidationError{a.field, a.value, "must be an http or https URL"}
+		}
+	}
+	if p := c.Integration.HTTPProxy; p != "" {
+		u, err := url.Parse(p)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") || u.Host == "" {
+			return &ValidationError{"integration.http-proxy", p, "must be an http, https or socks5 URL"}
+		}
+	}
+	return nil
+}
+
+// validateCore validates the default pathspec.
+func (c *Config) validateCore() error {
+	if p := c.Core.DefaultPathspec; p != "" {
+		clean := path.Clean(strings.ReplaceAll(p, "\\", "/"))
+		if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") || filepath.IsAbs(p) {
+			return &ValidationError{"core.default-pathspec", p, "must be a directory inside the repository, relative to its root"}
+		}
+	}
+	return nil
+}
+
+// validateClone validates the shorthand expansion settings of ggc clone.
+func (c *Config) validateClone() error {
+	switch p := c.Clone.Protocol; p {
+	case "", "https", "ssh":
+	default:
+		return &ValidationError{"clone.protocol", p, "must be one of: https, ssh"}
+	}
+	if h := c.Clone.DefaultHost; h != "" && (strings.ContainsAny(h, "/: \t") || strings.HasPrefix(h, ".")) {
+		return &ValidationError{"clone.default-host", h, "must be a bare host name such as github.com"}
+	}
+	if p := c.Clone.Profile; p != "" {
+		if _, ok := c.Profiles[p]; !ok {
+			return &ValidationError{"clone.profile", p, "must name a profile under profiles"}
+		}
+	}
+	return nil
+}
+
+// validateProfiles validates the named identities.
+func (c *Config) validateProfiles() error {
+	for _, name := range c.ProfileNames() {
+		p := c.Profiles[name]
+		field := "profiles." + name
+		if !configPathSegmentRe.MatchString(name) {
+			return &ValidationError{field, name, "profile names may only contain letters, digits, '-' and '_'"}
+		}
+		if strings.TrimSpace(p.Name) == "" {
+			return &ValidationError{field + ".name", p.Name, "must not be empty"}
+		}
+		if !strings.Contains(p.Email, "@") {
+			return &ValidationError{field + ".email", p.Email, "must be an email address"}
+		}
+		switch p.SigningFormat {
+		case "", "gpg", "ssh":
+		default:
+			return &ValidationError{field + ".signing-format", p.SigningFormat, "must be one of: gpg, ssh"}
+		}
+		if p.Sign && p.SigningKey == "" {
+			return &ValidationError{field + ".sign", p.Sign, "requires signing-key"}
+		}
+		for _, pattern := range p.Paths {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return &ValidationError{field + ".paths", pattern, "must be a valid glob"}
 			}
 		}
 	}
 	return nil
 }
 
+// validateInteractive validates the command palette settings.
+func (c *Config) validateInteractive() error {
+	for i, s := range c.Interactive.Sections {
+		field := fmt.Sprintf("interactive.sections[%d]", i)
+		if strings.TrimSpace(s.Name) == "" {
+			return &ValidationError{field + ".name", s.Name, "must not be empty"}
+		}
+		if len(s.Commands) == 0 {
+			return &ValidationError{field + ".commands", s.Commands, "must list at least one command"}
+		}
+	}
+	if t := c.Interactive.ChordTimeout; t != "" {
+		if d, err := time.ParseDuration(t); err != nil || d <= 0 {
+			return &ValidationError{"interactive.chord-timeout", t, "must be a positive duration such as 1s or 750ms"}
+		}
+	}
+	if t := c.Interactive.EscapeTimeout; t != "" {
+		if d, err := time.ParseDuration(t); err != nil || d < 0 {
+			return &ValidationError{"interactive.escape-timeout", t, "must be a duration such as 50ms, or 0 to not wait"}
+		}
+	}
+	if t := c.Interactive.StatusRefresh; t != "" {
+		if d, err := time.ParseDuration(t); err != nil || d < 0 {
+			return &ValidationError{"interactive.status-refresh", t, "must be a duration such as 10s, or 0 to turn periodic refresh off"}
+		}
+	}
+	return nil
+}
+
 // Validate is a function that handles validation operations
 func (c *Config) Validate() error {
 	if err := c.validateBranch(); err != nil {
@@ -151,9 +407,21 @@ func (c *Config) Validate() error {
 	if err := c.validateConfirmDestructive(); err != nil {
 		return err
 	}
+	if err := c.validateAutostash(); err != nil {
+		return err
+	}
 	if err := c.validateGitDefaultRemote(); err != nil {
 		return err
 	}
+	if err := c.validateCore(); err != nil {
+		return err
+	}
+	if err := c.validateClone(); err != nil {
+		return err
+	}
+	if err := c.validateProfiles(); err != nil {
+		return err
+	}
 	if err := c.validateAliases(); err != nil {
 		return err
 	}
@@ -163,5 +431,125 @@ func (c *Config) Validate() error {
 	if err := c.validateWorkflows(); err != nil {
 		return err
 	}
+	if err := c.validateCommit(); err != nil {
+		return err
+	}
+	if err := c.validateSnippets(); err != nil {
+		return err
+	}
+	if err := c.validateTickets(); err != nil {
+		return err
+	}
+	if err := c.validateIntegration(); err != nil {
+		return err
+	}
+	if err := c.validateInteractive(); err != nil {
+		return err
+	}
+	if err := c.validateSwitch(); err != nil {
+		return err
+	}
+	if err := c.validateSync(); err != nil {
+		return err
+	}
+	if err := c.validateRelease(); err != nil {
+		return err
+	}
+	if err := c.validateSafety(); err != nil {
+		return err
+	}
+	if err := c.validateSnapshot(); err != nil {
+		return err
+	}
+	if err := c.validateNotes(); err != nil {
+		return err
+	}
+	return c.validateRepos()
+}
+
+// validateSwitch validates the recent-branches list settings.
+func (c *Config) validateSwitch() error {
+	if c.Switch.Recent.Limit < 0 {
+		return &ValidationError{"switch.recent.limit", c.Switch.Recent.Limit, "must not be negative"}
+	}
+	for _, pattern := range c.Switch.Recent.Exclude {
+		if !safety.ValidPattern(pattern) {
+			return &ValidationError{"switch.recent.exclude", pattern, "must be a branch name or glob such as tmp/*"}
+		}
+	}
+	return nil
+}
+
+// validateSync validates how ggc sync takes in the upstream.
+func (c *Config) validateSync() error {
+	switch s := c.Sync.Strategy; s {
+	case "", "rebase", "merge":
+		return nil
+	default:
+		return &ValidationError{"sync.strategy", s, "must be one of: rebase, merge"}
+	}
+}
+
+// validateRelease validates the release tag prefix, which becomes part of
+// a ref name.
+func (c *Config) validateRelease() error {
+	if p := c.Release.TagPrefix; strings.ContainsAny(p, " \t~^:?*[\\") || strings.Contains(p, "..") {
+		return &ValidationError{"release.tag-prefix", p, "must be usable in a tag name"}
+	}
+	return nil
+}
+
+// validateSnapshot validates the snapshot pruning policy.
+func (c *Config) validateSnapshot() error {
+	if c.Snapshot.Keep < 0 {
+		return &ValidationError{"snapshot.keep", c.Snapshot.Keep, "must not be negative"}
+	}
+	if t := c.Snapshot.MaxAge; t != "" {
+		if d, err := time.ParseDuration(t); err != nil || d <= 0 {
+			return &ValidationError{"snapshot.max-age", t, "must be a positive duration such as 720h"}
+		}
+	}
+	return nil
+}
+
+// validateNotes validates the notes ref, which may be a name under
+// refs/notes or the full ref.
+func (c *Config) validateNotes() error {
+	if c.Notes.Ref == "" {
+		return nil
+	}
+	ref := strings.TrimPrefix(c.Notes.Ref, "refs/notes/")
+	if ref == "" || strings.Contains(ref, "..") || strings.ContainsAny(ref, " \t~^:?*[\\") ||
+		strings.HasPrefix(ref, "/") || strings.HasSuffix(ref, "/") || strings.HasSuffix(ref, ".lock") {
+		return &ValidationError{"notes.ref", c.Notes.Ref, "must be a ref name such as review or refs/notes/review"}
+	}
+	return nil
+}
+
+// validateRepos validates how ggc repos runs.
+func (c *Config) validateRepos() error {
+	if c.Repos.Parallel < 0 {
+		return &ValidationError{"repos.parallel", c.Repos.Parallel, "must not be negative"}
+	}
+	return nil
+}
+
+// validateSafety validates the protected branch globs.
+func (c *Config) validateSafety() error {
+	for _, pattern := range c.Safety.ProtectedBranches {
+		if !safety.ValidPattern(pattern) {
+			return &ValidationError{"safety.protected-branches", pattern, "must be a branch name or glob such as release/*"}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Safety.SecretRules)) {
+		pattern := c.Safety.SecretRules[name]
+		field := "safety.secret-rules." + name
+		if name == "" || strings.ContainsAny(name, " \t") {
+			return &ValidationError{field, name, "rule names must be a single word, such as internal-token"}
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return &ValidationError{field, pattern, "must be a valid regular expression"}
+		}
+	}
 	return nil
 }
@@ -0,0 +1,252 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// WorkflowStep is one step of a saved workflow. It is written either as
+// the command string alone or as a mapping with options. A step with
+// parallel: true is a group whose steps run at the same time:
+//
+//	workflows:
+//	  ship:
+//	    - add .
+//	    - run: commit <message>
+//	      when: has_staged_changes
+//	    - parallel: true
+//	      steps:
+//	        - status short
+//	        - log simple
+//	    - run: push current
+//	      when: branch != main
+//	      confirm: true
+//	    - run: pr create
+//	      continue-on-error: true
+type WorkflowStep struct {
+	Run             string         `yaml:"run,omitempty" desc:"Command the step runs, without the ggc prefix"`
+	When            string         `yaml:"when,omitempty" desc:"Condition the step runs under, such as has_staged_changes or branch != main"`
+	ContinueOnError bool           `yaml:"continue-on-error,omitempty" desc:"Keep running the workflow when this step fails"`
+	Confirm         bool           `yaml:"confirm,omitempty" desc:"Ask before running this step"`
+	Parallel        bool           `yaml:"parallel,omitempty" desc:"Make the step a group whose steps run at the same time"`
+	Steps           []ParallelStep `yaml:"steps,omitempty" desc:"Steps of a parallel group"`
+}
+
+// ParallelStep is one step of a parallel group. Steps of a group cannot
+// ask for confirmation, since they run at the same time.
+type ParallelStep struct {
+	Run             string `yaml:"run" desc:"Command the step runs, without the ggc prefix"`
+	When            string `yaml:"when,omitempty" desc:"Condition the step runs under"`
+	ContinueOnError bool   `yaml:"continue-on-error,omitempty" desc:"Keep the group going when this step fails"`
+}
+
+// stringShorthand is implemented by config types that may also be written
+// as a plain string, so that their schema allows both.
+type stringShorthand interface {
+	stringShorthand()
+}
+
+func (WorkflowStep) stringShorthand() {}
+func (ParallelStep) stringShorthand() {}
+
+// workflowStepKeys are the keys a workflow step mapping may use.
+var workflowStepKeys = []string{"run", "when", "continue-on-error", "confirm", "parallel", "steps"}
+
+// parallelStepKeys are the keys a step of a parallel group may use.
+var parallelStepKeys = []string{"run", "when", "continue-on-error"}
+
+// decodeStep decodes a step given as a command string into run, or as a
+// mapping with only the keys in keys into out.
+func decodeStep(node *yaml.Node, keys []string, run *string, out any) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(run)
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: a workflow step must be a command string or a mapping", node.Line)
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i]
+		known := false
+		for _, k := range keys {
+			if key.Value == k {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("line %d: unknown workflow step key %q (want %s)", key.Line, key.Value, strings.Join(keys, ", "))
+		}
+	}
+	return node.Decode(out)
+}
+
+// UnmarshalYAML reads a step given as a command string or as a mapping.
+// Unknown keys are an error, as they are elsewhere in the config.
+func (s *WorkflowStep) UnmarshalYAML(node *yaml.Node) error {
+	type plain WorkflowStep
+	var step plain
+	if err := decodeStep(node, workflowStepKeys, &step.Run, &step); err != nil {
+		return err
+	}
+	*s = WorkflowStep(step)
+	return nil
+}
+
+// UnmarshalYAML reads a group step given as a command string or as a
+// mapping.
+func (s *ParallelStep) UnmarshalYAML(node *yaml.Node) error {
+	type plain ParallelStep
+	var step plain
+	if err := decodeStep(node, parallelStepKeys, &step.Run, &step); err != nil {
+		return err
+	}
+	*s = ParallelStep(step)
+	return nil
+}
+
+// MarshalYAML writes a step without options as its command string.
+func (s WorkflowStep) MarshalYAML() (any, error) {
+	if s.When == "" && !s.ContinueOnError && !s.Confirm && !s.Parallel && len(s.Steps) == 0 {
+		return s.Run, nil
+	}
+	type plain WorkflowStep
+	return plain(s), nil
+}
+
+// MarshalYAML writes a group step without options as its command string.
+func (s ParallelStep) MarshalYAML() (any, error) {
+	if s.When == "" && !s.ContinueOnError {
+		return s.Run, nil
+	}
+	type plain ParallelStep
+	return plain(s), nil
+}
+
+// String returns the command of the step followed by its options; for a
+// parallel group, the commands of its steps.
+func (s WorkflowStep) String() string {
+	var options []string
+	if s.When != "" {
+		options = append(options, "when "+s.When)
+	}
+	if s.Confirm {
+		options = append(options, "confirm")
+	}
+	if s.ContinueOnError {
+		options = append(options, "continue on error")
+	}
+	run := s.Run
+	if s.Parallel {
+		steps := make([]string, len(s.Steps))
+		for i, step := range s.Steps {
+			steps[i] = step.String()
+		}
+		run = "parallel: " + strings.Join(steps, " | ")
+	}
+	if len(options) == 0 {
+		return run
+	}
+	return fmt.Sprintf("%s (%s)", run, strings.Join(options, ", "))
+}
+
+// String returns the command of the group step followed by its options.
+func (s ParallelStep) String() string {
+	return WorkflowStep{Run: s.Run, When: s.When, ContinueOnError: s.ContinueOnError}.String()
+}
+
+// RepoState is what workflow conditions are evaluated against.
+type RepoState struct {
+	Branch   string
+	Staged   int
+	Unstaged int
+	Ahead    int
+	Behind   int
+}
+
+// workflowPredicates are the conditions that take no operand.
+var workflowPredicates = map[string]func(RepoState) bool{
+	"has_staged_changes":   func(s RepoState) bool { return s.Staged > 0 },
+	"has_unstaged_changes": func(s RepoState) bool { return s.Unstaged > 0 },
+	"has_changes":          func(s RepoState) bool { return s.Staged > 0 || s.Unstaged > 0 },
+	"clean":                func(s RepoState) bool { return s.Staged == 0 && s.Unstaged == 0 },
+	"ahead":                func(s RepoState) bool { return s.Ahead > 0 },
+	"behind":               func(s RepoState) bool { return s.Behind > 0 },
+}
+
+// WorkflowCondition is a parsed `when` of a workflow step.
+type WorkflowCondition struct {
+	text   string
+	negate bool
+	// predicate is set for the named conditions; otherwise the condition
+	// compares the branch with pattern.
+	predicate func(RepoState) bool
+	pattern   string
+}
+
+// ParseWorkflowCondition parses the `when` of a workflow step:
+//
+//   - has_staged_changes, has_unstaged_changes, has_changes, clean, ahead
+//     or behind
+//   - branch == <pattern> or branch != <pattern>, where the pattern may
+//     use * and ? as in release/*
+//
+// A leading ! negates the condition.
+func ParseWorkflowCondition(text string) (WorkflowCondition, error) {
+	c := WorkflowCondition{text: strings.TrimSpace(text)}
+	expr := c.text
+	if rest, ok := strings.CutPrefix(expr, "!"); ok {
+		c.negate = true
+		expr = strings.TrimSpace(rest)
+	}
+	if predicate, ok := workflowPredicates[expr]; ok {
+		c.predicate = predicate
+		return c, nil
+	}
+	if rest, ok := strings.CutPrefix(expr, "branch"); ok {
+		rest = strings.TrimSpace(rest)
+		op, pattern := "", ""
+		switch {
+		case strings.HasPrefix(rest, "=="):
+			op, pattern = "==", rest[2:]
+		case strings.HasPrefix(rest, "!="):
+			op, pattern = "!=", rest[2:]
+		}
+		pattern = strings.Trim(strings.TrimSpace(pattern), `"'`)
+		if op != "" && pattern != "" {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return c, fmt.Errorf("invalid branch pattern %q: %w", pattern, err)
+			}
+			c.pattern = pattern
+			if op == "!=" {
+				c.negate = !c.negate
+			}
+			return c, nil
+		}
+	}
+	names := make([]string, 0, len(workflowPredicates))
+	for name := range workflowPredicates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return c, fmt.Errorf("unknown condition %q: use branch == <name>, branch != <name> or one of %s", c.text, strings.Join(names, ", "))
+}
+
+// Holds reports whether the condition is true in state.
+func (c WorkflowCondition) Holds(state RepoState) bool {
+	var result bool
+	if c.predicate != nil {
+		result = c.predicate(state)
+	} else {
+		result, _ = path.Match(c.pattern, state.Branch)
+	}
+	return result != c.negate
+}
+
+// String returns the condition as written.
+func (c WorkflowCondition) String() string {
+	return c.text
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// BuiltinTemplateSource is the Source of the templates ggc ships.
+const BuiltinTemplateSource = "built-in"
+
+// WorkflowTemplate is a ready-made workflow that `ggc workflow add` copies
+// into the workflows section. User templates are YAML files in
+// WorkflowTemplateDir with a description and steps:
+//
+//	description: Tag and push a release candidate
+//	steps:
+//	  - tag create <version>-rc
+//	  - run: tag push
+//	    confirm: true
+type WorkflowTemplate struct {
+	Name        string         `yaml:"-"`
+	Description string         `yaml:"description"`
+	Steps       []WorkflowStep `yaml:"steps"`
+	// Source is BuiltinTemplateSource or the file the template was read
+	// from.
+	Source string `yaml:"-"`
+}
+
+// builtinWorkflowTemplates are the templates ggc ships.
+var builtinWorkflowTemplates = []WorkflowTemplate{
+	{
+		Name:        "feature-start",
+		Description: "Start a feature branch from the latest base branch",
+		Steps: []WorkflowStep{
+			{Run: "switch <base>"},
+			{Run: "pull current"},
+			{Run: "branch create feature/<name>"},
+		},
+	},
+	{
+		Name:        "feature-ship",
+		Description: "Commit the staged changes, push the branch and open a pull request",
+		Steps: []WorkflowStep{
+			{Run: "commit <message>", When: "has_staged_changes"},
+			{Run: "push current"},
+			{Run: "pr create", ContinueOnError: true},
+		},
+	},
+	{
+		Name:        "hotfix-release",
+		Description: "Commit a fix on the release branch, push it and cut a patch release",
+		Steps: []WorkflowStep{
+			{Run: "commit <message>", When: "has_staged_changes"},
+			{Run: "push current"},
+			{Run: "release --dry-run"},
+			{Run: "release --patch", Confirm: true},
+		},
+	},
+	{
+		Name:        "sync-fork",
+		Description: "Bring a fork's branch, which tracks the upstream repository, up to date and push it to origin",
+		Steps: []WorkflowStep{
+			{Run: "switch <branch>"},
+			{Run: "sync --no-push"},
+			{Run: "push current"},
+		},
+	},
+	{
+		Name:        "cleanup",
+		Description: "Prune deleted remote branches and delete merged local ones",
+		Steps: []WorkflowStep{
+			{Run: "fetch prune"},
+			{Run: "branch delete merged", Confirm: true},
+		},
+	},
+}
+
+// WorkflowTemplateDir is where user workflow templates live:
+// ~/.config/ggc/workflows.
+func WorkflowTemplateDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "ggc", "workflows"), nil
+}
+
+// LoadWorkflowTemplates returns the built-in templates and the *.yaml
+// files in dir, ordered by name. A user template replaces the built-in one
+// of the same name. Files that cannot be read or are invalid are left out
+// and reported in the error, so the rest are still usable.
+func LoadWorkflowTemplates(dir string) ([]WorkflowTemplate, error) {
+	byName := make(map[string]WorkflowTemplate, len(builtinWorkflowTemplates))
+	for _, t := range builtinWorkflowTemplates {
+		t.Source = BuiltinTemplateSource
+		byName[t.Name] = t
+	}
+
+	var errs []error
+	if dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, file := range files {
+			t, err := readWorkflowTemplate(file)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			byName[t.Name] = t
+		}
+	}
+
+	templates := make([]WorkflowTemplate, 0, len(byName))
+	for _, t := range byName {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, errors.Join(errs...)
+}
+
+// readWorkflowTemplate reads a user template; its name is the file name
+// without .yaml.
+func readWorkflowTemplate(file string) (WorkflowTemplate, error) {
+	t := WorkflowTemplate{
+		Name:   strings.TrimSuffix(filepath.Base(file), ".yaml"),
+		Source: file,
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return t, err
+	}
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return t, fmt.Errorf("%s: %w", file, err)
+	}
+	c := Config{Workflows: map[string][]WorkflowStep{t.Name: t.Steps}}
+	if err := c.validateWorkflows(); err != nil {
+		return t, fmt.Errorf("%s: %w", file, err)
+	}
+	return t, nil
+}
+
+// Placeholders returns the <name> placeholders of the template's steps,
+// in the order they first appear.
+func (t WorkflowTemplate) Placeholders() []string {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(run string) {
+		for _, match := range angleBracketPlaceholderRe.FindAllString(run, -1) {
+			name := strings.Trim(match, "<>")
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	for _, step := range t.Steps {
+		add(step.Run)
+		for _, sub := range step.Steps {
+			add(sub.Run)
+		}
+	}
+	return names
+}
+
+// Instantiate returns the template's steps with the placeholders in values
+// filled in. Placeholders without a value are kept, so they are asked for
+// when the workflow runs.
+func (t WorkflowTemplate) Instantiate(values map[string]string) []WorkflowStep {
+	fill := func(run string) string {
+		for name, value := range values {
+			run = strings.ReplaceAll(run, "<"+name+">", value)
+		}
+		return run
+	}
+	steps := make([]WorkflowStep, len(t.Steps))
+	for i, step := range t.Steps {
+		step.Run = fill(step.Run)
+		if step.Steps != nil {
+			subs := make([]ParallelStep, len(step.Steps))
+			for j, sub := range step.Steps {
+				sub.Run = fill(sub.Run)
+				subs[j] = sub
+			}
+			step.Steps = subs
+		}
+		steps[i] = step
+	}
+	return steps
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinWorkflowTemplatesAreValid(t *testing.T) {
+	for _, tmpl := range builtinWorkflowTemplates {
+		if tmpl.Description == "" || len(tmpl.Steps) == 0 {
+			t.Errorf("template %s needs a description and steps", tmpl.Name)
+		}
+		for _, step := range tmpl.Steps {
+			if step.When == "" {
+				continue
+			}
+			if _, err := ParseWorkflowCondition(step.When); err != nil {
+				t.Errorf("template %s: %v", tmpl.Name, err)
+			}
+		}
+	}
+}
+
+func TestLoadWorkflowTemplates(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"cleanup.yaml": "description: My cleanup\nsteps:\n  - fetch prune\n",
+		"bad.yaml":     "description: x\nsteps: []\n",
+		"notes.txt":    "ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	templates, err := LoadWorkflowTemplates(dir)
+	if err == nil || !strings.Contains(err.Error(), "bad.yaml") {
+		t.Errorf("err = %v, want bad.yaml reported", err)
+	}
+	if len(templates) != len(builtinWorkflowTemplates) {
+		t.Fatalf("got %d templates, want %d", len(templates), len(builtinWorkflowTemplates))
+	}
+	for i, tmpl := range templates {
+		if i > 0 && templates[i-1].Name >= tmpl.Name {
+			t.Errorf("templates are not sorted: %s before %s", templates[i-1].Name, tmpl.Name)
+		}
+		if tmpl.Name == "cleanup" && (tmpl.Description != "My cleanup" || tmpl.Source != filepath.Join(dir, "cleanup.yaml")) {
+			t.Errorf("the user template should replace the built-in one: %+v", tmpl)
+		}
+	}
+}
+
+func TestWorkflowTemplateInstantiate(t *testing.T) {
+	tmpl := WorkflowTemplate{Steps: []WorkflowStep{
+		{Run: "switch <base>"},
+		{Run: "branch create feature/<name>", Confirm: true},
+		{Run: "rebase <base>"},
+	}}
+	if got := strings.Join(tmpl.Placeholders(), ","); got != "base,name" {
+		t.Errorf("Placeholders() = %s, want base,name", got)
+	}
+	steps := tmpl.Instantiate(map[string]string{"base": "main"})
+	if steps[0].Run != "switch main" || steps[1].Run != "branch create feature/<name>" || !steps[1].Confirm || steps[2].Run != "rebase main" {
+		t.Errorf("Instantiate() = %+v", steps)
+	}
+	if tmpl.Steps[0].Run != "switch <base>" {
+		t.Error("Instantiate() must not change the template")
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestWorkflowStepYAML(t *testing.T) {
+	var workflows map[string][]WorkflowStep
+	input := `
+ship:
+  - add .
+  - run: push current
+    when: branch != main
+    confirm: true
+    continue-on-error: true
+`
+	if err := yaml.Unmarshal([]byte(input), &workflows); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := []WorkflowStep{
+		{Run: "add ."},
+		{Run: "push current", When: "branch != main", Confirm: true, ContinueOnError: true},
+	}
+	got := workflows["ship"]
+	if len(got) != len(want) {
+		t.Fatalf("steps = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("step %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	out, err := yaml.Marshal(workflows)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(out), "- add .\n") || !strings.Contains(string(out), "run: push current") {
+		t.Errorf("marshaled steps:\n%s", out)
+	}
+}
+
+func TestWorkflowStepYAMLUnknownKey(t *testing.T) {
+	var steps []WorkflowStep
+	err := yaml.Unmarshal([]byte("- run: push\n  if: clean\n"), &steps)
+	if err == nil || !strings.Contains(err.Error(), `unknown workflow step key "if"`) {
+		t.Errorf("err = %v, want an unknown key error", err)
+	}
+}
+
+func TestWorkflowConditionHolds(t *testing.T) {
+	dirty := RepoState{Branch: "feature/x", Staged: 1, Unstaged: 0, Ahead: 2}
+	main := RepoState{Branch: "main", Unstaged: 3, Behind: 1}
+	tests := []struct {
+		when        string
+		dirty, main bool
+	}{
+		{"has_staged_changes", true, false},
+		{"has_unstaged_changes", false, true},
+		{"has_changes", true, true},
+		{"clean", false, false},
+		{"ahead", true, false},
+		{"behind", false, true},
+		{"!ahead", false, true},
+		{"branch == main", false, true},
+		{"branch != main", true, false},
+		{"branch == 'feature/*'", true, false},
+		{"! branch == feature/*", false, true},
+	}
+	for _, tt := range tests {
+		c, err := ParseWorkflowCondition(tt.when)
+		if err != nil {
+			t.Errorf("ParseWorkflowCondition(%q): %v", tt.when, err)
+			continue
+		}
+		if got := c.Holds(dirty); got != tt.dirty {
+			t.Errorf("%q on %s = %v, want %v", tt.when, dirty.Branch, got, tt.dirty)
+		}
+		if got := c.Holds(main); got != tt.main {
+			t.Errorf("%q on %s = %v, want %v", tt.when, main.Branch, got, tt.main)
+		}
+	}
+}
+
+func TestParseWorkflowConditionErrors(t *testing.T) {
+	for _, when := range []string{"", "dirty", "branch", "branch = main", "branch == [", "branch ~= main"} {
+		if _, err := ParseWorkflowCondition(when); err == nil {
+			t.Errorf("ParseWorkflowCondition(%q) succeeded, want an error", when)
+		}
+	}
+}
+
+func TestWorkflowStepYAMLParallel(t *testing.T) {
+	var steps []WorkflowStep
+	input := `
+- parallel: true
+  continue-on-error: true
+  steps:
+    - status short
+    - run: log simple
+      when: ahead
+`
+	if err := yaml.Unmarshal([]byte(input), &steps); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := WorkflowStep{
+		Parallel:        true,
+		ContinueOnError: true,
+		Steps:           []ParallelStep{{Run: "status short"}, {Run: "log simple", When: "ahead"}},
+	}
+	if len(steps) != 1 || !reflect.DeepEqual(steps[0], want) {
+		t.Fatalf("steps = %+v, want %+v", steps, want)
+	}
+	if got, want := steps[0].String(), "parallel: status short | log simple (when ahead) (continue on error)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	err := yaml.Unmarshal([]byte("- parallel: true\n  steps:\n    - run: push\n      confirm: true\n"), &steps)
+	if err == nil || !strings.Contains(err.Error(), `unknown workflow step key "confirm"`) {
+		t.Errorf("err = %v, want confirm rejected in a parallel group", err)
+	}
+}
@@ -47,7 +47,7 @@ func TestValidateWorkflows_Valid(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := &Config{Workflows: tt.workflows}
+			c := &Config{Workflows: workflowSteps(tt.workflows)}
 			if err := c.validateWorkflows(); err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
@@ -100,7 +100,7 @@ func TestValidateWorkflows_Invalid(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := &Config{Workflows: tt.workflows}
+			c := &Config{Workflows: workflowSteps(tt.workflows)}
 			err := c.validateWorkflows()
 			if err == nil {
 				t.Error("expected error, got nil")
@@ -112,3 +112,69 @@ func TestValidateWorkflows_Invalid(t *testing.T) {
 		})
 	}
 }
+
+// workflowSteps turns command strings into steps without options.
+func workflowSteps(workflows map[string][]string) map[string][]WorkflowStep {
+	if workflows == nil {
+		return nil
+	}
+	result := make(map[string][]WorkflowStep, len(workflows))
+	for name, commands := range workflows {
+		steps := make([]WorkflowStep, len(commands))
+		for i, command := range commands {
+			steps[i] = WorkflowStep{Run: command}
+		}
+		result[name] = steps
+	}
+	return result
+}
+
+func TestValidateWorkflows_Conditions(t *testing.T) {
+	valid := &Config{Workflows: map[string][]WorkflowStep{
+		"ship": {
+			{Run: "commit <message>", When: "has_staged_changes"},
+			{Run: "push current", When: "branch != main", Confirm: true, ContinueOnError: true},
+		},
+	}}
+	if err := valid.validateWorkflows(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := &Config{Workflows: map[string][]WorkflowStep{
+		"ship": {{Run: "push current", When: "on_main"}},
+	}}
+	err := invalid.validateWorkflows()
+	if err == nil || !strings.Contains(err.Error(), "workflows.ship[0].when") || !strings.Contains(err.Error(), "unknown condition") {
+		t.Errorf("error = %v, want an unknown condition in workflows.ship[0].when", err)
+	}
+}
+
+func TestValidateWorkflows_ParallelGroups(t *testing.T) {
+	valid := &Config{Workflows: map[string][]WorkflowStep{
+		"check": {{Parallel: true, Steps: []ParallelStep{{Run: "status"}, {Run: "fetch prune", When: "clean"}}}},
+	}}
+	if err := valid.validateWorkflows(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		step    WorkflowStep
+		wantMsg string
+	}{
+		{"steps without parallel", WorkflowStep{Steps: []ParallelStep{{Run: "status"}}}, "only allowed in a group with parallel: true"},
+		{"group with a command", WorkflowStep{Parallel: true, Run: "status", Steps: []ParallelStep{{Run: "status"}}}, "not a command of its own"},
+		{"empty group", WorkflowStep{Parallel: true}, "at least one step"},
+		{"unsafe group step", WorkflowStep{Parallel: true, Steps: []ParallelStep{{Run: "status; rm -rf /"}}}, "workflows.check[0].steps[0]"},
+		{"bad group condition", WorkflowStep{Parallel: true, Steps: []ParallelStep{{Run: "status", When: "sunny"}}}, "workflows.check[0].steps[0].when"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{Workflows: map[string][]WorkflowStep{"check": {tt.step}}}
+			err := c.validateWorkflows()
+			if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Package difftool renders diffs for display. Diffs are piped through an
+// external highlighter such as delta or diff-so-fancy when one is
+// configured and installed, and colored by ggc itself otherwise.
+package difftool
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// Highlighter renders diff text with the configured tool.
+type Highlighter struct {
+	name     string
+	args     []string
+	lookPath func(string) (string, error)
+	command  func(name string, args ...string) *exec.Cmd
+}
+
+// New returns a highlighter for tool, a command name optionally followed
+// by arguments (e.g. "delta --side-by-side"). An empty tool selects the
+// built-in colorizer.
+func New(tool string) *Highlighter {
+	h := &Highlighter{lookPath: exec.LookPath, command: exec.Command}
+	if fields := strings.Fields(tool); len(fields) > 0 {
+		h.name, h.args = fields[0], fields[1:]
+	}
+	return h
+}
+
+// External reports whether diffs go through an installed external tool.
+func (h *Highlighter) External() bool {
+	if h.name == "" {
+		return false
+	}
+	_, err := h.lookPath(h.name)
+	return err == nil
+}
+
+// Render writes diff to w. Plain output (see ui.ColorEnabled) gets the diff
+// unchanged; otherwise it goes through the external tool, or through
+// Colorize when the tool is not set or not installed.
+func (h *Highlighter) Render(w io.Writer, diff string) error {
+	if !ui.ColorEnabled(w) {
+		_, err := io.WriteString(w, diff)
+		return err
+	}
+	if !h.External() {
+		_, err := io.WriteString(w, Colorize(diff, ui.NewANSIColors()))
+		return err
+	}
+	cmd := h.command(h.name, h.args...)
+	cmd.Stdin = strings.NewReader(diff)
+	cmd.Stdout = w
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("diff tool %s: %w", h.name, err)
+	}
+	return nil
+}
+
+// Highlight returns the external tool's rendering of diff, for views that
+// draw the result themselves. ok is false when no external tool is in use.
+func (h *Highlighter) Highlight(diff string) (out string, ok bool, err error) {
+	if !h.External() {
+		return "", false, nil
+	}
+	var buf bytes.Buffer
+	cmd := h.command(h.name, h.args...)
+	cmd.Stdin = strings.NewReader(diff)
+	cmd.Stdout = &buf
+	if err := cmd.Run(); err != nil {
+		return "", true, fmt.Errorf("diff tool %s: %w", h.name, err)
+	}
+	return buf.String(), true, nil
+}
+
+// Colorize colors unified diff text line by line: file headers bold, hunk
+// headers cyan, additions green and removals red. Commit headers from
+// git show are yellow.
+func Colorize(diff string, c *ui.ANSIColors) string {
+	if c.Reset == "" {
+		return diff
+	}
+	lines := strings.SplitAfter(diff, "\n")
+	var b strings.Builder
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		text := strings.TrimSuffix(line, "\n")
+		color := lineColor(text, c)
+		if color == "" {
+			b.WriteString(line)
+			continue
+		}
+		b.WriteString(color + text + c.Reset + line[len(text):])
+	}
+	return b.String()
+}
+
+func lineColor(line string, c *ui.ANSIColors) string {
+	switch {
+	case strings.HasPrefix(line, "diff --git "), strings.HasPrefix(line, "index "),
+		strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "+++ "):
+		return c.Bold
+	case strings.HasPrefix(line, "commit "):
+		return c.Yellow
+	case strings.HasPrefix(line, "@@"):
+		return c.Cyan
+	case strings.HasPrefix(line, "+"):
+		return c.Green
+	case strings.HasPrefix(line, "-"):
+		return c.Red
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package difftool
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+const sampleDiff = "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1 +1 @@\n-old\n+new\n context\n"
+
+func fakeTool(h *Highlighter, installed bool) *[]string {
+	var got []string
+	h.lookPath = func(name string) (string, error) {
+		if !installed {
+			return "", errors.New("not found")
+		}
+		return "/usr/bin/" + name, nil
+	}
+	h.command = func(name string, args ...string) *exec.Cmd {
+		got = append([]string{name}, args...)
+		return exec.Command("sed", "s/^/| /")
+	}
+	return &got
+}
+
+func TestNew(t *testing.T) {
+	h := New("  delta --side-by-side ")
+	if h.name != "delta" || strings.Join(h.args, " ") != "--side-by-side" {
+		t.Errorf("New() = %q %v", h.name, h.args)
+	}
+	if New("").External() {
+		t.Error("an empty tool should not be external")
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Cleanup(func() { ui.SetColorMode(ui.ColorAuto) })
+
+	t.Run("plain output skips the tool", func(t *testing.T) {
+		ui.SetColorMode(ui.ColorNever)
+		h := New("delta")
+		got := fakeTool(h, true)
+		var buf bytes.Buffer
+		if err := h.Render(&buf, sampleDiff); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != sampleDiff || *got != nil {
+			t.Errorf("expected the diff unchanged, got %q (ran %v)", buf.String(), *got)
+		}
+	})
+
+	t.Run("external tool", func(t *testing.T) {
+		ui.SetColorMode(ui.ColorAlways)
+		h := New("delta --dark")
+		got := fakeTool(h, true)
+		var buf bytes.Buffer
+		if err := h.Render(&buf, sampleDiff); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(*got, " ") != "delta --dark" || !strings.HasPrefix(buf.String(), "| diff --git") {
+			t.Errorf("ran %v, output %q", *got, buf.String())
+		}
+	})
+
+	t.Run("missing tool falls back to Colorize", func(t *testing.T) {
+		ui.SetColorMode(ui.ColorAlways)
+		h := New("delta")
+		fakeTool(h, false)
+		var buf bytes.Buffer
+		if err := h.Render(&buf, sampleDiff); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), "\033[32m+new\033[0m\n") {
+			t.Errorf("expected built-in colors, got %q", buf.String())
+		}
+	})
+}
+
+func TestHighlight(t *testing.T) {
+	h := New("delta")
+	fakeTool(h, true)
+	out, ok, err := h.Highlight("+x\n")
+	if err != nil || !ok || out != "| +x\n" {
+		t.Errorf("Highlight() = %q, %v, %v", out, ok, err)
+	}
+
+	if _, ok, _ := New("").Highlight("+x\n"); ok {
+		t.Error("no tool configured should report ok=false")
+	}
+}
+
+func TestColorize(t *testing.T) {
+	c := ui.NewANSIColors()
+	got := Colorize(sampleDiff, c)
+	for _, want := range []string{
+		c.Bold + "--- a/a.go" + c.Reset,
+		c.Cyan + "@@ -1 +1 @@" + c.Reset,
+		c.Red + "-old" + c.Reset,
+		c.Green + "+new" + c.Reset,
+		"\n context\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Colorize() missing %q in %q", want, got)
+		}
+	}
+	if Colorize(sampleDiff, ui.NoColors()) != sampleDiff {
+		t.Error("an empty palette should leave the diff unchanged")
+	}
+}
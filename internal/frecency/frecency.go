@@ -0,0 +1,186 @@
+// Package frecency remembers how often and how recently each interactive
+// command was picked, so the interactive list can rank familiar commands
+// first. Unlike the history file, usage counts live in the user cache
+// directory and survive reboots: ranking only pays off once it has a few
+// weeks of picks to learn from.
+package frecency
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultMaxEntries caps how many commands are remembered. The lowest
+// scoring ones are forgotten first.
+const DefaultMaxEntries = 500
+
+// Usage is what the store knows about one command.
+type Usage struct {
+	// Count is how many times the command was picked.
+	Count int `json:"count"`
+	// Last is when it was last picked, in UTC.
+	Last time.Time `json:"last"`
+}
+
+// Table maps interactive command templates (e.g. "push current") to
+// their usage.
+type Table map[string]Usage
+
+// Score returns the frecency of command at now: its pick count weighted
+// by how recently it was last picked. Unknown commands score zero.
+func (t Table) Score(command string, now time.Time) float64 {
+	u, ok := t[command]
+	if !ok || u.Count <= 0 {
+		return 0
+	}
+	return float64(u.Count) * recencyWeight(now.Sub(u.Last))
+}
+
+// Add records one pick of command at now.
+func (t Table) Add(command string, now time.Time) {
+	u := t[command]
+	u.Count++
+	u.Last = now.UTC()
+	t[command] = u
+}
+
+// recencyWeight favors recent picks the way shell jumpers such as z do.
+func recencyWeight(age time.Duration) float64 {
+	switch {
+	case age < time.Hour:
+		return 4
+	case age < 24*time.Hour:
+		return 2
+	case age < 7*24*time.Hour:
+		return 1
+	default:
+		return 0.25
+	}
+}
+
+// Store is the persistence layer for the usage table. A zero-value Store
+// uses DefaultPath and DefaultMaxEntries.
+type Store struct {
+	// Path is the JSON file backing the store. When empty, DefaultPath
+	// is used lazily on the first call.
+	Path string
+	// MaxEntries caps the table size. Values <= 0 fall back to
+	// DefaultMaxEntries.
+	MaxEntries int
+}
+
+// DefaultPath returns the per-user usage file location under the user
+// cache directory.
+func DefaultPath() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locate user cache dir: %w", err)
+	}
+	return filepath.Join(base, "ggc", "frecency.json"), nil
+}
+
+func (s *Store) path() (string, error) {
+	if s.Path != "" {
+		return s.Path, nil
+	}
+	return DefaultPath()
+}
+
+func (s *Store) cap() int {
+	if s.MaxEntries > 0 {
+		return s.MaxEntries
+	}
+	return DefaultMaxEntries
+}
+
+// Load reads the usage table. A missing file yields an empty table.
+func (s *Store) Load() (Table, error) {
+	path, err := s.path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Table{}, nil
+		}
+		return nil, err
+	}
+	table := Table{}
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return table, nil
+}
+
+// Record adds one pick of command at now and writes the table back. It
+// re-reads the file first so concurrent ggc processes do not drop each
+// other's picks.
+func (s *Store) Record(command string, now time.Time) error {
+	table, err := s.Load()
+	if err != nil {
+		// A corrupt file is replaced rather than blocking ranking forever.
+		table = Table{}
+	}
+	table.Add(command, now)
+	s.prune(table, now)
+	return s.save(table)
+}
+
+// prune forgets the lowest scoring commands once the table is over cap.
+func (s *Store) prune(table Table, now time.Time) {
+	excess := len(table) - s.cap()
+	if excess <= 0 {
+		return
+	}
+	commands := make([]string, 0, len(table))
+	for cmd := range table {
+		commands = append(commands, cmd)
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		si, sj := table.Score(commands[i], now), table.Score(commands[j], now)
+		if si != sj {
+			return si < sj
+		}
+		return commands[i] < commands[j]
+	})
+	for _, cmd := range commands[:excess] {
+		delete(table, cmd)
+	}
+}
+
+// save atomically replaces the usage file with table.
+func (s *Store) save(table Table) error {
+	path, err := s.path()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(table)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".frecency-*.json")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,87 @@
+package frecency
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTable_Score(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	table := Table{
+		"push current": {Count: 3, Last: now.Add(-10 * time.Minute)},
+		"status":       {Count: 3, Last: now.Add(-30 * 24 * time.Hour)},
+		"pull current": {Count: 20, Last: now.Add(-3 * 24 * time.Hour)},
+	}
+
+	if got := table.Score("push current", now); got != 12 {
+		t.Errorf("recent score = %v, want 12", got)
+	}
+	if got := table.Score("status", now); got != 0.75 {
+		t.Errorf("old score = %v, want 0.75", got)
+	}
+	if table.Score("pull current", now) <= table.Score("push current", now) {
+		t.Error("frequent picks within the week should outrank a few recent ones")
+	}
+	if got := table.Score("log", now); got != 0 {
+		t.Errorf("unknown command score = %v, want 0", got)
+	}
+}
+
+func TestStore_RecordAndLoad(t *testing.T) {
+	store := &Store{Path: filepath.Join(t.TempDir(), "nested", "frecency.json")}
+	now := time.Now()
+
+	table, err := store.Load()
+	if err != nil || len(table) != 0 {
+		t.Fatalf("Load() on a missing file = %v, %v", table, err)
+	}
+
+	for range 2 {
+		if err := store.Record("push current", now); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	table, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if u := table["push current"]; u.Count != 2 || !u.Last.Equal(now.UTC()) {
+		t.Errorf("usage = %+v", u)
+	}
+}
+
+func TestStore_RecordReplacesCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frecency.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store := &Store{Path: path}
+	if _, err := store.Load(); err == nil {
+		t.Error("expected Load() to report a corrupt file")
+	}
+	if err := store.Record("status", time.Now()); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if table, err := store.Load(); err != nil || table["status"].Count != 1 {
+		t.Errorf("Load() = %v, %v", table, err)
+	}
+}
+
+func TestStore_PrunesLowestScores(t *testing.T) {
+	store := &Store{Path: filepath.Join(t.TempDir(), "frecency.json"), MaxEntries: 2}
+	now := time.Now()
+	for _, cmd := range []string{"a", "b", "b", "c"} {
+		if err := store.Record(cmd, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	table, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table) != 2 || table["b"].Count != 2 {
+		t.Errorf("table = %v, want b kept with one other entry", table)
+	}
+}
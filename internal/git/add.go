@@ -1,6 +1,8 @@
 package git
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"strings"
 )
@@ -9,6 +11,7 @@ import (
 type Stager interface {
 	Add(files ...string) error
 	AddInteractive() error
+	UnstagedFiles(paths ...string) ([]string, error)
 }
 
 // Add adds files to the staging area.
@@ -21,7 +24,7 @@ func (c *Client) Add(files ...string) error {
 	cmd := c.execCommand("git", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("add files", "git "+strings.Join(args, " "), err)
 	}
 	return nil
@@ -33,8 +36,61 @@ func (c *Client) AddInteractive() error {
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("interactive add", "git add -p", err)
 	}
 	return nil
 }
+
+// UnstagedFiles lists modified and untracked files that `git add` would
+// pick up, honoring .gitignore, under paths when any are given.
+func (c *Client) UnstagedFiles(paths ...string) ([]string, error) {
+	args := []string{"ls-files", "-z", "--modified", "--others", "--exclude-standard"}
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return nil, NewOpError("list unstaged files", "git ls-files --modified --others --exclude-standard", err)
+	}
+	seen := make(map[string]bool)
+	var files []string
+	for _, f := range strings.Split(string(out), "\x00") {
+		// A modified file that is also deleted is listed twice.
+		if f == "" || seen[f] {
+			continue
+		}
+		seen[f] = true
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// IndexPatcher applies patches straight to the index, leaving the working
+// tree untouched. It backs hunk-level staging.
+type IndexPatcher interface {
+	ApplyToIndex(patch string, reverse bool) error
+}
+
+// ApplyToIndex runs `git apply --cached` with patch on stdin. With reverse
+// set the patch is unapplied, which unstages the hunks it contains. git's
+// complaint is folded into the error instead of being written to stderr so
+// callers drawing a full-screen view stay intact.
+func (c *Client) ApplyToIndex(patch string, reverse bool) error {
+	args := []string{"apply", "--cached"}
+	if reverse {
+		args = append(args, "--reverse")
+	}
+	args = append(args, "-")
+	cmd := c.execCommand("git", args...)
+	cmd.Stdin = strings.NewReader(patch)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := c.run(cmd); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return NewOpError("apply patch to index", "git "+strings.Join(args, " "), err)
+	}
+	return nil
+}
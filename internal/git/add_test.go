@@ -1,8 +1,11 @@
 package git
 
 import (
+	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
+	"strings"
 	"testing"
 )
 
@@ -80,3 +83,97 @@ func TestClient_AddInteractive(t *testing.T) {
 		t.Errorf("AddInteractive() gotArgs = %v, want %v", gotArgs, wantArgs)
 	}
 }
+
+func TestClient_UnstagedFiles(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("printf", "a.go\\0b c.go\\0a.go\\0")
+		},
+	}
+
+	files, err := client.UnstagedFiles()
+	if err != nil {
+		t.Fatalf("UnstagedFiles() error = %v", err)
+	}
+	wantArgs := []string{"git", "ls-files", "-z", "--modified", "--others", "--exclude-standard"}
+	if !slices.Equal(gotArgs, wantArgs) {
+		t.Errorf("UnstagedFiles() gotArgs = %v, want %v", gotArgs, wantArgs)
+	}
+	if want := []string{"a.go", "b c.go"}; !slices.Equal(files, want) {
+		t.Errorf("UnstagedFiles() = %q, want %q", files, want)
+	}
+}
+
+func TestClient_UnstagedFiles_Paths(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("printf", "")
+		},
+	}
+
+	if _, err := client.UnstagedFiles(":(top)apps"); err != nil {
+		t.Fatalf("UnstagedFiles() error = %v", err)
+	}
+	wantArgs := []string{"git", "ls-files", "-z", "--modified", "--others", "--exclude-standard", "--", ":(top)apps"}
+	if !slices.Equal(gotArgs, wantArgs) {
+		t.Errorf("UnstagedFiles() gotArgs = %v, want %v", gotArgs, wantArgs)
+	}
+}
+
+func TestClient_ApplyToIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		reverse  bool
+		wantArgs []string
+	}{
+		{name: "stage", wantArgs: []string{"git", "apply", "--cached", "-"}},
+		{name: "unstage", reverse: true, wantArgs: []string{"git", "apply", "--cached", "--reverse", "-"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			stdin := filepath.Join(t.TempDir(), "stdin")
+			client := &Client{
+				execCommand: func(name string, args ...string) *exec.Cmd {
+					gotArgs = append([]string{name}, args...)
+					return exec.Command("sh", "-c", `cat > "$0"`, stdin)
+				},
+			}
+
+			if err := client.ApplyToIndex("the patch\n", tt.reverse); err != nil {
+				t.Fatalf("ApplyToIndex() error = %v", err)
+			}
+			if !slices.Equal(gotArgs, tt.wantArgs) {
+				t.Errorf("ApplyToIndex() gotArgs = %v, want %v", gotArgs, tt.wantArgs)
+			}
+			got, err := os.ReadFile(stdin)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != "the patch\n" {
+				t.Errorf("patch should be passed on stdin, got %q", got)
+			}
+		})
+	}
+}
+
+func TestClient_ApplyToIndex_Error(t *testing.T) {
+	client := &Client{
+		execCommand: func(string, ...string) *exec.Cmd {
+			return exec.Command("sh", "-c", "echo 'error: patch does not apply' >&2; exit 1")
+		},
+	}
+
+	err := client.ApplyToIndex("x", false)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "patch does not apply") {
+		t.Errorf("error should carry git's message, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlameReader reads line authorship.
+type BlameReader interface {
+	Blame(rev, path string) ([]BlameLine, error)
+}
+
+// BlameLine is one line of a file with the commit that last changed it.
+type BlameLine struct {
+	Hash       string
+	Line       int // line number in the blamed revision
+	OrigLine   int // line number in Hash
+	Text       string
+	Author     string
+	AuthorTime time.Time
+	Summary    string
+	Filename   string // path of the file in Hash
+	Previous   string // the commit before Hash that touched the file; empty at its root
+	PrevFile   string // path of the file in Previous
+}
+
+// Uncommitted reports whether the line has not been committed yet.
+func (l BlameLine) Uncommitted() bool {
+	return strings.Trim(l.Hash, "0") == ""
+}
+
+// Blame returns the authorship of every line of path at rev, or of the
+// working tree copy when rev is empty.
+func (c *Client) Blame(rev, path string) ([]BlameLine, error) {
+	args := []string{"blame", "--porcelain"}
+	if rev != "" {
+		args = append(args, rev)
+	}
+	args = append(args, "--", path)
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return nil, NewOpError("blame", "git "+strings.Join(args, " "), err)
+	}
+	return ParseBlamePorcelain(string(out)), nil
+}
+
+// ParseBlamePorcelain parses `git blame --porcelain` output. Porcelain
+// describes each commit once, on its first line, so later lines take the
+// details from there.
+func ParseBlamePorcelain(output string) []BlameLine {
+	var lines []BlameLine
+	commits := make(map[string]BlameLine)
+	var cur BlameLine
+	header := true
+	for _, raw := range strings.Split(output, "\n") {
+		if text, ok := strings.CutPrefix(raw, "\t"); ok {
+			cur.Text = text
+			commits[cur.Hash] = cur
+			lines = append(lines, cur)
+			header = true
+			continue
+		}
+		if header {
+			fields := strings.Fields(raw)
+			if len(fields) < 3 {
+				continue
+			}
+			cur = commits[fields[0]]
+			cur.Hash = fields[0]
+			cur.OrigLine, _ = strconv.Atoi(fields[1])
+			cur.Line, _ = strconv.Atoi(fields[2])
+			header = false
+			continue
+		}
+		key, value, _ := strings.Cut(raw, " ")
+		switch key {
+		case "author":
+			cur.Author = value
+		case "author-time":
+			if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+				cur.AuthorTime = time.Unix(secs, 0)
+			}
+		case "summary":
+			cur.Summary = value
+		case "filename":
+			cur.Filename = value
+		case "previous":
+			cur.Previous, cur.PrevFile, _ = strings.Cut(value, " ")
+		}
+	}
+	return lines
+}
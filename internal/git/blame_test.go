@@ -0,0 +1,78 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const blamePorcelain = `aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa 1 1 2
+author Alice
+author-mail <alice@example.com>
+author-time 1700000000
+author-tz +0000
+committer Alice
+committer-mail <alice@example.com>
+committer-time 1700000000
+committer-tz +0000
+summary Add greeting
+previous bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb old.txt
+filename hello.txt
+	hello
+aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa 2 2
+filename hello.txt
+	world
+0000000000000000000000000000000000000000 3 3 1
+author Not Committed Yet
+author-time 1700000100
+summary Version of hello.txt from hello.txt
+filename hello.txt
+	 local edit
+`
+
+func TestClient_Blame(t *testing.T) {
+	var gotArgs []string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return fakeExecCommand(blamePorcelain)
+		},
+	}
+
+	lines, err := c.Blame("HEAD~1", "hello.txt")
+	if err != nil {
+		t.Fatalf("Blame() error = %v", err)
+	}
+	if want := []string{"git", "blame", "--porcelain", "HEAD~1", "--", "hello.txt"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %+v", len(lines), lines)
+	}
+	second := lines[1]
+	if second.Text != "world" || second.Line != 2 || second.Author != "Alice" || second.Summary != "Add greeting" ||
+		second.AuthorTime.Unix() != 1700000000 || second.Previous != strings.Repeat("b", 40) || second.PrevFile != "old.txt" {
+		t.Errorf("second line = %+v", second)
+	}
+	if lines[0].Uncommitted() || !lines[2].Uncommitted() || lines[2].Text != " local edit" {
+		t.Errorf("uncommitted line = %+v", lines[2])
+	}
+}
+
+func TestClient_Blame_WorkingTree(t *testing.T) {
+	var gotArgs []string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return helperCommand(t, "", errors.New("no such path"))
+		},
+	}
+	if _, err := c.Blame("", "missing.txt"); err == nil {
+		t.Error("Blame() should fail when git blame fails")
+	}
+	if want := []string{"git", "blame", "--porcelain", "--", "missing.txt"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+}
@@ -34,6 +34,7 @@ type BranchWriter interface {
 	RenameBranch(old, newName string) error
 	MoveBranch(branch, commit string) error
 	SetUpstreamBranch(branch, upstream string) error
+	UnsetUpstreamBranch(branch string) error
 }
 
 // BranchOps is a pragmatic composite for the branch command dependencies.
@@ -67,7 +68,7 @@ func (c *Client) normalizeBranchName(name string) (string, error) {
 		return "", fmt.Errorf("branch name cannot be empty")
 	}
 	cmd := c.execCommand("git", "check-ref-format", "--branch", trimmed)
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return "", fmt.Errorf("invalid branch name %q: %w", trimmed, err)
 	}
 	return trimmed, nil
@@ -81,8 +82,14 @@ func (c *Client) ValidateBranchName(name string) error {
 
 // ListLocalBranches lists local branches.
 func (c *Client) ListLocalBranches() ([]string, error) {
+	if names, ok, err := readRefs(c, func(r *refDir) ([]string, error) { return r.refNames("refs/heads/") }); ok {
+		if err != nil {
+			return nil, NewOpError("list local branches", "read refs/heads", err)
+		}
+		return names, nil
+	}
 	cmd := c.execCommand("git", "branch", "--format", "%(refname:short)")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return nil, NewOpError("list local branches", "git branch --format %(refname:short)", err)
 	}
@@ -92,8 +99,21 @@ func (c *Client) ListLocalBranches() ([]string, error) {
 
 // ListRemoteBranches lists remote branches.
 func (c *Client) ListRemoteBranches() ([]string, error) {
+	if names, ok, err := readRefs(c, func(r *refDir) ([]string, error) { return r.refNames("refs/remotes/") }); ok {
+		if err != nil {
+			return nil, NewOpError("list remote branches", "read refs/remotes", err)
+		}
+		// Exclude symbolic refs such as origin/HEAD.
+		filtered := []string{}
+		for _, name := range names {
+			if !strings.HasSuffix(name, "/HEAD") {
+				filtered = append(filtered, name)
+			}
+		}
+		return filtered, nil
+	}
 	cmd := c.execCommand("git", "branch", "-r", "--format", "%(refname:short)")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return nil, NewOpError("list remote branches", "git branch -r --format %(refname:short)", err)
 	}
@@ -114,7 +134,7 @@ func (c *Client) CheckoutBranch(name string) error {
 	cmd := c.execCommand("git", "checkout", name)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("checkout branch", "git checkout "+name, err)
 	}
 	return nil
@@ -130,7 +150,7 @@ func (c *Client) CheckoutNewBranch(name string) error {
 	cmd := c.execCommand("git", "checkout", "-b", normalized)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("checkout new branch", fmt.Sprintf("git checkout -b %s", normalized), err)
 	}
 	return nil
@@ -146,7 +166,7 @@ func (c *Client) CheckoutNewBranchFromRemote(localBranch, remoteBranch string) e
 	cmd := c.execCommand("git", "checkout", "-b", normalizedLocal, "--track", remoteBranch)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("checkout new branch from remote", fmt.Sprintf("git checkout -b %s --track %s", normalizedLocal, remoteBranch), err)
 	}
 	return nil
@@ -162,16 +182,32 @@ func (c *Client) DeleteBranch(name string) error {
 	cmd := c.execCommand("git", "branch", "-d", normalized)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("delete branch", "git branch -d "+normalized, err)
 	}
 	return nil
 }
 
+// CreateBranchAt creates a branch pointing at commit without checking it out.
+func (c *Client) CreateBranchAt(name, commit string) error {
+	normalized, err := c.normalizeBranchName(name)
+	if err != nil {
+		return err
+	}
+
+	cmd := c.execCommand("git", "branch", normalized, commit)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("create branch", fmt.Sprintf("git branch %s %s", normalized, commit), err)
+	}
+	return nil
+}
+
 // ListMergedBranches lists branches that have been merged.
 func (c *Client) ListMergedBranches() ([]string, error) {
 	cmd := c.execCommand("git", "branch", "--merged")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return nil, NewOpError("list merged branches", "git branch --merged", err)
 	}
@@ -202,7 +238,7 @@ func (c *Client) RenameBranch(old, newName string) error {
 	cmd := c.execCommand("git", "branch", "-m", trimmedOld, normalizedNew)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("rename branch", fmt.Sprintf("git branch -m %s %s", trimmedOld, normalizedNew), err)
 	}
 	return nil
@@ -222,7 +258,7 @@ func (c *Client) MoveBranch(branch, commit string) error {
 	cmd := c.execCommand("git", "branch", "-f", normalized, trimmedCommit)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("move branch", fmt.Sprintf("git branch -f %s %s", normalized, trimmedCommit), err)
 	}
 	return nil
@@ -239,19 +275,37 @@ func (c *Client) SetUpstreamBranch(branch, upstream string) error {
 		return err
 	}
 
+	defer c.InvalidateStatusCache()
 	cmd := c.execCommand("git", "branch", "-u", trimmedUpstream, normalizedBranch)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("set upstream branch", fmt.Sprintf("git branch -u %s %s", trimmedUpstream, normalizedBranch), err)
 	}
 	return nil
 }
 
+// UnsetUpstreamBranch stops branch tracking its upstream (git branch
+// --unset-upstream <branch>).
+func (c *Client) UnsetUpstreamBranch(branch string) error {
+	normalizedBranch, err := c.normalizeBranchName(branch)
+	if err != nil {
+		return err
+	}
+	defer c.InvalidateStatusCache()
+	cmd := c.execCommand("git", "branch", "--unset-upstream", normalizedBranch)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("unset upstream branch", "git branch --unset-upstream "+normalizedBranch, err)
+	}
+	return nil
+}
+
 // ListBranchesVerbose lists branches with verbose info (parses `git branch -vv`).
 func (c *Client) ListBranchesVerbose() ([]BranchInfo, error) {
 	cmd := c.execCommand("git", "branch", "-vv")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return nil, NewOpError("list branches verbose", "git branch -vv", err)
 	}
@@ -318,7 +372,7 @@ func (c *Client) buildBranchInfoFallback(branch string) (*BranchInfo, error) {
 // getBranchSHA gets the SHA for a branch
 func (c *Client) getBranchSHA(branch string) (string, error) {
 	shaCmd := c.execCommand("git", "rev-parse", "--short", branch)
-	shaOut, shaErr := shaCmd.Output()
+	shaOut, shaErr := c.output(shaCmd)
 	if shaErr != nil {
 		return "", NewOpError("get branch info", fmt.Sprintf("git rev-parse --short %s", branch), shaErr)
 	}
@@ -328,7 +382,7 @@ func (c *Client) getBranchSHA(branch string) (string, error) {
 // getBranchLastCommitMsg gets the last commit message for a branch
 func (c *Client) getBranchLastCommitMsg(branch string) (string, error) {
 	msgCmd := c.execCommand("git", "log", "-1", "--pretty=%s", branch)
-	msgOut, msgErr := msgCmd.Output()
+	msgOut, msgErr := c.output(msgCmd)
 	if msgErr != nil {
 		return "", NewOpError("get branch info", fmt.Sprintf("git log -1 --pretty=%%s %s", branch), msgErr)
 	}
@@ -385,7 +439,7 @@ func (c *Client) SortBranches(by string) ([]string, error) {
 		sortKey = by // pass-through to git for flexibility
 	}
 	cmd := c.execCommand("git", "branch", "--sort="+sortKey, "--format", "%(refname:short)")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return nil, NewOpError("sort branches", fmt.Sprintf("git branch --sort=%s --format %%(refname:short)", sortKey), err)
 	}
@@ -395,7 +449,7 @@ func (c *Client) SortBranches(by string) ([]string, error) {
 // BranchesContaining lists branches containing a given commit.
 func (c *Client) BranchesContaining(commit string) ([]string, error) {
 	cmd := c.execCommand("git", "branch", "--contains", commit)
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return nil, NewOpError("branches containing commit", "git branch --contains "+commit, err)
 	}
@@ -411,6 +465,31 @@ func (c *Client) BranchesContaining(commit string) ([]string, error) {
 	return res, nil
 }
 
+// PublishedReader tells whether commits have been pushed.
+type PublishedReader interface {
+	RemoteBranchesContaining(commit string) ([]string, error)
+}
+
+// RemoteBranchesContaining lists the remote-tracking branches, such as
+// origin/main, that contain commit. A commit on one of them has been
+// pushed, as of the last fetch.
+func (c *Client) RemoteBranchesContaining(commit string) ([]string, error) {
+	cmd := c.execCommand("git", "branch", "-r", "--contains", commit, "--format=%(refname)")
+	out, err := c.output(cmd)
+	if err != nil {
+		return nil, NewOpError("remote branches containing commit", "git branch -r --contains "+commit, err)
+	}
+	var res []string
+	for _, ref := range splitBranchLines(out) {
+		name := strings.TrimPrefix(strings.TrimSpace(ref), "refs/remotes/")
+		// origin/HEAD is an alias of another branch.
+		if name != "" && !strings.HasSuffix(name, "/HEAD") {
+			res = append(res, name)
+		}
+	}
+	return res, nil
+}
+
 // parseBranchVVLine parses a single line of `git branch -vv` output into BranchInfo.
 func parseBranchVVLine(line string) BranchInfo {
 	// Example lines:
@@ -191,6 +191,21 @@ func TestClient_RenameMoveSetUpstream(t *testing.T) {
 		}
 	})
 
+	t.Run("unset_upstream_command", func(t *testing.T) {
+		c := &Client{execCommand: func(name string, arg ...string) *exec.Cmd {
+			if len(arg) > 0 && arg[0] == "check-ref-format" {
+				return exec.Command("true")
+			}
+			if name != "git" || strings.Join(arg, " ") != "branch --unset-upstream feat" {
+				t.Errorf("unexpected command: %s %v", name, arg)
+			}
+			return helperCommand(t, "", nil)
+		}}
+		if err := c.UnsetUpstreamBranch("feat"); err != nil {
+			t.Errorf("UnsetUpstreamBranch() error = %v", err)
+		}
+	})
+
 	t.Run("set_upstream_empty_remote", func(t *testing.T) {
 		c := &Client{execCommand: func(name string, arg ...string) *exec.Cmd {
 			t.Fatalf("execCommand should not be called for empty upstream")
@@ -354,6 +369,22 @@ func TestClient_BranchesContaining_EmptyOutput(t *testing.T) {
 	}
 }
 
+func TestClient_RemoteBranchesContaining(t *testing.T) {
+	c := &Client{execCommand: func(name string, arg ...string) *exec.Cmd {
+		if name != "git" || strings.Join(arg, " ") != "branch -r --contains HEAD --format=%(refname)" {
+			t.Errorf("unexpected command: %s %v", name, arg)
+		}
+		return fakeExecCommand("refs/remotes/origin/HEAD\nrefs/remotes/origin/main\nrefs/remotes/fork/feature/x\n")
+	}}
+	got, err := c.RemoteBranchesContaining("HEAD")
+	if err != nil {
+		t.Fatalf("RemoteBranchesContaining error: %v", err)
+	}
+	if !slices.Equal(got, []string{"origin/main", "fork/feature/x"}) {
+		t.Errorf("unexpected branches: %v", got)
+	}
+}
+
 func TestClient_CheckoutBranch(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,23 @@
+package git
+
+import (
+	"os"
+	"strings"
+)
+
+// CherryPickOps applies existing commits on top of HEAD.
+type CherryPickOps interface {
+	CherryPick(commits ...string) error
+}
+
+// CherryPick applies commits on top of HEAD, oldest first as given.
+func (c *Client) CherryPick(commits ...string) error {
+	args := append([]string{"cherry-pick"}, commits...)
+	cmd := c.execCommand("git", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("cherry-pick", "git "+strings.Join(args, " "), err)
+	}
+	return nil
+}
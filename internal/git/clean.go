@@ -7,45 +7,67 @@ import (
 )
 
 // CleanOps provides operations used by the clean command.
+// Paths limit the first four to those pathspecs.
 type CleanOps interface {
-	CleanFiles() error
-	CleanDirs() error
-	CleanDryRun() (string, error)
+	CleanFiles(paths ...string) error
+	CleanDirs(paths ...string) error
+	CleanDryRun(paths ...string) (string, error)
+	CleanIgnoredDryRun(paths ...string) (string, error)
 	CleanFilesForce(files []string) error
+	CleanPathsForce(paths []string) error
 }
 
-// CleanFiles cleans untracked files.
-func (c *Client) CleanFiles() error {
-	cmd := c.execCommand("git", "clean", "-fd")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return NewOpError("clean files", "git clean -fd", err)
-	}
-	return nil
+// CleanFiles cleans untracked files, under paths when any are given.
+func (c *Client) CleanFiles(paths ...string) error {
+	return c.runClean("clean files", "-fd", paths)
+}
+
+// CleanDirs cleans untracked and ignored files and directories, under
+// paths when any are given.
+func (c *Client) CleanDirs(paths ...string) error {
+	return c.runClean("clean directories", "-fdx", paths)
 }
 
-// CleanDirs cleans untracked directories.
-func (c *Client) CleanDirs() error {
-	cmd := c.execCommand("git", "clean", "-fdx")
+// runClean runs git clean with flags on the terminal.
+func (c *Client) runClean(op, flags string, paths []string) error {
+	args := cleanArgs(flags, paths)
+	cmd := c.execCommand("git", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return NewOpError("clean directories", "git clean -fdx", err)
+	if err := c.run(cmd); err != nil {
+		return NewOpError(op, "git "+strings.Join(args, " "), err)
 	}
 	return nil
 }
 
 // CleanDryRun shows what would be cleaned without actually cleaning.
-func (c *Client) CleanDryRun() (string, error) {
-	cmd := c.execCommand("git", "clean", "-nd")
-	out, err := cmd.Output()
+func (c *Client) CleanDryRun(paths ...string) (string, error) {
+	return c.cleanDryRun("-nd", paths)
+}
+
+// CleanIgnoredDryRun shows which ignored files and directories a clean
+// with -x would remove, without removing them.
+func (c *Client) CleanIgnoredDryRun(paths ...string) (string, error) {
+	return c.cleanDryRun("-ndX", paths)
+}
+
+func (c *Client) cleanDryRun(flags string, paths []string) (string, error) {
+	args := cleanArgs(flags, paths)
+	out, err := c.output(c.execCommand("git", args...))
 	if err != nil {
-		return "", NewOpError("clean dry run", "git clean -nd", err)
+		return "", NewOpError("clean dry run", "git "+strings.Join(args, " "), err)
 	}
 	return string(out), nil
 }
 
+func cleanArgs(flags string, paths []string) []string {
+	args := []string{"clean", flags}
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	return args
+}
+
 // CleanFilesForce removes specific files forcefully.
 func (c *Client) CleanFilesForce(files []string) error {
 	if len(files) == 0 {
@@ -56,8 +78,25 @@ func (c *Client) CleanFilesForce(files []string) error {
 	cmd := c.execCommand("git", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("clean files force", "git clean -f -- "+strings.Join(files, " "), err)
 	}
 	return nil
 }
+
+// CleanPathsForce removes specific untracked or ignored paths, including
+// whole directories.
+func (c *Client) CleanPathsForce(paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	args := append([]string{"clean", "-fdx", "--"}, paths...)
+	cmd := c.execCommand("git", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("clean paths force", "git clean -fdx -- "+strings.Join(paths, " "), err)
+	}
+	return nil
+}
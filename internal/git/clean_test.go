@@ -192,3 +192,64 @@ func TestClient_CleanFilesForce(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_CleanIgnoredDryRun(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return helperCommand(t, "Would remove build/\n", nil)
+		},
+	}
+
+	got, err := client.CleanIgnoredDryRun()
+	if err != nil || got != "Would remove build/\n" {
+		t.Fatalf("CleanIgnoredDryRun() = %q, %v", got, err)
+	}
+	if want := []string{"git", "clean", "-ndX"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("got %v, want %v", gotArgs, want)
+	}
+}
+
+func TestClient_CleanPathsForce(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("echo")
+		},
+	}
+
+	if err := client.CleanPathsForce(nil); err != nil || gotArgs != nil {
+		t.Fatalf("CleanPathsForce(nil) ran %v, %v", gotArgs, err)
+	}
+	_ = client.CleanPathsForce([]string{"build/", "notes.txt"})
+	want := []string{"git", "clean", "-fdx", "--", "build/", "notes.txt"}
+	if !slices.Equal(gotArgs, want) {
+		t.Errorf("got %v, want %v", gotArgs, want)
+	}
+}
+
+func TestClient_Clean_Paths(t *testing.T) {
+	var calls [][]string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			calls = append(calls, append([]string{name}, args...))
+			return exec.Command("echo")
+		},
+	}
+
+	_ = client.CleanFiles(":(top)apps/web")
+	_ = client.CleanDirs(":(top)apps/web")
+	_, _ = client.CleanDryRun(":(top)apps/web")
+	_, _ = client.CleanIgnoredDryRun(":(top)apps/web")
+	want := [][]string{
+		{"git", "clean", "-fd", "--", ":(top)apps/web"},
+		{"git", "clean", "-fdx", "--", ":(top)apps/web"},
+		{"git", "clean", "-nd", "--", ":(top)apps/web"},
+		{"git", "clean", "-ndX", "--", ":(top)apps/web"},
+	}
+	if !slices.EqualFunc(calls, want, slices.Equal) {
+		t.Errorf("got %v, want %v", calls, want)
+	}
+}
@@ -0,0 +1,72 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// CloneOps clones repositories and configures the new clones.
+type CloneOps interface {
+	Clone(url, dir string, opts CloneOptions) error
+	ConfigSetIn(dir, key, value string) error
+}
+
+// CloneOptions are the optional flags of git clone.
+type CloneOptions struct {
+	// Depth truncates history to that many commits; zero clones it all.
+	Depth int
+	// Branch checks out that branch (or tag) instead of the remote HEAD.
+	Branch            string
+	RecurseSubmodules bool
+	// Filter makes a partial clone, such as blob:none, which fetches
+	// file contents only when they are checked out.
+	Filter string
+	// Sparse checks out only the files at the top of the tree, for
+	// ggc sparse to add directories to.
+	Sparse bool
+}
+
+// Clone clones url into dir. An empty dir lets git derive it from url.
+func (c *Client) Clone(url, dir string, opts CloneOptions) error {
+	args := []string{"clone"}
+	if opts.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	if opts.Branch != "" {
+		args = append(args, "--branch", opts.Branch)
+	}
+	if opts.RecurseSubmodules {
+		args = append(args, "--recurse-submodules")
+	}
+	if opts.Filter != "" {
+		args = append(args, "--filter="+opts.Filter)
+	}
+	if opts.Sparse {
+		args = append(args, "--sparse")
+	}
+	args = append(args, "--", url)
+	if dir != "" {
+		args = append(args, dir)
+	}
+
+	stderr, done := c.transferStderr()
+	defer done()
+	cmd := c.execCommand("git", c.progressArgs(args)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("clone", fmt.Sprintf("git clone %s", url), err)
+	}
+	return nil
+}
+
+// ConfigSetIn sets a configuration value in the repository at dir, such
+// as a fresh clone, without changing the working directory.
+func (c *Client) ConfigSetIn(dir, key, value string) error {
+	cmd := c.execCommand("git", "-C", dir, "config", key, value)
+	if err := c.run(cmd); err != nil {
+		return NewOpError("config set", fmt.Sprintf("git -C %s config %s %s", dir, key, value), err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func TestClient_Clone(t *testing.T) {
+	tests := []struct {
+		name     string
+		dir      string
+		opts     CloneOptions
+		wantArgs []string
+	}{
+		{
+			name:     "defaults",
+			wantArgs: []string{"git", "clone", "--", "https://github.com/o/r.git"},
+		},
+		{
+			name: "all options",
+			dir:  "work",
+			opts: CloneOptions{Depth: 1, Branch: "dev", RecurseSubmodules: true},
+			wantArgs: []string{"git", "clone", "--depth", "1", "--branch", "dev",
+				"--recurse-submodules", "--", "https://github.com/o/r.git", "work"},
+		},
+		{
+			name:     "partial and sparse",
+			opts:     CloneOptions{Filter: "blob:none", Sparse: true},
+			wantArgs: []string{"git", "clone", "--filter=blob:none", "--sparse", "--", "https://github.com/o/r.git"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			c := &Client{execCommand: func(name string, args ...string) *exec.Cmd {
+				gotArgs = append([]string{name}, args...)
+				return exec.Command("true")
+			}}
+			if err := c.Clone("https://github.com/o/r.git", tt.dir, tt.opts); err != nil {
+				t.Fatalf("Clone() error = %v", err)
+			}
+			if !slices.Equal(gotArgs, tt.wantArgs) {
+				t.Errorf("Clone() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+
+	c := &Client{execCommand: func(_ string, _ ...string) *exec.Cmd { return helperCommand(t, "", errors.New("fatal")) }}
+	if err := c.Clone("o/r", "", CloneOptions{}); err == nil {
+		t.Error("Clone() should fail when git fails")
+	}
+}
+
+func TestClient_ConfigSetIn(t *testing.T) {
+	var gotArgs []string
+	c := &Client{execCommand: func(name string, args ...string) *exec.Cmd {
+		gotArgs = append([]string{name}, args...)
+		return exec.Command("true")
+	}}
+	if err := c.ConfigSetIn("work", "user.email", "me@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"git", "-C", "work", "config", "user.email", "me@example.com"}
+	if !slices.Equal(gotArgs, want) {
+		t.Errorf("ConfigSetIn() args = %v, want %v", gotArgs, want)
+	}
+}
@@ -14,6 +14,7 @@ type CommitWriter interface {
 	CommitAmend() error
 	CommitAmendNoEdit() error
 	CommitAmendWithMessage(message string) error
+	CommitAmendWith(opts AmendOptions) error
 	CommitAllowEmpty() error
 	CommitFixup(commit string) error
 }
@@ -24,10 +25,10 @@ func (c *Client) Commit(message string) error {
 		return err
 	}
 
-	cmd := c.execCommand("git", "commit", "-m", message)
+	cmd := c.execCommand("git", c.signArgs([]string{"commit", "-m", message})...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("commit", "git commit -m "+message, err)
 	}
 	return nil
@@ -35,11 +36,11 @@ func (c *Client) Commit(message string) error {
 
 // CommitAmend amends the last commit.
 func (c *Client) CommitAmend() error {
-	cmd := c.execCommand("git", "commit", "--amend")
+	cmd := c.execCommand("git", c.signArgs([]string{"commit", "--amend"})...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("commit amend", "git commit --amend", err)
 	}
 	return nil
@@ -47,10 +48,10 @@ func (c *Client) CommitAmend() error {
 
 // CommitAmendNoEdit amends the last commit without editing the message.
 func (c *Client) CommitAmendNoEdit() error {
-	cmd := c.execCommand("git", "commit", "--amend", "--no-edit")
+	cmd := c.execCommand("git", c.signArgs([]string{"commit", "--amend", "--no-edit"})...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("commit amend no-edit", "git commit --amend --no-edit", err)
 	}
 	return nil
@@ -62,15 +63,51 @@ func (c *Client) CommitAmendWithMessage(message string) error {
 		return err
 	}
 
-	cmd := c.execCommand("git", "commit", "--amend", "-m", message)
+	cmd := c.execCommand("git", c.signArgs([]string{"commit", "--amend", "-m", message})...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("commit amend with message", "git commit --amend -m "+message, err)
 	}
 	return nil
 }
 
+// AmendOptions are the options of CommitAmendWith.
+type AmendOptions struct {
+	// Message replaces the commit message; empty keeps it, opening the
+	// editor unless NoEdit is set.
+	Message string
+	NoEdit  bool
+	// ResetAuthor makes the user the author again and renews the author
+	// date, as git commit --amend --reset-author does.
+	ResetAuthor bool
+}
+
+// CommitAmendWith amends the last commit with opts.
+func (c *Client) CommitAmendWith(opts AmendOptions) error {
+	args := []string{"commit", "--amend"}
+	switch {
+	case opts.Message != "":
+		if err := validateCommitMessage(opts.Message); err != nil {
+			return err
+		}
+		args = append(args, "-m", opts.Message)
+	case opts.NoEdit:
+		args = append(args, "--no-edit")
+	}
+	if opts.ResetAuthor {
+		args = append(args, "--reset-author")
+	}
+	cmd := c.execCommand("git", c.signArgs(args)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	if err := c.run(cmd); err != nil {
+		return NewOpError("commit amend", "git "+strings.Join(args, " "), err)
+	}
+	return nil
+}
+
 func validateCommitMessage(message string) error {
 	trimmed := strings.TrimSpace(message)
 	if trimmed == "" {
@@ -90,11 +127,11 @@ func (c *Client) CommitFixup(commit string) error {
 	if strings.TrimSpace(commit) == "" {
 		return fmt.Errorf("commit reference cannot be empty")
 	}
-	cmd := c.execCommand("git", "commit", "--fixup", commit)
+	cmd := c.execCommand("git", c.signArgs([]string{"commit", "--fixup", commit})...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("commit fixup", "git commit --fixup "+commit, err)
 	}
 	return nil
@@ -102,10 +139,10 @@ func (c *Client) CommitFixup(commit string) error {
 
 // CommitAllowEmpty commits with --allow-empty.
 func (c *Client) CommitAllowEmpty() error {
-	cmd := c.execCommand("git", "commit", "--allow-empty", "-m", "empty commit")
+	cmd := c.execCommand("git", c.signArgs([]string{"commit", "--allow-empty", "-m", "empty commit"})...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("commit allow empty", "git commit --allow-empty -m 'empty commit'", err)
 	}
 	return nil
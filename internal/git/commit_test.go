@@ -315,3 +315,31 @@ func TestClient_CommitAmendWithMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_CommitAmendWith(t *testing.T) {
+	tests := []struct {
+		opts AmendOptions
+		want string
+	}{
+		{AmendOptions{}, "commit --amend"},
+		{AmendOptions{NoEdit: true}, "commit --amend --no-edit"},
+		{AmendOptions{NoEdit: true, ResetAuthor: true}, "commit --amend --no-edit --reset-author"},
+		{AmendOptions{Message: "fix: typo", ResetAuthor: true}, "commit --amend -m fix: typo --reset-author"},
+	}
+	for _, tt := range tests {
+		var got string
+		c := &Client{execCommand: func(_ string, arg ...string) *exec.Cmd {
+			got = strings.Join(arg, " ")
+			return exec.Command("true")
+		}}
+		if err := c.CommitAmendWith(tt.opts); err != nil {
+			t.Fatalf("CommitAmendWith(%+v) error = %v", tt.opts, err)
+		}
+		if got != tt.want {
+			t.Errorf("CommitAmendWith(%+v) ran git %s, want git %s", tt.opts, got, tt.want)
+		}
+	}
+	if err := (&Client{}).CommitAmendWith(AmendOptions{Message: "  "}); err == nil {
+		t.Error("a blank message should be rejected")
+	}
+}
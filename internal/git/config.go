@@ -13,10 +13,21 @@ type ConfigOps interface {
 	GetCommitHash() (string, error)
 }
 
+// ConfigReader reads git configuration with the usual precedence of
+// repository, global and system values.
+type ConfigReader interface {
+	ConfigGet(key string) (string, error)
+}
+
+// ConfigWriter sets git configuration in the current repository.
+type ConfigWriter interface {
+	ConfigSet(key, value string) error
+}
+
 // ConfigGet retrieves a git configuration value from local repository
 func (c *Client) ConfigGet(key string) (string, error) {
 	cmd := c.execCommand("git", "config", key)
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return "", NewOpError("config get", fmt.Sprintf("git config %s", key), err)
 	}
@@ -26,7 +37,7 @@ func (c *Client) ConfigGet(key string) (string, error) {
 // ConfigSet sets a git configuration value in local repository
 func (c *Client) ConfigSet(key, value string) error {
 	cmd := c.execCommand("git", "config", key, value)
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("config set", fmt.Sprintf("git config %s %s", key, value), err)
 	}
 	return nil
@@ -35,7 +46,7 @@ func (c *Client) ConfigSet(key, value string) error {
 // ConfigGetGlobal retrieves a git configuration value from global config
 func (c *Client) ConfigGetGlobal(key string) (string, error) {
 	cmd := c.execCommand("git", "config", "--global", key)
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return "", NewOpError("config get global", fmt.Sprintf("git config --global %s", key), err)
 	}
@@ -45,7 +56,7 @@ func (c *Client) ConfigGetGlobal(key string) (string, error) {
 // ConfigSetGlobal sets a git configuration value in global config
 func (c *Client) ConfigSetGlobal(key, value string) error {
 	cmd := c.execCommand("git", "config", "--global", key, value)
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("config set global", fmt.Sprintf("git config --global %s %s", key, value), err)
 	}
 	return nil
@@ -7,7 +7,7 @@ import (
 // GetVersion gets the git version/tag information
 func (c *Client) GetVersion() (string, error) {
 	cmd := c.execCommand("git", "describe", "--tags", "--always", "--dirty")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return "dev", nil // Return "dev" as fallback instead of error
 	}
@@ -30,7 +30,7 @@ func (c *Client) DiffHead() (string, error) {
 func (c *Client) DiffWith(args []string) (string, error) {
 	cmdArgs := append([]string{"diff"}, args...)
 	cmd := c.execCommand("git", cmdArgs...)
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		command := strings.Join(append([]string{"git"}, cmdArgs...), " ")
 		return "", NewOpError("get diff", command, err)
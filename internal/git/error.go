@@ -1,7 +1,10 @@
 package git
 
 import (
+	"errors"
 	"fmt"
+	"os/exec"
+	"strings"
 )
 
 // OpError represents a git operation error.
@@ -19,6 +22,16 @@ func (e *OpError) Error() string {
 	return fmt.Sprintf("git: %s failed: %s", e.Op, e.Err)
 }
 
+// Unwrap returns the underlying error.
+func (e *OpError) Unwrap() error { return e.Err }
+
+// Is reports whether git's output says the operation failed for the
+// reason target names, so that errors.Is(err, git.ErrAuthFailed) works on
+// any error returned by the client.
+func (e *OpError) Is(target error) bool {
+	return target != nil && Classify(e) == target
+}
+
 // NewOpError creates a new OpError.
 func NewOpError(op string, command string, err error) error {
 	return &OpError{
@@ -27,3 +40,81 @@ func NewOpError(op string, command string, err error) error {
 		Err:     err,
 	}
 }
+
+// Reasons a git command fails that the user can act on. Classify maps an
+// error to one of them by what git printed.
+var (
+	ErrNotARepo      = errors.New("not a git repository")
+	ErrDetachedHead  = errors.New("HEAD is detached")
+	ErrNoRemote      = errors.New("no remote is configured")
+	ErrNoUpstream    = errors.New("the branch has no upstream")
+	ErrAuthFailed    = errors.New("authentication failed")
+	ErrNetwork       = errors.New("the remote could not be reached")
+	ErrMergeConflict = errors.New("there are merge conflicts")
+	ErrDirtyWorktree = errors.New("local changes are in the way")
+	ErrPushRejected  = errors.New("the remote has commits you do not have")
+)
+
+// errorReasons lists what git prints for each reason, lowercased. The
+// first match wins, so the more specific reasons come first.
+var errorReasons = []struct {
+	err      error
+	patterns []string
+}{
+	{ErrNotARepo, []string{"not a git repository"}},
+	{ErrAuthFailed, []string{
+		"authentication failed", "permission denied (publickey",
+		"could not read username", "could not read password",
+		"access denied", "invalid username or password",
+		"the requested url returned error: 401", "the requested url returned error: 403",
+	}},
+	{ErrNetwork, []string{
+		"could not resolve host", "timed out", "connection refused",
+		"network is unreachable", "connection reset", "no route to host",
+		"unable to access",
+	}},
+	{ErrNoRemote, []string{
+		"no configured push destination", "does not appear to be a git repository",
+		"no remote repository specified", "no such remote",
+	}},
+	{ErrNoUpstream, []string{
+		"has no upstream branch", "no tracking information", "no upstream configured",
+	}},
+	{ErrDetachedHead, []string{
+		"you are not currently on a branch", "head detached", "detached head",
+	}},
+	{ErrMergeConflict, []string{
+		"conflict (", "merge conflict", "fix conflicts", "unmerged files",
+		"resolve your current index first",
+	}},
+	{ErrDirtyWorktree, []string{
+		"would be overwritten by", "please commit your changes or stash them",
+		"you have unstaged changes", "your index contains uncommitted changes",
+	}},
+	{ErrPushRejected, []string{
+		"[rejected]", "non-fast-forward", "fetch first", "updates were rejected",
+	}},
+}
+
+// Classify returns the Err* value that explains err, or nil when git's
+// output does not say. It reads the error text and the stderr of any
+// *exec.ExitError in the chain; commands whose stderr went straight to the
+// terminal cannot be classified.
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	text := strings.ToLower(err.Error())
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		text += "\n" + strings.ToLower(string(exitErr.Stderr))
+	}
+	for _, reason := range errorReasons {
+		for _, p := range reason.patterns {
+			if strings.Contains(text, p) {
+				return reason.err
+			}
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package git
 
 import (
 	"errors"
+	"os/exec"
 	"testing"
 )
 
@@ -63,3 +64,41 @@ func TestNewOpError(t *testing.T) {
 		t.Errorf("Err = %v, want %v", gitErr.Err, err)
 	}
 }
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want error
+	}{
+		{"fatal: not a git repository (or any of the parent directories): .git", ErrNotARepo},
+		{"git@github.com: Permission denied (publickey).", ErrAuthFailed},
+		{"fatal: unable to access 'https://example.com/r.git/': Could not resolve host: example.com", ErrNetwork},
+		{"fatal: The current branch main has no upstream branch.", ErrNoUpstream},
+		{"fatal: You are not currently on a branch.", ErrDetachedHead},
+		{"CONFLICT (content): Merge conflict in a.go", ErrMergeConflict},
+		{"error: Your local changes to the following files would be overwritten by checkout", ErrDirtyWorktree},
+		{" ! [rejected]        main -> main (fetch first)", ErrPushRejected},
+		{"exit status 1", nil},
+	}
+	for _, tt := range tests {
+		if got := Classify(errors.New(tt.msg)); got != tt.want {
+			t.Errorf("Classify(%q) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestOpError_IsReadsStderr(t *testing.T) {
+	c := &Client{execCommand: exec.Command}
+	err := c.run(exec.Command("sh", "-c", "echo 'fatal: No such remote: upstream' >&2; exit 2"))
+	opErr := NewOpError("remote remove", "git remote remove upstream", err)
+	if !errors.Is(opErr, ErrNoRemote) {
+		t.Errorf("errors.Is(%v, ErrNoRemote) = false", opErr)
+	}
+	if errors.Is(opErr, ErrAuthFailed) {
+		t.Error("a missing remote is not an authentication failure")
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(opErr, &exitErr) {
+		t.Error("OpError should unwrap to the *exec.ExitError")
+	}
+}
@@ -0,0 +1,318 @@
+package git
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// errFastRefsUnsupported makes a direct ref read fall back to git.
+var errFastRefsUnsupported = errors.New("not supported by the direct ref reader")
+
+// WithFastRefs returns a shallow copy of the client that, when on,
+// answers ref queries (current branch, branch lists, upstreams) by reading
+// the .git directory directly instead of starting git. It is a fast path,
+// not a git implementation: queries that need the object database, such
+// as log and status, and every mutation still run git. When the
+// repository uses something the reader does not understand, such as
+// config includes or the reftable format, the query falls back to git.
+func (c *Client) WithFastRefs(on bool) *Client {
+	clone := *c
+	clone.fastRefs = on
+	return &clone
+}
+
+// readRefs runs read against the repository in the working directory
+// when the fast path is on. ok is false when the caller should run
+// git instead.
+func readRefs[T any](c *Client, read func(r *refDir) (T, error)) (v T, ok bool, err error) {
+	if !c.fastRefs {
+		return v, false, nil
+	}
+	gitDir, commonDir, found := findGitDir()
+	if !found {
+		return v, false, nil
+	}
+	v, err = read(&refDir{gitDir: gitDir, commonDir: commonDir})
+	if errors.Is(err, errFastRefsUnsupported) {
+		return v, false, nil
+	}
+	return v, true, err
+}
+
+// refDir reads refs and config straight from a .git directory.
+type refDir struct {
+	gitDir    string
+	commonDir string
+}
+
+// currentBranch returns the checked-out branch, or "HEAD" when detached,
+// like git rev-parse --abbrev-ref HEAD.
+func (r *refDir) currentBranch() (string, error) {
+	if _, err := os.Stat(filepath.Join(r.commonDir, "reftable")); err == nil {
+		return "", errFastRefsUnsupported
+	}
+	data, err := os.ReadFile(filepath.Join(r.gitDir, "HEAD"))
+	if err != nil {
+		return "", errFastRefsUnsupported
+	}
+	head := strings.TrimSpace(string(data))
+	ref, symbolic := strings.CutPrefix(head, "ref: ")
+	if !symbolic {
+		return "HEAD", nil
+	}
+	branch, ok := strings.CutPrefix(ref, "refs/heads/")
+	if !ok {
+		return "", errFastRefsUnsupported
+	}
+	exists, err := r.refExists(ref)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		// An unborn branch: git rev-parse fails the same way.
+		return "", fmt.Errorf("ambiguous argument 'HEAD': unknown revision or path not in the working tree")
+	}
+	return branch, nil
+}
+
+// refExists reports whether ref is a loose or a packed ref.
+func (r *refDir) refExists(ref string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(r.commonDir, filepath.FromSlash(ref))); err == nil {
+		return true, nil
+	}
+	f, err := os.Open(filepath.Join(r.commonDir, "packed-refs"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errFastRefsUnsupported
+	}
+	defer func() { _ = f.Close() }()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if _, name, ok := strings.Cut(scanner.Text(), " "); ok && name == ref {
+			return true, nil
+		}
+	}
+	if scanner.Err() != nil {
+		return false, errFastRefsUnsupported
+	}
+	return false, nil
+}
+
+// refNames lists the refs under prefix (e.g. "refs/heads/"), loose and
+// packed, with the prefix stripped and sorted like git branch.
+func (r *refDir) refNames(prefix string) ([]string, error) {
+	if _, err := os.Stat(filepath.Join(r.commonDir, "reftable")); err == nil {
+		return nil, errFastRefsUnsupported
+	}
+	seen := map[string]bool{}
+
+	root := filepath.Join(r.commonDir, filepath.FromSlash(prefix))
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(d.Name(), ".lock") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		seen[filepath.ToSlash(rel)] = true
+		return nil
+	})
+	if err != nil {
+		return nil, errFastRefsUnsupported
+	}
+
+	if f, err := os.Open(filepath.Join(r.commonDir, "packed-refs")); err == nil {
+		defer func() { _ = f.Close() }()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" || line[0] == '#' || line[0] == '^' {
+				continue
+			}
+			_, ref, ok := strings.Cut(line, " ")
+			if name, found := strings.CutPrefix(ref, prefix); ok && found {
+				seen[name] = true
+			}
+		}
+		if scanner.Err() != nil {
+			return nil, errFastRefsUnsupported
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// upstream returns the short name of branch's upstream from the
+// repository config, like git rev-parse --abbrev-ref branch@{upstream}.
+func (r *refDir) upstream(branch string) (string, error) {
+	cfg, err := r.config()
+	if err != nil {
+		return "", err
+	}
+	remote, merge := cfg[configKey("branch", branch, "remote")], cfg[configKey("branch", branch, "merge")]
+	if remote == "" || merge == "" {
+		return "", fmt.Errorf("no upstream configured for branch '%s'", branch)
+	}
+	name, ok := strings.CutPrefix(merge, "refs/heads/")
+	if !ok {
+		return "", errFastRefsUnsupported
+	}
+	if remote == "." {
+		return name, nil
+	}
+	return remote + "/" + name, nil
+}
+
+// configKey is the key config stores a value under: the section and the
+// variable name lowercased, and the subsection, which is case sensitive,
+// quoted as git writes it, as in branch "Main".remote.
+func configKey(section, subsection, name string) string {
+	key := strings.ToLower(section)
+	if subsection != "" {
+		key += ` "` + subsection + `"`
+	}
+	return key + "." + strings.ToLower(name)
+}
+
+// config reads the repository's config file into configKey pairs. Both
+// [section "subsection"] and the older [section.subsection] headers are
+// read, and values lose their quotes, escapes and trailing comments.
+// Files with includes or syntax the reader does not handle fall back to
+// git.
+func (r *refDir) config() (map[string]string, error) {
+	f, err := os.Open(filepath.Join(r.commonDir, "config"))
+	if err != nil {
+		return nil, errFastRefsUnsupported
+	}
+	defer func() { _ = f.Close() }()
+
+	values := map[string]string{}
+	var section, subsection string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' {
+			section, subsection, err = parseConfigHeader(line)
+			if err != nil {
+				return nil, err
+			}
+			if section == "include" || section == "includeif" {
+				return nil, errFastRefsUnsupported
+			}
+			continue
+		}
+		name, raw, hasValue := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if name == "" || strings.ContainsAny(name, " \t#;\"") {
+			return nil, errFastRefsUnsupported
+		}
+		value := "true" // a bare name is a boolean set to true
+		if hasValue {
+			if value, err = parseConfigValue(raw); err != nil {
+				return nil, err
+			}
+		}
+		values[configKey(section, subsection, name)] = value
+	}
+	if scanner.Err() != nil {
+		return nil, errFastRefsUnsupported
+	}
+	return values, nil
+}
+
+// parseConfigHeader splits a section header line. In [section.sub] the
+// subsection is lowercased, as git does for that older syntax.
+func parseConfigHeader(line string) (section, subsection string, err error) {
+	end := strings.LastIndex(line, "]")
+	if end < 0 {
+		return "", "", errFastRefsUnsupported
+	}
+	if rest := strings.TrimSpace(line[end+1:]); rest != "" && rest[0] != '#' && rest[0] != ';' {
+		// A variable on the header line.
+		return "", "", errFastRefsUnsupported
+	}
+	header := strings.TrimSpace(line[1:end])
+	if name, quoted, ok := strings.Cut(header, " "); ok {
+		quoted = strings.TrimSpace(quoted)
+		if len(quoted) < 2 || quoted[0] != '"' || quoted[len(quoted)-1] != '"' {
+			return "", "", errFastRefsUnsupported
+		}
+		sub := strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(quoted[1 : len(quoted)-1])
+		return strings.ToLower(name), sub, nil
+	}
+	name, sub, _ := strings.Cut(header, ".")
+	return strings.ToLower(name), strings.ToLower(sub), nil
+}
+
+// parseConfigValue reads a value the way git does: whitespace around it
+// is dropped, double quotes keep it, # or ; outside quotes starts a
+// comment, and \", \\, \n, \t and \b are escapes. A value continued on the
+// next line falls back to git.
+func parseConfigValue(raw string) (string, error) {
+	var b strings.Builder
+	quoted := false
+	pending := "" // unquoted whitespace, kept only if more value follows
+	for i := 0; i < len(raw); i++ {
+		ch := raw[i]
+		switch {
+		case ch == '\\':
+			if i+1 == len(raw) {
+				return "", errFastRefsUnsupported
+			}
+			i++
+			b.WriteString(pending)
+			pending = ""
+			switch raw[i] {
+			case '"', '\\':
+				b.WriteByte(raw[i])
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'b':
+				b.WriteByte('\b')
+			default:
+				return "", errFastRefsUnsupported
+			}
+		case ch == '"':
+			quoted = !quoted
+		case !quoted && (ch == '#' || ch == ';'):
+			return b.String(), nil
+		case !quoted && (ch == ' ' || ch == '\t'):
+			if b.Len() > 0 {
+				pending += string(ch)
+			}
+		default:
+			b.WriteString(pending)
+			b.WriteByte(ch)
+			pending = ""
+		}
+	}
+	if quoted {
+		return "", errFastRefsUnsupported
+	}
+	return b.String(), nil
+}
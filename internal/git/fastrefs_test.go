@@ -0,0 +1,168 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newRefsTestRepo lays out refs, packed-refs and config under a fresh
+// .git directory and makes its work tree the working directory.
+func newRefsTestRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	gitDir := newCacheTestRepo(t)
+	for path, data := range files {
+		full := filepath.Join(gitDir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return gitDir
+}
+
+// noGitClient fails the test if git is started.
+func noGitClient(t *testing.T) *Client {
+	c := &Client{execCommand: func(name string, arg ...string) *exec.Cmd {
+		t.Errorf("fast ref path ran %s %v", name, arg)
+		return exec.Command("false")
+	}}
+	return c.WithFastRefs(true)
+}
+
+func TestFastRefs_Branches(t *testing.T) {
+	newRefsTestRepo(t, map[string]string{
+		"refs/heads/feature/login":       "2222222\n",
+		"refs/remotes/origin/HEAD":       "ref: refs/remotes/origin/main\n",
+		"refs/remotes/origin/main":       "1111111\n",
+		"refs/remotes/upstream/dev.lock": "3333333\n",
+		"packed-refs": "# pack-refs with: peeled fully-peeled sorted\n" +
+			"1111111 refs/heads/main\n" +
+			"4444444 refs/heads/old\n" +
+			"5555555 refs/remotes/origin/old\n" +
+			"6666666 refs/tags/v1.0.0\n" +
+			"^7777777\n",
+		"config": "[core]\n\tbare = false\n" +
+			"[remote \"origin\"]\n\turl = git@example.com:o/r.git\n" +
+			"[branch \"main\"]\n\tremote = origin\n\tmerge = refs/heads/main\n" +
+			"[branch \"old\"]\n\tremote = .\n\tmerge = refs/heads/main\n",
+	})
+	c := noGitClient(t)
+
+	if got, err := c.GetCurrentBranch(); err != nil || got != "main" {
+		t.Errorf("GetCurrentBranch() = %q, %v", got, err)
+	}
+	if got, err := c.GetBranchName(); err != nil || got != "main" {
+		t.Errorf("GetBranchName() = %q, %v", got, err)
+	}
+	local, err := c.ListLocalBranches()
+	if want := []string{"feature/login", "main", "old"}; err != nil || !reflect.DeepEqual(local, want) {
+		t.Errorf("ListLocalBranches() = %v, %v; want %v", local, err, want)
+	}
+	remote, err := c.ListRemoteBranches()
+	if want := []string{"origin/main", "origin/old"}; err != nil || !reflect.DeepEqual(remote, want) {
+		t.Errorf("ListRemoteBranches() = %v, %v; want %v", remote, err, want)
+	}
+	if got, err := c.GetUpstreamBranchName("main"); err != nil || got != "origin/main" {
+		t.Errorf("GetUpstreamBranchName(main) = %q, %v", got, err)
+	}
+	if got, err := c.GetUpstreamBranchName("old"); err != nil || got != "main" {
+		t.Errorf("GetUpstreamBranchName(old) = %q, %v", got, err)
+	}
+	if _, err := c.GetUpstreamBranchName("feature/login"); err == nil {
+		t.Error("GetUpstreamBranchName(feature/login) should fail without an upstream")
+	}
+}
+
+func TestFastRefs_Detached(t *testing.T) {
+	newRefsTestRepo(t, map[string]string{"HEAD": "1111111111111111111111111111111111111111\n"})
+	if got, err := noGitClient(t).GetCurrentBranch(); err != nil || got != "HEAD" {
+		t.Errorf("GetCurrentBranch() = %q, %v; want HEAD", got, err)
+	}
+}
+
+func TestFastRefs_FallsBackToGit(t *testing.T) {
+	newRefsTestRepo(t, map[string]string{
+		"config": "[include]\n\tpath = extra.config\n",
+	})
+	calls := 0
+	c := countingClient(nil, "origin/main", &calls).WithFastRefs(true)
+	if got, err := c.GetUpstreamBranchName("main"); err != nil || got != "origin/main" || calls != 1 {
+		t.Errorf("GetUpstreamBranchName() = %q, %v after %d git calls; want a git fallback", got, err, calls)
+	}
+
+	// Without the fast path .git is never read directly.
+	c = countingClient(nil, "main", &calls).WithFastRefs(false)
+	_, _ = c.GetCurrentBranch()
+	if calls != 2 {
+		t.Errorf("ran git %d times in total, want 2", calls)
+	}
+}
+
+func TestFastRefs_UnbornHead(t *testing.T) {
+	gitDir := newRefsTestRepo(t, map[string]string{"HEAD": "ref: refs/heads/trunk\n"})
+	c := noGitClient(t)
+	if got, err := c.GetCurrentBranch(); err == nil {
+		t.Errorf("GetCurrentBranch() = %q on an unborn branch; git rev-parse fails there", got)
+	}
+
+	// A packed ref counts as the branch being born.
+	if err := os.WriteFile(filepath.Join(gitDir, "packed-refs"), []byte("1111111 refs/heads/trunk\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := c.GetCurrentBranch(); err != nil || got != "trunk" {
+		t.Errorf("GetCurrentBranch() = %q, %v; want trunk", got, err)
+	}
+}
+
+func TestFastRefs_ConfigSyntax(t *testing.T) {
+	newRefsTestRepo(t, map[string]string{
+		"config": "[core]\n\tbare = false ; inline comment\n" +
+			"[branch.Legacy]\n\tremote = origin\n\tmerge = refs/heads/legacy\n" +
+			"[branch \"Main\"] # the default branch\n" +
+			"\tremote = upstream # fork\n" +
+			"\tMerge = \"refs/heads/main\" ; quoted\n" +
+			"[Branch \"we\\\"ird\"]\n\tremote = \"my remote\"\n\tmerge = refs/heads/we\"ird\"\n",
+	})
+	c := noGitClient(t)
+	tests := []struct{ branch, want string }{
+		{"Main", "upstream/main"},
+		// [branch.Legacy] is the old syntax, whose subsection git lowercases.
+		{"legacy", "origin/legacy"},
+		{`we"ird`, "my remote/weird"},
+	}
+	for _, tt := range tests {
+		if got, err := c.GetUpstreamBranchName(tt.branch); err != nil || got != tt.want {
+			t.Errorf("GetUpstreamBranchName(%q) = %q, %v; want %q", tt.branch, got, err, tt.want)
+		}
+	}
+	if _, err := c.GetUpstreamBranchName("Legacy"); err == nil {
+		t.Error("[branch.Legacy] should not configure the branch Legacy")
+	}
+}
+
+func TestParseConfigValue(t *testing.T) {
+	tests := []struct {
+		raw, want string
+		ok        bool
+	}{
+		{" origin ", "origin", true},
+		{" origin # comment", "origin", true},
+		{` "a # b" ; comment`, "a # b", true},
+		{` one  two `, "one  two", true},
+		{` "tab\there"`, "tab\there", true},
+		{` say \"hi\"`, `say "hi"`, true},
+		{` continued \`, "", false},
+		{` "unterminated`, "", false},
+	}
+	for _, tt := range tests {
+		got, err := parseConfigValue(tt.raw)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("parseConfigValue(%q) = %q, %v; want %q", tt.raw, got, err, tt.want)
+		}
+	}
+}
@@ -1,26 +1,118 @@
 package git
 
-import "os"
+import (
+	"io"
+	"os"
+	"strings"
+)
 
 // FetchOps provides fetch operation(s).
 type FetchOps interface {
 	Fetch(prune bool) error
 }
 
+// RefspecFetcher fetches an explicit refspec, such as a pull request head.
+type RefspecFetcher interface {
+	FetchRefspec(remote, refspec string) error
+}
+
+// FetchOptions shapes a fetch beyond the remote to fetch from.
+type FetchOptions struct {
+	Prune     bool // delete remote-tracking branches the remote no longer has
+	PruneTags bool // delete local tags the remote no longer has; implies Prune
+}
+
+// args returns the git fetch arguments for opts.
+func (o FetchOptions) args() []string {
+	args := []string{"fetch"}
+	if o.Prune || o.PruneTags {
+		args = append(args, "--prune")
+	}
+	if o.PruneTags {
+		args = append(args, "--prune-tags")
+	}
+	return args
+}
+
+// RemoteFetcher fetches with options, either from the default remote or
+// from one named remote with the output going to a writer of the caller's,
+// so several remotes can be fetched at once.
+type RemoteFetcher interface {
+	FetchWith(opts FetchOptions) error
+	FetchRemote(remote string, opts FetchOptions, out io.Writer) error
+}
+
+// RefTipReader reads the commit each ref points at, to compare the refs
+// before and after a fetch.
+type RefTipReader interface {
+	RefTips(prefixes ...string) (map[string]string, error)
+}
+
 // Fetch fetches from remote repository.
 func (c *Client) Fetch(prune bool) error {
-	var cmd = c.execCommand("git", "fetch")
-	if prune {
-		cmd = c.execCommand("git", "fetch", "--prune")
-	}
+	return c.FetchWith(FetchOptions{Prune: prune})
+}
 
+// FetchWith fetches from the default remote.
+func (c *Client) FetchWith(opts FetchOptions) error {
+	defer c.InvalidateStatusCache()
+	args := opts.args()
+	stderr, done := c.transferStderr()
+	defer done()
+	cmd := c.execCommand("git", c.progressArgs(args)...)
 	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		if prune {
+	cmd.Stderr = stderr
+	if err := c.run(cmd); err != nil {
+		if opts.Prune && !opts.PruneTags {
 			return NewOpError("fetch with prune", "git fetch --prune", err)
 		}
-		return NewOpError("fetch", "git fetch", err)
+		return NewOpError("fetch", "git "+strings.Join(args, " "), err)
 	}
 	return nil
 }
+
+// FetchRemote fetches from remote, writing git's output, progress
+// included, to out.
+func (c *Client) FetchRemote(remote string, opts FetchOptions, out io.Writer) error {
+	defer c.InvalidateStatusCache()
+	args := append(opts.args(), remote)
+	cmd := c.execCommand("git", append([]string{"fetch", "--progress"}, args[1:]...)...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := c.run(cmd); err != nil {
+		return NewOpError("fetch", "git "+strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// FetchRefspec fetches refspec from remote, e.g. "pull/7/head:pr-7".
+func (c *Client) FetchRefspec(remote, refspec string) error {
+	defer c.InvalidateStatusCache()
+	stderr, done := c.transferStderr()
+	defer done()
+	cmd := c.execCommand("git", c.progressArgs([]string{"fetch", remote, refspec})...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("fetch", "git fetch "+remote+" "+refspec, err)
+	}
+	return nil
+}
+
+// RefTips returns the commit of every ref under prefixes, such as
+// "refs/tags", keyed by full ref name. Annotated tags map to the tag
+// object, so moving one shows as a change.
+func (c *Client) RefTips(prefixes ...string) (map[string]string, error) {
+	args := append([]string{"for-each-ref", "--format=%(objectname) %(refname)"}, prefixes...)
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return nil, NewOpError("list refs", "git for-each-ref "+strings.Join(prefixes, " "), err)
+	}
+	tips := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if oid, ref, ok := strings.Cut(line, " "); ok {
+			tips[ref] = oid
+		}
+	}
+	return tips, nil
+}
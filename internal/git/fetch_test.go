@@ -1,8 +1,10 @@
 package git
 
 import (
+	"bytes"
 	"os/exec"
 	"slices"
+	"strings"
 	"testing"
 )
 
@@ -45,3 +47,88 @@ func TestClient_Fetch(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_FetchRefspec(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("echo")
+		},
+	}
+
+	if err := client.FetchRefspec("origin", "pull/7/head:pr-7"); err != nil {
+		t.Fatalf("FetchRefspec() error = %v", err)
+	}
+	wantArgs := []string{"git", "fetch", "origin", "pull/7/head:pr-7"}
+	if !slices.Equal(gotArgs, wantArgs) {
+		t.Errorf("FetchRefspec() gotArgs = %v, want %v", gotArgs, wantArgs)
+	}
+}
+
+func TestClient_FetchRemote(t *testing.T) {
+	tests := []struct {
+		opts     FetchOptions
+		wantArgs []string
+	}{
+		{FetchOptions{}, []string{"git", "fetch", "--progress", "upstream"}},
+		{FetchOptions{Prune: true}, []string{"git", "fetch", "--progress", "--prune", "upstream"}},
+		{FetchOptions{PruneTags: true}, []string{"git", "fetch", "--progress", "--prune", "--prune-tags", "upstream"}},
+	}
+	for _, tt := range tests {
+		var gotArgs []string
+		client := &Client{
+			execCommand: func(name string, args ...string) *exec.Cmd {
+				gotArgs = append([]string{name}, args...)
+				return exec.Command("echo", "From example.com")
+			},
+		}
+		var out bytes.Buffer
+		if err := client.FetchRemote("upstream", tt.opts, &out); err != nil {
+			t.Fatalf("FetchRemote() error = %v", err)
+		}
+		if !slices.Equal(gotArgs, tt.wantArgs) {
+			t.Errorf("FetchRemote(%+v) gotArgs = %v, want %v", tt.opts, gotArgs, tt.wantArgs)
+		}
+		if out.String() != "From example.com\n" {
+			t.Errorf("FetchRemote() output = %q", out.String())
+		}
+	}
+}
+
+func TestClient_FetchWith_PruneTags(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("false")
+		},
+	}
+	err := client.FetchWith(FetchOptions{PruneTags: true})
+	if want := []string{"git", "fetch", "--prune", "--prune-tags"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("FetchWith() gotArgs = %v, want %v", gotArgs, want)
+	}
+	if err == nil || !strings.Contains(err.Error(), "git fetch --prune --prune-tags") {
+		t.Errorf("FetchWith() error = %v", err)
+	}
+}
+
+func TestClient_RefTips(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("printf", "aaa refs/remotes/origin/main\nbbb refs/tags/v1.0.0\n")
+		},
+	}
+	tips, err := client.RefTips("refs/remotes", "refs/tags")
+	if err != nil {
+		t.Fatalf("RefTips() error = %v", err)
+	}
+	if want := []string{"git", "for-each-ref", "--format=%(objectname) %(refname)", "refs/remotes", "refs/tags"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("RefTips() gotArgs = %v, want %v", gotArgs, want)
+	}
+	if len(tips) != 2 || tips["refs/remotes/origin/main"] != "aaa" || tips["refs/tags/v1.0.0"] != "bbb" {
+		t.Errorf("RefTips() = %v", tips)
+	}
+}
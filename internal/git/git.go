@@ -2,6 +2,7 @@ package git
 
 import (
 	"context"
+	"log/slog"
 	"os/exec"
 )
 
@@ -11,6 +12,11 @@ import (
 type Client struct {
 	ctx         context.Context
 	execCommand func(name string, arg ...string) *exec.Cmd
+	statusCache *StatusCache // nil when status reads are not cached
+	fastRefs    bool         // answer ref queries from .git; see WithFastRefs
+	progress    func() ProgressSink
+	commitSign  string       // "-S" or "--no-gpg-sign" to override commit.gpgsign
+	logger      *slog.Logger // nil when git commands are not logged; see WithLogger
 }
 
 // NewClient creates a new Client with a default background context.
@@ -31,7 +37,8 @@ func (c *Client) WithContext(ctx context.Context) *Client {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	clone := &Client{ctx: ctx, execCommand: c.execCommand}
+	clone := *c
+	clone.ctx = ctx
 	// If the original client is using the default command factory, rewire
 	// it to the clone so that cancellation observes the new ctx.
 	// We detect this by checking the function value: only the default path
@@ -39,7 +46,7 @@ func (c *Client) WithContext(ctx context.Context) *Client {
 	if isBoundToDefaultExec(c) {
 		clone.execCommand = clone.newCommand
 	}
-	return clone
+	return &clone
 }
 
 // newCommand uses exec.CommandContext so that canceling the client's ctx
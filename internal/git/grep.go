@@ -0,0 +1,103 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Grepper searches tracked files.
+type Grepper interface {
+	GrepStream(ctx context.Context, args []string, hit func(GrepHit)) error
+}
+
+// GrepHit is one line git grep matched.
+type GrepHit struct {
+	Path   string
+	Line   int
+	Column int // of the first match, counted in bytes from 1
+	Text   string
+}
+
+// GrepStream runs git grep with args, which hold its options, the
+// pattern and any pathspecs, and calls hit for each matching line as git
+// prints it. Binary files are skipped. Finding nothing is not an error, and
+// neither is canceling ctx, which stops the search.
+func (c *Client) GrepStream(ctx context.Context, args []string, hit func(GrepHit)) error {
+	gitArgs := append([]string{"grep", "--null", "--line-number", "--column", "--no-color", "-I"}, args...)
+	cmd := c.execCommand("git", gitArgs...)
+	w := &grepWriter{ctx: ctx, hit: hit}
+	cmd.Stdout = w
+	err := c.run(cmd)
+	w.flush()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil, ctx.Err() != nil:
+		return nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && len(exitErr.Stderr) == 0:
+		return nil
+	}
+	return NewOpError("grep", "git "+strings.Join(gitArgs, " "), err)
+}
+
+// grepWriter parses git grep's output line by line as it arrives. Once
+// its context is done it refuses more output, which ends git.
+type grepWriter struct {
+	ctx  context.Context
+	hit  func(GrepHit)
+	rest []byte
+}
+
+func (w *grepWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+	w.rest = append(w.rest, p...)
+	for w.ctx.Err() == nil {
+		i := bytes.IndexByte(w.rest, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if h, ok := parseGrepLine(string(w.rest[:i])); ok {
+			w.hit(h)
+		}
+		w.rest = w.rest[i+1:]
+	}
+	return 0, w.ctx.Err()
+}
+
+// flush parses a last line that has no newline.
+func (w *grepWriter) flush() {
+	if h, ok := parseGrepLine(string(w.rest)); ok && w.ctx.Err() == nil {
+		w.hit(h)
+	}
+	w.rest = nil
+}
+
+// parseGrepLine parses "path\x00line:column:text".
+func parseGrepLine(s string) (GrepHit, bool) {
+	path, rest, ok := strings.Cut(s, "\x00")
+	if !ok {
+		return GrepHit{}, false
+	}
+	line, rest, ok := strings.Cut(rest, ":")
+	if !ok {
+		return GrepHit{}, false
+	}
+	column, text, ok := strings.Cut(rest, ":")
+	if !ok {
+		return GrepHit{}, false
+	}
+	h := GrepHit{Path: path, Text: text}
+	var err error
+	if h.Line, err = strconv.Atoi(line); err != nil {
+		return GrepHit{}, false
+	}
+	if h.Column, err = strconv.Atoi(column); err != nil {
+		return GrepHit{}, false
+	}
+	return h, true
+}
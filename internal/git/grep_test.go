@@ -0,0 +1,85 @@
+package git
+
+import (
+	"context"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func TestClient_GrepStream(t *testing.T) {
+	var gotArgs []string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("printf", `main.go\0003:6:func main() {\ncmd/a b.go\00012:1:main := 1`)
+		},
+	}
+	var hits []GrepHit
+	if err := c.GrepStream(context.Background(), []string{"-i", "main"}, func(h GrepHit) { hits = append(hits, h) }); err != nil {
+		t.Fatalf("GrepStream() error = %v", err)
+	}
+	if want := []string{"git", "grep", "--null", "--line-number", "--column", "--no-color", "-I", "-i", "main"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+	want := []GrepHit{
+		{Path: "main.go", Line: 3, Column: 6, Text: "func main() {"},
+		{Path: "cmd/a b.go", Line: 12, Column: 1, Text: "main := 1"},
+	}
+	if !slices.Equal(hits, want) {
+		t.Errorf("hits = %+v, want %+v", hits, want)
+	}
+}
+
+func TestClient_GrepStream_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		wantErr bool
+	}{
+		{"no matches", "exit 1", false},
+		{"bad pattern", "echo 'fatal: bad regex' >&2; exit 128", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				execCommand: func(_ string, _ ...string) *exec.Cmd {
+					return exec.Command("sh", "-c", tt.script)
+				},
+			}
+			err := c.GrepStream(context.Background(), []string{"x"}, func(GrepHit) {})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GrepStream() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_GrepStream_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Client{
+		execCommand: func(_ string, _ ...string) *exec.Cmd {
+			return exec.Command("sh", "-c", `while :; do printf 'a.go\0001:1:x\n'; done`)
+		},
+	}
+	n := 0
+	err := c.GrepStream(ctx, []string{"x"}, func(GrepHit) {
+		if n++; n == 3 {
+			cancel()
+		}
+	})
+	if err != nil || n != 3 {
+		t.Errorf("GrepStream() = %v after %d hits, want nil after 3", err, n)
+	}
+}
+
+func TestParseGrepLine(t *testing.T) {
+	if h, ok := parseGrepLine("a.go\x004:2:x := \"a:b\""); !ok || h.Text != `x := "a:b"` || h.Line != 4 || h.Column != 2 {
+		t.Errorf("parseGrepLine() = %+v, %v", h, ok)
+	}
+	for _, bad := range []string{"", "a.go:4:2:x", "a.go\x00x:2:y"} {
+		if _, ok := parseGrepLine(bad); ok {
+			t.Errorf("parseGrepLine(%q) should fail", bad)
+		}
+	}
+}
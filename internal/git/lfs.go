@@ -0,0 +1,48 @@
+package git
+
+import (
+	"bytes"
+	"strings"
+)
+
+// LFSReader tells whether a repository uses Git LFS and whether the
+// extension that fetches its content is there.
+type LFSReader interface {
+	LFSInstalled() bool
+	LFSFiles() ([]string, error)
+}
+
+// lfsPointerHeader starts every Git LFS pointer file.
+const lfsPointerHeader = "version https://git-lfs.github.com/spec/v1\n"
+
+// lfsPointerMax is the size pointer files stay under, per the LFS
+// specification.
+const lfsPointerMax = 1024
+
+// IsLFSPointer reports whether data is a Git LFS pointer, which stands in
+// the working tree for content that has not been downloaded.
+func IsLFSPointer(data []byte) bool {
+	return len(data) < lfsPointerMax && bytes.HasPrefix(data, []byte(lfsPointerHeader))
+}
+
+// LFSInstalled reports whether `git lfs` runs.
+func (c *Client) LFSInstalled() bool {
+	return c.run(c.execCommand("git", "lfs", "version")) == nil
+}
+
+// LFSFiles returns the tracked files that .gitattributes hands to LFS,
+// relative to the top of the working tree.
+func (c *Client) LFSFiles() ([]string, error) {
+	args := []string{"ls-files", "-z", "--full-name", "--", ":(top,attr:filter=lfs)"}
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return nil, NewOpError("list LFS files", "git "+strings.Join(args, " "), err)
+	}
+	var files []string
+	for _, f := range strings.Split(string(out), "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
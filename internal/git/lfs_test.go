@@ -0,0 +1,61 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClient_LFSFiles(t *testing.T) {
+	var gotArgs []string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("printf", `assets/logo.png\000video.mp4\000`)
+		},
+	}
+	files, err := c.LFSFiles()
+	if err != nil {
+		t.Fatalf("LFSFiles() error = %v", err)
+	}
+	if want := []string{"git", "ls-files", "-z", "--full-name", "--", ":(top,attr:filter=lfs)"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+	if want := []string{"assets/logo.png", "video.mp4"}; !slices.Equal(files, want) {
+		t.Errorf("files = %v, want %v", files, want)
+	}
+}
+
+func TestClient_LFSInstalled(t *testing.T) {
+	for _, installed := range []bool{true, false} {
+		c := &Client{
+			execCommand: func(_ string, args ...string) *exec.Cmd {
+				if !slices.Equal(args, []string{"lfs", "version"}) {
+					t.Errorf("args = %v", args)
+				}
+				if installed {
+					return helperCommand(t, "git-lfs/3.4.0", nil)
+				}
+				return helperCommand(t, "", errors.New("git: 'lfs' is not a git command"))
+			},
+		}
+		if got := c.LFSInstalled(); got != installed {
+			t.Errorf("LFSInstalled() = %v, want %v", got, installed)
+		}
+	}
+}
+
+func TestIsLFSPointer(t *testing.T) {
+	pointer := "version https://git-lfs.github.com/spec/v1\noid sha256:4d7a2146\nsize 12345\n"
+	if !IsLFSPointer([]byte(pointer)) {
+		t.Error("a pointer file should be recognized")
+	}
+	if IsLFSPointer([]byte("\x89PNG\r\n")) {
+		t.Error("real content is not a pointer")
+	}
+	if IsLFSPointer([]byte(pointer + strings.Repeat("x", 2048))) {
+		t.Error("a pointer is never that large")
+	}
+}
@@ -3,32 +3,262 @@ package git
 
 import (
 	"os"
+	"strconv"
+	"strings"
 )
 
 // LogReader provides read-only access to git log output.
+// Paths limit the log to commits that touch those pathspecs.
 type LogReader interface {
-	LogSimple() error
-	LogGraph() error
+	LogSimple(paths ...string) error
+	LogGraph(paths ...string) error
 }
 
-// LogSimple shows simple log.
-func (c *Client) LogSimple() error {
-	cmd := c.execCommand("git", "log", "--oneline", "--graph", "--decorate", "-10")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return NewOpError("log simple", "git log --oneline --graph --decorate -10", err)
+// LogGraphReader reads the commit graph of every ref.
+type LogGraphReader interface {
+	LogGraphLines(limit int) ([]GraphLine, error)
+}
+
+// CommitLister lists commits with their one-line summaries.
+type CommitLister interface {
+	ListCommits(revs ...string) ([]CommitSummary, error)
+}
+
+// CommitMessageReader reads raw commit messages.
+type CommitMessageReader interface {
+	CommitMessages(revRange string) ([]CommitMessage, error)
+}
+
+// CommitMessage is the abbreviated hash and raw message of one commit.
+type CommitMessage struct {
+	Hash    string
+	Message string
+}
+
+// CommitMessages returns the messages of the commits in revRange, newest
+// first. An empty revRange reads only HEAD.
+func (c *Client) CommitMessages(revRange string) ([]CommitMessage, error) {
+	// NUL separates hash from message and 0x1e separates commits; neither
+	// can appear in a commit message.
+	args := []string{"log", "--format=%h%x00%B%x1e"}
+	if revRange == "" {
+		args = append(args, "-1", "HEAD")
+	} else {
+		args = append(args, revRange)
 	}
-	return nil
+	cmd := c.execCommand("git", args...)
+	out, err := c.output(cmd)
+	if err != nil {
+		return nil, NewOpError("read commit messages", "git "+strings.Join(args, " "), err)
+	}
+
+	var messages []CommitMessage
+	for _, record := range strings.Split(string(out), "\x1e") {
+		hash, message, ok := strings.Cut(strings.TrimLeft(record, "\n"), "\x00")
+		if !ok {
+			continue
+		}
+		messages = append(messages, CommitMessage{Hash: hash, Message: strings.TrimRight(message, "\n")})
+	}
+	return messages, nil
+}
+
+// LogSimple shows the last ten commits, of paths when any are given.
+func (c *Client) LogSimple(paths ...string) error {
+	return c.showLog("log simple", []string{"--oneline", "--graph", "--decorate", "-10"}, paths)
 }
 
-// LogGraph shows log with graph.
-func (c *Client) LogGraph() error {
-	cmd := c.execCommand("git", "log", "--graph", "--oneline", "--decorate", "--all")
+// LogGraph shows the graph of every branch, limited to commits that touch
+// paths when any are given.
+func (c *Client) LogGraph(paths ...string) error {
+	return c.showLog("log graph", []string{"--graph", "--oneline", "--decorate", "--all"}, paths)
+}
+
+// showLog runs git log with opts on the terminal.
+func (c *Client) showLog(op string, opts, paths []string) error {
+	args := append([]string{"log"}, opts...)
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	cmd := c.execCommand("git", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return NewOpError("log graph", "git log --graph --oneline --decorate --all", err)
+	if err := c.run(cmd); err != nil {
+		return NewOpError(op, "git "+strings.Join(args, " "), err)
 	}
 	return nil
 }
+
+// GraphLine is one line of `git log --graph`. Lines that only continue the
+// graph between commits have an empty Hash.
+type GraphLine struct {
+	Graph   string // the graph columns, e.g. "| * "
+	Hash    string
+	Short   string
+	Refs    []string // full ref names, e.g. refs/heads/main or refs/tags/v1.0
+	Head    string   // the ref HEAD points at, or "HEAD" when detached here
+	Subject string
+	Author  string
+	Date    string // relative, e.g. "3 days ago"
+}
+
+// graphFormat separates the fields with 0x1f, which cannot appear in a ref
+// name and is never part of the graph drawing.
+const graphFormat = "--format=%x1f%H%x1f%h%x1f%D%x1f%s%x1f%an%x1f%ar"
+
+// LogGraphLines returns the graph of the newest limit commits across all
+// refs but the stash, in date order with full ref names.
+func (c *Client) LogGraphLines(limit int) ([]GraphLine, error) {
+	args := []string{"log", "--graph", "--exclude=refs/stash", "--all", "--date-order", "--decorate=full", "--color=never",
+		graphFormat, "-n", strconv.Itoa(limit)}
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return nil, NewOpError("log graph", "git "+strings.Join(args, " "), err)
+	}
+	return ParseGraphLines(string(out)), nil
+}
+
+// ParseGraphLines parses the output of LogGraphLines.
+func ParseGraphLines(output string) []GraphLine {
+	var lines []GraphLine
+	for _, raw := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		fields := strings.Split(raw, "\x1f")
+		if len(fields) < 7 {
+			if raw != "" {
+				lines = append(lines, GraphLine{Graph: raw})
+			}
+			continue
+		}
+		line := GraphLine{
+			Graph:   fields[0],
+			Hash:    fields[1],
+			Short:   fields[2],
+			Subject: fields[4],
+			Author:  fields[5],
+			Date:    fields[6],
+		}
+		line.Refs, line.Head = parseDecorations(fields[3])
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseDecorations splits a %D decoration such as
+// "HEAD -> refs/heads/main, tag: refs/tags/v1, refs/remotes/origin/main".
+func parseDecorations(decorations string) (refs []string, head string) {
+	if decorations == "" {
+		return nil, ""
+	}
+	for _, d := range strings.Split(decorations, ", ") {
+		switch {
+		case d == "HEAD":
+			head = "HEAD"
+		case strings.HasPrefix(d, "HEAD -> "):
+			head = strings.TrimPrefix(d, "HEAD -> ")
+			refs = append(refs, head)
+		default:
+			refs = append(refs, strings.TrimPrefix(d, "tag: "))
+		}
+	}
+	return refs, head
+}
+
+// CommitSummary is what a one-line listing shows of a commit.
+type CommitSummary struct {
+	Hash    string
+	Short   string
+	Subject string
+	Author  string
+	Date    string // relative, e.g. "3 days ago"
+	Merge   bool   // it has more than one parent
+}
+
+// summaryFormat separates the fields with 0x1f, which git never puts in a
+// subject or an author name.
+const summaryFormat = "--format=%H%x1f%h%x1f%s%x1f%an%x1f%ar%x1f%P"
+
+// ListCommits returns the commits git log selects with revs, which may
+// mix revisions, ranges and options such as --no-merges, newest first.
+func (c *Client) ListCommits(revs ...string) ([]CommitSummary, error) {
+	args := append([]string{"log", summaryFormat}, revs...)
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return nil, NewOpError("list commits", "git "+strings.Join(args, " "), err)
+	}
+	return ParseCommitSummaries(string(out)), nil
+}
+
+// ParseCommitSummaries parses the output of ListCommits.
+func ParseCommitSummaries(output string) []CommitSummary {
+	var commits []CommitSummary
+	for _, raw := range strings.Split(output, "\n") {
+		fields := strings.Split(raw, "\x1f")
+		if len(fields) != 6 {
+			continue
+		}
+		commits = append(commits, CommitSummary{
+			Hash:    fields[0],
+			Short:   fields[1],
+			Subject: fields[2],
+			Author:  fields[3],
+			Date:    fields[4],
+			Merge:   len(strings.Fields(fields[5])) > 1,
+		})
+	}
+	return commits
+}
+
+// FileHistoryReader lists the commits that changed a file.
+type FileHistoryReader interface {
+	FileHistory(path string, follow bool) ([]FileRevision, error)
+}
+
+// FileRevision is a commit that changed a file, and the file's path in it,
+// which differs from the path asked for once a rename is followed.
+type FileRevision struct {
+	CommitSummary
+	Path string
+}
+
+// FileHistory returns the commits that changed path, newest first. With
+// follow, the history continues across renames.
+func (c *Client) FileHistory(path string, follow bool) ([]FileRevision, error) {
+	// Each commit starts with 0x1e, so its summary line can be told from
+	// the path --name-only prints after it.
+	args := []string{"log", "--format=%x1e" + strings.TrimPrefix(summaryFormat, "--format="), "--name-only"}
+	if follow {
+		args = append(args, "--follow")
+	}
+	args = append(args, "--", path)
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return nil, NewOpError("file history", "git "+strings.Join(args, " "), err)
+	}
+	return parseFileHistory(string(out), path), nil
+}
+
+// parseFileHistory parses the output of FileHistory. A commit that lists
+// no path, as a merge does, keeps the path of the newer commit listed
+// before it.
+func parseFileHistory(output, path string) []FileRevision {
+	var revs []FileRevision
+	for _, record := range strings.Split(output, "\x1e") {
+		summary, names, _ := strings.Cut(record, "\n")
+		commits := ParseCommitSummaries(summary)
+		if len(commits) != 1 {
+			continue
+		}
+		rev := FileRevision{CommitSummary: commits[0], Path: path}
+		if len(revs) > 0 {
+			rev.Path = revs[len(revs)-1].Path
+		}
+		for _, name := range strings.Split(names, "\n") {
+			if name != "" {
+				rev.Path = name
+				break
+			}
+		}
+		revs = append(revs, rev)
+	}
+	return revs
+}
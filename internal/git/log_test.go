@@ -1,6 +1,7 @@
 package git
 
 import (
+	"errors"
 	"os/exec"
 	"strings"
 	"testing"
@@ -36,3 +37,186 @@ func TestClient_LogSimple(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_CommitMessages(t *testing.T) {
+	tests := []struct {
+		name     string
+		revRange string
+		wantArgs string
+	}{
+		{name: "head only", wantArgs: "log --format=%h%x00%B%x1e -1 HEAD"},
+		{name: "range", revRange: "main..HEAD", wantArgs: "log --format=%h%x00%B%x1e main..HEAD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs string
+			c := &Client{
+				execCommand: func(_ string, arg ...string) *exec.Cmd {
+					gotArgs = strings.Join(arg, " ")
+					return exec.Command("printf", `abc1234\0feat: one\n\nbody\n\n\036\ndef5678\0fix: two\n\n\036`)
+				},
+			}
+
+			got, err := c.CommitMessages(tt.revRange)
+			if err != nil {
+				t.Fatalf("CommitMessages() error = %v", err)
+			}
+			if gotArgs != tt.wantArgs {
+				t.Errorf("args = %q, want %q", gotArgs, tt.wantArgs)
+			}
+			if len(got) != 2 || got[0].Hash != "abc1234" || got[0].Message != "feat: one\n\nbody" || got[1].Message != "fix: two" {
+				t.Errorf("CommitMessages() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestClient_CommitMessages_Error(t *testing.T) {
+	c := &Client{execCommand: func(string, ...string) *exec.Cmd { return helperCommand(t, "", errors.New("bad range")) }}
+	if _, err := c.CommitMessages("nope..HEAD"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestClient_LogGraphLines(t *testing.T) {
+	output := "* \x1fabc123full\x1fabc123\x1fHEAD -> refs/heads/main, tag: refs/tags/v1.0, refs/remotes/origin/main\x1fsecond\x1fAlice\x1f2 hours ago\n" +
+		"|\\  \n" +
+		"| * \x1fdef456full\x1fdef456\x1f\x1ffirst\x1fBob\x1f3 days ago\n"
+	var gotArgs string
+	c := &Client{
+		execCommand: func(_ string, arg ...string) *exec.Cmd {
+			gotArgs = strings.Join(arg, " ")
+			return fakeExecCommand(output)
+		},
+	}
+
+	lines, err := c.LogGraphLines(50)
+	if err != nil {
+		t.Fatalf("LogGraphLines() error = %v", err)
+	}
+	if !strings.Contains(gotArgs, "--graph --exclude=refs/stash --all") || !strings.HasSuffix(gotArgs, "-n 50") {
+		t.Errorf("args = %q", gotArgs)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %+v", len(lines), lines)
+	}
+	first := lines[0]
+	if first.Graph != "* " || first.Hash != "abc123full" || first.Short != "abc123" || first.Subject != "second" ||
+		first.Author != "Alice" || first.Date != "2 hours ago" || first.Head != "refs/heads/main" {
+		t.Errorf("first line = %+v", first)
+	}
+	wantRefs := []string{"refs/heads/main", "refs/tags/v1.0", "refs/remotes/origin/main"}
+	if strings.Join(first.Refs, ",") != strings.Join(wantRefs, ",") {
+		t.Errorf("refs = %v, want %v", first.Refs, wantRefs)
+	}
+	if lines[1].Hash != "" || lines[1].Graph != "|\\  " {
+		t.Errorf("connector line = %+v", lines[1])
+	}
+	if lines[2].Graph != "| * " || lines[2].Refs != nil || lines[2].Head != "" {
+		t.Errorf("third line = %+v", lines[2])
+	}
+}
+
+func TestClient_LogGraphLines_Error(t *testing.T) {
+	c := &Client{
+		execCommand: func(_ string, _ ...string) *exec.Cmd {
+			return helperCommand(t, "", errors.New("fail"))
+		},
+	}
+	if _, err := c.LogGraphLines(10); err == nil {
+		t.Error("LogGraphLines() should fail when git log fails")
+	}
+}
+
+func TestParseGraphLines_DetachedHead(t *testing.T) {
+	lines := ParseGraphLines("* \x1fabc\x1fab\x1fHEAD, refs/heads/topic\x1fsubject\x1fA\x1fnow\n")
+	if len(lines) != 1 || lines[0].Head != "HEAD" || len(lines[0].Refs) != 1 || lines[0].Refs[0] != "refs/heads/topic" {
+		t.Errorf("lines = %+v", lines)
+	}
+}
+
+func TestClient_ListCommits(t *testing.T) {
+	var gotArgs string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = strings.Join(args, " ")
+			return helperCommand(t, "abc123full\x1fabc123\x1fAdd login\x1fAlice\x1f2 hours ago\x1fp1\n"+
+				"def456full\x1fdef456\x1fMerge topic\x1fBob\x1f3 days ago\x1fp1 p2\n", nil)
+		},
+	}
+	commits, err := c.ListCommits("--no-merges", "HEAD..topic")
+	if err != nil {
+		t.Fatalf("ListCommits() error = %v", err)
+	}
+	if want := "log --format=%H%x1f%h%x1f%s%x1f%an%x1f%ar%x1f%P --no-merges HEAD..topic"; gotArgs != want {
+		t.Errorf("args = %q, want %q", gotArgs, want)
+	}
+	want := []CommitSummary{
+		{Hash: "abc123full", Short: "abc123", Subject: "Add login", Author: "Alice", Date: "2 hours ago"},
+		{Hash: "def456full", Short: "def456", Subject: "Merge topic", Author: "Bob", Date: "3 days ago", Merge: true},
+	}
+	if len(commits) != len(want) || commits[0] != want[0] || commits[1] != want[1] {
+		t.Errorf("commits = %+v, want %+v", commits, want)
+	}
+}
+
+func TestClient_ListCommits_Error(t *testing.T) {
+	c := &Client{
+		execCommand: func(_ string, _ ...string) *exec.Cmd {
+			return helperCommand(t, "", errors.New("fail"))
+		},
+	}
+	if _, err := c.ListCommits("HEAD"); err == nil {
+		t.Error("ListCommits() should fail when git log fails")
+	}
+}
+
+func TestClient_FileHistory(t *testing.T) {
+	var gotArgs string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = strings.Join(args, " ")
+			return helperCommand(t, "\x1ec3\x1fc3s\x1fRename\x1fAlice\x1fnow\x1fc2\n\nnew.go\n"+
+				"\x1ec2\x1fc2s\x1fMerge topic\x1fBob\x1fyesterday\x1fc1 t1\n"+
+				"\x1ec1\x1fc1s\x1fAdd\x1fAlice\x1flast week\x1f\n\nold.go\n", nil)
+		},
+	}
+	revs, err := c.FileHistory("new.go", true)
+	if err != nil {
+		t.Fatalf("FileHistory() error = %v", err)
+	}
+	if want := "log --format=%x1e%H%x1f%h%x1f%s%x1f%an%x1f%ar%x1f%P --name-only --follow -- new.go"; gotArgs != want {
+		t.Errorf("args = %q, want %q", gotArgs, want)
+	}
+	if len(revs) != 3 {
+		t.Fatalf("revs = %+v", revs)
+	}
+	if revs[0].Short != "c3s" || revs[0].Path != "new.go" || revs[1].Path != "new.go" || !revs[1].Merge ||
+		revs[2].Path != "old.go" || revs[2].Subject != "Add" {
+		t.Errorf("revs = %+v", revs)
+	}
+}
+
+func TestClient_Log_Paths(t *testing.T) {
+	var calls []string
+	c := &Client{
+		execCommand: func(name string, arg ...string) *exec.Cmd {
+			calls = append(calls, strings.Join(arg, " "))
+			return helperCommand(t, "", nil)
+		},
+	}
+
+	if err := c.LogSimple(":(top)apps/web"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LogGraph(":(top)apps/web"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"log --oneline --graph --decorate -10 -- :(top)apps/web",
+		"log --graph --oneline --decorate --all -- :(top)apps/web",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
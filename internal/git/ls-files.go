@@ -8,7 +8,7 @@ type FileLister interface {
 // ListFiles lists all files managed by git.
 func (c *Client) ListFiles() (string, error) {
 	cmd := c.execCommand("git", "ls-files")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return "", NewOpError("list files", "git ls-files", err)
 	}
@@ -0,0 +1,140 @@
+package git
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RepoStats describes the object store, as `git count-objects -v` reports
+// it. Sizes are in bytes.
+type RepoStats struct {
+	LooseObjects  int
+	LooseSize     int64
+	PackedObjects int
+	Packs         int
+	PackSize      int64
+	PrunePackable int // loose objects that are also in a pack
+	Garbage       int // files in the object store that are not objects
+	GarbageSize   int64
+}
+
+// Size returns the disk space the object store takes.
+func (s RepoStats) Size() int64 {
+	return s.LooseSize + s.PackSize + s.GarbageSize
+}
+
+// BlobSize is a file in history and how large it is. Size is the content
+// size and DiskSize what it takes once compressed and deltified.
+type BlobSize struct {
+	Path     string
+	ID       string
+	Size     int64
+	DiskSize int64
+}
+
+// MaintenanceReader reports on the object store for ggc maintenance analyze.
+type MaintenanceReader interface {
+	RepoStats() (RepoStats, error)
+	LargestBlobs(n int) ([]BlobSize, error)
+}
+
+// LargeFileReader finds the largest files in history and the commits that
+// added them, for ggc analyze large-files.
+type LargeFileReader interface {
+	LargestBlobs(n int) ([]BlobSize, error)
+	BlobCommit(id string) (string, error)
+}
+
+// RepoStats reads the object store statistics with `git count-objects -v`.
+func (c *Client) RepoStats() (RepoStats, error) {
+	out, err := c.output(c.execCommand("git", "count-objects", "-v"))
+	if err != nil {
+		return RepoStats{}, NewOpError("count objects", "git count-objects -v", err)
+	}
+	return parseCountObjects(string(out)), nil
+}
+
+// parseCountObjects reads the output of `git count-objects -v`, whose
+// sizes are in KiB.
+func parseCountObjects(out string) RepoStats {
+	var s RepoStats
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "count":
+			s.LooseObjects = int(n)
+		case "size":
+			s.LooseSize = n * 1024
+		case "in-pack":
+			s.PackedObjects = int(n)
+		case "packs":
+			s.Packs = int(n)
+		case "size-pack":
+			s.PackSize = n * 1024
+		case "prune-packable":
+			s.PrunePackable = int(n)
+		case "garbage":
+			s.Garbage = int(n)
+		case "size-garbage":
+			s.GarbageSize = n * 1024
+		}
+	}
+	return s
+}
+
+// LargestBlobs returns the n largest files reachable from any ref, largest
+// first. A blob stored under several paths is listed under the first one
+// `git rev-list --objects` names.
+func (c *Client) LargestBlobs(n int) ([]BlobSize, error) {
+	objects, err := c.output(c.execCommand("git", "rev-list", "--objects", "--all"))
+	if err != nil {
+		return nil, NewOpError("list objects", "git rev-list --objects --all", err)
+	}
+	args := []string{"cat-file", "--batch-check=%(objecttype) %(objectname) %(objectsize) %(objectsize:disk) %(rest)"}
+	cmd := c.execCommand("git", args...)
+	cmd.Stdin = bytes.NewReader(objects)
+	out, err := c.output(cmd)
+	if err != nil {
+		return nil, NewOpError("read object sizes", "git "+strings.Join(args, " "), err)
+	}
+	var blobs []BlobSize
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.SplitN(line, " ", 5)
+		if len(fields) < 5 || fields[0] != "blob" {
+			continue
+		}
+		size, err1 := strconv.ParseInt(fields[2], 10, 64)
+		disk, err2 := strconv.ParseInt(fields[3], 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		blobs = append(blobs, BlobSize{Path: fields[4], ID: fields[1], Size: size, DiskSize: disk})
+	}
+	slices.SortStableFunc(blobs, func(a, b BlobSize) int { return cmp.Compare(b.Size, a.Size) })
+	if n > 0 && len(blobs) > n {
+		blobs = blobs[:n]
+	}
+	return blobs, nil
+}
+
+// BlobCommit returns the abbreviated hash of the oldest commit on any ref
+// that adds the blob id, "" when none does.
+func (c *Client) BlobCommit(id string) (string, error) {
+	args := []string{"log", "--all", "--reverse", "--format=%h", "--find-object=" + id}
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return "", NewOpError("find commit adding "+id, "git "+strings.Join(args, " "), err)
+	}
+	first, _, _ := strings.Cut(string(out), "\n")
+	return strings.TrimSpace(first), nil
+}
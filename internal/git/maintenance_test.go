@@ -0,0 +1,82 @@
+package git
+
+import (
+	"os/exec"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestClient_RepoStats(t *testing.T) {
+	out := "count: 12\nsize: 48\nin-pack: 3400\npacks: 3\nsize-pack: 2048\nprune-packable: 2\ngarbage: 1\nsize-garbage: 4\n"
+	c := &Client{
+		execCommand: func(_ string, args ...string) *exec.Cmd {
+			if !slices.Equal(args, []string{"count-objects", "-v"}) {
+				t.Errorf("args = %v", args)
+			}
+			return helperCommand(t, out, nil)
+		},
+	}
+	got, err := c.RepoStats()
+	if err != nil {
+		t.Fatalf("RepoStats() error = %v", err)
+	}
+	want := RepoStats{
+		LooseObjects: 12, LooseSize: 48 * 1024,
+		PackedObjects: 3400, Packs: 3, PackSize: 2048 * 1024,
+		PrunePackable: 2, Garbage: 1, GarbageSize: 4 * 1024,
+	}
+	if got != want {
+		t.Errorf("RepoStats() = %+v, want %+v", got, want)
+	}
+	if got.Size() != (48+2048+4)*1024 {
+		t.Errorf("Size() = %d", got.Size())
+	}
+}
+
+func TestClient_LargestBlobs(t *testing.T) {
+	batch := "commit c1 250 180 \n" +
+		"tree t1 90 80 \n" +
+		"blob b1 1200 900 README.md\n" +
+		"blob b2 5000000 4800000 assets/video.mp4\n" +
+		"blob b3 30000 12000 docs/guide with spaces.md\n"
+	var calls [][]string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			calls = append(calls, append([]string{name}, args...))
+			if args[0] == "rev-list" {
+				return helperCommand(t, "c1\nt1\nb1 README.md\n", nil)
+			}
+			return helperCommand(t, batch, nil)
+		},
+	}
+	got, err := c.LargestBlobs(2)
+	if err != nil {
+		t.Fatalf("LargestBlobs() error = %v", err)
+	}
+	want := []BlobSize{
+		{Path: "assets/video.mp4", ID: "b2", Size: 5000000, DiskSize: 4800000},
+		{Path: "docs/guide with spaces.md", ID: "b3", Size: 30000, DiskSize: 12000},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LargestBlobs() = %+v, want %+v", got, want)
+	}
+	if len(calls) != 2 || calls[0][1] != "rev-list" || calls[1][1] != "cat-file" {
+		t.Errorf("calls = %v", calls)
+	}
+}
+
+func TestClient_BlobCommit(t *testing.T) {
+	c := &Client{
+		execCommand: func(_ string, args ...string) *exec.Cmd {
+			if want := []string{"log", "--all", "--reverse", "--format=%h", "--find-object=b2"}; !slices.Equal(args, want) {
+				t.Errorf("args = %v, want %v", args, want)
+			}
+			return helperCommand(t, "a1b2c3d\nd4e5f60\n", nil)
+		},
+	}
+	got, err := c.BlobCommit("b2")
+	if err != nil || got != "a1b2c3d" {
+		t.Errorf("BlobCommit() = %q, %v; want a1b2c3d", got, err)
+	}
+}
@@ -0,0 +1,21 @@
+package git
+
+import "os"
+
+// MergeOps joins another line of history into the current branch.
+type MergeOps interface {
+	Merge(ref string) error
+}
+
+// Merge merges ref into the current branch, keeping git's default message
+// for a merge commit.
+func (c *Client) Merge(ref string) error {
+	defer c.InvalidateStatusCache()
+	cmd := c.execCommand("git", "merge", "--no-edit", ref)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("merge", "git merge --no-edit "+ref, err)
+	}
+	return nil
+}
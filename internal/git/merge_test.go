@@ -0,0 +1,35 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func TestClient_Merge(t *testing.T) {
+	var gotArgs []string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return helperCommand(t, "", nil)
+		},
+	}
+	if err := c.Merge("origin/main"); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if want := []string{"git", "merge", "--no-edit", "origin/main"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+}
+
+func TestClient_Merge_Error(t *testing.T) {
+	c := &Client{
+		execCommand: func(_ string, _ ...string) *exec.Cmd {
+			return helperCommand(t, "", errors.New("conflict"))
+		},
+	}
+	if err := c.Merge("origin/main"); err == nil {
+		t.Error("Merge() should fail when git merge fails")
+	}
+}
@@ -0,0 +1,156 @@
+package git
+
+import (
+	"os"
+	"strings"
+)
+
+// NotesRefPrefix is where git keeps notes refs.
+const NotesRefPrefix = "refs/notes/"
+
+// Note is a note on a commit.
+type Note struct {
+	Commit  string // abbreviated
+	Subject string // of the commit
+	Text    string
+}
+
+// NotesReader reads the notes under one notes ref.
+type NotesReader interface {
+	NotedCommits(ref string) ([]string, error)
+	Notes(ref string) ([]Note, error)
+	NoteText(ref, commit string) (string, error)
+}
+
+// NotesFetcher brings in a remote's notes.
+type NotesFetcher interface {
+	FetchNotes(remote, ref string) (bool, error)
+}
+
+// NotesOps writes, reads and shares the notes under one notes ref.
+type NotesOps interface {
+	NotesReader
+	NotesFetcher
+	EditNote(ref, commit, message string) error
+	PushNotes(remote, ref string) error
+}
+
+// NotesRef returns the full notes ref for ref, which may be given as
+// "review" or "refs/notes/review".
+func NotesRef(ref string) string {
+	if strings.HasPrefix(ref, NotesRefPrefix) {
+		return ref
+	}
+	return NotesRefPrefix + ref
+}
+
+// NotedCommits returns the full hashes of the commits that have a note
+// under ref, none when ref does not exist.
+func (c *Client) NotedCommits(ref string) ([]string, error) {
+	ref = NotesRef(ref)
+	out, err := c.output(c.execCommand("git", "notes", "--ref", ref, "list"))
+	if err != nil {
+		return nil, NewOpError("list notes", "git notes --ref "+ref+" list", err)
+	}
+	var commits []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		// Each line is the note's blob and the object it annotates.
+		if fields := strings.Fields(line); len(fields) == 2 {
+			commits = append(commits, fields[1])
+		}
+	}
+	return commits, nil
+}
+
+// Notes returns the notes under ref with the commits they annotate, the
+// most recent commit first.
+func (c *Client) Notes(ref string) ([]Note, error) {
+	commits, err := c.NotedCommits(ref)
+	if err != nil || len(commits) == 0 {
+		return nil, err
+	}
+	ref = NotesRef(ref)
+	// A note can span lines, so each entry ends with 0x1e and its fields
+	// are separated by 0x1f.
+	args := append([]string{"log", "--no-walk=sorted", "--no-notes", "--notes=" + ref,
+		"--format=%h%x1f%s%x1f%N%x1e"}, commits...)
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return nil, NewOpError("list notes", "git log --no-walk --notes="+ref, err)
+	}
+	var notes []Note
+	for _, entry := range strings.Split(string(out), "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(entry, "\n"), "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		notes = append(notes, Note{Commit: fields[0], Subject: fields[1], Text: strings.TrimSpace(fields[2])})
+	}
+	return notes, nil
+}
+
+// NoteText returns the note on commit under ref, empty when it has none.
+func (c *Client) NoteText(ref, commit string) (string, error) {
+	ref = NotesRef(ref)
+	out, err := c.output(c.execCommand("git", "show", "-s", "--no-notes", "--notes="+ref, "--format=%N", commit))
+	if err != nil {
+		return "", NewOpError("show note", "git show -s --notes="+ref+" "+commit, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// EditNote adds message to the note on commit under ref, starting the
+// note when there is none. With an empty message git opens the editor on
+// the note as it is.
+func (c *Client) EditNote(ref, commit, message string) error {
+	ref = NotesRef(ref)
+	args := []string{"notes", "--ref", ref, "edit", commit}
+	if message != "" {
+		args = []string{"notes", "--ref", ref, "append", "-m", message, commit}
+	}
+	cmd := c.execCommand("git", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("edit note", "git "+strings.Join(args[:4], " ")+" "+commit, err)
+	}
+	return nil
+}
+
+// PushNotes pushes the notes under ref to remote. git refuses when the
+// remote's notes have moved on; FetchNotes merges them first.
+func (c *Client) PushNotes(remote, ref string) error {
+	ref = NotesRef(ref)
+	cmd := c.execCommand("git", "push", remote, ref)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("push notes", "git push "+remote+" "+ref, err)
+	}
+	return nil
+}
+
+// FetchNotes fetches the remote's notes and merges those under ref into
+// the local ones; found is false when the remote has none there. Notes on
+// the same commit from both sides are joined rather than left as a
+// conflict. The remote's notes refs are kept under
+// refs/notes/remotes/<remote>/, which git log does not show.
+func (c *Client) FetchNotes(remote, ref string) (found bool, err error) {
+	ref = NotesRef(ref)
+	// A pattern fetches nothing, rather than failing, when the remote has
+	// no notes.
+	tracking := NotesRefPrefix + "remotes/" + remote + "/"
+	refspec := "+" + NotesRefPrefix + "*:" + tracking + "*"
+	if err := c.run(c.execCommand("git", "fetch", "--quiet", remote, refspec)); err != nil {
+		return false, NewOpError("fetch notes", "git fetch "+remote+" "+refspec, err)
+	}
+	tracking += strings.TrimPrefix(ref, NotesRefPrefix)
+	if !c.RevParseVerify(tracking) {
+		return false, nil
+	}
+	if err := c.run(c.execCommand("git", "notes", "--ref", ref, "merge", "--quiet", "--strategy=cat_sort_uniq", tracking)); err != nil {
+		return false, NewOpError("fetch notes", "git notes --ref "+ref+" merge "+tracking, err)
+	}
+	return true, nil
+}
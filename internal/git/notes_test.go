@@ -0,0 +1,154 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func TestNotesRef(t *testing.T) {
+	for ref, want := range map[string]string{
+		"commits":           "refs/notes/commits",
+		"review":            "refs/notes/review",
+		"refs/notes/review": "refs/notes/review",
+	} {
+		if got := NotesRef(ref); got != want {
+			t.Errorf("NotesRef(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestClient_Notes(t *testing.T) {
+	var calls [][]string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			calls = append(calls, append([]string{name}, args...))
+			if args[0] == "notes" {
+				return fakeExecCommand("5aad702 1b83008aaa\n8b493c9 babe9e0bbb\n")
+			}
+			return fakeExecCommand("1b83008\x1ftwo\x1fLGTM\n\x1e\nbabe9e0\x1fone\x1fneeds a test\n\nand docs\n\x1e\n")
+		},
+	}
+	notes, err := c.Notes("review")
+	if err != nil {
+		t.Fatalf("Notes: %v", err)
+	}
+	want := []Note{
+		{Commit: "1b83008", Subject: "two", Text: "LGTM"},
+		{Commit: "babe9e0", Subject: "one", Text: "needs a test\n\nand docs"},
+	}
+	if !slices.Equal(notes, want) {
+		t.Errorf("notes = %#v, want %#v", notes, want)
+	}
+	wantCalls := [][]string{
+		{"git", "notes", "--ref", "refs/notes/review", "list"},
+		{"git", "log", "--no-walk=sorted", "--no-notes", "--notes=refs/notes/review", "--format=%h%x1f%s%x1f%N%x1e", "1b83008aaa", "babe9e0bbb"},
+	}
+	if !slices.EqualFunc(calls, wantCalls, slices.Equal[[]string]) {
+		t.Errorf("calls = %v, want %v", calls, wantCalls)
+	}
+}
+
+func TestClient_Notes_None(t *testing.T) {
+	calls := 0
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			calls++
+			return helperCommand(t, "", nil)
+		},
+	}
+	notes, err := c.Notes("commits")
+	if err != nil || len(notes) != 0 {
+		t.Fatalf("Notes = %v, %v; want none", notes, err)
+	}
+	if calls != 1 {
+		t.Errorf("ran git %d times, want only the list", calls)
+	}
+}
+
+func TestClient_EditNote(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []string
+	}{
+		{"editor", "", []string{"git", "notes", "--ref", "refs/notes/commits", "edit", "HEAD"}},
+		{"message", "LGTM", []string{"git", "notes", "--ref", "refs/notes/commits", "append", "-m", "LGTM", "HEAD"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			c := &Client{
+				execCommand: func(name string, args ...string) *exec.Cmd {
+					got = append([]string{name}, args...)
+					return helperCommand(t, "", nil)
+				},
+			}
+			if err := c.EditNote("commits", "HEAD", tt.message); err != nil {
+				t.Fatalf("EditNote: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ran %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_FetchNotes(t *testing.T) {
+	var calls [][]string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			calls = append(calls, append([]string{name}, args...))
+			return helperCommand(t, "", nil)
+		},
+	}
+	found, err := c.FetchNotes("origin", "review")
+	if err != nil || !found {
+		t.Fatalf("FetchNotes = %v, %v; want found", found, err)
+	}
+	want := [][]string{
+		{"git", "fetch", "--quiet", "origin", "+refs/notes/*:refs/notes/remotes/origin/*"},
+		{"git", "rev-parse", "--verify", "--quiet", "refs/notes/remotes/origin/review"},
+		{"git", "notes", "--ref", "refs/notes/review", "merge", "--quiet", "--strategy=cat_sort_uniq", "refs/notes/remotes/origin/review"},
+	}
+	if !slices.EqualFunc(calls, want, slices.Equal[[]string]) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestClient_FetchNotes_NoneOnRemote(t *testing.T) {
+	var calls []string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			calls = append(calls, args[0])
+			if args[0] == "rev-parse" {
+				return helperCommand(t, "", errors.New("no such ref"))
+			}
+			return helperCommand(t, "", nil)
+		},
+	}
+	found, err := c.FetchNotes("origin", "commits")
+	if err != nil || found {
+		t.Fatalf("FetchNotes = %v, %v; want not found and no error", found, err)
+	}
+	if !slices.Equal(calls, []string{"fetch", "rev-parse"}) {
+		t.Errorf("ran %v, want no merge without remote notes", calls)
+	}
+}
+
+func TestClient_FetchNotes_FetchFails(t *testing.T) {
+	calls := 0
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			calls++
+			return helperCommand(t, "", errors.New("could not read from remote"))
+		},
+	}
+	if _, err := c.FetchNotes("origin", "commits"); err == nil {
+		t.Fatal("FetchNotes succeeded, want the fetch error")
+	}
+	if calls != 1 {
+		t.Errorf("ran git %d times, want nothing after the failed fetch", calls)
+	}
+}
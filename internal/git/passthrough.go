@@ -17,11 +17,12 @@ type PassthroughOps interface {
 // process so the user sees normal git output (including pagers when the
 // terminal supports them).
 func (c *Client) RunGit(name string, args []string) error {
+	defer c.InvalidateStatusCache()
 	gitArgs := append([]string{name}, args...)
 	cmd := c.execCommand("git", gitArgs...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError(name, "git "+name+joinArgs(args), err)
 	}
 	return nil
@@ -0,0 +1,41 @@
+package git
+
+import (
+	"io"
+	"os"
+)
+
+// ProgressSink receives the stderr of a command that transfers objects,
+// with git's progress reports in it. Close is called once the command
+// has exited.
+type ProgressSink interface {
+	io.Writer
+	Close() error
+}
+
+// WithProgress returns a shallow copy of the client that asks git for
+// progress on fetch, pull and push, even when stderr is not a terminal,
+// and streams stderr to a sink from newSink instead of os.Stderr.
+func (c *Client) WithProgress(newSink func() ProgressSink) *Client {
+	clone := *c
+	clone.progress = newSink
+	return &clone
+}
+
+// transferStderr returns the stderr for a transfer command and the
+// function to call when it exits.
+func (c *Client) transferStderr() (io.Writer, func()) {
+	if c.progress == nil {
+		return os.Stderr, func() {}
+	}
+	sink := c.progress()
+	return sink, func() { _ = sink.Close() }
+}
+
+// progressArgs adds --progress after the subcommand when progress is on.
+func (c *Client) progressArgs(args []string) []string {
+	if c.progress == nil || len(args) == 0 {
+		return args
+	}
+	return append([]string{args[0], "--progress"}, args[1:]...)
+}
@@ -0,0 +1,51 @@
+package git
+
+import (
+	"bytes"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+type recordingSink struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestClient_FetchWithProgress(t *testing.T) {
+	var gotArgs []string
+	sink := &recordingSink{}
+	c := (&Client{
+		execCommand: func(_ string, arg ...string) *exec.Cmd {
+			gotArgs = arg
+			return exec.Command("sh", "-c", `printf 'Receiving objects: 100%% (1/1), done.\n' >&2`)
+		},
+	}).WithProgress(func() ProgressSink { return sink })
+
+	if err := c.Fetch(true); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"fetch", "--progress", "--prune"}; !reflect.DeepEqual(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+	if sink.String() != "Receiving objects: 100% (1/1), done.\n" || !sink.closed {
+		t.Errorf("sink got %q, closed=%v", sink.String(), sink.closed)
+	}
+}
+
+func TestClient_ProgressArgs(t *testing.T) {
+	c := &Client{}
+	args := []string{"push", "origin", "main"}
+	if got := c.progressArgs(args); !reflect.DeepEqual(got, args) {
+		t.Errorf("without progress: %v", got)
+	}
+	c = c.WithProgress(func() ProgressSink { return &recordingSink{} })
+	if got, want := c.progressArgs(args), []string{"push", "--progress", "origin", "main"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("progressArgs() = %v, want %v", got, want)
+	}
+}
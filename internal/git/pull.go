@@ -14,14 +14,17 @@ type Puller interface {
 
 // Pull pulls from a remote.
 func (c *Client) Pull(rebase bool) error {
+	defer c.InvalidateStatusCache()
 	args := []string{"pull"}
 	if rebase {
 		args = append(args, "--rebase")
 	}
-	cmd := c.execCommand("git", args...)
+	stderr, done := c.transferStderr()
+	defer done()
+	cmd := c.execCommand("git", c.progressArgs(args)...)
 	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	cmd.Stderr = stderr
+	if err := c.run(cmd); err != nil {
 		return NewOpError("pull", fmt.Sprintf("git %s", strings.Join(args, " ")), err)
 	}
 	return nil
@@ -12,21 +12,160 @@ type Pusher interface {
 	Push(force bool) error
 }
 
-// Push pushes to a remote.
+// ForcePusher force-pushes the current branch. lease uses
+// --force-with-lease, which refuses to overwrite remote commits that have
+// not been fetched; without it the push uses --force.
+type ForcePusher interface {
+	PushForce(lease bool) error
+}
+
+// PushPreviewer tells what pushing the current branch would do.
+type PushPreviewer interface {
+	PushPreview() (*PushPreview, error)
+}
+
+// PushPreview is what pushing the current branch to origin would change,
+// as of the last fetch.
+type PushPreview struct {
+	Remote string
+	Branch string
+	// New is set when the remote has no such branch yet. Ahead then
+	// lists the commits that are on no remote branch.
+	New bool
+	// Ahead are the commits the push sends.
+	Ahead []CommitSummary
+	// Behind are the remote branch's commits that the branch lacks. A
+	// force push drops them; a plain push is rejected.
+	Behind []CommitSummary
+}
+
+// OutgoingPatchReader reads the changes pushing the current branch would
+// send, for the secret scan before a push.
+type OutgoingPatchReader interface {
+	OutgoingPatch() (string, error)
+}
+
+// UpstreamPusher pushes a branch and records the remote branch as its
+// upstream.
+type UpstreamPusher interface {
+	PushSetUpstream(remote, branch string) error
+}
+
+// RemoteBranchDeleter deletes a branch on a remote.
+type RemoteBranchDeleter interface {
+	DeleteRemoteBranch(remote, branch string) error
+}
+
+// Push pushes the current branch to origin; force uses
+// --force-with-lease.
 func (c *Client) Push(force bool) error {
+	if force {
+		return c.push("--force-with-lease")
+	}
+	return c.push("")
+}
+
+// PushForce force-pushes the current branch to origin, with
+// --force-with-lease when lease is set and --force otherwise.
+func (c *Client) PushForce(lease bool) error {
+	if lease {
+		return c.push("--force-with-lease")
+	}
+	return c.push("--force")
+}
+
+// PushPreview reads what pushing the current branch to origin would send
+// and overwrite, from the remote-tracking branch.
+func (c *Client) PushPreview() (*PushPreview, error) {
+	branch, err := c.GetCurrentBranch()
+	if err != nil {
+		return nil, NewOpError("push preview", "get current branch", err)
+	}
+	p := &PushPreview{Remote: "origin", Branch: branch}
+	tracking := "refs/remotes/origin/" + branch
+	if !c.RevParseVerify(tracking) {
+		p.New = true
+		p.Ahead, err = c.ListCommits("HEAD", "--not", "--remotes=origin")
+		return p, err
+	}
+	if p.Ahead, err = c.ListCommits(tracking + "..HEAD"); err != nil {
+		return nil, err
+	}
+	if p.Behind, err = c.ListCommits("HEAD.." + tracking); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// OutgoingPatch returns the patches of the commits pushing the current
+// branch to origin would send, as `git log -p -U0` prints them with each
+// commit starting with a NUL and its abbreviated hash. Without a
+// remote-tracking branch those are the commits on no remote branch.
+func (c *Client) OutgoingPatch() (string, error) {
+	branch, err := c.GetCurrentBranch()
+	if err != nil {
+		return "", NewOpError("read outgoing changes", "get current branch", err)
+	}
+	args := []string{"-c", "core.quotePath=false", "log", "-p", "-U0", "--no-color", "--no-ext-diff", "--format=%x00%h"}
+	if tracking := "refs/remotes/origin/" + branch; c.RevParseVerify(tracking) {
+		args = append(args, tracking+"..HEAD")
+	} else {
+		args = append(args, "HEAD", "--not", "--remotes=origin")
+	}
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return "", NewOpError("read outgoing changes", "git "+strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
+
+// push pushes the current branch to origin with flag, if any.
+func (c *Client) push(flag string) error {
+	defer c.InvalidateStatusCache()
 	branch, err := c.GetCurrentBranch()
 	if err != nil {
 		return NewOpError("push", "get current branch", err)
 	}
 	args := []string{"push", "origin", branch}
-	if force {
-		args = append(args, "--force-with-lease")
+	if flag != "" {
+		args = append(args, flag)
 	}
-	cmd := c.execCommand("git", args...)
+	stderr, done := c.transferStderr()
+	defer done()
+	cmd := c.execCommand("git", c.progressArgs(args)...)
 	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	cmd.Stderr = stderr
+	if err := c.run(cmd); err != nil {
 		return NewOpError("push", fmt.Sprintf("git %s", strings.Join(args, " ")), err)
 	}
 	return nil
 }
+
+// PushSetUpstream pushes branch to remote and sets it as the upstream.
+func (c *Client) PushSetUpstream(remote, branch string) error {
+	defer c.InvalidateStatusCache()
+	stderr, done := c.transferStderr()
+	defer done()
+	cmd := c.execCommand("git", c.progressArgs([]string{"push", "--set-upstream", remote, branch})...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("push", "git push --set-upstream "+remote+" "+branch, err)
+	}
+	return nil
+}
+
+// DeleteRemoteBranch deletes branch on remote, along with its
+// remote-tracking branch.
+func (c *Client) DeleteRemoteBranch(remote, branch string) error {
+	defer c.InvalidateStatusCache()
+	stderr, done := c.transferStderr()
+	defer done()
+	cmd := c.execCommand("git", "push", remote, "--delete", branch)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("delete remote branch", "git push "+remote+" --delete "+branch, err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package git
 import (
 	"os/exec"
 	"slices"
+	"strings"
 	"testing"
 )
 
@@ -48,3 +49,149 @@ func TestClient_Push(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_PushSetUpstream(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("echo")
+		},
+	}
+
+	if err := client.PushSetUpstream("upstream", "feature"); err != nil {
+		t.Fatalf("PushSetUpstream() error = %v", err)
+	}
+	wantArgs := []string{"git", "push", "--set-upstream", "upstream", "feature"}
+	if !slices.Equal(gotArgs, wantArgs) {
+		t.Errorf("PushSetUpstream() gotArgs = %v, want %v", gotArgs, wantArgs)
+	}
+}
+
+func TestClient_DeleteRemoteBranch(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("echo")
+		},
+	}
+
+	if err := client.DeleteRemoteBranch("origin", "feature/old"); err != nil {
+		t.Fatalf("DeleteRemoteBranch() error = %v", err)
+	}
+	wantArgs := []string{"git", "push", "origin", "--delete", "feature/old"}
+	if !slices.Equal(gotArgs, wantArgs) {
+		t.Errorf("DeleteRemoteBranch() gotArgs = %v, want %v", gotArgs, wantArgs)
+	}
+}
+
+func TestClient_PushForce(t *testing.T) {
+	for lease, flag := range map[bool]string{true: "--force-with-lease", false: "--force"} {
+		var gotArgs []string
+		client := &Client{
+			execCommand: func(name string, args ...string) *exec.Cmd {
+				if args[0] == "rev-parse" {
+					return exec.Command("echo", "-n", "main")
+				}
+				gotArgs = append([]string{name}, args...)
+				return exec.Command("echo")
+			},
+		}
+		if err := client.PushForce(lease); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"git", "push", "origin", "main", flag}; !slices.Equal(gotArgs, want) {
+			t.Errorf("PushForce(%v) ran %v, want %v", lease, gotArgs, want)
+		}
+	}
+}
+
+func TestClient_PushPreview(t *testing.T) {
+	commit := func(short, subject string) string {
+		return short + "full\x1f" + short + "\x1f" + subject + "\x1fme\x1f1 hour ago\x1fparent"
+	}
+	tests := []struct {
+		name       string
+		tracking   bool
+		wantNew    bool
+		wantAhead  []string
+		wantBehind []string
+	}{
+		{"existing branch", true, false, []string{"a1"}, []string{"b1"}},
+		{"new branch", false, true, []string{"n1"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				execCommand: func(name string, args ...string) *exec.Cmd {
+					joined := strings.Join(args, " ")
+					switch {
+					case strings.Contains(joined, "--verify"):
+						if tt.tracking {
+							return exec.Command("true")
+						}
+						return exec.Command("false")
+					case args[0] == "rev-parse":
+						return exec.Command("echo", "-n", "feature")
+					case strings.HasSuffix(joined, "refs/remotes/origin/feature..HEAD"):
+						return exec.Command("echo", commit("a1", "add"))
+					case strings.HasSuffix(joined, "HEAD..refs/remotes/origin/feature"):
+						return exec.Command("echo", commit("b1", "theirs"))
+					case strings.HasSuffix(joined, "HEAD --not --remotes=origin"):
+						return exec.Command("echo", commit("n1", "new"))
+					}
+					t.Errorf("unexpected git %s", joined)
+					return exec.Command("false")
+				},
+			}
+			p, err := client.PushPreview()
+			if err != nil {
+				t.Fatal(err)
+			}
+			shorts := func(cs []CommitSummary) []string {
+				var out []string
+				for _, c := range cs {
+					out = append(out, c.Short)
+				}
+				return out
+			}
+			if p.Branch != "feature" || p.New != tt.wantNew || !slices.Equal(shorts(p.Ahead), tt.wantAhead) || !slices.Equal(shorts(p.Behind), tt.wantBehind) {
+				t.Errorf("PushPreview() = %+v", p)
+			}
+		})
+	}
+}
+
+func TestClient_OutgoingPatch(t *testing.T) {
+	for _, tracking := range []bool{true, false} {
+		var logArgs string
+		client := &Client{
+			execCommand: func(name string, args ...string) *exec.Cmd {
+				joined := strings.Join(args, " ")
+				switch {
+				case strings.Contains(joined, "--verify"):
+					if tracking {
+						return exec.Command("true")
+					}
+					return exec.Command("false")
+				case args[0] == "rev-parse":
+					return exec.Command("echo", "-n", "feature")
+				}
+				logArgs = joined
+				return exec.Command("echo", "-n", "patch")
+			},
+		}
+		out, err := client.OutgoingPatch()
+		if err != nil || out != "patch" {
+			t.Fatalf("OutgoingPatch() = %q, %v", out, err)
+		}
+		want := "-c core.quotePath=false log -p -U0 --no-color --no-ext-diff --format=%x00%h HEAD --not --remotes=origin"
+		if tracking {
+			want = "-c core.quotePath=false log -p -U0 --no-color --no-ext-diff --format=%x00%h refs/remotes/origin/feature..HEAD"
+		}
+		if logArgs != want {
+			t.Errorf("tracking=%v: git %s, want git %s", tracking, logArgs, want)
+		}
+	}
+}
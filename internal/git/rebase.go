@@ -3,6 +3,7 @@ package git
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -11,6 +12,7 @@ type RebaseOps interface {
 	// sequence operations
 	RebaseInteractive(commitCount int) error
 	RebaseInteractiveAutosquash(commitCount int) error
+	RebaseInteractiveWithTodo(commitCount int, todo string) error
 	Rebase(upstream string) error
 	RebaseContinue() error
 	RebaseAbort() error
@@ -25,7 +27,7 @@ type RebaseOps interface {
 // LogOneline gets git log output in oneline format between commits.
 func (c *Client) LogOneline(from, to string) (string, error) {
 	cmd := c.execCommand("git", "log", "--oneline", "--reverse", fmt.Sprintf("%s..%s", from, to))
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return "", NewOpError("log oneline", fmt.Sprintf("git log --oneline --reverse %s..%s", from, to), err)
 	}
@@ -38,7 +40,7 @@ func (c *Client) RebaseInteractive(commitCount int) error {
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("rebase interactive", fmt.Sprintf("git rebase -i HEAD~%d", commitCount), err)
 	}
 	return nil
@@ -50,31 +52,80 @@ func (c *Client) RebaseInteractiveAutosquash(commitCount int) error {
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("rebase interactive autosquash", fmt.Sprintf("git rebase -i --autosquash HEAD~%d", commitCount), err)
 	}
 	return nil
 }
 
+// RebaseInteractiveWithTodo starts an interactive rebase for the specified
+// number of commits using a prepared todo list instead of opening the
+// sequence editor. Reword and edit steps still stop for the user as usual.
+func (c *Client) RebaseInteractiveWithTodo(commitCount int, todo string) error {
+	f, err := os.CreateTemp("", "ggc-rebase-todo-*")
+	if err != nil {
+		return NewOpError("rebase interactive", "create todo file", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.WriteString(todo); err != nil {
+		_ = f.Close()
+		return NewOpError("rebase interactive", "write todo file", err)
+	}
+	if err := f.Close(); err != nil {
+		return NewOpError("rebase interactive", "write todo file", err)
+	}
+
+	// git runs the sequence editor through the shell with the todo path
+	// appended, so copying our file over it installs the prepared list.
+	cmd := c.execCommand("git", "rebase", "-i", fmt.Sprintf("HEAD~%d", commitCount))
+	cmd.Env = append(os.Environ(), "GIT_SEQUENCE_EDITOR=cp "+shellQuote(filepath.ToSlash(f.Name())))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("rebase interactive", fmt.Sprintf("git rebase -i HEAD~%d", commitCount), err)
+	}
+	return nil
+}
+
+// shellQuote single-quotes s for POSIX sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 // Rebase performs a basic rebase onto the given upstream reference.
 func (c *Client) Rebase(upstream string) error {
 	cmd := c.execCommand("git", "rebase", upstream)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("rebase", fmt.Sprintf("git rebase %s", upstream), err)
 	}
 	return nil
 }
 
+// RebaseOnto replays the commits of branch after upstream onto newBase,
+// leaving branch checked out.
+func (c *Client) RebaseOnto(newBase, upstream, branch string) error {
+	defer c.InvalidateStatusCache()
+	cmd := c.execCommand("git", "rebase", "--onto", newBase, upstream, branch)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("rebase", fmt.Sprintf("git rebase --onto %s %s %s", newBase, upstream, branch), err)
+	}
+	return nil
+}
+
 // RebaseContinue continues an in-progress rebase.
 func (c *Client) RebaseContinue() error {
 	cmd := c.execCommand("git", "rebase", "--continue")
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("rebase continue", "git rebase --continue", err)
 	}
 	return nil
@@ -86,7 +137,7 @@ func (c *Client) RebaseAbort() error {
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("rebase abort", "git rebase --abort", err)
 	}
 	return nil
@@ -98,7 +149,7 @@ func (c *Client) RebaseSkip() error {
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("rebase skip", "git rebase --skip", err)
 	}
 	return nil
@@ -107,7 +158,7 @@ func (c *Client) RebaseSkip() error {
 // GetUpstreamBranch gets the upstream branch for the given branch.
 func (c *Client) GetUpstreamBranch(branch string) (string, error) {
 	cmd := c.execCommand("git", "rev-parse", "--abbrev-ref", fmt.Sprintf("%s@{upstream}", branch))
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		// If no upstream is set, return "main" as default
 		return "main", nil
@@ -1,7 +1,9 @@
 package git
 
 import (
+	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
 	"testing"
 )
@@ -387,3 +389,37 @@ func TestClient_RebaseSkip_Error(t *testing.T) {
 		t.Error("Expected RebaseSkip to return an error")
 	}
 }
+
+func TestClient_RebaseInteractiveWithTodo(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "git-rebase-todo")
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			// Stand in for git: invoke the sequence editor on the todo path
+			// the way git does, through the shell.
+			return exec.Command("sh", "-c", `eval "$GIT_SEQUENCE_EDITOR \"\$1\""`, "sh", target)
+		},
+	}
+
+	todo := "pick abc first\nfixup def second\n"
+	if err := client.RebaseInteractiveWithTodo(2, todo); err != nil {
+		t.Fatalf("RebaseInteractiveWithTodo() error = %v", err)
+	}
+	if want := []string{"git", "rebase", "-i", "HEAD~2"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("gotArgs = %v, want %v", gotArgs, want)
+	}
+	got, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("todo was not installed: %v", err)
+	}
+	if string(got) != todo {
+		t.Errorf("todo = %q, want %q", got, todo)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if got := shellQuote("/tmp/it's here"); got != `'/tmp/it'\''s here'` {
+		t.Errorf("shellQuote() = %s", got)
+	}
+}
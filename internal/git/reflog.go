@@ -0,0 +1,51 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RecentBranchReader lists the branches HEAD recently moved between.
+type RecentBranchReader interface {
+	RecentBranches(scan int) ([]string, error)
+}
+
+// RecentBranches reads the newest scan entries of HEAD's reflog and
+// returns the branches checked out in them, most recently used first.
+// Detached commits appear too; callers keep the names they recognize.
+func (c *Client) RecentBranches(scan int) ([]string, error) {
+	args := []string{"reflog", "show", "--format=%gs", "-n", strconv.Itoa(scan), "HEAD", "--"}
+	cmd := c.execCommand("git", args...)
+	out, err := c.output(cmd)
+	if err != nil {
+		return nil, NewOpError("read recent branches", "git "+strings.Join(args, " "), err)
+	}
+	return ParseRecentBranches(string(out)), nil
+}
+
+// ParseRecentBranches extracts the branches from reflog subjects such as
+// "checkout: moving from main to feature", newest first. The branch moved
+// to is more recent than the one moved from; each name is kept once.
+func ParseRecentBranches(out string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, line := range strings.Split(out, "\n") {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), "checkout: moving from ")
+		if !ok {
+			continue
+		}
+		from, to, ok := strings.Cut(rest, " to ")
+		if !ok {
+			continue
+		}
+		add(to)
+		add(from)
+	}
+	return names
+}
@@ -0,0 +1,54 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func TestParseRecentBranches(t *testing.T) {
+	out := "checkout: moving from main to feature/login\n" +
+		"commit: Add login form\n" +
+		"checkout: moving from develop to main\n" +
+		"checkout: moving from feature/login to develop\n" +
+		"checkout: moving from main to feature/login\n"
+	want := []string{"feature/login", "main", "develop"}
+	if got := ParseRecentBranches(out); !slices.Equal(got, want) {
+		t.Errorf("ParseRecentBranches() = %v, want %v", got, want)
+	}
+	if got := ParseRecentBranches(""); len(got) != 0 {
+		t.Errorf("ParseRecentBranches(\"\") = %v, want none", got)
+	}
+}
+
+func TestClient_RecentBranches(t *testing.T) {
+	var gotArgs []string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return helperCommand(t, "checkout: moving from main to develop\n", nil)
+		},
+	}
+	got, err := c.RecentBranches(100)
+	if err != nil {
+		t.Fatalf("RecentBranches() error = %v", err)
+	}
+	if want := []string{"develop", "main"}; !slices.Equal(got, want) {
+		t.Errorf("RecentBranches() = %v, want %v", got, want)
+	}
+	if want := []string{"git", "reflog", "show", "--format=%gs", "-n", "100", "HEAD", "--"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+}
+
+func TestClient_RecentBranches_Error(t *testing.T) {
+	c := &Client{
+		execCommand: func(_ string, _ ...string) *exec.Cmd {
+			return helperCommand(t, "", errors.New("no reflog"))
+		},
+	}
+	if _, err := c.RecentBranches(100); err == nil {
+		t.Error("RecentBranches() should fail when git reflog fails")
+	}
+}
@@ -2,6 +2,7 @@ package git
 
 import (
 	"os"
+	"strings"
 )
 
 // RemoteManager provides remote repository management operations.
@@ -12,12 +13,38 @@ type RemoteManager interface {
 	RemoteSetURL(name, url string) error
 }
 
+// RemoteURLReader reads the URL configured for a remote.
+type RemoteURLReader interface {
+	RemoteGetURL(name string) (string, error)
+}
+
+// TransportURLReader reads the URL git connects to for a remote, with
+// url.<base>.insteadOf and pushInsteadOf applied.
+type TransportURLReader interface {
+	TransportURL(remote string, push bool) (string, error)
+}
+
+// RemoteRenamer renames a remote.
+type RemoteRenamer interface {
+	RemoteRename(oldName, newName string) error
+}
+
+// RemoteProber checks that a remote URL can be reached.
+type RemoteProber interface {
+	RemoteReachable(url string) error
+}
+
+// RemoteNameLister lists remote names, for completion.
+type RemoteNameLister interface {
+	RemoteNames() ([]string, error)
+}
+
 // RemoteList lists all remotes.
 func (c *Client) RemoteList() error {
 	cmd := c.execCommand("git", "remote", "-v")
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("remote list", "git remote -v", err)
 	}
 	return nil
@@ -28,7 +55,7 @@ func (c *Client) RemoteAdd(name, url string) error {
 	cmd := c.execCommand("git", "remote", "add", name, url)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("remote add", "git remote add "+name+" "+url, err)
 	}
 	return nil
@@ -39,7 +66,7 @@ func (c *Client) RemoteRemove(name string) error {
 	cmd := c.execCommand("git", "remote", "remove", name)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("remote remove", "git remote remove "+name, err)
 	}
 	return nil
@@ -50,8 +77,70 @@ func (c *Client) RemoteSetURL(name, url string) error {
 	cmd := c.execCommand("git", "remote", "set-url", name, url)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("remote set-url", "git remote set-url "+name+" "+url, err)
 	}
 	return nil
 }
+
+// RemoteRename renames a remote, along with its remote-tracking branches
+// and the upstream settings that point at them.
+func (c *Client) RemoteRename(oldName, newName string) error {
+	cmd := c.execCommand("git", "remote", "rename", oldName, newName)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("remote rename", "git remote rename "+oldName+" "+newName, err)
+	}
+	return nil
+}
+
+// RemoteReachable asks the repository at url for its HEAD with git
+// ls-remote. Credential prompts are turned off, so a URL that needs
+// credentials git does not already have fails rather than waiting for
+// input.
+func (c *Client) RemoteReachable(url string) error {
+	cmd := c.execCommand("git", "ls-remote", url, "HEAD")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if _, err := c.output(cmd); err != nil {
+		return NewOpError("reach remote", "git ls-remote "+url+" HEAD", err)
+	}
+	return nil
+}
+
+// RemoteGetURL returns the fetch URL of a remote.
+func (c *Client) RemoteGetURL(name string) (string, error) {
+	cmd := c.execCommand("git", "remote", "get-url", name)
+	out, err := c.output(cmd)
+	if err != nil {
+		return "", NewOpError("remote get-url", "git remote get-url "+name, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// TransportURL returns the URL git fetches from, or with push pushes to,
+// for remote. An empty remote is the one git fetch uses by default: the
+// current branch's, else origin.
+func (c *Client) TransportURL(remote string, push bool) (string, error) {
+	args := []string{"ls-remote", "--get-url"}
+	switch {
+	case push:
+		args = []string{"remote", "get-url", "--push", remote}
+	case remote != "":
+		args = append(args, remote)
+	}
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return "", NewOpError("read remote URL", "git "+strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// RemoteNames returns the names of the configured remotes.
+func (c *Client) RemoteNames() ([]string, error) {
+	out, err := c.output(c.execCommand("git", "remote"))
+	if err != nil {
+		return nil, NewOpError("list remote names", "git remote", err)
+	}
+	return strings.Fields(string(out)), nil
+}
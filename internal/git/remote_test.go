@@ -1,6 +1,7 @@
 package git
 
 import (
+	"errors"
 	"os/exec"
 	"slices"
 	"testing"
@@ -85,3 +86,116 @@ func TestClient_RemoteSetURL(t *testing.T) {
 		t.Errorf("RemoteSetURL() gotArgs = %v, want %v", gotArgs, wantArgs)
 	}
 }
+
+func TestClient_RemoteGetURL(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("echo", "git@github.com:user/repo.git")
+		},
+	}
+
+	url, err := client.RemoteGetURL("origin")
+	if err != nil {
+		t.Fatalf("RemoteGetURL() error = %v", err)
+	}
+	if url != "git@github.com:user/repo.git" {
+		t.Errorf("RemoteGetURL() = %q", url)
+	}
+
+	wantArgs := []string{"git", "remote", "get-url", "origin"}
+	if !slices.Equal(gotArgs, wantArgs) {
+		t.Errorf("RemoteGetURL() gotArgs = %v, want %v", gotArgs, wantArgs)
+	}
+}
+
+func TestClient_TransportURL(t *testing.T) {
+	tests := []struct {
+		remote string
+		push   bool
+		want   []string
+	}{
+		{"", false, []string{"git", "ls-remote", "--get-url"}},
+		{"upstream", false, []string{"git", "ls-remote", "--get-url", "upstream"}},
+		{"origin", true, []string{"git", "remote", "get-url", "--push", "origin"}},
+	}
+	for _, tt := range tests {
+		var gotArgs []string
+		client := &Client{
+			execCommand: func(name string, args ...string) *exec.Cmd {
+				gotArgs = append([]string{name}, args...)
+				return exec.Command("echo", "git@github.com:user/repo.git")
+			},
+		}
+		url, err := client.TransportURL(tt.remote, tt.push)
+		if err != nil || url != "git@github.com:user/repo.git" {
+			t.Errorf("TransportURL(%q, %v) = %q, %v", tt.remote, tt.push, url, err)
+		}
+		if !slices.Equal(gotArgs, tt.want) {
+			t.Errorf("TransportURL(%q, %v) ran %v, want %v", tt.remote, tt.push, gotArgs, tt.want)
+		}
+	}
+}
+
+func TestClient_RemoteNames(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return helperCommand(t, "origin\nupstream\n", nil)
+		},
+	}
+	got, err := client.RemoteNames()
+	if err != nil {
+		t.Fatalf("RemoteNames() error = %v", err)
+	}
+	if want := []string{"origin", "upstream"}; !slices.Equal(got, want) {
+		t.Errorf("RemoteNames() = %v, want %v", got, want)
+	}
+	if want := []string{"git", "remote"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("gotArgs = %v, want %v", gotArgs, want)
+	}
+}
+
+func TestClient_RemoteRename(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("echo")
+		},
+	}
+
+	if err := client.RemoteRename("origin", "upstream"); err != nil {
+		t.Errorf("RemoteRename() error = %v", err)
+	}
+	wantArgs := []string{"git", "remote", "rename", "origin", "upstream"}
+	if !slices.Equal(gotArgs, wantArgs) {
+		t.Errorf("RemoteRename() gotArgs = %v, want %v", gotArgs, wantArgs)
+	}
+}
+
+func TestClient_RemoteReachable(t *testing.T) {
+	var gotArgs []string
+	var cmd *exec.Cmd
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			cmd = exec.Command("sh", "-c", "echo 'fatal: Could not read from remote repository.' >&2; echo 'git@github.com: Permission denied (publickey).' >&2; exit 128")
+			return cmd
+		},
+	}
+
+	err := client.RemoteReachable("git@github.com:user/repo.git")
+	if !errors.Is(err, ErrAuthFailed) {
+		t.Errorf("RemoteReachable() error = %v, want ErrAuthFailed", err)
+	}
+	wantArgs := []string{"git", "ls-remote", "git@github.com:user/repo.git", "HEAD"}
+	if !slices.Equal(gotArgs, wantArgs) {
+		t.Errorf("RemoteReachable() gotArgs = %v, want %v", gotArgs, wantArgs)
+	}
+	if !slices.Contains(cmd.Env, "GIT_TERMINAL_PROMPT=0") {
+		t.Error("RemoteReachable() should turn off credential prompts")
+	}
+}
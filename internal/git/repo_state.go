@@ -0,0 +1,69 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RepoState is what the workspace dashboard shows about a repository.
+type RepoState struct {
+	Status *StatusSummary
+	// Operation is the operation the repository is in the middle of, such
+	// as "rebase" or "merge"; empty when there is none.
+	Operation string
+}
+
+// RepoStateReader reads the state of the repository in another directory.
+type RepoStateReader interface {
+	RepoState(dir string) (RepoState, error)
+}
+
+// RepoState reads the branch, changes, ahead and behind counts and the
+// operation in progress of the repository in dir. It does not touch the
+// status cache, which belongs to the working directory's repository.
+func (c *Client) RepoState(dir string) (RepoState, error) {
+	out, err := c.output(c.execCommand("git", "-C", dir, "status", "--porcelain=v2", "--branch", "-z"))
+	if err != nil {
+		return RepoState{}, NewOpError("get status summary", "git -C "+dir+" status --porcelain=v2 --branch -z", err)
+	}
+	status, err := ParseStatusPorcelainV2(string(out))
+	if err != nil {
+		return RepoState{}, err
+	}
+	out, err = c.output(c.execCommand("git", "-C", dir, "rev-parse", "--absolute-git-dir"))
+	if err != nil {
+		return RepoState{}, NewOpError("get git dir", "git -C "+dir+" rev-parse --absolute-git-dir", err)
+	}
+	return RepoState{Status: status, Operation: OperationInProgress(strings.TrimSpace(string(out)))}, nil
+}
+
+// OperationInProgress returns the operation the repository with git
+// directory gitDir is in the middle of: rebase, am, merge, cherry-pick,
+// revert or bisect, or empty when there is none.
+func OperationInProgress(gitDir string) string {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(gitDir, name))
+		return err == nil
+	}
+	switch {
+	case exists("rebase-merge"):
+		return "rebase"
+	case exists("rebase-apply"):
+		// git am keeps its state in the same directory as the old rebase
+		// backend and marks it with "applying".
+		if exists(filepath.Join("rebase-apply", "applying")) {
+			return "am"
+		}
+		return "rebase"
+	case exists("MERGE_HEAD"):
+		return "merge"
+	case exists("CHERRY_PICK_HEAD"):
+		return "cherry-pick"
+	case exists("REVERT_HEAD"):
+		return "revert"
+	case exists("BISECT_LOG"):
+		return "bisect"
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOperationInProgress(t *testing.T) {
+	tests := []struct {
+		files []string
+		want  string
+	}{
+		{nil, ""},
+		{[]string{"rebase-merge/"}, "rebase"},
+		{[]string{"rebase-apply/"}, "rebase"},
+		{[]string{"rebase-apply/applying"}, "am"},
+		{[]string{"MERGE_HEAD"}, "merge"},
+		{[]string{"CHERRY_PICK_HEAD"}, "cherry-pick"},
+		{[]string{"REVERT_HEAD"}, "revert"},
+		{[]string{"BISECT_LOG"}, "bisect"},
+		// A conflict while rebasing leaves the rebase the operation to
+		// finish.
+		{[]string{"rebase-merge/", "CHERRY_PICK_HEAD"}, "rebase"},
+	}
+	for _, tt := range tests {
+		gitDir := t.TempDir()
+		for _, f := range tt.files {
+			path := filepath.Join(gitDir, f)
+			if strings.HasSuffix(f, "/") {
+				if err := os.MkdirAll(path, 0o755); err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, nil, 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := OperationInProgress(gitDir); got != tt.want {
+			t.Errorf("OperationInProgress with %v = %q, want %q", tt.files, got, tt.want)
+		}
+	}
+}
+
+func TestClient_RepoState(t *testing.T) {
+	gitDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(gitDir, "MERGE_HEAD"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	status := "# branch.oid abc123\x00# branch.head main\x00# branch.upstream origin/main\x00# branch.ab +2 -1\x00" +
+		"1 .M N... 100644 100644 100644 aaa bbb a.go\x00? new.txt\x00"
+	var calls [][]string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			calls = append(calls, append([]string{name}, args...))
+			if args[2] == "rev-parse" {
+				return fakeExecCommand(gitDir + "\n")
+			}
+			// NUL cannot be passed in an argument; printf writes \000 as one.
+			return exec.Command("printf", strings.ReplaceAll(status, "\x00", `\000`))
+		},
+	}
+	state, err := c.RepoState("/src/api")
+	if err != nil {
+		t.Fatalf("RepoState: %v", err)
+	}
+	s := state.Status
+	if s.Branch != "main" || s.Ahead != 2 || s.Behind != 1 || s.Modified() != 1 || s.Untracked() != 1 || state.Operation != "merge" {
+		t.Errorf("state = %+v, operation %q", s, state.Operation)
+	}
+	want := [][]string{
+		{"git", "-C", "/src/api", "status", "--porcelain=v2", "--branch", "-z"},
+		{"git", "-C", "/src/api", "rev-parse", "--absolute-git-dir"},
+	}
+	if !slices.EqualFunc(calls, want, slices.Equal[[]string]) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
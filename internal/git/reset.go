@@ -20,7 +20,7 @@ func (c *Client) ResetHardAndClean() error {
 	cmd := c.execCommand("git", "reset", "--hard", "origin/"+branch)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("reset hard and clean", "git reset --hard origin/"+branch, err)
 	}
 	if err := c.CleanDirs(); err != nil {
@@ -34,7 +34,7 @@ func (c *Client) ResetHard(commit string) error {
 	cmd := c.execCommand("git", "reset", "--hard", commit)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("reset hard", "git reset --hard "+commit, err)
 	}
 	return nil
@@ -45,7 +45,7 @@ func (c *Client) ResetSoft(commit string) error {
 	cmd := c.execCommand("git", "reset", "--soft", commit)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("reset soft", "git reset --soft "+commit, err)
 	}
 	return nil
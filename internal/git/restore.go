@@ -38,7 +38,7 @@ func (c *Client) Restore(paths []string, opts *RestoreOptions) error {
 	cmd := c.execCommand("git", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("restore", fmt.Sprintf("git %s", strings.Join(args, " ")), err)
 	}
 	return nil
@@ -6,19 +6,33 @@ import (
 
 // GetCurrentBranch gets the current branch name.
 func (c *Client) GetCurrentBranch() (string, error) {
-	cmd := c.execCommand("git", "rev-parse", "--abbrev-ref", "HEAD")
-	out, err := cmd.Output()
-	if err != nil {
-		return "", NewOpError("get current branch", "git rev-parse --abbrev-ref HEAD", err)
+	if branch, ok, err := readRefs(c, (*refDir).currentBranch); ok {
+		if err != nil {
+			return "", NewOpError("get current branch", "read HEAD", err)
+		}
+		return branch, nil
 	}
-	branch := strings.TrimSpace(string(out))
-	return branch, nil
+	return c.cachedRead("branch", func() (string, error) {
+		cmd := c.execCommand("git", "rev-parse", "--abbrev-ref", "HEAD")
+		out, err := c.output(cmd)
+		if err != nil {
+			return "", NewOpError("get current branch", "git rev-parse --abbrev-ref HEAD", err)
+		}
+		branch := strings.TrimSpace(string(out))
+		return branch, nil
+	})
 }
 
 // GetBranchName gets branch name.
 func (c *Client) GetBranchName() (string, error) {
+	if branch, ok, err := readRefs(c, (*refDir).currentBranch); ok {
+		if err != nil {
+			return "", NewOpError("get branch name", "read HEAD", err)
+		}
+		return branch, nil
+	}
 	cmd := c.execCommand("git", "rev-parse", "--abbrev-ref", "HEAD")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return "", NewOpError("get branch name", "git rev-parse --abbrev-ref HEAD", err)
 	}
@@ -29,7 +43,7 @@ func (c *Client) GetBranchName() (string, error) {
 // It runs: git rev-parse --verify --quiet <ref>
 func (c *Client) RevParseVerify(ref string) bool {
 	cmd := c.execCommand("git", "rev-parse", "--verify", "--quiet", ref)
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return false
 	}
 	return true
@@ -38,7 +52,7 @@ func (c *Client) RevParseVerify(ref string) bool {
 // GetCommitHash gets the short commit hash
 func (c *Client) GetCommitHash() (string, error) {
 	cmd := c.execCommand("git", "rev-parse", "--short", "HEAD")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return "unknown", nil // Return "unknown" as fallback instead of error
 	}
@@ -47,10 +61,49 @@ func (c *Client) GetCommitHash() (string, error) {
 
 // GetUpstreamBranchName gets the upstream branch name for a given branch.
 func (c *Client) GetUpstreamBranchName(branch string) (string, error) {
-	cmd := c.execCommand("git", "rev-parse", "--abbrev-ref", branch+"@{upstream}")
-	out, err := cmd.Output()
+	upstream, ok, err := readRefs(c, func(r *refDir) (string, error) { return r.upstream(branch) })
+	if ok {
+		if err != nil {
+			return "", NewOpError("get upstream branch", "read config", err)
+		}
+		return upstream, nil
+	}
+	return c.cachedRead("upstream "+branch, func() (string, error) {
+		cmd := c.execCommand("git", "rev-parse", "--abbrev-ref", branch+"@{upstream}")
+		out, err := c.output(cmd)
+		if err != nil {
+			return "", NewOpError("get upstream branch", "git rev-parse --abbrev-ref "+branch+"@{upstream}", err)
+		}
+		return strings.TrimSpace(string(out)), nil
+	})
+}
+
+// RevParse resolves ref to its full object name.
+func (c *Client) RevParse(ref string) (string, error) {
+	cmd := c.execCommand("git", "rev-parse", "--verify", ref)
+	out, err := c.output(cmd)
+	if err != nil {
+		return "", NewOpError("rev-parse", "git rev-parse --verify "+ref, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GitDir returns the absolute path of the repository's .git directory.
+func (c *Client) GitDir() (string, error) {
+	cmd := c.execCommand("git", "rev-parse", "--absolute-git-dir")
+	out, err := c.output(cmd)
+	if err != nil {
+		return "", NewOpError("get git dir", "git rev-parse --absolute-git-dir", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// TopLevel returns the absolute path of the working tree's root.
+func (c *Client) TopLevel() (string, error) {
+	cmd := c.execCommand("git", "rev-parse", "--show-toplevel")
+	out, err := c.output(cmd)
 	if err != nil {
-		return "", NewOpError("get upstream branch", "git rev-parse --abbrev-ref "+branch+"@{upstream}", err)
+		return "", NewOpError("get top level", "git rev-parse --show-toplevel", err)
 	}
 	return strings.TrimSpace(string(out)), nil
 }
@@ -242,3 +242,16 @@ func TestClient_GetUpstreamBranchName_Error(t *testing.T) {
 		t.Error("Expected GetUpstreamBranchName to return an error")
 	}
 }
+
+func TestClient_TopLevel(t *testing.T) {
+	c := &Client{execCommand: func(_ string, args ...string) *exec.Cmd {
+		if !slices.Equal(args, []string{"rev-parse", "--show-toplevel"}) {
+			t.Fatalf("unexpected args %v", args)
+		}
+		return fakeExecCommand("/home/me/repo\n")
+	}}
+	root, err := c.TopLevel()
+	if err != nil || root != "/home/me/repo" {
+		t.Fatalf("TopLevel = %q, %v", root, err)
+	}
+}
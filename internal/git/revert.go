@@ -0,0 +1,24 @@
+package git
+
+import (
+	"os"
+	"strings"
+)
+
+// RevertOps records commits that undo existing ones.
+type RevertOps interface {
+	Revert(commits ...string) error
+}
+
+// Revert records a commit undoing each of commits, keeping git's default
+// message.
+func (c *Client) Revert(commits ...string) error {
+	args := append([]string{"revert", "--no-edit"}, commits...)
+	cmd := c.execCommand("git", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("revert", "git "+strings.Join(args, " "), err)
+	}
+	return nil
+}
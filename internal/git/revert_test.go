@@ -0,0 +1,35 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func TestClient_Revert(t *testing.T) {
+	var gotArgs []string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return helperCommand(t, "", nil)
+		},
+	}
+	if err := c.Revert("abc123", "def456"); err != nil {
+		t.Fatalf("Revert() error = %v", err)
+	}
+	if want := []string{"git", "revert", "--no-edit", "abc123", "def456"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+}
+
+func TestClient_Revert_Error(t *testing.T) {
+	c := &Client{
+		execCommand: func(_ string, _ ...string) *exec.Cmd {
+			return helperCommand(t, "", errors.New("conflict"))
+		},
+	}
+	if err := c.Revert("abc123"); err == nil {
+		t.Error("Revert() should fail when git revert fails")
+	}
+}
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CommitRewriter changes the message of a commit on the current branch.
+type CommitRewriter interface {
+	RewordCommit(commit, message string) error
+}
+
+// RewordCommit replaces the message of commit, which must be on the
+// current branch, and replays the commits after it. With an empty message
+// git opens the editor on the current one. It runs an interactive rebase
+// with a prepared todo list and --autostash, so uncommitted changes are
+// kept; when the rebase stops, for instance because the editor was left
+// with an empty message, it is aborted and the branch is left as it was.
+func (c *Client) RewordCommit(commit, message string) error {
+	target, err := c.RevParse(commit + "^{commit}")
+	if err != nil {
+		return err
+	}
+	if err := c.run(c.execCommand("git", "merge-base", "--is-ancestor", target, "HEAD")); err != nil {
+		return NewOpError("reword", "git merge-base --is-ancestor "+commit+" HEAD", fmt.Errorf("%s is not on the current branch", commit))
+	}
+	// target^@ are the parents of target, so this lists target and every
+	// commit after it, oldest first, each with its parents.
+	out, err := c.output(c.execCommand("git", "rev-list", "--reverse", "--parents", "HEAD", "--not", target+"^@"))
+	if err != nil {
+		return NewOpError("reword", "git rev-list --parents HEAD --not "+commit+"^@", err)
+	}
+	var todo strings.Builder
+	upstream := "--root"
+	for i, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 2 {
+			return NewOpError("reword", "git rebase -i", errors.New("a merge commit follows it, and rewording only replays a straight line of commits"))
+		}
+		if i == 0 {
+			if len(fields) == 2 {
+				upstream = fields[1]
+			}
+			fmt.Fprintf(&todo, "reword %s\n", fields[0])
+			continue
+		}
+		fmt.Fprintf(&todo, "pick %s\n", fields[0])
+	}
+
+	dir, err := os.MkdirTemp("", "ggc-reword-*")
+	if err != nil {
+		return NewOpError("reword", "create todo file", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	todoPath := filepath.Join(dir, "todo")
+	if err := os.WriteFile(todoPath, []byte(todo.String()), 0o600); err != nil {
+		return NewOpError("reword", "write todo file", err)
+	}
+	// git runs both editors through the shell with the file to edit
+	// appended, so copying a prepared file over it answers for the user.
+	env := append(os.Environ(), "GIT_SEQUENCE_EDITOR=cp "+shellQuote(filepath.ToSlash(todoPath)))
+	if message != "" {
+		messagePath := filepath.Join(dir, "message")
+		if err := os.WriteFile(messagePath, []byte(message+"\n"), 0o600); err != nil {
+			return NewOpError("reword", "write message file", err)
+		}
+		env = append(env, "GIT_EDITOR=cp "+shellQuote(filepath.ToSlash(messagePath)))
+	}
+
+	cmd := c.execCommand("git", "rebase", "-i", "--autostash", upstream)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	defer c.InvalidateStatusCache()
+	if err := c.run(cmd); err != nil {
+		_ = c.run(c.execCommand("git", "rebase", "--abort"))
+		return NewOpError("reword", "git rebase -i --autostash "+upstream, err)
+	}
+	return nil
+}
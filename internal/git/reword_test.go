@@ -0,0 +1,116 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClient_RewordCommit(t *testing.T) {
+	dir := t.TempDir()
+	todo, message := filepath.Join(dir, "todo"), filepath.Join(dir, "message")
+	var calls [][]string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			calls = append(calls, append([]string{name}, args...))
+			switch args[0] {
+			case "rev-parse":
+				return fakeExecCommand("aaa111\n")
+			case "rev-list":
+				return fakeExecCommand("aaa111 root000\nbbb222 aaa111\nccc333 bbb222\n")
+			case "rebase":
+				// Run the editors the way git does, on copies kept for the test.
+				return exec.Command("sh", "-c", `eval "$GIT_SEQUENCE_EDITOR \"\$0\"" && eval "$GIT_EDITOR \"\$1\""`, todo, message)
+			}
+			return helperCommand(t, "", nil)
+		},
+	}
+	if err := c.RewordCommit("HEAD~2", "Fix the login redirect"); err != nil {
+		t.Fatalf("RewordCommit: %v", err)
+	}
+	want := [][]string{
+		{"git", "rev-parse", "--verify", "HEAD~2^{commit}"},
+		{"git", "merge-base", "--is-ancestor", "aaa111", "HEAD"},
+		{"git", "rev-list", "--reverse", "--parents", "HEAD", "--not", "aaa111^@"},
+		{"git", "rebase", "-i", "--autostash", "root000"},
+	}
+	if !slices.EqualFunc(calls, want, slices.Equal[[]string]) {
+		t.Fatalf("got %v\nwant %v", calls, want)
+	}
+	if got, _ := os.ReadFile(todo); string(got) != "reword aaa111\npick bbb222\npick ccc333\n" {
+		t.Errorf("todo = %q", got)
+	}
+	if got, _ := os.ReadFile(message); string(got) != "Fix the login redirect\n" {
+		t.Errorf("message = %q", got)
+	}
+}
+
+func TestClient_RewordCommit_Refuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		ancestor bool
+		revList  string
+		want     string
+	}{
+		{"not on the branch", false, "", "not on the current branch"},
+		{"merge after it", true, "aaa111 root000\nbbb222 aaa111 fff999\n", "merge commit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rebased := false
+			c := &Client{
+				execCommand: func(_ string, args ...string) *exec.Cmd {
+					switch args[0] {
+					case "rev-parse":
+						return fakeExecCommand("aaa111\n")
+					case "merge-base":
+						if !tt.ancestor {
+							return helperCommand(t, "", os.ErrInvalid)
+						}
+					case "rev-list":
+						return fakeExecCommand(tt.revList)
+					case "rebase":
+						rebased = true
+					}
+					return helperCommand(t, "", nil)
+				},
+			}
+			err := c.RewordCommit("aaa111", "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+			if rebased {
+				t.Error("rebase should not run")
+			}
+		})
+	}
+}
+
+func TestClient_RewordCommit_AbortsOnFailure(t *testing.T) {
+	var calls []string
+	c := &Client{
+		execCommand: func(_ string, args ...string) *exec.Cmd {
+			calls = append(calls, strings.Join(args, " "))
+			switch args[0] {
+			case "rev-parse":
+				return fakeExecCommand("aaa111\n")
+			case "rev-list":
+				return fakeExecCommand("aaa111\n")
+			case "rebase":
+				if args[1] == "-i" {
+					return helperCommand(t, "", os.ErrInvalid)
+				}
+			}
+			return helperCommand(t, "", nil)
+		},
+	}
+	if err := c.RewordCommit("aaa111", ""); err == nil {
+		t.Fatal("expected an error")
+	}
+	if !slices.Contains(calls, "rebase -i --autostash --root") || calls[len(calls)-1] != "rebase --abort" {
+		t.Errorf("calls = %q", calls)
+	}
+}
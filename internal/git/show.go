@@ -1,10 +1,17 @@
 package git
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
 
 // ShowOps provides access to the git show command.
 type ShowOps interface {
 	Show(args []string) error
+	ShowOutput(args []string) (string, error)
+	CommitDetail(rev string) (*CommitDetail, error)
 }
 
 // Show runs `git show` with the supplied arguments, streaming output to stdout.
@@ -14,7 +21,7 @@ func (c *Client) Show(args []string) error {
 	cmd := c.execCommand("git", gitArgs...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		full := "git show"
 		for _, a := range args {
 			full += " " + a
@@ -23,3 +30,131 @@ func (c *Client) Show(args []string) error {
 	}
 	return nil
 }
+
+// ShowOutput runs `git show` with the supplied arguments and returns its
+// output instead of streaming it, so it can be piped through a diff tool.
+func (c *Client) ShowOutput(args []string) (string, error) {
+	gitArgs := append([]string{"show"}, args...)
+	out, err := c.output(c.execCommand("git", gitArgs...))
+	if err != nil {
+		return "", NewOpError("show", strings.Join(append([]string{"git"}, gitArgs...), " "), err)
+	}
+	return string(out), nil
+}
+
+// CommitDetail is a commit's metadata, message and changed files, as
+// ggc show --format json reports them.
+type CommitDetail struct {
+	Hash           string
+	Parents        []string
+	AuthorName     string
+	AuthorEmail    string
+	AuthorDate     string // strict ISO 8601
+	CommitterName  string
+	CommitterEmail string
+	CommitterDate  string
+	Subject        string
+	Body           string
+	Files          []FileChange
+}
+
+// FileChange is one file a commit changed. Binary files have no line
+// counts.
+type FileChange struct {
+	Status     string // A, M, D, R, C or T, as git diff --name-status prints them
+	Path       string
+	OldPath    string // the source of a rename or copy
+	Insertions int
+	Deletions  int
+	Binary     bool
+}
+
+// commitDetailFormat separates the fields with 0x1f, which git never puts
+// in a name or an address; the message comes last since it may hold
+// anything else.
+const commitDetailFormat = "--format=%H%x1f%P%x1f%an%x1f%ae%x1f%aI%x1f%cn%x1f%ce%x1f%cI%x1f%B"
+
+// CommitDetail reads the commit rev points to. A tag is peeled to its
+// commit; renames are detected.
+func (c *Client) CommitDetail(rev string) (*CommitDetail, error) {
+	if rev == "" {
+		rev = "HEAD"
+	}
+	commit := rev + "^{commit}"
+	show := func(args ...string) (string, error) {
+		gitArgs := append(append([]string{"show"}, args...), commit)
+		out, err := c.output(c.execCommand("git", gitArgs...))
+		if err != nil {
+			return "", NewOpError("show", "git "+strings.Join(gitArgs, " "), err)
+		}
+		return string(out), nil
+	}
+
+	meta, err := show("--no-patch", commitDetailFormat)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.SplitN(meta, "\x1f", 9)
+	if len(fields) != 9 {
+		return nil, fmt.Errorf("unexpected git show output for %s", rev)
+	}
+	subject, body, _ := strings.Cut(strings.TrimRight(fields[8], "\n"), "\n")
+	d := &CommitDetail{
+		Hash:           fields[0],
+		Parents:        strings.Fields(fields[1]),
+		AuthorName:     fields[2],
+		AuthorEmail:    fields[3],
+		AuthorDate:     fields[4],
+		CommitterName:  fields[5],
+		CommitterEmail: fields[6],
+		CommitterDate:  fields[7],
+		Subject:        subject,
+		Body:           strings.TrimLeft(body, "\n"),
+	}
+
+	names, err := show("--format=", "-M", "--name-status", "-z")
+	if err != nil {
+		return nil, err
+	}
+	counts, err := show("--format=", "-M", "--numstat", "-z")
+	if err != nil {
+		return nil, err
+	}
+	d.Files = parseFileChanges(names, counts)
+	return d, nil
+}
+
+// parseFileChanges pairs the -z output of --name-status with that of
+// --numstat, which lists the same files in the same order.
+func parseFileChanges(names, counts string) []FileChange {
+	var files []FileChange
+	tokens := strings.Split(strings.TrimSuffix(names, "\x00"), "\x00")
+	for i := 0; i+1 < len(tokens); i += 2 {
+		f := FileChange{Status: tokens[i][:1], Path: tokens[i+1]}
+		if (f.Status == "R" || f.Status == "C") && i+2 < len(tokens) {
+			f.OldPath, f.Path = tokens[i+1], tokens[i+2]
+			i++
+		}
+		files = append(files, f)
+	}
+
+	tokens = strings.Split(strings.TrimSuffix(counts, "\x00"), "\x00")
+	for i, n := 0, 0; i < len(tokens) && n < len(files); i, n = i+1, n+1 {
+		parts := strings.SplitN(tokens[i], "\t", 3)
+		if len(parts) != 3 {
+			break
+		}
+		if parts[2] == "" {
+			// A rename or copy: the paths follow in tokens of their own.
+			i += 2
+		}
+		f := &files[n]
+		if parts[0] == "-" {
+			f.Binary = true
+			continue
+		}
+		f.Insertions, _ = strconv.Atoi(parts[0])
+		f.Deletions, _ = strconv.Atoi(parts[1])
+	}
+	return files
+}
@@ -61,3 +61,63 @@ func TestClient_Show_Error(t *testing.T) {
 		t.Error("expected error, got nil")
 	}
 }
+
+func TestClient_ShowOutput(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, a ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, a...)
+			return exec.Command("echo", "-n", "commit abc")
+		},
+	}
+
+	out, err := client.ShowOutput([]string{"HEAD~1"})
+	if err != nil {
+		t.Fatalf("ShowOutput() error = %v", err)
+	}
+	if want := []string{"git", "show", "HEAD~1"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("ShowOutput() gotArgs = %v, want %v", gotArgs, want)
+	}
+	if out != "commit abc" {
+		t.Errorf("ShowOutput() = %q", out)
+	}
+}
+
+func TestClient_CommitDetail(t *testing.T) {
+	var calls [][]string
+	client := &Client{
+		execCommand: func(name string, a ...string) *exec.Cmd {
+			calls = append(calls, append([]string{name}, a...))
+			if slices.Contains(a, "--no-patch") {
+				return fakeExecCommand("abc\x1fp1 p2\x1fAlice\x1falice@example.com\x1f2024-01-02T03:04:05+00:00\x1fBob\x1fbob@example.com\x1f2024-01-03T03:04:05+00:00\x1fAdd pager\n\nLong lines now scroll.\n")
+			}
+			return helperCommand(t, "", nil)
+		},
+	}
+
+	d, err := client.CommitDetail("v1.0.0")
+	if err != nil {
+		t.Fatalf("CommitDetail() error = %v", err)
+	}
+	if d.Hash != "abc" || !slices.Equal(d.Parents, []string{"p1", "p2"}) || d.AuthorEmail != "alice@example.com" ||
+		d.CommitterName != "Bob" || d.Subject != "Add pager" || d.Body != "Long lines now scroll." {
+		t.Errorf("CommitDetail() = %+v", d)
+	}
+	if len(calls) != 3 || calls[0][len(calls[0])-1] != "v1.0.0^{commit}" {
+		t.Errorf("calls = %v", calls)
+	}
+}
+
+func TestParseFileChanges(t *testing.T) {
+	names := "M\x00main.go\x00R087\x00old.go\x00new.go\x00A\x00logo.png\x00"
+	counts := "3\t1\tmain.go\x004\t2\t\x00old.go\x00new.go\x00-\t-\tlogo.png\x00"
+	got := parseFileChanges(names, counts)
+	want := []FileChange{
+		{Status: "M", Path: "main.go", Insertions: 3, Deletions: 1},
+		{Status: "R", Path: "new.go", OldPath: "old.go", Insertions: 4, Deletions: 2},
+		{Status: "A", Path: "logo.png", Binary: true},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseFileChanges() = %+v, want %+v", got, want)
+	}
+}
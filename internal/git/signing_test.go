@@ -0,0 +1,147 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestClient_WithCommitSigning(t *testing.T) {
+	var gotArgs []string
+	c := &Client{execCommand: func(name string, args ...string) *exec.Cmd {
+		gotArgs = append([]string{name}, args...)
+		return exec.Command("true")
+	}}
+
+	if err := c.WithCommitSigning(true).Commit("msg"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"git", "commit", "-S", "-m", "msg"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("signed commit args = %v, want %v", gotArgs, want)
+	}
+	if err := c.WithCommitSigning(false).CommitAmendNoEdit(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"git", "commit", "--no-gpg-sign", "--amend", "--no-edit"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("unsigned amend args = %v, want %v", gotArgs, want)
+	}
+	if err := c.Commit("msg"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"git", "commit", "-m", "msg"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("the original client should not sign, args = %v", gotArgs)
+	}
+}
+
+func TestClient_TagCreateSigned(t *testing.T) {
+	var gotArgs []string
+	c := &Client{execCommand: func(name string, args ...string) *exec.Cmd {
+		gotArgs = append([]string{name}, args...)
+		return exec.Command("true")
+	}}
+	if err := c.TagCreateSigned("v1.0.0", "abc123", "Release"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"git", "tag", "-s", "v1.0.0", "-m", "Release", "abc123"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("TagCreateSigned() args = %v, want %v", gotArgs, want)
+	}
+}
+
+func TestClient_CommitSignatures(t *testing.T) {
+	out := "aaaaaaaa\x1fG\x1fjane@example.com\x1fSHA256:abc\x1ffeat: signed\x1e\n" +
+		"bbbbbbbb\x1fN\x1f\x1f\x1ffix: unsigned\x1e\n"
+	c := &Client{execCommand: func(_ string, _ ...string) *exec.Cmd { return helperCommand(t, out, nil) }}
+
+	sigs, err := c.CommitSignatures("main..HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Signature{
+		{Object: "aaaaaaaa", Commit: "aaaaaaaa", Code: 'G', Signer: "jane@example.com", Key: "SHA256:abc", Subject: "feat: signed"},
+		{Object: "bbbbbbbb", Commit: "bbbbbbbb", Code: 'N', Subject: "fix: unsigned"},
+	}
+	if !slices.Equal(sigs, want) {
+		t.Errorf("CommitSignatures() = %+v, want %+v", sigs, want)
+	}
+	if sigs[0].Status() != "good" || !sigs[0].Verified() || sigs[1].Status() != "unsigned" || sigs[1].Verified() {
+		t.Errorf("unexpected status: %q %q", sigs[0].Status(), sigs[1].Status())
+	}
+}
+
+// TestSignatures_SSH signs a commit and a tag with a throwaway SSH key and
+// checks that both verify.
+func TestSignatures_SSH(t *testing.T) {
+	for _, bin := range []string{"git", "ssh-keygen"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("%s not installed", bin)
+		}
+	}
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	key := filepath.Join(root, "id_ed25519")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v\n%s", err, out)
+	}
+	pub, err := os.ReadFile(key + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signers := filepath.Join(root, "allowed_signers")
+	if err := os.WriteFile(signers, append([]byte(`jane@example.com namespaces="git" `), pub...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	work := filepath.Join(root, "work")
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if _, err := os.Stat(work); err == nil {
+			cmd.Dir = work
+		}
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-q", "-b", "main", work)
+	for _, kv := range [][2]string{
+		{"user.name", "Jane"}, {"user.email", "jane@example.com"},
+		{"gpg.format", "ssh"}, {"user.signingkey", key + ".pub"},
+		{"gpg.ssh.allowedSignersFile", signers},
+	} {
+		run("config", kv[0], kv[1])
+	}
+	run("commit", "-q", "--allow-empty", "-m", "unsigned")
+	t.Chdir(work)
+	if err := os.WriteFile("a.txt", []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "a.txt")
+
+	c := NewClient()
+	if err := c.WithCommitSigning(true).Commit("signed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.TagCreateSigned("v1", "", "release"); err != nil {
+		t.Fatal(err)
+	}
+	run("tag", "light", "HEAD~1")
+
+	commits, err := c.CommitSignatures("HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 || commits[0].Code != 'G' || commits[0].Signer != "jane@example.com" || commits[1].Code != 'N' {
+		t.Fatalf("CommitSignatures() = %+v", commits)
+	}
+	tags, err := c.TagSignatures([]string{commits[0].Commit, commits[1].Commit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 || tags[0].Object != "light" || tags[0].Code != 'N' ||
+		tags[1].Object != "v1" || tags[1].Code != 'G' || tags[1].Signer != "jane@example.com" || tags[1].Commit != commits[0].Commit {
+		t.Errorf("TagSignatures() = %+v", tags)
+	}
+}
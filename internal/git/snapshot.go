@@ -0,0 +1,157 @@
+package git
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SnapshotRefPrefix is the namespace ggc snapshot keeps its snapshots in.
+// Refs there are not branches, so they stay out of log, branch lists and
+// pushes, but they keep their commits from garbage collection.
+const SnapshotRefPrefix = "refs/ggc/snapshots/"
+
+// Snapshot is a saved state of the working tree.
+type Snapshot struct {
+	Ref     string
+	Commit  string // abbreviated
+	Time    time.Time
+	Message string
+}
+
+// Name returns the snapshot's ref without SnapshotRefPrefix.
+func (s Snapshot) Name() string {
+	return strings.TrimPrefix(s.Ref, SnapshotRefPrefix)
+}
+
+// SnapshotOps saves, lists, restores and drops working tree snapshots.
+type SnapshotOps interface {
+	SaveSnapshot(ref, message string) (string, error)
+	Snapshots() ([]Snapshot, error)
+	RestoreSnapshot(commit string) error
+	DeleteRef(ref string) error
+}
+
+// SaveSnapshot records the whole working tree, untracked files included
+// and ignored ones left out, in a commit on top of HEAD and pins it under
+// ref. It works on a copy of the index, so the real index, HEAD and the
+// working tree are left untouched.
+func (c *Client) SaveSnapshot(ref, message string) (string, error) {
+	gitDir, err := c.GitDir()
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(gitDir, "ggc-index-*")
+	if err != nil {
+		return "", NewOpError("save snapshot", "create temporary index", err)
+	}
+	indexPath := tmp.Name()
+	defer func() { _ = os.Remove(indexPath) }()
+	// Starting from the real index lets git add skip every file whose
+	// stat data has not changed. Without one, git refuses to read an
+	// empty index file, so only the name is kept.
+	index, err := os.ReadFile(filepath.Join(gitDir, "index"))
+	if err == nil {
+		_, err = tmp.Write(index)
+	}
+	_ = tmp.Close()
+	if err != nil && !os.IsNotExist(err) {
+		return "", NewOpError("save snapshot", "copy the index", err)
+	}
+	if len(index) == 0 {
+		_ = os.Remove(indexPath)
+	}
+	env := append(os.Environ(), "GIT_INDEX_FILE="+indexPath)
+
+	add := c.execCommand("git", "add", "--all")
+	add.Env = env
+	if err := c.run(add); err != nil {
+		return "", NewOpError("save snapshot", "git add --all", err)
+	}
+
+	writeTree := c.execCommand("git", "write-tree")
+	writeTree.Env = env
+	out, err := c.output(writeTree)
+	if err != nil {
+		return "", NewOpError("save snapshot", "git write-tree", err)
+	}
+	tree := strings.TrimSpace(string(out))
+
+	args := []string{"commit-tree", tree, "-m", message}
+	if head, err := c.RevParse("HEAD"); err == nil {
+		args = append(args, "-p", head)
+	}
+	out, err = c.output(c.execCommand("git", args...))
+	if err != nil {
+		return "", NewOpError("save snapshot", "git commit-tree "+tree, err)
+	}
+	commit := strings.TrimSpace(string(out))
+
+	if err := c.run(c.execCommand("git", "update-ref", ref, commit)); err != nil {
+		return "", NewOpError("save snapshot", "git update-ref "+ref+" "+commit, err)
+	}
+	return commit, nil
+}
+
+// Snapshots lists the snapshots under SnapshotRefPrefix, newest first.
+func (c *Client) Snapshots() ([]Snapshot, error) {
+	// NUL cannot appear in a ref name, a hash or a subject.
+	cmd := c.execCommand("git", "for-each-ref", "--sort=-refname",
+		"--format=%(refname)%00%(objectname:short)%00%(committerdate:unix)%00%(contents:subject)", SnapshotRefPrefix)
+	out, err := c.output(cmd)
+	if err != nil {
+		return nil, NewOpError("list snapshots", "git for-each-ref "+SnapshotRefPrefix, err)
+	}
+	var snapshots []Snapshot
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		unix, _ := strconv.ParseInt(fields[2], 10, 64)
+		snapshots = append(snapshots, Snapshot{
+			Ref:     fields[0],
+			Commit:  fields[1],
+			Time:    time.Unix(unix, 0),
+			Message: fields[3],
+		})
+	}
+	// Names are timestamps, so they sort by age, but the counter of a
+	// second snapshot in the same second is a number: -10 comes after -9.
+	slices.SortStableFunc(snapshots, func(a, b Snapshot) int {
+		stampA, nA := snapshotOrder(a.Name())
+		stampB, nB := snapshotOrder(b.Name())
+		return cmp.Or(strings.Compare(stampB, stampA), cmp.Compare(nB, nA))
+	})
+	return snapshots, nil
+}
+
+// snapshotOrder splits a snapshot name into its timestamp and counter,
+// which is 1 for the first snapshot of a second.
+func snapshotOrder(name string) (stamp string, n int) {
+	// The timestamp itself has a dash after the date.
+	if i := strings.LastIndex(name, "-"); i > len("20060102") {
+		if v, err := strconv.Atoi(name[i+1:]); err == nil {
+			return name[:i], v
+		}
+	}
+	return name, 1
+}
+
+// RestoreSnapshot writes the files of a snapshot back into the working
+// tree. It overlays them: files the snapshot does not have, such as those
+// added since, are left alone. The index and HEAD stay as they are.
+func (c *Client) RestoreSnapshot(commit string) error {
+	args := []string{"restore", "--source=" + commit, "--worktree", "--overlay", "--", ":/"}
+	cmd := c.execCommand("git", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("restore snapshot", "git "+strings.Join(args, " "), err)
+	}
+	return nil
+}
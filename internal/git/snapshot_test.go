@@ -0,0 +1,166 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_SaveSnapshot(t *testing.T) {
+	gitDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(gitDir, "index"), []byte("DIRC"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	seen := filepath.Join(t.TempDir(), "seen")
+	var calls [][]string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			calls = append(calls, append([]string{name}, args...))
+			switch args[0] {
+			case "rev-parse":
+				if args[1] == "--absolute-git-dir" {
+					return fakeExecCommand(gitDir)
+				}
+				return fakeExecCommand("head789\n")
+			case "add":
+				// Keep what the index git add works on holds.
+				return exec.Command("sh", "-c", `cat "$GIT_INDEX_FILE" > "$0"`, seen)
+			case "write-tree":
+				return fakeExecCommand("tree123\n")
+			case "commit-tree":
+				return fakeExecCommand("commit456\n")
+			}
+			return helperCommand(t, "", nil)
+		},
+	}
+
+	sha, err := c.SaveSnapshot(SnapshotRefPrefix+"20261016-120000", "before the refactor")
+	if err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	if sha != "commit456" {
+		t.Fatalf("sha = %q, want commit456", sha)
+	}
+	want := [][]string{
+		{"git", "rev-parse", "--absolute-git-dir"},
+		{"git", "add", "--all"},
+		{"git", "write-tree"},
+		{"git", "rev-parse", "--verify", "HEAD"},
+		{"git", "commit-tree", "tree123", "-m", "before the refactor", "-p", "head789"},
+		{"git", "update-ref", "refs/ggc/snapshots/20261016-120000", "commit456"},
+	}
+	if !slices.EqualFunc(calls, want, slices.Equal[[]string]) {
+		t.Fatalf("got %v\nwant %v", calls, want)
+	}
+	// git add must work on a copy of the real index, which is left alone,
+	// and the copy must not be left behind.
+	if data, _ := os.ReadFile(seen); string(data) != "DIRC" {
+		t.Errorf("git add saw an index holding %q, want a copy of the index", data)
+	}
+	if entries, _ := os.ReadDir(gitDir); len(entries) != 1 {
+		t.Errorf("the git dir holds %v, want only the index", entries)
+	}
+}
+
+func TestClient_Snapshots(t *testing.T) {
+	out := "refs/ggc/snapshots/20261016-120000\x00a1b2c3d\x001792152000\x00before the refactor\n" +
+		"refs/ggc/snapshots/20261015-090000\x00d4e5f60\x001792054800\x00\n"
+	var got []string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			got = append([]string{name}, args...)
+			// NUL cannot be passed in an argument; printf writes \000 as one.
+			return exec.Command("printf", strings.ReplaceAll(out, "\x00", `\000`))
+		},
+	}
+	snapshots, err := c.Snapshots()
+	if err != nil {
+		t.Fatalf("Snapshots: %v", err)
+	}
+	if !strings.Contains(strings.Join(got, " "), "--sort=-refname") || got[len(got)-1] != SnapshotRefPrefix {
+		t.Errorf("ran %v", got)
+	}
+	want := []Snapshot{
+		{Ref: "refs/ggc/snapshots/20261016-120000", Commit: "a1b2c3d", Time: time.Unix(1792152000, 0), Message: "before the refactor"},
+		{Ref: "refs/ggc/snapshots/20261015-090000", Commit: "d4e5f60", Time: time.Unix(1792054800, 0)},
+	}
+	if !slices.Equal(snapshots, want) {
+		t.Errorf("Snapshots() = %+v\nwant %+v", snapshots, want)
+	}
+	if snapshots[0].Name() != "20261016-120000" {
+		t.Errorf("Name() = %q", snapshots[0].Name())
+	}
+}
+
+func TestClient_SnapshotsOrderCounters(t *testing.T) {
+	var out strings.Builder
+	// for-each-ref sorts names as strings.
+	for _, name := range []string{"20261016-120000-9", "20261016-120000-2", "20261016-120000-10", "20261016-120000", "20261015-090000-3"} {
+		out.WriteString(SnapshotRefPrefix + name + `\000abc\0001792152000\000m\n`)
+	}
+	c := &Client{execCommand: func(string, ...string) *exec.Cmd { return exec.Command("printf", out.String()) }}
+	snapshots, err := c.Snapshots()
+	if err != nil {
+		t.Fatalf("Snapshots: %v", err)
+	}
+	var names []string
+	for _, s := range snapshots {
+		names = append(names, s.Name())
+	}
+	want := []string{"20261016-120000-10", "20261016-120000-9", "20261016-120000-2", "20261016-120000", "20261015-090000-3"}
+	if !slices.Equal(names, want) {
+		t.Errorf("Snapshots() names = %v, want %v", names, want)
+	}
+}
+
+func TestClient_RestoreSnapshotKeepsNewFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	work := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = work
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, data string) {
+		if err := os.WriteFile(filepath.Join(work, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("init", "-q", "-b", "main")
+	run("config", "user.name", "test")
+	run("config", "user.email", "test@example.com")
+	write("a.txt", "before")
+	run("add", "a.txt")
+	run("commit", "-qm", "initial")
+	t.Chdir(work)
+
+	c := NewClient()
+	ref := SnapshotRefPrefix + "20261016-120000"
+	if _, err := c.SaveSnapshot(ref, "snapshot"); err != nil {
+		t.Fatal(err)
+	}
+	write("a.txt", "after")
+	// Added and committed after the snapshot: a tracked file the snapshot
+	// does not have.
+	write("new.txt", "new")
+	run("add", "new.txt")
+	run("commit", "-qm", "add new.txt")
+
+	if err := c.RestoreSnapshot(ref); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(work, "a.txt")); string(data) != "before" {
+		t.Errorf("a.txt = %q, want the snapshot's content", data)
+	}
+	if data, err := os.ReadFile(filepath.Join(work, "new.txt")); err != nil || string(data) != "new" {
+		t.Errorf("new.txt = %q, %v; files added since the snapshot should be left alone", data, err)
+	}
+}
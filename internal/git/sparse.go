@@ -0,0 +1,95 @@
+package git
+
+import "strings"
+
+// SparseCheckoutOps reads and changes the sparse checkout of the working
+// tree.
+type SparseCheckoutOps interface {
+	SparseCheckout() (*SparseCheckout, error)
+	SparseSet(dirs []string) error
+	SparseAdd(dirs []string) error
+	SparseDisable() error
+	TreeDirectories() ([]string, error)
+}
+
+// SparseCheckout is the sparse checkout state of the working tree.
+type SparseCheckout struct {
+	// Enabled is false when the whole tree is checked out.
+	Enabled bool
+	// Cone is set when the rules are directories rather than patterns.
+	Cone bool
+	// Rules are the directories checked out in cone mode, or the
+	// patterns otherwise.
+	Rules []string
+}
+
+// SparseCheckout reports whether the working tree is sparse and its rules.
+func (c *Client) SparseCheckout() (*SparseCheckout, error) {
+	sc := &SparseCheckout{
+		Enabled: c.configBool("core.sparseCheckout"),
+		Cone:    c.configBool("core.sparseCheckoutCone"),
+	}
+	if !sc.Enabled {
+		return sc, nil
+	}
+	out, err := c.output(c.execCommand("git", "sparse-checkout", "list"))
+	if err != nil {
+		return nil, NewOpError("list sparse checkout", "git sparse-checkout list", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			sc.Rules = append(sc.Rules, line)
+		}
+	}
+	return sc, nil
+}
+
+// configBool reads a boolean setting, taking unset and unreadable as
+// false.
+func (c *Client) configBool(key string) bool {
+	out, err := c.output(c.execCommand("git", "config", "--get", "--bool", key))
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// SparseSet checks out only the files at the top of the tree and those
+// under dirs, in cone mode, turning sparse checkout on if it is off.
+func (c *Client) SparseSet(dirs []string) error {
+	args := append([]string{"sparse-checkout", "set", "--cone"}, dirs...)
+	if err := c.run(c.execCommand("git", args...)); err != nil {
+		return NewOpError("set sparse checkout", "git "+strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// SparseAdd checks out dirs as well.
+func (c *Client) SparseAdd(dirs []string) error {
+	args := append([]string{"sparse-checkout", "add"}, dirs...)
+	if err := c.run(c.execCommand("git", args...)); err != nil {
+		return NewOpError("add to sparse checkout", "git "+strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// SparseDisable checks out the whole tree again.
+func (c *Client) SparseDisable() error {
+	if err := c.run(c.execCommand("git", "sparse-checkout", "disable")); err != nil {
+		return NewOpError("disable sparse checkout", "git sparse-checkout disable", err)
+	}
+	return nil
+}
+
+// TreeDirectories lists every directory in the tree of HEAD, whether or
+// not it is checked out, sorted as git sorts paths.
+func (c *Client) TreeDirectories() ([]string, error) {
+	out, err := c.output(c.execCommand("git", "ls-tree", "-d", "-r", "-z", "--name-only", "HEAD"))
+	if err != nil {
+		return nil, NewOpError("list directories", "git ls-tree -d -r --name-only HEAD", err)
+	}
+	var dirs []string
+	for _, d := range strings.Split(string(out), "\x00") {
+		if d != "" {
+			dirs = append(dirs, d)
+		}
+	}
+	return dirs, nil
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClient_SparseCheckout(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		cone    bool
+		want    SparseCheckout
+	}{
+		{"off", false, false, SparseCheckout{}},
+		{"cone", true, true, SparseCheckout{Enabled: true, Cone: true, Rules: []string{"cmd", "docs/content"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var listed bool
+			c := &Client{execCommand: func(_ string, args ...string) *exec.Cmd {
+				switch strings.Join(args, " ") {
+				case "config --get --bool core.sparseCheckout":
+					if !tt.enabled {
+						return helperCommand(t, "", errors.New("exit status 1"))
+					}
+					return helperCommand(t, "true\n", nil)
+				case "config --get --bool core.sparseCheckoutCone":
+					return helperCommand(t, map[bool]string{true: "true\n", false: "false\n"}[tt.cone], nil)
+				case "sparse-checkout list":
+					listed = true
+					return helperCommand(t, "cmd\ndocs/content\n", nil)
+				}
+				t.Fatalf("unexpected git %v", args)
+				return nil
+			}}
+			got, err := c.SparseCheckout()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Enabled != tt.want.Enabled || got.Cone != tt.want.Cone || !slices.Equal(got.Rules, tt.want.Rules) {
+				t.Errorf("SparseCheckout() = %+v, want %+v", *got, tt.want)
+			}
+			if listed != tt.enabled {
+				t.Errorf("sparse-checkout list ran = %v", listed)
+			}
+		})
+	}
+}
+
+func TestClient_SparseChanges(t *testing.T) {
+	var got [][]string
+	c := &Client{execCommand: func(name string, args ...string) *exec.Cmd {
+		got = append(got, append([]string{name}, args...))
+		return exec.Command("true")
+	}}
+	if err := c.SparseSet([]string{"cmd", "docs"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SparseAdd([]string{"internal/git"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SparseDisable(); err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"git", "sparse-checkout", "set", "--cone", "cmd", "docs"},
+		{"git", "sparse-checkout", "add", "internal/git"},
+		{"git", "sparse-checkout", "disable"},
+	}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("ran %v, want %v", got, want)
+	}
+}
+
+func TestClient_TreeDirectories(t *testing.T) {
+	c := &Client{execCommand: func(_ string, args ...string) *exec.Cmd {
+		if want := []string{"ls-tree", "-d", "-r", "-z", "--name-only", "HEAD"}; !slices.Equal(args, want) {
+			t.Errorf("args = %v, want %v", args, want)
+		}
+		return exec.Command("printf", `cmd\000cmd/command\000docs\000`)
+	}}
+	dirs, err := c.TreeDirectories()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"cmd", "cmd/command", "docs"}; !slices.Equal(dirs, want) {
+		t.Errorf("dirs = %v, want %v", dirs, want)
+	}
+}
@@ -0,0 +1,104 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// Stacked branches are recorded in the branch's own config section, so
+// git branch -m carries the record along with the branch:
+//
+//	branch.<name>.ggc-parent  the branch it is stacked on
+//	branch.<name>.ggc-base    the parent commit it was last rebased onto
+const (
+	stackParentKey = "ggc-parent"
+	stackBaseKey   = "ggc-base"
+)
+
+// StackLink records that Branch is stacked on Parent. Base is the commit
+// of Parent that Branch was created from or last restacked onto; the
+// commits after it are the ones Branch adds.
+type StackLink struct {
+	Branch string
+	Parent string
+	Base   string
+}
+
+// StackOps reads and writes the records of stacked branches and moves a
+// branch onto a new parent commit.
+type StackOps interface {
+	StackLinks() ([]StackLink, error)
+	SetStackLink(link StackLink) error
+	RebaseOnto(newBase, upstream, branch string) error
+}
+
+// StackLinks returns every stacked branch recorded in the repository's
+// config, ordered as git lists them.
+func (c *Client) StackLinks() ([]StackLink, error) {
+	pattern := `^branch\..*\.(` + stackParentKey + `|` + stackBaseKey + `)$`
+	cmd := c.execCommand("git", "config", "--local", "--get-regexp", pattern)
+	out, err := c.output(cmd)
+	if err != nil {
+		// git config exits with 1 when no key matches.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, NewOpError("read stacked branches", "git config --local --get-regexp "+pattern, err)
+	}
+	return ParseStackLinks(string(out)), nil
+}
+
+// ParseStackLinks reads the output of git config --get-regexp over the
+// ggc-parent and ggc-base keys. Branches without a parent are dropped.
+func ParseStackLinks(out string) []StackLink {
+	var links []StackLink
+	index := make(map[string]int)
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		rest, ok := strings.CutPrefix(key, "branch.")
+		if !ok {
+			continue
+		}
+		dot := strings.LastIndex(rest, ".")
+		if dot < 0 {
+			continue
+		}
+		branch, variable := rest[:dot], rest[dot+1:]
+		i, seen := index[branch]
+		if !seen {
+			i = len(links)
+			index[branch] = i
+			links = append(links, StackLink{Branch: branch})
+		}
+		switch variable {
+		case stackParentKey:
+			links[i].Parent = value
+		case stackBaseKey:
+			links[i].Base = value
+		}
+	}
+	kept := links[:0]
+	for _, l := range links {
+		if l.Parent != "" {
+			kept = append(kept, l)
+		}
+	}
+	return kept
+}
+
+// SetStackLink records link in the repository's config.
+func (c *Client) SetStackLink(link StackLink) error {
+	for _, kv := range [][2]string{{stackParentKey, link.Parent}, {stackBaseKey, link.Base}} {
+		key := "branch." + link.Branch + "." + kv[0]
+		cmd := c.execCommand("git", "config", "--local", key, kv[1])
+		if err := c.run(cmd); err != nil {
+			return NewOpError("record stacked branch", "git config --local "+key+" "+kv[1], err)
+		}
+	}
+	return nil
+}
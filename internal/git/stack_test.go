@@ -0,0 +1,84 @@
+package git
+
+import (
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func TestParseStackLinks(t *testing.T) {
+	out := "branch.feature/a.ggc-parent main\n" +
+		"branch.feature/a.ggc-base 1111111\n" +
+		"branch.feature/a.v2.ggc-parent feature/a\n" +
+		"branch.orphan.ggc-base 2222222\n"
+	want := []StackLink{
+		{Branch: "feature/a", Parent: "main", Base: "1111111"},
+		{Branch: "feature/a.v2", Parent: "feature/a"},
+	}
+	if got := ParseStackLinks(out); !slices.Equal(got, want) {
+		t.Errorf("ParseStackLinks() = %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_StackLinks(t *testing.T) {
+	c := &Client{
+		execCommand: func(_ string, _ ...string) *exec.Cmd {
+			return helperCommand(t, "branch.b.ggc-parent a\n", nil)
+		},
+	}
+	got, err := c.StackLinks()
+	if err != nil {
+		t.Fatalf("StackLinks() error = %v", err)
+	}
+	if want := []StackLink{{Branch: "b", Parent: "a"}}; !slices.Equal(got, want) {
+		t.Errorf("StackLinks() = %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_StackLinks_NoneOrError(t *testing.T) {
+	// git config exits with 1 when nothing matches.
+	c := &Client{execCommand: func(_ string, _ ...string) *exec.Cmd { return exec.Command("sh", "-c", "exit 1") }}
+	if got, err := c.StackLinks(); err != nil || len(got) != 0 {
+		t.Errorf("StackLinks() = %v, %v; want none", got, err)
+	}
+	c = &Client{execCommand: func(_ string, _ ...string) *exec.Cmd { return exec.Command("sh", "-c", "exit 128") }}
+	if _, err := c.StackLinks(); err == nil {
+		t.Error("StackLinks() should fail outside a repository")
+	}
+}
+
+func TestClient_SetStackLink(t *testing.T) {
+	var calls [][]string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			calls = append(calls, append([]string{name}, args...))
+			return helperCommand(t, "", nil)
+		},
+	}
+	if err := c.SetStackLink(StackLink{Branch: "b", Parent: "a", Base: "abc"}); err != nil {
+		t.Fatalf("SetStackLink() error = %v", err)
+	}
+	want := [][]string{
+		{"git", "config", "--local", "branch.b.ggc-parent", "a"},
+		{"git", "config", "--local", "branch.b.ggc-base", "abc"},
+	}
+	if len(calls) != len(want) || !slices.Equal(calls[0], want[0]) || !slices.Equal(calls[1], want[1]) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestClient_RebaseOnto(t *testing.T) {
+	var gotArgs []string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return helperCommand(t, "", nil)
+		},
+	}
+	if err := c.RebaseOnto("a", "abc", "b"); err != nil {
+		t.Fatalf("RebaseOnto() error = %v", err)
+	}
+	if want := []string{"git", "rebase", "--onto", "a", "abc", "b"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+}
@@ -2,6 +2,7 @@ package git
 
 import (
 	"os"
+	"strings"
 )
 
 // StashOps provides operations used by the stash command.
@@ -9,11 +10,13 @@ type StashOps interface {
 	Stash() error
 	StashList() (string, error)
 	StashShow(stash string) error
+	StashDiff(stash string) (string, error)
 	StashApply(stash string) error
 	StashPop(stash string) error
 	StashPush(stash string) error
 	StashDrop(stash string) error
 	StashClear() error
+	StashBranch(branch, stash string) error
 }
 
 // Stash creates a new stash.
@@ -21,7 +24,7 @@ func (c *Client) Stash() error {
 	cmd := c.execCommand("git", "stash")
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("stash", "git stash", err)
 	}
 	return nil
@@ -30,7 +33,7 @@ func (c *Client) Stash() error {
 // StashList lists all stashes.
 func (c *Client) StashList() (string, error) {
 	cmd := c.execCommand("git", "stash", "list")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return "", NewOpError("stash list", "git stash list", err)
 	}
@@ -46,7 +49,7 @@ func (c *Client) StashShow(stash string) error {
 
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		cmdStr := "git stash show"
 		if stash != "" {
 			cmdStr = "git stash show " + stash
@@ -65,7 +68,7 @@ func (c *Client) StashApply(stash string) error {
 
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		cmdStr := "git stash apply"
 		if stash != "" {
 			cmdStr = "git stash apply " + stash
@@ -84,7 +87,7 @@ func (c *Client) StashPop(stash string) error {
 
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		cmdStr := "git stash pop"
 		if stash != "" {
 			cmdStr = "git stash pop " + stash
@@ -96,22 +99,55 @@ func (c *Client) StashPop(stash string) error {
 
 // StashPush creates a stash with an optional message.
 func (c *Client) StashPush(message string) error {
+	return c.StashPushWith(StashOptions{Message: message})
+}
+
+// StashOptions shapes a stash push beyond its message.
+type StashOptions struct {
+	Message   string
+	Untracked bool     // also stash untracked files
+	All       bool     // also stash untracked and ignored files
+	Staged    bool     // stash only the staged changes
+	Paths     []string // stash only these pathspecs; empty stashes everything
+}
+
+// args returns the git stash push arguments for opts.
+func (o StashOptions) args() []string {
 	args := []string{"stash", "push"}
-	if message != "" {
-		args = append(args, "-m", message)
+	if o.Message != "" {
+		args = append(args, "-m", o.Message)
+	}
+	switch {
+	case o.All:
+		args = append(args, "--all")
+	case o.Untracked:
+		args = append(args, "--include-untracked")
+	}
+	if o.Staged {
+		args = append(args, "--staged")
+	}
+	if len(o.Paths) > 0 {
+		args = append(args, "--")
+		args = append(args, o.Paths...)
 	}
+	return args
+}
+
+// StashPusher stashes with the options git stash push takes beyond a
+// message.
+type StashPusher interface {
+	StashPushWith(opts StashOptions) error
+}
 
+// StashPushWith creates a stash shaped by opts.
+func (c *Client) StashPushWith(opts StashOptions) error {
+	args := opts.args()
 	cmd := c.execCommand("git", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		cmdStr := "git stash push"
-		if message != "" {
-			cmdStr = "git stash push -m " + message
-		}
-		return NewOpError("stash push", cmdStr, err)
+	if err := c.run(cmd); err != nil {
+		return NewOpError("stash push", "git "+strings.Join(args, " "), err)
 	}
-
 	return nil
 }
 
@@ -124,7 +160,7 @@ func (c *Client) StashDrop(stash string) error {
 
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		cmdStr := "git stash drop"
 		if stash != "" {
 			cmdStr = "git stash drop " + stash
@@ -139,8 +175,38 @@ func (c *Client) StashClear() error {
 	cmd := c.execCommand("git", "stash", "clear")
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("stash clear", "git stash clear", err)
 	}
 	return nil
 }
+
+// StashDiff returns the patch recorded in a stash (the latest when stash
+// is empty).
+func (c *Client) StashDiff(stash string) (string, error) {
+	args := []string{"stash", "show", "-p"}
+	if stash != "" {
+		args = append(args, stash)
+	}
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return "", NewOpError("stash show", "git "+strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
+
+// StashBranch creates and checks out branch at the commit a stash was made
+// on, applies the stash and drops it when that succeeds.
+func (c *Client) StashBranch(branch, stash string) error {
+	args := []string{"stash", "branch", branch}
+	if stash != "" {
+		args = append(args, stash)
+	}
+	cmd := c.execCommand("git", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("stash branch", "git "+strings.Join(args, " "), err)
+	}
+	return nil
+}
@@ -212,6 +212,33 @@ func TestClient_StashPush(t *testing.T) {
 	}
 }
 
+func TestClient_StashPushWith(t *testing.T) {
+	tests := []struct {
+		opts     StashOptions
+		wantArgs []string
+	}{
+		{StashOptions{Untracked: true}, []string{"git", "stash", "push", "--include-untracked"}},
+		{StashOptions{All: true, Untracked: true}, []string{"git", "stash", "push", "--all"}},
+		{StashOptions{Message: "wip", Staged: true}, []string{"git", "stash", "push", "-m", "wip", "--staged"}},
+		{StashOptions{Paths: []string{"a.go", "docs"}}, []string{"git", "stash", "push", "--", "a.go", "docs"}},
+	}
+	for _, tt := range tests {
+		var gotArgs []string
+		client := &Client{
+			execCommand: func(name string, args ...string) *exec.Cmd {
+				gotArgs = append([]string{name}, args...)
+				return exec.Command("echo")
+			},
+		}
+		if err := client.StashPushWith(tt.opts); err != nil {
+			t.Errorf("StashPushWith(%+v) error = %v", tt.opts, err)
+		}
+		if !slices.Equal(gotArgs, tt.wantArgs) {
+			t.Errorf("StashPushWith(%+v) gotArgs = %v, want %v", tt.opts, gotArgs, tt.wantArgs)
+		}
+	}
+}
+
 func TestClient_StashDrop(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -448,3 +475,46 @@ func TestClient_StashPush_Error(t *testing.T) {
 		t.Error("Expected StashPush to return an error")
 	}
 }
+
+func TestClient_StashDiff(t *testing.T) {
+	for _, tt := range []struct {
+		stash    string
+		wantArgs []string
+	}{
+		{"", []string{"git", "stash", "show", "-p"}},
+		{"stash@{1}", []string{"git", "stash", "show", "-p", "stash@{1}"}},
+	} {
+		var gotArgs []string
+		client := &Client{
+			execCommand: func(name string, args ...string) *exec.Cmd {
+				gotArgs = append([]string{name}, args...)
+				return exec.Command("echo", "-n", "+line")
+			},
+		}
+		out, err := client.StashDiff(tt.stash)
+		if err != nil || out != "+line" {
+			t.Errorf("StashDiff(%q) = %q, %v", tt.stash, out, err)
+		}
+		if !slices.Equal(gotArgs, tt.wantArgs) {
+			t.Errorf("StashDiff(%q) gotArgs = %v, want %v", tt.stash, gotArgs, tt.wantArgs)
+		}
+	}
+}
+
+func TestClient_StashBranch(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("echo")
+		},
+	}
+
+	if err := client.StashBranch("feature", "stash@{2}"); err != nil {
+		t.Errorf("StashBranch() error = %v", err)
+	}
+	wantArgs := []string{"git", "stash", "branch", "feature", "stash@{2}"}
+	if !slices.Equal(gotArgs, wantArgs) {
+		t.Errorf("StashBranch() gotArgs = %v, want %v", gotArgs, wantArgs)
+	}
+}
@@ -1,9 +1,12 @@
 package git
 
+import "strings"
+
 // StatusReader provides read-only status output with color support.
+// Paths limit the status to those pathspecs.
 type StatusReader interface {
-	StatusWithColor() (string, error)
-	StatusShortWithColor() (string, error)
+	StatusWithColor(paths ...string) (string, error)
+	StatusShortWithColor(paths ...string) (string, error)
 }
 
 // BranchUpstreamReader provides information about the current branch and its upstream.
@@ -23,7 +26,7 @@ type StatusInfoReader interface {
 // Status gets git status output.
 func (c *Client) Status() (string, error) {
 	cmd := c.execCommand("git", "status")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return "", NewOpError("get status", "git status", err)
 	}
@@ -33,29 +36,35 @@ func (c *Client) Status() (string, error) {
 // StatusShort gets git status --short output.
 func (c *Client) StatusShort() (string, error) {
 	cmd := c.execCommand("git", "status", "--short")
-	out, err := cmd.Output()
+	out, err := c.output(cmd)
 	if err != nil {
 		return "", NewOpError("get status short", "git status --short", err)
 	}
 	return string(out), nil
 }
 
-// StatusWithColor gets git status output with color.
-func (c *Client) StatusWithColor() (string, error) {
-	cmd := c.execCommand("git", "-c", "color.status=always", "status")
-	out, err := cmd.Output()
-	if err != nil {
-		return "", NewOpError("get status with color", "git -c color.status=always status", err)
-	}
-	return string(out), nil
+// StatusWithColor gets git status output with color, limited to paths
+// when any are given.
+func (c *Client) StatusWithColor(paths ...string) (string, error) {
+	return c.colorStatus("get status with color", nil, paths)
 }
 
-// StatusShortWithColor gets git status --short output with color.
-func (c *Client) StatusShortWithColor() (string, error) {
-	cmd := c.execCommand("git", "-c", "color.status=always", "status", "--short")
-	out, err := cmd.Output()
+// StatusShortWithColor gets git status --short output with color, limited
+// to paths when any are given.
+func (c *Client) StatusShortWithColor(paths ...string) (string, error) {
+	return c.colorStatus("get status short with color", []string{"--short"}, paths)
+}
+
+// colorStatus runs git status with color forced and opts. It is not
+// cached, since working-tree edits do not show in .git.
+func (c *Client) colorStatus(op string, opts, paths []string) (string, error) {
+	args := append([]string{"-c", "color.status=always", "status"}, opts...)
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	out, err := c.output(c.execCommand("git", args...))
 	if err != nil {
-		return "", NewOpError("get status short with color", "git -c color.status=always status --short", err)
+		return "", NewOpError(op, "git "+strings.Join(args, " "), err)
 	}
 	return string(out), nil
 }
@@ -0,0 +1,322 @@
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatusCache memoizes the read-only calls ggc makes on almost every
+// invocation: the current branch, its upstream and ahead/behind counts.
+// Entries are keyed on a fingerprint of the repository read straight from
+// the .git directory (HEAD, the branch ref, the remote-tracking refs, the
+// index, packed-refs, FETCH_HEAD and config), so a commit, checkout, fetch
+// or push invalidates them without running git. Entries also expire after
+// TTL. Status output is never cached: a working-tree edit leaves .git
+// alone, so the fingerprint cannot tell it happened. When Dir is set the
+// entries are shared with ggc processes started shortly after.
+type StatusCache struct {
+	// Dir holds one JSON file per repository. Empty keeps the cache in
+	// memory only.
+	Dir string
+	// TTL bounds the age of an entry. Values <= 0 use DefaultStatusCacheTTL.
+	TTL time.Duration
+
+	mu    sync.Mutex
+	repos map[string]*repoCache // keyed by git dir
+	now   func() time.Time
+}
+
+// DefaultStatusCacheTTL is how long a cached read stays valid.
+const DefaultStatusCacheTTL = 2 * time.Second
+
+// envNoStatusCache turns the cache off when set to a truthy value.
+const envNoStatusCache = "GGC_NO_STATUS_CACHE"
+
+// StatusCacheInvalidator is implemented by clients that cache status
+// reads. Callers that know the repository changed behind the cache's
+// back, such as after running a workflow, invalidate it explicitly.
+type StatusCacheInvalidator interface {
+	InvalidateStatusCache()
+}
+
+type repoCache struct {
+	loaded  bool
+	Entries map[string]statusCacheEntry `json:"entries"`
+}
+
+type statusCacheEntry struct {
+	State string    `json:"state"`
+	At    time.Time `json:"at"`
+	Value string    `json:"value"`
+}
+
+// NewStatusCache returns a cache persisted under the user cache
+// directory, or nil when GGC_NO_STATUS_CACHE is set.
+func NewStatusCache() *StatusCache {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(envNoStatusCache))) {
+	case "", "0", "false", "no", "off":
+	default:
+		return nil
+	}
+	sc := &StatusCache{}
+	if base, err := os.UserCacheDir(); err == nil {
+		sc.Dir = filepath.Join(base, "ggc", "status")
+	}
+	return sc
+}
+
+// WithStatusCache returns a shallow copy of the client whose status reads
+// go through cache. A nil cache turns caching off.
+func (c *Client) WithStatusCache(cache *StatusCache) *Client {
+	clone := *c
+	clone.statusCache = cache
+	return &clone
+}
+
+// InvalidateStatusCache drops the cached reads for the current repository.
+func (c *Client) InvalidateStatusCache() {
+	if c.statusCache == nil {
+		return
+	}
+	if gitDir, _, ok := findGitDir(); ok {
+		c.statusCache.Invalidate(gitDir)
+	}
+}
+
+// cachedRead returns the cached result of read for key, running read and
+// caching its output on a miss. Errors are never cached.
+func (c *Client) cachedRead(key string, read func() (string, error)) (string, error) {
+	sc := c.statusCache
+	if sc == nil {
+		return read()
+	}
+	gitDir, commonDir, ok := findGitDir()
+	if !ok {
+		return read()
+	}
+	state := repoFingerprint(gitDir, commonDir)
+	if value, hit := sc.lookup(gitDir, key, state); hit {
+		return value, nil
+	}
+	value, err := read()
+	if err == nil {
+		sc.store(gitDir, key, state, value)
+	}
+	return value, err
+}
+
+func (sc *StatusCache) clock() time.Time {
+	if sc.now != nil {
+		return sc.now()
+	}
+	return time.Now()
+}
+
+func (sc *StatusCache) ttl() time.Duration {
+	if sc.TTL > 0 {
+		return sc.TTL
+	}
+	return DefaultStatusCacheTTL
+}
+
+// repo returns the entries for gitDir, loading them from disk once.
+// The caller holds sc.mu.
+func (sc *StatusCache) repo(gitDir string) *repoCache {
+	if sc.repos == nil {
+		sc.repos = make(map[string]*repoCache)
+	}
+	rc := sc.repos[gitDir]
+	if rc == nil {
+		rc = &repoCache{Entries: make(map[string]statusCacheEntry)}
+		sc.repos[gitDir] = rc
+	}
+	if !rc.loaded {
+		rc.loaded = true
+		if path := sc.file(gitDir); path != "" {
+			if data, err := os.ReadFile(path); err == nil {
+				// A corrupt file is ignored and overwritten on the next store.
+				_ = json.Unmarshal(data, rc)
+			}
+		}
+		if rc.Entries == nil {
+			rc.Entries = make(map[string]statusCacheEntry)
+		}
+	}
+	return rc
+}
+
+func (sc *StatusCache) lookup(gitDir, key, state string) (string, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	e, ok := sc.repo(gitDir).Entries[key]
+	if !ok || e.State != state {
+		return "", false
+	}
+	if age := sc.clock().Sub(e.At); age < 0 || age >= sc.ttl() {
+		return "", false
+	}
+	return e.Value, true
+}
+
+func (sc *StatusCache) store(gitDir, key, state, value string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	rc := sc.repo(gitDir)
+	now := sc.clock()
+	for k, e := range rc.Entries {
+		if e.State != state || now.Sub(e.At) >= sc.ttl() {
+			delete(rc.Entries, k)
+		}
+	}
+	rc.Entries[key] = statusCacheEntry{State: state, At: now, Value: value}
+	sc.save(gitDir, rc)
+}
+
+// Invalidate drops every entry for the repository at gitDir.
+func (sc *StatusCache) Invalidate(gitDir string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.repo(gitDir).Entries = make(map[string]statusCacheEntry)
+	if path := sc.file(gitDir); path != "" {
+		_ = os.Remove(path)
+	}
+}
+
+// file is the cache file for gitDir, or "" when the cache is in memory.
+func (sc *StatusCache) file(gitDir string) string {
+	if sc.Dir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(gitDir))
+	return filepath.Join(sc.Dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// save writes rc atomically. Failures only cost the next process a miss.
+func (sc *StatusCache) save(gitDir string, rc *repoCache) {
+	path := sc.file(gitDir)
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(rc)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".status-*")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.Write(data)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil || os.Rename(tmp.Name(), path) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// findGitDir locates the repository for the working directory without
+// running git: $GIT_DIR, or a .git directory or gitfile in the working
+// directory or one of its parents. commonDir differs from gitDir in
+// linked worktrees.
+func findGitDir() (gitDir, commonDir string, ok bool) {
+	if dir := os.Getenv("GIT_DIR"); dir != "" {
+		gitDir = dir
+	} else {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", "", false
+		}
+		if gitDir, ok = searchGitDir(wd); !ok {
+			return "", "", false
+		}
+	}
+	if abs, err := filepath.Abs(gitDir); err == nil {
+		gitDir = abs
+	}
+	commonDir = gitDir
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		dir := strings.TrimSpace(string(data))
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(gitDir, dir)
+		}
+		commonDir = filepath.Clean(dir)
+	}
+	return gitDir, commonDir, true
+}
+
+func searchGitDir(dir string) (string, bool) {
+	for {
+		dotGit := filepath.Join(dir, ".git")
+		if fi, err := os.Stat(dotGit); err == nil {
+			if fi.IsDir() {
+				return dotGit, true
+			}
+			data, err := os.ReadFile(dotGit)
+			if err != nil {
+				return "", false
+			}
+			target, found := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+			if !found {
+				return "", false
+			}
+			target = strings.TrimSpace(target)
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(dir, target)
+			}
+			return target, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// repoFingerprint summarizes the repository state the cached reads
+// depend on. Any change to it misses the cache.
+func repoFingerprint(gitDir, commonDir string) string {
+	var b strings.Builder
+	head, _ := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	b.WriteString(strings.TrimSpace(string(head)))
+	if ref, ok := strings.CutPrefix(strings.TrimSpace(string(head)), "ref: "); ok {
+		writeStat(&b, filepath.Join(commonDir, filepath.FromSlash(ref)))
+	}
+	writeStat(&b, filepath.Join(gitDir, "index"))
+	writeStat(&b, filepath.Join(gitDir, "FETCH_HEAD"))
+	writeStat(&b, filepath.Join(commonDir, "packed-refs"))
+	writeStat(&b, filepath.Join(commonDir, "config"))
+	writeRefStats(&b, filepath.Join(commonDir, "refs", "remotes"))
+	return b.String()
+}
+
+// writeRefStats adds every loose ref under dir, which is where fetch and
+// push update the upstream that ahead/behind counts compare against.
+func writeRefStats(b *strings.Builder, dir string) {
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		b.WriteString("|" + filepath.ToSlash(strings.TrimPrefix(path, dir)))
+		writeStat(b, path)
+		return nil
+	})
+}
+
+func writeStat(b *strings.Builder, path string) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		b.WriteString("|-")
+		return
+	}
+	fmt.Fprintf(b, "|%d:%d", fi.Size(), fi.ModTime().UnixNano())
+}
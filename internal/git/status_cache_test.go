@@ -0,0 +1,222 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newCacheTestRepo lays out a minimal .git directory, makes it the
+// working directory and returns its path.
+func newCacheTestRepo(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	gitDir := filepath.Join(root, ".git")
+	for path, data := range map[string]string{
+		"HEAD":            "ref: refs/heads/main\n",
+		"index":           "index-v1",
+		"refs/heads/main": "1111111\n",
+		"config":          "[core]\n",
+	} {
+		full := filepath.Join(gitDir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("GIT_DIR", "")
+	t.Chdir(root)
+	return gitDir
+}
+
+// countingClient returns a client whose git commands print output and
+// counts how many ran.
+func countingClient(cache *StatusCache, output string, calls *int) *Client {
+	return &Client{
+		statusCache: cache,
+		execCommand: func(_ string, _ ...string) *exec.Cmd {
+			*calls++
+			return fakeExecCommand(output)
+		},
+	}
+}
+
+func TestStatusCache_ReusesReadsUntilRepoChanges(t *testing.T) {
+	gitDir := newCacheTestRepo(t)
+	now := time.Unix(1000, 0)
+	cache := &StatusCache{now: func() time.Time { return now }}
+	calls := 0
+	c := countingClient(cache, "main", &calls)
+
+	for range 3 {
+		if got, err := c.GetCurrentBranch(); err != nil || got != "main" {
+			t.Fatalf("GetCurrentBranch() = %q, %v", got, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("git ran %d times, want 1", calls)
+	}
+
+	// Staging changes the index, which changes the fingerprint.
+	if err := os.WriteFile(filepath.Join(gitDir, "index"), []byte("index-v2 longer"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = c.GetCurrentBranch()
+	if calls != 2 {
+		t.Fatalf("git ran %d times after the index changed, want 2", calls)
+	}
+
+	// Entries expire after the TTL.
+	now = now.Add(DefaultStatusCacheTTL)
+	_, _ = c.GetCurrentBranch()
+	if calls != 3 {
+		t.Fatalf("git ran %d times after the TTL, want 3", calls)
+	}
+
+	c.InvalidateStatusCache()
+	_, _ = c.GetCurrentBranch()
+	if calls != 4 {
+		t.Fatalf("git ran %d times after invalidation, want 4", calls)
+	}
+}
+
+func TestStatusCache_RemoteTrackingRefs(t *testing.T) {
+	gitDir := newCacheTestRepo(t)
+	calls := 0
+	c := countingClient(&StatusCache{}, "0\t1", &calls)
+
+	_, _ = c.GetAheadBehindCount("main", "origin/main")
+	// A push or a fetch that only writes a loose remote-tracking ref.
+	ref := filepath.Join(gitDir, "refs", "remotes", "origin", "main")
+	if err := os.MkdirAll(filepath.Dir(ref), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ref, []byte("2222222\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = c.GetAheadBehindCount("main", "origin/main")
+	if calls != 2 {
+		t.Errorf("git ran %d times, want a new read after origin/main moved", calls)
+	}
+}
+
+func TestStatusCache_DoesNotCacheStatus(t *testing.T) {
+	newCacheTestRepo(t)
+	calls := 0
+	c := countingClient(&StatusCache{}, "", &calls)
+
+	// A file created between two reads changes nothing in .git.
+	for range 2 {
+		_, _ = c.StatusShortWithColor()
+		_, _ = c.StatusWithColor()
+		_, _ = c.StatusSummary()
+	}
+	if calls != 6 {
+		t.Errorf("git ran %d times, want every status read to run git", calls)
+	}
+}
+
+func TestStatusCache_KeysByArguments(t *testing.T) {
+	newCacheTestRepo(t)
+	calls := 0
+	c := countingClient(&StatusCache{}, "1\t2", &calls)
+
+	_, _ = c.GetAheadBehindCount("main", "origin/main")
+	_, _ = c.GetAheadBehindCount("main", "origin/main")
+	_, _ = c.GetAheadBehindCount("dev", "origin/dev")
+	_, _ = c.GetUpstreamBranchName("main")
+	if calls != 3 {
+		t.Errorf("git ran %d times, want 3", calls)
+	}
+}
+
+func TestStatusCache_DoesNotCacheErrors(t *testing.T) {
+	newCacheTestRepo(t)
+	calls := 0
+	c := &Client{
+		statusCache: &StatusCache{},
+		execCommand: func(_ string, _ ...string) *exec.Cmd {
+			calls++
+			return helperCommand(t, "", errors.New("fatal"))
+		},
+	}
+	_, _ = c.GetUpstreamBranchName("main")
+	_, _ = c.GetUpstreamBranchName("main")
+	if calls != 2 {
+		t.Errorf("git ran %d times, want 2", calls)
+	}
+}
+
+func TestStatusCache_SharedAcrossProcesses(t *testing.T) {
+	newCacheTestRepo(t)
+	dir := t.TempDir()
+	calls := 0
+
+	first := countingClient(&StatusCache{Dir: dir}, "main", &calls)
+	if _, err := first.GetCurrentBranch(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new cache on the same directory stands in for the next ggc process.
+	second := countingClient(&StatusCache{Dir: dir}, "stale", &calls)
+	got, err := second.GetCurrentBranch()
+	if err != nil || got != "main" {
+		t.Fatalf("GetCurrentBranch() = %q, %v", got, err)
+	}
+	if calls != 1 {
+		t.Fatalf("git ran %d times, want 1", calls)
+	}
+
+	second.InvalidateStatusCache()
+	third := countingClient(&StatusCache{Dir: dir}, "fresh", &calls)
+	if got, _ := third.GetCurrentBranch(); got != "fresh" {
+		t.Errorf("GetCurrentBranch() after invalidation = %q, want fresh", got)
+	}
+}
+
+func TestStatusCache_Disabled(t *testing.T) {
+	newCacheTestRepo(t)
+	calls := 0
+	c := countingClient(nil, "main", &calls)
+	_, _ = c.GetCurrentBranch()
+	_, _ = c.GetCurrentBranch()
+	if calls != 2 {
+		t.Errorf("git ran %d times without a cache, want 2", calls)
+	}
+
+	t.Setenv(envNoStatusCache, "1")
+	if NewStatusCache() != nil {
+		t.Error("NewStatusCache() should return nil when GGC_NO_STATUS_CACHE is set")
+	}
+}
+
+func TestFindGitDir_Worktree(t *testing.T) {
+	root := t.TempDir()
+	common := filepath.Join(root, "main", ".git")
+	wtGitDir := filepath.Join(common, "worktrees", "feature")
+	if err := os.MkdirAll(wtGitDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wtGitDir, "commondir"), []byte("../..\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wt := filepath.Join(root, "feature", "sub")
+	if err := os.MkdirAll(wt, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "feature", ".git"), []byte("gitdir: "+wtGitDir+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_DIR", "")
+	t.Chdir(wt)
+
+	gitDir, commonDir, ok := findGitDir()
+	if !ok || gitDir != wtGitDir || commonDir != common {
+		t.Errorf("findGitDir() = %q, %q, %v; want %q, %q", gitDir, commonDir, ok, wtGitDir, common)
+	}
+}
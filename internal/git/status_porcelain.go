@@ -0,0 +1,190 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StatusSummaryReader reads the branch, upstream and working tree state
+// with one git invocation.
+type StatusSummaryReader interface {
+	StatusSummary() (*StatusSummary, error)
+}
+
+// StatusSummary is the parsed output of
+// git status --porcelain=v2 --branch.
+type StatusSummary struct {
+	// Branch is the checked-out branch, or "HEAD" when detached, matching
+	// git rev-parse --abbrev-ref HEAD.
+	Branch   string
+	Detached bool
+	// OID is the commit HEAD points at; empty before the first commit.
+	OID string
+	// Upstream is the upstream branch, empty when none is set.
+	Upstream string
+	// Ahead and Behind count commits relative to Upstream. They are zero
+	// when there is no upstream or it is gone.
+	Ahead   int
+	Behind  int
+	Entries []StatusEntry
+}
+
+// StatusEntryKind says which kind of porcelain v2 line an entry came from.
+type StatusEntryKind byte
+
+// Porcelain v2 entry kinds.
+const (
+	StatusOrdinary  StatusEntryKind = '1'
+	StatusRenamed   StatusEntryKind = '2' // renamed or copied
+	StatusUnmerged  StatusEntryKind = 'u'
+	StatusUntracked StatusEntryKind = '?'
+	StatusIgnored   StatusEntryKind = '!'
+)
+
+// StatusEntry is one changed path.
+type StatusEntry struct {
+	Kind StatusEntryKind
+	// Index and WorkTree are the XY status codes; '.' means unchanged.
+	Index    byte
+	WorkTree byte
+	Path     string
+	// OrigPath is the source path of a rename or copy.
+	OrigPath string
+}
+
+// Staged counts paths with changes in the index.
+func (s *StatusSummary) Staged() int {
+	return s.count(func(e StatusEntry) bool {
+		return (e.Kind == StatusOrdinary || e.Kind == StatusRenamed) && e.Index != '.'
+	})
+}
+
+// Modified counts tracked paths with unstaged changes.
+func (s *StatusSummary) Modified() int {
+	return s.count(func(e StatusEntry) bool {
+		return (e.Kind == StatusOrdinary || e.Kind == StatusRenamed) && e.WorkTree != '.'
+	})
+}
+
+// Conflicted counts unmerged paths.
+func (s *StatusSummary) Conflicted() int {
+	return s.count(func(e StatusEntry) bool { return e.Kind == StatusUnmerged })
+}
+
+// Untracked counts untracked paths.
+func (s *StatusSummary) Untracked() int {
+	return s.count(func(e StatusEntry) bool { return e.Kind == StatusUntracked })
+}
+
+func (s *StatusSummary) count(match func(StatusEntry) bool) int {
+	n := 0
+	for _, e := range s.Entries {
+		if match(e) {
+			n++
+		}
+	}
+	return n
+}
+
+// StatusSummary runs git status --porcelain=v2 --branch -z once and
+// parses it, instead of separate calls for the branch, the working tree
+// and the ahead/behind counts.
+func (c *Client) StatusSummary() (*StatusSummary, error) {
+	cmd := c.execCommand("git", "status", "--porcelain=v2", "--branch", "-z")
+	out, err := c.output(cmd)
+	if err != nil {
+		return nil, NewOpError("get status summary", "git status --porcelain=v2 --branch -z", err)
+	}
+	return ParseStatusPorcelainV2(string(out))
+}
+
+// ParseStatusPorcelainV2 parses NUL-terminated (-z) porcelain v2 output
+// with branch headers.
+func ParseStatusPorcelainV2(out string) (*StatusSummary, error) {
+	s := &StatusSummary{}
+	records := strings.Split(out, "\x00")
+	for i := 0; i < len(records); i++ {
+		rec := records[i]
+		if rec == "" {
+			continue
+		}
+		switch rec[0] {
+		case '#':
+			if err := s.parseHeader(rec); err != nil {
+				return nil, err
+			}
+		case '1', '2', 'u':
+			e, err := parseChangedEntry(rec)
+			if err != nil {
+				return nil, err
+			}
+			if e.Kind == StatusRenamed {
+				// With -z the original path is the next record.
+				i++
+				if i >= len(records) {
+					return nil, fmt.Errorf("status: rename entry without original path: %q", rec)
+				}
+				e.OrigPath = records[i]
+			}
+			s.Entries = append(s.Entries, e)
+		case '?', '!':
+			if len(rec) < 3 {
+				return nil, fmt.Errorf("status: malformed entry %q", rec)
+			}
+			s.Entries = append(s.Entries, StatusEntry{Kind: StatusEntryKind(rec[0]), Path: rec[2:]})
+		default:
+			return nil, fmt.Errorf("status: unknown entry %q", rec)
+		}
+	}
+	return s, nil
+}
+
+func (s *StatusSummary) parseHeader(rec string) error {
+	fields := strings.Fields(rec)
+	if len(fields) < 3 {
+		return nil // headers without a value carry nothing we use
+	}
+	value := strings.Join(fields[2:], " ")
+	switch fields[1] {
+	case "branch.oid":
+		if value != "(initial)" {
+			s.OID = value
+		}
+	case "branch.head":
+		if value == "(detached)" {
+			s.Branch, s.Detached = "HEAD", true
+		} else {
+			s.Branch = value
+		}
+	case "branch.upstream":
+		s.Upstream = value
+	case "branch.ab":
+		if len(fields) != 4 {
+			return fmt.Errorf("status: malformed header %q", rec)
+		}
+		ahead, err1 := strconv.Atoi(strings.TrimPrefix(fields[2], "+"))
+		behind, err2 := strconv.Atoi(strings.TrimPrefix(fields[3], "-"))
+		if err1 != nil || err2 != nil {
+			return fmt.Errorf("status: malformed header %q", rec)
+		}
+		s.Ahead, s.Behind = ahead, behind
+	}
+	return nil
+}
+
+// parseChangedEntry parses a "1", "2" or "u" line. The path is the last
+// field and may contain spaces, so the fixed fields are split off first.
+func parseChangedEntry(rec string) (StatusEntry, error) {
+	fixed := map[byte]int{'1': 8, '2': 9, 'u': 10}[rec[0]]
+	fields := strings.SplitN(rec, " ", fixed+1)
+	if len(fields) != fixed+1 || len(fields[1]) != 2 {
+		return StatusEntry{}, fmt.Errorf("status: malformed entry %q", rec)
+	}
+	return StatusEntry{
+		Kind:     StatusEntryKind(rec[0]),
+		Index:    fields[1][0],
+		WorkTree: fields[1][1],
+		Path:     fields[fixed],
+	}, nil
+}
@@ -0,0 +1,205 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseStatusPorcelainV2(t *testing.T) {
+	out := strings.Join([]string{
+		"# branch.oid 4e1d5c0b2f3a",
+		"# branch.head feature/x",
+		"# branch.upstream origin/feature/x",
+		"# branch.ab +2 -1",
+		"1 M. N... 100644 100644 100644 aaa bbb staged.go",
+		"1 .M N... 100644 100644 100644 aaa aaa dir/with space.go",
+		"1 MM N... 100644 100644 100644 aaa bbb both.go",
+		"2 R. N... 100644 100644 100644 aaa aaa R100 new.go",
+		"old.go",
+		"u UU N... 100644 100644 100644 100644 aaa bbb ccc conflict.go",
+		"? notes.txt",
+		"",
+	}, "\x00")
+
+	got, err := ParseStatusPorcelainV2(out)
+	if err != nil {
+		t.Fatalf("ParseStatusPorcelainV2() error = %v", err)
+	}
+	want := &StatusSummary{
+		Branch:   "feature/x",
+		OID:      "4e1d5c0b2f3a",
+		Upstream: "origin/feature/x",
+		Ahead:    2,
+		Behind:   1,
+		Entries: []StatusEntry{
+			{Kind: StatusOrdinary, Index: 'M', WorkTree: '.', Path: "staged.go"},
+			{Kind: StatusOrdinary, Index: '.', WorkTree: 'M', Path: "dir/with space.go"},
+			{Kind: StatusOrdinary, Index: 'M', WorkTree: 'M', Path: "both.go"},
+			{Kind: StatusRenamed, Index: 'R', WorkTree: '.', Path: "new.go", OrigPath: "old.go"},
+			{Kind: StatusUnmerged, Index: 'U', WorkTree: 'U', Path: "conflict.go"},
+			{Kind: StatusUntracked, Path: "notes.txt"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseStatusPorcelainV2() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got.Staged() != 3 || got.Modified() != 2 || got.Conflicted() != 1 || got.Untracked() != 1 {
+		t.Errorf("counts: staged=%d modified=%d conflicted=%d untracked=%d",
+			got.Staged(), got.Modified(), got.Conflicted(), got.Untracked())
+	}
+}
+
+func TestParseStatusPorcelainV2_Headers(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want StatusSummary
+	}{
+		{
+			name: "initial commit without upstream",
+			out:  "# branch.oid (initial)\x00# branch.head main\x00",
+			want: StatusSummary{Branch: "main"},
+		},
+		{
+			name: "detached",
+			out:  "# branch.oid abc\x00# branch.head (detached)\x00",
+			want: StatusSummary{Branch: "HEAD", Detached: true, OID: "abc"},
+		},
+		{
+			name: "upstream gone",
+			out:  "# branch.oid abc\x00# branch.head main\x00# branch.upstream origin/main\x00",
+			want: StatusSummary{Branch: "main", OID: "abc", Upstream: "origin/main"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStatusPorcelainV2(tt.out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStatusPorcelainV2_Malformed(t *testing.T) {
+	for _, out := range []string{
+		"# branch.ab +x -1\x00",
+		"1 M\x00",
+		"2 R. N... 100644 100644 100644 aaa aaa R100 new.go",
+		"z what\x00",
+	} {
+		if _, err := ParseStatusPorcelainV2(out); err == nil {
+			t.Errorf("ParseStatusPorcelainV2(%q) should fail", out)
+		}
+	}
+}
+
+func TestClient_StatusSummary(t *testing.T) {
+	var gotArgs []string
+	c := &Client{
+		execCommand: func(_ string, arg ...string) *exec.Cmd {
+			gotArgs = arg
+			// Arguments cannot carry NUL bytes, so printf writes them.
+			return exec.Command("printf", `# branch.head main\000? a\000`)
+		},
+	}
+	s, err := c.StatusSummary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"status", "--porcelain=v2", "--branch", "-z"}; !reflect.DeepEqual(gotArgs, want) {
+		t.Errorf("args = %v, want %v", gotArgs, want)
+	}
+	if s.Branch != "main" || s.Untracked() != 1 {
+		t.Errorf("unexpected summary %+v", s)
+	}
+
+	c.execCommand = func(_ string, _ ...string) *exec.Cmd { return helperCommand(t, "", errors.New("fatal")) }
+	if _, err := c.StatusSummary(); err == nil {
+		t.Error("expected error")
+	}
+}
+
+// benchRepo creates a repository with an upstream, some commits ahead and
+// a few changed files, and makes it the working directory.
+func benchRepo(b *testing.B) {
+	b.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		b.Skip("git not installed")
+	}
+	root := b.TempDir()
+	upstream := filepath.Join(root, "upstream.git")
+	work := filepath.Join(root, "work")
+	run := func(dir string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=bench", "GIT_AUTHOR_EMAIL=bench@example.com",
+			"GIT_COMMITTER_NAME=bench", "GIT_COMMITTER_EMAIL=bench@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			b.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, data string) {
+		if err := os.WriteFile(filepath.Join(work, name), []byte(data), 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	run(root, "init", "-q", "--bare", upstream)
+	run(root, "init", "-q", "-b", "main", work)
+	write("a.txt", "a")
+	write("b.txt", "b")
+	run(work, "add", ".")
+	run(work, "commit", "-qm", "initial")
+	run(work, "remote", "add", "origin", upstream)
+	run(work, "push", "-q", "-u", "origin", "main")
+	write("a.txt", "a2")
+	run(work, "commit", "-qam", "ahead")
+	write("a.txt", "a3")
+	write("b.txt", "b2")
+	run(work, "add", "b.txt")
+	write("c.txt", "c")
+	b.Chdir(work)
+}
+
+// BenchmarkStatusSeparateCalls is the three-call path the interactive
+// header used before StatusSummary.
+func BenchmarkStatusSeparateCalls(b *testing.B) {
+	benchRepo(b)
+	c := NewClient()
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := c.GetCurrentBranch(); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := c.StatusShortWithColor(); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := c.GetAheadBehindCount("HEAD", "@{upstream}"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStatusSummary(b *testing.B) {
+	benchRepo(b)
+	c := NewClient()
+	b.ResetTimer()
+	for b.Loop() {
+		s, err := c.StatusSummary()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if s.Ahead != 1 || s.Staged() != 1 || s.Modified() != 1 {
+			b.Fatalf("unexpected summary %+v", s)
+		}
+	}
+}
@@ -84,6 +84,24 @@ func TestClient_StatusWithColor(t *testing.T) {
 	}
 }
 
+func TestClient_StatusWithColor_Paths(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("echo", "-n", "")
+		},
+	}
+
+	if _, err := client.StatusShortWithColor(":(top)apps/web"); err != nil {
+		t.Fatal(err)
+	}
+	wantArgs := []string{"git", "-c", "color.status=always", "status", "--short", "--", ":(top)apps/web"}
+	if !slices.Equal(gotArgs, wantArgs) {
+		t.Errorf("StatusShortWithColor() gotArgs = %v, want %v", gotArgs, wantArgs)
+	}
+}
+
 func TestClient_StatusShortWithColor(t *testing.T) {
 	var gotArgs []string
 	expectedOutput := " M file.go\n?? new_file.go"
@@ -33,7 +33,7 @@ func (c *Client) TagList(pattern []string) error {
 
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("tag list", "git tag --sort=-version:refname", err)
 	}
 	return nil
@@ -48,23 +48,47 @@ func (c *Client) TagCreate(name string, commit string) error {
 
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("tag create", "git tag "+name, err)
 	}
 	return nil
 }
 
+// TagAnnotator creates annotated tags on any commit.
+type TagAnnotator interface {
+	TagCreateAnnotatedAt(name, commit, message string) error
+}
+
+// NearestTagReader finds the tag a release starts from.
+type NearestTagReader interface {
+	NearestTag(rev string) (string, error)
+}
+
+// TagNameLister lists tag names, for completion.
+type TagNameLister interface {
+	TagNames() ([]string, error)
+}
+
 // TagCreateAnnotated creates an annotated tag.
 func (c *Client) TagCreateAnnotated(name, message string) error {
-	var cmd = c.execCommand("git", "tag", "-a", name)
+	return c.TagCreateAnnotatedAt(name, "", message)
+}
+
+// TagCreateAnnotatedAt creates an annotated tag on commit (HEAD when
+// empty). An empty message opens the editor.
+func (c *Client) TagCreateAnnotatedAt(name, commit, message string) error {
+	args := []string{"tag", "-a", name}
 	if message != "" {
-		cmd = c.execCommand("git", "tag", "-a", name, "-m", message)
+		args = append(args, "-m", message)
 	}
-
+	if commit != "" {
+		args = append(args, commit)
+	}
+	cmd := c.execCommand("git", args...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("tag create annotated", "git tag -a "+name, err)
 	}
 	return nil
@@ -76,7 +100,7 @@ func (c *Client) TagDelete(names []string) error {
 		cmd := c.execCommand("git", "tag", "-d", name)
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
-		if err := cmd.Run(); err != nil {
+		if err := c.run(cmd); err != nil {
 			return NewOpError("tag delete", "git tag -d "+name, err)
 		}
 	}
@@ -88,7 +112,7 @@ func (c *Client) TagPush(remote, name string) error {
 	cmd := c.execCommand("git", "push", remote, name)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("tag push", "git push "+remote+" "+name, err)
 	}
 	return nil
@@ -99,7 +123,7 @@ func (c *Client) TagPushAll(remote string) error {
 	cmd := c.execCommand("git", "push", remote, "--tags")
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("tag push all", "git push "+remote+" --tags", err)
 	}
 	return nil
@@ -110,7 +134,7 @@ func (c *Client) TagShow(name string) error {
 	cmd := c.execCommand("git", "show", name)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := c.run(cmd); err != nil {
 		return NewOpError("tag show", "git show "+name, err)
 	}
 	return nil
@@ -119,7 +143,7 @@ func (c *Client) TagShow(name string) error {
 // GetLatestTag gets the latest tag.
 func (c *Client) GetLatestTag() (string, error) {
 	cmd := c.execCommand("git", "describe", "--tags", "--abbrev=0")
-	output, err := cmd.Output()
+	output, err := c.output(cmd)
 	if err != nil {
 		return "", NewOpError("get latest tag", "git describe --tags --abbrev=0", err)
 	}
@@ -129,9 +153,30 @@ func (c *Client) GetLatestTag() (string, error) {
 // TagExists checks if a tag exists.
 func (c *Client) TagExists(name string) bool {
 	cmd := c.execCommand("git", "tag", "-l", name)
-	output, err := cmd.Output()
+	output, err := c.output(cmd)
 	if err != nil {
 		return false
 	}
 	return strings.TrimSpace(string(output)) != ""
 }
+
+// NearestTag returns the newest tag reachable from rev, or "" when there
+// is none.
+func (c *Client) NearestTag(rev string) (string, error) {
+	args := []string{"tag", "--merged", rev, "--sort=-creatordate"}
+	out, err := c.output(c.execCommand("git", args...))
+	if err != nil {
+		return "", NewOpError("nearest tag", "git "+strings.Join(args, " "), err)
+	}
+	first, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return first, nil
+}
+
+// TagNames returns the tag names, highest version first.
+func (c *Client) TagNames() ([]string, error) {
+	out, err := c.output(c.execCommand("git", "tag", "--sort=-version:refname"))
+	if err != nil {
+		return nil, NewOpError("list tag names", "git tag --sort=-version:refname", err)
+	}
+	return strings.Fields(string(out)), nil
+}
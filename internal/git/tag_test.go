@@ -330,3 +330,71 @@ func TestClient_TagExists(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_TagCreateAnnotatedAt(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return exec.Command("echo")
+		},
+	}
+	if err := client.TagCreateAnnotatedAt("v1.1.0", "abc123", "Release"); err != nil {
+		t.Fatalf("TagCreateAnnotatedAt() error = %v", err)
+	}
+	if want := []string{"git", "tag", "-a", "v1.1.0", "-m", "Release", "abc123"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("gotArgs = %v, want %v", gotArgs, want)
+	}
+}
+
+func TestClient_NearestTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{"newest first", "v1.2.0\nv1.1.0\n", "v1.2.0"},
+		{"no tags", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			client := &Client{
+				execCommand: func(name string, args ...string) *exec.Cmd {
+					gotArgs = append([]string{name}, args...)
+					return helperCommand(t, tt.output, nil)
+				},
+			}
+			got, err := client.NearestTag("HEAD")
+			if err != nil {
+				t.Fatalf("NearestTag() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NearestTag() = %q, want %q", got, tt.want)
+			}
+			if want := []string{"git", "tag", "--merged", "HEAD", "--sort=-creatordate"}; !slices.Equal(gotArgs, want) {
+				t.Errorf("gotArgs = %v, want %v", gotArgs, want)
+			}
+		})
+	}
+}
+
+func TestClient_TagNames(t *testing.T) {
+	var gotArgs []string
+	client := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			gotArgs = append([]string{name}, args...)
+			return helperCommand(t, "v1.10.0\nv1.9.0\n", nil)
+		},
+	}
+	got, err := client.TagNames()
+	if err != nil {
+		t.Fatalf("TagNames() error = %v", err)
+	}
+	if want := []string{"v1.10.0", "v1.9.0"}; !slices.Equal(got, want) {
+		t.Errorf("TagNames() = %v, want %v", got, want)
+	}
+	if want := []string{"git", "tag", "--sort=-version:refname"}; !slices.Equal(gotArgs, want) {
+		t.Errorf("gotArgs = %v, want %v", gotArgs, want)
+	}
+}
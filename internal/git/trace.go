@@ -0,0 +1,177 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// traceStderrLimit caps how much stderr a debug log entry carries.
+const traceStderrLimit = 4096
+
+// WithLogger returns a shallow copy of the client that logs every git
+// command it runs: the command line and its duration at info level, and
+// its directory, environment, exit code and stderr at debug level. A nil
+// logger turns logging off.
+func (c *Client) WithLogger(logger *slog.Logger) *Client {
+	clone := *c
+	clone.logger = logger
+	return &clone
+}
+
+// run, output and combinedOutput run cmd like the exec.Cmd methods of the
+// same names. Every git command the client runs goes through them so that
+// it is logged, and so that a failed command's stderr is kept on its
+// *exec.ExitError for Classify, as Output does.
+func (c *Client) run(cmd *exec.Cmd) error {
+	var stderr *bytes.Buffer
+	if cmd.Stderr == nil {
+		stderr = &bytes.Buffer{}
+		cmd.Stderr = stderr
+	}
+	t := c.startTrace(cmd)
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if stderr != nil && errors.As(err, &exitErr) && len(exitErr.Stderr) == 0 {
+		exitErr.Stderr = stderr.Bytes()
+	}
+	t.finish(err, nil)
+	return err
+}
+
+func (c *Client) output(cmd *exec.Cmd) ([]byte, error) {
+	t := c.startTrace(cmd)
+	out, err := cmd.Output()
+	t.finish(err, nil)
+	return out, err
+}
+
+func (c *Client) combinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	t := c.startTrace(cmd)
+	out, err := cmd.CombinedOutput()
+	t.finish(err, out)
+	return out, err
+}
+
+// trace is one logged command. A nil trace logs nothing.
+type trace struct {
+	logger *slog.Logger
+	ctx    context.Context
+	cmd    *exec.Cmd
+	debug  bool
+	stderr *bytes.Buffer // the command's stderr when it is collected in memory
+	start  time.Time
+}
+
+// startTrace starts logging cmd.
+func (c *Client) startTrace(cmd *exec.Cmd) *trace {
+	if c.logger == nil {
+		return nil
+	}
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	t := &trace{logger: c.logger, ctx: ctx, cmd: cmd, start: time.Now()}
+	t.debug = c.logger.Enabled(ctx, slog.LevelDebug)
+	t.stderr, _ = cmd.Stderr.(*bytes.Buffer)
+	return t
+}
+
+// finish logs the command once it has exited, as a single entry so that
+// a debug log reads one line per command.
+func (t *trace) finish(err error, combined []byte) {
+	if t == nil {
+		return
+	}
+	attrs := []slog.Attr{slog.Duration("duration", time.Since(t.start).Round(time.Microsecond))}
+	var exitErr *exec.ExitError
+	switch {
+	case t.debug:
+		attrs = append(attrs, slog.Int("exit", exitCode(t.cmd, err)))
+	case errors.As(err, &exitErr):
+		attrs = append(attrs, slog.Int("exit", exitErr.ExitCode()))
+	}
+	if err != nil && !errors.As(err, &exitErr) {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	if t.debug {
+		attrs = append(attrs, t.debugAttrs(err, combined)...)
+	}
+	t.logger.LogAttrs(t.ctx, slog.LevelInfo, commandLine(t.cmd), attrs...)
+}
+
+// debugAttrs are the directory, git environment and stderr of the command.
+func (t *trace) debugAttrs(err error, combined []byte) []slog.Attr {
+	var attrs []slog.Attr
+	if t.cmd.Dir != "" {
+		attrs = append(attrs, slog.String("dir", t.cmd.Dir))
+	}
+	if env := gitEnv(t.cmd); len(env) > 0 {
+		attrs = append(attrs, slog.String("env", strings.Join(env, " ")))
+	}
+	var stderr []byte
+	var exitErr *exec.ExitError
+	switch {
+	case t.stderr != nil:
+		stderr = t.stderr.Bytes()
+	case errors.As(err, &exitErr) && len(exitErr.Stderr) > 0:
+		stderr = exitErr.Stderr
+	case combined != nil && err != nil:
+		stderr = combined
+	}
+	if s := strings.TrimSpace(string(stderr)); s != "" {
+		if len(s) > traceStderrLimit {
+			s = s[:traceStderrLimit] + "..."
+		}
+		attrs = append(attrs, slog.String("stderr", s))
+	}
+	return attrs
+}
+
+func commandLine(cmd *exec.Cmd) string {
+	if len(cmd.Args) == 0 {
+		return cmd.Path
+	}
+	return strings.Join(cmd.Args, " ")
+}
+
+func exitCode(cmd *exec.Cmd, err error) int {
+	if cmd.ProcessState != nil {
+		return cmd.ProcessState.ExitCode()
+	}
+	if err != nil {
+		return -1
+	}
+	return 0
+}
+
+// gitEnv lists the git-related variables the command runs with, with
+// values that look like credentials masked.
+func gitEnv(cmd *exec.Cmd) []string {
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	var out []string
+	for _, kv := range env {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(key, "GIT_") {
+			continue
+		}
+		upper := strings.ToUpper(key)
+		for _, secret := range []string{"TOKEN", "PASSWORD", "SECRET", "AUTH"} {
+			if strings.Contains(upper, secret) {
+				value = "***"
+				break
+			}
+		}
+		out = append(out, key+"="+value)
+	}
+	return out
+}
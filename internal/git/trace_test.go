@@ -0,0 +1,69 @@
+package git
+
+import (
+	"bytes"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func newTraceClient(t *testing.T, level slog.Level) (*Client, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: level}))
+	c := &Client{execCommand: exec.Command}
+	return c.WithLogger(logger), &buf
+}
+
+func TestClient_TraceVerbose(t *testing.T) {
+	c, buf := newTraceClient(t, slog.LevelInfo)
+	out, err := c.output(exec.Command("echo", "hello"))
+	if err != nil || strings.TrimSpace(string(out)) != "hello" {
+		t.Fatalf("output = %q, %v", out, err)
+	}
+	log := buf.String()
+	if !strings.Contains(log, `msg="echo hello"`) || !strings.Contains(log, "duration=") {
+		t.Errorf("log = %q", log)
+	}
+	if strings.Contains(log, "exit=") {
+		t.Errorf("a successful command should not log its exit code at info level: %q", log)
+	}
+}
+
+func TestClient_TraceDebug(t *testing.T) {
+	t.Setenv("GIT_ASKPASS_TOKEN", "s3cret")
+	t.Setenv("GIT_TRACE", "0")
+	c, buf := newTraceClient(t, slog.LevelDebug)
+	cmd := exec.Command("sh", "-c", "echo oops >&2; exit 3")
+	if err := c.run(cmd); err == nil {
+		t.Fatal("expected the command to fail")
+	}
+	log := buf.String()
+	for _, want := range []string{"exit=3", `stderr=oops`, "GIT_TRACE=0", "GIT_ASKPASS_TOKEN=***"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log lacks %q: %q", want, log)
+		}
+	}
+	if strings.Contains(log, "s3cret") {
+		t.Errorf("log leaks a credential: %q", log)
+	}
+}
+
+func TestClient_TraceCombinedOutput(t *testing.T) {
+	c, buf := newTraceClient(t, slog.LevelDebug)
+	out, err := c.combinedOutput(exec.Command("sh", "-c", "echo out; echo err >&2; exit 1"))
+	if err == nil || !strings.Contains(string(out), "err") {
+		t.Fatalf("combinedOutput = %q, %v", out, err)
+	}
+	if !strings.Contains(buf.String(), "exit=1") {
+		t.Errorf("log = %q", buf.String())
+	}
+}
+
+func TestClient_NoLogger(t *testing.T) {
+	c := &Client{execCommand: exec.Command}
+	if err := c.run(exec.Command("true")); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,96 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// UndoOps provides the operations used to journal destructive commands and
+// to reverse them later with `ggc undo`.
+type UndoOps interface {
+	GetCurrentBranch() (string, error)
+	GitDir() (string, error)
+	RevParse(ref string) (string, error)
+	SnapshotPaths(ref, message string, paths []string) (string, error)
+	RestorePathsFrom(commit string, paths []string) error
+	CreateBranchAt(name, commit string) error
+	DeleteRef(ref string) error
+	ResetHard(commit string) error
+	ResetSoft(commit string) error
+}
+
+// SnapshotPaths records the working-tree content of paths in a parentless
+// commit and pins it under ref so it survives garbage collection. A
+// throwaway index is used, so the real index, HEAD and working tree are
+// left untouched. Ignored files are included because callers snapshot
+// exactly the paths they are about to delete.
+func (c *Client) SnapshotPaths(ref, message string, paths []string) (string, error) {
+	if len(paths) == 0 {
+		return "", NewOpError("snapshot paths", "git add", fmt.Errorf("no paths to snapshot"))
+	}
+	gitDir, err := c.GitDir()
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(gitDir, "ggc-index-*")
+	if err != nil {
+		return "", NewOpError("snapshot paths", "create temporary index", err)
+	}
+	indexPath := tmp.Name()
+	_ = tmp.Close()
+	// git refuses to read an empty index file, so only the name is kept.
+	_ = os.Remove(indexPath)
+	defer func() { _ = os.Remove(indexPath) }()
+	env := append(os.Environ(), "GIT_INDEX_FILE="+indexPath)
+
+	addArgs := append([]string{"add", "--force", "--"}, paths...)
+	add := c.execCommand("git", addArgs...)
+	add.Env = env
+	if err := c.run(add); err != nil {
+		return "", NewOpError("snapshot paths", "git "+strings.Join(addArgs, " "), err)
+	}
+
+	writeTree := c.execCommand("git", "write-tree")
+	writeTree.Env = env
+	out, err := c.output(writeTree)
+	if err != nil {
+		return "", NewOpError("snapshot paths", "git write-tree", err)
+	}
+	tree := strings.TrimSpace(string(out))
+
+	commitTree := c.execCommand("git", "commit-tree", tree, "-m", message)
+	out, err = c.output(commitTree)
+	if err != nil {
+		return "", NewOpError("snapshot paths", "git commit-tree "+tree, err)
+	}
+	commit := strings.TrimSpace(string(out))
+
+	updateRef := c.execCommand("git", "update-ref", ref, commit)
+	if err := c.run(updateRef); err != nil {
+		return "", NewOpError("snapshot paths", fmt.Sprintf("git update-ref %s %s", ref, commit), err)
+	}
+	return commit, nil
+}
+
+// RestorePathsFrom writes paths from commit back into the working tree
+// without staging them.
+func (c *Client) RestorePathsFrom(commit string, paths []string) error {
+	args := append([]string{"restore", "--source=" + commit, "--worktree", "--"}, paths...)
+	cmd := c.execCommand("git", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("restore paths", "git "+strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// DeleteRef removes ref.
+func (c *Client) DeleteRef(ref string) error {
+	cmd := c.execCommand("git", "update-ref", "-d", ref)
+	if err := c.run(cmd); err != nil {
+		return NewOpError("delete ref", "git update-ref -d "+ref, err)
+	}
+	return nil
+}
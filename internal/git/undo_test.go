@@ -0,0 +1,121 @@
+package git
+
+import (
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClient_SnapshotPaths(t *testing.T) {
+	gitDir := t.TempDir()
+	var cmds []*exec.Cmd
+	var calls [][]string
+	c := &Client{
+		execCommand: func(name string, args ...string) *exec.Cmd {
+			var cmd *exec.Cmd
+			switch args[0] {
+			case "rev-parse":
+				cmd = fakeExecCommand(gitDir)
+			case "write-tree":
+				cmd = fakeExecCommand("tree123\n")
+			case "commit-tree":
+				cmd = fakeExecCommand("commit456\n")
+			default:
+				cmd = helperCommand(t, "", nil)
+			}
+			calls = append(calls, append([]string{name}, args...))
+			cmds = append(cmds, cmd)
+			return cmd
+		},
+	}
+
+	sha, err := c.SnapshotPaths("refs/ggc/undo/x", "ggc undo: clean files", []string{"a.txt", "dir/"})
+	if err != nil {
+		t.Fatalf("SnapshotPaths: %v", err)
+	}
+	if sha != "commit456" {
+		t.Fatalf("sha = %q, want commit456", sha)
+	}
+	want := [][]string{
+		{"git", "rev-parse", "--absolute-git-dir"},
+		{"git", "add", "--force", "--", "a.txt", "dir/"},
+		{"git", "write-tree"},
+		{"git", "commit-tree", "tree123", "-m", "ggc undo: clean files"},
+		{"git", "update-ref", "refs/ggc/undo/x", "commit456"},
+	}
+	if !slices.EqualFunc(calls, want, slices.Equal[[]string]) {
+		t.Fatalf("got %v\nwant %v", calls, want)
+	}
+	// add and write-tree must run against a throwaway index so the
+	// user's real index is never touched.
+	for i := 1; i <= 2; i++ {
+		if !slices.ContainsFunc(cmds[i].Env, func(kv string) bool {
+			return strings.HasPrefix(kv, "GIT_INDEX_FILE="+gitDir)
+		}) {
+			t.Fatalf("%v should use a temporary index inside the git dir", calls[i])
+		}
+	}
+}
+
+func TestClient_SnapshotPaths_NoPaths(t *testing.T) {
+	c := &Client{execCommand: func(string, ...string) *exec.Cmd {
+		t.Fatal("no git command expected")
+		return nil
+	}}
+	if _, err := c.SnapshotPaths("refs/ggc/undo/x", "msg", nil); err == nil {
+		t.Fatal("expected error for empty path list")
+	}
+}
+
+func TestClient_RestorePathsFrom(t *testing.T) {
+	var got []string
+	c := &Client{execCommand: func(name string, args ...string) *exec.Cmd {
+		got = append([]string{name}, args...)
+		return helperCommand(t, "", nil)
+	}}
+	if err := c.RestorePathsFrom("abc", []string{"a.txt"}); err != nil {
+		t.Fatalf("RestorePathsFrom: %v", err)
+	}
+	want := []string{"git", "restore", "--source=abc", "--worktree", "--", "a.txt"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestClient_CreateBranchAt(t *testing.T) {
+	var got [][]string
+	c := &Client{execCommand: func(name string, args ...string) *exec.Cmd {
+		got = append(got, append([]string{name}, args...))
+		return helperCommand(t, "", nil)
+	}}
+	if err := c.CreateBranchAt("feature", "abc"); err != nil {
+		t.Fatalf("CreateBranchAt: %v", err)
+	}
+	want := []string{"git", "branch", "feature", "abc"}
+	if len(got) != 2 || !slices.Equal(got[1], want) {
+		t.Fatalf("got %v, want check-ref-format then %v", got, want)
+	}
+}
+
+func TestClient_DeleteRef_Error(t *testing.T) {
+	c := &Client{execCommand: func(string, ...string) *exec.Cmd {
+		return exec.Command("false")
+	}}
+	if err := c.DeleteRef("refs/ggc/undo/x"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestClient_RevParse(t *testing.T) {
+	c := &Client{execCommand: func(_ string, args ...string) *exec.Cmd {
+		if !slices.Equal(args, []string{"rev-parse", "--verify", "HEAD"}) {
+			t.Fatalf("unexpected args %v", args)
+		}
+		return fakeExecCommand("deadbeef\n")
+	}}
+	sha, err := c.RevParse("HEAD")
+	if err != nil || sha != "deadbeef" {
+		t.Fatalf("RevParse = %q, %v", sha, err)
+	}
+}
@@ -0,0 +1,50 @@
+package git
+
+import (
+	"os"
+	"strings"
+)
+
+// WIPCommitter saves the whole working tree as a commit and takes the
+// commit back, for ggc wip and ggc unwip.
+type WIPCommitter interface {
+	CommitWIP(message string) error
+	ResetMixed(commit string) error
+}
+
+// CommitWIP stages every change, untracked files included, and commits
+// it with message. Hooks are skipped: the commit is a save point, not a
+// change to check. When the commit fails, such as when signing does, the
+// index is put back as it was before.
+func (c *Client) CommitWIP(message string) error {
+	defer c.InvalidateStatusCache()
+	out, err := c.output(c.execCommand("git", "write-tree"))
+	if err != nil {
+		return NewOpError("wip", "git write-tree", err)
+	}
+	index := strings.TrimSpace(string(out))
+	if err := c.run(c.execCommand("git", "add", "--all")); err != nil {
+		return NewOpError("wip", "git add --all", err)
+	}
+	args := c.signArgs([]string{"commit", "--no-verify", "-m", message})
+	cmd := c.execCommand("git", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		_ = c.run(c.execCommand("git", "read-tree", index))
+		return NewOpError("wip", "git "+strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// ResetMixed moves HEAD to commit and unstages everything, leaving the
+// working tree as it is.
+func (c *Client) ResetMixed(commit string) error {
+	defer c.InvalidateStatusCache()
+	cmd := c.execCommand("git", "reset", "--mixed", "--quiet", commit)
+	cmd.Stderr = os.Stderr
+	if err := c.run(cmd); err != nil {
+		return NewOpError("reset mixed", "git reset --mixed "+commit, err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClient_CommitWIP(t *testing.T) {
+	var calls []string
+	c := &Client{
+		execCommand: func(_ string, args ...string) *exec.Cmd {
+			calls = append(calls, strings.Join(args, " "))
+			return exec.Command("true")
+		},
+	}
+	if err := c.CommitWIP("WIP: login form"); err != nil {
+		t.Fatalf("CommitWIP() error = %v", err)
+	}
+	want := []string{"write-tree", "add --all", "commit --no-verify -m WIP: login form"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
+
+func TestClient_CommitWIP_WriteTreeFails(t *testing.T) {
+	var calls int
+	c := &Client{
+		execCommand: func(_ string, _ ...string) *exec.Cmd {
+			calls++
+			return exec.Command("false")
+		},
+	}
+	if err := c.CommitWIP("WIP"); err == nil || calls != 1 {
+		t.Errorf("CommitWIP() error = %v after %d call(s); want an error before staging", err, calls)
+	}
+}
+
+func TestClient_CommitWIP_AddFails(t *testing.T) {
+	var calls []string
+	c := &Client{
+		execCommand: func(_ string, args ...string) *exec.Cmd {
+			calls = append(calls, args[0])
+			if args[0] == "write-tree" {
+				return exec.Command("echo", "4b825dc")
+			}
+			return exec.Command("false")
+		},
+	}
+	if err := c.CommitWIP("WIP"); err == nil || len(calls) != 2 {
+		t.Errorf("CommitWIP() error = %v after %q; want an error before committing", err, calls)
+	}
+}
+
+func TestClient_CommitWIP_CommitFails(t *testing.T) {
+	var calls []string
+	c := &Client{
+		execCommand: func(_ string, args ...string) *exec.Cmd {
+			calls = append(calls, strings.Join(args, " "))
+			switch args[0] {
+			case "write-tree":
+				return exec.Command("echo", "4b825dc")
+			case "commit":
+				return exec.Command("false")
+			}
+			return exec.Command("true")
+		},
+	}
+	if err := c.CommitWIP("WIP"); err == nil {
+		t.Fatal("CommitWIP() error = nil, want the commit's error")
+	}
+	want := []string{"write-tree", "add --all", "commit --no-verify -m WIP", "read-tree 4b825dc"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
+
+func TestClient_ResetMixed(t *testing.T) {
+	var got []string
+	c := &Client{
+		execCommand: func(_ string, args ...string) *exec.Cmd {
+			got = args
+			return exec.Command("true")
+		},
+	}
+	if err := c.ResetMixed("HEAD~1"); err != nil {
+		t.Fatalf("ResetMixed() error = %v", err)
+	}
+	if want := []string{"reset", "--mixed", "--quiet", "HEAD~1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("args = %v, want %v", got, want)
+	}
+}
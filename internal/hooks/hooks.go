@@ -0,0 +1,102 @@
+// Package hooks reads the declarative .ggc-hooks.yaml file and renders the
+// scripts ggc installs into .git/hooks.
+package hooks
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// FileName is the hooks file checked into the repository root.
+const FileName = ".ggc-hooks.yaml"
+
+// shimMarker identifies hook scripts written by Shim.
+const shimMarker = "# managed by ggc: runs the steps in " + FileName
+
+// StandardHooks are the client-side and server-side hooks git runs.
+var StandardHooks = []string{
+	"applypatch-msg", "pre-applypatch", "post-applypatch",
+	"pre-commit", "prepare-commit-msg", "commit-msg", "post-commit",
+	"pre-rebase", "post-checkout", "post-merge", "pre-push",
+	"pre-receive", "update", "post-receive", "post-update",
+	"push-to-checkout", "pre-auto-gc", "post-rewrite",
+}
+
+// Step is one command a hook runs. The hook's arguments are available as
+// "$1", "$2", ... and its standard input is passed to every step.
+type Step struct {
+	Name string `yaml:"name,omitempty"`
+	Run  string `yaml:"run"`
+}
+
+// Label names the step in output, falling back to its command.
+func (s Step) Label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Run
+}
+
+// File is the content of .ggc-hooks.yaml.
+type File struct {
+	Hooks map[string][]Step `yaml:"hooks"`
+}
+
+// Load reads and validates a hooks file.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f File
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := f.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// Validate checks that every hook is a git hook with runnable steps.
+func (f *File) Validate() error {
+	for _, name := range f.Names() {
+		if !slices.Contains(StandardHooks, name) {
+			return fmt.Errorf("hooks.%s: unknown git hook", name)
+		}
+		for i, step := range f.Hooks[name] {
+			if strings.TrimSpace(step.Run) == "" {
+				return fmt.Errorf("hooks.%s[%d].run: must not be empty", name, i)
+			}
+		}
+	}
+	return nil
+}
+
+// Names returns the declared hooks in sorted order.
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.Hooks))
+	for name := range f.Hooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Shim returns the hook script that hands hook to `ggc hook run`.
+func Shim(hook string) string {
+	return fmt.Sprintf("#!/bin/sh\n%s\nexec ggc hook run %s \"$@\"\n", shimMarker, hook)
+}
+
+// IsShim reports whether a hook script was written by Shim.
+func IsShim(script []byte) bool {
+	return bytes.Contains(script, []byte(shimMarker))
+}
@@ -0,0 +1,69 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string][]Step
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			content: "hooks:\n  pre-push:\n    - name: tests\n      run: go test ./...\n  commit-msg:\n    - run: ggc commit lint --file \"$1\"\n",
+			want: map[string][]Step{
+				"pre-push":   {{Name: "tests", Run: "go test ./..."}},
+				"commit-msg": {{Run: `ggc commit lint --file "$1"`}},
+			},
+		},
+		{name: "unknown hook", content: "hooks:\n  pre-comit:\n    - run: true\n", wantErr: "hooks.pre-comit: unknown git hook"},
+		{name: "empty step", content: "hooks:\n  pre-commit:\n    - name: nothing\n", wantErr: "hooks.pre-commit[0].run"},
+		{name: "unknown field", content: "hooks:\n  pre-commit:\n    - command: true\n", wantErr: "field command not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), FileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			f, err := Load(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(f.Hooks, tt.want) {
+				t.Errorf("Load() = %v, want %v", f.Hooks, tt.want)
+			}
+			if got := f.Names(); !reflect.DeepEqual(got, []string{"commit-msg", "pre-push"}) {
+				t.Errorf("Names() = %v", got)
+			}
+		})
+	}
+}
+
+func TestShim(t *testing.T) {
+	script := Shim("pre-push")
+	if !strings.HasPrefix(script, "#!/bin/sh\n") || !strings.Contains(script, `exec ggc hook run pre-push "$@"`) {
+		t.Errorf("unexpected shim:\n%s", script)
+	}
+	if !IsShim([]byte(script)) || IsShim([]byte("#!/bin/sh\nexit 0\n")) {
+		t.Error("IsShim() should only recognise scripts written by Shim")
+	}
+	for _, tmpl := range Templates {
+		if _, ok := FindTemplate(tmpl.Name); !ok || !strings.HasPrefix(tmpl.Script, "#!/bin/sh\n") {
+			t.Errorf("template %q is not usable", tmpl.Name)
+		}
+	}
+}
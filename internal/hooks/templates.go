@@ -0,0 +1,60 @@
+package hooks
+
+// Template is a ready-made hook script.
+type Template struct {
+	Name    string
+	Hook    string
+	Summary string
+	Script  string
+}
+
+// Templates are the scripts `ggc hook install --template` offers.
+var Templates = []Template{
+	{
+		Name:    "commit-lint",
+		Hook:    "commit-msg",
+		Summary: "Reject commit messages that fail ggc commit lint",
+		Script: `#!/bin/sh
+# commit-msg: check the message with ggc commit lint
+exec ggc commit lint --file "$1"
+`,
+	},
+	{
+		Name:    "pre-push-test",
+		Hook:    "pre-push",
+		Summary: "Run the project's tests before pushing",
+		Script: `#!/bin/sh
+# pre-push: run the project's tests
+if [ -f go.mod ]; then
+    exec go test ./...
+elif [ -f package.json ]; then
+    exec npm test
+elif [ -f Cargo.toml ]; then
+    exec cargo test
+elif [ -f Makefile ] && grep -q '^test:' Makefile; then
+    exec make test
+fi
+echo "pre-push: no test runner found, skipping"
+exit 0
+`,
+	},
+	{
+		Name:    "pre-commit-whitespace",
+		Hook:    "pre-commit",
+		Summary: "Reject staged changes with whitespace errors",
+		Script: `#!/bin/sh
+# pre-commit: reject whitespace errors in the staged changes
+exec git diff --cached --check
+`,
+	},
+}
+
+// FindTemplate returns the template called name.
+func FindTemplate(name string) (Template, bool) {
+	for _, t := range Templates {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
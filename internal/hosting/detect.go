@@ -0,0 +1,99 @@
+package hosting
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Kinds lists the supported services in detection order.
+var Kinds = []Kind{GitHub, GitLab, Gitea}
+
+// ParseRemoteURL extracts the host and repository from a git remote URL.
+// It accepts HTTPS and ssh:// URLs as well as the scp-like
+// "git@host:owner/repo.git" form. Everything before the last path segment
+// is the owner, so GitLab subgroups are kept.
+func ParseRemoteURL(raw string) (host string, repo Repo, err error) {
+	raw = strings.TrimSpace(raw)
+	var path string
+	switch {
+	case strings.Contains(raw, "://"):
+		u, perr := url.Parse(raw)
+		if perr != nil {
+			return "", Repo{}, fmt.Errorf("invalid remote URL %q: %w", raw, perr)
+		}
+		host, path = u.Hostname(), u.Path
+	case strings.Contains(raw, ":"):
+		// scp-like syntax: [user@]host:path
+		hostPart, p, _ := strings.Cut(raw, ":")
+		if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+			hostPart = hostPart[i+1:]
+		}
+		host, path = hostPart, p
+	default:
+		return "", Repo{}, fmt.Errorf("remote URL %q is not a hosted repository", raw)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	i := strings.LastIndex(path, "/")
+	if i <= 0 || host == "" || i == len(path)-1 {
+		return "", Repo{}, fmt.Errorf("cannot find owner/repo in remote URL %q", raw)
+	}
+	return host, Repo{Owner: path[:i], Name: path[i+1:]}, nil
+}
+
+// publicHosts are the hosting services recognized without configuration.
+var publicHosts = map[string]Kind{
+	"github.com":     GitHub,
+	"www.github.com": GitHub,
+	"gitlab.com":     GitLab,
+	"codeberg.org":   Gitea,
+}
+
+// Detect works out which service runs on host: one whose configured API
+// URL is on that host, or one of the public services. Other hosts are
+// refused rather than guessed from their name, since the service's token
+// is sent to whatever host is detected, and anyone can name a host
+// github.example.
+func Detect(host string, apiURLs map[Kind]string) (Kind, error) {
+	host = strings.ToLower(host)
+	for _, kind := range Kinds {
+		if u, err := url.Parse(apiURLs[kind]); err == nil && strings.EqualFold(u.Hostname(), host) {
+			return kind, nil
+		}
+	}
+	if kind, ok := publicHosts[host]; ok {
+		return kind, nil
+	}
+	return "", fmt.Errorf("cannot tell which hosting service runs on %s; set integration.<github|gitlab|gitea>.api-url to an API on that host", host)
+}
+
+// DefaultAPIURL returns the usual API endpoint of kind on host.
+func DefaultAPIURL(kind Kind, host string) string {
+	switch kind {
+	case GitHub:
+		if host == "" || host == "github.com" || host == "www.github.com" {
+			return DefaultGitHubAPIURL
+		}
+		return "https://" + host + "/api/v3"
+	case GitLab:
+		return "https://" + host + "/api/v4"
+	case Gitea:
+		return "https://" + host + "/api/v1"
+	}
+	return ""
+}
+
+// ConvertURL rewrites a remote URL to the SSH form git@host:owner/repo.git,
+// or to https://host/owner/repo.git when ssh is false. Credentials and
+// ports in raw are dropped, since they belong to the other protocol.
+func ConvertURL(raw string, ssh bool) (string, error) {
+	host, repo, err := ParseRemoteURL(raw)
+	if err != nil {
+		return "", err
+	}
+	if ssh {
+		return "git@" + host + ":" + repo.String() + ".git", nil
+	}
+	return "https://" + host + "/" + repo.String() + ".git", nil
+}
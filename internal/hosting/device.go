@@ -0,0 +1,160 @@
+package hosting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/httpclient"
+)
+
+// DefaultGitHubWebURL is the OAuth endpoint host of github.com.
+const DefaultGitHubWebURL = "https://github.com"
+
+// DeviceCode is the first step of the OAuth device flow: the user enters
+// UserCode at VerificationURI while the client polls for a token.
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// ErrDeviceFlowDenied is returned when the user rejects the authorization
+// or the device code expires before it is approved.
+var ErrDeviceFlowDenied = errors.New("device authorization was denied or expired")
+
+// DeviceFlow signs in to GitHub through an OAuth app without a browser
+// redirect.
+type DeviceFlow struct {
+	webURL     string
+	clientID   string
+	httpClient *http.Client
+	clientErr  error
+	sleep      func(context.Context, time.Duration) error
+}
+
+// NewDeviceFlow returns a device flow for the OAuth app clientID on the
+// GitHub server at webURL (DefaultGitHubWebURL when empty), reached with
+// the proxy and CA settings of httpclient.
+func NewDeviceFlow(webURL, clientID string) *DeviceFlow {
+	if webURL == "" {
+		webURL = DefaultGitHubWebURL
+	}
+	httpClient, err := httpclient.Default(30 * time.Second)
+	return &DeviceFlow{
+		webURL:     strings.TrimRight(webURL, "/"),
+		clientID:   clientID,
+		httpClient: httpClient,
+		clientErr:  err,
+		sleep:      sleepContext,
+	}
+}
+
+// WithHTTPClient replaces the HTTP client, mainly for tests.
+func (f *DeviceFlow) WithHTTPClient(hc *http.Client) *DeviceFlow {
+	f.httpClient, f.clientErr = hc, nil
+	return f
+}
+
+// RequestCode starts the flow for the given OAuth scopes.
+func (f *DeviceFlow) RequestCode(ctx context.Context, scopes ...string) (*DeviceCode, error) {
+	form := url.Values{"client_id": {f.clientID}, "scope": {strings.Join(scopes, " ")}}
+	var code DeviceCode
+	if err := f.post(ctx, "/login/device/code", form, &code); err != nil {
+		return nil, err
+	}
+	if code.DeviceCode == "" {
+		return nil, fmt.Errorf("device code request returned no code")
+	}
+	return &code, nil
+}
+
+// PollToken waits until the user approves code and returns the access
+// token, honoring the polling interval and slow_down responses.
+func (f *DeviceFlow) PollToken(ctx context.Context, code *DeviceCode) (string, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if code.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(code.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+	form := url.Values{
+		"client_id":   {f.clientID},
+		"device_code": {code.DeviceCode},
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+	}
+	for {
+		if err := f.sleep(ctx, interval); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return "", ErrDeviceFlowDenied
+			}
+			return "", err
+		}
+		var resp struct {
+			AccessToken string `json:"access_token"`
+			Error       string `json:"error"`
+			Interval    int    `json:"interval"`
+		}
+		if err := f.post(ctx, "/login/oauth/access_token", form, &resp); err != nil {
+			return "", err
+		}
+		switch resp.Error {
+		case "":
+			if resp.AccessToken == "" {
+				return "", fmt.Errorf("token response contained no access token")
+			}
+			return resp.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			if resp.Interval > 0 {
+				interval = time.Duration(resp.Interval) * time.Second
+			} else {
+				interval += 5 * time.Second
+			}
+		case "expired_token", "access_denied":
+			return "", ErrDeviceFlowDenied
+		default:
+			return "", fmt.Errorf("device flow: %s", resp.Error)
+		}
+	}
+}
+
+func (f *DeviceFlow) post(ctx context.Context, path string, form url.Values, out any) error {
+	if f.clientErr != nil {
+		return f.clientErr
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.webURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "ggc")
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return decodeAPIError(GitHub, resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,149 @@
+package hosting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultGiteaAPIURL is the API endpoint of Codeberg, the largest public
+// Gitea instance.
+const DefaultGiteaAPIURL = "https://codeberg.org/api/v1"
+
+// giteaDraftPrefix marks a work-in-progress pull request; Gitea has no
+// separate draft flag.
+const giteaDraftPrefix = "WIP: "
+
+type gitea struct {
+	api  *restClient
+	repo Repo
+}
+
+func newGitea(apiURL, token string, repo Repo) *gitea {
+	if apiURL == "" {
+		apiURL = DefaultGiteaAPIURL
+	}
+	headers := map[string]string{}
+	if token != "" {
+		headers["Authorization"] = "token " + token
+	}
+	return &gitea{api: newRESTClient(Gitea, apiURL, headers), repo: repo}
+}
+
+type giteaPull struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	Merged  bool   `json:"merged"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Head struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (p giteaPull) convert() PullRequest {
+	state := p.State
+	if p.Merged {
+		state = "merged"
+	}
+	return PullRequest{
+		Number: p.Number,
+		Title:  p.Title,
+		Body:   p.Body,
+		State:  state,
+		Draft:  strings.HasPrefix(p.Title, giteaDraftPrefix),
+		URL:    p.HTMLURL,
+		Author: p.User.Login,
+		Head:   p.Head.Ref,
+		Base:   p.Base.Ref,
+	}
+}
+
+func (g *gitea) Kind() Kind { return Gitea }
+func (g *gitea) Repo() Repo { return g.repo }
+
+func (g *gitea) path(rest string) string {
+	p := "/repos/" + url.PathEscape(g.repo.Owner) + "/" + url.PathEscape(g.repo.Name)
+	if rest != "" {
+		p += "/" + rest
+	}
+	return p
+}
+
+func (g *gitea) CreatePullRequest(ctx context.Context, pr NewPullRequest) (*PullRequest, error) {
+	title := pr.Title
+	if pr.Draft && !strings.HasPrefix(title, giteaDraftPrefix) {
+		title = giteaDraftPrefix + title
+	}
+	in := map[string]any{"title": title, "head": pr.Head, "base": pr.Base}
+	if pr.Body != "" {
+		in["body"] = pr.Body
+	}
+	var out giteaPull
+	if err := g.api.do(ctx, http.MethodPost, g.path("pulls"), in, &out); err != nil {
+		return nil, err
+	}
+	res := out.convert()
+	return &res, nil
+}
+
+func (g *gitea) ListPullRequests(ctx context.Context, state string) ([]PullRequest, error) {
+	q := url.Values{"limit": {"50"}, "sort": {"newest"}}
+	if state != "" {
+		q.Set("state", state)
+	}
+	var out []giteaPull
+	if err := g.api.do(ctx, http.MethodGet, g.path("pulls")+"?"+q.Encode(), nil, &out); err != nil {
+		return nil, err
+	}
+	prs := make([]PullRequest, len(out))
+	for i := range out {
+		prs[i] = out[i].convert()
+	}
+	return prs, nil
+}
+
+func (g *gitea) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	var out giteaPull
+	if err := g.api.do(ctx, http.MethodGet, g.path(fmt.Sprintf("pulls/%d", number)), nil, &out); err != nil {
+		return nil, err
+	}
+	res := out.convert()
+	return &res, nil
+}
+
+func (g *gitea) DefaultBranch(ctx context.Context) (string, error) {
+	var out struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, g.path(""), nil, &out); err != nil {
+		return "", err
+	}
+	return out.DefaultBranch, nil
+}
+
+func (g *gitea) HeadRefspec(number int) string {
+	return fmt.Sprintf("pull/%d/head", number)
+}
+
+func (g *gitea) CreateRelease(ctx context.Context, rel NewRelease) (*Release, error) {
+	in := map[string]any{"tag_name": rel.Tag, "name": rel.Name, "body": rel.Body, "prerelease": rel.Prerelease}
+	var out struct {
+		TagName string `json:"tag_name"`
+		Name    string `json:"name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := g.api.do(ctx, http.MethodPost, g.path("releases"), in, &out); err != nil {
+		return nil, err
+	}
+	return &Release{Tag: out.TagName, Name: out.Name, URL: out.HTMLURL}, nil
+}
@@ -0,0 +1,143 @@
+package hosting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// DefaultGitHubAPIURL is the API endpoint of github.com.
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+type gitHub struct {
+	api  *restClient
+	repo Repo
+}
+
+func newGitHub(apiURL, token string, repo Repo) *gitHub {
+	if apiURL == "" {
+		apiURL = DefaultGitHubAPIURL
+	}
+	headers := map[string]string{
+		"Accept":               "application/vnd.github+json",
+		"X-GitHub-Api-Version": "2022-11-28",
+	}
+	if token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+	return &gitHub{api: newRESTClient(GitHub, apiURL, headers), repo: repo}
+}
+
+type gitHubPull struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	Draft   bool   `json:"draft"`
+	Merged  bool   `json:"merged"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Head struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (p gitHubPull) convert() PullRequest {
+	state := p.State
+	if p.Merged {
+		state = "merged"
+	}
+	return PullRequest{
+		Number: p.Number,
+		Title:  p.Title,
+		Body:   p.Body,
+		State:  state,
+		Draft:  p.Draft,
+		URL:    p.HTMLURL,
+		Author: p.User.Login,
+		Head:   p.Head.Ref,
+		Base:   p.Base.Ref,
+	}
+}
+
+func (g *gitHub) Kind() Kind { return GitHub }
+func (g *gitHub) Repo() Repo { return g.repo }
+
+func (g *gitHub) path(rest string) string {
+	p := "/repos/" + url.PathEscape(g.repo.Owner) + "/" + url.PathEscape(g.repo.Name)
+	if rest != "" {
+		p += "/" + rest
+	}
+	return p
+}
+
+func (g *gitHub) CreatePullRequest(ctx context.Context, pr NewPullRequest) (*PullRequest, error) {
+	in := map[string]any{"title": pr.Title, "head": pr.Head, "base": pr.Base, "draft": pr.Draft}
+	if pr.Body != "" {
+		in["body"] = pr.Body
+	}
+	var out gitHubPull
+	if err := g.api.do(ctx, http.MethodPost, g.path("pulls"), in, &out); err != nil {
+		return nil, err
+	}
+	res := out.convert()
+	return &res, nil
+}
+
+func (g *gitHub) ListPullRequests(ctx context.Context, state string) ([]PullRequest, error) {
+	q := url.Values{"per_page": {"50"}}
+	if state != "" {
+		q.Set("state", state)
+	}
+	var out []gitHubPull
+	if err := g.api.do(ctx, http.MethodGet, g.path("pulls")+"?"+q.Encode(), nil, &out); err != nil {
+		return nil, err
+	}
+	prs := make([]PullRequest, len(out))
+	for i := range out {
+		prs[i] = out[i].convert()
+	}
+	return prs, nil
+}
+
+func (g *gitHub) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	var out gitHubPull
+	if err := g.api.do(ctx, http.MethodGet, g.path(fmt.Sprintf("pulls/%d", number)), nil, &out); err != nil {
+		return nil, err
+	}
+	res := out.convert()
+	return &res, nil
+}
+
+func (g *gitHub) DefaultBranch(ctx context.Context) (string, error) {
+	var out struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, g.path(""), nil, &out); err != nil {
+		return "", err
+	}
+	return out.DefaultBranch, nil
+}
+
+func (g *gitHub) HeadRefspec(number int) string {
+	return fmt.Sprintf("pull/%d/head", number)
+}
+
+func (g *gitHub) CreateRelease(ctx context.Context, rel NewRelease) (*Release, error) {
+	in := map[string]any{"tag_name": rel.Tag, "name": rel.Name, "body": rel.Body, "prerelease": rel.Prerelease}
+	var out struct {
+		TagName string `json:"tag_name"`
+		Name    string `json:"name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := g.api.do(ctx, http.MethodPost, g.path("releases"), in, &out); err != nil {
+		return nil, err
+	}
+	return &Release{Tag: out.TagName, Name: out.Name, URL: out.HTMLURL}, nil
+}
@@ -0,0 +1,154 @@
+package hosting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultGitLabAPIURL is the API endpoint of gitlab.com.
+const DefaultGitLabAPIURL = "https://gitlab.com/api/v4"
+
+type gitLab struct {
+	api  *restClient
+	repo Repo
+}
+
+func newGitLab(apiURL, token string, repo Repo) *gitLab {
+	if apiURL == "" {
+		apiURL = DefaultGitLabAPIURL
+	}
+	headers := map[string]string{}
+	if token != "" {
+		headers["PRIVATE-TOKEN"] = token
+	}
+	return &gitLab{api: newRESTClient(GitLab, apiURL, headers), repo: repo}
+}
+
+type gitLabMergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	State        string `json:"state"`
+	Draft        bool   `json:"draft"`
+	WebURL       string `json:"web_url"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	Author       struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+func (m gitLabMergeRequest) convert() PullRequest {
+	state := m.State
+	switch state {
+	case "opened", "locked":
+		state = "open"
+	}
+	return PullRequest{
+		Number: m.IID,
+		Title:  m.Title,
+		Body:   m.Description,
+		State:  state,
+		Draft:  m.Draft,
+		URL:    m.WebURL,
+		Author: m.Author.Username,
+		Head:   m.SourceBranch,
+		Base:   m.TargetBranch,
+	}
+}
+
+func (g *gitLab) Kind() Kind { return GitLab }
+func (g *gitLab) Repo() Repo { return g.repo }
+
+// path addresses the project by its URL-encoded full path, which GitLab
+// accepts in place of the numeric ID.
+func (g *gitLab) path(rest string) string {
+	p := "/projects/" + url.PathEscape(g.repo.String())
+	if rest != "" {
+		p += "/" + rest
+	}
+	return p
+}
+
+func (g *gitLab) CreatePullRequest(ctx context.Context, pr NewPullRequest) (*PullRequest, error) {
+	title := pr.Title
+	if pr.Draft && !strings.HasPrefix(title, "Draft:") {
+		title = "Draft: " + title
+	}
+	in := map[string]any{
+		"source_branch": pr.Head,
+		"target_branch": pr.Base,
+		"title":         title,
+	}
+	if pr.Body != "" {
+		in["description"] = pr.Body
+	}
+	var out gitLabMergeRequest
+	if err := g.api.do(ctx, http.MethodPost, g.path("merge_requests"), in, &out); err != nil {
+		return nil, err
+	}
+	res := out.convert()
+	return &res, nil
+}
+
+func (g *gitLab) ListPullRequests(ctx context.Context, state string) ([]PullRequest, error) {
+	q := url.Values{"per_page": {"50"}, "order_by": {"created_at"}, "sort": {"desc"}}
+	switch state {
+	case "open":
+		q.Set("state", "opened")
+	case "closed", "all":
+		q.Set("state", state)
+	}
+	var out []gitLabMergeRequest
+	if err := g.api.do(ctx, http.MethodGet, g.path("merge_requests")+"?"+q.Encode(), nil, &out); err != nil {
+		return nil, err
+	}
+	prs := make([]PullRequest, len(out))
+	for i := range out {
+		prs[i] = out[i].convert()
+	}
+	return prs, nil
+}
+
+func (g *gitLab) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	var out gitLabMergeRequest
+	if err := g.api.do(ctx, http.MethodGet, g.path(fmt.Sprintf("merge_requests/%d", number)), nil, &out); err != nil {
+		return nil, err
+	}
+	res := out.convert()
+	return &res, nil
+}
+
+func (g *gitLab) DefaultBranch(ctx context.Context) (string, error) {
+	var out struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, g.path(""), nil, &out); err != nil {
+		return "", err
+	}
+	return out.DefaultBranch, nil
+}
+
+func (g *gitLab) HeadRefspec(number int) string {
+	return fmt.Sprintf("merge-requests/%d/head", number)
+}
+
+// CreateRelease publishes a release. GitLab has no pre-releases, so
+// rel.Prerelease is ignored.
+func (g *gitLab) CreateRelease(ctx context.Context, rel NewRelease) (*Release, error) {
+	in := map[string]any{"tag_name": rel.Tag, "name": rel.Name, "description": rel.Body}
+	var out struct {
+		TagName string `json:"tag_name"`
+		Name    string `json:"name"`
+		Links   struct {
+			Self string `json:"self"`
+		} `json:"_links"`
+	}
+	if err := g.api.do(ctx, http.MethodPost, g.path("releases"), in, &out); err != nil {
+		return nil, err
+	}
+	return &Release{Tag: out.TagName, Name: out.Name, URL: out.Links.Self}, nil
+}
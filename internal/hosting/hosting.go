@@ -0,0 +1,292 @@
+// Package hosting talks to the code hosting service behind a git remote:
+// GitHub, GitLab or Gitea. Each service is a Provider with the same small
+// set of pull request operations, so commands do not care which one a
+// repository lives on.
+package hosting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/httpclient"
+)
+
+// Kind identifies a hosting service.
+type Kind string
+
+// Supported hosting services.
+const (
+	GitHub Kind = "github"
+	GitLab Kind = "gitlab"
+	Gitea  Kind = "gitea"
+)
+
+// String returns the display name of k.
+func (k Kind) String() string {
+	switch k {
+	case GitHub:
+		return "GitHub"
+	case GitLab:
+		return "GitLab"
+	case Gitea:
+		return "Gitea"
+	default:
+		return string(k)
+	}
+}
+
+// Noun is what k calls a pull request.
+func (k Kind) Noun() string {
+	if k == GitLab {
+		return "merge request"
+	}
+	return "pull request"
+}
+
+// Ref formats a pull request number the way k does: !7 on GitLab, #7
+// elsewhere.
+func (k Kind) Ref(number int) string {
+	if k == GitLab {
+		return fmt.Sprintf("!%d", number)
+	}
+	return fmt.Sprintf("#%d", number)
+}
+
+// Repo identifies a repository. On GitLab, Owner may contain subgroups
+// ("group/subgroup").
+type Repo struct {
+	Owner string
+	Name  string
+}
+
+// String returns "owner/name".
+func (r Repo) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// PullRequest is the subset of a pull (or merge) request that ggc shows.
+type PullRequest struct {
+	Number int
+	Title  string
+	Body   string
+	State  string // "open", "closed" or "merged"
+	Draft  bool
+	URL    string
+	Author string
+	Head   string // source branch
+	Base   string // target branch
+}
+
+// NewPullRequest describes a pull request to open.
+type NewPullRequest struct {
+	Title string
+	Head  string
+	Base  string
+	Body  string
+	Draft bool
+}
+
+// NewRelease describes a release to publish for an existing tag.
+type NewRelease struct {
+	Tag        string
+	Name       string
+	Body       string
+	Prerelease bool
+}
+
+// Release is a published release.
+type Release struct {
+	Tag  string
+	Name string
+	URL  string
+}
+
+// Provider is one hosting service bound to one repository.
+type Provider interface {
+	Kind() Kind
+	Repo() Repo
+	CreatePullRequest(ctx context.Context, pr NewPullRequest) (*PullRequest, error)
+	// ListPullRequests returns pull requests in state ("open", "closed"
+	// or "all"), newest first.
+	ListPullRequests(ctx context.Context, state string) ([]PullRequest, error)
+	GetPullRequest(ctx context.Context, number int) (*PullRequest, error)
+	DefaultBranch(ctx context.Context) (string, error)
+	// HeadRefspec is the remote ref holding the head commit of a pull
+	// request, for `git fetch`.
+	HeadRefspec(number int) string
+	// CreateRelease publishes a release for a tag that has been pushed.
+	CreateRelease(ctx context.Context, rel NewRelease) (*Release, error)
+}
+
+// New returns the provider of kind for repo, calling the API at apiURL
+// (the service's public endpoint when empty) with token.
+func New(kind Kind, apiURL, token string, repo Repo) (Provider, error) {
+	switch kind {
+	case GitHub:
+		return newGitHub(apiURL, token, repo), nil
+	case GitLab:
+		return newGitLab(apiURL, token, repo), nil
+	case Gitea:
+		return newGitea(apiURL, token, repo), nil
+	default:
+		return nil, fmt.Errorf("unsupported hosting service %q", kind)
+	}
+}
+
+// APIError is a non-2xx response from a hosting API.
+type APIError struct {
+	Kind       Kind
+	StatusCode int
+	Message    string
+	Errors     []string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s api: %d %s", strings.ToLower(e.Kind.String()), e.StatusCode, e.Message)
+	if len(e.Errors) > 0 {
+		msg += " (" + strings.Join(e.Errors, "; ") + ")"
+	}
+	return msg
+}
+
+// restClient sends JSON requests to one API.
+type restClient struct {
+	kind       Kind
+	baseURL    string
+	httpClient *http.Client
+	clientErr  error // why the proxy or CA settings cannot be used
+	headers    map[string]string
+}
+
+// newRESTClient returns a client for the API at baseURL that goes through
+// the proxy and trusts the certificates set with httpclient.SetDefaults.
+// When those settings are unusable, every request fails with the reason.
+func newRESTClient(kind Kind, baseURL string, headers map[string]string) *restClient {
+	httpClient, err := httpclient.Default(30 * time.Second)
+	return &restClient{
+		kind:       kind,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+		clientErr:  err,
+		headers:    headers,
+	}
+}
+
+// do sends in as JSON (when non-nil) and decodes a JSON response into out.
+func (c *restClient) do(ctx context.Context, method, path string, in, out any) error {
+	_, err := c.send(ctx, method, path, in, out)
+	return err
+}
+
+// send is do that also returns the response headers, which carry token
+// details on some services.
+func (c *restClient) send(ctx context.Context, method, path string, in, out any) (http.Header, error) {
+	if c.clientErr != nil {
+		return nil, c.clientErr
+	}
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "ggc")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.Header, decodeAPIError(c.kind, resp)
+	}
+	if out == nil {
+		return resp.Header, nil
+	}
+	return resp.Header, json.NewDecoder(resp.Body).Decode(out)
+}
+
+// decodeAPIError reads the error body of any of the supported services.
+// GitHub sends {"message", "errors": [...]}, Gitea {"message"} and GitLab
+// a "message" that may be a string, a list or a map of field errors.
+func decodeAPIError(kind Kind, resp *http.Response) error {
+	apiErr := &APIError{Kind: kind, StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	var payload struct {
+		Message any `json:"message"`
+		Error   any `json:"error"`
+		Errors  []struct {
+			Message string `json:"message"`
+			Code    string `json:"code"`
+			Field   string `json:"field"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&payload); err != nil {
+		return apiErr
+	}
+	for _, raw := range []any{payload.Message, payload.Error} {
+		switch v := raw.(type) {
+		case string:
+			if v != "" {
+				apiErr.Message = v
+			}
+		case []any:
+			apiErr.Errors = append(apiErr.Errors, flattenMessages("", v)...)
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				apiErr.Errors = append(apiErr.Errors, flattenMessages(k+" ", v[k])...)
+			}
+		}
+	}
+	for _, e := range payload.Errors {
+		switch {
+		case e.Message != "":
+			apiErr.Errors = append(apiErr.Errors, e.Message)
+		case e.Field != "":
+			apiErr.Errors = append(apiErr.Errors, e.Field+" "+e.Code)
+		case e.Code != "":
+			apiErr.Errors = append(apiErr.Errors, e.Code)
+		}
+	}
+	return apiErr
+}
+
+func flattenMessages(prefix string, v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{prefix + v}
+	case []any:
+		var out []string
+		for _, item := range v {
+			out = append(out, flattenMessages(prefix, item)...)
+		}
+		return out
+	default:
+		return []string{prefix + fmt.Sprint(v)}
+	}
+}
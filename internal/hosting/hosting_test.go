@@ -0,0 +1,454 @@
+package hosting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/httpclient"
+)
+
+func newTestProvider(t *testing.T, kind Kind, handler http.HandlerFunc) Provider {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	p, err := New(kind, srv.URL+"/", "secret", Repo{"octo", "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestGitHub_CreatePullRequest(t *testing.T) {
+	var got map[string]any
+	p := newTestProvider(t, GitHub, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/octo/hello/pulls" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("Authorization = %q", auth)
+		}
+		if accept := r.Header.Get("Accept"); accept != "application/vnd.github+json" {
+			t.Errorf("Accept = %q", accept)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number":7,"title":"feat: x","html_url":"https://github.com/octo/hello/pull/7","head":{"ref":"feature"},"user":{"login":"octo"}}`))
+	})
+
+	pr, err := p.CreatePullRequest(context.Background(), NewPullRequest{Title: "feat: x", Head: "feature", Base: "main", Body: "details", Draft: true})
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	if pr.Number != 7 || pr.URL != "https://github.com/octo/hello/pull/7" || pr.Head != "feature" || pr.Author != "octo" {
+		t.Errorf("unexpected pull request %+v", pr)
+	}
+	if got["head"] != "feature" || got["base"] != "main" || got["body"] != "details" || got["draft"] != true {
+		t.Errorf("unexpected request body %v", got)
+	}
+}
+
+func TestGitHub_ListGetDefaultBranch(t *testing.T) {
+	p := newTestProvider(t, GitHub, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/hello/pulls":
+			if s := r.URL.Query().Get("state"); s != "closed" {
+				t.Errorf("state = %q", s)
+			}
+			_, _ = w.Write([]byte(`[{"number":1,"title":"a","state":"closed","merged":true},{"number":2,"title":"b"}]`))
+		case "/repos/octo/hello/pulls/2":
+			_, _ = w.Write([]byte(`{"number":2,"title":"b","head":{"ref":"topic"}}`))
+		case "/repos/octo/hello":
+			_, _ = w.Write([]byte(`{"default_branch":"trunk"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	ctx := context.Background()
+	prs, err := p.ListPullRequests(ctx, "closed")
+	if err != nil || len(prs) != 2 || prs[0].State != "merged" || prs[1].Title != "b" {
+		t.Fatalf("ListPullRequests = %+v, %v", prs, err)
+	}
+	pr, err := p.GetPullRequest(ctx, 2)
+	if err != nil || pr.Head != "topic" {
+		t.Fatalf("GetPullRequest = %+v, %v", pr, err)
+	}
+	branch, err := p.DefaultBranch(ctx)
+	if err != nil || branch != "trunk" {
+		t.Fatalf("DefaultBranch = %q, %v", branch, err)
+	}
+	if ref := p.HeadRefspec(2); ref != "pull/2/head" {
+		t.Errorf("HeadRefspec = %q", ref)
+	}
+}
+
+func TestGitLab_MergeRequests(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tok := r.Header.Get("PRIVATE-TOKEN"); tok != "secret" {
+			t.Errorf("PRIVATE-TOKEN = %q", tok)
+		}
+		switch r.URL.EscapedPath() {
+		case "/projects/group%2Fsub%2Fapp/merge_requests":
+			if r.Method == http.MethodPost {
+				_ = json.NewDecoder(r.Body).Decode(&got)
+				_, _ = w.Write([]byte(`{"iid":3,"title":"Draft: feat: y","state":"opened","draft":true,"web_url":"https://gitlab.com/group/sub/app/-/merge_requests/3","source_branch":"y","target_branch":"main","author":{"username":"dev"}}`))
+				return
+			}
+			if s := r.URL.Query().Get("state"); s != "opened" {
+				t.Errorf("state = %q", s)
+			}
+			_, _ = w.Write([]byte(`[{"iid":3,"title":"t","state":"opened"},{"iid":2,"title":"u","state":"merged"}]`))
+		case "/projects/group%2Fsub%2Fapp":
+			_, _ = w.Write([]byte(`{"default_branch":"main"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := New(GitLab, srv.URL, "secret", Repo{"group/sub", "app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	pr, err := p.CreatePullRequest(ctx, NewPullRequest{Title: "feat: y", Head: "y", Base: "main", Draft: true})
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	if got["title"] != "Draft: feat: y" || got["source_branch"] != "y" || got["target_branch"] != "main" {
+		t.Errorf("unexpected request body %v", got)
+	}
+	if pr.Number != 3 || pr.State != "open" || !pr.Draft || pr.Author != "dev" || pr.Base != "main" {
+		t.Errorf("unexpected merge request %+v", pr)
+	}
+
+	prs, err := p.ListPullRequests(ctx, "open")
+	if err != nil || len(prs) != 2 || prs[1].State != "merged" {
+		t.Fatalf("ListPullRequests = %+v, %v", prs, err)
+	}
+	if branch, err := p.DefaultBranch(ctx); err != nil || branch != "main" {
+		t.Fatalf("DefaultBranch = %q, %v", branch, err)
+	}
+	if ref := p.HeadRefspec(3); ref != "merge-requests/3/head" {
+		t.Errorf("HeadRefspec = %q", ref)
+	}
+}
+
+func TestGitea_PullRequests(t *testing.T) {
+	var got map[string]any
+	p := newTestProvider(t, Gitea, func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "token secret" {
+			t.Errorf("Authorization = %q", auth)
+		}
+		switch r.URL.Path {
+		case "/repos/octo/hello/pulls":
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(`{"number":9,"title":"WIP: fix: z","state":"open","html_url":"https://codeberg.org/octo/hello/pulls/9","head":{"ref":"z"},"base":{"ref":"main"}}`))
+		case "/repos/octo/hello/pulls/9":
+			_, _ = w.Write([]byte(`{"number":9,"title":"fix: z","state":"closed","merged":true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	ctx := context.Background()
+	pr, err := p.CreatePullRequest(ctx, NewPullRequest{Title: "fix: z", Head: "z", Base: "main", Draft: true})
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	if got["title"] != "WIP: fix: z" || !pr.Draft || pr.Number != 9 {
+		t.Errorf("request %v, response %+v", got, pr)
+	}
+	pr, err = p.GetPullRequest(ctx, 9)
+	if err != nil || pr.State != "merged" {
+		t.Fatalf("GetPullRequest = %+v, %v", pr, err)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		kind Kind
+		body string
+		want string
+	}{
+		{GitHub, `{"message":"Validation Failed","errors":[{"message":"A pull request already exists for octo:feature."}]}`, "already exists"},
+		{GitLab, `{"message":["Another open merge request already exists for this source branch"]}`, "Another open merge request"},
+		{GitLab, `{"message":{"title":["can't be blank"]}}`, "title can't be blank"},
+		{Gitea, `{"message":"pull request already exists for these targets"}`, "already exists"},
+	}
+	for _, tt := range tests {
+		p := newTestProvider(t, tt.kind, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(tt.body))
+		})
+		_, err := p.CreatePullRequest(context.Background(), NewPullRequest{})
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%s: expected *APIError, got %v", tt.kind, err)
+		}
+		if apiErr.StatusCode != 422 || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: unexpected error %v", tt.kind, err)
+		}
+	}
+}
+
+func TestNew_Unsupported(t *testing.T) {
+	if _, err := New(Kind("bitbucket"), "", "", Repo{}); err == nil {
+		t.Error("expected error for unsupported kind")
+	}
+}
+
+func TestNew_NetworkSettings(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"default_branch":"main"}`))
+	}))
+	defer srv.Close()
+	t.Cleanup(func() { httpclient.SetDefaults(httpclient.Options{}) })
+
+	httpclient.SetDefaults(httpclient.Options{InsecureSkipVerify: true})
+	p, _ := New(GitHub, srv.URL, "secret", Repo{"octo", "hello"})
+	if branch, err := p.DefaultBranch(context.Background()); err != nil || branch != "main" {
+		t.Errorf("with insecure-skip-verify: DefaultBranch = %q, %v", branch, err)
+	}
+
+	httpclient.SetDefaults(httpclient.Options{CABundle: filepath.Join(t.TempDir(), "missing.pem")})
+	p, _ = New(GitHub, srv.URL, "secret", Repo{"octo", "hello"})
+	if _, err := p.DefaultBranch(context.Background()); err == nil || !strings.Contains(err.Error(), "integration.ca-bundle") {
+		t.Errorf("an unreadable CA bundle should fail the request, got %v", err)
+	}
+	if _, err := NewDeviceFlow(srv.URL, "id").RequestCode(context.Background()); err == nil || !strings.Contains(err.Error(), "integration.ca-bundle") {
+		t.Errorf("an unreadable CA bundle should fail the device flow, got %v", err)
+	}
+}
+
+func TestKindTerms(t *testing.T) {
+	if GitLab.Noun() != "merge request" || GitLab.Ref(4) != "!4" {
+		t.Errorf("GitLab terms: %q %q", GitLab.Noun(), GitLab.Ref(4))
+	}
+	if Gitea.Noun() != "pull request" || GitHub.Ref(4) != "#4" {
+		t.Errorf("GitHub/Gitea terms: %q %q", Gitea.Noun(), GitHub.Ref(4))
+	}
+}
+
+func TestCreateRelease(t *testing.T) {
+	tests := []struct {
+		kind     Kind
+		path     string
+		bodyKey  string
+		response string
+	}{
+		{GitHub, "/repos/octo/hello/releases", "body", `{"tag_name":"v1.2.0","name":"v1.2.0","html_url":"https://example.com/r"}`},
+		{Gitea, "/repos/octo/hello/releases", "body", `{"tag_name":"v1.2.0","name":"v1.2.0","html_url":"https://example.com/r"}`},
+		{GitLab, "/projects/octo%2Fhello/releases", "description", `{"tag_name":"v1.2.0","name":"v1.2.0","_links":{"self":"https://example.com/r"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			var got map[string]any
+			p := newTestProvider(t, tt.kind, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.EscapedPath() != tt.path {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+				}
+				_ = json.NewDecoder(r.Body).Decode(&got)
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(tt.response))
+			})
+			rel, err := p.CreateRelease(context.Background(), NewRelease{Tag: "v1.2.0", Name: "v1.2.0", Body: "notes"})
+			if err != nil {
+				t.Fatalf("CreateRelease: %v", err)
+			}
+			if rel.Tag != "v1.2.0" || rel.URL != "https://example.com/r" {
+				t.Errorf("unexpected release %+v", rel)
+			}
+			if got["tag_name"] != "v1.2.0" || got[tt.bodyKey] != "notes" {
+				t.Errorf("unexpected request body %v", got)
+			}
+		})
+	}
+}
+
+func TestWebURLs(t *testing.T) {
+	repo := Repo{Owner: "group/sub", Name: "app"}
+	tests := []struct {
+		kind       Kind
+		host       string
+		pull, diff string
+	}{
+		{GitHub, "github.com", "https://github.com/group/sub/app/pull/7", "https://github.com/group/sub/app/commit/abc"},
+		{GitLab, "gitlab.com", "https://gitlab.com/group/sub/app/-/merge_requests/7", "https://gitlab.com/group/sub/app/-/commit/abc"},
+		{Gitea, "codeberg.org", "https://codeberg.org/group/sub/app/pulls/7", "https://codeberg.org/group/sub/app/commit/abc"},
+	}
+	for _, tt := range tests {
+		if got := PullRequestURL(tt.kind, tt.host, repo, 7); got != tt.pull {
+			t.Errorf("PullRequestURL(%s) = %q, want %q", tt.kind, got, tt.pull)
+		}
+		if got := CommitURL(tt.kind, tt.host, repo, "abc"); got != tt.diff {
+			t.Errorf("CommitURL(%s) = %q, want %q", tt.kind, got, tt.diff)
+		}
+	}
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		raw       string
+		wantHost  string
+		wantRepo  Repo
+		wantError bool
+	}{
+		{"git@github.com:octo/hello.git", "github.com", Repo{"octo", "hello"}, false},
+		{"https://github.com/octo/hello", "github.com", Repo{"octo", "hello"}, false},
+		{"https://token@github.com/octo/hello.git/", "github.com", Repo{"octo", "hello"}, false},
+		{"ssh://git@ghe.example.com:2222/team/app.git", "ghe.example.com", Repo{"team", "app"}, false},
+		{"https://gitlab.com/group/sub/app.git", "gitlab.com", Repo{"group/sub", "app"}, false},
+		{"/srv/git/app.git", "", Repo{}, true},
+		{"https://github.com/octo", "", Repo{}, true},
+	}
+	for _, tt := range tests {
+		host, repo, err := ParseRemoteURL(tt.raw)
+		if (err != nil) != tt.wantError {
+			t.Errorf("ParseRemoteURL(%q) error = %v", tt.raw, err)
+			continue
+		}
+		if host != tt.wantHost || repo != tt.wantRepo {
+			t.Errorf("ParseRemoteURL(%q) = %q, %+v", tt.raw, host, repo)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	configured := map[Kind]string{Gitea: "https://git.example.com/api/v1"}
+	tests := []struct {
+		host    string
+		want    Kind
+		wantErr bool
+	}{
+		{"github.com", GitHub, false},
+		{"GitHub.com", GitHub, false},
+		{"gitlab.com", GitLab, false},
+		{"codeberg.org", Gitea, false},
+		{"git.example.com", Gitea, false},
+		{"scm.example.com", "", true},
+		// A name alone is not enough: the token would go to that host.
+		{"github.evil.example", "", true},
+		{"gitlab.internal.example", "", true},
+		{"gitea.example.com", "", true},
+	}
+	for _, tt := range tests {
+		got, err := Detect(tt.host, configured)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Detect(%q) = %q, %v", tt.host, got, err)
+		}
+	}
+}
+
+func TestDefaultAPIURL(t *testing.T) {
+	tests := []struct {
+		kind Kind
+		host string
+		want string
+	}{
+		{GitHub, "github.com", DefaultGitHubAPIURL},
+		{GitHub, "ghe.example.com", "https://ghe.example.com/api/v3"},
+		{GitLab, "gitlab.example.com", "https://gitlab.example.com/api/v4"},
+		{Gitea, "codeberg.org", "https://codeberg.org/api/v1"},
+	}
+	for _, tt := range tests {
+		if got := DefaultAPIURL(tt.kind, tt.host); got != tt.want {
+			t.Errorf("DefaultAPIURL(%s, %q) = %q, want %q", tt.kind, tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestDeviceFlow(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if r.Form.Get("client_id") != "app" {
+			t.Errorf("client_id = %q", r.Form.Get("client_id"))
+		}
+		switch r.URL.Path {
+		case "/login/device/code":
+			_, _ = w.Write([]byte(`{"device_code":"dc","user_code":"ABCD-1234","verification_uri":"https://github.com/login/device","interval":5}`))
+		case "/login/oauth/access_token":
+			polls++
+			switch polls {
+			case 1:
+				_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+			case 2:
+				_, _ = w.Write([]byte(`{"error":"slow_down","interval":10}`))
+			default:
+				_, _ = w.Write([]byte(`{"access_token":"gho_token"}`))
+			}
+		}
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	flow := NewDeviceFlow(srv.URL, "app")
+	flow.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	code, err := flow.RequestCode(context.Background(), "repo")
+	if err != nil || code.UserCode != "ABCD-1234" {
+		t.Fatalf("RequestCode = %+v, %v", code, err)
+	}
+	token, err := flow.PollToken(context.Background(), code)
+	if err != nil || token != "gho_token" {
+		t.Fatalf("PollToken = %q, %v", token, err)
+	}
+	want := []time.Duration{5 * time.Second, 5 * time.Second, 10 * time.Second}
+	if len(waits) != len(want) {
+		t.Fatalf("waits = %v, want %v", waits, want)
+	}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Errorf("wait %d = %v, want %v", i, waits[i], want[i])
+		}
+	}
+}
+
+func TestDeviceFlow_Denied(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"error":"access_denied"}`))
+	}))
+	defer srv.Close()
+
+	flow := NewDeviceFlow(srv.URL, "app")
+	flow.sleep = func(context.Context, time.Duration) error { return nil }
+	if _, err := flow.PollToken(context.Background(), &DeviceCode{DeviceCode: "dc"}); !errors.Is(err, ErrDeviceFlowDenied) {
+		t.Errorf("expected ErrDeviceFlowDenied, got %v", err)
+	}
+}
+
+func TestConvertURL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		ssh     bool
+		want    string
+		wantErr bool
+	}{
+		{"https://github.com/octo/hello", true, "git@github.com:octo/hello.git", false},
+		{"https://token@github.com/octo/hello.git", true, "git@github.com:octo/hello.git", false},
+		{"git@github.com:octo/hello.git", false, "https://github.com/octo/hello.git", false},
+		{"ssh://git@gitlab.example.com:2222/group/sub/app.git", false, "https://gitlab.example.com/group/sub/app.git", false},
+		{"git@gitlab.com:group/app.git", true, "git@gitlab.com:group/app.git", false},
+		{"/srv/git/app.git", true, "", true},
+	}
+	for _, tt := range tests {
+		got, err := ConvertURL(tt.raw, tt.ssh)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ConvertURL(%q, %v) = %q, %v; want %q", tt.raw, tt.ssh, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package hosting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TokenInfo is what a service reports about an access token.
+type TokenInfo struct {
+	User string
+	// Scopes are the token's scopes. ScopesKnown is false when the service
+	// does not report them, as for GitHub fine-grained tokens and Gitea.
+	Scopes      []string
+	ScopesKnown bool
+	// Expires is when the token stops working; zero when it does not
+	// expire or the service does not say.
+	Expires time.Time
+}
+
+// RequiredScopes are the token scopes the pr and release commands need.
+func RequiredScopes(kind Kind) []string {
+	switch kind {
+	case GitHub:
+		return []string{"repo"}
+	case GitLab:
+		return []string{"api"}
+	}
+	return nil
+}
+
+// MissingScopes returns the scopes of RequiredScopes(kind) the token
+// lacks. It is empty when the scopes are unknown.
+func (t *TokenInfo) MissingScopes(kind Kind) []string {
+	if !t.ScopesKnown {
+		return nil
+	}
+	var missing []string
+	for _, want := range RequiredScopes(kind) {
+		found := false
+		for _, s := range t.Scopes {
+			if s == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, want)
+		}
+	}
+	return missing
+}
+
+// gitHubExpirationLayouts are the formats of GitHub's
+// github-authentication-token-expiration header.
+var gitHubExpirationLayouts = []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700"}
+
+// CheckToken makes a lightweight authenticated call to the API of kind
+// and returns what it reports about token. An invalid token fails with an
+// *APIError whose StatusCode is 401.
+func CheckToken(ctx context.Context, kind Kind, apiURL, token string) (*TokenInfo, error) {
+	info := &TokenInfo{}
+	switch kind {
+	case GitHub:
+		api := newGitHub(apiURL, token, Repo{}).api
+		var user struct {
+			Login string `json:"login"`
+		}
+		header, err := api.send(ctx, http.MethodGet, "/user", nil, &user)
+		if err != nil {
+			return nil, err
+		}
+		info.User = user.Login
+		// Classic tokens list their scopes; fine-grained ones send no header.
+		if values, ok := header["X-Oauth-Scopes"]; ok {
+			info.ScopesKnown = true
+			for _, s := range strings.Split(strings.Join(values, ","), ",") {
+				if s = strings.TrimSpace(s); s != "" {
+					info.Scopes = append(info.Scopes, s)
+				}
+			}
+		}
+		if exp := header.Get("Github-Authentication-Token-Expiration"); exp != "" {
+			for _, layout := range gitHubExpirationLayouts {
+				if t, err := time.Parse(layout, exp); err == nil {
+					info.Expires = t
+					break
+				}
+			}
+		}
+	case GitLab:
+		api := newGitLab(apiURL, token, Repo{}).api
+		var user struct {
+			Username string `json:"username"`
+		}
+		if err := api.do(ctx, http.MethodGet, "/user", nil, &user); err != nil {
+			return nil, err
+		}
+		info.User = user.Username
+		// Only personal, project and group access tokens can describe
+		// themselves; an OAuth token gets a 404 here, which is not an error.
+		var self struct {
+			Scopes    []string `json:"scopes"`
+			ExpiresAt string   `json:"expires_at"`
+		}
+		if err := api.do(ctx, http.MethodGet, "/personal_access_tokens/self", nil, &self); err == nil {
+			info.Scopes, info.ScopesKnown = self.Scopes, true
+			if t, err := time.Parse(time.DateOnly, self.ExpiresAt); err == nil {
+				info.Expires = t
+			}
+		}
+	case Gitea:
+		api := newGitea(apiURL, token, Repo{}).api
+		var user struct {
+			Login string `json:"login"`
+		}
+		if err := api.do(ctx, http.MethodGet, "/user", nil, &user); err != nil {
+			return nil, err
+		}
+		info.User = user.Login
+	default:
+		return nil, fmt.Errorf("unsupported hosting service %q", kind)
+	}
+	return info, nil
+}
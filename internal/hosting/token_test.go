@@ -0,0 +1,89 @@
+package hosting
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCheckToken_GitHub(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" || r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", "read:org, workflow")
+		w.Header().Set("GitHub-Authentication-Token-Expiration", "2026-11-02 17:29:28 UTC")
+		_, _ = w.Write([]byte(`{"login":"octo"}`))
+	}))
+	defer srv.Close()
+
+	info, err := CheckToken(context.Background(), GitHub, srv.URL, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.User != "octo" || !reflect.DeepEqual(info.Scopes, []string{"read:org", "workflow"}) {
+		t.Errorf("info = %+v", info)
+	}
+	if want := time.Date(2026, 11, 2, 17, 29, 28, 0, time.UTC); !info.Expires.Equal(want) {
+		t.Errorf("Expires = %v, want %v", info.Expires, want)
+	}
+	if got := info.MissingScopes(GitHub); !reflect.DeepEqual(got, []string{"repo"}) {
+		t.Errorf("MissingScopes = %v", got)
+	}
+
+	_, err = CheckToken(context.Background(), GitHub, srv.URL, "wrong")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("err = %v, want a 401 APIError", err)
+	}
+}
+
+func TestCheckToken_GitHubFineGrained(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"login":"octo"}`))
+	}))
+	defer srv.Close()
+
+	info, err := CheckToken(context.Background(), GitHub, srv.URL, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ScopesKnown || info.MissingScopes(GitHub) != nil || !info.Expires.IsZero() {
+		t.Errorf("info = %+v, want unknown scopes and no expiry", info)
+	}
+}
+
+func TestCheckToken_GitLab(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/user":
+			_, _ = w.Write([]byte(`{"username":"tanuki"}`))
+		case "/personal_access_tokens/self":
+			_, _ = w.Write([]byte(`{"scopes":["api","read_user"],"expires_at":"2026-10-20"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	info, err := CheckToken(context.Background(), GitLab, srv.URL, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.User != "tanuki" || !info.ScopesKnown || len(info.MissingScopes(GitLab)) != 0 {
+		t.Errorf("info = %+v", info)
+	}
+	if want := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC); !info.Expires.Equal(want) {
+		t.Errorf("Expires = %v, want %v", info.Expires, want)
+	}
+}
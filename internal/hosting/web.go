@@ -0,0 +1,28 @@
+package hosting
+
+import "fmt"
+
+// WebURL returns the browser address of repo on host.
+func WebURL(host string, repo Repo) string {
+	return "https://" + host + "/" + repo.String()
+}
+
+// PullRequestURL returns the browser address of pull request number.
+func PullRequestURL(kind Kind, host string, repo Repo, number int) string {
+	switch kind {
+	case GitLab:
+		return fmt.Sprintf("%s/-/merge_requests/%d", WebURL(host, repo), number)
+	case Gitea:
+		return fmt.Sprintf("%s/pulls/%d", WebURL(host, repo), number)
+	default:
+		return fmt.Sprintf("%s/pull/%d", WebURL(host, repo), number)
+	}
+}
+
+// CommitURL returns the browser address of commit hash.
+func CommitURL(kind Kind, host string, repo Repo, hash string) string {
+	if kind == GitLab {
+		return WebURL(host, repo) + "/-/commit/" + hash
+	}
+	return WebURL(host, repo) + "/commit/" + hash
+}
@@ -0,0 +1,92 @@
+// Package httpclient builds the HTTP clients ggc reaches hosting APIs and
+// the release feed with, so that one proxy and certificate setup applies
+// to all of them.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Options are the network settings of integration in the config.
+type Options struct {
+	// Proxy is the URL of the proxy every request goes through. When
+	// empty, HTTPS_PROXY, HTTP_PROXY and NO_PROXY decide, as for git.
+	Proxy string
+	// CABundle is a PEM file of certificates trusted on top of the
+	// system's, such as a company's TLS-inspecting proxy.
+	CABundle string
+	// InsecureSkipVerify accepts any server certificate.
+	InsecureSkipVerify bool
+}
+
+// defaults are the options the program installs with SetDefaults. The
+// zero value is what net/http does on its own.
+var defaults Options
+
+// SetDefaults swaps the package-level options.
+func SetDefaults(opts Options) { defaults = opts }
+
+// Defaults returns the package-level options.
+func Defaults() Options { return defaults }
+
+// New returns a client with the given timeout that honors opts. The
+// error names the setting that cannot be used.
+func New(opts Options, timeout time.Duration) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.Proxy != "" {
+		proxy, err := url.Parse(opts.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("integration.http-proxy %q is not a proxy URL", opts.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if opts.CABundle != "" || opts.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if opts.CABundle != "" {
+		pool, err := certPool(opts.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	if opts.InsecureSkipVerify {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
+
+// Default is New with the package-level options.
+func Default(timeout time.Duration) (*http.Client, error) {
+	return New(defaults, timeout)
+}
+
+// certPool returns the system's certificates with those in the PEM file
+// at path added. A leading ~/ stands for the home directory.
+func certPool(path string) (*x509.CertPool, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("integration.ca-bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("integration.ca-bundle: no PEM certificates in %s", path)
+	}
+	return pool, nil
+}
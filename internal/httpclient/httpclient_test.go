@@ -0,0 +1,84 @@
+package httpclient
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, opts Options, url string) (string, error) {
+	t.Helper()
+	client, err := New(opts, 5*time.Second)
+	if err != nil {
+		t.Fatalf("New(%+v): %v", opts, err)
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	return string(body), err
+}
+
+func TestNew_CABundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	if _, err := get(t, Options{}, srv.URL); err == nil {
+		t.Fatal("a certificate the system does not trust should be refused")
+	}
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(bundle, cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if body, err := get(t, Options{CABundle: bundle}, srv.URL); err != nil || body != "ok" {
+		t.Errorf("with the CA bundle: body %q, err %v", body, err)
+	}
+	if body, err := get(t, Options{InsecureSkipVerify: true}, srv.URL); err != nil || body != "ok" {
+		t.Errorf("with insecure-skip-verify: body %q, err %v", body, err)
+	}
+}
+
+func TestNew_Proxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "via proxy: "+r.URL.String())
+	}))
+	defer proxy.Close()
+
+	body, err := get(t, Options{Proxy: proxy.URL}, "http://api.example.invalid/user")
+	if err != nil || body != "via proxy: http://api.example.invalid/user" {
+		t.Errorf("body %q, err %v", body, err)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		opts Options
+		want string
+	}{
+		{Options{Proxy: "proxy.example.com:8080"}, "integration.http-proxy"},
+		{Options{CABundle: filepath.Join(dir, "missing.pem")}, "integration.ca-bundle"},
+		{Options{CABundle: notPEM}, "no PEM certificates"},
+	}
+	for _, tt := range tests {
+		if _, err := New(tt.opts, time.Second); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("New(%+v) error = %v, want it to mention %q", tt.opts, err, tt.want)
+		}
+	}
+}
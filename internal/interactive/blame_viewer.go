@@ -0,0 +1,318 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/difftool"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+const (
+	// blameViewerRows caps how many lines of the file are drawn at once.
+	blameViewerRows = 25
+	// blameAuthorWidth and blameSummaryWidth size the gutter columns.
+	blameAuthorWidth  = 12
+	blameSummaryWidth = 24
+)
+
+// BlameSource is the git access the blame viewer needs.
+type BlameSource interface {
+	Blame(rev, path string) ([]git.BlameLine, error)
+	ShowOutput(args []string) (string, error)
+}
+
+// blameFrame is one blamed revision of the file. Re-blaming pushes a
+// frame; going back pops it.
+type blameFrame struct {
+	rev    string // empty for the working tree
+	path   string
+	lines  []git.BlameLine
+	cursor int
+	top    int
+	oldest time.Time
+	newest time.Time
+}
+
+// BlameViewer is a full-screen blame of one file. A gutter shows the
+// commit, author, date and summary of each run of lines, colored from
+// newest to oldest. Enter or d shows the highlighted line's commit, y
+// copies its hash, p blames the file again as it was just before that
+// commit and b goes back.
+// Navigation honors the move_up, move_down and soft_cancel bindings of the
+// active keybinding profile.
+type BlameViewer struct {
+	git     BlameSource
+	frames  []*blameFrame
+	diff    *diffPager
+	message string
+	keyMap  *kb.KeyBindingMap
+	colors  *ANSIColors
+	stdin   io.Reader
+	stdout  io.Writer
+	term    termio.Terminal
+
+	highlight func(diff string) (out string, ok bool, err error)
+	copy      func(text string) error
+}
+
+// NewBlameViewer returns a viewer over the blame of path using the
+// keybinding profile and diff tool configured in cfg. cfg may be nil.
+func NewBlameViewer(src BlameSource, cfg *config.Config) *BlameViewer {
+	var tool string
+	if cfg != nil {
+		tool = cfg.UI.DiffTool
+	}
+	v := &BlameViewer{
+		git:       src,
+		keyMap:    resolveResultsKeyMap(cfg),
+		colors:    NewANSIColors(),
+		stdin:     os.Stdin,
+		stdout:    os.Stdout,
+		term:      termio.DefaultTerminal{},
+		highlight: difftool.New(tool).Highlight,
+	}
+	v.copy = clipboardCopier(&v.stdout)
+	return v
+}
+
+// Run blames path at rev, or the working tree copy when rev is empty, and
+// shows it until the user quits.
+func (v *BlameViewer) Run(rev, path string) error {
+	if err := v.push(rev, path, 0); err != nil {
+		return err
+	}
+	if len(v.frame().lines) == 0 {
+		_, _ = fmt.Fprintf(v.stdout, "%s is empty\n", path)
+		return nil
+	}
+
+	if f, isFile := v.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := v.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = v.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(v.stdout)
+
+	reader := bufio.NewReader(v.stdin)
+	for {
+		v.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil || v.handleKey(ks) {
+			clearScreen(v.stdout)
+			return nil
+		}
+	}
+}
+
+// push blames path at rev and shows it with the cursor near line.
+func (v *BlameViewer) push(rev, path string, line int) error {
+	lines, err := v.git.Blame(rev, path)
+	if err != nil {
+		return err
+	}
+	f := &blameFrame{rev: rev, path: path, lines: lines, cursor: max(min(line, len(lines)-1), 0)}
+	for _, l := range lines {
+		if l.Uncommitted() {
+			continue
+		}
+		if f.oldest.IsZero() || l.AuthorTime.Before(f.oldest) {
+			f.oldest = l.AuthorTime
+		}
+		if l.AuthorTime.After(f.newest) {
+			f.newest = l.AuthorTime
+		}
+	}
+	v.frames = append(v.frames, f)
+	return nil
+}
+
+func (v *BlameViewer) frame() *blameFrame {
+	return v.frames[len(v.frames)-1]
+}
+
+// handleKey applies one keystroke and reports whether the viewer is done.
+func (v *BlameViewer) handleKey(ks kb.KeyStroke) bool {
+	v.message = ""
+	if v.diff != nil {
+		if v.diff.handleKey(ks, v.keyMap) {
+			v.diff = nil
+		}
+		return false
+	}
+	f := v.frame()
+	line := f.lines[f.cursor]
+
+	switch {
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewCharKeyStroke('q')),
+		v.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true
+	case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+		v.keyMap.MatchesKeyStroke("move_up", ks):
+		f.cursor = max(f.cursor-1, 0)
+	case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+		v.keyMap.MatchesKeyStroke("move_down", ks):
+		f.cursor = min(f.cursor+1, len(f.lines)-1)
+	case ks.Equals(kb.NewCtrlKeyStroke('u')):
+		f.cursor = max(f.cursor-blameViewerRows/2, 0)
+	case ks.Equals(kb.NewCtrlKeyStroke('d')):
+		f.cursor = min(f.cursor+blameViewerRows/2, len(f.lines)-1)
+	case ks.Equals(kb.NewEnterKeyStroke()), ks.Equals(kb.NewCharKeyStroke('d')):
+		v.showCommit(line)
+	case ks.Equals(kb.NewCharKeyStroke('y')):
+		if line.Uncommitted() {
+			v.message = "This line is not committed yet"
+		} else {
+			v.message = copyMessage(v.copy, line.Hash)
+		}
+	case ks.Equals(kb.NewCharKeyStroke('p')):
+		v.blameParent(line)
+	case ks.Equals(kb.NewCharKeyStroke('b')):
+		if len(v.frames) > 1 {
+			v.frames = v.frames[:len(v.frames)-1]
+		} else {
+			v.message = "Already at the first blame"
+		}
+	}
+	return false
+}
+
+func (v *BlameViewer) showCommit(line git.BlameLine) {
+	if line.Uncommitted() {
+		v.message = "This line is not committed yet"
+		return
+	}
+	c := v.colors
+	title := fmt.Sprintf("%s%s%s %s", c.Bold+c.BrightYellow, shortHash(line.Hash), c.Reset, line.Summary)
+	diff, err := openCommit(v.git, line.Hash, title, c, v.highlight)
+	if err != nil {
+		v.message = err.Error()
+		return
+	}
+	v.diff = diff
+}
+
+// blameParent blames the file as it was before the line's commit, under
+// the name it had then, with the cursor near where the line came from.
+func (v *BlameViewer) blameParent(line git.BlameLine) {
+	switch {
+	case line.Uncommitted():
+		v.message = "This line is not committed yet"
+		return
+	case line.Previous == "":
+		v.message = fmt.Sprintf("%s added %s; there is nothing before it", shortHash(line.Hash), line.Filename)
+		return
+	}
+	if err := v.push(line.Previous, line.PrevFile, line.OrigLine-1); err != nil {
+		v.message = err.Error()
+		return
+	}
+	if len(v.frame().lines) == 0 {
+		v.frames = v.frames[:len(v.frames)-1]
+		v.message = fmt.Sprintf("%s was empty before %s", line.PrevFile, shortHash(line.Hash))
+	}
+}
+
+func (v *BlameViewer) render() {
+	c := v.colors
+	clearScreen(v.stdout)
+	var b strings.Builder
+	if v.diff != nil {
+		v.diff.render(&b, c)
+	} else {
+		v.renderBlame(&b)
+	}
+	if v.message != "" {
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightRed, v.message, c.Reset)
+	}
+	help := "j/k move · enter/[d]iff · [p]arent blame · [b]ack · [y]ank hash · q quit"
+	if v.diff != nil {
+		help = diffPagerHelp
+	}
+	fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightBlack, help, c.Reset)
+	_, _ = io.WriteString(v.stdout, b.String())
+}
+
+func (v *BlameViewer) renderBlame(b *strings.Builder) {
+	c, f := v.colors, v.frame()
+	rev := f.rev
+	switch {
+	case rev == "":
+		rev = "working tree"
+	case len(rev) == 40:
+		rev = shortHash(rev)
+	}
+	fmt.Fprintf(b, "%sBlame%s %s %s(%s)%s\r\n\r\n", c.Bold+c.BrightCyan, c.Reset, f.path, c.BrightBlack, rev, c.Reset)
+
+	if f.cursor < f.top {
+		f.top = f.cursor
+	} else if f.cursor >= f.top+blameViewerRows {
+		f.top = f.cursor - blameViewerRows + 1
+	}
+	end := min(f.top+blameViewerRows, len(f.lines))
+	width := len(fmt.Sprint(len(f.lines)))
+	for i := f.top; i < end; i++ {
+		l := f.lines[i]
+		marker := "  "
+		if i == f.cursor {
+			marker = c.BrightGreen + "› " + c.Reset
+		}
+		gutter := strings.Repeat(" ", 8+1+blameAuthorWidth+1+10+1+blameSummaryWidth)
+		// Only the first line of each run from one commit fills the gutter.
+		if i == f.top || f.lines[i-1].Hash != l.Hash {
+			gutter = v.gutter(l)
+		}
+		age := v.ageColor(l)
+		fmt.Fprintf(b, "%s%s%s %*d │%s %s\r\n", marker, age, gutter, width, l.Line, c.Reset, displayLine(l.Text))
+	}
+
+	l := f.lines[f.cursor]
+	if !l.Uncommitted() {
+		fmt.Fprintf(b, "\r\n%s%s%s %s · %s · %s\r\n", c.BrightYellow, l.Hash, c.Reset, l.Author,
+			l.AuthorTime.Format("2006-01-02 15:04"), l.Summary)
+	}
+}
+
+// gutter renders the commit, author, date and summary of a line.
+func (v *BlameViewer) gutter(l git.BlameLine) string {
+	if l.Uncommitted() {
+		return fmt.Sprintf("%-8s %-*s %-10s %-*s", "--------", blameAuthorWidth, "You",
+			"", blameSummaryWidth, "Not committed yet")
+	}
+	return fmt.Sprintf("%-8s %-*s %s %-*s", shortHash(l.Hash), blameAuthorWidth, ellipsis(l.Author, blameAuthorWidth),
+		l.AuthorTime.Format("2006-01-02"), blameSummaryWidth, ellipsis(l.Summary, blameSummaryWidth))
+}
+
+// ageColor shades a line from bright for the newest change in the file to
+// dim for the oldest. Uncommitted lines stand out.
+func (v *BlameViewer) ageColor(l git.BlameLine) string {
+	c, f := v.colors, v.frame()
+	if l.Uncommitted() {
+		return c.BrightMagenta
+	}
+	shades := []string{c.BrightCyan, c.Cyan, c.BrightBlue, c.Blue, c.BrightBlack}
+	span := f.newest.Sub(f.oldest)
+	if span <= 0 {
+		return shades[0]
+	}
+	age := f.newest.Sub(l.AuthorTime)
+	return shades[min(int(float64(age)/float64(span)*float64(len(shades))), len(shades)-1)]
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
@@ -0,0 +1,131 @@
+package interactive
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// fakeBlameSource serves a fixed blame per revision and records what was
+// blamed and shown.
+type fakeBlameSource struct {
+	blames map[string][]git.BlameLine
+	blamed []string
+	shown  []string
+}
+
+func (f *fakeBlameSource) Blame(rev, path string) ([]git.BlameLine, error) {
+	f.blamed = append(f.blamed, rev+":"+path)
+	return f.blames[rev], nil
+}
+
+func (f *fakeBlameSource) ShowOutput(args []string) (string, error) {
+	hash := args[len(args)-1]
+	f.shown = append(f.shown, hash)
+	return "commit " + hash + "\n\ndiff --git a/a.go b/a.go\n+changed\n", nil
+}
+
+func newFakeBlameSource() *fakeBlameSource {
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	line := func(hash string, n int, text string, at time.Time, summary string) git.BlameLine {
+		return git.BlameLine{Hash: hash, Line: n, OrigLine: n, Text: text, Author: "Alice", AuthorTime: at,
+			Summary: summary, Filename: "a.go"}
+	}
+	second := line("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", 2, "\tfmt.Println()", recent, "Print")
+	second.Previous, second.PrevFile = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "old.go"
+	return &fakeBlameSource{blames: map[string][]git.BlameLine{
+		"": {
+			line("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", 1, "package a", old, "Initial commit"),
+			second,
+			line(strings.Repeat("0", 40), 3, "// wip", recent, ""),
+		},
+		"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa": {
+			line("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", 1, "package a", old, "Initial commit"),
+		},
+	}}
+}
+
+func newTestBlameViewer(src *fakeBlameSource, input string) (*BlameViewer, *bytes.Buffer) {
+	var out bytes.Buffer
+	v := NewBlameViewer(src, nil)
+	v.stdin = strings.NewReader(input)
+	v.stdout = &out
+	v.highlight = nil
+	v.copy = func(text string) error { return uiutil.CopyOSC52(&out, text) }
+	return v, &out
+}
+
+func TestBlameViewer_Gutter(t *testing.T) {
+	v, out := newTestBlameViewer(newFakeBlameSource(), "q")
+	if err := v.Run("", "a.go"); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	got := uiutil.StripANSI(out.String())
+	for _, want := range []string{
+		"Blame a.go (working tree)",
+		"aaaaaaaa Alice        2020-01-01 Initial commit           1 │ package a",
+		"bbbbbbbb Alice        2024-06-01 Print                    2 │     fmt.Println()",
+		"-------- You                     Not committed yet        3 │ // wip",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+}
+
+func TestBlameViewer_ShowCommit(t *testing.T) {
+	src := newFakeBlameSource()
+	v, out := newTestBlameViewer(src, "j\rqjdq")
+	if err := v.Run("", "a.go"); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(src.shown) != 1 || src.shown[0] != "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb" {
+		t.Errorf("shown = %v", src.shown)
+	}
+	if !strings.Contains(out.String(), "+changed") || !strings.Contains(out.String(), "not committed yet") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
+
+func TestBlameViewer_BlameParentAndBack(t *testing.T) {
+	src := newFakeBlameSource()
+	v, out := newTestBlameViewer(src, "jpbkpq")
+	if err := v.Run("", "a.go"); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := []string{":a.go", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa:old.go"}
+	if strings.Join(src.blamed, " ") != strings.Join(want, " ") {
+		t.Errorf("blamed = %v, want %v", src.blamed, want)
+	}
+	got := uiutil.StripANSI(out.String())
+	if !strings.Contains(got, "Blame old.go (aaaaaaaa)") {
+		t.Errorf("expected the parent blame, got %q", got)
+	}
+	if !strings.Contains(got, "aaaaaaaa added a.go; there is nothing before it") {
+		t.Errorf("expected the root commit to stop re-blaming, got %q", got)
+	}
+}
+
+func TestBlameViewer_Copy(t *testing.T) {
+	var copied []string
+	v, out := newTestBlameViewer(newFakeBlameSource(), "jyjyq")
+	v.copy = func(text string) error {
+		copied = append(copied, text)
+		return nil
+	}
+	if err := v.Run("", "a.go"); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if want := []string{"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"}; !slices.Equal(copied, want) {
+		t.Errorf("copied %v, want %v", copied, want)
+	}
+	if !strings.Contains(out.String(), "Copied bbbbbbbb") || !strings.Contains(out.String(), "not committed yet") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
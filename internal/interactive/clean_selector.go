@@ -0,0 +1,320 @@
+package interactive
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+const (
+	// cleanSelectorRows caps how many files are drawn at once.
+	cleanSelectorRows = 12
+	// cleanPreviewLines caps the preview of the highlighted file.
+	cleanPreviewLines = 10
+	// cleanPreviewWidth caps the width of a preview line.
+	cleanPreviewWidth = 120
+	// cleanPreviewBytes is how much of a file the preview reads.
+	cleanPreviewBytes = 4096
+)
+
+// CleanFile is a path git clean would remove.
+type CleanFile struct {
+	Path    string
+	Ignored bool
+	// Size is the size of the file, or of every file under a directory.
+	Size int64
+}
+
+// StatCleanFiles returns the untracked and ignored paths with their sizes,
+// untracked first. Paths are relative to the working directory, as git
+// clean prints them.
+func StatCleanFiles(untracked, ignored []string) []CleanFile {
+	files := make([]CleanFile, 0, len(untracked)+len(ignored))
+	for _, p := range untracked {
+		files = append(files, CleanFile{Path: p, Size: pathSize(p)})
+	}
+	for _, p := range ignored {
+		files = append(files, CleanFile{Path: p, Ignored: true, Size: pathSize(p)})
+	}
+	return files
+}
+
+// pathSize sums the sizes of the regular files at or under path.
+func pathSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// CleanSelector is a full-screen picker over the files git clean would
+// remove, with each file's size and a preview of the highlighted one.
+// Typing filters the list, Space marks a file, Ctrl+A marks every visible
+// file, Ctrl+R inverts the marks and Enter returns the marked files (or
+// the highlighted one when nothing is marked).
+type CleanSelector struct {
+	files    map[string]CleanFile
+	state    *UIState
+	previews map[string][]string
+	keyMap   *kb.KeyBindingMap
+	colors   *ANSIColors
+	stdin    io.Reader
+	stdout   io.Writer
+	term     termio.Terminal
+
+	preview func(path string) []string
+}
+
+// NewCleanSelector returns a picker over files using the keybinding
+// profile configured in cfg. cfg may be nil.
+func NewCleanSelector(files []CleanFile, cfg *config.Config) *CleanSelector {
+	byPath := make(map[string]CleanFile, len(files))
+	commands := make([]CommandInfo, len(files))
+	for i, f := range files {
+		byPath[f.Path] = f
+		commands[i] = CommandInfo{Command: f.Path}
+	}
+	state := &UIState{commands: commands, context: kb.ContextResults}
+	state.SetMultiSelect(true)
+	state.UpdateFiltered()
+	return &CleanSelector{
+		files:    byPath,
+		state:    state,
+		previews: make(map[string][]string),
+		keyMap:   resolveResultsKeyMap(cfg),
+		colors:   NewANSIColors(),
+		stdin:    os.Stdin,
+		stdout:   os.Stdout,
+		term:     termio.DefaultTerminal{},
+		preview:  previewPath,
+	}
+}
+
+// Run shows the picker until the user confirms or cancels. ok is false
+// when the user canceled or confirmed an empty list.
+func (s *CleanSelector) Run() (selected []CleanFile, ok bool, err error) {
+	if f, isFile := s.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := s.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = s.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(s.stdout)
+
+	reader := bufio.NewReader(s.stdin)
+	for {
+		s.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil {
+			clearScreen(s.stdout)
+			return nil, false, nil
+		}
+		if done, confirmed := s.handleKey(ks); done {
+			clearScreen(s.stdout)
+			if !confirmed {
+				return nil, false, nil
+			}
+			selected = s.selection()
+			return selected, len(selected) > 0, nil
+		}
+	}
+}
+
+// selection returns the marked files, or the highlighted one when none is
+// marked.
+func (s *CleanSelector) selection() []CleanFile {
+	var files []CleanFile
+	for _, cmd := range s.state.MarkedCommands() {
+		files = append(files, s.files[cmd.Command])
+	}
+	if len(files) == 0 {
+		if cmd := s.state.GetSelectedCommand(); cmd != nil {
+			files = append(files, s.files[cmd.Command])
+		}
+	}
+	return files
+}
+
+// markedSize sums the sizes of the marked files.
+func (s *CleanSelector) markedSize() int64 {
+	var size int64
+	for _, cmd := range s.state.MarkedCommands() {
+		size += s.files[cmd.Command].Size
+	}
+	return size
+}
+
+// handleKey applies one keystroke and reports whether the picker is done
+// and, if so, whether the selection was confirmed.
+func (s *CleanSelector) handleKey(ks kb.KeyStroke) (done, confirmed bool) {
+	st := s.state
+	switch {
+	case ks.Equals(kb.NewEnterKeyStroke()):
+		return true, true
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewEscapeKeyStroke()),
+		s.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true, false
+	case ks.Equals(kb.NewUpArrowKeyStroke()), s.keyMap.MatchesKeyStroke("move_up", ks):
+		st.MoveUp()
+	case ks.Equals(kb.NewDownArrowKeyStroke()), s.keyMap.MatchesKeyStroke("move_down", ks):
+		st.MoveDown()
+	case ks.Equals(kb.NewCharKeyStroke(' ')):
+		st.ToggleMark()
+	case ks.Equals(kb.NewCtrlKeyStroke('a')):
+		st.ToggleAllMarks()
+	case ks.Equals(kb.NewCtrlKeyStroke('r')):
+		st.InvertMarks()
+	case ks.Equals(kb.NewRawKeyStroke([]byte{0x7f})), ks.Equals(kb.NewCtrlKeyStroke('h')):
+		st.RemoveChar()
+	case ks.Kind == kb.KeyStrokeRawSeq:
+		if r, size := utf8.DecodeRune(ks.Seq); size == len(ks.Seq) && unicode.IsPrint(r) {
+			st.AddRune(r)
+		}
+	}
+	return false, false
+}
+
+func (s *CleanSelector) render() {
+	c, st := s.colors, s.state
+	clearScreen(s.stdout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sSelect files to delete%s %s(%d selected, %s)%s\r\n", c.Bold+c.BrightCyan, c.Reset,
+		c.BrightGreen, st.MarkedCount(), uiutil.FormatBytes(s.markedSize()), c.Reset)
+	fmt.Fprintf(&b, "%sFilter:%s %s\r\n\r\n", c.BrightBlue, c.Reset, st.input)
+
+	start := max(st.selected-cleanSelectorRows+1, 0)
+	end := min(start+cleanSelectorRows, len(st.filtered))
+	for i := start; i < end; i++ {
+		f := s.files[st.filtered[i].Command]
+		cursor := "  "
+		if i == st.selected {
+			cursor = c.BrightCyan + "▶ " + c.Reset
+		}
+		tag := ""
+		if f.Ignored {
+			tag = fmt.Sprintf(" %s(ignored)%s", c.BrightBlack, c.Reset)
+		}
+		fmt.Fprintf(&b, "%s%s%s%10s%s  %s%s\r\n", cursor, markGlyph(c, st.IsMarked(f.Path)),
+			c.BrightYellow, uiutil.FormatBytes(f.Size), c.Reset, f.Path, tag)
+	}
+	if len(st.filtered) == 0 {
+		fmt.Fprintf(&b, "%sNo matches.%s\r\n", c.BrightBlack, c.Reset)
+	} else if hidden := len(st.filtered) - (end - start); hidden > 0 {
+		fmt.Fprintf(&b, "%s… %d more%s\r\n", c.BrightBlack, hidden, c.Reset)
+	}
+
+	if cmd := st.GetSelectedCommand(); cmd != nil {
+		fmt.Fprintf(&b, "\r\n%s── %s%s\r\n", c.BrightBlack, cmd.Command, c.Reset)
+		s.renderPreview(&b, cmd.Command)
+	}
+	fmt.Fprintf(&b, "\r\n%s↑/↓ move · space mark · ctrl+a mark all · ctrl+r invert · type to filter · enter confirm · esc cancel%s\r\n", c.BrightBlack, c.Reset)
+	_, _ = io.WriteString(s.stdout, b.String())
+}
+
+func (s *CleanSelector) renderPreview(b *strings.Builder, path string) {
+	lines, ok := s.previews[path]
+	if !ok {
+		lines = s.preview(path)
+		s.previews[path] = lines
+	}
+	for i, line := range lines {
+		if i == cleanPreviewLines {
+			fmt.Fprintf(b, "%s… %d more line(s)%s\r\n", s.colors.BrightBlack, len(lines)-i, s.colors.Reset)
+			return
+		}
+		fmt.Fprintf(b, "%s\r\n", line)
+	}
+}
+
+// previewPath returns the first lines of a text file or the entries of a
+// directory. Binary files are described rather than shown.
+func previewPath(path string) []string {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return []string{err.Error()}
+		}
+		if len(entries) == 0 {
+			return []string{"(empty directory)"}
+		}
+		lines := make([]string, len(entries))
+		for i, e := range entries {
+			lines[i] = e.Name()
+			if e.IsDir() {
+				lines[i] += "/"
+			}
+		}
+		return lines
+	}
+	if !info.Mode().IsRegular() {
+		return []string{"(not a regular file)"}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	defer func() { _ = f.Close() }()
+	data := make([]byte, cleanPreviewBytes)
+	n, err := io.ReadFull(f, data)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return []string{err.Error()}
+	}
+	data = data[:n]
+	if len(data) == 0 {
+		return []string{"(empty file)"}
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return []string{"(binary file)"}
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = displayLine(line)
+	}
+	return lines
+}
+
+// displayLine makes one line of file content safe to draw: tabs become
+// spaces, control characters are dropped so the file cannot drive the
+// terminal, and long lines are cut.
+func displayLine(line string) string {
+	line = strings.ReplaceAll(strings.ToValidUTF8(line, "�"), "\t", "    ")
+	line = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, line)
+	if utf8.RuneCountInString(line) > cleanPreviewWidth {
+		line = string([]rune(line)[:cleanPreviewWidth-1]) + "…"
+	}
+	return line
+}
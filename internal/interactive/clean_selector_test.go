@@ -0,0 +1,107 @@
+package interactive
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+func runCleanSelector(t *testing.T, files []CleanFile, input string) ([]string, bool, string) {
+	t.Helper()
+	var out bytes.Buffer
+	s := NewCleanSelector(files, nil)
+	s.stdin = strings.NewReader(input)
+	s.stdout = &out
+	s.preview = func(path string) []string { return []string{"preview of " + path} }
+	got, ok, err := s.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, f := range got {
+		paths = append(paths, f.Path)
+	}
+	return paths, ok, out.String()
+}
+
+func TestCleanSelector(t *testing.T) {
+	files := []CleanFile{
+		{Path: "notes.txt", Size: 10},
+		{Path: "tmp/", Size: 2048},
+		{Path: "build/", Ignored: true, Size: 1 << 20},
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+		ok    bool
+	}{
+		{"enter takes the highlighted file", "\r", []string{"notes.txt"}, true},
+		{"space marks files", " \x1b[B\x1b[B \r", []string{"notes.txt", "build/"}, true},
+		{"ctrl+a marks everything", "\x01\r", []string{"notes.txt", "tmp/", "build/"}, true},
+		{"ctrl+r inverts the marks", " \x12\r", []string{"tmp/", "build/"}, true},
+		{"filter narrows the list", "build \r", []string{"build/"}, true},
+		{"escape cancels", " \x1b", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, _ := runCleanSelector(t, files, tt.input)
+			if ok != tt.ok || !slices.Equal(got, tt.want) {
+				t.Errorf("Run() = %v, %v; want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCleanSelector_RendersSizesAndPreview(t *testing.T) {
+	files := []CleanFile{{Path: "notes.txt", Size: 10}, {Path: "build/", Ignored: true, Size: 1536}}
+	_, _, out := runCleanSelector(t, files, "\x01\r")
+	out = uiutil.StripANSI(out)
+	for _, want := range []string{"(2 selected, 1.5 KiB)", "10 B  notes.txt", "1.5 KiB  build/ (ignored)", "preview of notes.txt"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render missing %q in %q", want, out)
+		}
+	}
+}
+
+func TestStatCleanFilesAndPreview(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.MkdirAll(filepath.Join("out", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"notes.txt":     "line one\nline two\n",
+		"out/a.bin":     "\x00\x01\x02",
+		"out/sub/b.txt": "12345",
+		"esc.txt":       "\x1b[31mred\x1b[0m\n",
+	} {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := StatCleanFiles([]string{"notes.txt"}, []string{"out/"})
+	want := []CleanFile{{Path: "notes.txt", Size: 18}, {Path: "out/", Ignored: true, Size: 8}}
+	if !slices.Equal(files, want) {
+		t.Errorf("StatCleanFiles() = %v, want %v", files, want)
+	}
+
+	if got := previewPath("notes.txt"); !slices.Equal(got, []string{"line one", "line two"}) {
+		t.Errorf("text preview = %q", got)
+	}
+	if got := previewPath("out/a.bin"); !slices.Equal(got, []string{"(binary file)"}) {
+		t.Errorf("binary preview = %q", got)
+	}
+	if got := previewPath("esc.txt"); !slices.Equal(got, []string{"[31mred[0m"}) {
+		t.Errorf("control characters survived the preview: %q", got)
+	}
+	if got := previewPath("out"); !slices.Equal(got, []string{"a.bin", "sub/"}) {
+		t.Errorf("directory preview = %q", got)
+	}
+}
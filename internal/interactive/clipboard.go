@@ -0,0 +1,23 @@
+package interactive
+
+import (
+	"fmt"
+	"io"
+
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// clipboardCopier returns the copy function of a viewer: the system
+// clipboard, falling back to OSC 52 on the viewer's output, read through
+// stdout at copy time so tests can swap it.
+func clipboardCopier(stdout *io.Writer) func(text string) error {
+	return func(text string) error { return uiutil.NewClipboard(*stdout).Copy(text) }
+}
+
+// copyMessage copies text and returns the line the viewer shows for it.
+func copyMessage(copy func(text string) error, text string) string {
+	if err := copy(text); err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("Copied %s", text)
+}
@@ -0,0 +1,399 @@
+package interactive
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/commitmsg"
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+// noScope is the picker entry for leaving the scope empty.
+const noScope = "(none)"
+
+// CommitComposerOptions configures the commit composer.
+type CommitComposerOptions struct {
+	// Template is the content of git's commit.template, used to prefill
+	// the message. Comment lines are ignored.
+	Template         string
+	SubjectMaxLength int // warn above this many characters; 0 disables
+	BodyMaxLength    int // warn above this many characters; 0 disables
+	// Conventional asks for a Conventional Commits type and scope.
+	Conventional bool
+	Types        []string // defaults to commitmsg.DefaultTypes
+	Scopes       []string // when set, the scope is picked instead of typed
+	// Snippets are inserted with {{name}} in the subject or body.
+	// Variables fill {NAME} placeholders; missing ones are asked for.
+	Snippets  map[string]string
+	Variables map[string]string
+	// Ticket adds the branch's ticket reference to the finished message;
+	// nil leaves it as written.
+	Ticket func(message string) string
+}
+
+// CommitComposerOptionsFromConfig maps the commit section of cfg to
+// composer options. cfg may be nil.
+func CommitComposerOptionsFromConfig(cfg *config.Config, template string) CommitComposerOptions {
+	opts := CommitComposerOptions{Template: template}
+	if cfg != nil {
+		opts.SubjectMaxLength = cfg.Commit.SubjectMaxLength
+		opts.BodyMaxLength = cfg.Commit.BodyMaxLength
+		opts.Conventional = cfg.Commit.Conventional
+		opts.Types = cfg.Commit.Types
+		opts.Scopes = cfg.Commit.Scopes
+		opts.Snippets = cfg.Snippets.Commit
+	}
+	return opts
+}
+
+// CommitComposer walks through a commit message one field at a time:
+// type and scope (Conventional Commits only), subject and body, then shows
+// the result with any line-length warnings before committing. Each text
+// field uses the same line editor as the interactive prompt, and snippets
+// and variables in it are expanded once it is entered.
+type CommitComposer struct {
+	opts     CommitComposerOptions
+	msg      commitmsg.Message
+	expander *commitmsg.Expander
+	// expandErr is why the last field could not be expanded, shown in the
+	// review.
+	expandErr error
+	keyMap    *kb.KeyBindingMap
+	ui        *UI
+	stdin     io.Reader
+	reader    *bufio.Reader
+	term      termio.Terminal
+}
+
+// NewCommitComposer returns a composer using the keybinding profile
+// configured in cfg for its pickers. cfg may be nil.
+func NewCommitComposer(opts CommitComposerOptions, cfg *config.Config) *CommitComposer {
+	if len(opts.Types) == 0 {
+		opts.Types = commitmsg.DefaultTypes
+	}
+	msg := commitmsg.ParseTemplate(opts.Template)
+	if !opts.Conventional {
+		// Keep a template's "type: " prefix as plain subject text.
+		msg = commitmsg.Message{Subject: msg.Header(), Body: msg.Body}
+	}
+	c := &CommitComposer{
+		opts:   opts,
+		msg:    msg,
+		keyMap: resolveResultsKeyMap(cfg),
+		ui:     &UI{stdout: os.Stdout, colors: NewANSIColors()},
+		stdin:  os.Stdin,
+		term:   termio.DefaultTerminal{},
+	}
+	c.expander = &commitmsg.Expander{Snippets: opts.Snippets, Variables: opts.Variables, Ask: c.askVariable}
+	return c
+}
+
+// Run shows the composer and returns the message and true once the user
+// confirms it, or false when canceled.
+func (c *CommitComposer) Run() (string, bool) {
+	if f, ok := c.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := c.term.MakeRaw(fd)
+		if err == nil {
+			enableBracketedPaste(c.ui.stdout)
+			defer func() {
+				disableBracketedPaste(c.ui.stdout)
+				_ = c.term.Restore(fd, oldState)
+			}()
+		}
+	}
+	defer showCursor(c.ui.stdout)
+	c.reader = bufio.NewReader(c.stdin)
+
+	for {
+		if !c.edit() {
+			clearScreen(c.ui.stdout)
+			return "", false
+		}
+		c.addTicket()
+		switch c.review() {
+		case reviewCommit:
+			clearScreen(c.ui.stdout)
+			return c.msg.String(), true
+		case reviewCancel:
+			clearScreen(c.ui.stdout)
+			return "", false
+		}
+	}
+}
+
+// edit asks for every field in turn, starting from the current values.
+func (c *CommitComposer) edit() bool {
+	if c.opts.Conventional {
+		typ, ok := c.pick("Type", c.opts.Types, c.msg.Type)
+		if !ok {
+			return false
+		}
+		c.msg.Type = typ
+		if !c.editScope() {
+			return false
+		}
+	}
+
+	c.expandErr = nil
+	subject, ok := c.readSubject()
+	if !ok {
+		return false
+	}
+	if subject, ok = c.expand(subject); !ok {
+		return false
+	}
+	// A snippet may span lines; what follows the first goes to the body.
+	subject, rest, _ := strings.Cut(subject, "\n")
+	c.msg.Subject = strings.TrimSpace(subject)
+	if rest = strings.Trim(rest, "\n"); rest != "" && !strings.Contains(c.msg.Body, rest) {
+		c.msg.Body = strings.Trim(rest+"\n"+c.msg.Body, "\n")
+	}
+
+	body, ok := c.readBody()
+	if !ok {
+		return false
+	}
+	if body, ok = c.expand(body); !ok {
+		return false
+	}
+	c.msg.Body = body
+	return true
+}
+
+// addTicket adds the ticket reference to the message, so the review shows
+// what will be committed.
+func (c *CommitComposer) addTicket() {
+	if c.opts.Ticket == nil {
+		return
+	}
+	msg := commitmsg.Parse(c.opts.Ticket(c.msg.String()))
+	if !c.opts.Conventional {
+		msg = commitmsg.Message{Subject: msg.Header(), Body: msg.Body}
+	}
+	c.msg = msg
+}
+
+// errVariableCanceled ends composing when the user cancels a variable
+// prompt.
+var errVariableCanceled = errors.New("canceled")
+
+// expand fills in the snippets and variables of text. When that fails the
+// text is kept as typed and the error is shown in the review; ok is false
+// only when the user cancels a variable prompt.
+func (c *CommitComposer) expand(text string) (string, bool) {
+	if !commitmsg.HasPlaceholders(text) {
+		return text, true
+	}
+	expanded, err := c.expander.Expand(text)
+	if errors.Is(err, errVariableCanceled) {
+		return "", false
+	}
+	if err != nil {
+		c.expandErr = err
+		return text, true
+	}
+	return expanded, true
+}
+
+// askVariable asks for the value of a {NAME} placeholder the branch name
+// does not provide.
+func (c *CommitComposer) askVariable(name string) (string, error) {
+	c.drawHeader(fmt.Sprintf("Value for {%s}", name))
+	res := c.readLine(name+": ", "", true, false)
+	if res.canceled {
+		return "", errVariableCanceled
+	}
+	return strings.TrimSpace(res.text), nil
+}
+
+func (c *CommitComposer) editScope() bool {
+	if len(c.opts.Scopes) > 0 {
+		scope, ok := c.pick("Scope", append([]string{noScope}, c.opts.Scopes...), c.msg.Scope)
+		if !ok {
+			return false
+		}
+		if scope == noScope {
+			scope = ""
+		}
+		c.msg.Scope = scope
+		return true
+	}
+	c.drawHeader("Scope (optional, Enter to skip)")
+	res := c.readLine("Scope: ", c.msg.Scope, true, false)
+	if res.canceled {
+		return false
+	}
+	c.msg.Scope = strings.TrimSpace(res.text)
+	return true
+}
+
+func (c *CommitComposer) readSubject() (string, bool) {
+	hint := "Subject"
+	if c.opts.SubjectMaxLength > 0 {
+		hint = fmt.Sprintf("Subject (header within %d characters)", c.opts.SubjectMaxLength)
+	}
+	if len(c.opts.Snippets) > 0 {
+		names := slices.Sorted(maps.Keys(c.opts.Snippets))
+		hint += " · snippets: {{" + strings.Join(names, "}} {{") + "}}"
+	}
+	c.drawHeader(hint)
+	res := c.readLine("Subject: ", c.msg.Subject, false, false)
+	if res.canceled {
+		return "", false
+	}
+	return strings.TrimSpace(res.text), true
+}
+
+// readBody reads body lines until Ctrl+D, starting from the existing body
+// so template text and earlier edits can be kept with Enter.
+func (c *CommitComposer) readBody() (string, bool) {
+	c.drawHeader("Body (optional): Enter starts a new line, Ctrl+D finishes")
+	var existing []string
+	if c.msg.Body != "" {
+		existing = strings.Split(c.msg.Body, "\n")
+	}
+	var lines []string
+	for i := 0; ; i++ {
+		initial := ""
+		if i < len(existing) {
+			initial = existing[i]
+		}
+		res := c.readLine("> ", initial, true, true)
+		if res.canceled {
+			return "", false
+		}
+		if res.text != "" || !res.finished {
+			lines = append(lines, strings.TrimRight(res.text, " "))
+		}
+		if res.finished {
+			return strings.Trim(strings.Join(lines, "\n"), "\n"), true
+		}
+	}
+}
+
+// readLine edits one line with the shared line editor, prefilled with
+// initial.
+func (c *CommitComposer) readLine(prompt, initial string, optional, multiline bool) inputResult {
+	runes := []rune(initial)
+	cursor := len(runes)
+	editor := &realTimeEditor{ui: c.ui, inputRunes: &runes, cursor: &cursor, optional: optional, multiline: multiline}
+	c.ui.write("%s%s%s%s", c.ui.colors.BrightBlue, prompt, c.ui.colors.Reset, initial)
+	for {
+		r, _, err := c.reader.ReadRune()
+		if err != nil {
+			return inputResult{canceled: true}
+		}
+		if res := editor.handleInput(r, c.reader); res.done || res.canceled {
+			return res
+		}
+	}
+}
+
+// pick shows options as a list and returns the chosen one. current, when
+// present in options, is preselected.
+func (c *CommitComposer) pick(label string, options []string, current string) (string, bool) {
+	cursor := 0
+	for i, o := range options {
+		if o == current {
+			cursor = i
+		}
+	}
+	for {
+		c.drawHeader(label + ": ↑/↓ or j/k to move, Enter to choose")
+		colors := c.ui.colors
+		for i, o := range options {
+			marker := "  "
+			if i == cursor {
+				marker = colors.BrightGreen + "› " + colors.Reset
+			}
+			c.ui.write("%s%s\r\n", marker, o)
+		}
+		ks, err := readRebaseKey(c.reader)
+		if err != nil {
+			return "", false
+		}
+		switch {
+		case ks.Equals(kb.NewEnterKeyStroke()):
+			return options[cursor], true
+		case ks.Equals(kb.NewCtrlKeyStroke('c')), c.keyMap.MatchesKeyStroke("soft_cancel", ks):
+			return "", false
+		case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+			c.keyMap.MatchesKeyStroke("move_up", ks):
+			if cursor > 0 {
+				cursor--
+			}
+		case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+			c.keyMap.MatchesKeyStroke("move_down", ks):
+			if cursor < len(options)-1 {
+				cursor++
+			}
+		}
+	}
+}
+
+type reviewChoice int
+
+const (
+	reviewCommit reviewChoice = iota
+	reviewEdit
+	reviewCancel
+)
+
+// review shows the finished message with warnings and asks what to do.
+func (c *CommitComposer) review() reviewChoice {
+	colors := c.ui.colors
+	for {
+		c.drawHeader("Review")
+		for _, line := range strings.Split(c.msg.String(), "\n") {
+			c.ui.write("  %s\r\n", line)
+		}
+		warnings := commitmsg.LengthWarnings(c.msg, c.opts.SubjectMaxLength, c.opts.BodyMaxLength)
+		if c.expandErr != nil {
+			warnings = append(warnings, c.expandErr.Error())
+		}
+		if len(warnings) > 0 {
+			c.ui.write("\r\n")
+			for _, w := range warnings {
+				c.ui.write("%s⚠ %s%s\r\n", colors.BrightYellow, w, colors.Reset)
+			}
+		}
+		c.ui.write("\r\n%sEnter commit · e edit · q cancel%s\r\n", colors.BrightBlack, colors.Reset)
+
+		ks, err := readRebaseKey(c.reader)
+		if err != nil {
+			return reviewCancel
+		}
+		switch {
+		case ks.Equals(kb.NewEnterKeyStroke()), ks.Equals(kb.NewCharKeyStroke('y')):
+			return reviewCommit
+		case ks.Equals(kb.NewCharKeyStroke('e')):
+			return reviewEdit
+		case ks.Equals(kb.NewCharKeyStroke('q')), ks.Equals(kb.NewCtrlKeyStroke('c')),
+			c.keyMap.MatchesKeyStroke("soft_cancel", ks):
+			return reviewCancel
+		}
+	}
+}
+
+// drawHeader clears the screen and shows the title, the header built so
+// far and the hint for the current step.
+func (c *CommitComposer) drawHeader(hint string) {
+	colors := c.ui.colors
+	clearScreen(c.ui.stdout)
+	c.ui.write("%sCompose commit%s\r\n", colors.Bold+colors.BrightCyan, colors.Reset)
+	if header := c.msg.Header(); header != "" {
+		c.ui.write("%s%s%s\r\n", colors.BrightBlack, header, colors.Reset)
+	}
+	c.ui.write("\r\n%s%s%s\r\n", colors.BrightBlack, hint, colors.Reset)
+}
@@ -0,0 +1,139 @@
+package interactive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func newTestCommitComposer(opts CommitComposerOptions, input string) (*CommitComposer, *bytes.Buffer) {
+	var out bytes.Buffer
+	c := NewCommitComposer(opts, nil)
+	c.stdin = strings.NewReader(input)
+	c.ui.stdout = &out
+	return c, &out
+}
+
+func TestCommitComposer_Plain(t *testing.T) {
+	// Subject, two body lines with a paragraph break, Ctrl+D, then commit.
+	c, _ := newTestCommitComposer(CommitComposerOptions{}, "Fix login\rfirst\r\rsecond\x04\r")
+
+	msg, ok := c.Run()
+	if !ok {
+		t.Fatal("expected the message to be confirmed")
+	}
+	if want := "Fix login\n\nfirst\n\nsecond"; msg != want {
+		t.Errorf("message = %q, want %q", msg, want)
+	}
+}
+
+func TestCommitComposer_Conventional(t *testing.T) {
+	opts := CommitComposerOptions{Conventional: true, Types: []string{"feat", "fix"}, Scopes: []string{"ui", "git"}}
+	// Pick "fix", pick scope "git", type the subject, skip the body.
+	c, _ := newTestCommitComposer(opts, "j\rjj\rhandle detached HEAD\r\x04\r")
+
+	msg, ok := c.Run()
+	if !ok {
+		t.Fatal("expected the message to be confirmed")
+	}
+	if msg != "fix(git): handle detached HEAD" {
+		t.Errorf("message = %q", msg)
+	}
+}
+
+func TestCommitComposer_TemplateAndWarnings(t *testing.T) {
+	opts := CommitComposerOptions{
+		Template:         "# Describe the change\nSubject from template\n\nBody from template\n",
+		SubjectMaxLength: 10,
+	}
+	// Accept the prefilled subject and body line, finish, then commit.
+	c, out := newTestCommitComposer(opts, "\r\x04\r")
+
+	msg, ok := c.Run()
+	if !ok {
+		t.Fatal("expected the message to be confirmed")
+	}
+	if msg != "Subject from template\n\nBody from template" {
+		t.Errorf("message = %q", msg)
+	}
+	if !strings.Contains(out.String(), "keep it within 10") {
+		t.Error("expected a subject length warning in the review")
+	}
+}
+
+func TestCommitComposer_Snippets(t *testing.T) {
+	opts := CommitComposerOptions{
+		Snippets:  map[string]string{"ticket": "[{TICKET}] ", "pair": "Co-authored-by: {PAIR}"},
+		Variables: map[string]string{"TICKET": "PROJ-1"},
+	}
+	// Subject with a snippet, a body line with another, whose variable is
+	// asked for, then commit.
+	c, out := newTestCommitComposer(opts, "{{ticket}}fix login\r{{pair}}\x04Ann\r\r")
+
+	msg, ok := c.Run()
+	if !ok {
+		t.Fatal("expected the message to be confirmed")
+	}
+	if want := "[PROJ-1] fix login\n\nCo-authored-by: Ann"; msg != want {
+		t.Errorf("message = %q, want %q", msg, want)
+	}
+	if !strings.Contains(out.String(), "snippets: {{pair}} {{ticket}}") {
+		t.Error("the subject hint should list the snippets")
+	}
+	if !strings.Contains(out.String(), "Value for {PAIR}") {
+		t.Error("expected a prompt for {PAIR}")
+	}
+}
+
+func TestCommitComposer_Ticket(t *testing.T) {
+	opts := CommitComposerOptions{Ticket: func(msg string) string { return "[PROJ-1] " + msg }}
+	c, out := newTestCommitComposer(opts, "fix login\r\x04\r")
+
+	msg, ok := c.Run()
+	if !ok {
+		t.Fatal("expected the message to be confirmed")
+	}
+	if msg != "[PROJ-1] fix login" {
+		t.Errorf("message = %q", msg)
+	}
+	if !strings.Contains(out.String(), "  [PROJ-1] fix login") {
+		t.Error("the review should show the ticket reference")
+	}
+}
+
+func TestCommitComposer_UnknownSnippet(t *testing.T) {
+	c, out := newTestCommitComposer(CommitComposerOptions{}, "{{nope}} fix\r\x04\r")
+
+	msg, ok := c.Run()
+	if !ok {
+		t.Fatal("expected the message to be confirmed")
+	}
+	if msg != "{{nope}} fix" {
+		t.Errorf("an unknown snippet should be kept as typed, got %q", msg)
+	}
+	if !strings.Contains(out.String(), "unknown snippet {{nope}}") {
+		t.Error("the review should explain the unknown snippet")
+	}
+}
+
+func TestCommitComposer_EditAndCancel(t *testing.T) {
+	// Write a subject, choose edit, change it, then cancel from review.
+	c, _ := newTestCommitComposer(CommitComposerOptions{}, "one\r\x04e\x7f\x7f\x7ftwo\r\x04q")
+
+	if _, ok := c.Run(); ok {
+		t.Fatal("q should cancel the commit")
+	}
+	if c.msg.Subject != "two" {
+		t.Errorf("edit should start from the previous values, subject = %q", c.msg.Subject)
+	}
+}
+
+func TestCommitComposer_RequiresSubject(t *testing.T) {
+	c, out := newTestCommitComposer(CommitComposerOptions{}, "\r")
+	if _, ok := c.Run(); ok {
+		t.Fatal("input ended without a subject")
+	}
+	if !strings.Contains(out.String(), "(required)") {
+		t.Error("an empty subject should be rejected")
+	}
+}
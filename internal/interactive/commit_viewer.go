@@ -0,0 +1,94 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/difftool"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+// CommitSource is the git access the commit viewer needs.
+type CommitSource interface {
+	ShowOutput(args []string) (string, error)
+}
+
+// CommitViewer is a full-screen view of one commit: its header, message,
+// diffstat and patch, scrolled in the same pager the log and blame viewers
+// open. Navigation honors the move_up, move_down and soft_cancel bindings
+// of the active keybinding profile.
+type CommitViewer struct {
+	git    CommitSource
+	keyMap *kb.KeyBindingMap
+	colors *ANSIColors
+	stdin  io.Reader
+	stdout io.Writer
+	term   termio.Terminal
+
+	highlight func(diff string) (out string, ok bool, err error)
+}
+
+// NewCommitViewer returns a viewer using the keybinding profile and diff
+// tool configured in cfg. cfg may be nil.
+func NewCommitViewer(src CommitSource, cfg *config.Config) *CommitViewer {
+	var tool string
+	if cfg != nil {
+		tool = cfg.UI.DiffTool
+	}
+	return &CommitViewer{
+		git:       src,
+		keyMap:    resolveResultsKeyMap(cfg),
+		colors:    NewANSIColors(),
+		stdin:     os.Stdin,
+		stdout:    os.Stdout,
+		term:      termio.DefaultTerminal{},
+		highlight: difftool.New(tool).Highlight,
+	}
+}
+
+// Run shows rev, HEAD when it is empty, until the user quits.
+func (v *CommitViewer) Run(rev string) error {
+	if rev == "" {
+		rev = "HEAD"
+	}
+	title := fmt.Sprintf("%s%s%s", v.colors.Bold+v.colors.BrightYellow, rev, v.colors.Reset)
+	pager, err := openCommit(v.git, rev, title, v.colors, v.highlight)
+	if err != nil {
+		return err
+	}
+
+	if f, isFile := v.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := v.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = v.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(v.stdout)
+
+	reader := bufio.NewReader(v.stdin)
+	for {
+		v.render(pager)
+		ks, err := readRebaseKey(reader)
+		if err != nil || pager.handleKey(ks, v.keyMap) {
+			clearScreen(v.stdout)
+			return nil
+		}
+	}
+}
+
+func (v *CommitViewer) render(pager *diffPager) {
+	c := v.colors
+	clearScreen(v.stdout)
+	var b strings.Builder
+	pager.render(&b, c)
+	fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightBlack, diffPagerHelp, c.Reset)
+	_, _ = io.WriteString(v.stdout, b.String())
+}
@@ -0,0 +1,54 @@
+package interactive
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// fakeCommitShower prints a commit with a long patch and records what was
+// shown.
+type fakeCommitShower struct {
+	args []string
+}
+
+func (f *fakeCommitShower) ShowOutput(args []string) (string, error) {
+	f.args = slices.Clone(args)
+	var b strings.Builder
+	b.WriteString("commit abc\nAuthor: Alice <alice@example.com>\n\n    Add pager\n\n a.go | 40 +\n")
+	for i := range 40 {
+		b.WriteString("+line " + string(rune('a'+i%26)) + "\n")
+	}
+	return b.String(), nil
+}
+
+func TestCommitViewer_Run(t *testing.T) {
+	src := &fakeCommitShower{}
+	var out bytes.Buffer
+	v := NewCommitViewer(src, nil)
+	v.stdin = strings.NewReader("jjq")
+	v.stdout = &out
+	v.highlight = nil
+
+	if err := v.Run(""); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if want := []string{"--stat", "--patch", "HEAD"}; !slices.Equal(src.args, want) {
+		t.Errorf("shown %v, want %v", src.args, want)
+	}
+	got := uiutil.StripANSI(out.String())
+	for _, want := range []string{"HEAD\r\n", "Author: Alice", "more line(s)", diffPagerHelp} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+	// Two lines down, the last frame starts at the blank line above the
+	// message.
+	frames := strings.Split(got, "HEAD\r\n\r\n")
+	if last := frames[len(frames)-1]; !strings.HasPrefix(last, "\r\n    Add pager\r\n") {
+		t.Errorf("last frame should be scrolled, got %q", last[:min(len(last), 40)])
+	}
+}
@@ -0,0 +1,350 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+// configEditorRows caps how many keys are drawn at once.
+const configEditorRows = 15
+
+// ConfigStore is the configuration the editor browses and changes.
+// config.Manager implements it; Set validates and saves.
+type ConfigStore interface {
+	Keys() []config.KeyInfo
+	Set(key string, value any) error
+}
+
+// ConfigEditor is a full-screen list of configuration keys with their
+// values, defaults and descriptions. Typing filters the keys, Enter edits
+// the selected one inline and Ctrl+R restores its default. Keys that an
+// environment variable overrides are flagged.
+type ConfigEditor struct {
+	store   ConfigStore
+	state   *UIState
+	infos   map[string]config.KeyInfo
+	editing string // key being edited, empty when browsing
+	value   []rune
+	message string
+	failed  bool
+	keyMap  *kb.KeyBindingMap
+	colors  *ANSIColors
+	stdin   io.Reader
+	stdout  io.Writer
+	term    termio.Terminal
+}
+
+// NewConfigEditor returns an editor over store using the keybinding
+// profile configured in cfg. cfg may be nil.
+func NewConfigEditor(store ConfigStore, cfg *config.Config) *ConfigEditor {
+	e := &ConfigEditor{
+		store:  store,
+		state:  &UIState{context: kb.ContextResults},
+		keyMap: resolveResultsKeyMap(cfg),
+		colors: NewANSIColors(),
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		term:   termio.DefaultTerminal{},
+	}
+	e.reload()
+	return e
+}
+
+// Run shows the editor until the user quits.
+func (e *ConfigEditor) Run() error {
+	if f, isFile := e.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := e.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = e.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(e.stdout)
+
+	reader := bufio.NewReader(e.stdin)
+	for {
+		e.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil || e.handleKey(ks) {
+			clearScreen(e.stdout)
+			return nil
+		}
+	}
+}
+
+// reload reads the keys again, keeping the filter and selection.
+func (e *ConfigEditor) reload() {
+	infos := e.store.Keys()
+	e.infos = make(map[string]config.KeyInfo, len(infos))
+	commands := make([]CommandInfo, len(infos))
+	for i, info := range infos {
+		e.infos[info.Key] = info
+		commands[i] = CommandInfo{Command: info.Key, Description: info.Description}
+	}
+	e.state.commands = commands
+	e.state.UpdateFiltered()
+}
+
+func (e *ConfigEditor) selected() (config.KeyInfo, bool) {
+	cmd := e.state.GetSelectedCommand()
+	if cmd == nil {
+		return config.KeyInfo{}, false
+	}
+	info, ok := e.infos[cmd.Command]
+	return info, ok
+}
+
+// handleKey applies one keystroke and reports whether the editor is done.
+func (e *ConfigEditor) handleKey(ks kb.KeyStroke) bool {
+	if e.editing != "" {
+		e.handleEditKey(ks)
+		return false
+	}
+	s := e.state
+	switch {
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewEscapeKeyStroke()),
+		e.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true
+	case ks.Equals(kb.NewUpArrowKeyStroke()), e.keyMap.MatchesKeyStroke("move_up", ks):
+		s.MoveUp()
+	case ks.Equals(kb.NewDownArrowKeyStroke()), e.keyMap.MatchesKeyStroke("move_down", ks):
+		s.MoveDown()
+	case ks.Equals(kb.NewEnterKeyStroke()):
+		e.startEdit()
+	case ks.Equals(kb.NewCtrlKeyStroke('r')):
+		e.restoreDefault()
+	case ks.Equals(kb.NewRawKeyStroke([]byte{0x7f})), ks.Equals(kb.NewCtrlKeyStroke('h')):
+		s.RemoveChar()
+	case ks.Kind == kb.KeyStrokeRawSeq:
+		if r, size := utf8.DecodeRune(ks.Seq); size == len(ks.Seq) && unicode.IsPrint(r) {
+			s.AddRune(r)
+		}
+	}
+	return false
+}
+
+func (e *ConfigEditor) handleEditKey(ks kb.KeyStroke) {
+	switch {
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewEscapeKeyStroke()):
+		e.editing, e.message = "", ""
+	case ks.Equals(kb.NewEnterKeyStroke()):
+		e.save(e.editing, string(e.value))
+	case ks.Equals(kb.NewCtrlKeyStroke('u')):
+		e.value = e.value[:0]
+	case ks.Equals(kb.NewRawKeyStroke([]byte{0x7f})), ks.Equals(kb.NewCtrlKeyStroke('h')):
+		if len(e.value) > 0 {
+			e.value = e.value[:len(e.value)-1]
+		}
+	case ks.Kind == kb.KeyStrokeRawSeq:
+		if r, size := utf8.DecodeRune(ks.Seq); size == len(ks.Seq) && unicode.IsPrint(r) {
+			e.value = append(e.value, r)
+		}
+	}
+}
+
+// startEdit opens the inline editor on the selected key, prefilled with
+// its value. Credentials start empty so they are never shown.
+func (e *ConfigEditor) startEdit() {
+	info, ok := e.selected()
+	if !ok {
+		return
+	}
+	if reason := readOnlyReason(info); reason != "" {
+		e.message, e.failed = reason, true
+		return
+	}
+	e.editing, e.message = info.Key, ""
+	e.value = []rune(formatConfigValue(info.Value))
+	if isSecretKey(info.Key) {
+		e.value = nil
+	}
+}
+
+// restoreDefault sets the selected key back to its built-in default.
+func (e *ConfigEditor) restoreDefault() {
+	info, ok := e.selected()
+	if !ok || readOnlyReason(info) != "" {
+		return
+	}
+	e.apply(info.Key, info.Default)
+}
+
+// save parses raw as the key's type, then validates and saves it. On
+// failure the message explains why and editing continues.
+func (e *ConfigEditor) save(key, raw string) {
+	value, err := parseConfigValue(e.infos[key].Value, raw)
+	if err != nil {
+		e.message, e.failed = err.Error(), true
+		return
+	}
+	e.apply(key, value)
+}
+
+func (e *ConfigEditor) apply(key string, value any) {
+	if err := e.store.Set(key, value); err != nil {
+		e.message, e.failed = err.Error(), true
+		return
+	}
+	e.editing = ""
+	e.message, e.failed = fmt.Sprintf("Saved %s", key), false
+	e.reload()
+}
+
+// readOnlyReason explains why a key cannot be edited inline, or returns
+// an empty string when it can.
+func readOnlyReason(info config.KeyInfo) string {
+	if strings.HasPrefix(info.Key, "meta.") {
+		return "meta keys are maintained by ggc"
+	}
+	switch editableKind(info.Value) {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64:
+		return ""
+	}
+	return fmt.Sprintf("%s is a list or map; edit it in the config file", info.Key)
+}
+
+func editableKind(value any) reflect.Kind {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return reflect.Invalid
+	}
+	if v.Kind() == reflect.Pointer {
+		return v.Type().Elem().Kind()
+	}
+	return v.Kind()
+}
+
+// parseConfigValue converts raw to the type of current.
+func parseConfigValue(current any, raw string) (any, error) {
+	raw = strings.TrimSpace(raw)
+	switch editableKind(current) {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not true or false", raw)
+		}
+		return b, nil
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a whole number", raw)
+		}
+		return n, nil
+	default:
+		return raw, nil
+	}
+}
+
+// formatConfigValue renders a value for display and editing; unset
+// optional values are empty.
+func formatConfigValue(value any) string {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return ""
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice && v.Len() == 0 || v.Kind() == reflect.Map && v.Len() == 0 {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func isSecretKey(key string) bool {
+	return strings.HasSuffix(key, ".token")
+}
+
+func (e *ConfigEditor) render() {
+	c, s := e.colors, e.state
+	clearScreen(e.stdout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sConfiguration%s\r\n", c.Bold+c.BrightCyan, c.Reset)
+	fmt.Fprintf(&b, "%sFilter:%s %s\r\n\r\n", c.BrightBlue, c.Reset, s.input)
+
+	width := 0
+	for _, cmd := range s.filtered {
+		width = max(width, len(cmd.Command))
+	}
+	width = min(width, 40)
+	start := max(s.selected-configEditorRows+1, 0)
+	end := min(start+configEditorRows, len(s.filtered))
+	for i := start; i < end; i++ {
+		info := e.infos[s.filtered[i].Command]
+		cursor := "  "
+		if i == s.selected {
+			cursor = c.BrightCyan + "▶ " + c.Reset
+		}
+		fmt.Fprintf(&b, "%s%-*s  %s\r\n", cursor, width, ellipsis(info.Key, width), e.valueLabel(info))
+	}
+	if len(s.filtered) == 0 {
+		fmt.Fprintf(&b, "%sNo matches.%s\r\n", c.BrightBlack, c.Reset)
+	} else if hidden := len(s.filtered) - (end - start); hidden > 0 {
+		fmt.Fprintf(&b, "%s… %d more%s\r\n", c.BrightBlack, hidden, c.Reset)
+	}
+
+	if info, ok := e.selected(); ok {
+		b.WriteString("\r\n")
+		if info.Description != "" {
+			fmt.Fprintf(&b, "%s\r\n", info.Description)
+		}
+		fmt.Fprintf(&b, "%sDefault:%s %s\r\n", c.BrightBlack, c.Reset, orUnset(formatConfigValue(info.Default)))
+		if info.Env != "" {
+			fmt.Fprintf(&b, "%sOverridden by $%s while it is set%s\r\n", c.BrightMagenta, info.Env, c.Reset)
+		}
+	}
+	if e.editing != "" {
+		fmt.Fprintf(&b, "\r\n%s%s =%s %s█\r\n", c.Bold, e.editing, c.Reset, string(e.value))
+	}
+	if e.message != "" {
+		color := c.BrightGreen
+		if e.failed {
+			color = c.BrightRed
+		}
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", color, e.message, c.Reset)
+	}
+	help := "↑/↓ move · type to filter · enter edit · ctrl+r default · esc quit"
+	if e.editing != "" {
+		help = "enter save · ctrl+u clear · esc cancel"
+	}
+	fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightBlack, help, c.Reset)
+	_, _ = io.WriteString(e.stdout, b.String())
+}
+
+// valueLabel renders a key's value: changed values stand out and
+// environment overrides are tagged.
+func (e *ConfigEditor) valueLabel(info config.KeyInfo) string {
+	c := e.colors
+	value := formatConfigValue(info.Value)
+	if isSecretKey(info.Key) && value != "" {
+		value = "********"
+	}
+	label := c.BrightBlack + orUnset(value) + c.Reset
+	if formatConfigValue(info.Value) != formatConfigValue(info.Default) {
+		label = c.BrightYellow + orUnset(value) + c.Reset
+	}
+	if info.Env != "" {
+		label += fmt.Sprintf(" %s[$%s]%s", c.BrightMagenta, info.Env, c.Reset)
+	}
+	return label
+}
+
+func orUnset(s string) string {
+	if s == "" {
+		return "(unset)"
+	}
+	return s
+}
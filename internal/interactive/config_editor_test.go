@@ -0,0 +1,87 @@
+package interactive
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+type fakeConfigStore struct {
+	infos []config.KeyInfo
+	set   map[string]any
+}
+
+func (f *fakeConfigStore) Keys() []config.KeyInfo { return f.infos }
+
+func (f *fakeConfigStore) Set(key string, value any) error {
+	if key == "behavior.confirm-destructive" && value != "always" {
+		return errors.New("must be one of: simple, always, never")
+	}
+	f.set[key] = value
+	for i := range f.infos {
+		if f.infos[i].Key == key {
+			f.infos[i].Value = value
+		}
+	}
+	return nil
+}
+
+func runConfigEditor(t *testing.T, input string) (*fakeConfigStore, string) {
+	t.Helper()
+	store := &fakeConfigStore{set: map[string]any{}, infos: []config.KeyInfo{
+		{Key: "behavior.confirm-destructive", Value: "simple", Default: "simple", Description: "How destructive commands ask for confirmation"},
+		{Key: "commit.subject-max-length", Value: 50, Default: 72},
+		{Key: "commit.types", Value: []string{"feat"}},
+		{Key: "ui.color", Value: true, Default: true, Env: "NO_COLOR"},
+	}}
+	var out bytes.Buffer
+	e := NewConfigEditor(store, nil)
+	e.stdin = strings.NewReader(input)
+	e.stdout = &out
+	if err := e.Run(); err != nil {
+		t.Fatal(err)
+	}
+	return store, uiutil.StripANSI(out.String())
+}
+
+func TestConfigEditor_Edit(t *testing.T) {
+	store, out := runConfigEditor(t, "subject\r\x15100\r\x03")
+	if store.set["commit.subject-max-length"] != 100 {
+		t.Errorf("set = %v, output:\n%s", store.set, out)
+	}
+	if !strings.Contains(out, "Saved commit.subject-max-length") || !strings.Contains(out, "Default: 72") {
+		t.Errorf("unexpected render:\n%s", out)
+	}
+}
+
+func TestConfigEditor_ValidationKeepsEditing(t *testing.T) {
+	store, out := runConfigEditor(t, "confirm\r\x15nope\r\x15always\r\x03")
+	if !strings.Contains(out, "must be one of") || store.set["behavior.confirm-destructive"] != "always" {
+		t.Errorf("set = %v, output:\n%s", store.set, out)
+	}
+}
+
+func TestConfigEditor_RejectsBadTypesAndLists(t *testing.T) {
+	store, out := runConfigEditor(t, "subject\r\x15ten\r\x03\x03")
+	if _, ok := store.set["commit.subject-max-length"]; ok || !strings.Contains(out, `"ten" is not a whole number`) {
+		t.Errorf("set = %v, output:\n%s", store.set, out)
+	}
+	_, out = runConfigEditor(t, "types\r\x03")
+	if !strings.Contains(out, "commit.types is a list or map") {
+		t.Errorf("lists should be read-only, output:\n%s", out)
+	}
+}
+
+func TestConfigEditor_RestoreDefaultAndEnv(t *testing.T) {
+	store, out := runConfigEditor(t, "ui.color\x12\x03")
+	if !strings.Contains(out, "[$NO_COLOR]") || !strings.Contains(out, "Overridden by $NO_COLOR") {
+		t.Errorf("env override should be flagged:\n%s", out)
+	}
+	if store.set["ui.color"] != true {
+		t.Errorf("ctrl+r should restore the default, set = %v", store.set)
+	}
+}
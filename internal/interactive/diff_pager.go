@@ -0,0 +1,82 @@
+package interactive
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/difftool"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+)
+
+// diffPagerRows caps how many diff lines are drawn at once.
+const diffPagerRows = 30
+
+// diffPager scrolls through one commit's diff inside a full-screen view.
+type diffPager struct {
+	title string
+	lines []string
+	top   int
+}
+
+// commitShower reads a commit the way git show prints it.
+type commitShower interface {
+	ShowOutput(args []string) (string, error)
+}
+
+// openCommit reads the stat and patch of hash, limited to paths when any
+// are given, colored and passed through highlight when it is set and color
+// is on.
+func openCommit(src commitShower, hash, title string, colors *ANSIColors,
+	highlight func(diff string) (string, bool, error), paths ...string) (*diffPager, error) {
+	args := []string{"--stat", "--patch", hash}
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	out, err := src.ShowOutput(args)
+	if err != nil {
+		return nil, err
+	}
+	rendered := difftool.Colorize(out, colors)
+	if colors.Reset != "" && highlight != nil {
+		if hl, ok, err := highlight(out); ok && err == nil {
+			rendered = hl
+		}
+	}
+	return &diffPager{title: title, lines: strings.Split(strings.TrimRight(rendered, "\n"), "\n")}, nil
+}
+
+// handleKey scrolls the diff and reports whether the pager should close:
+// q, Enter, Ctrl+C or soft_cancel go back.
+func (p *diffPager) handleKey(ks kb.KeyStroke, keyMap *kb.KeyBindingMap) (closed bool) {
+	last := max(len(p.lines)-diffPagerRows, 0)
+	switch {
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewCharKeyStroke('q')),
+		ks.Equals(kb.NewEnterKeyStroke()), keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true
+	case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+		keyMap.MatchesKeyStroke("move_up", ks):
+		p.top = max(p.top-1, 0)
+	case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+		keyMap.MatchesKeyStroke("move_down", ks):
+		p.top = min(p.top+1, last)
+	case ks.Equals(kb.NewCtrlKeyStroke('u')):
+		p.top = max(p.top-diffPagerRows/2, 0)
+	case ks.Equals(kb.NewCtrlKeyStroke('d')), ks.Equals(kb.NewCharKeyStroke(' ')):
+		p.top = min(p.top+diffPagerRows/2, last)
+	}
+	return false
+}
+
+func (p *diffPager) render(b *strings.Builder, c *ANSIColors) {
+	fmt.Fprintf(b, "%s\r\n\r\n", p.title)
+	end := min(p.top+diffPagerRows, len(p.lines))
+	for _, line := range p.lines[p.top:end] {
+		fmt.Fprintf(b, "%s%s\r\n", line, c.Reset)
+	}
+	if end < len(p.lines) {
+		fmt.Fprintf(b, "%s… %d more line(s)%s\r\n", c.BrightBlack, len(p.lines)-end, c.Reset)
+	}
+}
+
+// diffPagerHelp is the key help shown under a diff.
+const diffPagerHelp = "j/k scroll · ctrl+d/ctrl+u page · q back"
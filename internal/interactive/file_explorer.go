@@ -0,0 +1,473 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/difftool"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+const (
+	// explorerRows caps how many tree rows are drawn at once.
+	explorerRows = 15
+	// explorerPreviewLines caps the diff preview of the highlighted file.
+	explorerPreviewLines = 12
+)
+
+// ExplorerSource is the repository the file explorer reads and stages in.
+// Paths are relative to the top of the working tree.
+type ExplorerSource interface {
+	TopLevel() (string, error)
+	StatusSummary() (*git.StatusSummary, error)
+	DiffWith(args []string) (string, error)
+	Add(files ...string) error
+	RestoreStaged(paths ...string) error
+}
+
+// lfsLister is implemented by sources that can list the files stored in
+// Git LFS, so the explorer can tell pointers from downloaded content.
+type lfsLister interface {
+	LFSFiles() ([]string, error)
+}
+
+// explorerRow is one line of the tree: a directory, or a changed file
+// when entry is set.
+type explorerRow struct {
+	path  string // directories end in a slash
+	name  string
+	depth int
+	entry *git.StatusEntry
+}
+
+// FileExplorer is a full-screen tree of the changed and untracked files,
+// grouped by directory, with git status badges and a diff preview of the
+// highlighted file. s stages the highlighted file or everything under the
+// highlighted directory, u unstages it, y copies its path and Enter folds
+// a directory.
+// Navigation honors the move_up, move_down and soft_cancel bindings of the
+// active keybinding profile; arrow keys and j/k always work as well.
+type FileExplorer struct {
+	git       ExplorerSource
+	root      string
+	entries   []git.StatusEntry
+	rows      []explorerRow
+	collapsed map[string]bool
+	cursor    int
+	message   string
+	keyMap    *kb.KeyBindingMap
+	colors    *ANSIColors
+	stdin     io.Reader
+	stdout    io.Writer
+	term      termio.Terminal
+
+	highlight func(diff string) (out string, ok bool, err error)
+	previews  map[string][]string
+	// lfs maps the LFS files among the entries to whether the working
+	// tree holds only their pointer.
+	lfs  map[string]bool
+	copy func(text string) error
+}
+
+// NewFileExplorer returns an explorer over src using the keybinding
+// profile and diff tool configured in cfg. cfg may be nil.
+func NewFileExplorer(src ExplorerSource, cfg *config.Config) *FileExplorer {
+	var tool string
+	if cfg != nil {
+		tool = cfg.UI.DiffTool
+	}
+	e := &FileExplorer{
+		git:       src,
+		collapsed: make(map[string]bool),
+		keyMap:    resolveResultsKeyMap(cfg),
+		colors:    NewANSIColors(),
+		stdin:     os.Stdin,
+		stdout:    os.Stdout,
+		term:      termio.DefaultTerminal{},
+		previews:  make(map[string][]string),
+		lfs:       make(map[string]bool),
+	}
+	if e.colors.Reset != "" {
+		e.highlight = difftool.New(tool).Highlight
+	}
+	e.copy = clipboardCopier(&e.stdout)
+	return e
+}
+
+// Run shows the explorer until the user quits. Staging happens as keys are
+// pressed, so there is nothing to apply on exit.
+func (e *FileExplorer) Run() error {
+	root, err := e.git.TopLevel()
+	if err != nil {
+		return err
+	}
+	e.root = root
+	if err := e.reload(); err != nil {
+		return err
+	}
+	if len(e.entries) == 0 {
+		_, _ = fmt.Fprintln(e.stdout, "No changes to stage.")
+		return nil
+	}
+
+	if f, ok := e.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := e.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = e.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(e.stdout)
+
+	reader := bufio.NewReader(e.stdin)
+	for {
+		e.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil || e.handleKey(ks) {
+			clearScreen(e.stdout)
+			return nil
+		}
+	}
+}
+
+// reload reads the status again and rebuilds the tree, keeping the cursor
+// on the same path when it is still there.
+func (e *FileExplorer) reload() error {
+	var current string
+	if e.cursor < len(e.rows) {
+		current = e.rows[e.cursor].path
+	}
+	summary, err := e.git.StatusSummary()
+	if err != nil {
+		return err
+	}
+	e.entries = e.entries[:0]
+	for _, entry := range summary.Entries {
+		if entry.Kind != git.StatusIgnored {
+			e.entries = append(e.entries, entry)
+		}
+	}
+	sort.Slice(e.entries, func(i, j int) bool { return e.entries[i].Path < e.entries[j].Path })
+	clear(e.previews)
+	e.loadLFS()
+	e.buildRows()
+	for i, row := range e.rows {
+		if row.path == current {
+			e.cursor = i
+			return nil
+		}
+	}
+	e.cursor = min(e.cursor, max(len(e.rows)-1, 0))
+	return nil
+}
+
+// loadLFS notes which entries are stored in Git LFS and whether their
+// content has been downloaded. Sources that cannot list LFS files, and
+// errors listing them, leave every entry unmarked.
+func (e *FileExplorer) loadLFS() {
+	clear(e.lfs)
+	lister, ok := e.git.(lfsLister)
+	if !ok {
+		return
+	}
+	files, err := lister.LFSFiles()
+	if err != nil {
+		return
+	}
+	stored := make(map[string]bool, len(files))
+	for _, f := range files {
+		stored[f] = true
+	}
+	for _, entry := range e.entries {
+		if stored[entry.Path] {
+			e.lfs[entry.Path] = isLFSPointerFile(filepath.Join(e.root, filepath.FromSlash(entry.Path)))
+		}
+	}
+}
+
+// isLFSPointerFile reports whether the file at path is a Git LFS pointer.
+// Only the first bytes are read: pointers are small and content is not.
+func isLFSPointerFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	buf := make([]byte, 1024)
+	n, _ := io.ReadFull(f, buf)
+	return git.IsLFSPointer(buf[:n])
+}
+
+// lfsTag returns the marker of an LFS file, or "" for other files.
+func (e *FileExplorer) lfsTag(path string) string {
+	pointer, ok := e.lfs[path]
+	switch {
+	case !ok:
+		return ""
+	case pointer:
+		return " " + e.colors.BrightYellow + "[LFS pointer]" + e.colors.Reset
+	default:
+		return " " + e.colors.BrightBlack + "[LFS]" + e.colors.Reset
+	}
+}
+
+// buildRows lays the sorted entries out as a tree. Paths sharing a
+// directory are adjacent once sorted, so each directory row is emitted
+// just before its first file.
+func (e *FileExplorer) buildRows() {
+	e.rows = e.rows[:0]
+	seen := make(map[string]bool)
+	for i := range e.entries {
+		entry := &e.entries[i]
+		parts := strings.Split(strings.TrimSuffix(entry.Path, "/"), "/")
+		hidden := false
+		for depth := 0; depth < len(parts)-1 && !hidden; depth++ {
+			dir := strings.Join(parts[:depth+1], "/") + "/"
+			if !seen[dir] {
+				seen[dir] = true
+				e.rows = append(e.rows, explorerRow{path: dir, name: parts[depth] + "/", depth: depth})
+			}
+			hidden = e.collapsed[dir]
+		}
+		if hidden {
+			continue
+		}
+		name := parts[len(parts)-1]
+		if strings.HasSuffix(entry.Path, "/") {
+			name += "/"
+		}
+		e.rows = append(e.rows, explorerRow{path: entry.Path, name: name, depth: len(parts) - 1, entry: entry})
+	}
+}
+
+// handleKey applies one keystroke and reports whether the explorer is
+// done.
+func (e *FileExplorer) handleKey(ks kb.KeyStroke) bool {
+	e.message = ""
+	switch {
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewCharKeyStroke('q')),
+		e.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true
+	case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+		e.keyMap.MatchesKeyStroke("move_up", ks):
+		e.moveCursor(-1)
+	case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+		e.keyMap.MatchesKeyStroke("move_down", ks):
+		e.moveCursor(1)
+	case ks.Equals(kb.NewEnterKeyStroke()):
+		e.toggleFold()
+	case ks.Equals(kb.NewCharKeyStroke('s')), ks.Equals(kb.NewCharKeyStroke(' ')):
+		e.stage(false)
+	case ks.Equals(kb.NewCharKeyStroke('u')):
+		e.stage(true)
+	case ks.Equals(kb.NewCharKeyStroke('y')):
+		if len(e.rows) > 0 {
+			e.message = copyMessage(e.copy, strings.TrimSuffix(e.rows[e.cursor].path, "/"))
+		}
+	}
+	return false
+}
+
+func (e *FileExplorer) moveCursor(delta int) {
+	next := e.cursor + delta
+	if next >= 0 && next < len(e.rows) {
+		e.cursor = next
+	}
+}
+
+// toggleFold folds or unfolds the highlighted directory.
+func (e *FileExplorer) toggleFold() {
+	if len(e.rows) == 0 || e.rows[e.cursor].entry != nil {
+		return
+	}
+	dir := e.rows[e.cursor].path
+	e.collapsed[dir] = !e.collapsed[dir]
+	e.buildRows()
+}
+
+// stage stages (or, with unstage set, unstages) the highlighted file or
+// every change under the highlighted directory, then reloads the tree.
+func (e *FileExplorer) stage(unstage bool) {
+	if len(e.rows) == 0 {
+		return
+	}
+	row := e.rows[e.cursor]
+	paths := []string{topPathspec(row.path)}
+	if row.entry != nil && row.entry.OrigPath != "" {
+		paths = append(paths, topPathspec(row.entry.OrigPath))
+	}
+	var err error
+	if unstage {
+		err = e.git.RestoreStaged(paths...)
+	} else {
+		err = e.git.Add(paths...)
+	}
+	if err != nil {
+		e.message = err.Error()
+		return
+	}
+	if err := e.reload(); err != nil {
+		e.message = err.Error()
+	}
+}
+
+// topPathspec names a path relative to the top of the working tree, so
+// git finds it from any subdirectory, and keeps glob characters literal.
+func topPathspec(path string) string {
+	return ":(top,literal)" + strings.TrimSuffix(path, "/")
+}
+
+// badge returns the two-letter status of an entry as git status --short
+// prints it, colored by whether each side is staged.
+func (e *FileExplorer) badge(entry *git.StatusEntry) string {
+	c := e.colors
+	switch entry.Kind {
+	case git.StatusUntracked:
+		return c.BrightRed + "??" + c.Reset
+	case git.StatusUnmerged:
+		return c.BrightMagenta + "UU" + c.Reset
+	}
+	code := func(b byte) byte {
+		if b == '.' {
+			return ' '
+		}
+		return b
+	}
+	return fmt.Sprintf("%s%c%s%s%c%s", c.BrightGreen, code(entry.Index), c.Reset, c.BrightRed, code(entry.WorkTree), c.Reset)
+}
+
+// dirSummary counts the files under dir and how many of them have staged
+// and unstaged changes.
+func (e *FileExplorer) dirSummary(dir string) (files, staged, unstaged int) {
+	for _, entry := range e.entries {
+		if !strings.HasPrefix(entry.Path, dir) {
+			continue
+		}
+		files++
+		switch entry.Kind {
+		case git.StatusUntracked, git.StatusUnmerged:
+			unstaged++
+		default:
+			if entry.Index != '.' {
+				staged++
+			}
+			if entry.WorkTree != '.' {
+				unstaged++
+			}
+		}
+	}
+	return files, staged, unstaged
+}
+
+func (e *FileExplorer) render() {
+	c := e.colors
+	clearScreen(e.stdout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sStage files%s\r\n\r\n", c.Bold+c.BrightCyan, c.Reset)
+
+	start := max(e.cursor-explorerRows+1, 0)
+	end := min(start+explorerRows, len(e.rows))
+	for i := start; i < end; i++ {
+		row := e.rows[i]
+		marker := "  "
+		if i == e.cursor {
+			marker = c.BrightGreen + "› " + c.Reset
+		}
+		indent := strings.Repeat("  ", row.depth)
+		if row.entry != nil {
+			fmt.Fprintf(&b, "%s%s %s%s%s\r\n", marker, e.badge(row.entry), indent, row.name, e.lfsTag(row.path))
+			continue
+		}
+		fold := "▾"
+		if e.collapsed[row.path] {
+			fold = "▸"
+		}
+		files, staged, _ := e.dirSummary(row.path)
+		fmt.Fprintf(&b, "%s   %s%s%s %s%s %s(%d/%d staged)%s\r\n", marker, indent, c.BrightBlue, fold, row.name, c.Reset,
+			c.BrightBlack, staged, files, c.Reset)
+	}
+	if len(e.rows) == 0 {
+		fmt.Fprintf(&b, "%sNo changes left.%s\r\n", c.BrightBlack, c.Reset)
+	} else if hidden := len(e.rows) - (end - start); hidden > 0 {
+		fmt.Fprintf(&b, "%s… %d more%s\r\n", c.BrightBlack, hidden, c.Reset)
+	}
+
+	if len(e.rows) > 0 {
+		b.WriteString("\r\n")
+		e.renderPreview(&b, e.rows[e.cursor])
+	}
+	if e.message != "" {
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightRed, e.message, c.Reset)
+	}
+	fmt.Fprintf(&b, "\r\n%sj/k move · [s]tage · [u]nstage · enter fold · [y]ank path · q quit%s\r\n", c.BrightBlack, c.Reset)
+	_, _ = io.WriteString(e.stdout, b.String())
+}
+
+func (e *FileExplorer) renderPreview(b *strings.Builder, row explorerRow) {
+	c := e.colors
+	if row.entry == nil {
+		files, staged, unstaged := e.dirSummary(row.path)
+		fmt.Fprintf(b, "%s%s: %d file(s), %d with staged and %d with unstaged changes%s\r\n",
+			c.BrightBlack, row.path, files, staged, unstaged, c.Reset)
+		return
+	}
+	lines, ok := e.previews[row.path]
+	if !ok {
+		lines = e.preview(row.entry)
+		e.previews[row.path] = lines
+	}
+	for i, line := range lines {
+		if i == explorerPreviewLines {
+			fmt.Fprintf(b, "%s… %d more line(s)%s\r\n", c.BrightBlack, len(lines)-i, c.Reset)
+			return
+		}
+		fmt.Fprintf(b, "%s%s\r\n", line, c.Reset)
+	}
+}
+
+// preview returns the colored diff of an entry: the unstaged changes when
+// there are any, otherwise the staged ones. Untracked files show their
+// first lines instead, and LFS files whose content is not downloaded say
+// so above the diff of their pointer.
+func (e *FileExplorer) preview(entry *git.StatusEntry) []string {
+	if e.lfs[entry.Path] {
+		notice := e.colors.BrightYellow + "Stored in Git LFS but not downloaded; run 'ggc lfs pull' to fetch the content."
+		return append([]string{notice}, e.diffPreview(entry)...)
+	}
+	return e.diffPreview(entry)
+}
+
+func (e *FileExplorer) diffPreview(entry *git.StatusEntry) []string {
+	if entry.Kind == git.StatusUntracked {
+		return previewPath(filepath.Join(e.root, filepath.FromSlash(entry.Path)))
+	}
+	args := []string{"--no-color", "--no-ext-diff"}
+	if entry.Kind != git.StatusUnmerged && entry.WorkTree == '.' {
+		args = append(args, "--cached")
+	}
+	args = append(args, "--", topPathspec(entry.Path))
+	diff, err := e.git.DiffWith(args)
+	if err != nil {
+		return []string{e.colors.BrightRed + err.Error()}
+	}
+	if strings.TrimSpace(diff) == "" {
+		return []string{e.colors.BrightBlack + "(no textual changes)"}
+	}
+	rendered := difftool.Colorize(diff, e.colors)
+	if e.highlight != nil {
+		if out, ok, err := e.highlight(diff); ok && err == nil {
+			rendered = out
+		}
+	}
+	return strings.Split(strings.TrimRight(rendered, "\n"), "\n")
+}
@@ -0,0 +1,180 @@
+package interactive
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// fakeExplorerSource serves a fixed status and records staging calls.
+type fakeExplorerSource struct {
+	root     string
+	entries  []git.StatusEntry
+	diffArgs [][]string
+	added    []string
+	unstaged []string
+}
+
+func (f *fakeExplorerSource) TopLevel() (string, error) { return f.root, nil }
+
+func (f *fakeExplorerSource) StatusSummary() (*git.StatusSummary, error) {
+	return &git.StatusSummary{Entries: slices.Clone(f.entries)}, nil
+}
+
+func (f *fakeExplorerSource) DiffWith(args []string) (string, error) {
+	f.diffArgs = append(f.diffArgs, args)
+	return stagerDiff, nil
+}
+
+func (f *fakeExplorerSource) Add(files ...string) error {
+	f.added = append(f.added, files...)
+	return nil
+}
+
+func (f *fakeExplorerSource) RestoreStaged(paths ...string) error {
+	f.unstaged = append(f.unstaged, paths...)
+	return nil
+}
+
+// The real client is what `ggc add explore` hands the explorer.
+var _ ExplorerSource = (*git.Client)(nil)
+
+func explorerEntries() []git.StatusEntry {
+	return []git.StatusEntry{
+		{Kind: git.StatusOrdinary, Index: '.', WorkTree: 'M', Path: "cmd/add.go"},
+		{Kind: git.StatusOrdinary, Index: 'M', WorkTree: '.', Path: "cmd/command/basics.go"},
+		{Kind: git.StatusUntracked, Path: "notes.txt"},
+		{Kind: git.StatusRenamed, Index: 'R', WorkTree: '.', Path: "docs/new.md", OrigPath: "docs/old.md"},
+	}
+}
+
+func runFileExplorer(t *testing.T, src *fakeExplorerSource, input string) string {
+	t.Helper()
+	var out bytes.Buffer
+	e := NewFileExplorer(src, nil)
+	e.stdin = strings.NewReader(input)
+	e.stdout = &out
+	e.copy = func(text string) error { return uiutil.CopyOSC52(&out, text) }
+	if err := e.Run(); err != nil {
+		t.Fatal(err)
+	}
+	return uiutil.StripANSI(out.String())
+}
+
+func TestFileExplorer_Tree(t *testing.T) {
+	src := &fakeExplorerSource{entries: explorerEntries()}
+	out := runFileExplorer(t, src, "q")
+	for _, want := range []string{
+		"▾ cmd/ (1/2 staged)",
+		" M   add.go",
+		"   ▾ command/ (1/1 staged)",
+		"M      basics.go",
+		"▾ docs/ (1/1 staged)",
+		"R    new.md",
+		"?? notes.txt",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("tree missing %q in %q", want, out)
+		}
+	}
+}
+
+func TestFileExplorer_StageFileAndDirectory(t *testing.T) {
+	src := &fakeExplorerSource{entries: explorerEntries()}
+	// Stage cmd/add.go, then the whole cmd/ directory, then unstage the rename.
+	runFileExplorer(t, src, "jsksjjjjjuq")
+	if want := []string{":(top,literal)cmd/add.go", ":(top,literal)cmd"}; !slices.Equal(src.added, want) {
+		t.Errorf("added %v, want %v", src.added, want)
+	}
+	if want := []string{":(top,literal)docs/new.md", ":(top,literal)docs/old.md"}; !slices.Equal(src.unstaged, want) {
+		t.Errorf("unstaged %v, want %v", src.unstaged, want)
+	}
+}
+
+func TestFileExplorer_CopyPath(t *testing.T) {
+	src := &fakeExplorerSource{entries: explorerEntries()}
+	out := runFileExplorer(t, src, "jyq")
+	if !strings.Contains(out, "Copied cmd/add.go") {
+		t.Errorf("copy message missing in %q", out)
+	}
+	if !strings.Contains(out, "\x1b]52;c;Y21kL2FkZC5nbw==\a") {
+		t.Errorf("clipboard sequence missing in %q", out)
+	}
+}
+
+func TestFileExplorer_FoldDirectory(t *testing.T) {
+	src := &fakeExplorerSource{entries: explorerEntries()}
+	out := runFileExplorer(t, src, "\rq")
+	last := out[strings.LastIndex(out, "Stage files"):]
+	if !strings.Contains(last, "▸ cmd/") || strings.Contains(last, "add.go") {
+		t.Errorf("cmd/ should be folded: %q", last)
+	}
+}
+
+func TestFileExplorer_Preview(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "notes.txt"), []byte("remember the milk\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	src := &fakeExplorerSource{root: root, entries: explorerEntries()}
+	// Preview cmd/add.go (unstaged), cmd/command/basics.go (staged) and
+	// notes.txt (untracked).
+	out := runFileExplorer(t, src, "jjjjjjq")
+	if len(src.diffArgs) != 3 {
+		t.Fatalf("diffs %v", src.diffArgs)
+	}
+	if slices.Contains(src.diffArgs[0], "--cached") || !slices.Contains(src.diffArgs[1], "--cached") {
+		t.Errorf("unexpected diff args %v", src.diffArgs)
+	}
+	if !strings.Contains(out, "+ONE") || !strings.Contains(out, "remember the milk") {
+		t.Errorf("preview missing from %q", out)
+	}
+}
+
+// lfsExplorerSource also lists the files stored in Git LFS.
+type lfsExplorerSource struct {
+	fakeExplorerSource
+	lfsFiles []string
+}
+
+func (f *lfsExplorerSource) LFSFiles() ([]string, error) { return f.lfsFiles, nil }
+
+func TestFileExplorer_LFS(t *testing.T) {
+	root := t.TempDir()
+	pointer := "version https://git-lfs.github.com/spec/v1\noid sha256:4d7a\nsize 4096\n"
+	for name, content := range map[string]string{"logo.png": "\x89PNG real content", "video.mp4": pointer} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	src := &lfsExplorerSource{
+		fakeExplorerSource: fakeExplorerSource{root: root, entries: []git.StatusEntry{
+			{Kind: git.StatusOrdinary, Index: '.', WorkTree: 'M', Path: "logo.png"},
+			{Kind: git.StatusOrdinary, Index: '.', WorkTree: 'M', Path: "main.go"},
+			{Kind: git.StatusOrdinary, Index: '.', WorkTree: 'M', Path: "video.mp4"},
+		}},
+		lfsFiles: []string{"logo.png", "video.mp4"},
+	}
+	var out bytes.Buffer
+	e := NewFileExplorer(src, nil)
+	e.stdin = strings.NewReader("jjq")
+	e.stdout = &out
+	if err := e.Run(); err != nil {
+		t.Fatal(err)
+	}
+	got := uiutil.StripANSI(out.String())
+	for _, want := range []string{"logo.png [LFS]\r\n", "main.go\r\n", "video.mp4 [LFS pointer]\r\n", "not downloaded; run 'ggc lfs pull'"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "not downloaded") != 1 {
+		t.Errorf("only the pointer's preview should carry the notice:\n%s", got)
+	}
+}
@@ -0,0 +1,197 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/difftool"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+// FileHistorySource is the git access the file history viewer needs.
+type FileHistorySource interface {
+	FileHistory(path string, follow bool) ([]git.FileRevision, error)
+	ShowOutput(args []string) (string, error)
+}
+
+// FileHistoryViewer lists the commits that changed one file, newest
+// first. Enter or d shows the file's diff in the highlighted commit, y
+// copies its hash, and r ends the viewer to restore the file as it was
+// there. Navigation honors the move_up, move_down and soft_cancel bindings
+// of the active keybinding profile.
+type FileHistoryViewer struct {
+	git     FileHistorySource
+	path    string
+	revs    []git.FileRevision
+	cursor  int
+	top     int
+	diff    *diffPager
+	message string
+	keyMap  *kb.KeyBindingMap
+	colors  *ANSIColors
+	stdin   io.Reader
+	stdout  io.Writer
+	term    termio.Terminal
+
+	highlight func(diff string) (out string, ok bool, err error)
+	copy      func(text string) error
+}
+
+// NewFileHistoryViewer returns a viewer using the keybinding profile and
+// diff tool configured in cfg. cfg may be nil.
+func NewFileHistoryViewer(src FileHistorySource, cfg *config.Config) *FileHistoryViewer {
+	var tool string
+	if cfg != nil {
+		tool = cfg.UI.DiffTool
+	}
+	v := &FileHistoryViewer{
+		git:       src,
+		keyMap:    resolveResultsKeyMap(cfg),
+		colors:    NewANSIColors(),
+		stdin:     os.Stdin,
+		stdout:    os.Stdout,
+		term:      termio.DefaultTerminal{},
+		highlight: difftool.New(tool).Highlight,
+	}
+	v.copy = clipboardCopier(&v.stdout)
+	return v
+}
+
+// Run shows the history of path until the user quits or picks a revision
+// to restore. restore is false when there is nothing to do.
+func (v *FileHistoryViewer) Run(path string, follow bool) (rev git.FileRevision, restore bool, err error) {
+	v.path = path
+	if v.revs, err = v.git.FileHistory(path, follow); err != nil {
+		return git.FileRevision{}, false, err
+	}
+	if len(v.revs) == 0 {
+		_, _ = fmt.Fprintf(v.stdout, "No commits change %s\n", path)
+		return git.FileRevision{}, false, nil
+	}
+
+	if f, isFile := v.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := v.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = v.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(v.stdout)
+
+	reader := bufio.NewReader(v.stdin)
+	for {
+		v.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil {
+			clearScreen(v.stdout)
+			return git.FileRevision{}, false, nil
+		}
+		if done, restore := v.handleKey(ks); done {
+			clearScreen(v.stdout)
+			return v.revs[v.cursor], restore, nil
+		}
+	}
+}
+
+// handleKey applies one keystroke. done reports whether the viewer should
+// close and restore whether the highlighted revision should be restored.
+func (v *FileHistoryViewer) handleKey(ks kb.KeyStroke) (done, restore bool) {
+	v.message = ""
+	if v.diff != nil {
+		if v.diff.handleKey(ks, v.keyMap) {
+			v.diff = nil
+		}
+		return false, false
+	}
+
+	last := len(v.revs) - 1
+	switch {
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewCharKeyStroke('q')),
+		v.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true, false
+	case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+		v.keyMap.MatchesKeyStroke("move_up", ks):
+		v.cursor = max(v.cursor-1, 0)
+	case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+		v.keyMap.MatchesKeyStroke("move_down", ks):
+		v.cursor = min(v.cursor+1, last)
+	case ks.Equals(kb.NewCtrlKeyStroke('u')):
+		v.cursor = max(v.cursor-logViewerRows/2, 0)
+	case ks.Equals(kb.NewCtrlKeyStroke('d')):
+		v.cursor = min(v.cursor+logViewerRows/2, last)
+	case ks.Equals(kb.NewEnterKeyStroke()), ks.Equals(kb.NewCharKeyStroke('d')):
+		v.showDiff(v.revs[v.cursor])
+	case ks.Equals(kb.NewCharKeyStroke('y')):
+		v.message = copyMessage(v.copy, v.revs[v.cursor].Hash)
+	case ks.Equals(kb.NewCharKeyStroke('r')):
+		return true, true
+	}
+	return false, false
+}
+
+// showDiff opens the file's diff in rev over the list.
+func (v *FileHistoryViewer) showDiff(rev git.FileRevision) {
+	title := fmt.Sprintf("%s%s%s %s %s(%s)%s", v.colors.Bold+v.colors.BrightYellow, rev.Short, v.colors.Reset,
+		rev.Subject, v.colors.BrightBlack, rev.Path, v.colors.Reset)
+	diff, err := openCommit(v.git, rev.Hash, title, v.colors, v.highlight, rev.Path)
+	if err != nil {
+		v.message = err.Error()
+		return
+	}
+	v.diff = diff
+}
+
+func (v *FileHistoryViewer) render() {
+	c := v.colors
+	clearScreen(v.stdout)
+	var b strings.Builder
+	if v.diff != nil {
+		v.diff.render(&b, c)
+	} else {
+		v.renderList(&b)
+	}
+	if v.message != "" {
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightGreen, v.message, c.Reset)
+	}
+	help := "j/k move · enter/[d]iff · [r]estore this version · [y]ank hash · q quit"
+	if v.diff != nil {
+		help = diffPagerHelp
+	}
+	fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightBlack, help, c.Reset)
+	_, _ = io.WriteString(v.stdout, b.String())
+}
+
+func (v *FileHistoryViewer) renderList(b *strings.Builder) {
+	c := v.colors
+	fmt.Fprintf(b, "%sHistory of %s%s\r\n\r\n", c.Bold+c.BrightCyan, v.path, c.Reset)
+	if v.cursor < v.top {
+		v.top = v.cursor
+	} else if v.cursor >= v.top+logViewerRows {
+		v.top = v.cursor - logViewerRows + 1
+	}
+	end := min(v.top+logViewerRows, len(v.revs))
+	for i := v.top; i < end; i++ {
+		r := v.revs[i]
+		marker := "  "
+		if i == v.cursor {
+			marker = c.BrightGreen + "› " + c.Reset
+		}
+		renamed := ""
+		if r.Path != v.path {
+			renamed = fmt.Sprintf(" %sas %s%s", c.BrightMagenta, r.Path, c.Reset)
+		}
+		fmt.Fprintf(b, "%s%s%s%s %s%s %s(%s, %s)%s\r\n", marker, c.BrightYellow, r.Short, c.Reset,
+			r.Subject, renamed, c.BrightBlack, r.Author, r.Date, c.Reset)
+	}
+	if end < len(v.revs) {
+		fmt.Fprintf(b, "%s… %d more%s\r\n", c.BrightBlack, len(v.revs)-end, c.Reset)
+	}
+}
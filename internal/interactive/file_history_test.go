@@ -0,0 +1,91 @@
+package interactive
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// fakeFileHistory serves a file renamed from old.go in its first commit
+// and records what was shown.
+type fakeFileHistory struct {
+	follow bool
+	shown  [][]string
+}
+
+func (f *fakeFileHistory) FileHistory(_ string, follow bool) ([]git.FileRevision, error) {
+	f.follow = follow
+	return []git.FileRevision{
+		{CommitSummary: git.CommitSummary{Hash: "c2full", Short: "c2", Subject: "Rename", Author: "Alice", Date: "now"}, Path: "new.go"},
+		{CommitSummary: git.CommitSummary{Hash: "c1full", Short: "c1", Subject: "Add", Author: "Bob", Date: "last week"}, Path: "old.go"},
+	}, nil
+}
+
+func (f *fakeFileHistory) ShowOutput(args []string) (string, error) {
+	f.shown = append(f.shown, slices.Clone(args))
+	return "commit x\n\ndiff --git a/old.go b/old.go\n+added\n", nil
+}
+
+func newTestFileHistoryViewer(src *fakeFileHistory, input string) (*FileHistoryViewer, *bytes.Buffer) {
+	var out bytes.Buffer
+	v := NewFileHistoryViewer(src, nil)
+	v.stdin = strings.NewReader(input)
+	v.stdout = &out
+	v.highlight = nil
+	v.copy = func(text string) error { return uiutil.CopyOSC52(&out, text) }
+	return v, &out
+}
+
+func TestFileHistoryViewer_List(t *testing.T) {
+	src := &fakeFileHistory{}
+	v, out := newTestFileHistoryViewer(src, "q")
+	if _, restore, err := v.Run("new.go", true); err != nil || restore {
+		t.Fatalf("Run() = %v, %v", restore, err)
+	}
+	got := uiutil.StripANSI(out.String())
+	for _, want := range []string{"History of new.go", "› c2 Rename (Alice, now)", "  c1 Add as old.go (Bob, last week)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+	if !src.follow {
+		t.Error("follow was not passed on")
+	}
+}
+
+func TestFileHistoryViewer_DiffAndRestore(t *testing.T) {
+	src := &fakeFileHistory{}
+	v, out := newTestFileHistoryViewer(src, "jdqr")
+	rev, restore, err := v.Run("new.go", false)
+	if err != nil || !restore || rev.Hash != "c1full" || rev.Path != "old.go" {
+		t.Fatalf("Run() = %+v, %v, %v", rev, restore, err)
+	}
+	if want := [][]string{{"--stat", "--patch", "c1full", "--", "old.go"}}; !slices.EqualFunc(src.shown, want, slices.Equal) {
+		t.Errorf("shown %v, want %v", src.shown, want)
+	}
+	if !strings.Contains(uiutil.StripANSI(out.String()), "c1 Add (old.go)") {
+		t.Errorf("diff title missing in %q", out.String())
+	}
+}
+
+func TestFileHistoryViewer_Copy(t *testing.T) {
+	var copied []string
+	v, out := newTestFileHistoryViewer(&fakeFileHistory{}, "jyq")
+	v.copy = func(text string) error {
+		copied = append(copied, text)
+		return nil
+	}
+	if _, restore, err := v.Run("new.go", false); err != nil || restore {
+		t.Fatalf("Run() = %v, %v", restore, err)
+	}
+	if !slices.Equal(copied, []string{"c1full"}) {
+		t.Errorf("copied %v, want [c1full]", copied)
+	}
+	if !strings.Contains(out.String(), "Copied c1full") {
+		t.Errorf("copy message missing in %q", out.String())
+	}
+}
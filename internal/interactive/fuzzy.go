@@ -1,7 +1,11 @@
 // Package interactive houses interactive UI types and helpers shared across the application.
 package interactive
 
-import "unicode"
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
 
 // fuzzyMatch performs fuzzy matching between text and pattern
 // Returns true if all characters in pattern appear in text in order (but not necessarily consecutive)
@@ -96,6 +100,7 @@ func continuationPenalty(textRunes []rune, lastMatchIdx int) int {
 type matchScore struct {
 	first        int
 	gap          int
+	frecency     float64 // higher is better; filled in by the caller
 	trailing     int
 	continuation int
 	length       int
@@ -108,6 +113,11 @@ func (m matchScore) less(other matchScore) bool {
 	if m.gap != other.gap {
 		return m.gap < other.gap
 	}
+	// Usage only reorders equally tight matches, so a command the user
+	// runs often never outranks one that matches the query better.
+	if m.frecency != other.frecency {
+		return m.frecency > other.frecency
+	}
 	if m.continuation != other.continuation {
 		return m.continuation < other.continuation
 	}
@@ -119,3 +129,25 @@ func (m matchScore) less(other matchScore) bool {
 	}
 	return false
 }
+
+// FuzzyFilter returns the items query fuzzy-matches, case-insensitively,
+// best match first.
+func FuzzyFilter(query string, items []string) []string {
+	type match struct {
+		item  string
+		score matchScore
+	}
+	query = strings.ToLower(query)
+	var matches []match
+	for _, item := range items {
+		if ok, score := fuzzyMatchScore(strings.ToLower(item), query); ok {
+			matches = append(matches, match{item, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score.less(matches[j].score) })
+	filtered := make([]string, len(matches))
+	for i, m := range matches {
+		filtered[i] = m.item
+	}
+	return filtered
+}
@@ -17,18 +17,26 @@ type GitStatus struct {
 	Ahead      int
 	Behind     int
 	HasChanges bool
+	// IdentityWarning is set when the repository's user.name or
+	// user.email differs from the profile it is expected to use.
+	IdentityWarning string
 }
 
 // ANSIColors is an alias to the shared UI palette definition.
 type ANSIColors = uiutil.ANSIColors
 
-// NewANSIColors exposes the shared ANSI color palette helper.
+// NewANSIColors exposes the shared ANSI color palette helper. The palette is
+// empty when color has been turned off (--no-color, NO_COLOR, ui.color: false).
 func NewANSIColors() *ANSIColors {
-	return uiutil.NewANSIColors()
+	return uiutil.TerminalColors()
 }
 
 // getGitStatus retrieves the current Git repository status
 func getGitStatus(gitClient git.StatusInfoReader) *GitStatus {
+	if reader, ok := gitClient.(git.StatusSummaryReader); ok {
+		return getGitStatusSummary(reader)
+	}
+
 	status := &GitStatus{}
 
 	// Get current branch name
@@ -52,6 +60,25 @@ func getGitStatus(gitClient git.StatusInfoReader) *GitStatus {
 	return status
 }
 
+// getGitStatusSummary builds the status from a single porcelain v2 read.
+func getGitStatusSummary(reader git.StatusSummaryReader) *GitStatus {
+	summary, err := reader.StatusSummary()
+	if err != nil || summary.Branch == "" {
+		return nil // Not in a git repository
+	}
+	// Conflicts need attention in the work tree, so they count as modified.
+	modified := summary.Modified() + summary.Conflicted()
+	staged := summary.Staged()
+	return &GitStatus{
+		Branch:     summary.Branch,
+		Modified:   modified,
+		Staged:     staged,
+		HasChanges: modified > 0 || staged > 0,
+		Ahead:      summary.Ahead,
+		Behind:     summary.Behind,
+	}
+}
+
 // getGitBranch gets the current branch name
 func getGitBranch(gitClient git.StatusInfoReader) string {
 	branch, err := gitClient.GetCurrentBranch()
@@ -0,0 +1,180 @@
+package interactive
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// The header's git status is collected in the background so a slow
+// repository never delays the first frame. While Run owns the screen a
+// refresher goroutine reloads the status right away, then every
+// interactive.status-refresh, and after a workflow runs. Run starts again
+// after every command ggc executes, so those refresh too. While a reload
+// is in flight the previous status stays on screen, dimmed, next to a
+// spinner.
+
+// defaultStatusRefresh is how often the status reloads while idle.
+const defaultStatusRefresh = 10 * time.Second
+
+// spinnerStep is how long each spinner frame stays on screen.
+const spinnerStep = 120 * time.Millisecond
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// statusRefreshFrom reads interactive.status-refresh. Zero turns the
+// periodic reload off; empty or invalid values leave the default in place.
+func statusRefreshFrom(cfg *config.Config) time.Duration {
+	if cfg == nil || cfg.Interactive.StatusRefresh == "" {
+		return defaultStatusRefresh
+	}
+	d, err := time.ParseDuration(cfg.Interactive.StatusRefresh)
+	if err != nil || d < 0 {
+		return defaultStatusRefresh
+	}
+	return d
+}
+
+// statusRefresher is the background loop started by Run.
+type statusRefresher struct {
+	kick chan struct{}
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+// statusView returns the status to show and whether a reload is running.
+func (ui *UI) statusView() (*GitStatus, bool) {
+	ui.statusMu.Lock()
+	defer ui.statusMu.Unlock()
+	return ui.gitStatus, !ui.statusSince.IsZero()
+}
+
+// spinnerFrame is the spinner glyph for the reload in flight.
+func (ui *UI) spinnerFrame() string {
+	ui.statusMu.Lock()
+	since := ui.statusSince
+	ui.statusMu.Unlock()
+	return spinnerFrames[int(time.Since(since)/spinnerStep)%len(spinnerFrames)]
+}
+
+// refreshGitStatus reloads the status synchronously.
+func (ui *UI) refreshGitStatus() {
+	if ui.gitClient == nil {
+		return
+	}
+	status := ui.loadGitStatus()
+	ui.statusMu.Lock()
+	ui.gitStatus = status
+	ui.statusMu.Unlock()
+}
+
+// startStatusRefresh starts the refresher and returns the function that
+// stops it. The first reload starts immediately.
+func (ui *UI) startStatusRefresh() func() {
+	if ui.gitClient == nil {
+		return func() {}
+	}
+	sr := &statusRefresher{
+		kick: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+	}
+	ui.refresher = sr
+	ui.invalidateStatusCache() // a command may have just run
+	sr.done.Add(1)
+	go ui.statusLoop(sr)
+	sr.kick <- struct{}{}
+
+	return func() {
+		close(sr.stop)
+		sr.done.Wait()
+		ui.refresher = nil
+	}
+}
+
+// requestStatusRefresh asks a running refresher to reload now.
+func (ui *UI) requestStatusRefresh() {
+	if ui == nil || ui.refresher == nil {
+		return
+	}
+	ui.invalidateStatusCache()
+	select {
+	case ui.refresher.kick <- struct{}{}:
+	default: // a reload is already queued
+	}
+}
+
+// invalidateStatusCache makes the next reload bypass the git client's
+// status cache, for changes its repository fingerprint cannot see.
+func (ui *UI) invalidateStatusCache() {
+	if inv, ok := ui.gitClient.(git.StatusCacheInvalidator); ok {
+		inv.InvalidateStatusCache()
+	}
+}
+
+func (ui *UI) statusLoop(sr *statusRefresher) {
+	defer sr.done.Done()
+
+	var tick <-chan time.Time
+	if ui.statusInterval > 0 {
+		ticker := time.NewTicker(ui.statusInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-sr.stop:
+			return
+		case <-sr.kick:
+		case <-tick:
+		}
+		if !ui.reloadStatus(sr) {
+			return
+		}
+	}
+}
+
+// reloadStatus collects the status on its own goroutine, animating the
+// spinner until it arrives. It returns false when the refresher stopped
+// first; the result is then dropped.
+func (ui *UI) reloadStatus(sr *statusRefresher) bool {
+	ui.statusMu.Lock()
+	ui.statusSince = time.Now()
+	ui.statusMu.Unlock()
+
+	result := make(chan *GitStatus, 1)
+	go func() { result <- ui.loadGitStatus() }()
+
+	spin := time.NewTicker(spinnerStep)
+	defer spin.Stop()
+	for {
+		select {
+		case <-sr.stop:
+			ui.statusMu.Lock()
+			ui.statusSince = time.Time{}
+			ui.statusMu.Unlock()
+			return false
+		case <-spin.C:
+			ui.redraw()
+		case status := <-result:
+			ui.statusMu.Lock()
+			ui.gitStatus = status
+			ui.statusSince = time.Time{}
+			ui.statusMu.Unlock()
+			ui.redraw()
+			return true
+		}
+	}
+}
+
+// redraw renders the screen from the refresher goroutine, unless the
+// main loop has handed the terminal to something else.
+func (ui *UI) redraw() {
+	ui.drawMu.Lock()
+	defer ui.drawMu.Unlock()
+	if ui.drawing {
+		ui.renderer.Render(ui, ui.state)
+	}
+}
@@ -0,0 +1,140 @@
+package interactive
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+// gatedGitClient blocks GetCurrentBranch until release is closed.
+type gatedGitClient struct {
+	*testutil.MockGitClient
+	release chan struct{}
+}
+
+func (c *gatedGitClient) GetCurrentBranch() (string, error) {
+	<-c.release
+	return c.MockGitClient.GetCurrentBranch()
+}
+
+// syncBuffer is a bytes.Buffer safe for the refresher goroutine.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStatusRefresher(t *testing.T) {
+	client := &gatedGitClient{MockGitClient: testutil.NewMockGitClient(), release: make(chan struct{})}
+	out := &syncBuffer{}
+	ui := newUIWithKeyMap(kb.DefaultKeyBindingMap())
+	ui.colors = &ANSIColors{}
+	ui.renderer = &Renderer{writer: out, colors: ui.colors}
+	ui.gitClient = client
+	ui.drawing = true
+
+	stop := ui.startStatusRefresh()
+	defer stop()
+
+	waitFor(t, "spinner", func() bool { return strings.Contains(out.String(), "reading git status") })
+	if status, refreshing := ui.statusView(); status != nil || !refreshing {
+		t.Fatalf("statusView() = %v, %v during the first reload", status, refreshing)
+	}
+
+	close(client.release)
+	waitFor(t, "status", func() bool {
+		status, refreshing := ui.statusView()
+		return status != nil && !refreshing
+	})
+	waitFor(t, "redraw", func() bool { return strings.Contains(out.String(), "📍 main") })
+
+	status, _ := ui.statusView()
+	if status.Staged != 1 || status.Modified != 1 || status.Ahead != 2 || status.Behind != 1 {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestStatusRefresher_DrawsOnlyWhileRunOwnsScreen(t *testing.T) {
+	out := &syncBuffer{}
+	ui := newUIWithKeyMap(kb.DefaultKeyBindingMap())
+	ui.colors = &ANSIColors{}
+	ui.renderer = &Renderer{writer: out, colors: ui.colors}
+	ui.gitClient = testutil.NewMockGitClient()
+
+	stop := ui.startStatusRefresh()
+	waitFor(t, "status", func() bool {
+		status, _ := ui.statusView()
+		return status != nil
+	})
+	stop()
+
+	if out.String() != "" {
+		t.Errorf("refresher drew while the screen was handed off: %q", out.String())
+	}
+	ui.requestStatusRefresh() // no refresher: must not block
+}
+
+func TestRefreshingStatusLine(t *testing.T) {
+	out := &bytes.Buffer{}
+	ui := newUIWithKeyMap(kb.DefaultKeyBindingMap())
+	ui.colors = NewANSIColors()
+	r := &Renderer{writer: out, colors: ui.colors}
+	ui.statusSince = time.Now()
+
+	r.renderRefreshingGitStatus(ui, &GitStatus{Branch: "main", Ahead: 1})
+	got := out.String()
+	if !strings.Contains(got, spinnerFrames[0]+" 📍 main  ↑1") {
+		t.Errorf("refreshing status line = %q", got)
+	}
+}
+
+func TestStatusRefreshFrom(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", defaultStatusRefresh},
+		{"5s", 5 * time.Second},
+		{"0", 0},
+		{"-1s", defaultStatusRefresh},
+		{"often", defaultStatusRefresh},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{}
+		cfg.Interactive.StatusRefresh = tt.value
+		if got := statusRefreshFrom(cfg); got != tt.want {
+			t.Errorf("statusRefreshFrom(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+	if got := statusRefreshFrom(nil); got != defaultStatusRefresh {
+		t.Errorf("statusRefreshFrom(nil) = %v", got)
+	}
+}
@@ -0,0 +1,255 @@
+package interactive
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+const (
+	// grepViewerRows caps how many hits are drawn at once.
+	grepViewerRows = 15
+	// grepPreviewContext is how many lines the preview shows above and
+	// below the hit.
+	grepPreviewContext = 4
+	// grepRedrawEvery limits how often arriving hits redraw the screen.
+	grepRedrawEvery = 50 * time.Millisecond
+)
+
+// GrepSource is the git access the grep viewer needs.
+type GrepSource interface {
+	GrepStream(ctx context.Context, args []string, hit func(git.GrepHit)) error
+}
+
+// GrepViewer lists the hits of git grep as they arrive, with the lines
+// around the highlighted hit previewed below. Enter or e ends the viewer
+// to open the hit in an editor. Navigation honors the move_up, move_down
+// and soft_cancel bindings of the active keybinding profile.
+type GrepViewer struct {
+	git      GrepSource
+	title    string
+	keyMap   *kb.KeyBindingMap
+	colors   *ANSIColors
+	stdin    io.Reader
+	stdout   io.Writer
+	term     termio.Terminal
+	previews map[string][]string
+
+	// mu guards what the search goroutine updates; drawMu keeps it from
+	// drawing while the main loop does or after the viewer closed.
+	mu        sync.Mutex
+	hits      []git.GrepHit
+	searching bool
+	err       error
+	drawMu    sync.Mutex
+	drawing   bool
+	drawn     time.Time
+	cursor    int
+}
+
+// NewGrepViewer returns a viewer using the keybinding profile configured
+// in cfg. cfg may be nil.
+func NewGrepViewer(src GrepSource, cfg *config.Config) *GrepViewer {
+	return &GrepViewer{
+		git:      src,
+		keyMap:   resolveResultsKeyMap(cfg),
+		colors:   NewANSIColors(),
+		stdin:    os.Stdin,
+		stdout:   os.Stdout,
+		term:     termio.DefaultTerminal{},
+		previews: map[string][]string{},
+	}
+}
+
+// Run searches with the git grep arguments args and shows the hits until
+// the user quits or picks one. ok is false when nothing was picked.
+func (v *GrepViewer) Run(args []string) (hit git.GrepHit, ok bool, err error) {
+	v.title = "git grep " + strings.Join(args, " ")
+	if f, isFile := v.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := v.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = v.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(v.stdout)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	v.searching, v.drawing = true, true
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := v.git.GrepStream(ctx, args, v.add)
+		v.mu.Lock()
+		v.searching, v.err = false, err
+		v.mu.Unlock()
+		v.redraw(true)
+	}()
+	// stop ends the search and its drawing before the screen is cleared.
+	stop := sync.OnceFunc(func() {
+		v.drawMu.Lock()
+		v.drawing = false
+		v.drawMu.Unlock()
+		cancel()
+		wg.Wait()
+	})
+	defer stop()
+
+	reader := bufio.NewReader(v.stdin)
+	for {
+		v.redraw(true)
+		ks, err := readRebaseKey(reader)
+		if err != nil {
+			stop()
+			clearScreen(v.stdout)
+			return git.GrepHit{}, false, nil
+		}
+		if done, picked := v.handleKey(ks); done {
+			stop()
+			clearScreen(v.stdout)
+			if !picked {
+				return git.GrepHit{}, false, nil
+			}
+			v.mu.Lock()
+			defer v.mu.Unlock()
+			return v.hits[v.cursor], true, nil
+		}
+	}
+}
+
+// add records a hit from the search goroutine.
+func (v *GrepViewer) add(hit git.GrepHit) {
+	v.mu.Lock()
+	v.hits = append(v.hits, hit)
+	v.mu.Unlock()
+	v.redraw(false)
+}
+
+// handleKey applies one keystroke. done reports whether the viewer should
+// close and picked whether the highlighted hit should then be opened.
+func (v *GrepViewer) handleKey(ks kb.KeyStroke) (done, picked bool) {
+	v.mu.Lock()
+	last := len(v.hits) - 1
+	v.mu.Unlock()
+	v.drawMu.Lock()
+	defer v.drawMu.Unlock()
+
+	switch {
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewCharKeyStroke('q')),
+		v.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true, false
+	case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+		v.keyMap.MatchesKeyStroke("move_up", ks):
+		v.cursor = max(v.cursor-1, 0)
+	case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+		v.keyMap.MatchesKeyStroke("move_down", ks):
+		v.cursor = max(min(v.cursor+1, last), 0)
+	case ks.Equals(kb.NewCtrlKeyStroke('u')):
+		v.cursor = max(v.cursor-grepViewerRows/2, 0)
+	case ks.Equals(kb.NewCtrlKeyStroke('d')):
+		v.cursor = max(min(v.cursor+grepViewerRows/2, last), 0)
+	case ks.Equals(kb.NewEnterKeyStroke()), ks.Equals(kb.NewCharKeyStroke('e')):
+		return last >= 0, last >= 0
+	}
+	return false, false
+}
+
+// redraw renders the screen unless the viewer has closed. Unless force is
+// set, it skips frames that come sooner than grepRedrawEvery after the
+// last, so a fast search does not flood the terminal.
+func (v *GrepViewer) redraw(force bool) {
+	v.drawMu.Lock()
+	defer v.drawMu.Unlock()
+	if !v.drawing || (!force && time.Since(v.drawn) < grepRedrawEvery) {
+		return
+	}
+	v.drawn = time.Now()
+	v.render()
+}
+
+func (v *GrepViewer) render() {
+	c := v.colors
+	v.mu.Lock()
+	hits := v.hits
+	searching, searchErr := v.searching, v.err
+	v.mu.Unlock()
+
+	clearScreen(v.stdout)
+	var b strings.Builder
+	status := fmt.Sprintf("%d hit%s", len(hits), pluralize(len(hits)))
+	if searching {
+		status += ", searching…"
+	}
+	fmt.Fprintf(&b, "%s%s%s %s(%s)%s\r\n\r\n", c.Bold+c.BrightCyan, v.title, c.Reset, c.BrightBlack, status, c.Reset)
+
+	start := max(v.cursor-grepViewerRows+1, 0)
+	end := min(start+grepViewerRows, len(hits))
+	for i := start; i < end; i++ {
+		h := hits[i]
+		marker := "  "
+		if i == v.cursor {
+			marker = c.BrightGreen + "› " + c.Reset
+		}
+		fmt.Fprintf(&b, "%s%s%s%s:%s%d%s: %s\r\n", marker, c.BrightMagenta, h.Path, c.Reset,
+			c.BrightGreen, h.Line, c.Reset, strings.TrimSpace(h.Text))
+	}
+	switch {
+	case len(hits) == 0 && !searching && searchErr == nil:
+		fmt.Fprintf(&b, "%sNo matches.%s\r\n", c.BrightBlack, c.Reset)
+	case len(hits) > end:
+		fmt.Fprintf(&b, "%s… %d more%s\r\n", c.BrightBlack, len(hits)-end, c.Reset)
+	}
+	if searchErr != nil {
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightRed, searchErr, c.Reset)
+	}
+
+	if v.cursor < len(hits) {
+		b.WriteString("\r\n")
+		v.renderPreview(&b, hits[v.cursor])
+	}
+	fmt.Fprintf(&b, "\r\n%sj/k move · enter/[e]dit · q quit%s\r\n", c.BrightBlack, c.Reset)
+	_, _ = io.WriteString(v.stdout, b.String())
+}
+
+// renderPreview shows the lines around hit, numbered, with its own line
+// marked.
+func (v *GrepViewer) renderPreview(b *strings.Builder, hit git.GrepHit) {
+	c := v.colors
+	lines, ok := v.previews[hit.Path]
+	if !ok {
+		data, err := os.ReadFile(hit.Path)
+		if err != nil {
+			lines = []string{}
+		} else {
+			lines = strings.Split(strings.ReplaceAll(string(data), "\t", "    "), "\n")
+		}
+		v.previews[hit.Path] = lines
+	}
+	fmt.Fprintf(b, "%s── %s:%d%s\r\n", c.BrightBlack, hit.Path, hit.Line, c.Reset)
+	if len(lines) == 0 {
+		fmt.Fprintf(b, "%s(no preview)%s\r\n", c.BrightBlack, c.Reset)
+		return
+	}
+	from := max(hit.Line-grepPreviewContext, 1)
+	to := min(hit.Line+grepPreviewContext, len(lines))
+	for n := from; n <= to; n++ {
+		if n == hit.Line {
+			fmt.Fprintf(b, "%s%5d │ %s%s\r\n", c.Bold+c.BrightYellow, n, lines[n-1], c.Reset)
+			continue
+		}
+		fmt.Fprintf(b, "%s%5d │%s %s\r\n", c.BrightBlack, n, c.Reset, lines[n-1])
+	}
+}
@@ -0,0 +1,94 @@
+package interactive
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// fakeGrepSource serves fixed hits and closes done once they are all
+// sent, so that keys typed after it see every hit.
+type fakeGrepSource struct {
+	hits []git.GrepHit
+	args []string
+	done chan struct{}
+}
+
+func (f *fakeGrepSource) GrepStream(_ context.Context, args []string, hit func(git.GrepHit)) error {
+	f.args = slices.Clone(args)
+	for _, h := range f.hits {
+		hit(h)
+	}
+	close(f.done)
+	return nil
+}
+
+// afterReader reads r once ready is closed.
+type afterReader struct {
+	ready <-chan struct{}
+	r     *strings.Reader
+}
+
+func (a *afterReader) Read(p []byte) (int, error) {
+	<-a.ready
+	return a.r.Read(p)
+}
+
+func newTestGrepViewer(t *testing.T, input string) (*GrepViewer, *fakeGrepSource, *bytes.Buffer) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a\n\nfunc main() {\n\tprintln(\"hi\")\n}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	src := &fakeGrepSource{
+		hits: []git.GrepHit{
+			{Path: "a.go", Line: 3, Column: 6, Text: "func main() {"},
+			{Path: "a.go", Line: 4, Column: 2, Text: "\tprintln(\"hi\")"},
+		},
+		done: make(chan struct{}),
+	}
+	var out bytes.Buffer
+	v := NewGrepViewer(src, nil)
+	v.stdin = &afterReader{ready: src.done, r: strings.NewReader(input)}
+	v.stdout = &out
+	return v, src, &out
+}
+
+func TestGrepViewer_Pick(t *testing.T) {
+	v, src, out := newTestGrepViewer(t, "j\r")
+	hit, ok, err := v.Run([]string{"-i", "main"})
+	if err != nil || !ok || hit.Line != 4 {
+		t.Fatalf("Run() = %+v, %v, %v", hit, ok, err)
+	}
+	if !slices.Equal(src.args, []string{"-i", "main"}) {
+		t.Errorf("args = %v", src.args)
+	}
+	got := uiutil.StripANSI(out.String())
+	for _, want := range []string{
+		"git grep -i main (2 hits)",
+		"› a.go:3: func main() {",
+		"  a.go:4: println(\"hi\")",
+		"── a.go:4",
+		"    4 │     println(\"hi\")",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+}
+
+func TestGrepViewer_Quit(t *testing.T) {
+	v, _, _ := newTestGrepViewer(t, "q")
+	if _, ok, err := v.Run([]string{"main"}); ok || err != nil {
+		t.Errorf("Run() = %v, %v; want nothing picked", ok, err)
+	}
+}
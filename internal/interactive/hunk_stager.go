@@ -0,0 +1,332 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/difftool"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/patch"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+// hunkPreviewLines caps how much of the selected hunk is drawn below the list.
+const hunkPreviewLines = 20
+
+// HunkSource is the git access the hunk stager needs: reading diffs and
+// applying single-hunk patches to the index.
+type HunkSource interface {
+	DiffWith(args []string) (string, error)
+	ApplyToIndex(patch string, reverse bool) error
+}
+
+// hunkItem is one row of the hunk list.
+type hunkItem struct {
+	file   *patch.FileDiff
+	hunk   *patch.Hunk
+	staged bool
+}
+
+// HunkStager is a full-screen, hunk-level alternative to `git add -p`.
+// Unstaged and staged hunks are listed together; s stages the selected
+// hunk, u unstages it and x splits it into smaller hunks. Navigation
+// honors the move_up, move_down and soft_cancel bindings of the active
+// keybinding profile; arrow keys and j/k always work as well.
+type HunkStager struct {
+	git     HunkSource
+	paths   []string
+	items   []hunkItem
+	cursor  int
+	message string
+	keyMap  *kb.KeyBindingMap
+	colors  *ANSIColors
+	stdin   io.Reader
+	stdout  io.Writer
+	term    termio.Terminal
+
+	// highlight renders a hunk with ui.diff-tool; ok is false without one.
+	highlight   func(diff string) (out string, ok bool, err error)
+	highlighted map[*patch.Hunk][]string
+}
+
+// NewHunkStager returns a stager over the changes in paths (all changes
+// when empty) using the keybinding profile configured in cfg. cfg may be nil.
+func NewHunkStager(src HunkSource, paths []string, cfg *config.Config) *HunkStager {
+	var tool string
+	if cfg != nil {
+		tool = cfg.UI.DiffTool
+	}
+	s := &HunkStager{
+		git:         src,
+		paths:       paths,
+		keyMap:      resolveResultsKeyMap(cfg),
+		colors:      NewANSIColors(),
+		stdin:       os.Stdin,
+		stdout:      os.Stdout,
+		term:        termio.DefaultTerminal{},
+		highlighted: make(map[*patch.Hunk][]string),
+	}
+	if s.colors.Reset != "" {
+		s.highlight = difftool.New(tool).Highlight
+	}
+	return s
+}
+
+// Run shows the stager until the user quits. Staging happens as keys are
+// pressed, so there is nothing to apply on exit.
+func (s *HunkStager) Run() error {
+	if err := s.reload(); err != nil {
+		return err
+	}
+	if len(s.items) == 0 {
+		_, _ = fmt.Fprintln(s.stdout, "No changes to stage.")
+		return nil
+	}
+
+	if f, ok := s.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := s.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = s.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(s.stdout)
+
+	reader := bufio.NewReader(s.stdin)
+	for {
+		s.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil {
+			clearScreen(s.stdout)
+			return nil
+		}
+		if s.handleKey(ks) {
+			clearScreen(s.stdout)
+			return nil
+		}
+	}
+}
+
+// reload rebuilds the hunk list from git, keeping the cursor in range.
+// Unstaged hunks come first, then staged ones. Renames are disabled so
+// every hunk patch touches a single path.
+func (s *HunkStager) reload() error {
+	unstaged, err := s.diff(false)
+	if err != nil {
+		return err
+	}
+	staged, err := s.diff(true)
+	if err != nil {
+		return err
+	}
+	s.items = append(unstaged, staged...)
+	clear(s.highlighted)
+	if s.cursor >= len(s.items) {
+		s.cursor = max(len(s.items)-1, 0)
+	}
+	return nil
+}
+
+func (s *HunkStager) diff(cached bool) ([]hunkItem, error) {
+	args := []string{"--no-color", "--no-ext-diff", "--no-renames"}
+	if cached {
+		args = append(args, "--cached")
+	}
+	args = append(append(args, "--"), s.paths...)
+	out, err := s.git.DiffWith(args)
+	if err != nil {
+		return nil, err
+	}
+	files, err := patch.Parse(out)
+	if err != nil {
+		return nil, err
+	}
+	var items []hunkItem
+	for _, f := range files {
+		if f.Binary {
+			continue
+		}
+		for _, h := range f.Hunks {
+			items = append(items, hunkItem{file: f, hunk: h, staged: cached})
+		}
+	}
+	return items, nil
+}
+
+// handleKey applies one keystroke and reports whether the stager is done.
+func (s *HunkStager) handleKey(ks kb.KeyStroke) bool {
+	s.message = ""
+	switch {
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewCharKeyStroke('q')),
+		ks.Equals(kb.NewEnterKeyStroke()), s.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true
+	case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+		s.keyMap.MatchesKeyStroke("move_up", ks):
+		s.moveCursor(-1)
+	case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+		s.keyMap.MatchesKeyStroke("move_down", ks):
+		s.moveCursor(1)
+	case ks.Equals(kb.NewCharKeyStroke('s')):
+		s.apply(false)
+	case ks.Equals(kb.NewCharKeyStroke('u')):
+		s.apply(true)
+	case ks.Equals(kb.NewCharKeyStroke('x')):
+		s.split()
+	}
+	return false
+}
+
+func (s *HunkStager) moveCursor(delta int) {
+	next := s.cursor + delta
+	if next >= 0 && next < len(s.items) {
+		s.cursor = next
+	}
+}
+
+// apply stages (or, with unstage set, unstages) the selected hunk and
+// reloads the list from git.
+func (s *HunkStager) apply(unstage bool) {
+	if len(s.items) == 0 {
+		return
+	}
+	item := s.items[s.cursor]
+	if item.staged != unstage {
+		if unstage {
+			s.message = "Hunk is not staged"
+		} else {
+			s.message = "Hunk is already staged"
+		}
+		return
+	}
+	if err := s.git.ApplyToIndex(item.file.Patch(item.hunk), unstage); err != nil {
+		s.message = err.Error()
+		return
+	}
+	if err := s.reload(); err != nil {
+		s.message = err.Error()
+	}
+}
+
+// split replaces the selected hunk with its pieces. Splitting is local to
+// the view; the next reload shows git's own hunks again.
+func (s *HunkStager) split() {
+	if len(s.items) == 0 {
+		return
+	}
+	item := s.items[s.cursor]
+	pieces := item.hunk.Split()
+	if len(pieces) < 2 {
+		s.message = "Hunk cannot be split further"
+		return
+	}
+	replaced := make([]hunkItem, 0, len(s.items)+len(pieces)-1)
+	replaced = append(replaced, s.items[:s.cursor]...)
+	for _, p := range pieces {
+		replaced = append(replaced, hunkItem{file: item.file, hunk: p, staged: item.staged})
+	}
+	s.items = append(replaced, s.items[s.cursor+1:]...)
+}
+
+func (s *HunkStager) render() {
+	c := s.colors
+	clearScreen(s.stdout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sStage hunks%s\r\n\r\n", c.Bold+c.BrightCyan, c.Reset)
+	for i, item := range s.items {
+		marker := "  "
+		if i == s.cursor {
+			marker = c.BrightGreen + "› " + c.Reset
+		}
+		state, stateColor := "unstaged", c.BrightRed
+		if item.staged {
+			state, stateColor = "staged", c.BrightGreen
+		}
+		added, removed := hunkStat(item.hunk)
+		fmt.Fprintf(&b, "%s%s%-8s%s %s %s%s%s %s+%d%s %s-%d%s\r\n",
+			marker, stateColor, state, c.Reset, item.file.Path(),
+			c.BrightBlack, item.hunk.Header(), c.Reset,
+			c.Green, added, c.Reset, c.Red, removed, c.Reset)
+	}
+	if len(s.items) == 0 {
+		fmt.Fprintf(&b, "%sNo changes left.%s\r\n", c.BrightBlack, c.Reset)
+	} else {
+		b.WriteString("\r\n")
+		s.renderPreview(&b, s.items[s.cursor])
+	}
+	if s.message != "" {
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightRed, s.message, c.Reset)
+	}
+	fmt.Fprintf(&b, "\r\n%sj/k move · [s]tage · [u]nstage · [x] split · q quit%s\r\n", c.BrightBlack, c.Reset)
+	_, _ = io.WriteString(s.stdout, b.String())
+}
+
+func (s *HunkStager) renderPreview(b *strings.Builder, item hunkItem) {
+	if lines := s.highlightedPreview(item); lines != nil {
+		for i, line := range lines {
+			if i == hunkPreviewLines {
+				fmt.Fprintf(b, "%s… %d more line(s)%s\r\n", s.colors.BrightBlack, len(lines)-i, s.colors.Reset)
+				return
+			}
+			fmt.Fprintf(b, "%s%s\r\n", line, s.colors.Reset)
+		}
+		return
+	}
+
+	c := s.colors
+	h := item.hunk
+	for i, line := range h.Lines {
+		if i == hunkPreviewLines {
+			fmt.Fprintf(b, "%s… %d more line(s)%s\r\n", c.BrightBlack, len(h.Lines)-i, c.Reset)
+			return
+		}
+		color := ""
+		switch {
+		case strings.HasPrefix(line, "+"):
+			color = c.Green
+		case strings.HasPrefix(line, "-"):
+			color = c.Red
+		case strings.HasPrefix(line, `\`):
+			color = c.BrightBlack
+		}
+		fmt.Fprintf(b, "%s%s%s\r\n", color, line, c.Reset)
+	}
+}
+
+// highlightedPreview returns the selected hunk as rendered by the diff
+// tool, or nil to use the built-in colors. Results are cached per hunk; a
+// failing tool falls back to the built-in colors.
+func (s *HunkStager) highlightedPreview(item hunkItem) []string {
+	if s.highlight == nil {
+		return nil
+	}
+	if lines, ok := s.highlighted[item.hunk]; ok {
+		return lines
+	}
+	out, ok, err := s.highlight(item.file.Patch(item.hunk))
+	var lines []string
+	if ok && err == nil {
+		lines = strings.Split(strings.TrimRight(out, "\n"), "\n")
+	}
+	s.highlighted[item.hunk] = lines
+	return lines
+}
+
+// hunkStat counts the added and removed lines in h.
+func hunkStat(h *patch.Hunk) (int, int) {
+	var added, removed int
+	for _, line := range h.Lines {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,147 @@
+package interactive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+)
+
+const stagerDiff = `diff --git a/a.txt b/a.txt
+index 1111111..2222222 100644
+--- a/a.txt
++++ b/a.txt
+@@ -1,5 +1,5 @@
+-one
++ONE
+ two
+ three
+-four
++FOUR
+ five
+`
+
+// fakeHunkSource serves a fixed unstaged and staged diff and records
+// applied patches.
+type fakeHunkSource struct {
+	unstaged, staged string
+	applied          []string
+	reversed         []bool
+}
+
+func (f *fakeHunkSource) DiffWith(args []string) (string, error) {
+	for _, a := range args {
+		if a == "--cached" {
+			return f.staged, nil
+		}
+	}
+	return f.unstaged, nil
+}
+
+func (f *fakeHunkSource) ApplyToIndex(patch string, reverse bool) error {
+	f.applied = append(f.applied, patch)
+	f.reversed = append(f.reversed, reverse)
+	return nil
+}
+
+func newTestHunkStager(src *fakeHunkSource, input string) (*HunkStager, *bytes.Buffer) {
+	var out bytes.Buffer
+	s := NewHunkStager(src, nil, nil)
+	s.stdin = strings.NewReader(input)
+	s.stdout = &out
+	return s, &out
+}
+
+func TestHunkStager_SplitAndStage(t *testing.T) {
+	src := &fakeHunkSource{unstaged: stagerDiff}
+	// Split the hunk, move to the second piece and stage it.
+	s, out := newTestHunkStager(src, "xjsq")
+
+	if err := s.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(src.applied) != 1 || src.reversed[0] {
+		t.Fatalf("expected one forward apply, got %v", src.reversed)
+	}
+	if !strings.Contains(src.applied[0], "@@ -2,4 +2,4 @@\n two\n three\n-four\n+FOUR\n five\n") {
+		t.Errorf("applied patch = %q", src.applied[0])
+	}
+	if strings.Contains(src.applied[0], "+ONE") {
+		t.Error("the first piece must not be staged")
+	}
+	if !strings.Contains(out.String(), "[s]tage") {
+		t.Error("expected the key help footer to be rendered")
+	}
+}
+
+func TestHunkStager_Unstage(t *testing.T) {
+	src := &fakeHunkSource{staged: stagerDiff}
+	s, _ := newTestHunkStager(src, "")
+	if err := s.reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	s.handleKey(kb.NewCharKeyStroke('s'))
+	if len(src.applied) != 0 || s.message == "" {
+		t.Fatal("staging an already staged hunk should only warn")
+	}
+	s.handleKey(kb.NewCharKeyStroke('u'))
+	if len(src.applied) != 1 || !src.reversed[0] {
+		t.Fatalf("u should reverse-apply the staged hunk, got %v", src.reversed)
+	}
+}
+
+func TestHunkStager_NoChanges(t *testing.T) {
+	s, out := newTestHunkStager(&fakeHunkSource{}, "")
+	if err := s.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "No changes to stage.") {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestHunkStager_ProfileNavigation(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Interactive.Profile = "emacs"
+	src := &fakeHunkSource{unstaged: stagerDiff, staged: stagerDiff}
+	s := NewHunkStager(src, nil, cfg)
+	if err := s.reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	s.handleKey(kb.NewCtrlKeyStroke('n'))
+	if s.cursor != 1 || !s.items[s.cursor].staged {
+		t.Fatalf("cursor = %d, want the staged hunk", s.cursor)
+	}
+	if !s.handleKey(kb.NewEscapeKeyStroke()) {
+		t.Error("soft_cancel should close the stager")
+	}
+}
+
+func TestHunkStager_DiffToolPreview(t *testing.T) {
+	src := &fakeHunkSource{unstaged: stagerDiff}
+	s, out := newTestHunkStager(src, "q")
+	calls := 0
+	s.highlight = func(diff string) (string, bool, error) {
+		calls++
+		if !strings.Contains(diff, "+++ b/a.txt") {
+			t.Errorf("the tool should get a full patch, got %q", diff)
+		}
+		return "DELTA one\nDELTA two\n", true, nil
+	}
+	if err := s.reload(); err != nil {
+		t.Fatal(err)
+	}
+	s.render()
+	s.render()
+
+	if !strings.Contains(out.String(), "DELTA one") || strings.Contains(out.String(), "-four") {
+		t.Errorf("preview should come from the diff tool: %q", out.String())
+	}
+	if calls != 1 {
+		t.Errorf("tool ran %d times, want the result cached", calls)
+	}
+}
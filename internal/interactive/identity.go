@@ -0,0 +1,49 @@
+package interactive
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+)
+
+// loadGitStatus reads the status shown in the header, including the
+// identity check.
+func (ui *UI) loadGitStatus() *GitStatus {
+	status := getGitStatus(ui.gitClient)
+	if status != nil {
+		status.IdentityWarning = identityWarning(ui.profiles, ui.gitClient)
+	}
+	return status
+}
+
+// identityWarning describes how the repository's identity differs from
+// the profile it is expected to use. It is empty when they match, when no
+// profile applies, or when no profiles are configured, in which case git
+// is not asked at all.
+func identityWarning(cfg *config.Config, client git.StatusInfoReader) string {
+	if cfg == nil || len(cfg.Profiles) == 0 {
+		return ""
+	}
+	reader, ok := client.(git.ConfigReader)
+	if !ok {
+		return ""
+	}
+	pinned, _ := reader.ConfigGet(config.PinnedProfileKey)
+	dir, _ := os.Getwd()
+	name, ok := cfg.ExpectedProfile(pinned, dir)
+	if !ok {
+		return ""
+	}
+	prof := cfg.Profiles[name]
+	user, _ := reader.ConfigGet("user.name")
+	email, _ := reader.ConfigGet("user.email")
+	if prof.Matches(user, email) {
+		return ""
+	}
+	if email == "" {
+		email = "no identity"
+	}
+	return fmt.Sprintf("%s does not match profile %s (%s); run 'ggc profile apply'", email, name, prof.Email)
+}
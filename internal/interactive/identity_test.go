@@ -0,0 +1,62 @@
+package interactive
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/testutil"
+)
+
+// identityGitClient answers git config reads from values.
+type identityGitClient struct {
+	*testutil.MockGitClient
+	values map[string]string
+	reads  int
+}
+
+func (c *identityGitClient) ConfigGet(key string) (string, error) {
+	c.reads++
+	return c.values[key], nil
+}
+
+func TestIdentityWarning(t *testing.T) {
+	client := &identityGitClient{
+		MockGitClient: testutil.NewMockGitClient(),
+		values: map[string]string{
+			"ggc.profile": "work",
+			"user.name":   "Jane",
+			"user.email":  "jane@example.com",
+		},
+	}
+	cfg := &config.Config{Profiles: map[string]config.Profile{
+		"work": {Name: "Jane", Email: "jane@corp.example"},
+	}}
+
+	got := identityWarning(cfg, client)
+	if !strings.Contains(got, "jane@example.com does not match profile work (jane@corp.example)") {
+		t.Errorf("identityWarning() = %q", got)
+	}
+
+	client.values["user.email"] = "Jane@Corp.Example"
+	if got := identityWarning(cfg, client); got != "" {
+		t.Errorf("matching identity should not warn, got %q", got)
+	}
+
+	client.reads = 0
+	if got := identityWarning(&config.Config{}, client); got != "" || client.reads != 0 {
+		t.Errorf("without profiles: %q after %d git reads", got, client.reads)
+	}
+}
+
+func TestRenderGitStatus_IdentityWarning(t *testing.T) {
+	out := &syncBuffer{}
+	ui := newUIWithKeyMap(kb.DefaultKeyBindingMap())
+	ui.colors = &ANSIColors{}
+	ui.renderer = &Renderer{writer: out, colors: ui.colors}
+	ui.renderer.renderGitStatus(ui, &GitStatus{Branch: "main", IdentityWarning: "x does not match profile work"})
+	if got := out.String(); !strings.Contains(got, "main") || !strings.Contains(got, "⚠️  x does not match profile work") {
+		t.Errorf("header = %q", got)
+	}
+}
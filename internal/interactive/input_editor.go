@@ -10,6 +10,7 @@ import (
 type inputResult struct {
 	done     bool
 	canceled bool
+	finished bool // Ctrl+D ended a multi-line entry
 	text     string
 }
 
@@ -18,6 +19,10 @@ type realTimeEditor struct {
 	ui         *UI
 	inputRunes *[]rune
 	cursor     *int
+	// optional lets Enter accept an empty line.
+	optional bool
+	// multiline lets Ctrl+D end a multi-line entry after the current line.
+	multiline bool
 }
 
 // handleInput processes a single input rune
@@ -27,6 +32,12 @@ func (e *realTimeEditor) handleInput(r rune, reader *bufio.Reader) inputResult {
 		return e.handleEnter()
 	case 3: // Ctrl+C
 		return e.handleCtrlC()
+	case 4: // Ctrl+D
+		if e.multiline {
+			e.ui.write("\r\n")
+			return inputResult{done: true, finished: true, text: string(*e.inputRunes)}
+		}
+		return inputResult{}
 	case 7: // Ctrl+G
 		return e.handleSoftCancel()
 	case 127, '\b': // Backspace
@@ -48,7 +59,7 @@ func (e *realTimeEditor) handleInput(r rune, reader *bufio.Reader) inputResult {
 
 // handleEnter processes Enter key
 func (e *realTimeEditor) handleEnter() inputResult {
-	if len(*e.inputRunes) > 0 {
+	if len(*e.inputRunes) > 0 || e.optional {
 		e.ui.write("\r\n")
 		return inputResult{done: true, text: string(*e.inputRunes)}
 	}
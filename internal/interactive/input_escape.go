@@ -51,6 +51,12 @@ func (e *realTimeEditor) handleCSIEscape(reader *bufio.Reader) {
 		if err != nil {
 			return
 		}
+		if nb == '~' && string(params) == pasteStartParams {
+			for _, r := range pasteText(readPaste(reader.ReadByte)) {
+				e.handlePrintableChar(r)
+			}
+			return
+		}
 		if (nb >= 'A' && nb <= 'Z') || nb == '~' {
 			e.processCSIEscape(nb, string(params))
 			return
@@ -38,6 +38,26 @@ func (s *UIState) AddRune(r rune) {
 	}
 }
 
+// InsertText inserts text at the cursor as if typed, filtering once at
+// the end rather than after every rune.
+func (s *UIState) InsertText(text string) {
+	if text == "" {
+		return
+	}
+	s.resetHistoryRecall()
+	inputRunes := []rune(s.input)
+	if s.cursorPos > len(inputRunes) {
+		return
+	}
+	insert := []rune(text)
+	s.input = string(inputRunes[:s.cursorPos]) + text + string(inputRunes[s.cursorPos:])
+	s.cursorPos += len(insert)
+	s.UpdateFiltered()
+	if s.context != kb.ContextSearch {
+		s.SetContext(kb.ContextSearch)
+	}
+}
+
 // RemoveChar removes character before cursor (backspace)
 func (s *UIState) RemoveChar() {
 	s.resetHistoryRecall()
@@ -0,0 +1,79 @@
+package interactive
+
+// Query edits can be undone and redone. KeyHandler.HandleKey snapshots
+// the query before every key and records the snapshot when the key
+// changed it. Consecutive typed characters form one undo step, as in
+// readline, so undo removes a whole word rather than one letter.
+
+// maxUndoSteps bounds the undo stack.
+const maxUndoSteps = 100
+
+// inputEdit is the query and cursor position at one point in time.
+type inputEdit struct {
+	input  string
+	cursor int
+}
+
+func (s *UIState) inputSnapshot() inputEdit {
+	return inputEdit{input: s.input, cursor: s.cursorPos}
+}
+
+// recordEdit pushes before onto the undo stack if the query has changed
+// since. typed reports that the key was a printable character, which
+// joins the previous step when that was typed too.
+func (s *UIState) recordEdit(before inputEdit, typed bool) {
+	if s.editRestored {
+		s.editRestored = false
+		return
+	}
+	if before.input == s.input {
+		if before.cursor != s.cursorPos {
+			s.lastEditTyped = false
+		}
+		return
+	}
+	s.redoStack = nil
+	if typed && s.lastEditTyped {
+		return
+	}
+	s.lastEditTyped = typed
+	s.undoStack = append(s.undoStack, before)
+	if len(s.undoStack) > maxUndoSteps {
+		s.undoStack = s.undoStack[1:]
+	}
+}
+
+// Undo reverts the last query edit. It returns false when there is
+// nothing to undo.
+func (s *UIState) Undo() bool {
+	if len(s.undoStack) == 0 {
+		return false
+	}
+	prev := s.undoStack[len(s.undoStack)-1]
+	s.undoStack = s.undoStack[:len(s.undoStack)-1]
+	s.redoStack = append(s.redoStack, s.inputSnapshot())
+	s.restoreEdit(prev)
+	return true
+}
+
+// Redo re-applies the last undone edit. It returns false when there is
+// nothing to redo.
+func (s *UIState) Redo() bool {
+	if len(s.redoStack) == 0 {
+		return false
+	}
+	next := s.redoStack[len(s.redoStack)-1]
+	s.redoStack = s.redoStack[:len(s.redoStack)-1]
+	s.undoStack = append(s.undoStack, s.inputSnapshot())
+	s.restoreEdit(next)
+	return true
+}
+
+func (s *UIState) restoreEdit(e inputEdit) {
+	s.resetHistoryRecall()
+	s.input = e.input
+	s.cursorPos = min(e.cursor, len([]rune(e.input)))
+	s.UpdateFiltered()
+	s.lastEditTyped = false
+	s.editRestored = true
+}
@@ -1,9 +1,15 @@
 package interactive
 
 import (
+	"bufio"
+	"bytes"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
 )
 
 func TestBuildSearchKeybindEntriesUsesConfiguredBindings(t *testing.T) {
@@ -59,6 +65,239 @@ func TestBuildSearchKeybindEntriesFormatsMultipleKeys(t *testing.T) {
 	}
 }
 
+func TestNamedKeysInSearchMode(t *testing.T) {
+	ui := newUIWithKeyMap(&kb.KeyBindingMap{
+		DeleteToEnd: []kb.KeyStroke{kb.NewFnKeyStroke("F2")},
+	})
+	ui.state.input = "status"
+	ui.state.cursorPos = 3
+
+	// Home/End move the cursor without any binding, in xterm and rxvt form.
+	ui.handler.handleCSISequence(bufio.NewReader(strings.NewReader("8~")))
+	if ui.state.cursorPos != 6 {
+		t.Fatalf("End: cursor = %d, want 6", ui.state.cursorPos)
+	}
+	ui.handler.handleCSISequence(bufio.NewReader(strings.NewReader("H")))
+	if ui.state.cursorPos != 0 {
+		t.Fatalf("Home: cursor = %d, want 0", ui.state.cursorPos)
+	}
+
+	// A configured function key runs its action.
+	ui.state.cursorPos = 3
+	ui.handler.handleApplicationCursorMode(bufio.NewReader(strings.NewReader("Q")))
+	if ui.state.input != "sta" {
+		t.Fatalf("F2 bound to delete_to_end: input = %q, want %q", ui.state.input, "sta")
+	}
+
+	// Ctrl+Backspace deletes a word without any binding.
+	ui.state.input = "log simple"
+	ui.state.cursorPos = 10
+	ui.handler.handleCSISequence(bufio.NewReader(strings.NewReader("27;5;127~")))
+	if ui.state.input != "log " {
+		t.Fatalf("Ctrl+Backspace: input = %q, want %q", ui.state.input, "log ")
+	}
+
+	// screen sends Alt+Left as ESC and Left; it moves by word.
+	ui.state.input = "log simple"
+	ui.state.cursorPos = 10
+	ui.handler.handleEscapeSequence(bufio.NewReader(strings.NewReader("\x1b[D")))
+	if ui.state.cursorPos != 4 {
+		t.Fatalf("ESC ESC [ D: cursor = %d, want 4", ui.state.cursorPos)
+	}
+}
+
+func TestChordBindings(t *testing.T) {
+	ui := newUIWithKeyMap(&kb.KeyBindingMap{
+		DeleteToEnd: []kb.KeyStroke{kb.NewChordKeyStroke(kb.NewCtrlKeyStroke('x'), kb.NewCharKeyStroke('k'))},
+	})
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := ui.handler
+	h.clock = func() time.Time { return now }
+	h.chordTimeout = time.Second
+	reset := func() {
+		ui.state.input = "status"
+		ui.state.cursorPos = 3
+	}
+
+	reset()
+	h.HandleKey(24, false, nil, nil) // Ctrl+X
+	if got := h.PendingChord(); got != "Ctrl+x" {
+		t.Fatalf("PendingChord() = %q, want Ctrl+x", got)
+	}
+	h.HandleKey('k', false, nil, nil)
+	if ui.state.input != "sta" || h.PendingChord() != "" {
+		t.Fatalf("C-x k: input = %q, pending = %q", ui.state.input, h.PendingChord())
+	}
+
+	// A key after the timeout starts over and keeps its own meaning.
+	reset()
+	h.HandleKey(24, false, nil, nil)
+	now = now.Add(2 * time.Second)
+	h.HandleKey('k', false, nil, nil)
+	if ui.state.input != "staktus" {
+		t.Fatalf("after timeout: input = %q, want k inserted", ui.state.input)
+	}
+
+	// An unbound continuation is swallowed.
+	reset()
+	h.HandleKey(24, false, nil, nil)
+	h.HandleKey('z', false, nil, nil)
+	if ui.state.input != "status" || h.PendingChord() != "" {
+		t.Fatalf("unbound chord: input = %q, pending = %q", ui.state.input, h.PendingChord())
+	}
+}
+
+func TestViModalEditing(t *testing.T) {
+	restore := termio.SetPendingInputFunc(func(uintptr) (int, error) { return 0, nil })
+	defer restore()
+
+	ui := newUIWithKeyMap(kb.DefaultKeyBindingMap())
+	ui.stdin = os.Stdin
+	ui.stdout = &bytes.Buffer{}
+	ui.colors = NewANSIColors()
+	ui.workflowMgr = NewWorkflowManager()
+	for _, c := range []string{"add", "branch", "commit", "diff", "status"} {
+		ui.state.commands = append(ui.state.commands, CommandInfo{Command: c})
+	}
+	ui.state.UpdateFiltered()
+	h := ui.handler
+	h.vi = newViState(kb.CreateViProfile())
+	keys := func(s string) bool {
+		cont := true
+		for _, r := range s {
+			cont, _ = h.HandleKey(r, false, nil, nil)
+		}
+		return cont
+	}
+
+	if h.ViMode() != "INSERT" {
+		t.Fatalf("initial mode = %q, want INSERT", h.ViMode())
+	}
+	keys("\x1b")
+	if h.ViMode() != "NORMAL" {
+		t.Fatalf("ESC: mode = %q, want NORMAL", h.ViMode())
+	}
+
+	steps := []struct {
+		keys     string
+		selected int
+	}{
+		{"j", 1},
+		{"x", 1}, // unbound keys are not typed
+		{"G", 4},
+		{"gg", 0},
+		{"3j", 3},
+		{"k", 2},
+	}
+	for _, st := range steps {
+		keys(st.keys)
+		if ui.state.selected != st.selected || ui.state.input != "" {
+			t.Fatalf("after %q: selected = %d, input = %q", st.keys, ui.state.selected, ui.state.input)
+		}
+	}
+
+	// Visual mode adds the selected range to the workflow.
+	keys("vj")
+	if h.ViMode() != "VISUAL" || !h.inVisualRange(2) || !h.inVisualRange(3) || h.inVisualRange(4) {
+		t.Fatalf("visual range wrong: mode %q, selected %d", h.ViMode(), ui.state.selected)
+	}
+	keys("\t")
+	wf, _ := ui.workflowMgr.GetWorkflow(ui.workflowMgr.GetActiveID())
+	if h.ViMode() != "NORMAL" || wf == nil || wf.Size() != 2 {
+		t.Fatalf("Tab in visual mode: mode %q, workflow %+v", h.ViMode(), wf)
+	}
+
+	// i returns to insert mode, where keys are typed again.
+	keys("ist")
+	if h.ViMode() != "INSERT" || ui.state.input != "st" {
+		t.Fatalf("insert: mode %q, input %q", h.ViMode(), ui.state.input)
+	}
+	keys("\x1b0")
+	if ui.state.cursorPos != 0 {
+		t.Fatalf("0: cursor = %d, want 0", ui.state.cursorPos)
+	}
+	keys("A!")
+	if ui.state.input != "st!" {
+		t.Fatalf("A: input = %q", ui.state.input)
+	}
+
+	keys("\x1bu")
+	if ui.state.input != "st" {
+		t.Fatalf("u: input = %q, want the append undone", ui.state.input)
+	}
+	keys("\x12") // Ctrl+R
+	if ui.state.input != "st!" {
+		t.Fatalf("Ctrl+R: input = %q, want the append redone", ui.state.input)
+	}
+
+	if cont := keys("\x1bZZ"); cont {
+		t.Fatal("ZZ should quit")
+	}
+}
+
+func TestUndoRedo(t *testing.T) {
+	ui := newUIWithKeyMap(&kb.KeyBindingMap{
+		DeleteWord: []kb.KeyStroke{kb.NewCtrlKeyStroke('w')},
+		Undo:       []kb.KeyStroke{kb.NewCtrlKeyStroke('_')},
+		Redo:       []kb.KeyStroke{kb.NewCtrlKeyStroke('y')},
+	})
+	h := ui.handler
+	keys := func(s string) {
+		for _, r := range s {
+			h.HandleKey(r, false, nil, nil)
+		}
+	}
+	expect := func(step, want string) {
+		t.Helper()
+		if ui.state.input != want {
+			t.Fatalf("%s: input = %q, want %q", step, ui.state.input, want)
+		}
+	}
+
+	keys("commit")
+	keys("\x17") // Ctrl+W
+	expect("delete word", "")
+	keys("\x1f") // Ctrl+_
+	expect("undo delete", "commit")
+	keys("\x1f")
+	expect("undo typing", "")
+	keys("\x1f")
+	expect("undo with empty stack", "")
+	keys("\x19") // Ctrl+Y
+	expect("redo typing", "commit")
+	if ui.state.cursorPos != 6 {
+		t.Fatalf("cursor after redo = %d, want 6", ui.state.cursorPos)
+	}
+
+	// A new edit drops the redo stack.
+	keys("\x1f s")
+	keys("\x19")
+	expect("redo after new edit", " s")
+
+	// Without an undo binding Ctrl+_ keeps toggling the preview.
+	ui.handler.contextualMap.SetContext(kb.ContextSearch, kb.DefaultKeyBindingMap())
+	keys("\x1f")
+	if !ui.state.IsPreviewVisible() || ui.state.input != " s" {
+		t.Fatalf("Ctrl+_ without undo: preview %v, input %q", ui.state.IsPreviewVisible(), ui.state.input)
+	}
+}
+
+func TestViModeIndicator(t *testing.T) {
+	ui := newUIWithKeyMap(kb.DefaultKeyBindingMap())
+	if ui.viModeIndicator() != "" {
+		t.Error("no indicator expected without the vi profile")
+	}
+	ui.handler.vi = newViState(kb.CreateViProfile())
+	ui.handler.vi.enter(viNormal)
+
+	var buf bytes.Buffer
+	r := &Renderer{writer: &buf, colors: NewANSIColors(), width: 80, height: 24}
+	r.renderHeader(ui)
+	if !strings.Contains(buf.String(), "-- NORMAL --") {
+		t.Errorf("header = %q, want the mode indicator", buf.String())
+	}
+}
+
 func newUIWithKeyMap(km *kb.KeyBindingMap) *UI {
 	state := &UIState{context: kb.ContextSearch}
 	ui := &UI{state: state}
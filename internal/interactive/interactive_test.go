@@ -14,6 +14,7 @@ import (
 	"golang.org/x/term"
 
 	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
 	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
 	"github.com/bmf-san/ggc/v8/internal/termio"
 	"github.com/bmf-san/ggc/v8/internal/testutil"
@@ -496,9 +497,9 @@ func TestUIState_MoveUp(t *testing.T) {
 		input:     "",
 		cursorPos: 0,
 		filtered: []CommandInfo{
-			{"cmd1", "desc1"},
-			{"cmd2", "desc2"},
-			{"cmd3", "desc3"},
+			{Command: "cmd1", Description: "desc1"},
+			{Command: "cmd2", Description: "desc2"},
+			{Command: "cmd3", Description: "desc3"},
 		},
 	}
 
@@ -520,9 +521,9 @@ func TestUIState_MoveDown(t *testing.T) {
 		input:     "",
 		cursorPos: 0,
 		filtered: []CommandInfo{
-			{"cmd1", "desc1"},
-			{"cmd2", "desc2"},
-			{"cmd3", "desc3"},
+			{Command: "cmd1", Description: "desc1"},
+			{Command: "cmd2", Description: "desc2"},
+			{Command: "cmd3", Description: "desc3"},
 		},
 	}
 
@@ -771,8 +772,8 @@ func TestUIState_GetSelectedCommand(t *testing.T) {
 		input:     "",
 		cursorPos: 0,
 		filtered: []CommandInfo{
-			{"cmd1", "desc1"},
-			{"cmd2", "desc2"},
+			{Command: "cmd1", Description: "desc1"},
+			{Command: "cmd2", Description: "desc2"},
 		},
 	}
 
@@ -813,9 +814,9 @@ func TestRenderer_UpdateSize(t *testing.T) {
 func TestRenderer_CalculateMaxCommandLength(t *testing.T) {
 	renderer := &Renderer{}
 	commands := []CommandInfo{
-		{"short", "desc"},
-		{"very long command", "desc"},
-		{"medium", "desc"},
+		{Command: "short", Description: "desc"},
+		{Command: "very long command", Description: "desc"},
+		{Command: "medium", Description: "desc"},
 	}
 
 	maxLen := renderer.calculateMaxCommandLength(commands)
@@ -936,6 +937,130 @@ func TestRenderer_EmptyState(t *testing.T) {
 	}
 }
 
+func TestRenderer_PaletteSections(t *testing.T) {
+	var buf bytes.Buffer
+	colors := NewANSIColors()
+	renderer := &Renderer{writer: &buf, colors: colors, width: 80, height: 24}
+	state := &UIState{commands: []CommandInfo{
+		{Command: "push current", Section: "Pinned"},
+		{Command: "pull current", Section: "Sync"},
+		{Command: "status"},
+	}}
+	state.UpdateFiltered()
+	ui := &UI{
+		stdin:       strings.NewReader(""),
+		stdout:      &buf,
+		stderr:      &bytes.Buffer{},
+		term:        &mockTerminal{},
+		renderer:    renderer,
+		state:       state,
+		colors:      colors,
+		workflowMgr: NewWorkflowManager(),
+	}
+
+	renderer.Render(ui, state)
+	output := buf.String()
+	for _, want := range []string{"Pinned", "push current", "Sync", "pull current", "Start typing to search commands..."} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected palette output to contain %q", want)
+		}
+	}
+	if strings.Contains(output, "status") {
+		t.Error("unsectioned commands should wait for a query")
+	}
+
+	buf.Reset()
+	state.input = "u"
+	state.UpdateFiltered()
+	renderer.Render(ui, state)
+	if !strings.Contains(buf.String(), "Commands") || !strings.Contains(buf.String(), "status") {
+		t.Errorf("expected unsectioned matches under Commands, got %q", buf.String())
+	}
+}
+
+func TestRenderer_ScrollIndicators(t *testing.T) {
+	var buf bytes.Buffer
+	colors := NewANSIColors()
+	renderer := &Renderer{writer: &buf, colors: colors, width: 80, height: 12}
+	state := &UIState{input: "cmd"}
+	for i := range 30 {
+		state.commands = append(state.commands, CommandInfo{Command: fmt.Sprintf("cmd%02d", i)})
+	}
+	state.UpdateFiltered()
+	ui := &UI{
+		stdin:       strings.NewReader(""),
+		stdout:      &buf,
+		stderr:      &bytes.Buffer{},
+		term:        &mockTerminal{},
+		renderer:    renderer,
+		state:       state,
+		colors:      colors,
+		workflowMgr: NewWorkflowManager(),
+	}
+	handler := &KeyHandler{ui: ui}
+
+	renderer.Render(ui, state)
+	if out := buf.String(); strings.Contains(out, "more above") || !strings.Contains(out, "more below") || strings.Contains(out, "cmd29") {
+		t.Errorf("expected only the first page with a below indicator, got %q", out)
+	}
+
+	for range 3 {
+		handler.handleCSISequence(bufio.NewReader(strings.NewReader("6~")))
+	}
+	buf.Reset()
+	renderer.Render(ui, state)
+	out := buf.String()
+	if !strings.Contains(out, "more above") || !strings.Contains(out, state.filtered[state.selected].Command) {
+		t.Errorf("expected the selection to scroll into view, got %q", out)
+	}
+	if renderer.lines > renderer.height {
+		t.Errorf("rendered %d lines into a %d line terminal", renderer.lines, renderer.height)
+	}
+}
+
+func TestRenderer_PreviewPane(t *testing.T) {
+	var buf bytes.Buffer
+	colors := NewANSIColors()
+	renderer := &Renderer{writer: &buf, colors: colors, width: 80, height: 24}
+	state := &UIState{input: "push", commands: []CommandInfo{{
+		Command:     "push force",
+		Description: "Force push current branch",
+		Git:         "git push origin <branch> --force-with-lease",
+		Usage:       []string{"ggc push force"},
+		Examples:    []string{"ggc push force    # Force push current branch"},
+	}}}
+	state.UpdateFiltered()
+	ui := &UI{
+		stdin:       strings.NewReader(""),
+		stdout:      &buf,
+		stderr:      &bytes.Buffer{},
+		term:        &mockTerminal{},
+		renderer:    renderer,
+		state:       state,
+		colors:      colors,
+		workflowMgr: NewWorkflowManager(),
+	}
+	handler := &KeyHandler{ui: ui}
+
+	renderer.Render(ui, state)
+	if strings.Contains(buf.String(), "Preview:") {
+		t.Fatal("preview pane should be hidden by default")
+	}
+
+	handler.handleSpecialCtrlChars(31, nil, bufio.NewReader(strings.NewReader("")))
+	if !state.IsPreviewVisible() {
+		t.Fatal("Ctrl+/ should toggle the preview on")
+	}
+	buf.Reset()
+	renderer.Render(ui, state)
+	out := buf.String()
+	for _, want := range []string{"Preview:", "git push origin <branch> --force-with-lease", "Usage:", "Examples:", "# Force push current branch"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("preview missing %q in %q", want, out)
+		}
+	}
+}
+
 // Test Git status functionality
 func TestGetGitStatus(t *testing.T) {
 	// Create mock git client
@@ -1151,6 +1276,16 @@ func TestRenderer_RenderCommandItem(t *testing.T) {
 	if strings.Contains(output, "▶") {
 		t.Error("Expected non-selected item to NOT contain '▶' indicator")
 	}
+
+	// Multi-select mode draws a marker before each item
+	ui.state = &UIState{}
+	ui.state.SetMultiSelect(true)
+	ui.state.setMark("test command", true)
+	buf.Reset()
+	renderer.renderCommandItem(ui, cmd, 1, 0, 20)
+	if !strings.Contains(buf.String(), "◉") {
+		t.Errorf("Expected marked item to contain '◉', got %q", buf.String())
+	}
 }
 
 // Test interactive input functionality
@@ -1371,6 +1506,36 @@ func TestShouldHandleEscapeAsSoftCancel(t *testing.T) {
 	restorePending()
 }
 
+// TestEscapeIsLone_WaitsForEscapeTimeout checks that inside a multiplexer,
+// which may forward the rest of a sequence a moment after its ESC, the
+// handler waits for it before taking ESC as a key of its own.
+func TestEscapeIsLone_WaitsForEscapeTimeout(t *testing.T) {
+	ui := &UI{stdin: os.Stdin, state: &UIState{context: kb.ContextGlobal}}
+	handler := &KeyHandler{ui: ui, escapeTimeout: escapeTimeoutFrom(nil, "tmux")}
+	ui.handler = handler
+
+	probes := 0
+	restore := termio.SetPendingInputFunc(func(uintptr) (int, error) {
+		probes++
+		if probes < 2 {
+			return 0, nil
+		}
+		return 1, nil
+	})
+	defer restore()
+	if handler.escapeIsLone() {
+		t.Fatal("ESC followed by input within the escape timeout should start a sequence")
+	}
+
+	cfg := &config.Config{}
+	cfg.Interactive.EscapeTimeout = "0"
+	handler.escapeTimeout = escapeTimeoutFrom(cfg, "tmux")
+	probes = 0
+	if !handler.escapeIsLone() {
+		t.Fatal("escape-timeout 0 should not wait for input")
+	}
+}
+
 // TestHandleKey_EscapeWithReaderParameter tests that ESC key (byte 27) correctly
 // handles both soft cancel and escape sequences with the reader parameter.
 // This test ensures the merge conflict resolution between soft cancel logic
@@ -2401,10 +2566,10 @@ type mockStatusInfoReader struct {
 func (m *mockStatusInfoReader) GetCurrentBranch() (string, error) {
 	return m.currentBranch, m.currentBranchErr
 }
-func (m *mockStatusInfoReader) StatusWithColor() (string, error) {
+func (m *mockStatusInfoReader) StatusWithColor(...string) (string, error) {
 	return m.statusOutput, m.statusErr
 }
-func (m *mockStatusInfoReader) StatusShortWithColor() (string, error) {
+func (m *mockStatusInfoReader) StatusShortWithColor(...string) (string, error) {
 	return m.statusOutput, m.statusErr
 }
 func (m *mockStatusInfoReader) GetAheadBehindCount(_, _ string) (string, error) {
@@ -2463,6 +2628,43 @@ func TestGetGitStatus_NilOnBranchError(t *testing.T) {
 	}
 }
 
+type summaryGitClient struct {
+	mockStatusInfoReader
+	summary *git.StatusSummary
+	err     error
+}
+
+func (m *summaryGitClient) StatusSummary() (*git.StatusSummary, error) {
+	return m.summary, m.err
+}
+
+func TestGetGitStatus_UsesSummary(t *testing.T) {
+	client := &summaryGitClient{
+		mockStatusInfoReader: mockStatusInfoReader{currentBranchErr: errors.New("must not be called")},
+		summary: &git.StatusSummary{
+			Branch: "main",
+			Ahead:  1,
+			Behind: 2,
+			Entries: []git.StatusEntry{
+				{Kind: git.StatusOrdinary, Index: 'M', WorkTree: '.', Path: "a"},
+				{Kind: git.StatusOrdinary, Index: '.', WorkTree: 'M', Path: "b"},
+				{Kind: git.StatusUnmerged, Index: 'U', WorkTree: 'U', Path: "c"},
+				{Kind: git.StatusUntracked, Path: "d"},
+			},
+		},
+	}
+	got := getGitStatus(client)
+	want := &GitStatus{Branch: "main", Modified: 2, Staged: 1, Ahead: 1, Behind: 2, HasChanges: true}
+	if got == nil || *got != *want {
+		t.Fatalf("getGitStatus() = %+v, want %+v", got, want)
+	}
+
+	client.err = errors.New("not a git repository")
+	if got := getGitStatus(client); got != nil {
+		t.Errorf("expected nil status outside a repository, got %+v", got)
+	}
+}
+
 func TestUIWorkflow_NilManagerGuards(t *testing.T) {
 	ui := &UI{} // workflowMgr is nil
 
@@ -3,6 +3,7 @@ package interactive
 
 import (
 	"bufio"
+	"time"
 	"unicode"
 
 	"golang.org/x/term"
@@ -14,6 +15,18 @@ import (
 type KeyHandler struct {
 	ui            *UI
 	contextualMap *kb.ContextualKeyBindingMap
+
+	// Chord state; see keys_chord.go.
+	chord        chordState
+	chordTimeout time.Duration
+	clock        func() time.Time // nil means time.Now
+
+	// escapeTimeout is how long a lone ESC waits for the rest of a
+	// sequence; see escapeIsLone.
+	escapeTimeout time.Duration
+
+	// Vi modal state; nil unless the vi profile is active. See keys_vi.go.
+	vi *viState
 }
 
 // GetCurrentKeyMap returns the appropriate keybinding map for the current context
@@ -37,8 +50,25 @@ func (h *KeyHandler) GetCurrentKeyMap() *kb.KeyBindingMap {
 // HandleKey processes UTF-8 rune input and returns true if should continue
 // This method handles both single-byte (ASCII/control) and multibyte characters
 func (h *KeyHandler) HandleKey(r rune, _ bool, oldState *term.State, reader *bufio.Reader) (bool, []string) {
+	before := h.ui.state.inputSnapshot()
+	cont, result := h.handleKey(r, oldState, reader)
+	h.ui.state.recordEdit(before, unicode.IsPrint(r))
+	return cont, result
+}
+
+func (h *KeyHandler) handleKey(r rune, oldState *term.State, reader *bufio.Reader) (bool, []string) {
 	// Set the reader for consistent access during escape sequence handling
 	h.ui.reader = reader
+	// Multi-key chords claim their keys before any single-key binding
+	if handled, cont, result := h.handleChord(r, oldState); handled {
+		return cont, result
+	}
+
+	// In vi normal and visual mode, keys are commands rather than text
+	if handled, cont, result := h.handleViKey(r, oldState); handled {
+		return cont, result
+	}
+
 	// Handle workflow-specific keys first (Tab, etc.)
 	if handled, cont, result := h.handleWorkflowKeys(r, oldState); handled {
 		return cont, result
@@ -51,6 +81,12 @@ func (h *KeyHandler) HandleKey(r rune, _ bool, oldState *term.State, reader *buf
 		}
 	}
 
+	// Space marks the highlighted result while navigating a multi-select list
+	if r == ' ' && h.ui.state.IsMultiSelect() && h.ui.state.IsInResultsMode() {
+		h.ui.state.ToggleMark()
+		return true, nil
+	}
+
 	// Handle printable characters (both ASCII and multibyte)
 	// Workflow mode has no input field, so ignore printable characters
 	if unicode.IsPrint(r) {
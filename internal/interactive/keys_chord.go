@@ -0,0 +1,124 @@
+package interactive
+
+import (
+	"slices"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+)
+
+// Chords are bindings made of several keystrokes, such as C-x C-w in the
+// readline profile. The handler collects keys while they are a prefix of
+// some bound chord and dispatches the chord's action once it is complete.
+// Input is read with blocking reads, so the timeout is checked when the
+// next key arrives: a key pressed after it starts over.
+
+// defaultChordTimeout is how long a chord prefix waits for its next key.
+const defaultChordTimeout = time.Second
+
+// chordState is the chord prefix typed so far.
+type chordState struct {
+	keys []kb.KeyStroke
+	at   time.Time
+}
+
+// chordTimeoutFrom reads interactive.chord-timeout. Empty or invalid
+// values leave the default in place.
+func chordTimeoutFrom(cfg *config.Config) time.Duration {
+	if cfg == nil || cfg.Interactive.ChordTimeout == "" {
+		return defaultChordTimeout
+	}
+	d, err := time.ParseDuration(cfg.Interactive.ChordTimeout)
+	if err != nil || d <= 0 {
+		return defaultChordTimeout
+	}
+	return d
+}
+
+func (h *KeyHandler) now() time.Time {
+	if h.clock != nil {
+		return h.clock()
+	}
+	return time.Now()
+}
+
+func (h *KeyHandler) chordExpired() bool {
+	timeout := h.chordTimeout
+	if timeout <= 0 {
+		timeout = defaultChordTimeout
+	}
+	return h.now().Sub(h.chord.at) > timeout
+}
+
+// PendingChord returns the keys of an unfinished chord for display, or ""
+// when none is pending.
+func (h *KeyHandler) PendingChord() string {
+	if h == nil || len(h.chord.keys) == 0 || h.chordExpired() {
+		return ""
+	}
+	return kb.FormatKeyStrokeForDisplay(kb.NewChordKeyStroke(h.chord.keys...))
+}
+
+// chordKeyStroke converts a typed rune to the keystroke chords are written
+// with. Escape sequences never take part in chords.
+func chordKeyStroke(r rune) (kb.KeyStroke, bool) {
+	switch {
+	case r == 9 || r == 13:
+		return kb.NewRawKeyStroke([]byte{byte(r)}), true
+	case r >= 1 && r <= 26:
+		return kb.NewCtrlKeyStroke('a' + r - 1), true
+	case r >= 32 && r != 127:
+		return kb.NewRawKeyStroke([]byte(string(r))), true
+	}
+	return kb.KeyStroke{}, false
+}
+
+// handleChord feeds r to the chord state machine. It reports handled when
+// r extended, completed or broke off a chord.
+func (h *KeyHandler) handleChord(r rune, oldState *term.State) (bool, bool, []string) {
+	if len(h.chord.keys) > 0 && h.chordExpired() {
+		h.chord = chordState{}
+	}
+	pending := len(h.chord.keys) > 0
+	stroke, ok := chordKeyStroke(r)
+	if !ok {
+		// Esc, Backspace and escape sequences abandon a pending chord
+		// and keep their usual meaning.
+		h.chord = chordState{}
+		return false, true, nil
+	}
+
+	keys := append(slices.Clone(h.chord.keys), stroke)
+	km := h.GetCurrentKeyMap()
+	switch km.MatchChord(keys) {
+	case kb.ChordPrefix:
+		h.chord = chordState{keys: keys, at: h.now()}
+		return true, true, nil
+	case kb.ChordComplete:
+		h.chord = chordState{}
+		return h.runChord(km, kb.NewChordKeyStroke(keys...), oldState)
+	}
+	// An unbound continuation is swallowed, as in Emacs.
+	h.chord = chordState{}
+	return pending, true, nil
+}
+
+// runChord dispatches a completed chord through the same handlers as
+// single keystrokes.
+func (h *KeyHandler) runChord(km *kb.KeyBindingMap, chord kb.KeyStroke, oldState *term.State) (bool, bool, []string) {
+	if h.ui.state.IsWorkflowMode() {
+		if !h.handleWorkflowCtrlKeys(km, chord, 0, oldState) {
+			h.handleWorkflowClear(chord)
+		}
+		return true, true, nil
+	}
+	if km.MatchesKeyStroke("add_to_workflow", chord) {
+		h.addSelectedToWorkflow()
+		return true, true, nil
+	}
+	_, cont, result := h.handleSearchCtrlKeys(km, chord, oldState)
+	return true, cont, result
+}
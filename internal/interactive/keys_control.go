@@ -26,6 +26,12 @@ func (h *KeyHandler) handleCtrlLetterKeys(b byte, oldState *term.State) (bool, b
 	km := h.GetCurrentKeyMap()
 	ctrlStroke := kb.NewCtrlKeyStroke(rune('a' + b - 1))
 
+	// Suspend works the same in every mode.
+	if km.MatchesKeyStroke("suspend", ctrlStroke) {
+		h.ui.suspend(oldState)
+		return true, true, nil
+	}
+
 	// Workflow mode has different key handling
 	if h.ui.state.IsWorkflowMode() {
 		if h.handleWorkflowCtrlKeys(km, ctrlStroke, b, oldState) {
@@ -131,6 +137,12 @@ func (h *KeyHandler) handleSearchEditKeys(km *kb.KeyBindingMap, stroke kb.KeyStr
 	case km.MatchesKeyStroke("delete_to_end", stroke):
 		h.ui.state.DeleteToEnd()
 		return true
+	case km.MatchesKeyStroke("undo", stroke):
+		h.ui.state.Undo()
+		return true
+	case km.MatchesKeyStroke("redo", stroke):
+		h.ui.state.Redo()
+		return true
 	}
 	return false
 }
@@ -168,6 +180,22 @@ func (h *KeyHandler) handleSpecialCtrlChars(b byte, oldState *term.State, reader
 	case 127, 8: // Backspace
 		h.ui.state.RemoveChar()
 		return true, true, nil
+	case 31: // Ctrl+/ (sent as Ctrl+_ by most terminals)
+		if h.ui.state.IsWorkflowMode() {
+			return true, true, nil
+		}
+		// Profiles that bind readline's Ctrl+_ undo take the key over
+		// from the preview toggle.
+		km := h.GetCurrentKeyMap()
+		switch {
+		case km.MatchesKeyStroke("undo", kb.NewCtrlKeyStroke('_')):
+			h.ui.state.Undo()
+		case km.MatchesKeyStroke("redo", kb.NewCtrlKeyStroke('_')):
+			h.ui.state.Redo()
+		default:
+			h.ui.state.TogglePreview()
+		}
+		return true, true, nil
 	case 27: // ESC
 		if h.shouldHandleEscapeAsSoftCancel() {
 			h.handleSoftCancel(oldState)
@@ -184,5 +212,5 @@ func (h *KeyHandler) handleSpecialCtrlChars(b byte, oldState *term.State, reader
 // Supports:
 // - Arrow keys: ESC [ C/D (right/left), ESC O C/D (application mode)
 // - Ctrl+Arrow: ESC [ 1;5 C/D or ESC [ 5 C/D
-// - Alt/Option+Arrow: ESC [ 1;3 C/D, ESC [ 1;9 C/D (varies by terminal)
+// - Alt/Option+Arrow: ESC [ 1;3 C/D, ESC [ 1;9 C/D, ESC ESC [ C/D (varies by terminal)
 // - macOS Option word nav: ESC b / ESC f
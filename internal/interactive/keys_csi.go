@@ -7,29 +7,31 @@ import (
 )
 
 func (h *KeyHandler) handleCSISequence(reader *bufio.Reader) {
-	var params []byte
-	for {
-		var nb byte
-		var err error
-
-		if reader != nil {
-			// Use provided buffered reader (non-raw mode)
-			nb, err = reader.ReadByte()
-		} else {
-			// Raw mode: read directly from stdin
-			var buf [1]byte
-			_, err = h.ui.stdin.Read(buf[:])
-			nb = buf[0]
-		}
+	params, final, ok := h.readCSI(reader)
+	if !ok {
+		return
+	}
+	if final == '~' && params == pasteStartParams {
+		h.handlePaste(reader)
+		return
+	}
+	h.processCSIFinalByte(final, params)
+}
 
+// readCSI reads the parameters and final byte of a CSI sequence whose
+// ESC [ has been read.
+func (h *KeyHandler) readCSI(reader *bufio.Reader) (params string, final byte, ok bool) {
+	var buf []byte
+	for {
+		nb, err := h.readNextByte(reader)
 		if err != nil {
-			return
+			return "", 0, false
 		}
-		if (nb >= 'A' && nb <= 'Z') || nb == '~' {
-			h.processCSIFinalByte(nb, string(params))
-			return
+		// Final bytes are 0x40-0x7e; parameters and intermediates are below.
+		if nb >= 0x40 && nb <= 0x7e {
+			return string(buf), nb, true
 		}
-		params = append(params, nb)
+		buf = append(buf, nb)
 	}
 }
 
@@ -47,6 +49,10 @@ func (h *KeyHandler) processCSIFinalByte(final byte, params string) {
 		return
 	}
 
+	if h.handleNamedKey(km, keyStroke) {
+		return
+	}
+
 	// Fallback to default cursor movement and word navigation
 	h.handleDefaultArrowMovement(final, isWord)
 }
@@ -61,3 +67,35 @@ func (h *KeyHandler) buildCSISequence(final byte, params string) []byte {
 }
 
 // tryArrowKeybinding attempts to handle arrow keys via keybindings
+
+// handleNamedKey handles function keys, Home/End, PgUp/PgDn and modified
+// arrows in search mode. Configured bindings such as move_to_end: "end"
+// come first; Home/End, PgUp/PgDn and Ctrl+Backspace fall back to their
+// usual meaning.
+func (h *KeyHandler) handleNamedKey(km *kb.KeyBindingMap, keyStroke kb.KeyStroke) bool {
+	if h.ui.state.IsWorkflowMode() {
+		return false
+	}
+	named, ok := kb.DecodeSequence(keyStroke.Seq)
+	if !ok {
+		return false
+	}
+	if h.handleSearchNavKeys(km, named) || h.handleSearchEditKeys(km, named) {
+		return true
+	}
+	switch named.Name {
+	case kb.KeyPgUp:
+		h.ui.state.PageUp()
+	case kb.KeyPgDn:
+		h.ui.state.PageDown()
+	case kb.KeyHome:
+		h.ui.state.MoveToBeginning()
+	case kb.KeyEnd:
+		h.ui.state.MoveToEnd()
+	case kb.KeyCtrlBackspace:
+		h.ui.state.DeleteWord()
+	default:
+		return false
+	}
+	return true
+}
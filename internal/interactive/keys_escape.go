@@ -3,9 +3,11 @@ package interactive
 import (
 	"bufio"
 	"os"
+	"time"
 
 	"golang.org/x/term"
 
+	"github.com/bmf-san/ggc/v8/internal/config"
 	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
 	"github.com/bmf-san/ggc/v8/internal/termio"
 )
@@ -25,7 +27,7 @@ func (h *KeyHandler) handleEscapeSequence(reader *bufio.Reader) {
 	} else {
 		// Raw mode: read directly from stdin
 		var buf [1]byte
-		_, err = h.ui.stdin.Read(buf[:])
+		_, err = h.ui.keyInput().Read(buf[:])
 		b = buf[0]
 	}
 
@@ -38,6 +40,8 @@ func (h *KeyHandler) handleEscapeSequence(reader *bufio.Reader) {
 		h.handleCSISequence(reader)
 	case 'O':
 		h.handleApplicationCursorMode(reader)
+	case 27:
+		h.handleMetaEscape(reader)
 	case 'b':
 		h.ui.state.MoveWordLeft()
 	case 'f':
@@ -48,6 +52,25 @@ func (h *KeyHandler) handleEscapeSequence(reader *bufio.Reader) {
 	}
 }
 
+// handleMetaEscape handles keys a terminal sends as ESC followed by the
+// key's own sequence, as screen and tmux do for Alt+arrows. The first ESC
+// has been read.
+func (h *KeyHandler) handleMetaEscape(reader *bufio.Reader) {
+	if b, err := h.readNextByte(reader); err != nil || b != '[' {
+		return
+	}
+	params, final, ok := h.readCSI(reader)
+	if !ok {
+		return
+	}
+	seq := append([]byte{27}, h.buildCSISequence(final, params)...)
+	if h.handleNamedKey(h.GetCurrentKeyMap(), kb.NewRawKeyStroke(seq)) {
+		return
+	}
+	// Alt+Left and Alt+Right move by word, as ESC [ 1;3 D does.
+	h.handleDefaultArrowMovement(final, true)
+}
+
 func (h *KeyHandler) handleSoftCancel(_ *term.State) {
 	if h == nil || h.ui == nil {
 		return
@@ -63,7 +86,26 @@ func (h *KeyHandler) shouldHandleEscapeAsSoftCancel() bool {
 	if km == nil || !km.MatchesKeyStroke("soft_cancel", kb.NewEscapeKeyStroke()) {
 		return false
 	}
+	return h.escapeIsLone()
+}
 
+// escapeTimeoutFrom reads interactive.escape-timeout. Empty or invalid
+// values leave terminal's default in place; see kb.EscapeTimeout.
+func escapeTimeoutFrom(cfg *config.Config, terminal string) time.Duration {
+	if cfg == nil || cfg.Interactive.EscapeTimeout == "" {
+		return kb.EscapeTimeout(terminal)
+	}
+	d, err := time.ParseDuration(cfg.Interactive.EscapeTimeout)
+	if err != nil || d < 0 {
+		return kb.EscapeTimeout(terminal)
+	}
+	return d
+}
+
+// escapeIsLone reports whether an ESC just read was pressed on its own
+// rather than starting an escape sequence: nothing follows it within the
+// escape timeout.
+func (h *KeyHandler) escapeIsLone() bool {
 	if h.ui == nil {
 		return false
 	}
@@ -71,10 +113,14 @@ func (h *KeyHandler) shouldHandleEscapeAsSoftCancel() bool {
 	if h.ui.reader != nil && h.ui.reader.Buffered() > 0 {
 		return false
 	}
+	// The rest of a translated escape sequence waits in the console reader.
+	if keys, ok := h.ui.keys.(*termio.ConsoleReader); ok && keys.Buffered() > 0 {
+		return false
+	}
 
 	if file, ok := h.ui.stdin.(*os.File); ok {
-		if pending, err := termio.PendingInput(file.Fd()); err == nil {
-			return pending == 0
+		if ready, err := termio.WaitInput(file.Fd(), h.escapeTimeout); err == nil {
+			return !ready
 		}
 	}
 
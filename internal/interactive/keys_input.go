@@ -16,7 +16,7 @@ func (h *KeyHandler) readNextByte(reader *bufio.Reader) (byte, error) {
 		return reader.ReadByte()
 	}
 	var buf [1]byte
-	_, err := h.ui.stdin.Read(buf[:])
+	_, err := h.ui.keyInput().Read(buf[:])
 	return buf[0], err
 }
 
@@ -37,6 +37,7 @@ func (h *KeyHandler) restoreTerminalState(oldState *term.State) {
 	if oldState == nil {
 		return
 	}
+	disableBracketedPaste(h.ui.stdout)
 	if f, ok := h.ui.stdin.(*os.File); ok {
 		if err := h.ui.term.Restore(int(f.Fd()), oldState); err != nil {
 			h.ui.writeError("failed to restore terminal state: %v", err)
@@ -53,13 +54,15 @@ func (h *KeyHandler) reenterRawMode(oldState *term.State) {
 		fd := int(f.Fd())
 		if _, err := h.ui.term.MakeRaw(fd); err != nil {
 			h.ui.writeError("failed to set terminal to raw mode: %v", err)
+			return
 		}
+		enableBracketedPaste(h.ui.stdout)
 	}
 }
 
 // handleEnter handles Enter key press
 func (h *KeyHandler) handleEnter(oldState *term.State) (bool, []string) {
-	if !h.ui.state.HasInput() {
+	if !h.ui.state.HasInput() && !h.ui.state.ShowsPalette() {
 		return true, nil
 	}
 
@@ -82,7 +85,7 @@ func (h *KeyHandler) handleEnter(oldState *term.State) (bool, []string) {
 			h.reenterRawMode(oldState)
 			return true, nil
 		}
-		clearScreen(h.ui.stdout)
+		h.ui.clearScreen()
 		executeMsg := fmt.Sprintf("%s🚀 %sExecuting:%s %s%s%s\n\n",
 			h.ui.colors.BrightGreen,
 			h.ui.colors.BrightWhite+h.ui.colors.Bold,
@@ -102,7 +105,7 @@ func (h *KeyHandler) handleEnter(oldState *term.State) (bool, []string) {
 	}
 
 	// Clear screen and show execution message
-	clearScreen(h.ui.stdout)
+	h.ui.clearScreen()
 	executeMsg := fmt.Sprintf("%s🚀 %sExecuting:%s %s%s%s\n\n",
 		h.ui.colors.BrightGreen,
 		h.ui.colors.BrightWhite+h.ui.colors.Bold,
@@ -119,6 +122,7 @@ func (h *KeyHandler) handleEnter(oldState *term.State) (bool, []string) {
 		h.reenterRawMode(oldState)
 		return true, nil
 	}
+	h.ui.recordUsage(selectedCmd.Command)
 	return false, args
 }
 
@@ -199,7 +203,11 @@ func (h *KeyHandler) getRealTimeInput() (string, bool) {
 	if err != nil {
 		return h.getLineInput()
 	}
-	defer func() { _ = h.ui.term.Restore(fd, oldState) }()
+	enableBracketedPaste(h.ui.stdout)
+	defer func() {
+		disableBracketedPaste(h.ui.stdout)
+		_ = h.ui.term.Restore(fd, oldState)
+	}()
 
 	return h.processRealTimeInput()
 }
@@ -92,6 +92,9 @@ func (h *KeyHandler) handleApplicationCursorMode(reader *bufio.Reader) {
 	if h.tryArrowKeybinding(km, keyStroke) {
 		return
 	}
+	if h.handleNamedKey(km, keyStroke) {
+		return
+	}
 
 	// Fallback to default arrow key behavior
 	h.handleDefaultAppCursorMovement(nb)
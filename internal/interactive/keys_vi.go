@@ -0,0 +1,324 @@
+package interactive
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+)
+
+// The vi profile is modal. Insert mode is the regular search prompt;
+// a lone ESC switches to normal mode, where printable keys run the
+// actions bound in the profile's results context (hjkl, gg, G, w, b, ...)
+// instead of being typed, and i/a/I/A or / go back to insert mode.
+// Visual mode extends a range of results from where v was pressed so
+// the whole range can be added to the workflow at once.
+
+type viMode int
+
+const (
+	viInsert viMode = iota
+	viNormal
+	viVisual
+)
+
+func (m viMode) String() string {
+	switch m {
+	case viNormal:
+		return "NORMAL"
+	case viVisual:
+		return "VISUAL"
+	default:
+		return "INSERT"
+	}
+}
+
+// viState is the modal state machine. A nil *viState means the vi
+// profile is not active.
+type viState struct {
+	mode     viMode
+	bindings map[string]string // key bytes -> action
+	pending  string            // keys of an unfinished multi-key binding such as gg
+	count    int               // numeric prefix, 0 when none
+	anchor   int               // visual mode start
+}
+
+// viAction runs a normal-mode action n times (n >= 1). It returns false
+// when the UI should exit.
+type viAction func(h *KeyHandler, n int, oldState *term.State) bool
+
+// repeat wraps a single-step action so a count prefix repeats it.
+func repeat(step func(h *KeyHandler)) viAction {
+	return func(h *KeyHandler, n int, _ *term.State) bool {
+		for range n {
+			step(h)
+		}
+		return true
+	}
+}
+
+// once wraps an action that ignores the count prefix.
+func once(fn func(h *KeyHandler)) viAction {
+	return func(h *KeyHandler, _ int, _ *term.State) bool {
+		fn(h)
+		return true
+	}
+}
+
+// viActions are the profile actions the interactive UI implements in
+// normal and visual mode. Profile actions not listed here are ignored.
+var viActions = map[string]viAction{
+	"move_down":         repeat((*KeyHandler).handleMoveDown),
+	"move_up":           repeat((*KeyHandler).handleMoveUp),
+	"move_down_alt":     repeat((*KeyHandler).handleMoveDown),
+	"move_up_alt":       repeat((*KeyHandler).handleMoveUp),
+	"search_next":       repeat((*KeyHandler).handleMoveDown),
+	"search_previous":   repeat((*KeyHandler).handleMoveUp),
+	"move_left":         repeat(func(h *KeyHandler) { h.ui.state.MoveLeft() }),
+	"move_right":        repeat(func(h *KeyHandler) { h.ui.state.MoveRight() }),
+	"forward_word":      repeat(func(h *KeyHandler) { h.ui.state.MoveWordRight() }),
+	"forward_word_big":  repeat(func(h *KeyHandler) { h.ui.state.MoveWordRight() }),
+	"end_word":          repeat(func(h *KeyHandler) { h.ui.state.MoveWordRight() }),
+	"end_word_big":      repeat(func(h *KeyHandler) { h.ui.state.MoveWordRight() }),
+	"backward_word":     repeat(func(h *KeyHandler) { h.ui.state.MoveWordLeft() }),
+	"backward_word_big": repeat(func(h *KeyHandler) { h.ui.state.MoveWordLeft() }),
+	"first_char":        once(func(h *KeyHandler) { h.ui.state.MoveToBeginning() }),
+	"beginning_of_line": once(func(h *KeyHandler) { h.ui.state.MoveToBeginning() }),
+	"end_of_line":       once(func(h *KeyHandler) { h.ui.state.MoveToEnd() }),
+	"first_line":        once(func(h *KeyHandler) { h.selectResult(0) }),
+	"last_line":         once(func(h *KeyHandler) { h.selectResult(len(h.ui.state.filtered) - 1) }),
+	"top_of_screen":     once(func(h *KeyHandler) { h.selectResult(h.ui.state.resultsOffset) }),
+	"middle_of_screen": once(func(h *KeyHandler) {
+		s := h.ui.state
+		h.selectResult(s.resultsOffset + (s.pageSize()+1)/2)
+	}),
+	"bottom_of_screen": once(func(h *KeyHandler) {
+		s := h.ui.state
+		h.selectResult(s.resultsOffset + s.pageSize())
+	}),
+	"scroll_down":      repeat(func(h *KeyHandler) { h.ui.state.PageDown() }),
+	"scroll_up":        repeat(func(h *KeyHandler) { h.ui.state.PageUp() }),
+	"scroll_down_half": repeat(func(h *KeyHandler) { h.selectResult(h.ui.state.selected + h.ui.state.pageSize()/2) }),
+	"scroll_up_half":   repeat(func(h *KeyHandler) { h.selectResult(h.ui.state.selected - h.ui.state.pageSize()/2) }),
+	"scroll_line_down": repeat((*KeyHandler).handleMoveDown),
+	"scroll_line_up":   repeat((*KeyHandler).handleMoveUp),
+	"search_forward":   once((*KeyHandler).viNewSearch),
+	"search_backward":  once((*KeyHandler).viNewSearch),
+	"insert_mode":      once(func(h *KeyHandler) { h.vi.enter(viInsert) }),
+	"insert_after": once(func(h *KeyHandler) {
+		h.ui.state.MoveRight()
+		h.vi.enter(viInsert)
+	}),
+	"insert_at_end": once(func(h *KeyHandler) {
+		h.ui.state.MoveToEnd()
+		h.vi.enter(viInsert)
+	}),
+	"insert_at_beginning": once(func(h *KeyHandler) {
+		h.ui.state.MoveToBeginning()
+		h.vi.enter(viInsert)
+	}),
+	"visual_mode":      once((*KeyHandler).toggleVisual),
+	"visual_line_mode": once((*KeyHandler).toggleVisual),
+	"add_to_workflow":  once((*KeyHandler).viAddToWorkflow),
+	"toggle_workflow_view": once(func(h *KeyHandler) {
+		h.vi.enter(viNormal)
+		h.ui.ToggleWorkflowView()
+	}),
+	"clear_workflow": once(func(h *KeyHandler) { h.clearWorkflow() }),
+	"undo":           repeat(func(h *KeyHandler) { h.ui.state.Undo() }),
+	"redo":           repeat(func(h *KeyHandler) { h.ui.state.Redo() }),
+	"force_quit":     viQuit,
+	"save_and_quit":  viQuit,
+}
+
+// viQuit leaves interactive mode like Ctrl+C.
+func viQuit(h *KeyHandler, _ int, oldState *term.State) bool {
+	h.handleCtrlC(oldState)
+	return false
+}
+
+// newViState builds the state machine from the vi profile's global and
+// results-context bindings, or returns nil when profile is nil.
+func newViState(profile *kb.KeyBindingProfile) *viState {
+	if profile == nil {
+		return nil
+	}
+	layers := []map[string][]kb.KeyStroke{
+		profile.Global,
+		profile.Contexts[kb.ContextGlobal],
+		profile.Contexts[kb.ContextResults],
+	}
+	vi := &viState{bindings: make(map[string]string)}
+	for _, layer := range layers {
+		actions := make([]string, 0, len(layer))
+		for action := range layer {
+			actions = append(actions, action)
+		}
+		// Later layers override earlier ones; within a layer the first
+		// action in name order wins so lookups are deterministic.
+		sort.Sort(sort.Reverse(sort.StringSlice(actions)))
+		for _, action := range actions {
+			if _, ok := viActions[action]; !ok {
+				continue
+			}
+			for _, ks := range layer[action] {
+				if key, ok := viKeyBytes(ks); ok {
+					vi.bindings[key] = action
+				}
+			}
+		}
+	}
+	return vi
+}
+
+// viKeyBytes returns the bytes the terminal sends for ks, for the
+// keystroke kinds normal mode can bind.
+func viKeyBytes(ks kb.KeyStroke) (string, bool) {
+	switch ks.Kind {
+	case kb.KeyStrokeRawSeq:
+		return string(ks.Seq), len(ks.Seq) > 0
+	case kb.KeyStrokeCtrl:
+		r := unicode.ToLower(ks.Rune)
+		if r < 'a' || r > 'z' {
+			return "", false
+		}
+		return string(rune(r - 'a' + 1)), true
+	}
+	return "", false
+}
+
+func (vi *viState) enter(mode viMode) {
+	vi.mode = mode
+	vi.pending = ""
+	vi.count = 0
+}
+
+// ViMode returns the vi mode for the header ("NORMAL", ...), or "" when
+// the vi profile is not active.
+func (h *KeyHandler) ViMode() string {
+	if h == nil || h.vi == nil {
+		return ""
+	}
+	return h.vi.mode.String()
+}
+
+// viModeIndicator is the vi mode shown in the header, or "" when the vi
+// profile is off or the workflow screen, which is not modal, is shown.
+func (ui *UI) viModeIndicator() string {
+	if ui == nil || ui.state == nil || ui.state.IsWorkflowMode() {
+		return ""
+	}
+	return ui.handler.ViMode()
+}
+
+// inVisualRange reports whether result i is inside the visual selection.
+func (h *KeyHandler) inVisualRange(i int) bool {
+	if h == nil || h.vi == nil || h.vi.mode != viVisual {
+		return false
+	}
+	lo, hi := min(h.vi.anchor, h.ui.state.selected), max(h.vi.anchor, h.ui.state.selected)
+	return i >= lo && i <= hi
+}
+
+// handleViKey runs r through the vi state machine. It reports whether
+// the key was consumed; unconsumed keys get the regular handling.
+func (h *KeyHandler) handleViKey(r rune, oldState *term.State) (bool, bool, []string) {
+	vi := h.vi
+	if vi == nil || h.ui.state.IsWorkflowMode() || h.ui.state.IsHistorySearch() {
+		return false, true, nil
+	}
+
+	if r == 27 {
+		if !h.escapeIsLone() {
+			return false, true, nil
+		}
+		vi.enter(viNormal)
+		return true, true, nil
+	}
+	if vi.mode == viInsert {
+		return false, true, nil
+	}
+
+	// Counts: 1-9 start one, 0 extends it (a bare 0 is a motion).
+	if vi.pending == "" && r >= '0' && r <= '9' && (r != '0' || vi.count > 0) {
+		vi.count = min(vi.count*10+int(r-'0'), 9999)
+		return true, true, nil
+	}
+
+	key := vi.pending + string(r)
+	if action, ok := vi.bindings[key]; ok {
+		n := max(vi.count, 1)
+		vi.pending, vi.count = "", 0
+		return true, viActions[action](h, n, oldState), nil
+	}
+	if vi.hasPrefix(key) {
+		vi.pending = key
+		return true, true, nil
+	}
+	if vi.pending != "" {
+		vi.pending = ""
+		return h.handleViKey(r, oldState)
+	}
+	vi.count = 0
+
+	// Unbound printable keys do nothing in normal mode; control keys
+	// such as Enter and Ctrl+C keep their usual meaning.
+	if unicode.IsPrint(r) && !(r == ' ' && h.ui.state.IsMultiSelect()) {
+		return true, true, nil
+	}
+	return false, true, nil
+}
+
+// hasPrefix reports whether some binding starts with, but is longer
+// than, key.
+func (vi *viState) hasPrefix(key string) bool {
+	for k := range vi.bindings {
+		if len(k) > len(key) && strings.HasPrefix(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectResult highlights result i, clamped to the list.
+func (h *KeyHandler) selectResult(i int) {
+	s := h.ui.state
+	s.selected = max(min(i, len(s.filtered)-1), 0)
+}
+
+// toggleVisual enters visual mode anchored at the highlighted result,
+// or leaves it.
+func (h *KeyHandler) toggleVisual() {
+	if h.vi.mode == viVisual {
+		h.vi.enter(viNormal)
+		return
+	}
+	h.vi.enter(viVisual)
+	h.vi.anchor = h.ui.state.selected
+}
+
+// viNewSearch clears the query and returns to insert mode, like / in vi.
+func (h *KeyHandler) viNewSearch() {
+	h.ui.state.ClearInput()
+	h.ui.state.cursorPos = 0
+	h.vi.enter(viInsert)
+}
+
+// viAddToWorkflow adds the highlighted result, or in visual mode every
+// result in the selection, to the workflow.
+func (h *KeyHandler) viAddToWorkflow() {
+	if h.vi.mode != viVisual {
+		h.addSelectedToWorkflow()
+		return
+	}
+	s := h.ui.state
+	lo, hi := min(h.vi.anchor, s.selected), max(h.vi.anchor, s.selected)
+	for i := lo; i <= hi && i < len(s.filtered); i++ {
+		h.addCommandToWorkflow(s.filtered[i].Command)
+	}
+	h.vi.enter(viNormal)
+	s.ClearInput()
+}
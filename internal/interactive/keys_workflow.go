@@ -27,17 +27,22 @@ func (h *KeyHandler) handleSearchModeWorkflowKeys(r rune) (bool, bool, []string)
 	keyStroke := kb.NewCharKeyStroke(r)
 
 	if km.MatchesKeyStroke("add_to_workflow", keyStroke) {
-		if h.ui.state.HasInput() {
-			if cmd := h.ui.state.GetSelectedCommand(); cmd != nil {
-				h.addCommandToWorkflow(cmd.Command)
-				h.ui.state.ClearInput()
-			}
-		}
+		h.addSelectedToWorkflow()
 		return true, true, nil
 	}
 	return false, true, nil
 }
 
+// addSelectedToWorkflow queues the highlighted result and clears the search.
+func (h *KeyHandler) addSelectedToWorkflow() {
+	if h.ui.state.HasInput() || h.ui.state.ShowsPalette() {
+		if cmd := h.ui.state.GetSelectedCommand(); cmd != nil {
+			h.addCommandToWorkflow(cmd.Command)
+			h.ui.state.ClearInput()
+		}
+	}
+}
+
 func (h *KeyHandler) handleWorkflowModeKeys(r rune, oldState *term.State) (bool, bool, []string) {
 	if handled := h.handleWorkflowModeShortcut(r, oldState); handled {
 		return true, true, nil
@@ -220,9 +225,10 @@ func (h *KeyHandler) executeWorkflow(oldState *term.State) {
 	h.restoreTerminalState(oldState)
 
 	// Clear screen and execute workflow
-	clearScreen(h.ui.stdout)
+	h.ui.clearScreen()
 
 	err := h.ui.ExecuteWorkflow()
+	h.ui.requestStatusRefresh()
 	if errors.Is(err, ErrWorkflowCanceled) {
 		h.handleSoftCancel(oldState)
 		h.reenterRawMode(oldState)
@@ -0,0 +1,373 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/difftool"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+const (
+	// logViewerPage is how many more commits are read each time the
+	// cursor nears the end of the loaded graph.
+	logViewerPage = 200
+	// logViewerRows caps how many graph lines are drawn at once.
+	logViewerRows = 20
+)
+
+// LogSource is the git access the log viewer needs.
+type LogSource interface {
+	LogGraphLines(limit int) ([]git.GraphLine, error)
+	ShowOutput(args []string) (string, error)
+}
+
+// LogNotesSource is the optional git access that lets the log viewer mark
+// the commits with a note and show the note with the diff.
+type LogNotesSource interface {
+	NotedCommits(ref string) ([]string, error)
+}
+
+// LogActionKind is what the user chose to do with a commit.
+type LogActionKind int
+
+// Actions that end the viewer. Copying the hash and showing the diff
+// happen inside it.
+const (
+	LogCheckout LogActionKind = iota
+	LogCherryPick
+	LogRevert
+	LogBranch
+)
+
+// LogAction is the viewer's result: an action on one commit. The caller
+// runs it once the screen is restored, so git's output stays visible.
+type LogAction struct {
+	Kind   LogActionKind
+	Commit git.GraphLine
+}
+
+// LogViewer is a full-screen commit graph of every ref with branch and tag
+// decorations. More commits are read as the cursor nears the end. Enter or
+// d shows the highlighted commit's diff, y copies its hash, and c, p, r
+// and b end the viewer to check it out, cherry-pick it, revert it or start
+// a branch at it. Commits with a note under notes.ref are marked, and the
+// note is shown with the diff. Navigation honors the move_up, move_down and soft_cancel
+// bindings of the active keybinding profile.
+type LogViewer struct {
+	git     LogSource
+	lines   []git.GraphLine
+	limit   int
+	more    bool // whether commits past limit may exist
+	cursor  int  // index into lines; always a commit line
+	top     int
+	diff    *diffPager // the highlighted commit while it is shown
+	message string
+	keyMap  *kb.KeyBindingMap
+	colors  *ANSIColors
+	stdin   io.Reader
+	stdout  io.Writer
+	term    termio.Terminal
+
+	notesRef string
+	noted    map[string]bool // nil when the source cannot read notes
+
+	highlight func(diff string) (out string, ok bool, err error)
+	copy      func(text string) error
+}
+
+// NewLogViewer returns a viewer over the repository's history using the
+// keybinding profile, diff tool and notes ref configured in cfg. cfg may be
+// nil.
+func NewLogViewer(src LogSource, cfg *config.Config) *LogViewer {
+	tool, notesRef := "", "commits"
+	if cfg != nil {
+		tool = cfg.UI.DiffTool
+		if ref := strings.TrimSpace(cfg.Notes.Ref); ref != "" {
+			notesRef = ref
+		}
+	}
+	v := &LogViewer{
+		git:       src,
+		keyMap:    resolveResultsKeyMap(cfg),
+		colors:    NewANSIColors(),
+		stdin:     os.Stdin,
+		stdout:    os.Stdout,
+		term:      termio.DefaultTerminal{},
+		highlight: difftool.New(tool).Highlight,
+		notesRef:  git.NotesRef(notesRef),
+	}
+	v.copy = clipboardCopier(&v.stdout)
+	return v
+}
+
+// Run shows the viewer until the user picks an action or quits. ok is
+// false when there is nothing to do.
+func (v *LogViewer) Run() (action LogAction, ok bool, err error) {
+	if err := v.load(logViewerPage); err != nil {
+		return LogAction{}, false, err
+	}
+	v.loadNotes()
+	if !v.onCommit() {
+		_, _ = fmt.Fprintln(v.stdout, "No commits yet")
+		return LogAction{}, false, nil
+	}
+
+	if f, isFile := v.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := v.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = v.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(v.stdout)
+
+	reader := bufio.NewReader(v.stdin)
+	for {
+		v.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil {
+			clearScreen(v.stdout)
+			return LogAction{}, false, nil
+		}
+		action, done, chosen := v.handleKey(ks)
+		if done {
+			clearScreen(v.stdout)
+			return action, chosen, nil
+		}
+	}
+}
+
+// load reads the newest limit commits again. The graph is rebuilt from
+// the top because its columns depend on the commits around each line; the
+// cursor stays on the same commit.
+func (v *LogViewer) load(limit int) error {
+	lines, err := v.git.LogGraphLines(limit)
+	if err != nil {
+		return err
+	}
+	var hash string
+	if v.onCommit() {
+		hash = v.lines[v.cursor].Hash
+	}
+	commits := 0
+	for _, l := range lines {
+		if l.Hash != "" {
+			commits++
+		}
+	}
+	v.lines, v.limit, v.more = lines, limit, commits >= limit
+	v.cursor = 0
+	for i, l := range lines {
+		if l.Hash != "" && (hash == "" || l.Hash == hash) {
+			v.cursor = i
+			break
+		}
+	}
+	return nil
+}
+
+// loadNotes reads which commits have a note. Notes only add to the view,
+// so when they cannot be read the commits are shown without them.
+func (v *LogViewer) loadNotes() {
+	src, ok := v.git.(LogNotesSource)
+	if !ok {
+		return
+	}
+	commits, err := src.NotedCommits(v.notesRef)
+	if err != nil {
+		return
+	}
+	v.noted = make(map[string]bool, len(commits))
+	for _, hash := range commits {
+		v.noted[hash] = true
+	}
+}
+
+// onCommit reports whether the cursor is on a commit line.
+func (v *LogViewer) onCommit() bool {
+	return v.cursor < len(v.lines) && v.lines[v.cursor].Hash != ""
+}
+
+// move steps the cursor over n commits, skipping the lines that only
+// continue the graph, and reads more commits near the end.
+func (v *LogViewer) move(n int) {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		next := v.cursor + step
+		for next >= 0 && next < len(v.lines) && v.lines[next].Hash == "" {
+			next += step
+		}
+		if next < 0 || next >= len(v.lines) {
+			break
+		}
+		v.cursor = next
+	}
+	if v.more && v.cursor >= len(v.lines)-logViewerRows {
+		if err := v.load(v.limit + logViewerPage); err != nil {
+			v.message = err.Error()
+		}
+	}
+}
+
+// handleKey applies one keystroke. done reports whether the viewer should
+// close and chosen whether action should then be run.
+func (v *LogViewer) handleKey(ks kb.KeyStroke) (action LogAction, done, chosen bool) {
+	v.message = ""
+	if v.diff != nil {
+		if v.diff.handleKey(ks, v.keyMap) {
+			v.diff = nil
+		}
+		return LogAction{}, false, false
+	}
+	commit := v.lines[v.cursor]
+
+	switch {
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewCharKeyStroke('q')),
+		v.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return LogAction{}, true, false
+	case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+		v.keyMap.MatchesKeyStroke("move_up", ks):
+		v.move(-1)
+	case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+		v.keyMap.MatchesKeyStroke("move_down", ks):
+		v.move(1)
+	case ks.Equals(kb.NewCtrlKeyStroke('u')):
+		v.move(-logViewerRows / 2)
+	case ks.Equals(kb.NewCtrlKeyStroke('d')):
+		v.move(logViewerRows / 2)
+	case ks.Equals(kb.NewEnterKeyStroke()), ks.Equals(kb.NewCharKeyStroke('d')):
+		v.showDiff(commit)
+	case ks.Equals(kb.NewCharKeyStroke('y')):
+		v.message = copyMessage(v.copy, commit.Hash)
+	case ks.Equals(kb.NewCharKeyStroke('c')):
+		return LogAction{Kind: LogCheckout, Commit: commit}, true, true
+	case ks.Equals(kb.NewCharKeyStroke('p')):
+		return LogAction{Kind: LogCherryPick, Commit: commit}, true, true
+	case ks.Equals(kb.NewCharKeyStroke('r')):
+		return LogAction{Kind: LogRevert, Commit: commit}, true, true
+	case ks.Equals(kb.NewCharKeyStroke('b')):
+		return LogAction{Kind: LogBranch, Commit: commit}, true, true
+	}
+	return LogAction{}, false, false
+}
+
+// showDiff opens the commit's diff over the graph, with its note when the
+// source can read notes.
+func (v *LogViewer) showDiff(commit git.GraphLine) {
+	title := fmt.Sprintf("%s%s%s %s", v.colors.Bold+v.colors.BrightYellow, commit.Short, v.colors.Reset, commit.Subject)
+	var src commitShower = v.git
+	if v.noted != nil {
+		src = notesShower{commitShower: v.git, ref: v.notesRef}
+	}
+	diff, err := openCommit(src, commit.Hash, title, v.colors, v.highlight)
+	if err != nil {
+		v.message = err.Error()
+		return
+	}
+	v.diff = diff
+}
+
+// notesShower has git show print the notes under ref, and only those, in
+// place of the default notes.
+type notesShower struct {
+	commitShower
+	ref string
+}
+
+func (s notesShower) ShowOutput(args []string) (string, error) {
+	return s.commitShower.ShowOutput(append([]string{"--no-notes", "--notes=" + s.ref}, args...))
+}
+
+func (v *LogViewer) render() {
+	c := v.colors
+	clearScreen(v.stdout)
+	var b strings.Builder
+	if v.diff != nil {
+		v.diff.render(&b, c)
+	} else {
+		v.renderGraph(&b)
+	}
+	if v.message != "" {
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightGreen, v.message, c.Reset)
+	}
+	help := "j/k move · enter/[d]iff · [c]heckout · cherry-[p]ick · [r]evert · [b]ranch · [y]ank hash · q quit"
+	if v.diff != nil {
+		help = diffPagerHelp
+	}
+	fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightBlack, help, c.Reset)
+	_, _ = io.WriteString(v.stdout, b.String())
+}
+
+func (v *LogViewer) renderGraph(b *strings.Builder) {
+	c := v.colors
+	fmt.Fprintf(b, "%sCommits%s\r\n\r\n", c.Bold+c.BrightCyan, c.Reset)
+	if v.cursor < v.top {
+		v.top = v.cursor
+	} else if v.cursor >= v.top+logViewerRows {
+		v.top = v.cursor - logViewerRows + 1
+	}
+	end := min(v.top+logViewerRows, len(v.lines))
+	for i := v.top; i < end; i++ {
+		l := v.lines[i]
+		marker := "  "
+		if i == v.cursor {
+			marker = c.BrightGreen + "› " + c.Reset
+		}
+		if l.Hash == "" {
+			fmt.Fprintf(b, "%s%s\r\n", marker, l.Graph)
+			continue
+		}
+		var note string
+		if v.noted[l.Hash] {
+			note = c.BrightMagenta + "[note] " + c.Reset
+		}
+		fmt.Fprintf(b, "%s%s%s%s%s %s%s%s %s(%s, %s)%s\r\n", marker, l.Graph, c.BrightYellow, l.Short, c.Reset,
+			v.decorations(l), note, l.Subject, c.BrightBlack, l.Author, l.Date, c.Reset)
+	}
+	if end < len(v.lines) || v.more {
+		fmt.Fprintf(b, "%s…%s\r\n", c.BrightBlack, c.Reset)
+	}
+}
+
+// decorations renders a commit's refs like git log --decorate: local
+// branches green, remote-tracking branches red and tags yellow.
+func (v *LogViewer) decorations(l git.GraphLine) string {
+	c := v.colors
+	var parts []string
+	if l.Head == "HEAD" {
+		parts = append(parts, c.BrightCyan+"HEAD"+c.Reset)
+	}
+	for _, ref := range l.Refs {
+		var part string
+		switch {
+		case strings.HasPrefix(ref, "refs/heads/"):
+			part = c.BrightGreen + strings.TrimPrefix(ref, "refs/heads/") + c.Reset
+			if ref == l.Head {
+				part = c.BrightCyan + "HEAD -> " + part
+			}
+		case strings.HasPrefix(ref, "refs/remotes/"):
+			part = c.BrightRed + strings.TrimPrefix(ref, "refs/remotes/") + c.Reset
+		case strings.HasPrefix(ref, "refs/tags/"):
+			part = c.BrightYellow + "tag: " + strings.TrimPrefix(ref, "refs/tags/") + c.Reset
+		default:
+			part = ref
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(parts, ", ") + ") "
+}
@@ -0,0 +1,169 @@
+package interactive
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// fakeLogSource serves a linear history of n commits, newest first, with
+// a connector line after the first one, and records the limits asked for.
+type fakeLogSource struct {
+	n      int
+	limits []int
+}
+
+func (f *fakeLogSource) LogGraphLines(limit int) ([]git.GraphLine, error) {
+	f.limits = append(f.limits, limit)
+	var lines []git.GraphLine
+	for i := 0; i < min(limit, f.n); i++ {
+		line := git.GraphLine{Graph: "* ", Hash: fmt.Sprintf("hash%03d", i), Short: fmt.Sprintf("h%03d", i),
+			Subject: fmt.Sprintf("commit %d", i), Author: "Alice", Date: "now"}
+		if i == 0 {
+			line.Refs, line.Head = []string{"refs/heads/main", "refs/tags/v1.0", "refs/remotes/origin/main"}, "refs/heads/main"
+		}
+		lines = append(lines, line)
+		if i == 0 {
+			lines = append(lines, git.GraphLine{Graph: "|"})
+		}
+	}
+	return lines, nil
+}
+
+func (f *fakeLogSource) ShowOutput(args []string) (string, error) {
+	return "commit " + args[len(args)-1] + "\n\ndiff --git a/a.txt b/a.txt\n+added\n", nil
+}
+
+func newTestLogViewer(src *fakeLogSource, input string) (*LogViewer, *bytes.Buffer) {
+	var out bytes.Buffer
+	v := NewLogViewer(src, nil)
+	v.stdin = strings.NewReader(input)
+	v.stdout = &out
+	v.highlight = nil
+	v.copy = func(text string) error { return uiutil.CopyOSC52(&out, text) }
+	return v, &out
+}
+
+func TestLogViewer_Actions(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  LogActionKind
+		hash  string
+	}{
+		{"c", LogCheckout, "hash000"},
+		{"jp", LogCherryPick, "hash001"},
+		{"jjkr", LogRevert, "hash001"},
+		{"jjb", LogBranch, "hash002"},
+	}
+	for _, tt := range tests {
+		v, _ := newTestLogViewer(&fakeLogSource{n: 5}, tt.input)
+		got, ok, err := v.Run()
+		if err != nil || !ok || got.Kind != tt.kind || got.Commit.Hash != tt.hash {
+			t.Errorf("input %q: Run() = %+v, %v, %v", tt.input, got, ok, err)
+		}
+	}
+}
+
+func TestLogViewer_RendersDecorations(t *testing.T) {
+	v, out := newTestLogViewer(&fakeLogSource{n: 3}, "q")
+	if _, ok, _ := v.Run(); ok {
+		t.Error("q should not choose an action")
+	}
+	got := uiutil.StripANSI(out.String())
+	if !strings.Contains(got, "› * h000 (HEAD -> main, tag: v1.0, origin/main) commit 0 (Alice, now)") {
+		t.Errorf("expected the decorated first commit, got %q", got)
+	}
+	if !strings.Contains(got, "  |\r\n") {
+		t.Errorf("expected the connector line, got %q", got)
+	}
+}
+
+func TestLogViewer_LoadsMoreNearTheEnd(t *testing.T) {
+	src := &fakeLogSource{n: logViewerPage + 50}
+	v, _ := newTestLogViewer(src, strings.Repeat("j", logViewerPage-logViewerRows)+"c")
+	got, ok, err := v.Run()
+	if err != nil || !ok {
+		t.Fatalf("Run() = %+v, %v, %v", got, ok, err)
+	}
+	if len(src.limits) != 2 || src.limits[1] != 2*logViewerPage {
+		t.Errorf("limits = %v, want a second page", src.limits)
+	}
+	if want := fmt.Sprintf("hash%03d", logViewerPage-logViewerRows); got.Commit.Hash != want {
+		t.Errorf("cursor moved to %s, want %s", got.Commit.Hash, want)
+	}
+}
+
+func TestLogViewer_DiffAndCopy(t *testing.T) {
+	v, out := newTestLogViewer(&fakeLogSource{n: 2}, "j\rqyq")
+	if _, ok, _ := v.Run(); ok {
+		t.Error("q should not choose an action")
+	}
+	got := out.String()
+	if !strings.Contains(uiutil.StripANSI(got), "commit hash001") || !strings.Contains(got, "+added") {
+		t.Errorf("expected the diff of the second commit, got %q", got)
+	}
+	osc := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("hash001")) + "\a"
+	if !strings.Contains(got, osc) || !strings.Contains(got, "Copied hash001") {
+		t.Errorf("expected the hash on the clipboard, got %q", got)
+	}
+}
+
+// fakeNotesLogSource adds a note to the second commit and records what
+// git show was asked for.
+type fakeNotesLogSource struct {
+	fakeLogSource
+	refs []string
+	show []string
+}
+
+func (f *fakeNotesLogSource) NotedCommits(ref string) ([]string, error) {
+	f.refs = append(f.refs, ref)
+	return []string{"hash001"}, nil
+}
+
+func (f *fakeNotesLogSource) ShowOutput(args []string) (string, error) {
+	f.show = args
+	return f.fakeLogSource.ShowOutput(args)
+}
+
+func TestLogViewer_Notes(t *testing.T) {
+	src := &fakeNotesLogSource{fakeLogSource: fakeLogSource{n: 3}}
+	cfg := &config.Config{}
+	cfg.Notes.Ref = "review"
+	var out bytes.Buffer
+	v := NewLogViewer(src, cfg)
+	v.stdin = strings.NewReader("j\rqq")
+	v.stdout = &out
+	v.highlight = nil
+	if _, ok, _ := v.Run(); ok {
+		t.Error("q should not choose an action")
+	}
+	got := uiutil.StripANSI(out.String())
+	if !strings.Contains(got, "h001 [note] commit 1") || strings.Contains(got, "h002 [note]") {
+		t.Errorf("expected only the second commit marked, got %q", got)
+	}
+	if len(src.refs) != 1 || src.refs[0] != "refs/notes/review" {
+		t.Errorf("notes read from %v, want refs/notes/review once", src.refs)
+	}
+	want := []string{"--no-notes", "--notes=refs/notes/review", "--stat", "--patch", "hash001"}
+	if !slices.Equal(src.show, want) {
+		t.Errorf("git show %v, want %v", src.show, want)
+	}
+}
+
+func TestLogViewer_Empty(t *testing.T) {
+	v, out := newTestLogViewer(&fakeLogSource{}, "")
+	if _, ok, err := v.Run(); ok || err != nil {
+		t.Errorf("Run() ok = %v, err = %v", ok, err)
+	}
+	if !strings.Contains(out.String(), "No commits yet") {
+		t.Errorf("got %q", out.String())
+	}
+}
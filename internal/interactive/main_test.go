@@ -0,0 +1,29 @@
+package interactive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/frecency"
+	"github.com/bmf-san/ggc/v8/internal/transcript"
+)
+
+// TestMain points ranking personalization and workflow transcripts at a
+// throwaway directory so that tests never touch the real per-user usage
+// table or transcripts.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "ggc-interactive-test-")
+	if err != nil {
+		panic(err)
+	}
+	newUsageStore = func() *frecency.Store {
+		return &frecency.Store{Path: filepath.Join(dir, "frecency.json")}
+	}
+	newTranscriptStore = func() *transcript.Store {
+		return &transcript.Store{Dir: filepath.Join(dir, "workflow-runs")}
+	}
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
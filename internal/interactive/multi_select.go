@@ -0,0 +1,156 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+// multiSelectRows caps how many items are drawn at once.
+const multiSelectRows = 20
+
+// MultiSelector is a full-screen picker for choosing several items, built
+// on UIState's multi-select mode. Typing filters the list, Space marks
+// the highlighted item, Ctrl+A marks every visible item and Enter returns
+// the marked items (or the highlighted one when nothing is marked).
+type MultiSelector struct {
+	title  string
+	state  *UIState
+	keyMap *kb.KeyBindingMap
+	colors *ANSIColors
+	stdin  io.Reader
+	stdout io.Writer
+	term   termio.Terminal
+}
+
+// NewMultiSelector returns a picker over items using the keybinding
+// profile configured in cfg. cfg may be nil.
+func NewMultiSelector(title string, items []string, cfg *config.Config) *MultiSelector {
+	commands := make([]CommandInfo, len(items))
+	for i, item := range items {
+		commands[i] = CommandInfo{Command: item}
+	}
+	state := &UIState{commands: commands, context: kb.ContextResults}
+	state.SetMultiSelect(true)
+	state.UpdateFiltered()
+	return &MultiSelector{
+		title:  title,
+		state:  state,
+		keyMap: resolveResultsKeyMap(cfg),
+		colors: NewANSIColors(),
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		term:   termio.DefaultTerminal{},
+	}
+}
+
+// Run shows the picker until the user confirms or cancels. ok is false
+// when the user canceled or confirmed an empty list.
+func (m *MultiSelector) Run() (selected []string, ok bool, err error) {
+	if f, isFile := m.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := m.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = m.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(m.stdout)
+
+	reader := bufio.NewReader(m.stdin)
+	for {
+		m.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil {
+			clearScreen(m.stdout)
+			return nil, false, nil
+		}
+		if done, confirmed := m.handleKey(ks); done {
+			clearScreen(m.stdout)
+			if !confirmed {
+				return nil, false, nil
+			}
+			selected = m.selection()
+			return selected, len(selected) > 0, nil
+		}
+	}
+}
+
+// selection returns the marked items, or the highlighted one when none
+// is marked.
+func (m *MultiSelector) selection() []string {
+	var items []string
+	for _, cmd := range m.state.MarkedCommands() {
+		items = append(items, cmd.Command)
+	}
+	if len(items) == 0 {
+		if cmd := m.state.GetSelectedCommand(); cmd != nil {
+			items = append(items, cmd.Command)
+		}
+	}
+	return items
+}
+
+// handleKey applies one keystroke and reports whether the picker is done
+// and, if so, whether the selection was confirmed.
+func (m *MultiSelector) handleKey(ks kb.KeyStroke) (done, confirmed bool) {
+	s := m.state
+	switch {
+	case ks.Equals(kb.NewEnterKeyStroke()):
+		return true, true
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewEscapeKeyStroke()),
+		m.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true, false
+	case ks.Equals(kb.NewUpArrowKeyStroke()), m.keyMap.MatchesKeyStroke("move_up", ks):
+		s.MoveUp()
+	case ks.Equals(kb.NewDownArrowKeyStroke()), m.keyMap.MatchesKeyStroke("move_down", ks):
+		s.MoveDown()
+	case ks.Equals(kb.NewCharKeyStroke(' ')):
+		s.ToggleMark()
+	case ks.Equals(kb.NewCtrlKeyStroke('a')):
+		s.ToggleAllMarks()
+	case ks.Equals(kb.NewRawKeyStroke([]byte{0x7f})), ks.Equals(kb.NewCtrlKeyStroke('h')):
+		s.RemoveChar()
+	case ks.Kind == kb.KeyStrokeRawSeq:
+		if r, size := utf8.DecodeRune(ks.Seq); size == len(ks.Seq) && unicode.IsPrint(r) {
+			s.AddRune(r)
+		}
+	}
+	return false, false
+}
+
+func (m *MultiSelector) render() {
+	c, s := m.colors, m.state
+	clearScreen(m.stdout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s%s %s(%d selected)%s\r\n", c.Bold+c.BrightCyan, m.title, c.Reset,
+		c.BrightGreen, s.MarkedCount(), c.Reset)
+	fmt.Fprintf(&b, "%sFilter:%s %s\r\n\r\n", c.BrightBlue, c.Reset, s.input)
+
+	start := max(s.selected-multiSelectRows+1, 0)
+	end := min(start+multiSelectRows, len(s.filtered))
+	for i := start; i < end; i++ {
+		item := s.filtered[i]
+		cursor := "  "
+		if i == s.selected {
+			cursor = c.BrightCyan + "▶ " + c.Reset
+		}
+		fmt.Fprintf(&b, "%s%s%s\r\n", cursor, markGlyph(c, s.IsMarked(item.Command)), item.Command)
+	}
+	if len(s.filtered) == 0 {
+		fmt.Fprintf(&b, "%sNo matches.%s\r\n", c.BrightBlack, c.Reset)
+	} else if hidden := len(s.filtered) - (end - start); hidden > 0 {
+		fmt.Fprintf(&b, "%s… %d more%s\r\n", c.BrightBlack, hidden, c.Reset)
+	}
+	fmt.Fprintf(&b, "\r\n%s↑/↓ move · space mark · ctrl+a mark all · type to filter · enter confirm · esc cancel%s\r\n", c.BrightBlack, c.Reset)
+	_, _ = io.WriteString(m.stdout, b.String())
+}
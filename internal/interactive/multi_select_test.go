@@ -0,0 +1,55 @@
+package interactive
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+func runMultiSelector(t *testing.T, items []string, input string) ([]string, bool, string) {
+	t.Helper()
+	var out bytes.Buffer
+	m := NewMultiSelector("Delete branches", items, nil)
+	m.stdin = strings.NewReader(input)
+	m.stdout = &out
+	got, ok, err := m.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got, ok, out.String()
+}
+
+func TestMultiSelector(t *testing.T) {
+	items := []string{"main", "feature/a", "feature/b", "fix/c"}
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+		ok    bool
+	}{
+		{"enter takes the highlighted item", "\r", []string{"main"}, true},
+		{"space marks items", " \x1b[B\x1b[B \r", []string{"main", "feature/b"}, true},
+		{"filter then mark all", "feat\x01\r", []string{"feature/a", "feature/b"}, true},
+		{"backspace edits the filter", "fixx\x7f \r", []string{"fix/c"}, true},
+		{"escape cancels", " \x1b", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, _ := runMultiSelector(t, items, tt.input)
+			if ok != tt.ok || !slices.Equal(got, tt.want) {
+				t.Errorf("Run() = %v, %v; want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestMultiSelector_RendersMarksAndCount(t *testing.T) {
+	_, _, out := runMultiSelector(t, []string{"a", "b"}, " \r")
+	out = uiutil.StripANSI(out)
+	if !strings.Contains(out, "(1 selected)") || !strings.Contains(out, "◉ a") || !strings.Contains(out, "○ b") {
+		t.Errorf("unexpected render %q", out)
+	}
+}
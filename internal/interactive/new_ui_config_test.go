@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/frecency"
 	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
 	"github.com/bmf-san/ggc/v8/internal/testutil"
 )
@@ -50,3 +52,40 @@ func TestNewUIHonorsConfigProfileAndOverrides(t *testing.T) {
 		t.Fatalf("config override not applied: %#v", resultsMap.MoveDown)
 	}
 }
+
+func TestNewUIFrecency(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frecency.json")
+	prev := newUsageStore
+	newUsageStore = func() *frecency.Store { return &frecency.Store{Path: path} }
+	t.Cleanup(func() { newUsageStore = prev })
+
+	commands := []CommandInfo{{Command: "status"}, {Command: "push current"}}
+	gitClient := testutil.NewMockGitClient()
+
+	ui := NewUI(gitClient, commands, &config.Config{})
+	ui.recordUsage("push current")
+	ui.state.UpdateFiltered()
+	if got := ui.state.filtered[0].Command; got != "push current" {
+		t.Errorf("first command = %q, want the picked one", got)
+	}
+
+	// A fresh session ranks from the persisted table.
+	ui = NewUI(gitClient, commands, &config.Config{})
+	ui.state.UpdateFiltered()
+	if got := ui.state.filtered[0].Command; got != "push current" {
+		t.Errorf("first command after reload = %q", got)
+	}
+
+	off := false
+	cfg := &config.Config{}
+	cfg.Interactive.Frecency = &off
+	ui = NewUI(gitClient, commands, cfg)
+	ui.recordUsage("status")
+	ui.state.UpdateFiltered()
+	if got := ui.state.filtered[0].Command; got != "status" {
+		t.Errorf("first command with frecency off = %q, want registry order", got)
+	}
+	if table, _ := (&frecency.Store{Path: path}).Load(); table["status"].Count != 0 {
+		t.Error("picks should not be recorded with frecency off")
+	}
+}
@@ -0,0 +1,112 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// Bracketed paste: while the UI runs, the terminal wraps pasted text in
+// ESC[200~ ... ESC[201~. The whole paste is read at once so newlines and
+// escape characters inside it are inserted as text instead of acting as
+// Enter or starting key sequences. A paste of several lines adds each
+// line to the workflow as its own step.
+
+const (
+	pasteStartParams = "200" // ESC[200~
+	pasteEnd         = "\x1b[201~"
+	maxPasteBytes    = 1 << 20
+)
+
+// enableBracketedPaste turns bracketed paste on for a raw-mode screen.
+func enableBracketedPaste(w io.Writer) {
+	uiutil.EnableBracketedPaste(w)
+}
+
+// disableBracketedPaste turns it off before the terminal is handed back.
+func disableBracketedPaste(w io.Writer) {
+	uiutil.DisableBracketedPaste(w)
+}
+
+// readPaste reads a paste body up to the end marker. Text beyond
+// maxPasteBytes is read and dropped.
+func readPaste(next func() (byte, error)) string {
+	var text, tail []byte
+	for {
+		b, err := next()
+		if err != nil {
+			return string(append(text, tail...))
+		}
+		tail = append(tail, b)
+		if len(tail) > len(pasteEnd) {
+			if len(text) < maxPasteBytes {
+				text = append(text, tail[0])
+			}
+			tail = tail[1:]
+		}
+		if string(tail) == pasteEnd {
+			return string(text)
+		}
+	}
+}
+
+// pasteLines returns the non-blank lines of a paste, trimmed.
+func pasteLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// pasteText flattens a paste into one line of literal text: line breaks
+// and tabs become spaces and other control characters are dropped.
+func pasteText(text string) string {
+	text = strings.TrimRight(text, "\r\n")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// handlePaste reads a bracketed paste after its ESC[200~ start marker.
+func (h *KeyHandler) handlePaste(reader *bufio.Reader) {
+	text := readPaste(func() (byte, error) { return h.readNextByte(reader) })
+	state := h.ui.state
+
+	if lines := pasteLines(text); len(lines) > 1 && !state.IsHistorySearch() {
+		for _, line := range lines {
+			h.addPastedStep(strings.TrimPrefix(line, "ggc "))
+		}
+		h.ui.notifyWorkflowSuccess(fmt.Sprintf("Added %d pasted commands to the workflow", len(lines)), 3*time.Second)
+		state.ClearInput()
+		return
+	}
+	if state.IsWorkflowMode() {
+		return
+	}
+	state.InsertText(pasteText(text))
+}
+
+// addPastedStep adds one pasted command line to the active workflow.
+func (h *KeyHandler) addPastedStep(line string) {
+	parts := strings.Fields(line)
+	if len(parts) == 0 {
+		return
+	}
+	h.ui.AddToWorkflow(parts[0], parts[1:], line)
+}
@@ -0,0 +1,84 @@
+package interactive
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+)
+
+func TestReadPaste(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"hello\x1b[201~rest", "hello"},
+		{"a\x1b[Ab\x1b[201~", "a\x1b[Ab"},
+		{"unterminated", "unterminated"},
+	}
+	for _, tt := range tests {
+		r := bufio.NewReader(strings.NewReader(tt.in))
+		if got := readPaste(r.ReadByte); got != tt.want {
+			t.Errorf("readPaste(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPasteText(t *testing.T) {
+	if got := pasteText("git\tlog\x1b --oneline\n"); got != "git log --oneline" {
+		t.Errorf("pasteText() = %q", got)
+	}
+	if got := pasteLines("status\r\n\n  ggc diff \rlog"); strings.Join(got, "|") != "status|ggc diff|log" {
+		t.Errorf("pasteLines() = %q", got)
+	}
+}
+
+func newPasteTestUI() *UI {
+	ui := newUIWithKeyMap(kb.DefaultKeyBindingMap())
+	ui.stdout = &bytes.Buffer{}
+	ui.colors = NewANSIColors()
+	ui.workflowMgr = NewWorkflowManager()
+	return ui
+}
+
+func TestHandleKey_BracketedPaste(t *testing.T) {
+	ui := newPasteTestUI()
+	ui.state.input = "st"
+	ui.state.cursorPos = 2
+	reader := bufio.NewReader(strings.NewReader("[200~at\tus\x1b[201~"))
+	ui.handler.HandleKey(27, false, nil, reader)
+	if ui.state.input != "stat us" || ui.state.cursorPos != 7 {
+		t.Errorf("single-line paste: input %q, cursor %d", ui.state.input, ui.state.cursorPos)
+	}
+	if !ui.state.Undo() || ui.state.input != "st" {
+		t.Errorf("paste should undo in one step, input %q", ui.state.input)
+	}
+
+	reader = bufio.NewReader(strings.NewReader("[200~ggc status\n\ndiff staged\n\x1b[201~"))
+	ui.handler.HandleKey(27, false, nil, reader)
+	wf, _ := ui.workflowMgr.GetWorkflow(ui.workflowMgr.GetActiveID())
+	if wf == nil || wf.Size() != 2 {
+		t.Fatalf("multi-line paste: workflow %+v", wf)
+	}
+	steps := wf.GetSteps()
+	if got := steps[0].Description + "|" + steps[1].Description; got != "status|diff staged" {
+		t.Errorf("steps = %q", got)
+	}
+	if ui.state.input != "" {
+		t.Errorf("input after multi-line paste = %q", ui.state.input)
+	}
+}
+
+func TestRealTimeEditor_BracketedPaste(t *testing.T) {
+	ui := newPasteTestUI()
+	runes := []rune{}
+	cursor := 0
+	e := &realTimeEditor{ui: ui, inputRunes: &runes, cursor: &cursor}
+	reader := bufio.NewReader(strings.NewReader("[200~fix: a\nb\x1b[201~\r"))
+	if res := e.handleInput(27, reader); res.done {
+		t.Fatal("the paste's newline must not submit the input")
+	}
+	r, _, _ := reader.ReadRune()
+	if res := e.handleInput(r, reader); !res.done || res.text != "fix: a b" {
+		t.Errorf("result = %+v", res)
+	}
+}
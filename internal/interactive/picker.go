@@ -0,0 +1,150 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+// pickerRows caps how many items are drawn at once.
+const pickerRows = 20
+
+// PickItem is one choice in a Picker. Detail is shown dimmed beside it.
+type PickItem struct {
+	Value  string
+	Detail string
+}
+
+// Picker is a full-screen list for choosing one item. Typing filters the
+// list fuzzily and Enter returns the highlighted item.
+type Picker struct {
+	title   string
+	state   *UIState
+	details map[string]string
+	keyMap  *kb.KeyBindingMap
+	colors  *ANSIColors
+	stdin   io.Reader
+	stdout  io.Writer
+	term    termio.Terminal
+}
+
+// NewPicker returns a picker over items, filtered by query to begin with,
+// using the keybinding profile configured in cfg. cfg may be nil.
+func NewPicker(title string, items []PickItem, query string, cfg *config.Config) *Picker {
+	commands := make([]CommandInfo, len(items))
+	details := make(map[string]string, len(items))
+	for i, item := range items {
+		commands[i] = CommandInfo{Command: item.Value}
+		details[item.Value] = item.Detail
+	}
+	state := &UIState{commands: commands, context: kb.ContextResults}
+	state.UpdateFiltered()
+	for _, r := range query {
+		state.AddRune(r)
+	}
+	return &Picker{
+		title:   title,
+		state:   state,
+		details: details,
+		keyMap:  resolveResultsKeyMap(cfg),
+		colors:  NewANSIColors(),
+		stdin:   os.Stdin,
+		stdout:  os.Stdout,
+		term:    termio.DefaultTerminal{},
+	}
+}
+
+// Run shows the picker until the user picks an item or cancels. ok is
+// false when the user canceled or nothing matched.
+func (p *Picker) Run() (value string, ok bool, err error) {
+	if f, isFile := p.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := p.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = p.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(p.stdout)
+
+	reader := bufio.NewReader(p.stdin)
+	for {
+		p.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil {
+			clearScreen(p.stdout)
+			return "", false, nil
+		}
+		if done, confirmed := p.handleKey(ks); done {
+			clearScreen(p.stdout)
+			cmd := p.state.GetSelectedCommand()
+			if !confirmed || cmd == nil {
+				return "", false, nil
+			}
+			return cmd.Command, true, nil
+		}
+	}
+}
+
+// handleKey applies one keystroke and reports whether the picker is done
+// and, if so, whether an item was chosen.
+func (p *Picker) handleKey(ks kb.KeyStroke) (done, confirmed bool) {
+	s := p.state
+	switch {
+	case ks.Equals(kb.NewEnterKeyStroke()):
+		return true, true
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewEscapeKeyStroke()),
+		p.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true, false
+	case ks.Equals(kb.NewUpArrowKeyStroke()), p.keyMap.MatchesKeyStroke("move_up", ks):
+		s.MoveUp()
+	case ks.Equals(kb.NewDownArrowKeyStroke()), p.keyMap.MatchesKeyStroke("move_down", ks):
+		s.MoveDown()
+	case ks.Equals(kb.NewRawKeyStroke([]byte{0x7f})), ks.Equals(kb.NewCtrlKeyStroke('h')):
+		s.RemoveChar()
+	case ks.Kind == kb.KeyStrokeRawSeq:
+		if r, size := utf8.DecodeRune(ks.Seq); size == len(ks.Seq) && unicode.IsPrint(r) {
+			s.AddRune(r)
+		}
+	}
+	return false, false
+}
+
+func (p *Picker) render() {
+	c, s := p.colors, p.state
+	clearScreen(p.stdout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s%s\r\n", c.Bold+c.BrightCyan, p.title, c.Reset)
+	fmt.Fprintf(&b, "%sFilter:%s %s\r\n\r\n", c.BrightBlue, c.Reset, s.input)
+
+	start := max(s.selected-pickerRows+1, 0)
+	end := min(start+pickerRows, len(s.filtered))
+	for i := start; i < end; i++ {
+		item := s.filtered[i].Command
+		cursor := "  "
+		if i == s.selected {
+			cursor = c.BrightCyan + "▶ " + c.Reset
+		}
+		detail := ""
+		if d := p.details[item]; d != "" {
+			detail = fmt.Sprintf("  %s%s%s", c.BrightBlack, d, c.Reset)
+		}
+		fmt.Fprintf(&b, "%s%s%s\r\n", cursor, item, detail)
+	}
+	if len(s.filtered) == 0 {
+		fmt.Fprintf(&b, "%sNo matches.%s\r\n", c.BrightBlack, c.Reset)
+	} else if hidden := len(s.filtered) - (end - start); hidden > 0 {
+		fmt.Fprintf(&b, "%s… %d more%s\r\n", c.BrightBlack, hidden, c.Reset)
+	}
+	fmt.Fprintf(&b, "\r\n%s↑/↓ move · type to filter · enter choose · esc cancel%s\r\n", c.BrightBlack, c.Reset)
+	_, _ = io.WriteString(p.stdout, b.String())
+}
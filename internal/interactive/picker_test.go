@@ -0,0 +1,55 @@
+package interactive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+func newTestPicker(query, input string) (*Picker, *bytes.Buffer) {
+	var out bytes.Buffer
+	items := []PickItem{{Value: "main"}, {Value: "feature/login"}, {Value: "origin/fix-42", Detail: "remote"}}
+	p := NewPicker("Switch to branch", items, query, nil)
+	p.stdin = strings.NewReader(input)
+	p.stdout = &out
+	return p, &out
+}
+
+func TestPicker_Run(t *testing.T) {
+	tests := []struct {
+		query, input string
+		want         string
+		ok           bool
+	}{
+		{"", "\r", "main", true},
+		{"", "\x1b[B\r", "feature/login", true},
+		{"fix", "\r", "origin/fix-42", true},
+		{"", "log\r", "feature/login", true},
+		{"", "\x03", "", false},
+		{"zzz", "\r", "", false},
+	}
+	for _, tt := range tests {
+		p, _ := newTestPicker(tt.query, tt.input)
+		got, ok, err := p.Run()
+		if err != nil || ok != tt.ok || got != tt.want {
+			t.Errorf("query %q input %q: Run() = %q, %v, %v", tt.query, tt.input, got, ok, err)
+		}
+	}
+}
+
+func TestPicker_RendersDetails(t *testing.T) {
+	p, out := newTestPicker("", "\x03")
+	_, _, _ = p.Run()
+	if got := uiutil.StripANSI(out.String()); !strings.Contains(got, "origin/fix-42  remote") {
+		t.Errorf("expected the detail beside the item, got %q", got)
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	got := FuzzyFilter("LOG", []string{"main", "feature/login", "log"})
+	if strings.Join(got, ",") != "log,feature/login" {
+		t.Errorf("FuzzyFilter() = %v", got)
+	}
+}
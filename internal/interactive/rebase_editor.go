@@ -0,0 +1,233 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+// rebaseActionKeys maps the single-letter shortcuts shown in the footer to
+// todo actions. They mirror the abbreviations git accepts in the todo file.
+var rebaseActionKeys = map[rune]RebaseAction{
+	'p': RebasePick,
+	'r': RebaseReword,
+	'e': RebaseEdit,
+	's': RebaseSquash,
+	'f': RebaseFixup,
+	'd': RebaseDrop,
+}
+
+// RebaseEditor is a full-screen editor for a `git rebase -i` todo list.
+// Navigation honors the move_up, move_down and soft_cancel bindings of the
+// active keybinding profile; arrow keys and j/k always work as well.
+type RebaseEditor struct {
+	entries []RebaseTodoEntry
+	cursor  int
+	title   string
+	message string
+	keyMap  *kb.KeyBindingMap
+	colors  *ANSIColors
+	stdin   io.Reader
+	stdout  io.Writer
+	term    termio.Terminal
+}
+
+// NewRebaseEditor returns an editor over entries (oldest first) using the
+// keybinding profile configured in cfg. cfg may be nil.
+func NewRebaseEditor(title string, entries []RebaseTodoEntry, cfg *config.Config) *RebaseEditor {
+	return &RebaseEditor{
+		entries: append([]RebaseTodoEntry(nil), entries...),
+		title:   title,
+		keyMap:  resolveResultsKeyMap(cfg),
+		colors:  NewANSIColors(),
+		stdin:   os.Stdin,
+		stdout:  os.Stdout,
+		term:    termio.DefaultTerminal{},
+	}
+}
+
+// resolveResultsKeyMap resolves the list-navigation bindings for the
+// profile named in cfg, falling back to the built-in defaults.
+func resolveResultsKeyMap(cfg *config.Config) *kb.KeyBindingMap {
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	resolver := kb.NewKeyBindingResolver(cfg)
+	kb.RegisterBuiltinProfiles(resolver)
+
+	profile := kb.ProfileDefault
+	switch p := kb.Profile(cfg.Interactive.Profile); p {
+	case kb.ProfileEmacs, kb.ProfileVi, kb.ProfileReadline:
+		profile = p
+	}
+	contextual, err := resolver.ResolveContextual(profile)
+	if err != nil {
+		return kb.DefaultKeyBindingMap()
+	}
+	if km, ok := contextual.GetContext(kb.ContextResults); ok && km != nil {
+		return km
+	}
+	return kb.DefaultKeyBindingMap()
+}
+
+// Run shows the editor until the user applies or aborts. It returns the
+// edited todo list and true when applied.
+func (e *RebaseEditor) Run() ([]RebaseTodoEntry, bool) {
+	if f, ok := e.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := e.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = e.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(e.stdout)
+
+	reader := bufio.NewReader(e.stdin)
+	for {
+		e.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil {
+			return nil, false
+		}
+		if done, accepted := e.handleKey(ks); done {
+			clearScreen(e.stdout)
+			if !accepted {
+				return nil, false
+			}
+			return e.entries, true
+		}
+	}
+}
+
+// handleKey applies one keystroke and reports whether the editor is done
+// and, if so, whether the todo list was accepted.
+func (e *RebaseEditor) handleKey(ks kb.KeyStroke) (bool, bool) {
+	e.message = ""
+	switch {
+	case ks.Equals(kb.NewEnterKeyStroke()):
+		if err := ValidateRebaseTodo(e.entries); err != nil {
+			e.message = err.Error()
+			return false, false
+		}
+		return true, true
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewCharKeyStroke('q')),
+		e.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true, false
+	case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+		e.keyMap.MatchesKeyStroke("move_up", ks):
+		e.moveCursor(-1)
+	case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+		e.keyMap.MatchesKeyStroke("move_down", ks):
+		e.moveCursor(1)
+	case ks.Equals(kb.NewCharKeyStroke('K')):
+		e.moveEntry(-1)
+	case ks.Equals(kb.NewCharKeyStroke('J')):
+		e.moveEntry(1)
+	case ks.Kind == kb.KeyStrokeRawSeq && len(ks.Seq) == 1:
+		if action, ok := rebaseActionKeys[rune(ks.Seq[0])]; ok && len(e.entries) > 0 {
+			e.entries[e.cursor].Action = action
+		}
+	}
+	return false, false
+}
+
+func (e *RebaseEditor) moveCursor(delta int) {
+	next := e.cursor + delta
+	if next >= 0 && next < len(e.entries) {
+		e.cursor = next
+	}
+}
+
+// moveEntry swaps the selected commit with its neighbor, keeping the
+// cursor on the moved commit.
+func (e *RebaseEditor) moveEntry(delta int) {
+	next := e.cursor + delta
+	if next < 0 || next >= len(e.entries) {
+		return
+	}
+	e.entries[e.cursor], e.entries[next] = e.entries[next], e.entries[e.cursor]
+	e.cursor = next
+}
+
+func (e *RebaseEditor) render() {
+	c := e.colors
+	clearScreen(e.stdout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s%s\r\n", c.Bold+c.BrightCyan, e.title, c.Reset)
+	fmt.Fprintf(&b, "%sOldest commit first; applied top to bottom.%s\r\n\r\n", c.BrightBlack, c.Reset)
+	for i, entry := range e.entries {
+		marker := "  "
+		if i == e.cursor {
+			marker = c.BrightGreen + "› " + c.Reset
+		}
+		fmt.Fprintf(&b, "%s%s%-7s%s %s%s%s %s\r\n",
+			marker, rebaseActionColor(c, entry.Action), entry.Action, c.Reset,
+			c.Yellow, entry.Hash, c.Reset, entry.Subject)
+	}
+	if e.message != "" {
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightRed, e.message, c.Reset)
+	}
+	fmt.Fprintf(&b, "\r\n%s[p]ick [r]eword [e]dit [s]quash [f]ixup [d]rop · J/K move · Enter apply · q abort%s\r\n",
+		c.BrightBlack, c.Reset)
+	_, _ = io.WriteString(e.stdout, b.String())
+}
+
+func rebaseActionColor(c *ANSIColors, action RebaseAction) string {
+	switch action {
+	case RebaseDrop:
+		return c.BrightRed
+	case RebaseSquash, RebaseFixup:
+		return c.BrightMagenta
+	case RebaseReword, RebaseEdit:
+		return c.BrightBlue
+	default:
+		return c.BrightGreen
+	}
+}
+
+// readRebaseKey decodes one keystroke from r: arrow-key escape sequences,
+// a bare Esc, Enter, control characters or a printable rune.
+func readRebaseKey(r *bufio.Reader) (kb.KeyStroke, error) {
+	ch, _, err := r.ReadRune()
+	if err != nil {
+		return kb.KeyStroke{}, err
+	}
+	switch {
+	case ch == '\r' || ch == '\n':
+		return kb.NewEnterKeyStroke(), nil
+	case ch == 0x1b:
+		if r.Buffered() == 0 {
+			return kb.NewEscapeKeyStroke(), nil
+		}
+		seq := []byte{0x1b}
+		for r.Buffered() > 0 {
+			b, _ := r.ReadByte()
+			seq = append(seq, b)
+			if len(seq) > 2 && b >= 0x40 && b <= 0x7e {
+				break
+			}
+		}
+		switch string(seq) {
+		case "\x1b[A", "\x1bOA":
+			return kb.NewUpArrowKeyStroke(), nil
+		case "\x1b[B", "\x1bOB":
+			return kb.NewDownArrowKeyStroke(), nil
+		}
+		if named, ok := kb.DecodeSequence(seq); ok {
+			return named, nil
+		}
+		return kb.NewRawKeyStroke(seq), nil
+	case ch >= 1 && ch <= 26:
+		return kb.NewCtrlKeyStroke('a' + ch - 1), nil
+	default:
+		return kb.NewRawKeyStroke([]byte(string(ch))), nil
+	}
+}
@@ -0,0 +1,88 @@
+package interactive
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+)
+
+func newTestRebaseEditor(input string) (*RebaseEditor, *bytes.Buffer) {
+	var out bytes.Buffer
+	e := NewRebaseEditor("rebase", ParseRebaseTodo("a1 one\nb2 two\nc3 three"), nil)
+	e.stdin = strings.NewReader(input)
+	e.stdout = &out
+	return e, &out
+}
+
+func TestRebaseEditor_ReorderAndSquash(t *testing.T) {
+	// Move "three" up one row, then mark it as fixup of "one".
+	e, out := newTestRebaseEditor("jjKf\r")
+
+	entries, ok := e.Run()
+	if !ok {
+		t.Fatal("expected todo to be applied")
+	}
+	got := FormatRebaseTodo(entries)
+	if want := "pick a1 one\nfixup c3 three\npick b2 two\n"; got != want {
+		t.Errorf("todo = %q, want %q", got, want)
+	}
+	if !strings.Contains(out.String(), "[p]ick") {
+		t.Error("expected the key help footer to be rendered")
+	}
+}
+
+func TestRebaseEditor_Abort(t *testing.T) {
+	e, _ := newTestRebaseEditor("dq")
+	if _, ok := e.Run(); ok {
+		t.Fatal("q should abort the rebase")
+	}
+}
+
+func TestRebaseEditor_ProfileNavigation(t *testing.T) {
+	// The emacs profile binds Ctrl+N to move_down in the results list.
+	cfg := &config.Config{}
+	cfg.Interactive.Profile = "emacs"
+	e := NewRebaseEditor("rebase", ParseRebaseTodo("a1 one\nb2 two"), cfg)
+
+	e.handleKey(kb.NewCtrlKeyStroke('n'))
+	if e.cursor != 1 {
+		t.Fatalf("cursor = %d, want 1", e.cursor)
+	}
+	if done, _ := e.handleKey(kb.NewEscapeKeyStroke()); !done {
+		t.Error("soft_cancel should abort the editor")
+	}
+}
+
+func TestRebaseEditor_RejectsInvalidTodo(t *testing.T) {
+	e, out := newTestRebaseEditor("s\rq")
+	if _, ok := e.Run(); ok {
+		t.Fatal("squashing the first commit must not be applied")
+	}
+	if !strings.Contains(out.String(), "no earlier commit") {
+		t.Errorf("expected validation message, got %q", out.String())
+	}
+}
+
+func TestReadRebaseKey(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("\x1b[A\x1b[Bx\x0e\r"))
+	want := []kb.KeyStroke{
+		kb.NewUpArrowKeyStroke(),
+		kb.NewDownArrowKeyStroke(),
+		kb.NewCharKeyStroke('x'),
+		kb.NewCtrlKeyStroke('n'),
+		kb.NewEnterKeyStroke(),
+	}
+	for i, w := range want {
+		got, err := readRebaseKey(r)
+		if err != nil {
+			t.Fatalf("key %d: %v", i, err)
+		}
+		if !got.Equals(w) {
+			t.Errorf("key %d = %v, want %v", i, got, w)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package interactive
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RebaseAction is the verb of one line in a `git rebase -i` todo list.
+type RebaseAction string
+
+// Rebase todo actions supported by the rebase editor.
+const (
+	RebasePick   RebaseAction = "pick"
+	RebaseReword RebaseAction = "reword"
+	RebaseEdit   RebaseAction = "edit"
+	RebaseSquash RebaseAction = "squash"
+	RebaseFixup  RebaseAction = "fixup"
+	RebaseDrop   RebaseAction = "drop"
+)
+
+// RebaseTodoEntry is one commit in the rebase todo list.
+type RebaseTodoEntry struct {
+	Action  RebaseAction
+	Hash    string
+	Subject string
+}
+
+// ParseRebaseTodo builds a todo list from `git log --oneline --reverse`
+// output, so entries are ordered oldest first exactly as git expects. Every
+// commit starts as a pick.
+func ParseRebaseTodo(oneline string) []RebaseTodoEntry {
+	var entries []RebaseTodoEntry
+	for _, line := range strings.Split(oneline, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		hash, subject, _ := strings.Cut(line, " ")
+		entries = append(entries, RebaseTodoEntry{Action: RebasePick, Hash: hash, Subject: subject})
+	}
+	return entries
+}
+
+// FormatRebaseTodo renders entries in git's todo file format.
+func FormatRebaseTodo(entries []RebaseTodoEntry) string {
+	var b strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&b, "%s %s %s\n", e.Action, e.Hash, e.Subject)
+	}
+	return b.String()
+}
+
+// ValidateRebaseTodo reports todo lists git would reject: squash and fixup
+// need an earlier commit to fold into, and dropping every commit leaves
+// nothing to rebase.
+func ValidateRebaseTodo(entries []RebaseTodoEntry) error {
+	kept := 0
+	for _, e := range entries {
+		if e.Action == RebaseDrop {
+			continue
+		}
+		if kept == 0 && (e.Action == RebaseSquash || e.Action == RebaseFixup) {
+			return fmt.Errorf("cannot %s %s: no earlier commit to fold into", e.Action, e.Hash)
+		}
+		kept++
+	}
+	if kept == 0 {
+		return fmt.Errorf("every commit is dropped; use `ggc reset` instead")
+	}
+	return nil
+}
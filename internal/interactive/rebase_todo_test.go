@@ -0,0 +1,60 @@
+package interactive
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRebaseTodo(t *testing.T) {
+	entries := ParseRebaseTodo("abc123 first commit\n\ndef456 second: with colon\n")
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0] != (RebaseTodoEntry{Action: RebasePick, Hash: "abc123", Subject: "first commit"}) {
+		t.Errorf("entries[0] = %+v", entries[0])
+	}
+	if entries[1].Subject != "second: with colon" {
+		t.Errorf("entries[1].Subject = %q", entries[1].Subject)
+	}
+}
+
+func TestFormatRebaseTodo(t *testing.T) {
+	got := FormatRebaseTodo([]RebaseTodoEntry{
+		{Action: RebasePick, Hash: "abc", Subject: "one"},
+		{Action: RebaseFixup, Hash: "def", Subject: "two"},
+	})
+	if want := "pick abc one\nfixup def two\n"; got != want {
+		t.Errorf("FormatRebaseTodo() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateRebaseTodo(t *testing.T) {
+	cases := []struct {
+		name    string
+		actions []RebaseAction
+		wantErr string
+	}{
+		{name: "picks", actions: []RebaseAction{RebasePick, RebaseSquash}},
+		{name: "squash first", actions: []RebaseAction{RebaseSquash, RebasePick}, wantErr: "no earlier commit"},
+		{name: "fixup after drop", actions: []RebaseAction{RebaseDrop, RebaseFixup}, wantErr: "no earlier commit"},
+		{name: "all dropped", actions: []RebaseAction{RebaseDrop, RebaseDrop}, wantErr: "every commit is dropped"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entries := make([]RebaseTodoEntry, len(tc.actions))
+			for i, a := range tc.actions {
+				entries[i] = RebaseTodoEntry{Action: a, Hash: "h"}
+			}
+			err := ValidateRebaseTodo(entries)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -14,6 +14,11 @@ type Renderer struct {
 	width  int
 	height int
 	colors *ANSIColors
+	lines  int // lines written since the last clear; sizes the results viewport
+
+	// accessible is set in accessible mode, which announces changes in
+	// plain lines instead of drawing frames; see render_accessible.go.
+	accessible *accessibleView
 }
 
 type keybindHelpEntry struct {
@@ -29,7 +34,12 @@ func (r *Renderer) updateSize() {
 
 // Render displays the command list with proper terminal handling
 func (r *Renderer) Render(ui *UI, state *UIState) {
+	if r.accessible != nil {
+		r.renderAccessible(ui, state)
+		return
+	}
 	clearScreen(r.writer)
+	r.lines = 0
 	// Disable line wrapping during rendering, restore at end
 	uiutil.DisableWrap(r.writer)
 	var restoreCursor func()
@@ -49,6 +59,7 @@ func (r *Renderer) Render(ui *UI, state *UIState) {
 	r.renderSoftCancelFlash(ui)
 	r.renderWorkflowError(ui)
 	r.renderWorkflowNotice(ui)
+	r.renderPendingChord(ui)
 
 	switch state.mode {
 	case ModeWorkflow:
@@ -59,6 +70,12 @@ func (r *Renderer) Render(ui *UI, state *UIState) {
 		restoreCursor = r.saveCursorAtSearchPrompt(state)
 
 		switch {
+		case state.ShowsPalette():
+			preview := r.previewLines(state)
+			r.renderCommandList(ui, state, 2+previewHeight(preview))
+			r.renderPreview(ui, preview)
+			r.writeEmptyLine()
+			r.renderEmptyState(ui)
 		case state.input == "":
 			r.renderEmptyState(ui)
 			r.writeEmptyLine()
@@ -66,7 +83,9 @@ func (r *Renderer) Render(ui *UI, state *UIState) {
 		case len(state.filtered) == 0:
 			r.renderNoMatches(ui, state)
 		default:
-			r.renderCommandList(ui, state)
+			preview := r.previewLines(state)
+			r.renderCommandList(ui, state, previewHeight(preview))
+			r.renderPreview(ui, preview)
 		}
 	}
 }
@@ -102,11 +121,13 @@ func (r *Renderer) writeColorln(_ *UI, text string) {
 	// Move to line start, clear line, write content, then CRLF
 	_, _ = fmt.Fprint(r.writer, "\r\x1b[K")
 	_, _ = fmt.Fprint(r.writer, text+"\r\n")
+	r.lines++
 }
 
 // writeEmptyLine writes an empty line
 func (r *Renderer) writeEmptyLine() {
 	_, _ = fmt.Fprint(r.writer, "\r\x1b[K\r\n")
+	r.lines++
 }
 
 // calculateMaxCommandLength calculates the maximum command length for alignment
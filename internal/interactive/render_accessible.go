@@ -0,0 +1,211 @@
+package interactive
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Accessible mode (ui.accessible) is for screen readers and dumb
+// terminals. Instead of redrawing the screen, the renderer writes plain
+// lines, without colors, box drawing, emoji or cursor movement, and only
+// for what changed since the last frame: the query's match count, the
+// selected command, mode switches and notices. A screen reader then reads
+// each change once, as it is written.
+
+// accessibleView is what the accessible renderer last announced.
+type accessibleView struct {
+	started   bool
+	mode      UIMode
+	viMode    string
+	status    string
+	chord     string
+	notice    string
+	input     string
+	count     int
+	selection string
+}
+
+// renderAccessible announces what changed since the previous frame.
+func (r *Renderer) renderAccessible(ui *UI, state *UIState) {
+	v := r.accessible
+	if !v.started {
+		v.started = true
+		v.mode = state.mode
+		r.say("ggc interactive mode. Type to search commands, Up and Down to choose one, Enter to run it, Ctrl+C to quit.")
+	}
+	r.announceStatus(ui, v)
+	if state.mode != v.mode {
+		v.mode = state.mode
+		v.selection = ""
+		if state.IsWorkflowMode() {
+			r.say("Workflow mode.")
+		} else {
+			r.say("Search mode.")
+		}
+	}
+	if mode := ui.viModeIndicator(); mode != v.viMode {
+		v.viMode = mode
+		if mode != "" {
+			r.say(fmt.Sprintf("Vi %s mode.", strings.ToLower(mode)))
+		}
+	}
+	if ui.consumeSoftCancelFlash() {
+		r.say("Canceled.")
+	}
+	r.announceNotice(ui, v)
+	if ui.handler != nil {
+		if chord := ui.handler.PendingChord(); chord != v.chord {
+			v.chord = chord
+			if chord != "" {
+				r.say(chord + ", waiting for the next key.")
+			}
+		}
+	}
+
+	if state.IsWorkflowMode() {
+		r.announceWorkflowSelection(ui, state, v)
+		return
+	}
+	r.announceSearch(state, v)
+}
+
+// announceStatus says the git status when it is first read and whenever
+// it changes.
+func (r *Renderer) announceStatus(ui *UI, v *accessibleView) {
+	status, _ := ui.statusView()
+	if status == nil {
+		return
+	}
+	line := plainStatusLine(status)
+	if line == v.status {
+		return
+	}
+	v.status = line
+	r.say(line)
+	if status.IdentityWarning != "" {
+		r.say("Warning: " + status.IdentityWarning)
+	}
+}
+
+// plainStatusLine is gitStatusLine in words.
+func plainStatusLine(status *GitStatus) string {
+	parts := []string{"On branch " + status.Branch}
+	if status.Modified > 0 {
+		parts = append(parts, fmt.Sprintf("%d modified", status.Modified))
+	}
+	if status.Staged > 0 {
+		parts = append(parts, fmt.Sprintf("%d staged", status.Staged))
+	}
+	if status.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("%d ahead", status.Ahead))
+	}
+	if status.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("%d behind", status.Behind))
+	}
+	return strings.Join(parts, ", ") + "."
+}
+
+// announceNotice says a workflow error or notice once.
+func (r *Renderer) announceNotice(ui *UI, v *accessibleView) {
+	notice := ui.workflowErrorMessage()
+	if notice != "" {
+		notice = "Error: " + notice
+	} else {
+		notice = ui.workflowNoticeMessage()
+	}
+	if notice == v.notice {
+		return
+	}
+	v.notice = notice
+	if notice != "" {
+		r.say(plainText(notice))
+	}
+}
+
+// announceSearch says how many commands match a changed query and which
+// one is selected.
+func (r *Renderer) announceSearch(state *UIState, v *accessibleView) {
+	count := len(state.filtered)
+	if state.input != v.input || count != v.count {
+		v.input, v.count = state.input, count
+		v.selection = ""
+		switch {
+		case state.ShowsPalette():
+			r.say(fmt.Sprintf("Search is empty. %d commands, pinned ones first.", count))
+		case state.input == "":
+			r.say("Search is empty.")
+		case count == 0:
+			r.say(fmt.Sprintf("No commands match %s.", state.input))
+		default:
+			r.say(fmt.Sprintf("%d command%s match %s.", count, pluralize(count), state.input))
+		}
+	}
+
+	// With an empty query only the palette lists commands.
+	cmd := state.GetSelectedCommand()
+	if cmd == nil || (state.input == "" && !state.ShowsPalette()) {
+		return
+	}
+	selection := fmt.Sprintf("%d of %d: %s", state.selected+1, count, cmd.Command)
+	if cmd.Description != "" {
+		selection += ", " + cmd.Description
+	}
+	if state.IsMultiSelect() && state.IsMarked(cmd.Command) {
+		selection += ", marked"
+	}
+	if selection == v.selection {
+		return
+	}
+	v.selection = selection
+	r.say(selection + ".")
+}
+
+// announceWorkflowSelection says which workflow is selected in workflow
+// mode.
+func (r *Renderer) announceWorkflowSelection(ui *UI, state *UIState, v *accessibleView) {
+	summaries := ui.listWorkflows()
+	ui.ensureWorkflowListSelection()
+	selection := "No workflows yet. Press Ctrl+N to create one."
+	if i := state.workflowListIdx; i >= 0 && i < len(summaries) {
+		s := summaries[i]
+		name := strings.TrimSpace(s.Name)
+		if name == "" {
+			name = fmt.Sprintf("W%d", s.ID)
+		}
+		selection = fmt.Sprintf("Workflow %d of %d: %s, %d step%s", i+1, len(summaries), name, s.StepCount, pluralize(s.StepCount))
+		if s.IsActive {
+			selection += ", active"
+		}
+		selection += "."
+	}
+	if selection == v.selection {
+		return
+	}
+	v.selection = selection
+	r.say(selection)
+}
+
+// say writes one announcement on a line of its own.
+func (r *Renderer) say(text string) {
+	_, _ = fmt.Fprint(r.writer, text+"\r\n")
+}
+
+// plainText drops the emoji and symbols that decorate s, and the spaces
+// that set them apart from the text.
+func plainText(s string) string {
+	var b strings.Builder
+	afterSymbol := false
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.So, r) || unicode.Is(unicode.Variation_Selector, r):
+			afterSymbol = true
+			continue
+		case afterSymbol && r == ' ':
+			continue
+		}
+		afterSymbol = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package interactive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+)
+
+func newAccessibleUI(out *bytes.Buffer) *UI {
+	ui := newUIWithKeyMap(kb.DefaultKeyBindingMap())
+	ui.stdout = out
+	ui.state.commands = []CommandInfo{
+		{Command: "status", Description: "Show working tree status"},
+		{Command: "stash", Description: "Stash changes"},
+		{Command: "log simple", Description: "Show simple log"},
+	}
+	ui.renderer = &Renderer{writer: out, colors: NewANSIColors()}
+	ui.enableAccessible()
+	return ui
+}
+
+func TestRenderAccessible_AnnouncesChanges(t *testing.T) {
+	var out bytes.Buffer
+	ui := newAccessibleUI(&out)
+	ui.gitStatus = &GitStatus{Branch: "main", Modified: 2, Ahead: 1, HasChanges: true}
+	frame := func() string {
+		t.Helper()
+		out.Reset()
+		ui.state.UpdateFiltered()
+		ui.renderer.Render(ui, ui.state)
+		if strings.Contains(out.String(), "\x1b") {
+			t.Fatalf("accessible output has escape sequences: %q", out.String())
+		}
+		return out.String()
+	}
+
+	first := frame()
+	for _, want := range []string{"ggc interactive mode.", "On branch main, 2 modified, 1 ahead.\r\n", "Search is empty.\r\n"} {
+		if !strings.Contains(first, want) {
+			t.Errorf("first frame lacks %q:\n%s", want, first)
+		}
+	}
+
+	ui.state.input = "st"
+	if got, want := frame(), "2 commands match st.\r\n1 of 2: stash, Stash changes.\r\n"; got != want {
+		t.Errorf("after typing = %q, want %q", got, want)
+	}
+	if got := frame(); got != "" {
+		t.Errorf("an unchanged frame should say nothing, got %q", got)
+	}
+	ui.state.MoveDown()
+	if got, want := frame(), "2 of 2: status, Show working tree status.\r\n"; got != want {
+		t.Errorf("after moving = %q, want %q", got, want)
+	}
+	ui.state.input = "zzz"
+	if got, want := frame(), "No commands match zzz.\r\n"; got != want {
+		t.Errorf("no matches = %q, want %q", got, want)
+	}
+	ui.notifySoftCancel()
+	if got := frame(); got != "Canceled.\r\n" {
+		t.Errorf("soft cancel = %q", got)
+	}
+}
+
+func TestUIWrite_AccessibleDropsDecoration(t *testing.T) {
+	var out bytes.Buffer
+	ui := newAccessibleUI(&out)
+
+	ui.write("%s🚀 Executing: %s%s\n", ui.colors.BrightGreen, "status", ui.colors.Reset)
+	ui.writeln("⚠️  Step failed, continuing: %v", "exit status 1")
+	ui.clearScreen()
+	if got, want := out.String(), "Executing: status\nStep failed, continuing: exit status 1\r\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := map[string]string{
+		"📋 Step 1/2: status":           "Step 1/2: status",
+		"⏭️  Skipped: no changes":      "Skipped: no changes",
+		"┌─ Search: st":                "Search: st",
+		"status → git status":          "status → git status",
+		"plain text stays as it is…":   "plain text stays as it is…",
+		"\n🎉 Workflow completed (2)\n": "\nWorkflow completed (2)\n",
+	}
+	for in, want := range tests {
+		if got := plainText(in); got != want {
+			t.Errorf("plainText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
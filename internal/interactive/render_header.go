@@ -39,6 +39,20 @@ func (r *Renderer) renderWorkflowNotice(ui *UI) {
 	r.writeColorln(ui, "")
 }
 
+// renderPendingChord shows the keys of an unfinished chord, Emacs style,
+// so the user knows ggc is waiting for the rest of it.
+func (r *Renderer) renderPendingChord(ui *UI) {
+	if ui == nil || ui.handler == nil {
+		return
+	}
+	keys := ui.handler.PendingChord()
+	if keys == "" {
+		return
+	}
+	r.writeColorln(ui, fmt.Sprintf("%s⌨️  %s-%s %s(waiting for the next key)%s",
+		r.colors.BrightYellow, keys, r.colors.Reset, r.colors.BrightBlack, r.colors.Reset))
+}
+
 // renderWorkflowMode renders the workflow management screen.
 // Simplified: no input field, just workflow list and keybinds.
 func (r *Renderer) renderWorkflowMode(ui *UI, state *UIState) {
@@ -61,11 +75,19 @@ func (r *Renderer) renderHeader(ui *UI) {
 		r.colors.BrightCyan+r.colors.Bold,
 		titleText,
 		r.colors.Reset)
+	if mode := ui.viModeIndicator(); mode != "" {
+		title += fmt.Sprintf("  %s-- %s --%s", r.colors.BrightYellow+r.colors.Bold, mode, r.colors.Reset)
+	}
 	r.writeColorln(ui, title)
 
 	// Git status information
-	if ui.gitStatus != nil {
-		r.renderGitStatus(ui, ui.gitStatus)
+	switch status, refreshing := ui.statusView(); {
+	case status != nil && refreshing:
+		r.renderRefreshingGitStatus(ui, status)
+	case status != nil:
+		r.renderGitStatus(ui, status)
+	case refreshing:
+		r.writeColorln(ui, fmt.Sprintf("%s%s reading git status…%s", r.colors.BrightBlack, ui.spinnerFrame(), r.colors.Reset))
 	}
 
 	if ui != nil && ui.state != nil && ui.state.IsWorkflowMode() {
@@ -0,0 +1,76 @@
+package interactive
+
+import "fmt"
+
+// The preview pane sits below the results list and describes the
+// highlighted command: its summary, the git invocation it wraps, and its
+// usage lines and examples from the command registry.
+
+// maxPreviewExamples keeps the pane from crowding out the results list.
+const maxPreviewExamples = 3
+
+// TogglePreview shows or hides the preview pane.
+func (s *UIState) TogglePreview() {
+	s.showPreview = !s.showPreview
+}
+
+// IsPreviewVisible reports whether the preview pane is toggled on.
+func (s *UIState) IsPreviewVisible() bool {
+	return s.showPreview
+}
+
+// previewLines returns the pane's lines for the highlighted command, or
+// nil when the pane is off or nothing is highlighted.
+func (r *Renderer) previewLines(state *UIState) []string {
+	if !state.showPreview {
+		return nil
+	}
+	cmd := state.GetSelectedCommand()
+	if cmd == nil {
+		return nil
+	}
+	c := r.colors
+	label := func(name string) string {
+		return fmt.Sprintf("  %s%s%s", c.BrightBlue+c.Bold, name, c.Reset)
+	}
+
+	lines := []string{fmt.Sprintf("%s── Preview: %s%s%s", c.BrightBlue, c.BrightCyan+c.Bold, cmd.Command, c.Reset)}
+	if cmd.Description != "" {
+		lines = append(lines, fmt.Sprintf("  %s%s%s", c.White, cmd.Description, c.Reset))
+	}
+	if cmd.Git != "" {
+		lines = append(lines, fmt.Sprintf("%s %s%s%s", label("Runs:"), c.BrightYellow, cmd.Git, c.Reset))
+	}
+	if len(cmd.Usage) > 0 {
+		lines = append(lines, label("Usage:"))
+		for _, u := range cmd.Usage {
+			lines = append(lines, "    "+u)
+		}
+	}
+	if len(cmd.Examples) > 0 {
+		lines = append(lines, label("Examples:"))
+		for _, ex := range cmd.Examples[:min(len(cmd.Examples), maxPreviewExamples)] {
+			lines = append(lines, fmt.Sprintf("    %s%s%s", c.BrightBlack, ex, c.Reset))
+		}
+	}
+	return lines
+}
+
+// renderPreview draws lines returned by previewLines below a blank line.
+func (r *Renderer) renderPreview(ui *UI, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	r.writeEmptyLine()
+	for _, line := range lines {
+		r.writeColorln(ui, line)
+	}
+}
+
+// previewHeight is how many lines renderPreview takes for lines.
+func previewHeight(lines []string) int {
+	if len(lines) == 0 {
+		return 0
+	}
+	return len(lines) + 1
+}
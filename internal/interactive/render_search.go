@@ -53,6 +53,9 @@ func (r *Renderer) renderSearchPrompt(ui *UI, state *UIState) {
 			r.colors.BrightBlue,
 			r.colors.BrightMagenta+r.colors.Bold,
 			r.colors.Reset)
+		if state.IsMultiSelect() {
+			separator += fmt.Sprintf(" %s%d selected%s", r.colors.BrightGreen, state.MarkedCount(), r.colors.Reset)
+		}
 		r.writeColorln(ui, separator)
 	}
 	r.writeEmptyLine()
@@ -166,18 +169,30 @@ func (r *Renderer) buildSearchKeybindEntries(ui *UI) []keybindHelpEntry {
 
 	appendDynamic(km.MoveUp, defaultMap.MoveUp, "Navigate up")
 	appendDynamic(km.MoveDown, defaultMap.MoveDown, "Navigate down")
+	entries = append(entries, keybindHelpEntry{key: "PgUp/PgDn", desc: "Scroll results by a page"})
 	appendDynamic(km.ClearLine, defaultMap.ClearLine, "Clear all input")
 	appendDynamic(km.DeleteWord, defaultMap.DeleteWord, "Delete word")
 	appendDynamic(km.DeleteToEnd, defaultMap.DeleteToEnd, "Delete to end")
 	appendDynamic(km.MoveToBeginning, defaultMap.MoveToBeginning, "Move to beginning")
 	appendDynamic(km.MoveToEnd, defaultMap.MoveToEnd, "Move to end")
+	appendDynamic(km.Undo, nil, "Undo edit")
+	appendDynamic(km.Redo, nil, "Redo edit")
 
 	entries = append(entries, keybindHelpEntry{key: "Backspace", desc: "Delete character"})
 	entries = append(entries, keybindHelpEntry{key: "Enter", desc: "Execute selected command"})
+	entries = append(entries, keybindHelpEntry{key: "Ctrl+/", desc: "Toggle command preview"})
 
 	appendDynamic(km.AddToWorkflow, defaultMap.AddToWorkflow, "Add to workflow")
 	appendDynamic(km.ToggleWorkflowView, defaultMap.ToggleWorkflowView, "Toggle workflow view")
 
+	if ui.viModeIndicator() != "" {
+		entries = append(entries,
+			keybindHelpEntry{key: "Esc", desc: "Normal mode (vi)"},
+			keybindHelpEntry{key: "i/a/I/A or /", desc: "Back to insert mode (vi)"},
+			keybindHelpEntry{key: "v", desc: "Select a range to add to the workflow (vi)"},
+		)
+	}
+
 	entries = append(entries, keybindHelpEntry{key: "Ctrl+c", desc: "Quit"})
 
 	return entries
@@ -6,6 +6,33 @@ import (
 )
 
 func (r *Renderer) renderGitStatus(ui *UI, status *GitStatus) {
+	r.writeColorln(ui, r.gitStatusLine(status))
+	r.renderIdentityWarning(ui, status)
+}
+
+// renderIdentityWarning flags a repository whose identity differs from
+// its expected profile.
+func (r *Renderer) renderIdentityWarning(ui *UI, status *GitStatus) {
+	if status.IdentityWarning == "" {
+		return
+	}
+	r.writeColorln(ui, fmt.Sprintf("%s⚠️  %s%s", r.colors.BrightYellow, status.IdentityWarning, r.colors.Reset))
+}
+
+// renderRefreshingGitStatus shows the previous status dimmed, after a
+// spinner, while a reload is in flight.
+func (r *Renderer) renderRefreshingGitStatus(ui *UI, status *GitStatus) {
+	plain := *r
+	plain.colors = &ANSIColors{}
+	r.writeColorln(ui, fmt.Sprintf("%s%s %s%s",
+		r.colors.BrightBlack,
+		ui.spinnerFrame(),
+		plain.gitStatusLine(status),
+		r.colors.Reset))
+	r.renderIdentityWarning(ui, status)
+}
+
+func (r *Renderer) gitStatusLine(status *GitStatus) string {
 	var parts []string
 
 	// Branch name
@@ -51,7 +78,5 @@ func (r *Renderer) renderGitStatus(ui *UI, status *GitStatus) {
 		parts = append(parts, remotePart)
 	}
 
-	// Render the status line
-	statusLine := strings.Join(parts, "  ")
-	r.writeColorln(ui, statusLine)
+	return strings.Join(parts, "  ")
 }
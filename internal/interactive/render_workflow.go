@@ -206,8 +206,9 @@ func (r *Renderer) renderWorkflowModeKeybinds(_ *UI, _ *UIState) {
 	}
 }
 
-// renderCommandList renders the filtered command list
-func (r *Renderer) renderCommandList(ui *UI, state *UIState) {
+// renderCommandList renders the filtered command list in a viewport that
+// fits the terminal, leaving footer lines free below it.
+func (r *Renderer) renderCommandList(ui *UI, state *UIState, footer int) {
 	// Clamp selection index to valid range
 	if state.selected >= len(state.filtered) {
 		state.selected = len(state.filtered) - 1
@@ -219,9 +220,39 @@ func (r *Renderer) renderCommandList(ui *UI, state *UIState) {
 	// Calculate maximum command length for consistent alignment
 	maxCmdLen := r.calculateMaxCommandLength(state.filtered)
 
-	for i, cmd := range state.filtered {
+	grouped := state.hasSections()
+	start, end := state.resultsWindow(r.resultsRows(footer), grouped)
+	if start > 0 {
+		r.writeColorln(ui, fmt.Sprintf("%s  ↑ %d more above%s", r.colors.BrightBlack, start, r.colors.Reset))
+	}
+	for i := start; i < end; i++ {
+		cmd := state.filtered[i]
+		if grouped && (i == start || cmd.Section != state.filtered[i-1].Section) {
+			r.renderSectionHeader(ui, cmd.Section)
+		}
 		r.renderCommandItem(ui, cmd, i, state.selected, maxCmdLen)
 	}
+	if below := len(state.filtered) - end; below > 0 {
+		r.writeColorln(ui, fmt.Sprintf("%s  ↓ %d more below%s", r.colors.BrightBlack, below, r.colors.Reset))
+	}
+}
+
+// minResultsRows keeps a few results visible on very short terminals.
+const minResultsRows = 3
+
+// resultsRows returns how many list lines fit below what has been drawn,
+// keeping footer lines and the two scroll indicators free.
+func (r *Renderer) resultsRows(footer int) int {
+	return max(r.height-r.lines-footer-2, minResultsRows)
+}
+
+// renderSectionHeader draws the heading above a palette section.
+// Unsectioned commands are listed under "Commands".
+func (r *Renderer) renderSectionHeader(ui *UI, section string) {
+	if section == "" {
+		section = "Commands"
+	}
+	r.writeColorln(ui, fmt.Sprintf("%s%s%s", r.colors.BrightMagenta+r.colors.Bold, section, r.colors.Reset))
 }
 
 // renderCommandItem renders a single command item
@@ -238,8 +269,13 @@ func (r *Renderer) renderCommandItem(ui *UI, cmd CommandInfo, index, selected, m
 	}
 	padding := strings.Repeat(" ", paddingLen)
 
+	mark := r.selectionMark(ui, cmd, index)
+
 	// Calculate available width for description
 	usedWidth := 4 + len(cmd.Command) + len(padding) + 3 // prefix + command + padding + separator
+	if mark != "" {
+		usedWidth += 2
+	}
 	availableDescWidth := r.width - usedWidth
 	if availableDescWidth < 10 {
 		availableDescWidth = 10
@@ -250,8 +286,9 @@ func (r *Renderer) renderCommandItem(ui *UI, cmd CommandInfo, index, selected, m
 
 	if index == selected {
 		// Selected item with modern highlighting
-		selectedLine := fmt.Sprintf("%s▶ %s%s%s%s %s│%s %s%s%s",
+		selectedLine := fmt.Sprintf("%s▶ %s%s%s%s%s %s│%s %s%s%s",
 			r.colors.BrightCyan+r.colors.Bold,
+			mark,
 			r.colors.BrightWhite+r.colors.Bold+r.colors.Reverse,
 			" "+cmd.Command+" ",
 			r.colors.Reset,
@@ -264,7 +301,8 @@ func (r *Renderer) renderCommandItem(ui *UI, cmd CommandInfo, index, selected, m
 		r.writeColorln(ui, selectedLine)
 	} else {
 		// Regular item with improved styling
-		regularLine := fmt.Sprintf("  %s%s%s%s %s│%s %s%s%s",
+		regularLine := fmt.Sprintf("  %s%s%s%s%s %s│%s %s%s%s",
+			mark,
 			r.colors.BrightGreen+r.colors.Bold,
 			cmd.Command,
 			r.colors.Reset,
@@ -278,6 +316,29 @@ func (r *Renderer) renderCommandItem(ui *UI, cmd CommandInfo, index, selected, m
 	}
 }
 
+// selectionMark returns the marker drawn before cmd, the result at index,
+// in multi-select mode or vi visual mode, or "" otherwise.
+func (r *Renderer) selectionMark(ui *UI, cmd CommandInfo, index int) string {
+	if ui == nil || ui.state == nil {
+		return ""
+	}
+	if ui.viModeIndicator() == viVisual.String() {
+		return markGlyph(r.colors, ui.handler.inVisualRange(index))
+	}
+	if !ui.state.IsMultiSelect() {
+		return ""
+	}
+	return markGlyph(r.colors, ui.state.IsMarked(cmd.Command))
+}
+
+// markGlyph renders the multi-select marker for a marked or unmarked item.
+func markGlyph(c *ANSIColors, marked bool) string {
+	if marked {
+		return c.BrightGreen + "◉ " + c.Reset
+	}
+	return c.BrightBlack + "○ " + c.Reset
+}
+
 // renderWorkflowView renders the detailed workflow view
 func (r *Renderer) renderWorkflowView(ui *UI, _ *UIState) {
 	if ui == nil {
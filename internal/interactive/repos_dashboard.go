@@ -0,0 +1,264 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+// DashboardSource is the git access the workspace dashboard needs.
+type DashboardSource interface {
+	git.RepoStateReader
+}
+
+// DashboardRepo is a repository on the dashboard.
+type DashboardRepo struct {
+	Label string
+	Path  string
+}
+
+// DashboardActionKind is what the user chose to do in a repository.
+type DashboardActionKind int
+
+// Actions that end the dashboard.
+const (
+	// DashboardOpen opens interactive ggc in the repository.
+	DashboardOpen DashboardActionKind = iota
+	// DashboardRun asks for a ggc command and runs it in the repository.
+	DashboardRun
+)
+
+// DashboardAction is the dashboard's result: an action in one repository.
+// The caller runs it once the screen is restored and then runs the
+// dashboard again.
+type DashboardAction struct {
+	Kind DashboardActionKind
+	Repo DashboardRepo
+}
+
+// dashboardRow is a repository with its state as last read.
+type dashboardRow struct {
+	repo  DashboardRepo
+	state git.RepoState
+	err   error
+}
+
+// ReposDashboard is a full-screen table of repositories with each one's
+// branch, changes, commits ahead of and behind its upstream and the
+// operation it is in the middle of. r reads them all again, enter ends
+// the dashboard to open interactive ggc in the highlighted repository and
+// x to run a ggc command there. The state is read again and the cursor
+// kept each time Run is called. Navigation honors the move_up, move_down
+// and soft_cancel bindings of the active keybinding profile.
+type ReposDashboard struct {
+	git     DashboardSource
+	rows    []dashboardRow
+	cursor  int
+	message string
+	keyMap  *kb.KeyBindingMap
+	colors  *ANSIColors
+	stdin   io.Reader
+	stdout  io.Writer
+	term    termio.Terminal
+}
+
+// NewReposDashboard returns a dashboard over repos using the keybinding
+// profile configured in cfg. cfg may be nil.
+func NewReposDashboard(src DashboardSource, repos []DashboardRepo, cfg *config.Config) *ReposDashboard {
+	rows := make([]dashboardRow, len(repos))
+	for i, repo := range repos {
+		rows[i].repo = repo
+	}
+	return &ReposDashboard{
+		git:    src,
+		rows:   rows,
+		keyMap: resolveResultsKeyMap(cfg),
+		colors: NewANSIColors(),
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		term:   termio.DefaultTerminal{},
+	}
+}
+
+// Run shows the dashboard until the user picks an action or quits. ok is
+// false when there is nothing to do.
+func (d *ReposDashboard) Run() (action DashboardAction, ok bool, err error) {
+	if len(d.rows) == 0 {
+		_, _ = fmt.Fprintln(d.stdout, "No repositories")
+		return DashboardAction{}, false, nil
+	}
+	d.refresh()
+
+	if f, isFile := d.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := d.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = d.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(d.stdout)
+
+	reader := bufio.NewReader(d.stdin)
+	for {
+		d.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil {
+			clearScreen(d.stdout)
+			return DashboardAction{}, false, nil
+		}
+		action, done, chosen := d.handleKey(ks)
+		if done {
+			clearScreen(d.stdout)
+			return action, chosen, nil
+		}
+	}
+}
+
+// refresh reads the state of every repository, all at once, since each
+// read is its own git process.
+func (d *ReposDashboard) refresh() {
+	var wg sync.WaitGroup
+	for i := range d.rows {
+		row := &d.rows[i]
+		wg.Go(func() {
+			row.state, row.err = d.git.RepoState(row.repo.Path)
+		})
+	}
+	wg.Wait()
+}
+
+// handleKey applies one keystroke. done reports whether the dashboard
+// should close and chosen whether action should then be run.
+func (d *ReposDashboard) handleKey(ks kb.KeyStroke) (action DashboardAction, done, chosen bool) {
+	d.message = ""
+	repo := d.rows[d.cursor].repo
+
+	switch {
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewCharKeyStroke('q')),
+		d.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return DashboardAction{}, true, false
+	case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+		d.keyMap.MatchesKeyStroke("move_up", ks):
+		d.cursor = max(d.cursor-1, 0)
+	case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+		d.keyMap.MatchesKeyStroke("move_down", ks):
+		d.cursor = min(d.cursor+1, len(d.rows)-1)
+	case ks.Equals(kb.NewCharKeyStroke('r')):
+		d.refresh()
+		d.message = "Refreshed"
+	case ks.Equals(kb.NewEnterKeyStroke()):
+		return DashboardAction{Kind: DashboardOpen, Repo: repo}, true, true
+	case ks.Equals(kb.NewCharKeyStroke('x')):
+		return DashboardAction{Kind: DashboardRun, Repo: repo}, true, true
+	}
+	return DashboardAction{}, false, false
+}
+
+// dashboardCell is a table cell: its text and the color it is drawn in.
+type dashboardCell struct {
+	text  string
+	color string
+}
+
+func (d *ReposDashboard) render() {
+	c := d.colors
+	clearScreen(d.stdout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sRepositories%s\r\n\r\n", c.Bold+c.BrightCyan, c.Reset)
+
+	header := []dashboardCell{{"REPO", ""}, {"BRANCH", ""}, {"CHANGES", ""}, {"UPSTREAM", ""}, {"OPERATION", ""}}
+	table := [][]dashboardCell{header}
+	for _, row := range d.rows {
+		table = append(table, d.cells(row))
+	}
+	widths := make([]int, len(header))
+	for _, cells := range table {
+		for i, cell := range cells {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell.text))
+		}
+	}
+	for i, cells := range table {
+		marker := "  "
+		if i-1 == d.cursor {
+			marker = c.BrightGreen + "› " + c.Reset
+		}
+		b.WriteString(marker)
+		for j, cell := range cells {
+			text := cell.text
+			if j < len(cells)-1 {
+				text += strings.Repeat(" ", widths[j]-utf8.RuneCountInString(text)+2)
+			}
+			switch {
+			case i == 0:
+				text = c.BrightBlack + text + c.Reset
+			case cell.color != "":
+				text = cell.color + text + c.Reset
+			}
+			b.WriteString(text)
+		}
+		b.WriteString("\r\n")
+	}
+
+	if err := d.rows[d.cursor].err; err != nil {
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightRed, strings.TrimSpace(err.Error()), c.Reset)
+	}
+	if d.message != "" {
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightGreen, d.message, c.Reset)
+	}
+	help := "j/k move · enter open ggc · [x] run a command · [r]efresh · q quit  (+staged ~modified ?untracked !conflicted)"
+	fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightBlack, help, c.Reset)
+	_, _ = io.WriteString(d.stdout, b.String())
+}
+
+// cells renders a repository's row.
+func (d *ReposDashboard) cells(row dashboardRow) []dashboardCell {
+	c := d.colors
+	label := dashboardCell{row.repo.Label, c.Bold}
+	if row.err != nil {
+		return []dashboardCell{label, {"?", c.BrightRed}, {"unreadable", c.BrightRed}, {"", ""}, {"", ""}}
+	}
+	s := row.state.Status
+
+	branch := dashboardCell{s.Branch, c.BrightGreen}
+	if s.Detached {
+		branch = dashboardCell{"(detached " + shortHash(s.OID) + ")", c.BrightYellow}
+	}
+
+	changes := dashboardCell{"clean", c.BrightBlack}
+	var parts []string
+	for _, p := range []struct {
+		sign string
+		n    int
+	}{{"+", s.Staged()}, {"~", s.Modified()}, {"?", s.Untracked()}, {"!", s.Conflicted()}} {
+		if p.n > 0 {
+			parts = append(parts, fmt.Sprintf("%s%d", p.sign, p.n))
+		}
+	}
+	if len(parts) > 0 {
+		changes = dashboardCell{strings.Join(parts, " "), c.BrightYellow}
+		if s.Conflicted() > 0 {
+			changes.color = c.BrightRed
+		}
+	}
+
+	upstream := dashboardCell{"in sync", c.BrightBlack}
+	switch {
+	case s.Upstream == "":
+		upstream = dashboardCell{"no upstream", c.BrightBlack}
+	case s.Ahead > 0 || s.Behind > 0:
+		upstream = dashboardCell{fmt.Sprintf("↑%d ↓%d", s.Ahead, s.Behind), c.BrightCyan}
+	}
+
+	return []dashboardCell{label, branch, changes, upstream, {row.state.Operation, c.Bold + c.BrightRed}}
+}
@@ -0,0 +1,107 @@
+package interactive
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/git"
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// fakeDashboardSource serves a fixed state per path and counts reads.
+type fakeDashboardSource struct {
+	mu     sync.Mutex
+	states map[string]git.RepoState
+	reads  int
+}
+
+func (f *fakeDashboardSource) RepoState(dir string) (git.RepoState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reads++
+	state, ok := f.states[dir]
+	if !ok {
+		return git.RepoState{}, errors.New("fatal: cannot change to '" + dir + "'")
+	}
+	return state, nil
+}
+
+func dashboardEntry(kind git.StatusEntryKind, xy string) git.StatusEntry {
+	return git.StatusEntry{Kind: kind, Index: xy[0], WorkTree: xy[1], Path: "f"}
+}
+
+func newTestDashboard(input string) (*ReposDashboard, *fakeDashboardSource, *bytes.Buffer) {
+	src := &fakeDashboardSource{states: map[string]git.RepoState{
+		"/src/api": {Status: &git.StatusSummary{Branch: "main", Upstream: "origin/main", Ahead: 2, Behind: 1,
+			Entries: []git.StatusEntry{dashboardEntry(git.StatusOrdinary, "M."), dashboardEntry(git.StatusUntracked, "??")}}},
+		"/src/web": {Status: &git.StatusSummary{Branch: "feature", Upstream: "origin/feature",
+			Entries: []git.StatusEntry{dashboardEntry(git.StatusUnmerged, "UU")}}, Operation: "rebase"},
+		"/src/docs": {Status: &git.StatusSummary{Branch: "HEAD", Detached: true, OID: "0123456789abcdef"}},
+	}}
+	repos := []DashboardRepo{{"api", "/src/api"}, {"web", "/src/web"}, {"docs", "/src/docs"}, {"gone", "/src/gone"}}
+	var out bytes.Buffer
+	d := NewReposDashboard(src, repos, nil)
+	d.stdin = strings.NewReader(input)
+	d.stdout = &out
+	return d, src, &out
+}
+
+func TestReposDashboard_Renders(t *testing.T) {
+	d, _, out := newTestDashboard("jjjq")
+	if _, ok, err := d.Run(); ok || err != nil {
+		t.Fatalf("Run() ok = %v, err = %v; q should not choose an action", ok, err)
+	}
+	got := uiutil.StripANSI(out.String())
+	for _, want := range []string{
+		"  REPO  BRANCH               CHANGES     UPSTREAM     OPERATION",
+		"› api   main                 +1 ?1       ↑2 ↓1",
+		"  web   feature              !1          in sync      rebase",
+		"  docs  (detached 01234567)  clean       no upstream",
+		"› gone  ?                    unreadable",
+		"fatal: cannot change to '/src/gone'",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestReposDashboard_Actions(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  DashboardActionKind
+		repo  string
+	}{
+		{"\r", DashboardOpen, "api"},
+		{"jx", DashboardRun, "web"},
+		{"jjjjjkx", DashboardRun, "docs"},
+	}
+	for _, tt := range tests {
+		d, _, _ := newTestDashboard(tt.input)
+		got, ok, err := d.Run()
+		if err != nil || !ok || got.Kind != tt.kind || got.Repo.Label != tt.repo {
+			t.Errorf("input %q: Run() = %+v, %v, %v", tt.input, got, ok, err)
+		}
+	}
+}
+
+func TestReposDashboard_Refresh(t *testing.T) {
+	d, src, out := newTestDashboard("jrq")
+	d.Run()
+	if src.reads != 8 {
+		t.Errorf("reads = %d, want every repository read on opening and on r", src.reads)
+	}
+	if !strings.Contains(out.String(), "Refreshed") {
+		t.Error("r should say the state was read again")
+	}
+
+	// Running again reads the state again and keeps the cursor.
+	d.stdin = strings.NewReader("x")
+	got, _, _ := d.Run()
+	if src.reads != 12 || got.Repo.Label != "web" {
+		t.Errorf("reads = %d, repo = %q; want a fresh read with the cursor on web", src.reads, got.Repo.Label)
+	}
+}
@@ -0,0 +1,294 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+// sparsePickerRows caps how many tree rows are drawn at once.
+const sparsePickerRows = 20
+
+// sparseRow is one directory of the tree.
+type sparseRow struct {
+	path     string
+	depth    int
+	children bool
+}
+
+// SparsePicker is a full-screen tree of the directories in HEAD for
+// choosing which ones a cone-mode sparse checkout includes. Space
+// includes or excludes the highlighted directory with everything under
+// it, l and h unfold and fold it, and Enter applies the choice.
+// Navigation honors the move_up, move_down and soft_cancel bindings of the
+// active keybinding profile; arrow keys and j/k always work as well.
+type SparsePicker struct {
+	children map[string][]string // "" holds the top-level directories
+	included map[string]bool
+	expanded map[string]bool
+	rows     []sparseRow
+	cursor   int
+	message  string
+	keyMap   *kb.KeyBindingMap
+	colors   *ANSIColors
+	stdin    io.Reader
+	stdout   io.Writer
+	term     termio.Terminal
+}
+
+// NewSparsePicker returns a picker over dirs, every directory of the
+// tree, starting with included checked out. cfg may be nil.
+func NewSparsePicker(dirs, included []string, cfg *config.Config) *SparsePicker {
+	p := &SparsePicker{
+		children: make(map[string][]string),
+		included: make(map[string]bool),
+		expanded: make(map[string]bool),
+		keyMap:   resolveResultsKeyMap(cfg),
+		colors:   NewANSIColors(),
+		stdin:    os.Stdin,
+		stdout:   os.Stdout,
+		term:     termio.DefaultTerminal{},
+	}
+	for _, dir := range dirs {
+		parent := path.Dir(dir)
+		if parent == "." {
+			parent = ""
+		}
+		p.children[parent] = append(p.children[parent], dir)
+	}
+	for _, kids := range p.children {
+		slices.Sort(kids)
+	}
+	for _, dir := range included {
+		dir = strings.Trim(dir, "/")
+		p.included[dir] = true
+		// Unfold down to each included directory so it shows.
+		for parent := path.Dir(dir); parent != "."; parent = path.Dir(parent) {
+			p.expanded[parent] = true
+		}
+	}
+	p.buildRows()
+	return p
+}
+
+// Run shows the tree until the user applies or cancels. ok is false when
+// the user canceled; dirs is then nil.
+func (p *SparsePicker) Run() (dirs []string, ok bool, err error) {
+	if f, isFile := p.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := p.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = p.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(p.stdout)
+
+	reader := bufio.NewReader(p.stdin)
+	for {
+		p.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil {
+			clearScreen(p.stdout)
+			return nil, false, nil
+		}
+		if done, apply := p.handleKey(ks); done {
+			clearScreen(p.stdout)
+			if !apply {
+				return nil, false, nil
+			}
+			return p.selection(), true, nil
+		}
+	}
+}
+
+// selection returns the included directories, sorted.
+func (p *SparsePicker) selection() []string {
+	dirs := make([]string, 0, len(p.included))
+	for dir := range p.included {
+		dirs = append(dirs, dir)
+	}
+	slices.Sort(dirs)
+	return dirs
+}
+
+// buildRows lays out the unfolded part of the tree.
+func (p *SparsePicker) buildRows() {
+	p.rows = p.rows[:0]
+	var walk func(parent string, depth int)
+	walk = func(parent string, depth int) {
+		for _, dir := range p.children[parent] {
+			kids := len(p.children[dir]) > 0
+			p.rows = append(p.rows, sparseRow{path: dir, depth: depth, children: kids})
+			if kids && p.expanded[dir] {
+				walk(dir, depth+1)
+			}
+		}
+	}
+	walk("", 0)
+	p.cursor = min(p.cursor, max(len(p.rows)-1, 0))
+}
+
+// handleKey applies one keystroke and reports whether the picker is done
+// and, if so, whether to apply the choice.
+func (p *SparsePicker) handleKey(ks kb.KeyStroke) (done, apply bool) {
+	p.message = ""
+	switch {
+	case ks.Equals(kb.NewEnterKeyStroke()):
+		return true, true
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewCharKeyStroke('q')), ks.Equals(kb.NewEscapeKeyStroke()),
+		p.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return true, false
+	case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+		p.keyMap.MatchesKeyStroke("move_up", ks):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+		p.keyMap.MatchesKeyStroke("move_down", ks):
+		if p.cursor < len(p.rows)-1 {
+			p.cursor++
+		}
+	case ks.Equals(kb.NewRightArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('l')):
+		p.fold(false)
+	case ks.Equals(kb.NewLeftArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('h')):
+		p.fold(true)
+	case ks.Equals(kb.NewCharKeyStroke(' ')):
+		p.toggle()
+	}
+	return false, false
+}
+
+// fold folds or unfolds the highlighted directory. Folding a directory
+// that is already folded moves to its parent.
+func (p *SparsePicker) fold(collapse bool) {
+	if len(p.rows) == 0 {
+		return
+	}
+	row := p.rows[p.cursor]
+	if !collapse {
+		if row.children {
+			p.expanded[row.path] = true
+			p.buildRows()
+		}
+		return
+	}
+	if row.children && p.expanded[row.path] {
+		p.expanded[row.path] = false
+		p.buildRows()
+		return
+	}
+	parent := path.Dir(row.path)
+	for i, r := range p.rows {
+		if r.path == parent {
+			p.cursor = i
+			return
+		}
+	}
+}
+
+// toggle includes or excludes the highlighted directory. Including one
+// covers everything under it, so directories below it are no longer
+// listed on their own.
+func (p *SparsePicker) toggle() {
+	if len(p.rows) == 0 {
+		return
+	}
+	dir := p.rows[p.cursor].path
+	if ancestor := p.includedAncestor(dir); ancestor != "" {
+		p.message = fmt.Sprintf("%s/ is checked out with %s/; exclude that instead", dir, ancestor)
+		return
+	}
+	if p.included[dir] {
+		delete(p.included, dir)
+		return
+	}
+	for other := range p.included {
+		if strings.HasPrefix(other, dir+"/") {
+			delete(p.included, other)
+		}
+	}
+	p.included[dir] = true
+}
+
+// includedAncestor returns the included directory above dir, if any.
+func (p *SparsePicker) includedAncestor(dir string) string {
+	for parent := path.Dir(dir); parent != "."; parent = path.Dir(parent) {
+		if p.included[parent] {
+			return parent
+		}
+	}
+	return ""
+}
+
+// partlyIncluded reports whether a directory below dir is included.
+func (p *SparsePicker) partlyIncluded(dir string) bool {
+	for other := range p.included {
+		if strings.HasPrefix(other, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// check returns the box drawn before dir.
+func (p *SparsePicker) check(dir string) string {
+	c := p.colors
+	switch {
+	case p.included[dir]:
+		return c.BrightGreen + "[x]" + c.Reset
+	case p.includedAncestor(dir) != "":
+		return c.BrightBlack + "[x]" + c.Reset
+	case p.partlyIncluded(dir):
+		return c.BrightYellow + "[-]" + c.Reset
+	default:
+		return "[ ]"
+	}
+}
+
+func (p *SparsePicker) render() {
+	c := p.colors
+	clearScreen(p.stdout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sSparse checkout%s %s(%d included)%s\r\n\r\n", c.Bold+c.BrightCyan, c.Reset,
+		c.BrightGreen, len(p.included), c.Reset)
+
+	start := max(p.cursor-sparsePickerRows+1, 0)
+	end := min(start+sparsePickerRows, len(p.rows))
+	for i := start; i < end; i++ {
+		row := p.rows[i]
+		marker := "  "
+		if i == p.cursor {
+			marker = c.BrightGreen + "› " + c.Reset
+		}
+		fold := " "
+		if row.children {
+			fold = "▸"
+			if p.expanded[row.path] {
+				fold = "▾"
+			}
+		}
+		fmt.Fprintf(&b, "%s%s %s%s%s %s/%s\r\n", marker, p.check(row.path), strings.Repeat("  ", row.depth),
+			c.BrightBlue, fold, path.Base(row.path), c.Reset)
+	}
+	if len(p.rows) == 0 {
+		fmt.Fprintf(&b, "%sThe tree has no directories; only its top-level files are checked out.%s\r\n", c.BrightBlack, c.Reset)
+	} else if hidden := len(p.rows) - (end - start); hidden > 0 {
+		fmt.Fprintf(&b, "%s… %d more%s\r\n", c.BrightBlack, hidden, c.Reset)
+	}
+	if p.message != "" {
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightYellow, p.message, c.Reset)
+	}
+	fmt.Fprintf(&b, "\r\n%sFiles at the top level are always checked out.%s\r\n", c.BrightBlack, c.Reset)
+	fmt.Fprintf(&b, "%sj/k move · space include/exclude · l/h unfold/fold · enter apply · q cancel%s\r\n", c.BrightBlack, c.Reset)
+	_, _ = io.WriteString(p.stdout, b.String())
+}
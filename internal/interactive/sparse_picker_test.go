@@ -0,0 +1,68 @@
+package interactive
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+var sparseDirs = []string{"cmd", "cmd/command", "cmd/completions", "docs", "docs/content", "internal", "internal/git"}
+
+func runSparsePicker(t *testing.T, included []string, input string) ([]string, bool, string) {
+	t.Helper()
+	var out bytes.Buffer
+	p := NewSparsePicker(sparseDirs, included, nil)
+	p.stdin = strings.NewReader(input)
+	p.stdout = &out
+	dirs, ok, err := p.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dirs, ok, uiutil.StripANSI(out.String())
+}
+
+func TestSparsePicker_Tree(t *testing.T) {
+	_, _, out := runSparsePicker(t, []string{"cmd/command"}, "q")
+	for _, want := range []string{
+		"› [-] ▾ cmd/",
+		"  [x]     command/",
+		"  [ ]     completions/",
+		"  [ ] ▸ docs/",
+		"  [ ] ▸ internal/",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("tree lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSparsePicker_Toggle(t *testing.T) {
+	// Include docs, unfold internal and include internal/git, then include
+	// cmd, which covers cmd/command.
+	dirs, ok, _ := runSparsePicker(t, []string{"cmd/command"}, "jjj jlj kkkkk \r")
+	if !ok {
+		t.Fatal("the choice should be applied")
+	}
+	if want := []string{"cmd", "docs", "internal/git"}; !slices.Equal(dirs, want) {
+		t.Errorf("dirs = %v, want %v", dirs, want)
+	}
+}
+
+func TestSparsePicker_IncludedWithParent(t *testing.T) {
+	dirs, ok, out := runSparsePicker(t, []string{"cmd"}, "lj \r")
+	if !ok || !slices.Equal(dirs, []string{"cmd"}) {
+		t.Errorf("dirs = %v, %v; want [cmd]", dirs, ok)
+	}
+	if !strings.Contains(out, "cmd/command/ is checked out with cmd/") {
+		t.Errorf("expected a notice:\n%s", out)
+	}
+}
+
+func TestSparsePicker_Cancel(t *testing.T) {
+	if dirs, ok, _ := runSparsePicker(t, nil, " q"); ok || dirs != nil {
+		t.Errorf("cancel = %v, %v", dirs, ok)
+	}
+}
@@ -0,0 +1,310 @@
+package interactive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/difftool"
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+	"github.com/bmf-san/ggc/v8/internal/termio"
+)
+
+// stashPreviewLines caps how much of the selected stash is drawn below the list.
+const stashPreviewLines = 20
+
+// StashSource is the git access the stash browser needs.
+type StashSource interface {
+	StashList() (string, error)
+	StashDiff(stash string) (string, error)
+	StashDrop(stash string) error
+}
+
+// StashEntry is one line of `git stash list`.
+type StashEntry struct {
+	Ref     string // e.g. stash@{0}
+	Message string // e.g. "WIP on main: 1234567 subject"
+}
+
+// ParseStashList parses `git stash list` output.
+func ParseStashList(output string) []StashEntry {
+	var entries []StashEntry
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		ref, msg, _ := strings.Cut(line, ": ")
+		entries = append(entries, StashEntry{Ref: ref, Message: msg})
+	}
+	return entries
+}
+
+// StashActionKind is what the user chose to do with a stash.
+type StashActionKind int
+
+// Actions that end the browser. Drops happen inside the browser.
+const (
+	StashApply StashActionKind = iota
+	StashPop
+	StashBranch
+)
+
+// StashAction is the browser's result: an action on one stash. The caller
+// runs it once the screen is restored, so git's output stays visible.
+type StashAction struct {
+	Kind StashActionKind
+	Ref  string
+}
+
+// StashBrowser is a full-screen list of stashes with a diff preview of the
+// selected one. Enter or a applies the stash, p pops it, b turns it into a
+// branch and d (pressed twice) drops it. Space marks stashes so d drops all
+// of them at once. Navigation honors the move_up,
+// move_down and soft_cancel bindings of the active keybinding profile.
+type StashBrowser struct {
+	git         StashSource
+	entries     []StashEntry
+	cursor      int
+	message     string
+	pendingDrop string
+	marked      map[string]bool
+	keyMap      *kb.KeyBindingMap
+	colors      *ANSIColors
+	stdin       io.Reader
+	stdout      io.Writer
+	term        termio.Terminal
+
+	highlight func(diff string) (out string, ok bool, err error)
+	previews  map[string][]string
+}
+
+// NewStashBrowser returns a browser over the repository's stashes using
+// the keybinding profile and diff tool configured in cfg. cfg may be nil.
+func NewStashBrowser(src StashSource, cfg *config.Config) *StashBrowser {
+	var tool string
+	if cfg != nil {
+		tool = cfg.UI.DiffTool
+	}
+	return &StashBrowser{
+		git:       src,
+		keyMap:    resolveResultsKeyMap(cfg),
+		colors:    NewANSIColors(),
+		stdin:     os.Stdin,
+		stdout:    os.Stdout,
+		term:      termio.DefaultTerminal{},
+		highlight: difftool.New(tool).Highlight,
+		previews:  make(map[string][]string),
+	}
+}
+
+// Run shows the browser until the user picks an action or quits. ok is
+// false when there is nothing to do.
+func (s *StashBrowser) Run() (action StashAction, ok bool, err error) {
+	if err := s.reload(); err != nil {
+		return StashAction{}, false, err
+	}
+	if len(s.entries) == 0 {
+		_, _ = fmt.Fprintln(s.stdout, "No stashes found")
+		return StashAction{}, false, nil
+	}
+
+	if f, isFile := s.stdin.(*os.File); isFile && term.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		oldState, err := s.term.MakeRaw(fd)
+		if err == nil {
+			defer func() { _ = s.term.Restore(fd, oldState) }()
+		}
+	}
+	defer showCursor(s.stdout)
+
+	reader := bufio.NewReader(s.stdin)
+	for {
+		s.render()
+		ks, err := readRebaseKey(reader)
+		if err != nil {
+			clearScreen(s.stdout)
+			return StashAction{}, false, nil
+		}
+		action, done, chosen := s.handleKey(ks)
+		if done {
+			clearScreen(s.stdout)
+			return action, chosen, nil
+		}
+	}
+}
+
+// reload reads the stash list, keeping the cursor in range.
+func (s *StashBrowser) reload() error {
+	output, err := s.git.StashList()
+	if err != nil {
+		return err
+	}
+	s.entries = ParseStashList(output)
+	clear(s.previews)
+	// Refs are renumbered after a drop, so marks no longer apply.
+	s.marked = nil
+	if s.cursor >= len(s.entries) {
+		s.cursor = max(len(s.entries)-1, 0)
+	}
+	return nil
+}
+
+// handleKey applies one keystroke. done reports whether the browser should
+// close and chosen whether action should then be run.
+func (s *StashBrowser) handleKey(ks kb.KeyStroke) (action StashAction, done, chosen bool) {
+	s.message = ""
+	pendingDrop := s.pendingDrop
+	s.pendingDrop = ""
+	if len(s.entries) == 0 {
+		return StashAction{}, true, false
+	}
+	ref := s.entries[s.cursor].Ref
+
+	switch {
+	case ks.Equals(kb.NewCtrlKeyStroke('c')), ks.Equals(kb.NewCharKeyStroke('q')),
+		s.keyMap.MatchesKeyStroke("soft_cancel", ks):
+		return StashAction{}, true, false
+	case ks.Equals(kb.NewUpArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('k')),
+		s.keyMap.MatchesKeyStroke("move_up", ks):
+		if s.cursor > 0 {
+			s.cursor--
+		}
+	case ks.Equals(kb.NewDownArrowKeyStroke()), ks.Equals(kb.NewCharKeyStroke('j')),
+		s.keyMap.MatchesKeyStroke("move_down", ks):
+		if s.cursor < len(s.entries)-1 {
+			s.cursor++
+		}
+	case ks.Equals(kb.NewEnterKeyStroke()), ks.Equals(kb.NewCharKeyStroke('a')):
+		return StashAction{Kind: StashApply, Ref: ref}, true, true
+	case ks.Equals(kb.NewCharKeyStroke('p')):
+		return StashAction{Kind: StashPop, Ref: ref}, true, true
+	case ks.Equals(kb.NewCharKeyStroke('b')):
+		return StashAction{Kind: StashBranch, Ref: ref}, true, true
+	case ks.Equals(kb.NewCharKeyStroke(' ')):
+		s.toggleMark(ref)
+	case ks.Equals(kb.NewCharKeyStroke('d')):
+		targets := s.dropTargets(ref)
+		s.drop(targets, pendingDrop == strings.Join(targets, " "))
+	}
+	return StashAction{}, false, false
+}
+
+func (s *StashBrowser) toggleMark(ref string) {
+	if s.marked[ref] {
+		delete(s.marked, ref)
+		return
+	}
+	if s.marked == nil {
+		s.marked = make(map[string]bool)
+	}
+	s.marked[ref] = true
+}
+
+// dropTargets returns the marked stashes, newest last, or ref when none is
+// marked. Dropping the oldest first keeps the remaining refs valid.
+func (s *StashBrowser) dropTargets(ref string) []string {
+	var refs []string
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		if s.marked[s.entries[i].Ref] {
+			refs = append(refs, s.entries[i].Ref)
+		}
+	}
+	if len(refs) == 0 {
+		return []string{ref}
+	}
+	return refs
+}
+
+// drop asks for confirmation first, then drops refs and reloads the list.
+// The browser closes on its own once the last stash is gone.
+func (s *StashBrowser) drop(refs []string, confirmed bool) {
+	what := refs[0]
+	if len(refs) > 1 {
+		what = fmt.Sprintf("%d stashes", len(refs))
+	}
+	if !confirmed {
+		s.pendingDrop = strings.Join(refs, " ")
+		s.message = fmt.Sprintf("Press d again to drop %s", what)
+		return
+	}
+	for _, ref := range refs {
+		if err := s.git.StashDrop(ref); err != nil {
+			s.message = err.Error()
+			_ = s.reload()
+			return
+		}
+	}
+	if err := s.reload(); err != nil {
+		s.message = err.Error()
+		return
+	}
+	s.message = fmt.Sprintf("Dropped %s", what)
+}
+
+func (s *StashBrowser) render() {
+	c := s.colors
+	clearScreen(s.stdout)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sStashes%s", c.Bold+c.BrightCyan, c.Reset)
+	if len(s.marked) > 0 {
+		fmt.Fprintf(&b, " %s(%d selected)%s", c.BrightGreen, len(s.marked), c.Reset)
+	}
+	b.WriteString("\r\n\r\n")
+	for i, e := range s.entries {
+		marker := "  "
+		if i == s.cursor {
+			marker = c.BrightGreen + "› " + c.Reset
+		}
+		fmt.Fprintf(&b, "%s%s%s%-10s%s %s\r\n", marker, markGlyph(c, s.marked[e.Ref]), c.BrightYellow, e.Ref, c.Reset, e.Message)
+	}
+	if len(s.entries) == 0 {
+		fmt.Fprintf(&b, "%sNo stashes left.%s\r\n", c.BrightBlack, c.Reset)
+	} else {
+		b.WriteString("\r\n")
+		s.renderPreview(&b, s.entries[s.cursor].Ref)
+	}
+	if s.message != "" {
+		fmt.Fprintf(&b, "\r\n%s%s%s\r\n", c.BrightRed, s.message, c.Reset)
+	}
+	fmt.Fprintf(&b, "\r\n%sj/k move · enter/[a]pply · [p]op · [b]ranch · space mark · [d]rop · q quit%s\r\n", c.BrightBlack, c.Reset)
+	_, _ = io.WriteString(s.stdout, b.String())
+}
+
+func (s *StashBrowser) renderPreview(b *strings.Builder, ref string) {
+	c := s.colors
+	lines := s.preview(ref)
+	for i, line := range lines {
+		if i == stashPreviewLines {
+			fmt.Fprintf(b, "%s… %d more line(s)%s\r\n", c.BrightBlack, len(lines)-i, c.Reset)
+			return
+		}
+		fmt.Fprintf(b, "%s%s\r\n", line, c.Reset)
+	}
+}
+
+// preview returns the colored diff of ref, cached per stash. The diff
+// tool renders it when one is configured.
+func (s *StashBrowser) preview(ref string) []string {
+	if lines, ok := s.previews[ref]; ok {
+		return lines
+	}
+	diff, err := s.git.StashDiff(ref)
+	if err != nil {
+		return []string{s.colors.BrightRed + err.Error()}
+	}
+	rendered := difftool.Colorize(diff, s.colors)
+	if s.colors.Reset != "" && s.highlight != nil {
+		if out, ok, err := s.highlight(diff); ok && err == nil {
+			rendered = out
+		}
+	}
+	lines := strings.Split(strings.TrimRight(rendered, "\n"), "\n")
+	s.previews[ref] = lines
+	return lines
+}
@@ -0,0 +1,120 @@
+package interactive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// fakeStashSource serves a fixed stash list and records drops.
+type fakeStashSource struct {
+	refs    []string
+	dropped []string
+}
+
+func (f *fakeStashSource) StashList() (string, error) {
+	var b strings.Builder
+	for _, ref := range f.refs {
+		b.WriteString(ref + ": WIP on main: 1234567 work\n")
+	}
+	return b.String(), nil
+}
+
+func (f *fakeStashSource) StashDiff(stash string) (string, error) {
+	return "diff --git a/a.txt b/a.txt\n+from " + stash + "\n", nil
+}
+
+func (f *fakeStashSource) StashDrop(stash string) error {
+	f.dropped = append(f.dropped, stash)
+	for i, ref := range f.refs {
+		if ref == stash {
+			f.refs = append(f.refs[:i], f.refs[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func newTestStashBrowser(src *fakeStashSource, input string) (*StashBrowser, *bytes.Buffer) {
+	var out bytes.Buffer
+	s := NewStashBrowser(src, nil)
+	s.stdin = strings.NewReader(input)
+	s.stdout = &out
+	s.highlight = nil
+	return s, &out
+}
+
+func TestParseStashList(t *testing.T) {
+	got := ParseStashList("stash@{0}: WIP on main: abc fix\n\nstash@{1}: On dev: tidy\n")
+	if len(got) != 2 || got[0].Ref != "stash@{0}" || got[0].Message != "WIP on main: abc fix" || got[1].Ref != "stash@{1}" {
+		t.Errorf("ParseStashList() = %+v", got)
+	}
+}
+
+func TestStashBrowser_Actions(t *testing.T) {
+	tests := []struct {
+		input string
+		want  StashAction
+	}{
+		{"\r", StashAction{Kind: StashApply, Ref: "stash@{0}"}},
+		{"jp", StashAction{Kind: StashPop, Ref: "stash@{1}"}},
+		{"jjkb", StashAction{Kind: StashBranch, Ref: "stash@{1}"}},
+	}
+	for _, tt := range tests {
+		src := &fakeStashSource{refs: []string{"stash@{0}", "stash@{1}", "stash@{2}"}}
+		s, _ := newTestStashBrowser(src, tt.input)
+		got, ok, err := s.Run()
+		if err != nil || !ok || got != tt.want {
+			t.Errorf("input %q: Run() = %+v, %v, %v", tt.input, got, ok, err)
+		}
+	}
+}
+
+func TestStashBrowser_PreviewAndQuit(t *testing.T) {
+	src := &fakeStashSource{refs: []string{"stash@{0}", "stash@{1}"}}
+	s, out := newTestStashBrowser(src, "jq")
+	if _, ok, _ := s.Run(); ok {
+		t.Error("q should not choose an action")
+	}
+	if !strings.Contains(out.String(), "+from stash@{0}") || !strings.Contains(out.String(), "+from stash@{1}") {
+		t.Errorf("expected diff previews, got %q", out.String())
+	}
+}
+
+func TestStashBrowser_DropNeedsConfirmation(t *testing.T) {
+	src := &fakeStashSource{refs: []string{"stash@{0}", "stash@{1}"}}
+	s, out := newTestStashBrowser(src, "djddq")
+	if _, ok, _ := s.Run(); ok {
+		t.Error("dropping should not end the browser")
+	}
+	if strings.Join(src.dropped, " ") != "stash@{1}" {
+		t.Errorf("dropped %v, want only the confirmed stash", src.dropped)
+	}
+	if !strings.Contains(out.String(), "Press d again to drop stash@{0}") || !strings.Contains(out.String(), "Dropped stash@{1}") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
+
+func TestStashBrowser_DropMarked(t *testing.T) {
+	src := &fakeStashSource{refs: []string{"stash@{0}", "stash@{1}", "stash@{2}"}}
+	s, out := newTestStashBrowser(src, " jj ddq")
+	if _, ok, _ := s.Run(); ok {
+		t.Error("dropping should not end the browser")
+	}
+	if strings.Join(src.dropped, " ") != "stash@{2} stash@{0}" {
+		t.Errorf("dropped %v, want the marked stashes oldest first", src.dropped)
+	}
+	if !strings.Contains(out.String(), "(2 selected)") || !strings.Contains(out.String(), "Dropped 2 stashes") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
+
+func TestStashBrowser_Empty(t *testing.T) {
+	s, out := newTestStashBrowser(&fakeStashSource{}, "")
+	if _, ok, err := s.Run(); ok || err != nil {
+		t.Errorf("Run() ok=%v err=%v", ok, err)
+	}
+	if !strings.Contains(out.String(), "No stashes found") {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -43,6 +43,25 @@ type UIState struct {
 	workflowFocus   WorkflowFocus
 	workflowListIdx int
 	workflowOffset  int
+	resultsOffset   int // first visible result; see state_scroll.go
+	resultsPage     int // results visible in the last frame
+
+	// showPreview toggles the preview pane (Ctrl+/); see render_preview.go.
+	showPreview bool
+
+	// Query undo/redo stacks; see input_undo.go.
+	undoStack     []inputEdit
+	redoStack     []inputEdit
+	lastEditTyped bool
+	editRestored  bool
+
+	// rank returns the frecency of a command; see ui_frecency.go. nil
+	// keeps the registry order and pure match-quality ranking.
+	rank func(command string) float64
+
+	// Multi-select state; see state_selection.go.
+	multiSelect bool
+	marked      map[string]bool
 
 	// History recall (Ctrl+P / Ctrl+N) state. We snapshot the entries
 	// once when recall starts so the user gets a stable view to walk
@@ -119,12 +138,24 @@ func (s *UIState) SetWorkflowListIndex(idx, total int) {
 	s.workflowListIdx = idx
 }
 
-// UpdateFiltered updates the filtered commands based on current input using fuzzy matching
+// UpdateFiltered updates the filtered commands based on current input using fuzzy matching.
+// With ranking personalization on, frequently and recently used commands
+// lead an empty query and break ties between equally good matches.
 func (s *UIState) UpdateFiltered() {
 	input := strings.ToLower(s.input)
+	rank := s.rank
+	if s.historySearchActive {
+		// History search lists entries newest first; keep that order.
+		rank = nil
+	}
 	if input == "" {
 		s.filtered = make([]CommandInfo, len(s.commands))
 		copy(s.filtered, s.commands)
+		if rank != nil && !s.hasSections() {
+			sort.SliceStable(s.filtered, func(i, j int) bool {
+				return rank(s.filtered[i].Command) > rank(s.filtered[j].Command)
+			})
+		}
 	} else {
 		type match struct {
 			info  CommandInfo
@@ -134,6 +165,9 @@ func (s *UIState) UpdateFiltered() {
 		for _, cmd := range s.commands {
 			cmdLower := strings.ToLower(cmd.Command)
 			if ok, score := fuzzyMatchScore(cmdLower, input); ok {
+				if rank != nil {
+					score.frecency = rank(cmd.Command)
+				}
 				matches = append(matches, match{info: cmd, score: score})
 			}
 		}
@@ -145,6 +179,9 @@ func (s *UIState) UpdateFiltered() {
 			s.filtered[i] = match.info
 		}
 	}
+	if !s.historySearchActive {
+		s.groupBySection()
+	}
 	// Reset selection if out of bounds
 	if s.selected >= len(s.filtered) {
 		s.selected = len(s.filtered) - 1
@@ -0,0 +1,65 @@
+package interactive
+
+// The results list scrolls when it outgrows the terminal. The renderer
+// picks the window around the selection on every frame and remembers its
+// size so PgUp/PgDn can move by a page.
+
+// defaultPageSize is used before the first frame has been drawn.
+const defaultPageSize = 10
+
+// resultsWindow returns the [start, end) range of filtered results that
+// fits in rows lines and contains the selection, scrolling as little as
+// possible from the previous window. Section headers take a line each
+// when grouped is set.
+func (s *UIState) resultsWindow(rows int, grouped bool) (start, end int) {
+	if len(s.filtered) == 0 {
+		s.resultsOffset = 0
+		return 0, 0
+	}
+	offset := min(max(s.resultsOffset, 0), len(s.filtered)-1)
+	if s.selected < offset {
+		offset = s.selected
+	}
+	end = s.fitResults(offset, rows, grouped)
+	for s.selected >= end && offset < s.selected {
+		offset++
+		end = s.fitResults(offset, rows, grouped)
+	}
+	s.resultsOffset = offset
+	s.resultsPage = end - offset
+	return offset, end
+}
+
+// fitResults returns the end of the window starting at start.
+func (s *UIState) fitResults(start, rows int, grouped bool) int {
+	used := 0
+	i := start
+	for ; i < len(s.filtered); i++ {
+		n := 1
+		if grouped && (i == start || s.filtered[i].Section != s.filtered[i-1].Section) {
+			n++
+		}
+		if used+n > rows && i > start {
+			break
+		}
+		used += n
+	}
+	return i
+}
+
+func (s *UIState) pageSize() int {
+	if s.resultsPage > 1 {
+		return s.resultsPage - 1
+	}
+	return defaultPageSize
+}
+
+// PageUp moves the selection up by one page of results.
+func (s *UIState) PageUp() {
+	s.selected = max(s.selected-s.pageSize(), 0)
+}
+
+// PageDown moves the selection down by one page of results.
+func (s *UIState) PageDown() {
+	s.selected = max(min(s.selected+s.pageSize(), len(s.filtered)-1), 0)
+}
@@ -0,0 +1,53 @@
+package interactive
+
+import "sort"
+
+// Palette sections group configured commands (pinned ones, or user-defined
+// sections) at the top of the list. With an empty query only the sectioned
+// commands are listed, in their configured order, so they can be picked
+// without typing; with a query every match is shown, sectioned ones first.
+
+// hasSections reports whether any command belongs to a palette section.
+func (s *UIState) hasSections() bool {
+	for _, cmd := range s.commands {
+		if cmd.Section != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// groupBySection reorders the filtered list so each section's matches are
+// contiguous, sections in the order they first appear in the command
+// list and unsectioned commands last. Order within a section is kept.
+func (s *UIState) groupBySection() {
+	if !s.hasSections() {
+		return
+	}
+	order := make(map[string]int)
+	for _, cmd := range s.commands {
+		if _, ok := order[cmd.Section]; !ok && cmd.Section != "" {
+			order[cmd.Section] = len(order)
+		}
+	}
+	order[""] = len(order)
+
+	if s.input == "" {
+		palette := s.filtered[:0]
+		for _, cmd := range s.filtered {
+			if cmd.Section != "" {
+				palette = append(palette, cmd)
+			}
+		}
+		s.filtered = palette
+	}
+	sort.SliceStable(s.filtered, func(i, j int) bool {
+		return order[s.filtered[i].Section] < order[s.filtered[j].Section]
+	})
+}
+
+// ShowsPalette reports whether the palette sections are listed for an
+// empty query, which makes Enter run the highlighted entry.
+func (s *UIState) ShowsPalette() bool {
+	return s.input == "" && !s.historySearchActive && len(s.filtered) > 0 && s.filtered[0].Section != ""
+}
@@ -0,0 +1,90 @@
+package interactive
+
+// Multi-select lets the results list return several items. Marks are keyed
+// by command text, so they survive filtering: an item marked under one
+// query stays marked after the query changes.
+
+// SetMultiSelect turns multi-select mode on or off. Turning it off clears
+// every mark.
+func (s *UIState) SetMultiSelect(on bool) {
+	s.multiSelect = on
+	if !on {
+		s.marked = nil
+	}
+}
+
+// IsMultiSelect reports whether multi-select mode is on.
+func (s *UIState) IsMultiSelect() bool {
+	return s.multiSelect
+}
+
+// ToggleMark marks the highlighted result, or unmarks it when it is
+// already marked. It does nothing outside multi-select mode.
+func (s *UIState) ToggleMark() {
+	cmd := s.GetSelectedCommand()
+	if !s.multiSelect || cmd == nil {
+		return
+	}
+	s.setMark(cmd.Command, !s.marked[cmd.Command])
+}
+
+// ToggleAllMarks marks every visible result, or unmarks them all when
+// they are already marked.
+func (s *UIState) ToggleAllMarks() {
+	if !s.multiSelect || len(s.filtered) == 0 {
+		return
+	}
+	all := true
+	for _, cmd := range s.filtered {
+		if !s.marked[cmd.Command] {
+			all = false
+			break
+		}
+	}
+	for _, cmd := range s.filtered {
+		s.setMark(cmd.Command, !all)
+	}
+}
+
+// InvertMarks marks every visible result that is not marked and unmarks
+// the ones that are.
+func (s *UIState) InvertMarks() {
+	if !s.multiSelect {
+		return
+	}
+	for _, cmd := range s.filtered {
+		s.setMark(cmd.Command, !s.marked[cmd.Command])
+	}
+}
+
+func (s *UIState) setMark(command string, on bool) {
+	if !on {
+		delete(s.marked, command)
+		return
+	}
+	if s.marked == nil {
+		s.marked = make(map[string]bool)
+	}
+	s.marked[command] = true
+}
+
+// IsMarked reports whether command is marked.
+func (s *UIState) IsMarked(command string) bool {
+	return s.marked[command]
+}
+
+// MarkedCount returns how many items are marked, visible or not.
+func (s *UIState) MarkedCount() int {
+	return len(s.marked)
+}
+
+// MarkedCommands returns the marked items in list order.
+func (s *UIState) MarkedCommands() []CommandInfo {
+	var marked []CommandInfo
+	for _, cmd := range s.commands {
+		if s.marked[cmd.Command] {
+			marked = append(marked, cmd)
+		}
+	}
+	return marked
+}
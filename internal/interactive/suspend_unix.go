@@ -0,0 +1,24 @@
+//go:build !windows
+
+package interactive
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// jobControlSignals are the signals the shell's job control sends: a stop
+// request from outside, and the continue after fg or bg.
+var jobControlSignals = []os.Signal{unix.SIGTSTP, unix.SIGCONT}
+
+// isStopRequest reports whether sig asks the process to stop.
+func isStopRequest(sig os.Signal) bool {
+	return sig == unix.SIGTSTP
+}
+
+// raiseStop stops the process group, as the terminal does on Ctrl+Z in
+// cooked mode, and returns once the shell continues it.
+func raiseStop() error {
+	return unix.Kill(0, unix.SIGSTOP)
+}
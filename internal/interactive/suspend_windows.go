@@ -0,0 +1,17 @@
+//go:build windows
+
+package interactive
+
+import (
+	"errors"
+	"os"
+)
+
+// jobControlSignals is empty: Windows consoles have no job control.
+var jobControlSignals []os.Signal
+
+func isStopRequest(os.Signal) bool { return false }
+
+func raiseStop() error {
+	return errors.New("suspending is not supported on Windows")
+}
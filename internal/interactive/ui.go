@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/frecency"
 	"github.com/bmf-san/ggc/v8/internal/git"
 	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
 	"github.com/bmf-san/ggc/v8/internal/termio"
@@ -30,9 +32,17 @@ type UI struct {
 	state           *UIState
 	handler         *KeyHandler
 	colors          *ANSIColors
-	gitStatus       *GitStatus
+	gitStatus       *GitStatus // guarded by statusMu while Run is active
 	gitClient       git.StatusInfoReader
+	profiles        *config.Config // supplies the identity profiles
+	statusMu        sync.Mutex
+	statusSince     time.Time // when the reload in flight started; zero when idle
+	statusInterval  time.Duration
+	refresher       *statusRefresher
+	drawMu          sync.Mutex // serializes key handling and background redraws
+	drawing         bool       // Run's main loop owns the screen
 	reader          *bufio.Reader
+	keys            io.Reader // raw-mode key input; see keyInput
 	profile         kb.Profile
 	workflowMgr     *WorkflowManager
 	workflowEx      *WorkflowExecutor
@@ -41,6 +51,27 @@ type UI struct {
 	errorExpiresAt  time.Time
 	workflowNotice  string
 	noticeExpiresAt time.Time
+	usage           *frecency.Store // nil when ranking personalization is off
+	usageTable      frecency.Table
+	accessible      bool // ui.accessible; see render_accessible.go
+}
+
+// keyInput returns what keys are read from in raw mode: stdin, or on
+// Windows consoles without VT input, a translation of their key events.
+func (ui *UI) keyInput() io.Reader {
+	if ui.keys != nil {
+		return ui.keys
+	}
+	return ui.stdin
+}
+
+// enableAccessible switches to accessible mode: no colors, and plain
+// announcements instead of redrawn frames.
+func (ui *UI) enableAccessible() {
+	ui.accessible = true
+	ui.colors = &ANSIColors{}
+	ui.renderer.colors = ui.colors
+	ui.renderer.accessible = &accessibleView{}
 }
 
 // NewUI creates a new UI with the provided git client, command list, optional
@@ -121,23 +152,38 @@ func NewUI(gitClient git.StatusInfoReader, commands []CommandInfo, cfg *config.C
 		}
 	}
 
+	// Keystrokes bound to two actions only ever trigger one of them, so
+	// say so before the UI takes over the terminal.
+	if conflicts := resolver.Conflicts(profile); len(conflicts) > 0 {
+		for _, c := range conflicts {
+			fmt.Fprintf(os.Stderr, "Warning: keybinding conflict in %s\n", c)
+		}
+		fmt.Fprintln(os.Stderr, "Run 'ggc config keybindings doctor' to see how to fix them.")
+	}
+
 	workflowMgr := NewWorkflowManager()
 	// Load pre-defined workflows from config so they are available immediately
 	// in the workflow panel. LoadFromConfig is a no-op for nil/empty maps.
 	workflowMgr.LoadFromConfig(cfg.Workflows)
 
 	ui := &UI{
-		stdin:       os.Stdin,
-		stdout:      os.Stdout,
-		stderr:      os.Stderr,
-		term:        termio.DefaultTerminal{},
-		renderer:    renderer,
-		state:       state,
-		colors:      colors,
-		gitClient:   gitClient,
-		gitStatus:   getGitStatus(gitClient),
-		profile:     profile,
-		workflowMgr: workflowMgr,
+		stdin:          os.Stdin,
+		stdout:         os.Stdout,
+		stderr:         os.Stderr,
+		term:           termio.DefaultTerminal{},
+		renderer:       renderer,
+		state:          state,
+		colors:         colors,
+		gitClient:      gitClient,
+		profiles:       cfg,
+		statusInterval: statusRefreshFrom(cfg),
+		profile:        profile,
+		workflowMgr:    workflowMgr,
+	}
+
+	ui.enableFrecency(cfg)
+	if cfg.UI.Accessible {
+		ui.enableAccessible()
 	}
 
 	// Keep ContextManager alive via the onContextChange callback so it stays
@@ -149,6 +195,12 @@ func NewUI(gitClient git.StatusInfoReader, commands []CommandInfo, cfg *config.C
 	ui.handler = &KeyHandler{
 		ui:            ui,
 		contextualMap: contextualMap,
+		chordTimeout:  chordTimeoutFrom(cfg),
+		escapeTimeout: escapeTimeoutFrom(cfg, contextualMap.Terminal),
+	}
+	if profile == kb.ProfileVi {
+		viProfile, _ := resolver.GetProfile(kb.ProfileVi)
+		ui.handler.vi = newViState(viProfile)
 	}
 
 	// Set up workflow executor if router is provided
@@ -6,6 +6,15 @@ package interactive
 type CommandInfo struct {
 	Command     string
 	Description string
+	// Section names the palette group the command is listed under, such
+	// as "Pinned". Sectioned commands lead the list in the order they
+	// appear in; the rest follow ungrouped.
+	Section string
+	// Git, Usage and Examples feed the preview pane (Ctrl+/). Git is the
+	// underlying git invocation; all three are optional.
+	Git      string
+	Usage    []string
+	Examples []string
 }
 
 // extractPlaceholders extracts <...> placeholders from a string
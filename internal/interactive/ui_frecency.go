@@ -0,0 +1,41 @@
+package interactive
+
+import (
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/frecency"
+)
+
+// newUsageStore returns the store backing ranking personalization.
+// Tests swap it to keep the real per-user file untouched.
+var newUsageStore = func() *frecency.Store { return &frecency.Store{} }
+
+// enableFrecency loads the usage table and ranks the command list with it
+// unless interactive.frecency is false. An unreadable table leaves ranking
+// off for this session; the next pick rewrites it.
+func (ui *UI) enableFrecency(cfg *config.Config) {
+	if cfg != nil && cfg.Interactive.Frecency != nil && !*cfg.Interactive.Frecency {
+		return
+	}
+	ui.usage = newUsageStore()
+	table, err := ui.usage.Load()
+	if err != nil {
+		table = frecency.Table{}
+	}
+	ui.usageTable = table
+	ui.state.rank = func(command string) float64 {
+		return table.Score(command, time.Now())
+	}
+}
+
+// recordUsage counts one pick of command. Failures are ignored: ranking is
+// a convenience and must never get in the way of running the command.
+func (ui *UI) recordUsage(command string) {
+	if ui.usage == nil {
+		return
+	}
+	now := time.Now()
+	ui.usageTable.Add(command, now)
+	_ = ui.usage.Record(command, now)
+}
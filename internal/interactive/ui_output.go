@@ -12,19 +12,32 @@ func (ui *UI) writeError(format string, a ...interface{}) {
 
 // write writes a message to stdout
 func (ui *UI) write(format string, a ...interface{}) {
-	_, _ = fmt.Fprintf(ui.stdout, format, a...)
+	ui.writeColor(fmt.Sprintf(format, a...))
 }
 
 // writeColor writes a colored message to stdout
 func (ui *UI) writeColor(text string) {
+	if ui.accessible {
+		text = plainText(text)
+	}
 	_, _ = fmt.Fprint(ui.stdout, text)
 }
 
 // writeln writes a message with newline to stdout
 func (ui *UI) writeln(format string, a ...interface{}) {
 	// Move to line start, clear line, write content, then CRLF
-	_, _ = fmt.Fprint(ui.stdout, "\r\x1b[K")
-	_, _ = fmt.Fprintf(ui.stdout, format+"\r\n", a...)
+	if !ui.accessible {
+		_, _ = fmt.Fprint(ui.stdout, "\r\x1b[K")
+	}
+	ui.writeColor(fmt.Sprintf(format+"\r\n", a...))
+}
+
+// clearScreen clears the screen for a command's output. Accessible mode
+// keeps what was written, so output only ever goes down the screen.
+func (ui *UI) clearScreen() {
+	if !ui.accessible {
+		clearScreen(ui.stdout)
+	}
 }
 
 // notifySoftCancel sets the soft cancel flash notification
@@ -7,6 +7,8 @@ import (
 	"os"
 
 	"golang.org/x/term"
+
+	"github.com/bmf-san/ggc/v8/internal/termio"
 )
 
 // setupTerminal configures terminal raw mode and returns the old state and error status
@@ -41,11 +43,29 @@ func (ui *UI) Run() []string {
 	// Set up terminal restoration for raw mode
 	if f, ok := ui.stdin.(*os.File); ok && isRawMode {
 		fd := int(f.Fd())
+		if ui.keys == nil {
+			ui.keys = termio.NewInput(f)
+		}
+		if !ui.accessible {
+			enableBracketedPaste(ui.stdout)
+		}
 		defer func() {
+			if !ui.accessible {
+				disableBracketedPaste(ui.stdout)
+			}
 			if err := ui.term.Restore(fd, oldState); err != nil {
 				ui.writeError("failed to restore terminal state: %v", err)
 			}
 		}()
+		stopWatching := ui.watchJobControl(oldState)
+		defer stopWatching()
+	}
+
+	if isRawMode {
+		stop := ui.startStatusRefresh()
+		defer stop()
+	} else {
+		ui.refreshGitStatus()
 	}
 
 	return ui.runMainLoop(reader, isRawMode, oldState)
@@ -74,17 +94,28 @@ func (ui *UI) initializeTerminal() (*term.State, *bufio.Reader, bool) {
 func (ui *UI) runMainLoop(reader *bufio.Reader, isRawMode bool, oldState *term.State) []string {
 	if isRawMode {
 		if ui.reader == nil {
-			ui.reader = bufio.NewReader(ui.stdin)
+			ui.reader = bufio.NewReader(ui.keyInput())
 		}
 	} else {
 		ui.reader = reader
 	}
 
+	// The screen belongs to this loop except while it waits for a key,
+	// when the status refresher may redraw it.
+	ui.drawMu.Lock()
+	ui.drawing = true
+	defer func() {
+		ui.drawing = false
+		ui.drawMu.Unlock()
+	}()
+
 	for {
 		ui.state.UpdateFiltered()
 		ui.renderer.Render(ui, ui.state)
 
+		ui.drawMu.Unlock()
 		r, err := ui.readNextRune(reader, isRawMode)
+		ui.drawMu.Lock()
 		if err != nil {
 			if errors.Is(err, io.EOF) {
 				return nil
@@ -107,7 +138,7 @@ func (ui *UI) readNextRune(reader *bufio.Reader, isRawMode bool) (rune, error) {
 		for {
 			// Read single byte directly from stdin in raw mode
 			var buf [1]byte
-			n, readErr := ui.stdin.Read(buf[:])
+			n, readErr := ui.keyInput().Read(buf[:])
 
 			if readErr != nil {
 				return 0, readErr
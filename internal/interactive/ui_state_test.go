@@ -1,6 +1,7 @@
 package interactive
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"unicode/utf8"
@@ -217,3 +218,164 @@ func TestUIStateMoveWordBoundaries(t *testing.T) {
 		t.Fatalf("cursor should remain at end, got %d", state.cursorPos)
 	}
 }
+
+func TestUIStateMultiSelect(t *testing.T) {
+	state := &UIState{commands: []CommandInfo{{Command: "main"}, {Command: "feature/a"}, {Command: "feature/b"}}}
+	state.UpdateFiltered()
+
+	state.ToggleMark()
+	if state.MarkedCount() != 0 {
+		t.Fatal("ToggleMark should do nothing outside multi-select mode")
+	}
+
+	state.SetMultiSelect(true)
+	state.ToggleMark()
+	state.MoveDown()
+	state.MoveDown()
+	state.ToggleMark()
+	if got := state.MarkedCommands(); len(got) != 2 || got[0].Command != "main" || got[1].Command != "feature/b" {
+		t.Errorf("MarkedCommands() = %v", got)
+	}
+
+	// Marks survive filtering, and ToggleAllMarks works on the visible items.
+	state.input = "feat"
+	state.UpdateFiltered()
+	state.ToggleAllMarks()
+	if state.MarkedCount() != 3 || !state.IsMarked("main") {
+		t.Errorf("expected every item marked, got %d", state.MarkedCount())
+	}
+	state.ToggleAllMarks()
+	if state.MarkedCount() != 1 || !state.IsMarked("main") {
+		t.Errorf("expected only the hidden mark to remain, got %v", state.MarkedCommands())
+	}
+
+	state.SetMultiSelect(false)
+	if state.MarkedCount() != 0 {
+		t.Error("turning multi-select off should clear marks")
+	}
+}
+
+func TestUIStateFrecencyRanking(t *testing.T) {
+	usage := map[string]float64{"push current": 8, "pull rebase": 2}
+	state := &UIState{
+		commands: []CommandInfo{{Command: "pull current"}, {Command: "pull rebase"}, {Command: "push current"}, {Command: "status"}},
+		rank:     func(cmd string) float64 { return usage[cmd] },
+	}
+
+	commands := func() string {
+		var names []string
+		for _, c := range state.filtered {
+			names = append(names, c.Command)
+		}
+		return strings.Join(names, ", ")
+	}
+
+	state.UpdateFiltered()
+	if got, want := commands(), "push current, pull rebase, pull current, status"; got != want {
+		t.Errorf("empty query order = %q, want %q", got, want)
+	}
+
+	// Usage breaks ties between equally tight matches...
+	state.input = "pu"
+	state.UpdateFiltered()
+	if got, want := commands(), "push current, pull rebase, pull current"; got != want {
+		t.Errorf("tied match order = %q, want %q", got, want)
+	}
+
+	// ...but never beats a better match.
+	state.input = "pull"
+	state.UpdateFiltered()
+	if got := state.filtered[0].Command; got != "pull rebase" {
+		t.Errorf("first match = %q, want pull rebase", got)
+	}
+
+	state.rank = nil
+	state.input = ""
+	state.UpdateFiltered()
+	if got, want := commands(), "pull current, pull rebase, push current, status"; got != want {
+		t.Errorf("without ranking order = %q, want %q", got, want)
+	}
+}
+
+func TestUIStatePaletteSections(t *testing.T) {
+	state := &UIState{
+		commands: []CommandInfo{
+			{Command: "pull current"},
+			{Command: "push current", Section: "Pinned"},
+			{Command: "status"},
+			{Command: "fetch prune", Section: "Sync"},
+		},
+		rank: func(cmd string) float64 { return map[string]float64{"status": 5}[cmd] },
+	}
+
+	commands := func() string {
+		var names []string
+		for _, c := range state.filtered {
+			names = append(names, c.Command)
+		}
+		return strings.Join(names, ", ")
+	}
+
+	state.UpdateFiltered()
+	if got, want := commands(), "push current, fetch prune"; got != want {
+		t.Errorf("empty query = %q, want only the palette %q", got, want)
+	}
+	if !state.ShowsPalette() {
+		t.Error("expected the palette to be shown for an empty query")
+	}
+
+	state.input = "u"
+	state.UpdateFiltered()
+	if got, want := commands(), "push current, fetch prune, pull current, status"; got != want {
+		t.Errorf("query order = %q, want %q", got, want)
+	}
+	if state.ShowsPalette() {
+		t.Error("the palette should not be shown while searching")
+	}
+}
+
+func TestUIStateResultsWindow(t *testing.T) {
+	state := &UIState{}
+	for i := range 20 {
+		state.filtered = append(state.filtered, CommandInfo{Command: fmt.Sprintf("cmd%d", i)})
+	}
+
+	if start, end := state.resultsWindow(5, false); start != 0 || end != 5 {
+		t.Fatalf("initial window = [%d, %d)", start, end)
+	}
+
+	// Moving past the bottom scrolls by one row, not a whole page.
+	state.selected = 5
+	if start, end := state.resultsWindow(5, false); start != 1 || end != 6 {
+		t.Errorf("window after moving down = [%d, %d)", start, end)
+	}
+
+	// Moving back up within the window does not scroll.
+	state.selected = 3
+	if start, _ := state.resultsWindow(5, false); start != 1 {
+		t.Errorf("window start = %d, want 1", start)
+	}
+
+	state.PageDown()
+	if state.selected != 7 {
+		t.Errorf("PageDown selected = %d, want 7", state.selected)
+	}
+	state.selected = 18
+	state.PageDown()
+	if state.selected != 19 {
+		t.Errorf("PageDown should stop at the last result, got %d", state.selected)
+	}
+	state.selected = 2
+	state.PageUp()
+	if state.selected != 0 {
+		t.Errorf("PageUp should stop at the first result, got %d", state.selected)
+	}
+
+	// Section headers take a row each.
+	state = &UIState{filtered: []CommandInfo{
+		{Command: "a", Section: "Pinned"}, {Command: "b", Section: "Pinned"}, {Command: "c"}, {Command: "d"},
+	}}
+	if _, end := state.resultsWindow(4, true); end != 2 {
+		t.Errorf("grouped window end = %d, want 2", end)
+	}
+}
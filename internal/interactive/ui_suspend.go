@@ -0,0 +1,95 @@
+package interactive
+
+import (
+	"os"
+	"os/signal"
+
+	"golang.org/x/term"
+)
+
+// stopProcess stops ggc until the shell continues it.
+var stopProcess = raiseStop
+
+// suspend hands the terminal back to the shell and stops ggc, as Ctrl+Z
+// does in a cooked terminal. Once the shell continues it, raw mode is
+// entered again and the status reloaded, since the repository may have
+// changed in the meantime; the caller redraws the screen.
+func (ui *UI) suspend(oldState *term.State) {
+	f, ok := ui.stdin.(*os.File)
+	if !ok || oldState == nil || len(jobControlSignals) == 0 {
+		return
+	}
+	fd := int(f.Fd())
+
+	disableBracketedPaste(ui.stdout)
+	ui.write("\r\n")
+	if err := ui.term.Restore(fd, oldState); err != nil {
+		ui.writeError("failed to restore terminal state: %v", err)
+	}
+
+	stopErr := stopProcess()
+
+	if _, err := ui.term.MakeRaw(fd); err != nil {
+		ui.writeError("failed to set terminal to raw mode: %v", err)
+	}
+	enableBracketedPaste(ui.stdout)
+	if stopErr != nil {
+		ui.writeError("failed to suspend: %v", stopErr)
+		return
+	}
+	ui.requestStatusRefresh()
+}
+
+// resume enters raw mode again after the process was stopped from
+// outside, such as by kill -STOP, which leaves the terminal to the shell.
+func (ui *UI) resume() {
+	f, ok := ui.stdin.(*os.File)
+	if !ok {
+		return
+	}
+	if _, err := ui.term.MakeRaw(int(f.Fd())); err != nil {
+		ui.writeError("failed to set terminal to raw mode: %v", err)
+	}
+	enableBracketedPaste(ui.stdout)
+	ui.requestStatusRefresh()
+}
+
+// watchJobControl handles the job control signals while Run owns the
+// terminal in raw mode, where Ctrl+Z does not raise them: a stop request
+// suspends ggc cleanly instead of leaving the shell a raw terminal, and a
+// continue restores raw mode and redraws. It returns the function that
+// stops watching.
+func (ui *UI) watchJobControl(oldState *term.State) func() {
+	if len(jobControlSignals) == 0 {
+		return func() {}
+	}
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, jobControlSignals...)
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		for {
+			select {
+			case <-done:
+				return
+			case sig := <-sigs:
+				ui.drawMu.Lock()
+				if ui.drawing {
+					if isStopRequest(sig) {
+						ui.suspend(oldState)
+					} else {
+						ui.resume()
+					}
+					ui.renderer.Render(ui, ui.state)
+				}
+				ui.drawMu.Unlock()
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigs)
+		close(done)
+		<-finished
+	}
+}
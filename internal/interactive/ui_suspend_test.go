@@ -0,0 +1,125 @@
+//go:build !windows
+
+package interactive
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/term"
+
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+)
+
+// recordingTerminal records the raw mode changes made to it.
+type recordingTerminal struct {
+	calls *[]string
+}
+
+func (r recordingTerminal) MakeRaw(int) (*term.State, error) {
+	*r.calls = append(*r.calls, "raw")
+	return &term.State{}, nil
+}
+
+func (r recordingTerminal) Restore(int, *term.State) error {
+	*r.calls = append(*r.calls, "restore")
+	return nil
+}
+
+func newSuspendTestUI(t *testing.T) (*UI, *[]string, *bytes.Buffer) {
+	t.Helper()
+	stdin, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = stdin.Close(); _ = w.Close() })
+	var calls []string
+	out := &bytes.Buffer{}
+	return &UI{stdin: stdin, stdout: out, stderr: out, term: recordingTerminal{&calls}, colors: NewANSIColors()}, &calls, out
+}
+
+func TestUISuspend(t *testing.T) {
+	ui, calls, out := newSuspendTestUI(t)
+	prev := stopProcess
+	defer func() { stopProcess = prev }()
+	stopProcess = func() error {
+		*calls = append(*calls, "stop")
+		return nil
+	}
+
+	ui.suspend(&term.State{})
+	if got, want := strings.Join(*calls, ","), "restore,stop,raw"; got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+	// Bracketed paste is off while the shell has the terminal and back on
+	// afterwards.
+	if disable, enable := strings.Index(out.String(), "\x1b[?2004l"), strings.LastIndex(out.String(), "\x1b[?2004h"); disable < 0 || enable < disable {
+		t.Errorf("bracketed paste not toggled around the stop: %q", out.String())
+	}
+}
+
+func TestUISuspendFailure(t *testing.T) {
+	ui, calls, out := newSuspendTestUI(t)
+	prev := stopProcess
+	defer func() { stopProcess = prev }()
+	stopProcess = func() error { return errors.New("no job control") }
+
+	ui.suspend(&term.State{})
+	if got, want := strings.Join(*calls, ","), "restore,raw"; got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+	if !strings.Contains(out.String(), "failed to suspend: no job control") {
+		t.Errorf("output lacks the error: %q", out.String())
+	}
+}
+
+func TestUISuspendWithoutRawMode(t *testing.T) {
+	ui, calls, _ := newSuspendTestUI(t)
+	prev := stopProcess
+	defer func() { stopProcess = prev }()
+	stopProcess = func() error {
+		t.Error("stopped without raw mode")
+		return nil
+	}
+
+	ui.suspend(nil)
+	if len(*calls) != 0 {
+		t.Errorf("calls = %v, want none", *calls)
+	}
+}
+
+func TestSuspendKey(t *testing.T) {
+	ui := newUIWithKeyMap(kb.DefaultKeyBindingMap())
+	stdin, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = stdin.Close(); _ = w.Close() }()
+	var calls []string
+	ui.stdin = stdin
+	ui.stdout = &bytes.Buffer{}
+	ui.stderr = ui.stdout
+	ui.term = recordingTerminal{&calls}
+	prev := stopProcess
+	defer func() { stopProcess = prev }()
+	stopProcess = func() error {
+		calls = append(calls, "stop")
+		return nil
+	}
+
+	for _, workflow := range []bool{false, true} {
+		calls = nil
+		if workflow {
+			ui.state.SetMode(ModeWorkflow)
+		}
+		if cont, _ := ui.handler.HandleKey(26, true, &term.State{}, nil); !cont { // Ctrl+Z
+			t.Fatal("Ctrl+Z ended the UI")
+		}
+		if got := strings.Join(calls, ","); got != "restore,stop,raw" {
+			t.Errorf("workflow mode %v: calls = %s, want a suspend", workflow, got)
+		}
+	}
+}
@@ -35,7 +35,18 @@ func (ui *UI) ExecuteWorkflow() error {
 		return fmt.Errorf("workflow is empty")
 	}
 
-	return ui.workflowEx.Execute(wf)
+	return ui.workflowEx.ExecuteNamed(ui.activeWorkflowName(), wf)
+}
+
+// activeWorkflowName returns the name of the active workflow, if it has one.
+func (ui *UI) activeWorkflowName() string {
+	activeID := ui.workflowMgr.GetActiveID()
+	for _, summary := range ui.workflowMgr.ListWorkflows() {
+		if summary.ID == activeID {
+			return summary.Name
+		}
+	}
+	return ""
 }
 
 // activeWorkflow returns the currently active workflow, or nil if none exists.
@@ -21,16 +21,20 @@ func NewWorkflow() *Workflow {
 
 // AddStep adds a step to the workflow
 func (w *Workflow) AddStep(command string, args []string, description string) int {
-	w.mutex.Lock()
-	defer w.mutex.Unlock()
-
-	step := WorkflowStep{
-		ID:          w.nextID,
+	return w.AddStepWithOptions(WorkflowStep{
 		Command:     command,
 		Args:        args,
 		Description: description,
-	}
+	})
+}
+
+// AddStepWithOptions adds step, with its when, confirm and
+// continue-on-error options, to the workflow. Its ID is assigned here.
+func (w *Workflow) AddStepWithOptions(step WorkflowStep) int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
 
+	step.ID = w.nextID
 	w.steps = append(w.steps, step)
 	id := w.nextID
 	w.nextID++
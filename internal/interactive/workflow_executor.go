@@ -3,7 +3,15 @@ package interactive
 import (
 	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/git"
+	"github.com/bmf-san/ggc/v8/internal/selfexec"
+	"github.com/bmf-san/ggc/v8/internal/stats"
+	"github.com/bmf-san/ggc/v8/internal/transcript"
 )
 
 // CommandRouter represents an interface for routing commands
@@ -15,17 +23,87 @@ type CommandRouter interface {
 type WorkflowExecutor struct {
 	router CommandRouter
 	ui     *UI
+	// status reports the repository state the steps' when conditions are
+	// evaluated against; nil outside a repository.
+	status func() *GitStatus
+	// runParallel runs one step of a parallel group, writing its output
+	// to out. By default it runs ggc as a separate process, so that the
+	// steps do not share the state of this one.
+	runParallel func(args []string, out io.Writer) error
+	// transcripts records each run; nil records nothing.
+	transcripts *transcript.Store
 }
 
+// newTranscriptStore returns the store workflow runs are recorded in.
+var newTranscriptStore = func() *transcript.Store { return &transcript.Store{} }
+
 // ErrWorkflowCanceled indicates the workflow was aborted by the user via soft cancel.
 var ErrWorkflowCanceled = errors.New("workflow canceled")
 
+// stepStatus is how a workflow step ended.
+type stepStatus int
+
+const (
+	stepOK stepStatus = iota
+	stepSkipped
+	stepFailed
+)
+
+// stepResult is the outcome of one workflow step, for the summary.
+type stepResult struct {
+	step WorkflowStep
+	// label names the step in the summary.
+	label  string
+	status stepStatus
+	reason string
+	// args is what the step ran, with its placeholders filled in.
+	args     []string
+	exitCode int
+	duration time.Duration
+	// output is what the step printed, when it was captured.
+	output string
+}
+
 // NewWorkflowExecutor creates a new workflow executor
 func NewWorkflowExecutor(router CommandRouter, ui *UI) *WorkflowExecutor {
-	return &WorkflowExecutor{
+	we := &WorkflowExecutor{
 		router: router,
 		ui:     ui,
 	}
+	we.status = we.currentStatus
+	we.runParallel = func(args []string, out io.Writer) error { return selfexec.Run("", args, out) }
+	we.transcripts = newTranscriptStore()
+	return we
+}
+
+// WithStatusReader evaluates the steps' when conditions against the
+// repository client reads, for an executor that runs without a UI.
+func (we *WorkflowExecutor) WithStatusReader(client git.StatusInfoReader) *WorkflowExecutor {
+	we.status = func() *GitStatus { return freshStatus(client) }
+	return we
+}
+
+// WithTranscripts records the runs in store; nil records nothing.
+func (we *WorkflowExecutor) WithTranscripts(store *transcript.Store) *WorkflowExecutor {
+	we.transcripts = store
+	return we
+}
+
+// currentStatus reads the repository state afresh, since earlier steps
+// may have changed it.
+func (we *WorkflowExecutor) currentStatus() *GitStatus {
+	if we.ui == nil || we.ui.gitClient == nil {
+		return nil
+	}
+	return freshStatus(we.ui.gitClient)
+}
+
+// freshStatus reads the status of client, bypassing its cache.
+func freshStatus(client git.StatusInfoReader) *GitStatus {
+	if inv, ok := client.(git.StatusCacheInvalidator); ok {
+		inv.InvalidateStatusCache()
+	}
+	return getGitStatus(client)
 }
 
 // uiWrite writes to the UI stdout when the UI is available; otherwise falls back to fmt.Printf.
@@ -38,8 +116,18 @@ func (we *WorkflowExecutor) uiWrite(format string, a ...interface{}) {
 	_, _ = fmt.Printf(format, a...)
 }
 
-// Execute runs all steps in the workflow sequentially
+// Execute runs the steps of the workflow in order. A step whose when
+// condition does not hold, or whose confirmation is declined, is skipped.
+// A failed step stops the workflow unless it continues on error. A
+// summary of every step is printed at the end, and the run is recorded
+// in a transcript.
 func (we *WorkflowExecutor) Execute(workflow *Workflow) error {
+	return we.ExecuteNamed("", workflow)
+}
+
+// ExecuteNamed runs the workflow like Execute and records its transcript
+// under name.
+func (we *WorkflowExecutor) ExecuteNamed(name string, workflow *Workflow) error {
 	steps := workflow.GetSteps()
 
 	if len(steps) == 0 {
@@ -48,38 +136,227 @@ func (we *WorkflowExecutor) Execute(workflow *Workflow) error {
 
 	we.uiWrite("🚀 Starting workflow execution (%d steps)\n\n", len(steps))
 
+	started := time.Now()
+	results := make([]stepResult, 0, len(steps))
+	var stepErr error
 	for i, step := range steps {
 		we.uiWrite("📋 Step %d/%d: %s\n", i+1, len(steps), step.String())
 
-		// Resolve placeholders in each argument individually to preserve multiword values
-		resolvedArgs, canceled := resolveStepPlaceholders(we.ui, step)
-		if canceled {
+		var err error
+		if len(step.Parallel) > 0 {
+			var group []stepResult
+			group, err = we.runGroup(i+1, step)
+			results = append(results, group...)
+		} else {
+			var result stepResult
+			result, err = we.runStep(i+1, step)
+			results = append(results, result)
+		}
+		if errors.Is(err, ErrWorkflowCanceled) {
+			we.saveTranscript(name, started, results, true)
 			return ErrWorkflowCanceled
 		}
 
-		// Build parts array: command + resolved args
-		parts := append([]string{step.Command}, resolvedArgs...)
+		if err != nil && !step.ContinueOnError {
+			stepErr = fmt.Errorf("step %d/%d failed: %w", i+1, len(steps), err)
+			for _, rest := range steps[i+1:] {
+				results = append(results, stepResult{step: rest, label: rest.String(), status: stepSkipped, reason: fmt.Sprintf("step %d failed", i+1)})
+			}
+			break
+		}
 
-		if parts[0] == "" {
-			continue
+		// Add separator between steps (except for the last one)
+		if i < len(steps)-1 {
+			we.uiWrite("─────────────────────────────────────\n")
 		}
+	}
 
-		// Show resolved command
-		we.uiWrite("   → Resolved to: %s\n", strings.Join(parts, " "))
+	executed := we.printSummary(results)
+	we.saveTranscript(name, started, results, false)
+	if stepErr != nil {
+		return stepErr
+	}
 
-		// Execute the resolved command and propagate any routing error
-		if err := we.router.Route(parts); err != nil {
-			return fmt.Errorf("step %d/%d failed: %w", i+1, len(steps), err)
+	we.uiWrite("\n🎉 Workflow completed successfully! (%d steps executed)\n", executed)
+	_ = stats.RecordWorkflow(executed)
+	return nil
+}
+
+// runStep checks the step's condition and confirmation and runs it. The
+// error is the step's failure, or ErrWorkflowCanceled.
+func (we *WorkflowExecutor) runStep(n int, step WorkflowStep) (stepResult, error) {
+	result := stepResult{step: step, label: step.String(), status: stepSkipped}
+
+	if reason, err := we.checkStep(step); err != nil {
+		if errors.Is(err, ErrWorkflowCanceled) {
+			return result, err
 		}
+		return we.stepFailed(result, err)
+	} else if reason != "" {
+		result.reason = reason
+		we.uiWrite("⏭️  Skipped: %s\n", result.reason)
+		return result, nil
+	}
 
-		we.uiWrite("✅ Step %d completed successfully\n", i+1)
+	// Resolve placeholders in each argument individually to preserve multiword values
+	resolvedArgs, canceled := resolveStepPlaceholders(we.ui, step)
+	if canceled {
+		return result, ErrWorkflowCanceled
+	}
 
-		// Add separator between steps (except for the last one)
-		if i < len(steps)-1 {
-			we.uiWrite("─────────────────────────────────────\n")
+	// Build parts array: command + resolved args
+	parts := append([]string{step.Command}, resolvedArgs...)
+
+	if parts[0] == "" {
+		result.reason = "no command"
+		return result, nil
+	}
+
+	// Show resolved command
+	we.uiWrite("   → Resolved to: %s\n", strings.Join(parts, " "))
+
+	// Execute the resolved command and propagate any routing error
+	result.args = parts
+	start := time.Now()
+	err := we.router.Route(parts)
+	result.duration = time.Since(start)
+	if err != nil {
+		return we.stepFailed(result, err)
+	}
+
+	we.uiWrite("✅ Step %d completed successfully\n", n)
+	result.status = stepOK
+	return result, nil
+}
+
+// checkStep evaluates the step's when condition and asks for its
+// confirmation. It returns why the step is skipped, or "" to run it. The
+// error is a condition that cannot be evaluated, or ErrWorkflowCanceled.
+func (we *WorkflowExecutor) checkStep(step WorkflowStep) (string, error) {
+	if step.When != "" {
+		holds, err := we.conditionHolds(step.When)
+		if err != nil {
+			return "", err
+		}
+		if !holds {
+			return fmt.Sprintf("%s is false", step.When), nil
 		}
 	}
 
-	we.uiWrite("\n🎉 Workflow completed successfully! (%d steps executed)\n", len(steps))
-	return nil
+	if step.Confirm {
+		ok, canceled := confirmStep(we.ui, step)
+		if canceled {
+			return "", ErrWorkflowCanceled
+		}
+		if !ok {
+			return "not confirmed", nil
+		}
+	}
+	return "", nil
+}
+
+// stepFailed records err as the failure of the step.
+func (we *WorkflowExecutor) stepFailed(result stepResult, err error) (stepResult, error) {
+	result.status = stepFailed
+	result.reason = err.Error()
+	result.exitCode = exitCode(err)
+	if result.step.ContinueOnError {
+		we.uiWrite("⚠️  Step failed, continuing: %v\n", err)
+	}
+	return result, err
+}
+
+// conditionHolds evaluates a step's when against the repository state.
+func (we *WorkflowExecutor) conditionHolds(when string) (bool, error) {
+	cond, err := config.ParseWorkflowCondition(when)
+	if err != nil {
+		return false, err
+	}
+	status := we.status()
+	if status == nil {
+		return false, fmt.Errorf("cannot evaluate %q outside a git repository", when)
+	}
+	return cond.Holds(config.RepoState{
+		Branch:   status.Branch,
+		Staged:   status.Staged,
+		Unstaged: status.Modified,
+		Ahead:    status.Ahead,
+		Behind:   status.Behind,
+	}), nil
+}
+
+// printSummary prints the status of every step and returns how many ran.
+func (we *WorkflowExecutor) printSummary(results []stepResult) int {
+	var ok, skipped, failed int
+	we.uiWrite("\n📊 Summary\n")
+	for _, r := range results {
+		switch r.status {
+		case stepOK:
+			ok++
+			we.uiWrite("   ok       %s\n", r.label)
+		case stepSkipped:
+			skipped++
+			we.uiWrite("   skipped  %s: %s\n", r.label, r.reason)
+		case stepFailed:
+			failed++
+			we.uiWrite("   failed   %s: %s\n", r.label, r.reason)
+		}
+	}
+	we.uiWrite("   %d ok, %d skipped, %d failed\n", ok, skipped, failed)
+	return ok + failed
+}
+
+// exitCode is the exit status err stands for: that of a process that
+// exited, and 1 otherwise.
+func exitCode(err error) int {
+	var exited interface{ ExitCode() int }
+	if errors.As(err, &exited) && exited.ExitCode() > 0 {
+		return exited.ExitCode()
+	}
+	return 1
+}
+
+// saveTranscript records the run in the transcript store and says where.
+func (we *WorkflowExecutor) saveTranscript(name string, started time.Time, results []stepResult, canceled bool) {
+	if we.transcripts == nil {
+		return
+	}
+	run := &transcript.Run{
+		Workflow:   name,
+		Started:    started,
+		DurationMS: time.Since(started).Milliseconds(),
+		Canceled:   canceled,
+		Steps:      make([]transcript.Step, 0, len(results)),
+	}
+	for _, r := range results {
+		args := r.args
+		if args == nil && r.step.Command != "" {
+			args = append([]string{r.step.Command}, r.step.Args...)
+		}
+		step := transcript.Step{
+			Label:           r.label,
+			Args:            args,
+			When:            r.step.When,
+			Confirm:         r.step.Confirm,
+			ContinueOnError: r.step.ContinueOnError,
+			Status:          transcript.StatusOK,
+			Reason:          r.reason,
+			ExitCode:        r.exitCode,
+			DurationMS:      r.duration.Milliseconds(),
+			Output:          r.output,
+		}
+		switch r.status {
+		case stepSkipped:
+			step.Status = transcript.StatusSkipped
+		case stepFailed:
+			step.Status = transcript.StatusFailed
+		}
+		run.Steps = append(run.Steps, step)
+	}
+	path, err := we.transcripts.Save(run)
+	if err != nil {
+		we.uiWrite("⚠️  Could not save the transcript: %v\n", err)
+		return
+	}
+	we.uiWrite("📝 Transcript: %s\n", path)
 }
@@ -5,6 +5,8 @@ import (
 	"sort"
 	"strings"
 	"sync"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
 )
 
 // WorkflowSummary describes a workflow for listing/selection purposes.
@@ -209,7 +211,7 @@ func (m *WorkflowManager) CloneWorkflow(id int, name string) (int, bool) {
 
 	clone := NewWorkflow()
 	for _, step := range steps {
-		clone.AddStep(step.Command, append([]string(nil), step.Args...), step.Description)
+		clone.AddStepWithOptions(cloneStep(step))
 	}
 
 	m.mutex.Lock()
@@ -221,6 +223,19 @@ func (m *WorkflowManager) CloneWorkflow(id int, name string) (int, bool) {
 	return newID, true
 }
 
+// cloneStep copies step so that the copy shares no slices with it.
+func cloneStep(step WorkflowStep) WorkflowStep {
+	step.Args = append([]string(nil), step.Args...)
+	if step.Parallel != nil {
+		group := make([]WorkflowStep, len(step.Parallel))
+		for i, sub := range step.Parallel {
+			group[i] = cloneStep(sub)
+		}
+		step.Parallel = group
+	}
+	return step
+}
+
 // ClearWorkflow removes all steps from the specified workflow.
 func (m *WorkflowManager) ClearWorkflow(id int) bool {
 	m.mutex.RLock()
@@ -233,10 +248,28 @@ func (m *WorkflowManager) ClearWorkflow(id int) bool {
 	return true
 }
 
+// stepFromConfig makes a step of a configured command string; ok is false
+// when run is blank.
+func stepFromConfig(run, when string, continueOnError bool) (step WorkflowStep, ok bool) {
+	parts := strings.Fields(run)
+	if len(parts) == 0 {
+		return step, false
+	}
+	return WorkflowStep{
+		Command:         parts[0],
+		Args:            parts[1:],
+		Description:     run,
+		When:            when,
+		ContinueOnError: continueOnError,
+	}, true
+}
+
 // LoadFromConfig registers pre-defined workflows from the config's workflows
-// section. Each map key becomes the workflow name; each string in the slice
+// section. Each map key becomes the workflow name; each step's command string
 // becomes a step where the first whitespace-delimited token is the command and
-// the remainder are arguments. Interactive placeholder syntax (<name>) is
+// the remainder are arguments, keeping the step's when, confirm and
+// continue-on-error options. A parallel group becomes one step whose
+// Parallel holds the group's steps. Interactive placeholder syntax (<name>) is
 // supported and preserved in the step description.
 //
 // Workflows are inserted in alphabetical order by name so that the order shown
@@ -244,7 +277,7 @@ func (m *WorkflowManager) ClearWorkflow(id int) bool {
 //
 // The scratch workflow that was active before the call is restored as active
 // afterwards so the user can start typing immediately in the UI.
-func (m *WorkflowManager) LoadFromConfig(workflows map[string][]string) {
+func (m *WorkflowManager) LoadFromConfig(workflows map[string][]config.WorkflowStep) {
 	if len(workflows) == 0 {
 		return
 	}
@@ -267,12 +300,28 @@ func (m *WorkflowManager) LoadFromConfig(workflows map[string][]string) {
 	for _, name := range names {
 		steps := workflows[name]
 		wf := NewWorkflow()
-		for _, cmdStr := range steps {
-			parts := strings.Fields(cmdStr)
-			if len(parts) == 0 {
+		for _, step := range steps {
+			if step.Parallel {
+				group := WorkflowStep{
+					When:            step.When,
+					ContinueOnError: step.ContinueOnError,
+					Confirm:         step.Confirm,
+				}
+				for _, sub := range step.Steps {
+					if s, ok := stepFromConfig(sub.Run, sub.When, sub.ContinueOnError); ok {
+						s.ID = len(group.Parallel) + 1
+						group.Parallel = append(group.Parallel, s)
+					}
+				}
+				if len(group.Parallel) > 0 {
+					wf.AddStepWithOptions(group)
+				}
 				continue
 			}
-			wf.AddStep(parts[0], parts[1:], cmdStr)
+			if s, ok := stepFromConfig(step.Run, step.When, step.ContinueOnError); ok {
+				s.Confirm = step.Confirm
+				wf.AddStepWithOptions(s)
+			}
 		}
 		m.createWorkflowLocked(wf, name)
 	}
@@ -2,8 +2,21 @@ package interactive
 
 import (
 	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
 )
 
+// configWorkflows turns command strings into config steps without options.
+func configWorkflows(workflows map[string][]string) map[string][]config.WorkflowStep {
+	result := make(map[string][]config.WorkflowStep, len(workflows))
+	for name, commands := range workflows {
+		for _, command := range commands {
+			result[name] = append(result[name], config.WorkflowStep{Run: command})
+		}
+	}
+	return result
+}
+
 func TestLoadFromConfig_Nil(t *testing.T) {
 	mgr := NewWorkflowManager()
 	mgr.LoadFromConfig(nil) // must not panic
@@ -16,7 +29,7 @@ func TestLoadFromConfig_Nil(t *testing.T) {
 
 func TestLoadFromConfig_Empty(t *testing.T) {
 	mgr := NewWorkflowManager()
-	mgr.LoadFromConfig(map[string][]string{})
+	mgr.LoadFromConfig(map[string][]config.WorkflowStep{})
 
 	summaries := mgr.ListWorkflows()
 	if len(summaries) != 1 {
@@ -26,9 +39,9 @@ func TestLoadFromConfig_Empty(t *testing.T) {
 
 func TestLoadFromConfig_SingleWorkflow(t *testing.T) {
 	mgr := NewWorkflowManager()
-	mgr.LoadFromConfig(map[string][]string{
+	mgr.LoadFromConfig(configWorkflows(map[string][]string{
 		"deploy": {"add .", "commit <message>", "push current"},
-	})
+	}))
 
 	summaries := mgr.ListWorkflows()
 	if len(summaries) != 2 {
@@ -76,9 +89,9 @@ func TestLoadFromConfig_ScratchWorkflowRemainsActive(t *testing.T) {
 	mgr := NewWorkflowManager()
 	initialActiveID := mgr.GetActiveID()
 
-	mgr.LoadFromConfig(map[string][]string{
+	mgr.LoadFromConfig(configWorkflows(map[string][]string{
 		"acp": {"add .", "commit", "push current"},
-	})
+	}))
 
 	if mgr.GetActiveID() != initialActiveID {
 		t.Errorf("active ID changed after LoadFromConfig: got %d, want %d",
@@ -88,10 +101,10 @@ func TestLoadFromConfig_ScratchWorkflowRemainsActive(t *testing.T) {
 
 func TestLoadFromConfig_MultipleWorkflows(t *testing.T) {
 	mgr := NewWorkflowManager()
-	mgr.LoadFromConfig(map[string][]string{
+	mgr.LoadFromConfig(configWorkflows(map[string][]string{
 		"acp":          {"add .", "commit", "push current"},
 		"fetch-rebase": {"fetch origin", "rebase origin main"},
-	})
+	}))
 
 	summaries := mgr.ListWorkflows()
 	// 1 scratch + 2 config-defined
@@ -102,9 +115,9 @@ func TestLoadFromConfig_MultipleWorkflows(t *testing.T) {
 
 func TestLoadFromConfig_StepParsing(t *testing.T) {
 	mgr := NewWorkflowManager()
-	mgr.LoadFromConfig(map[string][]string{
+	mgr.LoadFromConfig(configWorkflows(map[string][]string{
 		"test": {"push origin main"},
-	})
+	}))
 
 	var testID int
 	for _, s := range mgr.ListWorkflows() {
@@ -134,3 +147,74 @@ func TestLoadFromConfig_StepParsing(t *testing.T) {
 		t.Errorf("Description = %q, want %q", s.Description, "push origin main")
 	}
 }
+
+func TestLoadFromConfig_StepOptions(t *testing.T) {
+	mgr := NewWorkflowManager()
+	mgr.LoadFromConfig(map[string][]config.WorkflowStep{
+		"ship": {
+			{Run: "add ."},
+			{Run: "push current", When: "branch != main", Confirm: true, ContinueOnError: true},
+		},
+	})
+
+	var shipID int
+	for _, s := range mgr.ListWorkflows() {
+		if s.Name == "ship" {
+			shipID = s.ID
+		}
+	}
+	cloneID, ok := mgr.CloneWorkflow(shipID, "")
+	if !ok {
+		t.Fatal("CloneWorkflow failed")
+	}
+	for _, id := range []int{shipID, cloneID} {
+		wf, _ := mgr.GetWorkflow(id)
+		steps := wf.GetSteps()
+		if len(steps) != 2 {
+			t.Fatalf("workflow %d: expected 2 steps, got %d", id, len(steps))
+		}
+		push := steps[1]
+		if push.When != "branch != main" || !push.Confirm || !push.ContinueOnError {
+			t.Errorf("workflow %d: push step options = %+v", id, push)
+		}
+		if got, want := push.String(), "[2] push current (when branch != main, confirm, continue on error)"; got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestLoadFromConfig_ParallelGroup(t *testing.T) {
+	mgr := NewWorkflowManager()
+	mgr.LoadFromConfig(map[string][]config.WorkflowStep{
+		"fetch-all": {
+			{Parallel: true, ContinueOnError: true, Steps: []config.ParallelStep{
+				{Run: "fetch origin"},
+				{Run: "fetch upstream", When: "clean"},
+			}},
+		},
+	})
+
+	var id int
+	for _, s := range mgr.ListWorkflows() {
+		if s.Name == "fetch-all" {
+			id = s.ID
+		}
+	}
+	cloneID, ok := mgr.CloneWorkflow(id, "")
+	if !ok {
+		t.Fatal("CloneWorkflow failed")
+	}
+	for _, wfID := range []int{id, cloneID} {
+		wf, _ := mgr.GetWorkflow(wfID)
+		steps := wf.GetSteps()
+		if len(steps) != 1 || len(steps[0].Parallel) != 2 {
+			t.Fatalf("workflow %d: steps = %+v, want one group of two", wfID, steps)
+		}
+		if got, want := steps[0].String(), "[1] parallel: fetch origin | fetch upstream (when clean) (continue on error)"; got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+		if sub := steps[0].Parallel[1]; sub.Command != "fetch" || len(sub.Args) != 1 || sub.Args[0] != "upstream" {
+			t.Errorf("group step = %+v", sub)
+		}
+	}
+}
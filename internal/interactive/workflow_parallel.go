@@ -0,0 +1,121 @@
+package interactive
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	uiutil "github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// parallelJob is a step of a parallel group that is going to run.
+type parallelJob struct {
+	result *stepResult
+	args   []string
+	out    *uiutil.PrefixWriter
+	// captured is the job's output without the prefixes, for the
+	// transcript.
+	captured bytes.Buffer
+	err      error
+}
+
+// runGroup runs the steps of a parallel group at the same time, each as its
+// own ggc process, and returns a result for each of them; for a group that
+// is skipped, a single result. The steps' when conditions are all evaluated
+// against the state before the group starts, and their placeholders are
+// asked for one after another before any of them runs. The error joins the
+// failures of the steps that do not continue on error.
+func (we *WorkflowExecutor) runGroup(n int, group WorkflowStep) ([]stepResult, error) {
+	result := stepResult{step: group, label: group.String(), status: stepSkipped}
+	if reason, err := we.checkStep(group); err != nil {
+		if errors.Is(err, ErrWorkflowCanceled) {
+			return nil, err
+		}
+		result, err = we.stepFailed(result, err)
+		return []stepResult{result}, err
+	} else if reason != "" {
+		result.reason = reason
+		we.uiWrite("⏭️  Skipped: %s\n", result.reason)
+		return []stepResult{result}, nil
+	}
+
+	results := make([]stepResult, len(group.Parallel))
+	var jobs []*parallelJob
+	var mu sync.Mutex
+	for i, step := range group.Parallel {
+		results[i] = stepResult{step: step, label: fmt.Sprintf("[%d.%d] %s", n, i+1, step.label()), status: stepSkipped}
+		r := &results[i]
+		if step.When != "" {
+			holds, err := we.conditionHolds(step.When)
+			if err != nil {
+				*r, _ = we.stepFailed(*r, err)
+				continue
+			}
+			if !holds {
+				r.reason = fmt.Sprintf("%s is false", step.When)
+				continue
+			}
+		}
+		args, canceled := resolveStepPlaceholders(we.ui, step)
+		if canceled {
+			return nil, ErrWorkflowCanceled
+		}
+		args = append([]string{step.Command}, args...)
+		r.args = args
+		jobs = append(jobs, &parallelJob{
+			result: r,
+			args:   args,
+			out:    uiutil.NewPrefixWriter(we.stdout(), "["+strings.Join(args, " ")+"] ", &mu),
+		})
+	}
+
+	we.uiWrite("   → Running %d step(s) in parallel\n", len(jobs))
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func(job *parallelJob) {
+			defer wg.Done()
+			start := time.Now()
+			job.err = we.runParallel(job.args, io.MultiWriter(job.out, &job.captured))
+			job.result.duration = time.Since(start)
+			job.out.Flush()
+		}(job)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, job := range jobs {
+		job.result.output = job.captured.String()
+		if job.err == nil {
+			job.result.status = stepOK
+			continue
+		}
+		*job.result, _ = we.stepFailed(*job.result, job.err)
+	}
+	for _, r := range results {
+		if r.status == stepFailed && !r.step.ContinueOnError {
+			errs = append(errs, fmt.Errorf("%s: %s", r.label, r.reason))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		if group.ContinueOnError {
+			we.uiWrite("⚠️  Step failed, continuing: %v\n", err)
+		}
+		return results, err
+	}
+	we.uiWrite("✅ Step %d completed successfully\n", n)
+	return results, nil
+}
+
+// stdout is where the output of parallel steps goes.
+func (we *WorkflowExecutor) stdout() io.Writer {
+	if we.ui != nil && we.ui.stdout != nil {
+		return we.ui.stdout
+	}
+	return os.Stdout
+}
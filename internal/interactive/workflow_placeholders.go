@@ -149,3 +149,37 @@ func interactiveInputForWorkflowScanner(scanner *bufio.Scanner, placeholders []s
 
 	return inputs, false
 }
+
+// confirmStep asks whether to run a step that has a confirmation gate.
+// Only y or yes runs it; canceled is true when the input was aborted.
+func confirmStep(ui *UI, step WorkflowStep) (ok, canceled bool) {
+	command := step.Description
+	if command == "" {
+		command = strings.TrimSpace(step.Command + " " + strings.Join(step.Args, " "))
+	}
+	var answer string
+	if ui != nil && ui.handler != nil {
+		ui.write("%s? %sRun %s?%s [y/N]: ",
+			ui.colors.BrightGreen,
+			ui.colors.BrightWhite+ui.colors.Bold,
+			command,
+			ui.colors.Reset)
+		answer, canceled = ui.readPlaceholderInput()
+		if canceled {
+			return false, true
+		}
+		ui.write("\n")
+	} else {
+		fmt.Printf("? Run %s? [y/N]: ", command)
+		scanner := bufio.NewScanner(os.Stdin)
+		if !scanner.Scan() {
+			return false, true
+		}
+		answer = scanner.Text()
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, false
+	}
+	return false, false
+}
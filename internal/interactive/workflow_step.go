@@ -11,17 +11,56 @@ type WorkflowStep struct {
 	Command     string   `json:"command"`
 	Args        []string `json:"args"`
 	Description string   `json:"description"`
+	// When is the condition the step runs under; see
+	// config.ParseWorkflowCondition. Empty runs the step always.
+	When string `json:"when,omitempty"`
+	// ContinueOnError keeps the workflow running when the step fails.
+	ContinueOnError bool `json:"continue_on_error,omitempty"`
+	// Confirm asks before running the step.
+	Confirm bool `json:"confirm,omitempty"`
+	// Parallel makes the step a group whose steps run at the same time,
+	// each as its own ggc process. A group has no command of its own.
+	Parallel []WorkflowStep `json:"parallel,omitempty"`
 }
 
 // String returns a string representation of the workflow step
 func (ws *WorkflowStep) String() string {
-	if ws.Description != "" {
-		return fmt.Sprintf("[%d] %s", ws.ID, ws.Description)
+	return fmt.Sprintf("[%d] %s", ws.ID, ws.label())
+}
+
+// label describes the step without its ID; for a parallel group, the
+// steps of the group.
+func (ws *WorkflowStep) label() string {
+	cmdStr := ws.Description
+	if len(ws.Parallel) > 0 {
+		labels := make([]string, len(ws.Parallel))
+		for i := range ws.Parallel {
+			labels[i] = ws.Parallel[i].label()
+		}
+		cmdStr = "parallel: " + strings.Join(labels, " | ")
+	} else if cmdStr == "" {
+		cmdStr = ws.Command
+		if len(ws.Args) > 0 {
+			cmdStr += " " + strings.Join(ws.Args, " ")
+		}
+	}
+	if options := ws.options(); len(options) > 0 {
+		cmdStr += " (" + strings.Join(options, ", ") + ")"
 	}
+	return cmdStr
+}
 
-	cmdStr := ws.Command
-	if len(ws.Args) > 0 {
-		cmdStr += " " + strings.Join(ws.Args, " ")
+// options describes the step options that are set.
+func (ws *WorkflowStep) options() []string {
+	var options []string
+	if ws.When != "" {
+		options = append(options, "when "+ws.When)
+	}
+	if ws.Confirm {
+		options = append(options, "confirm")
+	}
+	if ws.ContinueOnError {
+		options = append(options, "continue on error")
 	}
-	return fmt.Sprintf("[%d] %s", ws.ID, cmdStr)
+	return options
 }
@@ -4,9 +4,14 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/transcript"
 )
 
 func TestWorkflow_AddStep(t *testing.T) {
@@ -321,3 +326,264 @@ func (m *mockRouterNew) Route(args []string) error {
 	m.routedCommands = append(m.routedCommands, args)
 	return nil
 }
+
+// failingWorkflowRouter fails the commands named in fail.
+type failingWorkflowRouter struct {
+	fail     map[string]bool
+	executed []string
+}
+
+func (m *failingWorkflowRouter) Route(args []string) error {
+	m.executed = append(m.executed, strings.Join(args, " "))
+	if m.fail[args[0]] {
+		return fmt.Errorf("%s failed", args[0])
+	}
+	return nil
+}
+
+func newWorkflowTestUI() (*UI, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return &UI{stdout: out, stderr: &bytes.Buffer{}, colors: NewANSIColors()}, out
+}
+
+func TestWorkflowExecutor_StepOptions(t *testing.T) {
+	ui, out := newWorkflowTestUI()
+	router := &failingWorkflowRouter{fail: map[string]bool{"pr": true}}
+	executor := NewWorkflowExecutor(router, ui)
+	executor.status = func() *GitStatus { return &GitStatus{Branch: "main", Modified: 1} }
+
+	workflow := NewWorkflow()
+	workflow.AddStep("add", []string{"."}, "add .")
+	workflow.AddStepWithOptions(WorkflowStep{Command: "commit", Description: "commit", When: "has_staged_changes"})
+	workflow.AddStepWithOptions(WorkflowStep{Command: "pr", Args: []string{"create"}, Description: "pr create", ContinueOnError: true})
+	workflow.AddStepWithOptions(WorkflowStep{Command: "push", Args: []string{"current"}, Description: "push current", When: "branch == main"})
+
+	if err := executor.Execute(workflow); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got, want := strings.Join(router.executed, "|"), "add .|pr create|push current"; got != want {
+		t.Errorf("executed %q, want %q", got, want)
+	}
+	for _, want := range []string{
+		"skipped  [2] commit (when has_staged_changes): has_staged_changes is false",
+		"failed   [3] pr create (continue on error): pr failed",
+		"ok       [4] push current (when branch == main)",
+		"2 ok, 1 skipped, 1 failed",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestWorkflowExecutor_StopsOnFailure(t *testing.T) {
+	ui, out := newWorkflowTestUI()
+	router := &failingWorkflowRouter{fail: map[string]bool{"commit": true}}
+	executor := NewWorkflowExecutor(router, ui)
+
+	workflow := NewWorkflow()
+	workflow.AddStep("commit", nil, "commit")
+	workflow.AddStep("push", nil, "push")
+
+	err := executor.Execute(workflow)
+	if err == nil || !strings.Contains(err.Error(), "step 1/2 failed: commit failed") {
+		t.Fatalf("err = %v, want step 1/2 to fail", err)
+	}
+	if len(router.executed) != 1 {
+		t.Errorf("executed %v, want only commit", router.executed)
+	}
+	if !strings.Contains(out.String(), "skipped  [2] push: step 1 failed") || !strings.Contains(out.String(), "0 ok, 1 skipped, 1 failed") {
+		t.Errorf("unexpected summary:\n%s", out.String())
+	}
+}
+
+func TestWorkflowExecutor_ConditionOutsideRepository(t *testing.T) {
+	ui, _ := newWorkflowTestUI()
+	router := &failingWorkflowRouter{}
+	executor := NewWorkflowExecutor(router, ui)
+
+	workflow := NewWorkflow()
+	workflow.AddStepWithOptions(WorkflowStep{Command: "push", Description: "push", When: "ahead"})
+
+	err := executor.Execute(workflow)
+	if err == nil || !strings.Contains(err.Error(), "outside a git repository") {
+		t.Fatalf("err = %v, want a condition error", err)
+	}
+	if len(router.executed) != 0 {
+		t.Errorf("executed %v, want nothing", router.executed)
+	}
+}
+
+func TestWorkflowExecutor_Confirm(t *testing.T) {
+	oldStdin := os.Stdin
+	defer func() { os.Stdin = oldStdin }()
+
+	for _, tt := range []struct {
+		answer string
+		run    bool
+	}{
+		{"y\n", true},
+		{"yes\n", true},
+		{"n\n", false},
+		{"\n", false},
+	} {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("pipe: %v", err)
+		}
+		_, _ = w.WriteString(tt.answer)
+		_ = w.Close()
+		os.Stdin = r
+
+		ui, out := newWorkflowTestUI()
+		router := &failingWorkflowRouter{}
+		workflow := NewWorkflow()
+		workflow.AddStepWithOptions(WorkflowStep{Command: "push", Args: []string{"current"}, Description: "push current", Confirm: true})
+		if err := NewWorkflowExecutor(router, ui).Execute(workflow); err != nil {
+			t.Fatalf("answer %q: %v", tt.answer, err)
+		}
+		if ran := len(router.executed) == 1; ran != tt.run {
+			t.Errorf("answer %q: ran = %v, want %v", tt.answer, ran, tt.run)
+		}
+		if !tt.run && !strings.Contains(out.String(), "not confirmed") {
+			t.Errorf("answer %q: summary lacks the reason:\n%s", tt.answer, out.String())
+		}
+		_ = r.Close()
+	}
+}
+
+func TestWorkflowExecutor_ParallelGroup(t *testing.T) {
+	ui, out := newWorkflowTestUI()
+	router := &failingWorkflowRouter{}
+	executor := NewWorkflowExecutor(router, ui)
+	executor.status = func() *GitStatus { return &GitStatus{Branch: "main"} }
+	var mu sync.Mutex
+	var ran []string
+	executor.runParallel = func(args []string, w io.Writer) error {
+		mu.Lock()
+		ran = append(ran, strings.Join(args, " "))
+		mu.Unlock()
+		_, _ = fmt.Fprintf(w, "from %s\nno newline", args[1])
+		if args[1] == "upstream" {
+			return errors.New("exit status 1")
+		}
+		return nil
+	}
+
+	workflow := NewWorkflow()
+	workflow.AddStepWithOptions(WorkflowStep{Parallel: []WorkflowStep{
+		{ID: 1, Command: "fetch", Args: []string{"origin"}, Description: "fetch origin"},
+		{ID: 2, Command: "fetch", Args: []string{"upstream"}, Description: "fetch upstream", ContinueOnError: true},
+		{ID: 3, Command: "fetch", Args: []string{"fork"}, Description: "fetch fork", When: "has_changes"},
+	}})
+	workflow.AddStep("status", nil, "status")
+
+	if err := executor.Execute(workflow); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	sort.Strings(ran)
+	if got, want := strings.Join(ran, "|"), "fetch origin|fetch upstream"; got != want {
+		t.Errorf("ran in parallel %q, want %q", got, want)
+	}
+	if got, want := strings.Join(router.executed, "|"), "status"; got != want {
+		t.Errorf("executed %q, want %q", got, want)
+	}
+	for _, want := range []string{
+		"[1] parallel: fetch origin | fetch upstream (continue on error) | fetch fork (when has_changes)",
+		"[fetch origin] from origin\n",
+		"[fetch origin] no newline\n",
+		"[fetch upstream] from upstream\n",
+		"ok       [1.1] fetch origin",
+		"failed   [1.2] fetch upstream (continue on error): exit status 1",
+		"skipped  [1.3] fetch fork (when has_changes): has_changes is false",
+		"2 ok, 1 skipped, 1 failed",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestWorkflowExecutor_ParallelGroupFailure(t *testing.T) {
+	ui, out := newWorkflowTestUI()
+	router := &failingWorkflowRouter{}
+	executor := NewWorkflowExecutor(router, ui)
+	executor.runParallel = func(args []string, w io.Writer) error {
+		if args[1] == "upstream" {
+			return errors.New("exit status 128")
+		}
+		return nil
+	}
+
+	workflow := NewWorkflow()
+	workflow.AddStepWithOptions(WorkflowStep{Parallel: []WorkflowStep{
+		{ID: 1, Command: "fetch", Args: []string{"origin"}, Description: "fetch origin"},
+		{ID: 2, Command: "fetch", Args: []string{"upstream"}, Description: "fetch upstream"},
+	}})
+	workflow.AddStep("push", nil, "push")
+
+	err := executor.Execute(workflow)
+	if err == nil || !strings.Contains(err.Error(), "step 1/2 failed: [1.2] fetch upstream: exit status 128") {
+		t.Fatalf("err = %v, want the group to fail", err)
+	}
+	if len(router.executed) != 0 {
+		t.Errorf("executed %v, want nothing after the group", router.executed)
+	}
+	if !strings.Contains(out.String(), "skipped  [2] push: step 1 failed") {
+		t.Errorf("unexpected summary:\n%s", out.String())
+	}
+}
+
+func TestWorkflowExecutor_Transcript(t *testing.T) {
+	ui, out := newWorkflowTestUI()
+	router := &failingWorkflowRouter{fail: map[string]bool{"push": true}}
+	executor := NewWorkflowExecutor(router, ui)
+	store := &transcript.Store{Dir: t.TempDir()}
+	executor.transcripts = store
+	executor.runParallel = func(args []string, w io.Writer) error {
+		_, _ = fmt.Fprintf(w, "fetched %s\n", args[1])
+		return &exitError{code: 128}
+	}
+
+	workflow := NewWorkflow()
+	workflow.AddStep("add", []string{"."}, "add .")
+	workflow.AddStepWithOptions(WorkflowStep{ContinueOnError: true, Parallel: []WorkflowStep{
+		{ID: 1, Command: "fetch", Args: []string{"origin"}, Description: "fetch origin"},
+	}})
+	workflow.AddStepWithOptions(WorkflowStep{Command: "push", Args: []string{"current"}, Description: "push current"})
+	workflow.AddStep("status", nil, "status")
+
+	if err := executor.ExecuteNamed("ship", workflow); err == nil {
+		t.Fatal("Execute succeeded, want push to fail")
+	}
+	run, path, ok, err := store.Last()
+	if err != nil || !ok {
+		t.Fatalf("Last() = %v, %v", ok, err)
+	}
+	if !strings.Contains(out.String(), "Transcript: "+path) {
+		t.Errorf("output does not name the transcript %s:\n%s", path, out.String())
+	}
+	if run.Workflow != "ship" || len(run.Steps) != 4 {
+		t.Fatalf("run = %+v", run)
+	}
+	fetch := run.Steps[1]
+	if fetch.Label != "[2.1] fetch origin" || fetch.Status != transcript.StatusFailed || fetch.ExitCode != 128 || fetch.Output != "fetched origin\n" {
+		t.Errorf("fetch step = %+v", fetch)
+	}
+	push := run.Steps[2]
+	if strings.Join(push.Args, " ") != "push current" || push.Status != transcript.StatusFailed || push.Reason != "push failed" || push.ExitCode != 1 {
+		t.Errorf("push step = %+v", push)
+	}
+	if status := run.Steps[3]; status.Status != transcript.StatusSkipped || status.Reason != "step 3 failed" {
+		t.Errorf("status step = %+v", status)
+	}
+	if failed := run.Failed(); len(failed) != 2 {
+		t.Errorf("Failed() = %+v, want fetch and push", failed)
+	}
+}
+
+// exitError is an error carrying a process exit status.
+type exitError struct{ code int }
+
+func (e *exitError) Error() string { return fmt.Sprintf("exit status %d", e.code) }
+func (e *exitError) ExitCode() int { return e.code }
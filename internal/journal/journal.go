@@ -0,0 +1,206 @@
+// Package journal records destructive ggc operations so that `ggc undo`
+// can reverse them. Each repository keeps its own JSONL journal inside
+// its git directory, which means entries never leak between checkouts
+// and are removed together with the repository.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultMaxEntries is the number of operations kept per repository.
+// Older entries are dropped on the next write.
+const DefaultMaxEntries = 50
+
+// scannerMaxBuffer bounds individual journal lines. Entries carry path
+// lists for `clean`, which can grow past bufio.Scanner's 64 KiB default.
+const scannerMaxBuffer = 1024 * 1024
+
+// Kind identifies the destructive operation an entry reverses.
+type Kind string
+
+// Operation kinds understood by `ggc undo`.
+const (
+	// KindReset is a hard reset; undo moves HEAD back with reset --hard.
+	KindReset Kind = "reset"
+	// KindRebase is a completed rebase; undo moves HEAD back with reset --hard.
+	KindRebase Kind = "rebase"
+	// KindAmend is a commit amend; undo moves HEAD back with reset --soft
+	// so the amended changes stay staged.
+	KindAmend Kind = "amend"
+	// KindBranchDelete is a branch deletion; undo recreates the branch.
+	KindBranchDelete Kind = "branch-delete"
+	// KindUnwip is a WIP commit taken back by ggc unwip; undo moves HEAD
+	// back to it with reset --soft, leaving the working tree as it is.
+	KindUnwip Kind = "unwip"
+	// KindClean is a clean of untracked files; undo restores them from
+	// the snapshot commit.
+	KindClean Kind = "clean"
+)
+
+// Entry is one journaled operation.
+type Entry struct {
+	// Timestamp is when the operation completed, in UTC.
+	Timestamp time.Time `json:"ts"`
+	// Kind selects the undo strategy.
+	Kind Kind `json:"kind"`
+	// Command is the ggc invocation that performed the operation, used
+	// for display only.
+	Command string `json:"cmd"`
+	// Branch is the branch that was checked out, or the deleted branch
+	// for KindBranchDelete.
+	Branch string `json:"branch,omitempty"`
+	// Head is the commit HEAD pointed at before the operation.
+	Head string `json:"head,omitempty"`
+	// Tip is the commit a deleted branch pointed at.
+	Tip string `json:"tip,omitempty"`
+	// Snapshot is the commit holding files removed by clean, and Ref
+	// is the ref that pins it.
+	Snapshot string `json:"snapshot,omitempty"`
+	Ref      string `json:"ref,omitempty"`
+	// Paths lists the files captured in Snapshot.
+	Paths []string `json:"paths,omitempty"`
+}
+
+// Store is the persistence layer for journal entries.
+type Store struct {
+	// Path is the JSONL file backing the store.
+	Path string
+	// MaxEntries is the cap before older entries are dropped. Values
+	// <= 0 fall back to DefaultMaxEntries.
+	MaxEntries int
+}
+
+// PathForGitDir returns the journal location for the repository whose git
+// directory is gitDir.
+func PathForGitDir(gitDir string) string {
+	return filepath.Join(gitDir, "ggc", "undo.jsonl")
+}
+
+// SnapshotRef returns the ref used to pin a clean snapshot taken at ts.
+func SnapshotRef(ts time.Time) string {
+	return "refs/ggc/undo/" + ts.UTC().Format("20060102T150405.000000000Z")
+}
+
+func (s *Store) cap() int {
+	if s.MaxEntries > 0 {
+		return s.MaxEntries
+	}
+	return DefaultMaxEntries
+}
+
+// Append records e, stamping it with the current time when Timestamp is
+// zero, and drops entries beyond the cap. It returns the dropped entries
+// so that the caller can delete the snapshot refs they pinned.
+func (s *Store) Append(e Entry) (dropped []Entry, err error) {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+	all, err := s.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, e)
+	if max := s.cap(); len(all) > max {
+		dropped, all = all[:len(all)-max], all[len(all)-max:]
+	}
+	if err := s.rewrite(all); err != nil {
+		return nil, err
+	}
+	return dropped, nil
+}
+
+// ReadAll returns every entry, oldest first. A missing file yields an
+// empty slice.
+func (s *Store) ReadAll() ([]Entry, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return decodeEntries(f)
+}
+
+// Last returns the most recent entry. The bool is false when the journal
+// is empty.
+func (s *Store) Last() (Entry, bool, error) {
+	all, err := s.ReadAll()
+	if err != nil || len(all) == 0 {
+		return Entry{}, false, err
+	}
+	return all[len(all)-1], true, nil
+}
+
+// Pop removes the most recent entry once it has been undone.
+func (s *Store) Pop() error {
+	all, err := s.ReadAll()
+	if err != nil || len(all) == 0 {
+		return err
+	}
+	return s.rewrite(all[:len(all)-1])
+}
+
+func decodeEntries(r io.Reader) ([]Entry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), scannerMaxBuffer)
+	var out []Entry
+	for scanner.Scan() {
+		raw := scanner.Bytes()
+		if len(raw) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(raw, &e); err != nil {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, scanner.Err()
+}
+
+// rewrite atomically replaces the journal with entries via a temp file
+// and rename, so an interrupted write keeps the previous journal intact.
+func (s *Store) rewrite(entries []Entry) error {
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".undo-*.jsonl")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	bw := bufio.NewWriter(tmp)
+	for i := range entries {
+		b, err := json.Marshal(entries[i])
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmpPath)
+			return err
+		}
+		if _, err := bw.Write(append(b, '\n')); err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmpPath)
+			return err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, s.Path)
+}
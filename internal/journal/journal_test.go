@@ -0,0 +1,111 @@
+package journal
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	return &Store{Path: PathForGitDir(t.TempDir())}
+}
+
+func TestStore_AppendAndLast(t *testing.T) {
+	s := newTestStore(t)
+
+	if _, ok, err := s.Last(); err != nil || ok {
+		t.Fatalf("empty journal: ok=%v err=%v", ok, err)
+	}
+	if _, err := s.Append(Entry{Kind: KindReset, Command: "reset hard HEAD~1", Head: "aaa"}); err != nil {
+		t.Fatalf("append 1: %v", err)
+	}
+	if _, err := s.Append(Entry{Kind: KindBranchDelete, Command: "branch delete feat", Branch: "feat", Tip: "bbb"}); err != nil {
+		t.Fatalf("append 2: %v", err)
+	}
+
+	e, ok, err := s.Last()
+	if err != nil || !ok {
+		t.Fatalf("last: ok=%v err=%v", ok, err)
+	}
+	if e.Kind != KindBranchDelete || e.Tip != "bbb" {
+		t.Fatalf("unexpected last entry: %+v", e)
+	}
+	if e.Timestamp.IsZero() {
+		t.Fatal("Append should stamp a timestamp")
+	}
+}
+
+func TestStore_Pop(t *testing.T) {
+	s := newTestStore(t)
+	_, _ = s.Append(Entry{Kind: KindReset, Command: "first"})
+	_, _ = s.Append(Entry{Kind: KindAmend, Command: "second"})
+
+	if err := s.Pop(); err != nil {
+		t.Fatalf("pop: %v", err)
+	}
+	all, err := s.ReadAll()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(all) != 1 || all[0].Command != "first" {
+		t.Fatalf("pop should drop the newest entry, got %+v", all)
+	}
+
+	_ = s.Pop()
+	if err := s.Pop(); err != nil {
+		t.Fatalf("pop on empty journal should be a no-op, got %v", err)
+	}
+}
+
+func TestStore_CapsEntries(t *testing.T) {
+	s := newTestStore(t)
+	s.MaxEntries = 3
+	for i := 0; i < 5; i++ {
+		_, _ = s.Append(Entry{Kind: KindReset, Command: strings.Repeat("x", i+1)})
+	}
+	all, _ := s.ReadAll()
+	if len(all) != 3 {
+		t.Fatalf("want 3 entries, got %d", len(all))
+	}
+	if all[0].Command != "xxx" {
+		t.Fatalf("oldest entries should be dropped first, got %+v", all)
+	}
+}
+
+func TestStore_AppendReturnsDropped(t *testing.T) {
+	s := newTestStore(t)
+	for i := 0; i < DefaultMaxEntries; i++ {
+		dropped, err := s.Append(Entry{Kind: KindClean, Command: "clean files", Ref: fmt.Sprintf("refs/ggc/undo/%d", i)})
+		if err != nil || len(dropped) != 0 {
+			t.Fatalf("Append %d under the cap = %v, %v", i, dropped, err)
+		}
+	}
+	dropped, err := s.Append(Entry{Kind: KindReset, Command: "reset"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dropped) != 1 || dropped[0].Ref != "refs/ggc/undo/0" {
+		t.Fatalf("dropped = %+v, want the oldest entry", dropped)
+	}
+	if all, _ := s.ReadAll(); len(all) != DefaultMaxEntries || all[0].Ref != "refs/ggc/undo/1" {
+		t.Fatalf("journal keeps %d entries starting at %+v", len(all), all[0])
+	}
+}
+
+func TestPathForGitDir(t *testing.T) {
+	got := PathForGitDir(filepath.Join("repo", ".git"))
+	want := filepath.Join("repo", ".git", "ggc", "undo.jsonl")
+	if got != want {
+		t.Fatalf("PathForGitDir = %q, want %q", got, want)
+	}
+}
+
+func TestSnapshotRef(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	if got := SnapshotRef(ts); got != "refs/ggc/undo/20260102T030405.000000006Z" {
+		t.Fatalf("SnapshotRef = %q", got)
+	}
+}
@@ -2,7 +2,10 @@ package keybindings
 
 import (
 	"fmt"
+	"os"
 	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 // ContextTransitionAnimator provides visual feedback for context transitions
@@ -76,8 +79,8 @@ func (cta *ContextTransitionAnimator) slideTransition(from, to Context) {
 
 // highlightTransition performs a highlight animation
 func (cta *ContextTransitionAnimator) highlightTransition(from, to Context) {
-	// Use ANSI escape codes for highlighting
-	fmt.Printf("\033[1;33m[%s]\033[0m → \033[1;32m[%s]\033[0m\n", from, to)
+	colors := ui.ColorsFor(os.Stdout)
+	fmt.Printf("%s[%s]%s → %s[%s]%s\n", colors.Bold+colors.Yellow, from, colors.Reset, colors.Bold+colors.Green, to, colors.Reset)
 }
 
 // RegisterAnimation registers a custom animation function
@@ -0,0 +1,42 @@
+package keybindings
+
+// ChordMatch describes how a run of keystrokes relates to the chords
+// bound in a KeyBindingMap.
+type ChordMatch int
+
+// Possible chord matches.
+const (
+	ChordNone     ChordMatch = iota // no bound chord starts with the keys
+	ChordPrefix                     // the keys start a bound chord; wait for more
+	ChordComplete                   // the keys are a whole bound chord
+)
+
+// MatchChord reports whether keys form, or start, a chord bound to any
+// action. A complete chord wins over a longer one sharing its prefix.
+func (km *KeyBindingMap) MatchChord(keys []KeyStroke) ChordMatch {
+	if len(keys) == 0 {
+		return ChordNone
+	}
+	match := ChordNone
+	for _, strokes := range km.actionBindings() {
+		for _, ks := range strokes {
+			if ks.Kind != KeyStrokeChord || len(ks.Keys) < len(keys) || !chordHasPrefix(ks, keys) {
+				continue
+			}
+			if len(ks.Keys) == len(keys) {
+				return ChordComplete
+			}
+			match = ChordPrefix
+		}
+	}
+	return match
+}
+
+func chordHasPrefix(chord KeyStroke, keys []KeyStroke) bool {
+	for i, k := range keys {
+		if !normalizeNamedKey(k).Equals(normalizeNamedKey(chord.Keys[i])) {
+			return false
+		}
+	}
+	return true
+}
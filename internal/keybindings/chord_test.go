@@ -0,0 +1,66 @@
+package keybindings
+
+import "testing"
+
+func TestParseKeyStroke_Chord(t *testing.T) {
+	t.Parallel()
+
+	ks, err := ParseKeyStroke("C-x ctrl+s")
+	if err != nil {
+		t.Fatalf("ParseKeyStroke() error = %v", err)
+	}
+	want := NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('s'))
+	if !ks.Equals(want) {
+		t.Fatalf("ParseKeyStroke() = %v, want %v", ks, want)
+	}
+	if got := FormatKeyStrokeForDisplay(ks); got != "Ctrl+x Ctrl+s" {
+		t.Errorf("display = %q", got)
+	}
+	if _, err := ParseKeyStroke("C-x nope"); err == nil {
+		t.Error("expected an error for a chord with an invalid key")
+	}
+	if err := validateKeyStroke(ks); err != nil {
+		t.Errorf("validateKeyStroke() = %v", err)
+	}
+}
+
+func TestMatchChord(t *testing.T) {
+	t.Parallel()
+
+	km := &KeyBindingMap{
+		ToggleWorkflowView: []KeyStroke{NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('w'))},
+		ClearLine:          []KeyStroke{NewCtrlKeyStroke('u')},
+	}
+	tests := []struct {
+		keys []KeyStroke
+		want ChordMatch
+	}{
+		{[]KeyStroke{NewCtrlKeyStroke('x')}, ChordPrefix},
+		{[]KeyStroke{NewCtrlKeyStroke('x'), NewCtrlKeyStroke('w')}, ChordComplete},
+		{[]KeyStroke{NewCtrlKeyStroke('x'), NewCtrlKeyStroke('u')}, ChordNone},
+		{[]KeyStroke{NewCtrlKeyStroke('u')}, ChordNone},
+		{nil, ChordNone},
+	}
+	for _, tt := range tests {
+		if got := km.MatchChord(tt.keys); got != tt.want {
+			t.Errorf("MatchChord(%v) = %v, want %v", tt.keys, got, tt.want)
+		}
+	}
+
+	chord := NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('w'))
+	if !km.MatchesKeyStroke("toggle_workflow_view", chord) {
+		t.Error("a completed chord should match its action")
+	}
+	if km.MatchesKeyStroke("toggle_workflow_view", NewCtrlKeyStroke('x')) {
+		t.Error("the chord prefix alone must not trigger the action")
+	}
+}
+
+func TestReadlineProfileChords(t *testing.T) {
+	t.Parallel()
+
+	bindings := CreateReadlineProfile().Contexts[ContextSearch]["toggle_workflow_view"]
+	if len(bindings) != 1 || bindings[0].Kind != KeyStrokeChord {
+		t.Errorf("toggle_workflow_view = %v, want the C-x C-w chord", bindings)
+	}
+}
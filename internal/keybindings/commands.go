@@ -2,6 +2,7 @@ package keybindings
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -13,6 +14,7 @@ type ShowKeysCommand struct {
 	resolver *KeyBindingResolver
 	platform string
 	terminal string
+	out      io.Writer
 }
 
 // NewShowKeysCommand creates a new show keys command
@@ -21,75 +23,114 @@ func NewShowKeysCommand(resolver *KeyBindingResolver) *ShowKeysCommand {
 		resolver: resolver,
 		platform: DetectPlatform(),
 		terminal: DetectTerminal(),
+		out:      os.Stdout,
 	}
 }
 
-// Execute runs the show keys command
-func (skc *ShowKeysCommand) Execute(profile Profile, context Context, format string) error { //nolint:revive // rich output grouped by sections
-	fmt.Printf("ggc Interactive Mode - Effective Keybindings\n")
-	fmt.Printf("=============================================\n\n")
+// SetOutput redirects the listing, which goes to stdout by default.
+func (skc *ShowKeysCommand) SetOutput(w io.Writer) {
+	skc.out = w
+}
+
+// showKeysGroups lists the actions shown by ShowKeysCommand, by group.
+var showKeysGroups = []struct {
+	title   string
+	actions [][2]string // action, description
+}{
+	{"Navigation", [][2]string{
+		{"move_up", "Move selection up"},
+		{"move_down", "Move selection down"},
+		{"move_left", "Move cursor left"},
+		{"move_right", "Move cursor right"},
+		{"move_to_beginning", "Move to line beginning"},
+		{"move_to_end", "Move to line end"},
+	}},
+	{"Editing", [][2]string{
+		{"delete_word", "Delete previous word"},
+		{"delete_to_end", "Delete to line end"},
+		{"clear_line", "Clear entire line"},
+		{"soft_cancel", "Cancel the current input or overlay"},
+		{"undo", "Undo the last input edit"},
+		{"redo", "Redo an undone input edit"},
+		{"suspend", "Suspend ggc to the shell"},
+	}},
+	{"History", [][2]string{
+		{"history_prev", "Recall previous command"},
+		{"history_next", "Recall next command"},
+		{"history_search", "Search command history"},
+	}},
+	{"Workflow", [][2]string{
+		{"add_to_workflow", "Add selection to workflow"},
+		{"toggle_workflow_view", "Toggle workflow view"},
+		{"clear_workflow", "Clear workflow"},
+		{"workflow_create", "Create workflow"},
+		{"workflow_delete", "Delete workflow"},
+	}},
+}
 
-	// Get profile info
+// Execute prints the bindings in effect for profile and context after
+// every layer (profile, platform, terminal, user config) is applied.
+// format "compact" prints bare "action keys" lines.
+func (skc *ShowKeysCommand) Execute(profile Profile, context Context, format string) error {
 	prof, exists := skc.resolver.GetProfile(profile)
 	if !exists {
 		return fmt.Errorf("profile '%s' not found", profile)
 	}
-
-	fmt.Printf("Profile: %s", prof.Name)
-	if prof.Description != "" {
-		fmt.Printf(" (%s)", prof.Description)
+	if !context.IsValid() {
+		return fmt.Errorf("unknown context '%s' (valid: global, input, results, search)", context)
 	}
-	fmt.Printf("\n")
-
-	fmt.Printf("Platform: %s/%s\n", skc.platform, skc.terminal)
-	fmt.Printf("Context: %s\n\n", context)
-
-	// Get effective keybindings
 	keyMap, err := skc.resolver.Resolve(profile, context)
 	if err != nil {
 		return fmt.Errorf("failed to resolve keybindings: %w", err)
 	}
-
-	// Display keybindings by category
-	fmt.Printf("Core Actions:\n")
-	fmt.Printf("  Navigation:\n")
-	if len(keyMap.MoveUp) > 0 {
-		fmt.Printf("    move_up                 %-20s Move up one line\n", FormatKeyStrokesForDisplay(keyMap.MoveUp))
-	}
-	if len(keyMap.MoveDown) > 0 {
-		fmt.Printf("    move_down               %-20s Move down one line\n", FormatKeyStrokesForDisplay(keyMap.MoveDown))
-	}
-	if len(keyMap.MoveToBeginning) > 0 {
-		fmt.Printf("    move_to_beginning       %-20s Move to line beginning\n", FormatKeyStrokesForDisplay(keyMap.MoveToBeginning))
-	}
-	if len(keyMap.MoveToEnd) > 0 {
-		fmt.Printf("    move_to_end             %-20s Move to line end\n", FormatKeyStrokesForDisplay(keyMap.MoveToEnd))
+	bindings := keyMap.actionBindings()
+	w := skc.out
+
+	if format == "compact" {
+		for _, group := range showKeysGroups {
+			for _, a := range group.actions {
+				if keys := bindings[a[0]]; len(keys) > 0 {
+					_, _ = fmt.Fprintf(w, "%s %s\n", a[0], FormatKeyStrokesForDisplay(keys))
+				}
+			}
+		}
+		return nil
 	}
 
-	fmt.Printf("\n  Editing:\n")
-	if len(keyMap.DeleteWord) > 0 {
-		fmt.Printf("    delete_word             %-20s Delete previous word\n", FormatKeyStrokesForDisplay(keyMap.DeleteWord))
-	}
-	if len(keyMap.DeleteToEnd) > 0 {
-		fmt.Printf("    delete_to_end           %-20s Delete to line end\n", FormatKeyStrokesForDisplay(keyMap.DeleteToEnd))
+	_, _ = fmt.Fprintf(w, "ggc Interactive Mode - Effective Keybindings\n")
+	_, _ = fmt.Fprintf(w, "=============================================\n\n")
+	_, _ = fmt.Fprintf(w, "Profile: %s", prof.Name)
+	if prof.Description != "" {
+		_, _ = fmt.Fprintf(w, " (%s)", prof.Description)
 	}
-	if len(keyMap.ClearLine) > 0 {
-		fmt.Printf("    clear_line              %-20s Clear entire line\n", FormatKeyStrokesForDisplay(keyMap.ClearLine))
+	_, _ = fmt.Fprintf(w, "\nPlatform: %s/%s\n", skc.platform, skc.terminal)
+	_, _ = fmt.Fprintf(w, "Context: %s\n", context)
+
+	for _, group := range showKeysGroups {
+		var lines []string
+		for _, a := range group.actions {
+			if keys := bindings[a[0]]; len(keys) > 0 {
+				lines = append(lines, fmt.Sprintf("    %-23s %-20s %s", a[0], FormatKeyStrokesForDisplay(keys), a[1]))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "\n  %s:\n%s\n", group.title, strings.Join(lines, "\n"))
 	}
 
-	fmt.Printf("\nQuick Reference:\n")
-	fmt.Printf("  quit                    %-20s Exit to shell\n", "Ctrl+C")
+	_, _ = fmt.Fprintf(w, "\nQuick Reference:\n")
+	_, _ = fmt.Fprintf(w, "    %-23s %-20s Exit to shell\n", "quit", "Ctrl+C")
 
-	// Show resolution layers
-	fmt.Printf("\nResolution Layers Applied:\n")
-	fmt.Printf("  1. Base Profile: %s\n", profile)
-	fmt.Printf("  2. Platform: %s\n", skc.platform)
-	fmt.Printf("  3. Terminal: %s\n", skc.terminal)
-	fmt.Printf("  4. User Config: (if configured)\n")
+	_, _ = fmt.Fprintf(w, "\nResolution Layers Applied:\n")
+	_, _ = fmt.Fprintf(w, "  1. Base Profile: %s\n", profile)
+	_, _ = fmt.Fprintf(w, "  2. Platform: %s\n", skc.platform)
+	_, _ = fmt.Fprintf(w, "  3. Terminal: %s\n", skc.terminal)
+	_, _ = fmt.Fprintf(w, "  4. User Config: interactive.keybindings and friends, if set\n")
 
-	fmt.Printf("\nTips:\n")
-	fmt.Printf("  • Use 'ggc config keybindings --export' to backup your settings\n")
-	fmt.Printf("  • Profile switching: set 'interactive.profile' in config\n")
+	_, _ = fmt.Fprintf(w, "\nTips:\n")
+	_, _ = fmt.Fprintf(w, "  • Use 'ggc debug-keys raw' to see what your terminal sends\n")
+	_, _ = fmt.Fprintf(w, "  • Profile switching: set 'interactive.profile' in config\n")
 
 	return nil
 }
@@ -281,6 +322,11 @@ func (dkc *DebugKeysCommand) identifySequence(seq []byte) string { //nolint:revi
 		}
 	}
 
+	// Remaining named keys (F5-F12, Home/End, PgUp/PgDn, Ctrl+arrows, ...)
+	if named, ok := DecodeSequence(seq); ok {
+		return named.Name
+	}
+
 	return ""
 }
 
@@ -0,0 +1,142 @@
+package keybindings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// resolveLayer is one step of keybinding resolution. Later layers
+// override earlier ones.
+type resolveLayer struct {
+	name  string // where the bindings come from, e.g. "emacs profile"
+	user  bool   // whether the user can edit it
+	apply func(keyMap *KeyBindingMap)
+}
+
+// layers lists the resolution layers for profile and context, in order:
+// built-in defaults, the profile, the platform and terminal adjustments,
+// the user config and the GGC_KEYBIND_* environment.
+func (r *KeyBindingResolver) layers(profile Profile, context Context) []resolveLayer {
+	layers := []resolveLayer{{name: "built-in defaults", apply: r.applyDefaults}}
+	if prof, exists := r.profiles[profile]; exists {
+		layers = append(layers, resolveLayer{
+			name:  fmt.Sprintf("%s profile", profile),
+			apply: func(keyMap *KeyBindingMap) { r.applyProfile(keyMap, prof, context) },
+		})
+	}
+	layers = append(layers,
+		resolveLayer{name: fmt.Sprintf("%s platform defaults", r.platform), apply: r.applyPlatformLayer},
+		resolveLayer{name: fmt.Sprintf("%s terminal defaults", r.terminal), apply: r.applyTerminalLayer},
+	)
+	if r.userConfig != nil {
+		layers = append(layers,
+			resolveLayer{name: "interactive.keybindings", user: true, apply: r.applyUserGlobalBindings},
+			resolveLayer{
+				name:  fmt.Sprintf("interactive.contexts.%s.keybindings", context),
+				user:  true,
+				apply: func(keyMap *KeyBindingMap) { r.applyUserContextBindings(keyMap, context) },
+			},
+			resolveLayer{name: fmt.Sprintf("interactive.%s.keybindings", r.platform), user: true, apply: r.applyUserPlatformBindings},
+			resolveLayer{name: fmt.Sprintf("interactive.terminals.%s.keybindings", r.terminal), user: true, apply: r.applyUserTerminalBindings},
+		)
+	}
+	return append(layers, resolveLayer{name: "GGC_KEYBIND_* environment", user: true, apply: r.applyEnvironmentOverrides})
+}
+
+// Conflict is a keystroke bound to more than one action in one context.
+type Conflict struct {
+	Context   Context
+	KeyStroke string
+	Bindings  []ConflictBinding
+}
+
+// ConflictBinding is one of the actions of a Conflict and the layer that
+// gave the action its keystrokes.
+type ConflictBinding struct {
+	Action string
+	Layer  string
+	user   bool
+}
+
+// String describes the conflict on one line.
+func (c Conflict) String() string {
+	parts := make([]string, len(c.Bindings))
+	for i, b := range c.Bindings {
+		parts[i] = fmt.Sprintf("%s (%s)", b.Action, b.Layer)
+	}
+	return fmt.Sprintf("%s: %s is bound to %s", c.Context, c.KeyStroke, strings.Join(parts, " and "))
+}
+
+// Fix says how to resolve the conflict: change the binding the user set
+// most recently, or, when every binding is built in, override one of them
+// in the config section for the context.
+func (c Conflict) Fix() string {
+	for i := len(c.Bindings) - 1; i >= 0; i-- {
+		b := c.Bindings[i]
+		if !b.user {
+			continue
+		}
+		if strings.HasPrefix(b.Layer, "GGC_KEYBIND_") {
+			return fmt.Sprintf("change or unset GGC_KEYBIND_%s", strings.ToUpper(b.Action))
+		}
+		return fmt.Sprintf("bind %s to another key in %s", b.Action, b.Layer)
+	}
+	section := "interactive.keybindings"
+	if c.Context != ContextGlobal {
+		section = fmt.Sprintf("interactive.contexts.%s.keybindings", c.Context)
+	}
+	actions := make([]string, len(c.Bindings))
+	for i, b := range c.Bindings {
+		actions[i] = b.Action
+	}
+	return fmt.Sprintf("bind %s to another key in %s", strings.Join(actions, " or "), section)
+}
+
+// Conflicts resolves every context of profile and returns the keystrokes
+// bound to more than one action, with the layer each binding came from,
+// ordered by context and keystroke.
+func (r *KeyBindingResolver) Conflicts(profile Profile) []Conflict {
+	var conflicts []Conflict
+	for _, context := range GetAllContexts() {
+		keyMap := newEmptyKeyBindingMap()
+		sources := make(map[string]resolveLayer)
+		for _, layer := range r.layers(profile, context) {
+			before := keyMap.actionBindings()
+			layer.apply(keyMap)
+			for action, after := range keyMap.actionBindings() {
+				if !sameKeyStrokes(before[action], after) {
+					sources[action] = layer
+				}
+			}
+		}
+
+		found := conflictingActions(keyMap)
+		keys := make([]string, 0, len(found))
+		for key := range found {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			c := Conflict{Context: context, KeyStroke: key}
+			for _, action := range found[key] {
+				layer := sources[action]
+				c.Bindings = append(c.Bindings, ConflictBinding{Action: action, Layer: layer.name, user: layer.user})
+			}
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts
+}
+
+func sameKeyStrokes(a, b []KeyStroke) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equals(b[i]) {
+			return false
+		}
+	}
+	return true
+}
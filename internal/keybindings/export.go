@@ -228,6 +228,12 @@ func (ke *KeybindingExporter) formatKeystrokeForExport(ks KeyStroke) string { //
 		return fmt.Sprintf("raw:%x", ks.Seq)
 	case KeyStrokeFnKey:
 		return strings.ToLower(ks.Name)
+	case KeyStrokeChord:
+		parts := make([]string, len(ks.Keys))
+		for i, k := range ks.Keys {
+			parts[i] = ke.formatKeystrokeForExport(k)
+		}
+		return strings.Join(parts, " ")
 	default:
 		return fmt.Sprintf("unknown:%v", ks)
 	}
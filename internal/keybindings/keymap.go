@@ -21,6 +21,9 @@ type KeyBindingMap struct {
 	HistoryPrev        []KeyStroke // default: [Ctrl+P] in ContextInput only
 	HistoryNext        []KeyStroke // default: [Ctrl+N] in ContextInput only
 	HistorySearch      []KeyStroke // default: [Ctrl+R]
+	Undo               []KeyStroke // default: [], readline: [Ctrl+_]
+	Redo               []KeyStroke // default: []
+	Suspend            []KeyStroke // default: [Ctrl+Z]
 }
 
 // DefaultKeyBindingMap returns the built-in default control bindings.
@@ -41,6 +44,7 @@ func DefaultKeyBindingMap() *KeyBindingMap {
 		WorkflowCreate:     []KeyStroke{NewCtrlKeyStroke('n')},
 		WorkflowDelete:     []KeyStroke{NewCtrlKeyStroke('d')},
 		SoftCancel:         []KeyStroke{NewCtrlKeyStroke('g'), NewEscapeKeyStroke()},
+		Suspend:            []KeyStroke{NewCtrlKeyStroke('z')},
 	}
 }
 
@@ -110,7 +114,24 @@ func (km *KeyBindingMap) getFirstControlByte(keyStrokes []KeyStroke, fallback by
 
 // MatchesKeyStroke checks if any KeyStroke in the given action matches the input
 func (km *KeyBindingMap) MatchesKeyStroke(action string, input KeyStroke) bool {
-	actionMap := map[string][]KeyStroke{
+	keyStrokes, exists := km.actionBindings()[action]
+	if !exists {
+		return false
+	}
+
+	// Named keys may arrive as raw sequences; compare them by name.
+	input = normalizeNamedKey(input)
+	for _, ks := range keyStrokes {
+		if input.Equals(normalizeNamedKey(ks)) {
+			return true
+		}
+	}
+	return false
+}
+
+// actionBindings maps action names to their keystrokes.
+func (km *KeyBindingMap) actionBindings() map[string][]KeyStroke {
+	return map[string][]KeyStroke{
 		"delete_word":          km.DeleteWord,
 		"clear_line":           km.ClearLine,
 		"delete_to_end":        km.DeleteToEnd,
@@ -129,17 +150,8 @@ func (km *KeyBindingMap) MatchesKeyStroke(action string, input KeyStroke) bool {
 		"history_prev":         km.HistoryPrev,
 		"history_next":         km.HistoryNext,
 		"history_search":       km.HistorySearch,
+		"undo":                 km.Undo,
+		"redo":                 km.Redo,
+		"suspend":              km.Suspend,
 	}
-
-	keyStrokes, exists := actionMap[action]
-	if !exists {
-		return false
-	}
-
-	for _, ks := range keyStrokes {
-		if input.Equals(ks) {
-			return true
-		}
-	}
-	return false
 }
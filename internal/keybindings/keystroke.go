@@ -14,6 +14,7 @@ const (
 	KeyStrokeAlt                         // Alt/Meta key combinations (Alt+Backspace)
 	KeyStrokeRawSeq                      // Raw escape sequences
 	KeyStrokeFnKey                       // Function keys (F1, F2, etc.)
+	KeyStrokeChord                       // Sequence of keystrokes (C-x C-s)
 )
 
 // String returns a human-readable representation of the KeyStrokeKind
@@ -27,6 +28,8 @@ func (k KeyStrokeKind) String() string {
 		return "RawSeq"
 	case KeyStrokeFnKey:
 		return "FnKey"
+	case KeyStrokeChord:
+		return "Chord"
 	default:
 		return "Unknown"
 	}
@@ -38,6 +41,7 @@ type KeyStroke struct {
 	Rune rune          // For Ctrl+<letter>, Alt+<letter> - the letter
 	Seq  []byte        // For raw escape sequences
 	Name string        // For function keys (F1, F2, etc.) and special names
+	Keys []KeyStroke   // For chords - the keystrokes in order
 }
 
 // String returns a human-readable representation of the KeyStroke
@@ -54,6 +58,12 @@ func (ks KeyStroke) String() string {
 		return fmt.Sprintf("Seq%v", ks.Seq)
 	case KeyStrokeFnKey:
 		return ks.Name
+	case KeyStrokeChord:
+		parts := make([]string, len(ks.Keys))
+		for i, k := range ks.Keys {
+			parts[i] = k.String()
+		}
+		return strings.Join(parts, " ")
 	default:
 		return "Unknown"
 	}
@@ -79,6 +89,16 @@ func (ks KeyStroke) Equals(other KeyStroke) bool {
 		return true
 	case KeyStrokeFnKey:
 		return ks.Name == other.Name
+	case KeyStrokeChord:
+		if len(ks.Keys) != len(other.Keys) {
+			return false
+		}
+		for i, k := range ks.Keys {
+			if !k.Equals(other.Keys[i]) {
+				return false
+			}
+		}
+		return true
 	default:
 		return false
 	}
@@ -118,6 +138,18 @@ func NewRawKeyStroke(seq []byte) KeyStroke {
 	}
 }
 
+// NewChordKeyStroke creates a chord: keys pressed one after another,
+// such as C-x C-s. A single key is returned as is.
+func NewChordKeyStroke(keys ...KeyStroke) KeyStroke {
+	if len(keys) == 1 {
+		return keys[0]
+	}
+	return KeyStroke{
+		Kind: KeyStrokeChord,
+		Keys: keys,
+	}
+}
+
 // NewTabKeyStroke creates a new Tab KeyStroke
 func NewTabKeyStroke() KeyStroke {
 	return NewRawKeyStroke([]byte{9}) // Tab is ASCII 9
@@ -232,8 +264,26 @@ func ParseKeyBinding(keyStr string) (byte, error) { //nolint:revive // parsing m
 }
 
 // ParseKeyStroke parses a single key binding string and returns a KeyStroke
-// Supports enhanced formats including Alt keys
-func ParseKeyStroke(keyStr string) (KeyStroke, error) { //nolint:revive // parsing numerous historical formats
+// Supports enhanced formats including Alt keys. Space-separated keys such
+// as "C-x C-s" form a chord.
+func ParseKeyStroke(keyStr string) (KeyStroke, error) {
+	fields := strings.Fields(keyStr)
+	if len(fields) <= 1 {
+		return parseSingleKeyStroke(keyStr)
+	}
+	keys := make([]KeyStroke, len(fields))
+	for i, f := range fields {
+		ks, err := parseSingleKeyStroke(f)
+		if err != nil {
+			return KeyStroke{}, fmt.Errorf("chord %q: %w", keyStr, err)
+		}
+		keys[i] = ks
+	}
+	return NewChordKeyStroke(keys...), nil
+}
+
+// parseSingleKeyStroke parses one key of a binding.
+func parseSingleKeyStroke(keyStr string) (KeyStroke, error) { //nolint:revive // parsing numerous historical formats
 	s := strings.TrimSpace(keyStr)
 	if s == "" {
 		return KeyStroke{}, fmt.Errorf("empty key binding")
@@ -242,6 +292,12 @@ func ParseKeyStroke(keyStr string) (KeyStroke, error) { //nolint:revive // parsi
 	// Normalize to lowercase for comparison
 	sLower := strings.ToLower(s)
 
+	// Handle named keys: f1-f12, home, end, pgup, pgdn, shift+tab and
+	// ctrl/shift/alt-modified arrows (see named_keys.go)
+	if ks, ok := parseNamedKey(sLower); ok {
+		return ks, nil
+	}
+
 	// Handle "ctrl+<key>" format (case-insensitive)
 	if hasPrefixFold(s, "ctrl+") && len(s) > len("ctrl+") {
 		keyPart := s[len("ctrl+"):]
@@ -339,7 +395,7 @@ func ParseKeyStroke(keyStr string) (KeyStroke, error) { //nolint:revive // parsi
 		return NewRightArrowKeyStroke(), nil
 	}
 
-	return KeyStroke{}, fmt.Errorf("unsupported key binding format: %s (supported: 'ctrl+w', '^w', 'C-w', 'alt+backspace', 'M-backspace', 'up', 'down', 'left', 'right')", keyStr)
+	return KeyStroke{}, fmt.Errorf("unsupported key binding format: %s (supported: 'ctrl+w', '^w', 'C-w', 'alt+backspace', 'M-backspace', 'up', 'down', 'left', 'right', 'ctrl+left', 'shift+tab', 'home', 'end', 'pgup', 'pgdn', 'f1'-'f12')", keyStr)
 }
 
 // ParseKeyStrokes parses key binding configuration and returns []KeyStroke
@@ -423,6 +479,18 @@ func validateKeyStroke(ks KeyStroke) error { //nolint:revive // validation cover
 		if ks.Name == "" {
 			return fmt.Errorf("function key keystroke must have name")
 		}
+	case KeyStrokeChord:
+		if len(ks.Keys) < 2 {
+			return fmt.Errorf("chord must have at least two keystrokes")
+		}
+		for _, k := range ks.Keys {
+			if k.Kind == KeyStrokeChord {
+				return fmt.Errorf("chords cannot be nested")
+			}
+			if err := validateKeyStroke(k); err != nil {
+				return fmt.Errorf("chord key %s: %w", k, err)
+			}
+		}
 	default:
 		return fmt.Errorf("unknown keystroke kind: %v", ks.Kind)
 	}
@@ -487,6 +555,12 @@ func FormatKeyStrokeForDisplay(ks KeyStroke) string { //nolint:revive // handles
 		return fmt.Sprintf("Raw[%x]", ks.Seq)
 	case KeyStrokeFnKey:
 		return ks.Name
+	case KeyStrokeChord:
+		parts := make([]string, len(ks.Keys))
+		for i, k := range ks.Keys {
+			parts[i] = FormatKeyStrokeForDisplay(k)
+		}
+		return strings.Join(parts, " ")
 	default:
 		return fmt.Sprintf("Unknown[%v]", ks)
 	}
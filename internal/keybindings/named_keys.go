@@ -0,0 +1,241 @@
+package keybindings
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Named keys are keys that terminals report as multi-byte escape
+// sequences whose bytes vary between terminal types: function keys,
+// Home/End, PgUp/PgDn, Shift+Tab, Ctrl+Backspace and Ctrl, Shift or Alt
+// arrows. They are stored as KeyStrokeFnKey with a canonical Name ("F5",
+// "Ctrl+Left", "PgUp") so a binding written once matches whatever
+// sequence the running terminal sends.
+
+// Canonical names of the named keys.
+const (
+	KeyHome     = "Home"
+	KeyEnd      = "End"
+	KeyPgUp     = "PgUp"
+	KeyPgDn     = "PgDn"
+	KeyShiftTab = "Shift+Tab"
+	// KeyCtrlBackspace has a sequence of its own only where terminals
+	// report modified keys (xterm's modifyOtherKeys, the kitty keyboard
+	// protocol) and on Windows consoles; elsewhere it sends what
+	// Backspace or Ctrl+H does.
+	KeyCtrlBackspace = "Ctrl+Backspace"
+)
+
+// NewFnKeyStroke creates a named-key KeyStroke. name must be canonical;
+// use ParseKeyStroke for user input.
+func NewFnKeyStroke(name string) KeyStroke {
+	return KeyStroke{
+		Kind: KeyStrokeFnKey,
+		Name: name,
+	}
+}
+
+// namedKeyAliases maps accepted spellings (lowercase) to canonical names.
+// Function keys and modified arrows are handled by parseNamedKey.
+var namedKeyAliases = map[string]string{
+	"home":           KeyHome,
+	"end":            KeyEnd,
+	"pgup":           KeyPgUp,
+	"pageup":         KeyPgUp,
+	"page-up":        KeyPgUp,
+	"pgdn":           KeyPgDn,
+	"pgdown":         KeyPgDn,
+	"pagedown":       KeyPgDn,
+	"page-down":      KeyPgDn,
+	"shift+tab":      KeyShiftTab,
+	"s-tab":          KeyShiftTab,
+	"backtab":        KeyShiftTab,
+	"ctrl+backspace": KeyCtrlBackspace,
+	"c-backspace":    KeyCtrlBackspace,
+}
+
+// arrowNames maps arrow spellings to the direction used in canonical names.
+var arrowNames = map[string]string{
+	"left": "Left", "right": "Right", "up": "Up", "down": "Down",
+}
+
+// parseNamedKey recognizes named keys such as "f5", "home", "pgdn",
+// "shift+tab", "ctrl+left", "C-left" or "alt+left". s must already be
+// lowercase.
+func parseNamedKey(s string) (KeyStroke, bool) {
+	if name, ok := namedKeyAliases[s]; ok {
+		return NewFnKeyStroke(name), true
+	}
+	if n, ok := functionKeyNumber(s); ok {
+		return NewFnKeyStroke(fmt.Sprintf("F%d", n)), true
+	}
+	for _, mod := range []struct{ prefix, name string }{
+		{"ctrl+", "Ctrl"}, {"c-", "Ctrl"}, {"shift+", "Shift"}, {"s-", "Shift"},
+		{"alt+", "Alt"}, {"m-", "Alt"},
+	} {
+		if rest, ok := strings.CutPrefix(s, mod.prefix); ok {
+			if dir, ok := arrowNames[rest]; ok {
+				return NewFnKeyStroke(mod.name + "+" + dir), true
+			}
+		}
+	}
+	return KeyStroke{}, false
+}
+
+// functionKeyNumber parses "f1" through "f12".
+func functionKeyNumber(s string) (int, bool) {
+	var n int
+	if _, err := fmt.Sscanf(s, "f%d", &n); err != nil || fmt.Sprintf("f%d", n) != s {
+		return 0, false
+	}
+	return n, n >= 1 && n <= 12
+}
+
+// xtermSequences are the sequences sent by xterm and the many terminals
+// that copy it (iTerm2, Alacritty, kitty, WezTerm, GNOME Terminal, ...).
+// Home/End are listed in both normal and application cursor mode.
+var xtermSequences = map[string][]string{
+	"F1":        {"\x1bOP"},
+	"F2":        {"\x1bOQ"},
+	"F3":        {"\x1bOR"},
+	"F4":        {"\x1bOS"},
+	"F5":        {"\x1b[15~"},
+	"F6":        {"\x1b[17~"},
+	"F7":        {"\x1b[18~"},
+	"F8":        {"\x1b[19~"},
+	"F9":        {"\x1b[20~"},
+	"F10":       {"\x1b[21~"},
+	"F11":       {"\x1b[23~"},
+	"F12":       {"\x1b[24~"},
+	KeyHome:     {"\x1b[H", "\x1bOH"},
+	KeyEnd:      {"\x1b[F", "\x1bOF"},
+	KeyPgUp:     {"\x1b[5~"},
+	KeyPgDn:     {"\x1b[6~"},
+	KeyShiftTab: {"\x1b[Z"},
+	// modifyOtherKeys, then the kitty keyboard protocol.
+	KeyCtrlBackspace: {"\x1b[27;5;127~", "\x1b[127;5u"},
+	"Ctrl+Up":        {"\x1b[1;5A"},
+	"Ctrl+Down":      {"\x1b[1;5B"},
+	"Ctrl+Right":     {"\x1b[1;5C"},
+	"Ctrl+Left":      {"\x1b[1;5D"},
+	"Shift+Up":       {"\x1b[1;2A"},
+	"Shift+Down":     {"\x1b[1;2B"},
+	"Shift+Right":    {"\x1b[1;2C"},
+	"Shift+Left":     {"\x1b[1;2D"},
+	"Alt+Up":         {"\x1b[1;3A"},
+	"Alt+Down":       {"\x1b[1;3B"},
+	"Alt+Right":      {"\x1b[1;3C"},
+	"Alt+Left":       {"\x1b[1;3D"},
+}
+
+// metaArrows are Alt+arrows as terminals without modifier parameters send
+// them: ESC followed by the plain arrow.
+var metaArrows = map[string][]string{
+	"Alt+Up":    {"\x1b\x1b[A"},
+	"Alt+Down":  {"\x1b\x1b[B"},
+	"Alt+Right": {"\x1b\x1b[C"},
+	"Alt+Left":  {"\x1b\x1b[D"},
+}
+
+// terminalOverrides lists sequences that differ from xterm. Keys not
+// listed fall back to xtermSequences.
+var terminalOverrides = map[string]map[string][]string{
+	"rxvt": {
+		"F1":          {"\x1b[11~"},
+		"F2":          {"\x1b[12~"},
+		"F3":          {"\x1b[13~"},
+		"F4":          {"\x1b[14~"},
+		KeyHome:       {"\x1b[7~"},
+		KeyEnd:        {"\x1b[8~"},
+		"Ctrl+Up":     {"\x1bOa"},
+		"Ctrl+Down":   {"\x1bOb"},
+		"Ctrl+Right":  {"\x1bOc"},
+		"Ctrl+Left":   {"\x1bOd"},
+		"Shift+Up":    {"\x1b[a"},
+		"Shift+Down":  {"\x1b[b"},
+		"Shift+Right": {"\x1b[c"},
+		"Shift+Left":  {"\x1b[d"},
+		"Alt+Up":      metaArrows["Alt+Up"],
+		"Alt+Down":    metaArrows["Alt+Down"],
+		"Alt+Right":   metaArrows["Alt+Right"],
+		"Alt+Left":    metaArrows["Alt+Left"],
+	},
+	// tmux and screen translate Home/End to the VT220 codes. screen
+	// passes Alt+arrows on as ESC and the arrow; tmux sends the xterm
+	// form only with extended-keys on and the ESC form otherwise.
+	"tmux": {
+		KeyHome:     {"\x1b[1~"},
+		KeyEnd:      {"\x1b[4~"},
+		"Alt+Up":    {"\x1b[1;3A", "\x1b\x1b[A"},
+		"Alt+Down":  {"\x1b[1;3B", "\x1b\x1b[B"},
+		"Alt+Right": {"\x1b[1;3C", "\x1b\x1b[C"},
+		"Alt+Left":  {"\x1b[1;3D", "\x1b\x1b[D"},
+	},
+	"screen": {
+		KeyHome:     {"\x1b[1~"},
+		KeyEnd:      {"\x1b[4~"},
+		"Alt+Up":    metaArrows["Alt+Up"],
+		"Alt+Down":  metaArrows["Alt+Down"],
+		"Alt+Right": metaArrows["Alt+Right"],
+		"Alt+Left":  metaArrows["Alt+Left"],
+	},
+}
+
+// KeySequences returns the escape sequences terminal (as reported by
+// DetectTerminal) sends for the named key ks. It returns nil for
+// keystrokes that are not named keys.
+func KeySequences(ks KeyStroke, terminal string) [][]byte {
+	if ks.Kind != KeyStrokeFnKey {
+		return nil
+	}
+	seqs, ok := terminalOverrides[terminal][ks.Name]
+	if !ok {
+		seqs = xtermSequences[ks.Name]
+	}
+	out := make([][]byte, len(seqs))
+	for i, s := range seqs {
+		out[i] = []byte(s)
+	}
+	return out
+}
+
+// namedKeyBySequence is the reverse of every terminal's table, so input
+// decodes correctly even when DetectTerminal guesses wrong.
+var namedKeyBySequence = func() map[string]string {
+	m := make(map[string]string)
+	for name, seqs := range xtermSequences {
+		for _, s := range seqs {
+			m[s] = name
+		}
+	}
+	for _, table := range terminalOverrides {
+		for name, seqs := range table {
+			for _, s := range seqs {
+				m[s] = name
+			}
+		}
+	}
+	return m
+}()
+
+// DecodeSequence maps a raw escape sequence to the named key it stands
+// for, e.g. "\x1b[1;5D" to Ctrl+Left.
+func DecodeSequence(seq []byte) (KeyStroke, bool) {
+	name, ok := namedKeyBySequence[string(seq)]
+	if !ok {
+		return KeyStroke{}, false
+	}
+	return NewFnKeyStroke(name), true
+}
+
+// normalizeNamedKey converts raw sequences of named keys to their
+// KeyStrokeFnKey form and returns other keystrokes unchanged.
+func normalizeNamedKey(ks KeyStroke) KeyStroke {
+	if ks.Kind != KeyStrokeRawSeq {
+		return ks
+	}
+	if named, ok := DecodeSequence(ks.Seq); ok {
+		return named
+	}
+	return ks
+}
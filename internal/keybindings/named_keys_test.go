@@ -0,0 +1,81 @@
+package keybindings
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecodeSequence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		seq  string
+		want string
+	}{
+		{"\x1bOP", "F1"},
+		{"\x1b[11~", "F1"},
+		{"\x1b[24~", "F12"},
+		{"\x1b[Z", KeyShiftTab},
+		{"\x1b[1;5D", "Ctrl+Left"},
+		{"\x1bOd", "Ctrl+Left"},
+		{"\x1b[H", KeyHome},
+		{"\x1b[1~", KeyHome},
+		{"\x1b[8~", KeyEnd},
+		{"\x1b[5~", KeyPgUp},
+		{"\x1b[6~", KeyPgDn},
+		{"\x1b[27;5;127~", KeyCtrlBackspace},
+		{"\x1b[127;5u", KeyCtrlBackspace},
+		{"\x1b[1;3D", "Alt+Left"},
+		{"\x1b\x1b[C", "Alt+Right"},
+	}
+	for _, tt := range tests {
+		got, ok := DecodeSequence([]byte(tt.seq))
+		if !ok || !got.Equals(NewFnKeyStroke(tt.want)) {
+			t.Errorf("DecodeSequence(%q) = %v, %v; want %s", tt.seq, got, ok, tt.want)
+		}
+	}
+	if _, ok := DecodeSequence([]byte("\x1b[A")); ok {
+		t.Error("plain arrows should stay raw sequences")
+	}
+}
+
+func TestKeySequences_PerTerminal(t *testing.T) {
+	t.Parallel()
+
+	home := NewFnKeyStroke(KeyHome)
+	if got := KeySequences(home, "rxvt"); len(got) != 1 || !bytes.Equal(got[0], []byte("\x1b[7~")) {
+		t.Errorf("rxvt Home = %q", got)
+	}
+	if got := KeySequences(home, "tmux"); len(got) != 1 || !bytes.Equal(got[0], []byte("\x1b[1~")) {
+		t.Errorf("tmux Home = %q", got)
+	}
+	if got := KeySequences(home, "kitty"); len(got) != 2 || !bytes.Equal(got[0], []byte("\x1b[H")) {
+		t.Errorf("kitty Home = %q", got)
+	}
+	if got := KeySequences(NewFnKeyStroke("F5"), "rxvt"); len(got) != 1 || !bytes.Equal(got[0], []byte("\x1b[15~")) {
+		t.Errorf("rxvt F5 should fall back to xterm, got %q", got)
+	}
+	if got := KeySequences(NewFnKeyStroke("Alt+Left"), "screen"); len(got) != 1 || !bytes.Equal(got[0], []byte("\x1b\x1b[D")) {
+		t.Errorf("screen Alt+Left = %q", got)
+	}
+	if got := KeySequences(NewFnKeyStroke("Alt+Left"), "tmux"); len(got) != 2 {
+		t.Errorf("tmux Alt+Left should have both forms, got %q", got)
+	}
+	if got := KeySequences(NewCtrlKeyStroke('a'), "xterm"); got != nil {
+		t.Errorf("ctrl keys have no escape sequence, got %q", got)
+	}
+}
+
+func TestMatchesKeyStroke_NamedKeys(t *testing.T) {
+	t.Parallel()
+
+	km := &KeyBindingMap{MoveToEnd: []KeyStroke{NewFnKeyStroke(KeyEnd)}}
+	for _, seq := range []string{"\x1b[F", "\x1bOF", "\x1b[4~", "\x1b[8~"} {
+		if !km.MatchesKeyStroke("move_to_end", NewRawKeyStroke([]byte(seq))) {
+			t.Errorf("End binding should match %q", seq)
+		}
+	}
+	if km.MatchesKeyStroke("move_to_end", NewRawKeyStroke([]byte("\x1b[H"))) {
+		t.Error("End binding should not match Home")
+	}
+}
@@ -20,6 +20,13 @@ func TestParseKeyStrokeVariants(t *testing.T) {
 		{name: "emacs notation", input: "C-k", wantKind: KeyStrokeCtrl, wantRune: 'k'},
 		{name: "alt special", input: "Alt+Backspace", wantKind: KeyStrokeAlt, wantName: "backspace"},
 		{name: "meta letter", input: "M-b", wantKind: KeyStrokeAlt, wantRune: 'b'},
+		{name: "function key", input: "F12", wantKind: KeyStrokeFnKey, wantName: "F12"},
+		{name: "shift tab", input: "shift+tab", wantKind: KeyStrokeFnKey, wantName: "Shift+Tab"},
+		{name: "ctrl arrow", input: "Ctrl+Left", wantKind: KeyStrokeFnKey, wantName: "Ctrl+Left"},
+		{name: "emacs ctrl arrow", input: "C-right", wantKind: KeyStrokeFnKey, wantName: "Ctrl+Right"},
+		{name: "home", input: "home", wantKind: KeyStrokeFnKey, wantName: "Home"},
+		{name: "page down", input: "PageDown", wantKind: KeyStrokeFnKey, wantName: "PgDn"},
+		{name: "ctrl backspace", input: "Ctrl+Backspace", wantKind: KeyStrokeFnKey, wantName: "Ctrl+Backspace"},
 	}
 
 	for _, tt := range tests {
@@ -45,7 +52,7 @@ func TestParseKeyStrokeVariants(t *testing.T) {
 func TestParseKeyStrokeInvalid(t *testing.T) {
 	t.Parallel()
 
-	invalid := []string{"", "Alt+1", "Ctrl+", "Shift+A", "meta+unknown"}
+	invalid := []string{"", "Alt+1", "Ctrl+", "Shift+A", "meta+unknown", "f0", "f13", "ctrl+home"}
 	for _, input := range invalid {
 		input := input
 		t.Run(input, func(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"time"
 )
 
 // DetectPlatform identifies the current operating system platform
@@ -28,6 +29,15 @@ func DetectTerminal() string { //nolint:revive // terminal detection relies on h
 	term := os.Getenv("TERM")
 	termProgram := os.Getenv("TERM_PROGRAM")
 
+	// A multiplexer sits between ggc and the outer terminal, whose
+	// TERM_PROGRAM leaks through screen, so check for one first.
+	switch {
+	case os.Getenv("TMUX") != "" || termProgram == "tmux":
+		return "tmux"
+	case os.Getenv("STY") != "":
+		return "screen"
+	}
+
 	// Check TERM_PROGRAM first (more specific)
 	switch termProgram {
 	case "iTerm.app":
@@ -137,8 +147,9 @@ func GetPlatformSpecificKeyBindings(platform string) map[string][]KeyStroke {
 
 	case "windows":
 		// Windows specific bindings
-		// Windows typically uses Ctrl+Backspace for delete word
-		// NOTE: Ctrl+Backspace is not supported by NewCtrlKeyStroke; omitting until proper encoding is supported.
+		// Windows typically uses Ctrl+Backspace for delete word. The
+		// interactive UI deletes a word on Ctrl+Backspace wherever it is not
+		// bound to something else, so the profile's bindings stay as they are.
 
 	case "linux", "bsd", "unix":
 		// Unix-like systems - typically follow readline conventions
@@ -161,13 +172,13 @@ func GetTerminalSpecificKeyBindings(terminal string) map[string][]KeyStroke {
 
 	switch terminal {
 	case "tmux":
-		// tmux prefix handling - these would need special handling
-		// For now, just document that some keys might need prefix
+		// tmux's prefix, Ctrl+B, is bound to nothing by default.
 		break
 
 	case "screen":
-		// GNU Screen specific adjustments
-		break
+		// screen swallows its prefix, Ctrl+A, so offer Home for the
+		// action every profile binds to it.
+		terminalBindings["move_to_beginning"] = []KeyStroke{NewCtrlKeyStroke('a'), NewFnKeyStroke(KeyHome)}
 
 	case "iterm":
 		// iTerm2 specific features
@@ -184,6 +195,44 @@ func GetTerminalSpecificKeyBindings(terminal string) map[string][]KeyStroke {
 	return terminalBindings
 }
 
+// Multiplexer describes a terminal multiplexer ggc runs inside.
+type Multiplexer struct {
+	Name string
+	// Prefix is the key the multiplexer keeps for its own commands; ggc
+	// only sees it when it is sent through as Passthrough says.
+	Prefix      KeyStroke
+	Passthrough string
+}
+
+// multiplexers are the multiplexers DetectTerminal recognizes, with their
+// default prefixes.
+var multiplexers = map[string]Multiplexer{
+	"tmux":   {Name: "tmux", Prefix: NewCtrlKeyStroke('b'), Passthrough: "press Ctrl+b twice"},
+	"screen": {Name: "screen", Prefix: NewCtrlKeyStroke('a'), Passthrough: "press Ctrl+a then a"},
+}
+
+// MultiplexerFor returns the multiplexer terminal (as reported by
+// DetectTerminal) stands for, if any.
+func MultiplexerFor(terminal string) (Multiplexer, bool) {
+	m, ok := multiplexers[terminal]
+	return m, ok
+}
+
+// multiplexerEscapeTimeout is how long a lone ESC waits for the rest of a
+// sequence inside a multiplexer, which forwards a sequence's bytes in
+// separate writes when they arrive from the outer terminal that way.
+const multiplexerEscapeTimeout = 50 * time.Millisecond
+
+// EscapeTimeout returns how long the interactive UI waits after ESC
+// before taking it as a key of its own in terminal. Elsewhere a sequence
+// arrives in one read, so ESC is only checked against what is pending.
+func EscapeTimeout(terminal string) time.Duration {
+	if _, ok := MultiplexerFor(terminal); ok {
+		return multiplexerEscapeTimeout
+	}
+	return 0
+}
+
 // detectConflicts finds duplicate key assignments in a KeyBindingMap (legacy compatibility)
 func detectConflicts(keyMap *KeyBindingMap) []string {
 	// Convert to extended format and use newer conflict detection
@@ -193,40 +242,47 @@ func detectConflicts(keyMap *KeyBindingMap) []string {
 // detectConflictsV2 finds duplicate KeyStroke assignments in a KeyBindingMap (extended)
 func detectConflictsV2(keyMap *KeyBindingMap) []string {
 	var conflicts []string
+	for keystroke, actions := range conflictingActions(keyMap) {
+		conflicts = append(conflicts, fmt.Sprintf("keystroke %s assigned to: %v", keystroke, actions))
+	}
+	return conflicts
+}
 
-	// Build a map of KeyStrokes to actions
-	keystrokeToActions := make(map[string][]string)
+// conflictActions are the actions checked for conflicts, in report order.
+// The history and undo actions are left out: they share keys with the
+// movement actions on purpose, in the contexts that use them.
+var conflictActions = []string{
+	"delete_word",
+	"clear_line",
+	"delete_to_end",
+	"move_to_beginning",
+	"move_to_end",
+	"move_up",
+	"move_down",
+	"move_left",
+	"move_right",
+	"add_to_workflow",
+	"toggle_workflow_view",
+	"clear_workflow",
+}
 
-	// Helper function to add KeyStrokes to conflict map
-	addKeyStrokes := func(keyStrokes []KeyStroke, action string) {
-		for _, ks := range keyStrokes {
+// conflictingActions maps each keystroke bound to more than one action to
+// those actions.
+func conflictingActions(keyMap *KeyBindingMap) map[string][]string {
+	bindings := keyMap.actionBindings()
+	keystrokeToActions := make(map[string][]string)
+	for _, action := range conflictActions {
+		for _, ks := range bindings[action] {
 			key := ks.String()
 			keystrokeToActions[key] = append(keystrokeToActions[key], action)
 		}
 	}
-
-	// Add all actions
-	addKeyStrokes(keyMap.DeleteWord, "delete_word")
-	addKeyStrokes(keyMap.ClearLine, "clear_line")
-	addKeyStrokes(keyMap.DeleteToEnd, "delete_to_end")
-	addKeyStrokes(keyMap.MoveToBeginning, "move_to_beginning")
-	addKeyStrokes(keyMap.MoveToEnd, "move_to_end")
-	addKeyStrokes(keyMap.MoveUp, "move_up")
-	addKeyStrokes(keyMap.MoveDown, "move_down")
-	addKeyStrokes(keyMap.MoveLeft, "move_left")
-	addKeyStrokes(keyMap.MoveRight, "move_right")
-	addKeyStrokes(keyMap.AddToWorkflow, "add_to_workflow")
-	addKeyStrokes(keyMap.ToggleWorkflowView, "toggle_workflow_view")
-	addKeyStrokes(keyMap.ClearWorkflow, "clear_workflow")
-
-	// Find conflicts (multiple actions for same keystroke)
 	for keystroke, actions := range keystrokeToActions {
-		if len(actions) > 1 {
-			conflicts = append(conflicts, fmt.Sprintf("keystroke %s assigned to: %v", keystroke, actions))
+		if len(actions) < 2 {
+			delete(keystrokeToActions, keystroke)
 		}
 	}
-
-	return conflicts
+	return keystrokeToActions
 }
 
 // PlatformOptimizations provides platform-specific keybinding recommendations.
@@ -6,6 +6,8 @@ import "testing"
 
 func TestDetectTerminal_TermProgram(t *testing.T) {
 	t.Setenv("TERM", "")
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "")
 
 	cases := []struct {
 		prog string
@@ -15,6 +17,7 @@ func TestDetectTerminal_TermProgram(t *testing.T) {
 		{"Apple_Terminal", "terminal"},
 		{"vscode", "vscode"},
 		{"Hyper", "hyper"},
+		{"tmux", "tmux"},
 	}
 	for _, c := range cases {
 		t.Setenv("TERM_PROGRAM", c.prog)
@@ -26,6 +29,8 @@ func TestDetectTerminal_TermProgram(t *testing.T) {
 
 func TestDetectTerminal_TERM(t *testing.T) {
 	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "")
 
 	cases := []struct {
 		term string
@@ -51,6 +56,23 @@ func TestDetectTerminal_TERM(t *testing.T) {
 	}
 }
 
+func TestDetectTerminal_Multiplexer(t *testing.T) {
+	// The outer terminal's variables leak into the multiplexer.
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("TERM_PROGRAM", "iTerm.app")
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	t.Setenv("STY", "")
+	if got := DetectTerminal(); got != "tmux" {
+		t.Errorf("with $TMUX: want tmux, got %q", got)
+	}
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "1234.pts-0.host")
+	if got := DetectTerminal(); got != "screen" {
+		t.Errorf("with $STY: want screen, got %q", got)
+	}
+}
+
 // ── GetTerminalCapabilities ──────────────────────────────────────────────────
 
 func TestGetTerminalCapabilities_StandardTerminals(t *testing.T) {
@@ -156,3 +178,36 @@ func TestGetTerminalSpecificKeyBindings_All(t *testing.T) {
 		_ = bindings // all return empty map — no panic = pass
 	}
 }
+
+func TestGetTerminalSpecificKeyBindings_Screen(t *testing.T) {
+	got := GetTerminalSpecificKeyBindings("screen")["move_to_beginning"]
+	want := []KeyStroke{NewCtrlKeyStroke('a'), NewFnKeyStroke(KeyHome)}
+	if len(got) != len(want) || !got[0].Equals(want[0]) || !got[1].Equals(want[1]) {
+		t.Errorf("screen move_to_beginning = %v, want %v", got, want)
+	}
+}
+
+// ── Multiplexers ─────────────────────────────────────────────────────────────
+
+func TestMultiplexerFor(t *testing.T) {
+	if m, ok := MultiplexerFor("tmux"); !ok || !m.Prefix.Equals(NewCtrlKeyStroke('b')) {
+		t.Errorf("tmux = %+v, %v", m, ok)
+	}
+	if m, ok := MultiplexerFor("screen"); !ok || !m.Prefix.Equals(NewCtrlKeyStroke('a')) {
+		t.Errorf("screen = %+v, %v", m, ok)
+	}
+	if _, ok := MultiplexerFor("xterm"); ok {
+		t.Error("xterm is not a multiplexer")
+	}
+}
+
+func TestEscapeTimeout(t *testing.T) {
+	for _, terminal := range []string{"tmux", "screen"} {
+		if got := EscapeTimeout(terminal); got <= 0 {
+			t.Errorf("%s: EscapeTimeout = %v, want a wait", terminal, got)
+		}
+	}
+	if got := EscapeTimeout("xterm"); got != 0 {
+		t.Errorf("xterm: EscapeTimeout = %v, want 0", got)
+	}
+}
@@ -8,11 +8,11 @@ func CreateEmacsProfile() *KeyBindingProfile {
 		Description: "Comprehensive Emacs-style keybindings with authentic GNU Emacs behavior",
 		Global: map[string][]KeyStroke{
 			// Core Emacs global bindings
-			"quit":                {NewCtrlKeyStroke('g')},                        // C-g keyboard-quit
-			"help":                {NewCtrlKeyStroke('h')},                        // C-h help-command
-			"universal_argument":  {NewCtrlKeyStroke('u')},                        // C-u universal-argument
-			"exchange_point_mark": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('x')}, // C-x C-x (chord)
-			"suspend":             {NewCtrlKeyStroke('z')},                        // C-z suspend-frame
+			"quit":                {NewCtrlKeyStroke('g')},                                           // C-g keyboard-quit
+			"help":                {NewCtrlKeyStroke('h')},                                           // C-h help-command
+			"universal_argument":  {NewCtrlKeyStroke('u')},                                           // C-u universal-argument
+			"exchange_point_mark": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('x'))}, // C-x C-x (chord)
+			"suspend":             {NewCtrlKeyStroke('z')},                                           // C-z suspend-frame
 		},
 		Contexts: map[Context]map[string][]KeyStroke{
 			ContextGlobal: {
@@ -70,13 +70,13 @@ func CreateEmacsProfile() *KeyBindingProfile {
 				"kill_region":         {NewCtrlKeyStroke('w')},    // C-w kill-region
 
 				// Mark and region
-				"set_mark_command":    {NewCtrlKeyStroke(' ')},                        // C-SPC set-mark-command
-				"exchange_point_mark": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('x')}, // C-x C-x exchange-point-mark
+				"set_mark_command":    {NewCtrlKeyStroke(' ')},                                           // C-SPC set-mark-command
+				"exchange_point_mark": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('x'))}, // C-x C-x exchange-point-mark
 
 				// Buffer and file operations (adapted for CLI)
-				"save_buffer":      {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('s')}, // C-x C-s save-buffer
-				"find_file":        {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('f')}, // C-x C-f find-file
-				"switch_to_buffer": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('b')}, // C-x C-b switch-to-buffer
+				"save_buffer":      {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('s'))}, // C-x C-s save-buffer
+				"find_file":        {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('f'))}, // C-x C-f find-file
+				"switch_to_buffer": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('b'))}, // C-x C-b switch-to-buffer
 
 				// Miscellaneous
 				"quoted_insert":           {NewCtrlKeyStroke('q')},     // C-q quoted-insert
@@ -100,8 +100,8 @@ func CreateEmacsProfile() *KeyBindingProfile {
 				"end_of_buffer":       {NewAltKeyStroke('>', "")}, // M-> end-of-buffer
 
 				// Selection and marking
-				"set_mark_command":  {NewCtrlKeyStroke(' ')},                        // C-SPC set-mark-command
-				"mark_whole_buffer": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('h')}, // C-x h mark-whole-buffer
+				"set_mark_command":  {NewCtrlKeyStroke(' ')},                                           // C-SPC set-mark-command
+				"mark_whole_buffer": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('h'))}, // C-x h mark-whole-buffer
 
 				// Search in results
 				"isearch_forward":  {NewCtrlKeyStroke('s')}, // C-s isearch-forward
@@ -47,11 +47,11 @@ func CreateReadlineProfile() *KeyBindingProfile {
 				"delete_word":        {NewCtrlKeyStroke('w')},             // Alias for compatibility
 
 				// Line Killing and Yanking
-				"kill_line":         {NewCtrlKeyStroke('k')},                        // C-k kill-line
-				"unix_line_discard": {NewCtrlKeyStroke('u')},                        // C-u unix-line-discard
-				"kill_whole_line":   {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('k')}, // C-x C-k kill-whole-line
-				"clear_line":        {NewCtrlKeyStroke('u')},                        // Alias
-				"delete_to_end":     {NewCtrlKeyStroke('k')},                        // Alias
+				"kill_line":         {NewCtrlKeyStroke('k')},                                           // C-k kill-line
+				"unix_line_discard": {NewCtrlKeyStroke('u')},                                           // C-u unix-line-discard
+				"kill_whole_line":   {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('k'))}, // C-x C-k kill-whole-line
+				"clear_line":        {NewCtrlKeyStroke('u')},                                           // Alias
+				"delete_to_end":     {NewCtrlKeyStroke('k')},                                           // Alias
 
 				// Yank and Kill Ring
 				"yank":          {NewCtrlKeyStroke('y')},    // C-y yank
@@ -90,21 +90,21 @@ func CreateReadlineProfile() *KeyBindingProfile {
 				"universal_argument": {NewCtrlKeyStroke('u')},    // C-u universal-argument
 
 				// Miscellaneous
-				"quoted_insert":           {NewCtrlKeyStroke('v')},                        // C-v quoted-insert
-				"tab_insert":              {NewAltKeyStroke('\t', "")},                    // M-TAB tab-insert
-				"tilde_expand":            {NewAltKeyStroke('&', "")},                     // M-& tilde-expand
-				"set_mark":                {NewCtrlKeyStroke(' ')},                        // C-SPC set-mark
-				"exchange_point_and_mark": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('x')}, // C-x C-x exchange-point-and-mark
+				"quoted_insert":           {NewCtrlKeyStroke('v')},                                           // C-v quoted-insert
+				"tab_insert":              {NewAltKeyStroke('\t', "")},                                       // M-TAB tab-insert
+				"tilde_expand":            {NewAltKeyStroke('&', "")},                                        // M-& tilde-expand
+				"set_mark":                {NewCtrlKeyStroke(' ')},                                           // C-SPC set-mark
+				"exchange_point_and_mark": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('x'))}, // C-x C-x exchange-point-and-mark
 
 				// Editing Commands
-				"overwrite_mode": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('o')}, // C-x C-o overwrite-mode
-				"undo":           {NewCtrlKeyStroke('_')},                        // C-_ undo
-				"revert_line":    {NewAltKeyStroke('r', "")},                     // M-r revert-line
+				"overwrite_mode": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('o'))}, // C-x C-o overwrite-mode
+				"undo":           {NewCtrlKeyStroke('_')},                                           // C-_ undo
+				"revert_line":    {NewAltKeyStroke('r', "")},                                        // M-r revert-line
 
 				// Shell Integration
-				"glob_complete_word":   {NewAltKeyStroke('g', "")},                     // M-g glob-complete-word
-				"glob_expand_word":     {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('*')}, // C-x * glob-expand-word
-				"glob_list_expansions": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('g')}, // C-x g glob-list-expansions
+				"glob_complete_word":   {NewAltKeyStroke('g', "")},                                        // M-g glob-complete-word
+				"glob_expand_word":     {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('*'))}, // C-x * glob-expand-word
+				"glob_list_expansions": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('g'))}, // C-x g glob-list-expansions
 
 				// Line Editing
 				"accept_line": {NewRawKeyStroke([]byte{13})}, // RET accept-line
@@ -115,14 +115,14 @@ func CreateReadlineProfile() *KeyBindingProfile {
 				"bracketed_paste_begin": {NewRawKeyStroke([]byte{27, 91, 50, 48, 48, 126})}, // bracketed paste mode
 
 				// Macro Operations
-				"start_kbd_macro":     {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('(')}, // C-x ( start-kbd-macro
-				"end_kbd_macro":       {NewCtrlKeyStroke('x'), NewCtrlKeyStroke(')')}, // C-x ) end-kbd-macro
-				"call_last_kbd_macro": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('e')}, // C-x e call-last-kbd-macro
+				"start_kbd_macro":     {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('('))}, // C-x ( start-kbd-macro
+				"end_kbd_macro":       {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke(')'))}, // C-x ) end-kbd-macro
+				"call_last_kbd_macro": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('e'))}, // C-x e call-last-kbd-macro
 
 				// Advanced Readline Features
-				"dump_functions": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('f')}, // C-x C-f dump-functions
-				"dump_variables": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('v')}, // C-x C-v dump-variables
-				"dump_macros":    {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('m')}, // C-x C-m dump-macros
+				"dump_functions": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('f'))}, // C-x C-f dump-functions
+				"dump_variables": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('v'))}, // C-x C-v dump-variables
+				"dump_macros":    {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('m'))}, // C-x C-m dump-macros
 
 				// Menu Complete (bash 4.0+)
 				"menu_complete":          {NewAltKeyStroke('\t', "")}, // M-TAB menu-complete
@@ -160,13 +160,13 @@ func CreateReadlineProfile() *KeyBindingProfile {
 				"forward_search_history": {NewCtrlKeyStroke('s')}, // C-s forward-search
 
 				// Mark and selection
-				"set_mark":                {NewCtrlKeyStroke(' ')},                        // C-SPC set-mark
-				"exchange_point_and_mark": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('x')}, // C-x C-x exchange-point-and-mark
+				"set_mark":                {NewCtrlKeyStroke(' ')},                                           // C-SPC set-mark
+				"exchange_point_and_mark": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('x'))}, // C-x C-x exchange-point-and-mark
 
 				// Workflow operations (Readline style)
-				"add_to_workflow":      {NewRawKeyStroke([]byte{9})},                   // Tab
-				"toggle_workflow_view": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('w')}, // C-x C-w workflow
-				"clear_workflow":       {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('c')}, // C-x C-c clear
+				"add_to_workflow":      {NewRawKeyStroke([]byte{9})},                                      // Tab
+				"toggle_workflow_view": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('w'))}, // C-x C-w workflow
+				"clear_workflow":       {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('c'))}, // C-x C-c clear
 			},
 			ContextSearch: {
 				// Search mode using Readline search conventions
@@ -185,6 +185,7 @@ func CreateReadlineProfile() *KeyBindingProfile {
 				"kill_line":            {NewCtrlKeyStroke('k')}, // C-k kill-line
 				"unix_line_discard":    {NewCtrlKeyStroke('u')}, // C-u unix-line-discard
 				"delete_word":          {NewCtrlKeyStroke('w')}, // C-w delete-word
+				"undo":                 {NewCtrlKeyStroke('_')}, // C-_ undo
 
 				// Search string movement
 				"forward_char":      {NewCtrlKeyStroke('f')}, // C-f forward-char
@@ -205,9 +206,9 @@ func CreateReadlineProfile() *KeyBindingProfile {
 				"yank_last_arg": {NewAltKeyStroke('.', "")}, // M-. yank-last-arg
 
 				// Workflow operations (search context)
-				"add_to_workflow":      {NewRawKeyStroke([]byte{9})},                   // Tab
-				"toggle_workflow_view": {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('w')}, // C-x C-w workflow
-				"clear_workflow":       {NewCtrlKeyStroke('x'), NewCtrlKeyStroke('c')}, // C-x C-c clear
+				"add_to_workflow":      {NewRawKeyStroke([]byte{9})},                                      // Tab
+				"toggle_workflow_view": {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('w'))}, // C-x C-w workflow
+				"clear_workflow":       {NewChordKeyStroke(NewCtrlKeyStroke('x'), NewCtrlKeyStroke('c'))}, // C-x C-c clear
 			},
 		},
 	}
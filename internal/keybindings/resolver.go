@@ -75,7 +75,20 @@ func (r *KeyBindingResolver) Resolve(profile Profile, context Context) (*KeyBind
 	}
 
 	// Create new KeyBindingMap for this context
-	result := &KeyBindingMap{
+	result := newEmptyKeyBindingMap()
+	for _, layer := range r.layers(profile, context) {
+		layer.apply(result)
+	}
+
+	// Cache the result
+	r.cacheResult(profile, context, result)
+
+	return result, nil
+}
+
+// newEmptyKeyBindingMap is the map the resolution layers start from.
+func newEmptyKeyBindingMap() *KeyBindingMap {
+	return &KeyBindingMap{
 		DeleteWord:         []KeyStroke{},
 		ClearLine:          []KeyStroke{},
 		DeleteToEnd:        []KeyStroke{},
@@ -87,33 +100,6 @@ func (r *KeyBindingResolver) Resolve(profile Profile, context Context) (*KeyBind
 		ToggleWorkflowView: []KeyStroke{},
 		ClearWorkflow:      []KeyStroke{},
 	}
-
-	// Layer 1: Built-in defaults
-	r.applyDefaults(result)
-
-	// Layer 2: Profile base
-	if prof, exists := r.profiles[profile]; exists {
-		r.applyProfile(result, prof, context)
-	}
-
-	// Layer 3: Platform layer
-	r.applyPlatformLayer(result)
-
-	// Layer 4: Terminal layer
-	r.applyTerminalLayer(result)
-
-	// Layer 5: User config
-	if r.userConfig != nil {
-		r.applyUserConfig(result, context)
-	}
-
-	// Layer 6: Environment overrides
-	r.applyEnvironmentOverrides(result)
-
-	// Cache the result
-	r.cacheResult(profile, context, result)
-
-	return result, nil
 }
 
 // ResolveContextual resolves all contexts for a profile
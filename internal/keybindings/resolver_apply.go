@@ -17,6 +17,7 @@ func (r *KeyBindingResolver) applyDefaults(keyMap *KeyBindingMap) {
 	keyMap.WorkflowCreate = append(keyMap.WorkflowCreate, defaults.WorkflowCreate...)
 	keyMap.WorkflowDelete = append(keyMap.WorkflowDelete, defaults.WorkflowDelete...)
 	keyMap.SoftCancel = append(keyMap.SoftCancel, defaults.SoftCancel...)
+	keyMap.Suspend = append(keyMap.Suspend, defaults.Suspend...)
 }
 
 func (r *KeyBindingResolver) applyProfile(keyMap *KeyBindingMap, profile *KeyBindingProfile, context Context) {
@@ -48,6 +49,9 @@ func (r *KeyBindingResolver) applyProfile(keyMap *KeyBindingMap, profile *KeyBin
 	applyBinding("history_prev", &keyMap.HistoryPrev)
 	applyBinding("history_next", &keyMap.HistoryNext)
 	applyBinding("history_search", &keyMap.HistorySearch)
+	applyBinding("undo", &keyMap.Undo)
+	applyBinding("redo", &keyMap.Redo)
+	applyBinding("suspend", &keyMap.Suspend)
 }
 
 func (r *KeyBindingResolver) applyPlatformLayer(keyMap *KeyBindingMap) {
@@ -134,6 +138,7 @@ func (r *KeyBindingResolver) applyWorkflowAction(keyMap *KeyBindingMap, action s
 		"workflow_create":      &keyMap.WorkflowCreate,
 		"workflow_delete":      &keyMap.WorkflowDelete,
 		"soft_cancel":          &keyMap.SoftCancel,
+		"suspend":              &keyMap.Suspend,
 	}
 
 	if target, exists := actionMap[action]; exists {
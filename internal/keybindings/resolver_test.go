@@ -131,6 +131,29 @@ func TestKeyBindingResolverLayerPrecedence(t *testing.T) {
 	}
 }
 
+func TestKeyBindingResolverConflicts(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Interactive.Contexts.Search.Keybindings = map[string]interface{}{
+		"move_down": "Ctrl+W",
+	}
+
+	resolver := NewKeyBindingResolver(cfg)
+	resolver.ForceEnvironment("windows", "generic")
+	RegisterBuiltinProfiles(resolver)
+
+	conflicts := resolver.Conflicts(ProfileDefault)
+	if len(conflicts) != 1 {
+		t.Fatalf("expected one conflict, got %v", conflicts)
+	}
+	want := "search: Ctrl+w is bound to delete_word (built-in defaults) and move_down (interactive.contexts.search.keybindings)"
+	if got := conflicts[0].String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := conflicts[0].Fix(); got != "bind move_down to another key in interactive.contexts.search.keybindings" {
+		t.Errorf("Fix() = %q", got)
+	}
+}
+
 func TestResolveContextualAppliesOverridesPerContext(t *testing.T) {
 	cfg := &config.Config{}
 	cfg.Interactive.Contexts.Input.Keybindings = map[string]interface{}{
@@ -2,8 +2,8 @@ package keybindings
 
 import "os"
 
-func (r *KeyBindingResolver) applyUserConfig(keyMap *KeyBindingMap, context Context) { //nolint:revive // layered override logic retained for clarity
-	// Apply user global keybindings first
+// applyUserGlobalBindings applies interactive.keybindings.
+func (r *KeyBindingResolver) applyUserGlobalBindings(keyMap *KeyBindingMap) { //nolint:revive // layered override logic retained for clarity
 	userBindings := r.userConfig.Interactive.Keybindings
 
 	userValues := map[string]string{
@@ -63,15 +63,6 @@ func (r *KeyBindingResolver) applyUserConfig(keyMap *KeyBindingMap, context Cont
 			}
 		}
 	}
-
-	// Apply context-specific user bindings
-	r.applyUserContextBindings(keyMap, context)
-
-	// Apply platform-specific user bindings
-	r.applyUserPlatformBindings(keyMap)
-
-	// Apply terminal-specific user bindings
-	r.applyUserTerminalBindings(keyMap)
 }
 
 func (r *KeyBindingResolver) applyEnvironmentOverrides(keyMap *KeyBindingMap) {
@@ -90,6 +81,7 @@ func (r *KeyBindingResolver) applyEnvironmentOverrides(keyMap *KeyBindingMap) {
 		"GGC_KEYBIND_WORKFLOW_CREATE":      &keyMap.WorkflowCreate,
 		"GGC_KEYBIND_WORKFLOW_DELETE":      &keyMap.WorkflowDelete,
 		"GGC_KEYBIND_SOFT_CANCEL":          &keyMap.SoftCancel,
+		"GGC_KEYBIND_SUSPEND":              &keyMap.Suspend,
 	}
 
 	for envVar, target := range envOverrides {
@@ -221,6 +213,7 @@ func (r *KeyBindingResolver) applyUserWorkflowAction(keyMap *KeyBindingMap, acti
 		"workflow_create":      &keyMap.WorkflowCreate,
 		"workflow_delete":      &keyMap.WorkflowDelete,
 		"soft_cancel":          &keyMap.SoftCancel,
+		"suspend":              &keyMap.Suspend,
 	}
 
 	if target, exists := actionMap[action]; exists {
@@ -0,0 +1,95 @@
+// Package logging sets up the log/slog logger that traces the git
+// commands ggc runs. --verbose logs each command with its duration,
+// --debug adds its directory, environment, exit code and stderr, and
+// GGC_LOG_FILE sends the log to a file instead of stderr.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// EnvLogFile names a file that receives the log, appended to, instead of
+// stderr.
+const EnvLogFile = "GGC_LOG_FILE"
+
+// Level is how much is logged.
+type Level int
+
+// Levels, from quietest to most detailed.
+const (
+	LevelOff Level = iota
+	LevelVerbose
+	LevelDebug
+)
+
+func (l Level) slogLevel() slog.Level {
+	if l == LevelDebug {
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
+
+// IsFlag reports whether arg is one of the logging flags, so that the other
+// global flag parsers can let it through.
+func IsFlag(arg string) bool {
+	return arg == "--verbose" || arg == "--debug"
+}
+
+// SplitFlags removes the global --verbose and --debug flags that precede
+// the command name, returning the remaining arguments and the level they
+// select. --debug wins over --verbose.
+func SplitFlags(args []string) ([]string, Level) {
+	level := LevelOff
+	for len(args) > 0 {
+		switch args[0] {
+		case "--verbose":
+			level = max(level, LevelVerbose)
+		case "--debug":
+			level = LevelDebug
+		default:
+			return args, level
+		}
+		args = args[1:]
+	}
+	return args, level
+}
+
+// New returns the logger for level, writing to stderr, or to the file
+// named by GGC_LOG_FILE. A log file without a level flag gets the debug
+// log, so that a bug report only needs the variable set. The returned
+// function closes the file; the logger is nil when nothing is logged.
+func New(level Level, stderr io.Writer, getenv func(string) string) (*slog.Logger, func(), error) {
+	noop := func() {}
+	path := strings.TrimSpace(getenv(EnvLogFile))
+	if path == "" {
+		if level == LevelOff {
+			return nil, noop, nil
+		}
+		return slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{
+			Level:       level.slogLevel(),
+			ReplaceAttr: dropTime,
+		})), noop, nil
+	}
+	if level == LevelOff {
+		level = LevelDebug
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, noop, fmt.Errorf("open %s: %w", EnvLogFile, err)
+	}
+	logger := slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: level.slogLevel()}))
+	return logger, func() { _ = f.Close() }, nil
+}
+
+// dropTime leaves the timestamp out of terminal output, where it is only
+// noise next to the command that just ran.
+func dropTime(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+	return a
+}
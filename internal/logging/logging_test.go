@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		wantRest  []string
+		wantLevel Level
+	}{
+		{[]string{"status"}, []string{"status"}, LevelOff},
+		{[]string{"--verbose", "status"}, []string{"status"}, LevelVerbose},
+		{[]string{"--debug", "--verbose", "push"}, []string{"push"}, LevelDebug},
+		{[]string{"log", "--verbose"}, []string{"log", "--verbose"}, LevelOff},
+	}
+	for _, tt := range tests {
+		rest, level := SplitFlags(tt.args)
+		if !reflect.DeepEqual(rest, tt.wantRest) || level != tt.wantLevel {
+			t.Errorf("SplitFlags(%v) = %v, %v", tt.args, rest, level)
+		}
+	}
+}
+
+func TestNew_Stderr(t *testing.T) {
+	noEnv := func(string) string { return "" }
+	if logger, _, err := New(LevelOff, &bytes.Buffer{}, noEnv); err != nil || logger != nil {
+		t.Errorf("New(LevelOff) = %v, %v; want no logger", logger, err)
+	}
+
+	var buf bytes.Buffer
+	logger, closeLog, err := New(LevelVerbose, &buf, noEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeLog()
+	logger.Info("git status")
+	logger.Debug("hidden")
+	if got := buf.String(); got != "level=INFO msg=\"git status\"\n" {
+		t.Errorf("log = %q", got)
+	}
+}
+
+func TestNew_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ggc.log")
+	getenv := func(key string) string {
+		if key == EnvLogFile {
+			return path
+		}
+		return ""
+	}
+	var stderr bytes.Buffer
+	logger, closeLog, err := New(LevelOff, &stderr, getenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("git fetch", "exit", 0)
+	closeLog()
+
+	if stderr.Len() != 0 {
+		t.Errorf("a log file should keep stderr clean, got %q", stderr.String())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatalf("log file is not JSON: %q", data)
+	}
+	if entry["msg"] != "git fetch" || entry["level"] != "DEBUG" {
+		t.Errorf("entry = %v", entry)
+	}
+
+	if _, _, err := New(LevelVerbose, &stderr, func(string) string { return filepath.Join(path, "nested") }); err == nil || !strings.Contains(err.Error(), EnvLogFile) {
+		t.Errorf("New with an unwritable log file: %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+// Package patch parses unified diffs into files and hunks and renders
+// single hunks back into patches that `git apply` accepts.
+package patch
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@(.*)$`)
+
+// FileDiff is the part of a diff that touches one file.
+type FileDiff struct {
+	Header  []string // "diff --git", index, mode and ---/+++ lines
+	OldPath string
+	NewPath string
+	Binary  bool
+	Hunks   []*Hunk
+}
+
+// Path returns the path to show for the file, preferring the new name.
+func (f *FileDiff) Path() string {
+	if f.NewPath != "" && f.NewPath != "/dev/null" {
+		return f.NewPath
+	}
+	return f.OldPath
+}
+
+// Patch renders h as a standalone patch for this file.
+func (f *FileDiff) Patch(h *Hunk) string {
+	var b strings.Builder
+	for _, line := range f.Header {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteString(h.Header())
+	b.WriteByte('\n')
+	for _, line := range h.Lines {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Hunk is one @@ section of a file diff. Lines keep their leading
+// ' ', '+', '-' or '\' marker.
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Section  string // text after the closing @@, usually a function name
+	Lines    []string
+}
+
+// Header renders the @@ line for h.
+func (h *Hunk) Header() string {
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@%s", h.OldStart, h.OldLines, h.NewStart, h.NewLines, h.Section)
+}
+
+// Split breaks h into one hunk per contiguous run of changes, the same
+// way `git add -p` does for its "s" answer. Context between two runs is
+// kept in both neighbors so each piece still applies on its own. A hunk
+// with a single run of changes is returned unchanged.
+func (h *Hunk) Split() []*Hunk {
+	type run struct{ start, end int } // [start, end) indexes into h.Lines
+	var runs []run
+	for i := 0; i < len(h.Lines); {
+		if !isChange(h.Lines[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(h.Lines) && isChange(h.Lines[i]) {
+			i++
+		}
+		runs = append(runs, run{start, i})
+	}
+	if len(runs) < 2 {
+		return []*Hunk{h}
+	}
+
+	pieces := make([]*Hunk, 0, len(runs))
+	for i := range runs {
+		from := 0
+		if i > 0 {
+			from = runs[i-1].end
+		}
+		to := len(h.Lines)
+		if i < len(runs)-1 {
+			to = runs[i+1].start
+		}
+		oldBefore, newBefore := countLines(h.Lines[:from])
+		piece := &Hunk{
+			OldStart: h.OldStart + oldBefore,
+			NewStart: h.NewStart + newBefore,
+			Section:  h.Section,
+			Lines:    append([]string(nil), h.Lines[from:to]...),
+		}
+		piece.OldLines, piece.NewLines = countLines(piece.Lines)
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// isChange reports whether line is an addition, a removal, or the
+// "\ No newline at end of file" marker that belongs to one.
+func isChange(line string) bool {
+	return strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") || strings.HasPrefix(line, `\`)
+}
+
+// countLines returns how many old-side and new-side lines lines span.
+func countLines(lines []string) (int, int) {
+	var oldN, newN int
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			newN++
+		case strings.HasPrefix(line, "-"):
+			oldN++
+		case strings.HasPrefix(line, `\`):
+		default:
+			oldN++
+			newN++
+		}
+	}
+	return oldN, newN
+}
+
+// Parse splits unified diff output (as produced by `git diff`) into
+// per-file diffs.
+func Parse(diff string) ([]*FileDiff, error) {
+	var files []*FileDiff
+	var file *FileDiff
+	var hunk *Hunk
+
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	for n, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			file = &FileDiff{Header: []string{line}}
+			hunk = nil
+			files = append(files, file)
+		case file == nil:
+			if strings.TrimSpace(line) != "" {
+				return nil, fmt.Errorf("line %d: unexpected %q before the first file header", n+1, line)
+			}
+		case strings.HasPrefix(line, "@@"):
+			h, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+1, err)
+			}
+			hunk = h
+			file.Hunks = append(file.Hunks, hunk)
+		case hunk != nil:
+			hunk.Lines = append(hunk.Lines, line)
+		default:
+			file.Header = append(file.Header, line)
+			parseFileHeaderLine(file, line)
+		}
+	}
+	return files, nil
+}
+
+func parseFileHeaderLine(file *FileDiff, line string) {
+	switch {
+	case strings.HasPrefix(line, "--- "):
+		file.OldPath = trimDiffPath(strings.TrimPrefix(line, "--- "), "a/")
+	case strings.HasPrefix(line, "+++ "):
+		file.NewPath = trimDiffPath(strings.TrimPrefix(line, "+++ "), "b/")
+	case strings.HasPrefix(line, "Binary files "), line == "GIT binary patch":
+		file.Binary = true
+	}
+}
+
+func trimDiffPath(path, prefix string) string {
+	path = strings.TrimSuffix(path, "\t")
+	if path == "/dev/null" {
+		return path
+	}
+	return strings.TrimPrefix(path, prefix)
+}
+
+func parseHunkHeader(line string) (*Hunk, error) {
+	m := hunkHeaderRe.FindStringSubmatch(line)
+	if m == nil {
+		return nil, fmt.Errorf("malformed hunk header %q", line)
+	}
+	h := &Hunk{Section: m[5], OldLines: 1, NewLines: 1}
+	h.OldStart, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		h.OldLines, _ = strconv.Atoi(m[2])
+	}
+	h.NewStart, _ = strconv.Atoi(m[3])
+	if m[4] != "" {
+		h.NewLines, _ = strconv.Atoi(m[4])
+	}
+	return h, nil
+}
@@ -0,0 +1,117 @@
+package patch
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,7 +1,8 @@ package main
+ line1
+-line2
++line2 changed
+ line3
+ line4
+ line5
++line5.5
+ line6
+ line7
+@@ -20 +21 @@ func main() {
+-old
++new
+diff --git a/logo.png b/logo.png
+index 3333333..4444444 100644
+Binary files a/logo.png and b/logo.png differ
+`
+
+func TestParse(t *testing.T) {
+	files, err := Parse(sampleDiff)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2", len(files))
+	}
+
+	f := files[0]
+	if f.Path() != "main.go" || f.OldPath != "main.go" {
+		t.Errorf("paths = %q/%q", f.OldPath, f.NewPath)
+	}
+	if len(f.Header) != 4 {
+		t.Errorf("header = %v", f.Header)
+	}
+	if len(f.Hunks) != 2 {
+		t.Fatalf("got %d hunks, want 2", len(f.Hunks))
+	}
+	h := f.Hunks[0]
+	if h.OldStart != 1 || h.OldLines != 7 || h.NewStart != 1 || h.NewLines != 8 || h.Section != " package main" {
+		t.Errorf("first hunk = %+v", h)
+	}
+	if len(h.Lines) != 9 {
+		t.Errorf("first hunk has %d lines, want 9", len(h.Lines))
+	}
+	if h2 := f.Hunks[1]; h2.OldLines != 1 || h2.NewLines != 1 || h2.NewStart != 21 {
+		t.Errorf("omitted counts should default to 1, got %+v", h2)
+	}
+
+	if !files[1].Binary || len(files[1].Hunks) != 0 {
+		t.Errorf("binary file = %+v", files[1])
+	}
+}
+
+func TestParse_NewFile(t *testing.T) {
+	files, err := Parse("diff --git a/new.txt b/new.txt\nnew file mode 100644\nindex 0000000..1111111\n--- /dev/null\n+++ b/new.txt\n@@ -0,0 +1 @@\n+hello\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files[0].OldPath != "/dev/null" || files[0].Path() != "new.txt" {
+		t.Errorf("paths = %q/%q", files[0].OldPath, files[0].NewPath)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	if files, err := Parse(""); err != nil || len(files) != 0 {
+		t.Errorf("empty diff = %v, %v", files, err)
+	}
+	if _, err := Parse("garbage\n"); err == nil {
+		t.Error("expected an error for text before the first header")
+	}
+	if _, err := Parse("diff --git a/x b/x\n@@ nonsense @@\n"); err == nil {
+		t.Error("expected an error for a malformed hunk header")
+	}
+}
+
+func TestHunk_Split(t *testing.T) {
+	files, _ := Parse(sampleDiff)
+	pieces := files[0].Hunks[0].Split()
+	if len(pieces) != 2 {
+		t.Fatalf("got %d pieces, want 2", len(pieces))
+	}
+
+	if got := pieces[0].Header(); got != "@@ -1,5 +1,5 @@ package main" {
+		t.Errorf("first piece header = %q", got)
+	}
+	if got := pieces[1].Header(); got != "@@ -3,5 +3,6 @@ package main" {
+		t.Errorf("second piece header = %q", got)
+	}
+	if pieces[1].Lines[0] != " line3" || pieces[1].Lines[len(pieces[1].Lines)-1] != " line7" {
+		t.Errorf("second piece should share the context between runs: %v", pieces[1].Lines)
+	}
+
+	if single := files[0].Hunks[1].Split(); len(single) != 1 || single[0] != files[0].Hunks[1] {
+		t.Error("a hunk with one run of changes should not split")
+	}
+}
+
+func TestFileDiff_Patch(t *testing.T) {
+	files, _ := Parse(sampleDiff)
+	f := files[0]
+	got := f.Patch(f.Hunks[1])
+	want := strings.Join(f.Header, "\n") + "\n@@ -20,1 +21,1 @@ func main() {\n-old\n+new\n"
+	if got != want {
+		t.Errorf("Patch() = %q, want %q", got, want)
+	}
+}
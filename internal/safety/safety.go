@@ -0,0 +1,61 @@
+// Package safety decides which branches destructive commands must leave
+// alone unless the user confirms or passes --force-unsafe.
+package safety
+
+import (
+	"path"
+	"strings"
+)
+
+// ForceFlag lets a destructive command change a protected branch without
+// asking.
+const ForceFlag = "--force-unsafe"
+
+// Protection matches branch names against protected-branch globs such as
+// main or release/*. A * does not match across a slash.
+type Protection struct {
+	patterns []string
+}
+
+// NewProtection returns a Protection for patterns.
+func NewProtection(patterns []string) Protection {
+	return Protection{patterns: patterns}
+}
+
+// Match returns the pattern that protects branch, if any.
+func (p Protection) Match(branch string) (string, bool) {
+	branch = strings.TrimPrefix(branch, "refs/heads/")
+	if branch == "" {
+		return "", false
+	}
+	for _, pattern := range p.patterns {
+		if ok, err := path.Match(pattern, branch); err == nil && ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// ValidPattern reports whether pattern is a well-formed glob.
+func ValidPattern(pattern string) bool {
+	if strings.TrimSpace(pattern) == "" {
+		return false
+	}
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+// CutForceFlag removes ForceFlag from args and reports whether it was
+// there.
+func CutForceFlag(args []string) ([]string, bool) {
+	rest := make([]string, 0, len(args))
+	found := false
+	for _, arg := range args {
+		if arg == ForceFlag {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, found
+}
@@ -0,0 +1,49 @@
+package safety
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProtection_Match(t *testing.T) {
+	p := NewProtection([]string{"main", "release/*"})
+	tests := []struct {
+		branch  string
+		pattern string
+		want    bool
+	}{
+		{"main", "main", true},
+		{"refs/heads/main", "main", true},
+		{"release/1.2", "release/*", true},
+		{"release/1.2/hotfix", "", false},
+		{"feature/main", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		pattern, got := p.Match(tt.branch)
+		if got != tt.want || pattern != tt.pattern {
+			t.Errorf("Match(%q) = %q, %v; want %q, %v", tt.branch, pattern, got, tt.pattern, tt.want)
+		}
+	}
+	if _, ok := NewProtection(nil).Match("main"); ok {
+		t.Error("no patterns should protect nothing")
+	}
+}
+
+func TestValidPattern(t *testing.T) {
+	for pattern, want := range map[string]bool{"main": true, "release/*": true, "[": false, " ": false} {
+		if got := ValidPattern(pattern); got != want {
+			t.Errorf("ValidPattern(%q) = %v, want %v", pattern, got, want)
+		}
+	}
+}
+
+func TestCutForceFlag(t *testing.T) {
+	rest, found := CutForceFlag([]string{"hard", ForceFlag, "HEAD~1"})
+	if !found || !reflect.DeepEqual(rest, []string{"hard", "HEAD~1"}) {
+		t.Errorf("CutForceFlag() = %v, %v", rest, found)
+	}
+	if _, found := CutForceFlag([]string{"hard"}); found {
+		t.Error("CutForceFlag() found a flag that is not there")
+	}
+}
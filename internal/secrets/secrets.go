@@ -0,0 +1,143 @@
+// Package secrets looks for credentials, such as cloud keys, private keys
+// and API tokens, in the lines a diff adds.
+package secrets
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AllowMarker on a line keeps it out of the findings, for test fixtures
+// and examples that look like secrets but are not.
+const AllowMarker = "ggc:allow-secret"
+
+// DefaultRules are the patterns a Scanner looks for unless the
+// configuration replaces or drops them, keyed by rule name.
+var DefaultRules = map[string]string{
+	"aws-access-key-id":     `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	"aws-secret-access-key": `(?i)aws_?secret_?access_?key\s*[:=]\s*["']?[A-Za-z0-9/+]{40}\b`,
+	"private-key":           `-----BEGIN (?:RSA |EC |DSA |OPENSSH |ENCRYPTED |PGP )?PRIVATE KEY(?: BLOCK)?-----`,
+	"github-token":          `\b(?:gh[pousr]_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{82})\b`,
+	"gitlab-token":          `\bglpat-[A-Za-z0-9_-]{20}\b`,
+	"slack-token":           `\bxox[abprs]-[A-Za-z0-9-]{10,}`,
+	"stripe-secret-key":     `\b[rs]k_live_[A-Za-z0-9]{24,}\b`,
+	"google-api-key":        `\bAIza[0-9A-Za-z_-]{35}\b`,
+	genericRule:             `(?i)\b(?:api_?key|secret|token|passw(?:or)?d)\b["']?\s*[:=]\s*["'][^"'\s]{16,}["']`,
+}
+
+// genericRule catches assignments of long literals to names such as
+// password or api_key. It is tried last so the specific rules name what
+// they find.
+const genericRule = "generic-secret"
+
+// Rule is a named pattern that marks a line as holding a secret.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// Finding is a line, added by a commit, that a rule matched.
+type Finding struct {
+	Commit string
+	Path   string
+	Line   int
+	Rule   string
+	Text   string // the line with the match masked
+}
+
+// Scanner matches added lines against its rules.
+type Scanner struct {
+	rules []Rule
+}
+
+// NewScanner returns a scanner with DefaultRules changed by overrides: a
+// name of a default rule replaces its pattern, or drops it when the
+// pattern is empty, and any other name adds a rule. Patterns that do not
+// compile are skipped; configuration validation reports them.
+func NewScanner(overrides map[string]string) *Scanner {
+	patterns := maps.Clone(DefaultRules)
+	maps.Copy(patterns, overrides)
+	s := &Scanner{}
+	names := slices.Sorted(maps.Keys(patterns))
+	if i := slices.Index(names, genericRule); i >= 0 {
+		names = append(slices.Delete(names, i, i+1), genericRule)
+	}
+	for _, name := range names {
+		if patterns[name] == "" {
+			continue
+		}
+		re, err := regexp.Compile(patterns[name])
+		if err != nil {
+			continue
+		}
+		s.rules = append(s.rules, Rule{Name: name, Pattern: re})
+	}
+	return s
+}
+
+// ScanLine returns the rule that matches line and the line with the match
+// masked, or ok false when none does or the line carries AllowMarker.
+func (s *Scanner) ScanLine(line string) (rule, masked string, ok bool) {
+	if strings.Contains(line, AllowMarker) {
+		return "", "", false
+	}
+	for _, r := range s.rules {
+		if loc := r.Pattern.FindStringIndex(line); loc != nil {
+			return r.Name, line[:loc[0]] + mask(line[loc[0]:loc[1]]) + line[loc[1]:], true
+		}
+	}
+	return "", "", false
+}
+
+// ScanLog scans the output of `git log -p -U0 --format=%x00%h`: each
+// commit starts with a NUL and its hash, and only the lines a hunk adds
+// are looked at.
+func (s *Scanner) ScanLog(log string) []Finding {
+	var findings []Finding
+	var commit, path string
+	line, header := 0, false
+	for _, l := range strings.Split(log, "\n") {
+		switch {
+		case strings.HasPrefix(l, "\x00"):
+			commit, path, header = strings.TrimPrefix(l, "\x00"), "", false
+		case strings.HasPrefix(l, "diff --git "):
+			path, header = "", true
+		case header && strings.HasPrefix(l, "+++ "):
+			path = strings.TrimPrefix(strings.TrimPrefix(l, "+++ "), "b/")
+			if path == "/dev/null" {
+				path = ""
+			}
+		case strings.HasPrefix(l, "@@ "):
+			line, header = hunkStart(l), false
+		case !header && path != "" && strings.HasPrefix(l, "+"):
+			if rule, masked, ok := s.ScanLine(l[1:]); ok {
+				findings = append(findings, Finding{Commit: commit, Path: path, Line: line, Rule: rule, Text: strings.TrimSpace(masked)})
+			}
+			line++
+		}
+	}
+	return findings
+}
+
+// hunkStart returns the first new-file line of a hunk header such as
+// "@@ -3,0 +4,2 @@".
+func hunkStart(header string) int {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return 0
+	}
+	start, _, _ := strings.Cut(strings.TrimPrefix(fields[2], "+"), ",")
+	n, _ := strconv.Atoi(start)
+	return n
+}
+
+// mask hides all but the first four characters of a secret.
+func mask(s string) string {
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:4] + strings.Repeat("*", min(len(s)-4, 16))
+}
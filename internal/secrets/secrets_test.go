@@ -0,0 +1,80 @@
+package secrets
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The samples are put together at run time so that scanning this file
+// does not flag it.
+var (
+	awsKey      = "AKIA" + "IOSFODNN7EXAMPLE"
+	githubToken = "ghp_" + strings.Repeat("a1B2", 9)
+	privateKey  = "-----BEGIN " + "RSA PRIVATE KEY-----"
+)
+
+func TestScanner_ScanLine(t *testing.T) {
+	s := NewScanner(nil)
+	tests := []struct {
+		line string
+		rule string
+	}{
+		{"aws_access_key_id = " + awsKey, "aws-access-key-id"},
+		{privateKey, "private-key"},
+		{`token: "` + githubToken + `"`, "github-token"},
+		{`password = "correct-horse-battery-staple"`, "generic-secret"},
+		{`password = "short"`, ""},
+		{"key := " + awsKey + " // " + AllowMarker, ""},
+		{"func main() {}", ""},
+	}
+	for _, tt := range tests {
+		rule, masked, ok := s.ScanLine(tt.line)
+		if rule != tt.rule || ok != (tt.rule != "") {
+			t.Errorf("ScanLine(%q) = %q, %v; want %q", tt.line, rule, ok, tt.rule)
+		}
+		if ok && strings.Contains(masked, awsKey) {
+			t.Errorf("ScanLine(%q) did not mask the key: %q", tt.line, masked)
+		}
+	}
+}
+
+func TestNewScanner_Overrides(t *testing.T) {
+	s := NewScanner(map[string]string{
+		"aws-access-key-id": "",
+		"internal-token":    `\bitk_[a-z0-9]{8}\b`,
+	})
+	if _, _, ok := s.ScanLine(awsKey); ok {
+		t.Error("an empty pattern should drop the default rule")
+	}
+	if rule, _, _ := s.ScanLine("itk_abcd1234"); rule != "internal-token" {
+		t.Errorf("rule = %q, want internal-token", rule)
+	}
+}
+
+func TestScanner_ScanLog(t *testing.T) {
+	log := "\x00a1b2c3d\n" +
+		"diff --git a/config/prod.env b/config/prod.env\n" +
+		"new file mode 100644\n" +
+		"--- /dev/null\n" +
+		"+++ b/config/prod.env\n" +
+		"@@ -0,0 +1,3 @@\n" +
+		"+REGION=eu-west-1\n" +
+		"+AWS_ACCESS_KEY_ID=" + awsKey + "\n" +
+		"+++ this added line is not a header\n" +
+		"\x00d4e5f60\n" +
+		"diff --git a/deploy/key.pem b/deploy/key.pem\n" +
+		"--- a/deploy/key.pem\n" +
+		"+++ b/deploy/key.pem\n" +
+		"@@ -10,1 +12,2 @@\n" +
+		"-" + privateKey + "\n" +
+		"+" + privateKey + "\n"
+	got := NewScanner(nil).ScanLog(log)
+	want := []Finding{
+		{Commit: "a1b2c3d", Path: "config/prod.env", Line: 2, Rule: "aws-access-key-id", Text: "AWS_ACCESS_KEY_ID=AKIA****************"},
+		{Commit: "d4e5f60", Path: "deploy/key.pem", Line: 12, Rule: "private-key", Text: "----****************"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScanLog() = %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,69 @@
+// Package selfexec runs ggc again as a separate process, for commands
+// that run other ggc commands apart from their own state, such as the
+// parallel steps of a workflow and ggc repos.
+package selfexec
+
+import (
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+// executable is swapped in tests.
+var executable = os.Executable
+
+// Command returns ggc run with args in dir, or in the current directory
+// when dir is empty. The global --yes and color flags this process was
+// given go in front of args, so the child answers prompts and colors its
+// output the same way.
+func Command(dir string, args []string) (*exec.Cmd, error) {
+	exe, err := executable()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(exe, append(globalFlags(), args...)...)
+	cmd.Dir = dir
+	return cmd, nil
+}
+
+// Run runs ggc with args in dir, writing its output and errors to out.
+func Run(dir string, args []string, out io.Writer) error {
+	cmd, err := Command(dir, args)
+	if err != nil {
+		return err
+	}
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
+
+// Interact runs ggc with args in dir on the terminal, so interactive
+// commands work.
+func Interact(dir string, args []string) error {
+	cmd, err := Command(dir, args)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// globalFlags returns the global flags that reproduce the --yes and
+// color settings of this process.
+func globalFlags() []string {
+	var flags []string
+	if ui.AssumeYes() {
+		flags = append(flags, "--yes")
+	}
+	switch ui.CurrentColorMode() {
+	case ui.ColorNever:
+		flags = append(flags, "--no-color")
+	case ui.ColorAlways:
+		flags = append(flags, "--color=always")
+	}
+	return flags
+}
@@ -0,0 +1,42 @@
+package selfexec
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+func TestCommand_ForwardsGlobalFlags(t *testing.T) {
+	executable = func() (string, error) { return "/usr/local/bin/ggc", nil }
+	t.Cleanup(func() {
+		executable = os.Executable
+		ui.SetAssumeYes(false)
+		ui.SetColorMode(ui.ColorAuto)
+	})
+	tests := []struct {
+		name string
+		yes  bool
+		mode ui.ColorMode
+		want []string
+	}{
+		{"no flags", false, ui.ColorAuto, []string{"/usr/local/bin/ggc", "status"}},
+		{"yes", true, ui.ColorAuto, []string{"/usr/local/bin/ggc", "--yes", "status"}},
+		{"no color", false, ui.ColorNever, []string{"/usr/local/bin/ggc", "--no-color", "status"}},
+		{"both", true, ui.ColorAlways, []string{"/usr/local/bin/ggc", "--yes", "--color=always", "status"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui.SetAssumeYes(tt.yes)
+			ui.SetColorMode(tt.mode)
+			cmd, err := Command("/src/api", []string{"status"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cmd.Args, tt.want) || cmd.Dir != "/src/api" {
+				t.Errorf("args %q in %q, want %q in /src/api", cmd.Args, cmd.Dir, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+// Package stats keeps the local usage statistics behind `ggc stats`: how
+// often each command runs, how long interactive sessions last and how many
+// workflows run. Nothing is sent anywhere; the counts live in one JSON
+// file under the user cache directory.
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// envDisable, when set to a truthy value, stops every write, whatever the
+// config says.
+const envDisable = "GGC_NO_STATS"
+
+// Rough estimates behind TimeSaved: typing a short ggc command instead of
+// the git invocation it wraps, and a workflow step that would otherwise be
+// looked up and typed by hand.
+const (
+	savedPerCommand      = 3 * time.Second
+	savedPerWorkflowStep = 5 * time.Second
+)
+
+// Data is everything recorded since Since.
+type Data struct {
+	// Since is when the first event was recorded, in UTC.
+	Since time.Time `json:"since"`
+	// Commands maps canonical command names to how often they ran.
+	Commands map[string]int `json:"commands,omitempty"`
+	// Sessions counts interactive sessions and SessionSeconds their
+	// total length.
+	Sessions       int     `json:"sessions,omitempty"`
+	SessionSeconds float64 `json:"session_seconds,omitempty"`
+	// Workflows counts completed workflow runs and WorkflowSteps the
+	// steps they ran.
+	Workflows     int `json:"workflows,omitempty"`
+	WorkflowSteps int `json:"workflow_steps,omitempty"`
+}
+
+// CommandCount is one row of TopCommands.
+type CommandCount struct {
+	Command string
+	Count   int
+}
+
+// Empty reports whether nothing has been recorded.
+func (d *Data) Empty() bool {
+	return d.CommandsRun() == 0 && d.Sessions == 0 && d.Workflows == 0
+}
+
+// CommandsRun is the number of commands run.
+func (d *Data) CommandsRun() int {
+	total := 0
+	for _, n := range d.Commands {
+		total += n
+	}
+	return total
+}
+
+// TopCommands returns the n most-used commands, most used first and
+// alphabetically among equals.
+func (d *Data) TopCommands(n int) []CommandCount {
+	rows := make([]CommandCount, 0, len(d.Commands))
+	for cmd, count := range d.Commands {
+		rows = append(rows, CommandCount{cmd, count})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Command < rows[j].Command
+	})
+	if len(rows) > n {
+		rows = rows[:n]
+	}
+	return rows
+}
+
+// AverageSession is the mean length of an interactive session.
+func (d *Data) AverageSession() time.Duration {
+	if d.Sessions == 0 {
+		return 0
+	}
+	return time.Duration(d.SessionSeconds / float64(d.Sessions) * float64(time.Second))
+}
+
+// TimeSaved estimates the time ggc saved compared with typing the git
+// commands by hand.
+func (d *Data) TimeSaved() time.Duration {
+	return time.Duration(d.CommandsRun())*savedPerCommand + time.Duration(d.WorkflowSteps)*savedPerWorkflowStep
+}
+
+// Store is the persistence layer for the statistics. A zero-value Store
+// uses DefaultPath.
+type Store struct {
+	// Path is the JSON file backing the store. When empty, DefaultPath
+	// is used lazily on the first call.
+	Path string
+	// Disabled short-circuits every write. Reads still work so that
+	// ggc stats can show what was collected before.
+	Disabled bool
+}
+
+// Default returns a Store wired to DefaultPath and honoring the
+// GGC_NO_STATS env variable.
+func Default() *Store {
+	return &Store{Disabled: envTrue(os.Getenv(envDisable))}
+}
+
+// DefaultPath returns the per-user statistics file under the user cache
+// directory.
+func DefaultPath() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locate user cache dir: %w", err)
+	}
+	return filepath.Join(base, "ggc", "stats.json"), nil
+}
+
+func envTrue(v string) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "", "0", "false", "no", "off":
+		return false
+	}
+	return true
+}
+
+// Location returns the file backing the store.
+func (s *Store) Location() (string, error) {
+	if s.Path != "" {
+		return s.Path, nil
+	}
+	return DefaultPath()
+}
+
+// Load reads the statistics. A missing file yields empty Data.
+func (s *Store) Load() (Data, error) {
+	var d Data
+	path, err := s.Location()
+	if err != nil {
+		return d, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return d, nil
+		}
+		return d, err
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return Data{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return d, nil
+}
+
+// RecordCommand counts one run of command.
+func (s *Store) RecordCommand(command string, now time.Time) error {
+	return s.update(now, func(d *Data) {
+		if d.Commands == nil {
+			d.Commands = make(map[string]int)
+		}
+		d.Commands[command]++
+	})
+}
+
+// RecordSession adds an interactive session that lasted length.
+func (s *Store) RecordSession(length time.Duration, now time.Time) error {
+	return s.update(now, func(d *Data) {
+		d.Sessions++
+		d.SessionSeconds += length.Seconds()
+	})
+}
+
+// RecordWorkflow counts one completed workflow run of steps steps.
+func (s *Store) RecordWorkflow(steps int, now time.Time) error {
+	return s.update(now, func(d *Data) {
+		d.Workflows++
+		d.WorkflowSteps += steps
+	})
+}
+
+// Reset deletes everything recorded so far.
+func (s *Store) Reset() error {
+	path, err := s.Location()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// update applies change to the statistics on disk. It re-reads the file
+// first so concurrent ggc processes do not drop each other's counts, and
+// replaces a corrupt file rather than failing forever.
+func (s *Store) update(now time.Time, change func(*Data)) error {
+	if s.Disabled {
+		return nil
+	}
+	d, err := s.Load()
+	if err != nil {
+		d = Data{}
+	}
+	if d.Since.IsZero() {
+		d.Since = now.UTC()
+	}
+	change(&d)
+	return s.save(&d)
+}
+
+// save atomically replaces the statistics file with d.
+func (s *Store) save(d *Data) error {
+	path, err := s.Location()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".stats-*.json")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Package-level wrappers around the default store. Recording stays off
+// until the program installs a store with SetDefault, so tests and other
+// library callers never write the user's statistics.
+var defaultStore = &Store{Disabled: true}
+
+// SetDefault swaps the package-level store.
+func SetDefault(s *Store) { defaultStore = s }
+
+// Current returns the package-level store.
+func Current() *Store { return defaultStore }
+
+// RecordCommand counts one run of command on the default store.
+func RecordCommand(command string) error {
+	return defaultStore.RecordCommand(command, time.Now())
+}
+
+// RecordSession adds an interactive session on the default store.
+func RecordSession(length time.Duration) error {
+	return defaultStore.RecordSession(length, time.Now())
+}
+
+// RecordWorkflow counts a completed workflow run on the default store.
+func RecordWorkflow(steps int) error {
+	return defaultStore.RecordWorkflow(steps, time.Now())
+}
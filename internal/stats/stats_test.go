@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStore_Record(t *testing.T) {
+	s := &Store{Path: filepath.Join(t.TempDir(), "ggc", "stats.json")}
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	for _, cmd := range []string{"status", "commit", "status", "push", "status", "commit"} {
+		if err := s.RecordCommand(cmd, start.Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.RecordSession(4*time.Minute, start); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RecordSession(2*time.Minute, start); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RecordWorkflow(3, start); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Since.Equal(start.Add(time.Hour)) {
+		t.Errorf("Since = %v", d.Since)
+	}
+	want := []CommandCount{{"status", 3}, {"commit", 2}}
+	if got := d.TopCommands(2); !reflect.DeepEqual(got, want) {
+		t.Errorf("TopCommands = %v, want %v", got, want)
+	}
+	if d.CommandsRun() != 6 || d.Sessions != 2 || d.Workflows != 1 || d.WorkflowSteps != 3 {
+		t.Errorf("data = %+v", d)
+	}
+	if got := d.AverageSession(); got != 3*time.Minute {
+		t.Errorf("AverageSession = %v", got)
+	}
+	if got := d.TimeSaved(); got != 6*savedPerCommand+3*savedPerWorkflowStep {
+		t.Errorf("TimeSaved = %v", got)
+	}
+}
+
+func TestStore_DisabledAndReset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	disabled := &Store{Path: path, Disabled: true}
+	if err := disabled.RecordCommand("status", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("a disabled store wrote %s", path)
+	}
+
+	s := &Store{Path: path}
+	if err := s.RecordCommand("status", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Reset(); err != nil {
+		t.Errorf("resetting twice: %v", err)
+	}
+	if d, err := s.Load(); err != nil || !d.Empty() {
+		t.Errorf("after reset: %+v, %v", d, err)
+	}
+}
+
+func TestStore_ReplacesCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s := &Store{Path: path}
+	if _, err := s.Load(); err == nil {
+		t.Error("Load should report the corrupt file")
+	}
+	if err := s.RecordCommand("status", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if d, err := s.Load(); err != nil || d.Commands["status"] != 1 {
+		t.Errorf("after record: %+v, %v", d, err)
+	}
+}
+
+func TestDefault_HonorsEnv(t *testing.T) {
+	t.Setenv(envDisable, "1")
+	if !Default().Disabled {
+		t.Error("GGC_NO_STATS=1 should disable the default store")
+	}
+	t.Setenv(envDisable, "")
+	if Default().Disabled {
+		t.Error("the default store should record when GGC_NO_STATS is unset")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"golang.org/x/term"
 
 	commandregistry "github.com/bmf-san/ggc/v8/cmd/command"
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 // HelpData contains data for help message templates.
@@ -18,7 +19,11 @@ type HelpData struct {
 	Logo        string
 	Usage       string
 	Description string
+	Details     []string // long-form paragraphs, wrapped when rendered
+	Aliases     []string
+	Git         string
 	Examples    []string
+	Colors      *ui.ANSIColors // nil renders plain text
 }
 
 // Templates for help messages.
@@ -40,6 +45,7 @@ Main Commands:
   ggc add .                   Stage all changes
   ggc add interactive         Stage changes interactively
   ggc add patch               Stage changes interactively (patch mode)
+  ggc add select              Pick several files to stage
   ggc branch current          Show current branch name
   ggc branch checkout         Interactive branch switch
   ggc branch checkout remote  Create and checkout new local branch from remote
@@ -97,13 +103,19 @@ Main Commands:
   {{end}}`
 
 	commandHelpTemplate = `{{.Logo}}
-Usage: {{.Usage}}
+{{heading "Usage:"}} {{.Usage}}
 
-Description:
+{{heading "Description:"}}
   {{.Description}}
-
-Examples:
-{{range .Examples}}  {{.}}
+{{range .Details}}
+{{wrap .}}
+{{end}}
+{{- if or .Aliases .Git}}
+{{if .Aliases}}{{heading "Aliases:"}} {{join .Aliases ", "}}
+{{end}}{{if .Git}}{{heading "Runs:"}} {{.Git}}
+{{end}}{{end}}
+{{heading "Examples:"}}
+{{range .Examples}}  {{example .}}
 {{end}}
 `
 )
@@ -155,6 +167,11 @@ func RenderMainHelp(registry ...*commandregistry.Registry) (string, error) {
 		Notes: []string{
 			"Unified syntax: no option flags (-/--) — use subcommands and words.",
 			"To pass a literal that starts with '-', use the '--' separator: ggc commit -- - fix leading dash",
+			"Color: ggc --no-color <command> (or NO_COLOR=1) turns color off; --color=always keeps it when piping.",
+			"Confirmations: ggc --yes <command> answers yes to every prompt; without a terminal, prompts fail unless --yes is given.",
+			"Scope: ggc --path <dir> <command> limits status, diff, add, log and clean to a directory; --pick-path chooses it in a picker.",
+			"Batch: ggc with no command reads one command per line from stdin when it is not a terminal; ggc --batch does so in a terminal too.",
+			"Tracing: ggc --verbose <command> logs each git command with its duration; --debug adds exit codes, environment and stderr; GGC_LOG_FILE=<path> writes the log to a file.",
 		},
 	}
 
@@ -266,7 +283,12 @@ func dedupeHelpCommands(commands []helpCommand) []helpCommand {
 
 // RenderCommandHelp renders help message for a specific command.
 func RenderCommandHelp(data HelpData) (string, error) {
-	tmpl, err := template.New("commandHelp").Parse(commandHelpTemplate)
+	colors := data.Colors
+	if colors == nil {
+		colors = ui.NoColors()
+	}
+	funcs := commandHelpFuncs(colors, exampleWidth(data.Examples))
+	tmpl, err := template.New("commandHelp").Funcs(funcs).Parse(commandHelpTemplate)
 	if err != nil {
 		return "", err
 	}
@@ -278,3 +300,61 @@ func RenderCommandHelp(data HelpData) (string, error) {
 
 	return buf.String(), nil
 }
+
+// helpWidth is the column long-form paragraphs are wrapped at, and
+// maxExampleWidth the widest example command its comment is aligned after.
+const (
+	helpWidth       = 78
+	maxExampleWidth = 40
+)
+
+// exampleWidth is the column example comments line up at: after the
+// longest command that fits within maxExampleWidth.
+func exampleWidth(examples []string) int {
+	width := 0
+	for _, e := range examples {
+		cmd, _, ok := strings.Cut(e, "  # ")
+		if n := len(strings.TrimSpace(cmd)); ok && n > width && n <= maxExampleWidth {
+			width = n
+		}
+	}
+	return width
+}
+
+func commandHelpFuncs(c *ui.ANSIColors, width int) template.FuncMap {
+	return template.FuncMap{
+		"heading": func(s string) string { return c.Bold + s + c.Reset },
+		"join":    strings.Join,
+		"wrap":    func(s string) string { return wrapParagraph(s, "  ", helpWidth) },
+		// example colors the command and dims a trailing "# comment",
+		// lining the comments up.
+		"example": func(s string) string {
+			cmd, comment, ok := strings.Cut(s, "  # ")
+			if !ok {
+				return c.Cyan + s + c.Reset
+			}
+			cmd = strings.TrimSpace(cmd)
+			pad := strings.Repeat(" ", max(width-len(cmd), 0))
+			return c.Cyan + cmd + c.Reset + pad + "  " + c.BrightBlack + "# " + strings.TrimSpace(comment) + c.Reset
+		},
+	}
+}
+
+// wrapParagraph fills s into lines of at most width columns, each starting
+// with indent. Words longer than a line are kept whole.
+func wrapParagraph(s, indent string, width int) string {
+	var b strings.Builder
+	line := indent
+	for _, word := range strings.Fields(s) {
+		if line != indent && len(line)+1+len(word) > width {
+			b.WriteString(line + "\n")
+			line = indent
+		}
+		if line != indent {
+			line += " "
+		}
+		line += word
+	}
+	b.WriteString(line)
+	return b.String()
+}
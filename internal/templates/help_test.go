@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/ui"
 )
 
 func TestSelectLogo(t *testing.T) {
@@ -422,3 +424,45 @@ func TestLogoConstants_Properties(t *testing.T) {
 		t.Error("Logo and SmallLogo should be different")
 	}
 }
+
+func TestRenderCommandHelp_Rich(t *testing.T) {
+	data := HelpData{
+		Usage:       "ggc test",
+		Description: "Run the test",
+		Details:     []string{strings.Repeat("word ", 30)},
+		Aliases:     []string{"t"},
+		Git:         "git test",
+		Examples:    []string{"ggc test  # Run it", "ggc test --all   # Run everything"},
+	}
+
+	result, err := RenderCommandHelp(data)
+	if err != nil {
+		t.Fatalf("RenderCommandHelp error: %v", err)
+	}
+	for _, want := range []string{
+		"\n\n  word word",
+		"\n\nAliases: t\nRuns: git test\n\nExamples:\n",
+		"  ggc test        # Run it\n  ggc test --all  # Run everything\n",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("output lacks %q:\n%s", want, result)
+		}
+	}
+	for _, line := range strings.Split(result, "\n") {
+		if len(line) > helpWidth {
+			t.Errorf("line longer than %d columns: %q", helpWidth, line)
+		}
+	}
+	if strings.Contains(result, "\x1b[") {
+		t.Errorf("output without a palette has color codes:\n%s", result)
+	}
+
+	data.Colors = ui.NewANSIColors()
+	colored, err := RenderCommandHelp(data)
+	if err != nil {
+		t.Fatalf("RenderCommandHelp error: %v", err)
+	}
+	if !strings.Contains(colored, "\x1b[1mExamples:\x1b[0m") || ui.StripANSI(colored) != result {
+		t.Errorf("colored output should only add color codes:\n%s", colored)
+	}
+}
@@ -0,0 +1,201 @@
+package termio
+
+import (
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+)
+
+// KeyEvent is a key press as a Windows console reports it in a
+// KEY_EVENT_RECORD. Consoles that do not support
+// ENABLE_VIRTUAL_TERMINAL_INPUT report keys this way only, rather than as
+// the escape sequences the interactive UI decodes.
+type KeyEvent struct {
+	VirtualKey      uint16
+	Char            rune // a UTF-16 code unit; 0 for keys without a character
+	ControlKeyState uint32
+	Repeat          uint16
+}
+
+// Virtual key codes of the keys that have a keystroke of their own.
+const (
+	vkBack  = 0x08
+	vkTab   = 0x09
+	vkPrior = 0x21
+	vkNext  = 0x22
+	vkEnd   = 0x23
+	vkHome  = 0x24
+	vkLeft  = 0x25
+	vkUp    = 0x26
+	vkRight = 0x27
+	vkDown  = 0x28
+	vkA     = 0x41
+	vkZ     = 0x5A
+	vkF1    = 0x70
+	vkF12   = 0x7B
+)
+
+// Bits of KeyEvent.ControlKeyState.
+const (
+	rightAltPressed  = 0x0001
+	leftAltPressed   = 0x0002
+	rightCtrlPressed = 0x0004
+	leftCtrlPressed  = 0x0008
+	shiftPressed     = 0x0010
+)
+
+// arrowDirections names the arrow keys as the named keys do.
+var arrowDirections = map[uint16]string{
+	vkLeft: "Left", vkUp: "Up", vkRight: "Right", vkDown: "Down",
+}
+
+// arrowStrokes are the arrow keys pressed without modifiers.
+var arrowStrokes = map[uint16]kb.KeyStroke{
+	vkLeft:  kb.NewLeftArrowKeyStroke(),
+	vkUp:    kb.NewUpArrowKeyStroke(),
+	vkRight: kb.NewRightArrowKeyStroke(),
+	vkDown:  kb.NewDownArrowKeyStroke(),
+}
+
+// namedVirtualKeys are the keys other than arrows and function keys that
+// are named keys when pressed without modifiers.
+var namedVirtualKeys = map[uint16]string{
+	vkHome:  kb.KeyHome,
+	vkEnd:   kb.KeyEnd,
+	vkPrior: kb.KeyPgUp,
+	vkNext:  kb.KeyPgDn,
+}
+
+// Stroke returns the keystroke the event stands for: arrows, Home/End,
+// PgUp/PgDn, function keys, Shift+Tab and Ctrl+Backspace, modified arrows,
+// Ctrl+<letter> and Alt+<letter>. ok is false for plain characters and for
+// keys ggc has no keystroke for.
+func (e KeyEvent) Stroke() (ks kb.KeyStroke, ok bool) {
+	ctrl := e.ControlKeyState&(leftCtrlPressed|rightCtrlPressed) != 0
+	alt := e.ControlKeyState&(leftAltPressed|rightAltPressed) != 0
+	shift := e.ControlKeyState&shiftPressed != 0
+	// AltGr is reported as Ctrl+Alt; what it types is a character.
+	if ctrl && alt {
+		return kb.KeyStroke{}, false
+	}
+
+	if dir, ok := arrowDirections[e.VirtualKey]; ok {
+		switch {
+		case ctrl:
+			return kb.NewFnKeyStroke("Ctrl+" + dir), true
+		case shift:
+			return kb.NewFnKeyStroke("Shift+" + dir), true
+		}
+		return arrowStrokes[e.VirtualKey], true
+	}
+	if name, ok := namedVirtualKeys[e.VirtualKey]; ok {
+		return kb.NewFnKeyStroke(name), true
+	}
+
+	switch vk := e.VirtualKey; {
+	case vk >= vkF1 && vk <= vkF12:
+		return kb.NewFnKeyStroke(fmt.Sprintf("F%d", vk-vkF1+1)), true
+	case vk == vkTab && shift:
+		return kb.NewFnKeyStroke(kb.KeyShiftTab), true
+	case vk == vkBack && ctrl:
+		return kb.NewFnKeyStroke(kb.KeyCtrlBackspace), true
+	case vk == vkBack && alt:
+		return kb.NewAltKeyStroke(0, "backspace"), true
+	case vk >= vkA && vk <= vkZ && ctrl:
+		return kb.NewCtrlKeyStroke(rune('a' + vk - vkA)), true
+	case vk >= vkA && vk <= vkZ && alt:
+		return kb.NewAltKeyStroke(rune('a'+vk-vkA), ""), true
+	}
+	return kb.KeyStroke{}, false
+}
+
+// Sequence returns the bytes an xterm-compatible terminal sends for the
+// event, which is what the interactive UI decodes. It returns nil for
+// events that type nothing, such as a lone Shift.
+func (e KeyEvent) Sequence() []byte {
+	if ks, ok := e.Stroke(); ok {
+		switch ks.Kind {
+		case kb.KeyStrokeFnKey:
+			if seqs := kb.KeySequences(ks, "xterm"); len(seqs) > 0 {
+				return seqs[0]
+			}
+		case kb.KeyStrokeRawSeq:
+			return ks.Seq
+		case kb.KeyStrokeCtrl:
+			return []byte{byte(ks.Rune-'a') + 1}
+		case kb.KeyStrokeAlt:
+			if ks.Name == "backspace" {
+				return []byte{0x1b, 0x7f}
+			}
+			return []byte{0x1b, byte(ks.Rune)}
+		}
+	}
+	if e.Char == 0 {
+		return nil
+	}
+	return utf8.AppendRune(nil, e.Char)
+}
+
+// ConsoleReader reads the key events of a console that does not send
+// escape sequences and returns the bytes an xterm-compatible terminal would
+// have sent for them, so the interactive UI decodes keys the same way on
+// every console.
+type ConsoleReader struct {
+	// events blocks until the console has key events and returns the key
+	// presses among them; key releases are left out.
+	events func() ([]KeyEvent, error)
+	buf    []byte
+	// high is the first half of a character outside the BMP, which the
+	// console reports as two events.
+	high rune
+}
+
+// NewConsoleReader returns a ConsoleReader for the key presses events
+// returns.
+func NewConsoleReader(events func() ([]KeyEvent, error)) *ConsoleReader {
+	return &ConsoleReader{events: events}
+}
+
+// Read returns the bytes of the next key presses.
+func (r *ConsoleReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		events, err := r.events()
+		if err != nil {
+			return 0, err
+		}
+		for _, e := range events {
+			r.buf = append(r.buf, r.encode(e)...)
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Buffered returns the number of bytes that can be read without waiting
+// for the console, such as the rest of an escape sequence.
+func (r *ConsoleReader) Buffered() int {
+	return len(r.buf)
+}
+
+// encode returns the bytes of one key press, repeated as often as the key
+// repeated.
+func (r *ConsoleReader) encode(e KeyEvent) []byte {
+	switch {
+	case utf16.IsSurrogate(e.Char) && e.Char < 0xdc00:
+		r.high = e.Char
+		return nil
+	case utf16.IsSurrogate(e.Char):
+		e.Char = utf16.DecodeRune(r.high, e.Char)
+	}
+	r.high = 0
+
+	seq := e.Sequence()
+	var out []byte
+	for i := uint16(0); i < max(e.Repeat, 1); i++ {
+		out = append(out, seq...)
+	}
+	return out
+}
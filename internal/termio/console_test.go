@@ -0,0 +1,86 @@
+package termio
+
+import (
+	"io"
+	"testing"
+
+	kb "github.com/bmf-san/ggc/v8/internal/keybindings"
+)
+
+func TestKeyEventSequence(t *testing.T) {
+	tests := []struct {
+		name  string
+		event KeyEvent
+		want  string
+	}{
+		{"up", KeyEvent{VirtualKey: vkUp}, "\x1b[A"},
+		{"left", KeyEvent{VirtualKey: vkLeft}, "\x1b[D"},
+		{"ctrl left", KeyEvent{VirtualKey: vkLeft, ControlKeyState: leftCtrlPressed}, "\x1b[1;5D"},
+		{"shift down", KeyEvent{VirtualKey: vkDown, ControlKeyState: shiftPressed}, "\x1b[1;2B"},
+		{"home", KeyEvent{VirtualKey: vkHome}, "\x1b[H"},
+		{"end", KeyEvent{VirtualKey: vkEnd}, "\x1b[F"},
+		{"page down", KeyEvent{VirtualKey: vkNext}, "\x1b[6~"},
+		{"f12", KeyEvent{VirtualKey: vkF12}, "\x1b[24~"},
+		{"shift tab", KeyEvent{VirtualKey: vkTab, Char: '\t', ControlKeyState: shiftPressed}, "\x1b[Z"},
+		{"ctrl backspace", KeyEvent{VirtualKey: vkBack, Char: 0x7f, ControlKeyState: rightCtrlPressed}, "\x1b[27;5;127~"},
+		{"alt backspace", KeyEvent{VirtualKey: vkBack, Char: '\b', ControlKeyState: leftAltPressed}, "\x1b\x7f"},
+		{"backspace", KeyEvent{VirtualKey: vkBack, Char: '\b'}, "\b"},
+		{"ctrl r", KeyEvent{VirtualKey: 'R', Char: 0x12, ControlKeyState: leftCtrlPressed}, "\x12"},
+		{"alt b", KeyEvent{VirtualKey: 'B', Char: 'b', ControlKeyState: leftAltPressed}, "\x1bb"},
+		{"altgr", KeyEvent{VirtualKey: 'Q', Char: '@', ControlKeyState: leftCtrlPressed | rightAltPressed}, "@"},
+		{"letter", KeyEvent{VirtualKey: 'A', Char: 'a'}, "a"},
+		{"non-ascii", KeyEvent{Char: 'é'}, "é"},
+		{"enter", KeyEvent{VirtualKey: 0x0d, Char: '\r'}, "\r"},
+		{"lone shift", KeyEvent{VirtualKey: 0x10, ControlKeyState: shiftPressed}, ""},
+	}
+	for _, tt := range tests {
+		if got := string(tt.event.Sequence()); got != tt.want {
+			t.Errorf("%s: Sequence() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestKeyEventStroke(t *testing.T) {
+	ks, ok := KeyEvent{VirtualKey: vkBack, ControlKeyState: leftCtrlPressed}.Stroke()
+	if !ok || !ks.Equals(kb.NewFnKeyStroke(kb.KeyCtrlBackspace)) {
+		t.Errorf("Ctrl+Backspace = %v, %v", ks, ok)
+	}
+	if ks, ok := (KeyEvent{VirtualKey: vkUp}).Stroke(); !ok || !ks.Equals(kb.NewUpArrowKeyStroke()) {
+		t.Errorf("Up = %v, %v", ks, ok)
+	}
+	if ks, ok := (KeyEvent{VirtualKey: 'W', ControlKeyState: rightCtrlPressed}).Stroke(); !ok || !ks.Equals(kb.NewCtrlKeyStroke('w')) {
+		t.Errorf("Ctrl+W = %v, %v", ks, ok)
+	}
+	if _, ok := (KeyEvent{VirtualKey: 'W', Char: 'w'}).Stroke(); ok {
+		t.Error("a plain letter should have no keystroke")
+	}
+}
+
+func TestConsoleReader(t *testing.T) {
+	batches := [][]KeyEvent{
+		{{VirtualKey: vkUp}, {VirtualKey: 'A', Char: 'a', Repeat: 2}},
+		{},
+		// U+1F600 arrives as two events, one per UTF-16 code unit.
+		{{Char: 0xd83d}, {Char: 0xde00}},
+	}
+	r := NewConsoleReader(func() ([]KeyEvent, error) {
+		if len(batches) == 0 {
+			return nil, io.EOF
+		}
+		events := batches[0]
+		batches = batches[1:]
+		return events, nil
+	})
+
+	var buf [1]byte
+	if _, err := r.Read(buf[:]); err != nil || buf[0] != 0x1b {
+		t.Fatalf("Read = %q, %v; want ESC", buf[0], err)
+	}
+	if got := r.Buffered(); got != 4 {
+		t.Errorf("Buffered() = %d, want the rest of the arrow and two a's", got)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != "[Aaa😀" {
+		t.Errorf("rest = %q, %v; want %q", got, err, "[Aaa😀")
+	}
+}
@@ -1,7 +1,11 @@
 // Package termio provides small terminal utilities shared across the interactive UI.
 package termio
 
-import "golang.org/x/term"
+import (
+	"time"
+
+	"golang.org/x/term"
+)
 
 // Terminal abstracts terminal raw mode operations so callers can swap implementations in tests.
 type Terminal interface {
@@ -9,17 +13,19 @@ type Terminal interface {
 	Restore(fd int, state *term.State) error
 }
 
-// DefaultTerminal uses golang.org/x/term to manage terminal state.
+// DefaultTerminal uses golang.org/x/term to manage terminal state. On
+// Windows it also turns on VT processing for the console's output, so the
+// escape sequences the UI draws with are interpreted.
 type DefaultTerminal struct{}
 
 // MakeRaw switches the terminal into raw mode.
 func (DefaultTerminal) MakeRaw(fd int) (*term.State, error) {
-	return term.MakeRaw(fd)
+	return makeRaw(fd)
 }
 
 // Restore returns the terminal to its previous state.
 func (DefaultTerminal) Restore(fd int, state *term.State) error {
-	return term.Restore(fd, state)
+	return restore(fd, state)
 }
 
 var pendingInputHook = pendingInput
@@ -35,3 +41,25 @@ func SetPendingInputFunc(fn func(uintptr) (int, error)) func() {
 	pendingInputHook = fn
 	return func() { pendingInputHook = prev }
 }
+
+// waitInputPoll is how often WaitInput checks for input.
+const waitInputPoll = 5 * time.Millisecond
+
+// WaitInput reports whether input becomes readable on fd within timeout.
+// A zero timeout only checks what is pending already.
+func WaitInput(fd uintptr, timeout time.Duration) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		pending, err := PendingInput(fd)
+		if err != nil {
+			return false, err
+		}
+		if pending > 0 {
+			return true, nil
+		}
+		if !time.Now().Before(deadline) {
+			return false, nil
+		}
+		time.Sleep(waitInputPoll)
+	}
+}
@@ -1,6 +1,10 @@
 package termio
 
-import "testing"
+import (
+	"errors"
+	"testing"
+	"time"
+)
 
 func TestPendingInputHookOverride(t *testing.T) {
 	const (
@@ -45,3 +49,29 @@ func TestPendingInputHookOverride(t *testing.T) {
 		t.Fatalf("pendingInput after restore called %d times, want 1", stubHits)
 	}
 }
+
+func TestWaitInput(t *testing.T) {
+	calls := 0
+	restore := SetPendingInputFunc(func(uintptr) (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, nil
+		}
+		return 1, nil
+	})
+	t.Cleanup(restore)
+
+	if ready, err := WaitInput(0, 0); err != nil || ready {
+		t.Fatalf("WaitInput(0) = %v, %v; want no input without waiting", ready, err)
+	}
+	if ready, err := WaitInput(0, time.Second); err != nil || !ready {
+		t.Fatalf("WaitInput(1s) = %v, %v; want the input that arrives", ready, err)
+	}
+
+	restore()
+	restore2 := SetPendingInputFunc(func(uintptr) (int, error) { return 0, errors.New("not a terminal") })
+	t.Cleanup(restore2)
+	if _, err := WaitInput(0, time.Second); err == nil {
+		t.Fatal("WaitInput should return the probe's error")
+	}
+}
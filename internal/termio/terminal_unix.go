@@ -0,0 +1,24 @@
+//go:build !windows
+
+package termio
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+func makeRaw(fd int) (*term.State, error) {
+	return term.MakeRaw(fd)
+}
+
+func restore(fd int, state *term.State) error {
+	return term.Restore(fd, state)
+}
+
+// NewInput returns what keys are read from in raw mode. Terminals send
+// escape sequences, which the UI decodes itself, so this is f.
+func NewInput(f *os.File) io.Reader {
+	return f
+}
@@ -0,0 +1,132 @@
+//go:build windows
+
+package termio
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/term"
+)
+
+var readConsoleInput = kernel32.NewProc("ReadConsoleInputW")
+
+// output remembers the mode of the console's output from before makeRaw
+// turned on VT processing, so restore can put it back.
+var output struct {
+	sync.Mutex
+	saved bool
+	mode  uint32
+}
+
+// makeRaw switches the console to raw mode and turns on VT processing for
+// its output. Consoles older than Windows 10 reject the VT input mode that
+// term.MakeRaw asks for; they are put in raw mode without it, and NewInput
+// translates their key events instead.
+func makeRaw(fd int) (*term.State, error) {
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		if state, err = makeRawLegacy(fd); err != nil {
+			return nil, err
+		}
+	}
+	enableVirtualTerminalOutput()
+	return state, nil
+}
+
+// makeRawLegacy does what term.MakeRaw does, except for asking for VT
+// input.
+func makeRawLegacy(fd int) (*term.State, error) {
+	state, err := term.GetState(fd)
+	if err != nil {
+		return nil, err
+	}
+	var mode uint32
+	if err := windows.GetConsoleMode(windows.Handle(fd), &mode); err != nil {
+		return nil, err
+	}
+	mode &^= windows.ENABLE_ECHO_INPUT | windows.ENABLE_PROCESSED_INPUT | windows.ENABLE_LINE_INPUT | windows.ENABLE_PROCESSED_OUTPUT
+	if err := windows.SetConsoleMode(windows.Handle(fd), mode); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// enableVirtualTerminalOutput turns on VT processing for stdout when it is
+// a console that has it off. Consoles that cannot do it are left alone.
+func enableVirtualTerminalOutput() {
+	output.Lock()
+	defer output.Unlock()
+	h := windows.Handle(os.Stdout.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(h, &mode); err != nil || mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return
+	}
+	if err := windows.SetConsoleMode(h, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err == nil && !output.saved {
+		output.saved, output.mode = true, mode
+	}
+}
+
+func restore(fd int, state *term.State) error {
+	output.Lock()
+	if output.saved {
+		_ = windows.SetConsoleMode(windows.Handle(os.Stdout.Fd()), output.mode)
+		output.saved = false
+	}
+	output.Unlock()
+	return term.Restore(fd, state)
+}
+
+// NewInput returns what keys are read from in raw mode. A console with VT
+// input on sends escape sequences, which the UI decodes itself; for one
+// without, the key events are read and translated by a ConsoleReader.
+func NewInput(f *os.File) io.Reader {
+	var mode uint32
+	h := windows.Handle(f.Fd())
+	if err := windows.GetConsoleMode(h, &mode); err != nil || mode&windows.ENABLE_VIRTUAL_TERMINAL_INPUT != 0 {
+		return f
+	}
+	return NewConsoleReader(func() ([]KeyEvent, error) { return readKeyEvents(h) })
+}
+
+// readKeyEvents waits for input events on the console h and returns the
+// key presses among them.
+func readKeyEvents(h windows.Handle) ([]KeyEvent, error) {
+	var records [16]inputRecord
+	var n uint32
+	ret, _, err := readConsoleInput.Call(
+		uintptr(h),
+		uintptr(unsafe.Pointer(&records[0])),
+		uintptr(len(records)),
+		uintptr(unsafe.Pointer(&n)),
+	)
+	if ret == 0 {
+		return nil, err
+	}
+	var events []KeyEvent
+	for _, r := range records[:n] {
+		if r.EventType != keyEvent {
+			continue
+		}
+		if e, down := decodeKeyEvent(r.Event); down {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+// decodeKeyEvent reads a KEY_EVENT_RECORD: bKeyDown, wRepeatCount,
+// wVirtualKeyCode, wVirtualScanCode, uChar and dwControlKeyState.
+func decodeKeyEvent(b [16]byte) (e KeyEvent, down bool) {
+	le := binary.LittleEndian
+	return KeyEvent{
+		Repeat:          le.Uint16(b[4:]),
+		VirtualKey:      le.Uint16(b[6:]),
+		Char:            rune(le.Uint16(b[10:])),
+		ControlKeyState: le.Uint32(b[12:]),
+	}, le.Uint32(b[0:]) != 0
+}
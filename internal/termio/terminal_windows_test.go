@@ -0,0 +1,174 @@
+//go:build windows
+
+package termio
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// conptyChildEnv marks the test binary started inside a pseudo console by
+// TestConPTYKeys.
+const conptyChildEnv = "GGC_TERMIO_CONPTY_CHILD"
+
+// conptyKeys are what TestConPTYKeys types into the pseudo console: Up and
+// Home as VT sequences, Ctrl+Backspace in win32-input-mode (down and up),
+// which is how Windows Terminal reports keys that have no VT sequence, and
+// q to end.
+const conptyKeys = "\x1b[A\x1b[H\x1b[8;14;127;1;8;1_\x1b[8;14;127;0;8;1_q"
+
+// TestConPTYKeys runs ggc's console input in a pseudo console with VT
+// input off, as on legacy consoles, and checks that the key events the
+// console reports come out as the sequences the interactive UI decodes.
+func TestConPTYKeys(t *testing.T) {
+	inR, inW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = inW.Close() }()
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = outR.Close() }()
+
+	var console windows.Handle
+	err = windows.CreatePseudoConsole(windows.Coord{X: 80, Y: 25}, windows.Handle(inR.Fd()), windows.Handle(outW.Fd()), 0, &console)
+	// The pseudo console holds its own copies of its ends of the pipes.
+	_ = inR.Close()
+	_ = outW.Close()
+	if err != nil {
+		t.Skipf("pseudo consoles are not available: %v", err)
+	}
+	closeConsole := sync.OnceFunc(func() { windows.ClosePseudoConsole(console) })
+	defer closeConsole()
+
+	attrs, err := windows.NewProcThreadAttributeList(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer attrs.Delete()
+	// The attribute's value is the console handle itself.
+	if err := attrs.Update(windows.PROC_THREAD_ATTRIBUTE_PSEUDOCONSOLE, *(*unsafe.Pointer)(unsafe.Pointer(&console)), unsafe.Sizeof(console)); err != nil {
+		t.Fatal(err)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(conptyChildEnv, "1")
+	si := &windows.StartupInfoEx{ProcThreadAttributeList: attrs.List()}
+	si.Cb = uint32(unsafe.Sizeof(*si))
+	var pi windows.ProcessInformation
+	cmdline := windows.StringToUTF16Ptr(windows.EscapeArg(exe) + " -test.run=^TestConPTYKeysChild$")
+	if err := windows.CreateProcess(nil, cmdline, nil, nil, false, windows.EXTENDED_STARTUPINFO_PRESENT, nil, nil, &si.StartupInfo, &pi); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = windows.CloseHandle(pi.Process)
+		_ = windows.CloseHandle(pi.Thread)
+	}()
+
+	var mu sync.Mutex
+	var out bytes.Buffer
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := outR.Read(buf)
+			mu.Lock()
+			out.Write(buf[:n])
+			mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}()
+	waitFor := func(re *regexp.Regexp) []string {
+		t.Helper()
+		for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+			mu.Lock()
+			m := re.FindStringSubmatch(out.String())
+			mu.Unlock()
+			if m != nil {
+				return m
+			}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		t.Fatalf("pseudo console output has no %s:\n%q", re, out.String())
+		return nil
+	}
+
+	waitFor(regexp.MustCompile(`READY`))
+	if _, err := inW.Write([]byte(conptyKeys)); err != nil {
+		t.Fatal(err)
+	}
+	keys := waitFor(regexp.MustCompile(`KEYS ([0-9a-f]*)\.`))[1]
+
+	want := hex.EncodeToString([]byte("\x1b[A\x1b[H\x1b[27;5;127~"))
+	if keys != want {
+		t.Errorf("keys = %s, want %s", keys, want)
+	}
+	_, _ = windows.WaitForSingleObject(pi.Process, 10000)
+	closeConsole()
+}
+
+// TestConPTYKeysChild is the program TestConPTYKeys runs in its pseudo
+// console. It reads keys until q and prints them in hex.
+func TestConPTYKeysChild(t *testing.T) {
+	if os.Getenv(conptyChildEnv) != "1" {
+		t.Skip("run inside a pseudo console by TestConPTYKeys")
+	}
+	in, err := os.OpenFile("CONIN$", os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile("CONOUT$", os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = out.Close() }()
+
+	fd := int(in.Fd())
+	state, err := DefaultTerminal{}.MakeRaw(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = DefaultTerminal{}.Restore(fd, state) }()
+	// Turn VT input off, as legacy consoles have it, so that keys arrive
+	// as key events.
+	var mode uint32
+	if err := windows.GetConsoleMode(windows.Handle(in.Fd()), &mode); err != nil {
+		t.Fatal(err)
+	}
+	if err := windows.SetConsoleMode(windows.Handle(in.Fd()), mode&^windows.ENABLE_VIRTUAL_TERMINAL_INPUT); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := NewInput(in)
+	if _, ok := keys.(*ConsoleReader); !ok {
+		t.Fatalf("NewInput = %T, want a *ConsoleReader", keys)
+	}
+	_, _ = fmt.Fprint(out, "READY\r\n")
+	var read []byte
+	buf := make([]byte, 16)
+	for !bytes.HasSuffix(read, []byte("q")) {
+		n, err := keys.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		read = append(read, buf[:n]...)
+	}
+	_, _ = fmt.Fprintf(out, "KEYS %s.\r\n", hex.EncodeToString(bytes.TrimSuffix(read, []byte("q"))))
+}
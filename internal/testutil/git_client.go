@@ -5,6 +5,9 @@
 package testutil
 
 import (
+	"context"
+	"io"
+
 	"github.com/bmf-san/ggc/v8/internal/git"
 )
 
@@ -36,22 +39,27 @@ func (m *MockGitClient) GetCurrentBranch() (string, error) { return m.currentBra
 func (m *MockGitClient) GetBranchName() (string, error)    { return m.currentBranch, nil }
 
 // Status Operations
-func (m *MockGitClient) Status() (string, error)               { return m.gitStatus, nil }
-func (m *MockGitClient) StatusShort() (string, error)          { return m.gitStatus, nil }
-func (m *MockGitClient) StatusWithColor() (string, error)      { return m.gitStatus, nil }
-func (m *MockGitClient) StatusShortWithColor() (string, error) { return m.gitStatus, nil }
+func (m *MockGitClient) Status() (string, error)                        { return m.gitStatus, nil }
+func (m *MockGitClient) StatusShort() (string, error)                   { return m.gitStatus, nil }
+func (m *MockGitClient) StatusWithColor(...string) (string, error)      { return m.gitStatus, nil }
+func (m *MockGitClient) StatusShortWithColor(...string) (string, error) { return m.gitStatus, nil }
 
 // Staging Operations
-func (m *MockGitClient) Add(_ ...string) error { return nil }
-func (m *MockGitClient) AddInteractive() error { return nil }
+func (m *MockGitClient) Add(_ ...string) error                     { return nil }
+func (m *MockGitClient) AddInteractive() error                     { return nil }
+func (m *MockGitClient) UnstagedFiles(...string) ([]string, error) { return nil, nil }
+func (m *MockGitClient) ApplyToIndex(_ string, _ bool) error       { return nil }
 
 // Commit Operations
-func (m *MockGitClient) Commit(_ string) error                 { return nil }
-func (m *MockGitClient) CommitAmend() error                    { return nil }
-func (m *MockGitClient) CommitAmendNoEdit() error              { return nil }
-func (m *MockGitClient) CommitAmendWithMessage(_ string) error { return nil }
-func (m *MockGitClient) CommitAllowEmpty() error               { return nil }
-func (m *MockGitClient) CommitFixup(_ string) error            { return nil }
+func (m *MockGitClient) Commit(_ string) error                                { return nil }
+func (m *MockGitClient) CommitAmend() error                                   { return nil }
+func (m *MockGitClient) CommitAmendWith(git.AmendOptions) error               { return nil }
+func (m *MockGitClient) RemoteBranchesContaining(string) ([]string, error)    { return nil, nil }
+func (m *MockGitClient) CommitAmendNoEdit() error                             { return nil }
+func (m *MockGitClient) CommitAmendWithMessage(_ string) error                { return nil }
+func (m *MockGitClient) CommitAllowEmpty() error                              { return nil }
+func (m *MockGitClient) CommitFixup(_ string) error                           { return nil }
+func (m *MockGitClient) CommitMessages(_ string) ([]git.CommitMessage, error) { return nil, nil }
 
 // Diff Operations
 func (m *MockGitClient) Diff() (string, error)       { return "", nil }
@@ -74,55 +82,156 @@ func (m *MockGitClient) ListMergedBranches() ([]string, error)         { return
 func (m *MockGitClient) RevParseVerify(_ string) bool                  { return true }
 
 // Remote Operations
-func (m *MockGitClient) Push(_ bool) error              { return nil }
-func (m *MockGitClient) Pull(_ bool) error              { return nil }
-func (m *MockGitClient) Fetch(_ bool) error             { return nil }
-func (m *MockGitClient) RemoteList() error              { return nil }
-func (m *MockGitClient) RemoteAdd(_, _ string) error    { return nil }
-func (m *MockGitClient) RemoteRemove(_ string) error    { return nil }
-func (m *MockGitClient) RemoteSetURL(_, _ string) error { return nil }
+func (m *MockGitClient) PushForce(bool) error                                        { return nil }
+func (m *MockGitClient) PushPreview() (*git.PushPreview, error)                      { return &git.PushPreview{}, nil }
+func (m *MockGitClient) Push(_ bool) error                                           { return nil }
+func (m *MockGitClient) Pull(_ bool) error                                           { return nil }
+func (m *MockGitClient) Fetch(_ bool) error                                          { return nil }
+func (m *MockGitClient) FetchWith(_ git.FetchOptions) error                          { return nil }
+func (m *MockGitClient) FetchRemote(_ string, _ git.FetchOptions, _ io.Writer) error { return nil }
+func (m *MockGitClient) RefTips(_ ...string) (map[string]string, error)              { return nil, nil }
+func (m *MockGitClient) RemoteList() error                                           { return nil }
+func (m *MockGitClient) RemoteAdd(_, _ string) error                                 { return nil }
+func (m *MockGitClient) RemoteRemove(_ string) error                                 { return nil }
+func (m *MockGitClient) RemoteSetURL(_, _ string) error                              { return nil }
+func (m *MockGitClient) RemoteRename(_, _ string) error                              { return nil }
+func (m *MockGitClient) RemoteReachable(_ string) error                              { return nil }
+func (m *MockGitClient) RemoteGetURL(_ string) (string, error) {
+	return "git@github.com:owner/repo.git", nil
+}
+func (m *MockGitClient) PushSetUpstream(_, _ string) error    { return nil }
+func (m *MockGitClient) DeleteRemoteBranch(_, _ string) error { return nil }
+func (m *MockGitClient) FetchRefspec(_, _ string) error       { return nil }
+func (m *MockGitClient) Clone(_, _ string, _ git.CloneOptions) error {
+	return nil
+}
+func (m *MockGitClient) ConfigSetIn(_, _, _ string) error { return nil }
 
 // Tag Operations
-func (m *MockGitClient) TagList(_ []string) error              { return nil }
-func (m *MockGitClient) TagCreate(_, _ string) error           { return nil }
-func (m *MockGitClient) TagCreateAnnotated(_, _ string) error  { return nil }
-func (m *MockGitClient) TagDelete(_ []string) error            { return nil }
-func (m *MockGitClient) TagPush(_, _ string) error             { return nil }
-func (m *MockGitClient) TagPushAll(_ string) error             { return nil }
-func (m *MockGitClient) TagShow(_ string) error                { return nil }
-func (m *MockGitClient) GetLatestTag() (string, error)         { return "v1.0.0", nil }
-func (m *MockGitClient) TagExists(_ string) bool               { return true }
-func (m *MockGitClient) GetTagCommit(_ string) (string, error) { return "abc123", nil }
+func (m *MockGitClient) TagList(_ []string) error                  { return nil }
+func (m *MockGitClient) TagCreate(_, _ string) error               { return nil }
+func (m *MockGitClient) TagCreateAnnotated(_, _ string) error      { return nil }
+func (m *MockGitClient) TagCreateAnnotatedAt(_, _, _ string) error { return nil }
+func (m *MockGitClient) NearestTag(_ string) (string, error)       { return "", nil }
+func (m *MockGitClient) TagNames() ([]string, error)               { return nil, nil }
+func (m *MockGitClient) RemoteNames() ([]string, error)            { return nil, nil }
+func (m *MockGitClient) TagDelete(_ []string) error                { return nil }
+func (m *MockGitClient) TagPush(_, _ string) error                 { return nil }
+func (m *MockGitClient) TagPushAll(_ string) error                 { return nil }
+func (m *MockGitClient) TagShow(_ string) error                    { return nil }
+func (m *MockGitClient) GetLatestTag() (string, error)             { return "v1.0.0", nil }
+func (m *MockGitClient) TagExists(_ string) bool                   { return true }
+func (m *MockGitClient) GetTagCommit(_ string) (string, error)     { return "abc123", nil }
+func (m *MockGitClient) TagCreateSigned(_, _, _ string) error      { return nil }
+
+// Signature Operations
+func (m *MockGitClient) CommitSignatures(_ string) ([]git.Signature, error) { return nil, nil }
+func (m *MockGitClient) TagSignatures(_ []string) ([]git.Signature, error)  { return nil, nil }
 
 // Log Operations
-func (m *MockGitClient) LogSimple() error                       { return nil }
-func (m *MockGitClient) LogGraph() error                        { return nil }
-func (m *MockGitClient) LogOneline(_, _ string) (string, error) { return "", nil }
+func (m *MockGitClient) LogSimple(...string) error                            { return nil }
+func (m *MockGitClient) LogGraph(...string) error                             { return nil }
+func (m *MockGitClient) LogOneline(_, _ string) (string, error)               { return "", nil }
+func (m *MockGitClient) LogGraphLines(_ int) ([]git.GraphLine, error)         { return nil, nil }
+func (m *MockGitClient) Blame(_, _ string) ([]git.BlameLine, error)           { return nil, nil }
+func (m *MockGitClient) ListCommits(_ ...string) ([]git.CommitSummary, error) { return nil, nil }
+func (m *MockGitClient) FileHistory(_ string, _ bool) ([]git.FileRevision, error) {
+	return nil, nil
+}
+
+// Cherry-pick and Revert Operations
+func (m *MockGitClient) CherryPick(_ ...string) error { return nil }
+func (m *MockGitClient) Revert(_ ...string) error     { return nil }
+
+// Merge Operations
+func (m *MockGitClient) Merge(_ string) error { return nil }
+
+// Stack Operations
+func (m *MockGitClient) StackLinks() ([]git.StackLink, error) { return nil, nil }
+func (m *MockGitClient) SetStackLink(_ git.StackLink) error   { return nil }
+func (m *MockGitClient) RebaseOnto(_, _, _ string) error      { return nil }
+
+// Reflog Operations
+func (m *MockGitClient) RecentBranches(_ int) ([]string, error) { return nil, nil }
 
 // Show Operations
-func (m *MockGitClient) Show(_ []string) error { return nil }
+func (m *MockGitClient) Show(_ []string) error                 { return nil }
+func (m *MockGitClient) ShowOutput(_ []string) (string, error) { return "", nil }
+func (m *MockGitClient) CommitDetail(_ string) (*git.CommitDetail, error) {
+	return &git.CommitDetail{}, nil
+}
+
+// Grep Operations
+func (m *MockGitClient) GrepStream(_ context.Context, _ []string, _ func(git.GrepHit)) error {
+	return nil
+}
+
+// LFS Operations
+func (m *MockGitClient) LFSInstalled() bool          { return true }
+func (m *MockGitClient) LFSFiles() ([]string, error) { return nil, nil }
+
+// Sparse Checkout Operations
+func (m *MockGitClient) SparseCheckout() (*git.SparseCheckout, error) {
+	return &git.SparseCheckout{}, nil
+}
+func (m *MockGitClient) SparseSet(_ []string) error         { return nil }
+func (m *MockGitClient) SparseAdd(_ []string) error         { return nil }
+func (m *MockGitClient) SparseDisable() error               { return nil }
+func (m *MockGitClient) TreeDirectories() ([]string, error) { return nil, nil }
+
+// Reword Operations
+func (m *MockGitClient) RewordCommit(_, _ string) error { return nil }
+
+// Notes Operations
+func (m *MockGitClient) NotedCommits(_ string) ([]string, error) { return nil, nil }
+func (m *MockGitClient) Notes(_ string) ([]git.Note, error)      { return nil, nil }
+func (m *MockGitClient) NoteText(_, _ string) (string, error)    { return "", nil }
+func (m *MockGitClient) EditNote(_, _, _ string) error           { return nil }
+func (m *MockGitClient) PushNotes(_, _ string) error             { return nil }
+func (m *MockGitClient) FetchNotes(_, _ string) (bool, error)    { return false, nil }
+
+// Transport URL Operations
+func (m *MockGitClient) TransportURL(_ string, _ bool) (string, error) { return "", nil }
+
+// Repository State Operations
+func (m *MockGitClient) RepoState(_ string) (git.RepoState, error) { return git.RepoState{}, nil }
+
+// WIP Operations
+func (m *MockGitClient) CommitWIP(_ string) error  { return nil }
+func (m *MockGitClient) ResetMixed(_ string) error { return nil }
+
+// Outgoing Patch Operations
+func (m *MockGitClient) OutgoingPatch() (string, error) { return "", nil }
+
+// Maintenance Operations
+func (m *MockGitClient) RepoStats() (git.RepoStats, error)          { return git.RepoStats{}, nil }
+func (m *MockGitClient) LargestBlobs(_ int) ([]git.BlobSize, error) { return nil, nil }
+func (m *MockGitClient) BlobCommit(_ string) (string, error)        { return "", nil }
 
 // Passthrough Operations
 func (m *MockGitClient) RunGit(_ string, _ []string) error { return nil }
 
 // Rebase Operations
-func (m *MockGitClient) RebaseInteractive(_ int) error              { return nil }
-func (m *MockGitClient) RebaseInteractiveAutosquash(_ int) error    { return nil }
-func (m *MockGitClient) Rebase(_ string) error                      { return nil }
-func (m *MockGitClient) RebaseContinue() error                      { return nil }
-func (m *MockGitClient) RebaseAbort() error                         { return nil }
-func (m *MockGitClient) RebaseSkip() error                          { return nil }
-func (m *MockGitClient) GetUpstreamBranch(_ string) (string, error) { return "origin/main", nil }
+func (m *MockGitClient) RebaseInteractive(_ int) error                   { return nil }
+func (m *MockGitClient) RebaseInteractiveAutosquash(_ int) error         { return nil }
+func (m *MockGitClient) RebaseInteractiveWithTodo(_ int, _ string) error { return nil }
+func (m *MockGitClient) Rebase(_ string) error                           { return nil }
+func (m *MockGitClient) RebaseContinue() error                           { return nil }
+func (m *MockGitClient) RebaseAbort() error                              { return nil }
+func (m *MockGitClient) RebaseSkip() error                               { return nil }
+func (m *MockGitClient) GetUpstreamBranch(_ string) (string, error)      { return "origin/main", nil }
 
 // Stash Operations
-func (m *MockGitClient) Stash() error               { return nil }
-func (m *MockGitClient) StashList() (string, error) { return "", nil }
-func (m *MockGitClient) StashShow(_ string) error   { return nil }
-func (m *MockGitClient) StashApply(_ string) error  { return nil }
-func (m *MockGitClient) StashPop(_ string) error    { return nil }
-func (m *MockGitClient) StashPush(_ string) error   { return nil }
-func (m *MockGitClient) StashDrop(_ string) error   { return nil }
-func (m *MockGitClient) StashClear() error          { return nil }
+func (m *MockGitClient) Stash() error                       { return nil }
+func (m *MockGitClient) StashList() (string, error)         { return "", nil }
+func (m *MockGitClient) StashShow(_ string) error           { return nil }
+func (m *MockGitClient) StashApply(_ string) error          { return nil }
+func (m *MockGitClient) StashPop(_ string) error            { return nil }
+func (m *MockGitClient) StashPush(_ string) error           { return nil }
+func (m *MockGitClient) StashDrop(_ string) error           { return nil }
+func (m *MockGitClient) StashClear() error                  { return nil }
+func (m *MockGitClient) StashDiff(_ string) (string, error) { return "", nil }
+func (m *MockGitClient) StashBranch(_, _ string) error      { return nil }
 
 // Restore Operations
 func (m *MockGitClient) RestoreWorkingDir(_ ...string) error           { return nil }
@@ -143,10 +252,12 @@ func (m *MockGitClient) ResetHard(_ string) error { return nil }
 func (m *MockGitClient) ResetSoft(_ string) error { return nil }
 
 // Clean Operations
-func (m *MockGitClient) CleanFiles() error                { return nil }
-func (m *MockGitClient) CleanDirs() error                 { return nil }
-func (m *MockGitClient) CleanDryRun() (string, error)     { return "", nil }
-func (m *MockGitClient) CleanFilesForce(_ []string) error { return nil }
+func (m *MockGitClient) CleanFiles(...string) error                   { return nil }
+func (m *MockGitClient) CleanDirs(...string) error                    { return nil }
+func (m *MockGitClient) CleanDryRun(...string) (string, error)        { return "", nil }
+func (m *MockGitClient) CleanIgnoredDryRun(...string) (string, error) { return "", nil }
+func (m *MockGitClient) CleanFilesForce(_ []string) error             { return nil }
+func (m *MockGitClient) CleanPathsForce(_ []string) error             { return nil }
 
 // Utility Operations
 func (m *MockGitClient) ListFiles() (string, error) { return "", nil }
@@ -188,5 +299,19 @@ func (m *MockGitClient) ListBranchesVerbose() ([]git.BranchInfo, error) {
 func (m *MockGitClient) MoveBranch(_, _ string) error            { return nil }
 func (m *MockGitClient) RenameBranch(_, _ string) error          { return nil }
 func (m *MockGitClient) SetUpstreamBranch(_, _ string) error     { return nil }
+func (m *MockGitClient) UnsetUpstreamBranch(_ string) error      { return nil }
 func (m *MockGitClient) SortBranches(_ string) ([]string, error) { return []string{"main"}, nil }
 func (m *MockGitClient) ValidateBranchName(_ string) error       { return nil }
+
+// Undo Operations
+func (m *MockGitClient) GitDir() (string, error)                     { return "", nil }
+func (m *MockGitClient) RevParse(_ string) (string, error)           { return "", nil }
+func (m *MockGitClient) CreateBranchAt(_, _ string) error            { return nil }
+func (m *MockGitClient) RestorePathsFrom(_ string, _ []string) error { return nil }
+func (m *MockGitClient) DeleteRef(_ string) error                    { return nil }
+func (m *MockGitClient) SnapshotPaths(_, _ string, _ []string) (string, error) {
+	return "", nil
+}
+func (m *MockGitClient) SaveSnapshot(_, _ string) (string, error) { return "", nil }
+func (m *MockGitClient) Snapshots() ([]git.Snapshot, error)       { return nil, nil }
+func (m *MockGitClient) RestoreSnapshot(_ string) error           { return nil }
@@ -0,0 +1,107 @@
+// Package ticket finds issue-tracker references, such as PROJ-123, in
+// branch names and adds them to commit messages and pull request titles.
+package ticket
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/internal/commitmsg"
+)
+
+// DefaultFormat is how a reference is written when its rule has no
+// format.
+const DefaultFormat = "[{ID}]"
+
+// Rule describes how a ticket appears in branch names.
+type Rule struct {
+	// Pattern is a regular expression matched against the branch name;
+	// its first group, or else whole match, is the ticket ID.
+	Pattern string
+	// URL is the address of the ticket, with {ID} standing for the ID.
+	URL string
+	// Format is how the ticket is written in messages, with {ID}
+	// standing for the ID; DefaultFormat when empty.
+	Format string
+}
+
+// Ticket is a reference found in a branch name.
+type Ticket struct {
+	ID  string
+	Ref string // the ID written in the rule's format
+	URL string // "" when the rule has no URL
+}
+
+// Find returns the ticket in branch according to the first rule that
+// matches. Without rules it looks for a Jira-style key such as PROJ-123,
+// in any case, and upper-cases it.
+func Find(branch string, rules []Rule) (Ticket, bool) {
+	if len(rules) == 0 {
+		id := commitmsg.BranchVariables(branch, nil)["TICKET"]
+		if id == "" {
+			return Ticket{}, false
+		}
+		return newTicket(id, Rule{}), true
+	}
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		m := re.FindStringSubmatch(branch)
+		switch {
+		case m == nil:
+			continue
+		case len(m) > 1 && m[1] != "":
+			return newTicket(m[1], rule), true
+		case m[0] != "":
+			return newTicket(m[0], rule), true
+		}
+	}
+	return Ticket{}, false
+}
+
+func newTicket(id string, rule Rule) Ticket {
+	format := rule.Format
+	if format == "" {
+		format = DefaultFormat
+	}
+	t := Ticket{ID: id, Ref: strings.ReplaceAll(format, "{ID}", id)}
+	if rule.URL != "" {
+		t.URL = strings.ReplaceAll(rule.URL, "{ID}", id)
+	}
+	return t
+}
+
+// Placement is where Apply adds the reference to a message.
+type Placement string
+
+// Placements, as written in the config.
+const (
+	Off    Placement = ""
+	Prefix Placement = "prefix"
+	Suffix Placement = "suffix"
+)
+
+// Apply adds the reference of t to the first line of message, before or
+// after its text. A Conventional Commits type and scope stay in front.
+// Messages that already mention the ID are returned unchanged.
+func Apply(message string, t Ticket, where Placement) string {
+	if where == Off || t.ID == "" || strings.Contains(message, t.ID) {
+		return message
+	}
+	header, rest, hasRest := strings.Cut(message, "\n")
+	m := commitmsg.Parse(header)
+	switch where {
+	case Prefix:
+		m.Subject = t.Ref + " " + m.Subject
+	case Suffix:
+		m.Subject = strings.TrimRight(m.Subject, " ") + " " + t.Ref
+	default:
+		return message
+	}
+	if !hasRest {
+		return m.Header()
+	}
+	return m.Header() + "\n" + rest
+}
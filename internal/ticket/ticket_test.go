@@ -0,0 +1,49 @@
+package ticket
+
+import "testing"
+
+func TestFind(t *testing.T) {
+	rules := []Rule{
+		{Pattern: `^issue/(\d+)`, URL: "https://github.com/acme/app/issues/{ID}", Format: "#{ID}"},
+		{Pattern: `[A-Z]+-\d+`, URL: "https://acme.atlassian.net/browse/{ID}"},
+	}
+	tests := []struct {
+		branch string
+		rules  []Rule
+		want   Ticket
+		ok     bool
+	}{
+		{"feature/proj-12-login", nil, Ticket{ID: "PROJ-12", Ref: "[PROJ-12]"}, true},
+		{"main", nil, Ticket{}, false},
+		{"issue/42-crash", rules, Ticket{ID: "42", Ref: "#42", URL: "https://github.com/acme/app/issues/42"}, true},
+		{"feat/ABC-123-title", rules, Ticket{ID: "ABC-123", Ref: "[ABC-123]", URL: "https://acme.atlassian.net/browse/ABC-123"}, true},
+		{"feat/abc-123-title", rules, Ticket{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := Find(tt.branch, tt.rules)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("Find(%q) = %+v, %v; want %+v, %v", tt.branch, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	tk := Ticket{ID: "PROJ-1", Ref: "[PROJ-1]"}
+	tests := []struct {
+		msg   string
+		where Placement
+		want  string
+	}{
+		{"fix login", Prefix, "[PROJ-1] fix login"},
+		{"fix login", Suffix, "fix login [PROJ-1]"},
+		{"fix(auth)!: drop v1\n\nDetails.", Prefix, "fix(auth)!: [PROJ-1] drop v1\n\nDetails."},
+		{"PROJ-1: fix login", Prefix, "PROJ-1: fix login"},
+		{"fix login\n\nRefs PROJ-1", Suffix, "fix login\n\nRefs PROJ-1"},
+		{"fix login", Off, "fix login"},
+	}
+	for _, tt := range tests {
+		if got := Apply(tt.msg, tk, tt.where); got != tt.want {
+			t.Errorf("Apply(%q, %q) = %q, want %q", tt.msg, tt.where, got, tt.want)
+		}
+	}
+}
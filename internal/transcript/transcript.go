@@ -0,0 +1,198 @@
+// Package transcript records workflow runs: which steps ran with which
+// arguments, how each ended, how long it took and what it printed. Each
+// run is one JSON file under the user cache directory, so that `ggc
+// workflow rerun --failed` can repeat the steps that failed last time.
+package transcript
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMaxRuns is the number of runs kept. Older transcripts are
+// removed on the next save.
+const DefaultMaxRuns = 20
+
+// Status is how a step of a run ended.
+type Status string
+
+// Step statuses.
+const (
+	StatusOK      Status = "ok"
+	StatusSkipped Status = "skipped"
+	StatusFailed  Status = "failed"
+)
+
+// Step is one step of a run; a step of a parallel group is a step of its
+// own.
+type Step struct {
+	// Label names the step as the run's summary did, e.g. "[2] push
+	// current" or "[3.1] fetch origin".
+	Label string `json:"label"`
+	// Args is the command and arguments the step ran, with its
+	// placeholders filled in. A step that stopped before they were asked
+	// for keeps its placeholders.
+	Args []string `json:"args"`
+	// When, Confirm and ContinueOnError are the step's options, so a
+	// re-run behaves the same.
+	When            string `json:"when,omitempty"`
+	Confirm         bool   `json:"confirm,omitempty"`
+	ContinueOnError bool   `json:"continue_on_error,omitempty"`
+	Status          Status `json:"status"`
+	// Reason is why the step was skipped or how it failed.
+	Reason   string `json:"reason,omitempty"`
+	ExitCode int    `json:"exit_code"`
+	// DurationMS is how long the step ran, in milliseconds.
+	DurationMS int64 `json:"duration_ms"`
+	// Output is what the step printed. Only steps of parallel groups,
+	// which run as processes of their own, have their output captured;
+	// the others print straight to the terminal.
+	Output string `json:"output,omitempty"`
+}
+
+// Run is one execution of a workflow.
+type Run struct {
+	// Workflow is the name of the workflow, if it has one.
+	Workflow string    `json:"workflow,omitempty"`
+	Started  time.Time `json:"started"`
+	// DurationMS is how long the whole run took, in milliseconds.
+	DurationMS int64 `json:"duration_ms"`
+	// Canceled is set when the run was aborted before its last step.
+	Canceled bool   `json:"canceled,omitempty"`
+	Steps    []Step `json:"steps"`
+}
+
+// Failed returns the steps of the run that failed, in order.
+func (r *Run) Failed() []Step {
+	var failed []Step
+	for _, s := range r.Steps {
+		if s.Status == StatusFailed {
+			failed = append(failed, s)
+		}
+	}
+	return failed
+}
+
+// Store keeps the transcripts in a directory. A zero-value Store uses
+// DefaultDir and DefaultMaxRuns.
+type Store struct {
+	// Dir holds one JSON file per run. When empty, DefaultDir is used
+	// lazily on the first call.
+	Dir string
+	// MaxRuns caps the number of transcripts kept. Values <= 0 fall back
+	// to DefaultMaxRuns.
+	MaxRuns int
+}
+
+// DefaultDir returns the per-user transcript directory under the user
+// cache directory.
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locate user cache dir: %w", err)
+	}
+	return filepath.Join(base, "ggc", "workflow-runs"), nil
+}
+
+func (s *Store) dir() (string, error) {
+	if s.Dir != "" {
+		return s.Dir, nil
+	}
+	return DefaultDir()
+}
+
+func (s *Store) cap() int {
+	if s.MaxRuns > 0 {
+		return s.MaxRuns
+	}
+	return DefaultMaxRuns
+}
+
+// fileName names the transcript of run so that names sort by start time.
+func fileName(run *Run) string {
+	name := run.Started.UTC().Format("20060102-150405.000000")
+	if run.Workflow != "" {
+		name += "-" + strings.Map(func(r rune) rune {
+			if r == '-' || r == '_' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return '_'
+		}, run.Workflow)
+	}
+	return name + ".json"
+}
+
+// Save writes run to a file of its own and returns the file's path.
+// Transcripts beyond MaxRuns are removed, oldest first.
+func (s *Store) Save(run *Run) (string, error) {
+	dir, err := s.dir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fileName(run))
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return "", err
+	}
+	s.prune(dir)
+	return path, nil
+}
+
+// files returns the transcripts in dir, oldest first.
+func files(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// prune removes the oldest transcripts beyond the cap.
+func (s *Store) prune(dir string) {
+	files, err := files(dir)
+	if err != nil {
+		return
+	}
+	for excess := len(files) - s.cap(); excess > 0; excess-- {
+		_ = os.Remove(files[0])
+		files = files[1:]
+	}
+}
+
+// Last returns the most recent run and the file it was read from. ok is
+// false when no run has been recorded.
+func (s *Store) Last() (run Run, path string, ok bool, err error) {
+	dir, err := s.dir()
+	if err != nil {
+		return run, "", false, err
+	}
+	files, err := files(dir)
+	if err != nil || len(files) == 0 {
+		return run, "", false, err
+	}
+	path = files[len(files)-1]
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return run, "", false, nil
+		}
+		return run, path, false, err
+	}
+	if err := json.Unmarshal(data, &run); err != nil {
+		return run, path, false, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return run, path, true, nil
+}
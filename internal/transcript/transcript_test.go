@@ -0,0 +1,73 @@
+package transcript
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreSaveAndLast(t *testing.T) {
+	s := &Store{Dir: t.TempDir()}
+	if _, _, ok, err := s.Last(); ok || err != nil {
+		t.Fatalf("Last() on an empty store = %v, %v", ok, err)
+	}
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i, name := range []string{"ship", "release/rc"} {
+		run := &Run{
+			Workflow: name,
+			Started:  start.Add(time.Duration(i) * time.Minute),
+			Steps: []Step{
+				{Label: "[1] add .", Args: []string{"add", "."}, Status: StatusOK},
+				{Label: "[2] push current", Args: []string{"push", "current"}, Status: StatusFailed, Reason: "rejected", ExitCode: 1},
+			},
+		}
+		path, err := s.Save(run)
+		if err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if filepath.Dir(path) != s.Dir {
+			t.Errorf("saved to %s, want a file in %s", path, s.Dir)
+		}
+	}
+
+	run, path, ok, err := s.Last()
+	if err != nil || !ok {
+		t.Fatalf("Last() = %v, %v", ok, err)
+	}
+	if run.Workflow != "release/rc" || filepath.Base(path) != "20260102-030505.000000-release_rc.json" {
+		t.Errorf("Last() = %q from %s, want the release/rc run", run.Workflow, path)
+	}
+	failed := run.Failed()
+	if len(failed) != 1 || failed[0].Label != "[2] push current" || failed[0].Reason != "rejected" {
+		t.Errorf("Failed() = %+v", failed)
+	}
+}
+
+func TestStorePrunesOldRuns(t *testing.T) {
+	s := &Store{Dir: t.TempDir(), MaxRuns: 2}
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		if _, err := s.Save(&Run{Started: start.Add(time.Duration(i) * time.Second)}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Name() != "20260102-030407.000000.json" {
+		t.Errorf("kept %v, want the two newest runs", entries)
+	}
+}
+
+func TestStoreLastCorrupt(t *testing.T) {
+	s := &Store{Dir: t.TempDir()}
+	if err := os.WriteFile(filepath.Join(s.Dir, "20260102-030405.000000.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok, err := s.Last(); ok || err == nil {
+		t.Errorf("Last() = %v, %v, want a parse error", ok, err)
+	}
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrNoClipboard is returned when there is neither a clipboard tool nor a
+// terminal to send OSC 52 to.
+var ErrNoClipboard = errors.New("no clipboard available: install pbcopy, wl-copy, xclip or xsel, or run in a terminal that supports OSC 52")
+
+// Clipboard copies text to the system clipboard. On the local machine it
+// runs the platform's clipboard tool: pbcopy, clip.exe, wl-copy, xclip or
+// xsel. Over SSH, or when none is found, it writes the OSC 52 escape
+// sequence to the terminal, which most terminals honor and forward from a
+// remote host.
+type Clipboard struct {
+	tty         io.Writer // nil when output is not a terminal
+	goos        string
+	getenv      func(string) string
+	lookPath    func(string) (string, error)
+	execCommand func(string, ...string) *exec.Cmd
+}
+
+// NewClipboard returns a clipboard that falls back to OSC 52 on tty, the
+// terminal output. tty may be nil when there is no terminal.
+func NewClipboard(tty io.Writer) *Clipboard {
+	return &Clipboard{
+		tty:         tty,
+		goos:        runtime.GOOS,
+		getenv:      os.Getenv,
+		lookPath:    exec.LookPath,
+		execCommand: exec.Command,
+	}
+}
+
+// Copy puts text on the clipboard.
+func (c *Clipboard) Copy(text string) error {
+	if !c.remote() {
+		for _, tool := range c.tools() {
+			if _, err := c.lookPath(tool[0]); err != nil {
+				continue
+			}
+			cmd := c.execCommand(tool[0], tool[1:]...)
+			cmd.Stdin = strings.NewReader(text)
+			if err := cmd.Run(); err == nil {
+				return nil
+			}
+		}
+	}
+	if c.tty == nil {
+		return ErrNoClipboard
+	}
+	return CopyOSC52(c.tty, text)
+}
+
+// remote reports whether ggc runs over SSH, where the local clipboard
+// tools would copy on the wrong machine.
+func (c *Clipboard) remote() bool {
+	return c.getenv("SSH_TTY") != "" || c.getenv("SSH_CONNECTION") != ""
+}
+
+// tools returns the clipboard commands to try, in order.
+func (c *Clipboard) tools() [][]string {
+	switch c.goos {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip.exe"}}
+	}
+	var tools [][]string
+	if c.getenv("WAYLAND_DISPLAY") != "" {
+		tools = append(tools, []string{"wl-copy"})
+	}
+	if c.getenv("DISPLAY") != "" {
+		tools = append(tools, []string{"xclip", "-selection", "clipboard"}, []string{"xsel", "--clipboard", "--input"})
+	}
+	// Under WSL the Windows clipboard is reachable through clip.exe.
+	return append(tools, []string{"clip.exe"})
+}
+
+// CopyOSC52 puts text on the clipboard of the terminal behind w with the
+// OSC 52 escape sequence.
+func CopyOSC52(w io.Writer, text string) error {
+	_, err := fmt.Fprintf(w, "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	return err
+}
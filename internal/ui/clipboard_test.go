@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func newTestClipboard(tty *bytes.Buffer, goos string, env map[string]string, installed ...string) (*Clipboard, *[][]string) {
+	var ran [][]string
+	c := NewClipboard(nil)
+	if tty != nil {
+		c.tty = tty
+	}
+	c.goos = goos
+	c.getenv = func(key string) string { return env[key] }
+	c.lookPath = func(name string) (string, error) {
+		for _, tool := range installed {
+			if tool == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", exec.ErrNotFound
+	}
+	c.execCommand = func(name string, args ...string) *exec.Cmd {
+		ran = append(ran, append([]string{name}, args...))
+		return exec.Command("true")
+	}
+	return c, &ran
+}
+
+func TestClipboard_Copy(t *testing.T) {
+	tests := []struct {
+		name      string
+		goos      string
+		env       map[string]string
+		installed []string
+		want      [][]string
+		osc52     bool
+	}{
+		{"macOS", "darwin", nil, []string{"pbcopy"}, [][]string{{"pbcopy"}}, false},
+		{"Windows", "windows", nil, []string{"clip.exe"}, [][]string{{"clip.exe"}}, false},
+		{"X11", "linux", map[string]string{"DISPLAY": ":0"}, []string{"xclip", "xsel"}, [][]string{{"xclip", "-selection", "clipboard"}}, false},
+		{"X11 with xsel only", "linux", map[string]string{"DISPLAY": ":0"}, []string{"xsel"}, [][]string{{"xsel", "--clipboard", "--input"}}, false},
+		{"Wayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, []string{"wl-copy", "xclip"}, [][]string{{"wl-copy"}}, false},
+		{"WSL", "linux", nil, []string{"clip.exe"}, [][]string{{"clip.exe"}}, false},
+		{"no tool", "linux", map[string]string{"DISPLAY": ":0"}, nil, nil, true},
+		{"over SSH", "darwin", map[string]string{"SSH_TTY": "/dev/pts/1"}, []string{"pbcopy"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tty bytes.Buffer
+			c, ran := newTestClipboard(&tty, tt.goos, tt.env, tt.installed...)
+			if err := c.Copy("abc123"); err != nil {
+				t.Fatalf("Copy: %v", err)
+			}
+			if !reflect.DeepEqual(*ran, tt.want) {
+				t.Errorf("ran %v, want %v", *ran, tt.want)
+			}
+			if got := tty.String() != ""; got != tt.osc52 {
+				t.Errorf("OSC 52 written = %v, want %v (%q)", got, tt.osc52, tty.String())
+			}
+		})
+	}
+}
+
+func TestClipboard_CopyWithoutTerminal(t *testing.T) {
+	c, _ := newTestClipboard(nil, "linux", nil)
+	if err := c.Copy("abc123"); !errors.Is(err, ErrNoClipboard) {
+		t.Errorf("Copy error = %v, want ErrNoClipboard", err)
+	}
+}
+
+func TestCopyOSC52(t *testing.T) {
+	var out bytes.Buffer
+	if err := CopyOSC52(&out, "hash001"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "\x1b]52;c;aGFzaDAwMQ==\a"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/term"
+)
+
+// ColorMode selects when output is colored.
+type ColorMode int32
+
+// Color modes, from the --color flag or the ui.color setting.
+const (
+	// ColorAuto colors terminals unless the environment opts out.
+	ColorAuto ColorMode = iota
+	// ColorAlways colors every output, even pipes and files.
+	ColorAlways
+	// ColorNever never colors output.
+	ColorNever
+)
+
+// String returns the flag spelling of m.
+func (m ColorMode) String() string {
+	switch m {
+	case ColorAlways:
+		return "always"
+	case ColorNever:
+		return "never"
+	default:
+		return "auto"
+	}
+}
+
+// ParseColorMode parses "auto", "always" or "never".
+func ParseColorMode(s string) (ColorMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "auto", "":
+		return ColorAuto, nil
+	case "always":
+		return ColorAlways, nil
+	case "never":
+		return ColorNever, nil
+	}
+	return ColorAuto, fmt.Errorf("invalid color mode %q (want auto, always or never)", s)
+}
+
+var colorMode atomic.Int32
+
+// getenv is swapped in tests.
+var getenv = os.Getenv
+
+// SetColorMode sets the process-wide color mode.
+func SetColorMode(m ColorMode) {
+	colorMode.Store(int32(m))
+}
+
+// CurrentColorMode returns the mode set by SetColorMode.
+func CurrentColorMode() ColorMode {
+	return ColorMode(colorMode.Load())
+}
+
+// explicitColor returns the decision made by the color mode or the
+// environment, if any: the mode first, then NO_COLOR (any non-empty value
+// disables color, see no-color.org), then CLICOLOR_FORCE (any value other
+// than "0" enables it), then TERM=dumb.
+func explicitColor() (enabled, decided bool) {
+	switch CurrentColorMode() {
+	case ColorAlways:
+		return true, true
+	case ColorNever:
+		return false, true
+	}
+	if getenv("NO_COLOR") != "" {
+		return false, true
+	}
+	if v := getenv("CLICOLOR_FORCE"); v != "" && v != "0" {
+		return true, true
+	}
+	if getenv("TERM") == "dumb" {
+		return false, true
+	}
+	return false, false
+}
+
+// ColorEnabled reports whether output written to w should be colored:
+// an explicit mode or environment setting decides first, otherwise color
+// is used only when w is a terminal.
+func ColorEnabled(w io.Writer) bool {
+	if enabled, decided := explicitColor(); decided {
+		return enabled
+	}
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// ColorsFor returns the palette for output to w: the ANSI codes when
+// ColorEnabled(w), otherwise NoColors.
+func ColorsFor(w io.Writer) *ANSIColors {
+	if ColorEnabled(w) {
+		return NewANSIColors()
+	}
+	return NoColors()
+}
+
+// TerminalColors returns the palette for full-screen views, which only run
+// on a terminal. Only an explicit opt-out (--no-color, NO_COLOR, TERM=dumb
+// or ui.color: false) turns color off there.
+func TerminalColors() *ANSIColors {
+	if enabled, decided := explicitColor(); decided && !enabled {
+		return NoColors()
+	}
+	return NewANSIColors()
+}
+
+// NoColors returns a palette of empty codes, so text formatted with it is
+// plain.
+func NoColors() *ANSIColors {
+	return &ANSIColors{}
+}
+
+var sgrPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// StripANSI removes SGR color sequences, for output from tools that were
+// asked to color unconditionally.
+func StripANSI(s string) string {
+	return sgrPattern.ReplaceAllString(s, "")
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+)
+
+func withColorEnv(t *testing.T, env map[string]string, mode ColorMode) {
+	t.Helper()
+	oldGetenv, oldMode := getenv, CurrentColorMode()
+	getenv = func(key string) string { return env[key] }
+	SetColorMode(mode)
+	t.Cleanup(func() {
+		getenv = oldGetenv
+		SetColorMode(oldMode)
+	})
+}
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		mode ColorMode
+		want bool
+	}{
+		{"auto on a pipe", nil, ColorAuto, false},
+		{"always on a pipe", nil, ColorAlways, true},
+		{"never beats CLICOLOR_FORCE", map[string]string{"CLICOLOR_FORCE": "1"}, ColorNever, false},
+		{"always beats NO_COLOR", map[string]string{"NO_COLOR": "1"}, ColorAlways, true},
+		{"CLICOLOR_FORCE on a pipe", map[string]string{"CLICOLOR_FORCE": "1"}, ColorAuto, true},
+		{"CLICOLOR_FORCE=0 is ignored", map[string]string{"CLICOLOR_FORCE": "0"}, ColorAuto, false},
+		{"NO_COLOR beats CLICOLOR_FORCE", map[string]string{"NO_COLOR": "x", "CLICOLOR_FORCE": "1"}, ColorAuto, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withColorEnv(t, tt.env, tt.mode)
+			if got := ColorEnabled(&bytes.Buffer{}); got != tt.want {
+				t.Errorf("ColorEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorsFor(t *testing.T) {
+	withColorEnv(t, nil, ColorAuto)
+	if c := ColorsFor(&bytes.Buffer{}); c.Red != "" || c.Reset != "" {
+		t.Errorf("expected plain palette for a pipe, got %+v", c)
+	}
+	SetColorMode(ColorAlways)
+	if c := ColorsFor(&bytes.Buffer{}); c.Reset != "\033[0m" {
+		t.Errorf("expected ANSI palette, got %+v", c)
+	}
+}
+
+func TestTerminalColors(t *testing.T) {
+	withColorEnv(t, nil, ColorAuto)
+	if c := TerminalColors(); c.Reset == "" {
+		t.Error("full-screen views should keep color by default")
+	}
+
+	withColorEnv(t, map[string]string{"NO_COLOR": "1"}, ColorAuto)
+	if c := TerminalColors(); c.Reset != "" {
+		t.Error("NO_COLOR should disable color in full-screen views")
+	}
+
+	withColorEnv(t, map[string]string{"TERM": "dumb"}, ColorAuto)
+	if c := TerminalColors(); c.Bold != "" {
+		t.Error("TERM=dumb should disable color in full-screen views")
+	}
+}
+
+func TestParseColorMode(t *testing.T) {
+	for in, want := range map[string]ColorMode{"auto": ColorAuto, "ALWAYS": ColorAlways, "never": ColorNever, "": ColorAuto} {
+		got, err := ParseColorMode(in)
+		if err != nil || got != want {
+			t.Errorf("ParseColorMode(%q) = %v, %v", in, got, err)
+		}
+		if in != "" && in != "ALWAYS" && got.String() != in {
+			t.Errorf("%v.String() = %q", got, got.String())
+		}
+	}
+	if _, err := ParseColorMode("sometimes"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	in := "\033[32mM\033[m  file.go\n\033[1;31m??\033[0m new.go\n"
+	if got := StripANSI(in); got != "M  file.go\n?? new.go\n" {
+		t.Errorf("StripANSI() = %q", got)
+	}
+}
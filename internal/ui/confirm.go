@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrNotInteractive is returned when an operation needs confirmation but
+// nobody can give it.
+var ErrNotInteractive = errors.New("this needs confirmation but stdin is not a terminal; pass --yes to go ahead")
+
+var assumeYes atomic.Bool
+
+// SetAssumeYes answers every confirmation with yes, for the global --yes
+// flag.
+func SetAssumeYes(yes bool) {
+	assumeYes.Store(yes)
+}
+
+// AssumeYes reports whether confirmations are answered with yes.
+func AssumeYes() bool {
+	return assumeYes.Load()
+}
+
+// ConfirmMode is how destructive commands confirm, from the
+// behavior.confirm-destructive setting.
+type ConfirmMode string
+
+// Confirmation modes.
+const (
+	// ConfirmSimple asks yes or no, and asks for the target's name where
+	// a mistake is costly.
+	ConfirmSimple ConfirmMode = "simple"
+	// ConfirmAlways asks for the target's name, or "yes", every time.
+	ConfirmAlways ConfirmMode = "always"
+	// ConfirmNever does not ask.
+	ConfirmNever ConfirmMode = "never"
+)
+
+// LineReader shows a prompt and reads one line. canceled is true when the
+// user pressed Ctrl+C. prompt.Prompter satisfies it.
+type LineReader interface {
+	Input(prompt string) (line string, canceled bool, err error)
+}
+
+// Confirmer asks before destructive operations. When stdin is not a
+// terminal it refuses with ErrNotInteractive instead of waiting for input,
+// unless --yes was given. A nil Confirmer allows everything.
+type Confirmer struct {
+	in          LineReader
+	interactive bool
+	mode        ConfirmMode
+}
+
+// NewConfirmer returns a Confirmer reading answers from in. interactive
+// tells whether a person can answer, usually whether stdin is a terminal.
+func NewConfirmer(in LineReader, interactive bool, mode ConfirmMode) *Confirmer {
+	if mode == "" {
+		mode = ConfirmSimple
+	}
+	return &Confirmer{in: in, interactive: interactive, mode: mode}
+}
+
+// Confirm asks a yes/no question; anything but y or yes declines. Under
+// confirm-destructive: always the user types "yes" instead.
+func (c *Confirmer) Confirm(question string) (bool, error) {
+	if c != nil && c.mode == ConfirmAlways {
+		return c.ConfirmTyped(question, "yes")
+	}
+	return c.ask(question+" [y/N]: ", func(answer string) bool {
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return true
+		}
+		return false
+	})
+}
+
+// ConfirmTyped asks the user to type expected, such as the name of the
+// branch about to be deleted, to go ahead.
+func (c *Confirmer) ConfirmTyped(question, expected string) (bool, error) {
+	return c.ask(fmt.Sprintf("%s\nType %q to confirm: ", question, expected), func(answer string) bool {
+		return answer == expected
+	})
+}
+
+// Asks reports whether Confirm puts the question to a person, rather than
+// answering it for --yes or confirm-destructive: never, or failing with
+// ErrNotInteractive.
+func (c *Confirmer) Asks() bool {
+	return c != nil && c.mode != ConfirmNever && !AssumeYes() && c.interactive && c.in != nil
+}
+
+func (c *Confirmer) ask(prompt string, accept func(answer string) bool) (bool, error) {
+	if c == nil || c.mode == ConfirmNever || AssumeYes() {
+		return true, nil
+	}
+	if !c.interactive || c.in == nil {
+		return false, ErrNotInteractive
+	}
+	line, canceled, err := c.in.Input(prompt)
+	if canceled || err != nil {
+		return false, err
+	}
+	return accept(strings.TrimSpace(line)), nil
+}
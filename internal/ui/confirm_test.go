@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeLineReader struct {
+	answers []string
+	prompts []string
+}
+
+func (f *fakeLineReader) Input(prompt string) (string, bool, error) {
+	f.prompts = append(f.prompts, prompt)
+	if len(f.answers) == 0 {
+		return "", true, nil
+	}
+	answer := f.answers[0]
+	f.answers = f.answers[1:]
+	return answer, false, nil
+}
+
+func TestConfirmer_Confirm(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"y", true},
+		{"YES", true},
+		{"", false},
+		{"n", false},
+		{"sure", false},
+	}
+	for _, tt := range tests {
+		c := NewConfirmer(&fakeLineReader{answers: []string{tt.answer}}, true, ConfirmSimple)
+		got, err := c.Confirm("Drop stash@{0}?")
+		if err != nil || got != tt.want {
+			t.Errorf("Confirm() with %q = %v, %v; want %v", tt.answer, got, err, tt.want)
+		}
+	}
+}
+
+func TestConfirmer_ConfirmTyped(t *testing.T) {
+	in := &fakeLineReader{answers: []string{"origin", "upstream "}}
+	c := NewConfirmer(in, true, ConfirmSimple)
+	if ok, _ := c.ConfirmTyped("Remove remote 'upstream'?", "upstream"); ok {
+		t.Error("a different name should decline")
+	}
+	if ok, _ := c.ConfirmTyped("Remove remote 'upstream'?", "upstream"); !ok {
+		t.Error("the typed name should confirm")
+	}
+}
+
+func TestConfirmer_Modes(t *testing.T) {
+	in := &fakeLineReader{answers: []string{"y"}}
+	if ok, _ := NewConfirmer(in, true, ConfirmAlways).Confirm("Clean?"); ok {
+		t.Error("confirm-destructive: always should want \"yes\" typed, not y")
+	}
+	if ok, err := NewConfirmer(nil, false, ConfirmNever).Confirm("Clean?"); !ok || err != nil {
+		t.Errorf("confirm-destructive: never = %v, %v", ok, err)
+	}
+
+	_, err := NewConfirmer(&fakeLineReader{}, false, ConfirmSimple).Confirm("Clean?")
+	if !errors.Is(err, ErrNotInteractive) {
+		t.Errorf("without a terminal err = %v, want ErrNotInteractive", err)
+	}
+
+	SetAssumeYes(true)
+	t.Cleanup(func() { SetAssumeYes(false) })
+	if ok, err := NewConfirmer(&fakeLineReader{}, false, ConfirmSimple).ConfirmTyped("Delete?", "main"); !ok || err != nil {
+		t.Errorf("--yes = %v, %v", ok, err)
+	}
+
+	var nilConfirmer *Confirmer
+	if ok, _ := nilConfirmer.Confirm("Clean?"); !ok {
+		t.Error("a nil Confirmer should allow everything")
+	}
+}
+
+func TestConfirmer_Asks(t *testing.T) {
+	if !NewConfirmer(&fakeLineReader{}, true, ConfirmSimple).Asks() {
+		t.Error("a terminal should be asked")
+	}
+	if NewConfirmer(&fakeLineReader{}, false, ConfirmSimple).Asks() {
+		t.Error("without a terminal nobody can be asked")
+	}
+	if NewConfirmer(&fakeLineReader{}, true, ConfirmNever).Asks() {
+		t.Error("confirm-destructive: never should not ask")
+	}
+	var nilConfirmer *Confirmer
+	if nilConfirmer.Asks() {
+		t.Error("a nil Confirmer should not ask")
+	}
+	SetAssumeYes(true)
+	t.Cleanup(func() { SetAssumeYes(false) })
+	if NewConfirmer(&fakeLineReader{}, true, ConfirmSimple).Asks() {
+		t.Error("--yes should not ask")
+	}
+}
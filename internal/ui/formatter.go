@@ -12,11 +12,12 @@ type Formatter struct {
 	colors *ANSIColors
 }
 
-// NewFormatter creates a new Formatter with the given writer.
+// NewFormatter creates a new Formatter with the given writer. Colors follow
+// TerminalColors, so --no-color and NO_COLOR turn them off.
 func NewFormatter(w io.Writer) *Formatter {
 	return &Formatter{
 		w:      w,
-		colors: NewANSIColors(),
+		colors: TerminalColors(),
 	}
 }
 
@@ -0,0 +1,19 @@
+package ui
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	var mu sync.Mutex
+	w := NewPrefixWriter(&out, "[fetch] ", &mu)
+	_, _ = w.Write([]byte("one\r\ntw"))
+	_, _ = w.Write([]byte("o\nthree"))
+	w.Flush()
+	if got, want := out.String(), "[fetch] one\n[fetch] two\n[fetch] three\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
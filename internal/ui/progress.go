@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// ProgressRenderer turns the progress git writes to stderr during fetch,
+// pull, push and clone ("Receiving objects:  45% (450/1000), 1.20 MiB |
+// 2.00 MiB/s") into a bar redrawn in place on one line. When the output
+// is not a terminal it prints one line per finished phase instead, so
+// logs stay short. Lines that are not progress, such as errors, remote
+// messages and ref updates, pass through unchanged.
+type ProgressRenderer struct {
+	out    io.Writer
+	tty    bool
+	width  int
+	colors *ANSIColors
+
+	partial []byte // text after the last \r or \n
+	active  bool   // a bar is drawn on the current line
+}
+
+// ProgressPhase is one parsed progress line.
+type ProgressPhase struct {
+	Remote  bool   // reported by the remote ("remote: Counting objects")
+	Name    string // "Receiving objects"
+	Percent int    // -1 for phases that only count
+	Current int
+	Total   int
+	Detail  string // size and throughput, e.g. "1.20 MiB | 2.00 MiB/s"
+	Done    bool
+}
+
+var (
+	progressPercentRe = regexp.MustCompile(`^([A-Za-z][A-Za-z ]*):\s+(\d+)% \((\d+)/(\d+)\)(?:, (.*?))?\s*$`)
+	progressCountRe   = regexp.MustCompile(`^([A-Za-z][A-Za-z ]*): (\d+)(?:, (.*?))?\s*$`)
+)
+
+// progressBarWidth is the bar's width in cells, excluding brackets.
+const progressBarWidth = 20
+
+// NewProgressRenderer returns a renderer writing to w. Bars are drawn
+// only when w is a terminal.
+func NewProgressRenderer(w io.Writer) *ProgressRenderer {
+	p := &ProgressRenderer{out: w, colors: ColorsFor(w)}
+	if f, ok := w.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		p.tty = true
+		p.width, _ = Dimensions(w, 80, 24)
+	}
+	return p
+}
+
+// ParseProgress parses one line of git progress. It returns false for
+// lines that are not progress.
+func ParseProgress(line string) (ProgressPhase, bool) {
+	var ph ProgressPhase
+	line = strings.TrimRight(line, " \t")
+	if rest, ok := strings.CutPrefix(line, "remote: "); ok {
+		ph.Remote, line = true, rest
+	}
+	if rest, ok := strings.CutSuffix(line, ", done."); ok {
+		ph.Done, line = true, rest
+	}
+
+	if m := progressPercentRe.FindStringSubmatch(line); m != nil {
+		ph.Name, ph.Detail = m[1], m[5]
+		ph.Percent, _ = strconv.Atoi(m[2])
+		ph.Current, _ = strconv.Atoi(m[3])
+		ph.Total, _ = strconv.Atoi(m[4])
+		return ph, true
+	}
+	if m := progressCountRe.FindStringSubmatch(line); m != nil && isProgressPhase(m[1]) {
+		ph.Name, ph.Detail, ph.Percent = m[1], m[3], -1
+		ph.Current, _ = strconv.Atoi(m[2])
+		return ph, true
+	}
+	return ProgressPhase{}, false
+}
+
+// isProgressPhase keeps count-only lines such as "Enumerating objects: 12"
+// apart from ordinary "key: value" messages.
+func isProgressPhase(name string) bool {
+	return strings.HasSuffix(name, " objects") || strings.HasSuffix(name, " deltas")
+}
+
+// Write implements io.Writer. git ends progress updates with \r and
+// other lines with \n.
+func (p *ProgressRenderer) Write(b []byte) (int, error) {
+	for _, c := range b {
+		switch c {
+		case '\r', '\n':
+			p.line(string(p.partial), c == '\n')
+			p.partial = p.partial[:0]
+		default:
+			p.partial = append(p.partial, c)
+		}
+	}
+	return len(b), nil
+}
+
+// Close flushes a trailing partial line and ends the bar's line.
+func (p *ProgressRenderer) Close() error {
+	if len(p.partial) > 0 {
+		p.line(string(p.partial), true)
+		p.partial = p.partial[:0]
+	}
+	p.endBar()
+	return nil
+}
+
+func (p *ProgressRenderer) line(text string, final bool) {
+	if text == "" {
+		return
+	}
+	ph, ok := ParseProgress(text)
+	if !ok {
+		p.endBar()
+		_, _ = fmt.Fprintln(p.out, text)
+		return
+	}
+	done := ph.Done || (final && ph.Percent == 100)
+	switch {
+	case p.tty:
+		_, _ = fmt.Fprint(p.out, "\r\x1b[K"+p.formatBar(ph))
+		p.active = true
+		if done {
+			p.endBar()
+		}
+	case done:
+		_, _ = fmt.Fprintln(p.out, p.formatCompact(ph))
+	}
+}
+
+// endBar moves past a bar so the next output starts on a fresh line.
+func (p *ProgressRenderer) endBar() {
+	if p.active {
+		_, _ = fmt.Fprintln(p.out)
+		p.active = false
+	}
+}
+
+func (p *ProgressRenderer) formatBar(ph ProgressPhase) string {
+	var b strings.Builder
+	b.WriteString(ph.label())
+	if ph.Percent >= 0 {
+		filled := ph.Percent * progressBarWidth / 100
+		fmt.Fprintf(&b, " [%s%s%s%s] %3d%% %d/%d",
+			p.colors.Cyan, strings.Repeat("█", filled), p.colors.Reset,
+			strings.Repeat("░", progressBarWidth-filled),
+			ph.Percent, ph.Current, ph.Total)
+	} else {
+		fmt.Fprintf(&b, " %d", ph.Current)
+	}
+	if ph.Detail != "" {
+		b.WriteString("  " + ph.Detail)
+	}
+	if ph.Done {
+		b.WriteString(" " + p.colors.Green + "done" + p.colors.Reset)
+	}
+	if p.width > 0 {
+		return fitWidth(b.String(), p.width-1)
+	}
+	return b.String()
+}
+
+func (p *ProgressRenderer) formatCompact(ph ProgressPhase) string {
+	s := ph.label()
+	if ph.Percent >= 0 {
+		s += fmt.Sprintf(" %d%% (%d/%d)", ph.Percent, ph.Current, ph.Total)
+	} else {
+		s += fmt.Sprintf(" %d", ph.Current)
+	}
+	if ph.Detail != "" {
+		s += ", " + ph.Detail
+	}
+	return s
+}
+
+func (ph ProgressPhase) label() string {
+	if ph.Remote {
+		return "remote: " + ph.Name + ":"
+	}
+	return ph.Name + ":"
+}
+
+// fitWidth drops the tail of s beyond width visible cells, keeping
+// color sequences intact so the line's colors still reset.
+func fitWidth(s string, width int) string {
+	if len([]rune(StripANSI(s))) <= width {
+		return s
+	}
+	var b strings.Builder
+	visible := 0
+	for i := 0; i < len(s); {
+		if loc := sgrPattern.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			b.WriteString(s[i : i+loc[1]])
+			i += loc[1]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if visible < width {
+			b.WriteRune(r)
+			visible++
+		}
+		i += size
+	}
+	return b.String()
+}
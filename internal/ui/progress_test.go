@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const fetchStderr = "remote: Enumerating objects: 12, done.\n" +
+	"remote: Counting objects:  50% (5/10)\rremote: Counting objects: 100% (10/10), done.        \n" +
+	"Receiving objects:  45% (450/1000), 1.20 MiB | 2.00 MiB/s\r" +
+	"Receiving objects: 100% (1000/1000), 2.40 MiB | 2.00 MiB/s, done.\n" +
+	"Resolving deltas: 100% (5/5), done.\n" +
+	"From github.com:o/r\n" +
+	" * branch            main       -> FETCH_HEAD\n"
+
+func TestParseProgress(t *testing.T) {
+	tests := []struct {
+		line string
+		want ProgressPhase
+		ok   bool
+	}{
+		{
+			line: "Receiving objects:  45% (450/1000), 1.20 MiB | 2.00 MiB/s",
+			want: ProgressPhase{Name: "Receiving objects", Percent: 45, Current: 450, Total: 1000, Detail: "1.20 MiB | 2.00 MiB/s"},
+			ok:   true,
+		},
+		{
+			line: "remote: Compressing objects: 100% (3/3), done.   ",
+			want: ProgressPhase{Remote: true, Name: "Compressing objects", Percent: 100, Current: 3, Total: 3, Done: true},
+			ok:   true,
+		},
+		{
+			line: "remote: Enumerating objects: 12, done.",
+			want: ProgressPhase{Remote: true, Name: "Enumerating objects", Percent: -1, Current: 12, Done: true},
+			ok:   true,
+		},
+		{line: "remote: Total 3 (delta 0), reused 0 (delta 0)"},
+		{line: "error: failed to push some refs"},
+		{line: "hint: Updates were rejected: 2"},
+	}
+	for _, tt := range tests {
+		got, ok := ParseProgress(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseProgress(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestProgressRenderer_Compact(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewProgressRenderer(&buf)
+	_, _ = p.Write([]byte(fetchStderr))
+	_ = p.Close()
+
+	want := "remote: Enumerating objects: 12\n" +
+		"remote: Counting objects: 100% (10/10)\n" +
+		"Receiving objects: 100% (1000/1000), 2.40 MiB | 2.00 MiB/s\n" +
+		"Resolving deltas: 100% (5/5)\n" +
+		"From github.com:o/r\n" +
+		" * branch            main       -> FETCH_HEAD\n"
+	if got := buf.String(); got != want {
+		t.Errorf("compact output =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestProgressRenderer_Bar(t *testing.T) {
+	var buf bytes.Buffer
+	p := &ProgressRenderer{out: &buf, tty: true, colors: NoColors()}
+	// Split writes the way a pipe delivers them, mid-line.
+	for _, chunk := range []string{fetchStderr[:70], fetchStderr[70:200], fetchStderr[200:]} {
+		_, _ = p.Write([]byte(chunk))
+	}
+	_ = p.Close()
+
+	got := buf.String()
+	if !strings.Contains(got, "\r\x1b[KReceiving objects: [█████████░░░░░░░░░░░]  45% 450/1000  1.20 MiB | 2.00 MiB/s") {
+		t.Errorf("missing in-progress bar in %q", got)
+	}
+	if !strings.Contains(got, "\r\x1b[KReceiving objects: [████████████████████] 100% 1000/1000  2.40 MiB | 2.00 MiB/s done\n") {
+		t.Errorf("missing finished bar in %q", got)
+	}
+	if !strings.HasSuffix(got, "done\nFrom github.com:o/r\n * branch            main       -> FETCH_HEAD\n") {
+		t.Errorf("other lines should follow the last bar on fresh lines: %q", got)
+	}
+}
+
+func TestProgressRenderer_CloseEndsBar(t *testing.T) {
+	var buf bytes.Buffer
+	p := &ProgressRenderer{out: &buf, tty: true, colors: NoColors()}
+	_, _ = p.Write([]byte("Writing objects:  50% (1/2)\r"))
+	_ = p.Close()
+	if got := buf.String(); !strings.HasSuffix(got, "\n") {
+		t.Errorf("Close() should end the bar's line, got %q", got)
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	s := "\x1b[36mabcdef\x1b[0mgh"
+	if got := fitWidth(s, 4); got != "\x1b[36mabcd\x1b[0m" {
+		t.Errorf("fitWidth() = %q", got)
+	}
+	if got := fitWidth("abc", 10); got != "abc" {
+		t.Errorf("fitWidth() = %q", got)
+	}
+}
@@ -14,6 +14,9 @@ const (
 	escHideCursor  = "\x1b[?25l"
 	escShowCursor  = "\x1b[?25h"
 	escClearScreen = "\x1b[2J\x1b[H"
+
+	escEnableBracketedPaste  = "\x1b[?2004h"
+	escDisableBracketedPaste = "\x1b[?2004l"
 )
 
 // ClearScreen clears the terminal and positions the cursor at the top-left corner.
@@ -41,6 +44,17 @@ func EnableWrap(w io.Writer) {
 	_, _ = fmt.Fprint(w, escEnableWrap)
 }
 
+// EnableBracketedPaste asks the terminal to wrap pasted text in
+// ESC[200~ ... ESC[201~ so it can be told apart from typed keys.
+func EnableBracketedPaste(w io.Writer) {
+	_, _ = fmt.Fprint(w, escEnableBracketedPaste)
+}
+
+// DisableBracketedPaste turns bracketed paste mode off again.
+func DisableBracketedPaste(w io.Writer) {
+	_, _ = fmt.Fprint(w, escDisableBracketedPaste)
+}
+
 // Dimensions attempts to determine the terminal size for the provided writer. If the
 // writer is not backed by an *os.File or the lookup fails, it returns a safe default.
 func Dimensions(w io.Writer, fallbackWidth, fallbackHeight int) (width, height int) {
@@ -100,3 +100,30 @@ func TestEllipsis(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KiB"},
+		{20 * 1024 * 1024, "20.0 MiB"},
+		{3 << 40, "3.0 TiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestBracketedPaste(t *testing.T) {
+	var buf bytes.Buffer
+	EnableBracketedPaste(&buf)
+	DisableBracketedPaste(&buf)
+	if buf.String() != escEnableBracketedPaste+escDisableBracketedPaste {
+		t.Errorf("bracketed paste toggles = %q", buf.String())
+	}
+}
@@ -1,5 +1,7 @@
 package ui
 
+import "fmt"
+
 // Ellipsis truncates the provided string to maxLen characters, appending an ellipsis when
 // truncation occurs. For zero or negative lengths it returns an empty string. The function
 // is intentionally ASCII-focused to match existing interactive behavior.
@@ -15,3 +17,18 @@ func Ellipsis(s string, maxLen int) string {
 	}
 	return s[:maxLen-1] + "…"
 }
+
+// FormatBytes renders a size in bytes with a binary unit, such as 512 B,
+// 1.5 KiB or 20.0 MiB.
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 5; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,206 @@
+// Package update tells users when a newer ggc release exists. The release
+// API is asked at most once a day and its answer is cached under the
+// config directory, so printing the notice costs a small file read and
+// never waits on the network.
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvDisable turns the check off whatever the config says.
+	EnvDisable = "GGC_NO_UPDATE_CHECK"
+	// Interval is how long a check, and a notice, last.
+	Interval = 24 * time.Hour
+	// LatestReleaseURL is the GitHub API endpoint for the newest release.
+	LatestReleaseURL = "https://api.github.com/repos/bmf-san/ggc/releases/latest"
+	// ReleasePage is where the notice sends users.
+	ReleasePage = "https://github.com/bmf-san/ggc/releases/latest"
+)
+
+// Cache is what the last check found.
+type Cache struct {
+	// Checked is when a check last started, in UTC. It is set before the
+	// request is made so that concurrent ggc processes start only one.
+	Checked time.Time `json:"checked"`
+	// Latest is the tag of the newest release, such as "v8.3.0".
+	Latest string `json:"latest,omitempty"`
+	// Notified is when the notice for Latest was last printed, in UTC.
+	Notified time.Time `json:"notified,omitzero"`
+}
+
+// DefaultPath returns the cache location next to the user config file.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locate home dir: %w", err)
+	}
+	return filepath.Join(home, ".config", "ggc", "update-check.json"), nil
+}
+
+// Disabled reports whether EnvDisable is set to a true value.
+func Disabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(EnvDisable))) {
+	case "", "0", "false", "no", "off":
+		return false
+	}
+	return true
+}
+
+// Load reads the cache at path. A missing file yields an empty cache.
+func Load(path string) (Cache, error) {
+	var c Cache
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c, nil
+		}
+		return c, err
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Cache{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save atomically replaces the cache at path with c.
+func (c Cache) Save(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".update-check-*.json")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Due reports whether the last check is older than Interval.
+func (c Cache) Due(now time.Time) bool {
+	return now.Sub(c.Checked) >= Interval
+}
+
+// Notice returns the line telling users of current that Latest is out, or
+// "" when there is nothing new or the notice was printed within Interval.
+// A non-empty result marks the notice as printed; the caller saves c.
+func (c *Cache) Notice(current string, now time.Time) string {
+	if !Newer(c.Latest, current) || now.Sub(c.Notified) < Interval {
+		return ""
+	}
+	c.Notified = now.UTC()
+	return fmt.Sprintf("ggc %s is available (you have %s): %s", c.Latest, current, ReleasePage)
+}
+
+// Newer reports whether release latest is a higher MAJOR.MINOR.PATCH than
+// current. Either one failing to parse counts as not newer, so development
+// builds never see the notice.
+func Newer(latest, current string) bool {
+	l, ok := parse(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parse(current)
+	if !ok {
+		return false
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return false
+}
+
+// parse reads "v1.2.3" or "1.2.3", ignoring any pre-release or build
+// suffix.
+func parse(v string) ([3]int, bool) {
+	var out [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return out, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
+
+// LatestRelease asks the GitHub releases API at url for the tag of the
+// newest release.
+func LatestRelease(ctx context.Context, client *http.Client, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "ggc")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("latest release: %s", resp.Status)
+	}
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("latest release: %w", err)
+	}
+	if release.TagName == "" {
+		return "", fmt.Errorf("latest release: no tag name in the response")
+	}
+	return release.TagName, nil
+}
+
+// Refresh asks url for the newest release and stores it in the cache at
+// path, keeping what the last check found when the request fails.
+func Refresh(ctx context.Context, client *http.Client, url, path string, now time.Time) error {
+	latest, err := LatestRelease(ctx, client, url)
+	if err != nil {
+		return err
+	}
+	c, err := Load(path)
+	if err != nil {
+		c = Cache{}
+	}
+	if c.Latest != latest {
+		c.Notified = time.Time{}
+	}
+	c.Checked, c.Latest = now.UTC(), latest
+	return c.Save(path)
+}
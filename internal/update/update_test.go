@@ -0,0 +1,116 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v8.3.0", "v8.2.9", true},
+		{"v9.0.0", "8.9.9", true},
+		{"v8.2.0", "v8.2.0", false},
+		{"v8.2.0", "v8.3.0-rc.1", false},
+		{"v8.2.1", "v8.2.1-0.20260101000000-abcdef123456", false},
+		{"v8.3.0", "", false},
+		{"nightly", "v8.2.0", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestCache_Notice(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	c := Cache{Checked: now, Latest: "v8.3.0"}
+
+	got := c.Notice("v8.2.0", now)
+	if !strings.HasPrefix(got, "ggc v8.3.0 is available (you have v8.2.0)") {
+		t.Errorf("Notice = %q", got)
+	}
+	if again := c.Notice("v8.2.0", now.Add(time.Hour)); again != "" {
+		t.Errorf("second notice within a day = %q", again)
+	}
+	if later := c.Notice("v8.2.0", now.Add(Interval)); later == "" {
+		t.Error("notice should come back after a day")
+	}
+	if current := c.Notice("v8.3.0", now.Add(3*Interval)); current != "" {
+		t.Errorf("notice for the current release = %q", current)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "ggc" {
+			t.Errorf("User-Agent = %q", r.Header.Get("User-Agent"))
+		}
+		_, _ = w.Write([]byte(`{"tag_name": "v8.4.0", "name": "v8.4.0"}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "ggc", "update-check.json")
+	old := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	if err := (Cache{Checked: old, Latest: "v8.3.0", Notified: old}).Save(path); err != nil {
+		t.Fatal(err)
+	}
+	now := old.Add(2 * Interval)
+	if err := Refresh(context.Background(), srv.Client(), srv.URL, path, now); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Latest != "v8.4.0" || !c.Checked.Equal(now) || !c.Notified.IsZero() {
+		t.Errorf("cache = %+v", c)
+	}
+	if c.Due(now.Add(time.Hour)) || !c.Due(now.Add(Interval)) {
+		t.Error("Due should turn true a day after the check")
+	}
+}
+
+func TestRefresh_KeepsCacheOnFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "rate limited", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "update-check.json")
+	if err := (Cache{Latest: "v8.3.0"}).Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := Refresh(context.Background(), srv.Client(), srv.URL, path, time.Now()); err == nil {
+		t.Error("Refresh should report the failed request")
+	}
+	if c, _ := Load(path); c.Latest != "v8.3.0" {
+		t.Errorf("cache = %+v", c)
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	c, err := Load(filepath.Join(t.TempDir(), "none.json"))
+	if err != nil || !c.Checked.IsZero() || c.Latest != "" {
+		t.Errorf("Load = %+v, %v", c, err)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	t.Setenv(EnvDisable, "1")
+	if !Disabled() {
+		t.Error("Disabled should be true for 1")
+	}
+	t.Setenv(EnvDisable, "false")
+	if Disabled() {
+		t.Error("Disabled should be false for false")
+	}
+}
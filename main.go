@@ -7,13 +7,24 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"os/signal"
 	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
 
 	"github.com/bmf-san/ggc/v8/cmd"
 	"github.com/bmf-san/ggc/v8/internal/config"
 	"github.com/bmf-san/ggc/v8/internal/git"
 	"github.com/bmf-san/ggc/v8/internal/history"
+	"github.com/bmf-san/ggc/v8/internal/httpclient"
+	"github.com/bmf-san/ggc/v8/internal/logging"
+	"github.com/bmf-san/ggc/v8/internal/stats"
+	"github.com/bmf-san/ggc/v8/internal/ui"
+	"github.com/bmf-san/ggc/v8/internal/update"
 )
 
 var (
@@ -55,11 +66,34 @@ func GetVersionInfo() (string, string) {
 // RunApp contains the main application logic, separated for testability.
 // This function initializes all components and routes the provided arguments.
 func RunApp(args []string) error {
+	args, scope, err := splitPathFlags(args)
+	if err != nil {
+		return err
+	}
+	args, yes := splitYesFlag(args)
+	ui.SetAssumeYes(yes)
+	args, mode, modeSet, err := splitColorFlags(args)
+	if err != nil {
+		return err
+	}
+	args, batch := splitBatchFlag(args)
+	args, level := logging.SplitFlags(args)
+	if len(args) == 1 && args[0] == updateCheckCommand {
+		return runUpdateCheck()
+	}
+	logger, closeLog, err := logging.New(level, os.Stderr, os.Getenv)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
 	// Bind a signal-aware context so Ctrl+C cancels any running git subprocess.
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer stop()
 
-	client := git.NewClient().WithContext(ctx)
+	client := git.NewClient().WithContext(ctx).WithStatusCache(git.NewStatusCache()).
+		WithProgress(func() git.ProgressSink { return ui.NewProgressRenderer(os.Stderr) }).
+		WithLogger(logger)
 	cm := config.NewConfigManager(client)
 	if err := cm.LoadConfig(); err != nil {
 		if config.IsWarning(err) {
@@ -72,17 +106,40 @@ func RunApp(args []string) error {
 	}
 	cmd.SetVersionGetter(GetVersionInfo)
 	applyHistoryConfig(cm.GetConfig())
+	applyStatsConfig(cm.GetConfig())
+	applyIntegrationConfig(cm.GetConfig())
+	applyColorMode(cm.GetConfig(), mode, modeSet)
+	client = client.WithFastRefs(cm.GetConfig().Core.FastRefs)
 	c, err := cmd.NewCmd(client, cm)
 	if err != nil {
 		return err
 	}
-	return c.Execute(args)
+	if ok, err := applyPathScope(c, scope); err != nil || !ok {
+		return err
+	}
+	notice := startUpdateCheck(cm.GetConfig(), args)
+	run := c.Execute
+	if batch {
+		run = c.RunBatch
+	}
+	if err := run(args); err != nil {
+		if ctx.Err() != nil {
+			// Ctrl+C killed the git subprocess; say so in the exit code.
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		}
+		return err
+	}
+	notice(os.Stderr)
+	return nil
 }
 
 func main() {
 	if err := RunApp(os.Args[1:]); err != nil {
-		writeCLIError(os.Stderr, err, os.Getenv("GGC_VERBOSE") == "1")
-		os.Exit(1)
+		var reported *cmd.ReportedError
+		if !errors.As(err, &reported) {
+			writeCLIError(os.Stderr, err, os.Getenv("GGC_VERBOSE") == "1")
+		}
+		os.Exit(cmd.ExitCode(err))
 	}
 }
 
@@ -104,6 +161,261 @@ func applyHistoryConfig(cfg *config.Config) {
 	history.SetDefault(store)
 }
 
+// applyStatsConfig turns on usage statistics for ggc stats unless
+// stats.enabled is false or GGC_NO_STATS is set.
+func applyStatsConfig(cfg *config.Config) {
+	store := stats.Default()
+	if cfg != nil && cfg.Stats.Enabled != nil && !*cfg.Stats.Enabled {
+		store.Disabled = true
+	}
+	stats.SetDefault(store)
+}
+
+// applyIntegrationConfig hands the proxy and certificate settings of
+// integration to the HTTP clients of the hosting APIs and the update
+// check.
+func applyIntegrationConfig(cfg *config.Config) {
+	if cfg == nil {
+		return
+	}
+	httpclient.SetDefaults(httpclient.Options{
+		Proxy:              cfg.Integration.HTTPProxy,
+		CABundle:           cfg.Integration.CABundle,
+		InsecureSkipVerify: cfg.Integration.InsecureSkipVerify,
+	})
+}
+
+// updateCheckCommand is the hidden command that asks for the latest
+// release. It runs detached from the command the user typed, so a slow or
+// missing network never holds that command up.
+const updateCheckCommand = "__update-check"
+
+// runUpdateCheck refreshes the update cache with the latest release. It
+// reads the config only for the network settings of integration.
+func runUpdateCheck() error {
+	path, err := update.DefaultPath()
+	if err != nil {
+		return err
+	}
+	cm := config.NewConfigManager(git.NewClient())
+	if cm.Load() == nil {
+		applyIntegrationConfig(cm.GetConfig())
+	}
+	// The context bounds the whole check.
+	client, err := httpclient.Default(0)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return update.Refresh(ctx, client, update.LatestReleaseURL, path, time.Now())
+}
+
+// startUpdateCheck, when behavior.update-check is on, starts a detached
+// update check once the cached one is a day old, and returns a function
+// that prints the notice for a newer release an earlier check found. This
+// process only reads and writes the small cache file.
+func startUpdateCheck(cfg *config.Config, args []string) func(w *os.File) {
+	noop := func(*os.File) {}
+	current, _ := GetVersionInfo()
+	if cfg == nil || !cfg.Behavior.UpdateCheck || update.Disabled() || current == "" {
+		return noop
+	}
+	// Completion and other internal commands run from scripts.
+	if len(args) > 0 && strings.HasPrefix(args[0], "__") {
+		return noop
+	}
+	path, err := update.DefaultPath()
+	if err != nil {
+		return noop
+	}
+	cache, err := update.Load(path)
+	if err != nil {
+		cache = update.Cache{}
+	}
+	if now := time.Now(); cache.Due(now) {
+		// Record the attempt first so that concurrent runs start one check.
+		cache.Checked = now.UTC()
+		if cache.Save(path) == nil {
+			spawnUpdateCheck()
+		}
+	}
+	return func(w *os.File) {
+		if !term.IsTerminal(int(w.Fd())) {
+			return
+		}
+		// Re-read: the detached check may have finished meanwhile.
+		cache, err := update.Load(path)
+		if err != nil {
+			return
+		}
+		if msg := cache.Notice(current, time.Now()); msg != "" {
+			_ = cache.Save(path)
+			colors := ui.ColorsFor(w)
+			_, _ = fmt.Fprintf(w, "%s%s%s\n", colors.Yellow, msg, colors.Reset)
+		}
+	}
+}
+
+// spawnUpdateCheck starts `ggc __update-check` without waiting for it.
+var spawnUpdateCheck = func() {
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	c := exec.Command(exe, updateCheckCommand)
+	if c.Start() == nil {
+		_ = c.Process.Release()
+	}
+}
+
+// pathFlags are the global --path <dir> and --pick-path flags.
+type pathFlags struct {
+	dir  string
+	pick bool
+}
+
+// splitPathFlags removes the global --path <dir> (or --path=<dir>) and
+// --pick-path flags that precede the command name. The other global flags
+// are left in place for the splitters that run after it.
+func splitPathFlags(args []string) (rest []string, flags pathFlags, err error) {
+	rest = make([]string, 0, len(args))
+scan:
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--path":
+			if i+1 == len(args) {
+				return nil, flags, errors.New("--path needs a directory")
+			}
+			i++
+			flags.dir = args[i]
+		case strings.HasPrefix(arg, "--path="):
+			if flags.dir = strings.TrimPrefix(arg, "--path="); flags.dir == "" {
+				return nil, flags, errors.New("--path needs a directory")
+			}
+		case arg == "--pick-path":
+			flags.pick = true
+		case arg == "--yes", arg == "-y", arg == "--no-color", strings.HasPrefix(arg, "--color="), arg == batchFlag, logging.IsFlag(arg):
+			rest = append(rest, arg)
+		default:
+			rest = append(rest, args[i:]...)
+			break scan
+		}
+	}
+	if flags.pick && flags.dir != "" {
+		return nil, flags, errors.New("use either --path or --pick-path, not both")
+	}
+	return rest, flags, nil
+}
+
+// applyPathScope limits c to the directory of the path flags. ok is false
+// when the user canceled the directory picker, and the command should not
+// run.
+func applyPathScope(c *cmd.Cmd, flags pathFlags) (ok bool, err error) {
+	switch {
+	case flags.pick:
+		if ok, err = c.PickPathScope(); err == nil && !ok {
+			_, _ = fmt.Fprintln(os.Stdout, "Canceled.")
+		}
+		return ok, err
+	case flags.dir != "":
+		if err := c.SetPathScope(flags.dir); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// splitYesFlag removes the global --yes (-y) flag from the flags that
+// precede the command name. It answers every confirmation prompt with yes.
+func splitYesFlag(args []string) (rest []string, yes bool) {
+	rest = make([]string, 0, len(args))
+	for i, arg := range args {
+		switch {
+		case arg == "--yes", arg == "-y":
+			yes = true
+		case arg == "--no-color", strings.HasPrefix(arg, "--color="), arg == batchFlag, logging.IsFlag(arg):
+			rest = append(rest, arg)
+		default:
+			return append(rest, args[i:]...), yes
+		}
+	}
+	return rest, yes
+}
+
+// splitColorFlags removes the global --no-color and --color=<mode> flags
+// that precede the command name, returning the remaining arguments and the
+// mode they select. set is false when neither flag was given. The --batch
+// and logging flags are left in place for splitBatchFlag and
+// logging.SplitFlags.
+func splitColorFlags(args []string) (rest []string, mode ui.ColorMode, set bool, err error) {
+	rest = make([]string, 0, len(args))
+	for i, arg := range args {
+		switch {
+		case arg == "--no-color":
+			mode, set = ui.ColorNever, true
+		case strings.HasPrefix(arg, "--color="):
+			if mode, err = ui.ParseColorMode(strings.TrimPrefix(arg, "--color=")); err != nil {
+				return nil, ui.ColorAuto, false, err
+			}
+			set = true
+		case arg == batchFlag, logging.IsFlag(arg):
+			rest = append(rest, arg)
+		default:
+			return append(rest, args[i:]...), mode, set, nil
+		}
+	}
+	return rest, mode, set, nil
+}
+
+// batchFlag makes ggc run the commands piped to stdin even when stdin is
+// a terminal.
+const batchFlag = "--batch"
+
+// splitBatchFlag removes the global --batch flag from the flags that
+// precede the command name. The logging flags are left in place for
+// logging.SplitFlags.
+func splitBatchFlag(args []string) (rest []string, batch bool) {
+	rest = make([]string, 0, len(args))
+	for i, arg := range args {
+		switch {
+		case arg == batchFlag:
+			batch = true
+		case logging.IsFlag(arg):
+			rest = append(rest, arg)
+		default:
+			return append(rest, args[i:]...), batch
+		}
+	}
+	return rest, batch
+}
+
+// applyColorMode sets the process-wide color mode. A flag wins over
+// ui.color: false in the config. When color ends up off, git subprocesses
+// are told so too, since several commands ask git for colored output.
+func applyColorMode(cfg *config.Config, mode ui.ColorMode, set bool) {
+	if !set && cfg != nil && !cfg.UI.Color {
+		mode = ui.ColorNever
+	}
+	ui.SetColorMode(mode)
+	if !ui.ColorEnabled(os.Stdout) {
+		disableGitColor(os.Getenv, os.Setenv)
+	}
+}
+
+// disableGitColor adds color.ui=never to the GIT_CONFIG_COUNT environment
+// settings inherited by git subprocesses, keeping any that are already set.
+func disableGitColor(getenv func(string) string, setenv func(string, string) error) {
+	n, err := strconv.Atoi(getenv("GIT_CONFIG_COUNT"))
+	if err != nil || n < 0 {
+		n = 0
+	}
+	_ = setenv(fmt.Sprintf("GIT_CONFIG_KEY_%d", n), "color.ui")
+	_ = setenv(fmt.Sprintf("GIT_CONFIG_VALUE_%d", n), "never")
+	_ = setenv("GIT_CONFIG_COUNT", strconv.Itoa(n+1))
+}
+
 // writeCLIError renders a terminal-facing error consistently across the CLI.
 //
 // For *git.OpError we print a one-line "<op> failed" summary, followed by
@@ -112,7 +424,8 @@ func applyHistoryConfig(cfg *config.Config) {
 // that was attempted) is only shown when GGC_VERBOSE=1 because it can be
 // long and is usually noise in normal use. Non-git errors keep their
 // historical single-line format so we don't churn existing tests or user
-// expectations.
+// expectations. Either way, an error ggc recognizes ends with the next step
+// to take.
 func writeCLIError(w io.Writer, err error, verbose bool) {
 	var opErr *git.OpError
 	if errors.As(err, &opErr) {
@@ -124,7 +437,10 @@ func writeCLIError(w io.Writer, err error, verbose bool) {
 		if verbose && opErr.Command != "" {
 			_, _ = fmt.Fprintf(w, "  detail: %s\n", opErr.Command)
 		}
-		return
+	} else {
+		_, _ = fmt.Fprintf(w, "Error: %s\n", err.Error())
+	}
+	if s := cmd.Suggestion(err); s != "" {
+		_, _ = fmt.Fprintf(w, "  Next: %s\n", s)
 	}
-	_, _ = fmt.Fprintf(w, "Error: %s\n", err.Error())
 }
@@ -75,3 +75,12 @@ func TestWriteCLIError_WrappedOpError(t *testing.T) {
 		t.Errorf("errors.As through join should still find OpError: %q", got)
 	}
 }
+
+func TestWriteCLIError_SuggestsNextStep(t *testing.T) {
+	var buf bytes.Buffer
+	err := git.NewOpError("push", "git push", errors.New("fatal: No configured push destination."))
+	writeCLIError(&buf, err, false)
+	if !strings.Contains(buf.String(), "  Next: run `ggc remote add <name> <url>`") {
+		t.Errorf("missing next step: %q", buf.String())
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/ui"
+)
+
+func TestSplitColorFlags(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantRest []string
+		wantMode ui.ColorMode
+		wantSet  bool
+	}{
+		{[]string{"status"}, []string{"status"}, ui.ColorAuto, false},
+		{[]string{"--no-color", "status", "short"}, []string{"status", "short"}, ui.ColorNever, true},
+		{[]string{"--color=always", "log"}, []string{"log"}, ui.ColorAlways, true},
+		{[]string{"--no-color", "--color=auto"}, []string{}, ui.ColorAuto, true},
+		{[]string{"diff", "--no-color"}, []string{"diff", "--no-color"}, ui.ColorAuto, false},
+		{[]string{"--verbose", "--no-color", "status"}, []string{"--verbose", "status"}, ui.ColorNever, true},
+		{[]string{"--batch", "--no-color"}, []string{"--batch"}, ui.ColorNever, true},
+	}
+	for _, tt := range tests {
+		rest, mode, set, err := splitColorFlags(tt.args)
+		if err != nil {
+			t.Fatalf("splitColorFlags(%v): %v", tt.args, err)
+		}
+		if !reflect.DeepEqual(rest, tt.wantRest) || mode != tt.wantMode || set != tt.wantSet {
+			t.Errorf("splitColorFlags(%v) = %v, %v, %v", tt.args, rest, mode, set)
+		}
+	}
+
+	if _, _, _, err := splitColorFlags([]string{"--color=rainbow", "status"}); err == nil {
+		t.Error("expected error for an unknown color mode")
+	}
+}
+
+func TestSplitYesFlag(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantRest []string
+		wantYes  bool
+	}{
+		{[]string{"clean", "files"}, []string{"clean", "files"}, false},
+		{[]string{"--yes", "clean", "files"}, []string{"clean", "files"}, true},
+		{[]string{"--no-color", "-y", "stash", "clear"}, []string{"--no-color", "stash", "clear"}, true},
+		{[]string{"branch", "delete", "--yes"}, []string{"branch", "delete", "--yes"}, false},
+		{[]string{"--debug", "--yes", "push"}, []string{"--debug", "push"}, true},
+		{[]string{"--batch", "--yes"}, []string{"--batch"}, true},
+	}
+	for _, tt := range tests {
+		rest, yes := splitYesFlag(tt.args)
+		if !reflect.DeepEqual(rest, tt.wantRest) || yes != tt.wantYes {
+			t.Errorf("splitYesFlag(%v) = %v, %v", tt.args, rest, yes)
+		}
+	}
+}
+
+func TestSplitBatchFlag(t *testing.T) {
+	tests := []struct {
+		args      []string
+		wantRest  []string
+		wantBatch bool
+	}{
+		{[]string{"status"}, []string{"status"}, false},
+		{[]string{"--batch"}, []string{}, true},
+		{[]string{"--verbose", "--batch"}, []string{"--verbose"}, true},
+		{[]string{"--batch", "status"}, []string{"status"}, true},
+		{[]string{"status", "--batch"}, []string{"status", "--batch"}, false},
+	}
+	for _, tt := range tests {
+		rest, batch := splitBatchFlag(tt.args)
+		if !reflect.DeepEqual(rest, tt.wantRest) || batch != tt.wantBatch {
+			t.Errorf("splitBatchFlag(%v) = %v, %v", tt.args, rest, batch)
+		}
+	}
+}
+
+// TestGlobalBatchFlag_AnyOrder runs the global flags through the splitters
+// in the order RunApp does: --batch and --yes may come in either order.
+func TestGlobalBatchFlag_AnyOrder(t *testing.T) {
+	for _, args := range [][]string{{"--yes", "--batch"}, {"--batch", "--yes"}, {"--batch", "--path", "apps", "--no-color", "--yes"}} {
+		rest, _, err := splitPathFlags(args)
+		if err != nil {
+			t.Fatalf("splitPathFlags(%v): %v", args, err)
+		}
+		rest, yes := splitYesFlag(rest)
+		rest, _, _, err = splitColorFlags(rest)
+		if err != nil {
+			t.Fatalf("splitColorFlags(%v): %v", args, err)
+		}
+		rest, batch := splitBatchFlag(rest)
+		if !yes || !batch || len(rest) != 0 {
+			t.Errorf("%v: yes = %v, batch = %v, rest = %v", args, yes, batch, rest)
+		}
+	}
+}
+
+func TestApplyColorMode(t *testing.T) {
+	t.Cleanup(func() { ui.SetColorMode(ui.ColorAuto) })
+	for _, key := range []string{"GIT_CONFIG_COUNT", "GIT_CONFIG_KEY_0", "GIT_CONFIG_VALUE_0"} {
+		t.Setenv(key, "")
+	}
+
+	cfg := &config.Config{}
+	applyColorMode(cfg, ui.ColorAuto, false)
+	if ui.CurrentColorMode() != ui.ColorNever {
+		t.Errorf("ui.color: false should select never, got %v", ui.CurrentColorMode())
+	}
+
+	applyColorMode(cfg, ui.ColorAlways, true)
+	if ui.CurrentColorMode() != ui.ColorAlways {
+		t.Errorf("a flag should win over the config, got %v", ui.CurrentColorMode())
+	}
+}
+
+func TestDisableGitColor(t *testing.T) {
+	env := map[string]string{"GIT_CONFIG_COUNT": "1", "GIT_CONFIG_KEY_0": "core.pager", "GIT_CONFIG_VALUE_0": "cat"}
+	disableGitColor(func(k string) string { return env[k] }, func(k, v string) error {
+		env[k] = v
+		return nil
+	})
+	want := map[string]string{
+		"GIT_CONFIG_COUNT": "2",
+		"GIT_CONFIG_KEY_0": "core.pager", "GIT_CONFIG_VALUE_0": "cat",
+		"GIT_CONFIG_KEY_1": "color.ui", "GIT_CONFIG_VALUE_1": "never",
+	}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("env = %v", env)
+	}
+}
+
+func TestSplitPathFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		wantRest  []string
+		wantFlags pathFlags
+		wantErr   bool
+	}{
+		{args: []string{"status"}, wantRest: []string{"status"}},
+		{args: []string{"--path", "apps/web", "status"}, wantRest: []string{"status"}, wantFlags: pathFlags{dir: "apps/web"}},
+		{args: []string{"--yes", "--path=apps", "--no-color", "clean", "files"}, wantRest: []string{"--yes", "--no-color", "clean", "files"}, wantFlags: pathFlags{dir: "apps"}},
+		{args: []string{"--pick-path", "log", "simple"}, wantRest: []string{"log", "simple"}, wantFlags: pathFlags{pick: true}},
+		{args: []string{"--batch", "--path", "apps"}, wantRest: []string{"--batch"}, wantFlags: pathFlags{dir: "apps"}},
+		{args: []string{"grep", "--path", "x"}, wantRest: []string{"grep", "--path", "x"}},
+		{args: []string{"--path"}, wantErr: true},
+		{args: []string{"--path=", "status"}, wantErr: true},
+		{args: []string{"--path", "a", "--pick-path", "status"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		rest, flags, err := splitPathFlags(tt.args)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("splitPathFlags(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (!reflect.DeepEqual(rest, tt.wantRest) || flags != tt.wantFlags) {
+			t.Errorf("splitPathFlags(%v) = %v, %+v", tt.args, rest, flags)
+		}
+	}
+}
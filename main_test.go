@@ -21,6 +21,8 @@ import (
 func TestMain(m *testing.M) {
 	prev := history.Default()
 	history.SetDefault(&history.Store{Disabled: true})
+	// RunApp installs the statistics store; keep it off the real cache.
+	_ = os.Setenv("GGC_NO_STATS", "1")
 	code := m.Run()
 	history.SetDefault(prev)
 	os.Exit(code)
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bmf-san/ggc/v8/internal/config"
+	"github.com/bmf-san/ggc/v8/internal/httpclient"
+	"github.com/bmf-san/ggc/v8/internal/update"
+)
+
+func TestStartUpdateCheck(t *testing.T) {
+	originalVersion, originalSpawn := version, spawnUpdateCheck
+	t.Cleanup(func() { version, spawnUpdateCheck = originalVersion, originalSpawn })
+	version = "v8.0.0"
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		env       string
+		args      []string
+		checked   time.Time
+		wantSpawn bool
+	}{
+		{"off by default", false, "", []string{"status"}, time.Time{}, false},
+		{"due", true, "", []string{"status"}, time.Time{}, true},
+		{"checked today", true, "", []string{"status"}, time.Now().Add(-time.Hour), false},
+		{"disabled by env", true, "1", []string{"status"}, time.Time{}, false},
+		{"internal command", true, "", []string{"__complete", "branch"}, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sandboxHome(t)
+			t.Setenv(update.EnvDisable, tt.env)
+			path, err := update.DefaultPath()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.checked.IsZero() {
+				if err := (update.Cache{Checked: tt.checked}).Save(path); err != nil {
+					t.Fatal(err)
+				}
+			}
+			spawned := false
+			spawnUpdateCheck = func() { spawned = true }
+
+			cfg := &config.Config{}
+			cfg.Behavior.UpdateCheck = tt.enabled
+			notice := startUpdateCheck(cfg, tt.args)
+			notice(os.Stderr)
+
+			if spawned != tt.wantSpawn {
+				t.Errorf("spawned = %v, want %v", spawned, tt.wantSpawn)
+			}
+			if tt.wantSpawn {
+				if c, _ := update.Load(path); c.Due(time.Now()) {
+					t.Errorf("the attempt was not recorded: %+v", c)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyIntegrationConfig(t *testing.T) {
+	t.Cleanup(func() { httpclient.SetDefaults(httpclient.Options{}) })
+	cfg := &config.Config{}
+	cfg.Integration.HTTPProxy = "http://proxy.corp.example:3128"
+	cfg.Integration.CABundle = "~/corp-ca.pem"
+	cfg.Integration.InsecureSkipVerify = true
+	applyIntegrationConfig(cfg)
+
+	want := httpclient.Options{Proxy: "http://proxy.corp.example:3128", CABundle: "~/corp-ca.pem", InsecureSkipVerify: true}
+	if got := httpclient.Defaults(); got != want {
+		t.Errorf("httpclient.Defaults() = %+v, want %+v", got, want)
+	}
+}
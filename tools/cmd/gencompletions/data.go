@@ -91,6 +91,14 @@ func escapeBash(s string) string {
 	return strings.ReplaceAll(s, "\"", "\\\"")
 }
 
+func escapePowerShell(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
+
+func escapeNu(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(s)
+}
+
 func buildTemplateData(cmds []command.Info) *TemplateData {
 	data := &TemplateData{
 		Commands:   make([]*CommandData, 0, len(cmds)),
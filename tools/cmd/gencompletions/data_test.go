@@ -126,6 +126,12 @@ func TestEscapeFunctions(t *testing.T) {
 	if got := escapeBash(`say "hi"`); got != `say \"hi\"` {
 		t.Errorf("escapeBash = %q, want %q", got, `say \"hi\"`)
 	}
+	if got := escapePowerShell("it's"); got != "it''s" {
+		t.Errorf("escapePowerShell = %q, want %q", got, "it''s")
+	}
+	if got := escapeNu(`say "hi" \o/`); got != `say \"hi\" \\o/` {
+		t.Errorf("escapeNu = %q, want %q", got, `say \"hi\" \\o/`)
+	}
 }
 
 func TestJoin(t *testing.T) {
@@ -19,25 +19,31 @@ func main() {
 	data := buildTemplateData(registry.VisibleCommands())
 
 	templates := map[string]string{
-		"bash": "templates/bash.tmpl",
-		"zsh":  "templates/zsh.tmpl",
-		"fish": "templates/fish.tmpl",
+		"bash":       "templates/bash.tmpl",
+		"zsh":        "templates/zsh.tmpl",
+		"fish":       "templates/fish.tmpl",
+		"powershell": "templates/powershell.tmpl",
+		"nushell":    "templates/nushell.tmpl",
 	}
 
 	writers := map[string]string{
-		"bash": filepath.Join("cmd", "completions", "ggc.bash"),
-		"zsh":  filepath.Join("cmd", "completions", "ggc.zsh"),
-		"fish": filepath.Join("cmd", "completions", "ggc.fish"),
+		"bash":       filepath.Join("cmd", "completions", "ggc.bash"),
+		"zsh":        filepath.Join("cmd", "completions", "ggc.zsh"),
+		"fish":       filepath.Join("cmd", "completions", "ggc.fish"),
+		"powershell": filepath.Join("cmd", "completions", "ggc.ps1"),
+		"nushell":    filepath.Join("cmd", "completions", "ggc.nu"),
 	}
 
 	funcMap := template.FuncMap{
-		"join":         join,
-		"escapeZsh":    escapeZsh,
-		"escapeFish":   escapeFish,
-		"escapeBash":   escapeBash,
-		"hasKeywords":  hasKeywords,
-		"needsHandler": needsHandler,
-		"subcommandBy": subcommandBy,
+		"join":             join,
+		"escapeZsh":        escapeZsh,
+		"escapeFish":       escapeFish,
+		"escapeBash":       escapeBash,
+		"escapePowerShell": escapePowerShell,
+		"escapeNu":         escapeNu,
+		"hasKeywords":      hasKeywords,
+		"needsHandler":     needsHandler,
+		"subcommandBy":     subcommandBy,
 	}
 
 	for name, tmplPath := range templates {
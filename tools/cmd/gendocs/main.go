@@ -13,22 +13,46 @@ import (
 const commandsReferencePath = "docs/content/guide/commands.md"
 
 func main() {
-	if err := writeCommandsReference(commandsReferencePath); err != nil {
+	commands := sortedCommands()
+	if err := writeCommandsReference(commandsReferencePath, commands); err != nil {
 		fmt.Fprintf(os.Stderr, "Error writing commands reference: %v\n", err)
 		os.Exit(1)
 	}
 	fmt.Printf("%s regenerated from registry\n", commandsReferencePath)
+	if err := writeCommandPages(commandPagesDir, commands); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing command pages: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s regenerated from registry\n", commandPagesDir)
+	if err := writeManPage(manPagePath, commands); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing man page: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s regenerated from registry\n", manPagePath)
 }
 
-func writeCommandsReference(path string) error {
-	registry := command.NewRegistry()
-	commands := registry.VisibleCommands()
+// sortedCommands returns the visible commands by category, then name.
+func sortedCommands() []command.Info {
+	commands := command.NewRegistry().VisibleCommands()
 	sort.Slice(commands, func(i, j int) bool {
 		if commands[i].Category != commands[j].Category {
 			return command.CategoryOrder(commands[i].Category) < command.CategoryOrder(commands[j].Category)
 		}
 		return commands[i].Name < commands[j].Name
 	})
+	return commands
+}
+
+func groupByCategory(commands []command.Info) map[command.Category][]command.Info {
+	byCategory := make(map[command.Category][]command.Info)
+	for i := range commands {
+		c := commands[i]
+		byCategory[c.Category] = append(byCategory[c.Category], c)
+	}
+	return byCategory
+}
+
+func writeCommandsReference(path string, commands []command.Info) error {
 
 	var b strings.Builder
 	b.WriteString("---\n")
@@ -39,14 +63,10 @@ func writeCommandsReference(path string) error {
 	b.WriteString("  - guide\n")
 	b.WriteString("---\n\n")
 	b.WriteString("This reference is auto-generated from the command registry in [`cmd/command/`](https://github.com/bmf-san/ggc/tree/main/cmd/command). Do not edit this file by hand; run `make docs`.\n\n")
-	b.WriteString("For quick lookup, `ggc help` lists every command and `ggc help <command>` shows the same detail in your terminal.\n\n")
+	b.WriteString("For quick lookup, `ggc help` lists every command and `ggc help <command>` (or `ggc <command> --help`) shows the same detail in your terminal.\n\n")
 	b.WriteString("## Table of contents\n\n")
 
-	byCategory := make(map[command.Category][]command.Info)
-	for i := range commands {
-		c := commands[i]
-		byCategory[c.Category] = append(byCategory[c.Category], c)
-	}
+	byCategory := groupByCategory(commands)
 	for _, cat := range command.OrderedCategories() {
 		list := byCategory[cat]
 		if len(list) == 0 {
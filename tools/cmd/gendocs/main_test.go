@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bmf-san/ggc/v8/cmd/command"
+)
+
+func TestRoffEscape(t *testing.T) {
+	tests := map[string]string{
+		"ggc add --all": `ggc add \-\-all`,
+		".ggc.yaml":     `\&.ggc.yaml`,
+		`C:\repo`:       `C:\erepo`,
+	}
+	for in, want := range tests {
+		if got := roffEscape(in); got != want {
+			t.Errorf("roffEscape(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWriteCommandPages(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "removed.md")
+	if err := os.WriteFile(stale, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	commands := []command.Info{{
+		Name:        "tag",
+		Category:    command.CategoryTag,
+		Summary:     "Manage tags",
+		Description: "Tags mark releases.\n\nSigned tags need a key.",
+		Usage:       []string{"ggc tag list"},
+		Subcommands: []command.SubcommandInfo{
+			{Name: "tag list", Summary: "List tags", Git: "git tag", Usage: []string{"ggc tag list"}},
+			{Name: "tag secret", Summary: "Hidden", Hidden: true},
+		},
+	}}
+
+	if err := writeCommandPages(dir, commands); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("stale page was kept: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "tag.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+	for _, want := range []string{"title: \"ggc tag\"\n", "Manage tags.\n\nTags mark releases.\n\nSigned tags need a key.\n\n", "### `ggc tag list`\n\nList tags.\n\n**Runs:** `git tag`\n"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page lacks %q:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "secret") {
+		t.Errorf("page lists a hidden subcommand:\n%s", page)
+	}
+}
+
+func TestWriteManPage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "man", "ggc.1")
+	commands := []command.Info{{Name: "sync", Category: command.CategoryRemote, Summary: "Sync the branch", Usage: []string{"ggc sync --no-push"}}}
+	if err := writeManPage(path, commands); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{".TH GGC 1", ".B ggc sync\nSync the branch.\n", "ggc sync \\-\\-no\\-push\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("man page lacks %q:\n%s", want, data)
+		}
+	}
+}
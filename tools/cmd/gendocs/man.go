@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/cmd/command"
+)
+
+const manPagePath = "man/ggc.1"
+
+// writeManPage renders the registry as a ggc(1) man page. It carries no
+// date so that regenerating it only changes the file when the registry
+// does.
+func writeManPage(path string, commands []command.Info) error {
+	var b strings.Builder
+	b.WriteString(".\\\" Code generated by go run ./tools/cmd/gendocs; DO NOT EDIT.\n")
+	b.WriteString(".TH GGC 1 \"\" \"ggc\" \"User Commands\"\n")
+	b.WriteString(".SH NAME\n")
+	b.WriteString("ggc \\- an interactive Git CLI with short subcommands and fuzzy finders\n")
+	b.WriteString(".SH SYNOPSIS\n")
+	b.WriteString(".B ggc\n")
+	b.WriteString(".br\n")
+	b.WriteString(".B ggc\n")
+	b.WriteString(".I command\n")
+	b.WriteString(".RI [ args ...]\n")
+	b.WriteString(".SH DESCRIPTION\n")
+	b.WriteString("Without arguments, ggc opens the interactive command picker. ")
+	b.WriteString("With a command, it runs that command and exits. ")
+	b.WriteString("Run \\fBggc help\\fR \\fIcommand\\fR for the same detail as below.\n")
+	b.WriteString(".SH COMMANDS\n")
+
+	byCategory := groupByCategory(commands)
+	for _, cat := range command.OrderedCategories() {
+		list := byCategory[cat]
+		if len(list) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, ".SS %s\n", roffEscape(string(cat)))
+		for i := range list {
+			writeManCommand(&b, &list[i])
+		}
+	}
+
+	b.WriteString(".SH FILES\n")
+	b.WriteString(".TP\n")
+	b.WriteString(".I $XDG_CONFIG_HOME/ggc/config.yaml\n")
+	b.WriteString("User configuration; \\fI~/.config/ggc/config.yaml\\fR when XDG_CONFIG_HOME is unset, and the legacy \\fI~/.ggcconfig.yaml\\fR.\n")
+	b.WriteString(".TP\n")
+	b.WriteString(".I .ggc.yaml\n")
+	b.WriteString("Per-repository configuration at the root of the working tree, overlaying the user configuration.\n")
+	b.WriteString(".SH SEE ALSO\n")
+	b.WriteString(".BR git (1)\n")
+	b.WriteString(".PP\n")
+	b.WriteString("https://bmf-san.github.io/ggc/\n")
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+func writeManCommand(b *strings.Builder, c *command.Info) {
+	b.WriteString(".TP\n")
+	fmt.Fprintf(b, ".B ggc %s\n", roffEscape(c.Name))
+	if c.Summary != "" {
+		fmt.Fprintf(b, "%s.\n", roffEscape(strings.TrimSuffix(c.Summary, ".")))
+	}
+	b.WriteString(".RS\n")
+	for _, p := range strings.Split(strings.TrimSpace(c.Description), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			b.WriteString(".PP\n")
+			b.WriteString(roffEscape(p) + "\n")
+		}
+	}
+	if len(c.Aliases) > 0 {
+		b.WriteString(".PP\n")
+		fmt.Fprintf(b, "Aliases: %s\n", roffEscape(strings.Join(c.Aliases, ", ")))
+	}
+	writeManLines(b, c.Usage)
+	for _, s := range visibleSubs(c.Subcommands) {
+		b.WriteString(".TP\n")
+		fmt.Fprintf(b, ".B %s\n", roffEscape(s.Name))
+		b.WriteString(roffEscape(s.Summary) + "\n")
+	}
+	writeManLines(b, c.Examples)
+	b.WriteString(".RE\n")
+}
+
+// writeManLines prints lines as an unfilled block, keeping the spacing of
+// usage lines and example comments.
+func writeManLines(b *strings.Builder, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	b.WriteString(".PP\n.nf\n")
+	for _, l := range lines {
+		b.WriteString(roffEscape(l) + "\n")
+	}
+	b.WriteString(".fi\n")
+}
+
+// roffEscape makes s safe as roff text: backslashes are escaped, hyphens
+// stay ASCII hyphens in copy-pasted options, and a line starting with a
+// control character is not taken as a request.
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\e")
+	s = strings.ReplaceAll(s, "-", "\\-")
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = "\\&" + s
+	}
+	return s
+}
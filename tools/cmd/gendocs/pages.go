@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bmf-san/ggc/v8/cmd/command"
+)
+
+// commandPagesDir holds one generated page per command, served at
+// /ggc/commands/<name>/.
+const commandPagesDir = "docs/content/commands"
+
+// writeCommandPages writes a page for each command into dir, replacing
+// the pages of an earlier run so removed commands lose theirs.
+func writeCommandPages(dir string, commands []command.Info) error {
+	old, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return err
+	}
+	for _, path := range old {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for i := range commands {
+		c := &commands[i]
+		path := filepath.Join(dir, c.Name+".md")
+		if err := os.WriteFile(path, []byte(commandPage(c)), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func commandPage(c *command.Info) string {
+	summary := strings.TrimSuffix(c.Summary, ".")
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: \"ggc %s\"\n", c.Name)
+	fmt.Fprintf(&b, "description: %q\n", summary+".")
+	fmt.Fprintf(&b, "slug: %q\n", c.Name)
+	b.WriteString("categories:\n")
+	b.WriteString("  - commands\n")
+	b.WriteString("---\n\n")
+	b.WriteString("This page is auto-generated from the command registry in [`cmd/command/`](https://github.com/bmf-san/ggc/tree/main/cmd/command). Do not edit it by hand; run `make docs`.\n\n")
+	if summary != "" {
+		fmt.Fprintf(&b, "%s.\n\n", summary)
+	}
+	if c.Description != "" {
+		b.WriteString(strings.TrimSpace(c.Description) + "\n\n")
+	}
+	writeAliases(&b, c.Aliases)
+	if c.Git != "" {
+		fmt.Fprintf(&b, "**Runs:** `%s`\n\n", c.Git)
+	}
+	writeUsageBlock(&b, "Usage", c.Usage)
+	writeSubcommandDetails(&b, visibleSubs(c.Subcommands))
+	writeUsageBlock(&b, "Examples", c.Examples)
+	fmt.Fprintf(&b, "See the [command reference](/ggc/guide/commands/#%s) for every command in the %s category.\n", categoryAnchor(c.Category), c.Category)
+	return b.String()
+}
+
+// writeSubcommandDetails lists each subcommand with the git command it
+// runs and its own usage, which the one-page reference leaves out.
+func writeSubcommandDetails(b *strings.Builder, subs []command.SubcommandInfo) {
+	if len(subs) == 0 {
+		return
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Name < subs[j].Name })
+	b.WriteString("## Subcommands\n\n")
+	for i := range subs {
+		s := &subs[i]
+		fmt.Fprintf(b, "### `ggc %s`\n\n", s.Name)
+		if s.Summary != "" {
+			fmt.Fprintf(b, "%s.\n\n", strings.TrimSuffix(s.Summary, "."))
+		}
+		if s.Git != "" {
+			fmt.Fprintf(b, "**Runs:** `%s`\n\n", s.Git)
+		}
+		writeUsageBlock(b, "Usage", s.Usage)
+		writeUsageBlock(b, "Examples", s.Examples)
+	}
+}